@@ -0,0 +1,150 @@
+// Command benchcheck compares two `go test -bench` outputs and fails when a
+// benchmark got slower or allocates more than the allowed threshold.
+//
+//	benchcheck [-threshold 0.2] baseline.txt current.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultThreshold is the fractional slowdown tolerated before failing
+const DefaultThreshold = 0.2
+
+// Result is the averaged outcome of one benchmark
+type Result struct {
+	NsPerOp     float64
+	AllocsPerOp float64
+	runs        int
+}
+
+// Comparison is one benchmark present in both outputs
+type Comparison struct {
+	Name       string
+	Baseline   Result
+	Current    Result
+	Regression bool
+}
+
+// Delta returns the fractional change in time per operation
+func (c Comparison) Delta() float64 {
+	if c.Baseline.NsPerOp == 0 {
+		return 0
+	}
+	return c.Current.NsPerOp/c.Baseline.NsPerOp - 1
+}
+
+func main() {
+	threshold := flag.Float64("threshold", DefaultThreshold, "allowed fractional slowdown, e.g. 0.2 for 20%")
+	flag.Parse()
+	if flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: benchcheck [-threshold 0.2] baseline.txt current.txt")
+		os.Exit(2)
+	}
+
+	baseline, err := parseFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "benchcheck:", err)
+		os.Exit(2)
+	}
+	current, err := parseFile(flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "benchcheck:", err)
+		os.Exit(2)
+	}
+
+	comparisons := Compare(baseline, current, *threshold)
+	if Report(os.Stdout, comparisons) {
+		os.Exit(1)
+	}
+}
+
+// parseFile parses a benchmark output file
+func parseFile(path string) (map[string]Result, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Parse reads benchmark lines, averaging repeated runs (-count) of the
+// same benchmark. The -GOMAXPROCS suffix is dropped so outputs from
+// different machines line up.
+func Parse(r io.Reader) (map[string]Result, error) {
+	results := make(map[string]Result)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		name := fields[0]
+		if dash := strings.LastIndex(name, "-"); dash > 0 {
+			if _, err := strconv.Atoi(name[dash+1:]); err == nil {
+				name = name[:dash]
+			}
+		}
+
+		result := results[name]
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+			switch fields[i+1] {
+			case "ns/op":
+				result.NsPerOp = (result.NsPerOp*float64(result.runs) + value) / float64(result.runs+1)
+			case "allocs/op":
+				result.AllocsPerOp = (result.AllocsPerOp*float64(result.runs) + value) / float64(result.runs+1)
+			}
+		}
+		result.runs++
+		results[name] = result
+	}
+	return results, scanner.Err()
+}
+
+// Compare matches benchmarks by name and flags those whose time or
+// allocations per operation grew by more than threshold
+func Compare(baseline, current map[string]Result, threshold float64) []Comparison {
+	var comparisons []Comparison
+	for name, now := range current {
+		before, ok := baseline[name]
+		if !ok {
+			continue
+		}
+		c := Comparison{Name: name, Baseline: before, Current: now}
+		c.Regression = c.Delta() > threshold ||
+			(before.AllocsPerOp > 0 && now.AllocsPerOp > before.AllocsPerOp*(1+threshold))
+		comparisons = append(comparisons, c)
+	}
+	sort.Slice(comparisons, func(i, j int) bool { return comparisons[i].Name < comparisons[j].Name })
+	return comparisons
+}
+
+// Report prints a comparison table and returns true if anything regressed
+func Report(w io.Writer, comparisons []Comparison) bool {
+	regressed := false
+	for _, c := range comparisons {
+		mark := ""
+		if c.Regression {
+			mark = "  REGRESSION"
+			regressed = true
+		}
+		fmt.Fprintf(w, "%-50s %14.0f ns/op -> %14.0f ns/op  %+6.1f%%%s\n",
+			c.Name, c.Baseline.NsPerOp, c.Current.NsPerOp, c.Delta()*100, mark)
+	}
+	if len(comparisons) == 0 {
+		fmt.Fprintln(w, "no benchmarks in common")
+	}
+	return regressed
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const baselineOutput = `goos: linux
+BenchmarkGameLoopStep/pets=10-8     	   40000	     30000 ns/op	    1200 B/op	      10 allocs/op
+BenchmarkGameLoopStep/pets=10-8     	   40000	     32000 ns/op	    1200 B/op	      10 allocs/op
+BenchmarkBreed-8                    	  100000	      9000 ns/op
+BenchmarkRemoved-8                  	  100000	      1000 ns/op
+PASS
+`
+
+const currentOutput = `BenchmarkGameLoopStep/pets=10-4     	   40000	     33000 ns/op	    1200 B/op	      10 allocs/op
+BenchmarkBreed-4                    	  100000	     12000 ns/op
+BenchmarkAdded-4                    	  100000	      1000 ns/op
+`
+
+func TestParseAveragesRuns(t *testing.T) {
+	results, err := Parse(strings.NewReader(baselineOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	step := results["BenchmarkGameLoopStep/pets=10"]
+	if step.NsPerOp != 31000 || step.AllocsPerOp != 10 {
+		t.Errorf("Expected averaged runs, got %+v", step)
+	}
+	if len(results) != 3 {
+		t.Errorf("Expected 3 benchmarks, got %d", len(results))
+	}
+}
+
+func TestCompareFlagsRegressions(t *testing.T) {
+	baseline, _ := Parse(strings.NewReader(baselineOutput))
+	current, _ := Parse(strings.NewReader(currentOutput))
+
+	comparisons := Compare(baseline, current, 0.2)
+	if len(comparisons) != 2 {
+		t.Fatalf("Expected only shared benchmarks compared, got %v", comparisons)
+	}
+	if !comparisons[0].Regression || comparisons[0].Name != "BenchmarkBreed" {
+		t.Errorf("Expected a 33%% slowdown to regress, got %+v", comparisons[0])
+	}
+	if comparisons[1].Regression {
+		t.Errorf("Expected a 6%% slowdown within threshold, got %+v", comparisons[1])
+	}
+
+	var out bytes.Buffer
+	if !Report(&out, comparisons) || !strings.Contains(out.String(), "REGRESSION") {
+		t.Errorf("Expected the report to show the regression, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,2097 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata" // Time zones for clock alignment on systems without a zone database
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/calendar"
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/discord"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/keyring"
+	"github.com/Michael-W-Ellison/gochi/internal/mqtt"
+	"github.com/Michael-W-Ellison/gochi/internal/report"
+	"github.com/Michael-W-Ellison/gochi/internal/script"
+	"github.com/Michael-W-Ellison/gochi/internal/server"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/internal/ui"
+)
+
+// Build information, set with -ldflags by the Makefile
+var (
+	Version   = "dev"
+	BuildTime = "unknown"
+	GitCommit = "unknown"
+)
+
+// Startup settings
+const (
+	defaultConfigPath = "configs/config.yaml" // Used when neither -config nor GOCHI_CONFIG is given
+	profileEnv        = "GOCHI_PROFILE"       // Profile used when -profile is not given
+	passphraseEnv     = "GOCHI_PASSPHRASE"    // Save passphrase for unattended runs without a keychain
+	joinTokenEnv      = "GOCHI_TOKEN"         // API key used by join when -token is not given
+	starterPetName    = "Gochi"               // Name of the pet created on first run
+)
+
+// discordAPI is where slash commands are registered; tests point it elsewhere
+var discordAPI = discord.APIBase
+
+// openKeyring opens the keychain secrets are kept in; tests replace it
+var openKeyring = keyring.System
+
+// Where a save passphrase missing from the keychain is asked for
+var (
+	promptIn  io.Reader = os.Stdin
+	promptOut io.Writer = os.Stderr
+)
+
+// keyNames maps the names "gochi keys" accepts to keychain entries
+var keyNames = map[string]string{
+	"save":  keyring.SaveKey,
+	"cloud": keyring.CloudToken,
+}
+
+func main() {
+	// Cancelling the context on a signal lets the game loop finish its
+	// current tick and save before the process exits
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout)
+	stop()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gochi:", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches the command line and returns any fatal error
+func run(ctx context.Context, args []string, in io.Reader, out io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "config":
+			return configCommand(args[1:], out)
+		case "fsck":
+			return fsckCommand(ctx, args[1:], out)
+		case "compact":
+			return compactCommand(ctx, args[1:], out)
+		case "backup":
+			return backupCommand(ctx, args[1:], in, out)
+		case "diff":
+			return diffCommand(args[1:], out)
+		case "profile":
+			return profileCommand(args[1:], out)
+		case "lan":
+			return lanCommand(ctx, args[1:], out)
+		case "import":
+			return importCommand(ctx, args[1:], out)
+		case "export":
+			return exportCommand(ctx, args[1:], out)
+		case "legacy":
+			return legacyCommand(ctx, args[1:], in, out)
+		case "script":
+			return scriptCommand(args[1:], out)
+		case "serve":
+			return serveCommand(ctx, args[1:], out)
+		case "join":
+			return joinCommand(ctx, args[1:], in, out)
+		case "discord":
+			return discordCommand(ctx, args[1:], out)
+		case "keys":
+			return keysCommand(args[1:], in, out)
+		case "version":
+			fmt.Fprintf(out, "gochi %s (commit %s, built %s)\n", Version, GitCommit, BuildTime)
+			return nil
+		}
+	}
+
+	flags := flag.NewFlagSet("gochi", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file (default $GOCHI_CONFIG or "+defaultConfigPath+")")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to play (default $"+profileEnv+" or the selected profile)")
+	spectate := flags.Bool("spectate", false, "show a read-only dashboard without accepting commands, e.g. for streaming")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	if *spectate {
+		cfg.UI.Spectator = true
+	}
+	root := cfg.Data.SavePath
+	profile, err := openProfile(cfg, *name, out)
+	if err != nil {
+		return err
+	}
+	noticeLegacy(root, profile, out)
+	return play(ctx, cfg, profile, in, out)
+}
+
+// configCommand handles "gochi config validate [path]"
+func configCommand(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "validate" || len(args) > 2 {
+		return errors.New("usage: gochi config validate [path]")
+	}
+	path := ""
+	if len(args) == 2 {
+		path = args[1]
+	}
+
+	cfg, err := loadConfig(path)
+	if err == nil && cfg.App.TuningFile != "" {
+		_, err = config.LoadTuning(cfg.App.TuningFile)
+	}
+	if err != nil {
+		fmt.Fprintln(out, "configuration is invalid:")
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintln(out, "  -", line)
+		}
+		return config.ErrInvalidConfig
+	}
+	fmt.Fprintln(out, "configuration OK")
+	return nil
+}
+
+// fsckCommand handles "gochi fsck [-config path] [-profile name]
+// [-repair prune|memorial]", which checks a profile's saves for references
+// to pets that no longer exist
+func fsckCommand(ctx context.Context, args []string, out io.Writer) (err error) {
+	flags := flag.NewFlagSet("fsck", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to check")
+	repair := flags.String("repair", "", "fix dangling references: prune or memorial")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	if _, err := openProfile(cfg, *name, out); err != nil {
+		return err
+	}
+	dm, closeData, err := openData(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+
+	if *repair != "" {
+		strategy, err := data.ParseRepairStrategy(*repair)
+		if err != nil {
+			return err
+		}
+		repaired, err := dm.RepairIntegrity(ctx, strategy)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "repaired %d dangling references (%s)\n", repaired, strategy)
+	}
+
+	report, err := dm.CheckIntegrity(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "checked %d saves\n", report.Checked)
+	if report.Unreadable != nil {
+		for _, problem := range splitErrors(report.Unreadable) {
+			fmt.Fprintln(out, "  -", ui.ErrorMessage(problem))
+		}
+	}
+	for _, ref := range report.Dangling {
+		fmt.Fprintln(out, "  -", ref)
+	}
+	if len(report.Dangling) > 0 {
+		fmt.Fprintln(out, "run \"gochi fsck -repair prune\" or \"gochi fsck -repair memorial\" to fix them")
+		return fmt.Errorf("%w: %d references", data.ErrDanglingReferences, len(report.Dangling))
+	}
+	if report.Unreadable != nil {
+		return data.ErrCorruptSave
+	}
+	fmt.Fprintln(out, "saves OK")
+	return nil
+}
+
+// compactCommand handles "gochi compact [-config path] [-profile name]",
+// which compresses a profile's saves, summarizes history beyond the
+// retention limits and deduplicates its backups
+func compactCommand(ctx context.Context, args []string, out io.Writer) (err error) {
+	flags := flag.NewFlagSet("compact", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to compact")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	if _, err := openProfile(cfg, *name, out); err != nil {
+		return err
+	}
+	dm, closeData, err := openData(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	if _, err := dm.Recover(); err != nil {
+		return err
+	}
+
+	report, err := dm.Compact(ctx, core.DefaultRetentionPolicy())
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != nil {
+		fmt.Fprintln(out, "Some saves could not be compacted and were left untouched:")
+		for _, problem := range splitErrors(err) {
+			fmt.Fprintln(out, "  -", ui.ErrorMessage(problem))
+		}
+	}
+	history := report.History
+	fmt.Fprintf(out, "rewrote %d saves (%d memories and %d shared experiences summarized, %d trait snapshots dropped)\n",
+		report.Saves, history.MemoriesSummarized, history.ExperiencesSummarized, history.SnapshotsDropped)
+	fmt.Fprintf(out, "saves: %s -> %s\n", formatBytes(report.SaveBytesBefore), formatBytes(report.SaveBytesAfter))
+	fmt.Fprintf(out, "backups: %d files deduplicated, %d unused chunks removed\n", report.BackupFiles, report.ChunksRemoved)
+	fmt.Fprintf(out, "reclaimed %s\n", formatBytes(report.Reclaimed()))
+	return err
+}
+
+// backupCommand handles "gochi backup [-config path] [-profile name]
+// [-dry-run] [-yes] create | list | restore [name]". Backups are uploaded
+// to cloud storage when cloud backups are enabled; list and restore need
+// them enabled.
+func backupCommand(ctx context.Context, args []string, in io.Reader, out io.Writer) (err error) {
+	const usage = "usage: gochi backup [-config path] [-profile name] [-dry-run] [-yes] create | list | restore [name]"
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to back up")
+	dryRun := flags.Bool("dry-run", false, "show what a restore would change without changing it")
+	yes := flags.Bool("yes", false, "restore over existing saves without asking")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 || (flags.NArg() == 2 && flags.Arg(0) != "restore") {
+		return errors.New(usage)
+	}
+
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	if _, err := openProfile(cfg, *name, out); err != nil {
+		return err
+	}
+	dm, closeData, err := openData(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	offsite := offsiteBackups(cfg)
+	if offsite == nil && flags.Arg(0) != "create" {
+		return errors.New("cloud backups are disabled; set cloud.backups and cloud.endpoint in the configuration")
+	}
+
+	switch flags.Arg(0) {
+	case "create":
+		dir, err := dm.Backup(ctx, filepath.Join(cfg.Data.SavePath, data.BackupDirName))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "backed up to", dir)
+		if offsite == nil {
+			return nil
+		}
+		uploaded, err := offsite.Upload(ctx, dir)
+		if uploaded != "" {
+			fmt.Fprintln(out, "uploaded", uploaded)
+		}
+		return err
+	case "list":
+		names, err := offsite.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			fmt.Fprintln(out, name)
+		}
+		if len(names) == 0 {
+			fmt.Fprintln(out, "no backups in cloud storage")
+		}
+		return nil
+	case "restore":
+		if _, err := dm.Recover(); err != nil {
+			return err
+		}
+		preview, err := offsite.Preview(ctx, dm, flags.Arg(1))
+		if err != nil {
+			return err
+		}
+		printRestorePreview(out, preview)
+		if *dryRun {
+			return nil
+		}
+		if preview.Destructive() {
+			if !*yes && !confirmRestore(in, out) {
+				return errRestoreCancelled
+			}
+			dir, err := dm.Backup(ctx, filepath.Join(cfg.Data.SavePath, data.BackupDirName))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, "current saves backed up to", dir)
+		}
+		if err := offsite.Replace(ctx, dm, preview); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "restored", preview.Name)
+		return nil
+	}
+	return errors.New(usage)
+}
+
+// errRestoreCancelled is returned when a restore over existing saves is
+// not confirmed
+var errRestoreCancelled = errors.New("restore cancelled; nothing was changed")
+
+// printRestorePreview lists what restoring a backup would change, with the
+// fields each changed pet would lose
+func printRestorePreview(out io.Writer, preview data.RestorePreview) {
+	fmt.Fprintf(out, "restoring %s:\n", preview.Name)
+	lines := preview.Consequences()
+	for _, line := range lines {
+		fmt.Fprintln(out, " ", line)
+	}
+	for _, diff := range preview.Changed {
+		fmt.Fprintf(out, "%s now -> in the backup:\n", diff.Before.Name)
+		for _, change := range diff.Changes {
+			fmt.Fprintln(out, "   ", change)
+		}
+	}
+	if preview.Unchanged > 0 {
+		fmt.Fprintf(out, "  %d pets are the same in both\n", preview.Unchanged)
+	}
+	if len(lines) == 0 {
+		fmt.Fprintln(out, "  nothing would change")
+	}
+}
+
+// confirmRestore asks before a restore deletes or overwrites saves,
+// returning true only if "restore" is typed
+func confirmRestore(in io.Reader, out io.Writer) bool {
+	fmt.Fprint(out, "The saves above will be replaced (a backup of them is made first). Type \"restore\" to go ahead: ")
+	if !confirmed(in, "restore") {
+		fmt.Fprintln(out)
+		return false
+	}
+	return true
+}
+
+// confirmed reads a line and returns true only if it is word
+func confirmed(in io.Reader, word string) bool {
+	if in == nil {
+		return false
+	}
+	scanner := bufio.NewScanner(in)
+	return scanner.Scan() && strings.TrimSpace(scanner.Text()) == word
+}
+
+// diffCommand handles "gochi diff [-json] <before> <after>", which
+// compares two saves of a pet, for example the local and cloud copies
+func diffCommand(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the changes as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("usage: gochi diff [-json] <before> <after>")
+	}
+	before, err := data.ReadPetFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	after, err := data.ReadPetFile(flags.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diff := core.DiffPets(before, after)
+	if *asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+	fmt.Fprintf(out, "--- %s (%s)\n+++ %s (%s)\n", flags.Arg(0), before.Name, flags.Arg(1), after.Name)
+	if diff.Empty() {
+		fmt.Fprintln(out, "no differences")
+		return nil
+	}
+	section := ""
+	for _, change := range diff.Changes {
+		if change.Section != section {
+			section = change.Section
+			fmt.Fprintf(out, "%s:\n", section)
+		}
+		fmt.Fprintf(out, "  %-20s %s -> %s", change.Field, change.Before, change.After)
+		if change.Delta != 0 {
+			fmt.Fprintf(out, " (%+.4g)", change.Delta)
+		}
+		fmt.Fprintln(out)
+	}
+	return nil
+}
+
+// offsiteBackups returns the configured cloud backups, or nil if they are
+// disabled. Backups are kept under the account's collection.
+func offsiteBackups(cfg *config.Config) *data.CloudBackups {
+	if !cfg.Cloud.Backups {
+		return nil
+	}
+	collection := strings.TrimSuffix(cfg.Cloud.Endpoint, "/") + "/" + url.PathEscape(cfg.Cloud.Account)
+	offsite := data.NewCloudBackups(data.NewHTTPProvider(collection, cloudToken(cfg)), cfg.Cloud.KeepBackups)
+	offsite.Timeout = time.Duration(cfg.Cloud.Timeout) * time.Second
+	offsite.Backend = storage(cfg)
+	return offsite
+}
+
+// marketplace returns the cloud marketplace pets are adopted out through,
+// or nil if cloud sync is disabled. Every account shares one collection.
+func marketplace(cfg *config.Config) *data.Marketplace {
+	if !cfg.Cloud.Enabled {
+		return nil
+	}
+	collection := strings.TrimSuffix(cfg.Cloud.Endpoint, "/") + "/" + data.MarketCollection
+	market := data.NewMarketplace(data.NewHTTPProvider(collection, cloudToken(cfg)), cfg.Cloud.Account)
+	market.Timeout = time.Duration(cfg.Cloud.Timeout) * time.Second
+	return market
+}
+
+// restoreOffsite restores the latest cloud backup if the profile has no
+// saves yet. Play goes on without it if cloud storage cannot be reached.
+func restoreOffsite(ctx context.Context, offsite *data.CloudBackups, dm *data.DataManager, out io.Writer) {
+	name, err := offsite.Restore(ctx, dm, "")
+	switch {
+	case err == nil:
+		fmt.Fprintf(out, "Restored %s from cloud storage.\n", name)
+	case errors.Is(err, data.ErrSavesExist), errors.Is(err, data.ErrObjectNotFound):
+		// Nothing to restore, or nothing to restore onto
+	default:
+		fmt.Fprintln(out, "Could not restore from cloud storage:", ui.ErrorMessage(err))
+	}
+}
+
+// formatBytes renders a byte count with a binary unit
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit && n > -unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, prefix := float64(n)/unit, 0
+	for ; (value >= unit || value <= -unit) && prefix < 3; prefix++ {
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGT"[prefix])
+}
+
+// profileCommand handles "gochi profile [-config path] list | create <name>
+// | switch <name>"
+func profileCommand(args []string, out io.Writer) (err error) {
+	const usage = "usage: gochi profile [-config path] list | create <name> | switch <name>"
+	flags := flag.NewFlagSet("profile", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	profiles, closeProfiles := profileManager(cfg)
+	defer func() { err = errors.Join(err, closeProfiles()) }()
+	if err := migrateProfiles(profiles, out); err != nil {
+		return err
+	}
+
+	rest := flags.Args()
+	switch {
+	case len(rest) == 1 && rest[0] == "list":
+		names, err := profiles.List()
+		if err != nil {
+			return err
+		}
+		current, err := profiles.Current()
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			fmt.Fprintln(out, "no profiles yet; the", data.DefaultProfile, "profile is created on first play")
+		}
+		for _, name := range names {
+			marker := " "
+			if name == current {
+				marker = "*"
+			}
+			fmt.Fprintln(out, marker, name)
+		}
+		return nil
+	case len(rest) == 2 && rest[0] == "create":
+		if _, err := profiles.Create(rest[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "created profile %s; play it with \"gochi -profile %s\"\n", rest[1], rest[1])
+		return nil
+	case len(rest) == 2 && rest[0] == "switch":
+		if err := profiles.Switch(rest[1]); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "switched to profile", rest[1])
+		return nil
+	}
+	return errors.New(usage)
+}
+
+// keysCommand handles "gochi keys [-config path] status | set <save|cloud>
+// | forget <save|cloud>", which manages the secrets kept in the OS keychain.
+// Secrets to set are read from the input, one line each.
+func keysCommand(args []string, in io.Reader, out io.Writer) error {
+	const usage = "usage: gochi keys [-config path] status | set <save|cloud> | forget <save|cloud>"
+	flags := flag.NewFlagSet("keys", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	ring, err := openKeyring(cfg.Data.KeyringService)
+	if err != nil {
+		return err
+	}
+
+	rest := flags.Args()
+	switch {
+	case len(rest) == 1 && rest[0] == "status":
+		for _, name := range []string{"save", "cloud"} {
+			state := "stored"
+			if _, err := ring.Get(keyNames[name]); errors.Is(err, keyring.ErrNotFound) {
+				state = "not stored"
+			} else if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "%-5s %s in the %s keychain entry\n", name, state, cfg.Data.KeyringService)
+		}
+		checkKeys(cfg, ring, out)
+		return nil
+	case len(rest) == 2 && rest[0] == "set" && keyNames[rest[1]] != "":
+		fmt.Fprintf(out, "%s secret: ", rest[1])
+		secret, err := readSecret(in)
+		if err != nil {
+			return err
+		}
+		if secret == "" {
+			return errors.New("secret must not be empty")
+		}
+		if err := ring.Set(keyNames[rest[1]], secret); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "stored the", rest[1], "secret in the keychain")
+		return nil
+	case len(rest) == 2 && rest[0] == "forget" && keyNames[rest[1]] != "":
+		if err := ring.Delete(keyNames[rest[1]]); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "removed the", rest[1], "secret from the keychain")
+		return nil
+	}
+	return errors.New(usage)
+}
+
+// importCommand handles "gochi import [-config path] [-profile name]
+// [-format name] [-collision refuse|replace|keep-both] <file>", which brings
+// pets over from another pet game, or a pet archived on another machine
+func importCommand(ctx context.Context, args []string, out io.Writer) (err error) {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to import into")
+	format := flags.String("format", "", "save format: "+strings.Join(data.ImportFormats(), ", ")+" (default: detect)")
+	onCollision := flags.String("collision", data.CollisionRefuse.String(), "when a "+data.PetArchiveExt+" pet is already saved: refuse, replace or keep-both")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: gochi import [-config path] [-profile name] [-format name] [-collision refuse|replace|keep-both] <file>")
+	}
+	collision, err := data.ParseImportCollision(*onCollision)
+	if err != nil {
+		return err
+	}
+
+	payload, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	profile, err := openProfile(cfg, *name, out)
+	if err != nil {
+		return err
+	}
+	if *format == "" && data.IsPetArchive(payload) {
+		return importArchive(ctx, cfg, profile, payload, collision, out)
+	}
+	pets, used, err := data.ImportPets(payload, *format, profile.Owner())
+	if err != nil {
+		return err
+	}
+	dm, closeData, err := openData(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	if err := dm.SaveAll(ctx, pets); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Imported %d pets from a %s save into profile %s:\n", len(pets), used, profile.Name)
+	for _, pet := range pets {
+		fmt.Fprintf(out, "  %s, %.1f days old\n", pet.Name, pet.GetAge())
+	}
+	return nil
+}
+
+// importArchive imports a pet from a pet archive
+func importArchive(ctx context.Context, cfg *config.Config, profile *data.Profile, payload []byte, collision data.ImportCollision, out io.Writer) (err error) {
+	dm, closeData, err := openData(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	pet, err := dm.ImportPet(ctx, payload, profile.Owner(), collision)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Imported %s, %.1f days old, into profile %s as %s.\n", pet.Name, pet.GetAge(), profile.Name, pet.ID)
+	return nil
+}
+
+// exportCommand handles "gochi export [-config path] [-profile name]
+// [-compress] <pet> [file]", which packs a pet, active or archived, into a
+// portable archive for "gochi import" on another machine
+func exportCommand(ctx context.Context, args []string, out io.Writer) (err error) {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to export from")
+	compress := flags.Bool("compress", false, "compress the pet's save")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		return errors.New("usage: gochi export [-config path] [-profile name] [-compress] <pet> [file" + data.PetArchiveExt + "]")
+	}
+
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	if _, err := openProfile(cfg, *name, out); err != nil {
+		return err
+	}
+	dm, closeData, err := openData(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	pet, err := findSavedPet(ctx, dm, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	raw, err := data.ExportPet(pet, *compress)
+	if err != nil {
+		return err
+	}
+	file := flags.Arg(1)
+	if file == "" {
+		file = url.PathEscape(pet.Name) + data.PetArchiveExt
+	}
+	if err := os.WriteFile(file, raw, 0o600); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Exported %s to %s (%s).\n", pet.Name, file, formatBytes(int64(len(raw))))
+	return nil
+}
+
+// findSavedPet loads a pet, active or archived, by ID or name
+func findSavedPet(ctx context.Context, dm *data.DataManager, nameOrID string) (*core.DigitalPet, error) {
+	active, err := dm.LoadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := dm.LoadArchived(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pet := range append(active, archived...) {
+		if string(pet.ID) == nameOrID || strings.EqualFold(pet.Name, nameOrID) {
+			return pet, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", data.ErrPetNotFound, nameOrID)
+}
+
+// legacyCommand handles "gochi legacy [-config path] [-profile name] [-yes]
+// import [dir] | cleanup", which copies saves from the flat data/pets
+// layout of earlier versions into a profile and, once the player has
+// checked them, deletes the originals
+func legacyCommand(ctx context.Context, args []string, in io.Reader, out io.Writer) (err error) {
+	const usage = "usage: gochi legacy [-config path] [-profile name] [-yes] import [dir] | cleanup"
+	flags := flag.NewFlagSet("legacy", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to import into")
+	yes := flags.Bool("yes", false, "delete the originals without asking")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	command := flags.Arg(0)
+	if (command != "import" || flags.NArg() > 2) && (command != "cleanup" || flags.NArg() != 1) {
+		return errors.New(usage)
+	}
+
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	source := data.LegacyPetsPath(cfg.Data.SavePath)
+	if flags.NArg() == 2 {
+		source = flags.Arg(1)
+	}
+	profile, err := openProfile(cfg, *name, out)
+	if err != nil {
+		return err
+	}
+
+	if command == "cleanup" {
+		report, ok, err := data.LegacyImported(profile)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return data.ErrNoLegacyImport
+		}
+		if report.Cleaned {
+			fmt.Fprintf(out, "%s was already deleted.\n", report.Source)
+			return nil
+		}
+		printLegacyReport(out, profile, report)
+		if !*yes {
+			fmt.Fprintf(out, "Delete %s and everything in it? Type \"delete\" to go ahead: ", report.Source)
+			if !confirmed(in, "delete") {
+				fmt.Fprintln(out)
+				return errors.New("cleanup cancelled; the old saves were kept")
+			}
+		}
+		if _, err := data.CleanupLegacy(profile); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Deleted %s.\n", report.Source)
+		return nil
+	}
+
+	if !data.HasLegacy(storage(cfg), source) {
+		return fmt.Errorf("no saves from an earlier version in %s", source)
+	}
+	dm, closeData, err := openData(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	report, err := data.ImportLegacy(ctx, dm, profile, source)
+	printLegacyReport(out, profile, report)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "The originals in %s are untouched. Once you have checked your pets, run \"gochi legacy cleanup\" to delete them.\n", source)
+	return nil
+}
+
+// printLegacyReport describes an import from the legacy layout
+func printLegacyReport(out io.Writer, profile *data.Profile, report data.LegacyReport) {
+	fmt.Fprintf(out, "Imported from %s into profile %s on %s:\n", report.Source, profile.Name, report.At.Format("2006-01-02 15:04"))
+	fmt.Fprintf(out, "  %d pets\n", len(report.Pets))
+	for _, id := range report.Pets {
+		fmt.Fprintln(out, "   ", id)
+	}
+	fmt.Fprintf(out, "  %d backups\n", len(report.Backups))
+	if report.Settings {
+		fmt.Fprintf(out, "  settings, now the profile's %s\n", data.ProfileConfigName)
+	}
+	for _, skipped := range report.Skipped {
+		fmt.Fprintln(out, "  skipped", skipped)
+	}
+	for _, problem := range report.Problems {
+		fmt.Fprintln(out, "  failed", problem)
+	}
+}
+
+// noticeLegacy points out saves from an earlier version next to the save
+// root that this profile has not imported
+func noticeLegacy(root string, profile *data.Profile, out io.Writer) {
+	source := data.LegacyPetsPath(root)
+	if !data.HasLegacy(profile.Backend, source) {
+		return
+	}
+	if _, imported, _ := data.LegacyImported(profile); imported {
+		return
+	}
+	fmt.Fprintf(out, "Found saves from an earlier version in %s; run \"gochi legacy import\" to bring them into this profile.\n", source)
+}
+
+// serveCommand handles "gochi serve [-config path] [-profile name]", which
+// runs a profile headless with the admin API
+func serveCommand(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to serve")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	profile, err := openProfile(cfg, *name, out)
+	if err != nil {
+		return err
+	}
+	return serve(ctx, cfg, profile, out)
+}
+
+// joinCommand handles "gochi join [-token key] host:port", which joins the
+// shared session of a device running "gochi serve" as a thin client
+func joinCommand(ctx context.Context, args []string, in io.Reader, out io.Writer) error {
+	const usage = "usage: gochi join [-token key] host:port"
+	flags := flag.NewFlagSet("join", flag.ContinueOnError)
+	token := flags.String("token", os.Getenv(joinTokenEnv), "API key of the member joining (default $"+joinTokenEnv+")")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 || *token == "" {
+		return errors.New(usage)
+	}
+	return join(ctx, server.NewClient(flags.Arg(0), *token), bufio.NewScanner(in), out)
+}
+
+// join shows the host's pets and the care everyone gives as it happens,
+// and sends the member's care until end of input or "quit"
+func join(ctx context.Context, client *server.Client, scanner *bufio.Scanner, out io.Writer) error {
+	out = &syncWriter{w: out}
+	state, err := client.Session(ctx)
+	if err != nil {
+		return err
+	}
+	// seen is the newest care shown, sent with care so the host can refuse
+	// care over someone else's that this member has not seen yet
+	var mu sync.Mutex
+	var seen int64
+	notice := func(seq int64) int64 {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = max(seen, seq)
+		return seen
+	}
+	show := func(state *server.SessionState) {
+		notice(state.Seq)
+		printSession(out, state)
+	}
+	show(state)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go client.Events(streamCtx, func(e server.StreamedEvent) {
+		name, _ := e.Data["name"].(string)
+		switch e.Type {
+		case simulation.EventPetCare:
+			seq, _ := e.Data["seq"].(float64)
+			notice(int64(seq))
+			fmt.Fprintf(out, "* %v chose %v for %s\n", e.Data["member"], e.Data["action"], name)
+		case simulation.EventPetDied, simulation.EventPetCritical:
+			fmt.Fprintf(out, "! %s: %s\n", name, e.Type)
+		}
+	})
+
+	fmt.Fprintln(out, "Joined the shared session. Type \"<care> <pet>\" (feed, pet, play, groom, train, comfort or reward),")
+	fmt.Fprintln(out, "\"status\" to see everyone's pets, or \"quit\" to leave.")
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 0:
+			continue
+		case fields[0] == "quit" || fields[0] == "exit":
+			return nil
+		case fields[0] == "status":
+			if state, err := client.Session(ctx); err != nil {
+				fmt.Fprintln(out, err)
+			} else {
+				show(state)
+			}
+		case len(fields) < 2:
+			fmt.Fprintln(out, "Type \"<care> <pet>\", e.g. \"feed Mochi\".")
+		default:
+			given, err := client.Act(ctx, strings.Join(fields[1:], " "), fields[0], notice(0))
+			if err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			notice(given.Seq)
+			if len(given.Jealous) > 0 {
+				fmt.Fprintf(out, "%s saw and felt left out.\n", strings.Join(given.Jealous, " and "))
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// printSession shows the shared session's pets and who is connected
+func printSession(out io.Writer, state *server.SessionState) {
+	names := make([]string, 0, len(state.Members))
+	for _, member := range state.Members {
+		names = append(names, member.Name)
+	}
+	fmt.Fprintf(out, "Connected: %s\n", strings.Join(names, ", "))
+	for _, pet := range state.Pets {
+		if !pet.Alive {
+			fmt.Fprintf(out, "  %s has passed away\n", pet.Name)
+			continue
+		}
+		fmt.Fprintf(out, "  %-12s %-10s wellbeing %3.0f%%  nutrition %3.0f%%  happiness %3.0f%%  energy %3.0f%%  (%s)\n",
+			pet.Name, pet.Mood, pet.Wellbeing*100, pet.Nutrition*100, pet.Happiness*100, pet.Energy*100, pet.Location)
+	}
+}
+
+// discordCommand handles "gochi discord register [-config path]", which
+// registers the bot's slash commands with Discord
+func discordCommand(ctx context.Context, args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "register" {
+		return errors.New("usage: gochi discord register [-config path]")
+	}
+	flags := flag.NewFlagSet("discord register", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	if cfg.Discord.ApplicationID == "" || cfg.Discord.BotToken == "" {
+		return fmt.Errorf("%w: discord.application_id and discord.bot_token must be set to register commands", config.ErrInvalidConfig)
+	}
+	if err := discord.RegisterCommands(ctx, http.DefaultClient, discordAPI, cfg.Discord.ApplicationID, cfg.Discord.BotToken); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(discord.Commands()))
+	for _, cmd := range discord.Commands() {
+		names = append(names, "/"+cmd.Name)
+	}
+	fmt.Fprintf(out, "Registered %s\n", strings.Join(names, ", "))
+	return nil
+}
+
+// scriptCommand handles "gochi script check [-config path] [file]", which
+// compiles a reaction script without running it. The file defaults to the
+// configured one.
+func scriptCommand(args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "check" {
+		return errors.New("usage: gochi script check [-config path] [file]")
+	}
+	flags := flag.NewFlagSet("script check", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	file := flags.Arg(0)
+	if file == "" {
+		cfg, err := loadConfig(*path)
+		if err != nil {
+			return err
+		}
+		file = cfg.Scripting.File
+	}
+	rules, err := script.LoadFile(file)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s: %d rules OK\n", file, len(rules))
+	return nil
+}
+
+// lanCommand handles "gochi lan [flags] serve | sync", which shares a
+// profile's saves with another device on the local network. The serving
+// device shows a pairing code that the syncing device must be given.
+func lanCommand(ctx context.Context, args []string, out io.Writer) (err error) {
+	const usage = "usage: gochi lan [-config path] [-profile name] serve | sync -code <code> [-peer host:port] [-prefer local|remote]"
+	flags := flag.NewFlagSet("lan", flag.ContinueOnError)
+	path := flags.String("config", "", "configuration file")
+	name := flags.String("profile", os.Getenv(profileEnv), "profile to share")
+	listen := flags.String("listen", ":0", "address to serve on")
+	code := flags.String("code", "", "pairing code shown by the serving device")
+	peerAddr := flags.String("peer", "", "address of the serving device; found automatically if empty")
+	prefer := flags.String("prefer", "", "settle conflicts by keeping the local or remote copy")
+	wait := flags.Duration("wait", 3*time.Second, "how long to look for serving devices")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 || (*prefer != "" && *prefer != "local" && *prefer != "remote") {
+		return errors.New(usage)
+	}
+
+	cfg, err := loadConfig(*path)
+	if err != nil {
+		return err
+	}
+	profile, err := openProfile(cfg, *name, out)
+	if err != nil {
+		return err
+	}
+	dm, closeData, err := openData(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	group, err := net.ResolveUDPAddr("udp4", data.LANDiscoveryAddr)
+	if err != nil {
+		return err
+	}
+
+	switch flags.Arg(0) {
+	case "serve":
+		return lanServe(ctx, dm, profile, group, *listen, out)
+	case "sync":
+		if *code == "" {
+			return errors.New(usage)
+		}
+		peer := data.LANPeer{Name: *peerAddr, Addr: *peerAddr}
+		if *peerAddr == "" {
+			if peer, err = discoverPeer(ctx, group, *wait, out); err != nil {
+				return err
+			}
+		}
+		return lanSync(ctx, cfg, data.NewLANSyncManager(dm, peer, *code), peer, *prefer, out)
+	}
+	return errors.New(usage)
+}
+
+// lanServe offers the profile's saves until ctx is cancelled, announcing
+// them on the local network
+func lanServe(ctx context.Context, dm *data.DataManager, profile *data.Profile, group *net.UDPAddr, listen string, out io.Writer) error {
+	code, err := data.NewPairingCode()
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+	// mDNS responders send from the mDNS port; a host whose responder
+	// will not share it still announces, from any port
+	var conn net.PacketConn
+	if conn, err = net.ListenMulticastUDP("udp4", nil, group); err != nil {
+		conn, err = net.ListenPacket("udp4", ":0")
+	}
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	defer conn.Close()
+
+	host, _ := os.Hostname()
+	peer := data.LANPeer{Name: profile.Name + "@" + host, Addr: listener.Addr().String()}
+	go data.Announce(ctx, conn, group, peer, data.LANAnnounceInterval)
+
+	fmt.Fprintf(out, "Sharing profile %s on %s. Pairing code: %s\n", profile.Name, peer.Addr, code)
+	fmt.Fprintln(out, "On the other device run \"gochi lan sync -code "+code+"\". Press Ctrl-C to stop.")
+	return data.NewLANServer(dm, code).Serve(ctx, listener)
+}
+
+// discoverPeer listens for serving devices and returns the only one found
+func discoverPeer(ctx context.Context, group *net.UDPAddr, wait time.Duration, out io.Writer) (data.LANPeer, error) {
+	conn, err := net.ListenMulticastUDP("udp4", nil, group)
+	if err != nil {
+		return data.LANPeer{}, err
+	}
+	defer conn.Close()
+
+	fmt.Fprintln(out, "Looking for devices sharing pets...")
+	discoverCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	peers, err := data.Discover(discoverCtx, conn)
+	if err != nil {
+		return data.LANPeer{}, err
+	}
+	switch len(peers) {
+	case 0:
+		return data.LANPeer{}, errors.New("no devices found; run \"gochi lan serve\" on the other device or give -peer")
+	case 1:
+		return peers[0], nil
+	}
+	for _, peer := range peers {
+		fmt.Fprintf(out, "  %s at %s\n", peer.Name, peer.Addr)
+	}
+	return data.LANPeer{}, errors.New("several devices found; choose one with -peer")
+}
+
+// lanSync syncs with a peer and reports what changed. Conflicts are
+// settled by -prefer if the player chose a side, otherwise by the
+// cloud.conflicts setting.
+func lanSync(ctx context.Context, cfg *config.Config, syncer *data.CloudSyncManager, peer data.LANPeer, prefer string, out io.Writer) error {
+	resolution, err := data.ParseConflictResolution(cfg.Cloud.Conflicts)
+	if err != nil {
+		return err
+	}
+	switch prefer {
+	case "local":
+		resolution = data.ConflictPreferLocal
+	case "remote":
+		resolution = data.ConflictPreferCloud
+	}
+	syncer.Resolution = resolution
+
+	report, err := syncer.SyncAll(ctx)
+	fmt.Fprintf(out, "Synced with %s: %d sent, %d received, %d merged, %d conflicts\n",
+		peer.Name, len(report.Uploaded), len(report.Downloaded), len(report.Merged), len(report.Conflicts))
+	kept := map[data.ConflictChoice]string{data.KeepLocal: "kept the local copy of", data.KeepCloud: "kept the remote copy of", data.KeepMerged: "merged both copies of"}
+	for _, conflict := range report.ConflictReports {
+		if conflict.Settled() {
+			fmt.Fprintf(out, "  %s %s\n", kept[conflict.Kept], conflict.PetID)
+		}
+	}
+	if len(report.Conflicts) > 0 {
+		fmt.Fprintln(out, "Run again with -prefer local or -prefer remote to settle the conflicts.")
+	}
+	return err
+}
+
+// memoryStorage keeps every profile's saves while data.backend is memory,
+// so everything opened in one run shares them
+var memoryStorage = data.NewMemoryBackend()
+
+// storage returns where the configured saves are kept
+func storage(cfg *config.Config) data.StorageBackend {
+	if cfg.Data.Backend == data.BackendMemory {
+		return memoryStorage
+	}
+	return data.DiskBackend{}
+}
+
+// profileManager returns the manager for the profiles under the configured
+// save root, and the function that closes it once done with
+func profileManager(cfg *config.Config) (*data.ProfileManager, func() error) {
+	profiles := data.NewProfileManager(cfg.Data.SavePath)
+	profiles.Backend = storage(cfg)
+	profiles.Hooks = data.NewLifecycleHooks()
+	return profiles, watchData(cfg, profiles.Hooks)
+}
+
+// openProfile moves any saves in the legacy flat layout into the default
+// profile, opens the chosen profile and points the configuration at it: the
+// profile's own settings are read over the shared ones, saves go to its
+// directory and cloud sync uses its account unless one is configured
+func openProfile(cfg *config.Config, name string, out io.Writer) (_ *data.Profile, err error) {
+	profiles, closeProfiles := profileManager(cfg)
+	defer func() { err = errors.Join(err, closeProfiles()) }()
+	if err := migrateProfiles(profiles, out); err != nil {
+		return nil, err
+	}
+	profile, err := profiles.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Overlay(profile.ConfigPath()); err != nil {
+		return nil, fmt.Errorf("profile %s: %w", profile.Name, err)
+	}
+
+	cfg.Data.SavePath = profile.Dir
+	if cfg.Cloud.Account == "" {
+		cfg.Cloud.Account = profile.Name
+	}
+	return profile, nil
+}
+
+// migrateProfiles moves legacy saves into the default profile, saying so
+// if there were any
+func migrateProfiles(profiles *data.ProfileManager, out io.Writer) error {
+	moved, err := profiles.MigrateLegacy()
+	if moved > 0 {
+		fmt.Fprintf(out, "Moved %d saved entries into the %s profile.\n", moved, data.DefaultProfile)
+	}
+	return err
+}
+
+// openData opens the configured save directory. The caller closes it when
+// done, which delivers the lifecycle events still queued for the webhook.
+func openData(cfg *config.Config) (*data.DataManager, func() error, error) {
+	dm, err := data.NewDataManagerOn(storage(cfg), cfg.Data.SavePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	dm.Compress = cfg.Data.CompressSaves
+	if cfg.Data.EncryptionEnabled {
+		if dm.Cipher, err = unlockSaves(cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+	return dm, watchData(cfg, dm.Hooks), nil
+}
+
+// watchData posts the events of a set of lifecycle hooks to the webhook
+// at data.webhook_url, if any. The returned function delivers the events
+// still queued and stops the webhook, returning the posts that failed.
+func watchData(cfg *config.Config, hooks *data.LifecycleHooks) func() error {
+	if cfg.Data.WebhookURL == "" {
+		return func() error { return nil }
+	}
+	webhook := data.NewLifecycleWebhook(cfg.Data.WebhookURL, data.DefaultWebhookQueueSize)
+	hooks.Add(webhook.Send)
+	return webhook.Close
+}
+
+// unlockSaves returns the cipher for the save directory. The passphrase
+// comes from GOCHI_PASSPHRASE, else the keychain, else the player is asked
+// and it is kept in the keychain if there is one. A wrong passphrase is
+// not kept.
+func unlockSaves(cfg *config.Config) (*data.SaveCipher, error) {
+	if passphrase := os.Getenv(passphraseEnv); passphrase != "" {
+		return data.UnlockSaves(storage(cfg), cfg.Data.SavePath, passphrase)
+	}
+	ring, err := openKeyring(cfg.Data.KeyringService)
+	if err != nil {
+		ring = nil
+	}
+	passphrase, source, err := keyring.Lookup(ring, keyring.SaveKey, askPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("save passphrase: %w", err)
+	}
+	cipher, err := data.UnlockSaves(storage(cfg), cfg.Data.SavePath, passphrase)
+	if errors.Is(err, data.ErrWrongKey) {
+		if source == keyring.SourcePrompt && ring != nil {
+			ring.Delete(keyring.SaveKey)
+		} else if source == keyring.SourceKeyring {
+			err = fmt.Errorf("%w in the keychain; replace it with \"gochi keys set save\"", err)
+		}
+	}
+	return cipher, err
+}
+
+// askPassphrase asks the player for the save passphrase
+func askPassphrase(string) (string, error) {
+	fmt.Fprint(promptOut, "Save passphrase: ")
+	return readSecret(promptIn)
+}
+
+// readSecret reads one line without buffering past it, so the rest of the
+// input is left for the prompt
+func readSecret(in io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := in.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			line = append(line, b[0])
+		}
+		if err == io.EOF && len(line) > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimSpace(string(line)), nil
+}
+
+// cloudToken returns the configured cloud token, or the one kept in the
+// keychain when none is configured
+func cloudToken(cfg *config.Config) string {
+	if cfg.Cloud.Token != "" {
+		return cfg.Cloud.Token
+	}
+	ring, err := openKeyring(cfg.Data.KeyringService)
+	if err != nil {
+		return ""
+	}
+	token, err := ring.Get(keyring.CloudToken)
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+// checkKeys warns about secrets the configuration needs but the keychain
+// does not hold, and about a cloud token kept in plain text. ring may be
+// nil when the system has no keychain.
+func checkKeys(cfg *config.Config, ring keyring.Keyring, out io.Writer) {
+	cloud := cfg.Cloud.Enabled || cfg.Cloud.Backups
+	if cloud && cfg.Cloud.Token != "" {
+		fmt.Fprintln(out, "Warning: cloud.token is kept in plain text; move it to the keychain with \"gochi keys set cloud\".")
+	}
+	if ring == nil {
+		if cfg.Data.EncryptionEnabled && os.Getenv(passphraseEnv) == "" {
+			fmt.Fprintf(out, "Warning: no OS keychain is available; the save passphrase will be asked every time (or set %s).\n", passphraseEnv)
+		}
+		return
+	}
+	var names []string
+	if cfg.Data.EncryptionEnabled && os.Getenv(passphraseEnv) == "" {
+		names = append(names, keyring.SaveKey)
+	}
+	if cloud && cfg.Cloud.Token == "" {
+		names = append(names, keyring.CloudToken)
+	}
+	missing, err := keyring.Missing(ring, names...)
+	if err != nil {
+		fmt.Fprintln(out, "Warning: cannot read the keychain:", err)
+		return
+	}
+	for _, name := range missing {
+		switch name {
+		case keyring.SaveKey:
+			fmt.Fprintln(out, "The save passphrase is not in the keychain yet; you will be asked for it.")
+		case keyring.CloudToken:
+			fmt.Fprintln(out, "Warning: no cloud token is configured or in the keychain; store one with \"gochi keys set cloud\".")
+		}
+	}
+}
+
+// loadConfig loads the chosen file, falling back to defaults with
+// environment overrides when no file exists at the default location
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		path = os.Getenv("GOCHI_CONFIG")
+	}
+	if path == "" {
+		if _, err := os.Stat(defaultConfigPath); err == nil {
+			path = defaultConfigPath
+		}
+	}
+	if path != "" {
+		return config.Load(path)
+	}
+
+	cfg := config.Default()
+	if err := errors.Join(cfg.ApplyEnv(os.LookupEnv), cfg.Validate()); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// play runs the profile's game loop alongside the command prompt. When the
+// prompt ends or ctx is cancelled the loop is stopped and waited for, so
+// the final save always completes before returning.
+func play(ctx context.Context, cfg *config.Config, profile *data.Profile, in io.Reader, out io.Writer) (err error) {
+	out = &syncWriter{w: out}
+	scanner := bufio.NewScanner(in)
+	starter := func() *core.DigitalPet { return quizStarter(cfg, profile, scanner, out) }
+	if cfg.UI.Spectator {
+		// Spectators cannot steer the game, so nothing asks for input and
+		// inbound MQTT commands are ignored
+		starter = nil
+		cfg.MQTT.CommandTopic = ""
+	}
+	dm, closeData, err := openGame(cfg, out)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	shell, loop, err := newGame(ctx, cfg, profile, dm, starter, out)
+	if err != nil {
+		return err
+	}
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := watchTuning(loopCtx, cfg, loop, out); err != nil {
+		return err
+	}
+	if err := loadScripts(cfg, loop, out); err != nil {
+		return err
+	}
+	logger := log.New(io.Discard, "", 0)
+	if cfg.App.Debug {
+		logger = log.New(out, "", log.LstdFlags)
+	}
+	stopMQTT, err := startMQTT(loopCtx, cfg, loop, logger)
+	if err != nil {
+		return err
+	}
+	stopNotifier := startNotifier(cfg, loop)
+	startCalendar(cfg, loop, profile, out)
+	startReports(loopCtx, cfg, loop, profile, out)
+	startAmbience(cfg, loop, out)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- loop.Run(loopCtx) }()
+
+	prompt := make(chan error, 1)
+	if cfg.UI.Spectator {
+		go func() { prompt <- spectate(loopCtx, cfg, shell, loop, scanner, out) }()
+	} else {
+		startThoughts(loopCtx, cfg, shell, loop, out)
+		go func() { prompt <- repl(shell, loop, scanner, out) }()
+	}
+
+	var replErr error
+	select {
+	case replErr = <-prompt:
+	case <-ctx.Done():
+		fmt.Fprintln(out, "\nShutting down...")
+	}
+	cancel()
+	return errors.Join(replErr, <-stopped, stopNotifier(), stopMQTT())
+}
+
+// serve runs the profile's game loop without a prompt and serves the admin
+// API until ctx is cancelled, then waits for the final save
+func serve(ctx context.Context, cfg *config.Config, profile *data.Profile, out io.Writer) (err error) {
+	if cfg.Server.AdminToken == "" {
+		return fmt.Errorf("%w: server.admin_token: must be set to serve (e.g. GOCHI_SERVER_ADMIN_TOKEN)", config.ErrInvalidConfig)
+	}
+	keys, err := server.ParseAPIKeys(cfg.Server.APIKeys, cfg.Server.AdminToken)
+	if err != nil {
+		return fmt.Errorf("server.api_keys: %w", err)
+	}
+	out = &syncWriter{w: out}
+	dm, closeData, err := openGame(cfg, out)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeData()) }()
+	_, loop, err := newGame(ctx, cfg, profile, dm, nil, out)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", cfg.Server.Listen)
+	if err != nil {
+		return err
+	}
+
+	loopCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := watchTuning(loopCtx, cfg, loop, out); err != nil {
+		listener.Close()
+		return err
+	}
+	if err := loadScripts(cfg, loop, out); err != nil {
+		listener.Close()
+		return err
+	}
+	startCrowd(cfg, loop, out)
+
+	admin := server.NewAdmin(loop, cfg.Server.AdminToken, filepath.Join(cfg.Data.SavePath, data.BackupDirName))
+	admin.Debug = cfg.App.Debug
+	admin.Keys = keys
+	mux := http.NewServeMux()
+	mux.Handle(server.AdminPrefix, admin)
+	if cfg.Discord.Enabled && cfg.Discord.PublicKey != "" {
+		bot, err := discord.NewBot(loop, cfg.Discord.PublicKey)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		if cfg.UI.Qualitative {
+			bot.Impressions = &biology.Impressions{}
+		}
+		mux.Handle(discord.InteractionsPath, bot)
+		fmt.Fprintf(out, "Discord interactions endpoint at http://%s%s\n", listener.Addr(), discord.InteractionsPath)
+	}
+	stopMQTT, err := startMQTT(loopCtx, cfg, loop, log.New(out, "", log.LstdFlags))
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	stopNotifier := startNotifier(cfg, loop)
+	if feed := startCalendar(cfg, loop, profile, out); feed != nil && feed.Token != "" {
+		mux.Handle(calendar.FeedPath, feed)
+		fmt.Fprintf(out, "Calendar feed at http://%s%s?token=<calendar.token>\n", listener.Addr(), calendar.FeedPath)
+	}
+	startReports(loopCtx, cfg, loop, profile, out)
+
+	stopped := make(chan error, 1)
+	go func() {
+		err := runWatched(loopCtx, cfg, loop)
+		cancel() // A loop the watchdog gave up on stops the server too
+		stopped <- err
+	}()
+
+	fmt.Fprintf(out, "Serving profile %s; admin API at http://%s%s\n", profile.Name, listener.Addr(), server.AdminPrefix)
+	serveErr := server.Serve(loopCtx, listener, mux)
+	cancel()
+	return errors.Join(serveErr, <-stopped, stopNotifier(), stopMQTT())
+}
+
+// runWatched runs the loop under a watchdog that restarts it when it
+// stalls, unless server.stall_timeout is 0
+func runWatched(ctx context.Context, cfg *config.Config, loop *game.GameLoop) error {
+	if cfg.Server.StallTimeout <= 0 {
+		return loop.Run(ctx)
+	}
+	watchdog := game.NewWatchdog(loop, time.Duration(cfg.Server.StallTimeout)*time.Second)
+	watchdog.MaxRestarts = cfg.Server.MaxRestarts
+	if cfg.Data.Backend != data.BackendMemory {
+		watchdog.DumpDir = filepath.Join(cfg.Data.SavePath, game.StallDirName)
+	}
+	return watchdog.Run(ctx)
+}
+
+// startThoughts shows the pets' thought bubbles every thought interval
+// until ctx is cancelled
+func startThoughts(ctx context.Context, cfg *config.Config, shell *ui.Shell, loop *game.GameLoop, out io.Writer) {
+	if cfg.UI.ThoughtInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(cfg.UI.ThoughtInterval) * time.Second)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				var thoughts string
+				loop.Try(game.SubsystemDisplay, func() { thoughts = shell.Thoughts() })
+				if thoughts != "" {
+					fmt.Fprint(out, "\n"+thoughts+"> ")
+				}
+			}
+		}
+	}()
+}
+
+// startAmbience shows ambience cues, such as rain starting or a pet
+// purring, as subtle lines between prompts
+func startAmbience(cfg *config.Config, loop *game.GameLoop, out io.Writer) {
+	if !cfg.UI.Ambience {
+		return
+	}
+	prompt := "> "
+	if cfg.UI.Spectator {
+		prompt = ""
+	}
+	loop.Events.Subscribe(string(simulation.EventAmbience), 0, func(e simulation.Event) {
+		cue, _ := e.Data["cue"].(string)
+		name, _ := e.Data["name"].(string)
+		if line := ui.RenderCue(simulation.Cue(cue), name); line != "" {
+			fmt.Fprint(out, "\n"+line+prompt)
+		}
+	})
+}
+
+// startNotifier posts pet alerts to Discord when a webhook is configured.
+// The returned function posts what is still queued and stops it.
+func startNotifier(cfg *config.Config, loop *game.GameLoop) func() error {
+	if !cfg.Discord.Enabled || cfg.Discord.WebhookURL == "" {
+		return func() error { return nil }
+	}
+	notifier := discord.NewNotifier(cfg.Discord.WebhookURL, discord.DefaultNotifyQueueSize)
+	notifier.Attach(loop.Events)
+	return notifier.Close
+}
+
+// startMQTT publishes the pets to the MQTT broker when enabled. The
+// returned function waits for the bridge to disconnect after ctx ends.
+func startMQTT(ctx context.Context, cfg *config.Config, loop *game.GameLoop, logger *log.Logger) (func() error, error) {
+	if !cfg.MQTT.Enabled {
+		return func() error { return nil }, nil
+	}
+	allow, err := mqtt.ParseAllowlist(cfg.MQTT.AllowCommands)
+	if err != nil {
+		return nil, fmt.Errorf("%w: mqtt.allow_commands: %v", config.ErrInvalidConfig, err)
+	}
+	bridge := mqtt.NewBridge(loop, cfg.MQTT.Broker,
+		mqtt.Options{ClientID: cfg.MQTT.ClientID, Username: cfg.MQTT.Username, Password: cfg.MQTT.Password},
+		mqtt.Topics{State: cfg.MQTT.StateTopic, Event: cfg.MQTT.EventTopic, Command: cfg.MQTT.CommandTopic})
+	bridge.Allow = allow
+	bridge.Interval = time.Duration(cfg.MQTT.StateInterval) * time.Second
+	bridge.Retain = cfg.MQTT.Retain
+	bridge.Log = logger
+
+	done := make(chan error, 1)
+	go func() { done <- bridge.Run(ctx) }()
+	return func() error { return <-done }, nil
+}
+
+// startCalendar keeps the calendar file, if any, up to date on every
+// auto-save when the calendar is enabled, and returns the feed to serve
+func startCalendar(cfg *config.Config, loop *game.GameLoop, profile *data.Profile, out io.Writer) *calendar.Feed {
+	if !cfg.Calendar.Enabled {
+		return nil
+	}
+	feed := calendar.NewFeed(loop, cfg.App.Name+" ("+profile.Name+")", cfg.Calendar.Token)
+	feed.Horizon = time.Duration(cfg.Calendar.Horizon) * 24 * time.Hour
+	if cfg.Calendar.File != "" {
+		write := func(simulation.Event) {
+			if err := feed.WriteFile(cfg.Calendar.File); err != nil {
+				fmt.Fprintf(out, "Calendar not written: %v\n", err)
+			}
+		}
+		write(simulation.Event{})
+		loop.Events.Subscribe(string(simulation.EventAutoSave), 0, write)
+	}
+	return feed
+}
+
+// startReports records the household on every auto-save when weekly
+// reports are enabled and sends each week's report in the background
+func startReports(ctx context.Context, cfg *config.Config, loop *game.GameLoop, profile *data.Profile, out io.Writer) {
+	if !cfg.Report.Enabled {
+		return
+	}
+	dir := filepath.Join(profile.Dir, report.DirName)
+	recorder, err := report.NewRecorder(loop, filepath.Join(dir, report.StateFileName))
+	if err != nil {
+		fmt.Fprintf(out, "Weekly reports disabled: %v\n", err)
+		return
+	}
+	format, err := report.ParseFormat(cfg.Report.Format)
+	if err != nil {
+		fmt.Fprintf(out, "Weekly reports disabled: %v\n", err)
+		return
+	}
+	var sender report.Sender
+	switch cfg.Report.Delivery {
+	case "webhook":
+		sender = report.WebhookSender{URL: cfg.Report.WebhookURL}
+	case "smtp":
+		sender = report.NewSMTPSender(cfg.Report.SMTPServer, cfg.Report.SMTPUsername, cfg.Report.SMTPPassword,
+			cfg.Report.From, cfg.Report.To)
+	default:
+		if cfg.Report.Dir != "" {
+			dir = cfg.Report.Dir
+		}
+		sender = report.FileSender{Dir: dir}
+	}
+
+	recorder.Attach(loop.Events)
+	schedule := report.NewSchedule(recorder, sender, format, cfg.App.Name+" weekly summary ("+profile.Name+")")
+	loop.Events.Subscribe(string(simulation.EventAutoSave), 0, func(simulation.Event) {
+		go func() {
+			weekly, err := schedule.Run(ctx)
+			switch {
+			case err != nil:
+				fmt.Fprintf(out, "Weekly report not sent: %v\n", err)
+			case weekly != nil:
+				fmt.Fprintf(out, "Sent %q\n", weekly.Subject())
+			}
+		}()
+	})
+}
+
+// openGame warns about secrets missing from the keychain, before unlocking
+// the saves might add the passphrase, then opens the saves to play
+func openGame(cfg *config.Config, out io.Writer) (*data.DataManager, func() error, error) {
+	ring, err := openKeyring(cfg.Data.KeyringService)
+	if err != nil {
+		ring = nil
+	}
+	checkKeys(cfg, ring, out)
+	return openData(cfg)
+}
+
+// newGame recovers any interrupted saves in dm and loads the profile's
+// household into a shell and a game loop that is ready to run. If the
+// profile has no pets yet, starter creates the first one; nil gives a
+// random starter.
+func newGame(ctx context.Context, cfg *config.Config, profile *data.Profile, dm *data.DataManager, starter func() *core.DigitalPet, out io.Writer) (*ui.Shell, *game.GameLoop, error) {
+	report, err := dm.Recover()
+	if err != nil {
+		return nil, nil, err
+	}
+	if report.Unclean {
+		fmt.Fprintf(out, "Gochi did not shut down cleanly last time; recovered %d and rolled back %d interrupted saves.\n",
+			len(report.RolledForward), len(report.RolledBack))
+	}
+	offsite := offsiteBackups(cfg)
+	if offsite != nil {
+		restoreOffsite(ctx, offsite, dm, out)
+	}
+
+	pets, err := dm.LoadAll(ctx)
+	if err != nil {
+		fmt.Fprintln(out, "Some saves could not be loaded and were left untouched:")
+		for _, problem := range splitErrors(err) {
+			fmt.Fprintln(out, "  -", ui.ErrorMessage(problem))
+		}
+	}
+	if len(pets) == 0 {
+		if starter == nil || ctx.Err() != nil {
+			pets = append(pets, core.NewDigitalPetRandom(starterPetName, profile.Owner()))
+		} else {
+			pets = append(pets, starter())
+		}
+	}
+
+	shell, household, err := newSession(cfg, dm, pets)
+	if err != nil {
+		return nil, nil, err
+	}
+	shell.Owner = profile.Owner()
+	shell.Market = marketplace(cfg)
+	if cfg.UI.ProgressiveUnlocks {
+		unlocks, err := interaction.LoadUnlocks(filepath.Join(profile.Dir, interaction.UnlocksDirName, interaction.UnlocksFileName))
+		if err != nil {
+			return nil, nil, err
+		}
+		shell.Unlocks = unlocks
+		household.Unlocks = unlocks
+	}
+	loop, err := game.NewGameLoop(cfg.Simulation, household, dm)
+	if err != nil {
+		return nil, nil, err
+	}
+	loop.Offsite = offsite
+	if err := alignClock(cfg, loop, out); err != nil {
+		return nil, nil, err
+	}
+	return shell, loop, nil
+}
+
+// alignClock ties the loop to the player's clock when real-world alignment
+// is on, catching pets up on the time since they were last played with
+func alignClock(cfg *config.Config, loop *game.GameLoop, out io.Writer) error {
+	if !cfg.Clock.RealWorld {
+		return nil
+	}
+	loc, err := cfg.Clock.Location()
+	if err != nil {
+		return fmt.Errorf("%w: clock.time_zone: %v", config.ErrInvalidConfig, err)
+	}
+	hemisphere, err := cfg.Clock.HomeHemisphere()
+	if err != nil {
+		return fmt.Errorf("%w: clock.hemisphere: %v", config.ErrInvalidConfig, err)
+	}
+
+	catchUp := time.Duration(cfg.Clock.CatchUpDays) * 24 * time.Hour
+	if days := loop.AlignToClock(loc, hemisphere, catchUp); days*24 >= 1 {
+		fmt.Fprintf(out, "Caught up on %.0f hours since your last visit.\n", days*24)
+	}
+	return nil
+}
+
+// watchTuning applies the tuning file, if any, before the loop starts. In
+// dev mode the file keeps being watched and every change is logged.
+func watchTuning(ctx context.Context, cfg *config.Config, loop *game.GameLoop, out io.Writer) error {
+	if cfg.App.TuningFile == "" {
+		return nil
+	}
+
+	logger := log.New(io.Discard, "", 0)
+	if cfg.App.Debug {
+		logger = log.New(out, "", log.LstdFlags)
+	}
+	watcher := config.NewTuningWatcher(cfg.App.TuningFile, logger)
+	watcher.Apply = loop.Do
+	if _, err := watcher.Check(); err != nil {
+		return err
+	}
+	if cfg.App.Debug {
+		go watcher.Run(ctx)
+	}
+	return nil
+}
+
+// startCrowd lets viewers vote on care through the admin API when crowd
+// control is enabled. Each round's outcome is reported as it happens.
+func startCrowd(cfg *config.Config, loop *game.GameLoop, out io.Writer) {
+	if !cfg.Crowd.Enabled {
+		return
+	}
+	loop.Crowd = interaction.NewCrowdControl(time.Duration(cfg.Crowd.Round)*time.Second,
+		time.Duration(cfg.Crowd.Cooldown)*time.Second, time.Duration(cfg.Crowd.DisciplineCooldown)*time.Second)
+	loop.Events.Subscribe(string(simulation.EventPetCrowd), 0, func(e simulation.Event) {
+		if reason, refused := e.Data["reason"]; refused {
+			fmt.Fprintf(out, "Crowd vote for %s on %s refused: %v\n", e.Data["action"], e.Data["name"], reason)
+			return
+		}
+		fmt.Fprintf(out, "Crowd chose %s for %s (%v of %v votes)\n", e.Data["action"], e.Data["name"], e.Data["votes"], e.Data["total"])
+	})
+	fmt.Fprintf(out, "Crowd control on: votes close every %ds\n", cfg.Crowd.Round)
+}
+
+// loadScripts attaches the reaction rules to the loop when scripting is
+// enabled. Failed reactions are reported as they happen.
+func loadScripts(cfg *config.Config, loop *game.GameLoop, out io.Writer) error {
+	if !cfg.Scripting.Enabled {
+		return nil
+	}
+	rules, err := script.LoadFile(cfg.Scripting.File)
+	if err != nil {
+		return err
+	}
+	loop.Scripts = script.NewEngine(rules, cfg.Scripting.MaxSteps)
+	loop.Events.Subscribe(string(simulation.EventScript), 0, func(e simulation.Event) {
+		if err, failed := e.Data["error"]; failed {
+			fmt.Fprintf(out, "Reaction %q failed: %v\n", e.Data["rule"], err)
+		}
+	})
+	return nil
+}
+
+// splitErrors returns the individual errors of a joined error
+func splitErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// syncWriter serializes writes from the prompt and the shutdown notice
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write writes p to the underlying writer
+func (sw *syncWriter) Write(p []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.w.Write(p)
+}
+
+// newSession builds the shell and household from the configuration and
+// the household save in dm, if any. Both share the same pets and world
+// state. A world generated without a configured seed is kept with the
+// household, so it is the same world, and pets are where they were, on
+// the next run.
+func newSession(cfg *config.Config, dm *data.DataManager, pets []*core.DigitalPet) (*ui.Shell, *interaction.Household, error) {
+	biome, err := cfg.Environment.HomeBiome()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shell := ui.NewShell()
+	shell.Qualitative = cfg.UI.Qualitative
+
+	household := interaction.NewHousehold(pets...)
+	household.Inbox = shell.Inbox
+	household.Territory = shell.Territory
+	household.Garden = shell.Garden
+	household.PopulationCap = cfg.Household.PopulationCap
+	household.Inventory = shell.Inventory
+	household.Habitat = shell.Habitat
+	if dm != nil {
+		saved, err := dm.ReadHousehold()
+		if err != nil {
+			return nil, nil, err
+		}
+		if saved != nil {
+			if err := household.LoadState(saved); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	seed := cfg.Environment.Seed
+	if seed == 0 {
+		seed = household.WorldSeed
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if seed != household.WorldSeed {
+		household.WorldSeed = seed
+		if err := saveHousehold(dm, household); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	world, err := environment.GenerateWorld(cfg.Environment.WorldGen(seed))
+	if err != nil {
+		return nil, nil, err
+	}
+	shell.World = world
+	if cfg.Environment.Weather {
+		shell.Weather = environment.NewBiomeWeatherSystem(biome, seed)
+	}
+	if cfg.Environment.Events {
+		shell.Events = environment.NewSeededManager(world, seed)
+	}
+
+	household.Weather = shell.Weather
+	household.Environment = shell.Events
+	if cfg.Environment.Surprises {
+		household.Surprises = interaction.NewSeededRandomEvents(seed)
+	}
+	household.Adoption = interaction.NewSeededAdoptionCenter(seed)
+	household.World = world
+
+	// Pets saved in a world that is no longer played come home, and
+	// missing ones hide again somewhere in this one
+	for _, pet := range household.Pets {
+		if _, exists := world.Location(pet.Location); !exists {
+			pet.Location = environment.HomeLocation
+		}
+	}
+	for _, rescue := range household.Missing() {
+		if _, exists := world.Location(rescue.Hideout); !exists {
+			household.SetWorld(world)
+			break
+		}
+	}
+
+	shell.Data = dm
+	shell.Household = household
+	for _, pet := range pets {
+		shell.AddPet(pet)
+	}
+	household.Relationships.Reconcile() // Saves from before coordination may be one-sided
+	return shell, household, nil
+}
+
+// saveHousehold writes the household's state other than its pets to dm,
+// if any
+func saveHousehold(dm *data.DataManager, household *interaction.Household) error {
+	if dm == nil {
+		return nil
+	}
+	payload, err := household.SaveState()
+	if err != nil {
+		return err
+	}
+	return dm.WriteHousehold(context.Background(), payload)
+}
+
+// spectate shows the spectator dashboard every ui.spectator_interval
+// seconds until ctx is cancelled or "quit" is read. Other input is
+// refused, so viewers cannot change the game.
+func spectate(ctx context.Context, cfg *config.Config, shell *ui.Shell, loop *game.GameLoop, scanner *bufio.Scanner, out io.Writer) error {
+	show := func() {
+		var dashboard string
+		if err := loop.Try(game.SubsystemDisplay, func() { dashboard = shell.Spectate() }); err != nil {
+			dashboard = ui.ErrorMessage(err) + "\n"
+		}
+		fmt.Fprint(out, "\n"+dashboard)
+	}
+	fmt.Fprintln(out, "Spectator mode: commands are disabled. Type \"quit\" to leave.")
+	show()
+
+	lines := make(chan string)
+	done := make(chan error, 1)
+	go func() {
+		for scanner.Scan() {
+			select {
+			case lines <- strings.TrimSpace(scanner.Text()):
+			case <-ctx.Done():
+				return
+			}
+		}
+		done <- scanner.Err()
+	}()
+
+	ticker := time.NewTicker(time.Duration(cfg.UI.SpectatorInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-done:
+			return err
+		case <-ticker.C:
+			show()
+		case line := <-lines:
+			switch line {
+			case "quit", "exit":
+				return nil
+			case "":
+				show()
+			default:
+				fmt.Fprintln(out, "Spectator mode: commands are disabled.")
+			}
+		}
+	}
+}
+
+// quizStarter creates the first pet, asking the creation quiz if it is
+// enabled and biasing the pet's genes toward the answers
+func quizStarter(cfg *config.Config, profile *data.Profile, scanner *bufio.Scanner, out io.Writer) *core.DigitalPet {
+	if !cfg.UI.CreationQuiz {
+		return core.NewDigitalPetRandom(starterPetName, profile.Owner())
+	}
+	answers := ui.AskQuiz(scanner, out, genetics.CreationQuiz)
+	targets, err := genetics.QuizTargets(genetics.CreationQuiz, answers)
+	if err != nil || !ui.Answered(answers) {
+		fmt.Fprintln(out, "Quiz skipped; your companion's personality is a surprise.")
+		return core.NewDigitalPetRandom(starterPetName, profile.Owner())
+	}
+	fmt.Fprintln(out, "Thanks! Your companion is hatching...")
+	genome := genetics.BiasedGenome(targets, genetics.QuizBias)
+	return core.NewDigitalPetFromGenome(starterPetName, profile.Owner(), genome)
+}
+
+// repl reads commands from scanner until end of input or "quit". Commands
+// run between game loop ticks.
+func repl(shell *ui.Shell, loop *game.GameLoop, scanner *bufio.Scanner, out io.Writer) error {
+	fmt.Fprintln(out, "Welcome to Gochi. Type \"help\" for commands, \"quit\" to leave.")
+	for {
+		fmt.Fprint(out, "> ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "quit" || line == "exit" {
+			return nil
+		}
+
+		// A command that panics is reported like any other failure and the
+		// prompt carries on
+		var output, cues string
+		var err error
+		if perr := loop.Try(game.SubsystemCommands, func() {
+			output, err = shell.Execute(line)
+			cues = shell.Cues()
+		}); perr != nil {
+			err = perr
+		}
+		loop.Wake()
+		if err != nil {
+			fmt.Fprintln(out, ui.ErrorMessage(err))
+		} else {
+			fmt.Fprintln(out, output)
+		}
+		// The pets' vocal cues show between commands
+		fmt.Fprint(out, cues)
+	}
+}
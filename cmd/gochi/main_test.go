@@ -0,0 +1,937 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/keyring"
+	"github.com/Michael-W-Ellison/gochi/internal/script"
+	"github.com/Michael-W-Ellison/gochi/internal/server"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestMain(m *testing.M) {
+	// Keep tests away from the real keychain
+	openKeyring = func(string) (keyring.Keyring, error) { return nil, keyring.ErrUnavailable }
+	os.Exit(m.Run())
+}
+
+// useKeyring points the commands at an in-memory keychain for one test
+func useKeyring(t *testing.T) *keyring.Memory {
+	ring := keyring.NewMemory()
+	saved := openKeyring
+	openKeyring = func(string) (keyring.Keyring, error) { return ring, nil }
+	t.Cleanup(func() { openKeyring = saved })
+	return ring
+}
+
+func TestConfigValidate(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.yaml")
+	bad := filepath.Join(dir, "bad.yaml")
+	os.WriteFile(good, []byte("simulation:\n  tick_rate: 30\n"), 0o644)
+	os.WriteFile(bad, []byte("simulation:\n  tick_rate: 0\nenvironment:\n  biome: Moon\n"), 0o644)
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"config", "validate", good}, nil, &out); err != nil {
+		t.Fatalf("Expected valid config, got %v: %s", err, out.String())
+	}
+
+	out.Reset()
+	err := run(context.Background(), []string{"config", "validate", bad}, nil, &out)
+	if !errors.Is(err, config.ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+	if !strings.Contains(out.String(), "tick_rate") || !strings.Contains(out.String(), "biome") {
+		t.Errorf("Expected both problems listed, got:\n%s", out.String())
+	}
+}
+
+func TestREPL(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("environment:\n  seed: 3\ndata:\n  save_path: "+saves+"\n"), 0o644)
+
+	var out bytes.Buffer
+	in := strings.NewReader("help\nbogus\nquit\nhelp\n")
+	if err := run(context.Background(), []string{"-config", path}, in, &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "habitat") || !strings.Contains(out.String(), "error:") {
+		t.Errorf("Expected help output and an unknown command error, got:\n%s", out.String())
+	}
+
+	dm, err := data.NewDataManager(filepath.Join(saves, "profiles", data.DefaultProfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := dm.ListPets(context.Background(), data.FilterActive)
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("Expected the starter pet to be saved on quit, got %v (%v)", ids, err)
+	}
+	if report, _ := dm.Recover(); report.Unclean {
+		t.Error("Quitting should leave a clean shutdown")
+	}
+}
+
+func TestREPLShowsCues(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("environment:\n  seed: 3\ndata:\n  save_path: "+saves+"\n"), 0o644)
+	dm, err := data.NewDataManager(filepath.Join(saves, "profiles", data.DefaultProfile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet := core.NewDigitalPet("Rex", "")
+	pet.Biology.Vitals.Hydration = 0
+	if err := dm.SavePet(context.Background(), pet); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"-config", path}, strings.NewReader("help\nquit\n"), &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "! Rex noses the dry water dish.") {
+		t.Errorf("Expected the thirsty pet's cue after the command, got:\n%s", out.String())
+	}
+}
+
+func TestNewSessionReconcilesRelationships(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "")
+	mochi := core.NewDigitalPet("Mochi", "")
+	rex.Relationships.AddRelationship(mochi.ID, types.RelationshipFriend)
+
+	_, _, err := newSession(config.Default(), nil, []*core.DigitalPet{rex, mochi})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mochi.Relationships.HasRelationshipWith(rex.ID) {
+		t.Error("Expected the one-sided friendship to be mirrored on load")
+	}
+}
+
+func TestNewSessionKeepsWorld(t *testing.T) {
+	dm, err := data.NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Default()
+	cfg.Environment.Seed = 0
+	rex := core.NewDigitalPet("Rex", "")
+	shell, household, err := newSession(cfg, dm, []*core.DigitalPet{rex})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if household.WorldSeed == 0 {
+		t.Fatal("Expected the generated seed to be kept with the household")
+	}
+	var away string
+	for _, id := range shell.World.LocationIDs() {
+		if id != environment.HomeLocation {
+			away = id
+			break
+		}
+	}
+	rex.Location = away
+	mochi := core.NewDigitalPet("Mochi", "")
+	mochi.Location = "atlantis"
+
+	shell, household, err = newSession(cfg, dm, []*core.DigitalPet{rex, mochi})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := shell.World.Location(away); !exists || rex.Location != away {
+		t.Errorf("Expected the same world with Rex still at %s, got %s", away, rex.Location)
+	}
+	if mochi.Location != environment.HomeLocation {
+		t.Errorf("Expected a pet from another world to come home, got %s", mochi.Location)
+	}
+	if household.World != shell.World {
+		t.Error("Expected the shell and household to share the world")
+	}
+}
+
+func TestCreationQuiz(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("ui:\n  creation_quiz: true\ndata:\n  save_path: "+saves+"\n"), 0o644)
+
+	var out bytes.Buffer
+	in := strings.NewReader("1\n1\nskip\nstatus Gochi\nquit\n")
+	if err := run(context.Background(), []string{"-config", path}, in, &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	for _, want := range []string{"spend a free afternoon", "Your companion is hatching", "Gochi"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+
+	// The quiz is only asked before the first pet
+	out.Reset()
+	if err := run(context.Background(), []string{"-config", path}, strings.NewReader("quit\n"), &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if strings.Contains(out.String(), "spend a free afternoon") {
+		t.Errorf("Did not expect the quiz with an existing pet, got:\n%s", out.String())
+	}
+}
+
+func TestSpectate(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("ui:\n  creation_quiz: true\ndata:\n  save_path: "+saves+"\n"), 0o644)
+
+	var out bytes.Buffer
+	in := strings.NewReader("archive Gochi\nquit\n")
+	if err := run(context.Background(), []string{"-config", path, "-spectate"}, in, &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	for _, want := range []string{"Spectating", "Gochi", "commands are disabled"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "spend a free afternoon") {
+		t.Error("Spectator mode should not ask the creation quiz")
+	}
+
+	dm, _ := data.NewDataManager(filepath.Join(saves, "profiles", data.DefaultProfile))
+	if ids, _ := dm.ListPets(context.Background(), data.FilterActive); len(ids) != 1 {
+		t.Errorf("Spectators should not be able to archive pets, got active %v", ids)
+	} else if strings.Contains(out.String(), string(ids[0])) {
+		t.Errorf("Expected the pet ID %s to be masked, got:\n%s", ids[0], out.String())
+	}
+}
+
+func TestShutdownOnCancel(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+saves+"\n"), 0o644)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reader, writer := io.Pipe()
+	defer writer.Close()
+
+	var out bytes.Buffer
+	if err := run(ctx, []string{"-config", path}, reader, &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	dm, _ := data.NewDataManager(filepath.Join(saves, "profiles", data.DefaultProfile))
+	if ids, _ := dm.ListPets(context.Background(), data.FilterActive); len(ids) != 1 {
+		t.Errorf("Expected a final save after cancellation, got %v", ids)
+	}
+}
+
+func TestFsck(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+saves+"\n"), 0o644)
+
+	ctx := context.Background()
+	dm, _ := data.NewDataManager(saves)
+	pet := core.NewDigitalPet("Mochi", "owner")
+	pet.Relationships.AddRelationship("gone", types.RelationshipFriend)
+	dm.SavePet(ctx, pet)
+
+	var out bytes.Buffer
+	if err := run(ctx, []string{"fsck", "-config", path}, nil, &out); !errors.Is(err, data.ErrDanglingReferences) {
+		t.Fatalf("Expected ErrDanglingReferences, got %v", err)
+	}
+	if !strings.Contains(out.String(), "relationship refers to missing pet gone") {
+		t.Errorf("Expected the dangling reference listed, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run(ctx, []string{"fsck", "-config", path, "-repair", "memorial"}, nil, &out); err != nil {
+		t.Fatalf("Repair failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "repaired 1") || !strings.Contains(out.String(), "saves OK") {
+		t.Errorf("Expected a repair and a clean check, got:\n%s", out.String())
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+saves+"\n"), 0o644)
+
+	ctx := context.Background()
+	dm, _ := data.NewDataManager(saves)
+	pet := core.NewDigitalPet("Mochi", "owner")
+	dm.SavePet(ctx, pet)
+	dm.Backup(ctx, filepath.Join(saves, data.BackupDirName))
+
+	var out bytes.Buffer
+	if err := run(ctx, []string{"compact", "-config", path}, nil, &out); err != nil {
+		t.Fatalf("Compact failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "rewrote 1 saves") || !strings.Contains(out.String(), "reclaimed") {
+		t.Errorf("Expected a compaction summary, got:\n%s", out.String())
+	}
+	profile, _ := data.NewProfileManager(saves).Open("")
+	raw, _ := os.ReadFile(filepath.Join(profile.Dir, string(pet.ID)+".json"))
+	if !data.IsCompressed(raw) {
+		t.Error("Expected the save rewritten compressed")
+	}
+	dm, _ = data.NewDataManager(profile.Dir)
+	if _, err := dm.LoadPet(ctx, pet.ID); err != nil {
+		t.Errorf("Compacted save failed to load: %v", err)
+	}
+}
+
+// objectStore is a minimal plain HTTP object store for cloud backups
+type objectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (o *objectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/"):
+		for name := range o.objects {
+			if path.Dir(name)+"/" == r.URL.Path {
+				fmt.Fprintln(w, path.Base(name))
+			}
+		}
+	case r.Method == http.MethodPut:
+		o.objects[r.URL.Path], _ = io.ReadAll(r.Body)
+	case r.Method == http.MethodGet && o.objects[r.URL.Path] != nil:
+		w.Write(o.objects[r.URL.Path])
+	case r.Method == http.MethodDelete && o.objects[r.URL.Path] != nil:
+		delete(o.objects, r.URL.Path)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestCloudBackups(t *testing.T) {
+	store := &objectStore{objects: make(map[string][]byte)}
+	srv := httptest.NewServer(store)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	writeConfig := func(name string) string {
+		path := filepath.Join(dir, name+".yaml")
+		os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, name)+"\n"+
+			"cloud:\n  endpoint: "+srv.URL+"\n  account: alice\n  backups: true\n  keep_backups: 1\n"), 0o644)
+		return path
+	}
+	laptop, desktop := writeConfig("laptop"), writeConfig("desktop")
+
+	ctx := context.Background()
+	dm, _ := data.NewDataManager(filepath.Join(dir, "laptop"))
+	pet := core.NewDigitalPet("Mochi", "owner")
+	dm.SavePet(ctx, pet)
+
+	var out bytes.Buffer
+	for i := 0; i < 2; i++ {
+		if err := run(ctx, []string{"backup", "-config", laptop, "create"}, nil, &out); err != nil {
+			t.Fatalf("Backup failed: %v\n%s", err, out.String())
+		}
+	}
+	out.Reset()
+	if err := run(ctx, []string{"backup", "-config", laptop, "list"}, nil, &out); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if lines := strings.Fields(out.String()); len(lines) != 1 || !strings.HasSuffix(lines[0], data.BackupArchiveExt) {
+		t.Errorf("Expected one remote backup kept, got:\n%s", out.String())
+	}
+
+	// A fresh install restores the latest backup before play starts
+	out.Reset()
+	if err := run(ctx, []string{"-config", desktop}, strings.NewReader("quit\n"), &out); err != nil {
+		t.Fatalf("Play failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "from cloud storage") {
+		t.Errorf("Expected a backup restored from the cloud, got:\n%s", out.String())
+	}
+	profile, _ := data.NewProfileManager(filepath.Join(dir, "desktop")).Open("")
+	restored, _ := data.NewDataManager(profile.Dir)
+	if _, err := restored.LoadPet(ctx, pet.ID); err != nil {
+		t.Errorf("Expected Mochi restored, got %v", err)
+	}
+
+	// Restoring over saves previews what would be lost and asks first
+	newer := core.NewDigitalPet("Newer", "owner")
+	restored.SavePet(ctx, newer)
+	out.Reset()
+	if err := run(ctx, []string{"backup", "-config", desktop, "-dry-run", "restore"}, nil, &out); err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Newer ("+string(newer.ID)+") is deleted") || strings.Contains(out.String(), "Type") {
+		t.Errorf("Expected Newer's deletion previewed without asking, got:\n%s", out.String())
+	}
+	if err := run(ctx, []string{"backup", "-config", desktop, "restore"}, strings.NewReader("no\n"), &out); !errors.Is(err, errRestoreCancelled) {
+		t.Errorf("Expected the restore cancelled, got %v", err)
+	}
+	if _, err := restored.LoadPet(ctx, newer.ID); err != nil {
+		t.Errorf("Expected Newer kept after cancelling, got %v", err)
+	}
+	out.Reset()
+	if err := run(ctx, []string{"backup", "-config", desktop, "restore"}, strings.NewReader("restore\n"), &out); err != nil {
+		t.Fatalf("Restore failed: %v\n%s", err, out.String())
+	}
+	if _, err := restored.LoadPet(ctx, newer.ID); !errors.Is(err, data.ErrPetNotFound) {
+		t.Errorf("Expected Newer removed by the restore, got %v", err)
+	}
+	if !strings.Contains(out.String(), "current saves backed up to") {
+		t.Errorf("Expected the replaced saves backed up, got:\n%s", out.String())
+	}
+}
+
+func TestMarketplace(t *testing.T) {
+	cfg := config.Default()
+	if marketplace(cfg) != nil {
+		t.Error("Expected no marketplace without cloud sync")
+	}
+	cfg.Cloud.Enabled = true
+	cfg.Cloud.Endpoint = "https://cloud.example.com/"
+	cfg.Cloud.Account = "alice"
+	cfg.Cloud.Timeout = 5
+	market := marketplace(cfg)
+	if market == nil || market.Account != "alice" || market.Timeout != 5*time.Second {
+		t.Errorf("Expected alice's marketplace with a 5s timeout, got %+v", market)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	pet := core.NewDigitalPet("Mochi", "owner")
+	before, _ := pet.Save()
+	pet.Biology.Vitals.Energy = 0.25
+	after, _ := pet.Save()
+	os.WriteFile(filepath.Join(dir, "local.json"), before, 0o644)
+	os.WriteFile(filepath.Join(dir, "cloud.json"), after, 0o644)
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"diff", filepath.Join(dir, "local.json"), filepath.Join(dir, "cloud.json")}, nil, &out); err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "vitals:") || !strings.Contains(out.String(), "-> 0.25 (-0.75)") {
+		t.Errorf("Expected the energy change listed, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run(context.Background(), []string{"diff", "-json", filepath.Join(dir, "local.json"), filepath.Join(dir, "local.json")}, nil, &out); err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != `{
+  "changes": []
+}` {
+		t.Errorf("Expected no changes, got:\n%s", out.String())
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[int64]string{512: "512 B", 2048: "2.0 KiB", 3 << 20: "3.0 MiB", -1536: "-1.5 KiB"} {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+saves+"\n"), 0o644)
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	if err := run(ctx, []string{"-profile", "alice", "-config", path}, strings.NewReader("quit\n"), &out); !errors.Is(err, data.ErrProfileNotFound) {
+		t.Fatalf("Expected ErrProfileNotFound before the profile is created, got %v", err)
+	}
+	if err := run(ctx, []string{"profile", "-config", path, "create", "alice"}, nil, &out); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if err := run(ctx, []string{"profile", "-config", path, "switch", "alice"}, nil, &out); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+	if err := run(ctx, []string{"-config", path}, strings.NewReader("quit\n"), &out); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	dm, _ := data.NewDataManager(filepath.Join(saves, "profiles", "alice"))
+	pets, err := dm.LoadAll(ctx)
+	if err != nil || len(pets) != 1 || pets[0].Owner != "alice" {
+		t.Fatalf("Expected a starter pet owned by alice in her profile, got %v (%v)", pets, err)
+	}
+
+	out.Reset()
+	if err := run(ctx, []string{"profile", "-config", path, "list"}, nil, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "* alice") {
+		t.Errorf("Expected alice listed as selected, got:\n%s", out.String())
+	}
+}
+
+func TestLANSync(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, "saves")+"\n"), 0o644)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	host, _ := data.NewDataManager(t.TempDir())
+	shared := core.NewDigitalPet("Mochi", "owner")
+	host.SavePet(ctx, shared)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go data.NewLANServer(host, "123456").Serve(ctx, listener)
+
+	// The same pet changed differently on both devices conflicts
+	guest, _ := data.NewDataManager(filepath.Join(dir, "saves", "profiles", data.DefaultProfile))
+	shared.Name = "Mochi II"
+	guest.SavePet(ctx, shared)
+
+	var out bytes.Buffer
+	args := []string{"lan", "-config", path, "-code", "123456", "-peer", listener.Addr().String()}
+	if err := run(ctx, append(args, "sync"), nil, &out); !errors.Is(err, data.ErrConflict) {
+		t.Fatalf("Expected a conflict, got %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "1 conflicts") || !strings.Contains(out.String(), "-prefer") {
+		t.Errorf("Expected the conflict reported with advice, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run(ctx, append(args, "-prefer", "local", "sync"), nil, &out); err != nil {
+		t.Fatalf("Expected the conflict settled, got %v\n%s", err, out.String())
+	}
+	if loaded, _ := host.LoadPet(ctx, shared.ID); loaded.Name != "Mochi II" {
+		t.Errorf("Expected the local copy kept on the host, got %s", loaded.Name)
+	}
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, "saves")+"\n"), 0o644)
+	save := filepath.Join(dir, "old.sav")
+	os.WriteFile(save, []byte("name=Mametchi\nage=2\nhunger=4\nhappy=4\n"), 0o644)
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"import", "-config", path, save}, nil, &out); err != nil {
+		t.Fatalf("import failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Imported 1 pets from a classic save") {
+		t.Errorf("Expected the import reported, got:\n%s", out.String())
+	}
+
+	dm, _ := data.NewDataManager(filepath.Join(dir, "saves", "profiles", data.DefaultProfile))
+	pets, err := dm.LoadAll(context.Background())
+	if err != nil || len(pets) != 1 || pets[0].Name != "Mametchi" || pets[0].Owner != data.LegacyOwner {
+		t.Errorf("Expected Mametchi saved in the default profile, got %v (%v)", pets, err)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, "saves")+"\n"), 0o644)
+	dm, _ := data.NewDataManager(filepath.Join(dir, "saves", "profiles", data.DefaultProfile))
+	rex := core.NewDigitalPet("Rex", "owner")
+	if err := dm.SavePet(ctx, rex); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(dir, "rex"+data.PetArchiveExt)
+	var out bytes.Buffer
+	if err := run(ctx, []string{"export", "-config", path, "-compress", "rex", archive}, nil, &out); err != nil {
+		t.Fatalf("export failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Exported Rex to "+archive) {
+		t.Errorf("Expected the export reported, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run(ctx, []string{"import", "-config", path, archive}, nil, &out); !errors.Is(err, data.ErrPetExists) {
+		t.Errorf("Expected the import of a pet already saved refused, got %v\n%s", err, out.String())
+	}
+	if err := run(ctx, []string{"import", "-config", path, "-collision", "keep-both", archive}, nil, &out); err != nil {
+		t.Fatalf("import failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "as "+string(rex.ID)+"_2") {
+		t.Errorf("Expected the copy's new ID reported, got:\n%s", out.String())
+	}
+	if pets, err := dm.LoadAll(ctx); err != nil || len(pets) != 2 {
+		t.Errorf("Expected Rex and the copy saved, got %v (%v)", pets, err)
+	}
+}
+
+func TestLegacy(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, "saves")+"\n"), 0o644)
+	legacy := filepath.Join(dir, data.LegacyPetsDir)
+	os.MkdirAll(legacy, 0o755)
+	pet := core.NewDigitalPet("Oldie", data.LegacyOwner)
+	payload, _ := pet.Save()
+	os.WriteFile(filepath.Join(legacy, string(pet.ID)+".json"), payload, 0o644)
+
+	var out bytes.Buffer
+	if err := run(ctx, []string{"-config", path}, strings.NewReader("quit\n"), &out); err != nil {
+		t.Fatalf("Play failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "gochi legacy import") {
+		t.Errorf("Expected the old saves pointed out, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := run(ctx, []string{"legacy", "-config", path, "import"}, nil, &out); err != nil {
+		t.Fatalf("Import failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "1 pets") || !strings.Contains(out.String(), string(pet.ID)) {
+		t.Errorf("Expected Oldie reported, got:\n%s", out.String())
+	}
+	dm, _ := data.NewDataManager(filepath.Join(dir, "saves", "profiles", data.DefaultProfile))
+	if _, err := dm.LoadPet(ctx, pet.ID); err != nil {
+		t.Errorf("Expected Oldie in the default profile, got %v", err)
+	}
+
+	if err := run(ctx, []string{"legacy", "-config", path, "cleanup"}, strings.NewReader("no\n"), &out); err == nil {
+		t.Error("Expected the clean-up cancelled")
+	}
+	if !data.HasLegacy(data.DiskBackend{}, legacy) {
+		t.Fatal("Expected the originals kept until the clean-up is confirmed")
+	}
+	if err := run(ctx, []string{"legacy", "-config", path, "cleanup"}, strings.NewReader("delete\n"), &out); err != nil {
+		t.Fatalf("Clean-up failed: %v\n%s", err, out.String())
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("Expected the originals deleted, got %v", err)
+	}
+	if err := run(ctx, []string{"legacy", "-config", path, "import"}, nil, &out); err == nil {
+		t.Error("Expected nothing left to import")
+	}
+}
+
+func TestScriptCheck(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.txt")
+	os.WriteFile(good, []byte("when fear > 0.5 then comfort\n"), 0o644)
+	bad := filepath.Join(dir, "bad.txt")
+	os.WriteFile(bad, []byte("when fear > 0.5 then dance\n"), 0o644)
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"script", "check", good}, nil, &out); err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "1 rules OK") {
+		t.Errorf("Expected the rule count, got %q", out.String())
+	}
+	if err := run(context.Background(), []string{"script", "check", bad}, nil, &out); !errors.Is(err, script.ErrInvalidScript) {
+		t.Errorf("Expected ErrInvalidScript, got %v", err)
+	}
+}
+
+func TestServe(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+saves+"\nserver:\n  listen: 127.0.0.1:0\n"), 0o644)
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"serve", "-config", path}, nil, &out); !errors.Is(err, config.ErrInvalidConfig) {
+		t.Fatalf("Expected serving without a token to be refused, got %v", err)
+	}
+
+	t.Setenv("GOCHI_SERVER_ADMIN_TOKEN", "s3cret")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := run(ctx, []string{"serve", "-config", path}, nil, &out); err != nil {
+		t.Fatalf("serve failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "admin API at http://127.0.0.1:") {
+		t.Errorf("Expected the admin address announced, got:\n%s", out.String())
+	}
+	dm, _ := data.NewDataManager(filepath.Join(saves, "profiles", data.DefaultProfile))
+	if ids, _ := dm.ListPets(context.Background(), data.FilterActive); len(ids) != 1 {
+		t.Errorf("Expected a final save after serving, got %v", ids)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	dm, err := data.NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop, err := game.NewGameLoop(config.Default().Simulation, interaction.NewHousehold(core.NewDigitalPet("Mochi", "owner")), dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loop.Shutdown(context.Background())
+	admin := server.NewAdmin(loop, "s3cret", t.TempDir())
+	admin.Keys = []server.APIKey{{Name: "kids", Role: server.RoleCaretaker, Token: "feed-me"}}
+	srv := httptest.NewServer(admin)
+	defer srv.Close()
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"join", srv.URL}, nil, &out); err == nil {
+		t.Error("Expected joining without a key to be refused")
+	}
+	in := strings.NewReader("feed Mochi\nfeed Nobody\nstatus\nquit\n")
+	if err := run(context.Background(), []string{"join", "-token", "feed-me", srv.URL}, in, &out); err != nil {
+		t.Fatalf("join failed: %v\n%s", err, out.String())
+	}
+	for _, want := range []string{"Connected: kids", "Mochi", "Joined the shared session", "Nobody"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, out.String())
+		}
+	}
+	entries, err := dm.Audit.Query(data.AuditQuery{Actor: server.SessionActor + ":kids"})
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected the kids' feeding attributed to them, got %+v (%v)", entries, err)
+	}
+}
+
+func TestDiscordRegister(t *testing.T) {
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+	saved := discordAPI
+	discordAPI = srv.URL
+	defer func() { discordAPI = saved }()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("discord:\n  application_id: \"123\"\n"), 0o644)
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"discord", "register", "-config", path}, nil, &out); !errors.Is(err, config.ErrInvalidConfig) {
+		t.Fatalf("Expected registering without a bot token to be refused, got %v", err)
+	}
+
+	t.Setenv("GOCHI_DISCORD_BOT_TOKEN", "tok")
+	if err := run(context.Background(), []string{"discord", "register", "-config", path}, nil, &out); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	if auth != "Bot tok" || !strings.Contains(out.String(), "/status, /feed, /play") {
+		t.Errorf("Unexpected registration %q:\n%s", auth, out.String())
+	}
+}
+
+func TestServeRefusesUnknownMQTTCommands(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, "saves")+"\nserver:\n  listen: 127.0.0.1:0\n"), 0o644)
+	t.Setenv("GOCHI_SERVER_ADMIN_TOKEN", "s3cret")
+	t.Setenv("GOCHI_MQTT_ENABLED", "true")
+	t.Setenv("GOCHI_MQTT_ALLOW_COMMANDS", "feed,vet")
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"serve", "-config", path}, nil, &out); !errors.Is(err, config.ErrInvalidConfig) || !strings.Contains(err.Error(), "vet") {
+		t.Errorf("Expected the unknown command to be refused, got %v", err)
+	}
+}
+
+func TestServeCalendar(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	ics := filepath.Join(dir, "gochi.ics")
+	os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, "saves")+"\nserver:\n  listen: 127.0.0.1:0\n"+
+		"calendar:\n  enabled: true\n  file: "+ics+"\n  token: cal\n"), 0o644)
+	t.Setenv("GOCHI_SERVER_ADMIN_TOKEN", "s3cret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	if err := run(ctx, []string{"serve", "-config", path}, nil, &out); err != nil {
+		t.Fatalf("serve failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "/calendar.ics?token=") {
+		t.Errorf("Expected the calendar feed announced, got:\n%s", out.String())
+	}
+	written, err := os.ReadFile(ics)
+	if err != nil || !strings.Contains(string(written), "SUMMARY:Gochi turns 1") {
+		t.Errorf("Expected the starter pet's birthday in the calendar file, got %q (%v)", written, err)
+	}
+}
+
+func TestServeRejectsBadAPIKeys(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, "saves")+"\nserver:\n  listen: 127.0.0.1:0\n"), 0o644)
+	t.Setenv("GOCHI_SERVER_ADMIN_TOKEN", "s3cret")
+	t.Setenv("GOCHI_SERVER_API_KEYS", "kids:owner:t0ken")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	if err := run(ctx, []string{"serve", "-config", path}, nil, &out); !errors.Is(err, server.ErrInvalidAPIKey) {
+		t.Errorf("Expected an unknown role refused, got %v", err)
+	}
+}
+
+func TestServeCrowd(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+filepath.Join(dir, "saves")+"\nserver:\n  listen: 127.0.0.1:0\n"+
+		"crowd:\n  enabled: true\n  round: 15\n"), 0o644)
+	t.Setenv("GOCHI_SERVER_ADMIN_TOKEN", "s3cret")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	if err := run(ctx, []string{"serve", "-config", path}, nil, &out); err != nil {
+		t.Fatalf("serve failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Crowd control on: votes close every 15s") {
+		t.Errorf("Expected crowd control announced, got:\n%s", out.String())
+	}
+}
+
+func TestRealWorldClockCatchesUp(t *testing.T) {
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("data:\n  save_path: "+saves+"\n"+
+		"clock:\n  real_world: true\n  time_zone: UTC\n  hemisphere: south\n  catch_up_days: 2\n"), 0o644)
+
+	ctx := context.Background()
+	dm, _ := data.NewDataManager(saves)
+	pet := core.NewDigitalPet("Mochi", "owner")
+	pet.LastUpdateAt = time.Now().Add(-5 * 24 * time.Hour)
+	dm.SavePet(ctx, pet)
+
+	var out bytes.Buffer
+	if err := run(ctx, []string{"-config", path}, strings.NewReader("quit\n"), &out); err != nil {
+		t.Fatalf("play failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "Caught up on 48 hours") {
+		t.Errorf("Expected two days caught up, got:\n%s", out.String())
+	}
+}
+
+func TestKeys(t *testing.T) {
+	ring := useKeyring(t)
+	ctx := context.Background()
+	var out bytes.Buffer
+	if err := run(ctx, []string{"keys", "set", "cloud"}, strings.NewReader("tok3n\n"), &out); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if token, _ := ring.Get(keyring.CloudToken); token != "tok3n" {
+		t.Errorf("Expected the cloud token stored, got %q", token)
+	}
+
+	out.Reset()
+	if err := run(ctx, []string{"keys", "status"}, nil, &out); err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "save  not stored") || !strings.Contains(out.String(), "cloud stored") {
+		t.Errorf("Expected the save key missing and the cloud token stored, got:\n%s", out.String())
+	}
+
+	if err := run(ctx, []string{"keys", "forget", "save"}, nil, &out); !errors.Is(err, keyring.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound forgetting a missing key, got %v", err)
+	}
+	if err := run(ctx, []string{"keys", "forget", "cloud"}, nil, &out); err != nil {
+		t.Errorf("Forget failed: %v", err)
+	}
+	if err := run(ctx, []string{"keys", "set", "wifi"}, nil, &out); err == nil || !strings.Contains(err.Error(), "usage") {
+		t.Errorf("Expected usage for an unknown key, got %v", err)
+	}
+}
+
+func TestEncryptedSaves(t *testing.T) {
+	ring := useKeyring(t)
+	dir := t.TempDir()
+	saves := filepath.Join(dir, "saves")
+	path := filepath.Join(dir, "config.yaml")
+	os.WriteFile(path, []byte("environment:\n  seed: 3\ndata:\n  save_path: "+saves+"\n  encryption_enabled: true\n"), 0o644)
+
+	// The first run asks for the passphrase and keeps it in the keychain
+	savedIn, savedOut := promptIn, promptOut
+	promptIn, promptOut = strings.NewReader("open sesame\n"), io.Discard
+	defer func() { promptIn, promptOut = savedIn, savedOut }()
+
+	ctx := context.Background()
+	var out bytes.Buffer
+	if err := run(ctx, []string{"-config", path}, strings.NewReader("quit\n"), &out); err != nil {
+		t.Fatalf("Play failed: %v\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "not in the keychain yet") {
+		t.Errorf("Expected the missing passphrase noted at startup, got:\n%s", out.String())
+	}
+	if passphrase, _ := ring.Get(keyring.SaveKey); passphrase != "open sesame" {
+		t.Fatalf("Expected the passphrase kept in the keychain, got %q", passphrase)
+	}
+
+	profile := filepath.Join(saves, "profiles", data.DefaultProfile)
+	dm, _ := data.NewDataManager(profile)
+	if _, err := dm.LoadAll(ctx); !errors.Is(err, data.ErrEncryptedSave) {
+		t.Errorf("Expected the starter pet saved encrypted, got %v", err)
+	}
+
+	// Later runs take it from the keychain without asking
+	promptIn = strings.NewReader("")
+	out.Reset()
+	if err := run(ctx, []string{"-config", path}, strings.NewReader("quit\n"), &out); err != nil {
+		t.Fatalf("Play from the keychain failed: %v\n%s", err, out.String())
+	}
+
+	ring.Set(keyring.SaveKey, "wrong")
+	err := run(ctx, []string{"-config", path}, strings.NewReader("quit\n"), &out)
+	if !errors.Is(err, data.ErrWrongKey) || !strings.Contains(err.Error(), "gochi keys set save") {
+		t.Errorf("Expected a wrong keychain passphrase reported, got %v", err)
+	}
+}
+
+func TestCloudTokenFromKeychain(t *testing.T) {
+	cfg := config.Default()
+	if cloudToken(cfg) != "" {
+		t.Error("Expected no token without a keychain")
+	}
+	ring := useKeyring(t)
+	ring.Set(keyring.CloudToken, "from-keychain")
+	if token := cloudToken(cfg); token != "from-keychain" {
+		t.Errorf("Expected the keychain token, got %q", token)
+	}
+	cfg.Cloud.Token = "configured"
+	if token := cloudToken(cfg); token != "configured" {
+		t.Errorf("Expected the configured token to win, got %q", token)
+	}
+
+	var out bytes.Buffer
+	cfg.Cloud.Backups = true
+	checkKeys(cfg, ring, &out)
+	if !strings.Contains(out.String(), "plain text") {
+		t.Errorf("Expected a warning about the plain text token, got %q", out.String())
+	}
+}
@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownEmotion is returned when an emotion name is not registered
+var ErrUnknownEmotion = errors.New("unknown emotion")
+
+// Emotion names an emotion dimension, as used in stimuli, scripts and
+// saves. Content packs can register emotions beyond the built-in ones.
+type Emotion string
+
+// Built-in emotions every pet has
+const (
+	EmotionJoy         Emotion = "joy"
+	EmotionSadness     Emotion = "sadness"
+	EmotionAnger       Emotion = "anger"
+	EmotionFear        Emotion = "fear"
+	EmotionExcitement  Emotion = "excitement"
+	EmotionContentment Emotion = "contentment"
+	EmotionAffection   Emotion = "affection"
+	EmotionLoneliness  Emotion = "loneliness"
+	EmotionJealousy    Emotion = "jealousy"
+)
+
+// String returns the emotion's name
+func (e Emotion) String() string {
+	return string(e)
+}
+
+// BuiltinEmotions returns the emotions every pet has, in registry order
+func BuiltinEmotions() []Emotion {
+	return []Emotion{
+		EmotionJoy, EmotionSadness, EmotionAnger, EmotionFear, EmotionExcitement,
+		EmotionContentment, EmotionAffection, EmotionLoneliness, EmotionJealousy,
+	}
+}
+
+// ParseEmotion looks up an emotion registered in the default registry,
+// ignoring case and surrounding space
+func ParseEmotion(name string) (Emotion, error) {
+	emotion := Emotion(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := DefaultEmotionRegistry.Get(emotion); !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownEmotion, name)
+	}
+	return emotion, nil
+}
@@ -0,0 +1,190 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Emotion registry errors
+var (
+	ErrEmotionExists  = errors.New("emotion is already registered")
+	ErrInvalidEmotion = errors.New("invalid emotion dimension")
+)
+
+// DefaultEmotionDecayRate is how quickly emotions return to baseline per game day
+const DefaultEmotionDecayRate = 0.05
+
+// Valence describes how an emotion contributes to overall mood
+type Valence int
+
+const (
+	ValenceNeutral Valence = iota
+	ValencePositive
+	ValenceNegative
+)
+
+// EmotionDimension describes a single emotion an EmotionState tracks
+type EmotionDimension struct {
+	Name      Emotion // Key used in stimuli and saves, e.g. EmotionJoy
+	Label     string  // Adjective used when dominant, e.g. "joyful"
+	Baseline  float64 // Resting value the emotion decays toward
+	Initial   float64 // Value for a newly created pet
+	DecayRate float64 // Decay toward baseline per game day
+	Valence   Valence
+
+	// Interactions maps user interactions to the change in this emotion
+	// per unit of interaction quality
+	Interactions map[types.InteractionType]float64
+}
+
+// EmotionRegistry holds the set of emotion dimensions known to the engine.
+// Content packs can register additional dimensions at start-up.
+type EmotionRegistry struct {
+	mu sync.RWMutex
+
+	dimensions map[Emotion]*EmotionDimension
+	order      []Emotion
+}
+
+// NewEmotionRegistry creates a registry containing the built-in emotions
+func NewEmotionRegistry() *EmotionRegistry {
+	r := &EmotionRegistry{
+		dimensions: make(map[Emotion]*EmotionDimension),
+	}
+	for _, dim := range builtinEmotions() {
+		if err := r.Register(dim); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// DefaultEmotionRegistry is the registry used by all emotion states
+var DefaultEmotionRegistry = NewEmotionRegistry()
+
+// RegisterEmotion adds a dimension to the default registry
+func RegisterEmotion(dim EmotionDimension) error {
+	return DefaultEmotionRegistry.Register(dim)
+}
+
+// Register adds a new emotion dimension
+func (r *EmotionRegistry) Register(dim EmotionDimension) error {
+	if dim.Name == "" || dim.Label == "" {
+		return fmt.Errorf("%w: name and label are required", ErrInvalidEmotion)
+	}
+	if dim.DecayRate == 0 {
+		dim.DecayRate = DefaultEmotionDecayRate
+	}
+	dim.Baseline = clamp(dim.Baseline, 0.0, 1.0)
+	dim.Initial = clamp(dim.Initial, 0.0, 1.0)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.dimensions[dim.Name]; exists {
+		return fmt.Errorf("%w: %s", ErrEmotionExists, dim.Name)
+	}
+	r.dimensions[dim.Name] = &dim
+	r.order = append(r.order, dim.Name)
+	return nil
+}
+
+// Get returns a registered dimension by name
+func (r *EmotionRegistry) Get(name Emotion) (EmotionDimension, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dim, exists := r.dimensions[name]
+	if !exists {
+		return EmotionDimension{}, false
+	}
+	return *dim, true
+}
+
+// Dimensions returns all registered dimensions in registration order
+func (r *EmotionRegistry) Dimensions() []EmotionDimension {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dims := make([]EmotionDimension, 0, len(r.order))
+	for _, name := range r.order {
+		dims = append(dims, *r.dimensions[name])
+	}
+	return dims
+}
+
+// builtinEmotions returns the emotions every pet has
+func builtinEmotions() []EmotionDimension {
+	return []EmotionDimension{
+		{
+			Name: EmotionJoy, Label: "joyful", Baseline: 0.5, Initial: 0.6, Valence: ValencePositive,
+			Interactions: map[types.InteractionType]float64{
+				types.InteractionPetting:  0.1,
+				types.InteractionPlaying:  0.15,
+				types.InteractionFeeding:  0.1,
+				types.InteractionGrooming: 0.05,
+				types.InteractionRewards:  0.2,
+			},
+		},
+		{
+			Name: EmotionSadness, Label: "sad", Baseline: 0.1, Initial: 0.1, Valence: ValenceNegative,
+			Interactions: map[types.InteractionType]float64{
+				types.InteractionDiscipline: 0.05,
+				types.InteractionComfort:    -0.1,
+			},
+		},
+		{
+			Name: EmotionAnger, Label: "angry", Baseline: 0.0, Initial: 0.1, Valence: ValenceNegative,
+			Interactions: map[types.InteractionType]float64{
+				types.InteractionDiscipline: 0.05,
+			},
+		},
+		{
+			Name: EmotionFear, Label: "fearful", Baseline: 0.1, Initial: 0.1, Valence: ValenceNegative,
+			Interactions: map[types.InteractionType]float64{
+				types.InteractionDiscipline:  0.1,
+				types.InteractionMedicalCare: -0.1,
+				types.InteractionComfort:     -0.25,
+			},
+		},
+		{
+			Name: EmotionExcitement, Label: "excited", Baseline: 0.3, Initial: 0.4, Valence: ValencePositive,
+			Interactions: map[types.InteractionType]float64{
+				types.InteractionPlaying: 0.2,
+				types.InteractionRewards: 0.1,
+			},
+		},
+		{
+			Name: EmotionContentment, Label: "content", Baseline: 0.5, Initial: 0.5, Valence: ValencePositive,
+			Interactions: map[types.InteractionType]float64{
+				types.InteractionPetting:     0.1,
+				types.InteractionFeeding:     0.15,
+				types.InteractionGrooming:    0.1,
+				types.InteractionMedicalCare: 0.05,
+				types.InteractionComfort:     0.1,
+			},
+		},
+		{
+			Name: EmotionAffection, Label: "affectionate", Baseline: 0.4, Initial: 0.5, Valence: ValencePositive,
+			Interactions: map[types.InteractionType]float64{
+				types.InteractionPetting: 0.15,
+				types.InteractionRewards: 0.1,
+			},
+		},
+		{
+			Name: EmotionLoneliness, Label: "lonely", Baseline: 0.2, Initial: 0.2, Valence: ValenceNegative,
+			Interactions: map[types.InteractionType]float64{
+				types.InteractionPetting: -0.1,
+				types.InteractionPlaying: -0.1,
+				types.InteractionComfort: -0.05,
+			},
+		},
+		{
+			// Jealousy is excluded from mood; it is tracked for household reactions
+			Name: EmotionJealousy, Label: "jealous", Baseline: 0.0, Initial: 0.0, Valence: ValenceNeutral,
+		},
+	}
+}
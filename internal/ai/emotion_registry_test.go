@@ -0,0 +1,104 @@
+package ai
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestBuiltinEmotionsMatchFields(t *testing.T) {
+	e := NewEmotionState()
+
+	if e.Get("joy") != e.Joy || e.Joy != 0.6 {
+		t.Errorf("Expected joy 0.6, got %.2f", e.Get("joy"))
+	}
+
+	e.Set("fear", 0.9)
+	if e.Fear != 0.9 {
+		t.Error("Set should update the built-in field")
+	}
+}
+
+func TestRegisterCustomEmotion(t *testing.T) {
+	r := NewEmotionRegistry()
+	err := r.Register(EmotionDimension{
+		Name:     "pride",
+		Label:    "proud",
+		Baseline: 0.2,
+		Valence:  ValencePositive,
+		Interactions: map[types.InteractionType]float64{
+			types.InteractionTraining: 0.3,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	dim, ok := r.Get("pride")
+	if !ok || dim.DecayRate != DefaultEmotionDecayRate {
+		t.Error("Registered emotion should get the default decay rate")
+	}
+
+	if err := r.Register(EmotionDimension{Name: "pride", Label: "proud"}); !errors.Is(err, ErrEmotionExists) {
+		t.Errorf("Expected ErrEmotionExists, got %v", err)
+	}
+	if err := r.Register(EmotionDimension{Name: "nameless"}); !errors.Is(err, ErrInvalidEmotion) {
+		t.Errorf("Expected ErrInvalidEmotion, got %v", err)
+	}
+}
+
+func TestCustomEmotionInDefaultRegistry(t *testing.T) {
+	saved := DefaultEmotionRegistry
+	defer func() { DefaultEmotionRegistry = saved }()
+	DefaultEmotionRegistry = NewEmotionRegistry()
+
+	if err := RegisterEmotion(EmotionDimension{
+		Name:    "boredom",
+		Label:   "bored",
+		Valence: ValenceNegative,
+		Interactions: map[types.InteractionType]float64{
+			types.InteractionPlaying: -0.5,
+		},
+	}); err != nil {
+		t.Fatalf("RegisterEmotion failed: %v", err)
+	}
+
+	e := NewEmotionState()
+	e.ApplyEmotionalStimulus(EmotionalStimulus{Deltas: map[Emotion]float64{"boredom": 1.0}})
+	if e.Get("boredom") != 1.0 || e.DominantEmotion != "bored" {
+		t.Errorf("Expected bored to dominate, got %s (%.2f)", e.DominantEmotion, e.Get("boredom"))
+	}
+
+	e.ApplyEmotionalStimulus(CreateStimulusFromInteraction(types.InteractionPlaying, 1.0))
+	if e.Get("boredom") != 0.5 {
+		t.Errorf("Playing should relieve boredom, got %.2f", e.Get("boredom"))
+	}
+
+	e.Update(1.0)
+	if e.Get("boredom") >= 0.5 {
+		t.Error("Custom emotions should decay toward baseline")
+	}
+}
+
+func TestStimulusFromInteraction(t *testing.T) {
+	stimulus := CreateStimulusFromInteraction(types.InteractionPetting, 1.0)
+
+	if stimulus.JoyDelta != 0.1 || stimulus.AffectionDelta != 0.15 || stimulus.LonelinessDelta != -0.1 {
+		t.Errorf("Unexpected petting stimulus: %+v", stimulus)
+	}
+}
+
+func TestParseEmotion(t *testing.T) {
+	if emotion, err := ParseEmotion(" Joy "); err != nil || emotion != EmotionJoy {
+		t.Errorf("ParseEmotion(Joy) = %q, %v", emotion, err)
+	}
+	if _, err := ParseEmotion("joyy"); !errors.Is(err, ErrUnknownEmotion) {
+		t.Errorf("Expected ErrUnknownEmotion for a typo, got %v", err)
+	}
+	for _, emotion := range BuiltinEmotions() {
+		if _, ok := DefaultEmotionRegistry.Get(emotion); !ok {
+			t.Errorf("Built-in emotion %q is not registered", emotion)
+		}
+	}
+}
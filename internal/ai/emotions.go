@@ -17,6 +17,10 @@ type EmotionState struct {
 	Contentment float64 `json:"contentment"` // Peaceful satisfaction
 	Affection  float64 `json:"affection"`  // Love, attachment
 	Loneliness float64 `json:"loneliness"` // Feeling isolated
+	Jealousy   float64 `json:"jealousy"`   // Resentment at attention given to others
+
+	// Emotions registered by content packs, keyed by dimension name
+	Custom map[string]float64 `json:"custom,omitempty"`
 
 	// Current dominant emotion
 	DominantEmotion string `json:"dominant_emotion"`
@@ -27,33 +31,22 @@ type EmotionState struct {
 
 // NewEmotionState creates a new emotion state with neutral/positive defaults
 func NewEmotionState() *EmotionState {
-	return &EmotionState{
-		Joy:             0.6,
-		Sadness:         0.1,
-		Anger:           0.1,
-		Fear:            0.1,
-		Excitement:      0.4,
-		Contentment:     0.5,
-		Affection:       0.5,
-		Loneliness:      0.2,
-		DominantEmotion: "content",
-		LastUpdate:      time.Now(),
+	e := &EmotionState{
+		LastUpdate: time.Now(),
+	}
+	for _, dim := range DefaultEmotionRegistry.Dimensions() {
+		e.Set(dim.Name, dim.Initial)
 	}
+	e.DominantEmotion = "content"
+	return e
 }
 
 // Update processes emotional changes based on current state and time
 func (e *EmotionState) Update(deltaTime float64) {
-	// Emotions naturally decay toward neutral over time
-	decayRate := 0.05 * deltaTime
-
-	e.Joy = decay(e.Joy, 0.5, decayRate)
-	e.Sadness = decay(e.Sadness, 0.1, decayRate)
-	e.Anger = decay(e.Anger, 0.0, decayRate)
-	e.Fear = decay(e.Fear, 0.1, decayRate)
-	e.Excitement = decay(e.Excitement, 0.3, decayRate)
-	e.Contentment = decay(e.Contentment, 0.5, decayRate)
-	e.Affection = decay(e.Affection, 0.4, decayRate)
-	e.Loneliness = decay(e.Loneliness, 0.2, decayRate)
+	// Emotions naturally decay toward their baseline over time
+	for _, dim := range DefaultEmotionRegistry.Dimensions() {
+		e.Set(dim.Name, decay(e.Get(dim.Name), dim.Baseline, dim.DecayRate*deltaTime))
+	}
 
 	e.Clamp()
 	e.updateDominantEmotion()
@@ -70,11 +63,72 @@ func (e *EmotionState) ApplyEmotionalStimulus(stimulus EmotionalStimulus) {
 	e.Contentment += stimulus.ContentmentDelta
 	e.Affection += stimulus.AffectionDelta
 	e.Loneliness += stimulus.LonelinessDelta
+	e.Jealousy += stimulus.JealousyDelta
+
+	for name, delta := range stimulus.Deltas {
+		e.Set(name, e.Get(name)+delta)
+	}
 
 	e.Clamp()
 	e.updateDominantEmotion()
 }
 
+// field returns the struct field backing a built-in emotion, or nil
+func (e *EmotionState) field(name Emotion) *float64 {
+	switch name {
+	case EmotionJoy:
+		return &e.Joy
+	case EmotionSadness:
+		return &e.Sadness
+	case EmotionAnger:
+		return &e.Anger
+	case EmotionFear:
+		return &e.Fear
+	case EmotionExcitement:
+		return &e.Excitement
+	case EmotionContentment:
+		return &e.Contentment
+	case EmotionAffection:
+		return &e.Affection
+	case EmotionLoneliness:
+		return &e.Loneliness
+	case EmotionJealousy:
+		return &e.Jealousy
+	default:
+		return nil
+	}
+}
+
+// Get returns the value of an emotion by dimension name
+func (e *EmotionState) Get(name Emotion) float64 {
+	if f := e.field(name); f != nil {
+		return *f
+	}
+	return e.Custom[string(name)]
+}
+
+// Set changes the value of an emotion by dimension name
+func (e *EmotionState) Set(name Emotion, value float64) {
+	if f := e.field(name); f != nil {
+		*f = value
+		return
+	}
+	if e.Custom == nil {
+		e.Custom = make(map[string]float64)
+	}
+	e.Custom[string(name)] = value
+}
+
+// DominantIntensity returns the strength of the dominant emotion
+func (e *EmotionState) DominantIntensity() float64 {
+	for _, dim := range DefaultEmotionRegistry.Dimensions() {
+		if dim.Label == e.DominantEmotion {
+			return e.Get(dim.Name)
+		}
+	}
+	return 0.0
+}
+
 // EmotionalStimulus represents a change in emotional state
 type EmotionalStimulus struct {
 	JoyDelta         float64
@@ -85,13 +139,62 @@ type EmotionalStimulus struct {
 	ContentmentDelta float64
 	AffectionDelta   float64
 	LonelinessDelta  float64
-	Source           string // What caused this stimulus
+	JealousyDelta    float64
+	Deltas           map[Emotion]float64 // Changes to registered custom emotions
+	Source           string             // What caused this stimulus
+}
+
+// Add adds a change to an emotion by dimension name
+func (s *EmotionalStimulus) Add(name Emotion, delta float64) {
+	switch name {
+	case EmotionJoy:
+		s.JoyDelta += delta
+	case EmotionSadness:
+		s.SadnessDelta += delta
+	case EmotionAnger:
+		s.AngerDelta += delta
+	case EmotionFear:
+		s.FearDelta += delta
+	case EmotionExcitement:
+		s.ExcitementDelta += delta
+	case EmotionContentment:
+		s.ContentmentDelta += delta
+	case EmotionAffection:
+		s.AffectionDelta += delta
+	case EmotionLoneliness:
+		s.LonelinessDelta += delta
+	case EmotionJealousy:
+		s.JealousyDelta += delta
+	default:
+		if s.Deltas == nil {
+			s.Deltas = make(map[Emotion]float64)
+		}
+		s.Deltas[name] += delta
+	}
 }
 
 // GetMoodScore returns an overall mood rating (-1.0 to 1.0)
 func (e *EmotionState) GetMoodScore() float64 {
-	positive := (e.Joy + e.Excitement + e.Contentment + e.Affection) / 4.0
-	negative := (e.Sadness + e.Anger + e.Fear + e.Loneliness) / 4.0
+	positive, negative := 0.0, 0.0
+	positiveCount, negativeCount := 0, 0
+
+	for _, dim := range DefaultEmotionRegistry.Dimensions() {
+		switch dim.Valence {
+		case ValencePositive:
+			positive += e.Get(dim.Name)
+			positiveCount++
+		case ValenceNegative:
+			negative += e.Get(dim.Name)
+			negativeCount++
+		}
+	}
+
+	if positiveCount > 0 {
+		positive /= float64(positiveCount)
+	}
+	if negativeCount > 0 {
+		negative /= float64(negativeCount)
+	}
 	return positive - negative
 }
 
@@ -117,24 +220,13 @@ func (e *EmotionState) GetMoodDescription() string {
 
 // updateDominantEmotion determines which emotion is strongest
 func (e *EmotionState) updateDominantEmotion() {
-	emotions := map[string]float64{
-		"joyful":    e.Joy,
-		"sad":       e.Sadness,
-		"angry":     e.Anger,
-		"fearful":   e.Fear,
-		"excited":   e.Excitement,
-		"content":   e.Contentment,
-		"affectionate": e.Affection,
-		"lonely":    e.Loneliness,
-	}
-
 	maxEmotion := "content"
 	maxValue := 0.0
 
-	for emotion, value := range emotions {
-		if value > maxValue {
+	for _, dim := range DefaultEmotionRegistry.Dimensions() {
+		if value := e.Get(dim.Name); value > maxValue {
 			maxValue = value
-			maxEmotion = emotion
+			maxEmotion = dim.Label
 		}
 	}
 
@@ -143,14 +235,9 @@ func (e *EmotionState) updateDominantEmotion() {
 
 // Clamp ensures all emotions stay within valid range
 func (e *EmotionState) Clamp() {
-	e.Joy = clamp(e.Joy, 0.0, 1.0)
-	e.Sadness = clamp(e.Sadness, 0.0, 1.0)
-	e.Anger = clamp(e.Anger, 0.0, 1.0)
-	e.Fear = clamp(e.Fear, 0.0, 1.0)
-	e.Excitement = clamp(e.Excitement, 0.0, 1.0)
-	e.Contentment = clamp(e.Contentment, 0.0, 1.0)
-	e.Affection = clamp(e.Affection, 0.0, 1.0)
-	e.Loneliness = clamp(e.Loneliness, 0.0, 1.0)
+	for _, dim := range DefaultEmotionRegistry.Dimensions() {
+		e.Set(dim.Name, clamp(e.Get(dim.Name), 0.0, 1.0))
+	}
 }
 
 // GetBehaviorInfluence returns how emotions affect behavior choices
@@ -174,44 +261,16 @@ func (e *EmotionState) GetBehaviorInfluence(behavior types.BehaviorState) float6
 }
 
 // CreateStimulusFromInteraction creates an emotional response to user interaction
+// using the interaction mappings of every registered emotion
 func CreateStimulusFromInteraction(interactionType types.InteractionType, quality float64) EmotionalStimulus {
 	stimulus := EmotionalStimulus{
 		Source: interactionType.String(),
 	}
 
-	switch interactionType {
-	case types.InteractionPetting:
-		stimulus.JoyDelta = 0.1 * quality
-		stimulus.AffectionDelta = 0.15 * quality
-		stimulus.ContentmentDelta = 0.1 * quality
-		stimulus.LonelinessDelta = -0.1 * quality
-
-	case types.InteractionPlaying:
-		stimulus.JoyDelta = 0.15 * quality
-		stimulus.ExcitementDelta = 0.2 * quality
-		stimulus.LonelinessDelta = -0.1 * quality
-
-	case types.InteractionFeeding:
-		stimulus.ContentmentDelta = 0.15 * quality
-		stimulus.JoyDelta = 0.1 * quality
-
-	case types.InteractionGrooming:
-		stimulus.ContentmentDelta = 0.1 * quality
-		stimulus.JoyDelta = 0.05 * quality
-
-	case types.InteractionDiscipline:
-		stimulus.FearDelta = 0.1 * quality
-		stimulus.SadnessDelta = 0.05 * quality
-		stimulus.AngerDelta = 0.05 * quality
-
-	case types.InteractionRewards:
-		stimulus.JoyDelta = 0.2 * quality
-		stimulus.ExcitementDelta = 0.1 * quality
-		stimulus.AffectionDelta = 0.1 * quality
-
-	case types.InteractionMedicalCare:
-		stimulus.FearDelta = -0.1 * quality
-		stimulus.ContentmentDelta = 0.05 * quality
+	for _, dim := range DefaultEmotionRegistry.Dimensions() {
+		if delta, ok := dim.Interactions[interactionType]; ok {
+			stimulus.Add(dim.Name, delta*quality)
+		}
 	}
 
 	return stimulus
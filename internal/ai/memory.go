@@ -1,6 +1,8 @@
 package ai
 
 import (
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Michael-W-Ellison/gochi/pkg/types"
@@ -23,21 +25,24 @@ type Memory struct {
 	Type        MemoryType
 	Description string
 	Timestamp   time.Time
-	GameTime    float64 // Game time when memory was formed
-	Strength    float64 // How strong/important the memory is (0.0 to 1.0)
-	Emotion     string  // Associated emotional state
+	GameTime    float64  // Game time when memory was formed
+	Strength    float64  // How strong/important the memory is (0.0 to 1.0)
+	Valence     float64  // How pleasant the memory is (-1.0 to 1.0)
+	Emotion     string   // Associated emotional state
+	Tags        []string // Semantic tags such as "storm", "vet", or "friend:<petID>"
 	Details     map[string]interface{}
 	IsLongTerm  bool // Whether this has been consolidated to long-term memory
 }
 
 // MemorySystem manages both short-term and long-term memories
 type MemorySystem struct {
-	ShortTermMemories []*Memory          // Recent memories (last ~100)
-	LongTermMemories  []*Memory          // Important/consolidated memories
-	MemoryCapacity    int                // Max short-term memories
-	ConsolidationRate float64            // How often memories are consolidated
-	TotalMemories     int                // Total memories ever formed
-	MemoryIndex       map[string]*Memory // Quick lookup by ID
+	ShortTermMemories []*Memory                     // Recent memories (last ~100)
+	LongTermMemories  []*Memory                     // Important/consolidated memories
+	MemoryCapacity    int                           // Max short-term memories
+	ConsolidationRate float64                       // How often memories are consolidated
+	TotalMemories     int                           // Total memories ever formed
+	MemoryIndex       map[string]*Memory            // Quick lookup by ID
+	Summaries         map[MemoryType]*MemorySummary // Forgotten memories as aggregate counts
 }
 
 // NewMemorySystem creates a new memory system
@@ -53,17 +58,24 @@ func NewMemorySystem(capacity int) *MemorySystem {
 
 // RecordMemory adds a new memory to short-term storage
 func (m *MemorySystem) RecordMemory(memType MemoryType, description string, gameTime float64, strength float64, emotion string, details map[string]interface{}) {
-	memory := &Memory{
-		ID:          generateMemoryID(m.TotalMemories),
+	m.Remember(&Memory{
 		Type:        memType,
 		Description: description,
-		Timestamp:   time.Now(),
 		GameTime:    gameTime,
-		Strength:    clamp(strength, 0.0, 1.0),
+		Strength:    strength,
 		Emotion:     emotion,
 		Details:     details,
-		IsLongTerm:  false,
-	}
+	})
+}
+
+// Remember stores a fully described memory in short-term storage.
+// The ID and timestamp are assigned automatically.
+func (m *MemorySystem) Remember(memory *Memory) {
+	memory.ID = generateMemoryID(m.TotalMemories)
+	memory.Timestamp = time.Now()
+	memory.Strength = clamp(memory.Strength, 0.0, 1.0)
+	memory.Valence = clamp(memory.Valence, -1.0, 1.0)
+	memory.IsLongTerm = false
 
 	m.ShortTermMemories = append(m.ShortTermMemories, memory)
 	m.MemoryIndex[memory.ID] = memory
@@ -85,7 +97,74 @@ func (m *MemorySystem) RecordInteraction(interactionType types.InteractionType,
 	// Interactions are more memorable if they're very positive or very negative
 	strength := 0.3 + (quality * 0.7)
 
-	m.RecordMemory(MemoryInteraction, "User interaction: "+interactionType.String(), gameTime, strength, emotion, details)
+	m.Remember(&Memory{
+		Type:        MemoryInteraction,
+		Description: "User interaction: " + interactionType.String(),
+		GameTime:    gameTime,
+		Strength:    strength,
+		Valence:     InteractionValence(interactionType) * quality,
+		Emotion:     emotion,
+		Tags:        []string{TagUser, InteractionTag(interactionType)},
+		Details:     details,
+	})
+}
+
+// Common memory tags
+const (
+	TagUser       = "user"
+	TagVet        = "vet"
+	TagFood       = "food"
+	TagPlay       = "play"
+	TagTraining   = "training"
+	TagDiscipline = "discipline"
+	TagGrooming   = "grooming"
+	TagAffection  = "affection"
+)
+
+// PetTag returns the tag used for memories involving another pet
+func PetTag(petID types.PetID) string {
+	return "friend:" + string(petID)
+}
+
+// SkillTag returns the tag used for memories about a skill
+func SkillTag(skill SkillType) string {
+	return "skill:" + strings.ToLower(skill.String())
+}
+
+// InteractionTag returns the semantic tag for a user interaction
+func InteractionTag(interactionType types.InteractionType) string {
+	switch interactionType {
+	case types.InteractionFeeding:
+		return TagFood
+	case types.InteractionPlaying, types.InteractionEnvironmentalEnrichment:
+		return TagPlay
+	case types.InteractionTraining:
+		return TagTraining
+	case types.InteractionMedicalCare, types.InteractionGeneticScreening:
+		return TagVet
+	case types.InteractionDiscipline:
+		return TagDiscipline
+	case types.InteractionGrooming:
+		return TagGrooming
+	default:
+		return TagAffection
+	}
+}
+
+// InteractionValence returns how pleasant an interaction is for a pet
+func InteractionValence(interactionType types.InteractionType) float64 {
+	switch interactionType {
+	case types.InteractionDiscipline:
+		return -0.6
+	case types.InteractionMedicalCare, types.InteractionGeneticScreening:
+		return -0.2
+	case types.InteractionTraining, types.InteractionSocialIntroduction:
+		return 0.3
+	case types.InteractionRewards, types.InteractionPlaying:
+		return 0.8
+	default:
+		return 0.5
+	}
 }
 
 // ConsolidateMemories moves important short-term memories to long-term storage
@@ -141,9 +220,9 @@ func (m *MemorySystem) pruneWeakMemories() {
 		}
 	}
 
-	// Remove weakest memory
+	// Remove weakest memory, keeping it only as a count
 	removed := m.ShortTermMemories[weakestIdx]
-	delete(m.MemoryIndex, removed.ID)
+	m.summarize(removed)
 	m.ShortTermMemories = append(m.ShortTermMemories[:weakestIdx], m.ShortTermMemories[weakestIdx+1:]...)
 }
 
@@ -247,3 +326,85 @@ func (m *MemorySystem) HasMemoryOf(memType MemoryType) bool {
 func generateMemoryID(count int) string {
 	return time.Now().Format("20060102150405") + string(rune(count))
 }
+
+// allMemories returns short- and long-term memories together
+func (m *MemorySystem) allMemories() []*Memory {
+	all := make([]*Memory, 0, len(m.ShortTermMemories)+len(m.LongTermMemories))
+	all = append(all, m.LongTermMemories...)
+	return append(all, m.ShortTermMemories...)
+}
+
+// sortByStrength orders memories strongest first
+func sortByStrength(memories []*Memory) []*Memory {
+	sort.SliceStable(memories, func(i, j int) bool {
+		return memories[i].Strength > memories[j].Strength
+	})
+	return memories
+}
+
+// HasTag returns true if the memory carries the given tag
+func (mem *Memory) HasTag(tag string) bool {
+	for _, t := range mem.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ByTag returns memories carrying a tag, strongest first
+func (m *MemorySystem) ByTag(tag string) []*Memory {
+	var matches []*Memory
+	for _, memory := range m.allMemories() {
+		if memory.HasTag(tag) {
+			matches = append(matches, memory)
+		}
+	}
+	return sortByStrength(matches)
+}
+
+// ByValence returns memories whose valence lies within [min, max], strongest first
+func (m *MemorySystem) ByValence(min, max float64) []*Memory {
+	var matches []*Memory
+	for _, memory := range m.allMemories() {
+		if memory.Valence >= min && memory.Valence <= max {
+			matches = append(matches, memory)
+		}
+	}
+	return sortByStrength(matches)
+}
+
+// Strongest returns the N strongest memories
+func (m *MemorySystem) Strongest(count int) []*Memory {
+	memories := sortByStrength(m.allMemories())
+	if count < len(memories) {
+		memories = memories[:count]
+	}
+	return memories
+}
+
+// RecentAversive returns the most recent of the last `within` short-term
+// memories with valence below threshold, or nil if there is none
+func (m *MemorySystem) RecentAversive(within int, threshold float64) *Memory {
+	for i := len(m.ShortTermMemories) - 1; i >= 0 && i >= len(m.ShortTermMemories)-within; i-- {
+		if memory := m.ShortTermMemories[i]; memory.Valence < threshold {
+			return memory
+		}
+	}
+	return nil
+}
+
+// TagValence returns how the pet feels about a tag overall: the average
+// valence of matching memories weighted by strength. Returns 0 if the pet
+// has no memories with the tag.
+func (m *MemorySystem) TagValence(tag string) float64 {
+	total, weight := 0.0, 0.0
+	for _, memory := range m.ByTag(tag) {
+		total += memory.Valence * memory.Strength
+		weight += memory.Strength
+	}
+	if weight == 0 {
+		return 0.0
+	}
+	return total / weight
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestRecordInteractionTags(t *testing.T) {
+	m := NewMemorySystem(10)
+	m.RecordInteraction(types.InteractionMedicalCare, 1.0, 0.5, "fearful")
+
+	vet := m.ByTag(TagVet)
+	if len(vet) != 1 {
+		t.Fatalf("Expected one vet memory, got %d", len(vet))
+	}
+	if vet[0].Valence >= 0 {
+		t.Error("Vet visits should be remembered as unpleasant")
+	}
+	if !vet[0].HasTag(TagUser) {
+		t.Error("User interactions should be tagged with the user")
+	}
+}
+
+func TestMemoryQueries(t *testing.T) {
+	m := NewMemorySystem(10)
+	m.Remember(&Memory{Description: "storm", Strength: 0.9, Valence: -0.8, Tags: []string{"storm"}})
+	m.Remember(&Memory{Description: "park", Strength: 0.5, Valence: 0.7, Tags: []string{"play", PetTag("pet_1")}})
+	m.Remember(&Memory{Description: "nap", Strength: 0.2, Valence: 0.1})
+
+	if got := m.ByValence(-1.0, -0.5); len(got) != 1 || got[0].Description != "storm" {
+		t.Errorf("Expected storm as the only bad memory, got %v", got)
+	}
+
+	if got := m.ByTag(PetTag("pet_1")); len(got) != 1 || got[0].Description != "park" {
+		t.Errorf("Expected park memory with friend tag, got %v", got)
+	}
+
+	strongest := m.Strongest(2)
+	if len(strongest) != 2 || strongest[0].Description != "storm" || strongest[1].Description != "park" {
+		t.Errorf("Expected storm then park, got %v", strongest)
+	}
+}
+
+func TestTagValence(t *testing.T) {
+	m := NewMemorySystem(10)
+	if m.TagValence("storm") != 0 {
+		t.Error("Unknown tags should be neutral")
+	}
+
+	m.Remember(&Memory{Strength: 0.9, Valence: -1.0, Tags: []string{"storm"}})
+	m.Remember(&Memory{Strength: 0.1, Valence: 1.0, Tags: []string{"storm"}})
+
+	if v := m.TagValence("storm"); v >= -0.5 {
+		t.Errorf("Strong bad memories should dominate, got %.2f", v)
+	}
+}
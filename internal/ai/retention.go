@@ -0,0 +1,106 @@
+package ai
+
+// Memory retention settings
+const (
+	DefaultMaxLongTermMemories = 200  // Long-term memories kept in detail
+	FadedMemoryStrength        = 0.05 // Long-term memories weaker than this are summarized
+)
+
+// MemorySummary aggregates memories of one type that are no longer kept in
+// detail, so a pet's history survives as counts instead of raw entries
+type MemorySummary struct {
+	Type          MemoryType
+	Count         int
+	TotalValence  float64
+	FirstGameTime float64
+	LastGameTime  float64
+}
+
+// AverageValence returns how pleasant the summarized memories were on average
+func (s *MemorySummary) AverageValence() float64 {
+	if s == nil || s.Count == 0 {
+		return 0.0
+	}
+	return s.TotalValence / float64(s.Count)
+}
+
+// summarize folds a memory into its type's summary and forgets the details
+func (m *MemorySystem) summarize(memory *Memory) {
+	if m.Summaries == nil {
+		m.Summaries = make(map[MemoryType]*MemorySummary)
+	}
+	summary, exists := m.Summaries[memory.Type]
+	if !exists {
+		summary = &MemorySummary{Type: memory.Type, FirstGameTime: memory.GameTime, LastGameTime: memory.GameTime}
+		m.Summaries[memory.Type] = summary
+	}
+
+	summary.Count++
+	summary.TotalValence += memory.Valence
+	if memory.GameTime < summary.FirstGameTime {
+		summary.FirstGameTime = memory.GameTime
+	}
+	if memory.GameTime > summary.LastGameTime {
+		summary.LastGameTime = memory.GameTime
+	}
+	delete(m.MemoryIndex, memory.ID)
+}
+
+// Compact summarizes long-term memories that have faded and, beyond
+// maxLongTerm, the weakest of the rest. Returns how many were summarized.
+func (m *MemorySystem) Compact(maxLongTerm int) int {
+	kept := m.LongTermMemories[:0]
+	summarized := 0
+	for _, memory := range m.LongTermMemories {
+		if memory.Strength < FadedMemoryStrength {
+			m.summarize(memory)
+			summarized++
+			continue
+		}
+		kept = append(kept, memory)
+	}
+
+	if excess := len(kept) - maxLongTerm; excess > 0 {
+		weakest := make(map[*Memory]bool, excess)
+		ranked := sortByStrength(append([]*Memory(nil), kept...))
+		for _, memory := range ranked[len(ranked)-excess:] {
+			weakest[memory] = true
+		}
+
+		// Keep the survivors in the order they were formed
+		survivors := kept[:0]
+		for _, memory := range kept {
+			if weakest[memory] {
+				m.summarize(memory)
+				continue
+			}
+			survivors = append(survivors, memory)
+		}
+		kept = survivors
+		summarized += excess
+	}
+
+	// Copy so the dropped memories can be garbage collected
+	m.LongTermMemories = append(make([]*Memory, 0, len(kept)), kept...)
+	return summarized
+}
+
+// SummarizedCount returns how many memories are only kept as summaries
+func (m *MemorySystem) SummarizedCount() int {
+	total := 0
+	for _, summary := range m.Summaries {
+		total += summary.Count
+	}
+	return total
+}
+
+// TrimHistory keeps only the most recent max trait snapshots. Returns how
+// many snapshots were dropped.
+func (p *PersonalityMatrix) TrimHistory(max int) int {
+	excess := len(p.TraitHistory) - max
+	if excess <= 0 {
+		return 0
+	}
+	p.TraitHistory = append(make([]TraitSnapshot, 0, max), p.TraitHistory[excess:]...)
+	return excess
+}
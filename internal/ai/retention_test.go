@@ -0,0 +1,59 @@
+package ai
+
+import "testing"
+
+func TestMemoryCompactSummarizes(t *testing.T) {
+	m := NewMemorySystem(10)
+	for i := 0; i < 8; i++ {
+		m.Remember(&Memory{Type: MemoryEvent, GameTime: float64(i), Strength: 0.7 + float64(i)*0.01, Valence: 0.5})
+	}
+	m.Remember(&Memory{Type: MemoryInteraction, GameTime: 9, Strength: 0.9, Valence: -1})
+	m.ConsolidateMemories()
+	for _, memory := range m.LongTermMemories {
+		if memory.GameTime == 0 {
+			memory.Strength = 0.01 // Faded
+		}
+	}
+
+	summarized := m.Compact(5)
+	if summarized != 4 || len(m.LongTermMemories) != 5 {
+		t.Fatalf("Expected 4 summarized and 5 kept, got %d and %d", summarized, len(m.LongTermMemories))
+	}
+	if len(m.MemoryIndex) != 5 {
+		t.Errorf("Summarized memories should leave the index, got %d entries", len(m.MemoryIndex))
+	}
+	if m.Summaries[MemoryInteraction] != nil {
+		t.Error("The strongest memory should be kept")
+	}
+
+	events := m.Summaries[MemoryEvent]
+	if events == nil || events.Count != 4 || events.AverageValence() != 0.5 {
+		t.Errorf("Expected 4 summarized events averaging 0.5, got %+v", events)
+	}
+	if m.SummarizedCount() != 4 {
+		t.Errorf("Expected 4 summarized memories, got %d", m.SummarizedCount())
+	}
+}
+
+func TestPrunedMemoriesAreCounted(t *testing.T) {
+	m := NewMemorySystem(2)
+	for i := 0; i < 5; i++ {
+		m.Remember(&Memory{Type: MemorySocial, Strength: 0.1})
+	}
+	if len(m.ShortTermMemories) != 2 || m.SummarizedCount() != 3 {
+		t.Errorf("Expected 2 kept and 3 summarized, got %d and %d", len(m.ShortTermMemories), m.SummarizedCount())
+	}
+}
+
+func TestTrimHistory(t *testing.T) {
+	p := NewPersonalityMatrix()
+	for i := 0; i < 10; i++ {
+		p.TakeSnapshot(float64(i))
+	}
+	if dropped := p.TrimHistory(4); dropped != 6 || len(p.TraitHistory) != 4 {
+		t.Errorf("Expected 6 dropped and 4 kept, got %d and %d", dropped, len(p.TraitHistory))
+	}
+	if p.TraitHistory[0].Timestamp != 6 {
+		t.Errorf("Expected the most recent snapshots kept, oldest is %.0f", p.TraitHistory[0].Timestamp)
+	}
+}
@@ -0,0 +1,74 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownSkill is returned when a skill name is not recognised
+var ErrUnknownSkill = errors.New("unknown skill")
+
+// SkillType identifies a learnable skill
+type SkillType int
+
+const (
+	SkillAgility SkillType = iota
+	SkillObedience
+	SkillForaging
+	SkillSocial
+	SkillProblemSolving
+)
+
+// String returns the string representation of SkillType
+func (st SkillType) String() string {
+	return [...]string{
+		"Agility", "Obedience", "Foraging", "Social", "Problem Solving",
+	}[st]
+}
+
+// AllSkills returns every skill type
+func AllSkills() []SkillType {
+	return []SkillType{SkillAgility, SkillObedience, SkillForaging, SkillSocial, SkillProblemSolving}
+}
+
+// ParseSkill looks up a skill by name, ignoring case, spaces, dashes and
+// underscores, so "problem-solving" matches Problem Solving
+func ParseSkill(name string) (SkillType, error) {
+	normalize := strings.NewReplacer(" ", "", "-", "", "_", "")
+	for _, skill := range AllSkills() {
+		if strings.EqualFold(normalize.Replace(skill.String()), normalize.Replace(name)) {
+			return skill, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownSkill, name)
+}
+
+// SkillSet tracks a pet's proficiency in each skill
+type SkillSet struct {
+	Levels map[SkillType]float64 `json:"levels"` // Proficiency per skill (0.0 to 1.0)
+}
+
+// NewSkillSet creates a skill set with no training
+func NewSkillSet() *SkillSet {
+	return &SkillSet{
+		Levels: make(map[SkillType]float64),
+	}
+}
+
+// Level returns the proficiency in a skill
+func (s *SkillSet) Level(skill SkillType) float64 {
+	return s.Levels[skill]
+}
+
+// AddExperience improves a skill. Gains diminish as the skill approaches
+// mastery. Returns the actual increase in level.
+func (s *SkillSet) AddExperience(skill SkillType, experience float64) float64 {
+	if experience <= 0 {
+		return 0.0
+	}
+
+	before := s.Levels[skill]
+	s.Levels[skill] = clamp(before+experience*(1.0-before), 0.0, 1.0)
+	return s.Levels[skill] - before
+}
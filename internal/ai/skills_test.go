@@ -0,0 +1,46 @@
+package ai
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSkillExperienceDiminishes(t *testing.T) {
+	skills := NewSkillSet()
+
+	first := skills.AddExperience(SkillAgility, 0.5)
+	second := skills.AddExperience(SkillAgility, 0.5)
+
+	if first != 0.5 {
+		t.Errorf("Expected first gain of 0.5, got %.2f", first)
+	}
+	if second >= first {
+		t.Error("Gains should diminish as a skill approaches mastery")
+	}
+	if skills.Level(SkillAgility) > 1.0 {
+		t.Error("Skill level should not exceed 1.0")
+	}
+}
+
+func TestSkillIgnoresNegativeExperience(t *testing.T) {
+	skills := NewSkillSet()
+	skills.AddExperience(SkillForaging, 0.4)
+
+	if gain := skills.AddExperience(SkillForaging, -0.3); gain != 0 {
+		t.Errorf("Negative experience should not change skill, got %.2f", gain)
+	}
+	if skills.Level(SkillObedience) != 0 {
+		t.Error("Untrained skills should start at zero")
+	}
+}
+
+func TestParseSkill(t *testing.T) {
+	for _, name := range []string{"Problem Solving", "problem-solving", "PROBLEM_SOLVING", "problemsolving"} {
+		if skill, err := ParseSkill(name); err != nil || skill != SkillProblemSolving {
+			t.Errorf("Expected %q to parse as Problem Solving, got %v (%v)", name, skill, err)
+		}
+	}
+	if _, err := ParseSkill("juggling"); !errors.Is(err, ErrUnknownSkill) {
+		t.Errorf("Expected ErrUnknownSkill, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package ai
+
+// Synergy settings
+const (
+	SynergyLevel           = 0.6 // Level both skills of a synergy must reach
+	AdvancedAgilityBonus   = 1.5 // Experience multiplier on agility training with SynergyAdvancedAgility
+	OffLeashDangerFraction = 0.5 // Share of a destination's danger felt with SynergyOffLeash
+)
+
+// Synergy is a bonus a pet earns by training two skills together
+type Synergy int
+
+const (
+	SynergyAdvancedAgility Synergy = iota // A focused, agile pet takes to harder agility courses
+	SynergyOffLeash                       // An obedient forager can explore off the leash
+)
+
+// String returns the string representation of Synergy
+func (s Synergy) String() string {
+	return [...]string{"advanced agility", "off-leash exploration"}[s]
+}
+
+// SynergyRule is an entry in the synergy table: the skills that earn a
+// synergy and what it does
+type SynergyRule struct {
+	Synergy Synergy
+	Skills  [2]SkillType // Both must reach SynergyLevel
+	Effect  string       // What the synergy does, for players planning builds
+}
+
+// SynergyTable lists every synergy. Problem Solving stands in for focus
+// and Foraging for finding the way back when called.
+var SynergyTable = []SynergyRule{
+	{
+		Synergy: SynergyAdvancedAgility,
+		Skills:  [2]SkillType{SkillProblemSolving, SkillAgility},
+		Effect:  "agility training gains 50% more experience",
+	},
+	{
+		Synergy: SynergyOffLeash,
+		Skills:  [2]SkillType{SkillObedience, SkillForaging},
+		Effect:  "journeys feel half as dangerous",
+	},
+}
+
+// Has returns true if the skills have earned a synergy
+func (s *SkillSet) Has(synergy Synergy) bool {
+	for _, rule := range SynergyTable {
+		if rule.Synergy == synergy {
+			return s.Level(rule.Skills[0]) >= SynergyLevel && s.Level(rule.Skills[1]) >= SynergyLevel
+		}
+	}
+	return false
+}
+
+// Synergies returns the synergies the skills have earned, in table order
+func (s *SkillSet) Synergies() []Synergy {
+	var earned []Synergy
+	for _, rule := range SynergyTable {
+		if s.Has(rule.Synergy) {
+			earned = append(earned, rule.Synergy)
+		}
+	}
+	return earned
+}
+
+// Train improves a skill through training, with any synergy bonus.
+// Returns the actual increase in level.
+func (s *SkillSet) Train(skill SkillType, experience float64) float64 {
+	if skill == SkillAgility && s.Has(SynergyAdvancedAgility) {
+		experience *= AdvancedAgilityBonus
+	}
+	return s.AddExperience(skill, experience)
+}
+
+// DangerFactor returns the share of a destination's danger a pet feels
+// while exploring
+func (s *SkillSet) DangerFactor() float64 {
+	if s.Has(SynergyOffLeash) {
+		return OffLeashDangerFraction
+	}
+	return 1.0
+}
@@ -0,0 +1,37 @@
+package ai
+
+import "testing"
+
+func TestSynergies(t *testing.T) {
+	skills := NewSkillSet()
+	if len(skills.Synergies()) != 0 || skills.DangerFactor() != 1.0 {
+		t.Fatalf("Expected no synergies for an untrained pet, got %v", skills.Synergies())
+	}
+
+	skills.Levels[SkillProblemSolving] = SynergyLevel
+	skills.Levels[SkillAgility] = SynergyLevel - 0.01
+	if skills.Has(SynergyAdvancedAgility) {
+		t.Error("Expected both skills needed at the synergy level")
+	}
+
+	skills.Levels[SkillAgility] = SynergyLevel
+	before := skills.Level(SkillAgility)
+	advanced := skills.Train(SkillAgility, 0.1)
+	if want := 0.1 * AdvancedAgilityBonus * (1 - before); advanced < want-1e-9 || advanced > want+1e-9 {
+		t.Errorf("Expected advanced agility gain %.4f, got %.4f", want, advanced)
+	}
+	if gain := skills.Train(SkillSocial, 0.1); gain != 0.1 {
+		t.Errorf("Expected other skills trained without a bonus, got %.4f", gain)
+	}
+
+	skills.Levels[SkillObedience] = 0.9
+	skills.Levels[SkillForaging] = 0.7
+	if got := skills.Synergies(); len(got) != 2 || got[1] != SynergyOffLeash || skills.DangerFactor() != OffLeashDangerFraction {
+		t.Errorf("Expected both synergies, got %v", got)
+	}
+	for _, rule := range SynergyTable {
+		if rule.Synergy.String() == "" || rule.Effect == "" || rule.Skills[0] == rule.Skills[1] {
+			t.Errorf("Expected a complete synergy rule, got %+v", rule)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package biology
+
+import "github.com/Michael-W-Ellison/gochi/pkg/types"
+
+// Coat settings
+const (
+	LongCoatThreshold    = 0.6  // Coat length above which a pet grows a winter coat
+	WinterCoatGrowth     = 0.15 // Winter coat thickness gained per game day
+	SheddingRate         = 0.2  // Winter coat thickness shed per game day
+	SheddingThreshold    = 0.05 // Loose fur above which the pet is visibly shedding
+	BaseCleanlinessDecay = 0.01 // Cleanliness lost per game day with a short coat
+)
+
+// CoatStage represents the seasonal state of a pet's coat
+type CoatStage int
+
+const (
+	CoatSummer CoatStage = iota
+	CoatGrowing
+	CoatWinter
+	CoatShedding
+)
+
+// String returns the string representation of CoatStage
+func (cs CoatStage) String() string {
+	return [...]string{
+		"Summer Coat", "Growing In", "Winter Coat", "Shedding",
+	}[cs]
+}
+
+// Coat tracks a pet's fur and its seasonal changes. A nil coat behaves as
+// a short summer coat.
+type Coat struct {
+	Length    float64 `json:"length"`    // Genetic fur length (0.0 short to 1.0 long)
+	Thickness float64 `json:"thickness"` // Winter undercoat (0.0 none to 1.0 full)
+	LooseFur  float64 `json:"loose_fur"` // Shed fur waiting to be brushed out
+}
+
+// NewCoat creates a summer coat of the given length
+func NewCoat(length float64) *Coat {
+	return &Coat{Length: clamp(length, 0.0, 1.0)}
+}
+
+// IsLong returns true if the coat is long enough to change with the seasons
+func (c *Coat) IsLong() bool {
+	return c != nil && c.Length >= LongCoatThreshold
+}
+
+// Update grows the winter coat in autumn and winter and sheds it in
+// spring and summer
+func (c *Coat) Update(season types.Season, deltaTime float64) {
+	if !c.IsLong() {
+		return
+	}
+
+	switch season {
+	case types.SeasonAutumn, types.SeasonWinter:
+		c.Thickness += WinterCoatGrowth * deltaTime
+	default:
+		shed := SheddingRate * deltaTime
+		if shed > c.Thickness {
+			shed = c.Thickness
+		}
+		c.Thickness -= shed
+		c.LooseFur += shed
+	}
+
+	c.Thickness = clamp(c.Thickness, 0.0, 1.0)
+	c.LooseFur = clamp(c.LooseFur, 0.0, 1.0)
+}
+
+// Stage returns the current seasonal stage of the coat
+func (c *Coat) Stage() CoatStage {
+	switch {
+	case c == nil:
+		return CoatSummer
+	case c.LooseFur > SheddingThreshold:
+		return CoatShedding
+	case c.Thickness >= 0.8:
+		return CoatWinter
+	case c.Thickness > 0.0:
+		return CoatGrowing
+	default:
+		return CoatSummer
+	}
+}
+
+// IsShedding returns true during brushing season, while loose fur builds up
+func (c *Coat) IsShedding() bool {
+	return c.Stage() == CoatShedding
+}
+
+// HeatSensitivity returns how strongly hot weather affects the pet
+// (1.0 for a summer coat, up to 1.5 in a full winter coat)
+func (c *Coat) HeatSensitivity() float64 {
+	if c == nil {
+		return 1.0
+	}
+	return 1.0 + 0.5*c.Thickness
+}
+
+// Insulation returns how well the coat keeps out the cold (0.0 to 1.0).
+// Long fur helps a little; a winter undercoat helps most.
+func (c *Coat) Insulation() float64 {
+	if c == nil {
+		return 0.0
+	}
+	return clamp(0.4*c.Length+0.6*c.Thickness, 0.0, 1.0)
+}
+
+// CleanlinessDecay returns the cleanliness lost per game day. Thick coats
+// trap dirt and loose fur makes a mess while shedding.
+func (c *Coat) CleanlinessDecay() float64 {
+	if c == nil {
+		return BaseCleanlinessDecay
+	}
+	return BaseCleanlinessDecay * (1.0 + c.Length*c.Thickness + 3.0*c.LooseFur)
+}
+
+// GroomingEffectiveness returns how much of a grooming session reaches
+// the skin (0.6 to 1.0). Long, thick coats are harder to groom.
+func (c *Coat) GroomingEffectiveness() float64 {
+	if c == nil {
+		return 1.0
+	}
+	return 1.0 - 0.4*c.Length*c.Thickness
+}
+
+// Brush removes loose fur and returns the amount removed
+func (c *Coat) Brush(intensity float64) float64 {
+	if c == nil {
+		return 0.0
+	}
+	removed := c.LooseFur * clamp(intensity, 0.0, 1.0)
+	c.LooseFur -= removed
+	return removed
+}
@@ -0,0 +1,60 @@
+package biology
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestShortCoatIgnoresSeasons(t *testing.T) {
+	coat := NewCoat(0.2)
+	coat.Update(types.SeasonWinter, 10.0)
+
+	if coat.Thickness != 0 {
+		t.Errorf("Short coats should not grow a winter coat, got thickness %.2f", coat.Thickness)
+	}
+	if coat.Stage() != CoatSummer {
+		t.Errorf("Expected summer coat, got %s", coat.Stage())
+	}
+}
+
+func TestLongCoatSeasonalCycle(t *testing.T) {
+	coat := NewCoat(0.9)
+
+	coat.Update(types.SeasonWinter, 10.0)
+	if coat.Stage() != CoatWinter {
+		t.Fatalf("Expected winter coat, got %s", coat.Stage())
+	}
+	if coat.HeatSensitivity() <= 1.0 {
+		t.Error("Winter coat should lower heat tolerance")
+	}
+
+	winterDecay := coat.CleanlinessDecay()
+	coat.Update(types.SeasonSpring, 1.0)
+	if !coat.IsShedding() {
+		t.Fatalf("Expected shedding in spring, got %s", coat.Stage())
+	}
+	if coat.CleanlinessDecay() <= winterDecay {
+		t.Error("Shedding should make the pet dirty faster")
+	}
+
+	loose := coat.LooseFur
+	if removed := coat.Brush(1.0); removed != loose {
+		t.Errorf("Full brushing should remove all loose fur, removed %.2f of %.2f", removed, loose)
+	}
+	if coat.IsShedding() {
+		t.Error("Brushed coat should no longer be shedding")
+	}
+}
+
+func TestNilCoatDefaults(t *testing.T) {
+	var coat *Coat
+
+	coat.Update(types.SeasonWinter, 1.0)
+	if coat.CleanlinessDecay() != BaseCleanlinessDecay {
+		t.Error("Nil coat should decay at the base rate")
+	}
+	if coat.GroomingEffectiveness() != 1.0 {
+		t.Error("Nil coat should be fully groomable")
+	}
+}
@@ -0,0 +1,34 @@
+package biology
+
+// DecayRates are how quickly vital stats drift on their own, per game day
+type DecayRates struct {
+	Hydration      float64 `yaml:"hydration_decay"`
+	Nutrition      float64 `yaml:"nutrition_decay"`
+	Energy         float64 `yaml:"energy_decay"`
+	Fatigue        float64 `yaml:"fatigue_buildup"`
+	Happiness      float64 `yaml:"happiness_decay"` // Only while above neutral
+	StressRecovery float64 `yaml:"stress_recovery"` // While wellbeing is good
+	StressBuildup  float64 `yaml:"stress_buildup"`  // While wellbeing is poor
+	HealthLoss     float64 `yaml:"health_loss"`     // While wellbeing is poor
+	HealthRecovery float64 `yaml:"health_recovery"` // While wellbeing is high
+	NutrientUse    float64 `yaml:"nutrient_use"`    // For an average adult; see NutrientNeeds
+}
+
+// DefaultDecayRates returns the standard decay rates
+func DefaultDecayRates() DecayRates {
+	return DecayRates{
+		Hydration:      0.05,
+		Nutrition:      0.03,
+		Energy:         0.02,
+		Fatigue:        0.01,
+		Happiness:      0.005,
+		StressRecovery: 0.02,
+		StressBuildup:  0.01,
+		HealthLoss:     0.01,
+		HealthRecovery: 0.005,
+		NutrientUse:    0.05,
+	}
+}
+
+// Decay holds the decay rates in use. Tuning may replace it at runtime.
+var Decay = DefaultDecayRates()
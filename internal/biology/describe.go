@@ -0,0 +1,94 @@
+package biology
+
+import "sync"
+
+// DescriptionHysteresis is how far past the edge of its band a stat must
+// move before Impressions changes its description, so a value hovering at
+// an edge does not flicker between two descriptions
+const DescriptionHysteresis = 0.04
+
+// Band is one qualitative description of a stat, given from Min upward
+type Band struct {
+	Min  float64
+	Text string
+}
+
+// Scale describes a stat from 0.0 to 1.0 in words. Its bands ascend by Min
+// and the first starts at 0.0.
+type Scale []Band
+
+// band returns the index of the band a value falls in
+func (s Scale) band(value float64) int {
+	i := 0
+	for i+1 < len(s) && value >= s[i+1].Min {
+		i++
+	}
+	return i
+}
+
+// Describe returns the description of a value
+func (s Scale) Describe(value float64) string {
+	return s[s.band(value)].Text
+}
+
+// settle returns the band to describe a value by when band previous was
+// described last: it is kept until the value leaves it by more than
+// DescriptionHysteresis
+func (s Scale) settle(value float64, previous int) int {
+	if previous < 0 || previous >= len(s) {
+		return s.band(value)
+	}
+	above := previous+1 < len(s) && value >= s[previous+1].Min+DescriptionHysteresis
+	below := value < s[previous].Min-DescriptionHysteresis
+	if above || below {
+		return s.band(value)
+	}
+	return previous
+}
+
+// VitalScales describe each vital in words, for players who would rather
+// not see numbers. Inverted vitals describe their high values as the bad
+// ones. Every qualitative view uses these, so a pet is described the same
+// way everywhere.
+var VitalScales = map[Vital]Scale{
+	VitalHealth:      {{0, "gravely ill"}, {0.2, "looks unwell"}, {0.4, "a little under the weather"}, {0.6, "healthy"}, {0.85, "glowing with health"}},
+	VitalEnergy:      {{0, "exhausted"}, {0.2, "drowsy"}, {0.4, "a bit sluggish"}, {0.6, "lively"}, {0.85, "bursting with energy"}},
+	VitalHydration:   {{0, "parched"}, {0.2, "very thirsty"}, {0.45, "a little thirsty"}, {0.7, "well watered"}},
+	VitalNutrition:   {{0, "starving"}, {0.2, "looks thin"}, {0.4, "looks a bit thin"}, {0.6, "well fed"}, {0.9, "pleasantly round"}},
+	VitalHappiness:   {{0, "miserable"}, {0.2, "glum"}, {0.4, "content"}, {0.65, "cheerful"}, {0.85, "overjoyed"}},
+	VitalStress:      {{0, "completely calm"}, {0.2, "relaxed"}, {0.45, "a little tense"}, {0.65, "anxious"}, {0.85, "frantic"}},
+	VitalFatigue:     {{0, "fresh"}, {0.3, "a little tired"}, {0.6, "worn out"}, {0.85, "dead on its feet"}},
+	VitalCleanliness: {{0, "filthy"}, {0.25, "grubby"}, {0.5, "tidy"}, {0.8, "spotless"}},
+}
+
+// ImmunityScale describes immune strength in words
+var ImmunityScale = Scale{{0, "catches everything going"}, {0.3, "a little run down"}, {0.6, "hardy"}, {0.85, "rarely falls ill"}}
+
+// NutrientScale describes how well stocked a nutrient is in words. Its
+// lowest band ends at DeficiencyLevel.
+var NutrientScale = Scale{{0, "sorely lacking"}, {DeficiencyLevel, "running low"}, {0.6, "plenty"}}
+
+// Impressions remembers the band last used to describe each stat, so that
+// descriptions settle rather than flicker as values drift. Stats are keyed
+// by the caller, e.g. by pet and vital. The zero value is ready to use.
+type Impressions struct {
+	mu    sync.Mutex
+	bands map[string]int
+}
+
+// Describe describes a stat on a scale, keeping its previous description
+// while the value stays within DescriptionHysteresis of that band
+func (im *Impressions) Describe(key string, scale Scale, value float64) string {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	if im.bands == nil {
+		im.bands = make(map[string]int)
+	}
+	previous, seen := im.bands[key]
+	if !seen {
+		previous = -1
+	}
+	band := scale.settle(value, previous)
+	im.bands[key] = band
+	return scale[band].Text
+}
@@ -0,0 +1,51 @@
+package biology
+
+import "testing"
+
+func TestVitalScalesCoverEveryVital(t *testing.T) {
+	scales := map[string]Scale{"immunity": ImmunityScale, "nutrient": NutrientScale}
+	for _, vital := range AllVitals() {
+		scales[vital.Key()] = VitalScales[vital]
+	}
+	for name, scale := range scales {
+		if len(scale) < 2 || scale[0].Min != 0 {
+			t.Errorf("Expected %s described from 0.0 up, got %v", name, scale)
+			continue
+		}
+		for i := 1; i < len(scale); i++ {
+			if scale[i].Min <= scale[i-1].Min {
+				t.Errorf("Expected the %s bands to ascend, got %v", name, scale)
+			}
+		}
+	}
+	if got := VitalScales[VitalEnergy].Describe(0.95); got != "bursting with energy" {
+		t.Errorf("Expected a full energy bar described as bursting, got %q", got)
+	}
+	if got := VitalScales[VitalNutrition].Describe(0.45); got != "looks a bit thin" {
+		t.Errorf("Expected a pet at 45%% nutrition to look a bit thin, got %q", got)
+	}
+}
+
+func TestImpressionsSettle(t *testing.T) {
+	var im Impressions
+	scale := VitalScales[VitalHealth]
+	steps := []struct {
+		value float64
+		want  string
+	}{
+		{0.61, "healthy"},
+		{0.58, "healthy"}, // Within the hysteresis of the edge at 0.6
+		{0.55, "a little under the weather"},
+		{0.62, "a little under the weather"},
+		{0.65, "healthy"},
+		{0.1, "gravely ill"},
+	}
+	for _, step := range steps {
+		if got := im.Describe("rex/health", scale, step.value); got != step.want {
+			t.Errorf("At %.2f expected %q, got %q", step.value, step.want, got)
+		}
+	}
+	if got := im.Describe("pip/health", scale, 0.58); got != "a little under the weather" {
+		t.Errorf("Expected each stat to settle separately, got %q", got)
+	}
+}
@@ -0,0 +1,96 @@
+package biology
+
+// Hibernation settings
+const (
+	HibernationMetabolism = 0.2 // Fraction of normal decay while hibernating
+	WakeTransitionDays    = 1.0 // Game days taken to fully wake up
+)
+
+// HibernationState represents where a pet is in its hibernation cycle
+type HibernationState int
+
+const (
+	HibernationAwake HibernationState = iota
+	HibernationDormant
+	HibernationWaking
+)
+
+// String returns the string representation of HibernationState
+func (hs HibernationState) String() string {
+	return [...]string{
+		"Awake", "Hibernating", "Waking Up",
+	}[hs]
+}
+
+// Hibernation tracks a pet's seasonal energy-saving mode. A nil
+// hibernation behaves as permanently awake.
+type Hibernation struct {
+	State        HibernationState `json:"state"`
+	WakeProgress float64          `json:"wake_progress"` // 0.0 just stirring to 1.0 fully awake
+	DaysDormant  float64          `json:"days_dormant"`  // Game days spent hibernating this winter
+}
+
+// NewHibernation creates an awake hibernation tracker
+func NewHibernation() *Hibernation {
+	return &Hibernation{}
+}
+
+// Enter puts the pet into hibernation
+func (h *Hibernation) Enter() {
+	if h == nil || h.State == HibernationDormant {
+		return
+	}
+	h.State = HibernationDormant
+	h.WakeProgress = 0.0
+	h.DaysDormant = 0.0
+}
+
+// Wake starts the wake-up transition
+func (h *Hibernation) Wake() {
+	if h == nil || h.State != HibernationDormant {
+		return
+	}
+	h.State = HibernationWaking
+	h.WakeProgress = 0.0
+}
+
+// Update advances the hibernation cycle by deltaTime game days
+func (h *Hibernation) Update(deltaTime float64) {
+	if h == nil {
+		return
+	}
+
+	switch h.State {
+	case HibernationDormant:
+		h.DaysDormant += deltaTime
+	case HibernationWaking:
+		h.WakeProgress += deltaTime / WakeTransitionDays
+		if h.WakeProgress >= 1.0 {
+			h.State = HibernationAwake
+			h.WakeProgress = 1.0
+		}
+	}
+}
+
+// IsDormant returns true while the pet is hibernating
+func (h *Hibernation) IsDormant() bool {
+	return h != nil && h.State == HibernationDormant
+}
+
+// IsWaking returns true during the wake-up transition
+func (h *Hibernation) IsWaking() bool {
+	return h != nil && h.State == HibernationWaking
+}
+
+// MetabolicFactor returns the fraction of normal vital decay the pet
+// experiences. Metabolism ramps back up over the wake-up transition.
+func (h *Hibernation) MetabolicFactor() float64 {
+	switch {
+	case h.IsDormant():
+		return HibernationMetabolism
+	case h.IsWaking():
+		return HibernationMetabolism + (1.0-HibernationMetabolism)*h.WakeProgress
+	default:
+		return 1.0
+	}
+}
@@ -0,0 +1,40 @@
+package biology
+
+import "testing"
+
+func TestHibernationSlowsDecay(t *testing.T) {
+	awake := NewBiologicalSystems()
+	dormant := NewBiologicalSystems()
+	dormant.Hibernation.Enter()
+
+	awake.Update(2.0)
+	dormant.Update(2.0)
+
+	if dormant.Vitals.Hydration <= awake.Vitals.Hydration {
+		t.Error("Hibernating pets should lose hydration more slowly")
+	}
+	if dormant.GetAgeInDays() != awake.GetAgeInDays() {
+		t.Error("Hibernating pets should still age normally")
+	}
+}
+
+func TestHibernationWakeTransition(t *testing.T) {
+	h := NewHibernation()
+	h.Enter()
+	h.Wake()
+
+	if !h.IsWaking() {
+		t.Fatalf("Expected waking, got %s", h.State)
+	}
+
+	h.Update(WakeTransitionDays / 2)
+	factor := h.MetabolicFactor()
+	if factor <= HibernationMetabolism || factor >= 1.0 {
+		t.Errorf("Metabolism should ramp up while waking, got %.2f", factor)
+	}
+
+	h.Update(WakeTransitionDays)
+	if h.State != HibernationAwake || h.MetabolicFactor() != 1.0 {
+		t.Errorf("Expected fully awake, got %s", h.State)
+	}
+}
@@ -0,0 +1,159 @@
+package biology
+
+// Nutrition settings
+const (
+	DeficiencyLevel    = 0.3  // Nutrient level below which a pet is deficient
+	ProteinFitnessRate = 0.5  // Fraction of fitness gained on a protein-poor diet
+	VitaminImmuneLoss  = 0.05 // Immune strength lost per game day short of vitamins
+	VitaminImmuneGain  = 0.1  // Lost immune strength regained per game day once fed well
+	MinImmuneStrength  = 0.1  // Floor a vitamin deficit wears immunity down to
+)
+
+// Nutrient identifies one of the nutrients a diet tracks
+type Nutrient int
+
+const (
+	NutrientProtein Nutrient = iota
+	NutrientFat
+	NutrientCarbs
+	NutrientVitamins
+)
+
+// String returns the string representation of Nutrient
+func (n Nutrient) String() string {
+	return [...]string{
+		"Protein", "Fat", "Carbs", "Vitamins",
+	}[n]
+}
+
+// AllNutrients returns every nutrient
+func AllNutrients() []Nutrient {
+	return []Nutrient{NutrientProtein, NutrientFat, NutrientCarbs, NutrientVitamins}
+}
+
+// Nutrients is an amount of each nutrient, e.g. what one item of a food
+// provides. Amounts are on the same scale as the Nutrition vital.
+type Nutrients struct {
+	Protein  float64 `json:"protein"`
+	Fat      float64 `json:"fat"`
+	Carbs    float64 `json:"carbs"`
+	Vitamins float64 `json:"vitamins"`
+}
+
+// StandardMeal is a bowl of ordinary pet food, balanced in every nutrient.
+// Feeding without a particular food gives this, scaled by the intensity.
+var StandardMeal = Nutrients{Protein: 0.25, Fat: 0.2, Carbs: 0.25, Vitamins: 0.2}
+
+// Get returns the amount of one nutrient
+func (n Nutrients) Get(nutrient Nutrient) float64 {
+	return [...]float64{n.Protein, n.Fat, n.Carbs, n.Vitamins}[nutrient]
+}
+
+// Scale returns every amount multiplied by factor
+func (n Nutrients) Scale(factor float64) Nutrients {
+	return Nutrients{Protein: n.Protein * factor, Fat: n.Fat * factor, Carbs: n.Carbs * factor, Vitamins: n.Vitamins * factor}
+}
+
+// Filling returns how filling a food is as a feeding intensity (0.0 to
+// 1.0). Protein, fat and carbs satisfy hunger; vitamins do not.
+func (n Nutrients) Filling() float64 {
+	return clamp(n.Protein+n.Fat+n.Carbs, 0.0, 1.0)
+}
+
+// NutrientNeeds returns how quickly a pet uses up each nutrient relative
+// to an average adult. Bigger pets need more of everything, growing pets
+// extra protein, elders extra vitamins, and active pets more fat and carbs
+// to burn. size and activity range from 0.0 to 1.0, with 0.5 average.
+func NutrientNeeds(size, ageDays, activity float64) Nutrients {
+	base := 0.7 + 0.6*clamp(size, 0.0, 1.0)
+	fuel := 0.6 + 0.8*clamp(activity, 0.0, 1.0)
+	needs := Nutrients{Protein: base, Fat: base * fuel, Carbs: base * fuel, Vitamins: base}
+	if ageDays < AdultAge {
+		needs.Protein *= 1.5
+	}
+	if ageDays >= ElderAge {
+		needs.Vitamins *= 1.3
+	}
+	return needs
+}
+
+// Diet tracks how well stocked a pet is with each nutrient. A nil diet
+// behaves as perfectly balanced, so older saves never show deficiencies.
+type Diet struct {
+	Levels     Nutrients `json:"levels"`      // Each nutrient from 0.0 (depleted) to 1.0 (well stocked)
+	Needs      Nutrients `json:"needs"`       // Rate each nutrient is used, relative to an average adult
+	ImmuneLoss float64   `json:"immune_loss"` // Immune strength taken by a vitamin deficit, regained once fed well
+}
+
+// NewDiet creates a well-stocked diet with average needs
+func NewDiet() *Diet {
+	full := Nutrients{Protein: 1.0, Fat: 1.0, Carbs: 1.0, Vitamins: 1.0}
+	return &Diet{Levels: full, Needs: full}
+}
+
+// Eat stocks the diet with a food's nutrients
+func (d *Diet) Eat(food Nutrients) {
+	if d == nil {
+		return
+	}
+	d.Levels.Protein = clamp(d.Levels.Protein+food.Protein, 0.0, 1.0)
+	d.Levels.Fat = clamp(d.Levels.Fat+food.Fat, 0.0, 1.0)
+	d.Levels.Carbs = clamp(d.Levels.Carbs+food.Carbs, 0.0, 1.0)
+	d.Levels.Vitamins = clamp(d.Levels.Vitamins+food.Vitamins, 0.0, 1.0)
+}
+
+// Level returns how well stocked a nutrient is
+func (d *Diet) Level(nutrient Nutrient) float64 {
+	if d == nil {
+		return 1.0
+	}
+	return d.Levels.Get(nutrient)
+}
+
+// Deficient returns true while a nutrient is below DeficiencyLevel
+func (d *Diet) Deficient(nutrient Nutrient) bool {
+	return d.Level(nutrient) < DeficiencyLevel
+}
+
+// Deficiencies returns the nutrients the pet is short of
+func (d *Diet) Deficiencies() []Nutrient {
+	var short []Nutrient
+	for _, nutrient := range AllNutrients() {
+		if d.Deficient(nutrient) {
+			short = append(short, nutrient)
+		}
+	}
+	return short
+}
+
+// FitnessRate returns the fraction of physical training a pet gains:
+// without enough protein its muscles build more slowly
+func (d *Diet) FitnessRate() float64 {
+	if d.Deficient(NutrientProtein) {
+		return ProteinFitnessRate
+	}
+	return 1.0
+}
+
+// update uses up nutrients over deltaTime game days and lets a vitamin
+// deficit wear down the immune system until the pet is fed well again
+func (d *Diet) update(deltaTime float64, processes *PhysiologicalProcesses) {
+	if d == nil {
+		return
+	}
+	use := deltaTime * Decay.NutrientUse
+	d.Levels.Protein = clamp(d.Levels.Protein-use*d.Needs.Protein, 0.0, 1.0)
+	d.Levels.Fat = clamp(d.Levels.Fat-use*d.Needs.Fat, 0.0, 1.0)
+	d.Levels.Carbs = clamp(d.Levels.Carbs-use*d.Needs.Carbs, 0.0, 1.0)
+	d.Levels.Vitamins = clamp(d.Levels.Vitamins-use*d.Needs.Vitamins, 0.0, 1.0)
+
+	if d.Deficient(NutrientVitamins) {
+		loss := min(VitaminImmuneLoss*deltaTime, max(processes.ImmuneStrength-MinImmuneStrength, 0.0))
+		processes.ImmuneStrength -= loss
+		d.ImmuneLoss += loss
+	} else if d.ImmuneLoss > 0 {
+		gain := min(VitaminImmuneGain*deltaTime, d.ImmuneLoss)
+		processes.ImmuneStrength += gain
+		d.ImmuneLoss -= gain
+	}
+}
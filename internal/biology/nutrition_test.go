@@ -0,0 +1,57 @@
+package biology
+
+import "testing"
+
+func TestNutrientNeeds(t *testing.T) {
+	average := NutrientNeeds(0.5, AdultAge, 0.5)
+	if average != (Nutrients{Protein: 1, Fat: 1, Carbs: 1, Vitamins: 1}) {
+		t.Errorf("Expected an average adult to have needs of 1, got %+v", average)
+	}
+	young := NutrientNeeds(0.5, 0, 0.5)
+	if young.Protein <= average.Protein || young.Vitamins != average.Vitamins {
+		t.Errorf("Expected a growing pet to need extra protein only, got %+v", young)
+	}
+	if elder := NutrientNeeds(0.5, ElderAge, 0.5); elder.Vitamins <= average.Vitamins {
+		t.Errorf("Expected an elder to need extra vitamins, got %+v", elder)
+	}
+	if big := NutrientNeeds(1, AdultAge, 1); big.Carbs <= big.Protein || big.Protein <= average.Protein {
+		t.Errorf("Expected a big, active pet to need more, and more fuel most, got %+v", big)
+	}
+}
+
+func TestVitaminDeficitWeakensImmunity(t *testing.T) {
+	b := NewBiologicalSystems()
+	before := b.Processes.ImmuneStrength
+	b.Diet.Levels.Vitamins = DeficiencyLevel - 0.1
+
+	b.Diet.update(2.0, b.Processes)
+	if !b.Diet.Deficient(NutrientVitamins) || b.Processes.ImmuneStrength >= before {
+		t.Fatalf("Expected a vitamin deficit to weaken immunity, got %.2f", b.Processes.ImmuneStrength)
+	}
+
+	b.Diet.Eat(Nutrients{Vitamins: 1.0})
+	b.Diet.update(10.0, b.Processes)
+	if b.Processes.ImmuneStrength != before || b.Diet.ImmuneLoss != 0 {
+		t.Errorf("Expected immunity restored once fed well, got %.2f", b.Processes.ImmuneStrength)
+	}
+}
+
+func TestDietWithoutFood(t *testing.T) {
+	d := NewDiet()
+	d.update(1.0, NewPhysiologicalProcesses())
+	if d.Level(NutrientProtein) != 1.0-Decay.NutrientUse || len(d.Deficiencies()) != 0 {
+		t.Errorf("Expected nutrients used at the tuned rate, got %+v", d.Levels)
+	}
+	d.Levels.Protein = 0
+	if d.FitnessRate() != ProteinFitnessRate {
+		t.Errorf("Expected fitness slowed without protein, got %.2f", d.FitnessRate())
+	}
+
+	var old *Diet
+	if old.FitnessRate() != 1.0 || len(old.Deficiencies()) != 0 {
+		t.Error("A save without a diet should count as well fed")
+	}
+	if filling := (Nutrients{Protein: 0.3, Vitamins: 0.5}).Filling(); filling != 0.3 {
+		t.Errorf("Expected vitamins not to be filling, got %.2f", filling)
+	}
+}
@@ -0,0 +1,148 @@
+package biology
+
+import "math"
+
+// Thermoregulation settings
+const (
+	NormalBodyTemperature = 38.5 // Healthy body temperature in °C
+	ComfortableAmbient    = 20.0 // Ambient temperature an unfurred pet finds neutral in °C
+	ThermoneutralRange    = 8.0  // Ambient deviation compensated for without effort in °C
+	ThermalCoupling       = 0.15 // Body °C per °C of ambient load beyond the neutral range
+	ThermalResponseRate   = 4.0  // How quickly body temperature follows its target, per game day
+	InsulationWarmth      = 12.0 // Ambient °C a full coat of insulation is worth
+	ShelterProtection     = 0.8  // Fraction of thermal load removed by perfect shelter
+	ChillThreshold        = 37.5 // Body temperature below which the pet feels cold
+	OverheatThreshold     = 39.5 // Body temperature above which the pet feels hot
+	HypothermiaThreshold  = 36.0 // Body temperature at which hypothermia threatens
+	HeatstrokeThreshold   = 41.0 // Body temperature at which heatstroke threatens
+	ConditionOnsetDays    = 0.5  // Game days at an extreme before a condition sets in
+	ThermalHealthDrain    = 0.15 // Health lost per game day with a thermal condition
+)
+
+// ThermalCondition is an illness caused by extreme body temperature
+type ThermalCondition int
+
+const (
+	ThermalNone ThermalCondition = iota
+	ThermalHypothermia
+	ThermalHeatstroke
+)
+
+// String returns the string representation of ThermalCondition
+func (tc ThermalCondition) String() string {
+	return [...]string{
+		"None", "Hypothermia", "Heatstroke",
+	}[tc]
+}
+
+// ThermalExposure describes the conditions the pet's body must cope with
+type ThermalExposure struct {
+	Ambient    float64 // Surrounding temperature in °C
+	Insulation float64 // Fur insulation (0.0 to 1.0)
+	Shelter    float64 // Protection from the elements (0.0 to 1.0)
+}
+
+// Thermoregulation tracks a pet's body temperature and any illness from
+// sustained extremes. A nil thermoregulation stays at a normal temperature.
+type Thermoregulation struct {
+	BodyTemperature float64          `json:"body_temperature"` // °C
+	ExtremeDays     float64          `json:"extreme_days"`     // Game days spent at a dangerous temperature
+	Condition       ThermalCondition `json:"condition"`
+}
+
+// NewThermoregulation creates a pet at a normal body temperature
+func NewThermoregulation() *Thermoregulation {
+	return &Thermoregulation{BodyTemperature: NormalBodyTemperature}
+}
+
+// Temperature returns the current body temperature in °C
+func (t *Thermoregulation) Temperature() float64 {
+	if t == nil {
+		return NormalBodyTemperature
+	}
+	return t.BodyTemperature
+}
+
+// TargetTemperature returns the body temperature the pet settles at under
+// an exposure. Insulation makes the air feel warmer, the body compensates
+// within the thermoneutral range, and shelter blunts whatever remains.
+func TargetTemperature(exposure ThermalExposure) float64 {
+	load := exposure.Ambient + clamp(exposure.Insulation, 0.0, 1.0)*InsulationWarmth - ComfortableAmbient
+
+	excess := 0.0
+	switch {
+	case load > ThermoneutralRange:
+		excess = load - ThermoneutralRange
+	case load < -ThermoneutralRange:
+		excess = load + ThermoneutralRange
+	}
+	excess *= 1.0 - clamp(exposure.Shelter, 0.0, 1.0)*ShelterProtection
+
+	return NormalBodyTemperature + excess*ThermalCoupling
+}
+
+// Update moves body temperature toward its target and develops hypothermia
+// or heatstroke after ConditionOnsetDays at a dangerous temperature.
+// Returns the condition if it set in during this update.
+func (t *Thermoregulation) Update(exposure ThermalExposure, deltaTime float64) ThermalCondition {
+	if t == nil {
+		return ThermalNone
+	}
+
+	target := TargetTemperature(exposure)
+	t.BodyTemperature += (target - t.BodyTemperature) * math.Min(1.0, ThermalResponseRate*deltaTime)
+
+	danger := ThermalNone
+	switch {
+	case t.BodyTemperature <= HypothermiaThreshold:
+		danger = ThermalHypothermia
+	case t.BodyTemperature >= HeatstrokeThreshold:
+		danger = ThermalHeatstroke
+	}
+
+	if danger == ThermalNone {
+		t.ExtremeDays = 0
+		return ThermalNone
+	}
+
+	t.ExtremeDays += deltaTime
+	if t.Condition == ThermalNone && t.ExtremeDays >= ConditionOnsetDays {
+		t.Condition = danger
+		return danger
+	}
+	return ThermalNone
+}
+
+// Discomfort returns how cold and how hot the pet feels (0.0 to 1.0 each)
+func (t *Thermoregulation) Discomfort() (cold, heat float64) {
+	if t == nil {
+		return 0.0, 0.0
+	}
+	cold = clamp((ChillThreshold-t.BodyTemperature)/(ChillThreshold-HypothermiaThreshold), 0.0, 1.0)
+	heat = clamp((t.BodyTemperature-OverheatThreshold)/(HeatstrokeThreshold-OverheatThreshold), 0.0, 1.0)
+	return cold, heat
+}
+
+// HasCondition returns true if the pet is suffering hypothermia or heatstroke
+func (t *Thermoregulation) HasCondition() bool {
+	return t != nil && t.Condition != ThermalNone
+}
+
+// HealthDrain returns the health lost per game day to a thermal condition
+func (t *Thermoregulation) HealthDrain() float64 {
+	if !t.HasCondition() {
+		return 0.0
+	}
+	return ThermalHealthDrain
+}
+
+// Treat cures a thermal condition and brings the body temperature back
+// into the comfortable range
+func (t *Thermoregulation) Treat() {
+	if t == nil {
+		return
+	}
+	t.Condition = ThermalNone
+	t.ExtremeDays = 0
+	t.BodyTemperature = clamp(t.BodyTemperature, ChillThreshold, OverheatThreshold)
+}
@@ -0,0 +1,60 @@
+package biology
+
+import "testing"
+
+func TestInsulationAndShelterModulateTemperature(t *testing.T) {
+	blizzard := ThermalExposure{Ambient: -20}
+
+	bare := TargetTemperature(blizzard)
+	furred := TargetTemperature(ThermalExposure{Ambient: -20, Insulation: 1.0})
+	sheltered := TargetTemperature(ThermalExposure{Ambient: -20, Shelter: 1.0})
+
+	if bare >= HypothermiaThreshold {
+		t.Errorf("An unprotected pet should head for hypothermia, target %.1f", bare)
+	}
+	if furred <= bare || sheltered <= bare {
+		t.Error("Fur and shelter should both keep the pet warmer")
+	}
+	if got := TargetTemperature(ThermalExposure{Ambient: ComfortableAmbient}); got != NormalBodyTemperature {
+		t.Errorf("Mild weather should leave the body at %.1f, got %.1f", NormalBodyTemperature, got)
+	}
+}
+
+func TestSustainedExtremeCausesCondition(t *testing.T) {
+	thermo := NewThermoregulation()
+	heat := ThermalExposure{Ambient: 50}
+
+	if onset := thermo.Update(heat, 0.25); onset != ThermalNone {
+		t.Errorf("Condition should not set in immediately, got %s", onset)
+	}
+	if _, hot := thermo.Discomfort(); hot == 0 {
+		t.Error("Pet should feel the heat")
+	}
+	thermo.Update(heat, 0.25)
+	if onset := thermo.Update(heat, 0.25); onset != ThermalHeatstroke && thermo.Condition != ThermalHeatstroke {
+		t.Fatalf("Expected heatstroke, got %s", thermo.Condition)
+	}
+	if thermo.HealthDrain() <= 0 {
+		t.Error("Heatstroke should drain health")
+	}
+
+	// Cooling down alone does not cure the condition
+	thermo.Update(ThermalExposure{Ambient: ComfortableAmbient}, 1.0)
+	if !thermo.HasCondition() {
+		t.Error("Heatstroke should require care to cure")
+	}
+
+	thermo.Treat()
+	if thermo.HasCondition() {
+		t.Error("Treatment should cure heatstroke")
+	}
+}
+
+func TestNilThermoregulation(t *testing.T) {
+	var thermo *Thermoregulation
+	thermo.Update(ThermalExposure{Ambient: -40}, 1.0)
+	thermo.Treat()
+	if thermo.Temperature() != NormalBodyTemperature || thermo.HasCondition() {
+		t.Error("Nil thermoregulation should stay normal")
+	}
+}
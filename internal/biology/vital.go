@@ -0,0 +1,108 @@
+package biology
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownVital is returned when a vital name is not recognised
+var ErrUnknownVital = errors.New("unknown vital")
+
+// Vital identifies one of a pet's vital stats
+type Vital int
+
+const (
+	VitalHealth Vital = iota
+	VitalEnergy
+	VitalHydration
+	VitalNutrition
+	VitalHappiness
+	VitalStress
+	VitalFatigue
+	VitalCleanliness
+)
+
+// String returns the string representation of Vital
+func (v Vital) String() string {
+	return [...]string{
+		"Health", "Energy", "Hydration", "Nutrition",
+		"Happiness", "Stress", "Fatigue", "Cleanliness",
+	}[v]
+}
+
+// Key returns the name the vital is saved, scripted and imported under,
+// e.g. "health"
+func (v Vital) Key() string {
+	return strings.ToLower(v.String())
+}
+
+// Inverted returns true for vitals where higher is worse
+func (v Vital) Inverted() bool {
+	return v == VitalStress || v == VitalFatigue
+}
+
+// AllVitals returns every vital
+func AllVitals() []Vital {
+	return []Vital{
+		VitalHealth, VitalEnergy, VitalHydration, VitalNutrition,
+		VitalHappiness, VitalStress, VitalFatigue, VitalCleanliness,
+	}
+}
+
+// ParseVital looks up a vital by name, ignoring case and surrounding
+// space, so "Health" and "health" both match
+func ParseVital(name string) (Vital, error) {
+	for _, vital := range AllVitals() {
+		if strings.EqualFold(vital.String(), strings.TrimSpace(name)) {
+			return vital, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownVital, name)
+}
+
+// field returns the stat backing a vital
+func (s *VitalStats) field(v Vital) *float64 {
+	switch v {
+	case VitalHealth:
+		return &s.Health
+	case VitalEnergy:
+		return &s.Energy
+	case VitalHydration:
+		return &s.Hydration
+	case VitalNutrition:
+		return &s.Nutrition
+	case VitalHappiness:
+		return &s.Happiness
+	case VitalStress:
+		return &s.Stress
+	case VitalFatigue:
+		return &s.Fatigue
+	case VitalCleanliness:
+		return &s.Cleanliness
+	}
+	panic(fmt.Sprintf("biology: invalid vital %d", int(v)))
+}
+
+// Get returns the value of a vital
+func (s *VitalStats) Get(v Vital) float64 {
+	return *s.field(v)
+}
+
+// Set changes the value of a vital
+func (s *VitalStats) Set(v Vital, value float64) {
+	*s.field(v) = value
+}
+
+// CriticalVitals returns the vitals at critical levels: below threshold,
+// or above 1-threshold for inverted vitals
+func (s *VitalStats) CriticalVitals(threshold float64) []Vital {
+	var critical []Vital
+	for _, vital := range AllVitals() {
+		value := s.Get(vital)
+		if vital.Inverted() && value > 1.0-threshold || !vital.Inverted() && value < threshold {
+			critical = append(critical, vital)
+		}
+	}
+	return critical
+}
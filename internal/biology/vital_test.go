@@ -0,0 +1,43 @@
+package biology
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVitalGetSet(t *testing.T) {
+	vitals := NewVitalStats()
+	for i, vital := range AllVitals() {
+		vitals.Set(vital, float64(i)/10)
+	}
+	if vitals.Health != 0 || vitals.Nutrition != 0.3 || vitals.Cleanliness != 0.7 {
+		t.Errorf("Set should write the matching fields, got %+v", vitals)
+	}
+	if vitals.Get(VitalFatigue) != vitals.Fatigue {
+		t.Errorf("Get(Fatigue) = %.2f, want %.2f", vitals.Get(VitalFatigue), vitals.Fatigue)
+	}
+}
+
+func TestParseVital(t *testing.T) {
+	for _, vital := range AllVitals() {
+		for _, name := range []string{vital.String(), vital.Key(), " " + vital.Key() + " "} {
+			if got, err := ParseVital(name); err != nil || got != vital {
+				t.Errorf("ParseVital(%q) = %v, %v; want %v", name, got, err, vital)
+			}
+		}
+	}
+	if _, err := ParseVital("helth"); !errors.Is(err, ErrUnknownVital) {
+		t.Errorf("Expected ErrUnknownVital for a typo, got %v", err)
+	}
+}
+
+func TestCriticalVitals(t *testing.T) {
+	vitals := NewVitalStats()
+	vitals.Hydration = 0.1
+	vitals.Stress = 0.9
+
+	critical := vitals.CriticalVitals(0.2)
+	if len(critical) != 2 || critical[0] != VitalHydration || critical[1] != VitalStress {
+		t.Errorf("Expected hydration and stress critical, got %v", critical)
+	}
+}
@@ -3,6 +3,8 @@ package biology
 import (
 	"fmt"
 	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
 )
 
 // VitalStats represents the core vital statistics of a digital pet.
@@ -67,32 +69,9 @@ func (v *VitalStats) IsCritical(threshold float64) bool {
 // GetCriticalStats returns a list of stat names that are at critical levels
 func (v *VitalStats) GetCriticalStats(threshold float64) []string {
 	var critical []string
-
-	if v.Health < threshold {
-		critical = append(critical, "Health")
-	}
-	if v.Energy < threshold {
-		critical = append(critical, "Energy")
-	}
-	if v.Hydration < threshold {
-		critical = append(critical, "Hydration")
-	}
-	if v.Nutrition < threshold {
-		critical = append(critical, "Nutrition")
+	for _, vital := range v.CriticalVitals(threshold) {
+		critical = append(critical, vital.String())
 	}
-	if v.Happiness < threshold {
-		critical = append(critical, "Happiness")
-	}
-	if v.Stress > (1.0 - threshold) {
-		critical = append(critical, "Stress")
-	}
-	if v.Fatigue > (1.0 - threshold) {
-		critical = append(critical, "Fatigue")
-	}
-	if v.Cleanliness < threshold {
-		critical = append(critical, "Cleanliness")
-	}
-
 	return critical
 }
 
@@ -124,23 +103,31 @@ func NewPhysiologicalProcesses() *PhysiologicalProcesses {
 
 // BiologicalSystems manages all biological simulation aspects of a pet
 type BiologicalSystems struct {
-	Vitals      *VitalStats
-	Processes   *PhysiologicalProcesses
-	BirthTime   time.Time
-	LastUpdate  time.Time
-	IsAlive     bool
-	CauseOfDeath string
+	Vitals           *VitalStats
+	Processes        *PhysiologicalProcesses
+	Coat             *Coat
+	Hibernation      *Hibernation
+	Thermoregulation *Thermoregulation
+	Diet             *Diet
+	BirthTime        time.Time
+	LastUpdate       time.Time
+	IsAlive          bool
+	CauseOfDeath     string
 }
 
 // NewBiologicalSystems creates a new biological system for a pet
 func NewBiologicalSystems() *BiologicalSystems {
 	now := time.Now()
 	return &BiologicalSystems{
-		Vitals:     NewVitalStats(),
-		Processes:  NewPhysiologicalProcesses(),
-		BirthTime:  now,
-		LastUpdate: now,
-		IsAlive:    true,
+		Vitals:           NewVitalStats(),
+		Processes:        NewPhysiologicalProcesses(),
+		Coat:             NewCoat(0.5),
+		Hibernation:      NewHibernation(),
+		Thermoregulation: NewThermoregulation(),
+		Diet:             NewDiet(),
+		BirthTime:        now,
+		LastUpdate:       now,
+		IsAlive:          true,
 	}
 }
 
@@ -155,11 +142,21 @@ func (b *BiologicalSystems) Update(deltaTime float64) {
 	// Age the pet
 	b.Processes.Age += deltaTime
 
+	// Hibernating pets burn through their reserves far more slowly
+	b.Hibernation.Update(deltaTime)
+	metabolicTime := deltaTime * b.Hibernation.MetabolicFactor()
+
 	// Process metabolism - convert nutrition to energy
-	b.processMetabolism(deltaTime)
+	b.processMetabolism(metabolicTime)
 
 	// Natural decay of vital stats
-	b.decayVitalStats(deltaTime)
+	b.decayVitalStats(metabolicTime)
+
+	// Use up nutrients; a lack of vitamins weakens the immune system
+	b.Diet.update(metabolicTime, b.Processes)
+
+	// Hypothermia and heatstroke wear down health until treated
+	b.Vitals.Health -= b.Thermoregulation.HealthDrain() * deltaTime
 
 	// Check for death conditions
 	b.CheckDeathConditions()
@@ -190,36 +187,36 @@ func (b *BiologicalSystems) processMetabolism(deltaTime float64) {
 // decayVitalStats handles natural decay of stats over time
 func (b *BiologicalSystems) decayVitalStats(deltaTime float64) {
 	// Natural decay rates (per game day)
-	b.Vitals.Hydration -= deltaTime * 0.05
-	b.Vitals.Nutrition -= deltaTime * 0.03
-	b.Vitals.Energy -= deltaTime * 0.02
+	b.Vitals.Hydration -= deltaTime * Decay.Hydration
+	b.Vitals.Nutrition -= deltaTime * Decay.Nutrition
+	b.Vitals.Energy -= deltaTime * Decay.Energy
 
 	// Fatigue builds up over time awake
-	b.Vitals.Fatigue += deltaTime * 0.01
+	b.Vitals.Fatigue += deltaTime * Decay.Fatigue
 
-	// Cleanliness decreases slowly
-	b.Vitals.Cleanliness -= deltaTime * 0.01
+	// Cleanliness decreases slowly, faster for thick or shedding coats
+	b.Vitals.Cleanliness -= deltaTime * b.Coat.CleanlinessDecay()
 
 	// Happiness slowly trends toward neutral
 	if b.Vitals.Happiness > 0.5 {
-		b.Vitals.Happiness -= deltaTime * 0.005
+		b.Vitals.Happiness -= deltaTime * Decay.Happiness
 	}
 
 	// Stress slowly decreases if conditions are good
 	if b.Vitals.GetOverallWellbeing() > 0.7 {
-		b.Vitals.Stress -= deltaTime * 0.02
+		b.Vitals.Stress -= deltaTime * Decay.StressRecovery
 	} else {
 		// Stress increases if wellbeing is poor
-		b.Vitals.Stress += deltaTime * 0.01
+		b.Vitals.Stress += deltaTime * Decay.StressBuildup
 	}
 
 	// Health is affected by other vital stats
 	wellbeing := b.Vitals.GetOverallWellbeing()
 	if wellbeing < 0.4 {
-		b.Vitals.Health -= deltaTime * 0.01
+		b.Vitals.Health -= deltaTime * Decay.HealthLoss
 	} else if wellbeing > 0.8 {
 		// Slowly recover health when wellbeing is high
-		b.Vitals.Health += deltaTime * 0.005
+		b.Vitals.Health += deltaTime * Decay.HealthRecovery
 	}
 }
 
@@ -242,6 +239,27 @@ func (b *BiologicalSystems) GetAgeInDays() float64 {
 	return b.Processes.Age
 }
 
+// Life stage boundaries in days
+const (
+	JuvenileAge = 3.0
+	AdultAge    = 10.0
+	ElderAge    = 25.0
+)
+
+// GetLifeStage returns the pet's stage of development based on age
+func (b *BiologicalSystems) GetLifeStage() types.LifeStage {
+	switch age := b.Processes.Age; {
+	case age < JuvenileAge:
+		return types.LifeStageBaby
+	case age < AdultAge:
+		return types.LifeStageJuvenile
+	case age < ElderAge:
+		return types.LifeStageAdult
+	default:
+		return types.LifeStageElder
+	}
+}
+
 // CalculateLifespan estimates remaining lifespan based on current health
 func (b *BiologicalSystems) CalculateLifespan() float64 {
 	// Base lifespan of 30 days, modified by health
@@ -2,6 +2,8 @@ package biology
 
 import (
 	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
 )
 
 func TestNewVitalStats(t *testing.T) {
@@ -151,3 +153,21 @@ func TestGetAgeInDays(t *testing.T) {
 		t.Errorf("Expected age 5.5, got %.2f", age)
 	}
 }
+
+func TestGetLifeStage(t *testing.T) {
+	bio := NewBiologicalSystems()
+
+	stages := map[float64]types.LifeStage{
+		0.0:  types.LifeStageBaby,
+		5.0:  types.LifeStageJuvenile,
+		15.0: types.LifeStageAdult,
+		28.0: types.LifeStageElder,
+	}
+
+	for age, expected := range stages {
+		bio.Processes.Age = age
+		if stage := bio.GetLifeStage(); stage != expected {
+			t.Errorf("Age %.1f: expected %s, got %s", age, expected, stage)
+		}
+	}
+}
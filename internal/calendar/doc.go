@@ -0,0 +1,12 @@
+// Package calendar publishes a household's upcoming events as an
+// iCalendar feed that phone calendars can subscribe to.
+//
+// This package provides:
+//   - An iCalendar (RFC 5545) writer with escaping and line folding
+//   - Pet birthdays, active festivals and due vet visits as events
+//   - Conversion of game days to real time at the current time scale
+//   - A token-protected HTTP feed for server mode
+//
+// Event times assume game time keeps running at the current scale, so
+// subscribers see them move when the scale changes.
+package calendar
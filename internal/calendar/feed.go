@@ -0,0 +1,165 @@
+package calendar
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// Feed settings
+const (
+	FeedPath       = "/calendar.ics"     // Where the feed is served
+	DefaultHorizon = 14 * 24 * time.Hour // How far ahead events are listed
+	vetVisitLength = time.Hour           // Length of a due vet visit entry
+)
+
+// Event categories
+const (
+	CategoryBirthday = "Birthday"
+	CategoryFestival = "Festival"
+	CategoryVet      = "Vet"
+)
+
+// Collect lists the household's events from now until horizon has passed.
+// Game days are converted to real time at the loop's current time scale;
+// at the paused scale only events happening now are listed.
+func Collect(loop *game.GameLoop, now time.Time, horizon time.Duration) []Event {
+	gameDay := loop.Time.ConvertSimToRealTime(24 * time.Hour)
+	until := now.Add(horizon)
+	var events []Event
+
+	loop.Do(func() {
+		household := loop.Household
+		for id, pet := range household.Pets {
+			if !pet.IsAlive() {
+				continue
+			}
+			if household.VetDue(id) {
+				events = append(events, Event{
+					UID:         fmt.Sprintf("vet-%s@gochi", id),
+					Summary:     fmt.Sprintf("%s should see the vet", pet.Name),
+					Description: fmt.Sprintf("%s's health is at %.0f%%.", pet.Name, pet.Biology.Vitals.Health*100),
+					Category:    CategoryVet,
+					Start:       now,
+					End:         now.Add(vetVisitLength),
+				})
+			}
+			if gameDay == 0 {
+				continue
+			}
+
+			age := pet.GetAge()
+			for year := int(age/interaction.PetYearDays) + 1; ; year++ {
+				start := now.Add(days(float64(year)*interaction.PetYearDays-age, gameDay))
+				if start.After(until) {
+					break
+				}
+				events = append(events, Event{
+					UID:      fmt.Sprintf("birthday-%s-%d@gochi", id, year),
+					Summary:  fmt.Sprintf("%s turns %d", pet.Name, year),
+					Category: CategoryBirthday,
+					Start:    start,
+					End:      start.Add(gameDay),
+				})
+			}
+		}
+
+		if manager := household.Environment; manager != nil && gameDay != 0 {
+			for _, event := range manager.Events {
+				if event.Type != environment.EventFestival {
+					continue
+				}
+				name := event.Location
+				if location, ok := manager.World.Location(event.Location); ok {
+					name = location.Name
+				}
+				events = append(events, Event{
+					UID:      fmt.Sprintf("festival-%s-%g@gochi", event.Location, event.StartDay),
+					Summary:  "Festival in " + name,
+					Location: name,
+					Category: CategoryFestival,
+					Start:    now.Add(-days(manager.Day-event.StartDay, gameDay)),
+					End:      now.Add(days(event.EndDay-manager.Day, gameDay)),
+				})
+			}
+		}
+	})
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Start.Equal(events[j].Start) {
+			return events[i].Start.Before(events[j].Start)
+		}
+		return events[i].UID < events[j].UID
+	})
+	return events
+}
+
+// days converts game days to real time given the real length of a game day
+func days(n float64, gameDay time.Duration) time.Duration {
+	return time.Duration(n * float64(gameDay))
+}
+
+// Feed serves a game loop's calendar for calendar apps to subscribe to.
+// Calendar apps cannot send headers, so the secret is part of the URL.
+type Feed struct {
+	Loop    *game.GameLoop
+	Name    string
+	Token   string // Must be given as the token query parameter
+	Horizon time.Duration
+	now     func() time.Time
+}
+
+// NewFeed creates a calendar feed for a loop
+func NewFeed(loop *game.GameLoop, name, token string) *Feed {
+	return &Feed{Loop: loop, Name: name, Token: token, Horizon: DefaultHorizon, now: time.Now}
+}
+
+// Calendar builds the calendar as of now
+func (f *Feed) Calendar() *Calendar {
+	now := f.now()
+	return &Calendar{Name: f.Name, Stamp: now, Events: Collect(f.Loop, now, f.Horizon)}
+}
+
+// WriteFile writes the calendar to path, replacing the old file only once
+// the new one is complete
+func (f *Feed) WriteFile(path string) error {
+	var buf bytes.Buffer
+	f.Calendar().WriteTo(&buf)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// ServeHTTP answers a calendar app's request for the feed
+func (f *Feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	token := r.URL.Query().Get("token")
+	if f.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(f.Token)) != 1 {
+		http.Error(w, "calendar token required", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Method == http.MethodHead {
+		return
+	}
+	f.Calendar().WriteTo(w)
+}
@@ -0,0 +1,142 @@
+package calendar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestLoop creates a loop at 4x, where a game day lasts six real hours,
+// with one pet in a world whose town is holding a festival
+func newTestLoop(t *testing.T) (*game.GameLoop, *core.DigitalPet) {
+	t.Helper()
+	pet := core.NewDigitalPet("Mochi", "owner")
+	household := interaction.NewHousehold(pet)
+	household.Environment = environment.NewSeededManager(environment.DefaultWorldMap(), 1)
+	if _, err := household.Environment.StartEvent(environment.EventFestival, "town"); err != nil {
+		t.Fatal(err)
+	}
+	loop, err := game.NewGameLoop(config.Default().Simulation, household, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { loop.Shutdown(context.Background()) })
+	return loop, pet
+}
+
+// byCategory groups events by category
+func byCategory(events []Event) map[string][]Event {
+	groups := make(map[string][]Event)
+	for _, e := range events {
+		groups[e.Category] = append(groups[e.Category], e)
+	}
+	return groups
+}
+
+func TestCollect(t *testing.T) {
+	loop, pet := newTestLoop(t)
+	gameDay := 6 * time.Hour
+	events := byCategory(Collect(loop, testNow, 100*time.Hour))
+
+	birthdays := events[CategoryBirthday]
+	if len(birthdays) != 2 || birthdays[0].Summary != "Mochi turns 1" || birthdays[1].Summary != "Mochi turns 2" {
+		t.Fatalf("Expected two birthdays within 100 hours, got %+v", birthdays)
+	}
+	wantFirst := testNow.Add(time.Duration((interaction.PetYearDays - pet.GetAge()) * float64(gameDay)))
+	if d := birthdays[0].Start.Sub(wantFirst); d < -time.Second || d > time.Second {
+		t.Errorf("Expected the first birthday at %v, got %v", wantFirst, birthdays[0].Start)
+	}
+	if birthdays[0].End.Sub(birthdays[0].Start) != gameDay {
+		t.Errorf("Expected a birthday to last one game day, got %v", birthdays[0].End.Sub(birthdays[0].Start))
+	}
+
+	festivals := events[CategoryFestival]
+	if len(festivals) != 1 || !strings.HasPrefix(festivals[0].Summary, "Festival in ") || !festivals[0].End.Equal(testNow.Add(2*gameDay)) {
+		t.Errorf("Expected the town festival ending in two game days, got %+v", festivals)
+	}
+	if len(events[CategoryVet]) != 0 {
+		t.Errorf("Expected no vet visit for a healthy pet, got %+v", events[CategoryVet])
+	}
+}
+
+func TestCollectVetAndPausedScale(t *testing.T) {
+	loop, pet := newTestLoop(t)
+	loop.Do(func() {
+		pet.Biology.Thermoregulation.Condition = biology.ThermalHeatstroke
+		loop.Household.Update(0.001)
+	})
+	loop.Time.SetTimeScale(types.TimeScalePaused)
+
+	events := Collect(loop, testNow, DefaultHorizon)
+	if len(events) != 1 || events[0].Category != CategoryVet || !events[0].Start.Equal(testNow) || events[0].Summary != "Mochi should see the vet" {
+		t.Errorf("Expected only the vet visit while time is stopped, got %+v", events)
+	}
+}
+
+func TestFeedServesCalendar(t *testing.T) {
+	loop, _ := newTestLoop(t)
+	feed := NewFeed(loop, "Gochi", "s3cret")
+	feed.now = func() time.Time { return testNow }
+
+	for _, target := range []string{FeedPath, FeedPath + "?token=wrong"} {
+		rec := httptest.NewRecorder()
+		feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", target, rec.Code)
+		}
+	}
+	rec := httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, FeedPath+"?token=s3cret", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, FeedPath+"?token=s3cret", nil))
+	body := rec.Body.String()
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("Expected a calendar, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(body, "X-WR-CALNAME:Gochi") || !strings.Contains(body, "SUMMARY:Mochi turns 1") || !strings.Contains(body, "CATEGORIES:Festival") {
+		t.Errorf("Unexpected calendar:\n%s", body)
+	}
+
+	feed.Token = ""
+	rec = httptest.NewRecorder()
+	feed.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, FeedPath+"?token=", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected an empty token never to match, got %d", rec.Code)
+	}
+}
+
+func TestFeedWriteFile(t *testing.T) {
+	loop, _ := newTestLoop(t)
+	feed := NewFeed(loop, "Gochi", "")
+	feed.now = func() time.Time { return testNow }
+	path := filepath.Join(t.TempDir(), "gochi.ics")
+	if err := feed.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil || !strings.HasPrefix(string(written), "BEGIN:VCALENDAR") {
+		t.Errorf("Expected a calendar file, got %q (%v)", written, err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("The temporary file should be gone")
+	}
+}
@@ -0,0 +1,97 @@
+package calendar
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar settings
+const (
+	ProductID       = "-//Gochi//Pet Calendar//EN" // Identifies the generator to calendar apps
+	RefreshInterval = "PT1H"                       // How often subscribers should refetch
+	maxLineOctets   = 75                           // Longest content line before folding
+	timeFormat      = "20060102T150405Z"           // UTC date-time
+)
+
+// Event is one calendar entry
+type Event struct {
+	UID         string // Stable across feeds so apps update rather than duplicate
+	Summary     string
+	Description string
+	Location    string
+	Category    string
+	Start       time.Time
+	End         time.Time
+}
+
+// Calendar is an iCalendar document
+type Calendar struct {
+	Name   string
+	Stamp  time.Time // When the calendar was generated
+	Events []Event
+}
+
+// WriteTo writes the calendar in iCalendar format (RFC 5545)
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	line := func(name, value string) {
+		fold(&b, name+":"+value)
+	}
+	text := func(name, value string) {
+		if value != "" {
+			line(name, escapeText(value))
+		}
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", ProductID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	text("X-WR-CALNAME", c.Name)
+	line("REFRESH-INTERVAL;VALUE=DURATION", RefreshInterval)
+	line("X-PUBLISHED-TTL", RefreshInterval)
+	stamp := c.Stamp.UTC().Format(timeFormat)
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		text("UID", e.UID)
+		line("DTSTAMP", stamp)
+		line("DTSTART", e.Start.UTC().Format(timeFormat))
+		line("DTEND", e.End.UTC().Format(timeFormat))
+		text("SUMMARY", e.Summary)
+		text("DESCRIPTION", e.Description)
+		text("LOCATION", e.Location)
+		text("CATEGORIES", e.Category)
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// escapeText escapes a TEXT value
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// fold writes a content line, breaking it into lines of at most
+// maxLineOctets without splitting a character. Continuation lines start
+// with a space.
+func fold(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,79 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+	cal := &Calendar{
+		Name:  "Gochi",
+		Stamp: start,
+		Events: []Event{{
+			UID:         "birthday-1@gochi",
+			Summary:     "Mochi turns 2; cake, please",
+			Description: "line one\nline two \\ done",
+			Category:    CategoryBirthday,
+			Start:       start,
+			End:         start.Add(6 * time.Hour),
+		}},
+	}
+	var buf bytes.Buffer
+	if _, err := cal.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:Gochi\r\n",
+		"DTSTAMP:20260301T083000Z\r\n",
+		"DTSTART:20260301T083000Z\r\n",
+		"DTEND:20260301T143000Z\r\n",
+		`SUMMARY:Mochi turns 2\; cake\, please` + "\r\n",
+		`DESCRIPTION:line one\nline two \\ done` + "\r\n",
+		"CATEGORIES:Birthday\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "LOCATION") {
+		t.Error("Empty properties should be left out")
+	}
+}
+
+func TestFoldLongLines(t *testing.T) {
+	var b strings.Builder
+	long := "SUMMARY:" + strings.Repeat("é", 100)
+	fold(&b, long)
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("Expected the line to be folded, got %q", b.String())
+	}
+	var joined strings.Builder
+	for i, line := range lines {
+		if len(line) > maxLineOctets {
+			t.Errorf("Line %d has %d octets", i, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("Line %d splits a character: %q", i, line)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("Continuation line %d should start with a space", i)
+			}
+			line = line[1:]
+		}
+		joined.WriteString(line)
+	}
+	if joined.String() != long {
+		t.Error("Unfolding should give back the original line")
+	}
+}
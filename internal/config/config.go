@@ -0,0 +1,554 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrInvalidConfig is returned when a setting is malformed or out of range
+	ErrInvalidConfig = errors.New("invalid configuration")
+	// ErrUnknownTimeScale is returned when a time scale name is not recognised
+	ErrUnknownTimeScale = errors.New("unknown time scale")
+)
+
+// timeScaleNames maps configuration spellings to time scales
+var timeScaleNames = map[string]types.TimeScale{
+	"REAL_TIME":       types.TimeScaleRealTime,
+	"ACCELERATED_4X":  types.TimeScaleAccelerated4X,
+	"ACCELERATED_24X": types.TimeScaleAccelerated24X,
+	"PAUSED":          types.TimeScalePaused,
+}
+
+// logLevels lists the accepted logging levels
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// storageBackends lists where saves can be kept
+var storageBackends = []string{"disk", "memory"}
+
+// conflictResolutions lists how a sync may settle a pet changed on two devices
+var conflictResolutions = []string{"ask", "last-write-wins", "local", "cloud", "merge"}
+
+// reportDeliveries and reportFormats list the accepted report settings
+var (
+	reportDeliveries = []string{"file", "webhook", "smtp"}
+	reportFormats    = []string{"markdown", "html"}
+)
+
+// AppConfig identifies the running application
+type AppConfig struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Debug      bool   `yaml:"debug"`       // Dev mode: hot-reloads the tuning file
+	TuningFile string `yaml:"tuning_file"` // Balancing constants; empty uses the built-in values
+}
+
+// GameLoopConfig controls how fast the simulation runs
+type GameLoopConfig struct {
+	TimeScale        string `yaml:"time_scale"`         // REAL_TIME, ACCELERATED_4X, ACCELERATED_24X or PAUSED
+	TickRate         int    `yaml:"tick_rate"`          // Updates per second
+	AutoSaveInterval int    `yaml:"auto_save_interval"` // Seconds between auto-saves; 0 disables
+	IdleInterval     int    `yaml:"idle_interval"`      // Seconds between ticks while idle; 0 disables idling
+	IdleAfter        int    `yaml:"idle_after"`         // Seconds without input before idling
+}
+
+// DataConfig controls where and how pets are saved
+type DataConfig struct {
+	SavePath          string `yaml:"save_path"`
+	Backend           string `yaml:"backend"`            // disk, or memory to keep nothing once the process exits, e.g. for demos and CI
+	EncryptionEnabled bool   `yaml:"encryption_enabled"` // Encrypt saves with a passphrase kept in the OS keychain
+	CompressSaves     bool   `yaml:"compress_saves"`     // Write saves gzip-compressed; both forms are read
+	KeyringService    string `yaml:"keyring_service"`    // Keychain service the save passphrase and cloud token are stored under
+	WebhookURL        string `yaml:"webhook_url"`        // Receives each finished save, backup, sync and migration as JSON; empty disables it
+}
+
+// UIConfig controls the interactive prompt
+type UIConfig struct {
+	ThoughtInterval   int  `yaml:"thought_interval"`   // Seconds between pet thought bubbles; 0 disables them
+	CreationQuiz      bool `yaml:"creation_quiz"`      // Ask a personality quiz before creating the first pet
+	Spectator         bool `yaml:"spectator"`          // Show a read-only dashboard instead of the prompt, e.g. for streaming
+	SpectatorInterval int  `yaml:"spectator_interval"` // Seconds between spectator dashboard refreshes
+	Ambience          bool `yaml:"ambience"`           // Show ambience cues such as rain starting as lines of text
+	Qualitative       bool `yaml:"qualitative"`        // Describe vitals in words such as "looks a bit thin" instead of numbers and bars
+
+	ProgressiveUnlocks bool `yaml:"progressive_unlocks"` // Unlock advanced commands as pets reach milestones, with a tutorial for each
+}
+
+// EnvironmentConfig controls the world pets live in
+type EnvironmentConfig struct {
+	Biome     string `yaml:"biome"`     // Home biome name
+	Seed      int64  `yaml:"seed"`      // World and weather seed; 0 picks one at startup
+	Width     int    `yaml:"width"`     // World map width in cells
+	Height    int    `yaml:"height"`    // World map height in cells
+	Locations int    `yaml:"locations"` // Number of locations including home
+	Weather   bool   `yaml:"weather"`   // Whether weather is simulated
+	Events    bool   `yaml:"events"`    // Whether location events are simulated
+	Surprises bool   `yaml:"surprises"` // Whether pets have random surprise happenings
+}
+
+// HouseholdConfig controls how many pets the player keeps
+type HouseholdConfig struct {
+	PopulationCap int `yaml:"population_cap"` // Most pets kept at once; 0 for no cap
+}
+
+// CloudConfig controls cloud synchronisation and offsite backups
+type CloudConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	Endpoint     string `yaml:"endpoint"`
+	Account      string `yaml:"account"`       // Account pets are synced under; empty uses the profile name
+	Token        string `yaml:"token"`         // Bearer token sent to the endpoint
+	SyncInterval int    `yaml:"sync_interval"` // Seconds between syncs
+	Timeout      int    `yaml:"timeout"`       // Seconds before a cloud request is abandoned
+	Conflicts    string `yaml:"conflicts"`     // How a pet changed on two devices is settled: ask, last-write-wins, local, cloud or merge
+	Backups      bool   `yaml:"backups"`       // Upload every backup, and restore the latest onto a fresh profile
+	KeepBackups  int    `yaml:"keep_backups"`  // Remote backups kept; 0 keeps them all
+}
+
+// ServerConfig controls headless server mode
+type ServerConfig struct {
+	Listen     string `yaml:"listen"`      // Address of the admin API
+	AdminToken string `yaml:"admin_token"` // Bearer token for the admin API; required to serve
+	APIKeys    string `yaml:"api_keys"`    // Comma-separated name:role:token keys; roles are viewer, caretaker and admin
+
+	StallTimeout int `yaml:"stall_timeout"` // Seconds a tick may be overdue before the watchdog restarts the loop; 0 disables it
+	MaxRestarts  int `yaml:"max_restarts"`  // Stalls the watchdog recovers from before stopping the server
+}
+
+// DiscordConfig controls the Discord integration
+type DiscordConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	WebhookURL    string `yaml:"webhook_url"`    // Channel webhook for notifications; empty disables them
+	PublicKey     string `yaml:"public_key"`     // Application public key (hex); empty disables slash commands
+	ApplicationID string `yaml:"application_id"` // Needed to register slash commands
+	BotToken      string `yaml:"bot_token"`      // Needed to register slash commands
+}
+
+// MQTTConfig controls publishing pets to an MQTT broker for home automation
+type MQTTConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	Broker        string `yaml:"broker"` // host:port
+	ClientID      string `yaml:"client_id"`
+	Username      string `yaml:"username"` // Empty connects anonymously
+	Password      string `yaml:"password"`
+	StateTopic    string `yaml:"state_topic"`    // Retained pet state; {pet} is the pet's topic name
+	EventTopic    string `yaml:"event_topic"`    // Pet events; {event} is e.g. died or critical
+	CommandTopic  string `yaml:"command_topic"`  // Inbound commands; empty ignores all
+	AllowCommands string `yaml:"allow_commands"` // Comma-separated commands accepted, e.g. "feed,play"
+	StateInterval int    `yaml:"state_interval"` // Seconds between state messages
+	Retain        bool   `yaml:"retain"`         // Whether the broker keeps the last state
+}
+
+// ClockConfig aligns game time with the player's real clock and calendar
+type ClockConfig struct {
+	RealWorld   bool   `yaml:"real_world"`    // Day, night and seasons follow the real clock; time runs at REAL_TIME
+	TimeZone    string `yaml:"time_zone"`     // IANA name such as Europe/Paris; empty uses the system's
+	Hemisphere  string `yaml:"hemisphere"`    // north or south; decides which months are summer
+	CatchUpDays int    `yaml:"catch_up_days"` // Most time away simulated on start; 0 disables catching up
+}
+
+// Location returns the configured time zone
+func (c ClockConfig) Location() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.TimeZone)
+}
+
+// HomeHemisphere returns the configured hemisphere
+func (c ClockConfig) HomeHemisphere() (environment.Hemisphere, error) {
+	return environment.ParseHemisphere(c.Hemisphere)
+}
+
+// CalendarConfig controls the iCalendar feed of upcoming pet events
+type CalendarConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	File    string `yaml:"file"`    // Rewritten on every auto-save; empty disables
+	Token   string `yaml:"token"`   // Secret in the feed URL in server mode; empty disables the endpoint
+	Horizon int    `yaml:"horizon"` // Days of upcoming events listed
+}
+
+// ReportConfig controls the weekly summary of each pet's week
+type ReportConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	Delivery     string `yaml:"delivery"`      // file, webhook or smtp
+	Format       string `yaml:"format"`        // markdown or html
+	Dir          string `yaml:"dir"`           // Where file reports are written; empty uses the profile's reports directory
+	WebhookURL   string `yaml:"webhook_url"`   // Receives each report as JSON
+	SMTPServer   string `yaml:"smtp_server"`   // host:port
+	SMTPUsername string `yaml:"smtp_username"` // Empty sends without authenticating
+	SMTPPassword string `yaml:"smtp_password"`
+	From         string `yaml:"from"`
+	To           string `yaml:"to"` // Comma-separated recipients
+}
+
+// ScriptingConfig controls player-written reaction rules
+type ScriptingConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	File     string `yaml:"file"`      // Rules, one per line
+	MaxSteps int    `yaml:"max_steps"` // Evaluation steps allowed per rule and pet
+}
+
+// CrowdConfig controls crowd control, where stream viewers vote through
+// the admin API on how the pets are cared for
+type CrowdConfig struct {
+	Enabled            bool `yaml:"enabled"`
+	Round              int  `yaml:"round"`               // Seconds of voting before the winning action is applied
+	Cooldown           int  `yaml:"cooldown"`            // Seconds before the same action can be applied to a pet again
+	DisciplineCooldown int  `yaml:"discipline_cooldown"` // Seconds before a pet can be disciplined again
+}
+
+// LoggingConfig controls log output
+type LoggingConfig struct {
+	Level   string `yaml:"level"` // debug, info, warn or error
+	File    string `yaml:"file"`
+	Console bool   `yaml:"console"`
+}
+
+// Config is the complete application configuration
+type Config struct {
+	App         AppConfig         `yaml:"app"`
+	Simulation  GameLoopConfig    `yaml:"simulation"`
+	Clock       ClockConfig       `yaml:"clock"`
+	Data        DataConfig        `yaml:"data"`
+	Environment EnvironmentConfig `yaml:"environment"`
+	Household   HouseholdConfig   `yaml:"household"`
+	UI          UIConfig          `yaml:"ui"`
+	Cloud       CloudConfig       `yaml:"cloud"`
+	Server      ServerConfig      `yaml:"server"`
+	Discord     DiscordConfig     `yaml:"discord"`
+	MQTT        MQTTConfig        `yaml:"mqtt"`
+	Calendar    CalendarConfig    `yaml:"calendar"`
+	Report      ReportConfig      `yaml:"report"`
+	Scripting   ScriptingConfig   `yaml:"scripting"`
+	Crowd       CrowdConfig       `yaml:"crowd"`
+	Logging     LoggingConfig     `yaml:"logging"`
+}
+
+// Default returns the configuration used when no file is given
+func Default() *Config {
+	world := environment.DefaultWorldGenConfig(0)
+	return &Config{
+		App: AppConfig{
+			Name:    "Gochi",
+			Version: "0.1.0-alpha",
+		},
+		Simulation: GameLoopConfig{
+			TimeScale:        "ACCELERATED_4X",
+			TickRate:         60,
+			AutoSaveInterval: 300,
+			IdleInterval:     30,
+			IdleAfter:        60,
+		},
+		Clock: ClockConfig{
+			Hemisphere:  environment.HemisphereNorth.String(),
+			CatchUpDays: 3,
+		},
+		Data: DataConfig{
+			SavePath:          "./data/saves",
+			Backend:           "disk",
+			EncryptionEnabled: false,
+			CompressSaves:     true,
+			KeyringService:    "gochi",
+		},
+		Environment: EnvironmentConfig{
+			Biome:     environment.BiomeTemperate.String(),
+			Width:     world.Width,
+			Height:    world.Height,
+			Locations: world.Locations,
+			Weather:   true,
+			Events:    true,
+			Surprises: true,
+		},
+		UI: UIConfig{
+			ThoughtInterval:   90,
+			SpectatorInterval: 30,
+			Ambience:          true,
+		},
+		Cloud: CloudConfig{
+			SyncInterval: 600,
+			Timeout:      30,
+			Conflicts:    "ask",
+			KeepBackups:  7,
+		},
+		Server: ServerConfig{
+			Listen:       "127.0.0.1:8470",
+			StallTimeout: 60,
+			MaxRestarts:  3,
+		},
+		MQTT: MQTTConfig{
+			Broker:        "127.0.0.1:1883",
+			ClientID:      "gochi",
+			StateTopic:    "gochi/{pet}/state",
+			EventTopic:    "gochi/{pet}/event/{event}",
+			CommandTopic:  "gochi/{pet}/command",
+			StateInterval: 60,
+			Retain:        true,
+		},
+		Calendar: CalendarConfig{
+			Horizon: 14,
+		},
+		Report: ReportConfig{
+			Delivery: "file",
+			Format:   "markdown",
+		},
+		Scripting: ScriptingConfig{
+			File:     "configs/reactions.txt",
+			MaxSteps: 1000,
+		},
+		Household: HouseholdConfig{
+			PopulationCap: 8,
+		},
+		Crowd: CrowdConfig{
+			Round:              30,
+			Cooldown:           120,
+			DisciplineCooldown: 900,
+		},
+		Logging: LoggingConfig{
+			Level:   "info",
+			File:    "./data/logs/gochi.log",
+			Console: true,
+		},
+	}
+}
+
+// ParseTimeScale looks up a time scale by its configuration name, ignoring case
+func ParseTimeScale(name string) (types.TimeScale, error) {
+	scale, ok := timeScaleNames[strings.ToUpper(name)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownTimeScale, name)
+	}
+	return scale, nil
+}
+
+// TimeScaleName returns the configuration spelling of a time scale
+func TimeScaleName(scale types.TimeScale) string {
+	for name, s := range timeScaleNames {
+		if s == scale {
+			return name
+		}
+	}
+	return scale.String()
+}
+
+// Scale returns the configured time scale
+func (c GameLoopConfig) Scale() (types.TimeScale, error) {
+	return ParseTimeScale(c.TimeScale)
+}
+
+// HomeBiome returns the configured home biome
+func (c EnvironmentConfig) HomeBiome() (environment.Biome, error) {
+	return environment.ParseBiome(c.Biome)
+}
+
+// WorldGen returns the world generation settings for a seed
+func (c EnvironmentConfig) WorldGen(seed int64) environment.WorldGenConfig {
+	cfg := environment.DefaultWorldGenConfig(seed)
+	cfg.Width = c.Width
+	cfg.Height = c.Height
+	cfg.Locations = c.Locations
+	return cfg
+}
+
+// Validate checks every setting and returns all problems found, or nil
+func (c *Config) Validate() error {
+	var problems []error
+	report := func(key, format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf("%w: %s: %s", ErrInvalidConfig, key, fmt.Sprintf(format, args...)))
+	}
+
+	if _, err := c.Simulation.Scale(); err != nil {
+		report("simulation.time_scale", "%q is not one of REAL_TIME, ACCELERATED_4X, ACCELERATED_24X, PAUSED", c.Simulation.TimeScale)
+	}
+	if c.Simulation.TickRate < 1 || c.Simulation.TickRate > 1000 {
+		report("simulation.tick_rate", "%d must be between 1 and 1000", c.Simulation.TickRate)
+	}
+	if c.Simulation.AutoSaveInterval < 0 {
+		report("simulation.auto_save_interval", "%d must not be negative", c.Simulation.AutoSaveInterval)
+	}
+	if c.Simulation.IdleInterval < 0 || c.Simulation.IdleInterval > 3600 {
+		report("simulation.idle_interval", "%d must be between 0 and 3600", c.Simulation.IdleInterval)
+	}
+	if c.Simulation.IdleAfter < 0 {
+		report("simulation.idle_after", "%d must not be negative", c.Simulation.IdleAfter)
+	}
+
+	if _, err := c.Clock.Location(); err != nil {
+		report("clock.time_zone", "%q is not a known time zone", c.Clock.TimeZone)
+	}
+	if _, err := c.Clock.HomeHemisphere(); err != nil {
+		report("clock.hemisphere", "%q is not north or south", c.Clock.Hemisphere)
+	}
+	if c.Clock.CatchUpDays < 0 || c.Clock.CatchUpDays > 365 {
+		report("clock.catch_up_days", "%d must be between 0 and 365", c.Clock.CatchUpDays)
+	}
+
+	if strings.TrimSpace(c.Data.SavePath) == "" {
+		report("data.save_path", "must not be empty")
+	}
+	if !containsString(storageBackends, c.Data.Backend) {
+		report("data.backend", "%q is not one of %s", c.Data.Backend, strings.Join(storageBackends, ", "))
+	}
+	if strings.TrimSpace(c.Data.KeyringService) == "" {
+		report("data.keyring_service", "must not be empty")
+	}
+	if url := c.Data.WebhookURL; url != "" && !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		report("data.webhook_url", "%q must be an http or https URL", url)
+	}
+
+	if _, err := c.Environment.HomeBiome(); err != nil {
+		report("environment.biome", "%q is not a known biome", c.Environment.Biome)
+	}
+	if c.Environment.Width < 4 || c.Environment.Height < 4 {
+		report("environment.width", "map %dx%d must be at least 4x4", c.Environment.Width, c.Environment.Height)
+	}
+	if c.Environment.Locations < 1 {
+		report("environment.locations", "%d must be at least 1", c.Environment.Locations)
+	} else if c.Environment.Locations > c.Environment.Width*c.Environment.Height {
+		report("environment.locations", "%d do not fit on a %dx%d map", c.Environment.Locations, c.Environment.Width, c.Environment.Height)
+	}
+
+	if c.UI.ThoughtInterval < 0 {
+		report("ui.thought_interval", "%d must not be negative", c.UI.ThoughtInterval)
+	}
+	if c.UI.SpectatorInterval < 1 {
+		report("ui.spectator_interval", "%d must be at least 1", c.UI.SpectatorInterval)
+	}
+
+	if c.Cloud.Enabled || c.Cloud.Backups {
+		if !strings.HasPrefix(c.Cloud.Endpoint, "http://") && !strings.HasPrefix(c.Cloud.Endpoint, "https://") {
+			report("cloud.endpoint", "%q must be an http or https URL when cloud sync or backups are enabled", c.Cloud.Endpoint)
+		}
+	}
+	if c.Cloud.SyncInterval < 1 {
+		report("cloud.sync_interval", "%d must be at least 1", c.Cloud.SyncInterval)
+	}
+	if c.Cloud.Timeout < 1 {
+		report("cloud.timeout", "%d must be at least 1", c.Cloud.Timeout)
+	}
+	if !containsString(conflictResolutions, c.Cloud.Conflicts) {
+		report("cloud.conflicts", "%q is not one of %s", c.Cloud.Conflicts, strings.Join(conflictResolutions, ", "))
+	}
+	if c.Cloud.KeepBackups < 0 {
+		report("cloud.keep_backups", "%d must not be negative", c.Cloud.KeepBackups)
+	}
+
+	if strings.TrimSpace(c.Server.Listen) == "" {
+		report("server.listen", "must not be empty")
+	}
+	if c.Server.StallTimeout < 0 {
+		report("server.stall_timeout", "%d must not be negative", c.Server.StallTimeout)
+	}
+	if c.Server.MaxRestarts < 0 {
+		report("server.max_restarts", "%d must not be negative", c.Server.MaxRestarts)
+	}
+
+	if c.Discord.Enabled {
+		if c.Discord.WebhookURL == "" && c.Discord.PublicKey == "" {
+			report("discord.webhook_url", "a webhook URL or public key is needed when Discord is enabled")
+		}
+		if c.Discord.WebhookURL != "" && !strings.HasPrefix(c.Discord.WebhookURL, "https://") {
+			report("discord.webhook_url", "%q must be an https URL", c.Discord.WebhookURL)
+		}
+		if key := c.Discord.PublicKey; key != "" && (len(key) != 64 || strings.Trim(key, "0123456789abcdefABCDEF") != "") {
+			report("discord.public_key", "must be 64 hex digits")
+		}
+	}
+
+	if c.MQTT.Enabled {
+		if strings.TrimSpace(c.MQTT.Broker) == "" {
+			report("mqtt.broker", "must not be empty when MQTT is enabled")
+		}
+		if c.MQTT.ClientID == "" {
+			report("mqtt.client_id", "must not be empty when MQTT is enabled")
+		}
+		topics := []struct{ key, topic string }{
+			{"mqtt.state_topic", c.MQTT.StateTopic},
+			{"mqtt.event_topic", c.MQTT.EventTopic},
+			{"mqtt.command_topic", c.MQTT.CommandTopic},
+		}
+		for _, t := range topics {
+			if t.topic == "" && t.key == "mqtt.command_topic" {
+				continue // Commands are optional
+			}
+			if !containsString(strings.Split(t.topic, "/"), "{pet}") {
+				report(t.key, "%q must have {pet} as a topic level", t.topic)
+			} else if strings.ContainsAny(t.topic, "+#") {
+				report(t.key, "%q must not contain wildcards", t.topic)
+			}
+		}
+	}
+	if c.MQTT.StateInterval < 1 {
+		report("mqtt.state_interval", "%d must be at least 1", c.MQTT.StateInterval)
+	}
+
+	if c.Calendar.Enabled && c.Calendar.File == "" && c.Calendar.Token == "" {
+		report("calendar.file", "a file or token is needed when the calendar is enabled")
+	}
+	if c.Calendar.Horizon < 1 || c.Calendar.Horizon > 365 {
+		report("calendar.horizon", "%d must be between 1 and 365", c.Calendar.Horizon)
+	}
+
+	if !containsString(reportDeliveries, c.Report.Delivery) {
+		report("report.delivery", "%q is not one of %s", c.Report.Delivery, strings.Join(reportDeliveries, ", "))
+	}
+	if !containsString(reportFormats, c.Report.Format) {
+		report("report.format", "%q is not one of %s", c.Report.Format, strings.Join(reportFormats, ", "))
+	}
+	if c.Report.Enabled {
+		switch c.Report.Delivery {
+		case "webhook":
+			if !strings.HasPrefix(c.Report.WebhookURL, "https://") && !strings.HasPrefix(c.Report.WebhookURL, "http://") {
+				report("report.webhook_url", "%q must be an http or https URL", c.Report.WebhookURL)
+			}
+		case "smtp":
+			if strings.TrimSpace(c.Report.SMTPServer) == "" {
+				report("report.smtp_server", "must not be empty when reports are sent by smtp")
+			}
+			if c.Report.From == "" || c.Report.To == "" {
+				report("report.to", "a sender and recipients are needed when reports are sent by smtp")
+			}
+		}
+	}
+
+	if c.Scripting.Enabled && strings.TrimSpace(c.Scripting.File) == "" {
+		report("scripting.file", "must not be empty when scripting is enabled")
+	}
+	if c.Scripting.MaxSteps < 1 || c.Scripting.MaxSteps > 100000 {
+		report("scripting.max_steps", "%d must be between 1 and 100000", c.Scripting.MaxSteps)
+	}
+
+	if c.Household.PopulationCap < 0 {
+		report("household.population_cap", "%d must not be negative", c.Household.PopulationCap)
+	}
+
+	if c.Crowd.Round < 1 {
+		report("crowd.round", "%d must be at least 1", c.Crowd.Round)
+	}
+	if c.Crowd.Cooldown < 0 {
+		report("crowd.cooldown", "%d must not be negative", c.Crowd.Cooldown)
+	}
+	if c.Crowd.DisciplineCooldown < c.Crowd.Cooldown {
+		report("crowd.discipline_cooldown", "%d must be at least crowd.cooldown", c.Crowd.DisciplineCooldown)
+	}
+
+	if !containsString(logLevels, c.Logging.Level) {
+		report("logging.level", "%q is not one of %s", c.Logging.Level, strings.Join(logLevels, ", "))
+	}
+
+	return errors.Join(problems...)
+}
+
+// containsString returns true if list holds value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
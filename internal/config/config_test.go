@@ -0,0 +1,317 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestDefaultIsValid(t *testing.T) {
+	cfg := Default()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Default config should be valid, got %v", err)
+	}
+
+	scale, err := cfg.Simulation.Scale()
+	if err != nil || scale != types.TimeScaleAccelerated4X {
+		t.Errorf("Expected Accelerated4X, got %v (%v)", scale, err)
+	}
+	biome, err := cfg.Environment.HomeBiome()
+	if err != nil || biome != environment.BiomeTemperate {
+		t.Errorf("Expected Temperate home, got %v (%v)", biome, err)
+	}
+	if gen := cfg.Environment.WorldGen(7); gen.Seed != 7 || gen.Locations != cfg.Environment.Locations {
+		t.Errorf("WorldGen should carry the seed and location count, got %+v", gen)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Default()
+	cfg.Simulation.TimeScale = "WARP_SPEED"
+	cfg.Simulation.TickRate = 0
+	cfg.Simulation.IdleInterval = -1
+	cfg.Environment.Biome = "Moon"
+	cfg.Cloud.Enabled = true
+	cfg.Logging.Level = "loud"
+
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+	for _, key := range []string{"simulation.time_scale", "simulation.tick_rate", "simulation.idle_interval", "environment.biome", "cloud.endpoint", "logging.level"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s in:\n%v", key, err)
+		}
+	}
+	if lines := strings.Count(err.Error(), "\n") + 1; lines != 6 {
+		t.Errorf("Expected 6 problems, got %d:\n%v", lines, err)
+	}
+}
+
+func TestTimeScaleName(t *testing.T) {
+	for name := range timeScaleNames {
+		scale, _ := ParseTimeScale(name)
+		if got := TimeScaleName(scale); got != name {
+			t.Errorf("Expected %s, got %s", name, got)
+		}
+	}
+}
+
+func TestValidateDiscord(t *testing.T) {
+	cfg := Default()
+	cfg.Discord.Enabled = true
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "discord.webhook_url") {
+		t.Errorf("Expected Discord without a webhook or key to be refused, got %v", err)
+	}
+
+	cfg.Discord.WebhookURL = "http://discord.example/hook"
+	cfg.Discord.PublicKey = "not-hex"
+	err := cfg.Validate()
+	for _, key := range []string{"discord.webhook_url", "discord.public_key"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+
+	cfg.Discord.WebhookURL = "https://discord.example/hook"
+	cfg.Discord.PublicKey = strings.Repeat("ab", 32)
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a valid Discord setup, got %v", err)
+	}
+}
+
+func TestValidateMQTT(t *testing.T) {
+	cfg := Default()
+	cfg.MQTT.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected the default MQTT setup to be valid, got %v", err)
+	}
+
+	cfg.MQTT.Broker = ""
+	cfg.MQTT.StateTopic = "gochi/state"
+	cfg.MQTT.EventTopic = "gochi/{pet}/#"
+	cfg.MQTT.CommandTopic = "gochi/{pet}-cmd"
+	cfg.MQTT.StateInterval = 0
+	err := cfg.Validate()
+	for _, key := range []string{"mqtt.broker", "mqtt.state_topic", "mqtt.event_topic", "mqtt.command_topic", "mqtt.state_interval"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+
+	cfg = Default()
+	cfg.MQTT.Enabled = true
+	cfg.MQTT.CommandTopic = ""
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected commands to be optional, got %v", err)
+	}
+}
+
+func TestValidateCalendar(t *testing.T) {
+	cfg := Default()
+	cfg.Calendar.Enabled = true
+	cfg.Calendar.Horizon = 0
+	err := cfg.Validate()
+	for _, key := range []string{"calendar.file", "calendar.horizon"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+
+	cfg.Calendar.Token = "s3cret"
+	cfg.Calendar.Horizon = 30
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a feed with only a token to be valid, got %v", err)
+	}
+}
+
+func TestValidateReport(t *testing.T) {
+	cfg := Default()
+	cfg.Report.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected file reports to need nothing more, got %v", err)
+	}
+
+	cfg.Report.Delivery = "smtp"
+	cfg.Report.Format = "pdf"
+	err := cfg.Validate()
+	for _, key := range []string{"report.smtp_server", "report.to", "report.format"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+
+	cfg.Report.Delivery = "webhook"
+	cfg.Report.Format = "html"
+	cfg.Report.WebhookURL = "https://example.com/reports"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a webhook with a URL to be valid, got %v", err)
+	}
+}
+
+func TestValidateCloudBackups(t *testing.T) {
+	cfg := Default()
+	cfg.Cloud.Backups = true
+	cfg.Cloud.KeepBackups = -1
+	err := cfg.Validate()
+	for _, key := range []string{"cloud.endpoint", "cloud.keep_backups"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+
+	cfg.Cloud.Endpoint = "https://backup.example.com"
+	cfg.Cloud.KeepBackups = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected backups that keep everything to be valid, got %v", err)
+	}
+}
+
+func TestValidateThoughtInterval(t *testing.T) {
+	cfg := Default()
+	cfg.UI.ThoughtInterval = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "ui.thought_interval") {
+		t.Errorf("Expected a problem reported for ui.thought_interval, got %v", err)
+	}
+
+	cfg.UI.ThoughtInterval = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected thoughts to be allowed off, got %v", err)
+	}
+}
+
+func TestValidateSpectatorInterval(t *testing.T) {
+	cfg := Default()
+	cfg.UI.SpectatorInterval = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "ui.spectator_interval") {
+		t.Errorf("Expected a problem reported for ui.spectator_interval, got %v", err)
+	}
+}
+
+func TestValidateCrowd(t *testing.T) {
+	cfg := Default()
+	cfg.Crowd.Round = 0
+	cfg.Crowd.Cooldown = 600
+	cfg.Crowd.DisciplineCooldown = 300
+	err := cfg.Validate()
+	for _, key := range []string{"crowd.round", "crowd.discipline_cooldown"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+}
+
+func TestValidateDataBackend(t *testing.T) {
+	cfg := Default()
+	cfg.Data.Backend = "tape"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "data.backend") {
+		t.Errorf("Expected a problem reported for data.backend, got %v", err)
+	}
+	cfg.Data.Backend = "memory"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("The memory backend should be accepted, got %v", err)
+	}
+}
+
+func TestValidateDataWebhook(t *testing.T) {
+	cfg := Default()
+	cfg.Data.WebhookURL = "ftp://monitor.example.com"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "data.webhook_url") {
+		t.Errorf("Expected a problem reported for data.webhook_url, got %v", err)
+	}
+	cfg.Data.WebhookURL = "http://localhost:9000/gochi"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected an http webhook to be valid, got %v", err)
+	}
+}
+
+func TestValidateCloudConflicts(t *testing.T) {
+	cfg := Default()
+	cfg.Cloud.Conflicts = "coin-toss"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "cloud.conflicts") {
+		t.Errorf("Expected a problem reported for cloud.conflicts, got %v", err)
+	}
+	cfg.Cloud.Conflicts = "merge"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Merging should be accepted, got %v", err)
+	}
+}
+
+func TestValidateServerWatchdog(t *testing.T) {
+	cfg := Default()
+	cfg.Server.StallTimeout = -1
+	cfg.Server.MaxRestarts = -1
+	err := cfg.Validate()
+	for _, key := range []string{"server.stall_timeout", "server.max_restarts"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+}
+
+func TestValidateHousehold(t *testing.T) {
+	cfg := Default()
+	cfg.Household.PopulationCap = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "household.population_cap") {
+		t.Errorf("Expected a problem reported for household.population_cap, got %v", err)
+	}
+	cfg.Household.PopulationCap = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("A cap of 0 should turn the cap off, got %v", err)
+	}
+}
+
+func TestValidateClock(t *testing.T) {
+	cfg := Default()
+	if cfg.Clock.RealWorld {
+		t.Error("Real-world alignment should be off by default")
+	}
+	if loc, err := cfg.Clock.Location(); err != nil || loc != time.Local {
+		t.Errorf("Expected the system time zone by default, got %v (%v)", loc, err)
+	}
+
+	cfg.Clock.TimeZone = "Mars/Olympus_Mons"
+	cfg.Clock.Hemisphere = "east"
+	cfg.Clock.CatchUpDays = -1
+	err := cfg.Validate()
+	for _, key := range []string{"clock.time_zone", "clock.hemisphere", "clock.catch_up_days"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+
+	cfg.Clock = ClockConfig{RealWorld: true, TimeZone: "UTC", Hemisphere: "South"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a southern UTC clock to be valid, got %v", err)
+	}
+}
+
+func TestValidateScripting(t *testing.T) {
+	cfg := Default()
+	if cfg.Scripting.Enabled {
+		t.Error("Scripting should be disabled by default")
+	}
+	cfg.Scripting.Enabled = true
+	cfg.Scripting.File = " "
+	cfg.Scripting.MaxSteps = 0
+
+	err := cfg.Validate()
+	for _, key := range []string{"scripting.file", "scripting.max_steps"} {
+		if err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected a problem reported for %s, got %v", key, err)
+		}
+	}
+}
+
+func TestParseTimeScale(t *testing.T) {
+	if scale, err := ParseTimeScale("real_time"); err != nil || scale != types.TimeScaleRealTime {
+		t.Errorf("Expected RealTime, got %v (%v)", scale, err)
+	}
+	if _, err := ParseTimeScale("fast"); !errors.Is(err, ErrUnknownTimeScale) {
+		t.Errorf("Expected ErrUnknownTimeScale, got %v", err)
+	}
+}
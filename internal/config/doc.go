@@ -0,0 +1,11 @@
+// Package config loads application settings for Gochi.
+//
+// This package provides:
+//   - Typed configuration sections with sensible defaults
+//   - Loading from the YAML file in configs/config.yaml
+//   - Environment variable overrides (GOCHI_<SECTION>_<KEY>)
+//   - Validation that reports every invalid value before startup
+//
+// Settings are read without third-party dependencies, so only the simple
+// section/key/value layout of the shipped config file is supported.
+package config
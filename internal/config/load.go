@@ -0,0 +1,205 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts every environment variable that overrides a setting.
+// GOCHI_SIMULATION_TICK_RATE overrides simulation.tick_rate.
+const EnvPrefix = "GOCHI_"
+
+// Load reads a configuration file over the defaults, applies environment
+// overrides and validates the result. Every problem found is reported.
+func Load(path string) (*Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	cfg := Default()
+	return cfg, errors.Join(cfg.Decode(file), cfg.ApplyEnv(os.LookupEnv), cfg.Validate())
+}
+
+// Overlay reads a file over the current settings, such as a profile's own
+// settings over the shared file, then re-applies environment overrides so
+// they still take precedence and validates the result. A missing file
+// changes nothing.
+func (c *Config) Overlay(path string) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return errors.Join(c.Decode(file), c.ApplyEnv(os.LookupEnv), c.Validate())
+}
+
+// Decode reads YAML settings into the configuration. Only the subset used by
+// configs/config.yaml is understood: top-level sections holding scalar
+// key/value pairs. Sections the application does not use are skipped, but an
+// unknown key inside a known section is reported as a likely typo.
+func (c *Config) Decode(r io.Reader) error {
+	return decode(c, r)
+}
+
+// decode reads YAML sections into the struct pointed to by target, whose
+// fields are sections tagged with their yaml names
+func decode(target interface{}, r io.Reader) error {
+	var problems []error
+	report := func(line int, format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf("%w: line %d: %s", ErrInvalidConfig, line, fmt.Sprintf(format, args...)))
+	}
+
+	scanner := bufio.NewScanner(r)
+	section := ""
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := stripComment(scanner.Text())
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimSpace(raw), ":")
+		if !ok {
+			report(lineNo, "expected \"key: value\"")
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		indented := raw[0] == ' ' || raw[0] == '\t'
+		if !indented {
+			if value != "" {
+				report(lineNo, "top-level key %q must be a section", key)
+				section = ""
+				continue
+			}
+			section = key
+			continue
+		}
+		if section == "" {
+			report(lineNo, "key %q is outside any section", key)
+			continue
+		}
+
+		if err := set(target, section, key, unquote(value)); err != nil {
+			report(lineNo, "%v", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		problems = append(problems, err)
+	}
+	return errors.Join(problems...)
+}
+
+// ApplyEnv overrides settings from environment variables named
+// GOCHI_<SECTION>_<KEY>. lookup is usually os.LookupEnv.
+func (c *Config) ApplyEnv(lookup func(string) (string, bool)) error {
+	var problems []error
+	sections := reflect.ValueOf(c).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Type().Field(i).Tag.Get("yaml")
+		fields := sections.Field(i).Type()
+		for j := 0; j < fields.NumField(); j++ {
+			key := fields.Field(j).Tag.Get("yaml")
+			name := EnvPrefix + strings.ToUpper(section+"_"+key)
+			value, ok := lookup(name)
+			if !ok {
+				continue
+			}
+			if err := set(c, section, key, value); err != nil {
+				problems = append(problems, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, name, err))
+			}
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// set assigns a single setting from its text form. Unknown sections are
+// ignored so shared config files can carry settings for other tools.
+func set(target interface{}, section, key, value string) error {
+	sections := reflect.ValueOf(target).Elem()
+	group := fieldByTag(sections, section)
+	if !group.IsValid() {
+		return nil
+	}
+	field := fieldByTag(group, key)
+	if !field.IsValid() {
+		return fmt.Errorf("unknown setting %s.%s", section, key)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %q is not true or false", section, key, value)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %q is not a whole number", section, key, value)
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %q is not a number", section, key, value)
+		}
+		field.SetFloat(f)
+	}
+	return nil
+}
+
+// fieldByTag returns the struct field with a matching yaml tag
+func fieldByTag(v reflect.Value, tag string) reflect.Value {
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("yaml") == tag {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// stripComment removes a trailing # comment that is not inside quotes
+func stripComment(line string) string {
+	quote := byte(0)
+	for i := 0; i < len(line); i++ {
+		switch ch := line[i]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '#':
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return strings.TrimRight(line, " \t")
+}
+
+// unquote removes matching single or double quotes around a value
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeShippedConfig(t *testing.T) {
+	file, err := os.Open(filepath.Join("..", "..", "configs", "config.yaml"))
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer file.Close()
+
+	cfg := Default()
+	if err := cfg.Decode(file); err != nil {
+		t.Fatalf("Shipped config should decode, got %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Shipped config should be valid, got %v", err)
+	}
+	if cfg.App.Name != "Gochi" || !cfg.App.Debug {
+		t.Errorf("Expected app settings from file, got %+v", cfg.App)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	input := `
+# comment
+simulation:
+  time_scale: "REAL_TIME"  # trailing comment
+  tick_rate: 30
+environment:
+  biome: 'Desert # Dunes'
+  weather: false
+biology:
+  hunger_decay_rate: 0.3
+`
+	cfg := Default()
+	if err := cfg.Decode(strings.NewReader(input)); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if cfg.Simulation.TimeScale != "REAL_TIME" || cfg.Simulation.TickRate != 30 {
+		t.Errorf("Expected simulation overrides, got %+v", cfg.Simulation)
+	}
+	if cfg.Environment.Biome != "Desert # Dunes" || cfg.Environment.Weather {
+		t.Errorf("Expected quoted biome and weather off, got %+v", cfg.Environment)
+	}
+	if cfg.Simulation.AutoSaveInterval != 300 {
+		t.Error("Settings missing from the file should keep their defaults")
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	input := `
+simulation:
+  tick_rate: fast
+  tik_rate: 30
+data: ./saves
+cloud:
+  enabled: maybe
+`
+	err := Default().Decode(strings.NewReader(input))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+	for _, want := range []string{"line 3", "line 4", "line 5", "line 7"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected a problem at %s in:\n%v", want, err)
+		}
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"GOCHI_SIMULATION_TICK_RATE": "15",
+		"GOCHI_CLOUD_ENABLED":        "true",
+		"GOCHI_ENVIRONMENT_SEED":     "oops",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	cfg := Default()
+	err := cfg.ApplyEnv(lookup)
+	if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), "GOCHI_ENVIRONMENT_SEED") {
+		t.Errorf("Expected the bad seed to be reported, got %v", err)
+	}
+	if cfg.Simulation.TickRate != 15 || !cfg.Cloud.Enabled {
+		t.Errorf("Expected env overrides to apply, got %+v %+v", cfg.Simulation, cfg.Cloud)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("logging:\n  level: chatty\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected invalid level to be reported, got %v", err)
+	}
+
+	t.Setenv("GOCHI_LOGGING_LEVEL", "warn")
+	cfg, err := Load(path)
+	if err != nil || cfg.Logging.Level != "warn" {
+		t.Errorf("Environment should override the file, got %v (%v)", cfg.Logging.Level, err)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "profile.yaml")
+	os.WriteFile(path, []byte("cloud:\n  account: alice\nsimulation:\n  tick_rate: 20\n"), 0o644)
+
+	cfg := Default()
+	if err := cfg.Overlay(filepath.Join(dir, "missing.yaml")); err != nil {
+		t.Fatalf("A missing overlay should change nothing, got %v", err)
+	}
+	if err := cfg.Overlay(path); err != nil {
+		t.Fatalf("Overlay failed: %v", err)
+	}
+	if cfg.Cloud.Account != "alice" || cfg.Simulation.TickRate != 20 || cfg.Environment.Width != Default().Environment.Width {
+		t.Errorf("Expected overlaid settings over the rest, got %+v", cfg)
+	}
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+)
+
+// TuningPollInterval is how often dev mode checks the tuning file for edits
+const TuningPollInterval = 2 * time.Second
+
+// GeneticsTuning holds breeding parameters
+type GeneticsTuning struct {
+	MutationRate float64 `yaml:"mutation_rate"`
+}
+
+// TuningParameters gathers the balancing constants that can be changed
+// without recompiling. Every value is a rate or effect size of at least zero.
+type TuningParameters struct {
+	Biology     biology.DecayRates      `yaml:"biology"`
+	Interaction core.InteractionEffects `yaml:"interaction"`
+	Genetics    GeneticsTuning          `yaml:"genetics"`
+}
+
+// DefaultTuning returns the built-in tuning values
+func DefaultTuning() *TuningParameters {
+	return &TuningParameters{
+		Biology:     biology.DefaultDecayRates(),
+		Interaction: core.DefaultInteractionEffects(),
+		Genetics:    GeneticsTuning{MutationRate: genetics.DefaultMutationRate},
+	}
+}
+
+// CurrentTuning returns the tuning values the running systems are using
+func CurrentTuning() *TuningParameters {
+	return &TuningParameters{
+		Biology:     biology.Decay,
+		Interaction: core.Effects,
+		Genetics:    GeneticsTuning{MutationRate: genetics.MutationRate},
+	}
+}
+
+// LoadTuning reads a tuning file over the defaults and validates it
+func LoadTuning(path string) (*TuningParameters, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	t := DefaultTuning()
+	if err := errors.Join(decode(t, file), t.Validate()); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Validate checks that every value is a finite, non-negative number and
+// that rates applied as probabilities or per-day fractions do not exceed 1
+func (t *TuningParameters) Validate() error {
+	var problems []error
+	for _, value := range t.values() {
+		if math.IsNaN(value.Value) || math.IsInf(value.Value, 0) || value.Value < 0 {
+			problems = append(problems, fmt.Errorf("%w: %s: %v must be a non-negative number", ErrInvalidConfig, value.Key, value.Value))
+		}
+	}
+	if t.Genetics.MutationRate > 1 {
+		problems = append(problems, fmt.Errorf("%w: genetics.mutation_rate: %v must be at most 1", ErrInvalidConfig, t.Genetics.MutationRate))
+	}
+	return errors.Join(problems...)
+}
+
+// Apply installs the tuning values into the running systems and returns
+// what changed. Callers must make sure no simulation update is running.
+func (t *TuningParameters) Apply() []TuningChange {
+	changes := DiffTuning(CurrentTuning(), t)
+	biology.Decay = t.Biology
+	core.Effects = t.Interaction
+	genetics.MutationRate = t.Genetics.MutationRate
+	return changes
+}
+
+// TuningChange records one tuning value being changed
+type TuningChange struct {
+	Key string
+	Old float64
+	New float64
+}
+
+// String returns the change as "section.key: old -> new"
+func (c TuningChange) String() string {
+	return fmt.Sprintf("%s: %g -> %g", c.Key, c.Old, c.New)
+}
+
+// DiffTuning lists the values that differ between two tunings
+func DiffTuning(before, after *TuningParameters) []TuningChange {
+	old := before.values()
+	var changes []TuningChange
+	for i, value := range after.values() {
+		if value.Value != old[i].Value {
+			changes = append(changes, TuningChange{Key: value.Key, Old: old[i].Value, New: value.Value})
+		}
+	}
+	return changes
+}
+
+// tuningValue is a single named tuning value
+type tuningValue struct {
+	Key   string
+	Value float64
+}
+
+// values flattens the tuning into section.key values in declaration order
+func (t *TuningParameters) values() []tuningValue {
+	var values []tuningValue
+	sections := reflect.ValueOf(t).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Type().Field(i).Tag.Get("yaml")
+		fields := sections.Field(i)
+		for j := 0; j < fields.NumField(); j++ {
+			values = append(values, tuningValue{
+				Key:   section + "." + fields.Type().Field(j).Tag.Get("yaml"),
+				Value: fields.Field(j).Float(),
+			})
+		}
+	}
+	return values
+}
+
+// TuningWatcher reloads a tuning file whenever it is modified and logs
+// every value that changes, so a balancing session can be reproduced
+type TuningWatcher struct {
+	Path string
+	Log  *log.Logger
+	// Apply runs the installation of new values; the game loop passes its
+	// Do method so values never change mid-update. Nil runs it directly.
+	Apply func(func())
+
+	modTime time.Time
+}
+
+// NewTuningWatcher creates a watcher for a tuning file
+func NewTuningWatcher(path string, logger *log.Logger) *TuningWatcher {
+	return &TuningWatcher{Path: path, Log: logger}
+}
+
+// Check reloads the file if it changed since the last check and applies
+// it. An invalid file is logged and the running values are kept.
+func (w *TuningWatcher) Check() ([]TuningChange, error) {
+	info, err := os.Stat(w.Path)
+	if err != nil {
+		return nil, err
+	}
+	if info.ModTime().Equal(w.modTime) {
+		return nil, nil
+	}
+	w.modTime = info.ModTime()
+
+	tuning, err := LoadTuning(w.Path)
+	if err != nil {
+		w.Log.Printf("tuning: keeping current values, %s is invalid: %v", w.Path, err)
+		return nil, err
+	}
+
+	var changes []TuningChange
+	install := func() { changes = tuning.Apply() }
+	if w.Apply != nil {
+		w.Apply(install)
+	} else {
+		install()
+	}
+
+	for _, change := range changes {
+		w.Log.Printf("tuning: %s", change)
+	}
+	return changes, nil
+}
+
+// Run checks the file every TuningPollInterval until ctx is cancelled
+func (w *TuningWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(TuningPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Check()
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+)
+
+func TestShippedTuningMatchesDefaults(t *testing.T) {
+	tuning, err := LoadTuning(filepath.Join("..", "..", "configs", "tuning.yaml"))
+	if err != nil {
+		t.Fatalf("LoadTuning failed: %v", err)
+	}
+	if changes := DiffTuning(DefaultTuning(), tuning); len(changes) != 0 {
+		t.Errorf("Shipped tuning should match the built-in values, differs in %v", changes)
+	}
+}
+
+func TestTuningValidate(t *testing.T) {
+	tuning := DefaultTuning()
+	tuning.Biology.Hydration = -0.1
+	tuning.Genetics.MutationRate = 2
+
+	err := tuning.Validate()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Expected ErrInvalidConfig, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "biology.hydration_decay") || !strings.Contains(err.Error(), "genetics.mutation_rate") {
+		t.Errorf("Expected both problems reported, got %v", err)
+	}
+}
+
+func TestTuningWatcherHotReload(t *testing.T) {
+	t.Cleanup(func() { DefaultTuning().Apply() })
+
+	path := filepath.Join(t.TempDir(), "tuning.yaml")
+	os.WriteFile(path, []byte("biology:\n  hydration_decay: 0.08\n"), 0o644)
+
+	var logged bytes.Buffer
+	applied := 0
+	watcher := NewTuningWatcher(path, log.New(&logged, "", 0))
+	watcher.Apply = func(install func()) {
+		applied++
+		install()
+	}
+
+	changes, err := watcher.Check()
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Key != "biology.hydration_decay" || biology.Decay.Hydration != 0.08 {
+		t.Errorf("Expected hydration decay to change to 0.08, got %v", changes)
+	}
+	if applied != 1 || !strings.Contains(logged.String(), "biology.hydration_decay: 0.05 -> 0.08") {
+		t.Errorf("Expected the change to be applied and logged, got %q", logged.String())
+	}
+
+	if changes, _ := watcher.Check(); changes != nil {
+		t.Error("An unmodified file should not be reloaded")
+	}
+
+	// An invalid edit is logged and the running values are kept
+	os.WriteFile(path, []byte("genetics:\n  mutation_rate: lots\n"), 0o644)
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Second))
+	if _, err := watcher.Check(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Expected ErrInvalidConfig, got %v", err)
+	}
+	if genetics.MutationRate != genetics.DefaultMutationRate || !strings.Contains(logged.String(), "keeping current values") {
+		t.Error("An invalid tuning file should leave running values alone")
+	}
+}
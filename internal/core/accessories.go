@@ -0,0 +1,72 @@
+package core
+
+import (
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// Accessory reaction settings
+const (
+	AccessoryFussThreshold  = 0.7  // Independence above which a pet fusses about anything on its head
+	AccessoryProudThreshold = 0.6  // Average extraversion and playfulness above which a pet shows off
+	AccessoryShakeOffChance = 0.25 // Chance per game day that a fussy pet shakes off its head accessory
+)
+
+// AccessoryReaction is how a pet feels about what it is wearing
+type AccessoryReaction int
+
+const (
+	ReactionIndifferent AccessoryReaction = iota
+	ReactionProud                         // Shows it off to everyone
+	ReactionFussy                         // Paws at it and may shake it off
+)
+
+// String returns the string representation of AccessoryReaction
+func (ar AccessoryReaction) String() string {
+	return [...]string{"indifferent", "proud", "fussy"}[ar]
+}
+
+// Wearing returns the accessory a pet wears in a slot, if any
+func (p *DigitalPet) Wearing(slot environment.AccessorySlot) (environment.Accessory, bool) {
+	accessory, worn := p.Accessories[slot]
+	return accessory, worn
+}
+
+// Equip puts an accessory on the pet, returning the one it replaces in the
+// same slot, if any
+func (p *DigitalPet) Equip(accessory environment.Accessory) (environment.Accessory, bool) {
+	if p.Accessories == nil {
+		p.Accessories = make(map[environment.AccessorySlot]environment.Accessory)
+	}
+	previous, replaced := p.Accessories[accessory.Slot()]
+	p.Accessories[accessory.Slot()] = accessory
+	return previous, replaced
+}
+
+// Unequip takes off whatever the pet wears in a slot
+func (p *DigitalPet) Unequip(slot environment.AccessorySlot) (environment.Accessory, bool) {
+	accessory, worn := p.Accessories[slot]
+	delete(p.Accessories, slot)
+	return accessory, worn
+}
+
+// FussesAbout returns true if the pet dislikes wearing an accessory.
+// Independent pets will not tolerate anything on their heads.
+func (p *DigitalPet) FussesAbout(accessory environment.Accessory) bool {
+	return accessory.Slot() == environment.SlotHead && p.Personality.Traits.Independence > AccessoryFussThreshold
+}
+
+// ReactToAccessory returns how the pet feels about an accessory from its
+// personality and nudges its mood to match. Outgoing, playful pets love
+// to show off.
+func (p *DigitalPet) ReactToAccessory(accessory environment.Accessory) AccessoryReaction {
+	traits := p.Personality.Traits
+	switch {
+	case p.FussesAbout(accessory):
+		p.Emotions.Anger = clamp(p.Emotions.Anger+0.05, 0.0, 1.0)
+		return ReactionFussy
+	case (traits.Extraversion+traits.Playfulness)/2.0 > AccessoryProudThreshold:
+		p.Emotions.Joy = clamp(p.Emotions.Joy+0.05, 0.0, 1.0)
+		return ReactionProud
+	}
+	return ReactionIndifferent
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestEquipReplacesSameSlot(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	if _, replaced := pet.Equip(environment.AccessoryHat); replaced {
+		t.Error("Nothing should be replaced on a bare pet")
+	}
+	pet.Equip(environment.AccessoryCollar)
+	previous, replaced := pet.Equip(environment.AccessoryBow)
+	if !replaced || previous != environment.AccessoryHat {
+		t.Errorf("Expected the bow to replace the hat, got %v (%v)", previous, replaced)
+	}
+	if worn, _ := pet.Wearing(environment.SlotNeck); worn != environment.AccessoryCollar {
+		t.Errorf("Expected the collar kept on, got %v", worn)
+	}
+
+	if removed, worn := pet.Unequip(environment.SlotHead); !worn || removed != environment.AccessoryBow {
+		t.Errorf("Expected the bow taken off, got %v (%v)", removed, worn)
+	}
+	if _, worn := pet.Wearing(environment.SlotHead); worn {
+		t.Error("Expected nothing left on the head")
+	}
+}
+
+func TestAccessoriesPersist(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Equip(environment.AccessoryScarf)
+
+	data, err := json.Marshal(pet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded DigitalPet
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if worn, _ := loaded.Wearing(environment.SlotNeck); worn != environment.AccessoryScarf {
+		t.Errorf("Expected the scarf to survive a save, got %v", worn)
+	}
+}
+
+func TestReactToAccessory(t *testing.T) {
+	loner := NewDigitalPet("Loner", "user123")
+	loner.Personality.Traits.Independence = 0.9
+	if reaction := loner.ReactToAccessory(environment.AccessoryHat); reaction != ReactionFussy {
+		t.Errorf("Expected an independent pet to fuss about a hat, got %v", reaction)
+	}
+
+	showoff := NewDigitalPet("Showoff", "user123")
+	showoff.Personality.Traits.Independence = 0.2
+	showoff.Personality.Traits.Extraversion = 0.9
+	showoff.Personality.Traits.Playfulness = 0.9
+	joy := showoff.Emotions.Joy
+	if reaction := showoff.ReactToAccessory(environment.AccessoryHat); reaction != ReactionProud {
+		t.Errorf("Expected an outgoing pet to be proud, got %v", reaction)
+	}
+	if showoff.Emotions.Joy <= joy && joy < 1.0 {
+		t.Error("Showing off should lift the pet's joy")
+	}
+
+	calm := NewDigitalPet("Calm", "user123")
+	calm.Personality.Traits.Independence = 0.9
+	calm.Personality.Traits.Extraversion = 0.2
+	calm.Personality.Traits.Playfulness = 0.2
+	if reaction := calm.ReactToAccessory(environment.AccessoryCollar); reaction != ReactionIndifferent {
+		t.Errorf("Expected indifference to a collar, got %v", reaction)
+	}
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// Climate comfort settings
+const (
+	ColdDiscomfortTemperature = 0.0  // °C below which a pet starts to feel the cold
+	HeatDiscomfortTemperature = 28.0 // °C above which a pet starts to feel the heat
+	AcclimatizationWeight     = 0.6  // Share of a poor affinity that acclimatization can make up
+)
+
+// BiomeAffinity returns how well suited the pet is to a biome (0.0 to 1.0),
+// combining its genetic affinity with how acclimatized it has become
+func (p *DigitalPet) BiomeAffinity(biome environment.Biome) float64 {
+	innate := p.Genome.BiomeAffinity(biome)
+	return innate + (1.0-innate)*AcclimatizationWeight*p.Acclimatization.Level(biome)
+}
+
+// ClimateDiscomfort returns how uncomfortable the current temperature is
+// for this pet (0.0 to 1.0) and whether it is the cold that bothers it
+func (p *DigitalPet) ClimateDiscomfort(effects environment.WeatherEffects) (discomfort float64, cold bool) {
+	tolerance := p.Genome.ClimateTolerance()
+	adaptation := 1.0 - AcclimatizationWeight*p.Acclimatization.Level(effects.Biome)
+
+	if effects.Temperature < ColdDiscomfortTemperature {
+		chill := clamp((ColdDiscomfortTemperature-effects.Temperature)/20.0, 0.0, 1.0)
+		return chill * (1.0 - tolerance.Cold) * adaptation, true
+	}
+	if effects.Temperature > HeatDiscomfortTemperature {
+		heat := clamp((effects.Temperature-HeatDiscomfortTemperature)/15.0, 0.0, 1.0)
+		return heat * (1.0 - tolerance.Heat) * adaptation, false
+	}
+	return 0.0, false
+}
+
+// adaptWeather personalizes weather effects for the pet's climate
+// tolerance: cold makes it seek shelter and heat makes it sluggish
+func (p *DigitalPet) adaptWeather(effects environment.WeatherEffects) environment.WeatherEffects {
+	discomfort, cold := p.ClimateDiscomfort(effects)
+	if cold {
+		effects.ShelterUrge = clamp(effects.ShelterUrge+discomfort, 0.0, 1.0)
+	} else {
+		effects.Lethargy = clamp(effects.Lethargy+discomfort, 0.0, 1.0)
+	}
+	return effects
+}
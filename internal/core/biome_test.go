@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestColdSendsThinCoatedPetsToShelter(t *testing.T) {
+	blizzard := environment.WeatherEffects{Season: types.SeasonWinter, Biome: environment.BiomeArctic, Temperature: -25.0}
+
+	thin := NewDigitalPet("Thin", "user123")
+	setGene(thin.Genome, "coat_length", 0.0)
+	thin.CurrentBehavior = types.BehaviorPlaying
+	thin.ReactToWeather(blizzard, 0.01)
+	if thin.CurrentBehavior != types.BehaviorSheltering {
+		t.Errorf("Short-haired pet should shelter from the cold, got %s", thin.CurrentBehavior)
+	}
+
+	fluffy := NewDigitalPet("Fluffy", "user123")
+	fluffy.Personality.Traits.EnergyLevel = 1.0
+	setGene(fluffy.Genome, "coat_length", 1.0)
+	fluffy.CurrentBehavior = types.BehaviorPlaying
+	fluffy.ReactToWeather(blizzard, 0.01)
+	if fluffy.CurrentBehavior != types.BehaviorPlaying {
+		t.Errorf("Long-haired pet should shrug off the cold, got %s", fluffy.CurrentBehavior)
+	}
+}
+
+func TestAcclimatizationImprovesAffinity(t *testing.T) {
+	pet := NewDigitalPet("Nomad", "user123")
+	setGene(pet.Genome, "coat_length", 0.0)
+	before := pet.BiomeAffinity(environment.BiomeArctic)
+	discomfortBefore, _ := pet.ClimateDiscomfort(environment.WeatherEffects{Biome: environment.BiomeArctic, Temperature: -20.0})
+
+	pet.Acclimatization.Update(environment.BiomeArctic, environment.AcclimatizationDays)
+
+	if pet.BiomeAffinity(environment.BiomeArctic) <= before {
+		t.Error("Living in a biome should improve affinity for it")
+	}
+	discomfortAfter, _ := pet.ClimateDiscomfort(environment.WeatherEffects{Biome: environment.BiomeArctic, Temperature: -20.0})
+	if discomfortAfter >= discomfortBefore {
+		t.Error("Acclimatized pets should feel the climate less")
+	}
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrIncompatibleParents is returned when two pets cannot breed
+var ErrIncompatibleParents = errors.New("parents are not compatible for breeding")
+
+// CheckBreedingCompatibility evaluates whether two pets can breed using
+// the default kinship policy
+func CheckBreedingCompatibility(pet1, pet2 *DigitalPet) genetics.BreedingCompatibility {
+	return CheckBreedingCompatibilityWithPolicy(pet1, pet2, genetics.DefaultKinshipPolicy())
+}
+
+// CheckBreedingCompatibilityWithPolicy evaluates whether two pets can breed,
+// applying the given kinship policy to their pedigree relatedness
+func CheckBreedingCompatibilityWithPolicy(pet1, pet2 *DigitalPet, policy genetics.KinshipPolicy) genetics.BreedingCompatibility {
+	compat := genetics.CalculateCompatibility(pet1.Genome, pet2.Genome)
+
+	if pet1.ID == pet2.ID {
+		compat.Block("a pet cannot breed with itself")
+	}
+
+	if !pet1.IsAlive() || !pet2.IsAlive() {
+		compat.Block("both parents must be alive")
+	}
+
+	// Direct parent/offspring pairs are never allowed
+	if rel, exists := pet1.Relationships.GetRelationship(pet2.ID); exists {
+		if rel.Type == types.RelationshipParent || rel.Type == types.RelationshipOffspring {
+			compat.Block("parent and offspring cannot breed")
+		}
+	}
+
+	if pet1.ID != pet2.ID {
+		policy.Apply(&compat, genetics.Relatedness(pet1.Pedigree, pet2.Pedigree))
+	}
+
+	return compat
+}
+
+// Breed creates an offspring from two compatible parents using the
+// default kinship policy
+func Breed(parent1, parent2 *DigitalPet, name string) (*DigitalPet, error) {
+	return BreedWithPolicy(parent1, parent2, name, genetics.DefaultKinshipPolicy())
+}
+
+// BreedWithPolicy creates an offspring from two parents that are
+// compatible under the given kinship policy
+func BreedWithPolicy(parent1, parent2 *DigitalPet, name string, policy genetics.KinshipPolicy) (*DigitalPet, error) {
+	compat := CheckBreedingCompatibilityWithPolicy(parent1, parent2, policy)
+	if !compat.CanBreed {
+		return nil, fmt.Errorf("%w: %s", ErrIncompatibleParents, strings.Join(compat.Reasons, "; "))
+	}
+
+	genome := genetics.Breed(parent1.Genome, parent2.Genome, genetics.MutationRate)
+	return offspring(parent1, parent2, name, genome), nil
+}
+
+// BreedLitter creates one offspring per name from two parents that are
+// compatible under the given kinship policy. Each offspring is crossed over
+// independently, so siblings differ.
+func BreedLitter(parent1, parent2 *DigitalPet, names []string, policy genetics.KinshipPolicy) ([]*DigitalPet, error) {
+	compat := CheckBreedingCompatibilityWithPolicy(parent1, parent2, policy)
+	if !compat.CanBreed {
+		return nil, fmt.Errorf("%w: %s", ErrIncompatibleParents, strings.Join(compat.Reasons, "; "))
+	}
+
+	genomes := genetics.BreedLitter(parent1.Genome, parent2.Genome, len(names), genetics.MutationRate)
+	litter := make([]*DigitalPet, len(names))
+	for i, genome := range genomes {
+		litter[i] = offspring(parent1, parent2, names[i], genome)
+	}
+	return litter, nil
+}
+
+// offspring creates a child with the given genome and records the family
+// relationships on both sides
+func offspring(parent1, parent2 *DigitalPet, name string, genome *genetics.Genome) *DigitalPet {
+	child := newDigitalPet(name, parent1.Owner, genome)
+	child.Personality.Traits = genome.ExpressTraits()
+	child.Pedigree = genetics.NewPedigree(child.ID, name, genome.Generation, parent1.Pedigree, parent2.Pedigree)
+
+	parent1.Relationships.AddRelationship(child.ID, types.RelationshipOffspring)
+	parent2.Relationships.AddRelationship(child.ID, types.RelationshipOffspring)
+	child.Relationships.AddRelationship(parent1.ID, types.RelationshipParent)
+	child.Relationships.AddRelationship(parent2.ID, types.RelationshipParent)
+	return child
+}
+
+// ScreenGenetics performs a genetic screening and records the result. The
+// pet itself pays nothing; a screening asked for through the household is
+// charged genetics.ScreeningCost from its coins.
+func (p *DigitalPet) ScreenGenetics() genetics.ScreeningReport {
+	report := genetics.Screen(p.Genome)
+	p.Screening = &report
+	return report
+}
+
+// AnalyzePopulation builds a population genetics analyzer for a group of pets
+func AnalyzePopulation(pets []*DigitalPet) *genetics.PopulationAnalyzer {
+	genomes := make([]*genetics.Genome, 0, len(pets))
+	for _, pet := range pets {
+		if pet.Genome != nil {
+			genomes = append(genomes, pet.Genome)
+		}
+	}
+	return genetics.NewPopulationAnalyzer(genomes)
+}
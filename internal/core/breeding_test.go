@@ -0,0 +1,121 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestBreed(t *testing.T) {
+	parent1 := NewDigitalPet("Mom", "user123")
+	parent2 := NewDigitalPet("Dad", "user123")
+
+	child, err := Breed(parent1, parent2, "Kid")
+	if err != nil {
+		t.Fatalf("Breeding should succeed: %v", err)
+	}
+
+	if child.Genome.Generation != 1 {
+		t.Errorf("Child should be generation 1, got %d", child.Genome.Generation)
+	}
+
+	if rel, ok := child.Relationships.GetRelationship(parent1.ID); !ok || rel.Type != types.RelationshipParent {
+		t.Error("Child should record parent relationship")
+	}
+
+	if rel, ok := parent2.Relationships.GetRelationship(child.ID); !ok || rel.Type != types.RelationshipOffspring {
+		t.Error("Parent should record offspring relationship")
+	}
+}
+
+func TestBreedParentOffspringBlocked(t *testing.T) {
+	parent1 := NewDigitalPet("Mom", "user123")
+	parent2 := NewDigitalPet("Dad", "user123")
+
+	child, err := Breed(parent1, parent2, "Kid")
+	if err != nil {
+		t.Fatalf("Breeding should succeed: %v", err)
+	}
+
+	_, err = Breed(parent1, child, "Grandkid")
+	if !errors.Is(err, ErrIncompatibleParents) {
+		t.Errorf("Parent/offspring breeding should be blocked, got %v", err)
+	}
+}
+
+func TestBreedLitter(t *testing.T) {
+	parent1 := NewDigitalPet("Mom", "user123")
+	parent2 := NewDigitalPet("Dad", "user123")
+
+	litter, err := BreedLitter(parent1, parent2, []string{"Ada", "Bo", "Cy"}, genetics.DefaultKinshipPolicy())
+	if err != nil {
+		t.Fatalf("Breeding a litter should succeed: %v", err)
+	}
+	if len(litter) != 3 || litter[2].Name != "Cy" || litter[0].Genome == litter[1].Genome {
+		t.Fatalf("Expected three named pups with their own genomes, got %+v", litter)
+	}
+	for _, pup := range litter {
+		if rel, ok := parent1.Relationships.GetRelationship(pup.ID); !ok || rel.Type != types.RelationshipOffspring {
+			t.Errorf("Parent should record %s as offspring", pup.Name)
+		}
+	}
+	if r := genetics.Relatedness(litter[0].Pedigree, litter[1].Pedigree); r < 0.5 {
+		t.Errorf("Littermates should be related as full siblings, got %.2f", r)
+	}
+
+	if _, err := BreedLitter(parent1, parent1, []string{"Solo"}, genetics.DefaultKinshipPolicy()); !errors.Is(err, ErrIncompatibleParents) {
+		t.Errorf("Expected ErrIncompatibleParents, got %v", err)
+	}
+}
+
+func TestRename(t *testing.T) {
+	pet := NewDigitalPet("Pup", "user123")
+	id := pet.ID
+	pet.Rename("Biscuit")
+	if pet.Name != "Biscuit" || pet.Pedigree.Name != "Biscuit" || pet.ID != id {
+		t.Errorf("Expected the name changed and the ID kept, got %s/%s/%s", pet.Name, pet.Pedigree.Name, pet.ID)
+	}
+}
+
+func TestGeneticScreeningInteraction(t *testing.T) {
+	pet := NewDigitalPet("TestPet", "user123")
+
+	if pet.Screening != nil {
+		t.Error("New pet should not have screening results")
+	}
+
+	pet.ProcessUserInteraction(types.InteractionGeneticScreening, 1.0)
+
+	if pet.Screening == nil {
+		t.Error("Screening interaction should record results")
+	}
+}
+
+func TestBreedSiblingsPolicy(t *testing.T) {
+	parent1 := NewDigitalPet("Mom", "user123")
+	parent2 := NewDigitalPet("Dad", "user123")
+
+	sib1, err := Breed(parent1, parent2, "Sib1")
+	if err != nil {
+		t.Fatalf("Breeding should succeed: %v", err)
+	}
+	sib2, err := Breed(parent1, parent2, "Sib2")
+	if err != nil {
+		t.Fatalf("Breeding should succeed: %v", err)
+	}
+
+	if _, err := Breed(sib1, sib2, "Inbred"); !errors.Is(err, ErrIncompatibleParents) {
+		t.Errorf("Default policy should block siblings, got %v", err)
+	}
+
+	child, err := BreedWithPolicy(sib1, sib2, "Inbred", genetics.LaxKinshipPolicy())
+	if err != nil {
+		t.Fatalf("Lax policy should allow siblings: %v", err)
+	}
+
+	if child.Pedigree.InbreedingCoefficient() != 0.25 {
+		t.Errorf("Child of siblings should have F=0.25, got %.4f", child.Pedigree.InbreedingCoefficient())
+	}
+}
@@ -0,0 +1,11 @@
+package core
+
+// brush brushes out loose fur after grooming. Brushing out a shedding
+// coat is a treat.
+func (p *DigitalPet) brush(intensity float64) {
+	vitals := p.Biology.Vitals
+	if removed := p.Biology.Coat.Brush(intensity); removed > 0 {
+		vitals.Cleanliness += 0.5 * removed
+		vitals.Happiness += 0.3 * removed
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestGenomeSetsCoatLength(t *testing.T) {
+	pet := NewDigitalPet("Fluff", "user123")
+
+	if pet.Biology.Coat.Length != pet.Genome.GetTraitValue("coat_length") {
+		t.Error("Coat length should come from the genome")
+	}
+}
+
+func TestBrushingSeasonGrooming(t *testing.T) {
+	pet := NewDigitalPet("Fluff", "user123")
+	pet.Biology.Coat = biology.NewCoat(1.0)
+	pet.ReactToWeather(environment.WeatherEffects{Season: types.SeasonWinter}, 10.0)
+	pet.ReactToWeather(environment.WeatherEffects{Season: types.SeasonSpring}, 1.0)
+
+	if !pet.Biology.Coat.IsShedding() {
+		t.Fatalf("Expected shedding in spring, got %s", pet.Biology.Coat.Stage())
+	}
+
+	pet.Biology.Vitals.Happiness = 0.5
+	pet.ProcessUserInteraction(types.InteractionGrooming, 1.0)
+
+	if pet.Biology.Coat.IsShedding() {
+		t.Error("Grooming should brush out loose fur")
+	}
+	if pet.Biology.Vitals.Happiness <= 0.55 {
+		t.Error("Brushing out a shedding coat should be extra pleasant")
+	}
+}
+
+func TestWinterCoatWorsensHeat(t *testing.T) {
+	heat := environment.WeatherEffects{Weather: environment.WeatherHeatwave, Season: types.SeasonWinter, Lethargy: 0.7}
+
+	short := NewDigitalPet("Short", "user123")
+	short.Biology.Coat = biology.NewCoat(0.1)
+	fluffy := NewDigitalPet("Fluffy", "user123")
+	fluffy.Biology.Coat = biology.NewCoat(1.0)
+	fluffy.Biology.Coat.Thickness = 1.0
+
+	short.ReactToWeather(heat, 1.0)
+	fluffy.ReactToWeather(heat, 1.0)
+
+	if fluffy.Biology.Vitals.Energy >= short.Biology.Vitals.Energy {
+		t.Error("A winter coat should make heatwaves more draining")
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+)
+
+// Comfort settings
+const (
+	ComfortTriggerWindow = 5   // Recent memories searched for a phobia trigger
+	ComfortFearThreshold = 0.4 // Fear above which comfort can desensitize
+)
+
+// ComfortEffectiveness returns how well comforting soothes this pet
+// (0.4 to 1.0). Pets that are bonded to the user and emotionally resilient
+// respond best.
+func (p *DigitalPet) ComfortEffectiveness() float64 {
+	bond := (p.Emotions.Affection + p.Personality.Traits.Loyalty) / 2.0
+	resilience := p.Biology.Processes.EmotionalResilience
+	return 0.4 + 0.6*clamp((bond+resilience)/2.0, 0.0, 1.0)
+}
+
+// comfort reassures a fearful pet. Comforting a frightened pet right
+// after something scary softens its memory of the trigger, so repeated
+// comfort gradually desensitizes phobias.
+func (p *DigitalPet) comfort(intensity float64) {
+	effectiveness := p.ComfortEffectiveness()
+	if p.Emotions.Fear < ComfortFearThreshold {
+		return
+	}
+
+	trigger := p.Memory.RecentAversive(ComfortTriggerWindow, AversionThreshold)
+	if trigger == nil {
+		return
+	}
+
+	tags := make([]string, 0, len(trigger.Tags))
+	for _, tag := range trigger.Tags {
+		if tag != ai.TagUser {
+			tags = append(tags, tag)
+		}
+	}
+
+	p.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: "Comforted after: " + trigger.Description,
+		GameTime:    p.GetAge(),
+		Strength:    trigger.Strength * effectiveness,
+		Valence:     0.5 * intensity * effectiveness,
+		Emotion:     "content",
+		Tags:        tags,
+	})
+}
+
+// Helper function to clamp values
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// frightenedPet creates a pet that was just scared by a storm
+func frightenedPet() *DigitalPet {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Emotions.Fear = 0.8
+	pet.Biology.Vitals.Stress = 0.8
+	pet.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: "Thunderstorm",
+		Strength:    0.9,
+		Valence:     -0.9,
+		Tags:        []string{"storm"},
+	})
+	return pet
+}
+
+func TestComfortSoothesFasterThanPetting(t *testing.T) {
+	comforted := frightenedPet()
+	petted := frightenedPet()
+
+	comforted.ProcessUserInteraction(types.InteractionComfort, 1.0)
+	petted.ProcessUserInteraction(types.InteractionPetting, 1.0)
+
+	if comforted.Emotions.Fear >= petted.Emotions.Fear {
+		t.Error("Comfort should reduce fear more than petting")
+	}
+	if comforted.Biology.Vitals.Stress >= petted.Biology.Vitals.Stress {
+		t.Error("Comfort should reduce stress more than petting")
+	}
+}
+
+func TestComfortEffectivenessScalesWithBond(t *testing.T) {
+	bonded := NewDigitalPet("Bonded", "user123")
+	bonded.Emotions.Affection = 1.0
+	bonded.Personality.Traits.Loyalty = 1.0
+
+	distant := NewDigitalPet("Distant", "user123")
+	distant.Emotions.Affection = 0.0
+	distant.Personality.Traits.Loyalty = 0.0
+
+	if bonded.ComfortEffectiveness() <= distant.ComfortEffectiveness() {
+		t.Error("Bonded pets should be easier to comfort")
+	}
+}
+
+func TestComfortDesensitizes(t *testing.T) {
+	pet := frightenedPet()
+	before := pet.Memory.TagValence("storm")
+
+	for i := 0; i < 3; i++ {
+		pet.Emotions.Fear = 0.8
+		pet.ProcessUserInteraction(types.InteractionComfort, 1.0)
+	}
+
+	if after := pet.Memory.TagValence("storm"); after <= before {
+		t.Errorf("Repeated comfort should soften the storm phobia: %.2f -> %.2f", before, after)
+	}
+}
@@ -0,0 +1,258 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+)
+
+// DiffTolerance is the smallest change in a number that DiffPets reports
+const DiffTolerance = 1e-6
+
+// Sections of a pet diff, in the order they are reported
+const (
+	DiffSectionPet      = "pet"
+	DiffSectionVitals   = "vitals"
+	DiffSectionSkills   = "skills"
+	DiffSectionMemories = "memories"
+	DiffSectionSocial   = "social"
+	DiffSectionGenome   = "genome"
+)
+
+// FieldChange is one field that differs between two versions of a pet
+type FieldChange struct {
+	Section string  `json:"section"`
+	Field   string  `json:"field"`
+	Before  string  `json:"before"`
+	After   string  `json:"after"`
+	Delta   float64 `json:"delta,omitempty"` // After minus Before, for numbers
+}
+
+// String returns the change as "section.field: before -> after"
+func (c FieldChange) String() string {
+	s := fmt.Sprintf("%s.%s: %s -> %s", c.Section, c.Field, c.Before, c.After)
+	if c.Delta != 0 {
+		s += fmt.Sprintf(" (%+.4g)", c.Delta)
+	}
+	return s
+}
+
+// PetDiff lists what differs between two versions of a pet, such as the
+// local and cloud copies of a save
+type PetDiff struct {
+	Before  *DigitalPet   `json:"-"`
+	After   *DigitalPet   `json:"-"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// Empty returns true if the two versions do not differ
+func (d PetDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// Section returns the changes in one section
+func (d PetDiff) Section(section string) []FieldChange {
+	var changes []FieldChange
+	for _, change := range d.Changes {
+		if change.Section == section {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// String returns the diff one change per line
+func (d PetDiff) String() string {
+	if d.Empty() {
+		return "no differences"
+	}
+	lines := make([]string, len(d.Changes))
+	for i, change := range d.Changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// differ collects the changes of a PetDiff
+type differ struct {
+	section string
+	changes []FieldChange
+}
+
+// number records a numeric field if it moved by more than DiffTolerance
+func (d *differ) number(field string, before, after float64) {
+	if math.Abs(after-before) <= DiffTolerance {
+		return
+	}
+	d.changes = append(d.changes, FieldChange{
+		Section: d.section,
+		Field:   field,
+		Before:  formatDiffNumber(before),
+		After:   formatDiffNumber(after),
+		Delta:   after - before,
+	})
+}
+
+// text records a field if it differs
+func (d *differ) text(field, before, after string) {
+	if before != after {
+		d.changes = append(d.changes, FieldChange{Section: d.section, Field: field, Before: before, After: after})
+	}
+}
+
+// timestamp records a time field if it differs by a second or more
+func (d *differ) timestamp(field string, before, after time.Time) {
+	if before.Sub(after).Abs() < time.Second {
+		return
+	}
+	d.text(field, before.UTC().Format(time.RFC3339), after.UTC().Format(time.RFC3339))
+}
+
+// formatDiffNumber renders a number without trailing zeros
+func formatDiffNumber(value float64) string {
+	return fmt.Sprintf("%.4g", value)
+}
+
+// DiffPets compares two versions of a pet field by field: identity and
+// statistics, vitals, skills, memory counts, relationships and genome.
+// Subsystems missing from either version are reported rather than
+// compared, so damaged saves can still be diffed.
+func DiffPets(before, after *DigitalPet) PetDiff {
+	d := &differ{section: DiffSectionPet, changes: []FieldChange{}}
+	d.text("id", string(before.ID), string(after.ID))
+	d.text("name", before.Name, after.Name)
+	d.text("owner", string(before.Owner), string(after.Owner))
+	d.text("behavior", before.CurrentBehavior.String(), after.CurrentBehavior.String())
+	d.text("location", before.Location, after.Location)
+	d.timestamp("created_at", before.CreatedAt, after.CreatedAt)
+	d.timestamp("last_update_at", before.LastUpdateAt, after.LastUpdateAt)
+	d.number("total_interactions", float64(before.TotalInteractions), float64(after.TotalInteractions))
+	d.number("total_play_time", before.TotalPlayTime, after.TotalPlayTime)
+
+	d.section = DiffSectionVitals
+	if present(d, "biology", before.Biology == nil || before.Biology.Vitals == nil, after.Biology == nil || after.Biology.Vitals == nil) {
+		a, b := before.Biology, after.Biology
+		d.text("alive", fmt.Sprint(a.IsAlive), fmt.Sprint(b.IsAlive))
+		d.number("age_days", a.GetAgeInDays(), b.GetAgeInDays())
+		for _, vital := range biology.AllVitals() {
+			d.number(vital.Key(), a.Vitals.Get(vital), b.Vitals.Get(vital))
+		}
+	}
+
+	d.section = DiffSectionSkills
+	if present(d, "skills", before.Skills == nil, after.Skills == nil) {
+		for _, skill := range ai.AllSkills() {
+			d.number(skill.String(), before.Skills.Level(skill), after.Skills.Level(skill))
+		}
+	}
+
+	d.section = DiffSectionMemories
+	if present(d, "memory", before.Memory == nil, after.Memory == nil) {
+		a, b := before.Memory, after.Memory
+		d.number("short_term", float64(len(a.ShortTermMemories)), float64(len(b.ShortTermMemories)))
+		d.number("long_term", float64(len(a.LongTermMemories)), float64(len(b.LongTermMemories)))
+		d.number("total_formed", float64(a.TotalMemories), float64(b.TotalMemories))
+		d.number("summarized", float64(summarized(a)), float64(summarized(b)))
+	}
+
+	d.section = DiffSectionSocial
+	if present(d, "relationships", before.Relationships == nil, after.Relationships == nil) {
+		d.number("relationships", float64(len(before.Relationships.Relationships)), float64(len(after.Relationships.Relationships)))
+	}
+
+	d.section = DiffSectionGenome
+	if present(d, "genome", before.Genome == nil, after.Genome == nil) {
+		diffGenomes(d, before.Genome, after.Genome)
+	}
+	return PetDiff{Before: before, After: after, Changes: d.changes}
+}
+
+// present returns true if both versions have a subsystem to compare,
+// recording one that only one version has
+func present(d *differ, name string, missingBefore, missingAfter bool) bool {
+	switch {
+	case missingBefore && missingAfter:
+		return false
+	case missingBefore:
+		d.text(name, "missing", "present")
+		return false
+	case missingAfter:
+		d.text(name, "present", "missing")
+		return false
+	}
+	return true
+}
+
+// summarized returns how many forgotten memories a memory system has
+// folded into summaries
+func summarized(m *ai.MemorySystem) int {
+	total := 0
+	for _, summary := range m.Summaries {
+		total += summary.Count
+	}
+	return total
+}
+
+// diffGenomes records trait alleles and disorder loci that differ
+func diffGenomes(d *differ, a, b *genetics.Genome) {
+	d.number("generation", float64(a.Generation), float64(b.Generation))
+	d.number("mutations", float64(a.Mutations), float64(b.Mutations))
+
+	traits := make(map[string]bool, len(a.Traits))
+	for name := range a.Traits {
+		traits[name] = true
+	}
+	for name := range b.Traits {
+		traits[name] = true
+	}
+	names := make([]string, 0, len(traits))
+	for name := range traits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pa, inA := a.Traits[name]
+		pb, inB := b.Traits[name]
+		switch {
+		case !inA:
+			d.text(name, "missing", formatDiffNumber(pb.Expressed()))
+		case !inB:
+			d.text(name, formatDiffNumber(pa.Expressed()), "missing")
+		case pa != pb:
+			// Alleles can change without moving the expressed value
+			d.text(name, formatGenePair(pa), formatGenePair(pb))
+		}
+	}
+
+	for _, disorder := range genetics.AllDisorders() {
+		d.text(disorder.String(), locusStatus(a.Disorders[disorder]), locusStatus(b.Disorders[disorder]))
+	}
+}
+
+// formatGenePair renders a gene pair's expressed value and its alleles
+func formatGenePair(pair genetics.GenePair) string {
+	allele := func(a genetics.Allele) string {
+		if a.Dominant {
+			return formatDiffNumber(a.Value) + "D"
+		}
+		return formatDiffNumber(a.Value)
+	}
+	return fmt.Sprintf("%s [%s/%s]", formatDiffNumber(pair.Expressed()), allele(pair.Maternal), allele(pair.Paternal))
+}
+
+// locusStatus describes a disorder locus
+func locusStatus(locus genetics.DisorderLocus) string {
+	switch {
+	case locus.IsAffected():
+		return "affected"
+	case locus.IsCarrier():
+		return "carrier"
+	}
+	return "clear"
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// reload returns an independent copy of a pet through its save format
+func reload(t *testing.T, pet *DigitalPet) *DigitalPet {
+	t.Helper()
+	payload, err := pet.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy, err := Load(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return copy
+}
+
+func TestDiffPets(t *testing.T) {
+	before := NewDigitalPet("Mochi", "owner")
+	after := reload(t, before)
+	if diff := DiffPets(before, after); !diff.Empty() {
+		t.Fatalf("Expected a reloaded pet to match, got:\n%s", diff)
+	}
+
+	after.Biology.Vitals.Energy = before.Biology.Vitals.Energy - 0.5
+	after.Skills.AddExperience(ai.SkillAgility, 0.5)
+	after.Memory.RecordInteraction(types.InteractionPlaying, 1, 0.8, "joy")
+	after.LastUpdateAt = before.LastUpdateAt.Add(time.Hour)
+	after.Genome.Disorders[genetics.DisorderMetabolic] = genetics.DisorderLocus{Maternal: true}
+	after.Biology.Vitals.Health += DiffTolerance / 2
+
+	diff := DiffPets(before, after)
+	for section, fields := range map[string][]string{
+		DiffSectionPet:      {"last_update_at"},
+		DiffSectionVitals:   {"energy"},
+		DiffSectionSkills:   {"Agility"},
+		DiffSectionMemories: {"short_term", "total_formed"},
+		DiffSectionGenome:   {"Metabolic Disorder"},
+	} {
+		changes := diff.Section(section)
+		if len(changes) != len(fields) || changes[0].Field != fields[0] {
+			t.Errorf("Expected %v changed in %s, got %v", fields, section, changes)
+		}
+	}
+	if len(diff.Changes) != 6 {
+		t.Errorf("Expected changes below the tolerance ignored, got:\n%s", diff)
+	}
+	if energy := diff.Section(DiffSectionVitals)[0]; energy.Delta > -0.49 || !strings.Contains(energy.String(), "(-0.5)") {
+		t.Errorf("Expected the energy drop reported, got %s", energy)
+	}
+	if status := diff.Section(DiffSectionGenome)[0]; status.Before != "clear" || status.After != "carrier" {
+		t.Errorf("Expected clear -> carrier, got %s", status)
+	}
+}
+
+func TestDiffPetsMissingSystems(t *testing.T) {
+	before := NewDigitalPet("Mochi", "owner")
+	after := reload(t, before)
+	after.Skills = nil
+	after.Genome = nil
+
+	diff := DiffPets(before, after)
+	if len(diff.Changes) != 2 {
+		t.Fatalf("Expected the two missing systems reported, got:\n%s", diff)
+	}
+	if skills := diff.Section(DiffSectionSkills); skills[0].Before != "present" || skills[0].After != "missing" {
+		t.Errorf("Expected the skills reported missing, got %v", skills)
+	}
+}
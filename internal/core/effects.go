@@ -0,0 +1,41 @@
+package core
+
+// InteractionEffects are the vital stat changes caused by user interactions
+// at full intensity
+type InteractionEffects struct {
+	FeedNutrition       float64 `yaml:"feed_nutrition"`
+	FeedEnergy          float64 `yaml:"feed_energy"`
+	FeedHappiness       float64 `yaml:"feed_happiness"`
+	PetHappiness        float64 `yaml:"pet_happiness"`
+	PetStress           float64 `yaml:"pet_stress_relief"`
+	PlayHappiness       float64 `yaml:"play_happiness"`
+	PlayEnergy          float64 `yaml:"play_energy_cost"`
+	PlayStress          float64 `yaml:"play_stress_relief"`
+	MedicalHealth       float64 `yaml:"medical_health"`
+	MedicalStress       float64 `yaml:"medical_stress"`
+	RewardHappiness     float64 `yaml:"reward_happiness"`
+	DisciplineStress    float64 `yaml:"discipline_stress"`
+	DisciplineHappiness float64 `yaml:"discipline_happiness_cost"`
+}
+
+// DefaultInteractionEffects returns the standard interaction effects
+func DefaultInteractionEffects() InteractionEffects {
+	return InteractionEffects{
+		FeedNutrition:       0.3,
+		FeedEnergy:          0.1,
+		FeedHappiness:       0.05,
+		PetHappiness:        0.15,
+		PetStress:           0.1,
+		PlayHappiness:       0.2,
+		PlayEnergy:          0.1,
+		PlayStress:          0.05,
+		MedicalHealth:       0.2,
+		MedicalStress:       0.05,
+		RewardHappiness:     0.25,
+		DisciplineStress:    0.15,
+		DisciplineHappiness: 0.1,
+	}
+}
+
+// Effects holds the interaction effects in use. Tuning may replace it at runtime.
+var Effects = DefaultInteractionEffects()
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+)
+
+// Certification exam settings
+const (
+	ExamSkillThreshold = 0.6  // Skill level needed before an exam can be scheduled
+	ExamParts          = 3    // Parts in an exam; every part must be passed
+	ExamCooldownDays   = 7.0  // Game days after a failed exam before the skill can be tested again
+	ExamEnergyCost     = 0.15 // Energy spent sitting an exam
+	TagExam            = "exam"
+)
+
+var (
+	// ErrExamNotEligible is returned when a pet's skill is too low for an exam
+	ErrExamNotEligible = errors.New("skill is not high enough for certification")
+	// ErrExamCooldown is returned when retaking a failed exam too soon
+	ErrExamCooldown = errors.New("exam cannot be retaken yet")
+	// ErrAlreadyCertified is returned when a pet already holds a certificate
+	ErrAlreadyCertified = errors.New("already certified")
+)
+
+// skillTitles are the titles certified pets are known by
+var skillTitles = map[ai.SkillType]string{
+	ai.SkillAgility:        "Agility Ace",
+	ai.SkillObedience:      "Obedience Graduate",
+	ai.SkillForaging:       "Master Forager",
+	ai.SkillSocial:         "Social Butterfly",
+	ai.SkillProblemSolving: "Puzzle Master",
+}
+
+// Certificate is proof a pet passed a skill's certification exam
+type Certificate struct {
+	Skill  ai.SkillType `json:"skill"`
+	Score  float64      `json:"score"`  // Chance of passing each part (0.0 to 1.0)
+	Age    float64      `json:"age"`    // Pet's age in days when certified
+	Earned time.Time    `json:"earned"` // Wall-clock time of the exam
+}
+
+// Title returns the title a certificate grants
+func (c Certificate) Title() string {
+	return skillTitles[c.Skill]
+}
+
+// Credit returns the leaderboard credit a certificate is worth: more for
+// a higher score
+func (c Certificate) Credit() int {
+	return 50 + int(50*c.Score)
+}
+
+// Certifications records a pet's exam history
+type Certifications struct {
+	Certificates []Certificate            `json:"certificates,omitempty"`
+	FailedAt     map[ai.SkillType]float64 `json:"failed_at,omitempty"` // Age in days at each skill's last failed exam
+}
+
+// ExamResult is the outcome of a certification exam
+type ExamResult struct {
+	Skill       ai.SkillType
+	Parts       []float64    // Chance of passing each part that was attempted
+	Passed      bool         // True only if every part was passed
+	Certificate *Certificate // Set when passed
+	RetryAge    float64      // Age in days when a failed exam may be retaken
+}
+
+// Certificate returns the pet's certificate in a skill, if it holds one
+func (p *DigitalPet) Certificate(skill ai.SkillType) (Certificate, bool) {
+	if p.Certifications == nil {
+		return Certificate{}, false
+	}
+	for _, certificate := range p.Certifications.Certificates {
+		if certificate.Skill == skill {
+			return certificate, true
+		}
+	}
+	return Certificate{}, false
+}
+
+// Credits returns the pet's leaderboard credit from its certificates
+func (p *DigitalPet) Credits() int {
+	if p.Certifications == nil {
+		return 0
+	}
+	credits := 0
+	for _, certificate := range p.Certifications.Certificates {
+		credits += certificate.Credit()
+	}
+	return credits
+}
+
+// CanTakeExam returns nil if the pet may sit a skill's exam: it must be
+// alive, skilled enough, not yet certified and not cooling down from a
+// failed attempt
+func (p *DigitalPet) CanTakeExam(skill ai.SkillType) error {
+	if !p.IsAlive() {
+		return fmt.Errorf("%w: %s is not alive", ErrExamNotEligible, p.Name)
+	}
+	if _, certified := p.Certificate(skill); certified {
+		return fmt.Errorf("%w: %s in %s", ErrAlreadyCertified, p.Name, skill)
+	}
+	if level := p.Skills.Level(skill); level < ExamSkillThreshold {
+		return fmt.Errorf("%w: %s %s is %.2f, needs %.2f", ErrExamNotEligible, p.Name, skill, level, ExamSkillThreshold)
+	}
+	if p.Certifications != nil {
+		if failed, ok := p.Certifications.FailedAt[skill]; ok && p.GetAge() < failed+ExamCooldownDays {
+			return fmt.Errorf("%w: %s %s in %.1f days", ErrExamCooldown, p.Name, skill, failed+ExamCooldownDays-p.GetAge())
+		}
+	}
+	return nil
+}
+
+// ExamPartChance returns the chance of passing one part of a skill's exam.
+// It is stricter than everyday checks: a bare pass needs a skill well
+// above the threshold, and a tired or stressed pet does worse.
+func (p *DigitalPet) ExamPartChance(skill ai.SkillType) float64 {
+	vitals := p.Biology.Vitals
+	readiness := 0.7 + 0.15*vitals.Energy + 0.15*(1.0-vitals.Stress)
+	return clamp((1.2*p.Skills.Level(skill)-0.1)*readiness, 0.0, 0.95)
+}
+
+// TakeExam has the pet sit a skill's certification exam. Every part must
+// be passed; the exam stops at the first failed part. Passing grants a
+// certificate, failing starts the cooldown before the next attempt.
+func (p *DigitalPet) TakeExam(skill ai.SkillType, rng *rand.Rand) (ExamResult, error) {
+	if err := p.CanTakeExam(skill); err != nil {
+		return ExamResult{}, err
+	}
+	if p.Certifications == nil {
+		p.Certifications = &Certifications{}
+	}
+
+	result := ExamResult{Skill: skill, Passed: true}
+	chance := p.ExamPartChance(skill)
+	for part := 0; part < ExamParts; part++ {
+		result.Parts = append(result.Parts, chance)
+		if rng.Float64() >= chance {
+			result.Passed = false
+			break
+		}
+	}
+
+	vitals := p.Biology.Vitals
+	vitals.Energy -= ExamEnergyCost
+	valence := -0.4
+	description := fmt.Sprintf("Failed the %s certification exam", skill)
+	if result.Passed {
+		certificate := Certificate{Skill: skill, Score: chance, Age: p.GetAge(), Earned: time.Now()}
+		p.Certifications.Certificates = append(p.Certifications.Certificates, certificate)
+		delete(p.Certifications.FailedAt, skill)
+		result.Certificate = &certificate
+		vitals.Happiness += 0.2
+		valence = 0.8
+		description = fmt.Sprintf("Earned the %s certificate: %s", skill, certificate.Title())
+	} else {
+		if p.Certifications.FailedAt == nil {
+			p.Certifications.FailedAt = make(map[ai.SkillType]float64)
+		}
+		p.Certifications.FailedAt[skill] = p.GetAge()
+		result.RetryAge = p.GetAge() + ExamCooldownDays
+		vitals.Stress += 0.1
+	}
+	vitals.Clamp()
+
+	p.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: description,
+		GameTime:    p.GetAge(),
+		Strength:    0.7,
+		Valence:     valence,
+		Emotion:     p.Emotions.DominantEmotion,
+		Tags:        []string{TagExam, ai.SkillTag(skill)},
+	})
+	return result, nil
+}
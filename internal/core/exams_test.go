@@ -0,0 +1,114 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+)
+
+// examReadyPet creates a rested pet with a skill at level
+func examReadyPet(skill ai.SkillType, level float64) *DigitalPet {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Skills.Levels[skill] = level
+	pet.Biology.Vitals.Energy = 1.0
+	pet.Biology.Vitals.Stress = 0.0
+	return pet
+}
+
+func TestCanTakeExam(t *testing.T) {
+	pet := examReadyPet(ai.SkillAgility, ExamSkillThreshold-0.1)
+	if err := pet.CanTakeExam(ai.SkillAgility); !errors.Is(err, ErrExamNotEligible) {
+		t.Errorf("Expected ErrExamNotEligible below the threshold, got %v", err)
+	}
+	pet.Skills.Levels[ai.SkillAgility] = ExamSkillThreshold
+	if err := pet.CanTakeExam(ai.SkillAgility); err != nil {
+		t.Errorf("Expected the exam allowed at the threshold, got %v", err)
+	}
+}
+
+func TestExamPartChanceIsStrict(t *testing.T) {
+	novice := examReadyPet(ai.SkillAgility, ExamSkillThreshold)
+	expert := examReadyPet(ai.SkillAgility, 0.95)
+	if novice.ExamPartChance(ai.SkillAgility) >= expert.ExamPartChance(ai.SkillAgility) {
+		t.Error("Higher skill should make each part easier")
+	}
+	if chance := novice.ExamPartChance(ai.SkillAgility); chance >= ExamSkillThreshold+0.05 {
+		t.Errorf("A bare pass should not be likely on every part, got %.2f", chance)
+	}
+	tired := examReadyPet(ai.SkillAgility, 0.95)
+	tired.Biology.Vitals.Energy = 0.1
+	tired.Biology.Vitals.Stress = 0.9
+	if tired.ExamPartChance(ai.SkillAgility) >= expert.ExamPartChance(ai.SkillAgility) {
+		t.Error("A tired, stressed pet should do worse")
+	}
+}
+
+func TestTakeExamFailureStartsCooldown(t *testing.T) {
+	pet := examReadyPet(ai.SkillObedience, ExamSkillThreshold)
+	rng := rand.New(rand.NewSource(1))
+	var result ExamResult
+	for {
+		var err error
+		if result, err = pet.TakeExam(ai.SkillObedience, rng); err != nil {
+			t.Fatal(err)
+		}
+		if !result.Passed {
+			break
+		}
+		pet.Certifications = nil // Keep trying until a failure
+	}
+	if result.Certificate != nil || result.RetryAge != pet.GetAge()+ExamCooldownDays {
+		t.Errorf("Expected a cooldown after failing, got %+v", result)
+	}
+	if _, err := pet.TakeExam(ai.SkillObedience, rng); !errors.Is(err, ErrExamCooldown) {
+		t.Errorf("Expected ErrExamCooldown on an immediate retry, got %v", err)
+	}
+	pet.Certifications.FailedAt[ai.SkillObedience] -= ExamCooldownDays
+	if err := pet.CanTakeExam(ai.SkillObedience); err != nil {
+		t.Errorf("Expected a retry allowed after the cooldown, got %v", err)
+	}
+}
+
+func TestTakeExamPassGrantsCertificate(t *testing.T) {
+	pet := examReadyPet(ai.SkillForaging, 1.0)
+	rng := rand.New(rand.NewSource(1))
+	var result ExamResult
+	for attempt := 0; attempt < 50 && !result.Passed; attempt++ {
+		pet.Certifications = nil
+		var err error
+		if result, err = pet.TakeExam(ai.SkillForaging, rng); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !result.Passed || len(result.Parts) != ExamParts || result.Certificate == nil {
+		t.Fatalf("Expected a master to pass eventually, got %+v", result)
+	}
+	if titles := pet.Titles(); len(titles) != 1 || titles[0] != "Master Forager" {
+		t.Errorf("Expected the Master Forager title, got %v", titles)
+	}
+	if pet.Credits() <= 0 {
+		t.Error("Expected leaderboard credit for the certificate")
+	}
+	if _, err := pet.TakeExam(ai.SkillForaging, rng); !errors.Is(err, ErrAlreadyCertified) {
+		t.Errorf("Expected ErrAlreadyCertified, got %v", err)
+	}
+	if status := pet.GetCurrentStatus().String(); !strings.Contains(status, "Titles: Master Forager") {
+		t.Errorf("Expected the title in the status, got:\n%s", status)
+	}
+
+	data, err := json.Marshal(pet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded DigitalPet
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if _, certified := loaded.Certificate(ai.SkillForaging); !certified {
+		t.Error("Expected the certificate to survive a save")
+	}
+}
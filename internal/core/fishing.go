@@ -0,0 +1,90 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrCannotFish is returned when a pet is in no state to go fishing
+var ErrCannotFish = errors.New("pet cannot go fishing right now")
+
+// Fishing settings
+const (
+	FishingEnergyCost   = 0.1  // Energy spent per fishing trip
+	FishingExperience   = 0.03 // Foraging experience from a trip
+	CatchExperienceGain = 0.05 // Extra foraging experience from landing a fish
+)
+
+// FishingResult describes how a fishing trip went
+type FishingResult struct {
+	Fish   environment.FishSpecies // The fish that took the bait
+	Caught bool                    // Whether the pet landed it
+	Chance float64                 // Chance the pet had of landing it
+}
+
+// FishingPatience returns how well the pet's temperament suits waiting for
+// a bite: conscientious, calm pets do best and restless ones worst
+func (p *DigitalPet) FishingPatience() float64 {
+	traits := p.Personality.Traits
+	return clamp(0.5*traits.Conscientiousness+0.3*(1.0-traits.EnergyLevel)+0.2*(1.0-traits.Neuroticism), 0.0, 1.0)
+}
+
+// CatchChance returns the chance of landing a fish, from the pet's
+// patience and its foraging focus against how hard the fish fights
+func (p *DigitalPet) CatchChance(fish environment.FishSpecies) float64 {
+	focus := p.Skills.Level(ai.SkillForaging)
+	return clamp(0.35+0.35*p.FishingPatience()+0.4*focus-0.6*fish.Difficulty, 0.05, 0.95)
+}
+
+// GoFishing sends the pet fishing in a water biome. The catch resolves
+// automatically from the pet's patience and foraging skill, and every trip
+// trains foraging.
+func (p *DigitalPet) GoFishing(biome environment.Biome, season types.Season, rng *rand.Rand) (FishingResult, error) {
+	if !p.Biology.IsAlive || p.Biology.Hibernation.IsDormant() {
+		return FishingResult{}, fmt.Errorf("%w: %s", ErrCannotFish, p.Name)
+	}
+
+	fish, err := environment.HookFish(biome, season, rng)
+	if err != nil {
+		return FishingResult{}, err
+	}
+
+	result := FishingResult{Fish: fish, Chance: p.CatchChance(fish)}
+	result.Caught = rng.Float64() < result.Chance
+
+	vitals := p.Biology.Vitals
+	vitals.Energy -= FishingEnergyCost
+	experience := FishingExperience
+
+	valence := -0.2
+	description := fmt.Sprintf("A %s got away", fish.Name)
+	if result.Caught {
+		experience += CatchExperienceGain
+		vitals.Happiness += 0.1 + 0.1*fish.Difficulty
+		valence = 0.4 + 0.4*fish.Difficulty
+		description = fmt.Sprintf("Caught a %s", fish.Name)
+		p.Emotions.ApplyEmotionalStimulus(ai.EmotionalStimulus{
+			JoyDelta:        0.1 + 0.1*fish.Difficulty,
+			ExcitementDelta: 0.2 * fish.Difficulty,
+			Source:          "fishing",
+		})
+	}
+	vitals.Clamp()
+	p.Skills.Train(ai.SkillForaging, experience)
+
+	p.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: description,
+		GameTime:    p.GetAge(),
+		Strength:    0.3 + 0.5*fish.Difficulty,
+		Valence:     valence,
+		Emotion:     p.Emotions.DominantEmotion,
+		Tags:        []string{"fishing"},
+	})
+	return result, nil
+}
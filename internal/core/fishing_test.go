@@ -0,0 +1,55 @@
+package core
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestPatientSkilledPetsCatchMore(t *testing.T) {
+	fidget := NewDigitalPet("Fidget", "user123")
+	fidget.Personality.Traits.Conscientiousness = 0.0
+	fidget.Personality.Traits.EnergyLevel = 1.0
+	fidget.Personality.Traits.Neuroticism = 1.0
+
+	angler := NewDigitalPet("Angler", "user123")
+	angler.Personality.Traits.Conscientiousness = 1.0
+	angler.Personality.Traits.EnergyLevel = 0.0
+	angler.Personality.Traits.Neuroticism = 0.0
+	angler.Skills.Levels[ai.SkillForaging] = 1.0
+
+	gar, _ := environment.LookupFish("Giant Gar")
+	if fidget.CatchChance(gar) >= angler.CatchChance(gar) {
+		t.Error("A patient, skilled pet should have a better catch chance")
+	}
+}
+
+func TestGoFishing(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	rng := rand.New(rand.NewSource(1))
+
+	if _, err := pet.GoFishing(environment.BiomeDesert, types.SeasonSummer, rng); !errors.Is(err, environment.ErrNoFishing) {
+		t.Errorf("Expected ErrNoFishing, got %v", err)
+	}
+
+	before := pet.Skills.Level(ai.SkillForaging)
+	result, err := pet.GoFishing(environment.BiomeSwamp, types.SeasonSummer, rng)
+	if err != nil {
+		t.Fatalf("GoFishing failed: %v", err)
+	}
+	if result.Fish.Name == "" || result.Chance <= 0 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if pet.Skills.Level(ai.SkillForaging) <= before {
+		t.Error("Fishing should train foraging")
+	}
+
+	pet.Biology.Hibernation.Enter()
+	if _, err := pet.GoFishing(environment.BiomeSwamp, types.SeasonSummer, rng); !errors.Is(err, ErrCannotFish) {
+		t.Errorf("Expected ErrCannotFish while dormant, got %v", err)
+	}
+}
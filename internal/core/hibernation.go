@@ -0,0 +1,61 @@
+package core
+
+import (
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Hibernation settings
+const (
+	HibernationEnergyThreshold = 0.35 // Energy level below which a pet may hibernate
+	HibernationArcticAffinity  = 0.6  // Arctic affinity needed to hibernate
+	HibernationTemperature     = 5.0  // Ambient °C below which hibernation begins
+)
+
+// CanHibernate returns true for low-energy pets with a cold-adapted build
+func (p *DigitalPet) CanHibernate() bool {
+	return p.Personality.Traits.EnergyLevel < HibernationEnergyThreshold &&
+		p.BiomeAffinity(environment.BiomeArctic) >= HibernationArcticAffinity
+}
+
+// CanInteract returns whether an interaction is possible right now. A
+// hibernating pet can only be tended to gently; it cannot eat or play.
+func (p *DigitalPet) CanInteract(interactionType types.InteractionType) bool {
+	if !p.Biology.Hibernation.IsDormant() {
+		return true
+	}
+
+	switch interactionType {
+	case types.InteractionPetting, types.InteractionGrooming, types.InteractionMedicalCare,
+		types.InteractionComfort, types.InteractionGeneticScreening:
+		return true
+	default:
+		return false
+	}
+}
+
+// updateHibernation sends eligible pets into hibernation on cold winter
+// days and starts waking them once winter is over
+func (p *DigitalPet) updateHibernation(effects environment.WeatherEffects) {
+	hibernation := p.Biology.Hibernation
+
+	switch {
+	case hibernation.IsDormant() && effects.Season != types.SeasonWinter:
+		hibernation.Wake()
+		p.Memory.Remember(&ai.Memory{
+			Type:        ai.MemoryEvent,
+			Description: "Woke from hibernation",
+			GameTime:    p.GetAge(),
+			Strength:    0.6,
+			Valence:     0.3,
+			Emotion:     p.Emotions.DominantEmotion,
+			Tags:        []string{"hibernation"},
+		})
+
+	case !hibernation.IsDormant() && !hibernation.IsWaking() && effects.Season == types.SeasonWinter &&
+		effects.Temperature < HibernationTemperature && p.CanHibernate():
+		hibernation.Enter()
+		p.CurrentBehavior = types.BehaviorHibernating
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// setGene gives a genome a homozygous value for a trait
+func setGene(g *genetics.Genome, trait string, value float64) {
+	g.Traits[trait] = genetics.GenePair{
+		Maternal: genetics.Allele{Value: value},
+		Paternal: genetics.Allele{Value: value},
+	}
+}
+
+// sleepyPet creates a pet that is eligible to hibernate
+func sleepyPet() *DigitalPet {
+	pet := NewDigitalPet("Dozy", "user123")
+	pet.Personality.Traits.EnergyLevel = 0.1
+	setGene(pet.Genome, "coat_length", 1.0)
+	pet.Genome.ApplyCoat(pet.Biology)
+	return pet
+}
+
+func TestHibernationCycle(t *testing.T) {
+	pet := sleepyPet()
+	winter := environment.WeatherEffects{Season: types.SeasonWinter, Temperature: -5.0}
+
+	pet.ReactToWeather(winter, 0.1)
+	if pet.CurrentBehavior != types.BehaviorHibernating {
+		t.Fatalf("Expected hibernation, got %s", pet.CurrentBehavior)
+	}
+
+	pet.ReactToWeather(environment.WeatherEffects{Season: types.SeasonSpring, Temperature: 12.0}, 0.1)
+	if !pet.Biology.Hibernation.IsWaking() {
+		t.Fatalf("Expected waking in spring, got %s", pet.Biology.Hibernation.State)
+	}
+	if len(pet.Memory.ByTag("hibernation")) != 1 {
+		t.Error("Waking from hibernation should be remembered")
+	}
+}
+
+func TestEnergeticPetsStayAwake(t *testing.T) {
+	pet := sleepyPet()
+	pet.Personality.Traits.EnergyLevel = 0.9
+
+	pet.ReactToWeather(environment.WeatherEffects{Season: types.SeasonWinter, Temperature: -5.0}, 0.1)
+	if pet.Biology.Hibernation.IsDormant() {
+		t.Error("Energetic pets should not hibernate")
+	}
+}
+
+func TestHibernationLimitsInteractions(t *testing.T) {
+	pet := sleepyPet()
+	pet.Biology.Hibernation.Enter()
+	pet.Biology.Vitals.Nutrition = 0.5
+
+	pet.ProcessUserInteraction(types.InteractionFeeding, 1.0)
+	if pet.Biology.Vitals.Nutrition != 0.5 {
+		t.Error("Hibernating pets should not eat")
+	}
+	if !pet.CanInteract(types.InteractionGrooming) {
+		t.Error("Hibernating pets can still be groomed")
+	}
+}
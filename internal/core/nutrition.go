@@ -0,0 +1,67 @@
+package core
+
+import (
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// NutrientNeeds returns how quickly the pet uses up each nutrient, from
+// its body size, age and natural activity level
+func (p *DigitalPet) NutrientNeeds() biology.Nutrients {
+	size := 0.5
+	if p.Genome != nil {
+		size = p.Genome.GetTraitValue("body_size")
+	}
+	return biology.NutrientNeeds(size, p.GetAge(), p.Personality.Traits.EnergyLevel)
+}
+
+// TrainSkill trains one of the pet's skills. Agility is physical fitness,
+// and builds more slowly while the pet is short of protein. Returns the
+// increase in level.
+func (p *DigitalPet) TrainSkill(skill ai.SkillType, experience float64) float64 {
+	if skill == ai.SkillAgility {
+		experience *= p.Biology.Diet.FitnessRate()
+	}
+	return p.Skills.Train(skill, experience)
+}
+
+// Home cooking settings
+const (
+	MealHappiness = 0.15 // Happiness a fresh prepared meal gives on top of feeding
+	MinTaste      = 0.5  // Taste preference of a pet that cares least for a recipe
+)
+
+// TastePreference returns how much the pet enjoys a taste, from MinTaste
+// to MinTaste+1. Energetic pets like hearty food, easy-going pets rich
+// food, curious pets fresh food and playful pets sweet food.
+func (p *DigitalPet) TastePreference(taste environment.Taste) float64 {
+	traits := p.Personality.Traits
+	var liking float64
+	switch taste {
+	case environment.TasteHearty:
+		liking = traits.EnergyLevel
+	case environment.TasteRich:
+		liking = 1.0 - traits.Conscientiousness
+	case environment.TasteFresh:
+		liking = (traits.Openness + traits.Curiosity) / 2.0
+	case environment.TasteSweet:
+		liking = traits.Playfulness
+	}
+	return MinTaste + clamp(liking, 0.0, 1.0)
+}
+
+// EatMeal feeds the pet a prepared meal. It counts as eating all its
+// ingredients at once, and a home-cooked meal makes the pet happier than
+// raw food, more so when fresh and to its taste.
+func (p *DigitalPet) EatMeal(meal environment.PreparedMeal) {
+	if !p.Biology.IsAlive || !p.CanInteract(types.InteractionFeeding) {
+		return
+	}
+	p.Eat(meal.Nutrients)
+	vitals := p.Biology.Vitals
+	vitals.Happiness += MealHappiness * meal.Freshness() * p.TastePreference(meal.Recipe.Taste())
+	vitals.Clamp()
+	p.RecordSpecialMeal()
+}
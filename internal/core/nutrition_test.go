@@ -0,0 +1,78 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestEatStocksTheDiet(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Biology.Vitals.Nutrition = 0.2
+	pet.Biology.Diet.Levels = biology.Nutrients{}
+
+	fish := biology.Nutrients{Protein: 0.5, Fat: 0.2, Vitamins: 0.1}
+	pet.Eat(fish)
+	if pet.Biology.Diet.Levels != fish {
+		t.Errorf("Expected the fish's nutrients eaten, got %+v", pet.Biology.Diet.Levels)
+	}
+	if want := 0.2 + Effects.FeedNutrition*fish.Filling(); pet.Biology.Vitals.Nutrition < want-1e-9 {
+		t.Errorf("Expected the fish to be as filling as its macros, got %.2f", pet.Biology.Vitals.Nutrition)
+	}
+
+	pet.ProcessUserInteraction(types.InteractionFeeding, 1.0)
+	if pet.Biology.Diet.Level(biology.NutrientCarbs) != biology.StandardMeal.Carbs {
+		t.Errorf("Expected an ordinary meal to add its carbs, got %+v", pet.Biology.Diet.Levels)
+	}
+}
+
+func TestProteinDeficitSlowsAgility(t *testing.T) {
+	fed := NewDigitalPet("Rex", "user123")
+	starved := NewDigitalPet("Bones", "user123")
+	starved.Biology.Diet.Levels.Protein = 0
+
+	gain := fed.TrainSkill(ai.SkillAgility, 0.2)
+	if slowed := starved.TrainSkill(ai.SkillAgility, 0.2); slowed >= gain {
+		t.Errorf("Expected agility to build more slowly without protein, got %.3f vs %.3f", slowed, gain)
+	}
+	if starved.TrainSkill(ai.SkillObedience, 0.2) != gain {
+		t.Error("Expected other skills unaffected by protein")
+	}
+}
+
+func TestEatMealSuitsTaste(t *testing.T) {
+	meal := environment.PreparedMeal{Recipe: environment.RecipeSmoothie, Nutrients: biology.Nutrients{Carbs: 0.2, Vitamins: 0.4}}
+
+	playful := NewDigitalPet("Rex", "user123")
+	playful.Personality.Traits.Playfulness = 1.0
+	glum := NewDigitalPet("Bones", "user123")
+	glum.Personality.Traits.Playfulness = 0.0
+	if playful.TastePreference(environment.TasteSweet) <= glum.TastePreference(environment.TasteSweet) {
+		t.Error("Expected a playful pet to like sweet food more")
+	}
+
+	for _, pet := range []*DigitalPet{playful, glum} {
+		pet.Biology.Vitals.Happiness = 0.2
+		pet.EatMeal(meal)
+	}
+	if playful.Biology.Vitals.Happiness <= glum.Biology.Vitals.Happiness {
+		t.Errorf("Expected a meal to its taste to please more, got %.2f vs %.2f",
+			playful.Biology.Vitals.Happiness, glum.Biology.Vitals.Happiness)
+	}
+
+	stale := NewDigitalPet("Pip", "user123")
+	stale.Personality.Traits.Playfulness = 1.0
+	stale.Biology.Vitals.Happiness = 0.2
+	old := meal
+	old.Age = environment.MealShelfDays * 0.9
+	stale.EatMeal(old)
+	if stale.Biology.Vitals.Happiness >= playful.Biology.Vitals.Happiness {
+		t.Error("Expected a fresh meal to please more than a stale one")
+	}
+	if playful.Renown == nil || playful.Renown.SpecialMeals != 1 {
+		t.Error("A home-cooked meal should count as a special meal")
+	}
+}
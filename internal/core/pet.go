@@ -2,15 +2,29 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Michael-W-Ellison/gochi/internal/ai"
 	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
 	"github.com/Michael-W-Ellison/gochi/internal/social"
 	"github.com/Michael-W-Ellison/gochi/pkg/types"
 )
 
+// AversionThreshold is the remembered valence below which a pet becomes
+// fearful of a kind of interaction
+const AversionThreshold = -0.3
+
+// CriticalThreshold is the vital level below which a need is critical
+const CriticalThreshold = 0.3
+
+// ErrCorruptSave is returned when saved data cannot be read back as a pet
+var ErrCorruptSave = errors.New("save data is corrupt")
+
 // DigitalPet represents a complete digital pet with all its systems
 type DigitalPet struct {
 	// Identification
@@ -23,10 +37,29 @@ type DigitalPet struct {
 	Memory        *ai.MemorySystem             `json:"memory"`
 	Emotions      *ai.EmotionState             `json:"emotions"`
 	Relationships *social.SocialRelationships  `json:"relationships"`
+	Skills        *ai.SkillSet                 `json:"skills"`
+	Genome        *genetics.Genome             `json:"genome"`
+	Pedigree      *genetics.Pedigree           `json:"pedigree"`
+
+	// Genetic screening results (nil until the pet has been screened)
+	Screening *genetics.ScreeningReport `json:"screening,omitempty"`
+
+	// Skill certificates and failed exams (nil until the first exam)
+	Certifications *Certifications `json:"certifications,omitempty"`
+
+	// Deeds towards epithets, epithets earned and the title shown (nil until the first deed)
+	Renown *Renown `json:"renown,omitempty"`
 
 	// Current State
-	CurrentBehavior types.BehaviorState `json:"current_behavior"`
-	Location        string              `json:"location"`
+	CurrentBehavior types.BehaviorState          `json:"current_behavior"`
+	Location        string                       `json:"location"`
+	Acclimatization *environment.Acclimatization `json:"acclimatization"`
+
+	// Cosmetics, one per slot
+	Accessories map[environment.AccessorySlot]environment.Accessory `json:"accessories,omitempty"`
+
+	// Labels chosen by the player, such as "favorite" or "breeder"
+	Tags []string `json:"tags,omitempty"`
 
 	// Metadata
 	CreatedAt    time.Time `json:"created_at"`
@@ -40,10 +73,15 @@ type DigitalPet struct {
 
 // NewDigitalPet creates a new digital pet with default systems
 func NewDigitalPet(name string, owner types.UserID) *DigitalPet {
+	return newDigitalPet(name, owner, genetics.NewRandomGenome())
+}
+
+// newDigitalPet creates a pet with the given genome and applies its disorders and coat
+func newDigitalPet(name string, owner types.UserID, genome *genetics.Genome) *DigitalPet {
 	now := time.Now()
 	id := types.PetID(fmt.Sprintf("pet_%d_%s", now.Unix(), name))
 
-	return &DigitalPet{
+	pet := &DigitalPet{
 		ID:              id,
 		Name:            name,
 		Biology:         biology.NewBiologicalSystems(),
@@ -51,14 +89,30 @@ func NewDigitalPet(name string, owner types.UserID) *DigitalPet {
 		Memory:          ai.NewMemorySystem(100),
 		Emotions:        ai.NewEmotionState(),
 		Relationships:   social.NewSocialRelationships(20),
+		Skills:          ai.NewSkillSet(),
+		Genome:          genome,
 		CurrentBehavior: types.BehaviorIdle,
-		Location:        "home",
+		Location:        environment.HomeLocation,
+		Acclimatization: environment.NewAcclimatization(),
 		CreatedAt:       now,
 		LastUpdateAt:    now,
 		Owner:           owner,
 		TotalInteractions: 0,
 		TotalPlayTime:     0,
 	}
+
+	pet.Pedigree = genetics.NewPedigree(id, name, genome.Generation, nil, nil)
+	pet.Genome.ApplyDisorders(pet.Biology)
+	pet.Genome.ApplyCoat(pet.Biology)
+	return pet
+}
+
+// NewDigitalPetFromGenome creates a founder pet with a given genome and the
+// personality it expresses
+func NewDigitalPetFromGenome(name string, owner types.UserID, genome *genetics.Genome) *DigitalPet {
+	pet := newDigitalPet(name, owner, genome)
+	pet.Personality.Traits = genome.ExpressTraits()
+	return pet
 }
 
 // NewDigitalPetRandom creates a pet with randomized personality
@@ -74,7 +128,10 @@ func (p *DigitalPet) Update(deltaTime float64) {
 		return
 	}
 
-	// Update biological systems
+	// Update biological systems, using nutrients at the pet's own pace
+	if diet := p.Biology.Diet; diet != nil {
+		diet.Needs = p.NutrientNeeds()
+	}
 	p.Biology.Update(deltaTime)
 
 	// Update emotions
@@ -88,9 +145,6 @@ func (p *DigitalPet) Update(deltaTime float64) {
 		p.Memory.ConsolidateMemories()
 	}
 
-	// Update relationships
-	p.Relationships.UpdateAll(deltaTime)
-
 	// Update current behavior based on state
 	p.updateBehavior()
 
@@ -99,19 +153,37 @@ func (p *DigitalPet) Update(deltaTime float64) {
 	p.TotalPlayTime += deltaTime / 60.0 // Convert to hours
 }
 
-// ProcessUserInteraction handles user actions and applies effects
+// ProcessUserInteraction handles user actions and applies effects.
+// Feeding gives an ordinary meal; use Eat for a particular food.
 func (p *DigitalPet) ProcessUserInteraction(interactionType types.InteractionType, intensity float64) {
-	if !p.Biology.IsAlive {
+	p.interact(interactionType, intensity, nil)
+}
+
+// Eat feeds the pet a particular food. It is as filling as its protein,
+// fat and carbs, and stocks the diet with its nutrients instead of those
+// of an ordinary meal.
+func (p *DigitalPet) Eat(food biology.Nutrients) {
+	p.interact(types.InteractionFeeding, food.Filling(), &food)
+}
+
+// interact implements ProcessUserInteraction, with meal replacing the
+// nutrients of an ordinary meal when given
+func (p *DigitalPet) interact(interactionType types.InteractionType, intensity float64, meal *biology.Nutrients) {
+	if !p.Biology.IsAlive || !p.CanInteract(interactionType) {
 		return
 	}
 
 	p.TotalInteractions++
 
-	// Apply biological effects
-	p.applyInteractionEffects(interactionType, intensity)
+	// Apply biological and emotional effects
+	outcome := DefaultEffectPipeline.Outcome(p, interactionType, intensity)
+	if meal != nil {
+		outcome.Meal = *meal
+	}
+	p.applyOutcome(outcome)
 
-	// Apply emotional effects
-	stimulus := ai.CreateStimulusFromInteraction(interactionType, intensity)
+	stimulus := ai.CreateStimulusFromInteraction(interactionType, outcome.Quality)
+	stimulus.FearDelta += outcome.Fear
 	p.Emotions.ApplyEmotionalStimulus(stimulus)
 
 	// Record memory
@@ -121,44 +193,6 @@ func (p *DigitalPet) ProcessUserInteraction(interactionType types.InteractionTyp
 	p.updateBehavior()
 }
 
-// applyInteractionEffects modifies vital stats based on interaction type
-func (p *DigitalPet) applyInteractionEffects(interactionType types.InteractionType, intensity float64) {
-	vitals := p.Biology.Vitals
-
-	switch interactionType {
-	case types.InteractionFeeding:
-		vitals.Nutrition += 0.3 * intensity
-		vitals.Energy += 0.1 * intensity
-		vitals.Happiness += 0.05 * intensity
-
-	case types.InteractionPetting:
-		vitals.Happiness += 0.15 * intensity
-		vitals.Stress -= 0.1 * intensity
-
-	case types.InteractionPlaying:
-		vitals.Happiness += 0.2 * intensity
-		vitals.Energy -= 0.1 * intensity
-		vitals.Stress -= 0.05 * intensity
-
-	case types.InteractionGrooming:
-		vitals.Cleanliness += 0.3 * intensity
-		vitals.Happiness += 0.05 * intensity
-
-	case types.InteractionMedicalCare:
-		vitals.Health += 0.2 * intensity
-		vitals.Stress += 0.05 * intensity // Medical care can be stressful
-
-	case types.InteractionRewards:
-		vitals.Happiness += 0.25 * intensity
-
-	case types.InteractionDiscipline:
-		vitals.Stress += 0.15 * intensity
-		vitals.Happiness -= 0.1 * intensity
-	}
-
-	vitals.Clamp()
-}
-
 // updateBehavior determines the current behavior based on state
 func (p *DigitalPet) updateBehavior() {
 	vitals := p.Biology.Vitals
@@ -169,6 +203,17 @@ func (p *DigitalPet) updateBehavior() {
 		return
 	}
 
+	if p.Biology.Hibernation.IsDormant() {
+		p.CurrentBehavior = types.BehaviorHibernating
+		return
+	}
+
+	// Pets are groggy for the first half of waking from hibernation
+	if hibernation := p.Biology.Hibernation; hibernation.IsWaking() && hibernation.WakeProgress < 0.5 {
+		p.CurrentBehavior = types.BehaviorSleeping
+		return
+	}
+
 	if vitals.GetOverallWellbeing() < 0.3 {
 		p.CurrentBehavior = types.BehaviorDistressed
 		return
@@ -210,11 +255,23 @@ func (p *DigitalPet) GetCurrentStatus() PetStatus {
 		Energy:           p.Biology.Vitals.Energy,
 		Happiness:        p.Biology.Vitals.Happiness,
 		Wellbeing:        p.Biology.Vitals.GetOverallWellbeing(),
+		BodyTemperature:  p.Biology.Thermoregulation.Temperature(),
 		CurrentBehavior:  p.CurrentBehavior,
 		MoodDescription:  p.Emotions.GetMoodDescription(),
 		StatusDescription: p.Biology.GetStatus(),
-		CriticalNeeds:    p.Biology.Vitals.GetCriticalStats(0.3),
+		CriticalNeeds:    p.CriticalNeeds(),
+		Titles:           p.Titles(),
+		ActiveTitle:      p.ActiveTitle(),
+	}
+}
+
+// CriticalNeeds lists critically low vitals and any thermal condition
+func (p *DigitalPet) CriticalNeeds() []string {
+	needs := p.Biology.Vitals.GetCriticalStats(CriticalThreshold)
+	if thermo := p.Biology.Thermoregulation; thermo.HasCondition() {
+		needs = append(needs, thermo.Condition.String())
 	}
+	return needs
 }
 
 // PetStatus represents a snapshot of the pet's current state
@@ -227,20 +284,26 @@ type PetStatus struct {
 	Energy            float64
 	Happiness         float64
 	Wellbeing         float64
+	BodyTemperature   float64
 	CurrentBehavior   types.BehaviorState
 	MoodDescription   string
 	StatusDescription string
 	CriticalNeeds     []string
+	Titles            []string // Earned by passing certification exams and by deeds or character
+	ActiveTitle       string   // Shown with the name; empty for none
 }
 
 // String provides a human-readable status report
 func (s PetStatus) String() string {
-	status := fmt.Sprintf("=== %s (Age: %.1f days) ===\n", s.Name, s.Age)
+	status := fmt.Sprintf("=== %s (Age: %.1f days) ===\n", titled(s.Name, s.ActiveTitle), s.Age)
+	if len(s.Titles) > 0 {
+		status += fmt.Sprintf("Titles: %s\n", strings.Join(s.Titles, ", "))
+	}
 	status += fmt.Sprintf("Status: %s | Mood: %s\n", s.StatusDescription, s.MoodDescription)
 	status += fmt.Sprintf("Behavior: %s\n", s.CurrentBehavior.String())
 	status += fmt.Sprintf("Health: %.0f%% | Energy: %.0f%% | Happiness: %.0f%%\n",
 		s.Health*100, s.Energy*100, s.Happiness*100)
-	status += fmt.Sprintf("Overall Wellbeing: %.0f%%\n", s.Wellbeing*100)
+	status += fmt.Sprintf("Overall Wellbeing: %.0f%% | Body Temperature: %.1f°C\n", s.Wellbeing*100, s.BodyTemperature)
 
 	if len(s.CriticalNeeds) > 0 {
 		status += fmt.Sprintf("⚠️  Critical Needs: %v\n", s.CriticalNeeds)
@@ -259,7 +322,7 @@ func Load(data []byte) (*DigitalPet, error) {
 	var pet DigitalPet
 	err := json.Unmarshal(data, &pet)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrCorruptSave, err)
 	}
 	return &pet, nil
 }
@@ -283,3 +346,11 @@ func (p *DigitalPet) IsAlive() bool {
 func (p *DigitalPet) GetName() string {
 	return p.Name
 }
+
+// Rename changes the pet's name, including in its pedigree. The ID is kept.
+func (p *DigitalPet) Rename(name string) {
+	p.Name = name
+	if p.Pedigree != nil {
+		p.Pedigree.Name = name
+	}
+}
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
 	"github.com/Michael-W-Ellison/gochi/pkg/types"
 )
 
@@ -163,3 +165,22 @@ func TestDeadPetNoUpdates(t *testing.T) {
 	pet.ProcessUserInteraction(types.InteractionFeeding, 1.0)
 	// Should not panic or cause issues
 }
+
+func TestBadMemoriesCauseAversion(t *testing.T) {
+	calm := NewDigitalPet("Calm", "user123")
+	scared := NewDigitalPet("Scared", "user123")
+	scared.Memory.Remember(&ai.Memory{Strength: 1.0, Valence: -1.0, Tags: []string{ai.TagVet}})
+
+	calm.ProcessUserInteraction(types.InteractionMedicalCare, 1.0)
+	scared.ProcessUserInteraction(types.InteractionMedicalCare, 1.0)
+
+	if scared.Emotions.Fear <= calm.Emotions.Fear {
+		t.Error("A pet with bad vet memories should fear medical care")
+	}
+}
+
+func TestLoadCorruptSave(t *testing.T) {
+	if _, err := Load([]byte("{not json")); !errors.Is(err, ErrCorruptSave) {
+		t.Errorf("Expected ErrCorruptSave, got %v", err)
+	}
+}
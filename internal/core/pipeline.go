@@ -0,0 +1,253 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Effect pipeline errors
+var (
+	ErrModifierExists  = errors.New("effect modifier is already registered")
+	ErrInvalidModifier = errors.New("invalid effect modifier")
+)
+
+// Built-in effect modifiers, in the order they run
+const (
+	ModifierPersonality = "personality" // Affectionate pets love petting, playful ones play, nervous ones take discipline hard
+	ModifierEnvironment = "environment" // A thick seasonal coat is harder to groom
+	ModifierBond        = "bond"        // Comfort works best on bonded, resilient pets
+	ModifierMemory      = "memory"      // Bad memories of an interaction make the pet apprehensive
+	ModifierFatigue     = "fatigue"     // A tired pet gets less out of play
+)
+
+// Base effects of interactions without a tuning entry, at full intensity
+const (
+	GroomCleanliness  = 0.3
+	GroomHappiness    = 0.05
+	ComfortStress     = 0.25
+	ScreeningStress   = 0.05 // A vet visit is mildly stressful
+	ApprehensionScale = 0.2  // Fear per unit of bad memory
+	FatigueDamping    = 0.5  // Share of play's joy lost by an exhausted pet
+)
+
+// InteractionOutcome is what an interaction will do to a pet. It starts
+// as the base effects and each modifier adjusts it before it is applied.
+type InteractionOutcome struct {
+	Type      types.InteractionType
+	Intensity float64                   // Strength the interaction was given at
+	Vitals    map[biology.Vital]float64 // Change to each vital
+	Quality   float64                   // Strength of the emotional stimulus
+	Fear      float64                   // Extra fear on top of the stimulus
+	Meal      biology.Nutrients         // Nutrients eaten, for feeding
+}
+
+// Scale multiplies the change to a vital
+func (o *InteractionOutcome) Scale(vital biology.Vital, factor float64) {
+	if delta, ok := o.Vitals[vital]; ok {
+		o.Vitals[vital] = delta * factor
+	}
+}
+
+// EffectModifier adjusts interaction outcomes, e.g. for a festival buff
+type EffectModifier struct {
+	Name   string
+	Modify func(p *DigitalPet, outcome *InteractionOutcome)
+}
+
+// EffectPipeline turns interactions into outcomes: base effects, then
+// every modifier in registration order. Clamping happens when the
+// outcome is applied.
+type EffectPipeline struct {
+	mu        sync.RWMutex
+	modifiers []EffectModifier
+}
+
+// NewEffectPipeline creates a pipeline with the built-in modifiers
+func NewEffectPipeline() *EffectPipeline {
+	ep := &EffectPipeline{}
+	for _, modifier := range builtinModifiers() {
+		if err := ep.Register(modifier); err != nil {
+			panic(err)
+		}
+	}
+	return ep
+}
+
+// DefaultEffectPipeline is the pipeline used by all user interactions
+var DefaultEffectPipeline = NewEffectPipeline()
+
+// RegisterEffectModifier adds a modifier to the default pipeline
+func RegisterEffectModifier(modifier EffectModifier) error {
+	return DefaultEffectPipeline.Register(modifier)
+}
+
+// Register adds a modifier that runs after those already registered
+func (ep *EffectPipeline) Register(modifier EffectModifier) error {
+	if modifier.Name == "" || modifier.Modify == nil {
+		return fmt.Errorf("%w: name and function are required", ErrInvalidModifier)
+	}
+
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	for _, existing := range ep.modifiers {
+		if existing.Name == modifier.Name {
+			return fmt.Errorf("%w: %s", ErrModifierExists, modifier.Name)
+		}
+	}
+	ep.modifiers = append(ep.modifiers, modifier)
+	return nil
+}
+
+// Modifiers returns the names of the registered modifiers in the order they run
+func (ep *EffectPipeline) Modifiers() []string {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+
+	names := make([]string, 0, len(ep.modifiers))
+	for _, modifier := range ep.modifiers {
+		names = append(names, modifier.Name)
+	}
+	return names
+}
+
+// Outcome works out what an interaction will do to a pet without applying it
+func (ep *EffectPipeline) Outcome(p *DigitalPet, interactionType types.InteractionType, intensity float64) InteractionOutcome {
+	outcome := baseOutcome(interactionType, intensity)
+
+	ep.mu.RLock()
+	modifiers := append([]EffectModifier(nil), ep.modifiers...)
+	ep.mu.RUnlock()
+
+	for _, modifier := range modifiers {
+		modifier.Modify(p, &outcome)
+	}
+	return outcome
+}
+
+// baseOutcome returns an interaction's effects before any modifier
+func baseOutcome(interactionType types.InteractionType, intensity float64) InteractionOutcome {
+	vitals := map[biology.Vital]float64{}
+
+	switch interactionType {
+	case types.InteractionFeeding:
+		vitals[biology.VitalNutrition] = Effects.FeedNutrition
+		vitals[biology.VitalEnergy] = Effects.FeedEnergy
+		vitals[biology.VitalHappiness] = Effects.FeedHappiness
+
+	case types.InteractionPetting:
+		vitals[biology.VitalHappiness] = Effects.PetHappiness
+		vitals[biology.VitalStress] = -Effects.PetStress
+
+	case types.InteractionPlaying:
+		vitals[biology.VitalHappiness] = Effects.PlayHappiness
+		vitals[biology.VitalEnergy] = -Effects.PlayEnergy
+		vitals[biology.VitalStress] = -Effects.PlayStress
+
+	case types.InteractionGrooming:
+		vitals[biology.VitalCleanliness] = GroomCleanliness
+		vitals[biology.VitalHappiness] = GroomHappiness
+
+	case types.InteractionMedicalCare:
+		vitals[biology.VitalHealth] = Effects.MedicalHealth
+		vitals[biology.VitalStress] = Effects.MedicalStress // Medical care can be stressful
+
+	case types.InteractionRewards:
+		vitals[biology.VitalHappiness] = Effects.RewardHappiness
+
+	case types.InteractionDiscipline:
+		vitals[biology.VitalStress] = Effects.DisciplineStress
+		vitals[biology.VitalHappiness] = -Effects.DisciplineHappiness
+
+	case types.InteractionGeneticScreening:
+		vitals[biology.VitalStress] = ScreeningStress
+
+	case types.InteractionComfort:
+		vitals[biology.VitalStress] = -ComfortStress
+	}
+
+	for vital, delta := range vitals {
+		vitals[vital] = delta * intensity
+	}
+	outcome := InteractionOutcome{
+		Type:      interactionType,
+		Intensity: intensity,
+		Vitals:    vitals,
+		Quality:   intensity,
+	}
+	if interactionType == types.InteractionFeeding {
+		outcome.Meal = biology.StandardMeal.Scale(intensity)
+	}
+	return outcome
+}
+
+// builtinModifiers returns the modifiers every pipeline starts with
+func builtinModifiers() []EffectModifier {
+	return []EffectModifier{
+		{Name: ModifierPersonality, Modify: func(p *DigitalPet, o *InteractionOutcome) {
+			// An average trait of 0.5 leaves the effect as it is
+			traits := p.Personality.Traits
+			switch o.Type {
+			case types.InteractionPetting:
+				o.Scale(biology.VitalHappiness, 0.5+traits.Affectionate)
+			case types.InteractionPlaying:
+				o.Scale(biology.VitalHappiness, 0.5+traits.Playfulness)
+			case types.InteractionDiscipline:
+				o.Scale(biology.VitalStress, 0.5+traits.Neuroticism)
+			}
+		}},
+		{Name: ModifierEnvironment, Modify: func(p *DigitalPet, o *InteractionOutcome) {
+			if o.Type == types.InteractionGrooming {
+				o.Scale(biology.VitalCleanliness, p.Biology.Coat.GroomingEffectiveness())
+			}
+		}},
+		{Name: ModifierBond, Modify: func(p *DigitalPet, o *InteractionOutcome) {
+			if o.Type == types.InteractionComfort {
+				effectiveness := p.ComfortEffectiveness()
+				o.Scale(biology.VitalStress, effectiveness)
+				o.Quality *= effectiveness
+			}
+		}},
+		{Name: ModifierMemory, Modify: func(p *DigitalPet, o *InteractionOutcome) {
+			if valence := p.Memory.TagValence(ai.InteractionTag(o.Type)); valence < AversionThreshold {
+				o.Fear += -valence * ApprehensionScale
+			}
+		}},
+		{Name: ModifierFatigue, Modify: func(p *DigitalPet, o *InteractionOutcome) {
+			if o.Type == types.InteractionPlaying {
+				factor := 1.0 - FatigueDamping*p.Biology.Vitals.Fatigue
+				o.Scale(biology.VitalHappiness, factor)
+				o.Quality *= factor
+			}
+		}},
+	}
+}
+
+// applyOutcome changes the pet's vitals by an outcome, carries out the
+// interaction's side effects and clamps the result
+func (p *DigitalPet) applyOutcome(outcome InteractionOutcome) {
+	vitals := p.Biology.Vitals
+	for vital, delta := range outcome.Vitals {
+		vitals.Set(vital, vitals.Get(vital)+delta)
+	}
+
+	switch outcome.Type {
+	case types.InteractionFeeding:
+		p.Biology.Diet.Eat(outcome.Meal)
+	case types.InteractionGrooming:
+		p.brush(outcome.Intensity)
+	case types.InteractionMedicalCare:
+		p.Biology.Thermoregulation.Treat()
+	case types.InteractionGeneticScreening:
+		p.ScreenGenetics()
+	case types.InteractionComfort:
+		p.comfort(outcome.Intensity)
+	}
+
+	vitals.Clamp()
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestBaseOutcomeUsesTunedEffects(t *testing.T) {
+	outcome := baseOutcome(types.InteractionPlaying, 0.5)
+
+	want := map[biology.Vital]float64{
+		biology.VitalHappiness: Effects.PlayHappiness * 0.5,
+		biology.VitalEnergy:    -Effects.PlayEnergy * 0.5,
+		biology.VitalStress:    -Effects.PlayStress * 0.5,
+	}
+	if !reflect.DeepEqual(outcome.Vitals, want) || outcome.Quality != 0.5 {
+		t.Errorf("Expected the tuned play effects at half intensity, got %+v", outcome)
+	}
+}
+
+func TestBuiltinModifiers(t *testing.T) {
+	ep := NewEffectPipeline()
+	if got, want := ep.Modifiers(), []string{ModifierPersonality, ModifierEnvironment, ModifierBond, ModifierMemory, ModifierFatigue}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Modifiers() = %v, want %v", got, want)
+	}
+
+	pet := frightenedPet()
+	pet.Emotions.Affection = 0.0
+	pet.Personality.Traits.Loyalty = 0.0
+	effectiveness := pet.ComfortEffectiveness()
+
+	outcome := ep.Outcome(pet, types.InteractionComfort, 1.0)
+	if math.Abs(outcome.Vitals[biology.VitalStress]+ComfortStress*effectiveness) > 1e-9 || outcome.Quality != effectiveness {
+		t.Errorf("Bond should scale comfort by %.2f, got %+v", effectiveness, outcome)
+	}
+}
+
+func TestPersonalityAndFatigueModifiers(t *testing.T) {
+	ep := NewEffectPipeline()
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Personality.Traits.Playfulness = 0.5
+	pet.Biology.Vitals.Fatigue = 0.0
+	if got := ep.Outcome(pet, types.InteractionPlaying, 1.0); got.Vitals[biology.VitalHappiness] != Effects.PlayHappiness || got.Quality != 1.0 {
+		t.Errorf("An average, rested pet should get the base play effects, got %+v", got)
+	}
+
+	pet.Personality.Traits.Playfulness = 1.0
+	pet.Biology.Vitals.Fatigue = 1.0
+	outcome := ep.Outcome(pet, types.InteractionPlaying, 1.0)
+	want := Effects.PlayHappiness * 1.5 * (1 - FatigueDamping)
+	if math.Abs(outcome.Vitals[biology.VitalHappiness]-want) > 1e-9 || outcome.Quality != 1-FatigueDamping {
+		t.Errorf("Expected a playful but exhausted pet to gain %.3f happiness, got %+v", want, outcome)
+	}
+
+	pet.Personality.Traits.Neuroticism = 0.0
+	if got := ep.Outcome(pet, types.InteractionDiscipline, 1.0); math.Abs(got.Vitals[biology.VitalStress]-Effects.DisciplineStress*0.5) > 1e-9 {
+		t.Errorf("A calm pet should take half the stress of discipline, got %+v", got)
+	}
+}
+
+func TestModifiersRunInOrder(t *testing.T) {
+	ep := NewEffectPipeline()
+	var seen float64
+	if err := ep.Register(EffectModifier{Name: "festival", Modify: func(_ *DigitalPet, o *InteractionOutcome) {
+		seen = o.Vitals[biology.VitalHappiness]
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if names := ep.Modifiers(); names[len(names)-1] != "festival" {
+		t.Fatalf("Expected a registered modifier to run last, got %v", names)
+	}
+
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Personality.Traits.Playfulness = 0.5
+	pet.Biology.Vitals.Fatigue = 1.0
+	ep.Outcome(pet, types.InteractionPlaying, 1.0)
+	if want := Effects.PlayHappiness * (1 - FatigueDamping); math.Abs(seen-want) > 1e-9 {
+		t.Errorf("Expected the festival to see play already damped by fatigue (%.3f), got %.3f", want, seen)
+	}
+}
+
+func TestRegisterEffectModifier(t *testing.T) {
+	saved := DefaultEffectPipeline
+	defer func() { DefaultEffectPipeline = saved }()
+	DefaultEffectPipeline = NewEffectPipeline()
+
+	festival := EffectModifier{Name: "festival", Modify: func(_ *DigitalPet, o *InteractionOutcome) {
+		o.Scale(biology.VitalHappiness, 2.0)
+	}}
+	if err := RegisterEffectModifier(festival); err != nil {
+		t.Fatalf("RegisterEffectModifier failed: %v", err)
+	}
+	if err := RegisterEffectModifier(festival); !errors.Is(err, ErrModifierExists) {
+		t.Errorf("Expected ErrModifierExists, got %v", err)
+	}
+	if err := RegisterEffectModifier(EffectModifier{Name: "nameless"}); !errors.Is(err, ErrInvalidModifier) {
+		t.Errorf("Expected ErrInvalidModifier, got %v", err)
+	}
+
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Biology.Vitals.Happiness = 0.5
+	pet.ProcessUserInteraction(types.InteractionRewards, 1.0)
+	if want := 0.5 + 2*Effects.RewardHappiness; math.Abs(pet.Biology.Vitals.Happiness-want) > 1e-9 {
+		t.Errorf("Expected festival rewards to double happiness to %.2f, got %.2f", want, pet.Biology.Vitals.Happiness)
+	}
+}
+
+func TestOutcomeIsClampedWhenApplied(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Biology.Vitals.Nutrition = 0.9
+	pet.ProcessUserInteraction(types.InteractionFeeding, 1.0)
+
+	if pet.Biology.Vitals.Nutrition != 1.0 {
+		t.Errorf("Expected nutrition clamped to 1.0, got %.2f", pet.Biology.Vitals.Nutrition)
+	}
+}
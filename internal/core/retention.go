@@ -0,0 +1,156 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/social"
+)
+
+// RetentionPolicy limits how much of a pet's history is kept in detail.
+// Older records are summarized as aggregate counts rather than deleted.
+type RetentionPolicy struct {
+	MaxLongTermMemories  int // Long-term memories kept in detail
+	MaxSharedExperiences int // Shared experiences kept per relationship
+	MaxTraitSnapshots    int // Personality snapshots kept
+}
+
+// DefaultRetentionPolicy returns the standard retention limits
+func DefaultRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		MaxLongTermMemories:  ai.DefaultMaxLongTermMemories,
+		MaxSharedExperiences: social.DefaultMaxSharedExperiences,
+		MaxTraitSnapshots:    50,
+	}
+}
+
+// Least history a tightened policy keeps
+const (
+	MinLongTermMemories  = 10
+	MinSharedExperiences = 2
+	MinTraitSnapshots    = 5
+)
+
+// Tighten returns the policy with every limit halved level times, but no
+// lower than the Min limits
+func (policy RetentionPolicy) Tighten(level int) RetentionPolicy {
+	halve := func(limit, floor int) int {
+		for i := 0; i < level && limit > floor; i++ {
+			limit = max(limit/2, floor)
+		}
+		return limit
+	}
+	return RetentionPolicy{
+		MaxLongTermMemories:  halve(policy.MaxLongTermMemories, MinLongTermMemories),
+		MaxSharedExperiences: halve(policy.MaxSharedExperiences, MinSharedExperiences),
+		MaxTraitSnapshots:    halve(policy.MaxTraitSnapshots, MinTraitSnapshots),
+	}
+}
+
+// RetentionReport describes what a retention pass summarized or dropped
+type RetentionReport struct {
+	MemoriesSummarized    int
+	ExperiencesSummarized int
+	SnapshotsDropped      int
+}
+
+// Add adds another pass to the report
+func (r *RetentionReport) Add(other RetentionReport) {
+	r.MemoriesSummarized += other.MemoriesSummarized
+	r.ExperiencesSummarized += other.ExperiencesSummarized
+	r.SnapshotsDropped += other.SnapshotsDropped
+}
+
+// ApplyRetention compacts the pet's history according to the policy
+func (p *DigitalPet) ApplyRetention(policy RetentionPolicy) RetentionReport {
+	return RetentionReport{
+		MemoriesSummarized:    p.Memory.Compact(policy.MaxLongTermMemories),
+		ExperiencesSummarized: p.Relationships.Compact(policy.MaxSharedExperiences),
+		SnapshotsDropped:      p.Personality.TrimHistory(policy.MaxTraitSnapshots),
+	}
+}
+
+// UsageSection is the serialized size of one of a pet's systems
+type UsageSection struct {
+	Name  string
+	Bytes int
+}
+
+// MemoryUsage reports how much a pet's history holds and roughly how much
+// space it takes, measured as the size of each system when saved
+type MemoryUsage struct {
+	ShortTermMemories  int
+	LongTermMemories   int
+	SummarizedMemories int
+	Relationships      int
+	SharedExperiences  int
+	TraitSnapshots     int
+	Sections           []UsageSection
+	TotalBytes         int
+}
+
+// MemoryUsage measures the pet's history and the saved size of each system
+func (p *DigitalPet) MemoryUsage() MemoryUsage {
+	usage := MemoryUsage{
+		ShortTermMemories:  len(p.Memory.ShortTermMemories),
+		LongTermMemories:   len(p.Memory.LongTermMemories),
+		SummarizedMemories: p.Memory.SummarizedCount(),
+		Relationships:      p.Relationships.GetRelationshipCount(),
+		SharedExperiences:  p.Relationships.ExperienceCount(),
+		TraitSnapshots:     len(p.Personality.TraitHistory),
+	}
+
+	sections := []struct {
+		name  string
+		value interface{}
+	}{
+		{"memories", p.Memory},
+		{"relationships", p.Relationships},
+		{"personality", p.Personality},
+		{"genome", p.Genome},
+		{"pedigree", p.Pedigree},
+		{"biology", p.Biology},
+		{"skills", p.Skills},
+	}
+	for _, section := range sections {
+		encoded, err := json.Marshal(section.value)
+		if err != nil {
+			continue
+		}
+		usage.Sections = append(usage.Sections, UsageSection{Name: section.name, Bytes: len(encoded)})
+		usage.TotalBytes += len(encoded)
+	}
+	return usage
+}
+
+// historySections are the usage sections that grow with a pet's history
+var historySections = map[string]bool{"memories": true, "relationships": true, "personality": true}
+
+// HistoryBytes returns the saved size of the pet's memories, relationships
+// and personality history
+func (u MemoryUsage) HistoryBytes() int {
+	total := 0
+	for _, section := range u.Sections {
+		if historySections[section.Name] {
+			total += section.Bytes
+		}
+	}
+	return total
+}
+
+// String returns a short multi-line usage report
+func (u MemoryUsage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Memories: %d recent, %d long-term, %d summarized\n",
+		u.ShortTermMemories, u.LongTermMemories, u.SummarizedMemories)
+	fmt.Fprintf(&b, "Relationships: %d with %d shared experiences in detail\n",
+		u.Relationships, u.SharedExperiences)
+	fmt.Fprintf(&b, "Personality snapshots: %d\n", u.TraitSnapshots)
+	for _, section := range u.Sections {
+		fmt.Fprintf(&b, "  %-14s %8.1f KB\n", section.Name, float64(section.Bytes)/1024)
+	}
+	fmt.Fprintf(&b, "  %-14s %8.1f KB", "total", float64(u.TotalBytes)/1024)
+	return b.String()
+}
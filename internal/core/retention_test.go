@@ -0,0 +1,64 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestApplyRetentionShrinksSave(t *testing.T) {
+	pet := NewDigitalPet("Elder", "owner")
+	for i := 0; i < 500; i++ {
+		pet.Memory.Remember(&ai.Memory{Type: ai.MemoryEvent, Description: "A long afternoon nap in the sun", Strength: 0.8})
+		pet.Memory.ConsolidateMemories()
+	}
+	rel := pet.Relationships.AddRelationship("friend", types.RelationshipFriend)
+	for i := 0; i < 50; i++ {
+		rel.AddSharedExperience("chased leaves together", float64(i), 0.1)
+	}
+
+	before := pet.MemoryUsage()
+	report := pet.ApplyRetention(DefaultRetentionPolicy())
+	after := pet.MemoryUsage()
+
+	if report.MemoriesSummarized != 300 || report.ExperiencesSummarized != 30 {
+		t.Errorf("Expected 300 memories and 30 experiences summarized, got %+v", report)
+	}
+	if after.LongTermMemories != 200 || after.SummarizedMemories != 300 {
+		t.Errorf("Expected 200 kept and 300 summarized, got %+v", after)
+	}
+	if after.TotalBytes >= before.TotalBytes/2 {
+		t.Errorf("Expected the saved size to shrink substantially, %d -> %d bytes", before.TotalBytes, after.TotalBytes)
+	}
+	if !strings.Contains(after.String(), "300 summarized") || !strings.Contains(after.String(), "memories") {
+		t.Errorf("Unexpected usage report:\n%s", after)
+	}
+}
+
+func TestRetentionTighten(t *testing.T) {
+	policy := DefaultRetentionPolicy()
+	if got := policy.Tighten(0); got != policy {
+		t.Errorf("Expected level 0 to keep the policy, got %+v", got)
+	}
+	got := policy.Tighten(2)
+	if got.MaxLongTermMemories != 50 || got.MaxSharedExperiences != 5 || got.MaxTraitSnapshots != 12 {
+		t.Errorf("Expected every limit quartered, got %+v", got)
+	}
+	floor := RetentionPolicy{MinLongTermMemories, MinSharedExperiences, MinTraitSnapshots}
+	if got := policy.Tighten(20); got != floor {
+		t.Errorf("Expected the Min limits at most, got %+v", got)
+	}
+	// Limits already below the floor are left alone
+	if got := (RetentionPolicy{3, 1, 2}).Tighten(3); got != (RetentionPolicy{3, 1, 2}) {
+		t.Errorf("Expected small limits kept, got %+v", got)
+	}
+}
+
+func TestHistoryBytes(t *testing.T) {
+	usage := MemoryUsage{Sections: []UsageSection{{"memories", 100}, {"genome", 50}, {"relationships", 20}, {"personality", 5}}}
+	if got := usage.HistoryBytes(); got != 125 {
+		t.Errorf("Expected 125 bytes of history, got %d", got)
+	}
+}
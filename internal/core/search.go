@@ -0,0 +1,232 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+)
+
+// SearchHighTrait is the trait level a descriptive word such as "playful"
+// asks for, the same level GetPersonalityDescription calls "very playful"
+const SearchHighTrait = 0.7
+
+// ErrBadSearch is returned for a search term that cannot be understood
+var ErrBadSearch = errors.New("bad search")
+
+// personalityTraits reads the personality traits a search can name
+var personalityTraits = map[string]func(*ai.Traits) float64{
+	"openness":          func(t *ai.Traits) float64 { return t.Openness },
+	"conscientiousness": func(t *ai.Traits) float64 { return t.Conscientiousness },
+	"extraversion":      func(t *ai.Traits) float64 { return t.Extraversion },
+	"agreeableness":     func(t *ai.Traits) float64 { return t.Agreeableness },
+	"neuroticism":       func(t *ai.Traits) float64 { return t.Neuroticism },
+	"playfulness":       func(t *ai.Traits) float64 { return t.Playfulness },
+	"independence":      func(t *ai.Traits) float64 { return t.Independence },
+	"loyalty":           func(t *ai.Traits) float64 { return t.Loyalty },
+	"intelligence":      func(t *ai.Traits) float64 { return t.Intelligence },
+	"energy_level":      func(t *ai.Traits) float64 { return t.EnergyLevel },
+	"affectionate":      func(t *ai.Traits) float64 { return t.Affectionate },
+	"curiosity":         func(t *ai.Traits) float64 { return t.Curiosity },
+	"adaptability":      func(t *ai.Traits) float64 { return t.Adaptability },
+	"vocalization":      func(t *ai.Traits) float64 { return t.Vocalization },
+	"territoriality":    func(t *ai.Traits) float64 { return t.Territoriality },
+}
+
+// geneticTraits are searchable traits only the genome carries
+var geneticTraits = map[string]bool{"coat_length": true, "body_size": true}
+
+// traitAliases are shorter names for traits in search terms
+var traitAliases = map[string]string{
+	"fur":    "coat_length",
+	"coat":   "coat_length",
+	"size":   "body_size",
+	"energy": "energy_level",
+	"smarts": "intelligence",
+}
+
+// traitWords are the descriptive words a search accepts for a high trait
+var traitWords = map[string]string{
+	"playful":      "playfulness",
+	"independent":  "independence",
+	"loyal":        "loyalty",
+	"intelligent":  "intelligence",
+	"smart":        "intelligence",
+	"energetic":    "energy_level",
+	"affectionate": "affectionate",
+	"curious":      "curiosity",
+	"adaptable":    "adaptability",
+	"vocal":        "vocalization",
+	"territorial":  "territoriality",
+	"fluffy":       "coat_length",
+	"big":          "body_size",
+}
+
+// searchOperators are the comparisons a trait term may use, longest first
+// so that ">=" is not read as ">"
+var searchOperators = []string{">=", "<=", ">", "<", "="}
+
+// TraitCondition compares a trait with a value, e.g. fur>0.7
+type TraitCondition struct {
+	Trait string  `json:"trait"` // Canonical trait name, e.g. "coat_length"
+	Op    string  `json:"op"`    // One of >=, <=, >, < and =
+	Value float64 `json:"value"`
+}
+
+// String returns the condition as it is written in a search
+func (c TraitCondition) String() string {
+	return c.Trait + c.Op + strconv.FormatFloat(c.Value, 'f', -1, 64)
+}
+
+// holds returns true if a trait level satisfies the condition
+func (c TraitCondition) holds(level float64) bool {
+	switch c.Op {
+	case ">=":
+		return level >= c.Value
+	case "<=":
+		return level <= c.Value
+	case ">":
+		return level > c.Value
+	case "<":
+		return level < c.Value
+	}
+	return level == c.Value
+}
+
+// Search selects pets by name, tag and trait levels; every part must
+// match, and an empty search matches every pet
+type Search struct {
+	Names  []string         `json:"names,omitempty"` // Substrings of the name, ignoring case
+	Tags   []string         `json:"tags,omitempty"`
+	Traits []TraitCondition `json:"traits,omitempty"`
+}
+
+// CanonicalTrait returns the trait a search name or alias refers to
+func CanonicalTrait(name string) (string, bool) {
+	name = strings.ToLower(name)
+	if alias, exists := traitAliases[name]; exists {
+		name = alias
+	}
+	if _, exists := personalityTraits[name]; exists || geneticTraits[name] {
+		return name, true
+	}
+	return "", false
+}
+
+// ParseSearch reads a search such as `playful fur>0.7 tag:favorite`.
+// Terms are tag:<tag>, name:<text>, <trait><op><level> with op one of
+// >, >=, <, <= and =, or a word: descriptive words such as "playful" ask
+// for a trait above SearchHighTrait and any other word is part of the
+// name.
+func ParseSearch(query string) (Search, error) {
+	var s Search
+	for _, term := range strings.Fields(query) {
+		if err := s.add(term); err != nil {
+			return Search{}, err
+		}
+	}
+	return s, nil
+}
+
+// add adds one term to the search
+func (s *Search) add(term string) error {
+	lower := strings.ToLower(term)
+	switch {
+	case strings.HasPrefix(lower, "tag:"):
+		tag, err := NormalizeTag(lower[len("tag:"):])
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrBadSearch, err)
+		}
+		s.Tags = append(s.Tags, tag)
+		return nil
+	case strings.HasPrefix(lower, "name:"):
+		if lower == "name:" {
+			return fmt.Errorf("%w: empty name", ErrBadSearch)
+		}
+		s.Names = append(s.Names, lower[len("name:"):])
+		return nil
+	}
+
+	for _, op := range searchOperators {
+		i := strings.Index(lower, op)
+		if i < 0 {
+			continue
+		}
+		trait, ok := CanonicalTrait(lower[:i])
+		if !ok {
+			return fmt.Errorf("%w: unknown trait %q", ErrBadSearch, lower[:i])
+		}
+		value, err := strconv.ParseFloat(lower[i+len(op):], 64)
+		if err != nil || value < 0 || value > 1 {
+			return fmt.Errorf("%w: %q needs a level from 0 to 1", ErrBadSearch, term)
+		}
+		s.Traits = append(s.Traits, TraitCondition{Trait: trait, Op: op, Value: value})
+		return nil
+	}
+
+	if trait, ok := traitWords[lower]; ok {
+		s.Traits = append(s.Traits, TraitCondition{Trait: trait, Op: ">", Value: SearchHighTrait})
+		return nil
+	}
+	s.Names = append(s.Names, lower)
+	return nil
+}
+
+// TraitLevel returns a pet's level of a searchable trait, by its canonical
+// name or an alias. Personality traits are read as they are now, after
+// experience has shaped them; coat length and body size from the genome.
+func (p *DigitalPet) TraitLevel(name string) (float64, bool) {
+	trait, ok := CanonicalTrait(name)
+	if !ok {
+		return 0, false
+	}
+	if read, exists := personalityTraits[trait]; exists && p.Personality != nil && p.Personality.Traits != nil {
+		return read(p.Personality.Traits), true
+	}
+	if p.Genome == nil {
+		return 0, false
+	}
+	return p.Genome.GetTraitValue(trait), true
+}
+
+// Matches returns true if a pet satisfies every part of the search
+func (s Search) Matches(p *DigitalPet) bool {
+	name := strings.ToLower(p.Name)
+	for _, part := range s.Names {
+		if !strings.Contains(name, part) {
+			return false
+		}
+	}
+	for _, tag := range s.Tags {
+		if !p.HasTag(tag) {
+			return false
+		}
+	}
+	for _, condition := range s.Traits {
+		level, ok := p.TraitLevel(condition.Trait)
+		if !ok || !condition.holds(level) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchPets returns the pets a search matches, sorted by name
+func SearchPets(pets []*DigitalPet, s Search) []*DigitalPet {
+	var found []*DigitalPet
+	for _, pet := range pets {
+		if s.Matches(pet) {
+			found = append(found, pet)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Name != found[j].Name {
+			return found[i].Name < found[j].Name
+		}
+		return found[i].ID < found[j].ID
+	})
+	return found
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+)
+
+func TestParseSearch(t *testing.T) {
+	s, err := ParseSearch("playful fur>=0.7 tag:Favorite name:Rex bo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Traits) != 2 || s.Traits[0].String() != "playfulness>0.7" || s.Traits[1].String() != "coat_length>=0.7" {
+		t.Errorf("Expected playfulness>0.7 and coat_length>=0.7, got %v", s.Traits)
+	}
+	if len(s.Tags) != 1 || s.Tags[0] != "favorite" {
+		t.Errorf("Expected tag favorite, got %v", s.Tags)
+	}
+	if len(s.Names) != 2 || s.Names[0] != "rex" || s.Names[1] != "bo" {
+		t.Errorf("Expected names rex and bo, got %v", s.Names)
+	}
+
+	for _, bad := range []string{"wings>0.5", "fur>lots", "fur>2", "tag:", "name:"} {
+		if _, err := ParseSearch(bad); !errors.Is(err, ErrBadSearch) {
+			t.Errorf("Expected ErrBadSearch for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestSearchPets(t *testing.T) {
+	fluffy := NewDigitalPet("Bolt", "user123")
+	fluffy.Personality.Traits.Playfulness = 0.9
+	fluffy.Genome.Traits["coat_length"] = genetics.GenePair{
+		Maternal: genetics.Allele{Value: 0.9, Dominant: true}, Paternal: genetics.Allele{Value: 0.9, Dominant: true}}
+	fluffy.AddTag("favorite")
+
+	sleek := NewDigitalPet("Bean", "user123")
+	sleek.Personality.Traits.Playfulness = 0.9
+	sleek.Genome.Traits["coat_length"] = genetics.GenePair{
+		Maternal: genetics.Allele{Value: 0.1, Dominant: true}, Paternal: genetics.Allele{Value: 0.1, Dominant: true}}
+	sleek.AddTag("favorite")
+
+	calm := NewDigitalPet("Azure", "user123")
+	calm.Personality.Traits.Playfulness = 0.2
+
+	pets := []*DigitalPet{fluffy, sleek, calm}
+	s, _ := ParseSearch("playful fur>0.7 tag:favorite")
+	if found := SearchPets(pets, s); len(found) != 1 || found[0] != fluffy {
+		t.Errorf("Expected only Bolt, got %v", found)
+	}
+	s, _ = ParseSearch("b")
+	if found := SearchPets(pets, s); len(found) != 2 || found[0] != sleek || found[1] != fluffy {
+		t.Errorf("Expected Bean then Bolt by name, got %v", found)
+	}
+	if found := SearchPets(pets, Search{}); len(found) != 3 || found[0] != calm {
+		t.Errorf("An empty search should find every pet sorted by name, got %v", found)
+	}
+
+	if level, ok := fluffy.TraitLevel("FUR"); !ok || level != 0.9 {
+		t.Errorf("Expected a coat length of 0.9, got %v (%v)", level, ok)
+	}
+}
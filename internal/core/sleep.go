@@ -0,0 +1,61 @@
+package core
+
+import (
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Night sleep settings
+const (
+	NightRestRate = 1.0       // Fatigue shed and energy regained per game day asleep at night
+	CatchUpStep   = time.Hour // Real time simulated at once when catching up
+)
+
+// KeepHours puts the pet to bed at night so it sleeps when its owner does,
+// and lets it rest. Sick, distressed and hibernating pets are left alone.
+func (p *DigitalPet) KeepHours(night bool, deltaTime float64) {
+	if !night || !p.Biology.IsAlive {
+		return
+	}
+	switch p.CurrentBehavior {
+	case types.BehaviorSick, types.BehaviorDistressed, types.BehaviorHibernating:
+		return
+	}
+
+	p.CurrentBehavior = types.BehaviorSleeping
+	vitals := p.Biology.Vitals
+	vitals.Fatigue -= NightRestRate * deltaTime
+	vitals.Energy += NightRestRate * deltaTime
+	vitals.Clamp()
+}
+
+// CatchUp simulates the real time that passed since the pet was last
+// updated, e.g. while the game was closed, one CatchUpStep at a time so
+// the pet sleeps through the nights of now's time zone. At most limit is
+// simulated. Returns the game days simulated.
+func (p *DigitalPet) CatchUp(now time.Time, limit time.Duration) float64 {
+	from := p.LastUpdateAt
+	if from.IsZero() || !now.After(from) {
+		return 0
+	}
+	if now.Sub(from) > limit {
+		from = now.Add(-limit)
+	}
+
+	var elapsed time.Duration
+	for t := from; t.Before(now) && p.Biology.IsAlive; {
+		step := CatchUpStep
+		if remaining := now.Sub(t); remaining < step {
+			step = remaining
+		}
+		delta := step.Hours() / 24.0
+		p.Update(delta)
+		p.KeepHours(environment.IsNight(t.In(now.Location())), delta)
+		elapsed += step
+		t = t.Add(step)
+	}
+	p.LastUpdateAt = now
+	return elapsed.Hours() / 24.0
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestKeepHours(t *testing.T) {
+	pet := NewDigitalPet("Mochi", "user123")
+	pet.CurrentBehavior = types.BehaviorHappy
+	pet.Biology.Vitals.Fatigue = 0.5
+	pet.Biology.Vitals.Energy = 0.5
+
+	pet.KeepHours(false, 0.1)
+	if pet.CurrentBehavior != types.BehaviorHappy || pet.Biology.Vitals.Fatigue != 0.5 {
+		t.Errorf("Expected nothing to change by day, got %s", pet.CurrentBehavior)
+	}
+
+	pet.KeepHours(true, 0.1)
+	if pet.CurrentBehavior != types.BehaviorSleeping {
+		t.Errorf("Expected the pet asleep at night, got %s", pet.CurrentBehavior)
+	}
+	if pet.Biology.Vitals.Fatigue >= 0.5 || pet.Biology.Vitals.Energy <= 0.5 {
+		t.Errorf("Expected the pet to rest, got %+v", pet.Biology.Vitals)
+	}
+
+	pet.CurrentBehavior = types.BehaviorSick
+	pet.KeepHours(true, 0.1)
+	if pet.CurrentBehavior != types.BehaviorSick {
+		t.Errorf("Expected a sick pet to stay sick, got %s", pet.CurrentBehavior)
+	}
+}
+
+func TestCatchUp(t *testing.T) {
+	now := time.Date(2024, time.June, 3, 7, 0, 0, 0, time.UTC)
+	pet := NewDigitalPet("Mochi", "user123")
+	pet.LastUpdateAt = now.Add(-12 * time.Hour)
+	pet.Biology.Vitals.Fatigue = 0.6
+
+	days := pet.CatchUp(now, 72*time.Hour)
+	if days != 0.5 || !pet.LastUpdateAt.Equal(now) {
+		t.Fatalf("Expected half a day caught up to now, got %.3f to %v", days, pet.LastUpdateAt)
+	}
+	if pet.Biology.Vitals.Fatigue >= 0.6 {
+		t.Errorf("Expected a night's sleep to ease fatigue, got %.2f", pet.Biology.Vitals.Fatigue)
+	}
+	if pet.CatchUp(now, 72*time.Hour) != 0 {
+		t.Error("Expected nothing more to catch up")
+	}
+
+	pet.LastUpdateAt = now.Add(-30 * 24 * time.Hour)
+	if days := pet.CatchUp(now, 72*time.Hour); days != 3 {
+		t.Errorf("Expected catch-up capped at 3 days, got %.3f", days)
+	}
+}
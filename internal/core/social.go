@@ -0,0 +1,37 @@
+package core
+
+import "github.com/Michael-W-Ellison/gochi/internal/social"
+
+// BuildSocialGraph aggregates the relationships of a group of pets into
+// a single social graph, naming each pet with its active title
+func BuildSocialGraph(pets []*DigitalPet) *social.SocialGraph {
+	graph := social.NewSocialGraph()
+	for _, pet := range pets {
+		graph.AddPet(pet.ID, pet.DisplayName(), pet.Relationships)
+	}
+	return graph
+}
+
+// CoordinateRelationships registers a group of pets with a relationship
+// coordinator and repairs any one-sided relationships between them
+func CoordinateRelationships(pets []*DigitalPet) (*social.RelationshipCoordinator, int) {
+	coordinator := social.NewRelationshipCoordinator()
+	for _, pet := range pets {
+		pet.CoordinateWith(coordinator)
+	}
+	return coordinator, coordinator.Reconcile()
+}
+
+// CoordinateWith registers the pet's relationships with a coordinator,
+// which keeps them in step with those of the other pets it knows
+func (p *DigitalPet) CoordinateWith(coordinator *social.RelationshipCoordinator) {
+	coordinator.Register(p.ID, p.Relationships, p.relationshipAsymmetry())
+}
+
+// relationshipAsymmetry returns how far a pet's feelings may differ from
+// those of the pets it knows. Independent, less agreeable pets can be
+// more one-sided.
+func (p *DigitalPet) relationshipAsymmetry() float64 {
+	traits := p.Personality.Traits
+	return 0.1 + 0.2*traits.Independence + 0.1*(1.0-traits.Agreeableness)
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Tag settings
+const (
+	MaxTags      = 10 // Tags a pet can carry
+	MaxTagLength = 24 // Characters in a tag
+)
+
+var (
+	// ErrInvalidTag is returned for a tag that is empty, too long or has
+	// characters other than letters, digits, '-' and '_'
+	ErrInvalidTag = errors.New("invalid tag")
+	// ErrTooManyTags is returned when tagging a pet that has MaxTags tags
+	ErrTooManyTags = errors.New("too many tags")
+)
+
+// NormalizeTag returns a tag in lower case, or ErrInvalidTag
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" || len(tag) > MaxTagLength {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+	}
+	for _, r := range tag {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTag, tag)
+		}
+	}
+	return tag, nil
+}
+
+// HasTag returns true if the pet carries a tag, ignoring case
+func (p *DigitalPet) HasTag(tag string) bool {
+	for _, t := range p.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag labels the pet, keeping its tags sorted. Adding a tag the pet
+// already carries does nothing.
+func (p *DigitalPet) AddTag(tag string) error {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return err
+	}
+	if p.HasTag(tag) {
+		return nil
+	}
+	if len(p.Tags) >= MaxTags {
+		return fmt.Errorf("%w: %s already has %d", ErrTooManyTags, p.Name, MaxTags)
+	}
+	p.Tags = append(p.Tags, tag)
+	sort.Strings(p.Tags)
+	return nil
+}
+
+// RemoveTag takes a tag off the pet and returns true if it carried it
+func (p *DigitalPet) RemoveTag(tag string) bool {
+	for i, t := range p.Tags {
+		if strings.EqualFold(t, tag) {
+			p.Tags = append(p.Tags[:i], p.Tags[i+1:]...)
+			if len(p.Tags) == 0 {
+				p.Tags = nil
+			}
+			return true
+		}
+	}
+	return false
+}
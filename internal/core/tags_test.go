@@ -0,0 +1,55 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAddAndRemoveTags(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	for _, tag := range []string{"Favorite", "breeder", "favorite"} {
+		if err := pet.AddTag(tag); err != nil {
+			t.Fatalf("AddTag(%q) failed: %v", tag, err)
+		}
+	}
+	if len(pet.Tags) != 2 || pet.Tags[0] != "breeder" || pet.Tags[1] != "favorite" {
+		t.Errorf("Expected [breeder favorite], got %v", pet.Tags)
+	}
+	if !pet.HasTag("FAVORITE") {
+		t.Error("Tags should match ignoring case")
+	}
+
+	for _, bad := range []string{"", "two words", "way-too-long-for-a-pet-tag"} {
+		if err := pet.AddTag(bad); !errors.Is(err, ErrInvalidTag) {
+			t.Errorf("Expected ErrInvalidTag for %q, got %v", bad, err)
+		}
+	}
+
+	if !pet.RemoveTag("breeder") || pet.RemoveTag("breeder") {
+		t.Error("RemoveTag should report whether the pet carried the tag")
+	}
+	payload, err := json.Marshal(pet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded DigitalPet
+	if err := json.Unmarshal(payload, &loaded); err != nil || !loaded.HasTag("favorite") {
+		t.Errorf("Expected tags to survive a save, got %v (%v)", loaded.Tags, err)
+	}
+}
+
+func TestTagLimit(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	for i := 0; i < MaxTags; i++ {
+		if err := pet.AddTag(string(rune('a' + i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pet.AddTag("one-more"); !errors.Is(err, ErrTooManyTags) {
+		t.Errorf("Expected ErrTooManyTags, got %v", err)
+	}
+	if err := pet.AddTag("a"); err != nil {
+		t.Errorf("Re-adding a tag should not count against the limit, got %v", err)
+	}
+}
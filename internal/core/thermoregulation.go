@@ -0,0 +1,62 @@
+package core
+
+import (
+	"math"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Temperature regulation settings
+const (
+	NaturalShelter     = 0.4 // Shelter a pet finds by hunkering down away from home
+	ShelterSeekingHeat = 0.3 // Thermal discomfort at which a pet goes looking for shelter
+)
+
+// thermalExposure returns what the pet's body must cope with under the
+// weather, from its coat and the shelter around it
+func (p *DigitalPet) thermalExposure(effects environment.WeatherEffects) biology.ThermalExposure {
+	shelter := effects.Shelter
+	if p.CurrentBehavior == types.BehaviorSheltering {
+		shelter = math.Max(shelter, NaturalShelter)
+	}
+	return biology.ThermalExposure{
+		Ambient:    effects.Temperature + effects.HabitatShift,
+		Insulation: p.Biology.Coat.Insulation(),
+		Shelter:    shelter,
+	}
+}
+
+// regulateTemperature moves the pet's body temperature with the weather.
+// Being too hot or cold is stressful, and a sustained extreme brings on
+// hypothermia or heatstroke.
+func (p *DigitalPet) regulateTemperature(effects environment.WeatherEffects, deltaTime float64) {
+	thermo := p.Biology.Thermoregulation
+	onset := thermo.Update(p.thermalExposure(effects), deltaTime)
+
+	cold, heat := thermo.Discomfort()
+	vitals := p.Biology.Vitals
+	vitals.Stress += 0.1 * math.Max(cold, heat) * deltaTime
+	vitals.Clamp()
+
+	if onset != biology.ThermalNone {
+		p.Memory.Remember(&ai.Memory{
+			Type:        ai.MemoryEvent,
+			Description: "Came down with " + onset.String(),
+			GameTime:    p.GetAge(),
+			Strength:    0.8,
+			Valence:     -0.8,
+			Emotion:     p.Emotions.DominantEmotion,
+			Tags:        []string{"illness", effects.Weather.Tag()},
+		})
+	}
+}
+
+// SeeksShelter returns true if the pet is uncomfortable enough with the
+// temperature to go looking for shelter
+func (p *DigitalPet) SeeksShelter() bool {
+	cold, heat := p.Biology.Thermoregulation.Discomfort()
+	return math.Max(cold, heat) >= ShelterSeekingHeat
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestColdPetSeeksShelterAndFallsIll(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Biology.Coat = biology.NewCoat(0.0)
+	pet.CurrentBehavior = types.BehaviorPlaying
+	freezing := environment.WeatherEffects{Weather: environment.WeatherClear, Season: types.SeasonWinter, Temperature: -25, Hour: 12}
+
+	pet.ReactToWeather(freezing, 0.2)
+	if pet.CurrentBehavior != types.BehaviorSheltering {
+		t.Errorf("A freezing pet should seek shelter, got %s", pet.CurrentBehavior)
+	}
+
+	for i := 0; i < 10; i++ {
+		pet.ReactToWeather(freezing, 0.2)
+	}
+	thermo := pet.Biology.Thermoregulation
+	if thermo.Condition != biology.ThermalHypothermia {
+		t.Fatalf("Expected hypothermia, body at %.1f°C", thermo.BodyTemperature)
+	}
+	found := false
+	for _, need := range pet.GetCurrentStatus().CriticalNeeds {
+		found = found || need == "Hypothermia"
+	}
+	if !found {
+		t.Error("Hypothermia should be reported as a critical need")
+	}
+
+	pet.ProcessUserInteraction(types.InteractionMedicalCare, 1.0)
+	if thermo.HasCondition() {
+		t.Error("Medical care should treat hypothermia")
+	}
+}
+
+func TestHomeHabitatKeepsPetWarm(t *testing.T) {
+	habitat := environment.NewHabitat()
+	habitat.Add(environment.HabitatHeater)
+	habitat.Add(environment.HabitatBed)
+	cold := environment.WeatherEffects{Season: types.SeasonWinter, Temperature: -10, Hour: 12}
+
+	outside := NewDigitalPet("Outside", "user123")
+	outside.Biology.Coat = biology.NewCoat(0.0)
+	inside := NewDigitalPet("Inside", "user123")
+	inside.Biology.Coat = biology.NewCoat(0.0)
+
+	outside.ReactToWeather(cold, 1.0)
+	inside.ReactToWeather(habitat.Apply(cold), 1.0)
+
+	if inside.Biology.Thermoregulation.BodyTemperature <= outside.Biology.Thermoregulation.BodyTemperature {
+		t.Error("A heated, furnished home should keep the pet warmer")
+	}
+	if inside.SeeksShelter() {
+		t.Error("A pet in a heated home should be comfortable")
+	}
+}
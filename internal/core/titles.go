@@ -0,0 +1,217 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// Epithet settings
+const (
+	BraveDangerousTrips   = 1    // Dangerous journeys survived to be called the Brave
+	GourmandSpecialMeals  = 50   // Produce and fish eaten to be called the Gourmand
+	WandererTrips         = 10   // Journeys made to be called the Wanderer
+	EpithetTraitThreshold = 0.85 // Trait level that earns a trait epithet
+	EpithetTraitAge       = 7.0  // Days before a pet's character earns it an epithet
+	TagTitle              = "title"
+)
+
+// ErrUnknownTitle is returned when choosing a title the pet has not earned
+var ErrUnknownTitle = errors.New("title not earned")
+
+// Epithet is a title a pet earns through its deeds or character
+type Epithet int
+
+const (
+	EpithetBrave Epithet = iota
+	EpithetGourmand
+	EpithetWanderer
+	EpithetCurious
+	EpithetGentle
+	EpithetLoyal
+)
+
+// AllEpithets returns every epithet in declaration order
+func AllEpithets() []Epithet {
+	return []Epithet{EpithetBrave, EpithetGourmand, EpithetWanderer, EpithetCurious, EpithetGentle, EpithetLoyal}
+}
+
+// String returns the epithet as it follows a pet's name
+func (e Epithet) String() string {
+	return [...]string{"the Brave", "the Gourmand", "the Wanderer", "the Curious", "the Gentle", "the Loyal"}[e]
+}
+
+// Reason describes what earns an epithet
+func (e Epithet) Reason() string {
+	return [...]string{
+		"surviving a journey somewhere dangerous",
+		fmt.Sprintf("eating %d special meals of produce or fish", GourmandSpecialMeals),
+		fmt.Sprintf("making %d journeys", WandererTrips),
+		"an endlessly curious nature",
+		"a kind and agreeable nature",
+		"unwavering loyalty",
+	}[e]
+}
+
+// Renown records the deeds that earn epithets and which title a pet goes by
+type Renown struct {
+	Trips          int       `json:"trips,omitempty"`
+	DangerousTrips int       `json:"dangerous_trips,omitempty"`
+	SpecialMeals   int       `json:"special_meals,omitempty"`
+	Epithets       []Epithet `json:"epithets,omitempty"`
+	ActiveTitle    string    `json:"active_title,omitempty"` // Shown with the pet's name; empty for none
+}
+
+// renown returns the pet's renown, creating it on first use
+func (p *DigitalPet) renown() *Renown {
+	if p.Renown == nil {
+		p.Renown = &Renown{}
+	}
+	return p.Renown
+}
+
+// RecordSpecialMeal counts a meal of produce or fish towards the Gourmand
+func (p *DigitalPet) RecordSpecialMeal() {
+	p.renown().SpecialMeals++
+}
+
+// recordTrip counts a journey towards the Wanderer and, if the destination
+// was dangerous, the Brave
+func (p *DigitalPet) recordTrip(trip environment.Trip) {
+	renown := p.renown()
+	renown.Trips++
+	if trip.Danger >= environment.DangerThreshold {
+		renown.DangerousTrips++
+	}
+}
+
+// HasEpithet returns true if the pet has earned an epithet
+func (p *DigitalPet) HasEpithet(epithet Epithet) bool {
+	if p.Renown == nil {
+		return false
+	}
+	for _, earned := range p.Renown.Epithets {
+		if earned == epithet {
+			return true
+		}
+	}
+	return false
+}
+
+// deserves returns true if the pet currently meets an epithet's condition
+func (p *DigitalPet) deserves(epithet Epithet) bool {
+	var renown Renown
+	if p.Renown != nil {
+		renown = *p.Renown
+	}
+	traits := p.Personality.Traits
+	grown := p.GetAge() >= EpithetTraitAge
+	switch epithet {
+	case EpithetBrave:
+		return renown.DangerousTrips >= BraveDangerousTrips
+	case EpithetGourmand:
+		return renown.SpecialMeals >= GourmandSpecialMeals
+	case EpithetWanderer:
+		return renown.Trips >= WandererTrips
+	case EpithetCurious:
+		return grown && traits.Curiosity >= EpithetTraitThreshold
+	case EpithetGentle:
+		return grown && traits.Agreeableness >= EpithetTraitThreshold
+	case EpithetLoyal:
+		return grown && traits.Loyalty >= EpithetTraitThreshold
+	}
+	return false
+}
+
+// AwardEpithets grants every epithet the pet now deserves but has not yet
+// earned, and returns them. Epithets are kept once earned.
+func (p *DigitalPet) AwardEpithets() []Epithet {
+	if !p.IsAlive() {
+		return nil
+	}
+	var awarded []Epithet
+	for _, epithet := range AllEpithets() {
+		if p.HasEpithet(epithet) || !p.deserves(epithet) {
+			continue
+		}
+		renown := p.renown()
+		renown.Epithets = append(renown.Epithets, epithet)
+		awarded = append(awarded, epithet)
+		p.Memory.Remember(&ai.Memory{
+			Type:        ai.MemoryEvent,
+			Description: fmt.Sprintf("Became known as %s %s", p.Name, epithet),
+			GameTime:    p.GetAge(),
+			Strength:    0.6,
+			Valence:     0.6,
+			Emotion:     p.Emotions.DominantEmotion,
+			Tags:        []string{TagTitle},
+		})
+	}
+	return awarded
+}
+
+// Titles returns every title the pet may go by: its certificate titles in
+// skill order, then its epithets in the order they were earned
+func (p *DigitalPet) Titles() []string {
+	var titles []string
+	if p.Certifications != nil {
+		certificates := append([]Certificate(nil), p.Certifications.Certificates...)
+		sort.Slice(certificates, func(i, j int) bool { return certificates[i].Skill < certificates[j].Skill })
+		for _, certificate := range certificates {
+			titles = append(titles, certificate.Title())
+		}
+	}
+	if p.Renown != nil {
+		for _, epithet := range p.Renown.Epithets {
+			titles = append(titles, epithet.String())
+		}
+	}
+	return titles
+}
+
+// ActiveTitle returns the title the pet goes by, or "" for none
+func (p *DigitalPet) ActiveTitle() string {
+	if p.Renown == nil {
+		return ""
+	}
+	return p.Renown.ActiveTitle
+}
+
+// SetTitle chooses which earned title is shown with the pet's name,
+// ignoring case. An empty title shows none.
+func (p *DigitalPet) SetTitle(title string) error {
+	if title == "" {
+		if p.Renown != nil {
+			p.Renown.ActiveTitle = ""
+		}
+		return nil
+	}
+	for _, earned := range p.Titles() {
+		if strings.EqualFold(earned, title) {
+			p.renown().ActiveTitle = earned
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s has not earned %q", ErrUnknownTitle, p.Name, title)
+}
+
+// DisplayName returns the pet's name with its active title, e.g.
+// "Rex the Brave" or "Rex, Agility Ace"
+func (p *DigitalPet) DisplayName() string {
+	return titled(p.Name, p.ActiveTitle())
+}
+
+// titled joins a name and a title
+func titled(name, title string) string {
+	switch {
+	case title == "":
+		return name
+	case strings.HasPrefix(title, "the "):
+		return name + " " + title
+	}
+	return name + ", " + title
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestBraveAfterDangerousTrip(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Personality.Traits.Curiosity = 0.5
+
+	safe := environment.Trip{From: environment.HomeLocation, To: "beach", Days: 0.2, Danger: 0.1}
+	if err := pet.Travel(safe); err != nil {
+		t.Fatal(err)
+	}
+	if awarded := pet.AwardEpithets(); len(awarded) != 0 {
+		t.Errorf("A safe trip should not earn an epithet, got %v", awarded)
+	}
+
+	dangerous := environment.Trip{From: "beach", To: "tundra", Days: 0.4, Danger: environment.DangerThreshold + 0.2}
+	if err := pet.Travel(dangerous); err != nil {
+		t.Fatal(err)
+	}
+	awarded := pet.AwardEpithets()
+	if len(awarded) != 1 || awarded[0] != EpithetBrave {
+		t.Fatalf("Expected the Brave, got %v", awarded)
+	}
+	if len(pet.Memory.ByTag(TagTitle)) != 1 {
+		t.Error("Earning an epithet should be remembered")
+	}
+	if awarded := pet.AwardEpithets(); len(awarded) != 0 {
+		t.Errorf("Epithets should only be awarded once, got %v", awarded)
+	}
+}
+
+func TestGourmandAfterSpecialMeals(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	for i := 0; i < GourmandSpecialMeals-1; i++ {
+		pet.RecordSpecialMeal()
+	}
+	if pet.AwardEpithets(); pet.HasEpithet(EpithetGourmand) {
+		t.Fatal("The Gourmand should need every special meal")
+	}
+	pet.RecordSpecialMeal()
+	pet.AwardEpithets()
+	if !pet.HasEpithet(EpithetGourmand) {
+		t.Error("Expected the Gourmand after enough special meals")
+	}
+}
+
+func TestTraitEpithetsWaitForAge(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Personality.Traits.Loyalty = EpithetTraitThreshold
+	if pet.AwardEpithets(); pet.HasEpithet(EpithetLoyal) {
+		t.Fatal("A newborn's character should not earn an epithet yet")
+	}
+	pet.Biology.Processes.Age = EpithetTraitAge
+	pet.AwardEpithets()
+	if !pet.HasEpithet(EpithetLoyal) {
+		t.Error("Expected the Loyal once grown")
+	}
+
+	pet.Personality.Traits.Loyalty = 0.1
+	pet.AwardEpithets()
+	if !pet.HasEpithet(EpithetLoyal) {
+		t.Error("Epithets should be kept once earned")
+	}
+}
+
+func TestSetTitle(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	if err := pet.SetTitle("the Brave"); !errors.Is(err, ErrUnknownTitle) {
+		t.Errorf("Expected ErrUnknownTitle, got %v", err)
+	}
+
+	pet.Renown = &Renown{Epithets: []Epithet{EpithetBrave}}
+	pet.Certifications = &Certifications{Certificates: []Certificate{{Skill: ai.SkillAgility, Score: 0.8}}}
+	if titles := pet.Titles(); len(titles) != 2 || titles[0] != "Agility Ace" || titles[1] != "the Brave" {
+		t.Errorf("Expected certificate titles then epithets, got %v", titles)
+	}
+
+	if err := pet.SetTitle("THE BRAVE"); err != nil {
+		t.Fatal(err)
+	}
+	if got := pet.DisplayName(); got != "Rex the Brave" {
+		t.Errorf("Expected Rex the Brave, got %q", got)
+	}
+	if status := pet.GetCurrentStatus().String(); !strings.Contains(status, "=== Rex the Brave") {
+		t.Errorf("Expected the title in the status header, got:\n%s", status)
+	}
+	if err := pet.SetTitle("agility ace"); err != nil {
+		t.Fatal(err)
+	}
+	if got := pet.DisplayName(); got != "Rex, Agility Ace" {
+		t.Errorf("Expected Rex, Agility Ace, got %q", got)
+	}
+
+	data, err := pet.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded DigitalPet
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ActiveTitle() != "Agility Ace" || !loaded.HasEpithet(EpithetBrave) {
+		t.Error("Titles should survive a save")
+	}
+
+	if err := pet.SetTitle(""); err != nil || pet.DisplayName() != "Rex" {
+		t.Errorf("Expected the title cleared, got %q (%v)", pet.DisplayName(), err)
+	}
+}
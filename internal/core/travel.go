@@ -0,0 +1,46 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// ErrCannotTravel is returned when a pet is in no state to make a journey
+var ErrCannotTravel = errors.New("pet cannot travel right now")
+
+// TravelEnergyCost is the energy spent per game day on the road
+const TravelEnergyCost = 0.3
+
+// Travel takes the pet on a planned trip. The journey tires it, and
+// dangerous destinations are stressful for anxious pets, though less so
+// for pets trusted off the leash. Each journey counts towards the pet's
+// epithets.
+func (p *DigitalPet) Travel(trip environment.Trip) error {
+	if !p.Biology.IsAlive || p.Biology.Hibernation.IsDormant() {
+		return fmt.Errorf("%w: %s", ErrCannotTravel, p.Name)
+	}
+
+	vitals := p.Biology.Vitals
+	vitals.Energy -= TravelEnergyCost * trip.Days
+	vitals.Fatigue += 0.5 * TravelEnergyCost * trip.Days
+	danger := trip.Danger * p.Skills.DangerFactor()
+	vitals.Stress += 0.2 * danger * (0.5 + p.Personality.Traits.Neuroticism)
+	vitals.Clamp()
+
+	p.Location = trip.To
+	p.recordTrip(trip)
+	p.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: "Travelled to " + trip.To,
+		GameTime:    p.GetAge(),
+		Strength:    0.3 + 0.4*trip.Danger,
+		Valence:     0.3*p.Personality.Traits.Curiosity - 0.5*danger,
+		Emotion:     p.Emotions.DominantEmotion,
+		Tags:        []string{"travel", trip.To},
+	})
+	p.updateBehavior()
+	return nil
+}
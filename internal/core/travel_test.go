@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestTravel(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	energyBefore := pet.Biology.Vitals.Energy
+
+	trip := environment.Trip{From: environment.HomeLocation, To: "peak", Distance: 4.0, Days: 0.4, Danger: 0.5}
+	if err := pet.Travel(trip); err != nil {
+		t.Fatalf("Travel failed: %v", err)
+	}
+
+	if pet.Location != "peak" {
+		t.Errorf("Expected pet at the peak, got %s", pet.Location)
+	}
+	if pet.Biology.Vitals.Energy >= energyBefore {
+		t.Error("Travelling should be tiring")
+	}
+	if len(pet.Memory.ByTag("peak")) != 1 {
+		t.Error("The trip should be remembered")
+	}
+}
+
+func TestHibernatingPetsCannotTravel(t *testing.T) {
+	pet := NewDigitalPet("Dozy", "user123")
+	pet.Biology.Hibernation.Enter()
+
+	if err := pet.Travel(environment.Trip{To: "peak"}); !errors.Is(err, ErrCannotTravel) {
+		t.Errorf("Expected ErrCannotTravel, got %v", err)
+	}
+}
+
+func TestOffLeashTravelIsLessStressful(t *testing.T) {
+	pet := NewDigitalPet("Scout", "user123")
+	trip := environment.Trip{From: environment.HomeLocation, To: "peak", Days: 0.1, Danger: 0.8}
+	stressFrom := func() float64 {
+		pet.Biology.Vitals.Stress = 0.1
+		pet.Biology.Vitals.Energy = 1.0
+		if err := pet.Travel(trip); err != nil {
+			t.Fatal(err)
+		}
+		return pet.Biology.Vitals.Stress - 0.1
+	}
+
+	leashed := stressFrom()
+	pet.Skills.Levels[ai.SkillObedience] = ai.SynergyLevel
+	pet.Skills.Levels[ai.SkillForaging] = ai.SynergyLevel
+	offLeash := stressFrom()
+	if leashed <= 0 || math.Abs(offLeash-leashed*ai.OffLeashDangerFraction) > 1e-9 {
+		t.Errorf("Expected off-leash stress %.3f to be half of %.3f", offLeash, leashed)
+	}
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// RainLoverThreshold is the personality score above which a pet keeps
+// playing outdoors in the rain
+const RainLoverThreshold = 0.75
+
+// LovesRain returns true for pets playful and open enough to enjoy bad weather
+func (p *DigitalPet) LovesRain() bool {
+	traits := p.Personality.Traits
+	return (traits.Playfulness+traits.Openness+traits.EnergyLevel)/3.0 > RainLoverThreshold
+}
+
+// Dreads returns true if the pet's memories of a kind of weather are bad
+// enough that it would rather not be out in it
+func (p *DigitalPet) Dreads(weather environment.WeatherType) bool {
+	return p.Memory.TagValence(weather.Tag()) < AversionThreshold
+}
+
+// Activities is the gate pets consult when choosing what to do on their own
+var Activities = environment.NewActivityGate()
+
+// outdoorActivityFor returns the outdoor activity a behavior involves, if any
+func outdoorActivityFor(behavior types.BehaviorState) (environment.Activity, bool) {
+	switch behavior {
+	case types.BehaviorPlaying, types.BehaviorExcited:
+		return environment.ActivityOutdoorPlay, true
+	case types.BehaviorExploring:
+		return environment.ActivityExploring, true
+	case types.BehaviorExercising:
+		return environment.ActivityWalk, true
+	default:
+		return 0, false
+	}
+}
+
+// isOutdoorActivity returns true for behaviors that take the pet outside
+func isOutdoorActivity(behavior types.BehaviorState) bool {
+	_, outdoors := outdoorActivityFor(behavior)
+	return outdoors
+}
+
+// ReactToWeather adjusts the pet's coat, hibernation, acclimatization,
+// body temperature, behavior and emotions for the current weather. It should be called every tick after Update.
+func (p *DigitalPet) ReactToWeather(effects environment.WeatherEffects, deltaTime float64) {
+	if !p.Biology.IsAlive {
+		return
+	}
+
+	vitals := p.Biology.Vitals
+	traits := p.Personality.Traits
+	p.Biology.Coat.Update(effects.Season, deltaTime)
+	p.Acclimatization.Update(effects.Biome, deltaTime)
+
+	// Hibernating pets sleep through the weather
+	p.updateHibernation(effects)
+	if p.Biology.Hibernation.IsDormant() {
+		return
+	}
+
+	// An unsuitable climate wears on the pet
+	if discomfort, _ := p.ClimateDiscomfort(effects); discomfort > 0 {
+		vitals.Stress += 0.1 * discomfort * deltaTime
+		vitals.Clamp()
+	}
+	effects = p.adaptWeather(effects)
+	p.regulateTemperature(effects, deltaTime)
+
+	// Critical states take priority over the weather
+	switch p.CurrentBehavior {
+	case types.BehaviorSick, types.BehaviorDistressed, types.BehaviorSleeping:
+		return
+	}
+
+	if effects.ShelterUrge > 0 && effects.Onset {
+		fright := effects.ShelterUrge * (0.5 + 0.5*traits.Neuroticism)
+		p.Emotions.ApplyEmotionalStimulus(ai.EmotionalStimulus{
+			FearDelta: 0.3 * fright,
+			Source:    effects.Weather.String(),
+		})
+		p.Memory.Remember(&ai.Memory{
+			Type:        ai.MemoryEvent,
+			Description: effects.Weather.String() + " started",
+			GameTime:    p.GetAge(),
+			Strength:    0.4 + 0.5*fright,
+			Valence:     -fright,
+			Emotion:     p.Emotions.DominantEmotion,
+			Tags:        []string{effects.Weather.Tag()},
+		})
+	}
+
+	if effects.FirstSnow {
+		p.Emotions.ApplyEmotionalStimulus(ai.EmotionalStimulus{
+			ExcitementDelta: 0.4 * effects.Hyperactivity,
+			JoyDelta:        0.2 * effects.Hyperactivity,
+			Source:          "first snow",
+		})
+		p.Memory.Remember(&ai.Memory{
+			Type:        ai.MemoryEvent,
+			Description: "First snow of the winter",
+			GameTime:    p.GetAge(),
+			Strength:    0.8,
+			Valence:     0.8,
+			Emotion:     p.Emotions.DominantEmotion,
+			Tags:        []string{effects.Weather.Tag()},
+		})
+		p.CurrentBehavior = types.BehaviorExcited
+		return
+	}
+
+	if effects.Lethargy > 0 {
+		// A winter coat makes the heat harder to bear
+		lethargy := effects.Lethargy * p.Biology.Coat.HeatSensitivity()
+		vitals.Energy -= 0.1 * lethargy * deltaTime
+		vitals.Hydration -= 0.1 * lethargy * deltaTime
+		vitals.Clamp()
+		if isOutdoorActivity(p.CurrentBehavior) && lethargy > traits.EnergyLevel {
+			p.CurrentBehavior = types.BehaviorIdle
+		}
+	}
+
+	// Pets that remember this weather badly hide from even a little of it
+	if effects.ShelterUrge >= 0.5 || p.SeeksShelter() || effects.ShelterUrge > 0 && p.Dreads(effects.Weather) {
+		p.CurrentBehavior = types.BehaviorSheltering
+		return
+	}
+
+	// Give up on outdoor activities the conditions rule out or spoil
+	if activity, outdoors := outdoorActivityFor(p.CurrentBehavior); outdoors {
+		status := Activities.Check(activity, effects)
+		undeterred := effects.Weather.IsWet() && p.LovesRain()
+		if !status.Available || (status.Penalty >= 0.5 && !undeterred) {
+			p.CurrentBehavior = types.BehaviorIdle
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestStormSendsPetToShelter(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.CurrentBehavior = types.BehaviorPlaying
+	fearBefore := pet.Emotions.Fear
+
+	pet.ReactToWeather(environment.WeatherEffects{
+		Weather:     environment.WeatherStorm,
+		Onset:       true,
+		ShelterUrge: 1.0,
+		OutdoorPlay: 1.0,
+	}, 0.01)
+
+	if pet.CurrentBehavior != types.BehaviorSheltering {
+		t.Errorf("Expected sheltering, got %s", pet.CurrentBehavior)
+	}
+	if pet.Emotions.Fear <= fearBefore {
+		t.Error("Storm onset should frighten the pet")
+	}
+	if len(pet.Memory.ByTag("storm")) != 1 {
+		t.Error("Storm onset should be remembered")
+	}
+}
+
+func TestBadWeatherMemoriesSendPetToShelter(t *testing.T) {
+	rain := environment.WeatherEffects{Weather: environment.WeatherRain, OutdoorPlay: 0.4, ShelterUrge: 0.2}
+
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Personality.Traits.Playfulness = 1.0
+	pet.Personality.Traits.Openness = 1.0
+	pet.Personality.Traits.EnergyLevel = 1.0
+	pet.CurrentBehavior = types.BehaviorPlaying
+	pet.ReactToWeather(rain, 0.01)
+	if pet.CurrentBehavior != types.BehaviorPlaying {
+		t.Fatalf("Expected a rain lover to keep playing in light rain, got %s", pet.CurrentBehavior)
+	}
+
+	pet.Memory.Remember(&ai.Memory{Type: ai.MemoryEvent, Description: "Soaked and frozen", Strength: 0.9, Valence: -0.9, Tags: []string{"rain"}})
+	if !pet.Dreads(environment.WeatherRain) {
+		t.Fatal("Expected a bad rain memory to make the pet dread rain")
+	}
+	pet.ReactToWeather(rain, 0.01)
+	if pet.CurrentBehavior != types.BehaviorSheltering {
+		t.Errorf("Expected a pet that remembers rain badly to shelter, got %s", pet.CurrentBehavior)
+	}
+}
+
+func TestFirstSnowZoomies(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.CurrentBehavior = types.BehaviorIdle
+
+	pet.ReactToWeather(environment.WeatherEffects{
+		Weather:       environment.WeatherSnow,
+		Onset:         true,
+		FirstSnow:     true,
+		Hyperactivity: 1.0,
+	}, 0.01)
+
+	if pet.CurrentBehavior != types.BehaviorExcited {
+		t.Errorf("Expected zoomies, got %s", pet.CurrentBehavior)
+	}
+}
+
+func TestHeavyRainStopsOutdoorPlay(t *testing.T) {
+	rain := environment.WeatherEffects{Weather: environment.WeatherHeavyRain, OutdoorPlay: 0.8, ShelterUrge: 0.2}
+
+	homebody := NewDigitalPet("Homebody", "user123")
+	homebody.Personality.Traits.Playfulness = 0.2
+	homebody.CurrentBehavior = types.BehaviorPlaying
+	homebody.ReactToWeather(rain, 0.01)
+	if homebody.CurrentBehavior != types.BehaviorIdle {
+		t.Errorf("Homebody should stop playing in the rain, got %s", homebody.CurrentBehavior)
+	}
+
+	rainLover := NewDigitalPet("Splash", "user123")
+	rainLover.Personality.Traits.Playfulness = 1.0
+	rainLover.Personality.Traits.Openness = 1.0
+	rainLover.Personality.Traits.EnergyLevel = 1.0
+	rainLover.CurrentBehavior = types.BehaviorPlaying
+	rainLover.ReactToWeather(rain, 0.01)
+	if rainLover.CurrentBehavior != types.BehaviorPlaying {
+		t.Errorf("Rain lover should keep playing, got %s", rainLover.CurrentBehavior)
+	}
+}
+
+func TestHeatwaveLethargy(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.Personality.Traits.EnergyLevel = 0.3
+	pet.CurrentBehavior = types.BehaviorExploring
+	energyBefore := pet.Biology.Vitals.Energy
+
+	pet.ReactToWeather(environment.WeatherEffects{Weather: environment.WeatherHeatwave, Temperature: 30, Lethargy: 0.7, OutdoorPlay: 0.5}, 1.0)
+
+	if pet.Biology.Vitals.Energy >= energyBefore {
+		t.Error("Heatwaves should sap energy")
+	}
+	if pet.CurrentBehavior != types.BehaviorIdle {
+		t.Errorf("Pet should become lethargic, got %s", pet.CurrentBehavior)
+	}
+}
+
+func TestExploringStopsAtNightInFog(t *testing.T) {
+	pet := NewDigitalPet("Rex", "user123")
+	pet.CurrentBehavior = types.BehaviorExploring
+
+	pet.ReactToWeather(environment.WeatherEffects{Weather: environment.WeatherFog, OutdoorPlay: 0.2, Temperature: 12, Hour: 2}, 0.1)
+
+	if pet.CurrentBehavior != types.BehaviorIdle {
+		t.Errorf("Pet should not explore in the foggy dark, got %s", pet.CurrentBehavior)
+	}
+}
@@ -0,0 +1,181 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// archiveName is the directory archived pets are kept in
+const archiveName = "archive"
+
+var (
+	// ErrPetArchived is returned when writing a pet that has been archived
+	ErrPetArchived = errors.New("pet is archived")
+	// ErrPetActive is returned when unarchiving a pet that is already active
+	ErrPetActive = errors.New("pet is already active")
+)
+
+// PetFilter selects saves by archive state
+type PetFilter int
+
+const (
+	FilterActive PetFilter = iota
+	FilterArchived
+	FilterAll
+)
+
+// String returns the string representation of PetFilter
+func (f PetFilter) String() string {
+	return [...]string{"Active", "Archived", "All"}[f]
+}
+
+// ArchivePet takes a pet out of the active game without destroying it.
+// Its current state is written to the archive and its active save removed;
+// from then on writes to the pet are refused with ErrPetArchived, so a save
+// queued before archiving cannot bring it back. Archived pets still count
+// as existing for pedigrees and integrity checks.
+func (dm *DataManager) ArchivePet(ctx context.Context, pet *core.DigitalPet) error {
+	if err := ValidatePet(pet); err != nil {
+		return err
+	}
+	payload, err := pet.Save()
+	if err != nil {
+		return err
+	}
+
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if dm.archived(pet.ID) {
+		return fmt.Errorf("%w: %s", ErrPetArchived, pet.ID)
+	}
+
+	// Archive first: a crash before the active save is removed leaves both,
+	// which Recover resolves in favour of the archive
+	if err := dm.storage().MkdirAll(dm.archivePath()); err != nil {
+		return err
+	}
+	raw, err := dm.encode(payload)
+	if err != nil {
+		return err
+	}
+	path := dm.archivedPetPath(pet.ID)
+	if err := dm.storage().WriteFile(path+tempExt, raw); err != nil {
+		return err
+	}
+	if err := dm.storage().Rename(path+tempExt, path); err != nil {
+		return err
+	}
+	if err := dm.storage().Remove(dm.petPath(pet.ID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// UnarchivePet returns an archived pet to the active game
+func (dm *DataManager) UnarchivePet(ctx context.Context, id types.PetID) (*core.DigitalPet, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	path := dm.archivedPetPath(id)
+	payload, err := dm.readSave(path)
+	if err != nil {
+		return nil, petError(id, err)
+	}
+	pet, err := DecodePet(id, payload)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dm.storage().Stat(dm.petPath(id)); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPetActive, id)
+	}
+	if err := dm.storage().Rename(path, dm.petPath(id)); err != nil {
+		return nil, err
+	}
+	return pet, nil
+}
+
+// LoadArchivedPet reads an archived pet without reactivating it
+func (dm *DataManager) LoadArchivedPet(ctx context.Context, id types.PetID) (*core.DigitalPet, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	payload, err := dm.readSave(dm.archivedPetPath(id))
+	if err != nil {
+		return nil, petError(id, err)
+	}
+	return DecodePet(id, payload)
+}
+
+// LoadArchived loads every archived pet. Pets that fail to load are
+// reported in the returned error alongside the pets that did load.
+func (dm *DataManager) LoadArchived(ctx context.Context) ([]*core.DigitalPet, error) {
+	ids, err := dm.ListPets(ctx, FilterArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	pets := make([]*core.DigitalPet, 0, len(ids))
+	var problems []error
+	for _, id := range ids {
+		pet, err := dm.LoadArchivedPet(ctx, id)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", id, err))
+			continue
+		}
+		pets = append(pets, pet)
+	}
+	return pets, errors.Join(problems...)
+}
+
+// IsArchived returns true if a pet has been archived
+func (dm *DataManager) IsArchived(id types.PetID) bool {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	return dm.archived(id)
+}
+
+// archived implements IsArchived (must be called with lock held)
+func (dm *DataManager) archived(id types.PetID) bool {
+	_, err := dm.storage().Stat(dm.archivedPetPath(id))
+	return err == nil
+}
+
+// removeArchivedDuplicates removes active saves left behind by an archive
+// interrupted by a crash (must be called with lock held)
+func (dm *DataManager) removeArchivedDuplicates() error {
+	archived, err := dm.listSaves(dm.archivePath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, id := range archived {
+		if err := dm.storage().Remove(dm.petPath(id)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// archivePath returns the directory archived pets are kept in
+func (dm *DataManager) archivePath() string {
+	return filepath.Join(dm.SavePath, archiveName)
+}
+
+// archivedPetPath returns the archived save of a pet
+func (dm *DataManager) archivedPetPath(id types.PetID) string {
+	return filepath.Join(dm.archivePath(), filepath.Base(dm.petPath(id)))
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestArchiveAndUnarchivePet(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := core.NewDigitalPet("Kept", "owner")
+	retired := core.NewDigitalPet("Retired", "owner")
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{kept, retired}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dm.ArchivePet(ctx, retired); err != nil {
+		t.Fatalf("ArchivePet failed: %v", err)
+	}
+	if ids, _ := dm.ListPets(ctx, FilterActive); len(ids) != 1 || ids[0] != kept.ID {
+		t.Errorf("Expected only %s to be active, got %v", kept.ID, ids)
+	}
+	if ids, _ := dm.ListPets(ctx, FilterArchived); len(ids) != 1 || ids[0] != retired.ID {
+		t.Errorf("Expected only %s to be archived, got %v", retired.ID, ids)
+	}
+	if ids, _ := dm.ListPets(ctx, FilterAll); len(ids) != 2 {
+		t.Errorf("Expected both pets in the full listing, got %v", ids)
+	}
+	if pets, _ := dm.LoadAll(ctx); len(pets) != 1 {
+		t.Errorf("Archived pets should not be loaded into the game, got %d pets", len(pets))
+	}
+
+	// A save queued before archiving must not bring the pet back
+	if err := dm.SavePet(ctx, retired); !errors.Is(err, ErrPetArchived) {
+		t.Errorf("Expected ErrPetArchived writing an archived pet, got %v", err)
+	}
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{kept, retired}); !errors.Is(err, ErrPetArchived) {
+		t.Errorf("Expected ErrPetArchived in a transaction, got %v", err)
+	}
+
+	pet, err := dm.UnarchivePet(ctx, retired.ID)
+	if err != nil {
+		t.Fatalf("UnarchivePet failed: %v", err)
+	}
+	if pet.Name != retired.Name || dm.IsArchived(retired.ID) {
+		t.Errorf("Expected %s to be active again", retired.Name)
+	}
+	if _, err := dm.UnarchivePet(ctx, retired.ID); !errors.Is(err, ErrPetNotFound) {
+		t.Errorf("Expected ErrPetNotFound unarchiving an active pet, got %v", err)
+	}
+}
+
+func TestArchivedPetsCountForIntegrity(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := core.NewDigitalPet("A", "owner")
+	b := core.NewDigitalPet("B", "owner")
+	a.Relationships.AddRelationship(b.ID, types.RelationshipFriend)
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{a, b}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.ArchivePet(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := dm.CheckIntegrity(ctx)
+	if err != nil || !report.Clean() {
+		t.Errorf("A relationship with an archived pet should not dangle: %+v (%v)", report, err)
+	}
+}
+
+func TestRecoverFinishesInterruptedArchive(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet := core.NewDigitalPet("Mochi", "owner")
+	if err := dm.SavePet(ctx, pet); err != nil {
+		t.Fatal(err)
+	}
+	payload, _ := dm.ReadPet(ctx, pet.ID)
+
+	// Crash after the archive copy was written but before the active save
+	// was removed
+	if err := os.MkdirAll(dm.archivePath(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dm.archivedPetPath(pet.ID), payload, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dm.Recover(); err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if ids, _ := dm.ListPets(ctx, FilterActive); len(ids) != 0 {
+		t.Errorf("Expected the interrupted archive to be completed, got active %v", ids)
+	}
+	if !dm.IsArchived(pet.ID) {
+		t.Error("Expected the pet to stay archived")
+	}
+}
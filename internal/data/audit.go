@@ -0,0 +1,191 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Audit log settings
+const (
+	AuditFileName     = "audit.log" // Current audit log under the save path
+	MaxAuditFileSize  = 1 << 20     // Bytes written before the log is rotated
+	AuditRotations    = 5           // Rotated logs kept beside the current one
+	MaxAuditSummary   = 200         // Longest summary kept, in bytes
+	DefaultAuditLimit = 50          // Entries returned by a query without a limit
+)
+
+// Audited actions
+const (
+	AuditInteract = "interact" // Care given to a pet
+	AuditSave     = "save"     // Pets written to disk
+	AuditSync     = "sync"     // Saves exchanged with another device
+	AuditRestore  = "restore"  // Pets replaced from a backup or checkpoint
+	AuditRule     = "rule"     // A reaction rule acted on a pet
+	AuditAdopt    = "adopt"    // A pet brought home from the adoption center
+	AuditBreed    = "breed"    // Pets bred, or pups kept
+	AuditRehome   = "rehome"   // A pet adopted out of the household
+	AuditTrade    = "trade"    // Items offered or claimed on the marketplace
+	AuditInsure   = "insure"   // Insurance taken out or cancelled, or a vet bill paid
+	AuditDonate   = "donate"   // Coins or items given to the sanctuary
+	AuditImport   = "import"   // A pet brought in from a pet archive
+)
+
+// Actors the data layer records in the audit log
+const (
+	ActorSync    = "sync"    // Saves received from the cloud or another device
+	ActorOffsite = "offsite" // Restores of cloud backups
+	ActorImport  = "import"  // Pets brought in from a pet archive
+)
+
+// AuditEntry is one state-changing operation
+type AuditEntry struct {
+	Time    time.Time   `json:"time"`
+	Actor   string      `json:"actor"` // Who made the change, e.g. player, mqtt or discord:<user>
+	Action  string      `json:"action"`
+	PetID   types.PetID `json:"pet,omitempty"`
+	Summary string      `json:"summary"`
+}
+
+// AuditQuery selects audit entries; zero fields match everything
+type AuditQuery struct {
+	Actor  string // Case-insensitive; a trailing ":" matches every actor with that prefix
+	Action string
+	PetID  types.PetID
+	Since  time.Time
+	Limit  int // Newest entries returned; 0 uses DefaultAuditLimit
+}
+
+// matches returns true if an entry is selected by the query
+func (q AuditQuery) matches(e AuditEntry) bool {
+	switch {
+	case q.Action != "" && !strings.EqualFold(q.Action, e.Action),
+		q.PetID != "" && q.PetID != e.PetID,
+		!q.Since.IsZero() && e.Time.Before(q.Since):
+		return false
+	case strings.HasSuffix(q.Actor, ":"):
+		return strings.HasPrefix(strings.ToLower(e.Actor), strings.ToLower(q.Actor))
+	}
+	return q.Actor == "" || strings.EqualFold(q.Actor, e.Actor)
+}
+
+// AuditLog is an append-only record of who changed what, kept as JSON
+// lines. The log is rotated once it reaches MaxFileSize and the oldest
+// rotations are dropped. A nil log records nothing.
+type AuditLog struct {
+	mu          sync.Mutex
+	Path        string
+	MaxFileSize int64
+	Rotations   int
+	Backend     StorageBackend // Where the log is kept; nil uses the disk
+}
+
+// NewAuditLog creates an audit log at the given path
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{Path: path, MaxFileSize: MaxAuditFileSize, Rotations: AuditRotations}
+}
+
+// Record appends an entry, stamping it with the current time if it has none
+func (al *AuditLog) Record(entry AuditEntry) error {
+	if al == nil {
+		return nil
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if len(entry.Summary) > MaxAuditSummary {
+		entry.Summary = strings.ToValidUTF8(entry.Summary[:MaxAuditSummary], "")
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	if info, err := backendOr(al.Backend).Stat(al.Path); err == nil && info.Size()+int64(len(line)) >= al.MaxFileSize {
+		if err := al.rotate(); err != nil {
+			return err
+		}
+	}
+	return backendOr(al.Backend).AppendFile(al.Path, append(line, '\n'))
+}
+
+// Query returns the newest entries selected by q, newest first, searching
+// the rotated logs as well
+func (al *AuditLog) Query(q AuditQuery) ([]AuditEntry, error) {
+	if al == nil {
+		return nil, nil
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultAuditLimit
+	}
+
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	var found []AuditEntry
+	for i := 0; i <= al.Rotations && len(found) < limit; i++ {
+		entries, err := readAudit(backendOr(al.Backend), al.rotation(i))
+		if err != nil {
+			return nil, err
+		}
+		for j := len(entries) - 1; j >= 0 && len(found) < limit; j-- {
+			if q.matches(entries[j]) {
+				found = append(found, entries[j])
+			}
+		}
+	}
+	return found, nil
+}
+
+// rotate shifts every log up one rotation, dropping the oldest (must be
+// called with lock held)
+func (al *AuditLog) rotate() error {
+	if err := backendOr(al.Backend).Remove(al.rotation(al.Rotations)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := al.Rotations - 1; i >= 0; i-- {
+		if err := backendOr(al.Backend).Rename(al.rotation(i), al.rotation(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotation returns the path of a log; 0 is the current one
+func (al *AuditLog) rotation(i int) string {
+	if i == 0 {
+		return al.Path
+	}
+	return fmt.Sprintf("%s.%d", al.Path, i)
+}
+
+// readAudit reads the entries of one log, oldest first. A missing log has
+// none and lines torn by a crash are skipped.
+func readAudit(storage StorageBackend, path string) ([]AuditEntry, error) {
+	content, err := storage.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		var entry AuditEntry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditLogQuery(t *testing.T) {
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	entries := []AuditEntry{
+		{Time: start, Actor: "player", Action: AuditInteract, PetID: "rex", Summary: "fed Rex"},
+		{Time: start.Add(time.Minute), Actor: "mqtt", Action: AuditInteract, PetID: "rex", Summary: "fed Rex"},
+		{Time: start.Add(2 * time.Minute), Actor: "discord:ann", Action: AuditInteract, PetID: "mochi", Summary: "played with Mochi"},
+		{Time: start.Add(3 * time.Minute), Actor: "autosave", Action: AuditSave, Summary: "queued 2 pets"},
+	}
+	for _, entry := range entries {
+		if err := dm.Audit.Record(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, err := dm.Audit.Query(AuditQuery{})
+	if err != nil || len(all) != 4 || all[0].Action != AuditSave {
+		t.Fatalf("Expected every entry newest first, got %+v (%v)", all, err)
+	}
+	tests := []struct {
+		query AuditQuery
+		want  int
+	}{
+		{AuditQuery{PetID: "rex"}, 2},
+		{AuditQuery{Actor: "MQTT"}, 1},
+		{AuditQuery{Actor: "discord:"}, 1},
+		{AuditQuery{Action: AuditInteract, Since: start.Add(time.Minute)}, 2},
+		{AuditQuery{Limit: 1}, 1},
+	}
+	for _, tt := range tests {
+		if got, _ := dm.Audit.Query(tt.query); len(got) != tt.want {
+			t.Errorf("%+v: expected %d entries, got %+v", tt.query, tt.want, got)
+		}
+	}
+
+	var none *AuditLog
+	if err := none.Record(entries[0]); err != nil {
+		t.Errorf("A nil audit log should ignore entries, got %v", err)
+	}
+}
+
+func TestAuditLogRotation(t *testing.T) {
+	dir := t.TempDir()
+	al := NewAuditLog(filepath.Join(dir, AuditFileName))
+	al.MaxFileSize = 300
+	al.Rotations = 2
+	for i := 0; i < 20; i++ {
+		if err := al.Record(AuditEntry{Actor: "player", Action: AuditInteract, Summary: strings.Repeat("x", 100)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{AuditFileName, AuditFileName + ".1", AuditFileName + ".2"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil || info.Size() > al.MaxFileSize {
+			t.Errorf("Expected %s within %d bytes, got %v", name, al.MaxFileSize, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, AuditFileName+".3")); !os.IsNotExist(err) {
+		t.Error("Rotations beyond the limit should be dropped")
+	}
+	if got, _ := al.Query(AuditQuery{Limit: 100}); len(got) == 0 || len(got) >= 20 {
+		t.Errorf("Expected the kept entries across rotations, got %d", len(got))
+	}
+
+	al.Record(AuditEntry{Actor: "player", Summary: strings.Repeat("é", MaxAuditSummary)})
+	if got, _ := al.Query(AuditQuery{Limit: 1}); len(got[0].Summary) > MaxAuditSummary {
+		t.Errorf("Expected the summary cut to %d bytes, got %d", MaxAuditSummary, len(got[0].Summary))
+	}
+}
@@ -0,0 +1,538 @@
+package data
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Storage backends
+const (
+	BackendDisk   = "disk"   // Saves on the file system
+	BackendMemory = "memory" // Saves in memory, lost when the process exits
+)
+
+// StorageBackend is the file system saves, backups, journals and audit
+// logs are kept on. Paths are file system paths even when nothing is on
+// disk. Errors follow the os package, so os.IsNotExist and os.IsExist
+// work on them, and a missing parent directory is an error rather than
+// created on the fly.
+type StorageBackend interface {
+	ReadFile(name string) ([]byte, error)
+	// WriteFile replaces a file's content and returns once it is durable
+	WriteFile(name string, payload []byte) error
+	// AppendFile adds to the end of a file, creating it if needed, and
+	// returns once the addition is durable
+	AppendFile(name string, payload []byte) error
+	Truncate(name string, size int64) error
+	Stat(name string) (fs.FileInfo, error)
+	// ReadDir returns a directory's entries sorted by name
+	ReadDir(name string) ([]fs.DirEntry, error)
+	Mkdir(name string) error
+	MkdirAll(name string) error
+	Rename(oldName, newName string) error
+	Remove(name string) error
+	RemoveAll(name string) error
+	// Link makes newName another name for the file at oldName
+	Link(oldName, newName string) error
+	// SameFile returns true if two results of Stat are the same file
+	SameFile(a, b fs.FileInfo) bool
+}
+
+// OpenBackend returns the backend with a configuration name
+func OpenBackend(name string) (StorageBackend, error) {
+	switch name {
+	case BackendDisk, "":
+		return DiskBackend{}, nil
+	case BackendMemory:
+		return NewMemoryBackend(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", name)
+	}
+}
+
+// DiskBackend keeps files on the operating system's file system
+type DiskBackend struct{}
+
+// ReadFile reads a whole file
+func (DiskBackend) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+
+// WriteFile writes a file and flushes it to disk before returning
+func (DiskBackend) WriteFile(name string, payload []byte) error {
+	return syncWrite(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, payload)
+}
+
+// AppendFile appends to a file and flushes it to disk before returning
+func (DiskBackend) AppendFile(name string, payload []byte) error {
+	return syncWrite(name, os.O_WRONLY|os.O_CREATE|os.O_APPEND, payload)
+}
+
+// Truncate changes a file's size
+func (DiskBackend) Truncate(name string, size int64) error { return os.Truncate(name, size) }
+
+// Stat describes a file
+func (DiskBackend) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+// ReadDir lists a directory
+func (DiskBackend) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+
+// Mkdir creates a directory
+func (DiskBackend) Mkdir(name string) error { return os.Mkdir(name, 0o755) }
+
+// MkdirAll creates a directory and any missing parents
+func (DiskBackend) MkdirAll(name string) error { return os.MkdirAll(name, 0o755) }
+
+// Rename moves a file or directory
+func (DiskBackend) Rename(oldName, newName string) error { return os.Rename(oldName, newName) }
+
+// Remove removes a file or empty directory
+func (DiskBackend) Remove(name string) error { return os.Remove(name) }
+
+// RemoveAll removes a path and everything under it
+func (DiskBackend) RemoveAll(name string) error { return os.RemoveAll(name) }
+
+// Link creates a hard link
+func (DiskBackend) Link(oldName, newName string) error { return os.Link(oldName, newName) }
+
+// SameFile reports whether two files are the same inode
+func (DiskBackend) SameFile(a, b fs.FileInfo) bool { return os.SameFile(a, b) }
+
+// syncWrite writes to a file opened with flag and flushes it to disk
+func syncWrite(name string, flag int, payload []byte) error {
+	file, err := os.OpenFile(name, flag, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(payload); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// memoryNode is a file or directory held by a MemoryBackend. Hard links
+// share a node.
+type memoryNode struct {
+	dir      bool
+	content  []byte
+	modified time.Time
+}
+
+// info describes the node under a name (must be called with the
+// backend's lock held)
+func (n *memoryNode) info(name string) memoryInfo {
+	return memoryInfo{name: name, node: n, dir: n.dir, size: int64(len(n.content)), modified: n.modified}
+}
+
+// MemoryBackend is a StorageBackend held in memory, for tests, CI and
+// demos that should leave nothing on disk. Relative paths and the root
+// always exist as directories.
+type MemoryBackend struct {
+	mu    sync.Mutex
+	nodes map[string]*memoryNode
+}
+
+// NewMemoryBackend creates an empty in-memory file system
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{nodes: make(map[string]*memoryNode)}
+}
+
+// ReadFile returns a copy of a file's content
+func (m *MemoryBackend) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.file("open", name)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), node.content...), nil
+}
+
+// WriteFile replaces a file's content, creating the file if needed
+func (m *MemoryBackend) WriteFile(name string, payload []byte) error {
+	return m.write("open", name, payload, false)
+}
+
+// AppendFile adds to a file's content, creating the file if needed
+func (m *MemoryBackend) AppendFile(name string, payload []byte) error {
+	return m.write("open", name, payload, true)
+}
+
+// write implements WriteFile and AppendFile. Content is changed in place,
+// so every link to the file sees it.
+func (m *MemoryBackend) write(op, name string, payload []byte, appending bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = filepath.Clean(name)
+	node, ok := m.nodes[name]
+	switch {
+	case ok && node.dir:
+		return pathError(op, name, syscall.EISDIR)
+	case !ok:
+		if err := m.parent(op, name); err != nil {
+			return err
+		}
+		node = &memoryNode{}
+		m.nodes[name] = node
+	}
+	if !appending {
+		node.content = nil
+	}
+	node.content = append(node.content, payload...)
+	node.modified = time.Now()
+	return nil
+}
+
+// Truncate changes a file's size, padding it with zeros if it grows
+func (m *MemoryBackend) Truncate(name string, size int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, err := m.file("truncate", name)
+	if err != nil {
+		return err
+	}
+	if size <= int64(len(node.content)) {
+		node.content = node.content[:size]
+	} else {
+		node.content = append(node.content, make([]byte, size-int64(len(node.content)))...)
+	}
+	node.modified = time.Now()
+	return nil
+}
+
+// Stat describes a file or directory
+func (m *MemoryBackend) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = filepath.Clean(name)
+	node, ok := m.lookup(name)
+	if !ok {
+		return nil, pathError("stat", name, fs.ErrNotExist)
+	}
+	return node.info(filepath.Base(name)), nil
+}
+
+// ReadDir lists a directory's entries sorted by name
+func (m *MemoryBackend) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = filepath.Clean(name)
+	node, ok := m.lookup(name)
+	if !ok {
+		return nil, pathError("open", name, fs.ErrNotExist)
+	}
+	if !node.dir {
+		return nil, pathError("readdirent", name, syscall.ENOTDIR)
+	}
+
+	var entries []fs.DirEntry
+	for path, child := range m.nodes {
+		if filepath.Dir(path) == name && path != name {
+			entries = append(entries, fs.FileInfoToDirEntry(child.info(filepath.Base(path))))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// Mkdir creates a directory whose parent exists
+func (m *MemoryBackend) Mkdir(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = filepath.Clean(name)
+	if _, ok := m.lookup(name); ok {
+		return pathError("mkdir", name, fs.ErrExist)
+	}
+	if err := m.parent("mkdir", name); err != nil {
+		return err
+	}
+	m.nodes[name] = &memoryNode{dir: true, modified: time.Now()}
+	return nil
+}
+
+// MkdirAll creates a directory and any missing parents
+func (m *MemoryBackend) MkdirAll(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.mkdirAll(filepath.Clean(name))
+}
+
+// mkdirAll implements MkdirAll (must be called with lock held)
+func (m *MemoryBackend) mkdirAll(name string) error {
+	if node, ok := m.lookup(name); ok {
+		if !node.dir {
+			return pathError("mkdir", name, syscall.ENOTDIR)
+		}
+		return nil
+	}
+	if err := m.mkdirAll(filepath.Dir(name)); err != nil {
+		return err
+	}
+	m.nodes[name] = &memoryNode{dir: true, modified: time.Now()}
+	return nil
+}
+
+// Rename moves a file, or a directory with everything under it. A file
+// replaces a file and a directory replaces an empty directory.
+func (m *MemoryBackend) Rename(oldName, newName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	oldName, newName = filepath.Clean(oldName), filepath.Clean(newName)
+	node, ok := m.nodes[oldName]
+	if !ok {
+		return &os.LinkError{Op: "rename", Old: oldName, New: newName, Err: fs.ErrNotExist}
+	}
+	if oldName == newName {
+		return nil
+	}
+	if err := m.parent("rename", newName); err != nil {
+		return &os.LinkError{Op: "rename", Old: oldName, New: newName, Err: fs.ErrNotExist}
+	}
+	if target, ok := m.nodes[newName]; ok {
+		switch {
+		case target.dir != node.dir:
+			return &os.LinkError{Op: "rename", Old: oldName, New: newName, Err: fs.ErrExist}
+		case target.dir && m.hasChildren(newName):
+			return &os.LinkError{Op: "rename", Old: oldName, New: newName, Err: syscall.ENOTEMPTY}
+		}
+	}
+
+	prefix := oldName + string(filepath.Separator)
+	for path, child := range m.nodes {
+		if strings.HasPrefix(path, prefix) {
+			delete(m.nodes, path)
+			m.nodes[newName+string(filepath.Separator)+strings.TrimPrefix(path, prefix)] = child
+		}
+	}
+	delete(m.nodes, oldName)
+	m.nodes[newName] = node
+	return nil
+}
+
+// Remove removes a file or an empty directory
+func (m *MemoryBackend) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = filepath.Clean(name)
+	node, ok := m.nodes[name]
+	if !ok {
+		return pathError("remove", name, fs.ErrNotExist)
+	}
+	if node.dir && m.hasChildren(name) {
+		return pathError("remove", name, syscall.ENOTEMPTY)
+	}
+	delete(m.nodes, name)
+	return nil
+}
+
+// RemoveAll removes a path and everything under it. A missing path is
+// not an error.
+func (m *MemoryBackend) RemoveAll(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	name = filepath.Clean(name)
+	prefix := name + string(filepath.Separator)
+	for path := range m.nodes {
+		if path == name || strings.HasPrefix(path, prefix) {
+			delete(m.nodes, path)
+		}
+	}
+	return nil
+}
+
+// Link makes newName share oldName's content
+func (m *MemoryBackend) Link(oldName, newName string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	oldName, newName = filepath.Clean(oldName), filepath.Clean(newName)
+	node, err := m.file("link", oldName)
+	if err != nil {
+		return &os.LinkError{Op: "link", Old: oldName, New: newName, Err: fs.ErrNotExist}
+	}
+	if _, ok := m.lookup(newName); ok {
+		return &os.LinkError{Op: "link", Old: oldName, New: newName, Err: fs.ErrExist}
+	}
+	if err := m.parent("link", newName); err != nil {
+		return &os.LinkError{Op: "link", Old: oldName, New: newName, Err: fs.ErrNotExist}
+	}
+	m.nodes[newName] = node
+	return nil
+}
+
+// SameFile reports whether two results of Stat share their content
+func (m *MemoryBackend) SameFile(a, b fs.FileInfo) bool {
+	ia, ok := a.(memoryInfo)
+	if !ok {
+		return false
+	}
+	ib, ok := b.(memoryInfo)
+	return ok && ia.node == ib.node
+}
+
+// Size returns the bytes held in files, counting linked files once
+func (m *MemoryBackend) Size() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	seen := make(map[*memoryNode]bool)
+	var size int64
+	for _, node := range m.nodes {
+		if !seen[node] {
+			seen[node] = true
+			size += int64(len(node.content))
+		}
+	}
+	return size
+}
+
+// lookup returns the node at a clean path; relative paths and the root
+// are implicit directories (must be called with lock held)
+func (m *MemoryBackend) lookup(name string) (*memoryNode, bool) {
+	if node, ok := m.nodes[name]; ok {
+		return node, true
+	}
+	if name == "." || name == string(filepath.Separator) {
+		return &memoryNode{dir: true}, true
+	}
+	return nil, false
+}
+
+// file returns the file at a path (must be called with lock held)
+func (m *MemoryBackend) file(op, name string) (*memoryNode, error) {
+	name = filepath.Clean(name)
+	node, ok := m.lookup(name)
+	if !ok {
+		return nil, pathError(op, name, fs.ErrNotExist)
+	}
+	if node.dir {
+		return nil, pathError(op, name, syscall.EISDIR)
+	}
+	return node, nil
+}
+
+// parent checks that the directory holding a clean path exists (must be
+// called with lock held)
+func (m *MemoryBackend) parent(op, name string) error {
+	dir := filepath.Dir(name)
+	node, ok := m.lookup(dir)
+	if !ok {
+		return pathError(op, name, fs.ErrNotExist)
+	}
+	if !node.dir {
+		return pathError(op, name, syscall.ENOTDIR)
+	}
+	return nil
+}
+
+// hasChildren returns true if anything is under a directory (must be
+// called with lock held)
+func (m *MemoryBackend) hasChildren(name string) bool {
+	prefix := name + string(filepath.Separator)
+	for path := range m.nodes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathError returns an error shaped like the os package's
+func pathError(op, name string, err error) error {
+	return &fs.PathError{Op: op, Path: name, Err: err}
+}
+
+// memoryInfo describes a MemoryBackend file
+type memoryInfo struct {
+	name     string
+	node     *memoryNode // Compared by SameFile
+	dir      bool
+	size     int64
+	modified time.Time
+}
+
+func (i memoryInfo) Name() string       { return i.name }
+func (i memoryInfo) Size() int64        { return i.size }
+func (i memoryInfo) ModTime() time.Time { return i.modified }
+func (i memoryInfo) IsDir() bool        { return i.dir }
+func (i memoryInfo) Sys() interface{}   { return nil }
+
+// Mode returns the permissions a disk backend would have given the file
+func (i memoryInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+// globFiles returns the names in a directory matching a file name pattern,
+// like filepath.Glob with a pattern in the last element only
+func globFiles(b StorageBackend, pattern string) ([]string, error) {
+	dir, base := filepath.Split(pattern)
+	dir = filepath.Clean(dir)
+	entries, err := b.ReadDir(dir)
+	if err != nil {
+		return nil, nil // Like filepath.Glob, a missing directory matches nothing
+	}
+	var matches []string
+	for _, entry := range entries {
+		ok, err := filepath.Match(base, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matches = append(matches, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return matches, nil
+}
+
+// walkDir walks the tree under root like filepath.WalkDir, on a backend
+func walkDir(b StorageBackend, root string, fn fs.WalkDirFunc) error {
+	info, err := b.Stat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkEntry(b, root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if err == fs.SkipDir || err == fs.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walkEntry implements walkDir for one entry and everything under it
+func walkEntry(b StorageBackend, path string, entry fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(path, entry, nil); err != nil || !entry.IsDir() {
+		if err == fs.SkipDir && entry.IsDir() {
+			err = nil
+		}
+		return err
+	}
+	entries, err := b.ReadDir(path)
+	if err != nil {
+		if err = fn(path, entry, err); err != nil {
+			if err == fs.SkipDir && entry.IsDir() {
+				err = nil
+			}
+			return err
+		}
+	}
+	for _, child := range entries {
+		if err := walkEntry(b, filepath.Join(path, child.Name()), child, fn); err != nil {
+			if err == fs.SkipDir {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestMemoryBackendBehavesLikeDisk(t *testing.T) {
+	m := NewMemoryBackend()
+	if err := m.WriteFile("saves/a.json", []byte("a")); !os.IsNotExist(err) {
+		t.Errorf("Expected a missing parent to be an error, got %v", err)
+	}
+	if err := m.MkdirAll("saves/archive"); err != nil {
+		t.Fatal(err)
+	}
+	m.WriteFile("saves/b.json", []byte("b"))
+	m.WriteFile("saves/a.json", []byte("a"))
+	m.AppendFile("saves/a.json", []byte("ppend"))
+	if content, _ := m.ReadFile("saves/a.json"); string(content) != "append" {
+		t.Errorf("Expected the appended content, got %q", content)
+	}
+
+	entries, err := m.ReadDir("saves")
+	if err != nil || len(entries) != 3 || entries[0].Name() != "a.json" || !entries[1].IsDir() {
+		t.Fatalf("Expected the entries sorted by name, got %v (%v)", entries, err)
+	}
+	if err := m.Mkdir("saves"); !os.IsExist(err) {
+		t.Errorf("Expected Mkdir of an existing directory to fail, got %v", err)
+	}
+	if err := m.Remove("saves"); err == nil {
+		t.Error("Expected removing a directory that is not empty to fail")
+	}
+
+	// Renaming a directory moves everything under it
+	m.WriteFile("saves/archive/c.json", []byte("c"))
+	if err := m.Rename("saves/archive", "saves/old"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Stat("saves/archive/c.json"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the old path gone, got %v", err)
+	}
+	if content, _ := m.ReadFile("saves/old/c.json"); string(content) != "c" {
+		t.Errorf("Expected the file under the new path, got %q", content)
+	}
+
+	// Links share content until one name is removed
+	if err := m.Link("saves/b.json", "saves/old/b.json"); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := m.Stat("saves/b.json")
+	b, _ := m.Stat("saves/old/b.json")
+	if !m.SameFile(a, b) || m.Size() != int64(len("append")+len("b")+len("c")) {
+		t.Errorf("Expected the link to share its content, got size %d", m.Size())
+	}
+	m.RemoveAll("saves/old")
+	if content, _ := m.ReadFile("saves/b.json"); string(content) != "b" {
+		t.Errorf("Expected the original name kept, got %q", content)
+	}
+}
+
+func TestDataManagerOnMemoryBackend(t *testing.T) {
+	ctx := context.Background()
+	disk := t.TempDir()
+	root := filepath.Join(disk, "saves")
+	dm, err := NewDataManagerOn(NewMemoryBackend(), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm.Compress = true
+
+	// Saves, transactions, archives and the journal
+	mochi := core.NewDigitalPet("Mochi", "owner")
+	pip := core.NewDigitalPet("Pip", "owner")
+	if err := dm.BeginSession(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.WriteAll(ctx, payloads(t, mochi, pip)); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.ArchivePet(ctx, pip); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := dm.Recover(); err != nil || !report.Unclean {
+		t.Errorf("Expected the running session detected, got %+v (%v)", report, err)
+	}
+	if loaded, err := dm.LoadPet(ctx, mochi.ID); err != nil || loaded.Name != "Mochi" {
+		t.Fatalf("Expected the pet loaded back, got %v (%v)", loaded, err)
+	}
+
+	// Backups, deduplication and offsite copies
+	backups := filepath.Join(root, BackupDirName)
+	first, err := dm.Backup(ctx, backups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.Backup(ctx, backups); err != nil {
+		t.Fatal(err)
+	}
+	if page, err := ListBackups(ctx, dm.Backend, backups, BackupQuery{}); err != nil || page.Total != 2 {
+		t.Errorf("Expected 2 backups listed, got %+v (%v)", page, err)
+	}
+	report, err := dm.Compact(ctx, core.DefaultRetentionPolicy())
+	if err != nil || report.BackupFiles == 0 {
+		t.Errorf("Expected the backups deduplicated, got %+v (%v)", report, err)
+	}
+	offsite := NewCloudBackups(NewMemoryProvider(), 0)
+	offsite.Backend = dm.Backend
+	name, err := offsite.Upload(ctx, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh, _ := NewDataManagerOn(NewMemoryBackend(), root)
+	if _, err := offsite.Restore(ctx, fresh, name); err != nil {
+		t.Fatal(err)
+	}
+	if ids, _ := fresh.ListPets(ctx, FilterAll); len(ids) != 2 {
+		t.Errorf("Expected both pets restored from the offsite backup, got %v", ids)
+	}
+
+	// Sync and the audit log
+	sync := NewCloudSyncManager(dm, NewMemoryProvider())
+	if report, err := sync.SyncAll(ctx); err != nil || len(report.Uploaded) != 1 {
+		t.Errorf("Expected the active pet uploaded, got %+v (%v)", report, err)
+	}
+	if entries, _ := dm.Audit.Query(AuditQuery{Actor: ActorSync}); len(entries) == 0 {
+		t.Error("Expected the sync audited")
+	}
+	if err := dm.EndSession(); err != nil {
+		t.Fatal(err)
+	}
+
+	if entries, _ := os.ReadDir(disk); len(entries) != 0 {
+		t.Errorf("Expected nothing written to disk, found %v", entries)
+	}
+}
+
+// payloads serializes pets for WriteAll
+func payloads(t *testing.T, pets ...*core.DigitalPet) []PetPayload {
+	var out []PetPayload
+	for _, pet := range pets {
+		payload, err := pet.Save()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, PetPayload{ID: pet.ID, Payload: payload})
+	}
+	return out
+}
+
+func TestOpenBackend(t *testing.T) {
+	if b, err := OpenBackend(BackendMemory); err != nil || b == nil {
+		t.Errorf("Expected a memory backend, got %v (%v)", b, err)
+	}
+	if _, err := OpenBackend("tape"); err == nil {
+		t.Error("Expected an unknown backend to be refused")
+	}
+}
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Backup settings
+const (
+	BackupDirName      = "backups"         // Directory under the save path that holds backups
+	backupPrefix       = "backup-"         // Prefix of each backup directory
+	backupTimestamp    = "20060102-150405" // Layout of the backup directory suffix, in UTC
+	backupCollisionMax = 100               // Suffixes tried when backups land in the same second
+)
+
+// Backup copies every save, archived ones included, into a new directory
+// under dir and returns its path. The backup has the same layout as the
+// save directory, so it can be restored by pointing the save path at it.
+// A backup that fails part way is removed. Either way the lifecycle hooks
+// are told.
+func (dm *DataManager) Backup(ctx context.Context, dir string) (string, error) {
+	dm.mu.Lock()
+	path, pets, err := dm.backup(ctx, dir)
+	dm.mu.Unlock()
+
+	event := LifecycleEvent{Type: LifecycleBackup, Pets: pets, Path: path}
+	if err == nil {
+		event.Size = treeSize(dm.storage(), path)
+		event.Summary = fmt.Sprintf("backed up %d pets to %s", pets, path)
+	} else {
+		event.Summary = fmt.Sprintf("could not back up to %s", dir)
+	}
+	dm.Hooks.emit(event, err)
+	return path, err
+}
+
+// backup implements Backup, also returning how many pets were copied
+// (must be called with lock held)
+func (dm *DataManager) backup(ctx context.Context, dir string) (string, int, error) {
+	active, err := dm.listSaves(dm.SavePath)
+	if err != nil {
+		return "", 0, err
+	}
+	archived, err := dm.listSaves(dm.archivePath())
+	if err != nil && !os.IsNotExist(err) {
+		return "", 0, err
+	}
+
+	dest, err := dm.newBackupDir(dir)
+	if err != nil {
+		return "", 0, err
+	}
+	backup := dm.at(dest)
+	copyAll := func() error {
+		for _, id := range active {
+			if err := dm.copySave(ctx, dm.petPath(id), backup.petPath(id)); err != nil {
+				return err
+			}
+		}
+		if len(archived) == 0 {
+			return nil
+		}
+		if err := dm.storage().MkdirAll(backup.archivePath()); err != nil {
+			return err
+		}
+		for _, id := range archived {
+			if err := dm.copySave(ctx, dm.archivedPetPath(id), backup.archivedPetPath(id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := copyAll(); err != nil {
+		dm.storage().RemoveAll(dest)
+		return "", 0, err
+	}
+	return dest, len(active) + len(archived), nil
+}
+
+// newBackupDir creates an empty, uniquely named backup directory under dir
+func (dm *DataManager) newBackupDir(dir string) (string, error) {
+	if err := dm.storage().MkdirAll(dir); err != nil {
+		return "", err
+	}
+	name := backupPrefix + time.Now().UTC().Format(backupTimestamp)
+	path := filepath.Join(dir, name)
+	for i := 1; ; i++ {
+		err := dm.storage().Mkdir(path)
+		if err == nil {
+			return path, nil
+		}
+		if !os.IsExist(err) || i >= backupCollisionMax {
+			return "", err
+		}
+		path = filepath.Join(dir, name+"."+strconv.Itoa(i))
+	}
+}
+
+// copySave copies one save file durably
+func (dm *DataManager) copySave(ctx context.Context, from, to string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	payload, err := dm.storage().ReadFile(from)
+	if err != nil {
+		return err
+	}
+	return dm.storage().WriteFile(to, payload)
+}
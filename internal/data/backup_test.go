@@ -0,0 +1,69 @@
+package data
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestBackupCopiesActiveAndArchivedSaves(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := core.NewDigitalPet("Kept", "owner")
+	retired := core.NewDigitalPet("Retired", "owner")
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{kept, retired}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.ArchivePet(ctx, retired); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(dm.SavePath, BackupDirName)
+	first, err := dm.Backup(ctx, dir)
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	second, err := dm.Backup(ctx, dir)
+	if err != nil || second == first {
+		t.Fatalf("Expected a second, separate backup, got %s (%v)", second, err)
+	}
+
+	restored, err := NewDataManager(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pets, err := restored.LoadAll(ctx)
+	if err != nil || len(pets) != 1 || pets[0].ID != kept.ID {
+		t.Errorf("Expected the backup to hold %s, got %v (%v)", kept.ID, pets, err)
+	}
+	if !restored.IsArchived(retired.ID) {
+		t.Errorf("Expected %s archived in the backup", retired.ID)
+	}
+
+	// Backups live beside the saves without being mistaken for pets
+	if ids, _ := dm.ListPets(ctx, FilterAll); len(ids) != 2 {
+		t.Errorf("Backups should not show up as pets, got %v", ids)
+	}
+}
+
+func TestBackupCancelled(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	if err := dm.SavePet(context.Background(), core.NewDigitalPet("Mochi", "owner")); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	dir := t.TempDir()
+	if _, err := dm.Backup(ctx, dir); err == nil {
+		t.Fatal("Expected a cancelled backup to fail")
+	}
+	if entries, _ := filepath.Glob(filepath.Join(dir, "*")); len(entries) != 0 {
+		t.Errorf("Expected the partial backup removed, found %v", entries)
+	}
+}
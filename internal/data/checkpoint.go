@@ -0,0 +1,223 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Checkpoint settings
+const (
+	CheckpointDirName  = "checkpoints"     // Directory under the save path that holds checkpoints
+	MaxCheckpoints     = 10                // Checkpoints kept before older ones must be deleted
+	MaxCheckpointName  = 64                // Longest checkpoint name, in bytes
+	checkpointMetaName = "checkpoint.json" // Description written beside the saves
+	checkpointTempExt  = ".partial"        // Suffix of a checkpoint still being written
+)
+
+var (
+	// ErrCheckpointNotFound is returned when no checkpoint has the given name
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+	// ErrCheckpointExists is returned when a checkpoint name is already in use
+	ErrCheckpointExists = errors.New("checkpoint already exists")
+	// ErrCheckpointLimit is returned when MaxCheckpoints are already kept
+	ErrCheckpointLimit = errors.New("too many checkpoints")
+	// ErrInvalidCheckpoint is returned for an empty or overlong checkpoint name
+	ErrInvalidCheckpoint = errors.New("invalid checkpoint name")
+)
+
+// Checkpoint describes a named manual snapshot of one pet or the whole
+// household, kept until it is deleted
+type Checkpoint struct {
+	Name      string        `json:"name"`
+	Created   time.Time     `json:"created"`
+	Household bool          `json:"household"` // Taken of every pet rather than one
+	Pets      []types.PetID `json:"pets"`
+}
+
+// SaveCheckpoint writes the current state of pets as a new checkpoint.
+// Existing checkpoints are never overwritten: a name in use gives
+// ErrCheckpointExists and a full set gives ErrCheckpointLimit.
+func (dm *DataManager) SaveCheckpoint(ctx context.Context, name string, household bool, pets []*core.DigitalPet) (Checkpoint, error) {
+	name = strings.TrimSpace(name)
+	if strings.Trim(name, ".") == "" || len(name) > MaxCheckpointName {
+		return Checkpoint{}, fmt.Errorf("%w: %q must be 1 to %d characters", ErrInvalidCheckpoint, name, MaxCheckpointName)
+	}
+	cp := Checkpoint{Name: name, Created: time.Now().UTC(), Household: household}
+	payloads := make(map[types.PetID][]byte, len(pets))
+	for _, pet := range pets {
+		if err := ValidatePet(pet); err != nil {
+			return Checkpoint{}, err
+		}
+		payload, err := pet.Save()
+		if err != nil {
+			return Checkpoint{}, err
+		}
+		cp.Pets = append(cp.Pets, pet.ID)
+		payloads[pet.ID] = payload
+	}
+	sort.Slice(cp.Pets, func(i, j int) bool { return cp.Pets[i] < cp.Pets[j] })
+	meta, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return Checkpoint{}, err
+	}
+	existing, err := dm.checkpoints()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	for _, other := range existing {
+		if strings.EqualFold(other.Name, name) {
+			return Checkpoint{}, fmt.Errorf("%w: %s", ErrCheckpointExists, name)
+		}
+	}
+	if len(existing) >= MaxCheckpoints {
+		return Checkpoint{}, fmt.Errorf("%w: %d kept; delete one first", ErrCheckpointLimit, len(existing))
+	}
+
+	// Write into a temporary directory and rename it into place, so a
+	// crash never leaves a partial checkpoint that looks complete
+	dest := dm.checkpointPath(name)
+	temp := dest + checkpointTempExt
+	dm.storage().RemoveAll(temp)
+	if err := dm.storage().MkdirAll(temp); err != nil {
+		return Checkpoint{}, err
+	}
+	snapshot := &DataManager{SavePath: temp}
+	write := func() error {
+		for id, payload := range payloads {
+			raw, err := dm.encode(payload)
+			if err != nil {
+				return err
+			}
+			if err := dm.storage().WriteFile(snapshot.petPath(id), raw); err != nil {
+				return err
+			}
+		}
+		if err := dm.storage().WriteFile(filepath.Join(temp, checkpointMetaName), meta); err != nil {
+			return err
+		}
+		return dm.storage().Rename(temp, dest)
+	}
+	if err := write(); err != nil {
+		dm.storage().RemoveAll(temp)
+		return Checkpoint{}, err
+	}
+	return cp, nil
+}
+
+// ListCheckpoints returns every checkpoint, oldest first
+func (dm *DataManager) ListCheckpoints(ctx context.Context) ([]Checkpoint, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	return dm.checkpoints()
+}
+
+// LoadCheckpoint reads a checkpoint and the pets saved in it. Nothing is
+// written; replacing the current pets is up to the caller.
+func (dm *DataManager) LoadCheckpoint(ctx context.Context, name string) (Checkpoint, []*core.DigitalPet, error) {
+	if err := ctx.Err(); err != nil {
+		return Checkpoint{}, nil, err
+	}
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	cp, err := dm.readCheckpoint(dm.checkpointPath(name))
+	if err != nil {
+		return Checkpoint{}, nil, err
+	}
+	snapshot := &DataManager{SavePath: dm.checkpointPath(name)}
+	pets := make([]*core.DigitalPet, 0, len(cp.Pets))
+	for _, id := range cp.Pets {
+		payload, err := dm.readSave(snapshot.petPath(id))
+		if err != nil {
+			return Checkpoint{}, nil, petError(id, err)
+		}
+		pet, err := DecodePet(id, payload)
+		if err != nil {
+			return Checkpoint{}, nil, err
+		}
+		pets = append(pets, pet)
+	}
+	return cp, pets, nil
+}
+
+// DeleteCheckpoint removes a checkpoint
+func (dm *DataManager) DeleteCheckpoint(ctx context.Context, name string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	path := dm.checkpointPath(name)
+	if _, err := dm.readCheckpoint(path); err != nil {
+		return err
+	}
+	return dm.storage().RemoveAll(path)
+}
+
+// checkpoints implements ListCheckpoints (must be called with lock held).
+// Directories left by an interrupted write are skipped.
+func (dm *DataManager) checkpoints() ([]Checkpoint, error) {
+	entries, err := dm.storage().ReadDir(filepath.Join(dm.SavePath, CheckpointDirName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Checkpoint
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasSuffix(entry.Name(), checkpointTempExt) {
+			continue
+		}
+		cp, err := dm.readCheckpoint(filepath.Join(dm.SavePath, CheckpointDirName, entry.Name()))
+		if err != nil {
+			continue
+		}
+		list = append(list, cp)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Created.Before(list[j].Created) })
+	return list, nil
+}
+
+// readCheckpoint reads the description of the checkpoint in a directory
+func (dm *DataManager) readCheckpoint(dir string) (Checkpoint, error) {
+	raw, err := dm.storage().ReadFile(filepath.Join(dir, checkpointMetaName))
+	if os.IsNotExist(err) {
+		return Checkpoint{}, fmt.Errorf("%w: %s", ErrCheckpointNotFound, filepath.Base(dir))
+	}
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(raw, &cp); err != nil {
+		return Checkpoint{}, fmt.Errorf("%w: checkpoint %s: %w", ErrCorruptSave, filepath.Base(dir), err)
+	}
+	return cp, nil
+}
+
+// checkpointPath returns the directory of a checkpoint. Names are matched
+// case-insensitively and escaped like pet IDs.
+func (dm *DataManager) checkpointPath(name string) string {
+	return filepath.Join(dm.SavePath, CheckpointDirName, url.PathEscape(strings.ToLower(strings.TrimSpace(name))))
+}
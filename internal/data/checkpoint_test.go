@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestCheckpointSaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm.Compress = true
+	pet := core.NewDigitalPet("Rex", "owner")
+	pet.Biology.Vitals.Happiness = 0.9
+
+	if _, err := dm.SaveCheckpoint(ctx, "before breeding experiment", false, []*core.DigitalPet{pet}); err != nil {
+		t.Fatalf("SaveCheckpoint failed: %v", err)
+	}
+	pet.Biology.Vitals.Happiness = 0.1
+
+	cp, pets, err := dm.LoadCheckpoint(ctx, "Before Breeding Experiment")
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+	if cp.Name != "before breeding experiment" || cp.Household || len(pets) != 1 {
+		t.Fatalf("Unexpected checkpoint %+v with %d pets", cp, len(pets))
+	}
+	if pets[0].ID != pet.ID || pets[0].Biology.Vitals.Happiness != 0.9 {
+		t.Errorf("Expected the pet as it was at the checkpoint, got happiness %.1f", pets[0].Biology.Vitals.Happiness)
+	}
+	if ids, _ := dm.ListPets(ctx, FilterAll); len(ids) != 0 {
+		t.Errorf("Checkpoints should not show up as saves, got %v", ids)
+	}
+
+	if _, err := dm.SaveCheckpoint(ctx, "BEFORE breeding experiment", false, []*core.DigitalPet{pet}); !errors.Is(err, ErrCheckpointExists) {
+		t.Errorf("Expected ErrCheckpointExists, got %v", err)
+	}
+	if err := dm.DeleteCheckpoint(ctx, "before breeding experiment"); err != nil {
+		t.Fatalf("DeleteCheckpoint failed: %v", err)
+	}
+	if _, _, err := dm.LoadCheckpoint(ctx, "before breeding experiment"); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Errorf("Expected ErrCheckpointNotFound after deleting, got %v", err)
+	}
+}
+
+func TestCheckpointLimits(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pets := []*core.DigitalPet{core.NewDigitalPet("Rex", "owner"), core.NewDigitalPet("Bella", "owner")}
+
+	for _, name := range []string{"", "  ", "..", string(make([]byte, MaxCheckpointName+1))} {
+		if _, err := dm.SaveCheckpoint(ctx, name, true, pets); !errors.Is(err, ErrInvalidCheckpoint) {
+			t.Errorf("Expected ErrInvalidCheckpoint for %q, got %v", name, err)
+		}
+	}
+	for i := 0; i < MaxCheckpoints; i++ {
+		if _, err := dm.SaveCheckpoint(ctx, fmt.Sprintf("day %d", i), true, pets); err != nil {
+			t.Fatalf("SaveCheckpoint %d failed: %v", i, err)
+		}
+	}
+	if _, err := dm.SaveCheckpoint(ctx, "one too many", true, pets); !errors.Is(err, ErrCheckpointLimit) {
+		t.Errorf("Expected ErrCheckpointLimit, got %v", err)
+	}
+
+	list, err := dm.ListCheckpoints(ctx)
+	if err != nil || len(list) != MaxCheckpoints {
+		t.Fatalf("Expected %d checkpoints, got %d (%v)", MaxCheckpoints, len(list), err)
+	}
+	if list[0].Name != "day 0" || !list[0].Household || len(list[0].Pets) != 2 {
+		t.Errorf("Expected the oldest household checkpoint first, got %+v", list[0])
+	}
+}
@@ -0,0 +1,171 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCloudUnavailable is returned when a cloud provider cannot be
+	// reached. The operation may succeed if retried later.
+	ErrCloudUnavailable = errors.New("cloud storage is unavailable")
+	// ErrObjectNotFound is returned when a cloud provider has no object
+	// with the requested name
+	ErrObjectNotFound = errors.New("object not found in cloud storage")
+	// ErrConflict is returned when a pet changed both locally and in the
+	// cloud since the last sync
+	ErrConflict = errors.New("pet changed both locally and in the cloud")
+)
+
+// CloudProvider stores named objects remotely. Implementations return
+// ErrCloudUnavailable when the service cannot be reached and
+// ErrObjectNotFound when an object does not exist, and give up once ctx
+// is done.
+type CloudProvider interface {
+	List(ctx context.Context) ([]string, error)
+	Upload(ctx context.Context, name string, payload []byte) error
+	Download(ctx context.Context, name string) ([]byte, error)
+	GetLastModified(ctx context.Context, name string) (time.Time, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// memoryObject is one object held by a MemoryProvider
+type memoryObject struct {
+	payload  []byte
+	modified time.Time
+}
+
+// MemoryProvider is a CloudProvider held in memory, for tests and for
+// syncing between households in the same process
+type MemoryProvider struct {
+	// Latency delays every call, to simulate a slow connection
+	Latency time.Duration
+
+	mu      sync.Mutex
+	objects map[string]memoryObject
+	offline bool
+}
+
+// NewMemoryProvider creates an empty in-memory provider
+func NewMemoryProvider() *MemoryProvider {
+	return &MemoryProvider{objects: make(map[string]memoryObject)}
+}
+
+// SetOffline simulates the provider becoming unreachable or coming back
+func (p *MemoryProvider) SetOffline(offline bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.offline = offline
+}
+
+// List returns the names of every stored object in sorted order
+func (p *MemoryProvider) List(ctx context.Context) ([]string, error) {
+	if err := p.wait(ctx); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.offline {
+		return nil, ErrCloudUnavailable
+	}
+
+	names := make([]string, 0, len(p.objects))
+	for name := range p.objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Upload stores a copy of payload under name
+func (p *MemoryProvider) Upload(ctx context.Context, name string, payload []byte) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.offline {
+		return ErrCloudUnavailable
+	}
+	p.objects[name] = memoryObject{payload: append([]byte(nil), payload...), modified: time.Now()}
+	return nil
+}
+
+// Download returns a copy of the object stored under name
+func (p *MemoryProvider) Download(ctx context.Context, name string) ([]byte, error) {
+	if err := p.wait(ctx); err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	object, err := p.object(name)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(nil), object.payload...), nil
+}
+
+// GetLastModified returns when the object stored under name was uploaded
+func (p *MemoryProvider) GetLastModified(ctx context.Context, name string) (time.Time, error) {
+	if err := p.wait(ctx); err != nil {
+		return time.Time{}, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	object, err := p.object(name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return object.modified, nil
+}
+
+// Delete removes the object stored under name
+func (p *MemoryProvider) Delete(ctx context.Context, name string) error {
+	if err := p.wait(ctx); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.object(name); err != nil {
+		return err
+	}
+	delete(p.objects, name)
+	return nil
+}
+
+// wait simulates the provider's latency, giving up if ctx is done first
+func (p *MemoryProvider) wait(ctx context.Context) error {
+	if p.Latency <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(p.Latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// object looks up an object (must be called with lock held)
+func (p *MemoryProvider) object(name string) (memoryObject, error) {
+	if p.offline {
+		return memoryObject{}, ErrCloudUnavailable
+	}
+	object, exists := p.objects[name]
+	if !exists {
+		return memoryObject{}, fmt.Errorf("%w: %s", ErrObjectNotFound, name)
+	}
+	return object, nil
+}
+
+// IsTemporary returns true if err is a failure that may go away if the
+// operation is retried later
+func IsTemporary(err error) bool {
+	return errors.Is(err, ErrCloudUnavailable)
+}
@@ -0,0 +1,38 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryProvider(t *testing.T) {
+	p := NewMemoryProvider()
+	if err := p.Upload(context.Background(), "b.json", []byte("two")); err != nil {
+		t.Fatal(err)
+	}
+	p.Upload(context.Background(), "a.json", []byte("one"))
+
+	names, err := p.List(context.Background())
+	if err != nil || len(names) != 2 || names[0] != "a.json" {
+		t.Errorf("Expected sorted names, got %v (%v)", names, err)
+	}
+	if payload, err := p.Download(context.Background(), "a.json"); err != nil || string(payload) != "one" {
+		t.Errorf("Expected to download \"one\", got %q (%v)", payload, err)
+	}
+	if _, err := p.GetLastModified(context.Background(), "missing.json"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected ErrObjectNotFound, got %v", err)
+	}
+
+	if err := p.Delete(context.Background(), "b.json"); err != nil {
+		t.Errorf("Delete failed: %v", err)
+	}
+	if _, err := p.Download(context.Background(), "b.json"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected the deleted object gone, got %v", err)
+	}
+
+	p.SetOffline(true)
+	if _, err := p.Download(context.Background(), "a.json"); !errors.Is(err, ErrCloudUnavailable) || !IsTemporary(err) {
+		t.Errorf("Expected a temporary ErrCloudUnavailable, got %v", err)
+	}
+}
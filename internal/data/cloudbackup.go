@@ -0,0 +1,312 @@
+package data
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// Cloud backup settings
+const (
+	BackupArchiveExt   = ".tar.gz" // Extension of a backup uploaded to cloud storage
+	DefaultKeepBackups = 7         // Remote backups kept unless configured otherwise
+)
+
+// ErrSavesExist is returned when restoring a backup over a save directory
+// that already holds saves
+var ErrSavesExist = errors.New("save directory already has saves")
+
+// CloudBackups ships completed backups to a cloud provider, one gzipped
+// tar archive per backup, and restores them onto a fresh save directory.
+// Archives are named after their backup directory, so they sort oldest
+// first and sit alongside synced saves without being mistaken for them.
+type CloudBackups struct {
+	Provider CloudProvider
+	Keep     int            // Remote backups kept after each upload; 0 keeps them all
+	Timeout  time.Duration  // Limit on each provider call; 0 waits as long as ctx allows
+	Backend  StorageBackend // Where uploaded backups are read from; nil uses the disk
+}
+
+// NewCloudBackups creates cloud backups for a provider keeping the last
+// keep archives
+func NewCloudBackups(provider CloudProvider, keep int) *CloudBackups {
+	return &CloudBackups{Provider: provider, Keep: keep, Timeout: DefaultCloudTimeout}
+}
+
+// Upload archives a backup directory written by Backup, uploads it and
+// deletes the oldest remote backups beyond Keep. Returns the archive's
+// name; if only the clean-up failed the name is returned with the error.
+func (b *CloudBackups) Upload(ctx context.Context, dir string) (string, error) {
+	payload, err := packBackup(backendOr(b.Backend), dir)
+	if err != nil {
+		return "", err
+	}
+	name := filepath.Base(dir) + BackupArchiveExt
+	callCtx, cancel := b.callContext(ctx)
+	err = b.Provider.Upload(callCtx, name, payload)
+	cancel()
+	if err != nil {
+		return "", cloudError("", err)
+	}
+	if _, err := b.Prune(ctx); err != nil {
+		return name, fmt.Errorf("pruning old backups: %w", err)
+	}
+	return name, nil
+}
+
+// List returns the names of the remote backups, oldest first
+func (b *CloudBackups) List(ctx context.Context) ([]string, error) {
+	callCtx, cancel := b.callContext(ctx)
+	names, err := b.Provider.List(callCtx)
+	cancel()
+	if err != nil {
+		return nil, cloudError("", err)
+	}
+
+	var backups []string
+	for _, name := range names {
+		if strings.HasPrefix(name, backupPrefix) && strings.HasSuffix(name, BackupArchiveExt) {
+			backups = append(backups, name)
+		}
+	}
+	// Compare without the extension so that a backup sorts before those
+	// given a collision suffix in the same second
+	sort.Slice(backups, func(i, j int) bool {
+		return strings.TrimSuffix(backups[i], BackupArchiveExt) < strings.TrimSuffix(backups[j], BackupArchiveExt)
+	})
+	return backups, nil
+}
+
+// Prune deletes the oldest remote backups beyond Keep and returns their
+// names
+func (b *CloudBackups) Prune(ctx context.Context) ([]string, error) {
+	if b.Keep <= 0 {
+		return nil, nil
+	}
+	names, err := b.List(ctx)
+	if err != nil || len(names) <= b.Keep {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, name := range names[:len(names)-b.Keep] {
+		callCtx, cancel := b.callContext(ctx)
+		err := b.Provider.Delete(callCtx, name)
+		cancel()
+		if err != nil && !errors.Is(err, ErrObjectNotFound) {
+			return deleted, cloudError("", err)
+		}
+		deleted = append(deleted, name)
+	}
+	return deleted, nil
+}
+
+// Restore downloads a remote backup, the latest if name is empty, and
+// unpacks it into the save directory. The save directory must not hold
+// any saves, archived ones included; otherwise ErrSavesExist is returned
+// before the provider is contacted. Every save in the archive is checked
+// before any is written. Returns the name of the backup restored, or
+// ErrObjectNotFound if there are no remote backups.
+func (b *CloudBackups) Restore(ctx context.Context, dm *DataManager, name string) (string, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	if err := dm.checkFresh(); err != nil {
+		return "", err
+	}
+	name, files, err := b.download(ctx, dm, name)
+	if err != nil {
+		return "", err
+	}
+	if err := writeRestored(dm, files); err != nil {
+		return "", err
+	}
+	dm.Audit.Record(AuditEntry{Actor: ActorOffsite, Action: AuditRestore, Summary: fmt.Sprintf("restored backup %s", name)})
+	return name, nil
+}
+
+// download fetches and unpacks a remote backup, the latest if name is
+// empty, returning its name and saves
+func (b *CloudBackups) download(ctx context.Context, dm *DataManager, name string) (string, []restoredFile, error) {
+	if name == "" {
+		names, err := b.List(ctx)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(names) == 0 {
+			return "", nil, fmt.Errorf("%w: no backups", ErrObjectNotFound)
+		}
+		name = names[len(names)-1]
+	}
+
+	callCtx, cancel := b.callContext(ctx)
+	payload, err := b.Provider.Download(callCtx, name)
+	cancel()
+	if err != nil {
+		return "", nil, cloudError("", err)
+	}
+	files, err := unpackBackup(dm, payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return name, files, nil
+}
+
+// callContext bounds one provider call by Timeout
+func (b *CloudBackups) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if b.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, b.Timeout)
+}
+
+// checkFresh returns ErrSavesExist if the save directory holds any saves
+// (must be called with lock held)
+func (dm *DataManager) checkFresh() error {
+	for _, dir := range []string{dm.SavePath, dm.archivePath()} {
+		ids, err := dm.listSaves(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if len(ids) > 0 {
+			return fmt.Errorf("%w: %s", ErrSavesExist, dir)
+		}
+	}
+	return nil
+}
+
+// packBackup returns a gzipped tar of the saves in a backup directory,
+// archived ones under archive/
+func packBackup(storage StorageBackend, dir string) ([]byte, error) {
+	backup := &DataManager{SavePath: dir, Backend: storage}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, archived := range []bool{false, true} {
+		sub, savePath := "", backup.petPath
+		if archived {
+			sub, savePath = archiveName, backup.archivedPetPath
+		}
+		ids, err := backup.listSaves(filepath.Join(dir, sub))
+		if os.IsNotExist(err) && archived {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			file := savePath(id)
+			raw, err := storage.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			header := &tar.Header{
+				Name:    path.Join(sub, filepath.Base(file)),
+				Mode:    0o644,
+				Size:    int64(len(raw)),
+				ModTime: time.Now(),
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				return nil, err
+			}
+			if _, err := tw.Write(raw); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// restoredFile is one save unpacked from a backup archive
+type restoredFile struct {
+	path string
+	raw  []byte
+	pet  *core.DigitalPet
+}
+
+// unpackBackup reads a backup archive and checks every save in it,
+// returning where each belongs in the save directory. Entries other than
+// saves and archived saves are rejected, so an archive cannot write
+// outside the save directory.
+func unpackBackup(dm *DataManager, payload []byte) ([]restoredFile, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+	}
+	defer zr.Close()
+
+	var files []restoredFile
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+		}
+		dir, base := path.Split(header.Name)
+		id, ok := saveID(base)
+		if header.Typeflag != tar.TypeReg || (dir != "" && dir != archiveName+"/") || !ok {
+			return nil, fmt.Errorf("%w: unexpected entry %q", ErrCorruptSave, header.Name)
+		}
+
+		raw, err := io.ReadAll(io.LimitReader(tr, MaxSaveSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+		}
+		if len(raw) > MaxSaveSize {
+			return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrCorruptSave, header.Name, MaxSaveSize)
+		}
+		decoded, err := dm.decode(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", header.Name, err)
+		}
+		pet, err := DecodePet(id, decoded)
+		if err != nil {
+			return nil, err
+		}
+
+		file := dm.petPath(id)
+		if dir != "" {
+			file = dm.archivedPetPath(id)
+		}
+		files = append(files, restoredFile{path: file, raw: raw, pet: pet})
+	}
+}
+
+// writeRestored writes unpacked saves durably. If any write fails the
+// files already written are removed, leaving the directory fresh for
+// another attempt.
+func writeRestored(dm *DataManager, files []restoredFile) error {
+	if err := dm.storage().MkdirAll(dm.archivePath()); err != nil {
+		return err
+	}
+	for i, file := range files {
+		if err := dm.storage().WriteFile(file.path, file.raw); err != nil {
+			for _, written := range files[:i] {
+				dm.storage().Remove(written.path)
+			}
+			return err
+		}
+	}
+	return nil
+}
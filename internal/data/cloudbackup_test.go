@@ -0,0 +1,93 @@
+package data
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestCloudBackupRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dm, _ := NewDataManager(t.TempDir())
+	dm.Compress = true
+	kept := core.NewDigitalPet("Kept", "owner")
+	retired := core.NewDigitalPet("Retired", "owner")
+	dm.SaveAll(ctx, []*core.DigitalPet{kept, retired})
+	dm.ArchivePet(ctx, retired)
+
+	provider := NewMemoryProvider()
+	backups := NewCloudBackups(provider, 2)
+	dir := filepath.Join(dm.SavePath, BackupDirName)
+	var uploaded []string
+	for i := 0; i < 3; i++ {
+		local, err := dm.Backup(ctx, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, err := backups.Upload(ctx, local)
+		if err != nil {
+			t.Fatalf("Upload failed: %v", err)
+		}
+		uploaded = append(uploaded, name)
+	}
+	names, err := backups.List(ctx)
+	if err != nil || len(names) != 2 || names[0] != uploaded[1] || names[1] != uploaded[2] {
+		t.Fatalf("Expected the last two backups kept, got %v (%v)", names, err)
+	}
+
+	if _, err := backups.Restore(ctx, dm, ""); !errors.Is(err, ErrSavesExist) {
+		t.Errorf("Expected ErrSavesExist restoring over saves, got %v", err)
+	}
+	fresh, _ := NewDataManager(t.TempDir())
+	name, err := backups.Restore(ctx, fresh, "")
+	if err != nil || name != uploaded[2] {
+		t.Fatalf("Expected %s restored, got %s (%v)", uploaded[2], name, err)
+	}
+	if pet, err := fresh.LoadPet(ctx, kept.ID); err != nil || pet.Name != kept.Name {
+		t.Errorf("Expected %s restored, got %v", kept.ID, err)
+	}
+	if !fresh.IsArchived(retired.ID) {
+		t.Errorf("Expected %s restored to the archive", retired.ID)
+	}
+
+	empty, _ := NewDataManager(t.TempDir())
+	if _, err := NewCloudBackups(NewMemoryProvider(), 2).Restore(ctx, empty, ""); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected ErrObjectNotFound with no remote backups, got %v", err)
+	}
+	provider.SetOffline(true)
+	if _, err := backups.Restore(ctx, empty, ""); !IsTemporary(err) {
+		t.Errorf("Expected a temporary error while offline, got %v", err)
+	}
+}
+
+func TestCloudBackupRejectsStrayEntries(t *testing.T) {
+	ctx := context.Background()
+	pet := core.NewDigitalPet("Mochi", "owner")
+	payload, _ := pet.Save()
+
+	for _, entry := range []string{"../escape.json", "archive/../../escape.json", "notes.txt", "chunks/x.json"} {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		tw.WriteHeader(&tar.Header{Name: entry, Mode: 0o644, Size: int64(len(payload))})
+		tw.Write(payload)
+		tw.Close()
+		zw.Close()
+
+		provider := NewMemoryProvider()
+		provider.Upload(ctx, backupPrefix+"20260101-000000"+BackupArchiveExt, buf.Bytes())
+		dm, _ := NewDataManager(t.TempDir())
+		if _, err := NewCloudBackups(provider, 0).Restore(ctx, dm, ""); !errors.Is(err, ErrCorruptSave) {
+			t.Errorf("%s: expected ErrCorruptSave, got %v", entry, err)
+		}
+		if ids, _ := dm.ListPets(ctx, FilterAll); len(ids) != 0 {
+			t.Errorf("%s: expected nothing restored, got %v", entry, ids)
+		}
+	}
+}
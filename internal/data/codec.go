@@ -0,0 +1,120 @@
+package data
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// MaxSaveSize limits how large a compressed save may grow when read back
+const MaxSaveSize = 64 << 20
+
+// gzipMagic starts every gzip stream; plain saves start with JSON instead
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// IsCompressed returns true if a save file holds a compressed payload
+func IsCompressed(raw []byte) bool {
+	return bytes.HasPrefix(raw, gzipMagic)
+}
+
+// compressSave gzips a payload for writing to disk
+func compressSave(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeSave returns the payload held by a save file, decompressing it if
+// it was written compressed
+func decodeSave(raw []byte) ([]byte, error) {
+	if !IsCompressed(raw) {
+		return raw, nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+	}
+	payload, err := io.ReadAll(io.LimitReader(zr, MaxSaveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+	}
+	if len(payload) > MaxSaveSize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrCorruptSave, MaxSaveSize)
+	}
+	return payload, nil
+}
+
+// encode prepares a payload for disk in the manager's format
+func (dm *DataManager) encode(payload []byte) ([]byte, error) {
+	return dm.pack(payload, dm.Compress)
+}
+
+// pack compresses a payload if asked and encrypts it if the manager has a
+// cipher
+func (dm *DataManager) pack(payload []byte, compress bool) ([]byte, error) {
+	if compress {
+		var err error
+		if payload, err = compressSave(payload); err != nil {
+			return nil, err
+		}
+	}
+	if dm.Cipher == nil {
+		return payload, nil
+	}
+	return dm.Cipher.Seal(payload)
+}
+
+// unseal decrypts a save file with the manager's cipher, returning plain
+// and compressed saves as they are
+func (dm *DataManager) unseal(raw []byte) ([]byte, error) {
+	if !IsEncrypted(raw) {
+		return raw, nil
+	}
+	if dm.Cipher == nil {
+		return nil, ErrEncryptedSave
+	}
+	return dm.Cipher.Open(raw)
+}
+
+// decode returns the payload held by a save file in any format
+func (dm *DataManager) decode(raw []byte) ([]byte, error) {
+	inner, err := dm.unseal(raw)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSave(inner)
+}
+
+// readSave reads a save file and returns its payload
+func (dm *DataManager) readSave(path string) ([]byte, error) {
+	raw, err := dm.storage().ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return dm.decode(raw)
+}
+
+// ReadPetFile reads a save file from any path, compressed or not, such as
+// a copy taken from another device. Encrypted saves return
+// ErrEncryptedSave.
+func ReadPetFile(path string) (*core.DigitalPet, error) {
+	payload, err := (&DataManager{}).readSave(path)
+	if err != nil {
+		return nil, err
+	}
+	return DecodePet(types.PetID(filepath.Base(path)), payload)
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestCompressedSavesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := core.NewDigitalPet("Plain", "owner")
+	if err := dm.SavePet(ctx, plain); err != nil {
+		t.Fatal(err)
+	}
+
+	dm.Compress = true
+	packed := core.NewDigitalPet("Packed", "owner")
+	if err := dm.SavePet(ctx, packed); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{plain}); err != nil {
+		t.Fatal(err)
+	}
+	for _, pet := range []*core.DigitalPet{plain, packed} {
+		if raw, _ := os.ReadFile(dm.petPath(pet.ID)); !IsCompressed(raw) {
+			t.Errorf("Expected %s to be written compressed", pet.Name)
+		}
+	}
+
+	pets, err := dm.LoadAll(ctx)
+	if err != nil || len(pets) != 2 {
+		t.Fatalf("Expected both pets to load, got %d (%v)", len(pets), err)
+	}
+	payload, _ := packed.Save()
+	if err := dm.VerifyPet(ctx, packed.ID, payload); err != nil {
+		t.Errorf("Expected the compressed save to verify, got %v", err)
+	}
+
+	if err := dm.ArchivePet(ctx, packed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.LoadArchivedPet(ctx, packed.ID); err != nil {
+		t.Errorf("Expected the compressed archive to load, got %v", err)
+	}
+}
+
+func TestDecodeSaveRejectsBadStreams(t *testing.T) {
+	if _, err := decodeSave([]byte{0x1f, 0x8b, 0x08, 0x00}); !errors.Is(err, ErrCorruptSave) {
+		t.Errorf("Expected ErrCorruptSave for a truncated stream, got %v", err)
+	}
+	if payload, err := decodeSave([]byte(`{"id":"a"}`)); err != nil || string(payload) != `{"id":"a"}` {
+		t.Errorf("Expected plain saves to pass through, got %q (%v)", payload, err)
+	}
+}
+
+func TestReadPetFile(t *testing.T) {
+	dir := t.TempDir()
+	pet := core.NewDigitalPet("Mochi", "owner")
+	payload, _ := pet.Save()
+	compressed, _ := compressSave(payload)
+	os.WriteFile(filepath.Join(dir, "plain.json"), payload, 0o644)
+	os.WriteFile(filepath.Join(dir, "packed.json"), compressed, 0o644)
+	os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0o644)
+
+	for _, name := range []string{"plain.json", "packed.json"} {
+		if loaded, err := ReadPetFile(filepath.Join(dir, name)); err != nil || loaded.ID != pet.ID {
+			t.Errorf("%s: expected %s, got %v", name, pet.ID, err)
+		}
+	}
+	if _, err := ReadPetFile(filepath.Join(dir, "bad.json")); err == nil {
+		t.Error("Expected a malformed save rejected")
+	}
+}
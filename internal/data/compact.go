@@ -0,0 +1,239 @@
+package data
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ChunkDirName is the directory under the backup directory that holds the
+// content shared between backups
+const ChunkDirName = "chunks"
+
+// CompactReport describes what Compact changed and how much space it freed
+type CompactReport struct {
+	Saves           int                  // Saves rewritten, archived ones included
+	History         core.RetentionReport // History summarized or dropped across all pets
+	SaveBytesBefore int64                // Size of the saves before compaction
+	SaveBytesAfter  int64                // Size of the saves after compaction
+	BackupFiles     int                  // Backup files that now share a chunk
+	ChunksRemoved   int                  // Chunks no backup used any more
+	BackupBytes     int64                // Space freed in the backup directory
+}
+
+// Reclaimed returns the bytes freed by compaction
+func (r CompactReport) Reclaimed() int64 {
+	return r.SaveBytesBefore - r.SaveBytesAfter + r.BackupBytes
+}
+
+// Compact rewrites every save compressed, with history beyond policy
+// summarized, then deduplicates the backups under the save path. Saves
+// that are already compressed and have nothing to summarize are left
+// alone. Saves that cannot be read are skipped and reported in the
+// returned error alongside the report.
+func (dm *DataManager) Compact(ctx context.Context, policy core.RetentionPolicy) (CompactReport, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	var report CompactReport
+	active, err := dm.listSaves(dm.SavePath)
+	if err != nil {
+		return report, err
+	}
+	archived, err := dm.listSaves(dm.archivePath())
+	if err != nil && !os.IsNotExist(err) {
+		return report, err
+	}
+
+	var problems []error
+	compact := func(id types.PetID, isArchived bool) error {
+		err := dm.compactSave(ctx, id, isArchived, policy, &report)
+		if err != nil && ctx.Err() == nil {
+			problems = append(problems, fmt.Errorf("%s: %w", id, err))
+			return nil
+		}
+		return err
+	}
+	for _, id := range active {
+		if err := compact(id, false); err != nil {
+			return report, err
+		}
+	}
+	for _, id := range archived {
+		if err := compact(id, true); err != nil {
+			return report, err
+		}
+	}
+
+	if err := dm.dedupeBackups(ctx, filepath.Join(dm.SavePath, BackupDirName), &report); err != nil {
+		return report, err
+	}
+	return report, errors.Join(problems...)
+}
+
+// compactSave rewrites one save if compressing or summarizing it changes
+// anything (must be called with lock held)
+func (dm *DataManager) compactSave(ctx context.Context, id types.PetID, archived bool, policy core.RetentionPolicy, report *CompactReport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	path := dm.petPath(id)
+	if archived {
+		path = dm.archivedPetPath(id)
+	}
+	raw, err := dm.storage().ReadFile(path)
+	if err != nil {
+		return err
+	}
+	report.SaveBytesBefore += int64(len(raw))
+	report.SaveBytesAfter += int64(len(raw))
+
+	inner, err := dm.unseal(raw)
+	if err != nil {
+		return err
+	}
+	payload, err := decodeSave(inner)
+	if err != nil {
+		return err
+	}
+	pet, err := DecodePet(id, payload)
+	if err != nil {
+		return err
+	}
+	pruned := pet.ApplyRetention(policy)
+	packed := IsCompressed(inner) && IsEncrypted(raw) == (dm.Cipher != nil)
+	if packed && pruned == (core.RetentionReport{}) {
+		return nil
+	}
+
+	if payload, err = pet.Save(); err != nil {
+		return err
+	}
+	compressed, err := dm.pack(payload, true)
+	if err != nil {
+		return err
+	}
+	if archived {
+		if err := dm.storage().WriteFile(path+tempExt, compressed); err != nil {
+			return err
+		}
+		err = dm.storage().Rename(path+tempExt, path)
+	} else {
+		err = dm.writeSave(id, compressed)
+	}
+	if err != nil {
+		return err
+	}
+
+	report.Saves++
+	report.History.MemoriesSummarized += pruned.MemoriesSummarized
+	report.History.ExperiencesSummarized += pruned.ExperiencesSummarized
+	report.History.SnapshotsDropped += pruned.SnapshotsDropped
+	report.SaveBytesAfter += int64(len(compressed) - len(raw))
+	return nil
+}
+
+// dedupeBackups makes identical save files across backups share one copy
+// in the chunk store, named by the SHA-256 of the content, using hard
+// links. A backup can still be restored by pointing the save path at it,
+// since saves are always replaced by rename and never rewritten in place.
+// Chunks no backup uses any more are removed.
+func (dm *DataManager) dedupeBackups(ctx context.Context, dir string, report *CompactReport) error {
+	store := filepath.Join(dir, ChunkDirName)
+	used := make(map[string]bool)
+
+	err := walkDir(dm.storage(), dir, func(path string, entry fs.DirEntry, err error) error {
+		switch {
+		case err != nil && path == dir && errors.Is(err, fs.ErrNotExist):
+			return fs.SkipAll
+		case err != nil:
+			return err
+		case entry.IsDir() && path == store:
+			return fs.SkipDir
+		case entry.IsDir() || !strings.HasSuffix(path, saveExt):
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		sum, err := dm.fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		used[sum] = true
+		chunk := filepath.Join(store, sum)
+		chunkInfo, err := dm.storage().Stat(chunk)
+		if os.IsNotExist(err) {
+			if err := dm.storage().MkdirAll(store); err != nil {
+				return err
+			}
+			return dm.storage().Link(path, chunk)
+		}
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil || dm.storage().SameFile(info, chunkInfo) {
+			return err
+		}
+
+		temp := path + tempExt
+		dm.storage().Remove(temp)
+		if err := dm.storage().Link(chunk, temp); err != nil {
+			return err
+		}
+		if err := dm.storage().Rename(temp, path); err != nil {
+			dm.storage().Remove(temp)
+			return err
+		}
+		report.BackupFiles++
+		report.BackupBytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	chunks, err := dm.storage().ReadDir(store)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if used[chunk.Name()] || chunk.IsDir() {
+			continue
+		}
+		info, err := chunk.Info()
+		if err != nil {
+			return err
+		}
+		if err := dm.storage().Remove(filepath.Join(store, chunk.Name())); err != nil {
+			return err
+		}
+		report.ChunksRemoved++
+		report.BackupBytes += info.Size()
+	}
+	return nil
+}
+
+// fileChecksum returns the SHA-256 of a file's content in hex
+func (dm *DataManager) fileChecksum(path string) (string, error) {
+	content, err := dm.storage().ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
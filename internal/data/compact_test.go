@@ -0,0 +1,129 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// elderPet creates a pet with more history than the default policy keeps
+func elderPet(name string) *core.DigitalPet {
+	pet := core.NewDigitalPet(name, "owner")
+	for i := 0; i < 500; i++ {
+		pet.Memory.Remember(&ai.Memory{Type: ai.MemoryEvent, Description: "A long afternoon nap in the sun", Strength: 0.8})
+		pet.Memory.ConsolidateMemories()
+	}
+	return pet
+}
+
+func TestCompactSaves(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept, retired := elderPet("Kept"), elderPet("Retired")
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{kept, retired}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.ArchivePet(ctx, retired); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := dm.Compact(ctx, core.DefaultRetentionPolicy())
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if report.Saves != 2 || report.History.MemoriesSummarized != 600 {
+		t.Errorf("Expected both saves rewritten with 600 memories summarized, got %+v", report)
+	}
+	if report.SaveBytesAfter*4 > report.SaveBytesBefore || report.Reclaimed() <= 0 {
+		t.Errorf("Expected the saves to shrink at least fourfold, %d -> %d bytes", report.SaveBytesBefore, report.SaveBytesAfter)
+	}
+	for _, path := range []string{dm.petPath(kept.ID), dm.archivedPetPath(retired.ID)} {
+		if raw, _ := os.ReadFile(path); !IsCompressed(raw) {
+			t.Errorf("Expected %s to be compressed", filepath.Base(path))
+		}
+	}
+	if pet, err := dm.LoadPet(ctx, kept.ID); err != nil || len(pet.Memory.LongTermMemories) != 200 {
+		t.Errorf("Expected the compacted pet to load with 200 memories, got %v", err)
+	}
+	if _, err := dm.LoadArchivedPet(ctx, retired.ID); err != nil {
+		t.Errorf("Expected the compacted archive to load, got %v", err)
+	}
+
+	again, err := dm.Compact(ctx, core.DefaultRetentionPolicy())
+	if err != nil || again.Saves != 0 || again.Reclaimed() != 0 {
+		t.Errorf("Expected nothing left to compact, got %+v (%v)", again, err)
+	}
+}
+
+func TestCompactSkipsUnreadableSaves(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet := core.NewDigitalPet("Mochi", "owner")
+	if err := dm.SavePet(ctx, pet); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dm.SavePath, "broken"+saveExt), []byte("{"), 0o644)
+
+	report, err := dm.Compact(ctx, core.DefaultRetentionPolicy())
+	if err == nil || report.Saves != 1 {
+		t.Errorf("Expected one save compacted and the broken one reported, got %+v (%v)", report, err)
+	}
+}
+
+func TestCompactDeduplicatesBackups(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept, retired := core.NewDigitalPet("Kept", "owner"), core.NewDigitalPet("Retired", "owner")
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{kept, retired}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.ArchivePet(ctx, retired); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(dm.SavePath, BackupDirName)
+	first, _ := dm.Backup(ctx, dir)
+	second, _ := dm.Backup(ctx, dir)
+
+	report, err := dm.Compact(ctx, core.DefaultRetentionPolicy())
+	if err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if report.BackupFiles != 2 || report.BackupBytes == 0 {
+		t.Errorf("Expected the second backup's 2 files to share chunks, got %+v", report)
+	}
+	a, _ := os.Stat(filepath.Join(first, filepath.Base(dm.petPath(kept.ID))))
+	b, _ := os.Stat(filepath.Join(second, filepath.Base(dm.petPath(kept.ID))))
+	if a == nil || b == nil || !os.SameFile(a, b) {
+		t.Error("Expected identical backup files to be the same file")
+	}
+	restored, _ := NewDataManager(second)
+	if pets, err := restored.LoadAll(ctx); err != nil || len(pets) != 1 {
+		t.Errorf("Expected the deduplicated backup to restore, got %d pets (%v)", len(pets), err)
+	}
+
+	// Once the old backups are gone so are their chunks
+	third, _ := dm.Backup(ctx, dir)
+	os.RemoveAll(first)
+	os.RemoveAll(second)
+	report, err = dm.Compact(ctx, core.DefaultRetentionPolicy())
+	if err != nil || report.ChunksRemoved != 2 || report.BackupFiles != 0 {
+		t.Errorf("Expected the 2 old chunks removed, got %+v (%v)", report, err)
+	}
+	chunks, _ := os.ReadDir(filepath.Join(dir, ChunkDirName))
+	if len(chunks) != 2 {
+		t.Errorf("Expected 2 chunks for %s, got %d", filepath.Base(third), len(chunks))
+	}
+}
@@ -0,0 +1,159 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ConflictResolution is how a sync settles a pet that changed both locally
+// and in the cloud since the last sync
+type ConflictResolution int
+
+const (
+	ConflictAskUser       ConflictResolution = iota // Ask the Ask callback; without one the conflict is left for Resolve
+	ConflictLastWriteWins                           // Keep whichever copy the pet was updated in last
+	ConflictPreferLocal                             // Keep the local copy
+	ConflictPreferCloud                             // Keep the cloud copy
+	ConflictMerge                                   // Combine both copies; see MergePets
+)
+
+// String returns the name the resolution is configured by
+func (r ConflictResolution) String() string {
+	return [...]string{"ask", "last-write-wins", "local", "cloud", "merge"}[r]
+}
+
+// ParseConflictResolution looks up a resolution by the name String gives
+// it, ignoring case
+func ParseConflictResolution(name string) (ConflictResolution, error) {
+	for r := ConflictAskUser; r <= ConflictMerge; r++ {
+		if strings.EqualFold(r.String(), strings.TrimSpace(name)) {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown conflict resolution %q", name)
+}
+
+// ConflictChoice is which copy of a pet a conflict was settled with
+type ConflictChoice int
+
+const (
+	KeepNeither ConflictChoice = iota // Left unsettled; both copies stand until Resolve
+	KeepLocal
+	KeepCloud
+	KeepMerged
+)
+
+// String returns the string representation of ConflictChoice
+func (c ConflictChoice) String() string {
+	return [...]string{"Unresolved", "Local", "Cloud", "Merged"}[c]
+}
+
+// ConflictReport describes a pet saved differently on two devices, so the
+// player can see what diverged and how it was settled
+type ConflictReport struct {
+	PetID        types.PetID
+	LocalUpdated time.Time    // When the local copy was last updated
+	CloudUpdated time.Time    // When the cloud copy was last updated
+	Diff         core.PetDiff // From the local copy to the cloud copy
+	Kept         ConflictChoice
+}
+
+// Settled returns true if the conflict was resolved during the sync
+func (r ConflictReport) Settled() bool {
+	return r.Kept != KeepNeither
+}
+
+// MergePets combines two copies of a pet that changed on different
+// devices. The copy updated last supplies the pet's current state, such as
+// its vitals, name and location; progress that only ever grows is kept
+// from whichever copy got further: skill levels, interaction counts, play
+// time and tags. The copies are modified; the merged pet is returned.
+func MergePets(local, cloud *core.DigitalPet) *core.DigitalPet {
+	merged, other := local, cloud
+	if cloud.LastUpdateAt.After(local.LastUpdateAt) {
+		merged, other = cloud, local
+	}
+
+	if other.Skills != nil {
+		if merged.Skills == nil || merged.Skills.Levels == nil {
+			merged.Skills = other.Skills
+		} else {
+			for skill, level := range other.Skills.Levels {
+				merged.Skills.Levels[skill] = max(merged.Skills.Levels[skill], level)
+			}
+		}
+	}
+	merged.TotalInteractions = max(merged.TotalInteractions, other.TotalInteractions)
+	merged.TotalPlayTime = max(merged.TotalPlayTime, other.TotalPlayTime)
+	for _, tag := range other.Tags {
+		if !merged.HasTag(tag) {
+			merged.Tags = append(merged.Tags, tag)
+		}
+	}
+	return merged
+}
+
+// settle settles a pet that changed on both sides the way Resolution says
+// and reports what diverged. An unsettled conflict returns ErrConflict.
+// (must be called with lock held)
+func (m *CloudSyncManager) settle(ctx context.Context, id types.PetID, local, remote []byte, modified time.Time) (SyncAction, *ConflictReport, error) {
+	localPet, err := DecodePet(id, local)
+	if err != nil {
+		return SyncUnchanged, nil, err
+	}
+	cloudPet, err := DecodePet(id, remote)
+	if err != nil {
+		return SyncUnchanged, nil, fmt.Errorf("cloud copy of %w", err)
+	}
+	report := &ConflictReport{
+		PetID:        id,
+		LocalUpdated: localPet.LastUpdateAt,
+		CloudUpdated: cloudPet.LastUpdateAt,
+		Diff:         core.DiffPets(localPet, cloudPet),
+	}
+	report.Kept = m.choose(*report)
+
+	switch report.Kept {
+	case KeepLocal:
+		return SyncUploaded, report, m.upload(ctx, id, local)
+	case KeepCloud:
+		return SyncDownloaded, report, m.download(ctx, id, remote, modified)
+	case KeepMerged:
+		merged, err := MergePets(localPet, cloudPet).Save()
+		if err != nil {
+			return SyncUnchanged, report, err
+		}
+		if err := m.Data.WritePet(ctx, id, merged); err != nil {
+			return SyncUnchanged, report, err
+		}
+		m.Data.Audit.Record(AuditEntry{Actor: ActorSync, Action: AuditSync, PetID: id, Summary: "merged with the synced copy"})
+		return SyncMerged, report, m.upload(ctx, id, merged)
+	}
+	return SyncUnchanged, report, fmt.Errorf("%w: %s", ErrConflict, id)
+}
+
+// choose picks the copy to keep for a conflict
+func (m *CloudSyncManager) choose(report ConflictReport) ConflictChoice {
+	switch m.Resolution {
+	case ConflictLastWriteWins:
+		if report.CloudUpdated.After(report.LocalUpdated) {
+			return KeepCloud
+		}
+		return KeepLocal
+	case ConflictPreferLocal:
+		return KeepLocal
+	case ConflictPreferCloud:
+		return KeepCloud
+	case ConflictMerge:
+		return KeepMerged
+	}
+	if m.Ask != nil {
+		return m.Ask(report)
+	}
+	return KeepNeither
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// divergedPet saves a pet changed differently on a laptop and a phone,
+// the phone's change coming last, and returns the phone's sync manager
+func divergedPet(t *testing.T) (*core.DigitalPet, *DataManager, *CloudSyncManager) {
+	ctx := context.Background()
+	provider := NewMemoryProvider()
+	laptop, laptopSync := newDevice(t, provider)
+	phone, phoneSync := newDevice(t, provider)
+
+	pet := core.NewDigitalPet("Mochi", "owner")
+	laptop.SavePet(ctx, pet)
+	laptopSync.SyncAll(ctx)
+	phoneSync.SyncAll(ctx)
+
+	laptopPet, _ := laptop.LoadPet(ctx, pet.ID)
+	laptopPet.Name = "Laptop Mochi"
+	laptopPet.Skills.Levels[ai.SkillAgility] = 0.6
+	laptopPet.LastUpdateAt = pet.LastUpdateAt.Add(time.Minute)
+	laptop.SavePet(ctx, laptopPet)
+	laptopSync.SyncAll(ctx)
+
+	phonePet, _ := phone.LoadPet(ctx, pet.ID)
+	phonePet.Name = "Phone Mochi"
+	phonePet.Skills.Levels[ai.SkillObedience] = 0.4
+	phonePet.LastUpdateAt = pet.LastUpdateAt.Add(2 * time.Minute)
+	phone.SavePet(ctx, phonePet)
+	return pet, phone, phoneSync
+}
+
+func TestConflictResolutions(t *testing.T) {
+	tests := []struct {
+		resolution ConflictResolution
+		kept       ConflictChoice
+		name       string
+	}{
+		{ConflictLastWriteWins, KeepLocal, "Phone Mochi"},
+		{ConflictPreferLocal, KeepLocal, "Phone Mochi"},
+		{ConflictPreferCloud, KeepCloud, "Laptop Mochi"},
+		{ConflictMerge, KeepMerged, "Phone Mochi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.resolution.String(), func(t *testing.T) {
+			pet, phone, phoneSync := divergedPet(t)
+			phoneSync.Resolution = tt.resolution
+
+			report, err := phoneSync.SyncAll(context.Background())
+			if err != nil || len(report.Conflicts) != 0 || len(report.ConflictReports) != 1 {
+				t.Fatalf("Expected the conflict settled, got %+v (%v)", report, err)
+			}
+			conflict := report.ConflictReports[0]
+			if conflict.Kept != tt.kept || len(conflict.Diff.Section(core.DiffSectionSkills)) != 2 {
+				t.Errorf("Expected %s kept with both skill changes shown, got %s: %v", tt.kept, conflict.Kept, conflict.Diff)
+			}
+			if loaded, _ := phone.LoadPet(context.Background(), pet.ID); loaded.Name != tt.name {
+				t.Errorf("Expected %s, got %s", tt.name, loaded.Name)
+			}
+			if action, err := phoneSync.SyncPet(context.Background(), pet.ID); err != nil || action != SyncUnchanged {
+				t.Errorf("Expected both sides in step afterwards, got %v (%v)", action, err)
+			}
+		})
+	}
+}
+
+func TestConflictMergeKeepsProgress(t *testing.T) {
+	pet, phone, phoneSync := divergedPet(t)
+	phoneSync.Resolution = ConflictMerge
+	if report, err := phoneSync.SyncAll(context.Background()); err != nil || len(report.Merged) != 1 {
+		t.Fatalf("Expected the pet merged, got %+v (%v)", report, err)
+	}
+	merged, _ := phone.LoadPet(context.Background(), pet.ID)
+	if merged.Skills.Level(ai.SkillAgility) != 0.6 || merged.Skills.Level(ai.SkillObedience) != 0.4 {
+		t.Errorf("Expected the training from both devices kept, got %v", merged.Skills.Levels)
+	}
+}
+
+func TestConflictAskUser(t *testing.T) {
+	pet, phone, phoneSync := divergedPet(t)
+	var asked []ConflictReport
+	phoneSync.Ask = func(report ConflictReport) ConflictChoice {
+		asked = append(asked, report)
+		return KeepNeither
+	}
+
+	report, err := phoneSync.SyncAll(context.Background())
+	if !errors.Is(err, ErrConflict) || len(asked) != 1 || asked[0].PetID != pet.ID || report.ConflictReports[0].Settled() {
+		t.Fatalf("Expected the player asked and the conflict left, got %+v (%v)", report, err)
+	}
+	if !asked[0].CloudUpdated.Before(asked[0].LocalUpdated) {
+		t.Errorf("Expected the update times of both copies, got %+v", asked[0])
+	}
+
+	phoneSync.Ask = func(ConflictReport) ConflictChoice { return KeepCloud }
+	if _, err := phoneSync.SyncAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ := phone.LoadPet(context.Background(), pet.ID); loaded.Name != "Laptop Mochi" {
+		t.Errorf("Expected the player's choice kept, got %s", loaded.Name)
+	}
+}
+
+func TestParseConflictResolution(t *testing.T) {
+	if r, err := ParseConflictResolution("Last-Write-Wins"); err != nil || r != ConflictLastWriteWins {
+		t.Errorf("Expected last-write-wins, got %v (%v)", r, err)
+	}
+	if _, err := ParseConflictResolution("coin-toss"); err == nil {
+		t.Error("Expected an unknown resolution to be refused")
+	}
+}
@@ -4,9 +4,9 @@
 // This package provides:
 //   - Local save/load functionality
 //   - Cloud synchronization services
-//   - Data encryption for security
+//   - AES-256-GCM save encryption with passphrase-derived keys
 //   - Cache management for performance
-//   - Backup and recovery systems
+//   - Backup and recovery systems, with offsite copies in cloud storage
 //   - Data migration utilities
 //
 // The data systems ensure that pet state is preserved across sessions
@@ -0,0 +1,196 @@
+package data
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Save encryption settings
+const (
+	KeyFileName   = "encryption.key" // Passphrase check kept beside the saves
+	KeyIterations = 200000           // PBKDF2-SHA256 rounds deriving a key from a passphrase
+	KeySize       = 32               // AES-256
+	SaltSize      = 16
+)
+
+var (
+	// ErrEncryptedSave is returned when an encrypted save is read without a
+	// passphrase
+	ErrEncryptedSave = errors.New("save is encrypted")
+	// ErrWrongKey is returned when a passphrase does not open a save
+	ErrWrongKey = errors.New("wrong save passphrase")
+)
+
+// encryptedMagic starts every encrypted save, ahead of its salt and nonce
+var encryptedMagic = []byte("GOCHI-AES1\n")
+
+// keyCheck is sealed into the key file so a wrong passphrase is noticed
+// before any save is read or written with it
+var keyCheck = []byte("gochi save key")
+
+// IsEncrypted returns true if a save file holds an encrypted payload
+func IsEncrypted(raw []byte) bool {
+	return bytes.HasPrefix(raw, encryptedMagic)
+}
+
+// DeriveKey stretches a passphrase into a save key with PBKDF2-SHA256
+func DeriveKey(passphrase string, salt []byte) []byte {
+	return pbkdf2(passphrase, salt, KeyIterations)
+}
+
+// pbkdf2 computes the first PBKDF2-HMAC-SHA256 block, which is exactly one
+// key since SHA-256 yields KeySize bytes
+func pbkdf2(passphrase string, salt []byte, iterations int) []byte {
+	mac := hmac.New(sha256.New, []byte(passphrase))
+	mac.Write(salt)
+	binary.Write(mac, binary.BigEndian, uint32(1))
+	u := mac.Sum(nil)
+	key := append([]byte(nil), u...)
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range key {
+			key[j] ^= u[j]
+		}
+	}
+	return key
+}
+
+// SaveCipher encrypts saves with AES-256-GCM under keys derived from one
+// passphrase. Every save carries the salt its key was derived with, so
+// saves copied from another device or restored from a backup open with the
+// same passphrase.
+type SaveCipher struct {
+	mu         sync.Mutex
+	passphrase string
+	salt       []byte            // Salt new saves are written with
+	keys       map[string][]byte // Derived keys by salt
+}
+
+// NewSaveCipher creates a cipher for a passphrase that writes saves with
+// salt, or a random salt if salt is nil
+func NewSaveCipher(passphrase string, salt []byte) (*SaveCipher, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("%w: passphrase is empty", ErrWrongKey)
+	}
+	if salt == nil {
+		salt = make([]byte, SaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+	}
+	if len(salt) != SaltSize {
+		return nil, fmt.Errorf("salt must be %d bytes, got %d", SaltSize, len(salt))
+	}
+	return &SaveCipher{passphrase: passphrase, salt: salt, keys: make(map[string][]byte)}, nil
+}
+
+// UnlockSaves returns the cipher a passphrase gives for a save directory.
+// The directory's key file holds a check value sealed with the passphrase;
+// it is created on first use, and a passphrase that does not open it
+// returns ErrWrongKey.
+func UnlockSaves(storage StorageBackend, savePath, passphrase string) (*SaveCipher, error) {
+	path := filepath.Join(savePath, KeyFileName)
+	file, err := storage.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		c, err := NewSaveCipher(passphrase, nil)
+		if err != nil {
+			return nil, err
+		}
+		check, err := c.Seal(keyCheck)
+		if err != nil {
+			return nil, err
+		}
+		if err := storage.MkdirAll(savePath); err != nil {
+			return nil, err
+		}
+		return c, storage.WriteFile(path, check)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsEncrypted(file) || len(file) < len(encryptedMagic)+SaltSize {
+		return nil, fmt.Errorf("%w: %s is damaged", ErrCorruptSave, KeyFileName)
+	}
+	salt := file[len(encryptedMagic) : len(encryptedMagic)+SaltSize]
+	c, err := NewSaveCipher(passphrase, append([]byte(nil), salt...))
+	if err != nil {
+		return nil, err
+	}
+	if check, err := c.Open(file); err != nil || !bytes.Equal(check, keyCheck) {
+		return nil, ErrWrongKey
+	}
+	return c, nil
+}
+
+// key returns the key for a salt, deriving it on first use
+func (c *SaveCipher) key(salt []byte) []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key, ok := c.keys[string(salt)]
+	if !ok {
+		key = DeriveKey(c.passphrase, salt)
+		c.keys[string(salt)] = key
+	}
+	return key
+}
+
+// Seal encrypts a payload under a fresh nonce
+func (c *SaveCipher) Seal(payload []byte) ([]byte, error) {
+	aead, err := newAEAD(c.key(c.salt))
+	if err != nil {
+		return nil, err
+	}
+	header := len(encryptedMagic) + SaltSize + aead.NonceSize()
+	out := make([]byte, header, header+len(payload)+aead.Overhead())
+	copy(out, encryptedMagic)
+	copy(out[len(encryptedMagic):], c.salt)
+	nonce := out[len(encryptedMagic)+SaltSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(out, nonce, payload, encryptedMagic), nil
+}
+
+// Open decrypts a sealed payload, returning ErrWrongKey when the
+// passphrase does not fit or the data was altered
+func (c *SaveCipher) Open(raw []byte) ([]byte, error) {
+	sealed := raw[len(encryptedMagic):]
+	if len(sealed) < SaltSize {
+		return nil, fmt.Errorf("%w: encrypted save is truncated", ErrCorruptSave)
+	}
+	aead, err := newAEAD(c.key(sealed[:SaltSize]))
+	if err != nil {
+		return nil, err
+	}
+	sealed = sealed[SaltSize:]
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: encrypted save is truncated", ErrCorruptSave)
+	}
+	payload, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], encryptedMagic)
+	if err != nil {
+		return nil, ErrWrongKey
+	}
+	return payload, nil
+}
+
+// newAEAD returns the AES-GCM cipher for a save key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestEncryptedSavesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := core.NewDigitalPet("Plain", "owner")
+	if err := dm.SavePet(ctx, plain); err != nil {
+		t.Fatal(err)
+	}
+
+	if dm.Cipher, err = UnlockSaves(DiskBackend{}, dm.SavePath, "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	dm.Compress = true
+	secret := core.NewDigitalPet("Secret", "owner")
+	if err := dm.SavePet(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(dm.petPath(secret.ID))
+	if !IsEncrypted(raw) || bytes.Contains(raw, []byte("Secret")) {
+		t.Fatalf("Expected the save written encrypted, got %q", raw)
+	}
+
+	pets, err := dm.LoadAll(ctx)
+	if err != nil || len(pets) != 2 {
+		t.Fatalf("Expected plain and encrypted pets to load, got %d (%v)", len(pets), err)
+	}
+	if _, err := ReadPetFile(dm.petPath(secret.ID)); !errors.Is(err, ErrEncryptedSave) {
+		t.Errorf("Expected ErrEncryptedSave without a passphrase, got %v", err)
+	}
+
+	// A copy on another device opens with the same passphrase even though
+	// that device's key file has a different salt
+	other, _ := NewDataManager(t.TempDir())
+	if other.Cipher, err = UnlockSaves(DiskBackend{}, other.SavePath, "correct horse"); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(other.petPath(secret.ID), raw, 0o644)
+	if _, err := other.LoadPet(ctx, secret.ID); err != nil {
+		t.Errorf("Expected the copied save to open, got %v", err)
+	}
+
+	other.Cipher, _ = NewSaveCipher("wrong", nil)
+	if _, err := other.LoadPet(ctx, secret.ID); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("Expected ErrWrongKey, got %v", err)
+	}
+}
+
+func TestUnlockSavesChecksPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := UnlockSaves(DiskBackend{}, dir, ""); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("Expected an empty passphrase refused, got %v", err)
+	}
+	first, err := UnlockSaves(DiskBackend{}, dir, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, KeyFileName)); err != nil {
+		t.Fatalf("Expected the key file created, got %v", err)
+	}
+	again, err := UnlockSaves(DiskBackend{}, dir, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first.salt, again.salt) {
+		t.Error("Expected the salt kept between runs")
+	}
+	if _, err := UnlockSaves(DiskBackend{}, dir, "hunter3"); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("Expected ErrWrongKey, got %v", err)
+	}
+}
+
+func TestCompactEncryptsSaves(t *testing.T) {
+	ctx := context.Background()
+	dm, _ := NewDataManager(t.TempDir())
+	pet := core.NewDigitalPet("Rex", "owner")
+	if err := dm.SavePet(ctx, pet); err != nil {
+		t.Fatal(err)
+	}
+	dm.Cipher, _ = NewSaveCipher("passphrase", nil)
+	if _, err := dm.Compact(ctx, core.DefaultRetentionPolicy()); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(dm.petPath(pet.ID))
+	if !IsEncrypted(raw) {
+		t.Fatal("Expected compaction to encrypt the plain save")
+	}
+	inner, err := dm.unseal(raw)
+	if err != nil || !IsCompressed(inner) {
+		t.Errorf("Expected a compressed payload inside, got %v", err)
+	}
+}
+
+func TestPBKDF2Vectors(t *testing.T) {
+	vectors := []struct {
+		iterations int
+		want       string
+	}{
+		{1, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{2, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{4096, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+	}
+	for _, v := range vectors {
+		if got := hex.EncodeToString(pbkdf2("password", []byte("salt"), v.iterations)); got != v.want {
+			t.Errorf("%d iterations: expected %s, got %s", v.iterations, v.want, got)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// HouseholdFileName is the save beside the pet saves holding the rest of
+// the household. Its extension keeps it out of the pet listings.
+const HouseholdFileName = "household.state"
+
+// WriteHousehold atomically replaces the household save with an already
+// serialized payload, compressed and encrypted like the pet saves
+func (dm *DataManager) WriteHousehold(ctx context.Context, payload []byte) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	raw, err := dm.encode(payload)
+	if err != nil {
+		return err
+	}
+	path := dm.householdPath()
+	temp := path + tempExt
+	if err := dm.storage().WriteFile(temp, raw); err != nil {
+		return err
+	}
+	return dm.storage().Rename(temp, path)
+}
+
+// ReadHousehold returns the household save's payload, or nil if the
+// household has not been saved yet
+func (dm *DataManager) ReadHousehold() ([]byte, error) {
+	payload, err := dm.readSave(dm.householdPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return payload, err
+}
+
+// householdPath returns the household save file
+func (dm *DataManager) householdPath() string {
+	return filepath.Join(dm.SavePath, HouseholdFileName)
+}
@@ -0,0 +1,34 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestHouseholdSave(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload, err := dm.ReadHousehold(); err != nil || payload != nil {
+		t.Fatalf("Expected no household save yet, got %q (%v)", payload, err)
+	}
+
+	dm.Compress = true
+	if err := dm.SavePet(ctx, core.NewDigitalPet("Rex", "owner")); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.WriteHousehold(ctx, []byte(`{"fund":12}`)); err != nil {
+		t.Fatalf("WriteHousehold failed: %v", err)
+	}
+	payload, err := dm.ReadHousehold()
+	if err != nil || string(payload) != `{"fund":12}` {
+		t.Errorf("Expected the household payload back, got %q (%v)", payload, err)
+	}
+	if ids, _ := dm.ListPets(ctx, FilterAll); len(ids) != 1 {
+		t.Errorf("Expected the household save to stay out of the pet listing, got %v", ids)
+	}
+}
@@ -0,0 +1,227 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrCloudUnauthorized is returned when a cloud server rejects the
+	// configured credentials. Retrying will not help.
+	ErrCloudUnauthorized = errors.New("cloud storage rejected the credentials")
+	// errMissingCollection is returned by a WebDAV PUT into a collection
+	// that does not exist yet
+	errMissingCollection = errors.New("collection does not exist")
+)
+
+// HTTPProvider is a CloudProvider for self-hosted servers. Objects are
+// files under BaseURL, read with GET and HEAD and written with PUT. With
+// WebDAV set, objects are listed with PROPFIND and a missing collection is
+// created with MKCOL, which suits Nextcloud and other WebDAV servers;
+// without it the server must answer a GET of BaseURL with one object name
+// per line. Modification times come from the Last-Modified header, so they
+// have a resolution of one second.
+type HTTPProvider struct {
+	BaseURL  string // Collection holding the objects
+	WebDAV   bool
+	Username string // Basic auth user; empty with Token set sends a bearer token
+	Password string
+	Token    string
+	Client   *http.Client // Defaults to http.DefaultClient
+}
+
+// NewWebDAVProvider creates a provider for a WebDAV collection
+func NewWebDAVProvider(baseURL, username, password string) *HTTPProvider {
+	return &HTTPProvider{BaseURL: baseURL, WebDAV: true, Username: username, Password: password}
+}
+
+// NewHTTPProvider creates a provider for a plain HTTP server
+func NewHTTPProvider(baseURL, token string) *HTTPProvider {
+	return &HTTPProvider{BaseURL: baseURL, Token: token}
+}
+
+// List returns the names of every object in sorted order
+func (p *HTTPProvider) List(ctx context.Context) ([]string, error) {
+	if !p.WebDAV {
+		body, _, err := p.do(ctx, http.MethodGet, p.collectionURL(), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, line := range strings.Split(string(body), "\n") {
+			if name := strings.TrimSpace(line); name != "" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+
+	body, _, err := p.do(ctx, "PROPFIND", p.collectionURL(), []byte(propfindBody), map[string]string{
+		"Depth":        "1",
+		"Content-Type": "application/xml",
+	})
+	if errors.Is(err, ErrObjectNotFound) {
+		return nil, nil // Nothing uploaded yet
+	}
+	if err != nil {
+		return nil, err
+	}
+	var status multistatus
+	if err := xml.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("%w: malformed PROPFIND response: %v", ErrCloudUnavailable, err)
+	}
+
+	var names []string
+	for _, response := range status.Responses {
+		if response.isCollection() {
+			continue
+		}
+		href, err := url.PathUnescape(response.Href)
+		if err != nil {
+			continue
+		}
+		names = append(names, path.Base(href))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Upload stores payload under name, creating the collection if the server
+// needs it
+func (p *HTTPProvider) Upload(ctx context.Context, name string, payload []byte) error {
+	_, _, err := p.do(ctx, http.MethodPut, p.objectURL(name), payload, nil)
+	if p.WebDAV && errors.Is(err, errMissingCollection) {
+		if _, _, err := p.do(ctx, "MKCOL", p.collectionURL(), nil, nil); err != nil {
+			return err
+		}
+		_, _, err = p.do(ctx, http.MethodPut, p.objectURL(name), payload, nil)
+	}
+	return err
+}
+
+// Download returns the object stored under name
+func (p *HTTPProvider) Download(ctx context.Context, name string) ([]byte, error) {
+	body, _, err := p.do(ctx, http.MethodGet, p.objectURL(name), nil, nil)
+	return body, err
+}
+
+// GetLastModified returns when the object stored under name last changed
+func (p *HTTPProvider) GetLastModified(ctx context.Context, name string) (time.Time, error) {
+	_, header, err := p.do(ctx, http.MethodHead, p.objectURL(name), nil, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	modified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s has no valid Last-Modified header", ErrCloudUnavailable, name)
+	}
+	return modified, nil
+}
+
+// Delete removes the object stored under name
+func (p *HTTPProvider) Delete(ctx context.Context, name string) error {
+	_, _, err := p.do(ctx, http.MethodDelete, p.objectURL(name), nil, nil)
+	return err
+}
+
+// do sends a request and maps the response status onto the provider
+// errors: 404 is ErrObjectNotFound, 401 and 403 are ErrCloudUnauthorized,
+// and network failures, 429 and 5xx are ErrCloudUnavailable
+func (p *HTTPProvider) do(ctx context.Context, method, target string, body []byte, headers map[string]string) ([]byte, http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	switch {
+	case p.Username != "":
+		req.SetBasicAuth(p.Username, p.Password)
+	case p.Token != "":
+		req.Header.Set("Authorization", "Bearer "+p.Token)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrCloudUnavailable, err)
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrCloudUnavailable, err)
+	}
+
+	switch {
+	case resp.StatusCode < 300:
+		return content, resp.Header, nil
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil, fmt.Errorf("%w: %s", ErrObjectNotFound, target)
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return nil, nil, fmt.Errorf("%w: %s", ErrCloudUnauthorized, resp.Status)
+	case resp.StatusCode == http.StatusConflict && method == http.MethodPut && p.WebDAV:
+		return nil, nil, errMissingCollection
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return nil, nil, fmt.Errorf("%w: %s %s: %s", ErrCloudUnavailable, method, target, resp.Status)
+	}
+	if reason := strings.TrimSpace(string(content)); reason != "" {
+		return nil, nil, fmt.Errorf("%s %s: %s: %s", method, target, resp.Status, reason)
+	}
+	return nil, nil, fmt.Errorf("%s %s: %s", method, target, resp.Status)
+}
+
+// collectionURL returns the URL of the collection, ending in a slash
+func (p *HTTPProvider) collectionURL() string {
+	return strings.TrimSuffix(p.BaseURL, "/") + "/"
+}
+
+// objectURL returns the URL of an object
+func (p *HTTPProvider) objectURL(name string) string {
+	return p.collectionURL() + url.PathEscape(name)
+}
+
+// propfindBody asks a WebDAV server only for what List needs
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/></d:prop></d:propfind>`
+
+// multistatus is the part of a PROPFIND response List reads
+type multistatus struct {
+	Responses []davResponse `xml:"DAV: response"`
+}
+
+// davResponse describes one resource in a PROPFIND response
+type davResponse struct {
+	Href       string    `xml:"DAV: href"`
+	Collection *struct{} `xml:"DAV: propstat>prop>resourcetype>collection"`
+}
+
+// isCollection returns true if the resource is a collection rather than
+// an object
+func (r davResponse) isCollection() bool {
+	return r.Collection != nil || strings.HasSuffix(r.Href, "/")
+}
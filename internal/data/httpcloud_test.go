@@ -0,0 +1,221 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// davFile is one file held by davServer
+type davFile struct {
+	content  []byte
+	modified time.Time
+}
+
+// davServer is a minimal in-memory WebDAV server. Every write advances its
+// clock by a second so Last-Modified always changes.
+type davServer struct {
+	mu          sync.Mutex
+	user, pass  string
+	token       string
+	files       map[string]davFile
+	collections map[string]bool
+	clock       time.Time
+}
+
+func newDAVServer(t *testing.T) (*davServer, *httptest.Server) {
+	dav := &davServer{
+		user:        "alice",
+		pass:        "secret",
+		token:       "token",
+		files:       make(map[string]davFile),
+		collections: map[string]bool{"/": true},
+		clock:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	server := httptest.NewServer(dav)
+	t.Cleanup(server.Close)
+	return dav, server
+}
+
+func (d *davServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, basic := r.BasicAuth()
+	if !(basic && user == d.user && pass == d.pass) && r.Header.Get("Authorization") != "Bearer "+d.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	name := r.URL.Path
+	switch r.Method {
+	case "MKCOL":
+		if !d.collections[parentOf(name)] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		d.collections[strings.TrimSuffix(name, "/")+"/"] = true
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodPut:
+		if !d.collections[parentOf(name)] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		d.clock = d.clock.Add(time.Second)
+		d.files[name] = davFile{content: body, modified: d.clock}
+		w.WriteHeader(http.StatusCreated)
+	case "PROPFIND":
+		if !d.collections[name] {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprintf(w, `<?xml version="1.0"?><d:multistatus xmlns:d="DAV:">`)
+		fmt.Fprintf(w, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:resourcetype><d:collection/></d:resourcetype></d:prop></d:propstat></d:response>`, name)
+		for _, file := range d.list(name) {
+			fmt.Fprintf(w, `<d:response><d:href>%s</d:href><d:propstat><d:prop><d:resourcetype/></d:prop></d:propstat></d:response>`, (&url.URL{Path: file}).EscapedPath())
+		}
+		fmt.Fprint(w, `</d:multistatus>`)
+	case http.MethodGet, http.MethodHead:
+		if d.collections[name] {
+			for _, file := range d.list(name) {
+				fmt.Fprintln(w, path.Base(file))
+			}
+			return
+		}
+		file, exists := d.files[name]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Last-Modified", file.modified.Format(http.TimeFormat))
+		w.Write(file.content)
+	case http.MethodDelete:
+		if _, exists := d.files[name]; !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(d.files, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// list returns the files directly inside a collection (must be called with
+// lock held)
+func (d *davServer) list(collection string) []string {
+	var files []string
+	for name := range d.files {
+		if parentOf(name) == collection {
+			files = append(files, name)
+		}
+	}
+	return files
+}
+
+func TestWebDAVProvider(t *testing.T) {
+	ctx := context.Background()
+	_, server := newDAVServer(t)
+	p := NewWebDAVProvider(server.URL+"/gochi/alice", "alice", "secret")
+
+	if names, err := p.List(ctx); err != nil || len(names) != 0 {
+		t.Fatalf("Expected an empty listing before any upload, got %v (%v)", names, err)
+	}
+	if err := p.Upload(ctx, "b%2F1.json", []byte("two")); err == nil {
+		t.Fatal("Expected the upload to fail without a parent collection")
+	}
+
+	// The account's collection is created on demand, but not its parent
+	p.BaseURL = server.URL + "/alice"
+	if err := p.Upload(ctx, "b%2F1.json", []byte("two")); err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	p.Upload(ctx, "a.json", []byte("one"))
+
+	names, err := p.List(ctx)
+	if err != nil || len(names) != 2 || names[0] != "a.json" || names[1] != "b%2F1.json" {
+		t.Errorf("Expected [a.json b%%2F1.json], got %v (%v)", names, err)
+	}
+	if payload, err := p.Download(ctx, "b%2F1.json"); err != nil || string(payload) != "two" {
+		t.Errorf("Expected to download \"two\", got %q (%v)", payload, err)
+	}
+	first, err := p.GetLastModified(ctx, "b%2F1.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Upload(ctx, "b%2F1.json", []byte("three"))
+	if second, _ := p.GetLastModified(ctx, "b%2F1.json"); !second.After(first) {
+		t.Errorf("Expected a later modification time, got %v then %v", first, second)
+	}
+	if _, err := p.Download(ctx, "missing.json"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected ErrObjectNotFound, got %v", err)
+	}
+
+	p.Password = "wrong"
+	if _, err := p.List(ctx); !errors.Is(err, ErrCloudUnauthorized) || IsTemporary(err) {
+		t.Errorf("Expected a permanent ErrCloudUnauthorized, got %v", err)
+	}
+	server.Close()
+	if _, err := p.Download(ctx, "a.json"); !IsTemporary(err) {
+		t.Errorf("Expected a temporary error once the server is gone, got %v", err)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	ctx := context.Background()
+	dav, server := newDAVServer(t)
+	dav.collections["/plain/"] = true
+	p := NewHTTPProvider(server.URL+"/plain", "token")
+
+	if err := p.Upload(ctx, "a.json", []byte("one")); err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	if names, err := p.List(ctx); err != nil || len(names) != 1 || names[0] != "a.json" {
+		t.Errorf("Expected [a.json], got %v (%v)", names, err)
+	}
+	if _, err := p.GetLastModified(ctx, "a.json"); err != nil {
+		t.Errorf("GetLastModified failed: %v", err)
+	}
+	if err := p.Delete(ctx, "a.json"); err != nil {
+		t.Errorf("Delete failed: %v", err)
+	}
+	if err := p.Delete(ctx, "a.json"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected ErrObjectNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestSyncOverWebDAV(t *testing.T) {
+	ctx := context.Background()
+	_, server := newDAVServer(t)
+	laptop, laptopSync := newDevice(t, NewWebDAVProvider(server.URL+"/alice", "alice", "secret"))
+	phone, phoneSync := newDevice(t, NewWebDAVProvider(server.URL+"/alice", "alice", "secret"))
+
+	pet := core.NewDigitalPet("Mochi/2", "owner")
+	laptop.SavePet(ctx, pet)
+	if report, err := laptopSync.SyncAll(ctx); err != nil || len(report.Uploaded) != 1 {
+		t.Fatalf("Expected one upload, got %+v (%v)", report, err)
+	}
+	if report, err := phoneSync.SyncAll(ctx); err != nil || len(report.Downloaded) != 1 {
+		t.Fatalf("Expected one download, got %+v (%v)", report, err)
+	}
+	if loaded, err := phone.LoadPet(ctx, pet.ID); err != nil || loaded.Name != pet.Name {
+		t.Errorf("Expected %s on the phone, got %v", pet.Name, err)
+	}
+}
+
+// parentOf returns the collection holding a file or collection
+func parentOf(name string) string {
+	return strings.TrimSuffix(path.Dir(strings.TrimSuffix(name, "/")), "/") + "/"
+}
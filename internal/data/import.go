@@ -0,0 +1,429 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Classic save conversion
+const (
+	ClassicDaysPerYear = 1.5  // Gochi days per year of a classic pet's age
+	ClassicMaxHearts   = 4    // Hearts on a full classic hunger or happy meter
+	ClassicMistakeCost = 0.1  // Health lost per recorded care mistake
+	ClassicMinHealth   = 0.2  // Health an imported pet keeps however badly it was cared for
+	ClassicMinWeight   = 5.0  // Lightest classic weight in grams
+	ClassicMaxWeight   = 99.0 // Heaviest classic weight in grams
+)
+
+var (
+	// ErrUnknownFormat is returned when no importer recognises a file
+	ErrUnknownFormat = errors.New("unrecognised save format")
+	// ErrInvalidImport is returned when a file is in a known format but
+	// its contents cannot be converted
+	ErrInvalidImport = errors.New("invalid import")
+)
+
+// Importer converts another pet game's save into Gochi pets
+type Importer interface {
+	// Name identifies the format, for choosing it explicitly
+	Name() string
+	// Detect returns true if payload looks like this format
+	Detect(payload []byte) bool
+	// Import reads every pet in payload
+	Import(payload []byte) ([]PetDescriptor, error)
+}
+
+// importers are tried in order when detecting a format
+var (
+	importersMu sync.RWMutex
+	importers   = []Importer{ClassicImporter{}, DescriptorImporter{}, CSVImporter{}}
+)
+
+// RegisterImporter adds an importer, replacing one with the same name.
+// New formats are detected after the built-in ones.
+func RegisterImporter(importer Importer) {
+	importersMu.Lock()
+	defer importersMu.Unlock()
+	for i, existing := range importers {
+		if existing.Name() == importer.Name() {
+			importers[i] = importer
+			return
+		}
+	}
+	importers = append(importers, importer)
+}
+
+// ImportFormats returns the names of every registered format
+func ImportFormats() []string {
+	importersMu.RLock()
+	defer importersMu.RUnlock()
+	return namesOf(importers)
+}
+
+// ImportPets converts a save in the named format, or detects the format if
+// format is empty, and creates a pet for everything in it. Returns the
+// format used.
+func ImportPets(payload []byte, format string, owner types.UserID) ([]*core.DigitalPet, string, error) {
+	importer, err := findImporter(payload, format)
+	if err != nil {
+		return nil, "", err
+	}
+	descriptors, err := importer.Import(payload)
+	if err != nil {
+		return nil, importer.Name(), err
+	}
+	if len(descriptors) == 0 {
+		return nil, importer.Name(), fmt.Errorf("%w: no pets found", ErrInvalidImport)
+	}
+
+	pets := make([]*core.DigitalPet, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		pet, err := descriptor.Pet(owner)
+		if err != nil {
+			return nil, importer.Name(), err
+		}
+		pets = append(pets, pet)
+	}
+	return pets, importer.Name(), nil
+}
+
+// findImporter returns the importer for a format name, or the first one
+// that recognises payload
+func findImporter(payload []byte, format string) (Importer, error) {
+	importersMu.RLock()
+	defer importersMu.RUnlock()
+	for _, importer := range importers {
+		if format == "" && importer.Detect(payload) || strings.EqualFold(format, importer.Name()) {
+			return importer, nil
+		}
+	}
+	if format != "" {
+		return nil, fmt.Errorf("%w: %s (known formats: %s)", ErrUnknownFormat, format, strings.Join(namesOf(importers), ", "))
+	}
+	return nil, ErrUnknownFormat
+}
+
+// namesOf returns the names of importers
+func namesOf(list []Importer) []string {
+	names := make([]string, 0, len(list))
+	for _, importer := range list {
+		names = append(names, importer.Name())
+	}
+	return names
+}
+
+// PetDescriptor is a format-neutral description of a pet being imported.
+// Vitals and traits range from 0.0 to 1.0; anything not given keeps the
+// value a new pet would have.
+type PetDescriptor struct {
+	Name       string             `json:"name"`
+	AgeDays    float64            `json:"age_days"`
+	Generation int                `json:"generation"`
+	Vitals     map[string]float64 `json:"vitals"` // health, energy, hydration, nutrition, happiness, stress, fatigue, cleanliness
+	Traits     map[string]float64 `json:"traits"` // Any of genetics.TraitNames
+}
+
+// Pet creates the pet a descriptor describes. Its genome is approximated
+// from the traits, since other games do not record genes.
+func (d PetDescriptor) Pet(owner types.UserID) (*core.DigitalPet, error) {
+	name := strings.TrimSpace(d.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: pet has no name", ErrInvalidImport)
+	}
+	if d.AgeDays < 0 || math.IsNaN(d.AgeDays) || d.Generation < 0 {
+		return nil, fmt.Errorf("%w: %s has a negative age or generation", ErrInvalidImport, name)
+	}
+	for trait, value := range d.Traits {
+		if !containsTrait(trait) {
+			return nil, fmt.Errorf("%w: %s has unknown trait %q", ErrInvalidImport, name, trait)
+		}
+		if err := checkUnit(name, trait, value); err != nil {
+			return nil, err
+		}
+	}
+
+	genome := genetics.ApproximateGenome(d.Traits)
+	genome.Generation = d.Generation
+	pet := core.NewDigitalPetFromGenome(name, owner, genome)
+	pet.Biology.Processes.Age = d.AgeDays
+
+	for key, value := range d.Vitals {
+		vital, err := biology.ParseVital(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s has unknown vital %q", ErrInvalidImport, name, key)
+		}
+		if err := checkUnit(name, key, value); err != nil {
+			return nil, err
+		}
+		pet.Biology.Vitals.Set(vital, value)
+	}
+	return pet, nil
+}
+
+// checkUnit reports a value outside 0.0 to 1.0
+func checkUnit(pet, key string, value float64) error {
+	if math.IsNaN(value) || value < 0 || value > 1 {
+		return fmt.Errorf("%w: %s has %s %v, want 0 to 1", ErrInvalidImport, pet, key, value)
+	}
+	return nil
+}
+
+// containsTrait returns true if name is a heritable trait
+func containsTrait(name string) bool {
+	for _, trait := range genetics.TraitNames {
+		if trait == name {
+			return true
+		}
+	}
+	return false
+}
+
+// DescriptorImporter reads PetDescriptors written as JSON: one object, an
+// array of them, or an object whose "pets" field holds the array
+type DescriptorImporter struct{}
+
+// Name returns "json"
+func (DescriptorImporter) Name() string { return "json" }
+
+// Detect returns true for JSON objects and arrays
+func (DescriptorImporter) Detect(payload []byte) bool {
+	trimmed := bytes.TrimSpace(payload)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed)
+}
+
+// Import reads the descriptors
+func (DescriptorImporter) Import(payload []byte) ([]PetDescriptor, error) {
+	trimmed := bytes.TrimSpace(payload)
+	var descriptors []PetDescriptor
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := strictUnmarshal(trimmed, &descriptors); err != nil {
+			return nil, err
+		}
+		return descriptors, nil
+	}
+
+	var wrapped struct {
+		Pets []PetDescriptor `json:"pets"`
+	}
+	if json.Unmarshal(trimmed, &wrapped) == nil && wrapped.Pets != nil {
+		return wrapped.Pets, nil
+	}
+	var single PetDescriptor
+	if err := strictUnmarshal(trimmed, &single); err != nil {
+		return nil, err
+	}
+	return []PetDescriptor{single}, nil
+}
+
+// strictUnmarshal decodes JSON, rejecting unknown fields as likely typos
+func strictUnmarshal(payload []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	return nil
+}
+
+// CSVImporter reads one pet per row of a CSV file whose header names the
+// columns: name, age_days and generation, any vital, and any trait
+type CSVImporter struct{}
+
+// Name returns "csv"
+func (CSVImporter) Name() string { return "csv" }
+
+// Detect returns true if the first line is a header with a name column
+func (CSVImporter) Detect(payload []byte) bool {
+	header, err := csv.NewReader(bytes.NewReader(payload)).Read()
+	if err != nil || len(header) < 2 {
+		return false
+	}
+	for _, column := range header {
+		if strings.EqualFold(strings.TrimSpace(column), "name") {
+			return true
+		}
+	}
+	return false
+}
+
+// Import reads every row
+func (CSVImporter) Import(payload []byte) ([]PetDescriptor, error) {
+	reader := csv.NewReader(bytes.NewReader(payload))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	var descriptors []PetDescriptor
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		descriptor := PetDescriptor{Vitals: make(map[string]float64), Traits: make(map[string]float64)}
+		for i, column := range header {
+			value := strings.TrimSpace(row[i])
+			if value == "" {
+				continue
+			}
+			if column == "name" {
+				descriptor.Name = value
+				continue
+			}
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %s %q is not a number", ErrInvalidImport, line, column, value)
+			}
+			switch {
+			case column == "age_days":
+				descriptor.AgeDays = number
+			case column == "generation":
+				descriptor.Generation = int(number)
+			case containsTrait(column):
+				descriptor.Traits[column] = number
+			default:
+				descriptor.Vitals[column] = number
+			}
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors, nil
+}
+
+// ClassicImporter reads the key=value saves of classic egg-style
+// handheld pets, one pet per block separated by a blank line:
+//
+//	name=Mametchi
+//	age=4          # years; one year is one real day on the original
+//	hunger=3       # hearts out of 4
+//	happy=2        # hearts out of 4
+//	discipline=75  # percent
+//	weight=20      # grams
+//	care_mistakes=1
+//	generation=2
+//
+// Hearts become nutrition and happiness, discipline becomes
+// conscientiousness, weight becomes body size and care mistakes cost health.
+type ClassicImporter struct{}
+
+// Name returns "classic"
+func (ClassicImporter) Name() string { return "classic" }
+
+// Detect returns true if the file has both heart meters
+func (ClassicImporter) Detect(payload []byte) bool {
+	blocks, err := classicBlocks(payload)
+	return err == nil && len(blocks) > 0 && blocks[0].has("hunger") && blocks[0].has("happy")
+}
+
+// Import converts every block
+func (ClassicImporter) Import(payload []byte) ([]PetDescriptor, error) {
+	blocks, err := classicBlocks(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors := make([]PetDescriptor, 0, len(blocks))
+	for _, block := range blocks {
+		descriptor := PetDescriptor{
+			Name:   block.values["name"],
+			Vitals: make(map[string]float64),
+			Traits: make(map[string]float64),
+		}
+		numbers := make(map[string]float64)
+		for _, key := range []string{"age", "hunger", "happy", "discipline", "weight", "care_mistakes", "generation"} {
+			if !block.has(key) {
+				continue
+			}
+			number, err := strconv.ParseFloat(block.values[key], 64)
+			if err != nil || number < 0 {
+				return nil, fmt.Errorf("%w: line %d: %s %q is not a positive number", ErrInvalidImport, block.lines[key], key, block.values[key])
+			}
+			numbers[key] = number
+		}
+
+		descriptor.AgeDays = numbers["age"] * ClassicDaysPerYear
+		descriptor.Generation = int(numbers["generation"])
+		if block.has("hunger") {
+			descriptor.Vitals[biology.VitalNutrition.Key()] = math.Min(numbers["hunger"]/ClassicMaxHearts, 1)
+		}
+		if block.has("happy") {
+			descriptor.Vitals[biology.VitalHappiness.Key()] = math.Min(numbers["happy"]/ClassicMaxHearts, 1)
+		}
+		if block.has("care_mistakes") {
+			descriptor.Vitals[biology.VitalHealth.Key()] = math.Max(1-numbers["care_mistakes"]*ClassicMistakeCost, ClassicMinHealth)
+		}
+		if block.has("discipline") {
+			descriptor.Traits["conscientiousness"] = math.Min(numbers["discipline"]/100, 1)
+		}
+		if block.has("weight") {
+			size := (numbers["weight"] - ClassicMinWeight) / (ClassicMaxWeight - ClassicMinWeight)
+			descriptor.Traits["body_size"] = math.Max(0, math.Min(size, 1))
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors, nil
+}
+
+// classicBlock is one pet's keys in a classic save
+type classicBlock struct {
+	values map[string]string
+	lines  map[string]int // Line each key was read from
+}
+
+// has returns true if the block sets key
+func (b classicBlock) has(key string) bool {
+	_, ok := b.values[key]
+	return ok
+}
+
+// classicBlocks splits a classic save into blocks of key=value lines
+func classicBlocks(payload []byte) ([]classicBlock, error) {
+	var blocks []classicBlock
+	current := classicBlock{values: make(map[string]string), lines: make(map[string]int)}
+	flush := func() {
+		if len(current.values) > 0 {
+			blocks = append(blocks, current)
+			current = classicBlock{values: make(map[string]string), lines: make(map[string]int)}
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(payload))
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(text)
+		if text == "" {
+			flush()
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: expected key=value", ErrInvalidImport, line)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		current.values[key] = strings.TrimSpace(value)
+		current.lines[key] = line
+	}
+	flush()
+	return blocks, scanner.Err()
+}
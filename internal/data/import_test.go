@@ -0,0 +1,112 @@
+package data
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+const classicSave = `
+name=Mametchi
+age=4          # years
+hunger=3
+happy=2
+discipline=100
+weight=99
+care_mistakes=3
+generation=2
+
+name=Kuchipatchi
+hunger=4
+happy=4
+`
+
+func TestImportClassic(t *testing.T) {
+	pets, format, err := ImportPets([]byte(classicSave), "", "owner")
+	if err != nil || format != "classic" || len(pets) != 2 {
+		t.Fatalf("Expected two classic pets, got %d %s (%v)", len(pets), format, err)
+	}
+
+	pet := pets[0]
+	v := pet.Biology.Vitals
+	if pet.Name != "Mametchi" || pet.GetAge() != 4*ClassicDaysPerYear || pet.Genome.Generation != 2 {
+		t.Errorf("Expected Mametchi aged %.1f days in generation 2, got %s %.1f %d", 4*ClassicDaysPerYear, pet.Name, pet.GetAge(), pet.Genome.Generation)
+	}
+	if v.Nutrition != 0.75 || v.Happiness != 0.5 || math.Abs(v.Health-0.7) > 1e-9 {
+		t.Errorf("Expected hearts and care mistakes converted, got %+v", v)
+	}
+	if size := pet.Genome.GetTraitValue("body_size"); size < 1-2*0.05 {
+		t.Errorf("Expected a heavy pet to have a large body, got %.2f", size)
+	}
+	if err := ValidatePet(pet); err != nil {
+		t.Errorf("Imported pet should be valid: %v", err)
+	}
+}
+
+func TestImportCSVAndJSON(t *testing.T) {
+	csv := "name,age_days,happiness,playfulness\nPip,12,0.4,0.9\nMochi,2,,\n"
+	pets, format, err := ImportPets([]byte(csv), "", "owner")
+	if err != nil || format != "csv" || len(pets) != 2 {
+		t.Fatalf("Expected two CSV pets, got %d %s (%v)", len(pets), format, err)
+	}
+	if pets[0].GetAge() != 12 || pets[0].Biology.Vitals.Happiness != 0.4 || pets[0].Personality.Traits.Playfulness < 0.8 {
+		t.Errorf("Expected Pip's columns applied, got age %.1f %+v", pets[0].GetAge(), pets[0].Biology.Vitals)
+	}
+
+	json := `{"pets": [{"name": "Rex", "age_days": 5, "vitals": {"energy": 0.3}, "traits": {"loyalty": 1}}]}`
+	pets, format, err = ImportPets([]byte(json), "", "owner")
+	if err != nil || format != "json" || len(pets) != 1 || pets[0].Biology.Vitals.Energy != 0.3 {
+		t.Fatalf("Expected Rex from JSON, got %v %s (%v)", pets, format, err)
+	}
+}
+
+func TestImportRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		format  string
+		want    error
+	}{
+		{"unknown format", "just some text", "", ErrUnknownFormat},
+		{"unknown format name", "name,age\nPip,1\n", "tamagotchi-v9", ErrUnknownFormat},
+		{"out of range vital", `{"name": "Rex", "vitals": {"health": 3}}`, "", ErrInvalidImport},
+		{"unknown trait", `{"name": "Rex", "traits": {"wings": 1}}`, "", ErrInvalidImport},
+		{"misspelt field", `{"name": "Rex", "age_dyas": 2}`, "", ErrInvalidImport},
+		{"missing name", "name,age_days\n,3\n", "", ErrInvalidImport},
+		{"negative hearts", "name=Kuchi\nhunger=-1\nhappy=2\n", "", ErrInvalidImport},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ImportPets([]byte(tt.payload), tt.format, "owner"); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+// lineImporter reads one pet name per line
+type lineImporter struct{}
+
+func (lineImporter) Name() string { return "lines" }
+
+func (lineImporter) Detect(payload []byte) bool { return false }
+
+func (lineImporter) Import(payload []byte) ([]PetDescriptor, error) {
+	var descriptors []PetDescriptor
+	for _, name := range strings.Fields(string(payload)) {
+		descriptors = append(descriptors, PetDescriptor{Name: name})
+	}
+	return descriptors, nil
+}
+
+func TestRegisterImporter(t *testing.T) {
+	RegisterImporter(lineImporter{})
+	pets, _, err := ImportPets([]byte("Pip Mochi"), "lines", "owner")
+	if err != nil || len(pets) != 2 {
+		t.Fatalf("Expected two pets from the registered importer, got %d (%v)", len(pets), err)
+	}
+	if formats := ImportFormats(); formats[len(formats)-1] != "lines" {
+		t.Errorf("Expected the new format listed last, got %v", formats)
+	}
+}
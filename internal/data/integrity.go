@@ -0,0 +1,213 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrDanglingReferences is returned when saves refer to pets that no
+// longer have a save
+var ErrDanglingReferences = errors.New("saves refer to missing pets")
+
+// MemorialStrength is how strongly a pet remembers a friend whose
+// relationship was converted into a memorial
+const MemorialStrength = 0.9
+
+// ReferenceKind is the part of a save that refers to another pet
+type ReferenceKind int
+
+const (
+	ReferenceRelationship ReferenceKind = iota
+	ReferencePedigree
+)
+
+// String returns the string representation of ReferenceKind
+func (k ReferenceKind) String() string {
+	return [...]string{"relationship", "pedigree"}[k]
+}
+
+// RepairStrategy is how dangling references are fixed
+type RepairStrategy int
+
+const (
+	// RepairPrune removes relationships with missing pets
+	RepairPrune RepairStrategy = iota
+	// RepairMemorial replaces relationships with missing pets by a lasting
+	// memory of them
+	RepairMemorial
+)
+
+// String returns the string representation of RepairStrategy
+func (s RepairStrategy) String() string {
+	return [...]string{"prune", "memorial"}[s]
+}
+
+// ParseRepairStrategy looks up a repair strategy by name
+func ParseRepairStrategy(name string) (RepairStrategy, error) {
+	for _, strategy := range []RepairStrategy{RepairPrune, RepairMemorial} {
+		if strings.EqualFold(name, strategy.String()) {
+			return strategy, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown repair strategy %q (want prune or memorial)", name)
+}
+
+// DanglingReference is one reference from a save to a pet with no save
+type DanglingReference struct {
+	Owner  types.PetID // Pet whose save holds the reference
+	Kind   ReferenceKind
+	Target types.PetID
+	Name   string // Name of the missing pet, if the save records it
+}
+
+// String describes the reference
+func (r DanglingReference) String() string {
+	target := string(r.Target)
+	if r.Name != "" {
+		target = r.Name + " (" + target + ")"
+	}
+	return fmt.Sprintf("%s: %s refers to missing pet %s", r.Owner, r.Kind, target)
+}
+
+// IntegrityReport lists what CheckIntegrity found
+type IntegrityReport struct {
+	Checked    int                 // Saves that were read
+	Dangling   []DanglingReference // References to missing pets
+	Unreadable error               // Saves that could not be read, if any
+}
+
+// Clean returns true if every save was read and no reference dangles
+func (r IntegrityReport) Clean() bool {
+	return len(r.Dangling) == 0 && r.Unreadable == nil
+}
+
+// CheckIntegrity scans active saves for references to pets that have no
+// save. Archived pets are kept unchanged as records and count as existing,
+// as does an unreadable save; the latter is listed in Unreadable.
+func (dm *DataManager) CheckIntegrity(ctx context.Context) (IntegrityReport, error) {
+	ids, err := dm.ListPets(ctx, FilterAll)
+	if err != nil {
+		return IntegrityReport{}, err
+	}
+	pets, loadErr := dm.LoadAll(ctx)
+	if ctx.Err() != nil {
+		return IntegrityReport{}, ctx.Err()
+	}
+
+	exists := existsIn(ids)
+	report := IntegrityReport{Checked: len(pets), Unreadable: loadErr}
+	for _, pet := range pets {
+		report.Dangling = append(report.Dangling, danglingReferences(pet, exists)...)
+	}
+	return report, nil
+}
+
+// RepairIntegrity fixes every dangling reference with the chosen strategy
+// and saves the repaired pets as one transaction. Missing ancestors are
+// always kept as memorials, since pruning them would hide inbreeding.
+// Unreadable saves are left alone; CheckIntegrity reports them. Returns how
+// many references were repaired.
+func (dm *DataManager) RepairIntegrity(ctx context.Context, strategy RepairStrategy) (int, error) {
+	ids, err := dm.ListPets(ctx, FilterAll)
+	if err != nil {
+		return 0, err
+	}
+	pets, _ := dm.LoadAll(ctx)
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+
+	exists := existsIn(ids)
+	var repaired []*core.DigitalPet
+	total := 0
+	for _, pet := range pets {
+		if n := repairPet(pet, exists, strategy); n > 0 {
+			repaired = append(repaired, pet)
+			total += n
+		}
+	}
+	if len(repaired) == 0 {
+		return 0, nil
+	}
+	if err := dm.SaveAll(ctx, repaired); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// danglingReferences lists a pet's references to pets that do not exist
+func danglingReferences(pet *core.DigitalPet, exists func(types.PetID) bool) []DanglingReference {
+	var dangling []DanglingReference
+	if pet.Relationships != nil {
+		for _, id := range sortedRelationshipIDs(pet) {
+			if !exists(id) {
+				dangling = append(dangling, DanglingReference{Owner: pet.ID, Kind: ReferenceRelationship, Target: id})
+			}
+		}
+	}
+	for _, ancestor := range pet.Pedigree.MissingAncestors(exists) {
+		dangling = append(dangling, DanglingReference{
+			Owner:  pet.ID,
+			Kind:   ReferencePedigree,
+			Target: ancestor.ID,
+			Name:   ancestor.Name,
+		})
+	}
+	return dangling
+}
+
+// repairPet fixes a pet's dangling references and returns how many it fixed
+func repairPet(pet *core.DigitalPet, exists func(types.PetID) bool, strategy RepairStrategy) int {
+	repaired := 0
+	if pet.Relationships != nil {
+		for _, id := range sortedRelationshipIDs(pet) {
+			if exists(id) {
+				continue
+			}
+			rel, _ := pet.Relationships.RemoveRelationship(id)
+			if strategy == RepairMemorial {
+				pet.Memory.Remember(&ai.Memory{
+					Type:        ai.MemorySocial,
+					Description: fmt.Sprintf("Misses a companion who is no longer around (%s)", rel.GetDescription()),
+					GameTime:    pet.GetAge(),
+					Strength:    MemorialStrength,
+					Valence:     rel.Affection - rel.Rivalry,
+					Tags:        []string{ai.PetTag(id)},
+				})
+			}
+			repaired++
+		}
+	}
+	for _, ancestor := range pet.Pedigree.MissingAncestors(exists) {
+		ancestor.Memorial = true
+		repaired++
+	}
+	return repaired
+}
+
+// sortedRelationshipIDs returns the pets a pet has relationships with, in
+// a stable order
+func sortedRelationshipIDs(pet *core.DigitalPet) []types.PetID {
+	ids := make([]types.PetID, 0, len(pet.Relationships.Relationships))
+	for id := range pet.Relationships.Relationships {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// existsIn returns a lookup for a set of pet IDs
+func existsIn(ids []types.PetID) func(types.PetID) bool {
+	set := make(map[types.PetID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return func(id types.PetID) bool { return set[id] }
+}
@@ -0,0 +1,73 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// savedWithDanglingReferences saves two friends, one with a mother that
+// was never saved, then deletes the other friend
+func savedWithDanglingReferences(t *testing.T) (*DataManager, *core.DigitalPet) {
+	ctx := context.Background()
+	dm, _ := NewDataManager(t.TempDir())
+	pet := core.NewDigitalPet("Mochi", "owner")
+	friend := core.NewDigitalPet("Pip", "owner")
+	pet.Relationships.AddRelationship(friend.ID, types.RelationshipFriend)
+	friend.Relationships.AddRelationship(pet.ID, types.RelationshipFriend)
+	mother := genetics.NewPedigree("gone", "Old Mum", 0, nil, nil)
+	pet.Pedigree = genetics.NewPedigree(pet.ID, pet.Name, 1, mother, nil)
+
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{pet, friend}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.DeletePet(ctx, friend.ID); err != nil {
+		t.Fatal(err)
+	}
+	return dm, pet
+}
+
+func TestCheckIntegrity(t *testing.T) {
+	dm, pet := savedWithDanglingReferences(t)
+	report, err := dm.CheckIntegrity(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Checked != 1 || len(report.Dangling) != 2 || report.Clean() {
+		t.Fatalf("Expected two dangling references, got %+v", report)
+	}
+	if ref := report.Dangling[1]; ref.Owner != pet.ID || ref.Kind != ReferencePedigree || ref.Name != "Old Mum" {
+		t.Errorf("Unexpected pedigree reference %v", ref)
+	}
+}
+
+func TestRepairIntegrity(t *testing.T) {
+	for _, strategy := range []RepairStrategy{RepairPrune, RepairMemorial} {
+		ctx := context.Background()
+		dm, pet := savedWithDanglingReferences(t)
+		repaired, err := dm.RepairIntegrity(ctx, strategy)
+		if err != nil || repaired != 2 {
+			t.Fatalf("%s: expected 2 repairs, got %d (%v)", strategy, repaired, err)
+		}
+
+		report, _ := dm.CheckIntegrity(ctx)
+		if !report.Clean() {
+			t.Errorf("%s: expected clean saves after repair, got %+v", strategy, report)
+		}
+		loaded, _ := dm.LoadPet(ctx, pet.ID)
+		if loaded.Relationships.GetRelationshipCount() != 0 || !loaded.Pedigree.Mother.Memorial {
+			t.Errorf("%s: expected the relationship gone and the mother kept as a memorial", strategy)
+		}
+		memorials := 0
+		for friendID := range pet.Relationships.Relationships {
+			memorials += len(loaded.Memory.ByTag(ai.PetTag(friendID)))
+		}
+		if want := map[RepairStrategy]int{RepairPrune: 0, RepairMemorial: 1}[strategy]; memorials != want {
+			t.Errorf("%s: expected %d memorial memories, got %d", strategy, want, memorials)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Journal files
+const (
+	journalName = "journal.log"  // Write-ahead record of saves in progress
+	sessionName = "session.lock" // Present while a session is running
+	tempExt     = ".tmp"         // Suffix of saves not yet renamed into place
+)
+
+// Journal is a write-ahead log of pet saves. A save is recorded with its
+// checksum before the temporary file is written and cleared once the file
+// has been renamed into place, so after a crash Recover knows which writes
+// were interrupted and whether they completed.
+type Journal struct {
+	mu      sync.Mutex
+	Path    string
+	Backend StorageBackend // Where the journal is kept; nil uses the disk
+}
+
+// NewJournal creates a journal at the given path
+func NewJournal(path string) *Journal {
+	return &Journal{Path: path}
+}
+
+// Begin records that a pet's save is about to be written
+func (j *Journal) Begin(id types.PetID, payload []byte) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	line := "begin " + url.PathEscape(string(id)) + " " + checksum(payload) + "\n"
+	return backendOr(j.Backend).AppendFile(j.Path, []byte(line))
+}
+
+// Commit records that a pet's save is safely in place. Saves are written
+// one at a time, so committing clears the journal.
+func (j *Journal) Commit(id types.PetID) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return backendOr(j.Backend).Truncate(j.Path, 0)
+}
+
+// pending returns the saves that were begun but never committed, keyed by
+// pet ID with the checksum of the intended payload
+func (j *Journal) pending() (map[types.PetID]string, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	content, err := backendOr(j.Backend).ReadFile(j.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[types.PetID]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != "begin" {
+			continue // A torn final line means the save never started
+		}
+		id, err := url.PathUnescape(fields[1])
+		if err != nil {
+			continue
+		}
+		pending[types.PetID(id)] = fields[2]
+	}
+	return pending, scanner.Err()
+}
+
+// RecoveryReport describes what Recover found after the last session
+type RecoveryReport struct {
+	Unclean       bool          // The last session did not shut down cleanly
+	RolledForward []types.PetID // Interrupted saves that were complete and kept
+	RolledBack    []types.PetID // Interrupted saves discarded in favour of the previous save
+}
+
+// Recovered returns true if any interrupted save was repaired
+func (r RecoveryReport) Recovered() bool {
+	return len(r.RolledForward)+len(r.RolledBack) > 0
+}
+
+// Recover repairs saves interrupted by a crash. A temporary file whose
+// checksum matches the journal is complete and is moved into place; anything
+// else is discarded, leaving the last committed save untouched. Stray
+// temporary files are removed and the journal is cleared. An interrupted
+// household transaction is finished if it was committed and discarded if
+// not, and an interrupted archive is completed.
+func (dm *DataManager) Recover() (RecoveryReport, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	var report RecoveryReport
+	if _, err := dm.storage().Stat(filepath.Join(dm.SavePath, sessionName)); err == nil {
+		report.Unclean = true
+	}
+
+	forward, back, err := dm.finishTransaction()
+	report.RolledForward = append(report.RolledForward, forward...)
+	report.RolledBack = append(report.RolledBack, back...)
+	if err != nil {
+		return report, err
+	}
+
+	pending, err := dm.Journal.pending()
+	if err != nil {
+		return report, err
+	}
+	for id, sum := range pending {
+		path := dm.petPath(id)
+		temp := path + tempExt
+		if payload, err := dm.storage().ReadFile(temp); err == nil && checksum(payload) == sum {
+			if err := dm.storage().Rename(temp, path); err != nil {
+				return report, err
+			}
+			report.RolledForward = append(report.RolledForward, id)
+			continue
+		}
+		if payload, err := dm.storage().ReadFile(path); err == nil && checksum(payload) == sum {
+			report.RolledForward = append(report.RolledForward, id) // Renamed before the crash
+			continue
+		}
+		report.RolledBack = append(report.RolledBack, id)
+	}
+
+	if err := dm.removeArchivedDuplicates(); err != nil {
+		return report, err
+	}
+
+	strays, err := globFiles(dm.storage(), filepath.Join(dm.SavePath, "*"+tempExt))
+	if err != nil {
+		return report, err
+	}
+	for _, stray := range strays {
+		if err := dm.storage().Remove(stray); err != nil {
+			return report, err
+		}
+	}
+
+	if err := dm.storage().Truncate(dm.Journal.Path, 0); err != nil && !os.IsNotExist(err) {
+		return report, err
+	}
+	return report, nil
+}
+
+// BeginSession marks a session as running so an unclean exit is detected
+// by the next Recover
+func (dm *DataManager) BeginSession() error {
+	return dm.storage().WriteFile(filepath.Join(dm.SavePath, sessionName), nil)
+}
+
+// EndSession marks the session as cleanly shut down
+func (dm *DataManager) EndSession() error {
+	err := dm.storage().Remove(filepath.Join(dm.SavePath, sessionName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// checksum returns the hex SHA-256 of a payload
+func checksum(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
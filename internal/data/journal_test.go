@@ -0,0 +1,62 @@
+package data
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestRecoverInterruptedSaves(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	complete := core.NewDigitalPet("Complete", "owner")
+	torn := core.NewDigitalPet("Torn", "owner")
+	for _, pet := range []*core.DigitalPet{complete, torn} {
+		if err := dm.SavePet(context.Background(), pet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := dm.BeginSession(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash after one temp file was fully written and another
+	// was cut off part way through
+	complete.Name = "Complete v2"
+	payload, _ := complete.Save()
+	dm.Journal.Begin(complete.ID, payload)
+	os.WriteFile(dm.petPath(complete.ID)+tempExt, payload, 0o644)
+
+	torn.Name = "Torn v2"
+	payload, _ = torn.Save()
+	dm.Journal.Begin(torn.ID, payload)
+	os.WriteFile(dm.petPath(torn.ID)+tempExt, payload[:len(payload)/2], 0o644)
+
+	report, err := dm.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if !report.Unclean || !report.Recovered() {
+		t.Errorf("Expected an unclean session with repairs, got %+v", report)
+	}
+	if len(report.RolledForward) != 1 || report.RolledForward[0] != complete.ID {
+		t.Errorf("Expected %s rolled forward, got %v", complete.ID, report.RolledForward)
+	}
+	if len(report.RolledBack) != 1 || report.RolledBack[0] != torn.ID {
+		t.Errorf("Expected %s rolled back, got %v", torn.ID, report.RolledBack)
+	}
+
+	if pet, _ := dm.LoadPet(context.Background(), complete.ID); pet == nil || pet.Name != "Complete v2" {
+		t.Error("A complete interrupted save should be kept")
+	}
+	if pet, _ := dm.LoadPet(context.Background(), torn.ID); pet == nil || pet.Name != "Torn" {
+		t.Error("A torn save should fall back to the last committed save")
+	}
+
+	dm.EndSession()
+	report, _ = dm.Recover()
+	if report.Unclean || report.Recovered() {
+		t.Errorf("Expected nothing to recover after a clean session, got %+v", report)
+	}
+}
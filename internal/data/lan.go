@@ -0,0 +1,276 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// LAN sync settings
+const (
+	LANDiscoveryAddr    = "224.0.0.251:5353" // The mDNS group peers announce themselves on
+	LANAnnounceInterval = 2 * time.Second    // Time between announcements
+	PairingCodeDigits   = 6                  // Length of a pairing code
+	MaxPairingAttempts  = 5                  // Wrong codes a server accepts before refusing everyone
+	lanService          = "gochi-sync"       // Salts pairing tokens
+	lanSavesPath        = "/saves/"          // Where a server exposes its saves
+	maxLANSave          = 64 << 20           // Largest save a peer may send
+)
+
+var (
+	// ErrPairingFailed is returned when a peer rejects the pairing code
+	ErrPairingFailed = errors.New("pairing code rejected")
+	// ErrPairingLocked is returned by a server that refused too many wrong
+	// pairing codes and must be restarted with a new one
+	ErrPairingLocked = errors.New("too many wrong pairing codes")
+)
+
+// LANPeer is a device offering its saves on the local network
+type LANPeer struct {
+	Name string // Shown to the player choosing a peer
+	Addr string // host:port of the peer's sync server
+}
+
+// NewPairingCode returns a random numeric code for the player to read out
+// on one device and type on the other
+func NewPairingCode() (string, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(PairingCodeDigits), nil)
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", PairingCodeDigits, n), nil
+}
+
+// pairingToken derives the bearer token both devices send from the code
+func pairingToken(code string) string {
+	sum := sha256.Sum256([]byte(lanService + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// LANServer offers a device's active saves to paired peers. It speaks the
+// plain HTTP protocol of HTTPProvider, so a peer syncs with it through a
+// CloudSyncManager exactly as it would with a cloud account. Peers write
+// straight to the saves, so serve while the household is not being played.
+type LANServer struct {
+	Data *DataManager
+
+	mu       sync.Mutex
+	token    string
+	failures int
+}
+
+// NewLANServer creates a server for dm's saves that accepts peers knowing
+// the pairing code
+func NewLANServer(dm *DataManager, code string) *LANServer {
+	return &LANServer{Data: dm, token: pairingToken(code)}
+}
+
+// Serve answers peers on listener until ctx is cancelled
+func (s *LANServer) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.Close()
+		case <-stopped:
+		}
+	}()
+	err := server.Serve(listener)
+	close(stopped)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// ServeHTTP answers a single peer request
+func (s *LANServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := s.authorize(r); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, lanSavesPath) {
+		http.NotFound(w, r)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, lanSavesPath)
+	ctx := r.Context()
+
+	if name == "" {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		ids, err := s.Data.ListPets(ctx, FilterActive)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, id := range ids {
+			fmt.Fprintln(w, objectName(id))
+		}
+		return
+	}
+
+	id, err := url.PathUnescape(strings.TrimSuffix(name, saveExt))
+	if err != nil || !strings.HasSuffix(name, saveExt) {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		s.read(w, r, types.PetID(id))
+	case http.MethodPut:
+		s.write(w, r, types.PetID(id))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// authorize checks a request's pairing token. Once MaxPairingAttempts
+// wrong tokens have been seen every request is refused, so a code cannot
+// be guessed.
+func (s *LANServer) authorize(r *http.Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures >= MaxPairingAttempts {
+		return ErrPairingLocked
+	}
+	given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(given), []byte(s.token)) != 1 {
+		s.failures++
+		return ErrPairingFailed
+	}
+	return nil
+}
+
+// read answers GET and HEAD for a save
+func (s *LANServer) read(w http.ResponseWriter, r *http.Request, id types.PetID) {
+	payload, err := s.Data.ReadPet(r.Context(), id)
+	if errors.Is(err, ErrPetNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	info, err := s.Data.storage().Stat(s.Data.petPath(id))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Last-Modified", info.ModTime().UTC().Format(http.TimeFormat))
+	w.Write(payload)
+}
+
+// write answers PUT for a save. Only valid pets are written, and only to
+// their own save, so a peer cannot overwrite one pet with another.
+func (s *LANServer) write(w http.ResponseWriter, r *http.Request, id types.PetID) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxLANSave))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	pet, err := DecodePet(id, payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if pet.ID != id {
+		http.Error(w, fmt.Sprintf("save of %s sent for %s", pet.ID, id), http.StatusBadRequest)
+		return
+	}
+	if err := s.Data.WritePet(r.Context(), id, payload); err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NewLANSyncManager syncs dm with a paired peer using the same conflict
+// handling as cloud sync
+func NewLANSyncManager(dm *DataManager, peer LANPeer, code string) *CloudSyncManager {
+	provider := &HTTPProvider{BaseURL: "http://" + peer.Addr + lanSavesPath, Token: pairingToken(code)}
+	return NewCloudSyncManager(dm, provider)
+}
+
+// Announce advertises peer to dest every interval until ctx is cancelled,
+// so devices running Discover can find it. Announcements are mDNS
+// responses for the DNS-SD service type _gochi-sync._tcp, so other service
+// browsers on the network see the server too.
+func Announce(ctx context.Context, conn net.PacketConn, dest net.Addr, peer LANPeer, interval time.Duration) error {
+	message, err := mdnsAnnouncement(peer)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := conn.WriteTo(message, dest); err != nil && ctx.Err() == nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Discover collects the peers announcing themselves on conn until ctx is
+// done, in name order. Other mDNS traffic on the group is ignored.
+func Discover(ctx context.Context, conn net.PacketConn) ([]LANPeer, error) {
+	stop := context.AfterFunc(ctx, func() { conn.SetReadDeadline(time.Now()) })
+	defer stop()
+
+	found := make(map[string]LANPeer)
+	buf := make([]byte, 9000) // mDNS packets may fill a jumbo frame
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if ctx.Err() != nil {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		announced, err := parseMDNSPeers(buf[:n])
+		udp, ok := from.(*net.UDPAddr)
+		if err != nil || !ok {
+			continue
+		}
+		// Trust the sender's address over any host it names, which may be
+		// another interface
+		for _, peer := range announced {
+			_, port, _ := net.SplitHostPort(peer.Addr)
+			peer.Addr = net.JoinHostPort(udp.IP.String(), port)
+			found[peer.Addr] = peer
+		}
+	}
+
+	peers := make([]LANPeer, 0, len(found))
+	for _, peer := range found {
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].Name < peers[j].Name })
+	return peers, nil
+}
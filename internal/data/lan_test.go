@@ -0,0 +1,123 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// startLANServer serves dm's saves on a loopback port until the test ends
+func startLANServer(t *testing.T, dm *DataManager, code string) LANPeer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewLANServer(dm, code).Serve(ctx, listener) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve failed: %v", err)
+		}
+	})
+	return LANPeer{Name: "host", Addr: listener.Addr().String()}
+}
+
+func TestLANSync(t *testing.T) {
+	ctx := context.Background()
+	host, _ := NewDataManager(t.TempDir())
+	guest, _ := NewDataManager(t.TempDir())
+	hostPet := core.NewDigitalPet("Mochi", "owner")
+	guestPet := core.NewDigitalPet("Pip", "owner")
+	host.SavePet(ctx, hostPet)
+	guest.SavePet(ctx, guestPet)
+
+	code, err := NewPairingCode()
+	if err != nil || len(code) != PairingCodeDigits {
+		t.Fatalf("Expected a %d digit code, got %q (%v)", PairingCodeDigits, code, err)
+	}
+	peer := startLANServer(t, host, code)
+
+	report, err := NewLANSyncManager(guest, peer, code).SyncAll(ctx)
+	if err != nil || len(report.Uploaded) != 1 || len(report.Downloaded) != 1 {
+		t.Fatalf("Expected one pet each way, got %+v (%v)", report, err)
+	}
+	if _, err := host.LoadPet(ctx, guestPet.ID); err != nil {
+		t.Errorf("Expected the guest's pet on the host: %v", err)
+	}
+	if _, err := guest.LoadPet(ctx, hostPet.ID); err != nil {
+		t.Errorf("Expected the host's pet on the guest: %v", err)
+	}
+}
+
+func TestLANPairingLocksOut(t *testing.T) {
+	ctx := context.Background()
+	host, _ := NewDataManager(t.TempDir())
+	guest, _ := NewDataManager(t.TempDir())
+	peer := startLANServer(t, host, "123456")
+
+	for i := 0; i < MaxPairingAttempts; i++ {
+		if _, err := NewLANSyncManager(guest, peer, "000000").SyncAll(ctx); !errors.Is(err, ErrCloudUnauthorized) {
+			t.Fatalf("Expected a wrong code to be rejected, got %v", err)
+		}
+	}
+	if _, err := NewLANSyncManager(guest, peer, "123456").SyncAll(ctx); !errors.Is(err, ErrCloudUnauthorized) {
+		t.Errorf("Expected the server to refuse even the right code after too many guesses, got %v", err)
+	}
+}
+
+func TestLANServerRejectsMismatchedIDs(t *testing.T) {
+	ctx := context.Background()
+	host, _ := NewDataManager(t.TempDir())
+	victim := core.NewDigitalPet("Mochi", "owner")
+	host.SavePet(ctx, victim)
+	peer := startLANServer(t, host, "123456")
+
+	impostor, _ := core.NewDigitalPet("Pip", "owner").Save()
+	req, _ := http.NewRequest(http.MethodPut, "http://"+peer.Addr+lanSavesPath+objectName(victim.ID), bytes.NewReader(impostor))
+	req.Header.Set("Authorization", "Bearer "+pairingToken("123456"))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for another pet's save, got %s", resp.Status)
+	}
+	if loaded, err := host.LoadPet(ctx, victim.ID); err != nil || loaded.Name != "Mochi" {
+		t.Errorf("Expected Mochi untouched, got %v (%v)", loaded, err)
+	}
+}
+
+func TestAnnounceAndDiscover(t *testing.T) {
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	sender, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	go Announce(ctx, sender, listener.LocalAddr(), LANPeer{Name: "living room", Addr: "0.0.0.0:4000"}, 50*time.Millisecond)
+	listener.WriteTo([]byte("not an announcement"), listener.LocalAddr())
+
+	peers, err := Discover(ctx, listener)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 1 || peers[0].Name != "living room" || peers[0].Addr != "127.0.0.1:4000" {
+		t.Errorf("Expected the living room peer at the sender's address, got %+v", peers)
+	}
+}
@@ -0,0 +1,263 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Legacy layout settings. Versions before the save root kept every pet as
+// a plain JSON file in data/pets, next to where the save root now is, with
+// backups and settings alongside.
+const (
+	LegacyPetsDir      = "pets"          // The old pets directory, a sibling of the save root
+	LegacySettingsName = "settings.yaml" // The old settings, inside LegacyPetsDir
+	LegacyDirName      = "legacy"        // Directory under a profile's save path holding the import report
+	legacyReportName   = "import.json"   // The import report, inside LegacyDirName
+)
+
+// ErrNoLegacyImport is returned when cleaning up before anything was
+// imported from the legacy layout
+var ErrNoLegacyImport = errors.New("nothing has been imported from the legacy layout")
+
+// LegacyReport records an import from the legacy layout, kept in the
+// profile so the originals can be cleaned up once the player is happy
+type LegacyReport struct {
+	Source   string        `json:"source"`             // The legacy pets directory
+	At       time.Time     `json:"at"`                 // When it was imported
+	Pets     []types.PetID `json:"pets"`               // Pets imported
+	Skipped  []string      `json:"skipped,omitempty"`  // Files not imported, with why
+	Backups  []string      `json:"backups,omitempty"`  // Backups copied into the profile
+	Settings bool          `json:"settings"`           // The old settings became the profile's settings
+	Cleaned  bool          `json:"cleaned,omitempty"`  // The originals have been deleted
+	Problems []string      `json:"problems,omitempty"` // Errors that stopped part of the import
+}
+
+// LegacyPetsPath returns where the legacy layout would be for a save root
+func LegacyPetsPath(root string) string {
+	return filepath.Join(filepath.Dir(filepath.Clean(root)), LegacyPetsDir)
+}
+
+// HasLegacy returns true if dir on a backend holds saves in the legacy
+// layout
+func HasLegacy(storage StorageBackend, dir string) bool {
+	entries, err := storage.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), saveExt) {
+			return true
+		}
+	}
+	return false
+}
+
+// ImportLegacy copies the pets, backups and settings of a legacy pets
+// directory into a profile, writing pets through dm so they take the
+// profile's compression and encryption. Pets and backups the profile
+// already has are skipped rather than overwritten, and the settings are
+// only copied if the profile has none. The originals are left untouched;
+// the report is kept in the profile for CleanupLegacy. The lifecycle hooks
+// of dm are told how it went.
+func ImportLegacy(ctx context.Context, dm *DataManager, profile *Profile, source string) (LegacyReport, error) {
+	report, err := importLegacy(ctx, dm, profile, source)
+	event := LifecycleEvent{Type: LifecycleMigration, Pets: len(report.Pets), Path: source,
+		Summary: fmt.Sprintf("imported %d pets and %d backups into profile %s", len(report.Pets), len(report.Backups), profile.Name)}
+	if err == nil && len(report.Problems) > 0 {
+		event.Error = strings.Join(report.Problems, "; ")
+	}
+	dm.Hooks.emit(event, err)
+	return report, err
+}
+
+// importLegacy implements ImportLegacy
+func importLegacy(ctx context.Context, dm *DataManager, profile *Profile, source string) (LegacyReport, error) {
+	report := LegacyReport{Source: source, At: time.Now().UTC()}
+	entries, err := dm.storage().ReadDir(source)
+	if err != nil {
+		return report, err
+	}
+	existing, err := dm.ListPets(ctx, FilterAll)
+	if err != nil {
+		return report, err
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, saveExt) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		payload, err := dm.storage().ReadFile(filepath.Join(source, name))
+		if err != nil {
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		id, _ := saveID(name)
+		pet, err := DecodePet(id, payload)
+		if err != nil {
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		if containsPet(existing, pet.ID) || containsPet(report.Pets, pet.ID) {
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s: %s is already in profile %s", name, pet.Name, profile.Name))
+			continue
+		}
+		if err := dm.WritePet(ctx, pet.ID, payload); err != nil {
+			report.Problems = append(report.Problems, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		report.Pets = append(report.Pets, pet.ID)
+	}
+
+	backups, err := dm.storage().ReadDir(filepath.Join(source, BackupDirName))
+	if err != nil && !os.IsNotExist(err) {
+		report.Problems = append(report.Problems, err.Error())
+	}
+	for _, backup := range backups {
+		if !backup.IsDir() {
+			continue
+		}
+		from := filepath.Join(source, BackupDirName, backup.Name())
+		to := filepath.Join(profile.Dir, BackupDirName, backup.Name())
+		if _, err := dm.storage().Stat(to); err == nil {
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s: already in profile %s", filepath.Join(BackupDirName, backup.Name()), profile.Name))
+			continue
+		}
+		if err := copyTree(ctx, dm.storage(), from, to); err != nil {
+			dm.storage().RemoveAll(to)
+			report.Problems = append(report.Problems, fmt.Sprintf("%s: %v", backup.Name(), err))
+			continue
+		}
+		report.Backups = append(report.Backups, backup.Name())
+	}
+
+	settings := filepath.Join(source, LegacySettingsName)
+	if _, err := dm.storage().Stat(settings); err == nil {
+		if _, err := dm.storage().Stat(profile.ConfigPath()); err == nil {
+			report.Skipped = append(report.Skipped, fmt.Sprintf("%s: profile %s has its own settings", LegacySettingsName, profile.Name))
+		} else if err := copyFile(dm.storage(), settings, profile.ConfigPath()); err != nil {
+			report.Problems = append(report.Problems, fmt.Sprintf("%s: %v", LegacySettingsName, err))
+		} else {
+			report.Settings = true
+		}
+	}
+
+	sort.Slice(report.Pets, func(i, j int) bool { return report.Pets[i] < report.Pets[j] })
+	return report, saveLegacyReport(profile, report)
+}
+
+// LegacyImported returns the report of a profile's import from the
+// legacy layout, if it has one
+func LegacyImported(profile *Profile) (LegacyReport, bool, error) {
+	var report LegacyReport
+	payload, err := profile.storage().ReadFile(legacyReportPath(profile))
+	if os.IsNotExist(err) {
+		return report, false, nil
+	}
+	if err != nil {
+		return report, false, err
+	}
+	if err := json.Unmarshal(payload, &report); err != nil {
+		return report, false, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+	}
+	return report, true, nil
+}
+
+// CleanupLegacy deletes the legacy pets directory a profile was imported
+// from, once the player has confirmed the import. Returns
+// ErrNoLegacyImport if the profile has not imported one.
+func CleanupLegacy(profile *Profile) (LegacyReport, error) {
+	report, ok, err := LegacyImported(profile)
+	if err != nil {
+		return report, err
+	}
+	if !ok {
+		return report, ErrNoLegacyImport
+	}
+	if report.Cleaned {
+		return report, nil
+	}
+	if err := profile.storage().RemoveAll(report.Source); err != nil {
+		return report, err
+	}
+	report.Cleaned = true
+	return report, saveLegacyReport(profile, report)
+}
+
+// saveLegacyReport keeps an import report in the profile
+func saveLegacyReport(profile *Profile, report LegacyReport) error {
+	payload, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := legacyReportPath(profile)
+	storage := profile.storage()
+	if err := storage.MkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := storage.WriteFile(path+tempExt, payload); err != nil {
+		return err
+	}
+	return storage.Rename(path+tempExt, path)
+}
+
+// legacyReportPath returns where a profile's import report is kept
+func legacyReportPath(profile *Profile) string {
+	return filepath.Join(profile.Dir, LegacyDirName, legacyReportName)
+}
+
+// containsPet returns true if ids holds id
+func containsPet(ids []types.PetID, id types.PetID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// copyTree copies the regular files under one directory into another
+func copyTree(ctx context.Context, storage StorageBackend, from, to string) error {
+	return walkDir(storage, from, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(to, rel)
+		switch {
+		case entry.IsDir():
+			return storage.MkdirAll(dest)
+		case entry.Type().IsRegular():
+			return copyFile(storage, path, dest)
+		}
+		return nil
+	})
+}
+
+// copyFile copies one file durably
+func copyFile(storage StorageBackend, from, to string) error {
+	payload, err := storage.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	return storage.WriteFile(to, payload)
+}
@@ -0,0 +1,98 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// writeLegacy lays out pets, a backup and settings the way earlier
+// versions kept them
+func writeLegacy(t *testing.T, dir string, pets ...*core.DigitalPet) {
+	t.Helper()
+	backup := filepath.Join(dir, BackupDirName, "backup-20240101-000000")
+	if err := os.MkdirAll(backup, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, pet := range pets {
+		payload, err := pet.Save()
+		if err != nil {
+			t.Fatal(err)
+		}
+		os.WriteFile(filepath.Join(dir, string(pet.ID)+saveExt), payload, 0o644)
+		os.WriteFile(filepath.Join(backup, string(pet.ID)+saveExt), payload, 0o644)
+	}
+	os.WriteFile(filepath.Join(dir, "broken"+saveExt), []byte("{"), 0o644)
+	os.WriteFile(filepath.Join(dir, LegacySettingsName), []byte("ui:\n  thought_interval: 0\n"), 0o644)
+}
+
+func TestImportLegacy(t *testing.T) {
+	ctx := context.Background()
+	root := filepath.Join(t.TempDir(), "saves")
+	source := LegacyPetsPath(root)
+	if HasLegacy(DiskBackend{}, source) {
+		t.Fatal("Expected no legacy layout yet")
+	}
+	mochi, rex := core.NewDigitalPet("Mochi", "owner"), core.NewDigitalPet("Rex", "owner")
+	writeLegacy(t, source, mochi, rex)
+	if !HasLegacy(DiskBackend{}, source) || filepath.Base(source) != LegacyPetsDir {
+		t.Fatalf("Expected the legacy layout found at %s", source)
+	}
+
+	profile, _ := NewProfileManager(root).Open("")
+	dm, _ := NewDataManager(profile.Dir)
+	dm.Compress = true
+	dm.SavePet(ctx, rex)
+
+	report, err := ImportLegacy(ctx, dm, profile, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Pets) != 1 || report.Pets[0] != mochi.ID {
+		t.Errorf("Expected only Mochi imported, got %v", report.Pets)
+	}
+	if len(report.Skipped) != 2 || len(report.Backups) != 1 || !report.Settings || len(report.Problems) != 0 {
+		t.Errorf("Expected Rex and the broken save skipped and the backup and settings copied, got %+v", report)
+	}
+	if pet, err := dm.LoadPet(ctx, mochi.ID); err != nil || pet.Name != "Mochi" {
+		t.Errorf("Expected Mochi in the profile, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(profile.Dir, BackupDirName, report.Backups[0], string(rex.ID)+saveExt)); err != nil {
+		t.Errorf("Expected the backup copied, got %v", err)
+	}
+	if settings, _ := os.ReadFile(profile.ConfigPath()); !strings.Contains(string(settings), "thought_interval") {
+		t.Errorf("Expected the settings copied, got %q", settings)
+	}
+	if !HasLegacy(DiskBackend{}, source) {
+		t.Error("Expected the originals left in place")
+	}
+
+	kept, ok, err := LegacyImported(profile)
+	if err != nil || !ok || kept.Source != source || len(kept.Pets) != 1 || kept.Cleaned {
+		t.Errorf("Expected the report kept in the profile, got %+v (%v)", kept, err)
+	}
+	if pets, err := dm.LoadAll(ctx); err != nil || len(pets) != 2 {
+		t.Errorf("Expected the report not mistaken for a save, got %d pets (%v)", len(pets), err)
+	}
+
+	cleaned, err := CleanupLegacy(profile)
+	if err != nil || !cleaned.Cleaned {
+		t.Fatalf("Expected the originals cleaned up, got %+v (%v)", cleaned, err)
+	}
+	if _, err := os.Stat(source); !os.IsNotExist(err) {
+		t.Errorf("Expected %s deleted, got %v", source, err)
+	}
+	if _, err := dm.LoadPet(ctx, mochi.ID); err != nil {
+		t.Errorf("Expected Mochi kept after the clean-up, got %v", err)
+	}
+
+	other, _ := NewProfileManager(root).Create("other")
+	if _, err := CleanupLegacy(other); !errors.Is(err, ErrNoLegacyImport) {
+		t.Errorf("Expected ErrNoLegacyImport, got %v", err)
+	}
+}
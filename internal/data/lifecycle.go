@@ -0,0 +1,132 @@
+package data
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Lifecycle event types, emitted once a data operation finishes, whether it
+// succeeded or not. The names follow the game's event types, so "data.*"
+// covers them all.
+const (
+	LifecycleSave      = "data.save"      // Queued saves written, e.g. by an auto-save
+	LifecycleBackup    = "data.backup"    // A backup taken, with its Path and Size
+	LifecycleSync      = "data.sync"      // A sync of every pet, with its Conflicts
+	LifecycleMigration = "data.migration" // Saves moved into the current layout
+)
+
+// LifecycleEvent reports a finished data operation, so operators can see
+// that their saves, backups and syncs actually ran
+type LifecycleEvent struct {
+	Type      string              `json:"type"`
+	Time      time.Time           `json:"time"`
+	Pets      int                 `json:"pets"`                // Pets saved, backed up, synced or migrated
+	Path      string              `json:"path,omitempty"`      // The backup, or where migrated saves came from
+	Size      int64               `json:"size,omitempty"`      // Bytes in the backup
+	Summary   string              `json:"summary"`             // One line for people
+	Conflicts []LifecycleConflict `json:"conflicts,omitempty"` // Pets that changed on both sides of a sync
+	Error     string              `json:"error,omitempty"`     // Why the operation failed; empty on success
+}
+
+// Failed returns true if the operation did not complete
+func (e LifecycleEvent) Failed() bool {
+	return e.Error != ""
+}
+
+// LifecycleConflict summarises one sync conflict in a lifecycle event
+type LifecycleConflict struct {
+	PetID   types.PetID `json:"pet"`
+	Kept    string      `json:"kept"`    // Local, Cloud, Merged or Unresolved
+	Changes []string    `json:"changes"` // What differs, from the local copy to the cloud copy
+}
+
+// LifecycleHook receives lifecycle events. Hooks run on the goroutine that
+// finished the operation, so they must return quickly and must not call
+// back into the data layer.
+type LifecycleHook func(LifecycleEvent)
+
+// LifecycleHooks passes lifecycle events to any number of hooks. A nil set
+// emits nothing.
+type LifecycleHooks struct {
+	mu    sync.RWMutex
+	hooks []LifecycleHook
+}
+
+// NewLifecycleHooks creates an empty set of hooks
+func NewLifecycleHooks() *LifecycleHooks {
+	return &LifecycleHooks{}
+}
+
+// Add registers a hook for every later event
+func (h *LifecycleHooks) Add(hook LifecycleHook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, hook)
+}
+
+// Emit passes an event to every hook in the order they were added,
+// stamping it with the current time if it has none
+func (h *LifecycleHooks) Emit(e LifecycleEvent) {
+	if h == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	h.mu.RLock()
+	hooks := h.hooks
+	h.mu.RUnlock()
+	for _, hook := range hooks {
+		hook(e)
+	}
+}
+
+// emit emits an event for an operation, recording err if it failed
+func (h *LifecycleHooks) emit(e LifecycleEvent, err error) {
+	if err != nil {
+		e.Error = err.Error()
+	}
+	h.Emit(e)
+}
+
+// syncEvent describes a finished sync
+func syncEvent(report SyncReport, err error) LifecycleEvent {
+	e := LifecycleEvent{
+		Type: LifecycleSync,
+		Pets: len(report.Uploaded) + len(report.Downloaded) + len(report.Merged) + len(report.Conflicts),
+		Summary: fmt.Sprintf("%d sent, %d received, %d merged, %d conflicts",
+			len(report.Uploaded), len(report.Downloaded), len(report.Merged), len(report.Conflicts)),
+	}
+	for _, conflict := range report.ConflictReports {
+		changes := make([]string, len(conflict.Diff.Changes))
+		for i, change := range conflict.Diff.Changes {
+			changes[i] = change.String()
+		}
+		e.Conflicts = append(e.Conflicts, LifecycleConflict{PetID: conflict.PetID, Kept: conflict.Kept.String(), Changes: changes})
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	return e
+}
+
+// treeSize returns the bytes in every file under dir
+func treeSize(storage StorageBackend, dir string) int64 {
+	entries, err := storage.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+	var size int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			size += treeSize(storage, filepath.Join(dir, entry.Name()))
+		} else if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// recordEvents collects the lifecycle events a set of hooks emits
+func recordEvents(hooks *LifecycleHooks) func() []LifecycleEvent {
+	var mu sync.Mutex
+	var events []LifecycleEvent
+	hooks.Add(func(e LifecycleEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	})
+	return func() []LifecycleEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]LifecycleEvent(nil), events...)
+	}
+}
+
+func TestLifecycleSaveAndBackup(t *testing.T) {
+	ctx := context.Background()
+	dm, _ := NewDataManager(t.TempDir())
+	events := recordEvents(dm.Hooks)
+
+	q := NewSaveQueue(dm, 4)
+	q.EnqueueAll(ctx, []*core.DigitalPet{core.NewDigitalPet("Mochi", "owner"), core.NewDigitalPet("Pip", "owner")})
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := events(); len(got) != 1 || got[0].Type != LifecycleSave || got[0].Pets != 2 || got[0].Failed() {
+		t.Fatalf("Expected one save of 2 pets, got %+v", got)
+	}
+
+	path, err := dm.Backup(ctx, filepath.Join(dm.SavePath, BackupDirName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	backup := events()[1]
+	if backup.Type != LifecycleBackup || backup.Path != path || backup.Pets != 2 || backup.Time.IsZero() {
+		t.Errorf("Expected the backup reported with its path, got %+v", backup)
+	}
+	if backup.Size != treeSize(DiskBackend{}, path) || backup.Size == 0 {
+		t.Errorf("Expected the backup's size, got %d", backup.Size)
+	}
+
+	// A backup that cannot be written is reported as failed
+	blocked := filepath.Join(dm.SavePath, "blocked")
+	os.WriteFile(blocked, []byte("a file"), 0o644)
+	if _, err := dm.Backup(ctx, blocked); err == nil {
+		t.Fatal("Expected a backup into a file to fail")
+	}
+	if failed := events()[2]; !failed.Failed() || failed.Type != LifecycleBackup {
+		t.Errorf("Expected the failure reported, got %+v", failed)
+	}
+}
+
+func TestLifecycleSyncReportsConflicts(t *testing.T) {
+	pet, phone, phoneSync := divergedPet(t)
+	events := recordEvents(phone.Hooks)
+
+	phoneSync.SyncAll(context.Background())
+	got := events()
+	if len(got) != 1 || got[0].Type != LifecycleSync || !got[0].Failed() {
+		t.Fatalf("Expected one failed sync, got %+v", got)
+	}
+	conflicts := got[0].Conflicts
+	if len(conflicts) != 1 || conflicts[0].PetID != pet.ID || conflicts[0].Kept != KeepNeither.String() || len(conflicts[0].Changes) == 0 {
+		t.Errorf("Expected the unresolved conflict with what diverged, got %+v", conflicts)
+	}
+}
+
+func TestLifecycleMigration(t *testing.T) {
+	root := t.TempDir()
+	legacy, _ := NewDataManager(root)
+	legacy.SavePet(context.Background(), core.NewDigitalPet("Mochi", LegacyOwner))
+
+	pm := NewProfileManager(root)
+	pm.Hooks = NewLifecycleHooks()
+	events := recordEvents(pm.Hooks)
+	if _, err := pm.MigrateLegacy(); err != nil {
+		t.Fatal(err)
+	}
+	pm.MigrateLegacy()
+	if got := events(); len(got) != 1 || got[0].Type != LifecycleMigration || got[0].Path != root {
+		t.Errorf("Expected only the migration that moved saves reported, got %+v", got)
+	}
+}
@@ -0,0 +1,202 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrNotListed is returned when claiming a pet or item that is not on the
+// marketplace, or is reserved for another account
+var ErrNotListed = errors.New("not on the marketplace")
+
+// MarketCollection is the cloud collection shared by every account's
+// marketplace offers
+const MarketCollection = "market"
+
+// MarketListing is a pet offered for adoption, or an item offered for
+// trade, through the cloud
+type MarketListing struct {
+	PetID  types.PetID     `json:"pet,omitempty"`
+	Name   string          `json:"name"`
+	From   string          `json:"from"`          // Account that offered the pet
+	For    string          `json:"for,omitempty"` // Friend the pet is reserved for; empty offers it to anyone
+	Listed time.Time       `json:"listed"`
+	Save   json.RawMessage `json:"save,omitempty"`
+
+	Item string `json:"item,omitempty"` // Set instead of PetID and Save when an item is offered
+	Key  string `json:"-"`              // Cloud object holding the listing
+}
+
+// Marketplace offers pets to other players through a cloud provider
+// shared between accounts. A pet can be offered to anyone or reserved for
+// a friend's account.
+type Marketplace struct {
+	Provider CloudProvider
+	Account  string        // This player's account; reserved offers must name it
+	Timeout  time.Duration // Bounds each provider call; 0 waits as long as ctx
+}
+
+// NewMarketplace creates a marketplace for an account
+func NewMarketplace(provider CloudProvider, account string) *Marketplace {
+	return &Marketplace{Provider: provider, Account: account}
+}
+
+// Offer lists a pet, reserved for a friend's account if forAccount is not
+// empty. The pet's save travels with the listing.
+func (m *Marketplace) Offer(ctx context.Context, pet *core.DigitalPet, forAccount string) error {
+	if err := ValidatePet(pet); err != nil {
+		return err
+	}
+	save, err := pet.Save()
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(MarketListing{
+		PetID:  pet.ID,
+		Name:   pet.Name,
+		From:   m.Account,
+		For:    forAccount,
+		Listed: time.Now().UTC(),
+		Save:   save,
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := m.callContext(ctx)
+	defer cancel()
+	return m.Provider.Upload(ctx, string(pet.ID), payload)
+}
+
+// OfferItem lists an item, such as an accessory, reserved for a friend's
+// account if forAccount is not empty
+func (m *Marketplace) OfferItem(ctx context.Context, item, forAccount string) error {
+	listed := time.Now().UTC()
+	payload, err := json.Marshal(MarketListing{
+		Name:   item,
+		From:   m.Account,
+		For:    forAccount,
+		Listed: listed,
+		Item:   item,
+	})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := m.callContext(ctx)
+	defer cancel()
+	key := fmt.Sprintf("item-%s-%s-%d", strings.ToLower(item), m.Account, listed.UnixNano())
+	return m.Provider.Upload(ctx, key, payload)
+}
+
+// Listings returns the offers this account may claim, oldest first.
+// Offers reserved for other accounts are left out.
+func (m *Marketplace) Listings(ctx context.Context) ([]MarketListing, error) {
+	ctx, cancel := m.callContext(ctx)
+	defer cancel()
+	names, err := m.Provider.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var listings []MarketListing
+	for _, name := range names {
+		listing, err := m.download(ctx, name)
+		if errors.Is(err, ErrNotListed) || errors.Is(err, ErrCorruptSave) {
+			continue // Claimed while we were listing, or unreadable
+		}
+		if err != nil {
+			return nil, err
+		}
+		if m.mayClaim(listing) {
+			listings = append(listings, listing)
+		}
+	}
+	sort.Slice(listings, func(i, j int) bool { return listings[i].Listed.Before(listings[j].Listed) })
+	return listings, nil
+}
+
+// Claim takes a pet off the marketplace by name or ID and gives it to the
+// owner
+func (m *Marketplace) Claim(ctx context.Context, nameOrID string, owner types.UserID) (*core.DigitalPet, error) {
+	listings, err := m.Listings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, listing := range listings {
+		if listing.Item != "" || listing.PetID != types.PetID(nameOrID) && !strings.EqualFold(listing.Name, nameOrID) {
+			continue
+		}
+		pet, err := DecodePet(listing.PetID, listing.Save)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.remove(ctx, listing, nameOrID); err != nil {
+			return nil, err
+		}
+		pet.Owner = owner
+		return pet, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotListed, nameOrID)
+}
+
+// ClaimItem takes the oldest offer of an item off the marketplace
+func (m *Marketplace) ClaimItem(ctx context.Context, item string) error {
+	listings, err := m.Listings(ctx)
+	if err != nil {
+		return err
+	}
+	for _, listing := range listings {
+		if listing.Item != "" && strings.EqualFold(listing.Item, item) {
+			return m.remove(ctx, listing, item)
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotListed, item)
+}
+
+// remove deletes a claimed listing. Another player claiming it first
+// reports ErrNotListed.
+func (m *Marketplace) remove(ctx context.Context, listing MarketListing, name string) error {
+	ctx, cancel := m.callContext(ctx)
+	defer cancel()
+	err := m.Provider.Delete(ctx, listing.Key)
+	if errors.Is(err, ErrObjectNotFound) {
+		return fmt.Errorf("%w: %s", ErrNotListed, name)
+	}
+	return err
+}
+
+// mayClaim returns true if a listing is open to this account
+func (m *Marketplace) mayClaim(listing MarketListing) bool {
+	return listing.For == "" || strings.EqualFold(listing.For, m.Account)
+}
+
+// download reads one listing
+func (m *Marketplace) download(ctx context.Context, name string) (MarketListing, error) {
+	var listing MarketListing
+	payload, err := m.Provider.Download(ctx, name)
+	if errors.Is(err, ErrObjectNotFound) {
+		return listing, fmt.Errorf("%w: %s", ErrNotListed, name)
+	}
+	if err != nil {
+		return listing, err
+	}
+	if err := json.Unmarshal(payload, &listing); err != nil {
+		return listing, fmt.Errorf("%w: listing %s: %w", ErrCorruptSave, name, err)
+	}
+	listing.Key = name
+	return listing, nil
+}
+
+// callContext bounds provider calls by Timeout
+func (m *Marketplace) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if m.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, m.Timeout)
+}
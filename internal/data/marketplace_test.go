@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestMarketplaceOfferAndClaim(t *testing.T) {
+	ctx := context.Background()
+	provider := NewMemoryProvider()
+	ann := NewMarketplace(provider, "ann")
+	ben := NewMarketplace(provider, "ben")
+	cal := NewMarketplace(provider, "cal")
+
+	sprout := core.NewDigitalPet("Sprout", "ann")
+	pip := core.NewDigitalPet("Pip", "ann")
+	if err := ann.Offer(ctx, sprout, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := ann.Offer(ctx, pip, "Ben"); err != nil {
+		t.Fatal(err)
+	}
+
+	if listings, err := cal.Listings(ctx); err != nil || len(listings) != 1 || listings[0].Name != "Sprout" {
+		t.Fatalf("Expected only the open offer for cal, got %+v (%v)", listings, err)
+	}
+	if _, err := cal.Claim(ctx, "pip", "cal"); !errors.Is(err, ErrNotListed) {
+		t.Errorf("Expected a reserved pet to be unclaimable, got %v", err)
+	}
+
+	listings, err := ben.Listings(ctx)
+	if err != nil || len(listings) != 2 || listings[1].For != "Ben" || listings[0].From != "ann" {
+		t.Fatalf("Expected both offers for ben, got %+v (%v)", listings, err)
+	}
+	claimed, err := ben.Claim(ctx, "pip", "ben")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claimed.ID != pip.ID || claimed.Owner != "ben" {
+		t.Errorf("Expected Pip owned by ben, got %s owned by %s", claimed.ID, claimed.Owner)
+	}
+	if _, err := ben.Claim(ctx, "pip", "ben"); !errors.Is(err, ErrNotListed) {
+		t.Errorf("A claimed pet should leave the marketplace, got %v", err)
+	}
+}
+
+func TestMarketplaceSkipsCorruptListings(t *testing.T) {
+	ctx := context.Background()
+	provider := NewMemoryProvider()
+	provider.Upload(ctx, "junk", []byte("not json"))
+	market := NewMarketplace(provider, "ann")
+	if err := market.Offer(ctx, core.NewDigitalPet("Sprout", "ann"), ""); err != nil {
+		t.Fatal(err)
+	}
+	if listings, err := market.Listings(ctx); err != nil || len(listings) != 1 {
+		t.Errorf("Expected the readable offer only, got %+v (%v)", listings, err)
+	}
+}
+
+func TestMarketplaceTradesItems(t *testing.T) {
+	ctx := context.Background()
+	provider := NewMemoryProvider()
+	ann := NewMarketplace(provider, "ann")
+	ben := NewMarketplace(provider, "ben")
+
+	if err := ann.OfferItem(ctx, "Hat", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := ann.Offer(ctx, core.NewDigitalPet("Hat", "ann"), ""); err != nil {
+		t.Fatal(err)
+	}
+	listings, err := ben.Listings(ctx)
+	if err != nil || len(listings) != 2 {
+		t.Fatalf("Expected the item and the pet listed, got %+v (%v)", listings, err)
+	}
+
+	if err := ben.ClaimItem(ctx, "hat"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ben.ClaimItem(ctx, "hat"); !errors.Is(err, ErrNotListed) {
+		t.Errorf("A claimed item should leave the marketplace, got %v", err)
+	}
+	if pet, err := ben.Claim(ctx, "hat", "ben"); err != nil || pet.Name != "Hat" {
+		t.Errorf("Expected the pet named Hat still claimable, got %v (%v)", pet, err)
+	}
+}
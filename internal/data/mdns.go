@@ -0,0 +1,261 @@
+package data
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Multicast DNS (RFC 6762) and DNS service discovery (RFC 6763) records
+// used to announce and find LAN sync servers. Only the few record types a
+// service announcement needs are encoded; anything else in a packet is
+// skipped.
+const (
+	mdnsServiceType = "_gochi-sync._tcp.local." // DNS-SD service type of LAN sync servers
+	mdnsTTL         = 120                       // Seconds announced records stay valid
+	mdnsMaxLabel    = 63                        // Longest DNS label in bytes
+	mdnsCacheFlush  = 0x8000                    // Marks a record as unique to its owner
+
+	dnsTypeA   = 1
+	dnsTypePTR = 12
+	dnsTypeTXT = 16
+	dnsTypeSRV = 33
+	dnsClassIN = 1
+
+	dnsFlagResponse  = 0x8000
+	dnsFlagAuthority = 0x0400
+	dnsHeaderSize    = 12
+	maxNamePointers  = 16 // Compression pointers followed before a name is rejected
+)
+
+// errBadDNS is returned for a packet that is not a well-formed DNS message
+var errBadDNS = errors.New("malformed DNS message")
+
+// dnsRecord is one resource record of an mDNS message
+type dnsRecord struct {
+	Name string
+	Type uint16
+	Data []byte // Raw RDATA
+}
+
+// mdnsInstance returns the DNS-SD instance name of a peer: its name as a
+// single label under the service type. Dots would split the label, so
+// they are replaced.
+func mdnsInstance(name string) string {
+	label := strings.ReplaceAll(name, ".", "-")
+	if label == "" {
+		label = "gochi"
+	}
+	if len(label) > mdnsMaxLabel {
+		// Drop any rune cut in half
+		label = strings.ToValidUTF8(label[:mdnsMaxLabel], "")
+	}
+	return label + "." + mdnsServiceType
+}
+
+// mdnsAnnouncement builds an unsolicited mDNS response announcing a peer:
+// a PTR from the service type to the instance, an SRV with its port, a
+// TXT with its name and, when the peer gave a specific IPv4 address, an A
+// record for its host
+func mdnsAnnouncement(peer LANPeer) ([]byte, error) {
+	host, portText, err := net.SplitHostPort(peer.Addr)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(portText, 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	instance := mdnsInstance(peer.Name)
+	target := strings.TrimSuffix(instance, "."+mdnsServiceType)
+	target = strings.Map(func(r rune) rune {
+		if r < 0x80 && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, target) + ".local."
+
+	ip := net.ParseIP(host).To4()
+	records := []dnsRecord{
+		{Name: mdnsServiceType, Type: dnsTypePTR, Data: encodeName(instance)},
+		{Name: instance, Type: dnsTypeSRV, Data: append([]byte{0, 0, 0, 0, byte(port >> 8), byte(port)}, encodeName(target)...)},
+		{Name: instance, Type: dnsTypeTXT, Data: encodeTXT("name=" + peer.Name)},
+	}
+	if ip != nil && !ip.IsUnspecified() {
+		records = append(records, dnsRecord{Name: target, Type: dnsTypeA, Data: ip})
+	}
+
+	msg := make([]byte, dnsHeaderSize)
+	binary.BigEndian.PutUint16(msg[2:], dnsFlagResponse|dnsFlagAuthority)
+	binary.BigEndian.PutUint16(msg[6:], uint16(len(records)))
+	for _, record := range records {
+		class := uint16(dnsClassIN)
+		if record.Type != dnsTypePTR {
+			class |= mdnsCacheFlush // Shared PTRs may have many owners
+		}
+		msg = append(msg, encodeName(record.Name)...)
+		msg = binary.BigEndian.AppendUint16(msg, record.Type)
+		msg = binary.BigEndian.AppendUint16(msg, class)
+		msg = binary.BigEndian.AppendUint32(msg, mdnsTTL)
+		msg = binary.BigEndian.AppendUint16(msg, uint16(len(record.Data)))
+		msg = append(msg, record.Data...)
+	}
+	return msg, nil
+}
+
+// parseMDNSPeers returns the peers announced in an mDNS response, with the
+// port from each SRV record and the name from its TXT record. Their
+// addresses hold only the port; the caller supplies the host.
+func parseMDNSPeers(msg []byte) ([]LANPeer, error) {
+	records, err := parseDNS(msg)
+	if err != nil {
+		return nil, err
+	}
+	ports := make(map[string]string)
+	names := make(map[string]string)
+	var instances []string
+	for _, record := range records {
+		owner := strings.ToLower(record.Name)
+		if !strings.HasSuffix(owner, "."+mdnsServiceType) {
+			continue
+		}
+		switch record.Type {
+		case dnsTypeSRV:
+			if len(record.Data) < 7 {
+				return nil, errBadDNS
+			}
+			if _, seen := ports[owner]; !seen {
+				instances = append(instances, owner)
+			}
+			ports[owner] = strconv.Itoa(int(binary.BigEndian.Uint16(record.Data[4:6])))
+			if _, named := names[owner]; !named {
+				names[owner] = strings.TrimSuffix(record.Name, "."+mdnsServiceType)
+			}
+		case dnsTypeTXT:
+			for _, entry := range decodeTXT(record.Data) {
+				if name, ok := strings.CutPrefix(entry, "name="); ok {
+					names[owner] = name
+				}
+			}
+		}
+	}
+	peers := make([]LANPeer, 0, len(instances))
+	for _, instance := range instances {
+		peers = append(peers, LANPeer{Name: names[instance], Addr: net.JoinHostPort("", ports[instance])})
+	}
+	return peers, nil
+}
+
+// parseDNS returns the answer, authority and additional records of a DNS
+// response. Queries and malformed packets return errBadDNS.
+func parseDNS(msg []byte) ([]dnsRecord, error) {
+	if len(msg) < dnsHeaderSize || binary.BigEndian.Uint16(msg[2:])&dnsFlagResponse == 0 {
+		return nil, errBadDNS
+	}
+	questions := int(binary.BigEndian.Uint16(msg[4:]))
+	count := int(binary.BigEndian.Uint16(msg[6:])) + int(binary.BigEndian.Uint16(msg[8:])) + int(binary.BigEndian.Uint16(msg[10:]))
+
+	off := dnsHeaderSize
+	for i := 0; i < questions; i++ {
+		_, next, err := decodeName(msg, off)
+		if err != nil || next+4 > len(msg) {
+			return nil, errBadDNS
+		}
+		off = next + 4
+	}
+	records := make([]dnsRecord, 0, count)
+	for i := 0; i < count; i++ {
+		name, next, err := decodeName(msg, off)
+		if err != nil || next+10 > len(msg) {
+			return nil, errBadDNS
+		}
+		length := int(binary.BigEndian.Uint16(msg[next+8:]))
+		start := next + 10
+		if start+length > len(msg) {
+			return nil, errBadDNS
+		}
+		records = append(records, dnsRecord{
+			Name: name,
+			Type: binary.BigEndian.Uint16(msg[next:]),
+			Data: msg[start : start+length],
+		})
+		off = start + length
+	}
+	return records, nil
+}
+
+// encodeName encodes a dotted name as DNS labels, without compression
+func encodeName(name string) []byte {
+	var out []byte
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if label == "" {
+			continue
+		}
+		out = append(out, byte(len(label)))
+		out = append(out, label...)
+	}
+	return append(out, 0)
+}
+
+// decodeName reads a possibly compressed name at off, returning it dotted
+// and the offset just after it
+func decodeName(msg []byte, off int) (string, int, error) {
+	var labels []string
+	end, pointers := -1, 0
+	for {
+		if off >= len(msg) {
+			return "", 0, errBadDNS
+		}
+		length := int(msg[off])
+		switch {
+		case length == 0:
+			if end < 0 {
+				end = off + 1
+			}
+			return strings.Join(labels, ".") + ".", end, nil
+		case length&0xc0 == 0xc0:
+			if off+1 >= len(msg) || pointers >= maxNamePointers {
+				return "", 0, errBadDNS
+			}
+			if end < 0 {
+				end = off + 2
+			}
+			off = int(binary.BigEndian.Uint16(msg[off:]) & 0x3fff)
+			pointers++
+		case length > mdnsMaxLabel || off+1+length > len(msg):
+			return "", 0, errBadDNS
+		default:
+			labels = append(labels, string(msg[off+1:off+1+length]))
+			off += 1 + length
+		}
+	}
+}
+
+// encodeTXT encodes strings as TXT record data
+func encodeTXT(entries ...string) []byte {
+	var out []byte
+	for _, entry := range entries {
+		if len(entry) > 255 {
+			entry = entry[:255]
+		}
+		out = append(out, byte(len(entry)))
+		out = append(out, entry...)
+	}
+	return out
+}
+
+// decodeTXT splits TXT record data into its strings
+func decodeTXT(data []byte) []string {
+	var entries []string
+	for len(data) > 0 {
+		length := int(data[0])
+		if 1+length > len(data) {
+			break
+		}
+		entries = append(entries, string(data[1:1+length]))
+		data = data[1+length:]
+	}
+	return entries
+}
@@ -0,0 +1,73 @@
+package data
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMDNSAnnouncementRoundTrip(t *testing.T) {
+	long := strings.Repeat("é", 40) // 80 bytes, more than one label holds
+	for _, name := range []string{"default@den.local", long} {
+		msg, err := mdnsAnnouncement(LANPeer{Name: name, Addr: "192.168.1.20:4000"})
+		if err != nil {
+			t.Fatalf("mdnsAnnouncement failed: %v", err)
+		}
+		records, err := parseDNS(msg)
+		if err != nil || len(records) != 4 {
+			t.Fatalf("Expected PTR, SRV, TXT and A records, got %d (%v)", len(records), err)
+		}
+		if records[0].Name != mdnsServiceType || records[0].Type != dnsTypePTR {
+			t.Errorf("Expected the service type pointed at the instance first, got %+v", records[0])
+		}
+		if !utf8.ValidString(records[1].Name) {
+			t.Errorf("Expected a valid instance name, got %q", records[1].Name)
+		}
+		peers, err := parseMDNSPeers(msg)
+		if err != nil || len(peers) != 1 || peers[0].Name != name || peers[0].Addr != ":4000" {
+			t.Errorf("Expected %q on port 4000, got %+v (%v)", name, peers, err)
+		}
+	}
+}
+
+func TestParseMDNSCompressedNames(t *testing.T) {
+	// A response from another responder: the SRV owner points back at the
+	// service type in the PTR record
+	msg := make([]byte, dnsHeaderSize)
+	binary.BigEndian.PutUint16(msg[2:], dnsFlagResponse)
+	binary.BigEndian.PutUint16(msg[6:], 2)
+	serviceAt := len(msg)
+	msg = append(msg, encodeName(mdnsServiceType)...)
+	msg = append(msg, 0, dnsTypePTR, 0, dnsClassIN, 0, 0, 0, 120, 0, 2, 0xc0, byte(serviceAt))
+	msg = append(msg, 6)
+	msg = append(msg, "Attic!"...)
+	msg = append(msg, 0xc0, byte(serviceAt))
+	msg = append(msg, 0, dnsTypeSRV, 0, dnsClassIN, 0, 0, 0, 120, 0, 8, 0, 0, 0, 0, 0x1f, 0x90, 0xc0, byte(serviceAt))
+
+	peers, err := parseMDNSPeers(msg)
+	if err != nil || len(peers) != 1 || peers[0].Name != "Attic!" || peers[0].Addr != ":8080" {
+		t.Errorf("Expected Attic! on port 8080, got %+v (%v)", peers, err)
+	}
+}
+
+func TestParseDNSRejectsMalformedPackets(t *testing.T) {
+	query := make([]byte, dnsHeaderSize)
+	loop := make([]byte, dnsHeaderSize)
+	binary.BigEndian.PutUint16(loop[2:], dnsFlagResponse)
+	binary.BigEndian.PutUint16(loop[6:], 1)
+	loop = append(loop, 0xc0, dnsHeaderSize) // A name that points at itself
+	truncated, _ := mdnsAnnouncement(LANPeer{Name: "den", Addr: "10.0.0.1:4000"})
+
+	for name, msg := range map[string][]byte{
+		"text":      []byte("not an announcement"),
+		"query":     query,
+		"loop":      loop,
+		"truncated": truncated[:len(truncated)-3],
+	} {
+		if _, err := parseDNS(msg); !errors.Is(err, errBadDNS) {
+			t.Errorf("%s: expected errBadDNS, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Pet archive format
+const (
+	PetArchiveExt     = ".gochi"    // File extension of pet archives
+	PetArchiveFormat  = "gochi-pet" // Identifies a pet archive
+	PetArchiveVersion = 1           // Newest version this build writes and reads
+	archiveGzip       = "gzip"      // Compression of a compressed archive's save
+	maxArchiveSuffix  = 1000        // Collision suffixes tried before giving up
+)
+
+var (
+	// ErrNotPetArchive is returned when a file is not a pet archive
+	ErrNotPetArchive = errors.New("not a pet archive")
+	// ErrArchiveVersion is returned when an archive was written by a newer
+	// version of the game
+	ErrArchiveVersion = errors.New("pet archive is from a newer version")
+	// ErrArchiveChecksum is returned when an archive's save does not match
+	// its checksum, e.g. because the file was damaged on the way
+	ErrArchiveChecksum = errors.New("pet archive checksum mismatch")
+	// ErrPetExists is returned when importing a pet whose ID is already
+	// saved and the collision is to be refused
+	ErrPetExists = errors.New("pet already exists")
+)
+
+// PetArchive is a pet packed into one portable file for moving it to
+// another machine. The save travels whole, so the pet arrives with its
+// genome, pedigree, memories, skills and everything else it had. The
+// checksum covers the save before compression and ignores its whitespace,
+// which re-indenting the archive changes.
+type PetArchive struct {
+	Format      string          `json:"format"`  // Always PetArchiveFormat
+	Version     int             `json:"version"` // PetArchiveVersion when written
+	Exported    time.Time       `json:"exported"`
+	PetID       types.PetID     `json:"pet"`
+	Name        string          `json:"name"`
+	Compression string          `json:"compression,omitempty"` // "gzip", or empty when Save is plain JSON
+	Checksum    string          `json:"checksum"`              // Hex SHA-256 of the uncompressed save as compact JSON
+	Save        json.RawMessage `json:"save"`                  // The pet's save; base64 text when compressed
+}
+
+// ImportCollision is what an import does with a pet whose ID is already
+// saved, active or archived
+type ImportCollision int
+
+const (
+	CollisionRefuse   ImportCollision = iota // Import nothing and return ErrPetExists
+	CollisionReplace                         // Overwrite the active save; archived pets are still refused
+	CollisionKeepBoth                        // Import the pet under a new ID
+)
+
+// String returns the name the collision handling is chosen by
+func (c ImportCollision) String() string {
+	return [...]string{"refuse", "replace", "keep-both"}[c]
+}
+
+// ParseImportCollision looks up collision handling by the name String
+// gives it, ignoring case
+func ParseImportCollision(name string) (ImportCollision, error) {
+	for c := CollisionRefuse; c <= CollisionKeepBoth; c++ {
+		if strings.EqualFold(c.String(), strings.TrimSpace(name)) {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown collision handling %q", name)
+}
+
+// ExportPet packs a pet into a pet archive, compressing its save if asked.
+// Archives are never encrypted, so they can be opened with any passphrase.
+func ExportPet(pet *core.DigitalPet, compress bool) ([]byte, error) {
+	if err := ValidatePet(pet); err != nil {
+		return nil, err
+	}
+	save, err := pet.Save()
+	if err != nil {
+		return nil, err
+	}
+	if save, err = compactJSON(save); err != nil {
+		return nil, err
+	}
+	archive := PetArchive{
+		Format:   PetArchiveFormat,
+		Version:  PetArchiveVersion,
+		Exported: time.Now().UTC(),
+		PetID:    pet.ID,
+		Name:     pet.Name,
+		Checksum: checksum(save),
+		Save:     save,
+	}
+	if compress {
+		packed, err := compressSave(save)
+		if err != nil {
+			return nil, err
+		}
+		if archive.Save, err = json.Marshal(packed); err != nil {
+			return nil, err
+		}
+		archive.Compression = archiveGzip
+	}
+	return json.MarshalIndent(archive, "", "  ")
+}
+
+// IsPetArchive returns true if raw looks like a pet archive, of any version
+func IsPetArchive(raw []byte) bool {
+	var header struct {
+		Format string `json:"format"`
+	}
+	return json.Unmarshal(raw, &header) == nil && header.Format == PetArchiveFormat
+}
+
+// ReadPetArchive unpacks a pet archive, checking its version and checksum
+// and validating the pet, including that its genome and memories came
+// with it
+func ReadPetArchive(raw []byte) (*core.DigitalPet, PetArchive, error) {
+	var archive PetArchive
+	if err := json.Unmarshal(raw, &archive); err != nil || archive.Format != PetArchiveFormat {
+		return nil, archive, ErrNotPetArchive
+	}
+	if archive.Version < 1 || archive.Version > PetArchiveVersion {
+		return nil, archive, fmt.Errorf("%w: version %d, this build reads up to %d", ErrArchiveVersion, archive.Version, PetArchiveVersion)
+	}
+
+	save := []byte(archive.Save)
+	switch archive.Compression {
+	case "":
+	case archiveGzip:
+		var packed []byte
+		if err := json.Unmarshal(archive.Save, &packed); err != nil || !IsCompressed(packed) {
+			return nil, archive, fmt.Errorf("%w: %s: compressed save is not gzip", ErrCorruptSave, archive.PetID)
+		}
+		var err error
+		if save, err = decodeSave(packed); err != nil {
+			return nil, archive, fmt.Errorf("%s: %w", archive.PetID, err)
+		}
+	default:
+		return nil, archive, fmt.Errorf("%w: unknown compression %q", ErrArchiveVersion, archive.Compression)
+	}
+	compact, err := compactJSON(save)
+	if err != nil || checksum(compact) != archive.Checksum {
+		return nil, archive, fmt.Errorf("%w: %s", ErrArchiveChecksum, archive.PetID)
+	}
+
+	pet, err := DecodePet(archive.PetID, save)
+	if err != nil {
+		return nil, archive, err
+	}
+	if pet.ID != archive.PetID {
+		return nil, archive, fmt.Errorf("%w: archive of %s holds %s", ErrCorruptSave, archive.PetID, pet.ID)
+	}
+	return pet, archive, nil
+}
+
+// ImportPet unpacks a pet archive and saves the pet for owner, handling a
+// pet already saved under the same ID as collision says. Returns the pet as
+// saved, with its new ID when both were kept.
+func (dm *DataManager) ImportPet(ctx context.Context, raw []byte, owner types.UserID, collision ImportCollision) (*core.DigitalPet, error) {
+	pet, _, err := ReadPetArchive(raw)
+	if err != nil {
+		return nil, err
+	}
+	pet.Owner = owner
+
+	existing, err := dm.ListPets(ctx, FilterAll)
+	if err != nil {
+		return nil, err
+	}
+	exists := existsIn(existing)
+	summary := "imported from a pet archive"
+	if exists(pet.ID) {
+		switch collision {
+		case CollisionReplace:
+			summary = "replaced from a pet archive"
+		case CollisionKeepBoth:
+			id, err := freeID(pet.ID, exists)
+			if err != nil {
+				return nil, err
+			}
+			summary = fmt.Sprintf("imported from a pet archive of %s", pet.ID)
+			renumber(pet, id)
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrPetExists, pet.ID)
+		}
+	}
+
+	if err := dm.SavePet(ctx, pet); err != nil {
+		return nil, err
+	}
+	dm.Audit.Record(AuditEntry{Actor: ActorImport, Action: AuditImport, PetID: pet.ID, Summary: summary})
+	return pet, nil
+}
+
+// freeID returns the first of id_2, id_3, ... not yet saved
+func freeID(id types.PetID, exists func(types.PetID) bool) (types.PetID, error) {
+	for n := 2; n <= maxArchiveSuffix; n++ {
+		candidate := types.PetID(fmt.Sprintf("%s_%d", id, n))
+		if !exists(candidate) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s and %d copies", ErrPetExists, id, maxArchiveSuffix-1)
+}
+
+// renumber gives a pet a new ID, including in its pedigree
+func renumber(pet *core.DigitalPet, id types.PetID) {
+	pet.ID = id
+	if pet.Pedigree != nil {
+		pet.Pedigree.ID = id
+	}
+}
+
+// compactJSON removes the insignificant whitespace from a JSON document
+func compactJSON(doc []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestPetArchiveRoundTrip(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "owner")
+	pet.Memory.RecordMemory(ai.MemoryEvent, "found a stick", 0.5, 0.8, "joy", nil)
+
+	for _, compress := range []bool{false, true} {
+		raw, err := ExportPet(pet, compress)
+		if err != nil {
+			t.Fatalf("ExportPet failed: %v", err)
+		}
+		if !IsPetArchive(raw) {
+			t.Errorf("Expected an export to be recognised as a pet archive")
+		}
+		if compress != bytes.Contains(raw, []byte(`"compression": "gzip"`)) {
+			t.Errorf("Expected compression %v to be recorded, got:\n%s", compress, raw)
+		}
+		got, archive, err := ReadPetArchive(raw)
+		if err != nil {
+			t.Fatalf("ReadPetArchive failed: %v", err)
+		}
+		if archive.Version != PetArchiveVersion || got.ID != pet.ID || got.Name != "Rex" {
+			t.Errorf("Expected Rex back in a version %d archive, got %s in version %d", PetArchiveVersion, got.ID, archive.Version)
+		}
+		genome, _ := json.Marshal(pet.Genome)
+		gotGenome, _ := json.Marshal(got.Genome)
+		memories := got.Memory.GetRecentMemories(1)
+		if !bytes.Equal(genome, gotGenome) || len(memories) != 1 || memories[0].Description != "found a stick" {
+			t.Errorf("Expected the genome and memories to travel with the pet")
+		}
+	}
+}
+
+func TestReadPetArchiveRejectsBadArchives(t *testing.T) {
+	raw, err := ExportPet(core.NewDigitalPet("Rex", "owner"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit := func(change func(map[string]any)) []byte {
+		var fields map[string]any
+		json.Unmarshal(raw, &fields)
+		change(fields)
+		edited, _ := json.Marshal(fields)
+		return edited
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		want    error
+	}{
+		{"plain save", []byte(`{"id": "pet_1_Rex"}`), ErrNotPetArchive},
+		{"newer version", edit(func(f map[string]any) { f["version"] = PetArchiveVersion + 1 }), ErrArchiveVersion},
+		{"damaged", bytes.Replace(raw, []byte(`"Rex"`), []byte(`"Rox"`), -1), ErrArchiveChecksum},
+		{"bad compression", edit(func(f map[string]any) { f["compression"] = "gzip" }), ErrCorruptSave},
+		{"missing genome", edit(func(f map[string]any) {
+			save := f["save"].(map[string]any)
+			delete(save, "genome")
+			payload, _ := json.Marshal(save)
+			f["checksum"] = checksum(payload)
+			f["save"] = json.RawMessage(payload)
+		}), ErrInvalidPet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ReadPetArchive(tt.archive); !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestImportPetCollisions(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rex := core.NewDigitalPet("Rex", "owner")
+	if err := dm.SavePet(ctx, rex); err != nil {
+		t.Fatal(err)
+	}
+	rex.Name = "Rex the Traveller"
+	raw, err := ExportPet(rex, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dm.ImportPet(ctx, raw, "friend", CollisionRefuse); !errors.Is(err, ErrPetExists) {
+		t.Errorf("Expected the collision refused, got %v", err)
+	}
+
+	copied, err := dm.ImportPet(ctx, raw, "friend", CollisionKeepBoth)
+	if err != nil {
+		t.Fatalf("ImportPet failed: %v", err)
+	}
+	if copied.ID != rex.ID+"_2" || copied.Pedigree.ID != copied.ID || copied.Owner != "friend" {
+		t.Errorf("Expected a copy under %s_2 for friend, got %s (pedigree %s) for %s", rex.ID, copied.ID, copied.Pedigree.ID, copied.Owner)
+	}
+	if again, err := dm.ImportPet(ctx, raw, "friend", CollisionKeepBoth); err != nil || again.ID != rex.ID+"_3" {
+		t.Errorf("Expected a second copy under %s_3, got %v (%v)", rex.ID, again, err)
+	}
+
+	if _, err := dm.ImportPet(ctx, raw, "friend", CollisionReplace); err != nil {
+		t.Fatalf("ImportPet failed: %v", err)
+	}
+	if loaded, _ := dm.LoadPet(ctx, rex.ID); loaded == nil || loaded.Name != "Rex the Traveller" {
+		t.Errorf("Expected the original replaced, got %v", loaded)
+	}
+	if entries, _ := dm.Audit.Query(AuditQuery{Actor: ActorImport, Action: AuditImport}); len(entries) != 3 {
+		t.Errorf("Expected three imports by the import actor in the audit log, got %v", entries)
+	}
+
+	if err := dm.ArchivePet(ctx, rex); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.ImportPet(ctx, raw, "friend", CollisionReplace); !errors.Is(err, ErrPetArchived) {
+		t.Errorf("Expected an archived pet not to be replaced, got %v", err)
+	}
+}
+
+func TestParseImportCollision(t *testing.T) {
+	for c := CollisionRefuse; c <= CollisionKeepBoth; c++ {
+		if got, err := ParseImportCollision(c.String()); err != nil || got != c {
+			t.Errorf("Expected %s to parse, got %v (%v)", c, got, err)
+		}
+	}
+	if _, err := ParseImportCollision("overwrite"); err == nil {
+		t.Error("Expected an unknown name rejected")
+	}
+}
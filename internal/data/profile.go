@@ -0,0 +1,249 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Profile settings
+const (
+	DefaultProfile    = "default"      // Profile used when none is chosen
+	LegacyOwner       = "default-user" // Owner of pets saved before profiles existed
+	profilesName      = "profiles"     // Directory under the save root holding one directory per profile
+	currentName       = "current"      // File under profilesName naming the selected profile
+	ProfileConfigName = "config.yaml"  // A profile's own settings, read over the shared file
+	MaxProfileName    = 32             // Longest allowed profile name
+)
+
+var (
+	// ErrInvalidProfile is returned when a profile name is not allowed
+	ErrInvalidProfile = errors.New("invalid profile name")
+	// ErrProfileNotFound is returned when a profile has not been created
+	ErrProfileNotFound = errors.New("profile not found")
+	// ErrProfileExists is returned when creating a profile that already exists
+	ErrProfileExists = errors.New("profile already exists")
+)
+
+// profileNamePattern is what profile names may contain; they become
+// directory names, so nothing that could escape the profiles directory
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Profile is one player's pets, settings and cloud account on a shared
+// machine
+type Profile struct {
+	Name    string
+	Dir     string         // Save directory holding only this profile's pets
+	Backend StorageBackend // Where Dir is kept; nil uses the disk
+}
+
+// Owner returns the user that owns pets created in the profile. The default
+// profile keeps the owner pets had before profiles existed.
+func (p *Profile) Owner() types.UserID {
+	if p.Name == DefaultProfile {
+		return LegacyOwner
+	}
+	return types.UserID(p.Name)
+}
+
+// ConfigPath returns the profile's own settings file
+func (p *Profile) ConfigPath() string {
+	return filepath.Join(p.Dir, ProfileConfigName)
+}
+
+// storage returns the backend the profile is kept on
+func (p *Profile) storage() StorageBackend {
+	return backendOr(p.Backend)
+}
+
+// ProfileManager keeps profiles side by side under a save root, each in
+// its own directory, and remembers which one was last selected
+type ProfileManager struct {
+	Root    string
+	Backend StorageBackend  // Where Root is kept; nil uses the disk
+	Hooks   *LifecycleHooks // Told when legacy saves are migrated; nil tells no one
+}
+
+// NewProfileManager creates a manager for profiles under root on disk
+func NewProfileManager(root string) *ProfileManager {
+	return &ProfileManager{Root: root}
+}
+
+// ValidateProfileName checks that a name can be used for a profile
+func ValidateProfileName(name string) error {
+	if len(name) > MaxProfileName || !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q (use up to %d lowercase letters, digits, - and _)", ErrInvalidProfile, name, MaxProfileName)
+	}
+	return nil
+}
+
+// Create makes a new, empty profile
+func (pm *ProfileManager) Create(name string) (*Profile, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+	profile := pm.profile(name)
+	if _, err := pm.storage().Stat(profile.Dir); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProfileExists, name)
+	}
+	if err := pm.storage().MkdirAll(profile.Dir); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Get returns an existing profile
+func (pm *ProfileManager) Get(name string) (*Profile, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+	profile := pm.profile(name)
+	info, err := pm.storage().Stat(profile.Dir)
+	if os.IsNotExist(err) || (err == nil && !info.IsDir()) {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Open returns the profile to play. An empty name opens the selected
+// profile. The default profile is created on first use; any other profile
+// must have been created first.
+func (pm *ProfileManager) Open(name string) (*Profile, error) {
+	if name == "" {
+		current, err := pm.Current()
+		if err != nil {
+			return nil, err
+		}
+		name = current
+	}
+	profile, err := pm.Get(name)
+	if errors.Is(err, ErrProfileNotFound) && name == DefaultProfile {
+		return pm.Create(name)
+	}
+	return profile, err
+}
+
+// List returns the names of every profile in sorted order
+func (pm *ProfileManager) List() ([]string, error) {
+	entries, err := pm.storage().ReadDir(pm.profilesPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Current returns the selected profile, or DefaultProfile if none has been
+// selected
+func (pm *ProfileManager) Current() (string, error) {
+	content, err := pm.storage().ReadFile(filepath.Join(pm.profilesPath(), currentName))
+	if os.IsNotExist(err) {
+		return DefaultProfile, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	name := strings.TrimSpace(string(content))
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// Switch selects the profile played when none is named
+func (pm *ProfileManager) Switch(name string) error {
+	if _, err := pm.Get(name); err != nil {
+		return err
+	}
+	path := filepath.Join(pm.profilesPath(), currentName)
+	if err := pm.storage().WriteFile(path+tempExt, []byte(name+"\n")); err != nil {
+		return err
+	}
+	return pm.storage().Rename(path+tempExt, path)
+}
+
+// MigrateLegacy moves saves from the flat layout used before profiles
+// existed, where pets were kept directly in the save root, into the
+// default profile. Moves are renames, so an interrupted migration is
+// finished by running it again. Returns how many entries were moved. The
+// lifecycle hooks are told of any migration, finished or not.
+func (pm *ProfileManager) MigrateLegacy() (int, error) {
+	moved, err := pm.migrateLegacy()
+	if moved > 0 || err != nil {
+		pm.Hooks.emit(LifecycleEvent{Type: LifecycleMigration, Path: pm.Root,
+			Summary: fmt.Sprintf("moved %d saved entries into the %s profile", moved, DefaultProfile)}, err)
+	}
+	return moved, err
+}
+
+// migrateLegacy implements MigrateLegacy
+func (pm *ProfileManager) migrateLegacy() (int, error) {
+	entries, err := pm.storage().ReadDir(pm.Root)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var legacy []string
+	for _, entry := range entries {
+		if entry.Name() != profilesName {
+			legacy = append(legacy, entry.Name())
+		}
+	}
+	if len(legacy) == 0 {
+		return 0, nil
+	}
+
+	target := pm.profile(DefaultProfile)
+	if err := pm.storage().MkdirAll(target.Dir); err != nil {
+		return 0, err
+	}
+	moved := 0
+	for _, name := range legacy {
+		dest := filepath.Join(target.Dir, name)
+		if _, err := pm.storage().Stat(dest); err == nil {
+			return moved, fmt.Errorf("cannot migrate %s: %s already exists in the %s profile", name, name, DefaultProfile)
+		}
+		if err := pm.storage().Rename(filepath.Join(pm.Root, name), dest); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+// profile returns the profile with a name, whether or not it exists
+func (pm *ProfileManager) profile(name string) *Profile {
+	return &Profile{Name: name, Dir: filepath.Join(pm.profilesPath(), name), Backend: pm.Backend}
+}
+
+// storage returns the backend profiles are kept on
+func (pm *ProfileManager) storage() StorageBackend {
+	return backendOr(pm.Backend)
+}
+
+// profilesPath returns the directory profiles are kept in
+func (pm *ProfileManager) profilesPath() string {
+	return filepath.Join(pm.Root, profilesName)
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestProfileManager(t *testing.T) {
+	pm := NewProfileManager(t.TempDir())
+
+	if _, err := pm.Create("../escape"); !errors.Is(err, ErrInvalidProfile) {
+		t.Errorf("Expected ErrInvalidProfile for a path, got %v", err)
+	}
+	if _, err := pm.Open("alice"); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("Expected ErrProfileNotFound before creating, got %v", err)
+	}
+	alice, err := pm.Create("alice")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := pm.Create("alice"); !errors.Is(err, ErrProfileExists) {
+		t.Errorf("Expected ErrProfileExists, got %v", err)
+	}
+	if alice.Owner() != "alice" {
+		t.Errorf("Expected pets owned by alice, got %s", alice.Owner())
+	}
+
+	// The default profile is created on first use and keeps the old owner
+	def, err := pm.Open("")
+	if err != nil || def.Name != DefaultProfile || def.Owner() != LegacyOwner {
+		t.Fatalf("Expected the default profile, got %+v (%v)", def, err)
+	}
+
+	if err := pm.Switch("alice"); err != nil {
+		t.Fatalf("Switch failed: %v", err)
+	}
+	if current, _ := pm.Open(""); current.Name != "alice" {
+		t.Errorf("Expected alice after switching, got %s", current.Name)
+	}
+	if names, _ := pm.List(); len(names) != 2 || names[0] != "alice" || names[1] != DefaultProfile {
+		t.Errorf("Expected [alice default], got %v", names)
+	}
+}
+
+func TestProfilesKeepSavesApart(t *testing.T) {
+	ctx := context.Background()
+	pm := NewProfileManager(t.TempDir())
+	alice, _ := pm.Create("alice")
+	bob, _ := pm.Create("bob")
+
+	dm, _ := NewDataManager(alice.Dir)
+	if err := dm.SavePet(ctx, core.NewDigitalPet("Mochi", alice.Owner())); err != nil {
+		t.Fatal(err)
+	}
+	other, _ := NewDataManager(bob.Dir)
+	if ids, _ := other.ListPets(ctx, FilterAll); len(ids) != 0 {
+		t.Errorf("Expected bob to see none of alice's pets, got %v", ids)
+	}
+}
+
+func TestMigrateLegacyLayout(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	legacy, _ := NewDataManager(root)
+	pet := core.NewDigitalPet("Mochi", LegacyOwner)
+	if err := legacy.SavePet(ctx, pet); err != nil {
+		t.Fatal(err)
+	}
+
+	pm := NewProfileManager(root)
+	moved, err := pm.MigrateLegacy()
+	if err != nil || moved == 0 {
+		t.Fatalf("Expected the legacy save to be moved, got %d (%v)", moved, err)
+	}
+	if again, _ := pm.MigrateLegacy(); again != 0 {
+		t.Errorf("Expected nothing left to migrate, moved %d", again)
+	}
+	if _, err := os.Stat(filepath.Join(root, filepath.Base(legacy.petPath(pet.ID)))); !os.IsNotExist(err) {
+		t.Error("Expected the legacy save to leave the save root")
+	}
+
+	profile, err := pm.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dm, _ := NewDataManager(profile.Dir)
+	loaded, err := dm.LoadPet(ctx, pet.ID)
+	if err != nil || loaded.Owner != LegacyOwner {
+		t.Errorf("Expected the migrated pet in the default profile, got %v", err)
+	}
+}
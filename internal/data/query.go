@@ -0,0 +1,361 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Paging settings
+const (
+	DefaultPageSize = 50  // Entries returned by a query without a limit
+	MaxPageSize     = 500 // Largest limit a query may ask for
+)
+
+// ErrInvalidQuery is returned for a listing query that cannot be answered
+var ErrInvalidQuery = errors.New("invalid query")
+
+// AliveFilter selects pets by whether they are alive
+type AliveFilter int
+
+const (
+	AnyAlive AliveFilter = iota
+	OnlyAlive
+	OnlyDead
+)
+
+// String returns the string representation of AliveFilter
+func (f AliveFilter) String() string {
+	return [...]string{"Any", "Alive", "Dead"}[f]
+}
+
+// PetSort is the order a pet listing is returned in
+type PetSort int
+
+const (
+	SortByID PetSort = iota
+	SortByName
+	SortByLastPlayed
+	SortByAge
+)
+
+// String returns the string representation of PetSort
+func (s PetSort) String() string {
+	return [...]string{"ID", "Name", "LastPlayed", "Age"}[s]
+}
+
+// ParsePetSort returns the sort order with the given name
+func ParsePetSort(name string) (PetSort, error) {
+	for s := SortByID; s <= SortByAge; s++ {
+		if strings.EqualFold(strings.ReplaceAll(name, "-", ""), s.String()) {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown sort %q", ErrInvalidQuery, name)
+}
+
+// PetQuery selects, orders and pages saved pets; zero fields match
+// everything and return the first page in ID order
+type PetQuery struct {
+	Filter PetFilter
+	Owner  types.UserID
+	Alive  AliveFilter
+	Biomes []environment.Biome   // Biomes of the pets' current locations
+	World  *environment.WorldMap // Resolves locations to biomes; required with Biomes
+
+	Sort       PetSort
+	Descending bool
+	Offset     int
+	Limit      int // 0 uses DefaultPageSize
+}
+
+// PetSummary is what a listing shows of a pet without loading it
+type PetSummary struct {
+	ID         types.PetID  `json:"id"`
+	Name       string       `json:"name"`
+	Owner      types.UserID `json:"owner"`
+	Alive      bool         `json:"alive"`
+	Archived   bool         `json:"archived"`
+	Age        float64      `json:"age"` // In days
+	Location   string       `json:"location"`
+	LastPlayed time.Time    `json:"last_played"`
+}
+
+// PetPage is one page of a pet listing
+type PetPage struct {
+	Pets  []PetSummary `json:"pets"`
+	Total int          `json:"total"` // Pets matching the query across all pages
+	Next  int          `json:"next"`  // Offset of the next page; 0 on the last
+}
+
+// summaryFields are the parts of a save a summary is read from
+type summaryFields struct {
+	Name         string       `json:"name"`
+	Owner        types.UserID `json:"owner"`
+	Location     string       `json:"location"`
+	LastUpdateAt time.Time    `json:"last_update_at"`
+	Biology      *struct {
+		IsAlive   bool
+		Processes *struct {
+			Age float64 `json:"age"`
+		}
+	} `json:"biology"`
+}
+
+// cachedSummary is a summary along with the save it was read from
+type cachedSummary struct {
+	summary PetSummary
+	modTime time.Time
+	size    int64
+}
+
+// page returns the bounds of the requested page of total entries
+func page(offset, limit, total int) (start, end, next int, err error) {
+	if offset < 0 || limit < 0 || limit > MaxPageSize {
+		return 0, 0, 0, fmt.Errorf("%w: offset %d, limit %d", ErrInvalidQuery, offset, limit)
+	}
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	start = min(offset, total)
+	end = min(start+limit, total)
+	if end < total {
+		next = end
+	}
+	return start, end, next, nil
+}
+
+// matches reports whether a summary is selected by the query
+func (q PetQuery) matches(s PetSummary) bool {
+	if q.Owner != "" && s.Owner != q.Owner {
+		return false
+	}
+	if (q.Alive == OnlyAlive && !s.Alive) || (q.Alive == OnlyDead && s.Alive) {
+		return false
+	}
+	if len(q.Biomes) == 0 {
+		return true
+	}
+	loc, exists := q.World.Location(s.Location)
+	if !exists {
+		return false
+	}
+	for _, biome := range q.Biomes {
+		if loc.Biome == biome {
+			return true
+		}
+	}
+	return false
+}
+
+// less orders two summaries by the query's sort, breaking ties by ID
+func (q PetQuery) less(a, b PetSummary) bool {
+	switch q.Sort {
+	case SortByName:
+		if x, y := strings.ToLower(a.Name), strings.ToLower(b.Name); x != y {
+			return x < y
+		}
+	case SortByLastPlayed:
+		if !a.LastPlayed.Equal(b.LastPlayed) {
+			return a.LastPlayed.Before(b.LastPlayed)
+		}
+	case SortByAge:
+		if a.Age != b.Age {
+			return a.Age < b.Age
+		}
+	}
+	return a.ID < b.ID
+}
+
+// QueryPets returns one page of the saved pets matching a query. Only the
+// fields a summary needs are decoded, and summaries are cached until their
+// save changes, so listing hundreds of pets does not load every one.
+// Saves that cannot be read are left out and reported in the error
+// alongside the page.
+func (dm *DataManager) QueryPets(ctx context.Context, q PetQuery) (PetPage, error) {
+	if len(q.Biomes) > 0 && q.World == nil {
+		return PetPage{}, fmt.Errorf("%w: biome filter needs a world map", ErrInvalidQuery)
+	}
+	if _, _, _, err := page(q.Offset, q.Limit, 0); err != nil {
+		return PetPage{}, err
+	}
+	ids, err := dm.ListPets(ctx, q.Filter)
+	if err != nil {
+		return PetPage{}, err
+	}
+
+	var matched []PetSummary
+	var errs []error
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return PetPage{}, err
+		}
+		archived := q.Filter == FilterArchived || (q.Filter == FilterAll && dm.archived(id))
+		summary, err := dm.summary(id, archived)
+		if err != nil {
+			errs = append(errs, petError(id, err))
+			continue
+		}
+		if q.matches(summary) {
+			matched = append(matched, summary)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if q.Descending {
+			return q.less(matched[j], matched[i])
+		}
+		return q.less(matched[i], matched[j])
+	})
+
+	start, end, next, _ := page(q.Offset, q.Limit, len(matched))
+	return PetPage{
+		Pets:  append([]PetSummary{}, matched[start:end]...),
+		Total: len(matched),
+		Next:  next,
+	}, errors.Join(errs...)
+}
+
+// summary returns a pet's summary, reading its save only if it changed
+// since the summary was cached
+func (dm *DataManager) summary(id types.PetID, archived bool) (PetSummary, error) {
+	path := dm.petPath(id)
+	if archived {
+		path = dm.archivedPetPath(id)
+	}
+	info, err := dm.storage().Stat(path)
+	if err != nil {
+		return PetSummary{}, err
+	}
+
+	dm.summaryMu.Lock()
+	cached, exists := dm.summaries[path]
+	dm.summaryMu.Unlock()
+	if exists && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.summary, nil
+	}
+
+	payload, err := dm.readSave(path)
+	if err != nil {
+		return PetSummary{}, err
+	}
+	var fields summaryFields
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return PetSummary{}, fmt.Errorf("%w: %v", ErrCorruptSave, err)
+	}
+	summary := PetSummary{
+		ID:         id,
+		Name:       fields.Name,
+		Owner:      fields.Owner,
+		Archived:   archived,
+		Location:   fields.Location,
+		LastPlayed: fields.LastUpdateAt,
+	}
+	if fields.Biology != nil {
+		summary.Alive = fields.Biology.IsAlive
+		if fields.Biology.Processes != nil {
+			summary.Age = fields.Biology.Processes.Age
+		}
+	}
+
+	dm.summaryMu.Lock()
+	if dm.summaries == nil {
+		dm.summaries = make(map[string]cachedSummary)
+	}
+	dm.summaries[path] = cachedSummary{summary: summary, modTime: info.ModTime(), size: info.Size()}
+	dm.summaryMu.Unlock()
+	return summary, nil
+}
+
+// BackupQuery selects and pages backups; zero fields match everything and
+// return the first page, oldest first
+type BackupQuery struct {
+	Since       time.Time // Backups taken at or after Since
+	Until       time.Time // Backups taken before Until
+	NewestFirst bool
+	Offset      int
+	Limit       int // 0 uses DefaultPageSize
+}
+
+// BackupInfo describes one backup
+type BackupInfo struct {
+	Name  string    `json:"name"`
+	Taken time.Time `json:"taken"`
+}
+
+// BackupPage is one page of a backup listing
+type BackupPage struct {
+	Backups []BackupInfo `json:"backups"`
+	Total   int          `json:"total"` // Backups matching the query across all pages
+	Next    int          `json:"next"`  // Offset of the next page; 0 on the last
+}
+
+// backupTime returns when a backup was taken from its name, which may
+// carry a collision suffix and an archive extension
+func backupTime(name string) (time.Time, bool) {
+	stamp := strings.TrimPrefix(strings.TrimSuffix(name, BackupArchiveExt), backupPrefix)
+	if len(stamp) < len(backupTimestamp) || !strings.HasPrefix(name, backupPrefix) {
+		return time.Time{}, false
+	}
+	taken, err := time.Parse(backupTimestamp, stamp[:len(backupTimestamp)])
+	return taken, err == nil
+}
+
+// apply filters and pages backup names given oldest first
+func (q BackupQuery) apply(names []string) (BackupPage, error) {
+	var matched []BackupInfo
+	for _, name := range names {
+		taken, ok := backupTime(name)
+		if !ok || taken.Before(q.Since) || (!q.Until.IsZero() && !taken.Before(q.Until)) {
+			continue
+		}
+		matched = append(matched, BackupInfo{Name: name, Taken: taken})
+	}
+	if q.NewestFirst {
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+	}
+	start, end, next, err := page(q.Offset, q.Limit, len(matched))
+	if err != nil {
+		return BackupPage{}, err
+	}
+	return BackupPage{Backups: matched[start:end], Total: len(matched), Next: next}, nil
+}
+
+// ListBackups returns one page of the backups Backup made under dir
+func ListBackups(ctx context.Context, storage StorageBackend, dir string, q BackupQuery) (BackupPage, error) {
+	if err := ctx.Err(); err != nil {
+		return BackupPage{}, err
+	}
+	entries, err := storage.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return BackupPage{}, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), backupPrefix) {
+			names = append(names, entry.Name())
+		}
+	}
+	// ReadDir sorts by name, which puts a backup before those given a
+	// collision suffix in the same second
+	return q.apply(names)
+}
+
+// Query returns one page of the remote backups
+func (b *CloudBackups) Query(ctx context.Context, q BackupQuery) (BackupPage, error) {
+	names, err := b.List(ctx)
+	if err != nil {
+		return BackupPage{}, err
+	}
+	return q.apply(names)
+}
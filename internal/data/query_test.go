@@ -0,0 +1,198 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// savePets saves pets named in order, each a day older and played with an
+// hour later than the last
+func savePets(t *testing.T, dm *DataManager, owner types.UserID, names ...string) []*core.DigitalPet {
+	t.Helper()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var pets []*core.DigitalPet
+	for i, name := range names {
+		pet := core.NewDigitalPet(name, owner)
+		pet.Biology.Processes.Age = float64(i + 1)
+		pet.LastUpdateAt = start.Add(time.Duration(i) * time.Hour)
+		if err := dm.SavePet(context.Background(), pet); err != nil {
+			t.Fatal(err)
+		}
+		pets = append(pets, pet)
+	}
+	return pets
+}
+
+// names returns the names on a page
+func names(page PetPage) []string {
+	var out []string
+	for _, pet := range page.Pets {
+		out = append(out, pet.Name)
+	}
+	return out
+}
+
+func TestQueryPetsPagesAndSorts(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	savePets(t, dm, "owner", "Cleo", "alfie", "Bean", "Dot", "Echo")
+
+	first, err := dm.QueryPets(ctx, PetQuery{Sort: SortByName, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(first); len(got) != 2 || got[0] != "alfie" || got[1] != "Bean" {
+		t.Errorf("Expected [alfie Bean], got %v", got)
+	}
+	if first.Total != 5 || first.Next != 2 {
+		t.Errorf("Expected 5 pets with the next page at 2, got %d and %d", first.Total, first.Next)
+	}
+	last, err := dm.QueryPets(ctx, PetQuery{Sort: SortByName, Offset: 4, Limit: 2})
+	if err != nil || len(last.Pets) != 1 || last.Pets[0].Name != "Echo" || last.Next != 0 {
+		t.Errorf("Expected the last page to hold only Echo, got %v (%v)", names(last), err)
+	}
+
+	oldest, err := dm.QueryPets(ctx, PetQuery{Sort: SortByAge, Descending: true, Limit: 1})
+	if err != nil || oldest.Pets[0].Name != "Echo" || oldest.Pets[0].Age != 5 {
+		t.Errorf("Expected Echo to be oldest, got %+v (%v)", oldest.Pets, err)
+	}
+	recent, err := dm.QueryPets(ctx, PetQuery{Sort: SortByLastPlayed, Descending: true, Limit: 1})
+	if err != nil || recent.Pets[0].Name != "Echo" {
+		t.Errorf("Expected Echo to be played with last, got %+v (%v)", recent.Pets, err)
+	}
+
+	if _, err := dm.QueryPets(ctx, PetQuery{Limit: MaxPageSize + 1}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("Expected ErrInvalidQuery for an oversized page, got %v", err)
+	}
+	if _, err := dm.QueryPets(ctx, PetQuery{Offset: -1}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("Expected ErrInvalidQuery for a negative offset, got %v", err)
+	}
+}
+
+func TestQueryPetsFilters(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine := savePets(t, dm, "ana", "Fern", "Moss")
+	theirs := savePets(t, dm, "ben", "Pip")
+
+	mine[1].Biology.IsAlive = false
+	mine[1].Location = "beach"
+	if err := dm.SavePet(ctx, mine[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.ArchivePet(ctx, theirs[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	owned, err := dm.QueryPets(ctx, PetQuery{Owner: "ana"})
+	if err != nil || owned.Total != 2 {
+		t.Errorf("Expected ana's 2 pets, got %v (%v)", names(owned), err)
+	}
+	dead, err := dm.QueryPets(ctx, PetQuery{Alive: OnlyDead})
+	if err != nil || dead.Total != 1 || dead.Pets[0].Name != "Moss" {
+		t.Errorf("Expected only Moss to be dead, got %v (%v)", names(dead), err)
+	}
+	archived, err := dm.QueryPets(ctx, PetQuery{Filter: FilterArchived})
+	if err != nil || archived.Total != 1 || !archived.Pets[0].Archived || archived.Pets[0].Owner != "ben" {
+		t.Errorf("Expected Pip to be archived, got %+v (%v)", archived.Pets, err)
+	}
+	all, err := dm.QueryPets(ctx, PetQuery{Filter: FilterAll, Alive: OnlyAlive})
+	if err != nil || all.Total != 2 {
+		t.Errorf("Expected Fern and Pip alive across both, got %v (%v)", names(all), err)
+	}
+
+	coast, err := dm.QueryPets(ctx, PetQuery{Biomes: []environment.Biome{environment.BiomeOcean},
+		World: environment.DefaultWorldMap()})
+	if err != nil || coast.Total != 1 || coast.Pets[0].Name != "Moss" {
+		t.Errorf("Expected only Moss on the coast, got %v (%v)", names(coast), err)
+	}
+	if _, err := dm.QueryPets(ctx, PetQuery{Biomes: []environment.Biome{environment.BiomeOcean}}); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("Expected ErrInvalidQuery for a biome filter without a map, got %v", err)
+	}
+}
+
+func TestQueryPetsRereadsChangedSaves(t *testing.T) {
+	ctx := context.Background()
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pets := savePets(t, dm, "owner", "Taro")
+	if _, err := dm.QueryPets(ctx, PetQuery{}); err != nil {
+		t.Fatal(err)
+	}
+
+	pets[0].Name = "Taro the Great"
+	if err := dm.SavePet(ctx, pets[0]); err != nil {
+		t.Fatal(err)
+	}
+	page, err := dm.QueryPets(ctx, PetQuery{})
+	if err != nil || page.Pets[0].Name != "Taro the Great" {
+		t.Errorf("Expected the renamed pet, got %v (%v)", names(page), err)
+	}
+
+	os.WriteFile(dm.petPath(pets[0].ID), []byte("{"), 0o644)
+	page, err = dm.QueryPets(ctx, PetQuery{})
+	if !errors.Is(err, ErrCorruptSave) || page.Total != 0 {
+		t.Errorf("Expected the corrupt save to be reported and left out, got %v (%v)", names(page), err)
+	}
+}
+
+func TestListBackupsPages(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, name := range []string{"backup-20260101-000000", "backup-20260102-000000", "backup-20260102-000000.1",
+		"backup-20260103-000000", "notes"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := ListBackups(ctx, DiskBackend{}, dir, BackupQuery{NewestFirst: true, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 4 || page.Next != 2 || page.Backups[0].Name != "backup-20260103-000000" ||
+		page.Backups[1].Name != "backup-20260102-000000.1" {
+		t.Errorf("Expected the two newest of 4 backups, got %+v", page)
+	}
+
+	since := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	page, err = ListBackups(ctx, DiskBackend{}, dir, BackupQuery{Since: since, Until: since.Add(24 * time.Hour)})
+	if err != nil || page.Total != 2 || !page.Backups[0].Taken.Equal(since) {
+		t.Errorf("Expected the 2 backups from January 2nd, got %+v (%v)", page, err)
+	}
+
+	page, err = ListBackups(ctx, DiskBackend{}, filepath.Join(dir, "missing"), BackupQuery{})
+	if err != nil || page.Total != 0 {
+		t.Errorf("Expected no backups in a missing directory, got %+v (%v)", page, err)
+	}
+}
+
+func TestCloudBackupsQuery(t *testing.T) {
+	ctx := context.Background()
+	provider := NewMemoryProvider()
+	for _, day := range []string{"01", "02", "03"} {
+		provider.Upload(ctx, backupPrefix+"202601"+day+"-000000"+BackupArchiveExt, []byte("x"))
+	}
+
+	page, err := NewCloudBackups(provider, 0).Query(ctx, BackupQuery{Offset: 1, Limit: 1})
+	if err != nil || page.Total != 3 || len(page.Backups) != 1 || page.Next != 2 ||
+		page.Backups[0].Taken.Day() != 2 {
+		t.Errorf("Expected the second of 3 remote backups, got %+v (%v)", page, err)
+	}
+}
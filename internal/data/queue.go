@@ -0,0 +1,165 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// ErrQueueClosed is returned when a save is queued after shutdown began
+var ErrQueueClosed = errors.New("save queue is closed")
+
+// saveRequest is a snapshot of pets waiting to be written together
+type saveRequest struct {
+	pets []PetPayload
+}
+
+// SaveQueue writes pet saves in the background. Pets are serialized when
+// queued, so the caller may keep mutating them while the write happens.
+type SaveQueue struct {
+	mu       sync.Mutex
+	data     *DataManager
+	requests chan saveRequest
+	pending  int        // Saves accepted but not yet written
+	idle     *sync.Cond // Signalled on mu when pending drops to zero
+	done     chan struct{}
+	closed   bool
+	errs     []error
+}
+
+// NewSaveQueue starts a background writer holding up to size queued saves
+func NewSaveQueue(dm *DataManager, size int) *SaveQueue {
+	q := &SaveQueue{
+		data:     dm,
+		requests: make(chan saveRequest, size),
+		done:     make(chan struct{}),
+	}
+	q.idle = sync.NewCond(&q.mu)
+	go q.run()
+	return q
+}
+
+// Enqueue validates and snapshots a pet for writing. It blocks while the
+// queue is full, until there is room or ctx is cancelled.
+func (q *SaveQueue) Enqueue(ctx context.Context, pet *core.DigitalPet) error {
+	return q.EnqueueAll(ctx, []*core.DigitalPet{pet})
+}
+
+// EnqueueAll validates and snapshots several pets to be written as one
+// transaction. If any pet is invalid nothing is queued.
+func (q *SaveQueue) EnqueueAll(ctx context.Context, pets []*core.DigitalPet) error {
+	if len(pets) == 0 {
+		return nil
+	}
+	req := saveRequest{pets: make([]PetPayload, 0, len(pets))}
+	for _, pet := range pets {
+		if err := ValidatePet(pet); err != nil {
+			return err
+		}
+		payload, err := pet.Save()
+		if err != nil {
+			return err
+		}
+		req.pets = append(req.pets, PetPayload{ID: pet.ID, Payload: payload})
+	}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return ErrQueueClosed
+	}
+	q.pending++
+	q.mu.Unlock()
+
+	select {
+	case q.requests <- req:
+		return nil
+	case <-ctx.Done():
+		q.finish(nil)
+		return ctx.Err()
+	}
+}
+
+// Flush waits until every queued save has been written, including saves
+// queued while it waits, and returns any write errors since the last flush
+func (q *SaveQueue) Flush() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.pending > 0 {
+		q.idle.Wait()
+	}
+	err := errors.Join(q.errs...)
+	q.errs = nil
+	return err
+}
+
+// Close stops accepting saves, writes everything already queued and stops
+// the background writer
+func (q *SaveQueue) Close() error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	q.mu.Unlock()
+
+	err := q.Flush()
+	close(q.requests)
+	<-q.done
+	return err
+}
+
+// run writes queued saves until the queue is closed. An accepted save is
+// always written, whatever happened to the context it was queued with.
+func (q *SaveQueue) run() {
+	defer close(q.done)
+	for req := range q.requests {
+		q.finish(q.write(req))
+	}
+}
+
+// finish records that an accepted save was written, or given up on, and
+// wakes Flush once nothing is pending
+func (q *SaveQueue) finish(err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err != nil {
+		q.errs = append(q.errs, err)
+	}
+	q.pending--
+	if q.pending == 0 {
+		q.idle.Broadcast()
+	}
+}
+
+// write writes a single save through the journal and several as a
+// transaction, then tells the lifecycle hooks. Pets archived since they
+// were queued have left the game and are skipped.
+func (q *SaveQueue) write(req saveRequest) error {
+	pets := make([]PetPayload, 0, len(req.pets))
+	for _, p := range req.pets {
+		if !q.data.IsArchived(p.ID) {
+			pets = append(pets, p)
+		}
+	}
+
+	var err error
+	switch len(pets) {
+	case 0:
+		return nil
+	case 1:
+		err = q.data.WritePet(context.Background(), pets[0].ID, pets[0].Payload)
+	default:
+		err = q.data.WriteAll(context.Background(), pets)
+	}
+	summary := fmt.Sprintf("saved %d pets", len(pets))
+	if err != nil {
+		summary = fmt.Sprintf("could not save %d pets", len(pets))
+	}
+	q.data.Hooks.emit(LifecycleEvent{Type: LifecycleSave, Pets: len(pets), Summary: summary}, err)
+	return err
+}
@@ -0,0 +1,101 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestSaveQueueSnapshotsAndFlushes(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	q := NewSaveQueue(dm, 4)
+
+	pet := core.NewDigitalPet("Mochi", "owner")
+	if err := q.Enqueue(context.Background(), pet); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	pet.Name = "Changed after queueing"
+
+	if err := q.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	loaded, err := dm.LoadPet(context.Background(), pet.ID)
+	if err != nil || loaded.Name != "Mochi" {
+		t.Errorf("Expected the queued snapshot to be written, got %v (%v)", loaded, err)
+	}
+
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := q.Enqueue(context.Background(), pet); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected ErrQueueClosed, got %v", err)
+	}
+}
+
+func TestEnqueueGivesUpWhenCancelled(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	q := NewSaveQueue(dm, 1)
+	pet := core.NewDigitalPet("Mochi", "owner")
+
+	// Stall the writer so the queue fills up
+	dm.mu.Lock()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var err error
+	for i := 0; i < 3; i++ {
+		err = q.Enqueue(ctx, pet)
+	}
+	dm.mu.Unlock()
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the last save to give up, got %v", err)
+	}
+	if err := q.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+func TestFlushWhileEnqueueing(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	q := NewSaveQueue(dm, 4)
+	pet := core.NewDigitalPet("Mochi", "owner")
+
+	// An auto-save queueing while a backup flushes must neither panic nor
+	// leave a save unwritten once the last Flush returns
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if err := q.EnqueueAll(context.Background(), []*core.DigitalPet{pet}); err != nil {
+				t.Errorf("EnqueueAll failed: %v", err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if err := q.Flush(); err != nil {
+				t.Errorf("Flush failed: %v", err)
+			}
+		}
+	}()
+	wg.Wait()
+
+	if err := q.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	q.mu.Lock()
+	pending := q.pending
+	q.mu.Unlock()
+	if pending != 0 {
+		t.Errorf("Expected nothing pending after Flush, got %d", pending)
+	}
+	if err := q.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
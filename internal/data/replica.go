@@ -0,0 +1,437 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Replication settings
+const (
+	DefaultReplicaQueueSize  = 64               // Uploads that can wait for the secondary
+	DefaultReplicaTimeout    = 30 * time.Second // Limit on each upload to the secondary
+	MaxRecordedReplicaErrors = 32               // Secondary failures kept until the next flush
+)
+
+// replicaUpload is an upload waiting to be copied to the secondary
+type replicaUpload struct {
+	name    string
+	payload []byte
+}
+
+// copyDirection is which way Reconcile copies an object
+type copyDirection int
+
+const (
+	copyNone copyDirection = iota
+	copyToPrimary
+	copyToSecondary
+)
+
+// ReconcileReport lists the objects a reconciliation copied
+type ReconcileReport struct {
+	ToPrimary   []string
+	ToSecondary []string
+}
+
+// ReplicatedProvider is a CloudProvider backed by a primary and a
+// secondary provider, so an outage of either does not block play. Uploads
+// go to the primary and are copied to the secondary in the background;
+// while the primary is unavailable they go straight to the secondary
+// instead. Reads fail over to the secondary when the primary is
+// unavailable. Objects written to only one side are remembered, and
+// Reconcile copies them across once both are reachable.
+type ReplicatedProvider struct {
+	Primary   CloudProvider
+	Secondary CloudProvider
+	Timeout   time.Duration // Limit on each background upload; 0 waits indefinitely
+
+	// writing is held by uploads and for the whole of a reconciliation so
+	// that a reconciliation never copies an object over a newer write
+	writing sync.Mutex
+
+	mu              sync.Mutex
+	primaryBehind   map[string]bool // Objects newer on the secondary
+	secondaryBehind map[string]bool // Objects newer on the primary
+	errs            []error
+	closed          bool
+
+	uploads chan replicaUpload
+	pending sync.WaitGroup
+	done    chan struct{}
+}
+
+// NewReplicatedProvider starts replicating uploads from primary to
+// secondary, holding up to queueSize uploads waiting for the secondary.
+// Uploads that do not fit are left for Reconcile.
+func NewReplicatedProvider(primary, secondary CloudProvider, queueSize int) *ReplicatedProvider {
+	p := &ReplicatedProvider{
+		Primary:         primary,
+		Secondary:       secondary,
+		Timeout:         DefaultReplicaTimeout,
+		primaryBehind:   make(map[string]bool),
+		secondaryBehind: make(map[string]bool),
+		uploads:         make(chan replicaUpload, queueSize),
+		done:            make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// List returns the names stored on either provider in sorted order. It
+// fails only if neither provider can be reached.
+func (p *ReplicatedProvider) List(ctx context.Context) ([]string, error) {
+	primary, primaryErr := p.Primary.List(ctx)
+	secondary, secondaryErr := p.Secondary.List(ctx)
+	switch {
+	case primaryErr != nil && (!IsTemporary(primaryErr) || secondaryErr != nil):
+		return nil, failoverError(primaryErr, secondaryErr)
+	case secondaryErr != nil && !IsTemporary(secondaryErr):
+		return nil, secondaryErr
+	}
+	return unionNames(primary, secondary), nil
+}
+
+// Upload stores payload on the primary and queues a copy for the
+// secondary. If the primary is unavailable the payload is stored on the
+// secondary instead and copied back by the next Reconcile.
+func (p *ReplicatedProvider) Upload(ctx context.Context, name string, payload []byte) error {
+	p.writing.Lock()
+	defer p.writing.Unlock()
+
+	err := p.Primary.Upload(ctx, name, payload)
+	if err == nil {
+		p.mu.Lock()
+		delete(p.primaryBehind, name)
+		p.mu.Unlock()
+		p.replicate(name, payload)
+		return nil
+	}
+	if !IsTemporary(err) {
+		return err
+	}
+
+	if secondaryErr := p.Secondary.Upload(ctx, name, payload); secondaryErr != nil {
+		return failoverError(err, secondaryErr)
+	}
+	p.mu.Lock()
+	p.primaryBehind[name] = true
+	delete(p.secondaryBehind, name)
+	p.mu.Unlock()
+	return nil
+}
+
+// Download returns an object from whichever provider holds its latest
+// copy, failing over if that provider is unavailable
+func (p *ReplicatedProvider) Download(ctx context.Context, name string) ([]byte, error) {
+	var payload []byte
+	err := p.read(name, func(provider CloudProvider) (err error) {
+		payload, err = provider.Download(ctx, name)
+		return err
+	})
+	return payload, err
+}
+
+// GetLastModified returns when an object last changed on whichever
+// provider holds its latest copy, failing over if that provider is
+// unavailable
+func (p *ReplicatedProvider) GetLastModified(ctx context.Context, name string) (time.Time, error) {
+	var modified time.Time
+	err := p.read(name, func(provider CloudProvider) (err error) {
+		modified, err = provider.GetLastModified(ctx, name)
+		return err
+	})
+	return modified, err
+}
+
+// Delete removes an object from both providers once pending copies to
+// the secondary have finished, so a queued copy cannot bring it back. An
+// object missing from one side is only an error if it is missing from
+// both. Both providers must be reachable, or Reconcile would copy the
+// object back to the side it was deleted from.
+func (p *ReplicatedProvider) Delete(ctx context.Context, name string) error {
+	p.writing.Lock()
+	defer p.writing.Unlock()
+	p.pending.Wait()
+
+	primaryErr := p.Primary.Delete(ctx, name)
+	if primaryErr != nil && !errors.Is(primaryErr, ErrObjectNotFound) {
+		return fmt.Errorf("primary: %w", primaryErr)
+	}
+	secondaryErr := p.Secondary.Delete(ctx, name)
+	if secondaryErr != nil && !errors.Is(secondaryErr, ErrObjectNotFound) {
+		return fmt.Errorf("secondary: %w", secondaryErr)
+	}
+	p.mu.Lock()
+	delete(p.primaryBehind, name)
+	delete(p.secondaryBehind, name)
+	p.mu.Unlock()
+	if primaryErr != nil && secondaryErr != nil {
+		return primaryErr
+	}
+	return nil
+}
+
+// Reconcile makes both providers hold the same objects. An object missing
+// from one side is copied to it; an object that differs is copied from the
+// side known to have the latest write, or else from the side that changed
+// most recently. Uploads wait while it runs. Both providers must be
+// reachable.
+func (p *ReplicatedProvider) Reconcile(ctx context.Context) (ReconcileReport, error) {
+	p.writing.Lock()
+	defer p.writing.Unlock()
+	p.pending.Wait()
+
+	var report ReconcileReport
+	primary, err := p.Primary.List(ctx)
+	if err != nil {
+		return report, fmt.Errorf("primary: %w", err)
+	}
+	secondary, err := p.Secondary.List(ctx)
+	if err != nil {
+		return report, fmt.Errorf("secondary: %w", err)
+	}
+	inPrimary := nameSet(primary)
+	inSecondary := nameSet(secondary)
+
+	var problems []error
+	for _, name := range unionNames(primary, secondary) {
+		direction, err := p.direction(ctx, name, inPrimary[name], inSecondary[name])
+		if err == nil && direction != copyNone {
+			err = p.copyObject(ctx, name, direction)
+		}
+		if err != nil && (IsTemporary(err) || ctx.Err() != nil) {
+			// No point trying the remaining objects
+			return report, errors.Join(append(problems, err)...)
+		}
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+
+		switch direction {
+		case copyToPrimary:
+			report.ToPrimary = append(report.ToPrimary, name)
+		case copyToSecondary:
+			report.ToSecondary = append(report.ToSecondary, name)
+		}
+		p.mu.Lock()
+		delete(p.primaryBehind, name)
+		delete(p.secondaryBehind, name)
+		p.mu.Unlock()
+	}
+	return report, errors.Join(problems...)
+}
+
+// RunReconciler reconciles every interval until ctx is cancelled, passing
+// each result to report, which may be nil
+func (p *ReplicatedProvider) RunReconciler(ctx context.Context, interval time.Duration, report func(ReconcileReport, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := p.Reconcile(ctx)
+			if report != nil && ctx.Err() == nil {
+				report(result, err)
+			}
+		}
+	}
+}
+
+// Flush waits until every queued copy has reached the secondary or failed,
+// and returns the failures since the last flush. Failed copies are left
+// for Reconcile.
+func (p *ReplicatedProvider) Flush() error {
+	p.pending.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	err := errors.Join(p.errs...)
+	p.errs = nil
+	return err
+}
+
+// Close finishes the queued copies and stops background replication.
+// Later uploads reach the secondary only through Reconcile.
+func (p *ReplicatedProvider) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	err := p.Flush()
+	close(p.uploads)
+	<-p.done
+	return err
+}
+
+// replicate queues a copy of an upload for the secondary, or marks the
+// secondary as behind if the queue is full or closed
+func (p *ReplicatedProvider) replicate(name string, payload []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.secondaryBehind[name] = true
+	if p.closed {
+		return
+	}
+
+	p.pending.Add(1)
+	select {
+	case p.uploads <- replicaUpload{name: name, payload: append([]byte(nil), payload...)}:
+	default:
+		p.pending.Done()
+	}
+}
+
+// run copies queued uploads to the secondary until Close
+func (p *ReplicatedProvider) run() {
+	defer close(p.done)
+	for upload := range p.uploads {
+		ctx, cancel := p.callContext()
+		err := p.Secondary.Upload(ctx, upload.name, upload.payload)
+		cancel()
+
+		p.mu.Lock()
+		if err == nil {
+			delete(p.secondaryBehind, upload.name)
+		} else if len(p.errs) < MaxRecordedReplicaErrors {
+			p.errs = append(p.errs, fmt.Errorf("secondary: %s: %w", upload.name, cloudError("", err)))
+		}
+		p.mu.Unlock()
+		p.pending.Done()
+	}
+}
+
+// callContext bounds a background upload by Timeout
+func (p *ReplicatedProvider) callContext() (context.Context, context.CancelFunc) {
+	if p.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), p.Timeout)
+}
+
+// read calls fn on the provider holding the latest copy of an object, and
+// on the other provider if that one is unavailable
+func (p *ReplicatedProvider) read(name string, fn func(CloudProvider) error) error {
+	p.mu.Lock()
+	first, second := p.Primary, p.Secondary
+	if p.primaryBehind[name] {
+		first, second = second, first
+	}
+	p.mu.Unlock()
+
+	err := fn(first)
+	if err == nil || !IsTemporary(err) {
+		return err
+	}
+	if secondErr := fn(second); secondErr != nil {
+		return failoverError(err, secondErr)
+	}
+	return nil
+}
+
+// direction decides which way an object must be copied, if at all
+func (p *ReplicatedProvider) direction(ctx context.Context, name string, inPrimary, inSecondary bool) (copyDirection, error) {
+	switch {
+	case !inPrimary:
+		return copyToPrimary, nil
+	case !inSecondary:
+		return copyToSecondary, nil
+	}
+
+	primary, err := p.Primary.Download(ctx, name)
+	if err != nil {
+		return copyNone, fmt.Errorf("primary: %w", err)
+	}
+	secondary, err := p.Secondary.Download(ctx, name)
+	if err != nil {
+		return copyNone, fmt.Errorf("secondary: %w", err)
+	}
+	if bytes.Equal(primary, secondary) {
+		return copyNone, nil
+	}
+
+	p.mu.Lock()
+	primaryBehind, secondaryBehind := p.primaryBehind[name], p.secondaryBehind[name]
+	p.mu.Unlock()
+	switch {
+	case primaryBehind:
+		return copyToPrimary, nil
+	case secondaryBehind:
+		return copyToSecondary, nil
+	}
+
+	primaryModified, err := p.Primary.GetLastModified(ctx, name)
+	if err != nil {
+		return copyNone, fmt.Errorf("primary: %w", err)
+	}
+	secondaryModified, err := p.Secondary.GetLastModified(ctx, name)
+	if err != nil {
+		return copyNone, fmt.Errorf("secondary: %w", err)
+	}
+	if secondaryModified.After(primaryModified) {
+		return copyToPrimary, nil
+	}
+	return copyToSecondary, nil
+}
+
+// copyObject copies an object across in the given direction
+func (p *ReplicatedProvider) copyObject(ctx context.Context, name string, direction copyDirection) error {
+	from, to := p.Primary, p.Secondary
+	if direction == copyToPrimary {
+		from, to = to, from
+	}
+	payload, err := from.Download(ctx, name)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if err := to.Upload(ctx, name, payload); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// failoverError reports a call that failed on both providers. It is
+// temporary only if both failures were.
+func failoverError(primaryErr, secondaryErr error) error {
+	if secondaryErr == nil {
+		return primaryErr
+	}
+	if IsTemporary(primaryErr) && IsTemporary(secondaryErr) {
+		return fmt.Errorf("%w: primary: %v; secondary: %v", ErrCloudUnavailable, primaryErr, secondaryErr)
+	}
+	return fmt.Errorf("primary: %v; secondary: %w", primaryErr, secondaryErr)
+}
+
+// nameSet returns a lookup for a list of names
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// unionNames returns the names in either list, sorted and without repeats
+func unionNames(a, b []string) []string {
+	set := nameSet(a)
+	for _, name := range b {
+		set[name] = true
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
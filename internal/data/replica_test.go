@@ -0,0 +1,132 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestReplicatedProviderCopiesToSecondary(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := NewMemoryProvider(), NewMemoryProvider()
+	p := NewReplicatedProvider(primary, secondary, DefaultReplicaQueueSize)
+	defer p.Close()
+
+	if err := p.Upload(ctx, "a.json", []byte("one")); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if payload, err := secondary.Download(ctx, "a.json"); err != nil || string(payload) != "one" {
+		t.Errorf("Expected the upload replicated, got %q (%v)", payload, err)
+	}
+
+	secondary.SetOffline(true)
+	p.Upload(ctx, "a.json", []byte("two"))
+	if err := p.Flush(); !IsTemporary(err) {
+		t.Errorf("Expected the failed copy reported, got %v", err)
+	}
+	secondary.SetOffline(false)
+	report, err := p.Reconcile(ctx)
+	if err != nil || len(report.ToSecondary) != 1 || len(report.ToPrimary) != 0 {
+		t.Fatalf("Expected one copy to the secondary, got %+v (%v)", report, err)
+	}
+	if payload, _ := secondary.Download(ctx, "a.json"); string(payload) != "two" {
+		t.Errorf("Expected the secondary healed, got %q", payload)
+	}
+}
+
+func TestReplicatedProviderFailsOver(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := NewMemoryProvider(), NewMemoryProvider()
+	p := NewReplicatedProvider(primary, secondary, DefaultReplicaQueueSize)
+	defer p.Close()
+	dm, syncer := newDevice(t, p)
+
+	pet := core.NewDigitalPet("Mochi", "owner")
+	dm.SavePet(ctx, pet)
+	if _, err := syncer.SyncAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	p.Flush()
+
+	// Play continues through a primary outage
+	primary.SetOffline(true)
+	pet.Name = "Mochi II"
+	dm.SavePet(ctx, pet)
+	if action, err := syncer.SyncPet(ctx, pet.ID); err != nil || action != SyncUploaded {
+		t.Fatalf("Expected the change uploaded to the secondary, got %v (%v)", action, err)
+	}
+	if names, err := p.List(ctx); err != nil || len(names) != 1 {
+		t.Errorf("Expected to list from the secondary, got %v (%v)", names, err)
+	}
+
+	// Once back, the primary's stale copy is not read and is healed
+	primary.SetOffline(false)
+	payload, err := p.Download(ctx, objectName(pet.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded, _ := DecodePet(pet.ID, payload); loaded.Name != "Mochi II" {
+		t.Errorf("Expected the latest copy, got %s", loaded.Name)
+	}
+	report, err := p.Reconcile(ctx)
+	if err != nil || len(report.ToPrimary) != 1 {
+		t.Fatalf("Expected one copy to the primary, got %+v (%v)", report, err)
+	}
+	if report, _ := p.Reconcile(ctx); len(report.ToPrimary)+len(report.ToSecondary) != 0 {
+		t.Errorf("Expected nothing left to reconcile, got %+v", report)
+	}
+}
+
+func TestReplicatedProviderBothDown(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := NewMemoryProvider(), NewMemoryProvider()
+	p := NewReplicatedProvider(primary, secondary, DefaultReplicaQueueSize)
+	defer p.Close()
+	primary.SetOffline(true)
+	secondary.SetOffline(true)
+
+	if err := p.Upload(ctx, "a.json", []byte("one")); !IsTemporary(err) {
+		t.Errorf("Expected a temporary error, got %v", err)
+	}
+	if _, err := p.List(ctx); !IsTemporary(err) {
+		t.Errorf("Expected a temporary error, got %v", err)
+	}
+
+	secondary.SetOffline(false)
+	if _, err := p.Download(ctx, "a.json"); !errors.Is(err, ErrObjectNotFound) || IsTemporary(err) {
+		t.Errorf("Expected ErrObjectNotFound from the secondary, got %v", err)
+	}
+}
+
+func TestReplicatedProviderDelete(t *testing.T) {
+	ctx := context.Background()
+	primary, secondary := NewMemoryProvider(), NewMemoryProvider()
+	p := NewReplicatedProvider(primary, secondary, DefaultReplicaQueueSize)
+	defer p.Close()
+
+	p.Upload(ctx, "a.json", []byte("one"))
+	secondary.Upload(ctx, "b.json", []byte("two"))
+	if err := p.Delete(ctx, "a.json"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := p.Delete(ctx, "b.json"); err != nil {
+		t.Errorf("Expected an object on one side deleted, got %v", err)
+	}
+	if names, _ := p.List(ctx); len(names) != 0 {
+		t.Errorf("Expected both sides empty, got %v", names)
+	}
+	if err := p.Delete(ctx, "a.json"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Expected ErrObjectNotFound, got %v", err)
+	}
+
+	p.Upload(ctx, "c.json", []byte("three"))
+	secondary.SetOffline(true)
+	if err := p.Delete(ctx, "c.json"); !IsTemporary(err) {
+		t.Errorf("Expected the delete to need both sides, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrStalePreview is returned when the saves changed between previewing a
+// restore and carrying it out
+var ErrStalePreview = errors.New("saves changed since the restore was previewed")
+
+// RestorePreview is what restoring a backup over the current saves would
+// do, worked out without writing anything
+type RestorePreview struct {
+	Name       string             // Backup previewed
+	Added      []*core.DigitalPet // Pets only in the backup, which the restore brings back
+	Removed    []*core.DigitalPet // Pets saved now but not in the backup, which the restore deletes
+	Changed    []core.PetDiff     // Pets in both that differ; Before is the current save and After the backup
+	Unreadable []types.PetID      // Current saves that do not load, which the restore replaces or deletes
+	Unchanged  int                // Pets the same in both
+
+	files   []restoredFile
+	current []string // Paths of the current saves when previewed
+}
+
+// Destructive returns true if the restore would delete or overwrite
+// anything
+func (p RestorePreview) Destructive() bool {
+	return len(p.Removed) > 0 || len(p.Changed) > 0 || len(p.Unreadable) > 0
+}
+
+// Consequences describes what the restore would do, one line per pet
+func (p RestorePreview) Consequences() []string {
+	var lines []string
+	for _, pet := range p.Removed {
+		lines = append(lines, fmt.Sprintf("%s (%s) is deleted: it is not in the backup", pet.Name, pet.ID))
+	}
+	for _, diff := range p.Changed {
+		lines = append(lines, fmt.Sprintf("%s (%s) goes back to the backup: %d changes are lost", diff.Before.Name, diff.Before.ID, len(diff.Changes)))
+	}
+	for _, id := range p.Unreadable {
+		lines = append(lines, fmt.Sprintf("%s does not load and is replaced or deleted", id))
+	}
+	for _, pet := range p.Added {
+		lines = append(lines, fmt.Sprintf("%s (%s) is brought back", pet.Name, pet.ID))
+	}
+	return lines
+}
+
+// Preview downloads a remote backup, the latest if name is empty, and
+// compares it with the current saves, archived ones included. Nothing is
+// written; pass the preview to Replace to carry out the restore.
+func (b *CloudBackups) Preview(ctx context.Context, dm *DataManager, name string) (RestorePreview, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	name, files, err := b.download(ctx, dm, name)
+	if err != nil {
+		return RestorePreview{}, err
+	}
+	current, err := dm.currentSaves()
+	if err != nil {
+		return RestorePreview{}, err
+	}
+
+	preview := RestorePreview{Name: name, files: files}
+	type save struct {
+		pet      *core.DigitalPet
+		archived bool
+	}
+	saved := make(map[types.PetID]save)
+	unreadable := make(map[types.PetID]bool)
+	for _, path := range current {
+		preview.current = append(preview.current, path)
+		id, _ := saveID(filepath.Base(path))
+		raw, err := dm.readSave(path)
+		var pet *core.DigitalPet
+		if err == nil {
+			pet, err = DecodePet(id, raw)
+		}
+		if err != nil {
+			preview.Unreadable = append(preview.Unreadable, id)
+			unreadable[id] = true
+			continue
+		}
+		saved[id] = save{pet: pet, archived: path == dm.archivedPetPath(id)}
+	}
+
+	for _, file := range files {
+		id := file.pet.ID
+		now, ok := saved[id]
+		if !ok {
+			if !unreadable[id] {
+				preview.Added = append(preview.Added, file.pet)
+			}
+			continue
+		}
+		delete(saved, id)
+		diff := core.DiffPets(now.pet, file.pet)
+		if archived := file.path == dm.archivedPetPath(id); archived != now.archived {
+			diff.Changes = append(diff.Changes, core.FieldChange{
+				Section: core.DiffSectionPet,
+				Field:   "archived",
+				Before:  strconv.FormatBool(now.archived),
+				After:   strconv.FormatBool(archived),
+			})
+		}
+		if diff.Empty() {
+			preview.Unchanged++
+		} else {
+			preview.Changed = append(preview.Changed, diff)
+		}
+	}
+	for _, now := range saved {
+		preview.Removed = append(preview.Removed, now.pet)
+	}
+	sort.Slice(preview.Removed, func(i, j int) bool { return preview.Removed[i].ID < preview.Removed[j].ID })
+	return preview, nil
+}
+
+// Replace carries out a previewed restore: the backup's saves are written
+// and every current save not in the backup is deleted. ErrStalePreview is
+// returned, before anything is written, if saves were added or removed
+// since the preview. Callers should back up the current saves first, as
+// overwritten saves cannot be recovered otherwise.
+func (b *CloudBackups) Replace(ctx context.Context, dm *DataManager, preview RestorePreview) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	current, err := dm.currentSaves()
+	if err != nil {
+		return err
+	}
+	if len(current) != len(preview.current) {
+		return ErrStalePreview
+	}
+	for i := range current {
+		if current[i] != preview.current[i] {
+			return ErrStalePreview
+		}
+	}
+	if err := writeRestored(dm, preview.files); err != nil {
+		return err
+	}
+	kept := make(map[string]bool, len(preview.files))
+	for _, file := range preview.files {
+		kept[file.path] = true
+	}
+	for _, path := range current {
+		if kept[path] {
+			continue
+		}
+		if err := dm.storage().Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	dm.Audit.Record(AuditEntry{Actor: ActorOffsite, Action: AuditRestore,
+		Summary: fmt.Sprintf("restored backup %s over %d saves", preview.Name, len(current))})
+	return nil
+}
+
+// currentSaves returns the paths of every save, archived ones included,
+// in sorted order (must be called with lock held)
+func (dm *DataManager) currentSaves() ([]string, error) {
+	var paths []string
+	active, err := dm.listSaves(dm.SavePath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, id := range active {
+		paths = append(paths, dm.petPath(id))
+	}
+	archived, err := dm.listSaves(dm.archivePath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, id := range archived {
+		paths = append(paths, dm.archivedPetPath(id))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
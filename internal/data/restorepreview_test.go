@@ -0,0 +1,86 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestRestorePreview(t *testing.T) {
+	ctx := context.Background()
+	dm, _ := NewDataManager(t.TempDir())
+	kept := core.NewDigitalPet("Kept", "owner")
+	changed := core.NewDigitalPet("Changed", "owner")
+	lost := core.NewDigitalPet("Lost", "owner")
+	dm.SaveAll(ctx, []*core.DigitalPet{kept, changed, lost})
+
+	backups := NewCloudBackups(NewMemoryProvider(), 2)
+	local, err := dm.Backup(ctx, filepath.Join(dm.SavePath, BackupDirName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backups.Upload(ctx, local); err != nil {
+		t.Fatal(err)
+	}
+
+	// Since the backup: one pet changed, one deleted and one new
+	changed.Biology.Vitals.Energy = 0.1
+	dm.SavePet(ctx, changed)
+	dm.DeletePet(ctx, lost.ID)
+	added := core.NewDigitalPet("Added", "owner")
+	dm.SavePet(ctx, added)
+
+	preview, err := backups.Preview(ctx, dm, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview.Added) != 1 || preview.Added[0].ID != lost.ID {
+		t.Errorf("Expected %s brought back, got %v", lost.ID, preview.Added)
+	}
+	if len(preview.Removed) != 1 || preview.Removed[0].ID != added.ID {
+		t.Errorf("Expected %s deleted, got %v", added.ID, preview.Removed)
+	}
+	if len(preview.Changed) != 1 || preview.Changed[0].Before.ID != changed.ID || len(preview.Changed[0].Section(core.DiffSectionVitals)) == 0 {
+		t.Errorf("Expected %s's energy rolled back, got %+v", changed.ID, preview.Changed)
+	}
+	if preview.Unchanged != 1 || !preview.Destructive() {
+		t.Errorf("Expected one pet unchanged and a destructive restore, got %+v", preview)
+	}
+	if lines := preview.Consequences(); len(lines) != 3 || !strings.Contains(lines[0], "Added") || !strings.Contains(lines[0], "deleted") {
+		t.Errorf("Expected the deletion listed first, got %q", lines)
+	}
+	if pet, _ := dm.LoadPet(ctx, changed.ID); pet.Biology.Vitals.Energy != 0.1 {
+		t.Error("Expected the preview to change nothing")
+	}
+
+	// The preview is refused once the saves it compared have changed
+	stale := core.NewDigitalPet("Stale", "owner")
+	dm.SavePet(ctx, stale)
+	if err := backups.Replace(ctx, dm, preview); !errors.Is(err, ErrStalePreview) {
+		t.Errorf("Expected ErrStalePreview, got %v", err)
+	}
+	dm.DeletePet(ctx, stale.ID)
+
+	if err := backups.Replace(ctx, dm, preview); err != nil {
+		t.Fatal(err)
+	}
+	ids, _ := dm.ListPets(ctx, FilterAll)
+	if len(ids) != 3 || dm.archived(added.ID) {
+		t.Errorf("Expected the three backed up pets, got %v", ids)
+	}
+	if _, err := dm.LoadPet(ctx, added.ID); !errors.Is(err, ErrPetNotFound) {
+		t.Errorf("Expected %s deleted, got %v", added.ID, err)
+	}
+	if pet, _ := dm.LoadPet(ctx, changed.ID); pet.Biology.Vitals.Energy == 0.1 {
+		t.Error("Expected the changed pet rolled back")
+	}
+
+	again, err := backups.Preview(ctx, dm, "")
+	if err != nil || again.Destructive() || len(again.Added) != 0 || again.Unchanged != 3 {
+		t.Errorf("Expected nothing left to restore, got %+v (%v)", again, err)
+	}
+}
@@ -0,0 +1,311 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrInvalidPet is returned when a pet fails validation and will not be saved
+	ErrInvalidPet = errors.New("invalid pet state")
+	// ErrPetNotFound is returned when no save exists for a pet
+	ErrPetNotFound = errors.New("pet not found")
+	// ErrCorruptSave is returned when a save exists but cannot be read back
+	// as a valid pet. It is the same error core.Load returns.
+	ErrCorruptSave = core.ErrCorruptSave
+)
+
+// saveExt is the file extension of pet saves
+const saveExt = ".json"
+
+// DataManager stores pets as one JSON file each under a save directory.
+// Every write goes through the journal so a crash mid-write can be undone.
+type DataManager struct {
+	mu sync.Mutex
+
+	SavePath string
+	Backend  StorageBackend // Where the save directory is kept; nil uses the disk
+	Journal  *Journal
+	Audit    *AuditLog       // Who changed what; kept beside the saves
+	Compress bool            // Write saves gzip-compressed; both forms are read
+	Cipher   *SaveCipher     // Encrypts saves when set; plain saves are still read
+	Hooks    *LifecycleHooks // Told when queued saves, backups, syncs and migrations finish
+
+	summaryMu sync.Mutex
+	summaries map[string]cachedSummary // Listing summaries by save path
+}
+
+// NewDataManager creates the save directory on disk if needed and opens
+// its journal and audit log
+func NewDataManager(savePath string) (*DataManager, error) {
+	return NewDataManagerOn(DiskBackend{}, savePath)
+}
+
+// NewDataManagerOn creates the save directory on a backend if needed and
+// opens its journal and audit log there
+func NewDataManagerOn(backend StorageBackend, savePath string) (*DataManager, error) {
+	if err := backend.MkdirAll(savePath); err != nil {
+		return nil, err
+	}
+	journal := NewJournal(filepath.Join(savePath, journalName))
+	journal.Backend = backend
+	audit := NewAuditLog(filepath.Join(savePath, AuditFileName))
+	audit.Backend = backend
+	return &DataManager{SavePath: savePath, Backend: backend, Journal: journal, Audit: audit, Hooks: NewLifecycleHooks()}, nil
+}
+
+// SavePet validates and writes a pet
+func (dm *DataManager) SavePet(ctx context.Context, pet *core.DigitalPet) error {
+	if err := ValidatePet(pet); err != nil {
+		return err
+	}
+	payload, err := pet.Save()
+	if err != nil {
+		return err
+	}
+	return dm.WritePet(ctx, pet.ID, payload)
+}
+
+// WritePet atomically replaces a pet's save with an already serialized
+// payload. The payload is written to a temporary file and renamed into
+// place; the journal records the write so Recover can finish or discard it.
+// A cancelled ctx stops the write before it starts; a write in progress
+// always completes so the journal stays consistent.
+func (dm *DataManager) WritePet(ctx context.Context, id types.PetID, payload []byte) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if dm.archived(id) {
+		return fmt.Errorf("%w: %s", ErrPetArchived, id)
+	}
+
+	raw, err := dm.encode(payload)
+	if err != nil {
+		return err
+	}
+	return dm.writeSave(id, raw)
+}
+
+// writeSave replaces a pet's active save file with data already in its disk
+// format (must be called with lock held)
+func (dm *DataManager) writeSave(id types.PetID, raw []byte) error {
+	path := dm.petPath(id)
+	temp := path + tempExt
+	if err := dm.Journal.Begin(id, raw); err != nil {
+		return err
+	}
+	if err := dm.storage().WriteFile(temp, raw); err != nil {
+		return err
+	}
+	if err := dm.storage().Rename(temp, path); err != nil {
+		return err
+	}
+	return dm.Journal.Commit(id)
+}
+
+// LoadPet reads and validates a pet's save
+func (dm *DataManager) LoadPet(ctx context.Context, id types.PetID) (*core.DigitalPet, error) {
+	payload, err := dm.ReadPet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return DecodePet(id, payload)
+}
+
+// ReadPet returns a pet's saved payload, decompressed but not decoded
+func (dm *DataManager) ReadPet(ctx context.Context, id types.PetID) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	payload, err := dm.readSave(dm.petPath(id))
+	if err != nil {
+		return nil, petError(id, err)
+	}
+	return payload, nil
+}
+
+// VerifyPet reads a pet's save back and checks it matches the payload
+func (dm *DataManager) VerifyPet(ctx context.Context, id types.PetID, payload []byte) error {
+	stored, err := dm.ReadPet(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(stored, payload) {
+		return fmt.Errorf("%w: %s was not stored intact", ErrCorruptSave, id)
+	}
+	_, err = DecodePet(id, stored)
+	return err
+}
+
+// DeletePet removes a pet's save
+func (dm *DataManager) DeletePet(ctx context.Context, id types.PetID) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return petError(id, dm.storage().Remove(dm.petPath(id)))
+}
+
+// ListPets returns the IDs of the saved pets matching filter in sorted order
+func (dm *DataManager) ListPets(ctx context.Context, filter PetFilter) ([]types.PetID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var ids []types.PetID
+	if filter != FilterArchived {
+		active, err := dm.listSaves(dm.SavePath)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, active...)
+	}
+	if filter != FilterActive {
+		archived, err := dm.listSaves(dm.archivePath())
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		ids = append(ids, archived...)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// listSaves returns the IDs of the saves in a directory
+func (dm *DataManager) listSaves(dir string) ([]types.PetID, error) {
+	entries, err := dm.storage().ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []types.PetID
+	for _, entry := range entries {
+		if id, ok := saveID(entry.Name()); ok && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// saveID returns the pet a save file name belongs to
+func saveID(name string) (types.PetID, bool) {
+	if !strings.HasSuffix(name, saveExt) {
+		return "", false
+	}
+	id, err := url.PathUnescape(strings.TrimSuffix(name, saveExt))
+	if err != nil {
+		return "", false
+	}
+	return types.PetID(id), true
+}
+
+// LoadAll loads every active pet. Pets that fail to load are reported in
+// the returned error alongside the pets that did load. Loading stops early
+// if ctx is cancelled.
+func (dm *DataManager) LoadAll(ctx context.Context) ([]*core.DigitalPet, error) {
+	ids, err := dm.ListPets(ctx, FilterActive)
+	if err != nil {
+		return nil, err
+	}
+
+	pets := make([]*core.DigitalPet, 0, len(ids))
+	var problems []error
+	for _, id := range ids {
+		pet, err := dm.LoadPet(ctx, id)
+		if ctx.Err() != nil {
+			return pets, errors.Join(append(problems, ctx.Err())...)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", id, err))
+			continue
+		}
+		pets = append(pets, pet)
+	}
+	return pets, errors.Join(problems...)
+}
+
+// petPath returns the save file for a pet. IDs are escaped because they
+// embed the pet's name.
+func (dm *DataManager) petPath(id types.PetID) string {
+	return filepath.Join(dm.SavePath, url.PathEscape(string(id))+saveExt)
+}
+
+// DecodePet decodes a saved payload and validates the pet. A payload that
+// does not decode, or decodes to an invalid pet, is reported as
+// ErrCorruptSave since the data on disk is at fault.
+func DecodePet(id types.PetID, payload []byte) (*core.DigitalPet, error) {
+	pet, err := core.Load(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+	if err := ValidatePet(pet); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptSave, err)
+	}
+	return pet, nil
+}
+
+// petError classifies a file system error for a pet's save, keeping the
+// original error in the chain
+func petError(id types.PetID, err error) error {
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s: %w", ErrPetNotFound, id, err)
+	}
+	return err
+}
+
+// ValidatePet checks that a pet is complete and its vitals are sane
+// before it is written over a good save
+func ValidatePet(pet *core.DigitalPet) error {
+	switch {
+	case pet == nil:
+		return fmt.Errorf("%w: nil pet", ErrInvalidPet)
+	case pet.ID == "":
+		return fmt.Errorf("%w: missing ID", ErrInvalidPet)
+	case pet.Biology == nil || pet.Biology.Vitals == nil || pet.Genome == nil ||
+		pet.Personality == nil || pet.Emotions == nil || pet.Memory == nil:
+		return fmt.Errorf("%w: %s is missing a core system", ErrInvalidPet, pet.ID)
+	}
+
+	for _, vital := range biology.AllVitals() {
+		value := pet.Biology.Vitals.Get(vital)
+		if math.IsNaN(value) || value < 0 || value > 1 {
+			return fmt.Errorf("%w: %s has %s %v", ErrInvalidPet, pet.ID, vital.Key(), value)
+		}
+	}
+	return nil
+}
+
+// storage returns the backend the save directory is kept on
+func (dm *DataManager) storage() StorageBackend {
+	return backendOr(dm.Backend)
+}
+
+// at returns a manager for another save directory on the same backend,
+// such as a backup or checkpoint, without a journal or audit log
+func (dm *DataManager) at(savePath string) *DataManager {
+	return &DataManager{SavePath: savePath, Backend: dm.Backend}
+}
+
+// backendOr returns b, or the disk if b is nil
+func backendOr(b StorageBackend) StorageBackend {
+	if b == nil {
+		return DiskBackend{}
+	}
+	return b
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// largePet creates a long-lived pet with full memories and relationships
+func largePet(name string) *core.DigitalPet {
+	pet := core.NewDigitalPetRandom(name, "owner")
+	pet.Memory = ai.NewMemorySystem(1000)
+	for i := 0; i < 1000; i++ {
+		interactionType := types.InteractionType(i % int(types.InteractionComfort+1))
+		pet.Memory.RecordInteraction(interactionType, float64(i)/10, float64(i%10)/10, "content")
+	}
+	pet.Memory.ConsolidateMemories()
+	for i := 0; i < 20; i++ {
+		rel := pet.Relationships.AddRelationship(types.PetID(fmt.Sprintf("friend_%d", i)), types.RelationshipFriend)
+		for j := 0; j < 20; j++ {
+			rel.AddSharedExperience("played together", float64(j), 0.1)
+		}
+	}
+	return pet
+}
+
+func BenchmarkSavePetLarge(b *testing.B) {
+	dm, err := NewDataManager(b.TempDir())
+	if err != nil {
+		b.Fatal(err)
+	}
+	pet := largePet("Elder")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := dm.SavePet(context.Background(), pet); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoadPetLarge(b *testing.B) {
+	dm, err := NewDataManager(b.TempDir())
+	if err != nil {
+		b.Fatal(err)
+	}
+	pet := largePet("Elder")
+	if err := dm.SavePet(context.Background(), pet); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := dm.LoadPet(context.Background(), pet.ID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSaveQueueHousehold measures saving a whole household through
+// the background queue, the path auto-save takes every interval
+func BenchmarkSaveQueueHousehold(b *testing.B) {
+	for _, count := range []int{10, 100} {
+		b.Run(fmt.Sprintf("pets=%d", count), func(b *testing.B) {
+			dm, err := NewDataManager(b.TempDir())
+			if err != nil {
+				b.Fatal(err)
+			}
+			pets := make([]*core.DigitalPet, count)
+			for i := range pets {
+				pets[i] = core.NewDigitalPetRandom(fmt.Sprintf("Pet%03d", i), "owner")
+			}
+			q := NewSaveQueue(dm, count)
+			defer q.Close()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, pet := range pets {
+					if err := q.Enqueue(context.Background(), pet); err != nil {
+						b.Fatal(err)
+					}
+				}
+				if err := q.Flush(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
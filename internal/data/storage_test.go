@@ -0,0 +1,93 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestSaveAndLoadPet(t *testing.T) {
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pet := core.NewDigitalPet("Mochi/2", "owner")
+	if err := dm.SavePet(context.Background(), pet); err != nil {
+		t.Fatalf("SavePet failed: %v", err)
+	}
+
+	ids, err := dm.ListPets(context.Background(), FilterActive)
+	if err != nil || len(ids) != 1 || ids[0] != pet.ID {
+		t.Fatalf("Expected [%s], got %v (%v)", pet.ID, ids, err)
+	}
+	loaded, err := dm.LoadPet(context.Background(), pet.ID)
+	if err != nil {
+		t.Fatalf("LoadPet failed: %v", err)
+	}
+	if loaded.Name != pet.Name || loaded.Genome == nil {
+		t.Errorf("Loaded pet does not match: %+v", loaded)
+	}
+
+	if err := dm.DeletePet(context.Background(), pet.ID); err != nil {
+		t.Fatalf("DeletePet failed: %v", err)
+	}
+	if _, err := dm.LoadPet(context.Background(), pet.ID); !errors.Is(err, ErrPetNotFound) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected deleted pet to be missing, got %v", err)
+	}
+	if err := dm.DeletePet(context.Background(), pet.ID); !errors.Is(err, ErrPetNotFound) {
+		t.Errorf("Expected ErrPetNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestSaveRejectsInvalidPet(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	pet := core.NewDigitalPet("Mochi", "owner")
+	if err := dm.SavePet(context.Background(), pet); err != nil {
+		t.Fatal(err)
+	}
+	saved := pet.Biology.Vitals.Health
+
+	pet.Biology.Vitals.Health = math.NaN()
+	if err := dm.SavePet(context.Background(), pet); !errors.Is(err, ErrInvalidPet) {
+		t.Fatalf("Expected ErrInvalidPet, got %v", err)
+	}
+	loaded, err := dm.LoadPet(context.Background(), pet.ID)
+	if err != nil || loaded.Biology.Vitals.Health != saved {
+		t.Error("A rejected save should leave the previous save untouched")
+	}
+}
+
+func TestLoadCorruptSave(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	if err := dm.WritePet(context.Background(), "broken", []byte("{not json")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.LoadPet(context.Background(), "broken"); !errors.Is(err, ErrCorruptSave) {
+		t.Errorf("Expected ErrCorruptSave for bad JSON, got %v", err)
+	}
+
+	if err := dm.WritePet(context.Background(), "empty", []byte(`{"id":"empty"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.LoadPet(context.Background(), "empty"); !errors.Is(err, ErrCorruptSave) || !errors.Is(err, ErrInvalidPet) {
+		t.Errorf("Expected ErrCorruptSave for an incomplete pet, got %v", err)
+	}
+}
+
+func TestCancelledContextStopsIO(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := dm.SavePet(ctx, core.NewDigitalPet("Mochi", "owner")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled save, got %v", err)
+	}
+	if _, err := dm.LoadAll(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled load, got %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// DefaultCloudTimeout bounds each call to a cloud provider
+const DefaultCloudTimeout = 30 * time.Second
+
+// SyncAction is what a sync did for one pet
+type SyncAction int
+
+const (
+	SyncUnchanged SyncAction = iota
+	SyncUploaded
+	SyncDownloaded
+	SyncMerged // Both copies were combined and the result saved on both sides
+)
+
+// String returns the string representation of SyncAction
+func (a SyncAction) String() string {
+	return [...]string{"Unchanged", "Uploaded", "Downloaded", "Merged"}[a]
+}
+
+// syncRecord remembers both sides of a pet as of its last sync
+type syncRecord struct {
+	checksum       string    // Checksum of the payload both sides agreed on
+	remoteModified time.Time // Cloud modification time at that point
+}
+
+// SyncReport summarises a sync of every pet
+type SyncReport struct {
+	Uploaded        []types.PetID
+	Downloaded      []types.PetID
+	Merged          []types.PetID
+	Conflicts       []types.PetID    // Pets left unsettled, for Resolve
+	ConflictReports []ConflictReport // Every pet that changed on both sides, settled or not
+}
+
+// CloudSyncManager keeps local saves and a cloud provider in step. A pet
+// that changed on one side since the last sync is copied to the other; a
+// pet that changed on both sides is settled the way Resolution says, or
+// left alone and reported as a conflict.
+type CloudSyncManager struct {
+	mu         sync.Mutex
+	Data       *DataManager
+	Provider   CloudProvider
+	Timeout    time.Duration      // Limit on each provider call; 0 waits as long as ctx allows
+	Resolution ConflictResolution // How pets changed on both sides are settled
+	// Ask chooses the copy to keep when Resolution is ConflictAskUser;
+	// KeepNeither leaves the conflict for Resolve. It is called during the
+	// sync and must not call back into the manager.
+	Ask     func(ConflictReport) ConflictChoice
+	records map[types.PetID]syncRecord
+}
+
+// NewCloudSyncManager creates a sync manager for local saves and a provider
+func NewCloudSyncManager(dm *DataManager, provider CloudProvider) *CloudSyncManager {
+	return &CloudSyncManager{
+		Data:     dm,
+		Provider: provider,
+		Timeout:  DefaultCloudTimeout,
+		records:  make(map[types.PetID]syncRecord),
+	}
+}
+
+// SyncPet brings one pet up to date on both sides. Returns ErrConflict if
+// both sides changed and the conflict was not settled, and
+// ErrCloudUnavailable if the provider is down or does not answer within
+// Timeout.
+func (m *CloudSyncManager) SyncPet(ctx context.Context, id types.PetID) (SyncAction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	action, _, err := m.syncPet(ctx, id)
+	return action, err
+}
+
+// SyncAll syncs every pet known locally or in the cloud, then tells the
+// lifecycle hooks how it went. Pets that fail are reported in the returned
+// error; the rest are still synced unless the provider is unavailable or
+// ctx is cancelled.
+func (m *CloudSyncManager) SyncAll(ctx context.Context) (SyncReport, error) {
+	m.mu.Lock()
+	report, err := m.syncAll(ctx)
+	m.mu.Unlock()
+	m.Data.Hooks.Emit(syncEvent(report, err))
+	return report, err
+}
+
+// syncAll implements SyncAll (must be called with lock held)
+func (m *CloudSyncManager) syncAll(ctx context.Context) (SyncReport, error) {
+	var report SyncReport
+	ids, err := m.allPets(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	var problems []error
+	for _, id := range ids {
+		action, conflict, err := m.syncPet(ctx, id)
+		if conflict != nil {
+			report.ConflictReports = append(report.ConflictReports, *conflict)
+		}
+		switch {
+		case errors.Is(err, ErrConflict):
+			report.Conflicts = append(report.Conflicts, id)
+			problems = append(problems, err)
+		case errors.Is(err, ErrCloudUnavailable), ctx.Err() != nil:
+			// No point trying the remaining pets
+			return report, errors.Join(append(problems, err)...)
+		case err != nil:
+			problems = append(problems, err)
+		case action == SyncUploaded:
+			report.Uploaded = append(report.Uploaded, id)
+		case action == SyncDownloaded:
+			report.Downloaded = append(report.Downloaded, id)
+		case action == SyncMerged:
+			report.Merged = append(report.Merged, id)
+		}
+	}
+	if len(report.Uploaded)+len(report.Downloaded)+len(report.Merged)+len(report.Conflicts) > 0 {
+		m.Data.Audit.Record(AuditEntry{Actor: ActorSync, Action: AuditSync, Summary: fmt.Sprintf("%d sent, %d received, %d merged, %d conflicts",
+			len(report.Uploaded), len(report.Downloaded), len(report.Merged), len(report.Conflicts))})
+	}
+	return report, errors.Join(problems...)
+}
+
+// Resolve settles a conflict by overwriting one side with the other
+func (m *CloudSyncManager) Resolve(ctx context.Context, id types.PetID, keepLocal bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if keepLocal {
+		local, err := m.Data.ReadPet(ctx, id)
+		if err != nil {
+			return err
+		}
+		return m.upload(ctx, id, local)
+	}
+
+	remote, err := m.fetch(ctx, id)
+	if err != nil {
+		return err
+	}
+	modified, err := m.lastModified(ctx, id)
+	if err != nil {
+		return err
+	}
+	return m.download(ctx, id, remote, modified)
+}
+
+// syncPet implements SyncPet, also returning the conflict if the pet
+// changed on both sides (must be called with lock held)
+func (m *CloudSyncManager) syncPet(ctx context.Context, id types.PetID) (SyncAction, *ConflictReport, error) {
+	local, err := m.Data.ReadPet(ctx, id)
+	localExists := err == nil
+	if err != nil && !errors.Is(err, ErrPetNotFound) {
+		return SyncUnchanged, nil, err
+	}
+	modified, err := m.lastModified(ctx, id)
+	remoteExists := err == nil
+	if err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return SyncUnchanged, nil, err
+	}
+
+	switch {
+	case !localExists && !remoteExists:
+		return SyncUnchanged, nil, fmt.Errorf("%w: %s", ErrPetNotFound, id)
+	case !remoteExists:
+		return SyncUploaded, nil, m.upload(ctx, id, local)
+	}
+
+	record, synced := m.records[id]
+	if synced && modified.Equal(record.remoteModified) {
+		// The cloud copy has not changed since the last sync
+		if !localExists || checksum(local) == record.checksum {
+			return SyncUnchanged, nil, nil
+		}
+		return SyncUploaded, nil, m.upload(ctx, id, local)
+	}
+
+	remote, err := m.fetch(ctx, id)
+	if err != nil {
+		return SyncUnchanged, nil, err
+	}
+	// A cloud copy can get a new timestamp without changing, as when a
+	// replica answers for a provider that is down, so compare contents
+	localChanged := localExists && (!synced || checksum(local) != record.checksum)
+	remoteChanged := !synced || checksum(remote) != record.checksum
+	switch {
+	case localChanged && checksum(local) == checksum(remote), !localChanged && !remoteChanged:
+		m.records[id] = syncRecord{checksum: checksum(remote), remoteModified: modified}
+		return SyncUnchanged, nil, nil
+	case localChanged && !remoteChanged:
+		return SyncUploaded, nil, m.upload(ctx, id, local)
+	case localChanged:
+		return m.settle(ctx, id, local, remote, modified)
+	}
+	return SyncDownloaded, nil, m.download(ctx, id, remote, modified)
+}
+
+// upload copies a local save to the cloud and records the new state
+func (m *CloudSyncManager) upload(ctx context.Context, id types.PetID, local []byte) error {
+	callCtx, cancel := m.callContext(ctx)
+	err := m.Provider.Upload(callCtx, objectName(id), local)
+	cancel()
+	if err != nil {
+		return cloudError(id, err)
+	}
+	modified, err := m.lastModified(ctx, id)
+	if err != nil {
+		return err
+	}
+	m.records[id] = syncRecord{checksum: checksum(local), remoteModified: modified}
+	return nil
+}
+
+// download validates a cloud copy, writes it locally and records the new
+// state. A corrupt cloud copy never replaces a local save.
+func (m *CloudSyncManager) download(ctx context.Context, id types.PetID, remote []byte, modified time.Time) error {
+	if _, err := DecodePet(id, remote); err != nil {
+		return fmt.Errorf("cloud copy of %w", err)
+	}
+	if err := m.Data.WritePet(ctx, id, remote); err != nil {
+		return err
+	}
+	m.records[id] = syncRecord{checksum: checksum(remote), remoteModified: modified}
+	m.Data.Audit.Record(AuditEntry{Actor: ActorSync, Action: AuditSync, PetID: id, Summary: "replaced by the synced copy"})
+	return nil
+}
+
+// allPets lists every pet saved locally or in the cloud
+func (m *CloudSyncManager) allPets(ctx context.Context) ([]types.PetID, error) {
+	ids, err := m.Data.ListPets(ctx, FilterActive)
+	if err != nil {
+		return nil, err
+	}
+	callCtx, cancel := m.callContext(ctx)
+	names, err := m.Provider.List(callCtx)
+	cancel()
+	if err != nil {
+		return nil, cloudError("", err)
+	}
+
+	seen := make(map[types.PetID]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, name := range names {
+		unescaped, err := url.PathUnescape(strings.TrimSuffix(name, saveExt))
+		if err != nil || !strings.HasSuffix(name, saveExt) {
+			continue
+		}
+		if id := types.PetID(unescaped); !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// fetch downloads a pet's cloud copy
+func (m *CloudSyncManager) fetch(ctx context.Context, id types.PetID) ([]byte, error) {
+	callCtx, cancel := m.callContext(ctx)
+	defer cancel()
+	payload, err := m.Provider.Download(callCtx, objectName(id))
+	if err != nil {
+		return nil, cloudError(id, err)
+	}
+	return payload, nil
+}
+
+// lastModified returns when a pet's cloud copy last changed
+func (m *CloudSyncManager) lastModified(ctx context.Context, id types.PetID) (time.Time, error) {
+	callCtx, cancel := m.callContext(ctx)
+	defer cancel()
+	modified, err := m.Provider.GetLastModified(callCtx, objectName(id))
+	if err != nil {
+		return time.Time{}, cloudError(id, err)
+	}
+	return modified, nil
+}
+
+// callContext bounds a single provider call by Timeout
+func (m *CloudSyncManager) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if m.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, m.Timeout)
+}
+
+// objectName returns the cloud object name for a pet's save
+func objectName(id types.PetID) string {
+	return url.PathEscape(string(id)) + saveExt
+}
+
+// cloudError adds the pet to a provider error. A call that timed out is
+// reported as ErrCloudUnavailable, since a hung provider is as good as down.
+func cloudError(id types.PetID, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("%w: %w", ErrCloudUnavailable, err)
+	}
+	if id == "" {
+		return err
+	}
+	return fmt.Errorf("%s: %w", id, err)
+}
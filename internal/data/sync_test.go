@@ -0,0 +1,124 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// newDevice creates local storage syncing with a shared provider
+func newDevice(t *testing.T, provider CloudProvider) (*DataManager, *CloudSyncManager) {
+	dm, err := NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dm, NewCloudSyncManager(dm, provider)
+}
+
+func TestSyncBetweenDevices(t *testing.T) {
+	provider := NewMemoryProvider()
+	laptop, laptopSync := newDevice(t, provider)
+	phone, phoneSync := newDevice(t, provider)
+
+	pet := core.NewDigitalPet("Mochi", "owner")
+	laptop.SavePet(context.Background(), pet)
+	if report, err := laptopSync.SyncAll(context.Background()); err != nil || len(report.Uploaded) != 1 {
+		t.Fatalf("Expected one upload, got %+v (%v)", report, err)
+	}
+	if report, err := phoneSync.SyncAll(context.Background()); err != nil || len(report.Downloaded) != 1 {
+		t.Fatalf("Expected one download, got %+v (%v)", report, err)
+	}
+	if action, err := phoneSync.SyncPet(context.Background(), pet.ID); err != nil || action != SyncUnchanged {
+		t.Errorf("Expected nothing to do, got %v (%v)", action, err)
+	}
+	if entries, _ := phone.Audit.Query(AuditQuery{Actor: ActorSync}); len(entries) != 2 || entries[1].PetID != pet.ID {
+		t.Errorf("Expected the download and the sync recorded in the audit log, got %+v", entries)
+	}
+
+	pet.Name = "Mochi II"
+	phone.SavePet(context.Background(), pet)
+	if action, err := phoneSync.SyncPet(context.Background(), pet.ID); err != nil || action != SyncUploaded {
+		t.Errorf("Expected the phone's change uploaded, got %v (%v)", action, err)
+	}
+	if action, err := laptopSync.SyncPet(context.Background(), pet.ID); err != nil || action != SyncDownloaded {
+		t.Errorf("Expected the laptop to download the change, got %v (%v)", action, err)
+	}
+	if loaded, _ := laptop.LoadPet(context.Background(), pet.ID); loaded.Name != "Mochi II" {
+		t.Errorf("Expected the renamed pet on the laptop, got %s", loaded.Name)
+	}
+}
+
+func TestSyncConflictAndResolve(t *testing.T) {
+	provider := NewMemoryProvider()
+	laptop, laptopSync := newDevice(t, provider)
+	phone, phoneSync := newDevice(t, provider)
+
+	pet := core.NewDigitalPet("Mochi", "owner")
+	laptop.SavePet(context.Background(), pet)
+	laptopSync.SyncAll(context.Background())
+	phoneSync.SyncAll(context.Background())
+
+	pet.Name = "Laptop Mochi"
+	laptop.SavePet(context.Background(), pet)
+	laptopSync.SyncPet(context.Background(), pet.ID)
+	pet.Name = "Phone Mochi"
+	phone.SavePet(context.Background(), pet)
+
+	report, err := phoneSync.SyncAll(context.Background())
+	if !errors.Is(err, ErrConflict) || len(report.Conflicts) != 1 {
+		t.Fatalf("Expected a conflict, got %+v (%v)", report, err)
+	}
+	if err := phoneSync.Resolve(context.Background(), pet.ID, false); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if loaded, _ := phone.LoadPet(context.Background(), pet.ID); loaded.Name != "Laptop Mochi" {
+		t.Errorf("Expected the cloud copy kept, got %s", loaded.Name)
+	}
+}
+
+func TestSyncRejectsCorruptCloudCopy(t *testing.T) {
+	provider := NewMemoryProvider()
+	_, sync := newDevice(t, provider)
+	provider.Upload(context.Background(), "bad.json", []byte("{not json"))
+
+	if _, err := sync.SyncPet(context.Background(), "bad"); !errors.Is(err, ErrCorruptSave) {
+		t.Errorf("Expected ErrCorruptSave, got %v", err)
+	}
+}
+
+func TestSyncWhileOffline(t *testing.T) {
+	provider := NewMemoryProvider()
+	dm, sync := newDevice(t, provider)
+	dm.SavePet(context.Background(), core.NewDigitalPet("Mochi", "owner"))
+
+	provider.SetOffline(true)
+	if _, err := sync.SyncAll(context.Background()); !IsTemporary(err) {
+		t.Errorf("Expected a temporary error, got %v", err)
+	}
+}
+
+func TestSyncTimesOut(t *testing.T) {
+	provider := NewMemoryProvider()
+	provider.Latency = time.Second
+	dm, sync := newDevice(t, provider)
+	sync.Timeout = 10 * time.Millisecond
+	dm.SavePet(context.Background(), core.NewDigitalPet("Mochi", "owner"))
+
+	start := time.Now()
+	_, err := sync.SyncAll(context.Background())
+	if !IsTemporary(err) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a timed out call to be temporary, got %v", err)
+	}
+	if time.Since(start) > 500*time.Millisecond {
+		t.Error("Expected the sync to give up at the timeout")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := sync.SyncAll(ctx); !errors.Is(err, context.Canceled) || IsTemporary(err) {
+		t.Errorf("Expected a cancelled sync to report cancellation, got %v", err)
+	}
+}
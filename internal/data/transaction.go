@@ -0,0 +1,183 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Transaction files
+const (
+	stagingName  = "staging"  // Directory holding a household save in progress
+	manifestName = "manifest" // Written last; its presence commits the staged saves
+)
+
+// PetPayload is a serialized pet ready to be written
+type PetPayload struct {
+	ID      types.PetID
+	Payload []byte
+}
+
+// SaveAll validates and writes several pets as one transaction. If any
+// pet is invalid nothing is written.
+func (dm *DataManager) SaveAll(ctx context.Context, pets []*core.DigitalPet) error {
+	payloads := make([]PetPayload, 0, len(pets))
+	for _, pet := range pets {
+		if err := ValidatePet(pet); err != nil {
+			return err
+		}
+		payload, err := pet.Save()
+		if err != nil {
+			return err
+		}
+		payloads = append(payloads, PetPayload{ID: pet.ID, Payload: payload})
+	}
+	return dm.WriteAll(ctx, payloads)
+}
+
+// WriteAll replaces several saves so that either all of them or none of
+// them change, keeping cross-pet data such as relationships consistent.
+// Every payload is first written to a staging area; the saves are then
+// committed by atomically putting a manifest of them in place, and only
+// after that moved over the live saves. A failure or cancellation before
+// the commit discards the staging area; a crash after it is finished by
+// Recover.
+func (dm *DataManager) WriteAll(ctx context.Context, payloads []PetPayload) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	// A committed transaction left by a crash must land before a new one
+	// replaces the staging area
+	if _, _, err := dm.finishTransaction(); err != nil {
+		return err
+	}
+	for _, p := range payloads {
+		if dm.archived(p.ID) {
+			return fmt.Errorf("%w: %s", ErrPetArchived, p.ID)
+		}
+	}
+
+	staging := dm.stagingPath()
+	if err := dm.stage(ctx, staging, payloads); err != nil {
+		return errors.Join(err, dm.storage().RemoveAll(staging))
+	}
+	_, _, err := dm.finishTransaction()
+	return err
+}
+
+// stage writes the payloads and then the manifest that commits them
+// (must be called with lock held)
+func (dm *DataManager) stage(ctx context.Context, staging string, payloads []PetPayload) error {
+	if err := dm.storage().MkdirAll(staging); err != nil {
+		return err
+	}
+
+	var manifest strings.Builder
+	for _, p := range payloads {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		raw, err := dm.encode(p.Payload)
+		if err != nil {
+			return err
+		}
+		if err := dm.storage().WriteFile(filepath.Join(staging, filepath.Base(dm.petPath(p.ID))), raw); err != nil {
+			return err
+		}
+		manifest.WriteString(url.PathEscape(string(p.ID)) + " " + checksum(raw) + "\n")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	manifestPath := filepath.Join(staging, manifestName)
+	if err := dm.storage().WriteFile(manifestPath+tempExt, []byte(manifest.String())); err != nil {
+		return err
+	}
+	return dm.storage().Rename(manifestPath+tempExt, manifestPath)
+}
+
+// finishTransaction completes or discards whatever is in the staging area.
+// With a manifest every staged save that matches its checksum is moved into
+// place; saves already moved are skipped. Without one the staged saves were
+// never committed and are discarded. Returns the pets rolled forward and
+// back (must be called with lock held).
+func (dm *DataManager) finishTransaction() (forward, back []types.PetID, err error) {
+	staging := dm.stagingPath()
+	entries, err := readManifest(dm.storage(), filepath.Join(staging, manifestName))
+	if os.IsNotExist(err) {
+		staged, _ := globFiles(dm.storage(), filepath.Join(staging, "*"+saveExt))
+		for _, path := range staged {
+			if id, err := url.PathUnescape(strings.TrimSuffix(filepath.Base(path), saveExt)); err == nil {
+				back = append(back, types.PetID(id))
+			}
+		}
+		return nil, back, dm.storage().RemoveAll(staging)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, entry := range entries {
+		path := dm.petPath(entry.id)
+		staged := filepath.Join(staging, filepath.Base(path))
+		payload, err := dm.storage().ReadFile(staged)
+		if os.IsNotExist(err) {
+			continue // Moved into place before the interruption
+		}
+		if err != nil {
+			return forward, back, err
+		}
+		if checksum(payload) != entry.sum {
+			return forward, back, fmt.Errorf("%w: staged save of %s does not match the manifest", ErrCorruptSave, entry.id)
+		}
+		if err := dm.storage().Rename(staged, path); err != nil {
+			return forward, back, err
+		}
+		forward = append(forward, entry.id)
+	}
+	return forward, back, dm.storage().RemoveAll(staging)
+}
+
+// stagingPath returns the directory transactions are staged in
+func (dm *DataManager) stagingPath() string {
+	return filepath.Join(dm.SavePath, stagingName)
+}
+
+// manifestEntry is one committed save listed in a manifest
+type manifestEntry struct {
+	id  types.PetID
+	sum string
+}
+
+// readManifest parses a transaction manifest
+func readManifest(storage StorageBackend, path string) ([]manifestEntry, error) {
+	content, err := storage.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []manifestEntry
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: malformed transaction manifest", ErrCorruptSave)
+		}
+		id, err := url.PathUnescape(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed transaction manifest", ErrCorruptSave)
+		}
+		entries = append(entries, manifestEntry{id: types.PetID(id), sum: fields[1]})
+	}
+	return entries, scanner.Err()
+}
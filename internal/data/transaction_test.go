@@ -0,0 +1,112 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestSaveAllIsAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	dm, _ := NewDataManager(t.TempDir())
+	a := core.NewDigitalPet("Mochi", "owner")
+	b := core.NewDigitalPet("Pip", "owner")
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{a, b}); err != nil {
+		t.Fatalf("SaveAll failed: %v", err)
+	}
+	if ids, _ := dm.ListPets(ctx, FilterActive); len(ids) != 2 {
+		t.Fatalf("Expected both pets saved, got %v", ids)
+	}
+
+	a.Name = "Renamed"
+	b.Biology.Vitals.Health = math.NaN()
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{a, b}); !errors.Is(err, ErrInvalidPet) {
+		t.Fatalf("Expected ErrInvalidPet, got %v", err)
+	}
+	if loaded, _ := dm.LoadPet(ctx, a.ID); loaded.Name != "Mochi" {
+		t.Errorf("An invalid pet should stop the whole save, got %s", loaded.Name)
+	}
+}
+
+func TestCancelledTransactionLeavesSavesUntouched(t *testing.T) {
+	dm, _ := NewDataManager(t.TempDir())
+	pet := core.NewDigitalPet("Mochi", "owner")
+	dm.SavePet(context.Background(), pet)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pet.Name = "Renamed"
+	if err := dm.SaveAll(ctx, []*core.DigitalPet{pet}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a cancelled save, got %v", err)
+	}
+	if _, err := os.Stat(dm.stagingPath()); !os.IsNotExist(err) {
+		t.Error("Expected the staging area to be discarded")
+	}
+	if loaded, _ := dm.LoadPet(context.Background(), pet.ID); loaded.Name != "Mochi" {
+		t.Errorf("Expected the previous save kept, got %s", loaded.Name)
+	}
+}
+
+func TestRecoverFinishesCommittedTransaction(t *testing.T) {
+	ctx := context.Background()
+	dm, _ := NewDataManager(t.TempDir())
+	a := core.NewDigitalPet("Mochi", "owner")
+	b := core.NewDigitalPet("Pip", "owner")
+	dm.SaveAll(ctx, []*core.DigitalPet{a, b})
+
+	// Crash after the manifest was written and one save moved into place
+	a.Name, b.Name = "Mochi II", "Pip II"
+	payloadA, _ := a.Save()
+	payloadB, _ := b.Save()
+	if err := dm.stage(ctx, dm.stagingPath(), []PetPayload{{a.ID, payloadA}, {b.ID, payloadB}}); err != nil {
+		t.Fatal(err)
+	}
+	os.Rename(filepath.Join(dm.stagingPath(), fileName(dm, a)), dm.petPath(a.ID))
+
+	report, err := dm.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if len(report.RolledForward) != 1 || report.RolledForward[0] != b.ID {
+		t.Errorf("Expected the second pet rolled forward, got %+v", report)
+	}
+	loadedA, _ := dm.LoadPet(ctx, a.ID)
+	loadedB, _ := dm.LoadPet(ctx, b.ID)
+	if loadedA.Name != "Mochi II" || loadedB.Name != "Pip II" {
+		t.Errorf("Expected both pets updated, got %s and %s", loadedA.Name, loadedB.Name)
+	}
+}
+
+func TestRecoverDiscardsUncommittedTransaction(t *testing.T) {
+	ctx := context.Background()
+	dm, _ := NewDataManager(t.TempDir())
+	pet := core.NewDigitalPet("Mochi", "owner")
+	dm.SavePet(ctx, pet)
+
+	// Crash while staging, before the manifest was written
+	pet.Name = "Renamed"
+	payload, _ := pet.Save()
+	os.MkdirAll(dm.stagingPath(), 0o755)
+	os.WriteFile(filepath.Join(dm.stagingPath(), fileName(dm, pet)), payload, 0o644)
+
+	report, err := dm.Recover()
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if len(report.RolledBack) != 1 {
+		t.Errorf("Expected the staged save rolled back, got %+v", report)
+	}
+	if loaded, _ := dm.LoadPet(ctx, pet.ID); loaded.Name != "Mochi" {
+		t.Errorf("Expected the previous save kept, got %s", loaded.Name)
+	}
+}
+
+// fileName returns the base name of a pet's save
+func fileName(dm *DataManager, pet *core.DigitalPet) string {
+	return filepath.Base(dm.petPath(pet.ID))
+}
@@ -0,0 +1,146 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Lifecycle webhook settings
+const (
+	DefaultWebhookQueueSize = 64               // Events that can wait to be posted
+	DefaultWebhookTimeout   = 10 * time.Second // Limit on posting one event
+	MaxRecordedWebhookErrs  = 16               // Post failures kept until Close
+)
+
+var (
+	// ErrWebhookQueueFull is returned when an event is dropped because the
+	// queue is full
+	ErrWebhookQueueFull = errors.New("lifecycle webhook queue is full")
+	// ErrWebhookClosed is returned when sending after Close
+	ErrWebhookClosed = errors.New("lifecycle webhook is closed")
+)
+
+// LifecycleWebhook posts lifecycle events to a URL as JSON in the
+// background, so a slow or unreachable monitor never holds up a save. Add
+// its Send method to a set of LifecycleHooks.
+type LifecycleWebhook struct {
+	URL     string
+	Client  *http.Client
+	Timeout time.Duration
+
+	mu      sync.Mutex
+	queue   chan LifecycleEvent
+	done    chan struct{}
+	closed  bool
+	dropped int
+	errs    []error
+}
+
+// NewLifecycleWebhook creates a webhook for a URL and starts its sender
+func NewLifecycleWebhook(url string, queueSize int) *LifecycleWebhook {
+	if queueSize < 1 {
+		queueSize = DefaultWebhookQueueSize
+	}
+	w := &LifecycleWebhook{
+		URL:     url,
+		Client:  http.DefaultClient,
+		Timeout: DefaultWebhookTimeout,
+		queue:   make(chan LifecycleEvent, queueSize),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Send queues an event without waiting. Its signature suits
+// LifecycleHooks.Add; use Queue to learn whether the event was dropped.
+func (w *LifecycleWebhook) Send(e LifecycleEvent) {
+	w.Queue(e)
+}
+
+// Queue queues an event without waiting
+func (w *LifecycleWebhook) Queue(e LifecycleEvent) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrWebhookClosed
+	}
+	select {
+	case w.queue <- e:
+		return nil
+	default:
+		w.dropped++
+		return ErrWebhookQueueFull
+	}
+}
+
+// Dropped returns how many events were dropped because the queue was full
+func (w *LifecycleWebhook) Dropped() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dropped
+}
+
+// Close posts the events already queued, stops the sender and returns the
+// posts that failed
+func (w *LifecycleWebhook) Close() error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+	<-w.done
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return errors.Join(w.errs...)
+}
+
+// run posts queued events until the queue is closed
+func (w *LifecycleWebhook) run() {
+	defer close(w.done)
+	for e := range w.queue {
+		if err := w.post(e); err != nil {
+			w.mu.Lock()
+			if len(w.errs) < MaxRecordedWebhookErrs {
+				w.errs = append(w.errs, err)
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+// post sends one event to the URL
+func (w *LifecycleWebhook) post(e LifecycleEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), w.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("lifecycle webhook: %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
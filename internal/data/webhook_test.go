@@ -0,0 +1,48 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLifecycleWebhookPostsEvents(t *testing.T) {
+	var mu sync.Mutex
+	var received []LifecycleEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e LifecycleEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if e.Type == LifecycleSync {
+			http.Error(w, "monitor is down", http.StatusServiceUnavailable)
+			return
+		}
+		mu.Lock()
+		received = append(received, e)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	hooks := NewLifecycleHooks()
+	webhook := NewLifecycleWebhook(server.URL, 0)
+	hooks.Add(webhook.Send)
+	hooks.Emit(LifecycleEvent{Type: LifecycleBackup, Path: "backups/backup-1", Size: 2048, Pets: 2})
+	hooks.Emit(LifecycleEvent{Type: LifecycleSync, Summary: "0 sent"})
+
+	err := webhook.Close()
+	if err == nil || !strings.Contains(err.Error(), "monitor is down") {
+		t.Errorf("Expected the refused post reported, got %v", err)
+	}
+	if len(received) != 1 || received[0].Path != "backups/backup-1" || received[0].Size != 2048 || received[0].Time.IsZero() {
+		t.Errorf("Expected the backup posted as JSON, got %+v", received)
+	}
+	if err := webhook.Queue(LifecycleEvent{Type: LifecycleSave}); !errors.Is(err, ErrWebhookClosed) {
+		t.Errorf("Expected ErrWebhookClosed, got %v", err)
+	}
+}
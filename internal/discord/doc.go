@@ -0,0 +1,14 @@
+// Package discord connects a Gochi household to Discord.
+//
+// This package provides:
+//   - Channel notifications, through a webhook, when a pet dies or falls
+//     into critical condition, and when backups, syncs and migrations
+//     finish or any data operation fails
+//   - An interactions endpoint for the /status, /feed and /play slash
+//     commands, verified with the application's public key
+//   - An ASCII portrait of the pet in status replies
+//   - Registration of the slash commands with Discord
+//
+// Notifications work in any mode; slash commands need server mode, since
+// Discord must be able to reach the interactions endpoint.
+package discord
@@ -0,0 +1,354 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/ui"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Interaction settings
+const (
+	InteractionsPath     = "/discord/interactions"       // Where the interactions endpoint is served
+	APIBase              = "https://discord.com/api/v10" // Discord REST API used to register commands
+	CareIntensity        = 0.5                           // Strength of /feed and /play
+	MaxInteractionBody   = 64 << 10                      // Bytes accepted in an interaction
+	MaxInteractionAge    = 5 * time.Minute               // Signed timestamps older than this are refused
+	registerTimeout      = 30 * time.Second              // Limit on registering commands
+	ephemeralMessageFlag = 64                            // Reply only visible to the caller
+	AuditActor           = "discord"                     // Actor in the audit log, followed by ":" and the user name
+)
+
+// Discord interaction and response types
+const (
+	interactionPing    = 1
+	interactionCommand = 2
+
+	responsePong    = 1
+	responseMessage = 4
+
+	optionString = 3
+)
+
+var (
+	// ErrInvalidPublicKey is returned when the application public key is malformed
+	ErrInvalidPublicKey = errors.New("invalid discord public key")
+	// ErrBadSignature is returned for interactions not signed by Discord
+	ErrBadSignature = errors.New("invalid interaction signature")
+	// ErrUnknownCommand is returned for slash commands the bot does not have
+	ErrUnknownCommand = errors.New("unknown command")
+	// ErrWhichPet is returned when a command needs a pet name to be unambiguous
+	ErrWhichPet = errors.New("which pet?")
+)
+
+// CommandOption is an argument of a slash command
+type CommandOption struct {
+	Type        int    `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+// Command is a slash command definition as registered with Discord
+type Command struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     []CommandOption `json:"options,omitempty"`
+}
+
+// petOption is the optional pet argument every command takes
+var petOption = CommandOption{Type: optionString, Name: "pet", Description: "Pet name; may be left out with only one pet"}
+
+// Commands returns the slash commands the bot answers
+func Commands() []Command {
+	return []Command{
+		{Name: "status", Description: "Show how a pet is doing", Options: []CommandOption{petOption}},
+		{Name: "feed", Description: "Feed a pet", Options: []CommandOption{petOption}},
+		{Name: "play", Description: "Play with a pet", Options: []CommandOption{petOption}},
+	}
+}
+
+// RegisterCommands replaces the application's global slash commands with
+// Commands. baseURL is normally APIBase.
+func RegisterCommands(ctx context.Context, client *http.Client, baseURL, applicationID, botToken string) error {
+	body, err := json.Marshal(Commands())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, registerTimeout)
+	defer cancel()
+	url := strings.TrimSuffix(baseURL, "/") + "/applications/" + applicationID + "/commands"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bot "+botToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("registering discord commands: %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// Bot answers slash commands for the household of a game loop
+type Bot struct {
+	Loop      *game.GameLoop
+	PublicKey ed25519.PublicKey
+	// Impressions describes pets in words instead of percentages in
+	// /status replies when set
+	Impressions *biology.Impressions
+	now         func() time.Time
+}
+
+// NewBot creates a bot that trusts interactions signed with the
+// application's hex-encoded public key
+func NewBot(loop *game.GameLoop, publicKey string) (*Bot, error) {
+	key, err := hex.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: expected %d hex-encoded bytes", ErrInvalidPublicKey, ed25519.PublicKeySize)
+	}
+	return &Bot{Loop: loop, PublicKey: key, now: time.Now}, nil
+}
+
+// interactionRequest is the part of a Discord interaction the bot reads
+type interactionRequest struct {
+	Type int `json:"type"`
+	Data struct {
+		Name    string `json:"name"`
+		Options []struct {
+			Name  string          `json:"name"`
+			Value json.RawMessage `json:"value"`
+		} `json:"options"`
+	} `json:"data"`
+	Member *struct {
+		User discordUser `json:"user"`
+	} `json:"member"` // Set for commands in a server
+	User *discordUser `json:"user"` // Set for commands in direct messages
+}
+
+// discordUser is the Discord account that used a command
+type discordUser struct {
+	Username string `json:"username"`
+}
+
+// actor names the caller in the audit log
+func (in *interactionRequest) actor() string {
+	switch {
+	case in.Member != nil && in.Member.User.Username != "":
+		return AuditActor + ":" + in.Member.User.Username
+	case in.User != nil && in.User.Username != "":
+		return AuditActor + ":" + in.User.Username
+	}
+	return AuditActor
+}
+
+// option returns a string option of the command, or ""
+func (in *interactionRequest) option(name string) string {
+	for _, opt := range in.Data.Options {
+		var value string
+		if opt.Name == name && json.Unmarshal(opt.Value, &value) == nil {
+			return value
+		}
+	}
+	return ""
+}
+
+// response is the bot's reply to an interaction
+type response struct {
+	Type int           `json:"type"`
+	Data *responseData `json:"data,omitempty"`
+}
+
+// responseData is the message sent in reply
+type responseData struct {
+	Content         string          `json:"content"`
+	Flags           int             `json:"flags,omitempty"`
+	AllowedMentions allowedMentions `json:"allowed_mentions"`
+}
+
+// ServeHTTP answers one interaction. Discord refuses endpoints that accept
+// unsigned requests, so the signature is checked before anything else.
+func (b *Bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxInteractionBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := b.verify(r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var in interactionRequest
+	if err := json.Unmarshal(body, &in); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var resp response
+	switch in.Type {
+	case interactionPing:
+		resp = response{Type: responsePong}
+	case interactionCommand:
+		resp = b.command(&in)
+	default:
+		http.Error(w, "unsupported interaction type "+strconv.Itoa(in.Type), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// verify checks that Discord signed the timestamp and body recently
+func (b *Bot) verify(header http.Header, body []byte) error {
+	sig, err := hex.DecodeString(header.Get("X-Signature-Ed25519"))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return ErrBadSignature
+	}
+	timestamp := header.Get("X-Signature-Timestamp")
+	if !ed25519.Verify(b.PublicKey, append([]byte(timestamp), body...), sig) {
+		return ErrBadSignature
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrBadSignature
+	}
+	if age := b.now().Sub(time.Unix(seconds, 0)); age > MaxInteractionAge || age < -MaxInteractionAge {
+		return fmt.Errorf("%w: timestamp is %s old", ErrBadSignature, age.Round(time.Second))
+	}
+	return nil
+}
+
+// command runs a slash command against the household
+func (b *Bot) command(in *interactionRequest) response {
+	var content string
+	var err error
+	b.Loop.Do(func() {
+		var pet *core.DigitalPet
+		pet, err = b.findPet(in.option("pet"))
+		if err != nil {
+			return
+		}
+		switch in.Data.Name {
+		case "status":
+			content = RenderStatus(pet, b.Impressions)
+		case "feed":
+			content, err = b.care(in.actor(), pet, types.InteractionFeeding)
+		case "play":
+			content, err = b.care(in.actor(), pet, types.InteractionPlaying)
+		default:
+			err = fmt.Errorf("%w: /%s", ErrUnknownCommand, in.Data.Name)
+		}
+	})
+	b.Loop.Wake()
+	if err != nil {
+		return message(ui.ErrorMessage(err), ephemeralMessageFlag)
+	}
+	return message(content, 0)
+}
+
+// care performs an interaction for a caller and describes how the pet
+// reacted and any jealousy it caused
+func (b *Bot) care(actor string, pet *core.DigitalPet, kind types.InteractionType) (string, error) {
+	household := b.Loop.Household
+	reactions, err := household.Interact(pet.ID, kind, CareIntensity)
+	if err != nil {
+		return "", err
+	}
+	b.Loop.Audit(actor, data.AuditInteract, pet.ID, fmt.Sprintf("%s for %s", kind, pet.Name))
+	content := household.Feedback.Generate(pet, kind)
+	for _, reaction := range reactions {
+		if other, ok := household.Pets[reaction.PetID]; ok {
+			content += fmt.Sprintf(" %s looks jealous.", other.Name)
+		}
+	}
+	return content, nil
+}
+
+// findPet looks a pet up by name or ID, ignoring case. With no name the
+// only pet is chosen.
+func (b *Bot) findPet(name string) (*core.DigitalPet, error) {
+	pets := b.Loop.Household.Pets
+	if name == "" {
+		if len(pets) == 1 {
+			for _, pet := range pets {
+				return pet, nil
+			}
+		}
+		names := make([]string, 0, len(pets))
+		for _, pet := range pets {
+			names = append(names, pet.Name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("%w: %s", ErrWhichPet, strings.Join(names, ", "))
+	}
+	for _, pet := range pets {
+		if strings.EqualFold(pet.Name, name) || string(pet.ID) == name {
+			return pet, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ui.ErrPetNotFound, name)
+}
+
+// RenderStatus describes a pet for chat: its portrait in a code block and
+// its main vitals, in words when im is set
+func RenderStatus(pet *core.DigitalPet, im *biology.Impressions) string {
+	status := pet.GetCurrentStatus()
+	var b strings.Builder
+	b.WriteString("```\n")
+	b.WriteString(ui.RenderPet(pet))
+	b.WriteString("```\n")
+	if !status.IsAlive {
+		fmt.Fprintf(&b, "%s has passed away.", pet.Name)
+		return b.String()
+	}
+	if im != nil {
+		fmt.Fprintf(&b, "%s: %s, %s and %s · %s", pet.Name, ui.VitalImpression(im, pet, biology.VitalHealth),
+			ui.VitalImpression(im, pet, biology.VitalEnergy), ui.VitalImpression(im, pet, biology.VitalHappiness), status.MoodDescription)
+	} else {
+		fmt.Fprintf(&b, "Health %.0f%% · Energy %.0f%% · Happiness %.0f%% · %s",
+			status.Health*100, status.Energy*100, status.Happiness*100, status.MoodDescription)
+	}
+	if len(status.CriticalNeeds) > 0 {
+		fmt.Fprintf(&b, "\nNeeds help: %s", strings.Join(status.CriticalNeeds, ", "))
+	}
+	return b.String()
+}
+
+// message builds a message response
+func message(content string, flags int) response {
+	if runes := []rune(content); len(runes) > MaxMessageLength {
+		content = string(runes[:MaxMessageLength])
+	}
+	return response{Type: responseMessage, Data: &responseData{
+		Content:         content,
+		Flags:           flags,
+		AllowedMentions: allowedMentions{Parse: []string{}},
+	}}
+}
@@ -0,0 +1,184 @@
+package discord
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestBot creates a bot over a household of pets and the key that signs
+// its interactions
+func newTestBot(t *testing.T, names ...string) (*Bot, ed25519.PrivateKey) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	household := interaction.NewHousehold()
+	for _, name := range names {
+		household.AddPet(core.NewDigitalPet(name, "owner"))
+	}
+	loop, err := game.NewGameLoop(config.Default().Simulation, household, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { loop.Shutdown(context.Background()) })
+	bot, err := NewBot(loop, hex.EncodeToString(public))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.now = func() time.Time { return testNow }
+	return bot, private
+}
+
+// send signs an interaction as Discord would and returns the reply
+func send(t *testing.T, bot *Bot, key ed25519.PrivateKey, at time.Time, body string) (int, response) {
+	t.Helper()
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, InteractionsPath, strings.NewReader(body))
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(key, []byte(timestamp+body))))
+	rec := httptest.NewRecorder()
+	bot.ServeHTTP(rec, req)
+
+	var resp response
+	if rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("bad JSON %q: %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code, resp
+}
+
+// commandBody returns an application command interaction
+func commandBody(name, pet string) string {
+	if pet == "" {
+		return `{"type":2,"data":{"name":"` + name + `"}}`
+	}
+	return `{"type":2,"data":{"name":"` + name + `","options":[{"name":"pet","type":3,"value":"` + pet + `"}]}}`
+}
+
+func TestNewBotRejectsBadKey(t *testing.T) {
+	for _, key := range []string{"", "zz", strings.Repeat("ab", 16)} {
+		if _, err := NewBot(nil, key); !errors.Is(err, ErrInvalidPublicKey) {
+			t.Errorf("Key %q: expected ErrInvalidPublicKey, got %v", key, err)
+		}
+	}
+}
+
+func TestBotVerifiesSignatures(t *testing.T) {
+	bot, key := newTestBot(t, "Mochi")
+	if code, resp := send(t, bot, key, testNow, `{"type":1}`); code != http.StatusOK || resp.Type != responsePong {
+		t.Errorf("Expected a pong, got %d %+v", code, resp)
+	}
+
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+	if code, _ := send(t, bot, otherKey, testNow, `{"type":1}`); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a foreign signature, got %d", code)
+	}
+	if code, _ := send(t, bot, key, testNow.Add(-MaxInteractionAge-time.Minute), `{"type":1}`); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a stale timestamp, got %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, InteractionsPath, strings.NewReader(`{"type":1}`))
+	rec := httptest.NewRecorder()
+	bot.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a signature, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	bot.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, InteractionsPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", rec.Code)
+	}
+}
+
+func TestBotCommands(t *testing.T) {
+	bot, key := newTestBot(t, "Mochi")
+
+	_, resp := send(t, bot, key, testNow, commandBody("status", ""))
+	if resp.Type != responseMessage || !strings.Contains(resp.Data.Content, "```") || !strings.Contains(resp.Data.Content, "Health") {
+		t.Errorf("Unexpected status reply %+v", resp.Data)
+	}
+	_, resp = send(t, bot, key, testNow, commandBody("feed", "mochi"))
+	if !strings.HasPrefix(resp.Data.Content, "Mochi ") || resp.Data.Flags != 0 {
+		t.Errorf("Expected Mochi's reaction to being fed, got %+v", resp.Data)
+	}
+	fed := resp.Data.Content
+	_, resp = send(t, bot, key, testNow, commandBody("play", ""))
+	if !strings.HasPrefix(resp.Data.Content, "Mochi ") || resp.Data.Content == fed {
+		t.Errorf("Unexpected play reply %+v", resp.Data)
+	}
+
+	_, resp = send(t, bot, key, testNow, commandBody("feed", "Biscuit"))
+	if resp.Data.Flags != ephemeralMessageFlag || !strings.Contains(resp.Data.Content, "Biscuit") {
+		t.Errorf("Expected a private not-found reply, got %+v", resp.Data)
+	}
+	_, resp = send(t, bot, key, testNow, commandBody("dance", ""))
+	if resp.Data.Flags != ephemeralMessageFlag {
+		t.Errorf("Expected a private reply to an unknown command, got %+v", resp.Data)
+	}
+}
+
+func TestBotDescribesInWords(t *testing.T) {
+	bot, key := newTestBot(t, "Mochi")
+	bot.Impressions = &biology.Impressions{}
+	_, resp := send(t, bot, key, testNow, commandBody("status", ""))
+	if strings.Contains(resp.Data.Content, "%") || !strings.Contains(resp.Data.Content, "Mochi: ") {
+		t.Errorf("Expected the vitals described in words, got %+v", resp.Data)
+	}
+}
+
+func TestBotAsksWhichPet(t *testing.T) {
+	bot, key := newTestBot(t, "Mochi", "Biscuit")
+	_, resp := send(t, bot, key, testNow, commandBody("status", ""))
+	if resp.Data.Flags != ephemeralMessageFlag || !strings.Contains(resp.Data.Content, "Biscuit, Mochi") {
+		t.Errorf("Expected the pet names to choose from, got %+v", resp.Data)
+	}
+	_, resp = send(t, bot, key, testNow, commandBody("status", "Biscuit"))
+	if !strings.Contains(resp.Data.Content, "Health") {
+		t.Errorf("Expected Biscuit's status, got %+v", resp.Data)
+	}
+}
+
+func TestRegisterCommands(t *testing.T) {
+	var got []Command
+	var auth, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, path = r.Header.Get("Authorization"), r.Method+" "+r.URL.Path
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte("[]"))
+	}))
+	defer srv.Close()
+
+	if err := RegisterCommands(context.Background(), srv.Client(), srv.URL, "123", "tok"); err != nil {
+		t.Fatal(err)
+	}
+	if auth != "Bot tok" || path != "PUT /applications/123/commands" || len(got) != len(Commands()) {
+		t.Errorf("Unexpected registration %q %q %+v", auth, path, got)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "401: Unauthorized", http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+	if err := RegisterCommands(context.Background(), failing.Client(), failing.URL, "123", "bad"); err == nil {
+		t.Error("Expected an error for a refused registration")
+	}
+}
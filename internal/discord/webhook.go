@@ -0,0 +1,223 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+// Notification settings
+const (
+	DefaultNotifyQueueSize = 64               // Messages that can wait to be posted
+	DefaultNotifyTimeout   = 10 * time.Second // Limit on posting one message
+	MaxMessageLength       = 2000             // Discord's limit on message content
+	MaxRecordedPostErrors  = 16               // Post failures kept until the next Close
+)
+
+var (
+	// ErrNotifyQueueFull is returned when a notification is dropped because
+	// the queue is full
+	ErrNotifyQueueFull = errors.New("discord notification queue is full")
+	// ErrNotifierClosed is returned when notifying after Close
+	ErrNotifierClosed = errors.New("discord notifier is closed")
+)
+
+// Notifier posts messages to a Discord channel webhook in the background,
+// so a slow or unreachable Discord never holds up the game
+type Notifier struct {
+	WebhookURL string
+	Client     *http.Client
+	Timeout    time.Duration
+
+	mu      sync.Mutex
+	queue   chan string
+	done    chan struct{}
+	closed  bool
+	dropped int
+	errs    []error
+}
+
+// NewNotifier creates a notifier for a webhook and starts its sender
+func NewNotifier(webhookURL string, queueSize int) *Notifier {
+	if queueSize < 1 {
+		queueSize = DefaultNotifyQueueSize
+	}
+	n := &Notifier{
+		WebhookURL: webhookURL,
+		Client:     http.DefaultClient,
+		Timeout:    DefaultNotifyTimeout,
+		queue:      make(chan string, queueSize),
+		done:       make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+// Attach subscribes the notifier to the events worth a message: pet
+// alerts, game loop stalls and finished data operations
+func (n *Notifier) Attach(events *simulation.EventSystem) simulation.SubscriptionID {
+	return events.Subscribe(simulation.WildcardEvent, 0, func(e simulation.Event) {
+		if msg, ok := Describe(e); ok {
+			n.Notify(msg)
+		}
+	})
+}
+
+// Describe returns the channel message for an event, if it deserves one
+func Describe(e simulation.Event) (string, bool) {
+	name, _ := e.Data["name"].(string)
+	switch e.Type {
+	case simulation.EventPetDied:
+		msg := fmt.Sprintf("**%s** has died.", name)
+		if cause, _ := e.Data["cause"].(string); cause != "" {
+			msg = fmt.Sprintf("**%s** has died of %s.", name, cause)
+		}
+		return msg, true
+	case simulation.EventPetCritical:
+		needs, _ := e.Data["needs"].([]string)
+		return fmt.Sprintf("**%s** needs help now: %s.", name, strings.Join(needs, ", ")), true
+	case simulation.EventPetCrowd:
+		if applied, _ := e.Data["applied"].(bool); !applied {
+			return "", false
+		}
+		return fmt.Sprintf("The crowd chose %s for **%s** (%v of %v votes).", e.Data["action"], name, e.Data["votes"], e.Data["total"]), true
+	case simulation.EventStall:
+		overdue, _ := e.Data["overdue"].(float64)
+		msg := fmt.Sprintf("The game stalled for %.0fs and was restarted.", overdue)
+		if restarted, _ := e.Data["restarted"].(bool); !restarted {
+			msg = fmt.Sprintf("The game stalled for %.0fs too many times and has stopped.", overdue)
+		}
+		if saved, _ := e.Data["saved"].(bool); !saved {
+			msg += " The pets could not be saved; their last saves stand."
+		}
+		return msg, true
+	case simulation.EventDataBackup, simulation.EventDataSync, simulation.EventDataMigration, simulation.EventDataSave:
+		return describeData(e)
+	default:
+		return "", false
+	}
+}
+
+// describeData returns the channel message for a finished data operation.
+// Every failure deserves one; routine saves do not.
+func describeData(e simulation.Event) (string, bool) {
+	summary, _ := e.Data["summary"].(string)
+	if reason, _ := e.Data["error"].(string); reason != "" {
+		return fmt.Sprintf("**Data protection failed**: %s: %s", summary, reason), true
+	}
+	switch e.Type {
+	case simulation.EventDataBackup:
+		size, _ := e.Data["size"].(int64)
+		return fmt.Sprintf("Backup complete: %s (%.1f KB).", summary, float64(size)/1024), true
+	case simulation.EventDataSync:
+		return fmt.Sprintf("Sync complete: %s.", summary), true
+	case simulation.EventDataMigration:
+		return fmt.Sprintf("Saves migrated: %s.", summary), true
+	}
+	return "", false
+}
+
+// Notify queues a message without waiting. Messages are cut to Discord's
+// length limit.
+func (n *Notifier) Notify(content string) error {
+	if runes := []rune(content); len(runes) > MaxMessageLength {
+		content = string(runes[:MaxMessageLength])
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return ErrNotifierClosed
+	}
+	select {
+	case n.queue <- content:
+		return nil
+	default:
+		n.dropped++
+		return ErrNotifyQueueFull
+	}
+}
+
+// Dropped returns how many messages were dropped because the queue was full
+func (n *Notifier) Dropped() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.dropped
+}
+
+// Close posts the messages already queued, stops the sender and returns
+// the posts that failed
+func (n *Notifier) Close() error {
+	n.mu.Lock()
+	if !n.closed {
+		n.closed = true
+		close(n.queue)
+	}
+	n.mu.Unlock()
+	<-n.done
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return errors.Join(n.errs...)
+}
+
+// run posts queued messages until the queue is closed
+func (n *Notifier) run() {
+	defer close(n.done)
+	for content := range n.queue {
+		if err := n.post(content); err != nil {
+			n.mu.Lock()
+			if len(n.errs) < MaxRecordedPostErrors {
+				n.errs = append(n.errs, err)
+			}
+			n.mu.Unlock()
+		}
+	}
+}
+
+// webhookMessage is the body of a webhook post. Mentions are never parsed,
+// so a pet name cannot ping the channel.
+type webhookMessage struct {
+	Content         string          `json:"content"`
+	AllowedMentions allowedMentions `json:"allowed_mentions"`
+}
+
+// allowedMentions restricts who a message may ping
+type allowedMentions struct {
+	Parse []string `json:"parse"`
+}
+
+// post sends one message to the webhook
+func (n *Notifier) post(content string) error {
+	body, err := json.Marshal(webhookMessage{Content: content, AllowedMentions: allowedMentions{Parse: []string{}}})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), n.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("discord webhook: %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
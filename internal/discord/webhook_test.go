@@ -0,0 +1,129 @@
+package discord
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+// webhookRecorder is a fake webhook that keeps the messages posted to it
+type webhookRecorder struct {
+	mu       sync.Mutex
+	messages []webhookMessage
+	status   int
+}
+
+func (wr *webhookRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var msg webhookMessage
+	json.NewDecoder(r.Body).Decode(&msg)
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	wr.messages = append(wr.messages, msg)
+	if wr.status != 0 {
+		w.WriteHeader(wr.status)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestNotifierPostsEvents(t *testing.T) {
+	recorder := &webhookRecorder{}
+	srv := httptest.NewServer(recorder)
+	defer srv.Close()
+
+	events := simulation.NewEventSystem(0)
+	n := NewNotifier(srv.URL, 0)
+	n.Attach(events)
+	events.Publish(simulation.Event{Type: simulation.EventPetDied, Data: map[string]interface{}{"name": "Mochi", "cause": "starvation"}})
+	events.Publish(simulation.Event{Type: simulation.EventTick})
+	events.Publish(simulation.Event{Type: simulation.EventPetCritical, Data: map[string]interface{}{"name": "@everyone", "needs": []string{"hunger", "thirst"}}})
+	if err := n.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	if len(recorder.messages) != 2 {
+		t.Fatalf("Expected 2 posts, got %+v", recorder.messages)
+	}
+	if got := recorder.messages[0].Content; got != "**Mochi** has died of starvation." {
+		t.Errorf("Unexpected death message %q", got)
+	}
+	if got := recorder.messages[1].Content; got != "**@everyone** needs help now: hunger, thirst." {
+		t.Errorf("Unexpected critical message %q", got)
+	}
+	if recorder.messages[1].AllowedMentions.Parse == nil || len(recorder.messages[1].AllowedMentions.Parse) != 0 {
+		t.Error("Expected mentions to be disabled")
+	}
+}
+
+func TestNotifierReportsFailures(t *testing.T) {
+	recorder := &webhookRecorder{status: http.StatusNotFound}
+	srv := httptest.NewServer(recorder)
+	defer srv.Close()
+
+	n := NewNotifier(srv.URL, 1)
+	if err := n.Notify(strings.Repeat("é", MaxMessageLength+10)); err != nil {
+		t.Fatal(err)
+	}
+	err := n.Close()
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected the 404 from Close, got %v", err)
+	}
+	if got := len([]rune(recorder.messages[0].Content)); got != MaxMessageLength {
+		t.Errorf("Expected the message cut to %d runes, got %d", MaxMessageLength, got)
+	}
+	if err := n.Notify("late"); !errors.Is(err, ErrNotifierClosed) {
+		t.Errorf("Expected ErrNotifierClosed, got %v", err)
+	}
+}
+
+func TestNotifierDropsWhenFull(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(srv.URL, 1)
+	var full error
+	for i := 0; i < 4 && full == nil; i++ {
+		full = n.Notify("hello")
+	}
+	if !errors.Is(full, ErrNotifyQueueFull) || n.Dropped() != 1 {
+		t.Errorf("Expected a dropped message, got %v with %d dropped", full, n.Dropped())
+	}
+	close(release)
+	n.Close()
+}
+
+func TestDescribeStall(t *testing.T) {
+	msg, ok := Describe(simulation.Event{Type: simulation.EventStall, Data: map[string]interface{}{"overdue": 75.0, "saved": true, "restarted": true}})
+	if !ok || msg != "The game stalled for 75s and was restarted." {
+		t.Errorf("Unexpected restart message %q", msg)
+	}
+	msg, _ = Describe(simulation.Event{Type: simulation.EventStall, Data: map[string]interface{}{"overdue": 75.0, "saved": false, "restarted": false}})
+	if !strings.Contains(msg, "has stopped") || !strings.Contains(msg, "could not be saved") {
+		t.Errorf("Unexpected give-up message %q", msg)
+	}
+}
+
+func TestDescribeData(t *testing.T) {
+	msg, ok := Describe(simulation.Event{Type: simulation.EventDataBackup,
+		Data: map[string]interface{}{"summary": "backed up 2 pets to backups/backup-1", "size": int64(2048)}})
+	if !ok || msg != "Backup complete: backed up 2 pets to backups/backup-1 (2.0 KB)." {
+		t.Errorf("Unexpected backup message %q", msg)
+	}
+	if _, ok := Describe(simulation.Event{Type: simulation.EventDataSave, Data: map[string]interface{}{"summary": "saved 2 pets"}}); ok {
+		t.Error("Routine saves should not be posted")
+	}
+	msg, ok = Describe(simulation.Event{Type: simulation.EventDataSave,
+		Data: map[string]interface{}{"summary": "could not save 2 pets", "error": "disk full"}})
+	if !ok || !strings.Contains(msg, "failed") || !strings.Contains(msg, "disk full") {
+		t.Errorf("Unexpected failure message %q", msg)
+	}
+}
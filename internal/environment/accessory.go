@@ -0,0 +1,129 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrUnknownAccessory is returned when an accessory name is not recognised
+	ErrUnknownAccessory = errors.New("unknown accessory")
+	// ErrNotForSale is returned when buying an accessory that can only be earned
+	ErrNotForSale = errors.New("accessory is not for sale")
+)
+
+// AccessorySlot is where on a pet an accessory is worn. A pet wears at
+// most one accessory per slot.
+type AccessorySlot int
+
+const (
+	SlotHead AccessorySlot = iota
+	SlotNeck
+)
+
+// String returns the string representation of AccessorySlot
+func (as AccessorySlot) String() string {
+	return [...]string{"Head", "Neck"}[as]
+}
+
+// Accessory is a purely cosmetic item a pet can wear
+type Accessory int
+
+const (
+	AccessoryBandana Accessory = iota
+	AccessoryCollar
+	AccessoryScarf
+	AccessoryHat
+	AccessoryBow
+	AccessoryPartyHat // Handed out at festivals
+	AccessoryRosette  // Awarded for milestones
+	AccessoryLaurel   // Given by the sanctuary to its friends
+	AccessoryMedal    // Given by the sanctuary to its benefactors
+)
+
+// AllAccessories returns every accessory in declaration order
+func AllAccessories() []Accessory {
+	return []Accessory{
+		AccessoryBandana, AccessoryCollar, AccessoryScarf, AccessoryHat,
+		AccessoryBow, AccessoryPartyHat, AccessoryRosette, AccessoryLaurel, AccessoryMedal,
+	}
+}
+
+// String returns the string representation of Accessory
+func (a Accessory) String() string {
+	return [...]string{
+		"Bandana", "Collar", "Scarf", "Hat", "Bow", "PartyHat", "Rosette", "Laurel", "Medal",
+	}[a]
+}
+
+// ParseAccessory looks up an accessory by name, ignoring case
+func ParseAccessory(name string) (Accessory, error) {
+	for _, accessory := range AllAccessories() {
+		if strings.EqualFold(accessory.String(), name) {
+			return accessory, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownAccessory, name)
+}
+
+// accessorySpec describes how an accessory is worn and drawn
+type accessorySpec struct {
+	Slot  AccessorySlot
+	Price int    // Coins at the shop; 0 if it can only be earned
+	Art   string // Drawn above the ears for the head, or as the chin line for the neck
+}
+
+// accessorySpecs lists every accessory
+var accessorySpecs = map[Accessory]accessorySpec{
+	AccessoryBandana:  {Slot: SlotNeck, Price: 15, Art: ">=v=<"},
+	AccessoryCollar:   {Slot: SlotNeck, Price: 20, Art: ">-o-<"},
+	AccessoryScarf:    {Slot: SlotNeck, Price: 25, Art: ">~~~<"},
+	AccessoryHat:      {Slot: SlotHead, Price: 30, Art: "_|=|_"},
+	AccessoryBow:      {Slot: SlotHead, Price: 10, Art: " >o< "},
+	AccessoryPartyHat: {Slot: SlotHead, Art: "  /\\ "},
+	AccessoryRosette:  {Slot: SlotNeck, Art: ">-@-<"},
+	AccessoryLaurel:   {Slot: SlotHead, Art: " \\Y/ "},
+	AccessoryMedal:    {Slot: SlotNeck, Art: ">-*-<"},
+}
+
+// Slot returns where the accessory is worn
+func (a Accessory) Slot() AccessorySlot {
+	return accessorySpecs[a].Slot
+}
+
+// Price returns the accessory's shop price, or ErrNotForSale if it can
+// only be earned
+func (a Accessory) Price() (int, error) {
+	price := accessorySpecs[a].Price
+	if price == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrNotForSale, a)
+	}
+	return price, nil
+}
+
+// Art returns the five characters the accessory is drawn with
+func (a Accessory) Art() string {
+	return accessorySpecs[a].Art
+}
+
+// AddAccessory stores an accessory in the inventory
+func (inv *Inventory) AddAccessory(accessory Accessory) {
+	if inv.Accessories == nil {
+		inv.Accessories = make(map[Accessory]int)
+	}
+	inv.Accessories[accessory]++
+}
+
+// TakeAccessory removes an accessory from the inventory, e.g. to put it on
+// a pet
+func (inv *Inventory) TakeAccessory(accessory Accessory) error {
+	if inv.Accessories[accessory] < 1 {
+		return fmt.Errorf("%w: %s", ErrOutOfStock, accessory)
+	}
+	inv.Accessories[accessory]--
+	if inv.Accessories[accessory] == 0 {
+		delete(inv.Accessories, accessory)
+	}
+	return nil
+}
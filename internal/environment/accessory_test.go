@@ -0,0 +1,46 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAccessories(t *testing.T) {
+	for _, accessory := range AllAccessories() {
+		parsed, err := ParseAccessory(accessory.String())
+		if err != nil || parsed != accessory {
+			t.Errorf("Expected %s to parse, got %v (%v)", accessory, parsed, err)
+		}
+		if len(accessory.Art()) != 5 {
+			t.Errorf("Expected %s to be drawn with 5 characters, got %q", accessory, accessory.Art())
+		}
+	}
+	if _, err := ParseAccessory("monocle"); !errors.Is(err, ErrUnknownAccessory) {
+		t.Errorf("Expected ErrUnknownAccessory, got %v", err)
+	}
+	if price, err := AccessoryHat.Price(); err != nil || price <= 0 {
+		t.Errorf("Expected hats for sale, got %d (%v)", price, err)
+	}
+	if _, err := AccessoryRosette.Price(); !errors.Is(err, ErrNotForSale) {
+		t.Errorf("Expected rosettes to be earned only, got %v", err)
+	}
+}
+
+func TestInventoryAccessories(t *testing.T) {
+	inv := &Inventory{}
+	if err := inv.TakeAccessory(AccessoryBow); !errors.Is(err, ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock from an empty inventory, got %v", err)
+	}
+	inv.AddAccessory(AccessoryBow)
+	inv.AddAccessory(AccessoryBow)
+	if err := inv.TakeAccessory(AccessoryBow); err != nil {
+		t.Fatal(err)
+	}
+	if inv.Accessories[AccessoryBow] != 1 {
+		t.Errorf("Expected one bow left, got %d", inv.Accessories[AccessoryBow])
+	}
+	inv.TakeAccessory(AccessoryBow)
+	if _, left := inv.Accessories[AccessoryBow]; left {
+		t.Error("Expected used-up accessories removed from the inventory")
+	}
+}
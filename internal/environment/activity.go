@@ -0,0 +1,248 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrActivityUnavailable is returned when conditions rule out an activity
+var ErrActivityUnavailable = errors.New("activity is not available right now")
+
+// Activity gate settings
+const (
+	DaybreakHour     = 6.0  // Hour the sun rises
+	NightfallHour    = 20.0 // Hour the sun sets
+	SwimmingMinTemp  = 15.0 // Coldest water temperature for swimming in °C
+	FrozenGroundTemp = 0.0  // Temperature below which nothing can be planted in °C
+	NightPenalty     = 0.3  // Penalty on exploring in the dark
+)
+
+// Activity is something the player or a pet can choose to do
+type Activity int
+
+const (
+	ActivityIndoorPlay Activity = iota
+	ActivityOutdoorPlay
+	ActivityWalk
+	ActivityExploring
+	ActivitySwimming
+	ActivityFishing
+	ActivityGardening
+	ActivitySunbathing
+	ActivitySnowPlay
+	ActivityStargazing
+)
+
+// AllActivities returns every activity in declaration order
+func AllActivities() []Activity {
+	return []Activity{
+		ActivityIndoorPlay, ActivityOutdoorPlay, ActivityWalk, ActivityExploring, ActivitySwimming,
+		ActivityFishing, ActivityGardening, ActivitySunbathing, ActivitySnowPlay, ActivityStargazing,
+	}
+}
+
+// String returns the string representation of Activity
+func (a Activity) String() string {
+	return [...]string{
+		"Indoor Play", "Outdoor Play", "Walk", "Exploring", "Swimming",
+		"Fishing", "Gardening", "Sunbathing", "Snow Play", "Stargazing",
+	}[a]
+}
+
+// ActivityStatus reports whether an activity can be done and how much
+// the conditions spoil it
+type ActivityStatus struct {
+	Activity  Activity
+	Available bool
+	Penalty   float64 // Reduction in enjoyment (0.0 = none, 1.0 = ruined)
+	Reason    string  // Why the activity is unavailable or penalized
+}
+
+// ActivityRule decides an activity's status under the given conditions
+type ActivityRule func(effects WeatherEffects) ActivityStatus
+
+// ActivityGate decides which activities the current weather, season,
+// biome and time of day allow. Both menus and autonomous behavior consult
+// it so they agree on what is possible.
+type ActivityGate struct {
+	rules map[Activity]ActivityRule
+}
+
+// NewActivityGate creates a gate with the standard rules
+func NewActivityGate() *ActivityGate {
+	return &ActivityGate{
+		rules: map[Activity]ActivityRule{
+			ActivityIndoorPlay:  func(WeatherEffects) ActivityStatus { return ActivityStatus{Available: true} },
+			ActivityOutdoorPlay: outdoorPlayRule,
+			ActivityWalk:        walkRule,
+			ActivityExploring:   exploringRule,
+			ActivitySwimming:    swimmingRule,
+			ActivityFishing:     fishingRule,
+			ActivityGardening:   gardeningRule,
+			ActivitySunbathing:  sunbathingRule,
+			ActivitySnowPlay:    snowPlayRule,
+			ActivityStargazing:  stargazingRule,
+		},
+	}
+}
+
+// Register adds or replaces the rule for an activity
+func (g *ActivityGate) Register(activity Activity, rule ActivityRule) {
+	g.rules[activity] = rule
+}
+
+// Check returns an activity's status under the given conditions
+func (g *ActivityGate) Check(activity Activity, effects WeatherEffects) ActivityStatus {
+	rule, exists := g.rules[activity]
+	if !exists {
+		return ActivityStatus{Activity: activity, Available: true}
+	}
+	status := rule(effects)
+	status.Activity = activity
+	status.Penalty = clamp(status.Penalty, 0.0, 1.0)
+	return status
+}
+
+// Require returns an ErrActivityUnavailable error if the activity cannot
+// be done under the given conditions
+func (g *ActivityGate) Require(activity Activity, effects WeatherEffects) error {
+	if status := g.Check(activity, effects); !status.Available {
+		return fmt.Errorf("%w: %s (%s)", ErrActivityUnavailable, activity, status.Reason)
+	}
+	return nil
+}
+
+// Statuses returns the status of every activity with a rule
+func (g *ActivityGate) Statuses(effects WeatherEffects) []ActivityStatus {
+	activities := make([]Activity, 0, len(g.rules))
+	for activity := range g.rules {
+		activities = append(activities, activity)
+	}
+	sort.Slice(activities, func(i, j int) bool { return activities[i] < activities[j] })
+
+	statuses := make([]ActivityStatus, 0, len(activities))
+	for _, activity := range activities {
+		statuses = append(statuses, g.Check(activity, effects))
+	}
+	return statuses
+}
+
+// Available returns the activities that can be done right now
+func (g *ActivityGate) Available(effects WeatherEffects) []Activity {
+	var available []Activity
+	for _, status := range g.Statuses(effects) {
+		if status.Available {
+			available = append(available, status.Activity)
+		}
+	}
+	return available
+}
+
+// IsDaylight returns true between daybreak and nightfall
+func (e WeatherEffects) IsDaylight() bool {
+	return e.Hour >= DaybreakHour && e.Hour < NightfallHour
+}
+
+// unavailable builds the status of an activity that cannot be done
+func unavailable(reason string) ActivityStatus {
+	return ActivityStatus{Reason: reason}
+}
+
+// weatherPenalty builds an available status penalized by the weather
+func weatherPenalty(effects WeatherEffects, scale float64) ActivityStatus {
+	status := ActivityStatus{Available: true, Penalty: effects.OutdoorPlay * scale}
+	if status.Penalty > 0 {
+		status.Reason = effects.Weather.String()
+	}
+	return status
+}
+
+func outdoorPlayRule(effects WeatherEffects) ActivityStatus {
+	if effects.Weather == WeatherStorm {
+		return unavailable("storm")
+	}
+	return weatherPenalty(effects, 1.0)
+}
+
+func walkRule(effects WeatherEffects) ActivityStatus {
+	if effects.Weather == WeatherStorm {
+		return unavailable("storm")
+	}
+	return weatherPenalty(effects, 0.7)
+}
+
+func exploringRule(effects WeatherEffects) ActivityStatus {
+	if effects.Weather == WeatherStorm || effects.Weather == WeatherHeavyRain {
+		return unavailable(effects.Weather.String())
+	}
+	status := weatherPenalty(effects, 1.0)
+	if !effects.IsDaylight() {
+		status.Penalty += NightPenalty
+		status.Reason = "dark"
+	}
+	return status
+}
+
+func swimmingRule(effects WeatherEffects) ActivityStatus {
+	switch {
+	case !effects.Biome.HasFishing() && effects.Biome != BiomeTropical:
+		return unavailable("no water nearby")
+	case effects.Season == types.SeasonWinter || effects.Temperature < SwimmingMinTemp:
+		return unavailable("water too cold")
+	case effects.Weather == WeatherStorm:
+		return unavailable("storm")
+	}
+	return weatherPenalty(effects, 0.5)
+}
+
+func fishingRule(effects WeatherEffects) ActivityStatus {
+	switch {
+	case !effects.Biome.HasFishing():
+		return unavailable("no water nearby")
+	case effects.Weather == WeatherStorm:
+		return unavailable("storm")
+	}
+	return weatherPenalty(effects, 0.4)
+}
+
+func gardeningRule(effects WeatherEffects) ActivityStatus {
+	switch {
+	case effects.Temperature < FrozenGroundTemp:
+		return unavailable("ground frozen")
+	case effects.Weather == WeatherStorm:
+		return unavailable("storm")
+	}
+	return weatherPenalty(effects, 0.5)
+}
+
+func sunbathingRule(effects WeatherEffects) ActivityStatus {
+	switch {
+	case !effects.IsDaylight():
+		return unavailable("no sun")
+	case effects.Weather != WeatherClear && effects.Weather != WeatherHeatwave:
+		return unavailable(effects.Weather.String())
+	case effects.Weather == WeatherHeatwave:
+		return ActivityStatus{Available: true, Penalty: 0.5, Reason: "too hot"}
+	}
+	return ActivityStatus{Available: true}
+}
+
+func snowPlayRule(effects WeatherEffects) ActivityStatus {
+	if effects.Weather != WeatherSnow {
+		return unavailable("no snow")
+	}
+	return ActivityStatus{Available: true}
+}
+
+func stargazingRule(effects WeatherEffects) ActivityStatus {
+	switch {
+	case effects.IsDaylight():
+		return unavailable("daytime")
+	case effects.Weather != WeatherClear:
+		return unavailable("sky obscured")
+	}
+	return ActivityStatus{Available: true}
+}
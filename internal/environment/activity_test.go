@@ -0,0 +1,57 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestActivityGateRules(t *testing.T) {
+	gate := NewActivityGate()
+
+	winterBeach := WeatherEffects{Weather: WeatherClear, Season: types.SeasonWinter, Biome: BiomeOcean, Temperature: 2, Hour: 12}
+	if gate.Check(ActivitySwimming, winterBeach).Available {
+		t.Error("Swimming should be unavailable in winter")
+	}
+	if !gate.Check(ActivityFishing, winterBeach).Available {
+		t.Error("Fishing should be available on a clear winter beach")
+	}
+
+	noon := WeatherEffects{Weather: WeatherClear, Season: types.SeasonSummer, Temperature: 25, Hour: 12}
+	if gate.Check(ActivityStargazing, noon).Available {
+		t.Error("Stargazing should be unavailable at noon")
+	}
+	if !gate.Check(ActivitySunbathing, noon).Available {
+		t.Error("Sunbathing should be available at noon")
+	}
+
+	night := noon
+	night.Hour = 23
+	if !gate.Check(ActivityStargazing, night).Available {
+		t.Error("Stargazing should be available on a clear night")
+	}
+	if status := gate.Check(ActivityExploring, night); status.Penalty != NightPenalty {
+		t.Errorf("Exploring at night should be penalized, got %+v", status)
+	}
+
+	storm := WeatherEffects{Weather: WeatherStorm, OutdoorPlay: 1.0, Hour: 12}
+	if err := gate.Require(ActivityOutdoorPlay, storm); !errors.Is(err, ErrActivityUnavailable) {
+		t.Errorf("Expected ErrActivityUnavailable, got %v", err)
+	}
+	available := gate.Available(storm)
+	if len(available) == 0 || available[0] != ActivityIndoorPlay {
+		t.Errorf("Indoor play should always be available, got %v", available)
+	}
+}
+
+func TestActivityGateRegister(t *testing.T) {
+	gate := NewActivityGate()
+	gate.Register(ActivityIndoorPlay, func(WeatherEffects) ActivityStatus {
+		return ActivityStatus{Reason: "nap time"}
+	})
+
+	if status := gate.Check(ActivityIndoorPlay, WeatherEffects{}); status.Available || status.Activity != ActivityIndoorPlay {
+		t.Errorf("Registered rule should replace the default, got %+v", status)
+	}
+}
@@ -0,0 +1,158 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownBiome is returned when a biome name is not recognised
+var ErrUnknownBiome = errors.New("unknown biome")
+
+// Acclimatization settings
+const (
+	AcclimatizationDays = 14.0 // Game days of residence needed to fully acclimatize
+	AcclimatizationLoss = 0.02 // Acclimatization lost per game day away from a biome
+)
+
+// Biome represents a broad climate region a pet can live in
+type Biome int
+
+const (
+	BiomeTemperate Biome = iota
+	BiomeArctic
+	BiomeDesert
+	BiomeTropical
+	BiomeOcean
+	BiomeSwamp
+	BiomeMountain
+	BiomeUrban
+)
+
+// AllBiomes returns every biome in declaration order
+func AllBiomes() []Biome {
+	return []Biome{
+		BiomeTemperate, BiomeArctic, BiomeDesert, BiomeTropical,
+		BiomeOcean, BiomeSwamp, BiomeMountain, BiomeUrban,
+	}
+}
+
+// String returns the string representation of Biome
+func (b Biome) String() string {
+	return [...]string{
+		"Temperate", "Arctic", "Desert", "Tropical", "Ocean", "Swamp", "Mountain", "Urban",
+	}[b]
+}
+
+// ParseBiome looks up a biome by name, ignoring case
+func ParseBiome(name string) (Biome, error) {
+	for _, biome := range AllBiomes() {
+		if strings.EqualFold(biome.String(), name) {
+			return biome, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownBiome, name)
+}
+
+// Icon returns the single-character map symbol for the biome
+func (b Biome) Icon() byte {
+	return "TADJOSMU"[b]
+}
+
+// Climate describes how harsh a biome is
+type Climate struct {
+	TemperatureShift float64 // Added to seasonal temperatures in °C
+	Cold             float64 // How demanding the cold is (0.0 to 1.0)
+	Heat             float64 // How demanding the heat is (0.0 to 1.0)
+}
+
+// biomeClimates lists the climate of each biome
+var biomeClimates = map[Biome]Climate{
+	BiomeTemperate: {TemperatureShift: 0.0},
+	BiomeArctic:    {TemperatureShift: -15.0, Cold: 1.0},
+	BiomeDesert:    {TemperatureShift: 12.0, Cold: 0.2, Heat: 1.0},
+	BiomeTropical:  {TemperatureShift: 10.0, Heat: 0.7},
+	BiomeOcean:     {TemperatureShift: -2.0, Cold: 0.3, Heat: 0.1},
+	BiomeSwamp:     {TemperatureShift: 5.0, Cold: 0.1, Heat: 0.5},
+	BiomeMountain:  {TemperatureShift: -8.0, Cold: 0.7},
+	BiomeUrban:     {TemperatureShift: 2.0, Heat: 0.1},
+}
+
+// Climate returns the climate of the biome
+func (b Biome) Climate() Climate {
+	return biomeClimates[b]
+}
+
+// Resources describes what a biome offers the pets living in it
+type Resources struct {
+	Food          float64 // Forage available (1.0 is typical)
+	SocialDensity float64 // How busy the area is with other animals and people (1.0 is typical)
+	Water         float64 // Drinking water available (1.0 is typical)
+}
+
+// biomeResources lists the resources of each biome
+var biomeResources = map[Biome]Resources{
+	BiomeTemperate: {Food: 1.0, SocialDensity: 1.0, Water: 1.0},
+	BiomeArctic:    {Food: 0.3, SocialDensity: 0.2, Water: 0.6},
+	BiomeDesert:    {Food: 0.3, SocialDensity: 0.3, Water: 0.3},
+	BiomeTropical:  {Food: 1.4, SocialDensity: 0.8, Water: 1.2},
+	BiomeOcean:     {Food: 1.1, SocialDensity: 0.7, Water: 0.5},
+	BiomeSwamp:     {Food: 0.9, SocialDensity: 0.5, Water: 1.3},
+	BiomeMountain:  {Food: 0.5, SocialDensity: 0.3, Water: 1.0},
+	BiomeUrban:     {Food: 0.6, SocialDensity: 2.0, Water: 0.8},
+}
+
+// Resources returns the resources of the biome
+func (b Biome) Resources() Resources {
+	return biomeResources[b]
+}
+
+// Acclimatization tracks how used a pet has become to each biome it has
+// lived in
+type Acclimatization struct {
+	Levels map[Biome]float64 `json:"levels"` // 0.0 unfamiliar to 1.0 fully adapted
+}
+
+// NewAcclimatization creates a pet with no acclimatization anywhere
+func NewAcclimatization() *Acclimatization {
+	return &Acclimatization{
+		Levels: make(map[Biome]float64),
+	}
+}
+
+// Update gradually acclimatizes the pet to the biome it lives in while it
+// slowly loses its adaptation to others
+func (a *Acclimatization) Update(current Biome, deltaTime float64) {
+	if a == nil {
+		return
+	}
+	if a.Levels == nil {
+		a.Levels = make(map[Biome]float64) // e.g. decoded from a save without it
+	}
+
+	for biome, level := range a.Levels {
+		if biome != current {
+			a.Levels[biome] = clamp(level-AcclimatizationLoss*deltaTime, 0.0, 1.0)
+		}
+	}
+	a.Levels[current] = clamp(a.Levels[current]+deltaTime/AcclimatizationDays, 0.0, 1.0)
+}
+
+// Level returns how acclimatized the pet is to a biome
+func (a *Acclimatization) Level(biome Biome) float64 {
+	if a == nil {
+		return 0.0
+	}
+	return a.Levels[biome]
+}
+
+// Helper function to clamp values
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
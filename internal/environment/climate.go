@@ -0,0 +1,194 @@
+package environment
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+)
+
+// Climate zone settings
+const (
+	LatitudeBands = 3   // Bands the world map is divided into, north to south
+	LatitudeShift = 4.0 // °C warmer per band further south than home
+	FreezingPoint = 0.0 // °C below which rain falls as snow away from home
+)
+
+// ClimateZone is a region with weather of its own: one biome within one
+// latitude band of the world map
+type ClimateZone struct {
+	Biome Biome `json:"biome"`
+	Band  int   `json:"band"` // 0 is the northernmost band
+}
+
+// String returns the zone as "Mountain/0"
+func (z ClimateZone) String() string {
+	return fmt.Sprintf("%s/%d", z.Biome, z.Band)
+}
+
+// Zone returns the climate zone a location lies in
+func (w *WorldMap) Zone(loc *Location) ClimateZone {
+	band := 0
+	if w.Height > 0 {
+		band = loc.Y * LatitudeBands / w.Height
+	}
+	if band < 0 {
+		band = 0
+	}
+	if band >= LatitudeBands {
+		band = LatitudeBands - 1
+	}
+	return ClimateZone{Biome: loc.Biome, Band: band}
+}
+
+// RegionalWeather is the weather of a climate zone other than home's. It
+// changes on the same schedule and in the same season as the home weather
+// but is rolled separately.
+type RegionalWeather struct {
+	Zone     ClimateZone `json:"zone"`
+	Current  Weather     `json:"current"`
+	Forecast WeatherType `json:"forecast"`
+	Shift    float64     `json:"shift"`     // Latitude temperature shift from home in °C
+	SnowSeen bool        `json:"snow_seen"` // Whether it has snowed here this winter
+
+	changed   bool
+	firstSnow bool
+	rng       *rand.Rand
+}
+
+// region returns the weather of a zone, starting it if this is the first
+// time the zone is visited
+func (ws *WeatherSystem) region(zone ClimateZone, shift float64) *RegionalWeather {
+	if r, exists := ws.Regions[zone.String()]; exists {
+		return r
+	}
+	if ws.Regions == nil {
+		ws.Regions = make(map[string]*RegionalWeather)
+	}
+
+	hash := fnv.New64a()
+	hash.Write([]byte(zone.String()))
+	r := &RegionalWeather{
+		Zone:  zone,
+		Shift: shift,
+		rng:   rand.New(rand.NewSource(ws.seed ^ int64(hash.Sum64()))),
+	}
+	r.set(r.roll(ws), ws)
+	r.changed, r.firstSnow = false, false
+	r.Forecast = r.roll(ws)
+	ws.Regions[zone.String()] = r
+	return r
+}
+
+// roll picks the zone's next weather from the seasonal table
+func (r *RegionalWeather) roll(ws *WeatherSystem) WeatherType {
+	return pickWeather(seasonalWeather[ws.Season], r.rng).InBiome(r.Zone.Biome)
+}
+
+// set changes the zone's weather, turning rain to snow below freezing
+func (r *RegionalWeather) set(weatherType WeatherType, ws *WeatherSystem) {
+	temperature := r.temperatureFor(weatherType, ws)
+	if temperature < FreezingPoint && (weatherType == WeatherRain || weatherType == WeatherHeavyRain) {
+		weatherType = WeatherSnow
+	}
+	if weatherType != r.Current.Type || r.Current == (Weather{}) {
+		r.force(weatherType, ws)
+	}
+}
+
+// force changes the zone's weather to exactly weatherType
+func (r *RegionalWeather) force(weatherType WeatherType, ws *WeatherSystem) {
+	r.Current = Weather{
+		Type:        weatherType,
+		Temperature: r.temperatureFor(weatherType, ws),
+		StartedAt:   ws.Day,
+	}
+	r.changed = true
+	r.firstSnow = weatherType == WeatherSnow && !r.SnowSeen
+	if r.firstSnow {
+		r.SnowSeen = true
+	}
+}
+
+// temperatureFor returns a plausible temperature in the zone for a
+// weather type this season
+func (r *RegionalWeather) temperatureFor(weatherType WeatherType, ws *WeatherSystem) float64 {
+	noise := r.rng.Float64()*4.0 - 2.0
+	return seasonalTemperature[ws.Season] + r.Zone.Biome.Climate().TemperatureShift + r.Shift +
+		weatherTemperatureShift[weatherType] + noise
+}
+
+// regionAt returns the weather of a location's climate zone, or nil if
+// the location shares home's weather
+func (ws *WeatherSystem) regionAt(world *WorldMap, loc *Location) *RegionalWeather {
+	home, exists := world.Location(HomeLocation)
+	if !exists {
+		return nil
+	}
+	zone, homeZone := world.Zone(loc), world.Zone(home)
+	if zone == homeZone {
+		return nil
+	}
+	return ws.region(zone, float64(zone.Band-homeZone.Band)*LatitudeShift)
+}
+
+// EffectsAt returns the weather's effects at a location on a world map.
+// Locations in home's climate zone share the household's weather; each
+// other zone has its own, so the peak can be snowy while the beach is
+// sunny.
+func (ws *WeatherSystem) EffectsAt(world *WorldMap, loc *Location) WeatherEffects {
+	r := ws.regionAt(world, loc)
+	if r == nil {
+		return ws.EffectsIn(loc.Biome)
+	}
+	effects := WeatherEffects{
+		Weather:     r.Current.Type,
+		Season:      ws.Season,
+		Biome:       loc.Biome,
+		Temperature: r.Current.Temperature,
+		Hour:        ws.Effects().Hour,
+		Onset:       r.changed,
+		FirstSnow:   r.firstSnow,
+	}
+	effects.applyModifiers()
+	return effects
+}
+
+// SetWeatherAt forces a weather condition at a location, e.g. for
+// scripted events. In home's climate zone this is SetWeather; elsewhere
+// only the location's zone changes.
+func (ws *WeatherSystem) SetWeatherAt(world *WorldMap, loc *Location, weatherType WeatherType) {
+	if r := ws.regionAt(world, loc); r != nil {
+		if weatherType != r.Current.Type {
+			r.force(weatherType, ws)
+		}
+		return
+	}
+	ws.SetWeather(weatherType)
+}
+
+// ForecastAt returns the weather expected at a location at the next change
+func (ws *WeatherSystem) ForecastAt(world *WorldMap, loc *Location) WeatherType {
+	if r := ws.regionAt(world, loc); r != nil {
+		return r.Forecast
+	}
+	return ws.Forecast.InBiome(loc.Biome)
+}
+
+// updateRegions clears last tick's changes and, in a new season, the
+// snow each zone has seen
+func (ws *WeatherSystem) updateRegions(newSeason bool) {
+	for _, r := range ws.Regions {
+		r.changed, r.firstSnow = false, false
+		if newSeason {
+			r.SnowSeen = false
+		}
+	}
+}
+
+// rollRegions moves every zone on to its forecast weather
+func (ws *WeatherSystem) rollRegions() {
+	for _, r := range ws.Regions {
+		r.set(r.Forecast, ws)
+		r.Forecast = r.roll(ws)
+	}
+}
@@ -0,0 +1,89 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestClimateZones(t *testing.T) {
+	world := DefaultWorldMap()
+	for id, want := range map[string]ClimateZone{
+		"peak":   {Biome: BiomeMountain, Band: 0},
+		"home":   {Biome: BiomeTemperate, Band: 1},
+		"meadow": {Biome: BiomeTemperate, Band: 1},
+		"dunes":  {Biome: BiomeDesert, Band: 2},
+	} {
+		loc, _ := world.Location(id)
+		if got := world.Zone(loc); got != want {
+			t.Errorf("Zone(%s) = %s, want %s", id, got, want)
+		}
+	}
+}
+
+func TestHomeZoneSharesWeather(t *testing.T) {
+	world := DefaultWorldMap()
+	ws := NewSeededWeatherSystem(1)
+	ws.SetWeather(WeatherStorm)
+
+	meadow, _ := world.Location("meadow")
+	if effects := ws.EffectsAt(world, meadow); effects.Weather != WeatherStorm || effects.ShelterUrge != 1.0 {
+		t.Errorf("Expected the meadow to share home's storm, got %+v", effects)
+	}
+	if len(ws.Regions) != 0 {
+		t.Errorf("Home's zone should not start regional weather, got %v", ws.Regions)
+	}
+}
+
+func TestRegionsHaveTheirOwnWeather(t *testing.T) {
+	world := DefaultWorldMap()
+	ws := NewSeededWeatherSystem(7)
+	ws.SetSeason(types.SeasonWinter)
+	peak, _ := world.Location("peak")
+	beach, _ := world.Location("beach")
+
+	snowyPeakSunnyBeach := false
+	for i := 0; i < int(SeasonLengthDays/WeatherChangeInterval); i++ {
+		atPeak, atBeach := ws.EffectsAt(world, peak), ws.EffectsAt(world, beach)
+		if atPeak.Weather == WeatherRain || atPeak.Weather == WeatherHeavyRain {
+			t.Fatalf("Rain should fall as snow on a winter peak at %.1f°C", atPeak.Temperature)
+		}
+		if atPeak.Temperature >= atBeach.Temperature {
+			t.Errorf("Expected the northern peak colder than the beach, got %.1f and %.1f", atPeak.Temperature, atBeach.Temperature)
+		}
+		snowyPeakSunnyBeach = snowyPeakSunnyBeach || atPeak.Weather == WeatherSnow && atBeach.Weather == WeatherClear
+		ws.Update(WeatherChangeInterval)
+	}
+	if !snowyPeakSunnyBeach {
+		t.Error("Expected the peak snowy while the beach was sunny at some point in winter")
+	}
+}
+
+func TestRegionalWeatherIsSeeded(t *testing.T) {
+	world := DefaultWorldMap()
+	peak, _ := world.Location("peak")
+	a, b := NewSeededWeatherSystem(3), NewSeededWeatherSystem(3)
+	b.EffectsAt(world, peak) // Visiting early must not change the sequence
+
+	for i := 0; i < 20; i++ {
+		a.Update(WeatherChangeInterval)
+		b.Update(WeatherChangeInterval)
+	}
+	if a.Current != b.Current {
+		t.Errorf("Regional weather should not disturb home's, got %+v and %+v", a.Current, b.Current)
+	}
+}
+
+func TestSetWeatherAt(t *testing.T) {
+	world := DefaultWorldMap()
+	ws := NewSeededWeatherSystem(1)
+	beach, _ := world.Location("beach")
+
+	ws.SetWeatherAt(world, beach, WeatherFog)
+	if ws.EffectsAt(world, beach).Weather != WeatherFog || ws.Current.Type == WeatherFog {
+		t.Errorf("Expected fog only at the beach, got %s there and %s at home", ws.EffectsAt(world, beach).Weather, ws.Current.Type)
+	}
+	if forecast := ws.ForecastAt(world, beach); forecast != ws.Regions[world.Zone(beach).String()].Forecast {
+		t.Errorf("ForecastAt should give the zone's forecast, got %s", forecast)
+	}
+}
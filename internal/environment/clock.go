@@ -0,0 +1,75 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrUnknownHemisphere is returned when a hemisphere name is not recognised
+var ErrUnknownHemisphere = errors.New("unknown hemisphere")
+
+// Hemisphere decides which months of the real year are summer
+type Hemisphere int
+
+const (
+	HemisphereNorth Hemisphere = iota
+	HemisphereSouth
+)
+
+// String returns the string representation of Hemisphere
+func (h Hemisphere) String() string {
+	return [...]string{"north", "south"}[h]
+}
+
+// ParseHemisphere looks up a hemisphere by name, ignoring case
+func ParseHemisphere(name string) (Hemisphere, error) {
+	for h := HemisphereNorth; h <= HemisphereSouth; h++ {
+		if strings.EqualFold(h.String(), name) {
+			return h, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownHemisphere, name)
+}
+
+// SeasonOn returns the meteorological season on a real date: spring starts
+// in March in the north and in September in the south
+func SeasonOn(date time.Time, hemisphere Hemisphere) types.Season {
+	month := int(date.Month()) - int(time.March)
+	if hemisphere == HemisphereSouth {
+		month += 6
+	}
+	return types.Season((month + 12) % 12 / 3)
+}
+
+// HourOf returns the time of day of a real time in hours (0 to 24)
+func HourOf(t time.Time) float64 {
+	return float64(t.Hour()) + float64(t.Minute())/60.0 + float64(t.Second())/3600.0
+}
+
+// AlignTo makes the weather follow the real calendar: the season comes from
+// the date and the time of day from the clock. Weather still changes every
+// WeatherChangeInterval game days.
+func (ws *WeatherSystem) AlignTo(clock func() time.Time, hemisphere Hemisphere) {
+	ws.clock = clock
+	ws.hemisphere = hemisphere
+	if season := SeasonOn(clock(), hemisphere); season != ws.Season {
+		ws.Season = season
+		ws.SnowSeen = false
+		ws.Forecast = ws.rollWeather()
+	}
+}
+
+// IsAligned returns whether the weather follows the real calendar
+func (ws *WeatherSystem) IsAligned() bool {
+	return ws.clock != nil
+}
+
+// IsNight returns true between nightfall and daybreak on a real clock
+func IsNight(t time.Time) bool {
+	hour := HourOf(t)
+	return hour < DaybreakHour || hour >= NightfallHour
+}
@@ -0,0 +1,68 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestParseHemisphere(t *testing.T) {
+	if h, err := ParseHemisphere("South"); err != nil || h != HemisphereSouth {
+		t.Errorf("Expected south, got %v (%v)", h, err)
+	}
+	if _, err := ParseHemisphere("east"); !errors.Is(err, ErrUnknownHemisphere) {
+		t.Errorf("Expected ErrUnknownHemisphere, got %v", err)
+	}
+}
+
+func TestSeasonOn(t *testing.T) {
+	cases := []struct {
+		month      time.Month
+		hemisphere Hemisphere
+		want       types.Season
+	}{
+		{time.March, HemisphereNorth, types.SeasonSpring},
+		{time.July, HemisphereNorth, types.SeasonSummer},
+		{time.November, HemisphereNorth, types.SeasonAutumn},
+		{time.January, HemisphereNorth, types.SeasonWinter},
+		{time.December, HemisphereNorth, types.SeasonWinter},
+		{time.January, HemisphereSouth, types.SeasonSummer},
+		{time.April, HemisphereSouth, types.SeasonAutumn},
+		{time.July, HemisphereSouth, types.SeasonWinter},
+		{time.September, HemisphereSouth, types.SeasonSpring},
+	}
+	for _, c := range cases {
+		date := time.Date(2024, c.month, 15, 12, 0, 0, 0, time.UTC)
+		if got := SeasonOn(date, c.hemisphere); got != c.want {
+			t.Errorf("%s in the %s: expected %s, got %s", c.month, c.hemisphere, c.want, got)
+		}
+	}
+}
+
+func TestWeatherAlignedToClock(t *testing.T) {
+	now := time.Date(2024, time.July, 1, 23, 30, 0, 0, time.UTC)
+	ws := NewSeededWeatherSystem(3)
+	ws.AlignTo(func() time.Time { return now }, HemisphereSouth)
+	if !ws.IsAligned() || ws.Season != types.SeasonWinter {
+		t.Fatalf("Expected an aligned southern winter, got %s", ws.Season)
+	}
+	effects := ws.Effects()
+	if effects.Hour != 23.5 || effects.IsDaylight() {
+		t.Errorf("Expected a dark 23:30, got hour %.2f", effects.Hour)
+	}
+
+	// Game days pass faster than the calendar but the season holds
+	ws.Update(SeasonLengthDays * 2)
+	ws.SetSeason(types.SeasonSummer)
+	if ws.Season != types.SeasonWinter {
+		t.Errorf("Expected the season to follow the date, got %s", ws.Season)
+	}
+
+	now = time.Date(2024, time.September, 2, 9, 0, 0, 0, time.UTC)
+	ws.Update(0.1)
+	if ws.Season != types.SeasonSpring || !ws.Effects().IsDaylight() {
+		t.Errorf("Expected a spring morning, got %s at %.1f", ws.Season, ws.Effects().Hour)
+	}
+}
@@ -0,0 +1,347 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+)
+
+var (
+	// ErrUnknownIngredient is returned when a name is not a crop, fish or dairy item
+	ErrUnknownIngredient = errors.New("unknown ingredient")
+	// ErrUnknownRecipe is returned when a recipe name is not recognised
+	ErrUnknownRecipe = errors.New("unknown recipe")
+	// ErrNoRecipe is returned when ingredients do not combine into any recipe
+	ErrNoRecipe = errors.New("no recipe uses those ingredients")
+)
+
+// Cooking settings
+const (
+	MealShelfDays = 3.0 // Game days a prepared meal keeps before it spoils
+	DairyPrice    = 5   // Coins for one dairy item at the shop
+)
+
+// Dairy is a dairy ingredient bought at the shop
+type Dairy int
+
+const (
+	DairyMilk Dairy = iota
+	DairyCheese
+	DairyYogurt
+)
+
+// AllDairy returns every dairy item
+func AllDairy() []Dairy {
+	return []Dairy{DairyMilk, DairyCheese, DairyYogurt}
+}
+
+// String returns the string representation of Dairy
+func (d Dairy) String() string {
+	return [...]string{"Milk", "Cheese", "Yogurt"}[d]
+}
+
+// ParseDairy looks up a dairy item by name, ignoring case
+func ParseDairy(name string) (Dairy, error) {
+	for _, dairy := range AllDairy() {
+		if strings.EqualFold(dairy.String(), strings.TrimSpace(name)) {
+			return dairy, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownIngredient, name)
+}
+
+// dairyNutrients lists what one item of each dairy provides
+var dairyNutrients = map[Dairy]biology.Nutrients{
+	DairyMilk:   {Protein: 0.1, Fat: 0.1, Carbs: 0.1, Vitamins: 0.2},
+	DairyCheese: {Protein: 0.25, Fat: 0.3, Vitamins: 0.1},
+	DairyYogurt: {Protein: 0.15, Fat: 0.05, Carbs: 0.1, Vitamins: 0.25},
+}
+
+// Nutrients returns what one item of the dairy provides
+func (d Dairy) Nutrients() biology.Nutrients {
+	return dairyNutrients[d]
+}
+
+// IngredientKind is the part an ingredient plays in a recipe
+type IngredientKind int
+
+const (
+	IngredientMeat      IngredientKind = iota // Fish
+	IngredientVegetable                       // Garden produce
+	IngredientDairy
+)
+
+// String returns the string representation of IngredientKind
+func (k IngredientKind) String() string {
+	return [...]string{"Meat", "Vegetable", "Dairy"}[k]
+}
+
+// Ingredient is one item from the inventory to cook with
+type Ingredient struct {
+	Kind  IngredientKind
+	Crop  Crop   // For vegetables
+	Fish  string // Species, for meat
+	Dairy Dairy  // For dairy
+}
+
+// ParseIngredient looks up an ingredient by name, ignoring case: a crop,
+// a fish species or a dairy item
+func ParseIngredient(name string) (Ingredient, error) {
+	name = strings.TrimSpace(name)
+	if crop, err := ParseCrop(name); err == nil {
+		return Ingredient{Kind: IngredientVegetable, Crop: crop}, nil
+	}
+	if fish, err := LookupFish(name); err == nil {
+		return Ingredient{Kind: IngredientMeat, Fish: fish.Name}, nil
+	}
+	dairy, err := ParseDairy(name)
+	if err != nil {
+		return Ingredient{}, err
+	}
+	return Ingredient{Kind: IngredientDairy, Dairy: dairy}, nil
+}
+
+// String returns the ingredient's name
+func (i Ingredient) String() string {
+	switch i.Kind {
+	case IngredientMeat:
+		return i.Fish
+	case IngredientVegetable:
+		return i.Crop.String()
+	default:
+		return i.Dairy.String()
+	}
+}
+
+// Nutrients returns what the ingredient provides
+func (i Ingredient) Nutrients() biology.Nutrients {
+	switch i.Kind {
+	case IngredientMeat:
+		fish, _ := LookupFish(i.Fish)
+		return fish.Nutrients
+	case IngredientVegetable:
+		return i.Crop.Nutrients()
+	default:
+		return i.Dairy.Nutrients()
+	}
+}
+
+// Taste is the character of a recipe, which pets like to different degrees
+type Taste int
+
+const (
+	TasteHearty Taste = iota // Filling; liked by energetic pets
+	TasteRich                // Indulgent; liked by easy-going pets
+	TasteFresh               // Light and new; liked by open, curious pets
+	TasteSweet               // Liked by playful pets
+)
+
+// String returns the string representation of Taste
+func (t Taste) String() string {
+	return [...]string{"Hearty", "Rich", "Fresh", "Sweet"}[t]
+}
+
+// Recipe is a meal that can be prepared from ingredients
+type Recipe int
+
+const (
+	RecipeFishStew Recipe = iota
+	RecipeFishPie
+	RecipeVegetableBake
+	RecipeGardenSalad
+	RecipeSmoothie
+)
+
+// AllRecipes returns every recipe
+func AllRecipes() []Recipe {
+	return []Recipe{RecipeFishStew, RecipeFishPie, RecipeVegetableBake, RecipeGardenSalad, RecipeSmoothie}
+}
+
+// String returns the string representation of Recipe
+func (r Recipe) String() string {
+	return [...]string{
+		"Fish Stew", "Fish Pie", "Vegetable Bake", "Garden Salad", "Smoothie",
+	}[r]
+}
+
+// ParseRecipe looks up a recipe by name, ignoring case
+func ParseRecipe(name string) (Recipe, error) {
+	for _, recipe := range AllRecipes() {
+		if strings.EqualFold(recipe.String(), strings.TrimSpace(name)) {
+			return recipe, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownRecipe, name)
+}
+
+// recipeSpec describes what goes into a recipe and how it tastes
+type recipeSpec struct {
+	Kinds []IngredientKind // One ingredient of each, sorted
+	Taste Taste
+}
+
+// recipeSpecs lists every recipe
+var recipeSpecs = map[Recipe]recipeSpec{
+	RecipeFishStew:      {Kinds: []IngredientKind{IngredientMeat, IngredientVegetable}, Taste: TasteHearty},
+	RecipeFishPie:       {Kinds: []IngredientKind{IngredientMeat, IngredientVegetable, IngredientDairy}, Taste: TasteRich},
+	RecipeVegetableBake: {Kinds: []IngredientKind{IngredientVegetable, IngredientVegetable, IngredientDairy}, Taste: TasteRich},
+	RecipeGardenSalad:   {Kinds: []IngredientKind{IngredientVegetable, IngredientVegetable}, Taste: TasteFresh},
+	RecipeSmoothie:      {Kinds: []IngredientKind{IngredientVegetable, IngredientDairy}, Taste: TasteSweet},
+}
+
+// Taste returns the recipe's taste
+func (r Recipe) Taste() Taste {
+	return recipeSpecs[r].Taste
+}
+
+// Ingredients returns the kinds of ingredient the recipe takes
+func (r Recipe) Ingredients() []IngredientKind {
+	return append([]IngredientKind(nil), recipeSpecs[r].Kinds...)
+}
+
+// MatchRecipe returns the recipe that takes exactly these ingredients, in
+// any order
+func MatchRecipe(ingredients []Ingredient) (Recipe, error) {
+	kinds := make([]IngredientKind, len(ingredients))
+	for i, ingredient := range ingredients {
+		kinds[i] = ingredient.Kind
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	for _, recipe := range AllRecipes() {
+		if fmt.Sprint(recipeSpecs[recipe].Kinds) == fmt.Sprint(kinds) {
+			return recipe, nil
+		}
+	}
+	return 0, ErrNoRecipe
+}
+
+// PreparedMeal is a meal cooked from ingredients. It provides all their
+// nutrients together and keeps for MealShelfDays.
+type PreparedMeal struct {
+	Recipe      Recipe            `json:"recipe"`
+	Ingredients []string          `json:"ingredients"`
+	Nutrients   biology.Nutrients `json:"nutrients"`
+	Age         float64           `json:"age"` // Game days since it was cooked
+}
+
+// Freshness returns how fresh the meal is, from 1.0 just cooked to 0.0
+// spoiled
+func (m PreparedMeal) Freshness() float64 {
+	return clamp(1.0-m.Age/MealShelfDays, 0.0, 1.0)
+}
+
+// Spoiled returns true once the meal is past its shelf life
+func (m PreparedMeal) Spoiled() bool {
+	return m.Age >= MealShelfDays
+}
+
+// Cook prepares a meal from ingredients in the inventory, using them up.
+// Nothing is used unless every ingredient is in stock and together they
+// make a recipe.
+func (inv *Inventory) Cook(ingredients []Ingredient) (PreparedMeal, error) {
+	recipe, err := MatchRecipe(ingredients)
+	if err != nil {
+		return PreparedMeal{}, err
+	}
+
+	produce, fish, dairy := map[Crop]int{}, map[string]int{}, map[Dairy]int{}
+	meal := PreparedMeal{Recipe: recipe}
+	for _, ingredient := range ingredients {
+		switch ingredient.Kind {
+		case IngredientMeat:
+			fish[ingredient.Fish]++
+		case IngredientVegetable:
+			produce[ingredient.Crop]++
+		case IngredientDairy:
+			dairy[ingredient.Dairy]++
+		}
+		meal.Ingredients = append(meal.Ingredients, ingredient.String())
+		n := ingredient.Nutrients()
+		meal.Nutrients = biology.Nutrients{
+			Protein:  meal.Nutrients.Protein + n.Protein,
+			Fat:      meal.Nutrients.Fat + n.Fat,
+			Carbs:    meal.Nutrients.Carbs + n.Carbs,
+			Vitamins: meal.Nutrients.Vitamins + n.Vitamins,
+		}
+	}
+	for crop, count := range produce {
+		if inv.Produce[crop] < count {
+			return PreparedMeal{}, fmt.Errorf("%w: %s", ErrOutOfStock, crop)
+		}
+	}
+	for name, count := range fish {
+		if inv.Fish[name] < count {
+			return PreparedMeal{}, fmt.Errorf("%w: %s", ErrOutOfStock, name)
+		}
+	}
+	for item, count := range dairy {
+		if inv.Dairy[item] < count {
+			return PreparedMeal{}, fmt.Errorf("%w: %s", ErrOutOfStock, item)
+		}
+	}
+
+	for crop, count := range produce {
+		take(inv.Produce, crop, count)
+	}
+	for name, count := range fish {
+		for ; count > 0; count-- {
+			inv.TakeFish(name)
+		}
+	}
+	for item, count := range dairy {
+		inv.Dairy[item] -= count
+		if inv.Dairy[item] == 0 {
+			delete(inv.Dairy, item)
+		}
+	}
+	inv.Meals = append(inv.Meals, meal)
+	return meal, nil
+}
+
+// BuyDairy buys one dairy item at the shop
+func (inv *Inventory) BuyDairy(item Dairy) error {
+	if err := inv.Spend(DairyPrice); err != nil {
+		return err
+	}
+	if inv.Dairy == nil {
+		inv.Dairy = make(map[Dairy]int)
+	}
+	inv.Dairy[item]++
+	return nil
+}
+
+// TakeMeal removes the oldest prepared meal of a recipe, e.g. to serve it
+// before it spoils
+func (inv *Inventory) TakeMeal(recipe Recipe) (PreparedMeal, error) {
+	oldest := -1
+	for i, meal := range inv.Meals {
+		if meal.Recipe == recipe && (oldest < 0 || meal.Age > inv.Meals[oldest].Age) {
+			oldest = i
+		}
+	}
+	if oldest < 0 {
+		return PreparedMeal{}, fmt.Errorf("%w: %s", ErrOutOfStock, recipe)
+	}
+	meal := inv.Meals[oldest]
+	inv.Meals = append(inv.Meals[:oldest], inv.Meals[oldest+1:]...)
+	return meal, nil
+}
+
+// AgeMeals ages the prepared meals by deltaTime game days and throws out
+// the ones that spoil, returning them
+func (inv *Inventory) AgeMeals(deltaTime float64) []PreparedMeal {
+	var kept, spoiled []PreparedMeal
+	for _, meal := range inv.Meals {
+		meal.Age += deltaTime
+		if meal.Spoiled() {
+			spoiled = append(spoiled, meal)
+		} else {
+			kept = append(kept, meal)
+		}
+	}
+	inv.Meals = kept
+	return spoiled
+}
@@ -0,0 +1,68 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCookUsesIngredientsTogether(t *testing.T) {
+	inv := NewInventory()
+	inv.Produce[CropCarrots] = 1
+	inv.Fish["Cod"] = 1
+	cod, _ := ParseIngredient("cod")
+	carrot, _ := ParseIngredient("Carrots")
+	cheese, _ := ParseIngredient("cheese")
+
+	if _, err := inv.Cook([]Ingredient{cod, carrot, cheese}); !errors.Is(err, ErrOutOfStock) {
+		t.Fatalf("Expected ErrOutOfStock without cheese, got %v", err)
+	}
+	if inv.Produce[CropCarrots] != 1 || inv.Fish["Cod"] != 1 {
+		t.Error("A failed cook should not use up any ingredients")
+	}
+
+	meal, err := inv.Cook([]Ingredient{carrot, cod})
+	if err != nil {
+		t.Fatalf("Cook failed: %v", err)
+	}
+	if meal.Recipe != RecipeFishStew {
+		t.Errorf("Expected fish stew, got %s", meal.Recipe)
+	}
+	want := cod.Nutrients().Protein + carrot.Nutrients().Protein
+	if meal.Nutrients.Protein != want {
+		t.Errorf("Expected the meal to add up its ingredients' protein %.2f, got %.2f", want, meal.Nutrients.Protein)
+	}
+	if len(inv.Produce) != 0 || len(inv.Fish) != 0 || len(inv.Meals) != 1 {
+		t.Errorf("Expected the ingredients turned into a meal, got %+v", inv)
+	}
+
+	if _, err := MatchRecipe([]Ingredient{cod, cod}); !errors.Is(err, ErrNoRecipe) {
+		t.Errorf("Expected ErrNoRecipe for two fish, got %v", err)
+	}
+	if _, err := ParseIngredient("gravel"); !errors.Is(err, ErrUnknownIngredient) {
+		t.Errorf("Expected ErrUnknownIngredient, got %v", err)
+	}
+}
+
+func TestMealsSpoil(t *testing.T) {
+	inv := NewInventory()
+	if err := inv.BuyDairy(DairyYogurt); err != nil {
+		t.Fatalf("BuyDairy failed: %v", err)
+	}
+	if inv.Coins != StartingCoins-DairyPrice {
+		t.Errorf("Expected the yogurt paid for, got %d coins", inv.Coins)
+	}
+	inv.Produce[CropBerries] = 1
+	if _, err := inv.Cook([]Ingredient{{Kind: IngredientDairy, Dairy: DairyYogurt}, {Kind: IngredientVegetable, Crop: CropBerries}}); err != nil {
+		t.Fatalf("Cook failed: %v", err)
+	}
+
+	if spoiled := inv.AgeMeals(1.5); len(spoiled) != 0 || inv.Meals[0].Freshness() != 0.5 {
+		t.Fatalf("Expected a half-fresh meal, got %+v", inv.Meals)
+	}
+	if spoiled := inv.AgeMeals(MealShelfDays); len(spoiled) != 1 || len(inv.Meals) != 0 {
+		t.Errorf("Expected the meal to spoil, got %d spoiled and %d kept", len(spoiled), len(inv.Meals))
+	}
+	if _, err := inv.TakeMeal(RecipeSmoothie); !errors.Is(err, ErrOutOfStock) {
+		t.Errorf("Expected no smoothie left to serve, got %v", err)
+	}
+}
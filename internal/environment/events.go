@@ -0,0 +1,311 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// ErrLocationClosed is returned when travelling to a location an event has closed
+var ErrLocationClosed = errors.New("location is closed")
+
+// EventRollInterval is how many game days pass between event rolls
+const EventRollInterval = 1.0
+
+// LocationEventType identifies a kind of dynamic location event
+type LocationEventType int
+
+const (
+	EventBerryBloom LocationEventType = iota
+	EventFestival
+	EventRockslide
+	EventDrought
+	EventFlood
+)
+
+// String returns the string representation of LocationEventType
+func (et LocationEventType) String() string {
+	return [...]string{
+		"Berry Bloom", "Festival", "Rockslide", "Drought", "Flood",
+	}[et]
+}
+
+// locationEventSpec describes where an event can happen and what it does
+type locationEventSpec struct {
+	Biomes       []Biome
+	Chance       float64 // Chance per roll of starting somewhere eligible
+	Duration     float64 // Game days the event lasts
+	FoodBonus    float64 // Added to the location's food
+	WaterBonus   float64 // Added to the location's water
+	SocialBonus  float64 // Added to the location's social density
+	DangerBonus  float64 // Added to the location's danger
+	Closes       bool    // Whether the location cannot be visited
+	Announcement string  // Format string taking the location name
+	Ending       string  // Format string taking the location name
+}
+
+// locationEventSpecs lists every location event
+var locationEventSpecs = map[LocationEventType]locationEventSpec{
+	EventBerryBloom: {
+		Biomes:       []Biome{BiomeTemperate, BiomeTropical},
+		Chance:       0.08,
+		Duration:     7.0,
+		FoodBonus:    1.0,
+		Announcement: "The berries are blooming in %s! Food will be plentiful there for a week.",
+		Ending:       "The berry bloom in %s is over.",
+	},
+	EventFestival: {
+		Biomes:       []Biome{BiomeUrban},
+		Chance:       0.1,
+		Duration:     2.0,
+		SocialBonus:  1.5,
+		Announcement: "A festival has started in %s. Expect crowds!",
+		Ending:       "The festival in %s has wrapped up.",
+	},
+	EventRockslide: {
+		Biomes:       []Biome{BiomeMountain},
+		Chance:       0.06,
+		Duration:     3.0,
+		DangerBonus:  0.3,
+		Closes:       true,
+		Announcement: "A rockslide has closed %s. Stay clear until it is cleared.",
+		Ending:       "The rockslide at %s has been cleared.",
+	},
+	EventDrought: {
+		Biomes:       []Biome{BiomeTemperate, BiomeDesert, BiomeTropical, BiomeSwamp, BiomeMountain, BiomeUrban},
+		Duration:     DroughtRecoveryDays,
+		FoodBonus:    -0.4,
+		WaterBonus:   -0.6,
+		Announcement: "A drought has set in at %s. Water and forage are scarce there.",
+		Ending:       "%s has recovered from the drought.",
+	},
+	EventFlood: {
+		Biomes:       []Biome{BiomeTropical, BiomeOcean, BiomeSwamp},
+		Duration:     FloodRecoveryDays,
+		FoodBonus:    -0.3,
+		DangerBonus:  0.3,
+		Closes:       true,
+		Announcement: "Heavy rain has flooded %s. Stay clear until the water goes down.",
+		Ending:       "The floodwater at %s has gone down.",
+	},
+}
+
+// LocationEvent is a temporary event changing a location's characteristics
+type LocationEvent struct {
+	Type     LocationEventType `json:"type"`
+	Location string            `json:"location"`
+	StartDay float64           `json:"start_day"`
+	EndDay   float64           `json:"end_day"`
+}
+
+// Announcement reports an event starting or ending
+type Announcement struct {
+	Event   LocationEvent
+	Started bool   // False when the event has ended
+	Text    string // Player-facing description
+}
+
+// Conditions are a location's current characteristics after events apply
+type Conditions struct {
+	Resources
+	Danger float64
+	Closed bool
+	Events []LocationEventType
+}
+
+// Has returns true if an event of the given type is under way
+func (c Conditions) Has(eventType LocationEventType) bool {
+	for _, event := range c.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Manager runs dynamic events across the locations of a world
+type Manager struct {
+	World    *WorldMap        `json:"world"`
+	Events   []*LocationEvent `json:"events"` // Active events
+	Day      float64          `json:"day"`
+	NextRoll float64          `json:"next_roll"`
+
+	Moisture map[string]float64 `json:"moisture,omitempty"` // Recent rainfall per location; see UpdateWater
+
+	announcements []Announcement
+	rng           *rand.Rand
+}
+
+// NewManager creates an event manager for a world
+func NewManager(world *WorldMap) *Manager {
+	return NewSeededManager(world, time.Now().UnixNano())
+}
+
+// NewSeededManager creates a deterministic event manager for testing
+func NewSeededManager(world *WorldMap, seed int64) *Manager {
+	return &Manager{
+		World:    world,
+		Events:   make([]*LocationEvent, 0),
+		NextRoll: EventRollInterval,
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Update advances time by deltaTime game days, ending expired events and
+// rolling for new ones
+func (m *Manager) Update(deltaTime float64) {
+	m.Day += deltaTime
+
+	active := m.Events[:0]
+	for _, event := range m.Events {
+		if m.Day >= event.EndDay {
+			m.announce(*event, false)
+		} else {
+			active = append(active, event)
+		}
+	}
+	m.Events = active
+
+	for m.Day >= m.NextRoll {
+		m.NextRoll += EventRollInterval
+		m.rollEvents()
+	}
+}
+
+// rollEvents gives each kind of event a chance to start somewhere eligible
+func (m *Manager) rollEvents() {
+	for _, eventType := range []LocationEventType{EventBerryBloom, EventFestival, EventRockslide} {
+		spec := locationEventSpecs[eventType]
+		if m.rng.Float64() >= spec.Chance {
+			continue
+		}
+
+		var eligible []string
+		for _, id := range m.World.LocationIDs() {
+			if spec.appliesTo(m.World.Locations[id].Biome) && !m.hasEvent(id, eventType) {
+				eligible = append(eligible, id)
+			}
+		}
+		if len(eligible) > 0 {
+			m.StartEvent(eventType, eligible[m.rng.Intn(len(eligible))])
+		}
+	}
+}
+
+// appliesTo returns true if the event can happen in a biome
+func (spec locationEventSpec) appliesTo(biome Biome) bool {
+	for _, b := range spec.Biomes {
+		if b == biome {
+			return true
+		}
+	}
+	return false
+}
+
+// hasEvent returns true if an event of the given type is active at a location
+func (m *Manager) hasEvent(location string, eventType LocationEventType) bool {
+	return m.activeEvent(location, eventType) != nil
+}
+
+// activeEvent returns the active event of the given type at a location, or nil
+func (m *Manager) activeEvent(location string, eventType LocationEventType) *LocationEvent {
+	for _, event := range m.Events {
+		if event.Location == location && event.Type == eventType {
+			return event
+		}
+	}
+	return nil
+}
+
+// StartEvent begins an event at a location, e.g. for scripted events
+func (m *Manager) StartEvent(eventType LocationEventType, location string) (*LocationEvent, error) {
+	if _, exists := m.World.Location(location); !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLocation, location)
+	}
+
+	event := &LocationEvent{
+		Type:     eventType,
+		Location: location,
+		StartDay: m.Day,
+		EndDay:   m.Day + locationEventSpecs[eventType].Duration,
+	}
+	m.Events = append(m.Events, event)
+	m.announce(*event, true)
+	return event, nil
+}
+
+// announce queues an announcement for an event starting or ending
+func (m *Manager) announce(event LocationEvent, started bool) {
+	spec := locationEventSpecs[event.Type]
+	format := spec.Ending
+	if started {
+		format = spec.Announcement
+	}
+
+	name := event.Location
+	if loc, exists := m.World.Location(event.Location); exists {
+		name = loc.Name
+	}
+
+	m.announcements = append(m.announcements, Announcement{
+		Event:   event,
+		Started: started,
+		Text:    fmt.Sprintf(format, name),
+	})
+}
+
+// DrainAnnouncements returns and clears the announcements made since the
+// last call
+func (m *Manager) DrainAnnouncements() []Announcement {
+	announcements := m.announcements
+	m.announcements = nil
+	return announcements
+}
+
+// Conditions returns a location's characteristics with active events applied
+func (m *Manager) Conditions(location string) Conditions {
+	loc, exists := m.World.Location(location)
+	if !exists {
+		return Conditions{}
+	}
+
+	cond := Conditions{
+		Resources: loc.Biome.Resources(),
+		Danger:    loc.Danger,
+	}
+	for _, event := range m.Events {
+		if event.Location != location {
+			continue
+		}
+		spec := locationEventSpecs[event.Type]
+		cond.Food += spec.FoodBonus
+		cond.Water += spec.WaterBonus
+		cond.SocialDensity += spec.SocialBonus
+		cond.Danger = clamp(cond.Danger+spec.DangerBonus, 0.0, 1.0)
+		cond.Closed = cond.Closed || spec.Closes
+		cond.Events = append(cond.Events, event.Type)
+	}
+	cond.Food = math.Max(cond.Food, 0.0)
+	cond.Water = math.Max(cond.Water, 0.0)
+	sort.Slice(cond.Events, func(i, j int) bool { return cond.Events[i] < cond.Events[j] })
+	return cond
+}
+
+// PlanTrip plans a journey like WorldMap.PlanTrip, refusing destinations
+// an event has closed and accounting for event danger
+func (m *Manager) PlanTrip(from, to string) (Trip, error) {
+	trip, err := m.World.PlanTrip(from, to)
+	if err != nil {
+		return Trip{}, err
+	}
+
+	cond := m.Conditions(to)
+	if cond.Closed {
+		return Trip{}, fmt.Errorf("%w: %s", ErrLocationClosed, to)
+	}
+	trip.Danger = cond.Danger
+	return trip, nil
+}
@@ -0,0 +1,75 @@
+package environment
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEventModifiesConditionsUntilItEnds(t *testing.T) {
+	m := NewSeededManager(DefaultWorldMap(), 1)
+	m.NextRoll = math.Inf(1) // Only scripted events
+	base := m.Conditions("forest")
+
+	if _, err := m.StartEvent(EventBerryBloom, "forest"); err != nil {
+		t.Fatalf("StartEvent failed: %v", err)
+	}
+	announcements := m.DrainAnnouncements()
+	if len(announcements) != 1 || !announcements[0].Started {
+		t.Fatalf("Expected a start announcement, got %+v", announcements)
+	}
+
+	cond := m.Conditions("forest")
+	if cond.Food != base.Food+1.0 || len(cond.Events) != 1 {
+		t.Errorf("Berry bloom should boost food, got %+v", cond)
+	}
+
+	m.Update(7.0)
+	if got := m.Conditions("forest"); got.Food != base.Food {
+		t.Errorf("Food should return to normal after the bloom, got %.2f", got.Food)
+	}
+
+	ended := false
+	for _, a := range m.DrainAnnouncements() {
+		if a.Event.Type == EventBerryBloom && !a.Started {
+			ended = true
+		}
+	}
+	if !ended {
+		t.Error("Expected an announcement when the bloom ends")
+	}
+}
+
+func TestRockslideClosesLocation(t *testing.T) {
+	world := DefaultWorldMap()
+	m := NewSeededManager(world, 1)
+	m.StartEvent(EventRockslide, "peak")
+
+	if _, err := m.PlanTrip(HomeLocation, "peak"); !errors.Is(err, ErrLocationClosed) {
+		t.Errorf("Expected ErrLocationClosed, got %v", err)
+	}
+	if cond := m.Conditions("peak"); cond.Danger <= world.Locations["peak"].Danger {
+		t.Error("Rockslide should make the location more dangerous")
+	}
+	if _, err := m.StartEvent(EventRockslide, "atlantis"); !errors.Is(err, ErrUnknownLocation) {
+		t.Errorf("Expected ErrUnknownLocation, got %v", err)
+	}
+}
+
+func TestEventsRollInEligibleBiomes(t *testing.T) {
+	world := DefaultWorldMap()
+	m := NewSeededManager(world, 7)
+
+	for day := 0; day < 200; day++ {
+		m.Update(1.0)
+		for _, event := range m.Events {
+			biome := world.Locations[event.Location].Biome
+			if !locationEventSpecs[event.Type].appliesTo(biome) {
+				t.Fatalf("%s started in %s biome", event.Type, biome)
+			}
+		}
+	}
+	if len(m.DrainAnnouncements()) == 0 {
+		t.Error("Expected events to occur over 200 days")
+	}
+}
@@ -0,0 +1,107 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrNoFishing is returned when fishing somewhere without water
+	ErrNoFishing = errors.New("there is nowhere to fish here")
+	// ErrUnknownFish is returned when a fish name is not recognised
+	ErrUnknownFish = errors.New("unknown fish")
+)
+
+// FishSpecies is a kind of fish that can be caught
+type FishSpecies struct {
+	Name       string
+	Biomes     []Biome
+	Seasons    []types.Season    // Seasons it bites in; empty means all year
+	Commonness float64           // Relative chance of hooking it
+	Difficulty float64           // How hard it is to land (0.0 to 1.0)
+	Nutrients  biology.Nutrients // What eating it provides; fish are rich in protein
+}
+
+// fishSpecies lists every catchable fish
+var fishSpecies = []FishSpecies{
+	{Name: "Mackerel", Biomes: []Biome{BiomeOcean}, Commonness: 5, Difficulty: 0.2, Nutrients: biology.Nutrients{Protein: 0.3, Fat: 0.2, Vitamins: 0.15}},
+	{Name: "Sea Bass", Biomes: []Biome{BiomeOcean}, Seasons: []types.Season{types.SeasonSummer, types.SeasonAutumn}, Commonness: 3, Difficulty: 0.4, Nutrients: biology.Nutrients{Protein: 0.5, Fat: 0.2, Vitamins: 0.1}},
+	{Name: "Cod", Biomes: []Biome{BiomeOcean}, Seasons: []types.Season{types.SeasonAutumn, types.SeasonWinter}, Commonness: 3, Difficulty: 0.4, Nutrients: biology.Nutrients{Protein: 0.55, Fat: 0.15, Vitamins: 0.1}},
+	{Name: "Swordfish", Biomes: []Biome{BiomeOcean}, Seasons: []types.Season{types.SeasonSummer}, Commonness: 0.5, Difficulty: 0.8, Nutrients: biology.Nutrients{Protein: 0.7, Fat: 0.3, Vitamins: 0.15}},
+	{Name: "Mudskipper", Biomes: []Biome{BiomeSwamp}, Seasons: []types.Season{types.SeasonSpring, types.SeasonSummer}, Commonness: 4, Difficulty: 0.15, Nutrients: biology.Nutrients{Protein: 0.2, Fat: 0.1, Vitamins: 0.05}},
+	{Name: "Catfish", Biomes: []Biome{BiomeSwamp}, Commonness: 4, Difficulty: 0.3, Nutrients: biology.Nutrients{Protein: 0.4, Fat: 0.2, Vitamins: 0.05}},
+	{Name: "Eel", Biomes: []Biome{BiomeSwamp, BiomeOcean}, Seasons: []types.Season{types.SeasonAutumn, types.SeasonWinter}, Commonness: 1.5, Difficulty: 0.55, Nutrients: biology.Nutrients{Protein: 0.3, Fat: 0.3, Vitamins: 0.2}},
+	{Name: "Giant Gar", Biomes: []Biome{BiomeSwamp}, Commonness: 0.5, Difficulty: 0.85, Nutrients: biology.Nutrients{Protein: 0.7, Fat: 0.3, Vitamins: 0.1}},
+}
+
+// bitesIn returns true if the fish can be caught in a biome and season
+func (f FishSpecies) bitesIn(biome Biome, season types.Season) bool {
+	inBiome := false
+	for _, b := range f.Biomes {
+		inBiome = inBiome || b == biome
+	}
+	if !inBiome {
+		return false
+	}
+	if len(f.Seasons) == 0 {
+		return true
+	}
+	for _, s := range f.Seasons {
+		if s == season {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFishing returns true if the biome has water to fish in
+func (b Biome) HasFishing() bool {
+	return b == BiomeOcean || b == BiomeSwamp
+}
+
+// FishFor returns the fish biting in a biome and season
+func FishFor(biome Biome, season types.Season) []FishSpecies {
+	var fish []FishSpecies
+	for _, f := range fishSpecies {
+		if f.bitesIn(biome, season) {
+			fish = append(fish, f)
+		}
+	}
+	return fish
+}
+
+// HookFish picks which fish takes the bait, weighted by commonness
+func HookFish(biome Biome, season types.Season, rng *rand.Rand) (FishSpecies, error) {
+	fish := FishFor(biome, season)
+	if !biome.HasFishing() || len(fish) == 0 {
+		return FishSpecies{}, fmt.Errorf("%w: %s", ErrNoFishing, biome)
+	}
+
+	total := 0.0
+	for _, f := range fish {
+		total += f.Commonness
+	}
+	roll := rng.Float64() * total
+	for _, f := range fish {
+		roll -= f.Commonness
+		if roll < 0 {
+			return f, nil
+		}
+	}
+	return fish[len(fish)-1], nil
+}
+
+// LookupFish finds a fish species by name, ignoring case
+func LookupFish(name string) (FishSpecies, error) {
+	for _, f := range fishSpecies {
+		if strings.EqualFold(f.Name, name) {
+			return f, nil
+		}
+	}
+	return FishSpecies{}, fmt.Errorf("%w: %s", ErrUnknownFish, name)
+}
@@ -0,0 +1,58 @@
+package environment
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestFishDependOnBiomeAndSeason(t *testing.T) {
+	for _, f := range FishFor(BiomeOcean, types.SeasonWinter) {
+		if f.Name == "Swordfish" || f.Name == "Catfish" {
+			t.Errorf("%s should not bite in a winter ocean", f.Name)
+		}
+	}
+	if len(FishFor(BiomeSwamp, types.SeasonSummer)) == 0 {
+		t.Error("Expected fish in a summer swamp")
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	if _, err := HookFish(BiomeDesert, types.SeasonSummer, rng); !errors.Is(err, ErrNoFishing) {
+		t.Errorf("Expected ErrNoFishing, got %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		fish, err := HookFish(BiomeOcean, types.SeasonSummer, rng)
+		if err != nil {
+			t.Fatalf("HookFish failed: %v", err)
+		}
+		seen[fish.Name] = true
+	}
+	if !seen["Mackerel"] || seen["Cod"] {
+		t.Errorf("Unexpected summer ocean catches: %v", seen)
+	}
+}
+
+func TestFishAlbum(t *testing.T) {
+	inv := NewInventory()
+	eel, err := LookupFish("eel")
+	if err != nil {
+		t.Fatalf("LookupFish failed: %v", err)
+	}
+
+	if !inv.AddFish(eel) || inv.AddFish(eel) {
+		t.Error("Only the first catch of a species should be new")
+	}
+	if err := inv.TakeFish("Eel"); err != nil {
+		t.Fatalf("TakeFish failed: %v", err)
+	}
+	if inv.Fish["Eel"] != 1 || inv.FishAlbum["Eel"] != 2 {
+		t.Errorf("Eating a fish should not remove it from the album: %+v", inv)
+	}
+	if err := inv.TakeFish("Cod"); !errors.Is(err, ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock, got %v", err)
+	}
+}
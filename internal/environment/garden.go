@@ -0,0 +1,385 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrUnknownCrop is returned when a crop name is not recognised
+	ErrUnknownCrop = errors.New("unknown crop")
+	// ErrUnknownPlot is returned when a plot number is out of range
+	ErrUnknownPlot = errors.New("unknown garden plot")
+	// ErrPlotOccupied is returned when planting in a plot that is in use
+	ErrPlotOccupied = errors.New("plot is already planted")
+	// ErrPlotEmpty is returned when tending or harvesting an empty plot
+	ErrPlotEmpty = errors.New("nothing is planted there")
+	// ErrNotRipe is returned when harvesting a crop that is still growing
+	ErrNotRipe = errors.New("crop is not ready to harvest")
+	// ErrOutOfStock is returned when the inventory lacks the requested item
+	ErrOutOfStock = errors.New("not enough in the inventory")
+	// ErrNothingToGather is returned when a biome has no seeds to gather
+	ErrNothingToGather = errors.New("no seeds to gather here")
+)
+
+// Garden settings
+const (
+	GardenPlots      = 4    // Plots in a new garden
+	DryingRate       = 0.35 // Moisture lost per game day
+	HeatDryingRate   = 0.4  // Extra moisture lost per game day in a heatwave
+	DroughtThreshold = 0.15 // Moisture below which crops stop growing and wilt
+	WiltDays         = 3.0  // Game days of drought before a crop dies
+	WeedRate         = 0.1  // Weed cover gained per game day
+	OffSeasonGrowth  = 0.5  // Growth rate outside a crop's seasons
+	WinterGrowth     = 0.1  // Growth rate in winter for crops not suited to it
+	StartingCoins    = 250  // Coins in a new inventory
+)
+
+// Crop is a plant that can be grown in the garden
+type Crop int
+
+const (
+	CropBerries Crop = iota
+	CropCarrots
+	CropPumpkins
+	CropMelons
+	CropRice
+	CropTurnips
+)
+
+// AllCrops returns every crop in declaration order
+func AllCrops() []Crop {
+	return []Crop{CropBerries, CropCarrots, CropPumpkins, CropMelons, CropRice, CropTurnips}
+}
+
+// String returns the string representation of Crop
+func (c Crop) String() string {
+	return [...]string{
+		"Berries", "Carrots", "Pumpkins", "Melons", "Rice", "Turnips",
+	}[c]
+}
+
+// ParseCrop looks up a crop by name, ignoring case
+func ParseCrop(name string) (Crop, error) {
+	for _, crop := range AllCrops() {
+		if strings.EqualFold(crop.String(), name) {
+			return crop, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownCrop, name)
+}
+
+// cropSpec describes where a crop's seeds are found and how it grows
+type cropSpec struct {
+	Biomes    []Biome           // Biomes its seeds can be gathered in
+	Seasons   []types.Season    // Seasons it grows at full speed
+	GrowDays  float64           // Game days to ripen in good conditions
+	Yield     int               // Produce per harvest
+	Nutrients biology.Nutrients // What one item provides
+}
+
+// cropSpecs lists every crop
+var cropSpecs = map[Crop]cropSpec{
+	CropBerries:  {Biomes: []Biome{BiomeTemperate, BiomeTropical}, Seasons: []types.Season{types.SeasonSpring, types.SeasonSummer}, GrowDays: 4, Yield: 4, Nutrients: biology.Nutrients{Protein: 0.02, Fat: 0.03, Carbs: 0.25, Vitamins: 0.5}},
+	CropCarrots:  {Biomes: []Biome{BiomeTemperate, BiomeMountain}, Seasons: []types.Season{types.SeasonSpring, types.SeasonAutumn}, GrowDays: 5, Yield: 3, Nutrients: biology.Nutrients{Protein: 0.05, Fat: 0.02, Carbs: 0.43, Vitamins: 0.4}},
+	CropPumpkins: {Biomes: []Biome{BiomeTemperate, BiomeSwamp}, Seasons: []types.Season{types.SeasonSummer, types.SeasonAutumn}, GrowDays: 8, Yield: 2, Nutrients: biology.Nutrients{Protein: 0.1, Fat: 0.05, Carbs: 0.85, Vitamins: 0.3}},
+	CropMelons:   {Biomes: []Biome{BiomeTropical, BiomeDesert}, Seasons: []types.Season{types.SeasonSummer}, GrowDays: 6, Yield: 2, Nutrients: biology.Nutrients{Protein: 0.05, Fat: 0.03, Carbs: 0.72, Vitamins: 0.3}},
+	CropRice:     {Biomes: []Biome{BiomeSwamp, BiomeTropical}, Seasons: []types.Season{types.SeasonSpring, types.SeasonSummer}, GrowDays: 7, Yield: 5, Nutrients: biology.Nutrients{Protein: 0.08, Fat: 0.02, Carbs: 0.3, Vitamins: 0.05}},
+	CropTurnips:  {Biomes: []Biome{BiomeArctic, BiomeMountain}, Seasons: []types.Season{types.SeasonAutumn, types.SeasonWinter}, GrowDays: 6, Yield: 3, Nutrients: biology.Nutrients{Protein: 0.06, Fat: 0.02, Carbs: 0.42, Vitamins: 0.25}},
+}
+
+// Nutrients returns what one item of the crop provides. Crops are rich
+// in carbs and vitamins but poor in protein.
+func (c Crop) Nutrients() biology.Nutrients {
+	return cropSpecs[c].Nutrients
+}
+
+// growthRate returns the crop's relative growth speed in a season
+func (c Crop) growthRate(season types.Season) float64 {
+	for _, s := range cropSpecs[c].Seasons {
+		if s == season {
+			return 1.0
+		}
+	}
+	if season == types.SeasonWinter {
+		return WinterGrowth
+	}
+	return OffSeasonGrowth
+}
+
+// CropsFor returns the crops whose seeds can be gathered in a biome
+func CropsFor(biome Biome) []Crop {
+	var crops []Crop
+	for _, crop := range AllCrops() {
+		for _, b := range cropSpecs[crop].Biomes {
+			if b == biome {
+				crops = append(crops, crop)
+				break
+			}
+		}
+	}
+	return crops
+}
+
+// Inventory holds the player's coins, seeds, harvested produce, caught fish
+// and spare accessories
+type Inventory struct {
+	Coins     int            `json:"coins"`
+	Seeds     map[Crop]int   `json:"seeds"`
+	Produce   map[Crop]int   `json:"produce"`
+	Fish      map[string]int `json:"fish"`       // Fish in stock by species
+	FishAlbum map[string]int `json:"fish_album"` // Fish ever caught by species
+
+	Accessories map[Accessory]int `json:"accessories,omitempty"` // Accessories not being worn
+
+	Dairy map[Dairy]int  `json:"dairy,omitempty"` // Bought for cooking
+	Meals []PreparedMeal `json:"meals,omitempty"` // Cooked and waiting to be served
+
+	Spent int `json:"spent,omitempty"` // Coins ever spent through Spend
+}
+
+// NewInventory creates an inventory with StartingCoins and nothing else
+func NewInventory() *Inventory {
+	return &Inventory{
+		Coins:     StartingCoins,
+		Seeds:     make(map[Crop]int),
+		Produce:   make(map[Crop]int),
+		Fish:      make(map[string]int),
+		FishAlbum: make(map[string]int),
+	}
+}
+
+// take removes count items from a stock
+func take(stock map[Crop]int, crop Crop, count int) error {
+	if stock[crop] < count {
+		return fmt.Errorf("%w: %s", ErrOutOfStock, crop)
+	}
+	stock[crop] -= count
+	if stock[crop] == 0 {
+		delete(stock, crop)
+	}
+	return nil
+}
+
+// Spend removes coins, e.g. to pay a fee
+func (inv *Inventory) Spend(amount int) error {
+	if inv.Coins < amount {
+		return fmt.Errorf("%w: %d coins, have %d", ErrOutOfStock, amount, inv.Coins)
+	}
+	inv.Coins -= amount
+	inv.Spent += amount
+	return nil
+}
+
+// TakeProduce removes one harvested item, e.g. to feed a pet
+func (inv *Inventory) TakeProduce(crop Crop) error {
+	return take(inv.Produce, crop, 1)
+}
+
+// AddFish stores a caught fish, recording it in the album. Returns true
+// if it is the first of its species.
+func (inv *Inventory) AddFish(fish FishSpecies) bool {
+	inv.Fish[fish.Name]++
+	inv.FishAlbum[fish.Name]++
+	return inv.FishAlbum[fish.Name] == 1
+}
+
+// TakeFish removes one caught fish, e.g. to feed a pet
+func (inv *Inventory) TakeFish(name string) error {
+	if inv.Fish[name] < 1 {
+		return fmt.Errorf("%w: %s", ErrOutOfStock, name)
+	}
+	inv.Fish[name]--
+	if inv.Fish[name] == 0 {
+		delete(inv.Fish, name)
+	}
+	return nil
+}
+
+// Plot is a single bed in the garden
+type Plot struct {
+	Crop     Crop    `json:"crop"`
+	Planted  bool    `json:"planted"`
+	Growth   float64 `json:"growth"`   // 0.0 seed to 1.0 ripe
+	Moisture float64 `json:"moisture"` // 0.0 parched to 1.0 soaked
+	Weeds    float64 `json:"weeds"`    // Weed cover slowing growth (0.0 to 1.0)
+	DryDays  float64 `json:"dry_days"` // Consecutive game days in drought
+}
+
+// IsRipe returns true if the plot holds a crop ready to harvest
+func (p *Plot) IsRipe() bool {
+	return p.Planted && p.Growth >= 1.0
+}
+
+// GardenNotice reports a crop ripening or dying
+type GardenNotice struct {
+	Plot int
+	Crop Crop
+	Ripe bool // False when the crop wilted
+}
+
+// Garden is the set of plots at the home location
+type Garden struct {
+	Plots []*Plot `json:"plots"`
+
+	notices []GardenNotice
+	rng     *rand.Rand
+}
+
+// NewGarden creates a garden with empty plots
+func NewGarden() *Garden {
+	return NewSeededGarden(time.Now().UnixNano())
+}
+
+// NewSeededGarden creates a garden with deterministic seed gathering for testing
+func NewSeededGarden(seed int64) *Garden {
+	g := &Garden{
+		Plots: make([]*Plot, GardenPlots),
+		rng:   rand.New(rand.NewSource(seed)),
+	}
+	for i := range g.Plots {
+		g.Plots[i] = &Plot{}
+	}
+	return g
+}
+
+// plot looks up a plot by its 1-based number
+func (g *Garden) plot(n int) (*Plot, error) {
+	if n < 1 || n > len(g.Plots) {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownPlot, n)
+	}
+	return g.Plots[n-1], nil
+}
+
+// Gather finds a seed of a random crop native to a biome and adds it to
+// the inventory
+func (g *Garden) Gather(biome Biome, inv *Inventory) (Crop, error) {
+	crops := CropsFor(biome)
+	if len(crops) == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrNothingToGather, biome)
+	}
+	crop := crops[g.rng.Intn(len(crops))]
+	inv.Seeds[crop]++
+	return crop, nil
+}
+
+// Plant sows a seed from the inventory into an empty plot
+func (g *Garden) Plant(n int, crop Crop, inv *Inventory) error {
+	p, err := g.plot(n)
+	if err != nil {
+		return err
+	}
+	if p.Planted {
+		return fmt.Errorf("%w: %d", ErrPlotOccupied, n)
+	}
+	if err := take(inv.Seeds, crop, 1); err != nil {
+		return err
+	}
+	*p = Plot{Crop: crop, Planted: true, Moisture: 1.0}
+	return nil
+}
+
+// Water soaks a planted plot
+func (g *Garden) Water(n int) error {
+	p, err := g.plot(n)
+	if err != nil {
+		return err
+	}
+	if !p.Planted {
+		return fmt.Errorf("%w: %d", ErrPlotEmpty, n)
+	}
+	p.Moisture = 1.0
+	p.DryDays = 0
+	return nil
+}
+
+// Tend clears the weeds from a planted plot
+func (g *Garden) Tend(n int) error {
+	p, err := g.plot(n)
+	if err != nil {
+		return err
+	}
+	if !p.Planted {
+		return fmt.Errorf("%w: %d", ErrPlotEmpty, n)
+	}
+	p.Weeds = 0
+	return nil
+}
+
+// Harvest picks a ripe crop into the inventory, leaving the plot empty.
+// Weedy plots yield less.
+func (g *Garden) Harvest(n int, inv *Inventory) (Crop, int, error) {
+	p, err := g.plot(n)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !p.Planted {
+		return 0, 0, fmt.Errorf("%w: %d", ErrPlotEmpty, n)
+	}
+	if !p.IsRipe() {
+		return 0, 0, fmt.Errorf("%w: %s is %.0f%% grown", ErrNotRipe, p.Crop, p.Growth*100)
+	}
+
+	crop := p.Crop
+	yield := cropSpecs[crop].Yield
+	if p.Weeds >= 0.5 {
+		yield = (yield + 1) / 2
+	}
+	inv.Produce[crop] += yield
+	*p = Plot{}
+	return crop, yield, nil
+}
+
+// Update grows the garden by deltaTime game days. Rain waters the plots,
+// heat dries them out, and growth depends on the season and weed cover.
+// Crops left in drought for WiltDays die.
+func (g *Garden) Update(effects WeatherEffects, deltaTime float64) {
+	for i, p := range g.Plots {
+		if !p.Planted {
+			continue
+		}
+
+		if effects.Weather.IsWet() || effects.Weather == WeatherSnow {
+			p.Moisture = 1.0
+		} else {
+			drying := DryingRate
+			if effects.Weather == WeatherHeatwave {
+				drying += HeatDryingRate
+			}
+			p.Moisture = clamp(p.Moisture-drying*deltaTime, 0.0, 1.0)
+		}
+		p.Weeds = clamp(p.Weeds+WeedRate*deltaTime, 0.0, 1.0)
+
+		if p.Moisture < DroughtThreshold {
+			p.DryDays += deltaTime
+			if p.DryDays >= WiltDays {
+				g.notices = append(g.notices, GardenNotice{Plot: i + 1, Crop: p.Crop})
+				*p = Plot{}
+			}
+			continue
+		}
+		p.DryDays = 0
+
+		if p.IsRipe() {
+			continue
+		}
+		rate := p.Crop.growthRate(effects.Season) * (1.0 - p.Weeds*0.5)
+		p.Growth = clamp(p.Growth+rate*deltaTime/cropSpecs[p.Crop].GrowDays, 0.0, 1.0)
+		if p.IsRipe() {
+			g.notices = append(g.notices, GardenNotice{Plot: i + 1, Crop: p.Crop, Ripe: true})
+		}
+	}
+}
+
+// DrainNotices returns and clears the notices raised since the last call
+func (g *Garden) DrainNotices() []GardenNotice {
+	notices := g.notices
+	g.notices = nil
+	return notices
+}
@@ -0,0 +1,121 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestGardenGrowAndHarvest(t *testing.T) {
+	g := NewSeededGarden(1)
+	inv := NewInventory()
+
+	if err := g.Plant(1, CropBerries, inv); !errors.Is(err, ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock without seeds, got %v", err)
+	}
+
+	inv.Seeds[CropBerries] = 1
+	if err := g.Plant(1, CropBerries, inv); err != nil {
+		t.Fatalf("Plant failed: %v", err)
+	}
+	if inv.Seeds[CropBerries] != 0 {
+		t.Error("Planting should use up the seed")
+	}
+	if err := g.Plant(1, CropBerries, inv); !errors.Is(err, ErrPlotOccupied) {
+		t.Errorf("Expected ErrPlotOccupied, got %v", err)
+	}
+
+	rain := WeatherEffects{Weather: WeatherRain, Season: types.SeasonSpring}
+	g.Update(rain, 2.0)
+	if _, _, err := g.Harvest(1, inv); !errors.Is(err, ErrNotRipe) {
+		t.Errorf("Expected ErrNotRipe, got %v", err)
+	}
+
+	for day := 0; day < 10 && !g.Plots[0].IsRipe(); day++ {
+		g.Update(rain, 1.0)
+		g.Tend(1)
+	}
+	notices := g.DrainNotices()
+	if len(notices) != 1 || !notices[0].Ripe {
+		t.Fatalf("Expected a ripening notice, got %+v", notices)
+	}
+
+	crop, yield, err := g.Harvest(1, inv)
+	if err != nil {
+		t.Fatalf("Harvest failed: %v", err)
+	}
+	if crop != CropBerries || inv.Produce[CropBerries] != yield || yield != cropSpecs[CropBerries].Yield {
+		t.Errorf("Expected full yield of berries, got %d %s", yield, crop)
+	}
+	if g.Plots[0].Planted {
+		t.Error("Harvesting should clear the plot")
+	}
+}
+
+func TestGardenSeasonAffectsGrowth(t *testing.T) {
+	g := NewSeededGarden(1)
+	inv := NewInventory()
+	inv.Seeds[CropMelons] = 2
+	g.Plant(1, CropMelons, inv)
+	g.Plant(2, CropMelons, inv)
+
+	g.Plots[0].Growth = 0
+	g.Update(WeatherEffects{Weather: WeatherRain, Season: types.SeasonSummer}, 1.0)
+	summer := g.Plots[0].Growth
+
+	g.Plots[0].Growth = 0
+	g.Update(WeatherEffects{Weather: WeatherSnow, Season: types.SeasonWinter}, 1.0)
+	if winter := g.Plots[0].Growth; winter >= summer {
+		t.Errorf("Melons should grow slower in winter (%.3f) than summer (%.3f)", winter, summer)
+	}
+}
+
+func TestGardenDroughtKillsCrops(t *testing.T) {
+	g := NewSeededGarden(1)
+	inv := NewInventory()
+	inv.Seeds[CropCarrots] = 1
+	g.Plant(1, CropCarrots, inv)
+
+	heat := WeatherEffects{Weather: WeatherHeatwave, Season: types.SeasonSummer}
+	for day := 0; day < 6; day++ {
+		g.Update(heat, 1.0)
+	}
+
+	if g.Plots[0].Planted {
+		t.Error("Unwatered crops should wilt in a heatwave")
+	}
+	notices := g.DrainNotices()
+	if len(notices) != 1 || notices[0].Ripe {
+		t.Errorf("Expected a wilting notice, got %+v", notices)
+	}
+}
+
+func TestGatherSeeds(t *testing.T) {
+	g := NewSeededGarden(1)
+	inv := NewInventory()
+
+	crop, err := g.Gather(BiomeArctic, inv)
+	if err != nil || crop != CropTurnips || inv.Seeds[CropTurnips] != 1 {
+		t.Errorf("Expected turnip seeds from the arctic, got %s (%v)", crop, err)
+	}
+	if _, err := g.Gather(BiomeUrban, inv); !errors.Is(err, ErrNothingToGather) {
+		t.Errorf("Expected ErrNothingToGather, got %v", err)
+	}
+	if _, err := ParseCrop("pumpkins"); err != nil {
+		t.Errorf("ParseCrop should ignore case: %v", err)
+	}
+}
+
+func TestInventorySpend(t *testing.T) {
+	inv := NewInventory()
+	if err := inv.Spend(StartingCoins - 50); err != nil {
+		t.Fatalf("Spend failed: %v", err)
+	}
+	if err := inv.Spend(51); !errors.Is(err, ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock when overspending, got %v", err)
+	}
+	if inv.Coins != 50 || inv.Spent != StartingCoins-50 {
+		t.Errorf("Expected 50 coins left and the rest counted as spent, got %d and %d", inv.Coins, inv.Spent)
+	}
+}
@@ -0,0 +1,120 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownHabitatItem is returned when a habitat item name is not recognised
+var ErrUnknownHabitatItem = errors.New("unknown habitat item")
+
+// Habitat settings
+const (
+	HomeShelter   = 0.5  // Shelter the home offers with no items
+	HeaterOnBelow = 15.0 // Ambient °C below which heaters warm the home
+	CoolingAbove  = 24.0 // Ambient °C above which fans and shade cool the home
+)
+
+// HabitatItem is something placed in the home to keep pets comfortable
+type HabitatItem int
+
+const (
+	HabitatBed HabitatItem = iota
+	HabitatBlanket
+	HabitatHeater
+	HabitatFan
+	HabitatShade
+)
+
+// AllHabitatItems returns every habitat item in declaration order
+func AllHabitatItems() []HabitatItem {
+	return []HabitatItem{HabitatBed, HabitatBlanket, HabitatHeater, HabitatFan, HabitatShade}
+}
+
+// String returns the string representation of HabitatItem
+func (hi HabitatItem) String() string {
+	return [...]string{
+		"Bed", "Blanket", "Heater", "Fan", "Shade",
+	}[hi]
+}
+
+// ParseHabitatItem looks up a habitat item by name, ignoring case
+func ParseHabitatItem(name string) (HabitatItem, error) {
+	for _, item := range AllHabitatItems() {
+		if strings.EqualFold(item.String(), name) {
+			return item, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownHabitatItem, name)
+}
+
+// habitatItemSpec describes how an item helps
+type habitatItemSpec struct {
+	Shelter float64 // Added to the home's shelter
+	Warming float64 // °C added when it is cold
+	Cooling float64 // °C removed when it is hot
+}
+
+// habitatItemSpecs lists every habitat item
+var habitatItemSpecs = map[HabitatItem]habitatItemSpec{
+	HabitatBed:     {Shelter: 0.2},
+	HabitatBlanket: {Shelter: 0.1, Warming: 3.0},
+	HabitatHeater:  {Warming: 8.0},
+	HabitatFan:     {Cooling: 6.0},
+	HabitatShade:   {Shelter: 0.1, Cooling: 3.0},
+}
+
+// Habitat is the set of comfort items in the home
+type Habitat struct {
+	Items map[HabitatItem]bool `json:"items"`
+}
+
+// NewHabitat creates an empty home habitat
+func NewHabitat() *Habitat {
+	return &Habitat{
+		Items: make(map[HabitatItem]bool),
+	}
+}
+
+// Add places an item in the home
+func (h *Habitat) Add(item HabitatItem) {
+	h.Items[item] = true
+}
+
+// Remove takes an item out of the home
+func (h *Habitat) Remove(item HabitatItem) {
+	delete(h.Items, item)
+}
+
+// Shelter returns the shelter quality of the home (0.0 to 1.0)
+func (h *Habitat) Shelter() float64 {
+	shelter := HomeShelter
+	for item := range h.Items {
+		shelter += habitatItemSpecs[item].Shelter
+	}
+	return clamp(shelter, 0.0, 1.0)
+}
+
+// TemperatureShift returns how much the items change the temperature pets
+// feel at home for a given ambient temperature
+func (h *Habitat) TemperatureShift(ambient float64) float64 {
+	shift := 0.0
+	for item := range h.Items {
+		spec := habitatItemSpecs[item]
+		if ambient < HeaterOnBelow {
+			shift += spec.Warming
+		}
+		if ambient > CoolingAbove {
+			shift -= spec.Cooling
+		}
+	}
+	return shift
+}
+
+// Apply returns the weather effects as felt by a pet at home
+func (h *Habitat) Apply(effects WeatherEffects) WeatherEffects {
+	effects.Shelter = h.Shelter()
+	effects.HabitatShift = h.TemperatureShift(effects.Temperature)
+	return effects
+}
@@ -0,0 +1,39 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHabitatItems(t *testing.T) {
+	h := NewHabitat()
+	if h.Shelter() != HomeShelter || h.TemperatureShift(-5) != 0 {
+		t.Error("An empty home should only offer basic shelter")
+	}
+
+	h.Add(HabitatBed)
+	h.Add(HabitatHeater)
+	h.Add(HabitatFan)
+	if h.Shelter() <= HomeShelter {
+		t.Error("A bed should improve shelter")
+	}
+	if h.TemperatureShift(-5) <= 0 {
+		t.Error("A heater should warm the home in the cold")
+	}
+	if h.TemperatureShift(35) >= 0 {
+		t.Error("A fan should cool the home in the heat")
+	}
+
+	effects := h.Apply(WeatherEffects{Temperature: -5})
+	if effects.Shelter != h.Shelter() || effects.HabitatShift != h.TemperatureShift(-5) || effects.Temperature != -5 {
+		t.Errorf("Unexpected effects %+v", effects)
+	}
+
+	h.Remove(HabitatHeater)
+	if h.TemperatureShift(-5) != 0 {
+		t.Error("Removing the heater should stop the warming")
+	}
+	if _, err := ParseHabitatItem("jacuzzi"); !errors.Is(err, ErrUnknownHabitatItem) {
+		t.Errorf("Expected ErrUnknownHabitatItem, got %v", err)
+	}
+}
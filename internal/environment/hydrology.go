@@ -0,0 +1,69 @@
+package environment
+
+import "math"
+
+// Hydrology settings
+const (
+	MoistureDays        = 3.0 // Game days of weather a location's moisture reflects
+	NormalMoisture      = 0.3 // Moisture of a location before any weather is seen
+	DroughtLevel        = 0.1 // Moisture below which a drought sets in
+	DroughtRelief       = 0.2 // Moisture above which a drought starts to recover
+	DroughtRecoveryDays = 5.0 // Game days a drought lingers once the rain returns
+	FloodLevel          = 0.5 // Moisture above which low-lying biomes flood
+	FloodRelief         = 0.4 // Moisture below which floodwater starts to go down
+	FloodRecoveryDays   = 3.0 // Game days floodwater takes to go down
+)
+
+// precipitation is how much each weather type waters the ground (0.0 to
+// 1.0); a heatwave dries it out
+var precipitation = map[WeatherType]float64{
+	WeatherCloudy:    0.1,
+	WeatherRain:      0.6,
+	WeatherHeavyRain: 1.0,
+	WeatherStorm:     0.9,
+	WeatherSnow:      0.3,
+	WeatherHeatwave:  -0.2,
+	WeatherFog:       0.1,
+}
+
+// UpdateWater advances each location's moisture by deltaTime game days of
+// the weather there. A sustained dry spell brings drought and sustained
+// heavy rain floods low-lying biomes; both lift only after a recovery
+// period once the weather turns.
+func (m *Manager) UpdateWater(weather *WeatherSystem, deltaTime float64) {
+	if m.Moisture == nil {
+		m.Moisture = make(map[string]float64)
+	}
+	rate := math.Min(deltaTime/MoistureDays, 1.0)
+
+	for _, id := range m.World.LocationIDs() {
+		loc := m.World.Locations[id]
+		moisture, seen := m.Moisture[id]
+		if !seen {
+			moisture = NormalMoisture
+		}
+		rain := precipitation[weather.EffectsAt(m.World, loc).Weather]
+		moisture = clamp(moisture+(rain-moisture)*rate, 0.0, 1.0)
+		m.Moisture[id] = moisture
+
+		m.updateWaterEvent(EventDrought, loc, moisture < DroughtLevel, moisture <= DroughtRelief)
+		m.updateWaterEvent(EventFlood, loc, moisture > FloodLevel, moisture >= FloodRelief)
+	}
+}
+
+// updateWaterEvent starts a drought or flood at a location once triggered
+// and holds off its recovery while it persists
+func (m *Manager) updateWaterEvent(eventType LocationEventType, loc *Location, triggered, persists bool) {
+	spec := locationEventSpecs[eventType]
+	if !spec.appliesTo(loc.Biome) {
+		return
+	}
+
+	event := m.activeEvent(loc.ID, eventType)
+	switch {
+	case event == nil && triggered:
+		m.StartEvent(eventType, loc.ID)
+	case event != nil && persists:
+		event.EndDay = m.Day + spec.Duration
+	}
+}
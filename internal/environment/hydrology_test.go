@@ -0,0 +1,89 @@
+package environment
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// setWeatherEverywhere forces the same weather in every climate zone
+func setWeatherEverywhere(ws *WeatherSystem, world *WorldMap, weatherType WeatherType) {
+	for _, id := range world.LocationIDs() {
+		ws.SetWeatherAt(world, world.Locations[id], weatherType)
+	}
+}
+
+func TestDrySpellBringsDroughtThatLingers(t *testing.T) {
+	world := DefaultWorldMap()
+	m := NewSeededManager(world, 1)
+	m.NextRoll = math.Inf(1) // Only water events
+	ws := NewSeededWeatherSystem(1)
+	base := m.Conditions("forest")
+
+	setWeatherEverywhere(ws, world, WeatherHeatwave)
+	for day := 0; day < 10; day++ {
+		m.Update(1.0)
+		m.UpdateWater(ws, 1.0)
+	}
+
+	cond := m.Conditions("forest")
+	if !cond.Has(EventDrought) || cond.Water >= base.Water || cond.Food >= base.Food {
+		t.Fatalf("Expected a drought to cut the forest's water and food, got %+v", cond)
+	}
+	if m.Conditions("beach").Has(EventDrought) {
+		t.Error("The ocean should not dry out")
+	}
+	started := false
+	for _, a := range m.DrainAnnouncements() {
+		if a.Event.Type == EventDrought && a.Event.Location == "forest" && a.Started {
+			started = true
+		}
+	}
+	if !started {
+		t.Error("Expected a drought announcement for the forest")
+	}
+
+	setWeatherEverywhere(ws, world, WeatherRain)
+	for day := 0; day < 3; day++ {
+		m.Update(1.0)
+		m.UpdateWater(ws, 1.0)
+	}
+	if !m.Conditions("forest").Has(EventDrought) {
+		t.Fatal("The drought should not lift as soon as the rain returns")
+	}
+	for day := 0; day < int(DroughtRecoveryDays)+1; day++ {
+		m.Update(1.0)
+		m.UpdateWater(ws, 1.0)
+	}
+	if got := m.Conditions("forest"); got.Has(EventDrought) || got.Water != base.Water {
+		t.Errorf("Expected the forest to recover from the drought, got %+v", got)
+	}
+}
+
+func TestSustainedHeavyRainFloodsLowBiomes(t *testing.T) {
+	world := DefaultWorldMap()
+	m := NewSeededManager(world, 1)
+	m.NextRoll = math.Inf(1)
+	ws := NewSeededWeatherSystem(1)
+
+	setWeatherEverywhere(ws, world, WeatherHeavyRain)
+	m.UpdateWater(ws, 0.5)
+	if m.Conditions("marsh").Has(EventFlood) {
+		t.Fatal("A few hours of rain should not flood the marsh")
+	}
+	for day := 0; day < 7; day++ {
+		m.Update(1.0)
+		m.UpdateWater(ws, 1.0)
+	}
+
+	if !m.Conditions("marsh").Has(EventFlood) {
+		t.Fatalf("Expected the marsh to flood, got moisture %.2f", m.Moisture["marsh"])
+	}
+	if m.Conditions(HomeLocation).Has(EventFlood) {
+		t.Error("Home is not low-lying and should not flood")
+	}
+	world.Discover("marsh")
+	if _, err := m.PlanTrip(HomeLocation, "marsh"); !errors.Is(err, ErrLocationClosed) {
+		t.Errorf("Expected the flooded marsh to be closed, got %v", err)
+	}
+}
@@ -0,0 +1,365 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Weather simulation settings
+const (
+	SeasonLengthDays      = 7.0  // Game days per season
+	WeatherChangeInterval = 0.25 // Game days between weather rolls
+)
+
+var (
+	// ErrUnknownWeather is returned when a weather name is not recognised
+	ErrUnknownWeather = errors.New("unknown weather")
+	// ErrUnknownSeason is returned when a season name is not recognised
+	ErrUnknownSeason = errors.New("unknown season")
+)
+
+// WeatherType represents the current weather condition
+type WeatherType int
+
+const (
+	WeatherClear WeatherType = iota
+	WeatherCloudy
+	WeatherRain
+	WeatherHeavyRain
+	WeatherStorm
+	WeatherSnow
+	WeatherHeatwave
+	WeatherFog
+)
+
+// String returns the string representation of WeatherType
+func (wt WeatherType) String() string {
+	return [...]string{
+		"Clear", "Cloudy", "Rain", "Heavy Rain", "Storm", "Snow", "Heatwave", "Fog",
+	}[wt]
+}
+
+// ParseWeatherType looks up a weather condition by name or tag, ignoring case
+func ParseWeatherType(name string) (WeatherType, error) {
+	for wt := WeatherClear; wt <= WeatherFog; wt++ {
+		if strings.EqualFold(wt.String(), name) || strings.EqualFold(wt.Tag(), name) {
+			return wt, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownWeather, name)
+}
+
+// ParseSeason looks up a season by name, ignoring case
+func ParseSeason(name string) (types.Season, error) {
+	for season := types.SeasonSpring; season <= types.SeasonWinter; season++ {
+		if strings.EqualFold(season.String(), name) {
+			return season, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownSeason, name)
+}
+
+// Tag returns the memory tag used for experiences of this weather
+func (wt WeatherType) Tag() string {
+	return [...]string{
+		"clear", "cloudy", "rain", "heavy-rain", "storm", "snow", "heatwave", "fog",
+	}[wt]
+}
+
+// Icon returns the single-character map overlay for this weather
+func (wt WeatherType) Icon() byte {
+	return "  ,;#*^="[wt]
+}
+
+// InBiome returns how this weather actually falls in a biome: rain freezes
+// to snow in the arctic and snow melts to rain in hot biomes
+func (wt WeatherType) InBiome(biome Biome) WeatherType {
+	climate := biome.Climate()
+	switch {
+	case climate.Cold >= 1.0 && (wt == WeatherRain || wt == WeatherHeavyRain):
+		return WeatherSnow
+	case climate.Heat >= 0.5 && wt == WeatherSnow:
+		return WeatherRain
+	default:
+		return wt
+	}
+}
+
+// IsWet returns true for weather that makes outdoor activity unpleasant
+func (wt WeatherType) IsWet() bool {
+	return wt == WeatherRain || wt == WeatherHeavyRain || wt == WeatherStorm
+}
+
+// Weather is a snapshot of the current conditions
+type Weather struct {
+	Type        WeatherType `json:"type"`
+	Temperature float64     `json:"temperature"` // Ambient temperature in °C
+	StartedAt   float64     `json:"started_at"`  // Game day the condition began
+}
+
+// seasonalWeather lists the relative likelihood of each weather type per season
+var seasonalWeather = map[types.Season]map[WeatherType]float64{
+	types.SeasonSpring: {WeatherClear: 3, WeatherCloudy: 3, WeatherRain: 3, WeatherHeavyRain: 1, WeatherStorm: 1, WeatherFog: 1},
+	types.SeasonSummer: {WeatherClear: 5, WeatherCloudy: 2, WeatherRain: 1, WeatherStorm: 2, WeatherHeatwave: 2},
+	types.SeasonAutumn: {WeatherClear: 2, WeatherCloudy: 3, WeatherRain: 3, WeatherHeavyRain: 2, WeatherStorm: 1, WeatherFog: 2},
+	types.SeasonWinter: {WeatherClear: 2, WeatherCloudy: 3, WeatherSnow: 4, WeatherFog: 1, WeatherStorm: 1},
+}
+
+// seasonalTemperature is the typical temperature for each season in °C
+var seasonalTemperature = map[types.Season]float64{
+	types.SeasonSpring: 14.0,
+	types.SeasonSummer: 26.0,
+	types.SeasonAutumn: 11.0,
+	types.SeasonWinter: -1.0,
+}
+
+// weatherTemperatureShift adjusts temperature for each weather type
+var weatherTemperatureShift = map[WeatherType]float64{
+	WeatherClear:     2.0,
+	WeatherRain:      -2.0,
+	WeatherHeavyRain: -3.0,
+	WeatherStorm:     -4.0,
+	WeatherSnow:      -5.0,
+	WeatherHeatwave:  12.0,
+	WeatherFog:       -1.0,
+}
+
+// SeasonForDay returns the season on a given game day
+func SeasonForDay(day float64) types.Season {
+	if day < 0 {
+		day = 0
+	}
+	return types.Season(int(day/SeasonLengthDays) % 4)
+}
+
+// WeatherEffects describes how the current weather should influence pets
+type WeatherEffects struct {
+	Weather       WeatherType
+	Season        types.Season
+	Biome         Biome
+	Temperature   float64
+	Hour          float64 // Time of day in hours (0 to 24)
+	Onset         bool    // The weather changed this tick
+	FirstSnow     bool    // This is the first snowfall of the winter
+	ShelterUrge   float64 // Desire to seek shelter (0.0 to 1.0)
+	Hyperactivity float64 // Urge to run around (0.0 to 1.0)
+	Lethargy      float64 // Reduction in activity from heat (0.0 to 1.0)
+	OutdoorPlay   float64 // Penalty on outdoor play (0.0 = none, 1.0 = refused)
+	Shelter       float64 // Quality of shelter around the pet (0.0 to 1.0)
+	HabitatShift  float64 // Temperature change from habitat items in °C
+}
+
+// WeatherSystem simulates changing weather through the seasons
+type WeatherSystem struct {
+	Current   Weather      `json:"current"`
+	Previous  WeatherType  `json:"previous"`
+	Season    types.Season `json:"season"`
+	Biome     Biome        `json:"biome"`
+	Day       float64      `json:"day"`       // Current game day
+	NextRoll  float64      `json:"next_roll"` // Game day of the next weather change
+	Forecast  WeatherType  `json:"forecast"`  // Weather expected at the next change
+	SnowSeen  bool         `json:"snow_seen"` // Whether it has snowed this winter
+	changed   bool
+	firstSnow bool
+	rng       *rand.Rand
+	seed      int64
+
+	clock      func() time.Time // Set when aligned to the real calendar
+	hemisphere Hemisphere
+
+	// Regions holds the weather of climate zones away from home, keyed by
+	// zone, started as pets visit them
+	Regions map[string]*RegionalWeather `json:"regions,omitempty"`
+}
+
+// NewWeatherSystem creates a weather system starting on a clear spring day
+func NewWeatherSystem() *WeatherSystem {
+	return NewSeededWeatherSystem(time.Now().UnixNano())
+}
+
+// NewSeededWeatherSystem creates a deterministic weather system for testing
+func NewSeededWeatherSystem(seed int64) *WeatherSystem {
+	return NewBiomeWeatherSystem(BiomeTemperate, seed)
+}
+
+// NewBiomeWeatherSystem creates a seeded weather system for a biome
+func NewBiomeWeatherSystem(biome Biome, seed int64) *WeatherSystem {
+	ws := &WeatherSystem{
+		Season:   types.SeasonSpring,
+		Biome:    biome,
+		NextRoll: WeatherChangeInterval,
+		rng:      rand.New(rand.NewSource(seed)),
+		seed:     seed,
+	}
+	ws.Current = Weather{Type: WeatherClear, Temperature: ws.temperatureFor(WeatherClear)}
+	ws.Previous = WeatherClear
+	ws.Forecast = ws.rollWeather()
+	return ws
+}
+
+// Update advances the weather by deltaTime game days
+func (ws *WeatherSystem) Update(deltaTime float64) {
+	ws.changed = false
+	ws.firstSnow = false
+	ws.Day += deltaTime
+
+	season := SeasonForDay(ws.Day)
+	if ws.clock != nil {
+		season = SeasonOn(ws.clock(), ws.hemisphere)
+	}
+	newSeason := season != ws.Season
+	if newSeason {
+		ws.Season = season
+		ws.SnowSeen = false
+	}
+	ws.updateRegions(newSeason)
+
+	for ws.Day >= ws.NextRoll {
+		ws.NextRoll += WeatherChangeInterval
+		ws.SetWeather(ws.Forecast)
+		ws.Forecast = ws.rollWeather()
+		ws.rollRegions()
+	}
+}
+
+// SetWeather forces a weather condition, e.g. for scripted events
+func (ws *WeatherSystem) SetWeather(weatherType WeatherType) {
+	if weatherType == ws.Current.Type {
+		return
+	}
+
+	ws.Previous = ws.Current.Type
+	ws.Current = Weather{
+		Type:        weatherType,
+		Temperature: ws.temperatureFor(weatherType),
+		StartedAt:   ws.Day,
+	}
+	ws.changed = true
+
+	ws.firstSnow = weatherType == WeatherSnow && !ws.SnowSeen
+	if ws.firstSnow {
+		ws.SnowSeen = true
+	}
+}
+
+// SetSeason jumps forward to the start of the next occurrence of a season,
+// e.g. for testing, and forecasts weather for it. Time never runs backwards.
+// Weather aligned to the real calendar keeps the season of the date.
+func (ws *WeatherSystem) SetSeason(season types.Season) {
+	if season == ws.Season || ws.clock != nil {
+		return
+	}
+
+	year := SeasonLengthDays * 4
+	start := math.Floor(ws.Day/year)*year + float64(season)*SeasonLengthDays
+	if start <= ws.Day {
+		start += year
+	}
+	ws.Day = start
+	ws.Season = season
+	ws.SnowSeen = false
+	ws.NextRoll = start + WeatherChangeInterval
+	ws.Forecast = ws.rollWeather()
+	ws.updateRegions(true)
+	for _, r := range ws.Regions {
+		r.Forecast = r.roll(ws)
+	}
+}
+
+// rollWeather picks the next weather from the seasonal table
+func (ws *WeatherSystem) rollWeather() WeatherType {
+	return pickWeather(seasonalWeather[ws.Season], ws.rng)
+}
+
+// pickWeather picks a weather type from a table of relative likelihoods
+func pickWeather(table map[WeatherType]float64, rng *rand.Rand) WeatherType {
+	total := 0.0
+	for wt := WeatherClear; wt <= WeatherFog; wt++ {
+		total += table[wt]
+	}
+
+	roll := rng.Float64() * total
+	for wt := WeatherClear; wt <= WeatherFog; wt++ {
+		roll -= table[wt]
+		if roll < 0 {
+			return wt
+		}
+	}
+	return WeatherClear
+}
+
+// temperatureFor returns a plausible temperature for a weather type this season
+func (ws *WeatherSystem) temperatureFor(weatherType WeatherType) float64 {
+	noise := 0.0
+	if ws.rng != nil {
+		noise = ws.rng.Float64()*4.0 - 2.0
+	}
+	return seasonalTemperature[ws.Season] + ws.Biome.Climate().TemperatureShift + weatherTemperatureShift[weatherType] + noise
+}
+
+// Effects returns how the current weather influences pet behavior
+func (ws *WeatherSystem) Effects() WeatherEffects {
+	effects := WeatherEffects{
+		Weather:     ws.Current.Type,
+		Season:      ws.Season,
+		Biome:       ws.Biome,
+		Temperature: ws.Current.Temperature,
+		Hour:        math.Mod(ws.Day, 1.0) * 24.0,
+		Onset:       ws.changed,
+		FirstSnow:   ws.firstSnow,
+	}
+	if ws.clock != nil {
+		effects.Hour = HourOf(ws.clock())
+	}
+
+	effects.applyModifiers()
+	return effects
+}
+
+// EffectsIn returns the current weather's effects at a location in another
+// biome, with the weather and temperature adjusted to its climate
+func (ws *WeatherSystem) EffectsIn(biome Biome) WeatherEffects {
+	effects := ws.Effects()
+	if biome == ws.Biome {
+		return effects
+	}
+
+	effects.Biome = biome
+	effects.Weather = ws.Current.Type.InBiome(biome)
+	effects.Temperature += biome.Climate().TemperatureShift - ws.Biome.Climate().TemperatureShift
+	effects.FirstSnow = effects.FirstSnow && effects.Weather == WeatherSnow
+	effects.ShelterUrge, effects.Hyperactivity, effects.Lethargy, effects.OutdoorPlay = 0, 0, 0, 0
+	effects.applyModifiers()
+	return effects
+}
+
+// applyModifiers sets the behavioral modifiers for the effects' weather
+func (e *WeatherEffects) applyModifiers() {
+	switch e.Weather {
+	case WeatherStorm:
+		e.ShelterUrge = 1.0
+		e.OutdoorPlay = 1.0
+	case WeatherHeavyRain:
+		e.ShelterUrge = 0.5
+		e.OutdoorPlay = 0.8
+	case WeatherRain:
+		e.ShelterUrge = 0.2
+		e.OutdoorPlay = 0.4
+	case WeatherSnow:
+		e.OutdoorPlay = 0.1
+		if e.FirstSnow {
+			e.Hyperactivity = 1.0
+		}
+	case WeatherHeatwave:
+		e.Lethargy = 0.7
+		e.OutdoorPlay = 0.5
+	case WeatherFog:
+		e.OutdoorPlay = 0.2
+	}
+}
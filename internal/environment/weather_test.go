@@ -0,0 +1,177 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestSeasonForDay(t *testing.T) {
+	cases := map[float64]types.Season{
+		0:                      types.SeasonSpring,
+		SeasonLengthDays:       types.SeasonSummer,
+		SeasonLengthDays*3 + 1: types.SeasonWinter,
+		SeasonLengthDays * 4:   types.SeasonSpring,
+	}
+	for day, expected := range cases {
+		if got := SeasonForDay(day); got != expected {
+			t.Errorf("Day %.1f: expected %s, got %s", day, expected, got)
+		}
+	}
+}
+
+func TestWeatherChangesDeterministically(t *testing.T) {
+	a := NewSeededWeatherSystem(5)
+	b := NewSeededWeatherSystem(5)
+
+	for i := 0; i < 40; i++ {
+		a.Update(0.1)
+		b.Update(0.1)
+		if a.Current != b.Current {
+			t.Fatal("Seeded weather systems should agree")
+		}
+	}
+}
+
+func TestSnowOnlyInWinterTable(t *testing.T) {
+	ws := NewSeededWeatherSystem(1)
+	for i := 0; i < 200; i++ {
+		if ws.rollWeather() == WeatherSnow {
+			t.Fatal("It should not snow in spring")
+		}
+	}
+}
+
+func TestFirstSnowEffects(t *testing.T) {
+	ws := NewSeededWeatherSystem(1)
+	ws.Season = types.SeasonWinter
+
+	ws.SetWeather(WeatherSnow)
+	effects := ws.Effects()
+	if !effects.Onset || !effects.FirstSnow || effects.Hyperactivity != 1.0 {
+		t.Errorf("First snow should cause zoomies, got %+v", effects)
+	}
+
+	ws.SetWeather(WeatherCloudy)
+	ws.SetWeather(WeatherSnow)
+	if ws.Effects().FirstSnow {
+		t.Error("Only the first snow of the winter should count")
+	}
+}
+
+func TestStormEffects(t *testing.T) {
+	ws := NewSeededWeatherSystem(1)
+	ws.SetWeather(WeatherStorm)
+
+	effects := ws.Effects()
+	if effects.ShelterUrge != 1.0 || effects.OutdoorPlay != 1.0 {
+		t.Errorf("Storms should send pets to shelter, got %+v", effects)
+	}
+}
+
+func TestBiomeShiftsTemperature(t *testing.T) {
+	temperate := NewBiomeWeatherSystem(BiomeTemperate, 3)
+	arctic := NewBiomeWeatherSystem(BiomeArctic, 3)
+
+	if arctic.Current.Temperature >= temperate.Current.Temperature {
+		t.Error("Arctic weather should be colder than temperate weather")
+	}
+	if arctic.Effects().Biome != BiomeArctic {
+		t.Error("Weather effects should report the biome")
+	}
+}
+
+func TestAcclimatization(t *testing.T) {
+	a := NewAcclimatization()
+
+	a.Update(BiomeDesert, AcclimatizationDays/2)
+	if level := a.Level(BiomeDesert); level < 0.49 || level > 0.51 {
+		t.Errorf("Expected half acclimatization, got %.2f", level)
+	}
+
+	a.Update(BiomeArctic, 5.0)
+	if a.Level(BiomeDesert) >= 0.5 {
+		t.Error("Acclimatization should fade after moving away")
+	}
+	if a.Level(BiomeArctic) <= 0 {
+		t.Error("Pet should start acclimatizing to its new biome")
+	}
+}
+
+func TestAcclimatizationWithoutLevels(t *testing.T) {
+	var a Acclimatization // As decoded from a save that predates it
+	a.Update(BiomeDesert, 1.0)
+	if a.Level(BiomeDesert) <= 0 {
+		t.Error("Expected a zero-value acclimatization to start acclimatizing")
+	}
+}
+
+func TestForecastBecomesWeather(t *testing.T) {
+	ws := NewSeededWeatherSystem(9)
+	forecast := ws.Forecast
+
+	ws.Update(WeatherChangeInterval)
+
+	if ws.Current.Type != forecast {
+		t.Errorf("Expected forecast %s to arrive, got %s", forecast, ws.Current.Type)
+	}
+	if WeatherRain.InBiome(BiomeArctic) != WeatherSnow {
+		t.Error("Rain should fall as snow in the arctic")
+	}
+}
+
+func TestEffectsInOtherBiome(t *testing.T) {
+	ws := NewSeededWeatherSystem(1)
+	ws.SetWeather(WeatherRain)
+
+	home := ws.Effects()
+	tundra := ws.EffectsIn(BiomeArctic)
+	if tundra.Weather != WeatherSnow || tundra.ShelterUrge != 0 {
+		t.Errorf("Rain should fall as snow in the arctic, got %+v", tundra)
+	}
+	if tundra.Temperature >= home.Temperature {
+		t.Error("The arctic should be colder than home")
+	}
+	if ws.EffectsIn(BiomeTemperate) != home {
+		t.Error("Effects in the system's own biome should be unchanged")
+	}
+}
+
+func TestParseWeatherTypeAndSeason(t *testing.T) {
+	if wt, err := ParseWeatherType("heavy-rain"); err != nil || wt != WeatherHeavyRain {
+		t.Errorf("Expected Heavy Rain from its tag, got %v (%v)", wt, err)
+	}
+	if wt, err := ParseWeatherType("STORM"); err != nil || wt != WeatherStorm {
+		t.Errorf("Expected Storm, got %v (%v)", wt, err)
+	}
+	if _, err := ParseWeatherType("meteors"); !errors.Is(err, ErrUnknownWeather) {
+		t.Errorf("Expected ErrUnknownWeather, got %v", err)
+	}
+	if season, err := ParseSeason("autumn"); err != nil || season != types.SeasonAutumn {
+		t.Errorf("Expected Autumn, got %v (%v)", season, err)
+	}
+	if _, err := ParseSeason("monsoon"); !errors.Is(err, ErrUnknownSeason) {
+		t.Errorf("Expected ErrUnknownSeason, got %v", err)
+	}
+}
+
+func TestSetSeasonJumpsForward(t *testing.T) {
+	ws := NewSeededWeatherSystem(3)
+	ws.Update(SeasonLengthDays*2 + 1) // Autumn
+	day := ws.Day
+
+	ws.SetSeason(types.SeasonSummer)
+	if ws.Season != types.SeasonSummer || SeasonForDay(ws.Day) != types.SeasonSummer {
+		t.Fatalf("Expected summer, got %s on day %.1f", ws.Season, ws.Day)
+	}
+	if ws.Day <= day {
+		t.Errorf("Expected time to move forward from day %.1f, got %.1f", day, ws.Day)
+	}
+
+	// The next update must not snap back to the old season
+	ws.Update(0.1)
+	if ws.Season != types.SeasonSummer {
+		t.Errorf("Expected the forced season to stick, got %s", ws.Season)
+	}
+}
@@ -0,0 +1,165 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrUnknownLocation is returned when a location ID is not on the map
+	ErrUnknownLocation = errors.New("unknown location")
+	// ErrUndiscovered is returned when travelling to a location not yet discovered
+	ErrUndiscovered = errors.New("location has not been discovered")
+)
+
+// World map settings
+const (
+	HomeLocation    = "home" // ID of the location every pet starts at
+	DiscoveryRadius = 7.0    // Map distance within which arriving reveals new locations
+	DangerThreshold = 0.4    // Danger at which a location is marked as dangerous
+	TravelSpeed     = 10.0   // Map distance covered per game day
+)
+
+// Location is a named place on the world map
+type Location struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Biome      Biome   `json:"biome"`
+	X          int     `json:"x"`                // Map column
+	Y          int     `json:"y"`                // Map row
+	Danger     float64 `json:"danger,omitempty"` // Risk to visiting pets (0.0 to 1.0)
+	Discovered bool    `json:"discovered"`
+}
+
+// IsDangerous returns true if the location warrants a danger marker
+func (l *Location) IsDangerous() bool {
+	return l.Danger >= DangerThreshold
+}
+
+// WorldMap is the grid of locations pets can live in and visit
+type WorldMap struct {
+	Width     int                  `json:"width"`
+	Height    int                  `json:"height"`
+	Locations map[string]*Location `json:"locations"`
+}
+
+// NewWorldMap creates an empty world map of the given size
+func NewWorldMap(width, height int) *WorldMap {
+	return &WorldMap{
+		Width:     width,
+		Height:    height,
+		Locations: make(map[string]*Location),
+	}
+}
+
+// DefaultWorldMap creates the standard hand-made world around the home
+func DefaultWorldMap() *WorldMap {
+	w := NewWorldMap(24, 10)
+	w.AddLocation(&Location{ID: "tundra", Name: "Frozen Tundra", Biome: BiomeArctic, X: 3, Y: 1, Danger: 0.6})
+	w.AddLocation(&Location{ID: "peak", Name: "Windy Peak", Biome: BiomeMountain, X: 12, Y: 1, Danger: 0.5})
+	w.AddLocation(&Location{ID: "forest", Name: "Old Forest", Biome: BiomeTemperate, X: 6, Y: 4, Danger: 0.2})
+	w.AddLocation(&Location{ID: HomeLocation, Name: "Home", Biome: BiomeTemperate, X: 12, Y: 5})
+	w.AddLocation(&Location{ID: "meadow", Name: "Sunny Meadow", Biome: BiomeTemperate, X: 17, Y: 4})
+	w.AddLocation(&Location{ID: "town", Name: "Market Town", Biome: BiomeUrban, X: 16, Y: 7})
+	w.AddLocation(&Location{ID: "beach", Name: "Pebble Beach", Biome: BiomeOcean, X: 22, Y: 6, Danger: 0.1})
+	w.AddLocation(&Location{ID: "marsh", Name: "Misty Marsh", Biome: BiomeSwamp, X: 4, Y: 8, Danger: 0.4})
+	w.AddLocation(&Location{ID: "jungle", Name: "Green Jungle", Biome: BiomeTropical, X: 11, Y: 8, Danger: 0.3})
+	w.AddLocation(&Location{ID: "dunes", Name: "Red Dunes", Biome: BiomeDesert, X: 19, Y: 9, Danger: 0.5})
+	w.Discover(HomeLocation)
+	return w
+}
+
+// AddLocation adds or replaces a location
+func (w *WorldMap) AddLocation(loc *Location) {
+	w.Locations[loc.ID] = loc
+}
+
+// Location looks up a location by ID
+func (w *WorldMap) Location(id string) (*Location, bool) {
+	loc, exists := w.Locations[id]
+	return loc, exists
+}
+
+// LocationIDs returns all location IDs in alphabetical order
+func (w *WorldMap) LocationIDs() []string {
+	ids := make([]string, 0, len(w.Locations))
+	for id := range w.Locations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Discover reveals a location and any others within DiscoveryRadius of it
+func (w *WorldMap) Discover(id string) {
+	origin, exists := w.Locations[id]
+	if !exists {
+		return
+	}
+	for _, loc := range w.Locations {
+		if distance(origin, loc) <= DiscoveryRadius {
+			loc.Discovered = true
+		}
+	}
+}
+
+// DiscoveredIDs returns the IDs of discovered locations in alphabetical order
+func (w *WorldMap) DiscoveredIDs() []string {
+	var ids []string
+	for _, id := range w.LocationIDs() {
+		if w.Locations[id].Discovered {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Distance returns the map distance between two locations
+func (w *WorldMap) Distance(from, to string) (float64, error) {
+	a, exists := w.Locations[from]
+	if !exists {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownLocation, from)
+	}
+	b, exists := w.Locations[to]
+	if !exists {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownLocation, to)
+	}
+	return distance(a, b), nil
+}
+
+// Trip describes a planned journey between two locations
+type Trip struct {
+	From     string
+	To       string
+	Distance float64 // Map distance
+	Days     float64 // Game days spent travelling
+	Danger   float64 // Danger at the destination
+}
+
+// PlanTrip plans a journey to a discovered location
+func (w *WorldMap) PlanTrip(from, to string) (Trip, error) {
+	dist, err := w.Distance(from, to)
+	if err != nil {
+		return Trip{}, err
+	}
+
+	dest := w.Locations[to]
+	if !dest.Discovered {
+		return Trip{}, fmt.Errorf("%w: %s", ErrUndiscovered, to)
+	}
+
+	return Trip{
+		From:     from,
+		To:       to,
+		Distance: dist,
+		Days:     dist / TravelSpeed,
+		Danger:   dest.Danger,
+	}, nil
+}
+
+// distance returns the straight-line distance between two locations
+func distance(a, b *Location) float64 {
+	return math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y))
+}
@@ -0,0 +1,30 @@
+package environment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDiscoveryAndTripPlanning(t *testing.T) {
+	w := DefaultWorldMap()
+
+	if _, err := w.PlanTrip(HomeLocation, "tundra"); !errors.Is(err, ErrUndiscovered) {
+		t.Errorf("Expected ErrUndiscovered, got %v", err)
+	}
+	if _, err := w.PlanTrip(HomeLocation, "atlantis"); !errors.Is(err, ErrUnknownLocation) {
+		t.Errorf("Expected ErrUnknownLocation, got %v", err)
+	}
+
+	trip, err := w.PlanTrip(HomeLocation, "peak")
+	if err != nil {
+		t.Fatalf("PlanTrip failed: %v", err)
+	}
+	if trip.Distance != 4.0 || trip.Days != 4.0/TravelSpeed {
+		t.Errorf("Unexpected trip %+v", trip)
+	}
+
+	w.Discover("forest")
+	if !w.Locations["marsh"].Discovered {
+		t.Error("Visiting the forest should reveal the nearby marsh")
+	}
+}
@@ -0,0 +1,232 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// ErrWorldTooCrowded is returned when the requested locations do not fit on the map
+var ErrWorldTooCrowded = errors.New("not enough room for the requested locations")
+
+// placementAttempts is how many random spots are tried per location
+const placementAttempts = 200
+
+// WorldGenConfig controls procedural world generation
+type WorldGenConfig struct {
+	Seed       int64
+	Width      int
+	Height     int
+	Locations  int     // Number of locations including home
+	MinSpacing float64 // Minimum map distance between locations
+	MaxSpacing float64 // Maximum distance from a new location to its nearest neighbor
+}
+
+// DefaultWorldGenConfig returns a medium-sized world configuration
+func DefaultWorldGenConfig(seed int64) WorldGenConfig {
+	return WorldGenConfig{
+		Seed:       seed,
+		Width:      32,
+		Height:     14,
+		Locations:  12,
+		MinSpacing: 3.0,
+		MaxSpacing: DiscoveryRadius,
+	}
+}
+
+// biomeNeighbors lists which biomes sit naturally next to each other.
+// Unlisted pairings are still possible but rare.
+var biomeNeighbors = map[Biome][]Biome{
+	BiomeTemperate: {BiomeTemperate, BiomeMountain, BiomeOcean, BiomeSwamp, BiomeTropical, BiomeUrban},
+	BiomeArctic:    {BiomeArctic, BiomeMountain, BiomeOcean},
+	BiomeDesert:    {BiomeDesert, BiomeTropical, BiomeMountain, BiomeTemperate},
+	BiomeTropical:  {BiomeTropical, BiomeSwamp, BiomeOcean, BiomeDesert},
+	BiomeOcean:     {BiomeOcean, BiomeTemperate, BiomeSwamp, BiomeTropical, BiomeArctic, BiomeUrban},
+	BiomeSwamp:     {BiomeSwamp, BiomeTemperate, BiomeTropical, BiomeOcean},
+	BiomeMountain:  {BiomeMountain, BiomeArctic, BiomeTemperate, BiomeDesert},
+	BiomeUrban:     {BiomeUrban, BiomeTemperate, BiomeOcean},
+}
+
+// biomeDanger is the typical danger of each biome
+var biomeDanger = map[Biome]float64{
+	BiomeTemperate: 0.1,
+	BiomeArctic:    0.5,
+	BiomeDesert:    0.45,
+	BiomeTropical:  0.3,
+	BiomeOcean:     0.15,
+	BiomeSwamp:     0.35,
+	BiomeMountain:  0.45,
+	BiomeUrban:     0.05,
+}
+
+// placeAdjectives and placeNouns are combined to name generated locations
+var (
+	placeAdjectives = map[Biome][]string{
+		BiomeTemperate: {"Sunny", "Quiet", "Old", "Green", "Gentle", "Hidden"},
+		BiomeArctic:    {"Frozen", "Icy", "Bitter", "White", "Silent"},
+		BiomeDesert:    {"Red", "Scorched", "Dusty", "Endless", "Golden"},
+		BiomeTropical:  {"Lush", "Steaming", "Tangled", "Emerald", "Humming"},
+		BiomeOcean:     {"Pebble", "Windy", "Salty", "Shell", "Foggy"},
+		BiomeSwamp:     {"Misty", "Murky", "Croaking", "Sunken", "Mossy"},
+		BiomeMountain:  {"Windy", "Jagged", "Lonely", "Grey", "High"},
+		BiomeUrban:     {"Busy", "Market", "Cobbled", "Lantern", "Riverside"},
+	}
+	placeNouns = map[Biome][]string{
+		BiomeTemperate: {"Meadow", "Forest", "Valley", "Glade", "Orchard", "Hollow"},
+		BiomeArctic:    {"Tundra", "Glacier", "Icefield", "Floe"},
+		BiomeDesert:    {"Dunes", "Mesa", "Flats", "Canyon"},
+		BiomeTropical:  {"Jungle", "Rainforest", "Grove", "Lagoon"},
+		BiomeOcean:     {"Beach", "Cove", "Shore", "Bay"},
+		BiomeSwamp:     {"Marsh", "Bog", "Fen", "Bayou"},
+		BiomeMountain:  {"Peak", "Ridge", "Pass", "Crag"},
+		BiomeUrban:     {"Town", "Village", "Harbor", "Square"},
+	}
+)
+
+// worldGenerator holds the state of a single generation run
+type worldGenerator struct {
+	cfg   WorldGenConfig
+	rng   *rand.Rand
+	world *WorldMap
+}
+
+// GenerateWorld creates a fresh seeded world. Home sits in a temperate
+// spot near the middle; every other location is placed within MaxSpacing
+// of an existing one, so the whole world can be discovered on foot.
+// Biomes follow latitude (cold north, hot south, oceans at the coasts)
+// and favor plausible neighbors.
+func GenerateWorld(cfg WorldGenConfig) (*WorldMap, error) {
+	if cfg.Locations < 1 || cfg.Width < 1 || cfg.Height < 1 {
+		return nil, fmt.Errorf("%w: %d locations on a %dx%d map", ErrWorldTooCrowded, cfg.Locations, cfg.Width, cfg.Height)
+	}
+
+	g := &worldGenerator{
+		cfg:   cfg,
+		rng:   rand.New(rand.NewSource(cfg.Seed)),
+		world: NewWorldMap(cfg.Width, cfg.Height),
+	}
+
+	g.world.AddLocation(&Location{ID: HomeLocation, Name: "Home", Biome: BiomeTemperate, X: cfg.Width / 2, Y: cfg.Height / 2})
+
+	for len(g.world.Locations) < cfg.Locations {
+		x, y, nearest, ok := g.findSpot()
+		if !ok {
+			return nil, fmt.Errorf("%w: placed %d of %d", ErrWorldTooCrowded, len(g.world.Locations), cfg.Locations)
+		}
+
+		biome := g.pickBiome(x, y, nearest.Biome)
+		name := g.uniqueName(biome)
+		g.world.AddLocation(&Location{
+			ID:     strings.ToLower(strings.ReplaceAll(name, " ", "-")),
+			Name:   name,
+			Biome:  biome,
+			X:      x,
+			Y:      y,
+			Danger: math.Round(clamp(biomeDanger[biome]+g.rng.Float64()*0.3-0.15, 0.0, 1.0)*100) / 100,
+		})
+	}
+
+	g.world.Discover(HomeLocation)
+	return g.world, nil
+}
+
+// findSpot picks a free grid position near an existing location and
+// returns that nearest location
+func (g *worldGenerator) findSpot() (int, int, *Location, bool) {
+	ids := g.world.LocationIDs()
+
+	for attempt := 0; attempt < placementAttempts; attempt++ {
+		anchor := g.world.Locations[ids[g.rng.Intn(len(ids))]]
+		angle := g.rng.Float64() * 2 * math.Pi
+		radius := g.cfg.MinSpacing + g.rng.Float64()*(g.cfg.MaxSpacing-g.cfg.MinSpacing)
+		candidate := &Location{
+			X: anchor.X + int(math.Round(radius*math.Cos(angle))),
+			Y: anchor.Y + int(math.Round(radius*math.Sin(angle))),
+		}
+
+		// Leave a column either side free for map overlays
+		if candidate.X < 1 || candidate.X > g.cfg.Width-2 || candidate.Y < 0 || candidate.Y >= g.cfg.Height {
+			continue
+		}
+
+		var nearest *Location
+		closest := math.Inf(1)
+		for _, id := range ids {
+			loc := g.world.Locations[id]
+			if d := distance(candidate, loc); d < closest {
+				nearest, closest = loc, d
+			}
+		}
+		if closest >= g.cfg.MinSpacing && closest <= g.cfg.MaxSpacing {
+			return candidate.X, candidate.Y, nearest, true
+		}
+	}
+	return 0, 0, nil, false
+}
+
+// pickBiome chooses a biome for a position from its latitude and how far
+// inland it is, favoring biomes that sit well next to its nearest neighbor
+func (g *worldGenerator) pickBiome(x, y int, neighbor Biome) Biome {
+	latitude := float64(y) / math.Max(float64(g.cfg.Height-1), 1) // 0 north to 1 south
+	coast := math.Min(float64(x), float64(g.cfg.Width-1-x)) / math.Max(float64(g.cfg.Width)/2, 1)
+
+	weights := map[Biome]float64{
+		BiomeTemperate: 3.0 * (1.0 - math.Abs(latitude-0.5)*2),
+		BiomeArctic:    3.0 * math.Max(0, 0.4-latitude) / 0.4,
+		BiomeMountain:  1.0 + 1.5*math.Max(0, 0.5-latitude),
+		BiomeDesert:    3.0 * math.Max(0, latitude-0.6) / 0.4,
+		BiomeTropical:  2.0 * math.Max(0, latitude-0.5) / 0.5,
+		BiomeSwamp:     1.0,
+		BiomeOcean:     3.0 * math.Max(0, 0.3-coast) / 0.3,
+		BiomeUrban:     0.5,
+	}
+
+	allowed := make(map[Biome]bool)
+	for _, b := range biomeNeighbors[neighbor] {
+		allowed[b] = true
+	}
+
+	total := 0.0
+	for _, biome := range AllBiomes() {
+		if !allowed[biome] {
+			weights[biome] *= 0.1
+		}
+		total += weights[biome]
+	}
+
+	roll := g.rng.Float64() * total
+	for _, biome := range AllBiomes() {
+		roll -= weights[biome]
+		if roll < 0 {
+			return biome
+		}
+	}
+	return BiomeTemperate
+}
+
+// uniqueName generates a location name not already used on the map
+func (g *worldGenerator) uniqueName(biome Biome) string {
+	adjectives, nouns := placeAdjectives[biome], placeNouns[biome]
+
+	used := make(map[string]bool, len(g.world.Locations))
+	for _, loc := range g.world.Locations {
+		used[loc.Name] = true
+	}
+
+	for attempt := 0; attempt < placementAttempts; attempt++ {
+		name := adjectives[g.rng.Intn(len(adjectives))] + " " + nouns[g.rng.Intn(len(nouns))]
+		if !used[name] {
+			return name
+		}
+	}
+
+	// Every combination is taken; number the duplicates
+	base := adjectives[0] + " " + nouns[0]
+	for n := 2; ; n++ {
+		if name := fmt.Sprintf("%s %d", base, n); !used[name] {
+			return name
+		}
+	}
+}
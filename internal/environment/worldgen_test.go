@@ -0,0 +1,64 @@
+package environment
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGenerateWorldIsSeeded(t *testing.T) {
+	a, err := GenerateWorld(DefaultWorldGenConfig(42))
+	if err != nil {
+		t.Fatalf("GenerateWorld failed: %v", err)
+	}
+	b, _ := GenerateWorld(DefaultWorldGenConfig(42))
+
+	if len(a.Locations) != 12 {
+		t.Fatalf("Expected 12 locations, got %d", len(a.Locations))
+	}
+	for id, loc := range a.Locations {
+		other, exists := b.Locations[id]
+		if !exists || *other != *loc {
+			t.Errorf("Same seed should generate the same world, %s differs", id)
+		}
+	}
+}
+
+func TestGenerateWorldSpacing(t *testing.T) {
+	cfg := DefaultWorldGenConfig(7)
+	w, err := GenerateWorld(cfg)
+	if err != nil {
+		t.Fatalf("GenerateWorld failed: %v", err)
+	}
+
+	if home, ok := w.Location(HomeLocation); !ok || home.Biome != BiomeTemperate {
+		t.Error("Generated worlds should have a temperate home")
+	}
+
+	for _, a := range w.Locations {
+		nearest := math.Inf(1)
+		for _, b := range w.Locations {
+			if a.ID == b.ID {
+				continue
+			}
+			d := distance(a, b)
+			if d < cfg.MinSpacing {
+				t.Errorf("%s and %s are too close (%.1f)", a.ID, b.ID, d)
+			}
+			nearest = math.Min(nearest, d)
+		}
+		if nearest > cfg.MaxSpacing {
+			t.Errorf("%s is unreachable (nearest %.1f)", a.ID, nearest)
+		}
+	}
+}
+
+func TestGenerateWorldTooCrowded(t *testing.T) {
+	cfg := DefaultWorldGenConfig(1)
+	cfg.Width, cfg.Height = 5, 3
+	cfg.Locations = 50
+
+	if _, err := GenerateWorld(cfg); !errors.Is(err, ErrWorldTooCrowded) {
+		t.Errorf("Expected ErrWorldTooCrowded, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// PurrThreshold is the contentment at which a pet starts purring
+const PurrThreshold = 0.75
+
+// ambience is what the loop last heard, to publish cues when it changes
+type ambience struct {
+	seen    bool
+	night   bool
+	weather environment.WeatherType
+	pets    map[types.PetID]petAmbience
+}
+
+// petAmbience is what the loop last heard from one pet
+type petAmbience struct {
+	purring bool
+	asleep  bool
+}
+
+// weatherCue returns the cue for the weather changing, if it makes a sound
+func weatherCue(before, now environment.WeatherType) (simulation.Cue, bool) {
+	wet := func(wt environment.WeatherType) bool {
+		return wt == environment.WeatherRain || wt == environment.WeatherHeavyRain || wt == environment.WeatherStorm
+	}
+	switch {
+	case now == before:
+		return "", false
+	case now == environment.WeatherStorm:
+		return simulation.CueThunder, true
+	case wet(now) && !wet(before):
+		return simulation.CueRainStart, true
+	case now == environment.WeatherSnow:
+		return simulation.CueSnowfall, true
+	case now == environment.WeatherFog:
+		return simulation.CueWind, true
+	case wet(before) && !wet(now):
+		return simulation.CueRainStop, true
+	}
+	return "", false
+}
+
+// publishAmbience publishes a cue for each change in the time of day, the
+// weather or a pet's contented and sleeping sounds since the last step.
+// The first step and pets seen for the first time are only recorded, so
+// loading a household does not replay the scene.
+func (g *GameLoop) publishAmbience() {
+	cue := func(c simulation.Cue, petID types.PetID, name string) {
+		details := map[string]interface{}{"cue": string(c)}
+		if name != "" {
+			details["name"] = name
+		}
+		g.Events.PublishAsync(simulation.Event{Type: simulation.EventAmbience, PetID: petID, Data: details})
+	}
+
+	night := g.Time.IsNighttime()
+	if g.ambience.seen && night != g.ambience.night {
+		if night {
+			cue(simulation.CueNightCrickets, "", "")
+		} else {
+			cue(simulation.CueDawnBirds, "", "")
+		}
+	}
+	g.ambience.night = night
+	if ws := g.Household.Weather; ws != nil {
+		if c, changed := weatherCue(g.ambience.weather, ws.Current.Type); changed && g.ambience.seen {
+			cue(c, "", "")
+		}
+		g.ambience.weather = ws.Current.Type
+	}
+	g.ambience.seen = true
+
+	for id := range g.ambience.pets {
+		if _, present := g.Household.Pets[id]; !present {
+			delete(g.ambience.pets, id)
+		}
+	}
+	for id, pet := range g.Household.Pets {
+		alive := pet.IsAlive()
+		now := petAmbience{
+			purring: alive && pet.Emotions.Contentment >= PurrThreshold,
+			asleep:  alive && pet.CurrentBehavior == types.BehaviorSleeping,
+		}
+		before, seen := g.ambience.pets[id]
+		g.ambience.pets[id] = now
+		if !seen {
+			continue
+		}
+		if now.purring && !before.purring {
+			cue(simulation.CuePurr, id, pet.Name)
+		}
+		if now.asleep && !before.asleep {
+			cue(simulation.CueSnore, id, pet.Name)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+func TestWeatherCue(t *testing.T) {
+	cases := []struct {
+		before, now environment.WeatherType
+		cue         simulation.Cue
+	}{
+		{environment.WeatherClear, environment.WeatherRain, simulation.CueRainStart},
+		{environment.WeatherRain, environment.WeatherStorm, simulation.CueThunder},
+		{environment.WeatherStorm, environment.WeatherCloudy, simulation.CueRainStop},
+		{environment.WeatherCloudy, environment.WeatherSnow, simulation.CueSnowfall},
+		{environment.WeatherClear, environment.WeatherFog, simulation.CueWind},
+		{environment.WeatherRain, environment.WeatherHeavyRain, ""},
+		{environment.WeatherClear, environment.WeatherCloudy, ""},
+	}
+	for _, c := range cases {
+		cue, ok := weatherCue(c.before, c.now)
+		if cue != c.cue || ok != (c.cue != "") {
+			t.Errorf("%s to %s: expected %q, got %q (%v)", c.before, c.now, c.cue, cue, ok)
+		}
+	}
+}
+
+func TestStepPublishesAmbience(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	household := interaction.NewHousehold(pet)
+	household.Weather = environment.NewSeededWeatherSystem(1)
+	loop, err := NewGameLoop(config.Default().Simulation, household, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	cues := make(map[simulation.Cue]simulation.Event)
+	loop.Events.Subscribe(string(simulation.EventAmbience), 0, func(e simulation.Event) {
+		mu.Lock()
+		cues[simulation.Cue(e.Data["cue"].(string))] = e
+		mu.Unlock()
+	})
+
+	loop.Do(func() {
+		household.Weather.SetWeather(environment.WeatherClear)
+		pet.Emotions.Contentment = 0.3
+	})
+	loop.Step(0.01)
+	loop.Do(func() {
+		household.Weather.SetWeather(environment.WeatherRain)
+		pet.Emotions.Contentment = 1.0
+	})
+	loop.Step(0.01)
+	if err := loop.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if _, ok := cues[simulation.CueRainStart]; !ok {
+		t.Errorf("Expected a rain_start cue, got %+v", cues)
+	}
+	if purr, ok := cues[simulation.CuePurr]; !ok || purr.PetID != pet.ID || purr.Data["name"] != "Mochi" {
+		t.Errorf("Expected Mochi to purr, got %+v", cues)
+	}
+}
@@ -0,0 +1,165 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// MaxTickFactor is the most a budget lengthens ticks by
+const MaxTickFactor = 16
+
+// Budget limits the CPU and memory the loop uses, for hosts with little
+// to spare, such as phones or apps that embed a pet. Zero fields are
+// unlimited.
+type Budget struct {
+	// MaxStepTime is the time one update may take. While updates take
+	// longer, Run ticks less often, each tick covering more game time.
+	MaxStepTime time.Duration
+	// MaxHistoryBytes is the saved size a pet's memories, relationships
+	// and personality history may reach. Pets over it have more of their
+	// history summarized on the next retention pass.
+	MaxHistoryBytes int
+}
+
+// Fidelity is what the loop has given up to stay within its budget
+type Fidelity struct {
+	TickFactor int                  `json:"tick_factor"`           // Ticks are this many times their configured length
+	LastStep   time.Duration        `json:"last_step"`             // Time the last update took
+	SlowSteps  int                  `json:"slow_steps"`            // Updates that took longer than MaxStepTime
+	Retention  core.RetentionPolicy `json:"retention"`             // Limits in force, tighter than the loop's Retention when history is over budget
+	Summarized core.RetentionReport `json:"summarized"`            // History summarized or dropped under the tightened limits
+	OverBudget []types.PetID        `json:"over_budget,omitempty"` // Pets still over MaxHistoryBytes at the tightest retention
+}
+
+// Reduced returns true if anything was given up
+func (f Fidelity) Reduced() bool {
+	return f.TickFactor > 1 || f.Summarized != (core.RetentionReport{}) || len(f.OverBudget) > 0
+}
+
+// Sacrificed describes what was given up, one line each
+func (f Fidelity) Sacrificed() []string {
+	var lines []string
+	if f.TickFactor > 1 {
+		lines = append(lines, fmt.Sprintf("ticks are %dx coarser after %d slow updates (last took %s)",
+			f.TickFactor, f.SlowSteps, f.LastStep.Round(time.Microsecond)))
+	}
+	if f.Summarized != (core.RetentionReport{}) {
+		lines = append(lines, fmt.Sprintf("%d memories and %d shared experiences summarized and %d personality snapshots dropped; "+
+			"keeping %d memories, %d experiences per relationship and %d snapshots",
+			f.Summarized.MemoriesSummarized, f.Summarized.ExperiencesSummarized, f.Summarized.SnapshotsDropped,
+			f.Retention.MaxLongTermMemories, f.Retention.MaxSharedExperiences, f.Retention.MaxTraitSnapshots))
+	}
+	if len(f.OverBudget) > 0 {
+		lines = append(lines, fmt.Sprintf("%d pets are over the history budget even at the tightest retention", len(f.OverBudget)))
+	}
+	return lines
+}
+
+// budgetState is how far the loop has adapted to its budget
+type budgetState struct {
+	budget     Budget
+	tickFactor int
+	tightening int // Times the retention limits have been halved
+	lastStep   time.Duration
+	slowSteps  int
+	summarized core.RetentionReport
+	overBudget []types.PetID
+}
+
+// SetBudget limits the resources the loop uses and starts adapting to the
+// new budget from full fidelity. History already summarized stays so.
+func (g *GameLoop) SetBudget(b Budget) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.budget = budgetState{budget: b}
+}
+
+// Budget returns the loop's resource budget
+func (g *GameLoop) Budget() Budget {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.budget.budget
+}
+
+// Fidelity reports what the loop has given up to stay within its budget
+func (g *GameLoop) Fidelity() Fidelity {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.fidelity()
+}
+
+// fidelity implements Fidelity (must be called with lock held)
+func (g *GameLoop) fidelity() Fidelity {
+	return Fidelity{
+		TickFactor: max(g.budget.tickFactor, 1),
+		LastStep:   g.budget.lastStep,
+		SlowSteps:  g.budget.slowSteps,
+		Retention:  g.retention(),
+		Summarized: g.budget.summarized,
+		OverBudget: append([]types.PetID(nil), g.budget.overBudget...),
+	}
+}
+
+// retention returns the retention limits in force (must be called with
+// lock held)
+func (g *GameLoop) retention() core.RetentionPolicy {
+	return g.Retention.Tighten(g.budget.tightening)
+}
+
+// timeStep notes how long an update took, doubling the tick length while
+// updates are over budget and halving it again once they take less than
+// half of it (must be called with lock held)
+func (g *GameLoop) timeStep(d time.Duration) {
+	limit := g.budget.budget.MaxStepTime
+	g.budget.lastStep = d
+	if limit <= 0 {
+		return
+	}
+	factor := max(g.budget.tickFactor, 1)
+	switch {
+	case d > limit:
+		g.budget.slowSteps++
+		factor = min(factor*2, MaxTickFactor)
+	case d < limit/2 && factor > 1:
+		factor /= 2
+	}
+	g.budget.tickFactor = factor
+}
+
+// fitHistory summarizes more of each pet's history while it is over
+// MaxHistoryBytes, tightening the retention limits for every pet until
+// they reach their floor (must be called with lock held)
+func (g *GameLoop) fitHistory() {
+	limit := g.budget.budget.MaxHistoryBytes
+	if limit <= 0 {
+		return
+	}
+	g.budget.overBudget = nil
+	for _, id := range sortedIDs(g.Household.Pets) {
+		pet := g.Household.Pets[id]
+		for pet.MemoryUsage().HistoryBytes() > limit {
+			tighter := g.Retention.Tighten(g.budget.tightening + 1)
+			if tighter == g.retention() {
+				g.budget.overBudget = append(g.budget.overBudget, id)
+				break
+			}
+			g.budget.tightening++
+			g.budget.summarized.Add(pet.ApplyRetention(tighter))
+		}
+	}
+}
+
+// sortedIDs returns the pets' IDs in order, so pets are fitted to the
+// budget in the same order on every run
+func sortedIDs(pets map[types.PetID]*core.DigitalPet) []types.PetID {
+	ids := make([]types.PetID, 0, len(pets))
+	for id := range pets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
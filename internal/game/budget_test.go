@@ -0,0 +1,102 @@
+package game
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// newBudgetLoop creates a loop over one pet that never idles
+func newBudgetLoop(t *testing.T, pet *core.DigitalPet) *GameLoop {
+	t.Helper()
+	cfg := config.Default().Simulation
+	cfg.IdleInterval = 0
+	loop, err := NewGameLoop(cfg, interaction.NewHousehold(pet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { loop.Shutdown(context.Background()) })
+	return loop
+}
+
+func TestBudgetCoarsensTicks(t *testing.T) {
+	loop := newBudgetLoop(t, core.NewDigitalPet("Mochi", "owner"))
+	tick := time.Second / 60
+	if f := loop.Fidelity(); f.TickFactor != 1 || f.Reduced() || loop.Stats().Fidelity != nil {
+		t.Fatalf("Expected full fidelity without a budget, got %+v", f)
+	}
+
+	loop.SetBudget(Budget{MaxStepTime: 10 * time.Millisecond})
+	loop.Do(func() {
+		for i := 0; i < 6; i++ {
+			loop.timeStep(20 * time.Millisecond)
+		}
+	})
+	f := loop.Fidelity()
+	if f.TickFactor != MaxTickFactor || f.SlowSteps != 6 || !f.Reduced() {
+		t.Errorf("Expected ticks %dx coarser after slow updates, got %+v", MaxTickFactor, f)
+	}
+	if got := loop.nextTick(tick, 0); got != tick*MaxTickFactor {
+		t.Errorf("Expected a %s tick, got %s", tick*MaxTickFactor, got)
+	}
+	if lines := f.Sacrificed(); len(lines) != 1 || !strings.Contains(lines[0], "16x coarser") {
+		t.Errorf("Expected the coarser ticks described, got %q", lines)
+	}
+	if stats := loop.Stats(); stats.Fidelity == nil || stats.Fidelity.TickFactor != MaxTickFactor {
+		t.Errorf("Expected the fidelity in the stats, got %+v", stats.Fidelity)
+	}
+
+	// Ticks return to their length once updates are fast again, but an
+	// update near the limit holds them where they are
+	loop.Do(func() {
+		loop.timeStep(9 * time.Millisecond)
+		loop.timeStep(time.Millisecond)
+	})
+	if f := loop.Fidelity(); f.TickFactor != MaxTickFactor/2 {
+		t.Errorf("Expected ticks %dx coarser, got %d", MaxTickFactor/2, f.TickFactor)
+	}
+
+	loop.SetBudget(Budget{})
+	loop.Step(0.01)
+	if f := loop.Fidelity(); f.TickFactor != 1 || f.LastStep <= 0 {
+		t.Errorf("Expected full fidelity with the budget lifted and the step timed, got %+v", f)
+	}
+}
+
+func TestBudgetSummarizesHistory(t *testing.T) {
+	pet := core.NewDigitalPet("Elder", "owner")
+	for i := 0; i < 300; i++ {
+		pet.Memory.Remember(&ai.Memory{Type: ai.MemoryEvent, Description: "A long afternoon nap in the sun", Strength: 0.8})
+		pet.Memory.ConsolidateMemories()
+	}
+	loop := newBudgetLoop(t, pet)
+	loop.Retention.MaxLongTermMemories = 1000 // Only the budget summarizes
+	before := pet.MemoryUsage().HistoryBytes()
+
+	loop.SetBudget(Budget{MaxHistoryBytes: before / 3})
+	loop.Step(RetentionInterval)
+	f := loop.Fidelity()
+	if after := pet.MemoryUsage().HistoryBytes(); after > before/3 {
+		t.Errorf("Expected the history within %d bytes, got %d", before/3, after)
+	}
+	if f.Summarized.MemoriesSummarized == 0 || f.Retention.MaxLongTermMemories >= 1000 || len(f.OverBudget) != 0 {
+		t.Errorf("Expected memories summarized under tighter retention, got %+v", f)
+	}
+	if lines := f.Sacrificed(); len(lines) != 1 || !strings.Contains(lines[0], "memories") {
+		t.Errorf("Expected the summarizing described, got %q", lines)
+	}
+
+	// A budget no retention can meet is reported rather than chased
+	loop.SetBudget(Budget{MaxHistoryBytes: 1})
+	loop.Step(RetentionInterval)
+	f = loop.Fidelity()
+	if len(f.OverBudget) != 1 || f.OverBudget[0] != pet.ID || f.Retention.MaxLongTermMemories != core.MinLongTermMemories {
+		t.Errorf("Expected the pet over budget at the tightest retention, got %+v", f)
+	}
+}
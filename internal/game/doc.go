@@ -0,0 +1,11 @@
+// Package game runs the Gochi simulation loop.
+//
+// This package provides:
+//   - The game loop that advances time for the household and its pets
+//   - Periodic auto-saves through the background save queue
+//   - A coordinated shutdown that stops ticking, flushes queued saves and
+//     writes a final validated save
+//
+// The game loop ties together the simulation, interaction and data layers
+// so the application entry point only has to start and stop it.
+package game
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Statistics history settings
+const (
+	HistoryInterval = time.Minute // Span of one history entry
+	HistoryLength   = 60          // Entries kept; the oldest is dropped first
+	AverageWindow   = 5           // Entries averaged for the moving averages in Stats
+)
+
+// MinuteStats aggregates what the loop did over one HistoryInterval
+type MinuteStats struct {
+	Start        time.Time `json:"start"`
+	Frames       int       `json:"frames"` // Updates run
+	FPS          float64   `json:"fps"`
+	FrameP50     float64   `json:"frame_p50_ms"` // Median time spent in an update
+	FrameP95     float64   `json:"frame_p95_ms"`
+	FrameMax     float64   `json:"frame_max_ms"`
+	Saves        int       `json:"saves"`        // Auto-saves queued
+	Events       int       `json:"events"`       // Events published
+	Interactions int       `json:"interactions"` // User interactions with any pet
+}
+
+// history keeps per-interval aggregates in a ring, plus the interval
+// being recorded
+type history struct {
+	entries []MinuteStats
+	next    int // Ring position the next finished entry is written to
+
+	current      MinuteStats
+	frames       []time.Duration // Frame times of the current interval
+	events       int             // Events published when last recorded
+	interactions int             // Interactions counted when last recorded
+}
+
+// record adds a frame that started at now and took d (must be called
+// with lock held). events and interactions are running totals; the
+// first frame only notes them, so earlier activity is not counted.
+func (h *history) record(now time.Time, d time.Duration, events, interactions int) {
+	first := h.current.Start.IsZero()
+	h.roll(now)
+	h.frames = append(h.frames, d)
+	if first {
+		h.events, h.interactions = events, interactions
+		return
+	}
+	if events > h.events {
+		h.current.Events += events - h.events
+	}
+	if interactions > h.interactions {
+		h.current.Interactions += interactions - h.interactions
+	}
+	h.events, h.interactions = events, interactions
+}
+
+// save counts an auto-save made at now (must be called with lock held)
+func (h *history) save(now time.Time) {
+	h.roll(now)
+	h.current.Saves++
+}
+
+// roll finishes the current entry once its interval is over and starts
+// the one now falls in
+func (h *history) roll(now time.Time) {
+	start := now.Truncate(HistoryInterval)
+	if h.current.Start.Equal(start) {
+		return
+	}
+	if !h.current.Start.IsZero() {
+		entry := h.summarize()
+		if len(h.entries) < HistoryLength {
+			h.entries = append(h.entries, entry)
+		} else {
+			h.entries[h.next] = entry
+		}
+		h.next = (h.next + 1) % HistoryLength
+	}
+	h.current = MinuteStats{Start: start}
+	h.frames = h.frames[:0]
+}
+
+// summarize returns the current entry with its frame statistics filled in
+func (h *history) summarize() MinuteStats {
+	entry := h.current
+	entry.Frames = len(h.frames)
+	if entry.Frames == 0 {
+		return entry
+	}
+	sorted := append([]time.Duration(nil), h.frames...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	entry.FPS = float64(entry.Frames) / HistoryInterval.Seconds()
+	entry.FrameP50 = milliseconds(percentile(sorted, 0.50))
+	entry.FrameP95 = milliseconds(percentile(sorted, 0.95))
+	entry.FrameMax = milliseconds(sorted[len(sorted)-1])
+	return entry
+}
+
+// list returns the finished entries, oldest first
+func (h *history) list() []MinuteStats {
+	if len(h.entries) < HistoryLength {
+		return append([]MinuteStats(nil), h.entries...)
+	}
+	return append(append([]MinuteStats(nil), h.entries[h.next:]...), h.entries[:h.next]...)
+}
+
+// percentile returns the nearest-rank percentile p of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// History returns the loop's per-minute statistics for up to the last
+// HistoryLength minutes, oldest first. The minute in progress is left
+// out until it is over.
+func (g *GameLoop) History() []MinuteStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.history.roll(time.Now())
+	return g.history.list()
+}
+
+// interactionCount returns the user interactions the household's pets
+// have had in total (must be called with lock held)
+func (g *GameLoop) interactionCount() int {
+	total := 0
+	for _, pet := range g.Household.Pets {
+		total += pet.TotalInteractions
+	}
+	return total
+}
+
+// averages returns the mean FPS and p95 frame time over the last
+// AverageWindow finished minutes (must be called with lock held)
+func (g *GameLoop) averages() (fps, frameP95 float64) {
+	entries := g.history.list()
+	if len(entries) > AverageWindow {
+		entries = entries[len(entries)-AverageWindow:]
+	}
+	if len(entries) == 0 {
+		return 0, 0
+	}
+	for _, entry := range entries {
+		fps += entry.FPS
+		frameP95 += entry.FrameP95
+	}
+	return fps / float64(len(entries)), frameP95 / float64(len(entries))
+}
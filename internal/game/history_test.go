@@ -0,0 +1,88 @@
+package game
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestHistoryAggregatesMinutes(t *testing.T) {
+	var h history
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	h.record(start, time.Millisecond, 10, 3) // Earlier activity is only noted
+	for i := 2; i <= 20; i++ {
+		h.record(start.Add(time.Duration(i)*time.Second), time.Duration(i)*time.Millisecond, 10+i, 3+i/10)
+	}
+	h.save(start.Add(30 * time.Second))
+	h.record(start.Add(HistoryInterval), time.Millisecond, 40, 5)
+
+	entries := h.list()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one finished minute, got %d", len(entries))
+	}
+	got := entries[0]
+	if got.Frames != 20 || got.Saves != 1 || got.Events != 20 || got.Interactions != 2 {
+		t.Errorf("Unexpected counts: %+v", got)
+	}
+	if got.FrameP50 != 10 || got.FrameP95 != 19 || got.FrameMax != 20 || got.FPS != 20.0/60.0 {
+		t.Errorf("Unexpected frame statistics: %+v", got)
+	}
+}
+
+func TestHistoryKeepsLastHour(t *testing.T) {
+	var h history
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i <= HistoryLength+5; i++ {
+		h.record(start.Add(time.Duration(i)*HistoryInterval), time.Millisecond, 0, 0)
+	}
+
+	entries := h.list()
+	if len(entries) != HistoryLength {
+		t.Fatalf("Expected %d entries, got %d", HistoryLength, len(entries))
+	}
+	if first := start.Add(5 * HistoryInterval); !entries[0].Start.Equal(first) {
+		t.Errorf("Expected the oldest entry from %s, got %s", first, entries[0].Start)
+	}
+	for i := 1; i < len(entries); i++ {
+		if !entries[i].Start.After(entries[i-1].Start) {
+			t.Fatalf("Entries out of order at %d", i)
+		}
+	}
+}
+
+func TestStatsMovingAverages(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	household := interaction.NewHousehold(pet)
+	loop, err := NewGameLoop(config.Default().Simulation, household, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loop.Shutdown(context.Background())
+
+	loop.Update()
+	loop.Do(func() {
+		household.Interact(pet.ID, types.InteractionPetting, 0.5)
+		// Pretend a minute of frames has passed
+		next := loop.history.current.Start.Add(HistoryInterval)
+		loop.history.record(next, 4*time.Millisecond, loop.Events.Published(), loop.interactionCount())
+		loop.history.record(next.Add(HistoryInterval), time.Millisecond, loop.Events.Published(), loop.interactionCount())
+	})
+
+	interactions := 0
+	for _, minute := range loop.History() {
+		interactions += minute.Interactions
+	}
+	if interactions != 1 {
+		t.Fatalf("Expected the petting counted once, got %d", interactions)
+	}
+	stats := loop.Stats()
+	if stats.AverageFPS <= 0 || stats.AverageP95 <= 0 {
+		t.Errorf("Expected moving averages from the history, got %+v", stats)
+	}
+}
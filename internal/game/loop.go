@@ -0,0 +1,650 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/script"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Game loop settings
+const (
+	SaveQueueSize     = 32               // Saves that can wait for the background writer
+	ShutdownTimeout   = 30 * time.Second // Limit on the final save at shutdown
+	SecondsPerDay     = 86400.0          // Game seconds in a game day
+	RetentionInterval = 1.0              // Game days between history compaction passes
+	IdleMargin        = 0.1              // Distance from a critical level that keeps the loop awake
+)
+
+// Actors the loop records in the audit log
+const (
+	ActorAutoSave = "autosave" // Periodic saves
+	ActorShutdown = "shutdown" // The final save
+	ActorScript   = "script"   // Reaction rules
+	ActorCrowd    = "crowd"    // Care voted for by viewers
+)
+
+var (
+	// ErrNoStorage is returned when an operation needs saves but the loop has none
+	ErrNoStorage = errors.New("game loop has no storage")
+	// ErrClockAligned is returned when changing time that follows the wall clock
+	ErrClockAligned = errors.New("game time is aligned to the real-world clock")
+)
+
+// GameLoop advances the household through simulated time and keeps its
+// pets saved. All access to the household while the loop is running must
+// go through Do.
+type GameLoop struct {
+	mu sync.Mutex
+
+	Config    config.GameLoopConfig
+	Time      *simulation.TimeManager
+	Household *interaction.Household
+	Data      *data.DataManager // Optional; nil disables saving
+	Saves     *data.SaveQueue
+	Retention core.RetentionPolicy
+	// Offsite uploads each backup to cloud storage. Optional; nil keeps
+	// backups local.
+	Offsite *data.CloudBackups
+	// Events receives game loop events. Handlers of async events run
+	// outside the loop and must use Do to touch the household.
+	Events *simulation.EventSystem
+	// Scripts runs player reaction rules after every tick. Optional; nil
+	// disables scripting.
+	Scripts *script.Engine
+	// Crowd applies the care viewers vote for. Optional; nil disables
+	// crowd control.
+	Crowd *interaction.CrowdControl
+	// Logger receives recovered panics with their stacks. Optional; nil
+	// uses the standard logger.
+	Logger *log.Logger
+
+	lastSave       time.Time
+	lastActivity   time.Time
+	idle           bool
+	wake           chan struct{}
+	sinceRetention float64
+	conditions     map[types.PetID]petCondition
+	ambience       ambience
+	faults         map[string]*Fault
+	history        history
+
+	budget budgetState
+	due    atomic.Int64 // Unix nanoseconds by which the next tick should start
+}
+
+// petCondition is what the loop last saw of a pet, to publish changes
+type petCondition struct {
+	dead     bool
+	critical bool
+}
+
+// NewGameLoop creates a loop for a household. If dm is not nil pets are
+// auto-saved in the background and saved once more on shutdown.
+func NewGameLoop(cfg config.GameLoopConfig, household *interaction.Household, dm *data.DataManager) (*GameLoop, error) {
+	scale, err := cfg.Scale()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.TickRate < 1 {
+		return nil, fmt.Errorf("%w: tick rate %d", config.ErrInvalidConfig, cfg.TickRate)
+	}
+
+	g := &GameLoop{
+		Config:    cfg,
+		Time:      simulation.NewTimeManager(scale),
+		Household: household,
+		Data:      dm,
+		Retention: core.DefaultRetentionPolicy(),
+		Events:    simulation.NewEventSystem(simulation.DefaultEventQueueSize),
+		lastSave:  time.Now(),
+
+		lastActivity: time.Now(),
+		wake:         make(chan struct{}, 1),
+		conditions:   make(map[types.PetID]petCondition),
+		ambience:     ambience{pets: make(map[types.PetID]petAmbience)},
+		faults:       make(map[string]*Fault),
+	}
+	if dm != nil {
+		g.Saves = data.NewSaveQueue(dm, SaveQueueSize)
+		dm.Hooks.Add(g.publishLifecycle)
+	}
+	return g, nil
+}
+
+// publishLifecycle publishes a finished data operation as a data event, so
+// notifications and monitoring see saves, backups and syncs alongside pet
+// events
+func (g *GameLoop) publishLifecycle(e data.LifecycleEvent) {
+	details := map[string]interface{}{"pets": e.Pets, "summary": e.Summary}
+	if e.Path != "" {
+		details["path"] = e.Path
+	}
+	if e.Type == data.LifecycleBackup && !e.Failed() {
+		details["size"] = e.Size
+	}
+	if e.Type == data.LifecycleSync {
+		details["conflicts"] = e.Conflicts
+	}
+	if e.Failed() {
+		details["error"] = e.Error
+	}
+	g.Events.PublishAsync(simulation.Event{Type: simulation.EventType(e.Type), Data: details})
+}
+
+// Do runs fn while no update is in progress
+func (g *GameLoop) Do(fn func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	fn()
+}
+
+// Wake records player input and makes an idle loop tick at once and return
+// to the full tick rate
+func (g *GameLoop) Wake() {
+	g.mu.Lock()
+	g.lastActivity = time.Now()
+	g.mu.Unlock()
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Update advances the simulation by the time elapsed since the last update
+func (g *GameLoop) Update() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	start := time.Now()
+	g.step(g.Time.Update() / SecondsPerDay)
+	g.history.record(start, time.Since(start), g.Events.Published(), g.interactionCount())
+}
+
+// AlignToClock makes game time follow the wall clock in loc, so pets sleep
+// when the player does, and the weather follow the hemisphere's seasons.
+// Each pet is first caught up on the real time since it was last updated,
+// up to catchUp. Returns the longest catch-up in game days.
+func (g *GameLoop) AlignToClock(loc *time.Location, hemisphere environment.Hemisphere, catchUp time.Duration) float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.Time.AlignToClock(loc)
+	if g.Household.Weather != nil {
+		g.Household.Weather.AlignTo(func() time.Time { return time.Now().In(loc) }, hemisphere)
+	}
+
+	longest := 0.0
+	now := time.Now().In(loc)
+	for _, pet := range g.Household.Pets {
+		if days := pet.CatchUp(now, catchUp); days > longest {
+			longest = days
+		}
+	}
+	return longest
+}
+
+// Step advances the simulation by a number of game days
+func (g *GameLoop) Step(days float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.step(days)
+}
+
+// step implements Step (must be called with lock held). Each pet and
+// each subsystem runs behind its own panic boundary, so a panic sets that
+// part aside for a while instead of stopping the game.
+func (g *GameLoop) step(days float64) {
+	if days <= 0 {
+		return
+	}
+	start := time.Now()
+	defer func() { g.timeStep(time.Since(start)) }()
+	night := g.Time.IsAligned() && g.Time.IsNighttime()
+	for _, pet := range g.pets() {
+		g.tick(PetSubsystem(pet.ID), func() {
+			pet.Update(days)
+			if g.Time.IsAligned() {
+				pet.KeepHours(night, days)
+			}
+		})
+	}
+	g.tick(SubsystemHousehold, func() { g.Household.Update(days) })
+	if g.Scripts != nil {
+		g.tick(SubsystemScripts, g.runScripts)
+	}
+	if g.Crowd != nil {
+		g.tick(SubsystemCrowd, g.resolveCrowd)
+	}
+
+	// Long-lived pets would otherwise accumulate history without bound
+	g.sinceRetention += days
+	if g.sinceRetention >= RetentionInterval {
+		g.sinceRetention = 0
+		g.tick(SubsystemRetention, func() {
+			for _, pet := range g.Household.Pets {
+				report := pet.ApplyRetention(g.retention())
+				if g.budget.tightening > 0 {
+					g.budget.summarized.Add(report)
+				}
+			}
+			g.fitHistory()
+		})
+	}
+
+	g.tick(SubsystemConditions, g.publishConditions)
+	g.tick(SubsystemAmbience, g.publishAmbience)
+
+	// Dropped ticks are counted by the event system; the loop never waits
+	g.Events.PublishAsync(simulation.Event{Type: simulation.EventTick, Data: map[string]interface{}{"days": days}})
+}
+
+// runScripts runs the reaction rules and records what they did (must be
+// called with lock held)
+func (g *GameLoop) runScripts() {
+	for _, reaction := range g.Scripts.Run(g.Household) {
+		details := map[string]interface{}{"rule": reaction.Rule.Source}
+		summary := reaction.Rule.Source
+		if reaction.Err != nil {
+			details["error"] = reaction.Err
+			summary = fmt.Sprintf("%s failed: %v", summary, reaction.Err)
+		}
+		g.Audit(ActorScript, data.AuditRule, reaction.PetID, summary)
+		g.Events.PublishAsync(simulation.Event{Type: simulation.EventScript, PetID: reaction.PetID, Data: details})
+	}
+}
+
+// resolveCrowd applies the care viewers voted for (must be called with
+// lock held)
+func (g *GameLoop) resolveCrowd() {
+	result, ok := g.Crowd.Resolve(g.Household, time.Now())
+	if !ok {
+		return
+	}
+	details := map[string]interface{}{
+		"name": result.Name, "action": result.Action, "votes": result.Votes,
+		"total": result.Total, "applied": result.Applied,
+	}
+	if result.Reason != "" {
+		details["reason"] = result.Reason
+	} else {
+		g.Audit(ActorCrowd, data.AuditInteract, result.PetID,
+			fmt.Sprintf("%s for %s (%d of %d votes)", result.Action, result.Name, result.Votes, result.Total))
+	}
+	g.Events.PublishAsync(simulation.Event{Type: simulation.EventPetCrowd, PetID: result.PetID, Data: details})
+}
+
+// publishConditions announces pets that died or fell into critical
+// condition since the last step. Pets seen for the first time are only
+// recorded, so loading a household does not repeat old news.
+func (g *GameLoop) publishConditions() {
+	for id := range g.conditions {
+		if _, present := g.Household.Pets[id]; !present {
+			delete(g.conditions, id)
+		}
+	}
+	for id, pet := range g.Household.Pets {
+		alive := pet.IsAlive()
+		var needs []string
+		if alive {
+			needs = pet.CriticalNeeds()
+		}
+		now := petCondition{dead: !alive, critical: len(needs) > 0}
+		before, seen := g.conditions[id]
+		g.conditions[id] = now
+		if !seen {
+			continue
+		}
+		if now.dead && !before.dead {
+			g.Events.PublishAsync(simulation.Event{Type: simulation.EventPetDied, PetID: id,
+				Data: map[string]interface{}{"name": pet.Name, "cause": pet.Biology.CauseOfDeath}})
+		}
+		if now.critical && !before.critical {
+			g.Events.PublishAsync(simulation.Event{Type: simulation.EventPetCritical, PetID: id,
+				Data: map[string]interface{}{"name": pet.Name, "needs": needs}})
+		}
+	}
+}
+
+// AutoSave queues the household for a background save as one transaction,
+// so relationships between pets stay consistent on disk, and writes the
+// rest of the household beside them. Invalid pets are left out and
+// reported. Gives up waiting for room in the queue once ctx is cancelled.
+func (g *GameLoop) AutoSave(ctx context.Context) error {
+	if g.Saves == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	pets, problems := g.savablePets()
+	if err := g.Saves.EnqueueAll(ctx, pets); err != nil {
+		problems = append(problems, err)
+	}
+	if err := g.saveHousehold(ctx); err != nil {
+		problems = append(problems, err)
+	}
+	g.lastSave = time.Now()
+	g.history.save(g.lastSave)
+	g.Audit(ActorAutoSave, data.AuditSave, "", fmt.Sprintf("queued %d pets", len(pets)))
+	g.Events.PublishAsync(simulation.Event{Type: simulation.EventAutoSave, Data: map[string]interface{}{"pets": len(pets)}})
+	return errors.Join(problems...)
+}
+
+// Backup saves the household and waits for the save to be written, then
+// copies every save into a new backup under dir and returns its path. With
+// Offsite set the backup is uploaded too; if only the upload fails the
+// path of the local backup is returned with the error.
+func (g *GameLoop) Backup(ctx context.Context, dir string) (string, error) {
+	if g.Data == nil {
+		return "", ErrNoStorage
+	}
+	if err := g.AutoSave(ctx); err != nil {
+		return "", err
+	}
+	if err := g.Saves.Flush(); err != nil {
+		return "", err
+	}
+	path, err := g.Data.Backup(ctx, dir)
+	if err != nil || g.Offsite == nil {
+		return path, err
+	}
+	if _, err := g.Offsite.Upload(ctx, path); err != nil {
+		return path, fmt.Errorf("uploading backup: %w", err)
+	}
+	return path, nil
+}
+
+// Audit records a state-changing operation in the audit log when the loop
+// has storage. A failure to record never holds up the change itself.
+func (g *GameLoop) Audit(actor, action string, petID types.PetID, summary string) {
+	if g.Data == nil {
+		return
+	}
+	g.Data.Audit.Record(data.AuditEntry{Actor: actor, Action: action, PetID: petID, Summary: summary})
+}
+
+// LoopStats summarizes a running game loop
+type LoopStats struct {
+	Pets           int     `json:"pets"`
+	Alive          int     `json:"alive"`
+	GameDays       float64 `json:"game_days"`  // Simulated since the loop started
+	TimeScale      string  `json:"time_scale"` // Configuration spelling, e.g. ACCELERATED_4X
+	Paused         bool    `json:"paused"`
+	Idle           bool    `json:"idle"` // Ticking at the idle interval
+	Ticks          uint64  `json:"ticks"`
+	DroppedEvents  int     `json:"dropped_events"`
+	HandlerPanics  int     `json:"handler_panics"`
+	UnreadMessages int     `json:"unread_messages"`
+	Degraded       []Fault `json:"degraded,omitempty"`   // Subsystems set aside after a panic
+	AverageFPS     float64 `json:"average_fps"`          // Over the last AverageWindow minutes
+	AverageP95     float64 `json:"average_frame_p95_ms"` // Mean of each minute's p95 frame time
+
+	Sanctuary interaction.SanctuaryStats `json:"sanctuary"`          // Donations and community standing
+	Fidelity  *Fidelity                  `json:"fidelity,omitempty"` // What was given up to stay within the budget, if one is set
+}
+
+// Stats reports the state of the loop and its household
+func (g *GameLoop) Stats() LoopStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ts := g.Time.GetStats()
+	stats := LoopStats{
+		Pets:          len(g.Household.Pets),
+		GameDays:      ts.SimulatedDays,
+		TimeScale:     config.TimeScaleName(ts.CurrentTimeScale),
+		Paused:        ts.IsPaused,
+		Idle:          g.idle,
+		Ticks:         ts.TickCount,
+		DroppedEvents: g.Events.Dropped(),
+		HandlerPanics: g.Events.Panics(),
+		Degraded:      g.faultList(),
+	}
+	stats.AverageFPS, stats.AverageP95 = g.averages()
+	stats.Sanctuary = g.Household.SanctuaryStats()
+	if g.budget.budget != (Budget{}) {
+		fidelity := g.fidelity()
+		stats.Fidelity = &fidelity
+	}
+	for _, pet := range g.Household.Pets {
+		if !g.setAside(pet) && pet.IsAlive() {
+			stats.Alive++
+		}
+	}
+	if g.Household.Inbox != nil {
+		stats.UnreadMessages = g.Household.Inbox.UnreadCount()
+	}
+	return stats
+}
+
+// Run ticks at the configured rate until ctx is cancelled, auto-saving on
+// schedule. While idle it ticks at the idle interval instead, still waking
+// for auto-saves, and goes back to full speed at once on Wake. When ctx is
+// cancelled ticking stops, queued saves are flushed and a final validated
+// save is written. Work started by the loop uses ctx, so it is abandoned on
+// shutdown; the final save gets a fresh context bounded by
+// ShutdownTimeout. Auto-save failures do not stop the loop; they are
+// returned together with any shutdown error. Panics in updates and
+// auto-saves are recovered and the subsystem set aside; see Faults.
+func (g *GameLoop) Run(ctx context.Context) error {
+	if g.Data != nil {
+		if err := g.Data.BeginSession(); err != nil {
+			return err
+		}
+	}
+	problems := g.ticks(ctx)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	return errors.Join(append(problems, g.Shutdown(shutdownCtx))...)
+}
+
+// ticks implements Run's ticking until ctx is cancelled and returns the
+// auto-save failures. Before each wait it records when the next tick is
+// due, so a Watchdog can tell a stalled loop from an idle one.
+func (g *GameLoop) ticks(ctx context.Context) []error {
+	tick := time.Second / time.Duration(g.Config.TickRate)
+	timer := time.NewTimer(tick)
+	defer timer.Stop()
+	g.expect(tick)
+	interval := time.Duration(g.Config.AutoSaveInterval) * time.Second
+
+	var problems []error
+	for {
+		select {
+		case <-ctx.Done():
+			return problems
+		case <-g.wake:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-timer.C:
+		}
+
+		g.Update()
+		if interval > 0 && g.saveDue(interval) {
+			var err error
+			if perr := g.contain(SubsystemAutoSave, func() { err = g.AutoSave(ctx) }); perr != nil {
+				err = perr
+			}
+			if err != nil && ctx.Err() == nil {
+				problems = append(problems, err)
+			}
+		}
+		next := g.nextTick(tick, interval)
+		timer.Reset(next)
+		g.expect(next)
+	}
+}
+
+// nextTick returns the time until the next tick: tick normally, or the
+// idle interval once there has been no input for a while and no pet is
+// near a critical level, shortened so auto-saves stay on schedule
+func (g *GameLoop) nextTick(tick, saveInterval time.Duration) time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	tick *= time.Duration(max(g.budget.tickFactor, 1))
+	idleInterval := time.Duration(g.Config.IdleInterval) * time.Second
+	g.idle = idleInterval > tick &&
+		time.Since(g.lastActivity) >= time.Duration(g.Config.IdleAfter)*time.Second &&
+		(g.Time.IsPausedState() || !g.needsAttention())
+	if !g.idle {
+		return tick
+	}
+
+	wait := idleInterval
+	if saveInterval > 0 {
+		if due := saveInterval - time.Since(g.lastSave); due < wait {
+			wait = due
+		}
+	}
+	if wait < tick {
+		wait = tick
+	}
+	return wait
+}
+
+// needsAttention returns true if a living pet is critical or close to it
+func (g *GameLoop) needsAttention() bool {
+	for _, pet := range g.Household.Pets {
+		if g.setAside(pet) || !pet.IsAlive() {
+			continue
+		}
+		if pet.Biology.Thermoregulation.HasCondition() ||
+			len(pet.Biology.Vitals.GetCriticalStats(core.CriticalThreshold+IdleMargin)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// saveDue returns true once interval has passed since the last auto-save
+func (g *GameLoop) saveDue(interval time.Duration) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return time.Since(g.lastSave) >= interval
+}
+
+// Shutdown announces the shutdown and delivers pending events, flushes
+// queued saves, then saves the household as one transaction and verifies
+// every pet while holding the loop so nothing changes underneath the final
+// save, and writes the rest of the household beside the pets. The session is only marked clean if every pet was saved intact.
+// Handler panics are not errors here; they were isolated from the loop and
+// are counted by the event system.
+func (g *GameLoop) Shutdown(ctx context.Context) error {
+	g.Events.PublishAsync(simulation.Event{Type: simulation.EventShutdown})
+	g.Events.Close()
+	if g.Data == nil {
+		return nil
+	}
+
+	var problems []error
+	if err := g.Saves.Close(); err != nil {
+		problems = append(problems, err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	pets, invalid := g.savablePets()
+	problems = append(problems, invalid...)
+	if err := g.finalSave(ctx, pets); err != nil {
+		problems = append(problems, err)
+	} else {
+		g.Audit(ActorShutdown, data.AuditSave, "", fmt.Sprintf("saved %d pets", len(pets)))
+	}
+	if err := g.saveHousehold(ctx); err != nil {
+		problems = append(problems, err)
+	}
+	if len(problems) > 0 {
+		return errors.Join(problems...)
+	}
+	return g.Data.EndSession()
+}
+
+// finalSave writes the pets as one transaction and reads each back to
+// confirm its save is intact
+func (g *GameLoop) finalSave(ctx context.Context, pets []*core.DigitalPet) error {
+	payloads := make([]data.PetPayload, 0, len(pets))
+	for _, pet := range pets {
+		payload, err := pet.Save()
+		if err != nil {
+			return fmt.Errorf("%s: %w", pet.ID, err)
+		}
+		payloads = append(payloads, data.PetPayload{ID: pet.ID, Payload: payload})
+	}
+	if err := g.Data.WriteAll(ctx, payloads); err != nil {
+		return err
+	}
+
+	var problems []error
+	for _, p := range payloads {
+		if err := g.Data.VerifyPet(ctx, p.ID, p.Payload); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", p.ID, err))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// saveHousehold writes the household's state other than its pets (must be
+// called with lock held)
+func (g *GameLoop) saveHousehold(ctx context.Context) error {
+	payload, err := g.Household.SaveState()
+	if err != nil {
+		return err
+	}
+	return g.Data.WriteHousehold(ctx, payload)
+}
+
+// savablePets returns the household's valid pets, missing ones included,
+// in ID order along with the reasons the others cannot be saved. Pets set
+// aside after a panic keep their last save.
+func (g *GameLoop) savablePets() ([]*core.DigitalPet, []error) {
+	var valid []*core.DigitalPet
+	var problems []error
+	for _, pet := range g.keptPets() {
+		if g.setAside(pet) {
+			problems = append(problems, fmt.Errorf("%s: %w", pet.ID, ErrSubsystemPanic))
+			continue
+		}
+		if err := data.ValidatePet(pet); err != nil {
+			problems = append(problems, fmt.Errorf("%s: %w", pet.ID, err))
+			continue
+		}
+		valid = append(valid, pet)
+	}
+	return valid, problems
+}
+
+// pets returns the household's pets in ID order
+func (g *GameLoop) pets() []*core.DigitalPet {
+	pets := make([]*core.DigitalPet, 0, len(g.Household.Pets))
+	for _, pet := range g.Household.Pets {
+		pets = append(pets, pet)
+	}
+	sort.Slice(pets, func(i, j int) bool { return pets[i].ID < pets[j].ID })
+	return pets
+}
+
+// keptPets returns the household's pets in ID order, including those that
+// ran away and are still being searched for
+func (g *GameLoop) keptPets() []*core.DigitalPet {
+	pets := g.pets()
+	for _, rescue := range g.Household.Missing() {
+		pets = append(pets, rescue.Pet)
+	}
+	sort.Slice(pets, func(i, j int) bool { return pets[i].ID < pets[j].ID })
+	return pets
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// benchTickDays is one tick at 60 updates per second and 24x speed
+const benchTickDays = 24.0 / 60.0 / SecondsPerDay
+
+// benchHousehold creates a household of pets with every optional system on
+func benchHousehold(pets int) *interaction.Household {
+	household := interaction.NewHousehold()
+	for i := 0; i < pets; i++ {
+		household.AddPet(core.NewDigitalPetRandom(fmt.Sprintf("Pet%03d", i), "owner"))
+	}
+	household.Weather = environment.NewSeededWeatherSystem(1)
+	household.Environment = environment.NewSeededManager(environment.DefaultWorldMap(), 1)
+	household.Garden = environment.NewSeededGarden(1)
+	return household
+}
+
+func BenchmarkGameLoopUpdate(b *testing.B) {
+	for _, pets := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("pets=%d", pets), func(b *testing.B) {
+			loop, err := NewGameLoop(config.Default().Simulation, benchHousehold(pets), nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				loop.Update()
+			}
+		})
+	}
+}
+
+func BenchmarkGameLoopStep(b *testing.B) {
+	for _, pets := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("pets=%d", pets), func(b *testing.B) {
+			loop, err := NewGameLoop(config.Default().Simulation, benchHousehold(pets), nil)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				loop.Step(benchTickDays)
+			}
+		})
+	}
+}
+
+func BenchmarkHouseholdInteract(b *testing.B) {
+	household := benchHousehold(10)
+	ids := make([]types.PetID, 0, len(household.Pets))
+	for id := range household.Pets {
+		ids = append(ids, id)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		household.Interact(ids[i%len(ids)], types.InteractionPetting, 0.5)
+	}
+}
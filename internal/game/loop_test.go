@@ -0,0 +1,404 @@
+package game
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/script"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestStepAgesPets(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	loop, err := NewGameLoop(config.Default().Simulation, interaction.NewHousehold(pet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loop.Step(1.0)
+	if age := pet.GetAge(); age < 0.99 || age > 1.01 {
+		t.Errorf("Expected the pet to age a day, got %.2f", age)
+	}
+	if err := loop.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown without storage should be a no-op, got %v", err)
+	}
+}
+
+func TestAlignToClock(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	pet.LastUpdateAt = time.Now().Add(-6 * time.Hour)
+	household := interaction.NewHousehold(pet)
+	household.Weather = environment.NewSeededWeatherSystem(1)
+	loop, err := NewGameLoop(config.Default().Simulation, household, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loop.Shutdown(context.Background())
+
+	// A time zone where it is two in the morning
+	utc := time.Now().UTC()
+	night := time.FixedZone("night", (2-utc.Hour())*3600)
+	if days := loop.AlignToClock(night, environment.HemisphereSouth, 72*time.Hour); days < 0.24 || days > 0.26 {
+		t.Errorf("Expected six hours caught up, got %.3f days", days)
+	}
+	if !loop.Time.IsAligned() || !household.Weather.IsAligned() {
+		t.Fatal("Expected the clock and weather to be aligned")
+	}
+
+	loop.Step(0.01)
+	if pet.CurrentBehavior != types.BehaviorSleeping {
+		t.Errorf("Expected the pet asleep at night, got %s", pet.CurrentBehavior)
+	}
+}
+
+func TestRunShutsDownCleanly(t *testing.T) {
+	dm, _ := data.NewDataManager(t.TempDir())
+	pet := core.NewDigitalPet("Mochi", "owner")
+	cfg := config.Default().Simulation
+	cfg.TickRate = 100
+
+	loop, err := NewGameLoop(cfg, interaction.NewHousehold(pet), dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- loop.Run(ctx) }()
+
+	time.Sleep(30 * time.Millisecond)
+	if err := loop.AutoSave(context.Background()); err != nil {
+		t.Fatalf("AutoSave failed: %v", err)
+	}
+	cancel()
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("Run returned %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not stop after cancellation")
+	}
+
+	loaded, err := dm.LoadPet(context.Background(), pet.ID)
+	if err != nil || loaded.GetAge() != pet.GetAge() {
+		t.Errorf("Expected the final save to match the live pet, got %v (%v)", loaded, err)
+	}
+	if report, _ := dm.Recover(); report.Unclean {
+		t.Error("A cancelled run should end its session cleanly")
+	}
+}
+
+func TestShutdownSavesMissingPets(t *testing.T) {
+	ctx := context.Background()
+	dm, _ := data.NewDataManager(t.TempDir())
+	rex := core.NewDigitalPet("Rex", "owner")
+	mochi := core.NewDigitalPet("Mochi", "owner")
+	household := interaction.NewHousehold(rex, mochi)
+	household.World = environment.DefaultWorldMap()
+	loop, err := NewGameLoop(config.Default().Simulation, household, dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rex.Biology.Vitals.Happiness = 0.05
+	rex.Biology.Vitals.Stress = 0.9
+	household.Update(2.0)
+	if len(household.Missing()) != 1 {
+		t.Fatal("Expected Rex to run away")
+	}
+	if err := loop.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	pets, err := dm.LoadAll(ctx)
+	if err != nil || len(pets) != 2 {
+		t.Fatalf("Expected both pets saved, got %d (%v)", len(pets), err)
+	}
+	payload, err := dm.ReadHousehold()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := interaction.NewHousehold(pets...)
+	if err := reloaded.LoadState(payload); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if missing := reloaded.Missing(); len(missing) != 1 || missing[0].Pet.ID != rex.ID {
+		t.Errorf("Expected Rex to still be missing, got %+v", missing)
+	}
+}
+
+func TestBackupUploadsOffsite(t *testing.T) {
+	dm, _ := data.NewDataManager(t.TempDir())
+	loop, err := NewGameLoop(config.Default().Simulation, interaction.NewHousehold(core.NewDigitalPet("Mochi", "owner")), dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loop.Shutdown(context.Background())
+	provider := data.NewMemoryProvider()
+	loop.Offsite = data.NewCloudBackups(provider, 1)
+	var finished []simulation.Event
+	loop.Events.Subscribe("data.*", 0, func(e simulation.Event) { finished = append(finished, e) })
+
+	ctx := context.Background()
+	dir := filepath.Join(dm.SavePath, data.BackupDirName)
+	path, err := loop.Backup(ctx, dir)
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	loop.Events.Flush()
+	if len(finished) != 2 || finished[0].Type != simulation.EventDataSave || finished[1].Type != simulation.EventDataBackup ||
+		finished[1].Data["path"] != path || finished[1].Data["size"].(int64) == 0 {
+		t.Errorf("Expected the save and backup published as data events, got %+v", finished)
+	}
+	if names, _ := loop.Offsite.List(ctx); len(names) != 1 || names[0] != filepath.Base(path)+data.BackupArchiveExt {
+		t.Errorf("Expected %s uploaded, got %v", path, names)
+	}
+
+	provider.SetOffline(true)
+	path, err = loop.Backup(ctx, dir)
+	if !data.IsTemporary(err) || path == "" {
+		t.Errorf("Expected the local backup kept when the upload fails, got %q (%v)", path, err)
+	}
+}
+
+func TestNextTickIdles(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	cfg := config.Default().Simulation
+	cfg.IdleAfter = 0
+	loop, err := NewGameLoop(cfg, interaction.NewHousehold(pet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loop.Shutdown(context.Background())
+	tick := time.Second / 60
+
+	if got := loop.nextTick(tick, 0); got != 30*time.Second || !loop.Stats().Idle {
+		t.Errorf("Expected a 30s idle tick, got %s", got)
+	}
+	if got := loop.nextTick(tick, 10*time.Second); got > 10*time.Second || got < 9*time.Second {
+		t.Errorf("Expected to wake for the auto-save in 10s, got %s", got)
+	}
+
+	pet.Biology.Vitals.Nutrition = core.CriticalThreshold + IdleMargin/2
+	if got := loop.nextTick(tick, 0); got != tick || loop.Stats().Idle {
+		t.Errorf("Expected a hungry pet to keep the loop awake, got %s", got)
+	}
+	loop.Time.Pause()
+	if got := loop.nextTick(tick, 0); got != 30*time.Second {
+		t.Errorf("Expected a paused loop to idle, got %s", got)
+	}
+	loop.Time.Resume()
+
+	pet.Biology.Vitals.Nutrition = 1.0
+	loop.Config.IdleAfter = 60
+	loop.Wake()
+	if got := loop.nextTick(tick, 0); got != tick {
+		t.Errorf("Expected input to keep the loop at the full rate, got %s", got)
+	}
+}
+
+func TestWakeTicksIdleLoop(t *testing.T) {
+	cfg := config.Default().Simulation
+	cfg.IdleAfter = 0
+	cfg.IdleInterval = 3600
+	loop, err := NewGameLoop(cfg, interaction.NewHousehold(core.NewDigitalPet("Mochi", "owner")), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- loop.Run(ctx) }()
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+
+	waitFor := func(what string, ok func(LoopStats) bool) LoopStats {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			if stats := loop.Stats(); ok(stats) {
+				return stats
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("Timed out waiting for %s", what)
+		return LoopStats{}
+	}
+	idle := waitFor("the loop to idle", func(s LoopStats) bool { return s.Idle && s.Ticks > 0 })
+	time.Sleep(50 * time.Millisecond)
+	if stats := loop.Stats(); stats.Ticks != idle.Ticks {
+		t.Errorf("Expected no ticks while idle, got %d more", stats.Ticks-idle.Ticks)
+	}
+
+	loop.Wake()
+	waitFor("a tick after waking", func(s LoopStats) bool { return s.Ticks > idle.Ticks })
+}
+
+func TestNewGameLoopRejectsBadConfig(t *testing.T) {
+	cfg := config.Default().Simulation
+	cfg.TickRate = 0
+	if _, err := NewGameLoop(cfg, interaction.NewHousehold(), nil); err == nil {
+		t.Error("Expected a zero tick rate to be rejected")
+	}
+}
+
+func TestPanickingHandlerDoesNotStopLoop(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	loop, err := NewGameLoop(config.Default().Simulation, interaction.NewHousehold(pet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	ticks := 0
+	loop.Events.Subscribe(string(simulation.EventTick), 1, func(simulation.Event) { panic("boom") })
+	loop.Events.Subscribe("game.*", 0, func(simulation.Event) {
+		mu.Lock()
+		ticks++
+		mu.Unlock()
+	})
+
+	loop.Step(0.5)
+	loop.Step(0.5)
+	if err := loop.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if ticks != 3 || loop.Events.Panics() != 2 {
+		t.Errorf("Expected 2 ticks plus shutdown delivered and 2 panics, got %d and %d", ticks, loop.Events.Panics())
+	}
+	if age := pet.GetAge(); age < 0.99 {
+		t.Errorf("Expected the pet to keep aging, got %.2f", age)
+	}
+}
+
+func TestStepRunsScripts(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	loop, err := NewGameLoop(config.Default().Simulation, interaction.NewHousehold(pet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := script.Parse(`when health > 0 then pet, notify "hello {name}"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop.Scripts = script.NewEngine(rules, 0)
+
+	var mu sync.Mutex
+	var reactions []simulation.Event
+	loop.Events.Subscribe(string(simulation.EventScript), 0, func(e simulation.Event) {
+		mu.Lock()
+		reactions = append(reactions, e)
+		mu.Unlock()
+	})
+
+	loop.Step(0.1)
+	loop.Step(0.1)
+	if err := loop.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if len(reactions) != 1 || reactions[0].PetID != pet.ID || reactions[0].Data["error"] != nil {
+		t.Errorf("Expected one successful reaction event, got %+v", reactions)
+	}
+	if loop.Household.Inbox.UnreadCount() != 1 {
+		t.Errorf("Expected the notification in the inbox, got %d unread", loop.Household.Inbox.UnreadCount())
+	}
+}
+
+func TestStepAppliesCrowdVotes(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	loop, err := NewGameLoop(config.Default().Simulation, interaction.NewHousehold(pet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop.Crowd = interaction.NewCrowdControl(time.Second, time.Minute, time.Hour)
+
+	var mu sync.Mutex
+	var results []simulation.Event
+	loop.Events.Subscribe(string(simulation.EventPetCrowd), 0, func(e simulation.Event) {
+		mu.Lock()
+		results = append(results, e)
+		mu.Unlock()
+	})
+
+	// The round started two seconds ago, so the next step resolves it
+	if err := loop.Crowd.Vote("viewer", pet.ID, "feed", time.Now().Add(-2*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	loop.Step(0.01)
+	loop.Step(0.01)
+	if err := loop.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if len(results) != 1 || results[0].PetID != pet.ID || results[0].Data["action"] != "feed" || results[0].Data["applied"] != true {
+		t.Errorf("Expected one applied crowd event, got %+v", results)
+	}
+}
+
+func TestStepPublishesConditionChanges(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	loop, err := NewGameLoop(config.Default().Simulation, interaction.NewHousehold(pet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var events []simulation.Event
+	loop.Events.Subscribe("pet.*", 0, func(e simulation.Event) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	})
+
+	loop.Step(0.01)
+	loop.Do(func() { pet.Biology.Vitals.Hydration = 0.05 })
+	loop.Step(0.01)
+	loop.Step(0.01)
+	loop.Do(func() {
+		pet.Biology.IsAlive = false
+		pet.Biology.CauseOfDeath = "old age"
+	})
+	loop.Step(0.01)
+	if err := loop.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected a critical and a death event, got %+v", events)
+	}
+	if events[0].Type != simulation.EventPetCritical || events[0].Data["name"] != "Mochi" {
+		t.Errorf("Expected Mochi to become critical, got %+v", events[0])
+	}
+	if events[1].Type != simulation.EventPetDied || events[1].Data["cause"] != "old age" {
+		t.Errorf("Expected Mochi's death, got %+v", events[1])
+	}
+}
+
+func TestStatsReportSanctuary(t *testing.T) {
+	household := interaction.NewHousehold(core.NewDigitalPet("Mochi", "owner"))
+	loop, err := NewGameLoop(config.Default().Simulation, household, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer loop.Shutdown(context.Background())
+
+	if _, err := household.Donate("coins", 75); err != nil {
+		t.Fatal(err)
+	}
+	stats := loop.Stats().Sanctuary
+	if stats.Standing != 75 || stats.Donations != 1 || stats.ByKind[interaction.DonationCoins] != 75 {
+		t.Errorf("Expected the donation in the statistics, got %+v", stats)
+	}
+}
@@ -0,0 +1,212 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Panic recovery settings
+const (
+	PanicRetryInterval   = time.Minute     // Wall time a degraded subsystem is skipped before it is tried again
+	EmergencySaveTimeout = 5 * time.Second // Limit on waiting for room in the save queue after a panic
+)
+
+// Subsystems the loop isolates from each other's panics. Each pet is its
+// own subsystem too, named by PetSubsystem.
+const (
+	SubsystemHousehold  = "household"  // Weather, garden, breeding and other household updates
+	SubsystemScripts    = "scripts"    // Reaction rules
+	SubsystemCrowd      = "crowd"      // Crowd votes
+	SubsystemRetention  = "retention"  // History compaction
+	SubsystemConditions = "conditions" // Pet condition events
+	SubsystemAmbience   = "ambience"   // Ambience cues
+	SubsystemAutoSave   = "autosave"   // Periodic saves
+	SubsystemCommands   = "commands"   // Player commands
+	SubsystemDisplay    = "display"    // Thought bubbles and the spectator dashboard
+)
+
+// ActorRecovery is recorded in the audit log for emergency saves
+const ActorRecovery = "recovery"
+
+// ErrSubsystemPanic wraps a panic recovered from a subsystem
+var ErrSubsystemPanic = errors.New("subsystem panicked")
+
+// Fault is a panic recovered from a subsystem
+type Fault struct {
+	Subsystem string    `json:"subsystem"`
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"-"`
+	At        time.Time `json:"at"`
+	Count     int       `json:"count"` // Panics in a row, without a clean run between
+	Until     time.Time `json:"until"` // When a degraded subsystem is next tried
+}
+
+// PetSubsystem names the subsystem that updates one pet, so a pet whose
+// state makes its update panic is set aside without stopping the others
+func PetSubsystem(id types.PetID) string {
+	return "pet " + string(id)
+}
+
+// Try runs fn while no update is in progress, like Do, but recovers a
+// panic: it is logged with its stack, the pets are saved and the subsystem
+// is marked degraded, and an error wrapping ErrSubsystemPanic is returned
+// so the caller can carry on. Try always runs fn, even for a degraded
+// subsystem.
+func (g *GameLoop) Try(subsystem string, fn func()) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.guard(subsystem, fn)
+}
+
+// Faults returns the subsystems that have panicked and not run cleanly
+// since, in name order
+func (g *GameLoop) Faults() []Fault {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.faultList()
+}
+
+// faultList implements Faults (must be called with lock held)
+func (g *GameLoop) faultList() []Fault {
+	faults := make([]Fault, 0, len(g.faults))
+	for _, f := range g.faults {
+		faults = append(faults, *f)
+	}
+	sort.Slice(faults, func(i, j int) bool { return faults[i].Subsystem < faults[j].Subsystem })
+	return faults
+}
+
+// tick runs part of an update unless its subsystem is degraded and not yet
+// due a retry (must be called with lock held)
+func (g *GameLoop) tick(subsystem string, fn func()) {
+	if f := g.faults[subsystem]; f != nil && time.Now().Before(f.Until) {
+		return
+	}
+	g.guard(subsystem, fn)
+}
+
+// guard runs fn, recovering a panic. A clean run clears the subsystem's
+// fault. (must be called with lock held)
+func (g *GameLoop) guard(subsystem string, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = g.recordPanic(subsystem, r, debug.Stack())
+		}
+	}()
+	fn()
+	if f := g.faults[subsystem]; f != nil {
+		delete(g.faults, subsystem)
+		g.logger().Printf("%s recovered after %d panics", subsystem, f.Count)
+	}
+	return nil
+}
+
+// contain runs fn without the lock held, recovering a panic
+func (g *GameLoop) contain(subsystem string, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			stack := debug.Stack()
+			g.mu.Lock()
+			err = g.recordPanic(subsystem, r, stack)
+			g.mu.Unlock()
+		}
+	}()
+	fn()
+	return nil
+}
+
+// recordPanic logs a recovered panic, marks its subsystem degraded and
+// publishes it. The first panic in a row also saves the pets, since a
+// second could come before the next auto-save. (must be called with lock
+// held)
+func (g *GameLoop) recordPanic(subsystem string, r interface{}, stack []byte) error {
+	now := time.Now()
+	f := g.faults[subsystem]
+	if f == nil {
+		f = &Fault{Subsystem: subsystem}
+		g.faults[subsystem] = f
+	}
+	f.Count++
+	f.Panic = fmt.Sprint(r)
+	f.Stack = string(stack)
+	f.At = now
+	f.Until = now.Add(PanicRetryInterval)
+
+	g.logger().Printf("recovered panic in %s: %v\n%s", subsystem, r, stack)
+	g.Events.PublishAsync(simulation.Event{Type: simulation.EventPanic,
+		Data: map[string]interface{}{"subsystem": subsystem, "panic": f.Panic, "count": f.Count}})
+	if f.Count == 1 {
+		g.emergencySave(ActorRecovery, "a panic in "+subsystem)
+	}
+	return fmt.Errorf("%w: %s: %v", ErrSubsystemPanic, subsystem, r)
+}
+
+// emergencySave queues the valid pets for saving after a panic or stall,
+// recording actor in the audit log, and returns true if they were queued.
+// A panic while saving is logged rather than recorded, so it cannot
+// recurse. (must be called with lock held)
+func (g *GameLoop) emergencySave(actor, reason string) (queued bool) {
+	if g.Saves == nil {
+		return false
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			g.logger().Printf("emergency save after %s failed: %v", reason, r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), EmergencySaveTimeout)
+	defer cancel()
+	pets := g.intactPets()
+	if err := g.Saves.EnqueueAll(ctx, pets); err != nil {
+		g.logger().Printf("emergency save after %s failed: %v", reason, err)
+		return false
+	}
+	g.lastSave = time.Now()
+	g.Audit(actor, data.AuditSave, "", fmt.Sprintf("queued %d pets after %s", len(pets), reason))
+	return true
+}
+
+// setAside returns true if a pet's update panicked and has not run
+// cleanly since, so the loop leaves the pet alone (must be called with
+// lock held)
+func (g *GameLoop) setAside(pet *core.DigitalPet) bool {
+	return g.faults[PetSubsystem(pet.ID)] != nil
+}
+
+// intactPets returns the pets that validate without panicking, in ID
+// order, since the pet behind a panic may be too damaged to validate
+// (must be called with lock held)
+func (g *GameLoop) intactPets() []*core.DigitalPet {
+	var intact []*core.DigitalPet
+	for _, pet := range g.keptPets() {
+		if g.setAside(pet) {
+			continue
+		}
+		func() {
+			defer func() { recover() }()
+			if data.ValidatePet(pet) == nil {
+				intact = append(intact, pet)
+			}
+		}()
+	}
+	return intact
+}
+
+// logger returns where recovered panics are logged
+func (g *GameLoop) logger() *log.Logger {
+	if g.Logger != nil {
+		return g.Logger
+	}
+	return log.Default()
+}
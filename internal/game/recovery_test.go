@@ -0,0 +1,151 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+// fault returns a subsystem's fault, or nil
+func fault(loop *GameLoop, subsystem string) *Fault {
+	for _, f := range loop.Faults() {
+		if f.Subsystem == subsystem {
+			return &f
+		}
+	}
+	return nil
+}
+
+func TestStepRecoversPanickingPet(t *testing.T) {
+	dm, _ := data.NewDataManager(t.TempDir())
+	healthy := core.NewDigitalPet("Mochi", "owner")
+	broken := core.NewDigitalPet("Glitch", "owner")
+	broken.Biology = nil
+	loop, err := NewGameLoop(config.Default().Simulation, interaction.NewHousehold(healthy, broken), dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	loop.Logger = log.New(&logs, "", 0)
+	panics := make(chan simulation.Event, 4)
+	loop.Events.Subscribe(string(simulation.EventPanic), 0, func(e simulation.Event) { panics <- e })
+
+	loop.Step(1.0)
+	if age := healthy.GetAge(); age < 0.99 {
+		t.Errorf("Expected the healthy pet to keep ageing, got %.2f", age)
+	}
+	petFault := fault(loop, PetSubsystem(broken.ID))
+	if petFault == nil || petFault.Count != 1 {
+		t.Fatalf("Expected the broken pet degraded, got %+v", loop.Faults())
+	}
+	if !strings.Contains(logs.String(), "recovered panic in "+PetSubsystem(broken.ID)) || !strings.Contains(logs.String(), "goroutine") {
+		t.Errorf("Expected the panic logged with its stack, got:\n%s", logs.String())
+	}
+	select {
+	case e := <-panics:
+		if e.Data["subsystem"] != PetSubsystem(broken.ID) {
+			t.Errorf("Expected the broken pet's panic published first, got %v", e.Data)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected a panic event")
+	}
+
+	// The degraded pet is skipped until it is due a retry
+	loop.Step(1.0)
+	if f := fault(loop, PetSubsystem(broken.ID)); f.Count != 1 {
+		t.Errorf("Expected the degraded pet skipped, got %d panics", f.Count)
+	}
+	loop.Do(func() { loop.faults[PetSubsystem(broken.ID)].Until = time.Now() })
+	loop.Step(1.0)
+	if f := fault(loop, PetSubsystem(broken.ID)); f.Count != 2 {
+		t.Errorf("Expected the retry to panic again, got %d panics", f.Count)
+	}
+	if stats := loop.Stats(); len(stats.Degraded) == 0 {
+		t.Error("Expected the degraded subsystems in the stats")
+	}
+
+	// The first panic queued an emergency save of the valid pets
+	if err := loop.Saves.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.LoadPet(context.Background(), healthy.ID); err != nil {
+		t.Errorf("Expected an emergency save of the healthy pet, got %v", err)
+	}
+
+	// Once the pet is repaired a clean run clears the faults
+	loop.Do(func() {
+		broken.Biology = core.NewDigitalPet("Glitch", "owner").Biology
+		for _, f := range loop.faults {
+			f.Until = time.Now()
+		}
+	})
+	loop.Step(0.1)
+	if faults := loop.Faults(); len(faults) != 0 {
+		t.Errorf("Expected the faults cleared, got %+v", faults)
+	}
+	loop.Shutdown(context.Background())
+}
+
+func TestTryRecoversCommands(t *testing.T) {
+	loop, err := NewGameLoop(config.Default().Simulation, interaction.NewHousehold(core.NewDigitalPet("Mochi", "owner")), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop.Logger = log.New(&bytes.Buffer{}, "", 0)
+
+	err = loop.Try(SubsystemCommands, func() { panic("boom") })
+	if !errors.Is(err, ErrSubsystemPanic) || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("Expected ErrSubsystemPanic, got %v", err)
+	}
+
+	// Commands are never skipped, and the loop is not left locked
+	ran := false
+	if err := loop.Try(SubsystemCommands, func() { ran = true }); err != nil || !ran {
+		t.Errorf("Expected the next command to run, got %v", err)
+	}
+	if faults := loop.Faults(); len(faults) != 0 {
+		t.Errorf("Expected a clean command to clear the fault, got %+v", faults)
+	}
+	loop.Step(0.1)
+}
+
+func TestRunSurvivesPanickingSubsystem(t *testing.T) {
+	dm, _ := data.NewDataManager(t.TempDir())
+	pet := core.NewDigitalPet("Mochi", "owner")
+	cfg := config.Default().Simulation
+	cfg.TickRate = 100
+	household := interaction.NewHousehold(pet)
+	loop, err := NewGameLoop(cfg, household, dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loop.Logger = log.New(&bytes.Buffer{}, "", 0)
+	household.Pets[pet.ID].Biology = nil
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- loop.Run(ctx) }()
+	time.Sleep(50 * time.Millisecond)
+	if err := loop.Try(SubsystemCommands, func() {}); err != nil {
+		t.Errorf("Expected commands to keep working, got %v", err)
+	}
+	if fault(loop, PetSubsystem(pet.ID)) == nil {
+		t.Errorf("Expected the pet degraded, got %+v", loop.Faults())
+	}
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the loop to shut down")
+	}
+}
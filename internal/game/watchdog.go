@@ -0,0 +1,204 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+// Watchdog settings
+const (
+	DefaultStallTimeout = time.Minute           // Time past a due tick before the loop counts as stalled
+	DefaultMaxRestarts  = 3                     // Restarts before the watchdog gives up
+	MinWatchInterval    = 10 * time.Millisecond // Shortest time between checks on the loop
+)
+
+// StallDirName is the directory under the save path that server mode
+// keeps goroutine dumps of stalls in
+const StallDirName = "stalls"
+
+// ActorWatchdog is recorded in the audit log for saves after a stall
+const ActorWatchdog = "watchdog"
+
+// ErrStalled is returned when the loop stalled more times than the
+// watchdog restarts it
+var ErrStalled = errors.New("game loop stalled")
+
+// Stall is a time the watchdog found the loop stuck
+type Stall struct {
+	At        time.Time     `json:"at"`
+	Overdue   time.Duration `json:"overdue"`   // How long the next tick was late
+	Saved     bool          `json:"saved"`     // Whether the pets were queued for saving
+	Restarted bool          `json:"restarted"` // False once the watchdog gave up
+	Dump      string        `json:"-"`         // Every goroutine's stack when the stall was found
+	DumpFile  string        `json:"dump_file,omitempty"`
+}
+
+// Watchdog runs a game loop in server mode and restarts its ticking when
+// updates stop arriving, as after a deadlock or livelock. Each stall is
+// logged with a goroutine dump, the pets are saved if the household is
+// not held by the stuck update, and an EventStall is published for the
+// notifiers.
+type Watchdog struct {
+	Loop *GameLoop
+	// Timeout is how long past its due time a tick may be before the loop
+	// counts as stalled. It should be longer than the slowest update.
+	Timeout time.Duration
+	// MaxRestarts is how many stalls are recovered from before Run gives
+	// up and returns ErrStalled
+	MaxRestarts int
+	// DumpDir receives a goroutine dump file per stall. Optional; empty
+	// only logs the dump.
+	DumpDir string
+
+	mu     sync.Mutex
+	stalls []Stall
+}
+
+// NewWatchdog creates a watchdog for a loop with the default limits
+func NewWatchdog(loop *GameLoop, timeout time.Duration) *Watchdog {
+	if timeout <= 0 {
+		timeout = DefaultStallTimeout
+	}
+	return &Watchdog{Loop: loop, Timeout: timeout, MaxRestarts: DefaultMaxRestarts}
+}
+
+// Stalls returns the stalls found so far, oldest first
+func (w *Watchdog) Stalls() []Stall {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]Stall(nil), w.stalls...)
+}
+
+// Run runs the loop like GameLoop.Run, watching its ticks. A stalled loop
+// has its ticking abandoned and started afresh; the stuck update is left
+// to finish on its own, since a goroutine cannot be stopped from outside.
+// After MaxRestarts restarts the next stall stops Run: queued saves are
+// flushed, but no final save is attempted and the session is left
+// unclean, so the next start recovers from the last good saves.
+func (w *Watchdog) Run(ctx context.Context) error {
+	g := w.Loop
+	if g.Data != nil {
+		if err := g.Data.BeginSession(); err != nil {
+			return err
+		}
+	}
+
+	var problems []error
+	for restarts := 0; ; restarts++ {
+		tickCtx, cancel := context.WithCancel(ctx)
+		done := make(chan []error, 1)
+		go func() { done <- g.ticks(tickCtx) }()
+		overdue, errs := w.watch(done)
+		cancel()
+		problems = append(problems, errs...)
+		if overdue == 0 {
+			break
+		}
+
+		restart := restarts < w.MaxRestarts
+		w.record(overdue, restart)
+		if !restart {
+			if g.Saves != nil {
+				problems = append(problems, g.Saves.Close())
+			}
+			return errors.Join(append(problems, fmt.Errorf("%w: %d times", ErrStalled, restarts+1))...)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), ShutdownTimeout)
+	defer cancel()
+	return errors.Join(append(problems, g.Shutdown(shutdownCtx))...)
+}
+
+// watch waits for the ticking to stop and returns its auto-save failures,
+// or returns how overdue the next tick is once it is late by more than
+// Timeout
+func (w *Watchdog) watch(done <-chan []error) (time.Duration, []error) {
+	check := time.NewTicker(max(w.Timeout/4, MinWatchInterval))
+	defer check.Stop()
+	for {
+		select {
+		case errs := <-done:
+			return 0, errs
+		case now := <-check.C:
+			if overdue := w.Loop.overdue(now); overdue > w.Timeout {
+				return overdue, nil
+			}
+		}
+	}
+}
+
+// record records a stall: the goroutines are dumped, the pets saved if
+// the household is free and the stall published
+func (w *Watchdog) record(overdue time.Duration, restart bool) {
+	g := w.Loop
+	stall := Stall{At: time.Now(), Overdue: overdue, Restarted: restart}
+
+	var dump bytes.Buffer
+	pprof.Lookup("goroutine").WriteTo(&dump, 2)
+	stall.Dump = dump.String()
+	g.logger().Printf("game loop stalled: next tick %s overdue\n%s", overdue.Round(time.Millisecond), stall.Dump)
+	if w.DumpDir != "" {
+		path, err := w.writeDump(stall)
+		if err != nil {
+			g.logger().Printf("writing goroutine dump: %v", err)
+		}
+		stall.DumpFile = path
+	}
+
+	stall.Saved = g.stallSave()
+	w.mu.Lock()
+	w.stalls = append(w.stalls, stall)
+	w.mu.Unlock()
+
+	g.Events.PublishAsync(simulation.Event{Type: simulation.EventStall, Data: map[string]interface{}{
+		"overdue": overdue.Seconds(), "saved": stall.Saved, "restarted": restart}})
+}
+
+// writeDump writes a stall's goroutine dump under DumpDir and returns its
+// path
+func (w *Watchdog) writeDump(stall Stall) (string, error) {
+	if err := os.MkdirAll(w.DumpDir, 0o755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(w.DumpDir, "stall-"+stall.At.UTC().Format("20060102-150405.000")+".txt")
+	if err := os.WriteFile(path, []byte(stall.Dump), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// expect records that the next tick is due after wait
+func (g *GameLoop) expect(wait time.Duration) {
+	g.due.Store(time.Now().Add(wait).UnixNano())
+}
+
+// overdue returns how late the next tick is at now, or 0 if it is not due
+func (g *GameLoop) overdue(now time.Time) time.Duration {
+	due := g.due.Load()
+	if due == 0 {
+		return 0
+	}
+	return max(now.Sub(time.Unix(0, due)), 0)
+}
+
+// stallSave queues the intact pets for saving after a stall. The update
+// that stalled may hold the household, possibly half changed, so the
+// pets are only saved if it is free; otherwise their last saves stand.
+func (g *GameLoop) stallSave() bool {
+	if !g.mu.TryLock() {
+		g.logger().Print("household is held by the stalled update; keeping the last saves")
+		return false
+	}
+	defer g.mu.Unlock()
+	return g.emergencySave(ActorWatchdog, "a stall")
+}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+// watchedLoop returns a fast-ticking loop with storage and a watchdog
+// that counts it stalled after 50ms
+func watchedLoop(t *testing.T) (*Watchdog, *data.DataManager, *bytes.Buffer) {
+	dm, _ := data.NewDataManager(t.TempDir())
+	cfg := config.Default().Simulation
+	cfg.TickRate = 100
+	loop, err := NewGameLoop(cfg, interaction.NewHousehold(core.NewDigitalPet("Mochi", "owner")), dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	loop.Logger = log.New(&logs, "", 0)
+	w := NewWatchdog(loop, 50*time.Millisecond)
+	w.DumpDir = t.TempDir()
+	return w, dm, &logs
+}
+
+func TestWatchdogRestartsStalledLoop(t *testing.T) {
+	w, dm, logs := watchedLoop(t)
+	stalls := make(chan simulation.Event, 4)
+	w.Loop.Events.Subscribe(string(simulation.EventStall), 0, func(e simulation.Event) { stalls <- e })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- w.Run(ctx) }()
+
+	// Holding the household stalls the next update
+	release := make(chan struct{})
+	go w.Loop.Do(func() { <-release })
+	select {
+	case e := <-stalls:
+		if e.Data["restarted"] != true || e.Data["saved"] != false {
+			t.Errorf("Expected a restart without a save while the household is held, got %v", e.Data)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the stall to be published")
+	}
+	close(release)
+
+	found := w.Stalls()
+	if len(found) == 0 || found[0].Overdue <= w.Timeout {
+		t.Fatalf("Expected a stall overdue by more than the timeout, got %+v", found)
+	}
+	if !strings.Contains(found[0].Dump, "goroutine") || !strings.Contains(logs.String(), "game loop stalled") {
+		t.Error("Expected the goroutine dump logged")
+	}
+	if dump, err := os.ReadFile(found[0].DumpFile); err != nil || !bytes.Contains(dump, []byte("goroutine")) {
+		t.Errorf("Expected the dump written to a file, got %v", err)
+	}
+
+	// The restarted loop ticks again and shuts down cleanly
+	ticks := w.Loop.Time.GetStats().TickCount
+	time.Sleep(50 * time.Millisecond)
+	if w.Loop.Time.GetStats().TickCount <= ticks {
+		t.Error("Expected the restarted loop to keep ticking")
+	}
+	cancel()
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("Run returned %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not stop after cancellation")
+	}
+	if report, _ := dm.Recover(); report.Unclean {
+		t.Error("A restarted loop should still end its session cleanly")
+	}
+}
+
+func TestWatchdogGivesUp(t *testing.T) {
+	w, dm, _ := watchedLoop(t)
+	w.MaxRestarts = 1
+	release := make(chan struct{})
+	defer close(release)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- w.Run(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+	go w.Loop.Do(func() { <-release })
+
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, ErrStalled) {
+			t.Fatalf("Expected ErrStalled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the watchdog to give up")
+	}
+	found := w.Stalls()
+	if len(found) != 2 || !found[0].Restarted || found[1].Restarted {
+		t.Errorf("Expected one restart and then giving up, got %+v", found)
+	}
+	if report, _ := dm.Recover(); !report.Unclean {
+		t.Error("Expected the session left unclean after giving up")
+	}
+}
+
+func TestStallSavesFreeHousehold(t *testing.T) {
+	w, dm, _ := watchedLoop(t)
+	w.record(time.Second, true)
+
+	if found := w.Stalls(); len(found) != 1 || !found[0].Saved {
+		t.Fatalf("Expected the pets saved after a stall in a free household, got %+v", found)
+	}
+	if err := w.Loop.Saves.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := dm.Audit.Query(data.AuditQuery{Actor: ActorWatchdog})
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Expected the save audited, got %v (%v)", entries, err)
+	}
+}
@@ -0,0 +1,62 @@
+package genetics
+
+import "fmt"
+
+// DefaultMutationRate is the mutation rate used when breeding
+const DefaultMutationRate = 0.05
+
+// MutationRate is the mutation rate currently used when breeding. Tuning
+// may change it at runtime.
+var MutationRate = DefaultMutationRate
+
+// BreedingCompatibility describes whether two genomes can breed and
+// what the outcome is likely to be
+type BreedingCompatibility struct {
+	Score    float64  // Overall compatibility (0.0 to 1.0)
+	CanBreed bool     // Whether breeding is allowed
+	Reasons  []string // Why breeding is blocked, if it is
+	Warnings []string // Non-blocking concerns about the pairing
+}
+
+// CalculateCompatibility evaluates a potential breeding pair.
+// Moderately different genomes score best; near-identical genomes are
+// penalised to discourage loss of diversity.
+func CalculateCompatibility(g1, g2 *Genome) BreedingCompatibility {
+	similarity := g1.Similarity(g2)
+
+	compat := BreedingCompatibility{
+		Score:    1.0 - similarity*0.5,
+		CanBreed: true,
+	}
+
+	if similarity > 0.95 {
+		compat.Score -= 0.3
+		compat.Warnings = append(compat.Warnings, "parents are genetically near-identical")
+	}
+
+	// Warn when both parents carry the same recessive disorder
+	for _, disorder := range AllDisorders() {
+		risk := OffspringRisk(g1, g2, disorder)
+		if risk > 0 {
+			compat.Warnings = append(compat.Warnings,
+				fmt.Sprintf("both parents carry %s: %.0f%% of offspring will be affected", disorder, risk*100))
+			compat.Score -= risk * 0.2
+		}
+	}
+
+	compat.Score = clamp(compat.Score, 0.0, 1.0)
+	return compat
+}
+
+// Block marks the pairing as unable to breed for the given reason
+func (c *BreedingCompatibility) Block(reason string) {
+	c.CanBreed = false
+	c.Reasons = append(c.Reasons, reason)
+}
+
+// Breed produces a mutated offspring genome from two parents
+func Breed(parent1, parent2 *Genome, mutationRate float64) *Genome {
+	child := Crossover(parent1, parent2)
+	child.Mutate(mutationRate)
+	return child
+}
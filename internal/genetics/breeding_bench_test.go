@@ -0,0 +1,51 @@
+package genetics
+
+import (
+	"fmt"
+	"testing"
+)
+
+func BenchmarkBreed(b *testing.B) {
+	parent1, parent2 := NewRandomGenome(), NewRandomGenome()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Breed(parent1, parent2, DefaultMutationRate)
+	}
+}
+
+// BenchmarkBreedGenerations breeds a population forward over several
+// generations, the way a long-running breeding program grows
+func BenchmarkBreedGenerations(b *testing.B) {
+	for _, size := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("population=%d", size), func(b *testing.B) {
+			founders := make([]*Genome, size)
+			for i := range founders {
+				founders[i] = NewRandomGenome()
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				population := founders
+				for generation := 0; generation < 5; generation++ {
+					next := make([]*Genome, len(population))
+					for j := range next {
+						next[j] = Breed(population[j], population[(j+1)%len(population)], DefaultMutationRate)
+					}
+					population = next
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPopulationAnalysis(b *testing.B) {
+	genomes := make([]*Genome, 1000)
+	for i := range genomes {
+		genomes[i] = NewRandomGenome()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analyzer := NewPopulationAnalyzer(genomes)
+		analyzer.GeneticDiversity()
+		analyzer.AnalyzeAllLoci()
+	}
+}
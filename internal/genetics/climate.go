@@ -0,0 +1,32 @@
+package genetics
+
+import "github.com/Michael-W-Ellison/gochi/internal/environment"
+
+// ClimateTolerance describes how well a pet's body copes with cold and heat
+type ClimateTolerance struct {
+	Cold float64 // 0.0 (feels every chill) to 1.0 (shrugs off the cold)
+	Heat float64 // 0.0 (wilts in the heat) to 1.0 (thrives in it)
+}
+
+// ClimateTolerance derives cold and heat tolerance from the genome. Long
+// fur keeps out the cold; small bodies shed heat easily while long fur
+// traps it.
+func (g *Genome) ClimateTolerance() ClimateTolerance {
+	coat := g.GetTraitValue("coat_length")
+	size := g.GetTraitValue("body_size")
+	return ClimateTolerance{
+		Cold: coat,
+		Heat: clamp(0.7*(1.0-size)+0.3*(1.0-coat), 0.0, 1.0),
+	}
+}
+
+// BiomeAffinity returns how naturally suited the genome is to a biome
+// (0.0 to 1.0). A temperate biome suits everyone.
+func (g *Genome) BiomeAffinity(biome environment.Biome) float64 {
+	climate := biome.Climate()
+	tolerance := g.ClimateTolerance()
+
+	coldMismatch := climate.Cold * (1.0 - tolerance.Cold)
+	heatMismatch := climate.Heat * (1.0 - tolerance.Heat)
+	return 1.0 - max(coldMismatch, heatMismatch)
+}
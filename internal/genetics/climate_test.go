@@ -0,0 +1,30 @@
+package genetics
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// climateGenome builds a genome with the given coat length and body size
+func climateGenome(coat, size float64) *Genome {
+	g := NewRandomGenome()
+	g.Traits["coat_length"] = GenePair{Allele{Value: coat}, Allele{Value: coat}}
+	g.Traits["body_size"] = GenePair{Allele{Value: size}, Allele{Value: size}}
+	return g
+}
+
+func TestBiomeAffinity(t *testing.T) {
+	husky := climateGenome(1.0, 0.8)
+	fennec := climateGenome(0.0, 0.1)
+
+	if husky.BiomeAffinity(environment.BiomeArctic) <= fennec.BiomeAffinity(environment.BiomeArctic) {
+		t.Error("Long fur should suit the arctic better")
+	}
+	if fennec.BiomeAffinity(environment.BiomeDesert) <= husky.BiomeAffinity(environment.BiomeDesert) {
+		t.Error("Small short-haired bodies should suit the desert better")
+	}
+	if husky.BiomeAffinity(environment.BiomeTemperate) != 1.0 {
+		t.Error("Temperate biomes should suit everyone")
+	}
+}
@@ -0,0 +1,162 @@
+package genetics
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+)
+
+// FounderCarrierRate is the chance that a founder allele carries a disorder
+const FounderCarrierRate = 0.1
+
+// ScreeningCost is the price of a genetic screening at the vet
+const ScreeningCost = 50
+
+// DisorderType identifies a recessive genetic disorder
+type DisorderType int
+
+const (
+	DisorderHeartDefect DisorderType = iota
+	DisorderWeakImmunity
+	DisorderRespiratory
+	DisorderMetabolic
+)
+
+// String returns the string representation of DisorderType
+func (d DisorderType) String() string {
+	return [...]string{
+		"Heart Defect", "Weak Immunity", "Respiratory Disorder", "Metabolic Disorder",
+	}[d]
+}
+
+// AllDisorders returns every known disorder type
+func AllDisorders() []DisorderType {
+	return []DisorderType{
+		DisorderHeartDefect,
+		DisorderWeakImmunity,
+		DisorderRespiratory,
+		DisorderMetabolic,
+	}
+}
+
+// DisorderLocus is the gene pair for a recessive disorder.
+// A true allele carries the defect; one copy makes a silent carrier,
+// two copies cause the disorder.
+type DisorderLocus struct {
+	Maternal bool `json:"maternal"`
+	Paternal bool `json:"paternal"`
+}
+
+// IsCarrier returns true if exactly one allele carries the defect
+func (l DisorderLocus) IsCarrier() bool {
+	return l.Maternal != l.Paternal
+}
+
+// IsAffected returns true if both alleles carry the defect
+func (l DisorderLocus) IsAffected() bool {
+	return l.Maternal && l.Paternal
+}
+
+// HasDefect returns true if either allele carries the defect
+func (l DisorderLocus) HasDefect() bool {
+	return l.Maternal || l.Paternal
+}
+
+// pick randomly selects one allele to pass to offspring
+func (l DisorderLocus) pick() bool {
+	if rand.Float64() < 0.5 {
+		return l.Maternal
+	}
+	return l.Paternal
+}
+
+// GetAffectedDisorders returns the disorders this genome expresses
+func (g *Genome) GetAffectedDisorders() []DisorderType {
+	var affected []DisorderType
+	for _, disorder := range AllDisorders() {
+		if g.Disorders[disorder].IsAffected() {
+			affected = append(affected, disorder)
+		}
+	}
+	return affected
+}
+
+// GetCarriedDisorders returns the disorders this genome silently carries
+func (g *Genome) GetCarriedDisorders() []DisorderType {
+	var carried []DisorderType
+	for _, disorder := range AllDisorders() {
+		if g.Disorders[disorder].IsCarrier() {
+			carried = append(carried, disorder)
+		}
+	}
+	return carried
+}
+
+// ApplyDisorders applies the health consequences of expressed disorders
+func (g *Genome) ApplyDisorders(bio *biology.BiologicalSystems) {
+	for _, disorder := range g.GetAffectedDisorders() {
+		switch disorder {
+		case DisorderHeartDefect:
+			bio.Processes.CardiovascularHealth -= 0.4
+		case DisorderWeakImmunity:
+			bio.Processes.ImmuneStrength -= 0.4
+		case DisorderRespiratory:
+			bio.Processes.RespiratoryHealth -= 0.4
+		case DisorderMetabolic:
+			bio.Processes.DigestiveEfficiency -= 0.4
+		}
+		bio.Vitals.Health -= 0.1
+	}
+
+	bio.Processes.CardiovascularHealth = clamp(bio.Processes.CardiovascularHealth, 0.1, 1.0)
+	bio.Processes.ImmuneStrength = clamp(bio.Processes.ImmuneStrength, 0.1, 1.0)
+	bio.Processes.RespiratoryHealth = clamp(bio.Processes.RespiratoryHealth, 0.1, 1.0)
+	bio.Processes.DigestiveEfficiency = clamp(bio.Processes.DigestiveEfficiency, 0.1, 1.0)
+	bio.Vitals.Clamp()
+}
+
+// ScreeningReport contains the result of a genetic screening
+type ScreeningReport struct {
+	Carrier  []DisorderType
+	Affected []DisorderType
+}
+
+// Screen reveals the carrier and affected status of a genome
+func Screen(g *Genome) ScreeningReport {
+	return ScreeningReport{
+		Carrier:  g.GetCarriedDisorders(),
+		Affected: g.GetAffectedDisorders(),
+	}
+}
+
+// IsClear returns true if no disorder alleles were found
+func (r ScreeningReport) IsClear() bool {
+	return len(r.Carrier) == 0 && len(r.Affected) == 0
+}
+
+// String provides a human-readable screening summary
+func (r ScreeningReport) String() string {
+	if r.IsClear() {
+		return "No disorder alleles detected"
+	}
+	return fmt.Sprintf("Carrier of: %v | Affected by: %v", r.Carrier, r.Affected)
+}
+
+// OffspringRisk returns the chance that offspring of two genomes will be
+// affected by the given disorder
+func OffspringRisk(g1, g2 *Genome, disorder DisorderType) float64 {
+	return alleleChance(g1.Disorders[disorder]) * alleleChance(g2.Disorders[disorder])
+}
+
+// alleleChance returns the chance of passing on a defective allele
+func alleleChance(l DisorderLocus) float64 {
+	chance := 0.0
+	if l.Maternal {
+		chance += 0.5
+	}
+	if l.Paternal {
+		chance += 0.5
+	}
+	return chance
+}
@@ -0,0 +1,103 @@
+package genetics
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+)
+
+func TestDisorderLocus(t *testing.T) {
+	carrier := DisorderLocus{Maternal: true}
+	if !carrier.IsCarrier() || carrier.IsAffected() {
+		t.Error("Single defective allele should make a carrier, not affected")
+	}
+
+	affected := DisorderLocus{Maternal: true, Paternal: true}
+	if affected.IsCarrier() || !affected.IsAffected() {
+		t.Error("Two defective alleles should make an affected pet")
+	}
+
+	clear := DisorderLocus{}
+	if clear.HasDefect() {
+		t.Error("Clear locus should not have a defect")
+	}
+}
+
+func TestApplyDisorders(t *testing.T) {
+	g := NewRandomGenome()
+	g.Disorders[DisorderHeartDefect] = DisorderLocus{Maternal: true, Paternal: true}
+	g.Disorders[DisorderWeakImmunity] = DisorderLocus{Maternal: true}
+
+	bio := biology.NewBiologicalSystems()
+	g.ApplyDisorders(bio)
+
+	if bio.Processes.CardiovascularHealth >= 1.0 {
+		t.Error("Heart defect should reduce cardiovascular health")
+	}
+
+	if bio.Processes.ImmuneStrength != 0.9 {
+		t.Errorf("Carriers should show no symptoms, immune strength %.2f", bio.Processes.ImmuneStrength)
+	}
+}
+
+func TestScreen(t *testing.T) {
+	g := NewGenome()
+	g.Disorders[DisorderMetabolic] = DisorderLocus{Paternal: true}
+
+	report := Screen(g)
+
+	if report.IsClear() {
+		t.Error("Screening should detect carrier status")
+	}
+
+	if len(report.Carrier) != 1 || report.Carrier[0] != DisorderMetabolic {
+		t.Errorf("Expected carrier of Metabolic Disorder, got %v", report.Carrier)
+	}
+
+	if len(report.Affected) != 0 {
+		t.Errorf("Expected no affected disorders, got %v", report.Affected)
+	}
+}
+
+func TestOffspringRisk(t *testing.T) {
+	carrier1 := NewGenome()
+	carrier1.Disorders[DisorderRespiratory] = DisorderLocus{Maternal: true}
+	carrier2 := NewGenome()
+	carrier2.Disorders[DisorderRespiratory] = DisorderLocus{Paternal: true}
+
+	if risk := OffspringRisk(carrier1, carrier2, DisorderRespiratory); risk != 0.25 {
+		t.Errorf("Two carriers should give 25%% risk, got %.2f", risk)
+	}
+
+	if risk := OffspringRisk(carrier1, NewGenome(), DisorderRespiratory); risk != 0.0 {
+		t.Errorf("One carrier should give no risk, got %.2f", risk)
+	}
+}
+
+func TestCompatibilityCarrierWarning(t *testing.T) {
+	g1 := NewRandomGenome()
+	g2 := NewRandomGenome()
+	for _, d := range AllDisorders() {
+		g1.Disorders[d] = DisorderLocus{}
+		g2.Disorders[d] = DisorderLocus{}
+	}
+
+	clean := CalculateCompatibility(g1, g2)
+
+	g1.Disorders[DisorderHeartDefect] = DisorderLocus{Maternal: true}
+	g2.Disorders[DisorderHeartDefect] = DisorderLocus{Maternal: true}
+
+	risky := CalculateCompatibility(g1, g2)
+
+	if len(risky.Warnings) <= len(clean.Warnings) {
+		t.Error("Compatibility should warn when both parents are carriers")
+	}
+
+	if risky.Score >= clean.Score {
+		t.Error("Carrier pairing should lower compatibility score")
+	}
+
+	if !risky.CanBreed {
+		t.Error("Carrier pairing should warn, not block")
+	}
+}
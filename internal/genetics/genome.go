@@ -0,0 +1,228 @@
+package genetics
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+)
+
+// TraitNames lists the heritable traits encoded in the genome
+var TraitNames = []string{
+	"openness", "conscientiousness", "extraversion", "agreeableness", "neuroticism",
+	"playfulness", "independence", "loyalty", "intelligence", "energy_level",
+	"affectionate", "curiosity", "adaptability", "vocalization", "territoriality",
+	"coat_length", "body_size",
+}
+
+// Allele represents a single gene variant inherited from one parent
+type Allele struct {
+	Value    float64 `json:"value"`             // Trait contribution (0.0 to 1.0)
+	Dominant bool    `json:"dominant"`          // Dominant alleles mask recessive ones
+	Mutated  bool    `json:"mutated,omitempty"` // Whether this allele arose by mutation
+}
+
+// GenePair represents the two alleles carried for a single trait
+type GenePair struct {
+	Maternal Allele `json:"maternal"`
+	Paternal Allele `json:"paternal"`
+}
+
+// Expressed returns the phenotype value produced by the gene pair.
+// A dominant allele masks a recessive one; otherwise the alleles blend.
+func (g GenePair) Expressed() float64 {
+	switch {
+	case g.Maternal.Dominant && !g.Paternal.Dominant:
+		return g.Maternal.Value
+	case g.Paternal.Dominant && !g.Maternal.Dominant:
+		return g.Paternal.Value
+	default:
+		return (g.Maternal.Value + g.Paternal.Value) / 2.0
+	}
+}
+
+// Genome holds the complete genetic makeup of a pet
+type Genome struct {
+	Traits     map[string]GenePair            `json:"traits"`
+	Disorders  map[DisorderType]DisorderLocus `json:"disorders"`
+	Generation int                            `json:"generation"` // 0 for founders
+	Mutations  int                            `json:"mutations"`  // Mutations accumulated in this genome
+}
+
+// NewGenome creates an empty genome
+func NewGenome() *Genome {
+	return &Genome{
+		Traits:    make(map[string]GenePair),
+		Disorders: make(map[DisorderType]DisorderLocus),
+	}
+}
+
+// NewRandomGenome creates a founder genome with random alleles
+func NewRandomGenome() *Genome {
+	g := NewGenome()
+
+	for _, name := range TraitNames {
+		g.Traits[name] = GenePair{
+			Maternal: randomAllele(),
+			Paternal: randomAllele(),
+		}
+	}
+
+	for _, disorder := range AllDisorders() {
+		g.Disorders[disorder] = DisorderLocus{
+			Maternal: rand.Float64() < FounderCarrierRate,
+			Paternal: rand.Float64() < FounderCarrierRate,
+		}
+	}
+
+	return g
+}
+
+// ApproximationSpread is how far the alleles of an approximated genome may
+// stray from the trait values they approximate
+const ApproximationSpread = 0.05
+
+// ApproximateGenome creates a founder genome that expresses roughly the
+// given trait values, for pets whose genes are not known. Traits not given
+// are random. Both alleles carry the target value give or take
+// ApproximationSpread, so descendants inherit it too.
+func ApproximateGenome(targets map[string]float64) *Genome {
+	g := NewRandomGenome()
+	for _, name := range TraitNames {
+		target, known := targets[name]
+		if !known {
+			continue
+		}
+		pair := g.Traits[name]
+		pair.Maternal.Value = clamp(target+(rand.Float64()*2-1)*ApproximationSpread, 0.0, 1.0)
+		pair.Paternal.Value = clamp(target+(rand.Float64()*2-1)*ApproximationSpread, 0.0, 1.0)
+		g.Traits[name] = pair
+	}
+	return g
+}
+
+// Crossover produces an offspring genome by taking one random allele
+// from each parent for every locus
+func Crossover(parent1, parent2 *Genome) *Genome {
+	child := NewGenome()
+
+	for _, name := range TraitNames {
+		p1 := parent1.Traits[name]
+		p2 := parent2.Traits[name]
+		child.Traits[name] = GenePair{
+			Maternal: pickAllele(p1),
+			Paternal: pickAllele(p2),
+		}
+	}
+
+	for _, disorder := range AllDisorders() {
+		child.Disorders[disorder] = DisorderLocus{
+			Maternal: parent1.Disorders[disorder].pick(),
+			Paternal: parent2.Disorders[disorder].pick(),
+		}
+	}
+
+	child.Generation = max(parent1.Generation, parent2.Generation) + 1
+	return child
+}
+
+// Mutate randomly perturbs trait alleles and returns the number of mutations applied
+func (g *Genome) Mutate(mutationRate float64) int {
+	count := 0
+
+	for name, pair := range g.Traits {
+		if rand.Float64() < mutationRate {
+			pair.Maternal.Value = clamp(pair.Maternal.Value+(rand.Float64()-0.5)*0.2, 0.0, 1.0)
+			pair.Maternal.Mutated = true
+			count++
+		}
+		if rand.Float64() < mutationRate {
+			pair.Paternal.Value = clamp(pair.Paternal.Value+(rand.Float64()-0.5)*0.2, 0.0, 1.0)
+			pair.Paternal.Mutated = true
+			count++
+		}
+		g.Traits[name] = pair
+	}
+
+	g.Mutations += count
+	return count
+}
+
+// GetTraitValue returns the expressed value for a named trait
+func (g *Genome) GetTraitValue(name string) float64 {
+	pair, exists := g.Traits[name]
+	if !exists {
+		return 0.5
+	}
+	return pair.Expressed()
+}
+
+// ExpressTraits converts the genome into a personality trait set
+func (g *Genome) ExpressTraits() *ai.Traits {
+	traits := &ai.Traits{
+		Openness:          g.GetTraitValue("openness"),
+		Conscientiousness: g.GetTraitValue("conscientiousness"),
+		Extraversion:      g.GetTraitValue("extraversion"),
+		Agreeableness:     g.GetTraitValue("agreeableness"),
+		Neuroticism:       g.GetTraitValue("neuroticism"),
+		Playfulness:       g.GetTraitValue("playfulness"),
+		Independence:      g.GetTraitValue("independence"),
+		Loyalty:           g.GetTraitValue("loyalty"),
+		Intelligence:      g.GetTraitValue("intelligence"),
+		EnergyLevel:       g.GetTraitValue("energy_level"),
+		Affectionate:      g.GetTraitValue("affectionate"),
+		Curiosity:         g.GetTraitValue("curiosity"),
+		Adaptability:      g.GetTraitValue("adaptability"),
+		Vocalization:      g.GetTraitValue("vocalization"),
+		Territoriality:    g.GetTraitValue("territoriality"),
+	}
+	traits.Clamp()
+	return traits
+}
+
+// ApplyCoat gives the pet the coat length encoded in its genome
+func (g *Genome) ApplyCoat(bio *biology.BiologicalSystems) {
+	bio.Coat = biology.NewCoat(g.GetTraitValue("coat_length"))
+}
+
+// Similarity returns how genetically alike two genomes are (0.0 to 1.0)
+func (g *Genome) Similarity(other *Genome) float64 {
+	if len(TraitNames) == 0 {
+		return 0.0
+	}
+
+	totalDiff := 0.0
+	for _, name := range TraitNames {
+		totalDiff += math.Abs(g.GetTraitValue(name) - other.GetTraitValue(name))
+	}
+
+	return 1.0 - totalDiff/float64(len(TraitNames))
+}
+
+// randomAllele creates an allele with a random value and dominance
+func randomAllele() Allele {
+	return Allele{
+		Value:    rand.Float64(),
+		Dominant: rand.Float64() < 0.5,
+	}
+}
+
+// pickAllele randomly selects one allele from a gene pair
+func pickAllele(pair GenePair) Allele {
+	if rand.Float64() < 0.5 {
+		return pair.Maternal
+	}
+	return pair.Paternal
+}
+
+// Helper function to clamp values
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,119 @@
+package genetics
+
+import "testing"
+
+func TestGenePairExpressed(t *testing.T) {
+	tests := []struct {
+		name     string
+		pair     GenePair
+		expected float64
+	}{
+		{"maternal dominant", GenePair{Allele{Value: 0.8, Dominant: true}, Allele{Value: 0.2, Dominant: false}}, 0.8},
+		{"paternal dominant", GenePair{Allele{Value: 0.8, Dominant: false}, Allele{Value: 0.2, Dominant: true}}, 0.2},
+		{"both recessive blend", GenePair{Allele{Value: 0.8, Dominant: false}, Allele{Value: 0.2, Dominant: false}}, 0.5},
+		{"both dominant blend", GenePair{Allele{Value: 0.6, Dominant: true}, Allele{Value: 0.4, Dominant: true}}, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pair.Expressed(); got != tt.expected {
+				t.Errorf("Expressed() = %.2f, want %.2f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewRandomGenome(t *testing.T) {
+	g := NewRandomGenome()
+
+	if len(g.Traits) != len(TraitNames) {
+		t.Errorf("Expected %d traits, got %d", len(TraitNames), len(g.Traits))
+	}
+
+	if len(g.Disorders) != len(AllDisorders()) {
+		t.Errorf("Expected %d disorder loci, got %d", len(AllDisorders()), len(g.Disorders))
+	}
+
+	if g.Generation != 0 {
+		t.Errorf("Founder genome should be generation 0, got %d", g.Generation)
+	}
+}
+
+func TestApproximateGenome(t *testing.T) {
+	g := ApproximateGenome(map[string]float64{"playfulness": 0.9, "body_size": 0.0})
+
+	if got := g.GetTraitValue("playfulness"); got < 0.9-ApproximationSpread || got > 0.9+ApproximationSpread {
+		t.Errorf("Expected playfulness near 0.9, got %.2f", got)
+	}
+	if got := g.GetTraitValue("body_size"); got > ApproximationSpread {
+		t.Errorf("Expected body size near 0, got %.2f", got)
+	}
+	if len(g.Traits) != len(TraitNames) {
+		t.Errorf("Expected every trait filled in, got %d", len(g.Traits))
+	}
+}
+
+func TestCrossover(t *testing.T) {
+	p1 := NewRandomGenome()
+	p2 := NewRandomGenome()
+	p2.Generation = 2
+
+	child := Crossover(p1, p2)
+
+	if child.Generation != 3 {
+		t.Errorf("Child generation should be 3, got %d", child.Generation)
+	}
+
+	for _, name := range TraitNames {
+		pair := child.Traits[name]
+		if pair.Maternal != p1.Traits[name].Maternal && pair.Maternal != p1.Traits[name].Paternal {
+			t.Errorf("Maternal allele for %s did not come from parent1", name)
+		}
+		if pair.Paternal != p2.Traits[name].Maternal && pair.Paternal != p2.Traits[name].Paternal {
+			t.Errorf("Paternal allele for %s did not come from parent2", name)
+		}
+	}
+}
+
+func TestMutate(t *testing.T) {
+	g := NewRandomGenome()
+
+	if count := g.Mutate(0.0); count != 0 {
+		t.Errorf("Zero mutation rate should not mutate, got %d mutations", count)
+	}
+
+	count := g.Mutate(1.0)
+	if count != len(TraitNames)*2 {
+		t.Errorf("Full mutation rate should mutate every allele, got %d", count)
+	}
+
+	if g.Mutations != count {
+		t.Errorf("Mutations should be tracked, expected %d got %d", count, g.Mutations)
+	}
+}
+
+func TestExpressTraits(t *testing.T) {
+	g := NewRandomGenome()
+	traits := g.ExpressTraits()
+
+	if traits.Openness != g.GetTraitValue("openness") {
+		t.Error("Expressed openness should match genome")
+	}
+
+	if traits.EnergyLevel != g.GetTraitValue("energy_level") {
+		t.Error("Expressed energy level should match genome")
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	g := NewRandomGenome()
+
+	if sim := g.Similarity(g); sim != 1.0 {
+		t.Errorf("Genome should be identical to itself, got %.2f", sim)
+	}
+
+	other := NewRandomGenome()
+	if sim := g.Similarity(other); sim < 0.0 || sim > 1.0 {
+		t.Errorf("Similarity should be between 0 and 1, got %.2f", sim)
+	}
+}
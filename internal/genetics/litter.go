@@ -0,0 +1,48 @@
+package genetics
+
+import "math/rand"
+
+// Litter settings
+const (
+	MaxLitterSize       = 4   // Most offspring born at once
+	LitterBodySizeScale = 0.7 // How much small parents raise the expected litter
+	LitterBaseFertility = 0.3 // Fertility of the largest, healthiest parents
+	InbreedingThreshold = 0.8 // Parent similarity above which litters shrink
+)
+
+// ExpectedLitterSize returns the mean litter size of two parents. Smaller
+// parents have larger litters, unhealthy parents smaller ones, and
+// near-identical parents lose fertility to inbreeding. health is the
+// parents' average health (0.0 to 1.0).
+func ExpectedLitterSize(g1, g2 *Genome, health float64) float64 {
+	bodySize := (g1.GetTraitValue("body_size") + g2.GetTraitValue("body_size")) / 2.0
+	fertility := LitterBaseFertility + LitterBodySizeScale*(1.0-bodySize)
+	fertility *= clamp(health, 0.0, 1.0)
+	if similarity := g1.Similarity(g2); similarity > InbreedingThreshold {
+		fertility *= 1.0 - (similarity-InbreedingThreshold)/(1.0-InbreedingThreshold)
+	}
+	return 1.0 + float64(MaxLitterSize-1)*clamp(fertility, 0.0, 1.0)
+}
+
+// LitterSize draws a litter size between 1 and MaxLitterSize whose mean
+// is ExpectedLitterSize
+func LitterSize(g1, g2 *Genome, health float64, rng *rand.Rand) int {
+	chance := (ExpectedLitterSize(g1, g2, health) - 1.0) / float64(MaxLitterSize-1)
+	size := 1
+	for i := 1; i < MaxLitterSize; i++ {
+		if rng.Float64() < chance {
+			size++
+		}
+	}
+	return size
+}
+
+// BreedLitter produces size offspring genomes, each crossed over and
+// mutated independently so siblings differ
+func BreedLitter(parent1, parent2 *Genome, size int, mutationRate float64) []*Genome {
+	litter := make([]*Genome, size)
+	for i := range litter {
+		litter[i] = Breed(parent1, parent2, mutationRate)
+	}
+	return litter
+}
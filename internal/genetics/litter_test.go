@@ -0,0 +1,60 @@
+package genetics
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestExpectedLitterSize(t *testing.T) {
+	small1 := ApproximateGenome(map[string]float64{"body_size": 0.1, "playfulness": 0.1})
+	small2 := ApproximateGenome(map[string]float64{"body_size": 0.1, "playfulness": 0.9})
+	large1 := ApproximateGenome(map[string]float64{"body_size": 0.9, "playfulness": 0.1})
+	large2 := ApproximateGenome(map[string]float64{"body_size": 0.9, "playfulness": 0.9})
+
+	smallHealthy := ExpectedLitterSize(small1, small2, 1.0)
+	if large := ExpectedLitterSize(large1, large2, 1.0); large >= smallHealthy {
+		t.Errorf("Expected small parents to have larger litters, got %.2f vs %.2f", smallHealthy, large)
+	}
+	if sick := ExpectedLitterSize(small1, small2, 0.3); sick >= smallHealthy {
+		t.Errorf("Expected poor health to shrink the litter, got %.2f vs %.2f", sick, smallHealthy)
+	}
+	if inbred := ExpectedLitterSize(small1, small1, 1.0); inbred > 1.0+1e-9 {
+		t.Errorf("Expected identical parents to have single births, got %.2f", inbred)
+	}
+}
+
+func TestLitterSize(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	g1 := ApproximateGenome(map[string]float64{"body_size": 0.0})
+	g2 := ApproximateGenome(map[string]float64{"body_size": 0.0, "loyalty": 1.0})
+
+	const draws = 2000
+	total := 0
+	for i := 0; i < draws; i++ {
+		size := LitterSize(g1, g2, 0.8, rng)
+		if size < 1 || size > MaxLitterSize {
+			t.Fatalf("Litter size %d outside 1..%d", size, MaxLitterSize)
+		}
+		total += size
+	}
+	mean := float64(total) / draws
+	if want := ExpectedLitterSize(g1, g2, 0.8); mean < want-0.1 || mean > want+0.1 {
+		t.Errorf("Expected a mean litter near %.2f, got %.2f", want, mean)
+	}
+}
+
+func TestBreedLitter(t *testing.T) {
+	p1, p2 := NewRandomGenome(), NewRandomGenome()
+	litter := BreedLitter(p1, p2, 3, 0.0)
+	if len(litter) != 3 {
+		t.Fatalf("Expected 3 offspring, got %d", len(litter))
+	}
+	if litter[0] == litter[1] {
+		t.Error("Each offspring should have its own genome")
+	}
+	for _, child := range litter {
+		if child.Generation != 1 {
+			t.Errorf("Expected generation 1, got %d", child.Generation)
+		}
+	}
+}
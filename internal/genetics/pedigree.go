@@ -0,0 +1,175 @@
+package genetics
+
+import (
+	"fmt"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// MaxPedigreeDepth is the number of ancestor generations kept in a pedigree
+const MaxPedigreeDepth = 6
+
+// Pedigree is a family tree rooted at a single pet
+type Pedigree struct {
+	ID         types.PetID `json:"id"`
+	Name       string      `json:"name"`
+	Generation int         `json:"generation"`
+	Mother     *Pedigree   `json:"mother,omitempty"`
+	Father     *Pedigree   `json:"father,omitempty"`
+	// Memorial marks an ancestor that no longer has a save of its own; it
+	// is kept by name so lineage and kinship checks still work
+	Memorial bool `json:"memorial,omitempty"`
+}
+
+// NewPedigree creates a pedigree for a pet with the given parents.
+// Parent pedigrees are copied and truncated to MaxPedigreeDepth.
+func NewPedigree(id types.PetID, name string, generation int, mother, father *Pedigree) *Pedigree {
+	return &Pedigree{
+		ID:         id,
+		Name:       name,
+		Generation: generation,
+		Mother:     mother.truncate(MaxPedigreeDepth - 1),
+		Father:     father.truncate(MaxPedigreeDepth - 1),
+	}
+}
+
+// MissingAncestors returns the ancestors that exists reports as gone and
+// that are not yet marked as memorials
+func (p *Pedigree) MissingAncestors(exists func(types.PetID) bool) []*Pedigree {
+	if p == nil {
+		return nil
+	}
+	var missing []*Pedigree
+	for _, parent := range []*Pedigree{p.Mother, p.Father} {
+		if parent == nil {
+			continue
+		}
+		if !parent.Memorial && !exists(parent.ID) {
+			missing = append(missing, parent)
+		}
+		missing = append(missing, parent.MissingAncestors(exists)...)
+	}
+	return missing
+}
+
+// truncate returns a copy of the pedigree limited to depth generations
+func (p *Pedigree) truncate(depth int) *Pedigree {
+	if p == nil || depth <= 0 {
+		return nil
+	}
+	return &Pedigree{
+		ID:         p.ID,
+		Name:       p.Name,
+		Generation: p.Generation,
+		Mother:     p.Mother.truncate(depth - 1),
+		Father:     p.Father.truncate(depth - 1),
+		Memorial:   p.Memorial,
+	}
+}
+
+// Ancestors returns every known ancestor with its distance in generations
+func (p *Pedigree) Ancestors() map[types.PetID]int {
+	ancestors := make(map[types.PetID]int)
+	p.collectAncestors(1, ancestors)
+	return ancestors
+}
+
+// collectAncestors walks the tree recording the closest distance to each ancestor
+func (p *Pedigree) collectAncestors(distance int, ancestors map[types.PetID]int) {
+	if p == nil {
+		return
+	}
+	for _, parent := range []*Pedigree{p.Mother, p.Father} {
+		if parent == nil {
+			continue
+		}
+		if existing, seen := ancestors[parent.ID]; !seen || distance < existing {
+			ancestors[parent.ID] = distance
+		}
+		parent.collectAncestors(distance+1, ancestors)
+	}
+}
+
+// Kinship returns the coefficient of kinship between two pets: the chance
+// that alleles drawn at random from each are identical by descent
+func Kinship(a, b *Pedigree) float64 {
+	if a == nil || b == nil {
+		return 0.0
+	}
+
+	if a.ID == b.ID {
+		return 0.5 * (1.0 + Kinship(a.Mother, a.Father))
+	}
+
+	// Recurse through the younger pet so an ancestor is never expanded
+	// past one of its own descendants
+	if a.Generation < b.Generation {
+		a, b = b, a
+	}
+	return 0.5 * (Kinship(a.Mother, b) + Kinship(a.Father, b))
+}
+
+// Relatedness returns the coefficient of relationship between two pets
+// (0.5 for parent/offspring and full siblings, 0.25 for grandparents and
+// half siblings, 0.125 for first cousins)
+func Relatedness(a, b *Pedigree) float64 {
+	return 2.0 * Kinship(a, b)
+}
+
+// InbreedingCoefficient returns the pedigree inbreeding coefficient of a pet
+func (p *Pedigree) InbreedingCoefficient() float64 {
+	if p == nil {
+		return 0.0
+	}
+	return Kinship(p.Mother, p.Father)
+}
+
+// KinshipPolicy controls how close relatives are treated when breeding
+type KinshipPolicy struct {
+	BlockThreshold float64 // Relatedness at or above which breeding is blocked
+	WarnThreshold  float64 // Relatedness at or above which a warning is issued
+	PenaltyPerUnit float64 // Compatibility score penalty per unit of relatedness
+}
+
+// DefaultKinshipPolicy blocks first-degree relatives and penalises closer kin
+func DefaultKinshipPolicy() KinshipPolicy {
+	return KinshipPolicy{
+		BlockThreshold: 0.5,
+		WarnThreshold:  0.125,
+		PenaltyPerUnit: 1.0,
+	}
+}
+
+// StrictKinshipPolicy blocks anything as close as half siblings or grandparents
+func StrictKinshipPolicy() KinshipPolicy {
+	return KinshipPolicy{
+		BlockThreshold: 0.25,
+		WarnThreshold:  0.0625,
+		PenaltyPerUnit: 1.5,
+	}
+}
+
+// LaxKinshipPolicy never blocks on relatedness and only applies a small penalty
+func LaxKinshipPolicy() KinshipPolicy {
+	return KinshipPolicy{
+		BlockThreshold: 2.0, // Above the maximum possible relatedness
+		WarnThreshold:  0.5,
+		PenaltyPerUnit: 0.25,
+	}
+}
+
+// Apply adjusts a compatibility result for the relatedness of the pair
+func (kp KinshipPolicy) Apply(compat *BreedingCompatibility, relatedness float64) {
+	if relatedness <= 0 {
+		return
+	}
+
+	compat.Score = clamp(compat.Score-relatedness*kp.PenaltyPerUnit, 0.0, 1.0)
+
+	if relatedness >= kp.BlockThreshold {
+		compat.Block(fmt.Sprintf("pets are too closely related (relatedness %.3f)", relatedness))
+	} else if relatedness >= kp.WarnThreshold {
+		compat.Warnings = append(compat.Warnings,
+			fmt.Sprintf("pets are close kin (relatedness %.3f)", relatedness))
+	}
+}
@@ -0,0 +1,135 @@
+package genetics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// family builds a small pedigree: two founders, two full siblings,
+// a grandchild and a cousin pair
+func family() map[string]*Pedigree {
+	mom := NewPedigree("mom", "Mom", 0, nil, nil)
+	dad := NewPedigree("dad", "Dad", 0, nil, nil)
+	sib1 := NewPedigree("sib1", "Sib1", 1, mom, dad)
+	sib2 := NewPedigree("sib2", "Sib2", 1, mom, dad)
+	out1 := NewPedigree("out1", "Out1", 0, nil, nil)
+	out2 := NewPedigree("out2", "Out2", 0, nil, nil)
+	cousin1 := NewPedigree("cousin1", "Cousin1", 2, sib1, out1)
+	cousin2 := NewPedigree("cousin2", "Cousin2", 2, sib2, out2)
+
+	return map[string]*Pedigree{
+		"mom": mom, "dad": dad, "sib1": sib1, "sib2": sib2,
+		"cousin1": cousin1, "cousin2": cousin2,
+	}
+}
+
+func TestRelatedness(t *testing.T) {
+	f := family()
+
+	tests := []struct {
+		name     string
+		a, b     *Pedigree
+		expected float64
+	}{
+		{"unrelated founders", f["mom"], f["dad"], 0.0},
+		{"parent and offspring", f["mom"], f["sib1"], 0.5},
+		{"full siblings", f["sib1"], f["sib2"], 0.5},
+		{"grandparent", f["dad"], f["cousin1"], 0.25},
+		{"aunt and niece", f["sib1"], f["cousin2"], 0.25},
+		{"first cousins", f["cousin1"], f["cousin2"], 0.125},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Relatedness(tt.a, tt.b)
+			if math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Relatedness() = %.4f, want %.4f", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInbreedingCoefficient(t *testing.T) {
+	f := family()
+
+	if f["sib1"].InbreedingCoefficient() != 0.0 {
+		t.Error("Child of unrelated founders should not be inbred")
+	}
+
+	inbred := NewPedigree("inbred", "Inbred", 2, f["sib1"], f["sib2"])
+	if got := inbred.InbreedingCoefficient(); got != 0.25 {
+		t.Errorf("Child of full siblings should have F=0.25, got %.4f", got)
+	}
+}
+
+func TestPedigreeTruncation(t *testing.T) {
+	p := NewPedigree("g0", "G0", 0, nil, nil)
+	for i := 1; i <= MaxPedigreeDepth+3; i++ {
+		p = NewPedigree("g", "G", i, p, nil)
+	}
+
+	ancestors := p.Ancestors()
+	deepest := 0
+	for _, distance := range ancestors {
+		if distance > deepest {
+			deepest = distance
+		}
+	}
+
+	if deepest >= MaxPedigreeDepth {
+		t.Errorf("Pedigree should be truncated below depth %d, found %d", MaxPedigreeDepth, deepest)
+	}
+}
+
+func TestKinshipPolicyApply(t *testing.T) {
+	compat := BreedingCompatibility{Score: 0.8, CanBreed: true}
+	DefaultKinshipPolicy().Apply(&compat, 0.5)
+	if compat.CanBreed {
+		t.Error("Default policy should block full siblings")
+	}
+
+	compat = BreedingCompatibility{Score: 0.8, CanBreed: true}
+	DefaultKinshipPolicy().Apply(&compat, 0.125)
+	if !compat.CanBreed || len(compat.Warnings) == 0 {
+		t.Error("Default policy should warn but allow first cousins")
+	}
+
+	compat = BreedingCompatibility{Score: 0.8, CanBreed: true}
+	StrictKinshipPolicy().Apply(&compat, 0.25)
+	if compat.CanBreed {
+		t.Error("Strict policy should block half siblings")
+	}
+
+	compat = BreedingCompatibility{Score: 0.8, CanBreed: true}
+	LaxKinshipPolicy().Apply(&compat, 0.5)
+	if !compat.CanBreed {
+		t.Error("Lax policy should not block on relatedness")
+	}
+	if compat.Score >= 0.8 {
+		t.Error("Lax policy should still apply a penalty")
+	}
+}
+
+func TestMissingAncestors(t *testing.T) {
+	grandmother := NewPedigree("gran", "Gran", 0, nil, nil)
+	mother := NewPedigree("mum", "Mum", 1, grandmother, nil)
+	father := NewPedigree("dad", "Dad", 1, nil, nil)
+	child := NewPedigree("kid", "Kid", 2, mother, father)
+
+	exists := func(id types.PetID) bool { return id == "mum" }
+	missing := child.MissingAncestors(exists)
+	if len(missing) != 2 || missing[0].ID != "gran" || missing[1].ID != "dad" {
+		t.Fatalf("Expected gran and dad missing, got %v", missing)
+	}
+	for _, ancestor := range missing {
+		ancestor.Memorial = true
+	}
+	if len(child.MissingAncestors(exists)) != 0 {
+		t.Error("Memorials should not be reported again")
+	}
+	if copied := NewPedigree("sib", "Sib", 3, child, nil); !copied.Mother.Father.Memorial {
+		t.Error("Expected memorial marks to be kept when a pedigree is copied")
+	}
+}
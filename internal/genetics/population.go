@@ -0,0 +1,298 @@
+package genetics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// AlleleBins is the number of value buckets used to classify trait alleles
+const AlleleBins = 5
+
+// AlleleClass identifies an allele variant for frequency counting.
+// Continuous allele values are bucketed so that near-identical alleles
+// count as the same variant.
+type AlleleClass struct {
+	Bin      int
+	Dominant bool
+}
+
+// String returns a compact label such as "D2" or "r4"
+func (c AlleleClass) String() string {
+	prefix := "r"
+	if c.Dominant {
+		prefix = "D"
+	}
+	return prefix + strconv.Itoa(c.Bin)
+}
+
+// classify returns the allele class for an allele
+func classify(a Allele) AlleleClass {
+	bin := int(a.Value * AlleleBins)
+	if bin >= AlleleBins {
+		bin = AlleleBins - 1
+	}
+	return AlleleClass{Bin: bin, Dominant: a.Dominant}
+}
+
+// LocusStats contains population statistics for a single trait locus
+type LocusStats struct {
+	Trait                  string
+	AlleleFrequencies      map[AlleleClass]float64
+	ObservedHeterozygosity float64 // Fraction of individuals carrying two different variants
+	ExpectedHeterozygosity float64 // Heterozygosity under random mating
+	InbreedingCoefficient  float64 // Wright's F: 1 - Ho/He
+	MeanValue              float64 // Average expressed trait value
+}
+
+// PopulationAnalyzer computes population genetics statistics across
+// a household or colony of pets
+type PopulationAnalyzer struct {
+	Genomes []*Genome
+}
+
+// NewPopulationAnalyzer creates an analyzer for a set of genomes
+func NewPopulationAnalyzer(genomes []*Genome) *PopulationAnalyzer {
+	return &PopulationAnalyzer{Genomes: genomes}
+}
+
+// Size returns the number of individuals in the population
+func (pa *PopulationAnalyzer) Size() int {
+	return len(pa.Genomes)
+}
+
+// AnalyzeLocus computes statistics for a single trait
+func (pa *PopulationAnalyzer) AnalyzeLocus(trait string) LocusStats {
+	stats := LocusStats{
+		Trait:             trait,
+		AlleleFrequencies: make(map[AlleleClass]float64),
+	}
+
+	if len(pa.Genomes) == 0 {
+		return stats
+	}
+
+	heterozygotes := 0
+	totalAlleles := 0.0
+	valueSum := 0.0
+
+	for _, g := range pa.Genomes {
+		pair := g.Traits[trait]
+		maternal := classify(pair.Maternal)
+		paternal := classify(pair.Paternal)
+
+		stats.AlleleFrequencies[maternal]++
+		stats.AlleleFrequencies[paternal]++
+		totalAlleles += 2
+
+		if maternal != paternal {
+			heterozygotes++
+		}
+
+		valueSum += pair.Expressed()
+	}
+
+	sumSquares := 0.0
+	for class, count := range stats.AlleleFrequencies {
+		freq := count / totalAlleles
+		stats.AlleleFrequencies[class] = freq
+		sumSquares += freq * freq
+	}
+
+	stats.ObservedHeterozygosity = float64(heterozygotes) / float64(len(pa.Genomes))
+	stats.ExpectedHeterozygosity = 1.0 - sumSquares
+	stats.MeanValue = valueSum / float64(len(pa.Genomes))
+
+	if stats.ExpectedHeterozygosity > 0 {
+		stats.InbreedingCoefficient = 1.0 - stats.ObservedHeterozygosity/stats.ExpectedHeterozygosity
+	}
+
+	return stats
+}
+
+// AnalyzeAllLoci computes statistics for every trait locus
+func (pa *PopulationAnalyzer) AnalyzeAllLoci() []LocusStats {
+	all := make([]LocusStats, 0, len(TraitNames))
+	for _, trait := range TraitNames {
+		all = append(all, pa.AnalyzeLocus(trait))
+	}
+	return all
+}
+
+// GeneticDiversity returns the mean expected heterozygosity across all loci
+// (0.0 = clonal population, approaching 1.0 = highly diverse)
+func (pa *PopulationAnalyzer) GeneticDiversity() float64 {
+	if len(pa.Genomes) == 0 {
+		return 0.0
+	}
+
+	total := 0.0
+	for _, stats := range pa.AnalyzeAllLoci() {
+		total += stats.ExpectedHeterozygosity
+	}
+	return total / float64(len(TraitNames))
+}
+
+// MeanInbreedingCoefficient returns the average inbreeding coefficient across loci
+func (pa *PopulationAnalyzer) MeanInbreedingCoefficient() float64 {
+	if len(pa.Genomes) == 0 {
+		return 0.0
+	}
+
+	total := 0.0
+	for _, stats := range pa.AnalyzeAllLoci() {
+		total += stats.InbreedingCoefficient
+	}
+	return total / float64(len(TraitNames))
+}
+
+// DisorderAlleleFrequencies returns the fraction of defective alleles for each disorder
+func (pa *PopulationAnalyzer) DisorderAlleleFrequencies() map[DisorderType]float64 {
+	freqs := make(map[DisorderType]float64)
+	if len(pa.Genomes) == 0 {
+		return freqs
+	}
+
+	for _, disorder := range AllDisorders() {
+		defective := 0
+		for _, g := range pa.Genomes {
+			locus := g.Disorders[disorder]
+			if locus.Maternal {
+				defective++
+			}
+			if locus.Paternal {
+				defective++
+			}
+		}
+		freqs[disorder] = float64(defective) / float64(len(pa.Genomes)*2)
+	}
+
+	return freqs
+}
+
+// TraitAveragesByGeneration returns mean expressed trait values for each generation
+func (pa *PopulationAnalyzer) TraitAveragesByGeneration() map[int]map[string]float64 {
+	sums := make(map[int]map[string]float64)
+	counts := make(map[int]int)
+
+	for _, g := range pa.Genomes {
+		if sums[g.Generation] == nil {
+			sums[g.Generation] = make(map[string]float64)
+		}
+		for _, trait := range TraitNames {
+			sums[g.Generation][trait] += g.GetTraitValue(trait)
+		}
+		counts[g.Generation]++
+	}
+
+	for gen, traits := range sums {
+		for trait := range traits {
+			traits[trait] /= float64(counts[gen])
+		}
+	}
+
+	return sums
+}
+
+// Generations returns the generation numbers present, in ascending order
+func (pa *PopulationAnalyzer) Generations() []int {
+	seen := make(map[int]bool)
+	var gens []int
+	for _, g := range pa.Genomes {
+		if !seen[g.Generation] {
+			seen[g.Generation] = true
+			gens = append(gens, g.Generation)
+		}
+	}
+	sort.Ints(gens)
+	return gens
+}
+
+// Report returns a human-readable population report
+func (pa *PopulationAnalyzer) Report() string {
+	report := fmt.Sprintf("=== Population Report (%d pets) ===\n", pa.Size())
+	if pa.Size() == 0 {
+		return report
+	}
+
+	report += fmt.Sprintf("Genetic Diversity: %.2f | Mean Inbreeding: %.2f\n",
+		pa.GeneticDiversity(), pa.MeanInbreedingCoefficient())
+	report += fmt.Sprintf("Generations: %v\n", pa.Generations())
+
+	report += "\nTrait          Mean   Ho     He     F\n"
+	for _, stats := range pa.AnalyzeAllLoci() {
+		report += fmt.Sprintf("%-14s %.2f   %.2f   %.2f   %+.2f\n", stats.Trait,
+			stats.MeanValue, stats.ObservedHeterozygosity, stats.ExpectedHeterozygosity,
+			stats.InbreedingCoefficient)
+	}
+
+	report += "\nDisorder allele frequencies:\n"
+	freqs := pa.DisorderAlleleFrequencies()
+	for _, disorder := range AllDisorders() {
+		report += fmt.Sprintf("  %s: %.0f%%\n", disorder, freqs[disorder]*100)
+	}
+
+	return report
+}
+
+// WriteCSV exports per-generation trait averages and per-locus statistics
+// as CSV rows for spreadsheet analysis
+func (pa *PopulationAnalyzer) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"section", "key", "trait", "value"}); err != nil {
+		return err
+	}
+
+	for _, stats := range pa.AnalyzeAllLoci() {
+		rows := [][]string{
+			{"locus", "mean", stats.Trait, formatFloat(stats.MeanValue)},
+			{"locus", "observed_heterozygosity", stats.Trait, formatFloat(stats.ObservedHeterozygosity)},
+			{"locus", "expected_heterozygosity", stats.Trait, formatFloat(stats.ExpectedHeterozygosity)},
+			{"locus", "inbreeding_coefficient", stats.Trait, formatFloat(stats.InbreedingCoefficient)},
+		}
+
+		classes := make([]AlleleClass, 0, len(stats.AlleleFrequencies))
+		for class := range stats.AlleleFrequencies {
+			classes = append(classes, class)
+		}
+		sort.Slice(classes, func(i, j int) bool {
+			return classes[i].String() < classes[j].String()
+		})
+		for _, class := range classes {
+			rows = append(rows, []string{"allele", class.String(), stats.Trait, formatFloat(stats.AlleleFrequencies[class])})
+		}
+
+		if err := writer.WriteAll(rows); err != nil {
+			return err
+		}
+	}
+
+	averages := pa.TraitAveragesByGeneration()
+	for _, gen := range pa.Generations() {
+		for _, trait := range TraitNames {
+			row := []string{"generation", strconv.Itoa(gen), trait, formatFloat(averages[gen][trait])}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	freqs := pa.DisorderAlleleFrequencies()
+	for _, disorder := range AllDisorders() {
+		row := []string{"disorder", disorder.String(), "", formatFloat(freqs[disorder])}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatFloat formats a float for CSV output
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
@@ -0,0 +1,106 @@
+package genetics
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeLocusClonal(t *testing.T) {
+	founder := NewRandomGenome()
+	founder.Traits["openness"] = GenePair{Allele{Value: 0.5, Dominant: true}, Allele{Value: 0.5, Dominant: true}}
+
+	pa := NewPopulationAnalyzer([]*Genome{founder, founder, founder})
+	stats := pa.AnalyzeLocus("openness")
+
+	if stats.ExpectedHeterozygosity != 0.0 {
+		t.Errorf("Clonal homozygous population should have no heterozygosity, got %.2f", stats.ExpectedHeterozygosity)
+	}
+
+	if len(stats.AlleleFrequencies) != 1 {
+		t.Errorf("Expected a single allele variant, got %d", len(stats.AlleleFrequencies))
+	}
+}
+
+func TestAnalyzeLocusInbreeding(t *testing.T) {
+	a := NewGenome()
+	a.Traits["loyalty"] = GenePair{Allele{Value: 0.1, Dominant: true}, Allele{Value: 0.1, Dominant: true}}
+	b := NewGenome()
+	b.Traits["loyalty"] = GenePair{Allele{Value: 0.9, Dominant: false}, Allele{Value: 0.9, Dominant: false}}
+
+	pa := NewPopulationAnalyzer([]*Genome{a, b})
+	stats := pa.AnalyzeLocus("loyalty")
+
+	if stats.ObservedHeterozygosity != 0.0 {
+		t.Errorf("All individuals are homozygous, got Ho %.2f", stats.ObservedHeterozygosity)
+	}
+
+	if stats.InbreedingCoefficient != 1.0 {
+		t.Errorf("Homozygous population with two variants should have F=1, got %.2f", stats.InbreedingCoefficient)
+	}
+}
+
+func TestTraitAveragesByGeneration(t *testing.T) {
+	g0 := NewRandomGenome()
+	g1 := NewRandomGenome()
+	g1.Generation = 1
+
+	pa := NewPopulationAnalyzer([]*Genome{g0, g1})
+	averages := pa.TraitAveragesByGeneration()
+
+	if len(averages) != 2 {
+		t.Fatalf("Expected 2 generations, got %d", len(averages))
+	}
+
+	if averages[1]["curiosity"] != g1.GetTraitValue("curiosity") {
+		t.Error("Generation average should match single member")
+	}
+}
+
+func TestDisorderAlleleFrequencies(t *testing.T) {
+	a := NewGenome()
+	a.Disorders[DisorderHeartDefect] = DisorderLocus{Maternal: true}
+	b := NewGenome()
+
+	freqs := NewPopulationAnalyzer([]*Genome{a, b}).DisorderAlleleFrequencies()
+
+	if freqs[DisorderHeartDefect] != 0.25 {
+		t.Errorf("Expected 25%% defective allele frequency, got %.2f", freqs[DisorderHeartDefect])
+	}
+}
+
+func TestPopulationReportAndCSV(t *testing.T) {
+	pa := NewPopulationAnalyzer([]*Genome{NewRandomGenome(), NewRandomGenome()})
+
+	report := pa.Report()
+	if !strings.Contains(report, "Genetic Diversity") {
+		t.Error("Report should include diversity summary")
+	}
+
+	var buf bytes.Buffer
+	if err := pa.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV output should be valid: %v", err)
+	}
+
+	if len(records) < len(TraitNames) {
+		t.Errorf("Expected at least %d rows, got %d", len(TraitNames), len(records))
+	}
+}
+
+func TestEmptyPopulation(t *testing.T) {
+	pa := NewPopulationAnalyzer(nil)
+
+	if pa.GeneticDiversity() != 0.0 {
+		t.Error("Empty population should have zero diversity")
+	}
+
+	if pa.Report() == "" {
+		t.Error("Empty population should still produce a report header")
+	}
+}
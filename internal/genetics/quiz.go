@@ -0,0 +1,130 @@
+package genetics
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidAnswer is returned when a quiz answer does not match a question
+var ErrInvalidAnswer = errors.New("invalid quiz answer")
+
+// QuizBias is how far the creation quiz pulls each allele toward the trait
+// value asked for; the rest of the allele stays random
+const QuizBias = 0.6
+
+// QuizAnswer is one answer to a creation quiz question
+type QuizAnswer struct {
+	Text   string
+	Traits map[string]float64 // Trait values the answer asks for
+}
+
+// QuizQuestion is a creation quiz question about the companion the player
+// would like
+type QuizQuestion struct {
+	Prompt  string
+	Answers []QuizAnswer
+}
+
+// CreationQuiz lists the questions asked before the first pet is created
+var CreationQuiz = []QuizQuestion{
+	{
+		Prompt: "How should your companion spend a free afternoon?",
+		Answers: []QuizAnswer{
+			{Text: "Racing around until they drop", Traits: map[string]float64{"energy_level": 0.9, "playfulness": 0.8}},
+			{Text: "Curled up somewhere warm", Traits: map[string]float64{"energy_level": 0.2, "playfulness": 0.35}},
+			{Text: "A bit of both", Traits: map[string]float64{"energy_level": 0.5, "playfulness": 0.6}},
+		},
+	},
+	{
+		Prompt: "When you sit down, your companion...",
+		Answers: []QuizAnswer{
+			{Text: "climbs straight into your lap", Traits: map[string]float64{"affectionate": 0.9, "independence": 0.2}},
+			{Text: "settles nearby, in their own space", Traits: map[string]float64{"affectionate": 0.4, "independence": 0.8}},
+		},
+	},
+	{
+		Prompt: "A new toy arrives. Your companion...",
+		Answers: []QuizAnswer{
+			{Text: "investigates every inch of it", Traits: map[string]float64{"curiosity": 0.9, "openness": 0.8}},
+			{Text: "waits to see if it's safe", Traits: map[string]float64{"curiosity": 0.3, "neuroticism": 0.6}},
+			{Text: "only cares if you play too", Traits: map[string]float64{"loyalty": 0.85, "curiosity": 0.5}},
+		},
+	},
+	{
+		Prompt: "Visitors come over. Your companion...",
+		Answers: []QuizAnswer{
+			{Text: "greets everyone at the door", Traits: map[string]float64{"extraversion": 0.9, "agreeableness": 0.8}},
+			{Text: "watches from a safe distance", Traits: map[string]float64{"extraversion": 0.2, "agreeableness": 0.5}},
+			{Text: "keeps an eye on you and the house", Traits: map[string]float64{"territoriality": 0.8, "loyalty": 0.8}},
+		},
+	},
+	{
+		Prompt: "How chatty should they be?",
+		Answers: []QuizAnswer{
+			{Text: "Always something to say", Traits: map[string]float64{"vocalization": 0.9}},
+			{Text: "The strong, silent type", Traits: map[string]float64{"vocalization": 0.15}},
+		},
+	},
+	{
+		Prompt: "When it comes to learning tricks...",
+		Answers: []QuizAnswer{
+			{Text: "a quick study who loves a challenge", Traits: map[string]float64{"intelligence": 0.85, "conscientiousness": 0.7}},
+			{Text: "they do things their own way", Traits: map[string]float64{"conscientiousness": 0.25, "independence": 0.7}},
+		},
+	},
+	{
+		Prompt: "When plans change suddenly...",
+		Answers: []QuizAnswer{
+			{Text: "they roll with it", Traits: map[string]float64{"adaptability": 0.85, "neuroticism": 0.2}},
+			{Text: "they'd rather keep to their routine", Traits: map[string]float64{"adaptability": 0.3, "conscientiousness": 0.7}},
+		},
+	},
+}
+
+// QuizTargets combines answers to a quiz into trait targets. answers[i] is
+// the index of the answer to question i, or -1 if it was skipped; missing
+// answers count as skipped. A trait asked for by several answers gets
+// their average.
+func QuizTargets(quiz []QuizQuestion, answers []int) (map[string]float64, error) {
+	if len(answers) > len(quiz) {
+		return nil, fmt.Errorf("%w: %d answers to %d questions", ErrInvalidAnswer, len(answers), len(quiz))
+	}
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for i, answer := range answers {
+		if answer == -1 {
+			continue
+		}
+		if answer < 0 || answer >= len(quiz[i].Answers) {
+			return nil, fmt.Errorf("%w: %d to question %d", ErrInvalidAnswer, answer+1, i+1)
+		}
+		for name, value := range quiz[i].Answers[answer].Traits {
+			sums[name] += value
+			counts[name]++
+		}
+	}
+
+	targets := make(map[string]float64, len(sums))
+	for name, sum := range sums {
+		targets[name] = sum / float64(counts[name])
+	}
+	return targets, nil
+}
+
+// BiasedGenome creates a founder genome whose alleles are pulled toward
+// target trait values by bias, from 0 (fully random) to 1 (exactly the
+// targets). Traits without a target are random.
+func BiasedGenome(targets map[string]float64, bias float64) *Genome {
+	bias = clamp(bias, 0.0, 1.0)
+	g := NewRandomGenome()
+	for name, target := range targets {
+		pair, exists := g.Traits[name]
+		if !exists {
+			continue
+		}
+		pair.Maternal.Value += (target - pair.Maternal.Value) * bias
+		pair.Paternal.Value += (target - pair.Paternal.Value) * bias
+		g.Traits[name] = pair
+	}
+	return g
+}
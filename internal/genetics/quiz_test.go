@@ -0,0 +1,81 @@
+package genetics
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCreationQuizTraitsExist(t *testing.T) {
+	known := make(map[string]bool)
+	for _, name := range TraitNames {
+		known[name] = true
+	}
+	if n := len(CreationQuiz); n < 6 || n > 8 {
+		t.Errorf("Expected 6 to 8 questions, got %d", n)
+	}
+	for _, question := range CreationQuiz {
+		for _, answer := range question.Answers {
+			for name := range answer.Traits {
+				if !known[name] {
+					t.Errorf("%q: %q asks for unknown trait %s", question.Prompt, answer.Text, name)
+				}
+			}
+		}
+	}
+}
+
+func TestQuizTargets(t *testing.T) {
+	quiz := []QuizQuestion{
+		{Answers: []QuizAnswer{{Traits: map[string]float64{"loyalty": 0.9}}, {Traits: map[string]float64{"loyalty": 0.1}}}},
+		{Answers: []QuizAnswer{{Traits: map[string]float64{"loyalty": 0.5, "curiosity": 0.8}}}},
+		{Answers: []QuizAnswer{{Traits: map[string]float64{"vocalization": 0.9}}}},
+	}
+
+	targets, err := QuizTargets(quiz, []int{0, 0, -1})
+	if err != nil {
+		t.Fatalf("QuizTargets failed: %v", err)
+	}
+	if math.Abs(targets["loyalty"]-0.7) > 1e-9 || targets["curiosity"] != 0.8 {
+		t.Errorf("Expected averaged targets, got %v", targets)
+	}
+	if _, asked := targets["vocalization"]; asked {
+		t.Error("A skipped question should not set a target")
+	}
+
+	if _, err := QuizTargets(quiz, []int{2}); !errors.Is(err, ErrInvalidAnswer) {
+		t.Errorf("Expected ErrInvalidAnswer for an answer out of range, got %v", err)
+	}
+	if _, err := QuizTargets(quiz, []int{0, 0, 0, 0}); !errors.Is(err, ErrInvalidAnswer) {
+		t.Errorf("Expected ErrInvalidAnswer for too many answers, got %v", err)
+	}
+}
+
+func TestBiasedGenome(t *testing.T) {
+	const founders = 200
+	total := 0.0
+	varied := false
+	var first float64
+	for i := 0; i < founders; i++ {
+		value := BiasedGenome(map[string]float64{"energy_level": 0.9}, QuizBias).GetTraitValue("energy_level")
+		total += value
+		if i == 0 {
+			first = value
+		} else if value != first {
+			varied = true
+		}
+	}
+
+	// Random alleles average 0.5, so the biased average is 0.5 + 0.4*bias
+	if mean := total / founders; math.Abs(mean-(0.5+0.4*QuizBias)) > 0.05 {
+		t.Errorf("Expected energy pulled toward the answer, mean %.3f", mean)
+	}
+	if !varied {
+		t.Error("Quiz pets should keep some randomness")
+	}
+
+	exact := BiasedGenome(map[string]float64{"loyalty": 0.3}, 1.0)
+	if got := exact.GetTraitValue("loyalty"); math.Abs(got-0.3) > 1e-9 {
+		t.Errorf("Full bias should give the target, got %.3f", got)
+	}
+}
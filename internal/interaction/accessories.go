@@ -0,0 +1,106 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrNothingWorn is returned when taking off an accessory a pet is not wearing
+var ErrNothingWorn = errors.New("not wearing anything there")
+
+// BuyAccessory spends coins on an accessory for the inventory
+func (h *Household) BuyAccessory(accessory environment.Accessory) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	price, err := accessory.Price()
+	if err != nil {
+		return err
+	}
+	if err := h.Inventory.Spend(price); err != nil {
+		return err
+	}
+	h.Inventory.AddAccessory(accessory)
+	return nil
+}
+
+// StoreAccessory puts an accessory in the inventory, e.g. one claimed from
+// the marketplace
+func (h *Household) StoreAccessory(accessory environment.Accessory) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Inventory.AddAccessory(accessory)
+}
+
+// TakeAccessory removes a spare accessory from the inventory, e.g. to
+// offer it on the marketplace
+func (h *Household) TakeAccessory(accessory environment.Accessory) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.Inventory.TakeAccessory(accessory)
+}
+
+// DressUp puts an accessory from the inventory on a present pet and
+// returns how the pet feels about it. Anything the pet already wore in
+// the same slot goes back in the inventory.
+func (h *Household) DressUp(petID types.PetID, accessory environment.Accessory) (core.AccessoryReaction, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, present := h.Pets[petID]
+	if !present {
+		return 0, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	if err := h.Inventory.TakeAccessory(accessory); err != nil {
+		return 0, err
+	}
+	if previous, replaced := pet.Equip(accessory); replaced {
+		h.Inventory.AddAccessory(previous)
+	}
+	return pet.ReactToAccessory(accessory), nil
+}
+
+// Undress takes off what a present pet wears in a slot and puts it back in
+// the inventory
+func (h *Household) Undress(petID types.PetID, slot environment.AccessorySlot) (environment.Accessory, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, present := h.Pets[petID]
+	if !present {
+		return 0, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	accessory, worn := pet.Unequip(slot)
+	if !worn {
+		return 0, fmt.Errorf("%w: %s's %s", ErrNothingWorn, pet.Name, slot)
+	}
+	h.Inventory.AddAccessory(accessory)
+	return accessory, nil
+}
+
+// grantAccessory adds an earned accessory to the inventory and returns a
+// sentence telling the player (must be called with lock held)
+func (h *Household) grantAccessory(accessory environment.Accessory) string {
+	h.Inventory.AddAccessory(accessory)
+	return fmt.Sprintf(" A %s was added to your wardrobe.", accessory)
+}
+
+// updateAccessories lets pets that fuss about what they wear shake it off
+// now and then. The accessory lands back in the inventory (must be called
+// with lock held).
+func (h *Household) updateAccessories(deltaTime float64) {
+	for _, pet := range h.Pets {
+		accessory, worn := pet.Wearing(environment.SlotHead)
+		if !worn || !pet.IsAlive() || !pet.FussesAbout(accessory) {
+			continue
+		}
+		if h.rng.Float64() >= core.AccessoryShakeOffChance*deltaTime {
+			continue
+		}
+		pet.Unequip(environment.SlotHead)
+		h.Inventory.AddAccessory(accessory)
+		h.Inbox.Post(MessageSystem, pet.ID, fmt.Sprintf("%s shook off their %s", pet.Name, accessory),
+			fmt.Sprintf("%s would rather not wear a %s. It is back in your wardrobe.", pet.Name, accessory))
+	}
+}
@@ -0,0 +1,97 @@
+package interaction
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestDressUpAndUndress(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	if _, err := h.DressUp(pet.ID, environment.AccessoryHat); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock without a hat, got %v", err)
+	}
+	if err := h.BuyAccessory(environment.AccessoryRosette); !errors.Is(err, environment.ErrNotForSale) {
+		t.Errorf("Expected rosettes to be earned only, got %v", err)
+	}
+
+	coins := h.Inventory.Coins
+	if err := h.BuyAccessory(environment.AccessoryHat); err != nil {
+		t.Fatal(err)
+	}
+	price, _ := environment.AccessoryHat.Price()
+	if h.Inventory.Coins != coins-price {
+		t.Errorf("Expected the hat to cost %d coins, have %d of %d", price, h.Inventory.Coins, coins)
+	}
+	h.Inventory.AddAccessory(environment.AccessoryBow)
+	if _, err := h.DressUp(pet.ID, environment.AccessoryHat); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.DressUp(pet.ID, environment.AccessoryBow); err != nil {
+		t.Fatal(err)
+	}
+	if h.Inventory.Accessories[environment.AccessoryHat] != 1 {
+		t.Error("Expected the replaced hat back in the inventory")
+	}
+
+	if _, err := h.Undress(pet.ID, environment.SlotNeck); !errors.Is(err, ErrNothingWorn) {
+		t.Errorf("Expected ErrNothingWorn, got %v", err)
+	}
+	if accessory, err := h.Undress(pet.ID, environment.SlotHead); err != nil || accessory != environment.AccessoryBow {
+		t.Errorf("Expected the bow taken off, got %v (%v)", accessory, err)
+	}
+	if _, err := h.DressUp("missing", environment.AccessoryBow); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+}
+
+func TestIndependentPetsShakeOffHats(t *testing.T) {
+	loner := core.NewDigitalPet("Loner", "user123")
+	loner.Personality.Traits.Independence = 1.0
+	easygoing := core.NewDigitalPet("Easy", "user123")
+	easygoing.Personality.Traits.Independence = 0.0
+	h := NewHousehold(loner, easygoing)
+	h.rng = rand.New(rand.NewSource(1))
+	loner.Equip(environment.AccessoryHat)
+	easygoing.Equip(environment.AccessoryHat)
+
+	for day := 0; day < 40; day++ {
+		h.Update(1.0)
+	}
+	if _, worn := loner.Wearing(environment.SlotHead); worn {
+		t.Error("Expected the independent pet to shake off the hat eventually")
+	}
+	if _, worn := easygoing.Wearing(environment.SlotHead); !worn {
+		t.Error("Expected the easygoing pet to keep the hat on")
+	}
+	if h.Inventory.Accessories[environment.AccessoryHat] != 1 {
+		t.Error("Expected the shaken-off hat back in the inventory")
+	}
+}
+
+func TestFestivalsAndMilestonesGrantAccessories(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.Environment = environment.NewSeededManager(environment.DefaultWorldMap(), 1)
+	h.Environment.StartEvent(environment.EventFestival, "town")
+	h.Update(0.1)
+	if h.Inventory.Accessories[environment.AccessoryPartyHat] != 1 {
+		t.Error("Expected a party hat for the festival")
+	}
+
+	for i := 0; i < 10; i++ {
+		if _, err := h.Interact(pet.ID, types.InteractionFeeding, 0.1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h.Celebrate(time.Now())
+	if h.Inventory.Accessories[environment.AccessoryRosette] != 1 {
+		t.Error("Expected a rosette for the milestone")
+	}
+}
@@ -0,0 +1,261 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrNotAdoptable is returned when adopting a pet that is not at the adoption center
+	ErrNotAdoptable = errors.New("no such pet at the adoption center")
+	// ErrNoAdoptionCenter is returned when the household has no adoption center
+	ErrNoAdoptionCenter = errors.New("no adoption center available")
+)
+
+// Adoption settings
+const (
+	AdoptionRosterSize    = 4                    // Pets waiting at the adoption center
+	AdoptionRotationDays  = 2.0                  // Game days between new arrivals
+	AdoptionFee           = 120                  // Coins to adopt a healthy juvenile
+	AdoptionConditionRate = 0.25                 // Chance an arrival has a pre-existing condition
+	AdoptionMaxAge        = biology.ElderAge + 5 // Oldest arrival, in game days
+	QuirkTraitThreshold   = 0.65                 // How strong a trait must be to shape a quirk
+	TagAdoption           = "adoption"
+)
+
+// adoptionNames are the names arrivals are given at the center
+var adoptionNames = []string{
+	"Biscuit", "Pepper", "Maple", "Juniper", "Olive", "Pip", "Clover", "Socks",
+	"Mochi", "Bramble", "Tofu", "Willow", "Nutmeg", "Ziggy", "Hazel", "Rascal",
+}
+
+// adoptionBackstories describe how an arrival came to the center; %s is
+// the pet's name
+var adoptionBackstories = []string{
+	"%s was found wandering near the old mill and has been waiting for a home ever since.",
+	"%s's family moved overseas and could not bring them along.",
+	"%s grew up on a busy farm and misses having company.",
+	"%s was handed in by a neighbour who found them sheltering from a storm.",
+	"%s lived with an elderly owner who can no longer look after them.",
+	"%s was adopted once before, but it did not work out through no fault of their own.",
+}
+
+// quirk is a habit an arrival with a strong trait may have
+type quirk struct {
+	trait func(t *ai.Traits) float64 // Nil for quirks any pet may have
+	text  string
+}
+
+// adoptionQuirks lists the quirks arrivals can have
+var adoptionQuirks = []quirk{
+	{func(t *ai.Traits) float64 { return t.Playfulness }, "turns every sock into a toy"},
+	{func(t *ai.Traits) float64 { return t.Curiosity }, "has to sniff every new bag that comes through the door"},
+	{func(t *ai.Traits) float64 { return t.Affectionate }, "leans against your legs whenever you stand still"},
+	{func(t *ai.Traits) float64 { return t.Independence }, "prefers to nap alone on the highest shelf"},
+	{func(t *ai.Traits) float64 { return t.Neuroticism }, "hides from the vacuum cleaner, even when it is off"},
+	{func(t *ai.Traits) float64 { return t.Vocalization }, "hums quietly while eating"},
+	{func(t *ai.Traits) float64 { return t.Territoriality }, "guards the food bowl long after it is empty"},
+	{nil, "sleeps on their back with all four paws in the air"},
+	{nil, "sneezes when excited"},
+	{nil, "chases their own shadow at sunset"},
+}
+
+// Adoptable is a pet waiting at the adoption center
+type Adoptable struct {
+	Pet        *core.DigitalPet
+	Backstory  string
+	Quirk      string
+	Conditions []genetics.DisorderType // Pre-existing conditions found by the center's screening
+	Fee        int                     // Coins to adopt
+	Sanctuary  bool                    // Raised at the sanctuary for its patrons
+}
+
+// AdoptionCenter keeps a rotating roster of generated pets the player can
+// adopt instead of raising a newborn
+type AdoptionCenter struct {
+	Roster []*Adoptable
+
+	untilArrival float64 // Game days until the next rotation
+	rng          *rand.Rand
+}
+
+// NewAdoptionCenter creates an adoption center seeded from the current time
+func NewAdoptionCenter() *AdoptionCenter {
+	return NewSeededAdoptionCenter(time.Now().UnixNano())
+}
+
+// NewSeededAdoptionCenter creates a deterministic adoption center for testing
+func NewSeededAdoptionCenter(seed int64) *AdoptionCenter {
+	ac := &AdoptionCenter{
+		untilArrival: AdoptionRotationDays,
+		rng:          rand.New(rand.NewSource(seed)),
+	}
+	ac.fill()
+	return ac
+}
+
+// Update advances the center's clock. Every AdoptionRotationDays the
+// longest-waiting pet finds a home elsewhere and new pets arrive to fill
+// the roster. Returns the pets that arrived.
+func (ac *AdoptionCenter) Update(deltaTime float64) []*Adoptable {
+	ac.untilArrival -= deltaTime
+	if ac.untilArrival > 0 {
+		return nil
+	}
+	ac.untilArrival += AdoptionRotationDays
+	if len(ac.Roster) >= AdoptionRosterSize {
+		ac.Roster = ac.Roster[1:]
+	}
+	return ac.fill()
+}
+
+// Find looks up a waiting pet by case-insensitive name
+func (ac *AdoptionCenter) Find(name string) (*Adoptable, error) {
+	for _, adoptable := range ac.Roster {
+		if strings.EqualFold(adoptable.Pet.Name, name) {
+			return adoptable, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrNotAdoptable, name)
+}
+
+// remove takes a pet off the roster
+func (ac *AdoptionCenter) remove(adoptable *Adoptable) {
+	for i, waiting := range ac.Roster {
+		if waiting == adoptable {
+			ac.Roster = append(ac.Roster[:i], ac.Roster[i+1:]...)
+			return
+		}
+	}
+}
+
+// fill generates arrivals until the roster is full and returns them
+func (ac *AdoptionCenter) fill() []*Adoptable {
+	var arrivals []*Adoptable
+	for len(ac.Roster) < AdoptionRosterSize {
+		adoptable := ac.generate()
+		ac.Roster = append(ac.Roster, adoptable)
+		arrivals = append(arrivals, adoptable)
+	}
+	return arrivals
+}
+
+// generate creates an arrival: a founder of any age past babyhood with a
+// backstory, a quirk shaped by its personality and sometimes a genetic
+// condition
+func (ac *AdoptionCenter) generate() *Adoptable {
+	genome := genetics.NewRandomGenome()
+	if ac.rng.Float64() < AdoptionConditionRate {
+		disorders := genetics.AllDisorders()
+		genome.Disorders[disorders[ac.rng.Intn(len(disorders))]] = genetics.DisorderLocus{Maternal: true, Paternal: true}
+	}
+
+	pet := core.NewDigitalPetFromGenome(ac.freeName(), "", genome)
+	pet.Biology.Processes.Age = biology.JuvenileAge + ac.rng.Float64()*(AdoptionMaxAge-biology.JuvenileAge)
+	report := pet.ScreenGenetics()
+
+	return &Adoptable{
+		Pet:        pet,
+		Backstory:  fmt.Sprintf(adoptionBackstories[ac.rng.Intn(len(adoptionBackstories))], pet.Name),
+		Quirk:      ac.quirk(pet.Personality.Traits),
+		Conditions: report.Affected,
+		Fee:        adoptionFee(pet.Biology.GetLifeStage(), len(report.Affected)),
+	}
+}
+
+// freeName picks a name no waiting pet has
+func (ac *AdoptionCenter) freeName() string {
+	free := make([]string, 0, len(adoptionNames))
+	for _, name := range adoptionNames {
+		if _, err := ac.Find(name); err != nil {
+			free = append(free, name)
+		}
+	}
+	return free[ac.rng.Intn(len(free))]
+}
+
+// quirk picks a quirk matching one of the pet's strong traits, or one any
+// pet may have
+func (ac *AdoptionCenter) quirk(traits *ai.Traits) string {
+	var fitting []string
+	for _, q := range adoptionQuirks {
+		if q.trait == nil || q.trait(traits) >= QuirkTraitThreshold {
+			fitting = append(fitting, q.text)
+		}
+	}
+	return fitting[ac.rng.Intn(len(fitting))]
+}
+
+// adoptionFee returns the fee for a pet: older pets and pets with
+// conditions cost less, so they still find homes
+func adoptionFee(stage types.LifeStage, conditions int) int {
+	fee := float64(AdoptionFee)
+	switch stage {
+	case types.LifeStageAdult:
+		fee *= 0.75
+	case types.LifeStageElder:
+		fee *= 0.4
+	}
+	fee *= math.Pow(0.5, float64(conditions))
+	return int(math.Round(fee))
+}
+
+// Adopt pays the fee for a waiting pet from the household inventory and
+// brings it home
+func (h *Household) Adopt(name string, owner types.UserID) (*Adoptable, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.Adoption == nil {
+		return nil, ErrNoAdoptionCenter
+	}
+	adoptable, err := h.Adoption.Find(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.full(); err != nil {
+		return nil, err
+	}
+	if err := h.Inventory.Spend(adoptable.Fee); err != nil {
+		return nil, err
+	}
+
+	h.Adoption.remove(adoptable)
+	pet := adoptable.Pet
+	pet.Owner = owner
+	pet.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: fmt.Sprintf("%s was adopted and brought home.", pet.Name),
+		GameTime:    pet.GetAge(),
+		Strength:    0.8,
+		Valence:     0.7,
+		Emotion:     pet.Emotions.DominantEmotion,
+		Tags:        []string{TagAdoption},
+	})
+	h.join(pet)
+	return adoptable, nil
+}
+
+// updateAdoption rotates the adoption center and announces new arrivals
+// (must be called with lock held)
+func (h *Household) updateAdoption(deltaTime float64) {
+	arrivals := h.Adoption.Update(deltaTime)
+	if len(arrivals) == 0 {
+		return
+	}
+	names := make([]string, len(arrivals))
+	for i, adoptable := range arrivals {
+		names[i] = adoptable.Pet.Name
+	}
+	h.Inbox.Post(MessageSystem, "", "New arrivals at the adoption center",
+		fmt.Sprintf("%s arrived and would love a home. Use `adopt` to meet them.", strings.Join(names, ", ")))
+}
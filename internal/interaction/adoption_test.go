@@ -0,0 +1,116 @@
+package interaction
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestAdoptionRoster(t *testing.T) {
+	ac := NewSeededAdoptionCenter(1)
+	if len(ac.Roster) != AdoptionRosterSize {
+		t.Fatalf("Expected %d pets waiting, got %d", AdoptionRosterSize, len(ac.Roster))
+	}
+
+	names := make(map[string]bool)
+	for _, adoptable := range ac.Roster {
+		pet := adoptable.Pet
+		if names[pet.Name] {
+			t.Errorf("Duplicate name %s on the roster", pet.Name)
+		}
+		names[pet.Name] = true
+		if pet.GetAge() < biology.JuvenileAge {
+			t.Errorf("%s should be past babyhood, is %.1f days old", pet.Name, pet.GetAge())
+		}
+		if !strings.Contains(adoptable.Backstory, pet.Name) || adoptable.Quirk == "" {
+			t.Errorf("%s needs a backstory and a quirk: %+v", pet.Name, adoptable)
+		}
+		if pet.Screening == nil || len(pet.Screening.Affected) != len(adoptable.Conditions) {
+			t.Errorf("%s's conditions should come from a screening", pet.Name)
+		}
+		if adoptable.Fee <= 0 || adoptable.Fee > AdoptionFee {
+			t.Errorf("Unexpected fee %d for %s", adoptable.Fee, pet.Name)
+		}
+	}
+}
+
+func TestAdoptionFee(t *testing.T) {
+	young := adoptionFee(types.LifeStageJuvenile, 0)
+	if young != AdoptionFee {
+		t.Errorf("Expected the full fee for a healthy juvenile, got %d", young)
+	}
+	if elder := adoptionFee(types.LifeStageElder, 0); elder >= young {
+		t.Errorf("Elders should cost less: %d vs %d", elder, young)
+	}
+	if unwell := adoptionFee(types.LifeStageJuvenile, 1); unwell >= young {
+		t.Errorf("Pets with conditions should cost less: %d vs %d", unwell, young)
+	}
+}
+
+func TestAdoptionRotation(t *testing.T) {
+	ac := NewSeededAdoptionCenter(1)
+	first := ac.Roster[0]
+
+	if arrivals := ac.Update(AdoptionRotationDays / 2); len(arrivals) != 0 {
+		t.Fatalf("Expected no arrivals yet, got %d", len(arrivals))
+	}
+	arrivals := ac.Update(AdoptionRotationDays / 2)
+	if len(arrivals) != 1 || len(ac.Roster) != AdoptionRosterSize {
+		t.Fatalf("Expected one arrival and a full roster, got %d and %d", len(arrivals), len(ac.Roster))
+	}
+	if _, err := ac.Find(first.Pet.Name); !errors.Is(err, ErrNotAdoptable) {
+		t.Error("The longest-waiting pet should have found a home elsewhere")
+	}
+}
+
+func TestHouseholdAdopt(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	if _, err := h.Adopt("Anyone", "user123"); !errors.Is(err, ErrNoAdoptionCenter) {
+		t.Errorf("Expected ErrNoAdoptionCenter, got %v", err)
+	}
+
+	h.Adoption = NewSeededAdoptionCenter(1)
+	if _, err := h.Adopt("Nobody", "user123"); !errors.Is(err, ErrNotAdoptable) {
+		t.Errorf("Expected ErrNotAdoptable, got %v", err)
+	}
+
+	waiting := h.Adoption.Roster[0]
+	h.Inventory.Coins = waiting.Fee - 1
+	if _, err := h.Adopt(waiting.Pet.Name, "user123"); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock without enough coins, got %v", err)
+	}
+
+	h.Inventory.Coins = waiting.Fee
+	adopted, err := h.Adopt(strings.ToLower(waiting.Pet.Name), "user123")
+	if err != nil {
+		t.Fatalf("Adopt failed: %v", err)
+	}
+	if adopted.Pet.Owner != "user123" || h.Pets[adopted.Pet.ID] != adopted.Pet {
+		t.Error("The adopted pet should join the household with its new owner")
+	}
+	if h.Inventory.Coins != 0 || len(h.Adoption.Roster) != AdoptionRosterSize-1 {
+		t.Errorf("Expected the fee paid and the pet gone from the roster, got %d coins and %d waiting",
+			h.Inventory.Coins, len(h.Adoption.Roster))
+	}
+	if len(adopted.Pet.Memory.ByTag(TagAdoption)) != 1 {
+		t.Error("The pet should remember being adopted")
+	}
+
+	h.Update(AdoptionRotationDays)
+	if len(h.Adoption.Roster) != AdoptionRosterSize {
+		t.Errorf("Expected the roster refilled, got %d", len(h.Adoption.Roster))
+	}
+	announced := false
+	for _, msg := range h.Inbox.List() {
+		announced = announced || strings.Contains(msg.Subject, "adoption center")
+	}
+	if !announced {
+		t.Error("Expected new arrivals to be announced in the inbox")
+	}
+}
@@ -0,0 +1,273 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrUnknownAction is returned when voting for an action the crowd cannot choose
+	ErrUnknownAction = errors.New("unknown crowd action")
+	// ErrInvalidVoter is returned for a vote without a voter or with an overlong one
+	ErrInvalidVoter = errors.New("invalid voter")
+	// ErrRoundFull is returned when MaxCrowdVoters have already voted this round
+	ErrRoundFull = errors.New("voting round is full")
+)
+
+// Crowd control settings
+const (
+	CrowdIntensity     = 0.5   // Intensity of an interaction chosen by the crowd
+	DisciplineMajority = 0.6   // Share of a round's votes discipline needs before it is applied
+	MaxCrowdVoters     = 10000 // Voters counted per round
+	MaxVoterName       = 64    // Longest voter name, in bytes
+)
+
+// ActionDiscipline is the crowd action held to stricter rules
+const ActionDiscipline = "discipline"
+
+// CrowdActions maps the actions viewers can vote for to interactions
+var CrowdActions = map[string]types.InteractionType{
+	"feed":           types.InteractionFeeding,
+	"pet":            types.InteractionPetting,
+	"play":           types.InteractionPlaying,
+	"train":          types.InteractionTraining,
+	"groom":          types.InteractionGrooming,
+	"comfort":        types.InteractionComfort,
+	"reward":         types.InteractionRewards,
+	ActionDiscipline: types.InteractionDiscipline,
+}
+
+// CrowdStanding is the number of votes for one action on one pet
+type CrowdStanding struct {
+	PetID  types.PetID `json:"pet"`
+	Action string      `json:"action"`
+	Votes  int         `json:"votes"`
+
+	first int // Order the option got its first vote; breaks ties
+}
+
+// CrowdResult is the outcome of a voting round
+type CrowdResult struct {
+	PetID   types.PetID `json:"pet"`
+	Name    string      `json:"name"`
+	Action  string      `json:"action"`
+	Votes   int         `json:"votes"`
+	Total   int         `json:"total"` // Votes cast in the round
+	Applied bool        `json:"applied"`
+	Reason  string      `json:"reason,omitempty"` // Why no action was applied
+	Ended   time.Time   `json:"ended"`
+}
+
+// crowdOption is an action on a pet that votes can be cast for
+type crowdOption struct {
+	petID  types.PetID
+	action string
+}
+
+// CrowdControl collects care votes from viewers, for example relayed from
+// a stream chat, and applies the most popular action at the end of each
+// round. Each voter has one vote per round; voting again changes it. An
+// action recently applied to a pet is skipped in favour of the runner-up,
+// and discipline also needs a clear majority. Safe for concurrent use.
+type CrowdControl struct {
+	Round              time.Duration // Voting time before an action is applied
+	Cooldown           time.Duration // Before the same action can be applied to a pet again
+	DisciplineCooldown time.Duration // Before a pet can be disciplined again
+
+	mu        sync.Mutex
+	votes     map[string]crowdOption // By voter
+	first     map[crowdOption]int
+	cast      int
+	roundEnds time.Time
+	applied   map[crowdOption]time.Time // When each action was last applied to each pet
+	last      *CrowdResult
+}
+
+// NewCrowdControl creates crowd control with the given round length and
+// cooldowns. The first round starts with the first vote or resolution.
+func NewCrowdControl(round, cooldown, disciplineCooldown time.Duration) *CrowdControl {
+	return &CrowdControl{
+		Round:              round,
+		Cooldown:           cooldown,
+		DisciplineCooldown: disciplineCooldown,
+		votes:              make(map[string]crowdOption),
+		first:              make(map[crowdOption]int),
+		applied:            make(map[crowdOption]time.Time),
+	}
+}
+
+// Vote records a voter's choice of action for a pet in the current round,
+// replacing any earlier vote of theirs. The pet is not checked; callers
+// look it up in the household first.
+func (cc *CrowdControl) Vote(voter string, petID types.PetID, action string, now time.Time) error {
+	voter = strings.TrimSpace(voter)
+	if voter == "" || len(voter) > MaxVoterName {
+		return fmt.Errorf("%w: must be 1 to %d characters", ErrInvalidVoter, MaxVoterName)
+	}
+	action = strings.ToLower(strings.TrimSpace(action))
+	if _, ok := CrowdActions[action]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAction, action)
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.startRound(now)
+	if _, voted := cc.votes[voter]; !voted && len(cc.votes) >= MaxCrowdVoters {
+		return fmt.Errorf("%w: %d voters", ErrRoundFull, MaxCrowdVoters)
+	}
+	option := crowdOption{petID: petID, action: action}
+	if _, ok := cc.first[option]; !ok {
+		cc.first[option] = cc.cast
+	}
+	cc.cast++
+	cc.votes[voter] = option
+	return nil
+}
+
+// Standings returns the votes in the current round, most popular first
+func (cc *CrowdControl) Standings() []CrowdStanding {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.standings()
+}
+
+// RoundEnds returns when the current round is resolved; zero before the
+// first round starts
+func (cc *CrowdControl) RoundEnds() time.Time {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.roundEnds
+}
+
+// Last returns the outcome of the last round with votes, or nil
+func (cc *CrowdControl) Last() *CrowdResult {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.last == nil {
+		return nil
+	}
+	last := *cc.last
+	return &last
+}
+
+// Resolve ends the current round once it is over and applies the most
+// popular allowed action to the household. It reports false while the
+// round is running and for rounds without votes.
+func (cc *CrowdControl) Resolve(h *Household, now time.Time) (CrowdResult, bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.roundEnds.IsZero() || now.Before(cc.roundEnds) {
+		cc.startRound(now)
+		return CrowdResult{}, false
+	}
+	standings := cc.standings()
+	total := len(cc.votes)
+	cc.votes = make(map[string]crowdOption)
+	cc.first = make(map[crowdOption]int)
+	cc.cast = 0
+	cc.roundEnds = now.Add(cc.Round)
+	if total == 0 {
+		return CrowdResult{}, false
+	}
+
+	var result CrowdResult
+	for i, standing := range standings {
+		option := crowdOption{petID: standing.PetID, action: standing.Action}
+		candidate := CrowdResult{PetID: standing.PetID, Action: standing.Action, Votes: standing.Votes, Total: total, Ended: now}
+		reason := cc.apply(h, option, standing.Votes, total, now, &candidate)
+		if reason == "" {
+			candidate.Applied = true
+			cc.applied[option] = now
+			result = candidate
+			break
+		}
+		if i == 0 {
+			result = candidate
+			result.Reason = reason
+		}
+	}
+	cc.last = &result
+	return result, true
+}
+
+// apply applies an option unless it breaks the crowd rules and returns
+// why it was refused, or "" once applied (must be called with lock held)
+func (cc *CrowdControl) apply(h *Household, option crowdOption, votes, total int, now time.Time, result *CrowdResult) string {
+	pet := h.pet(option.petID)
+	if pet == nil {
+		return "pet is not in the household"
+	}
+	result.Name = pet.Name
+	cooldown := cc.Cooldown
+	if option.action == ActionDiscipline {
+		if float64(votes) < DisciplineMajority*float64(total) {
+			return fmt.Sprintf("discipline needs %.0f%% of the votes", DisciplineMajority*100)
+		}
+		cooldown = cc.DisciplineCooldown
+	}
+	if last, ok := cc.applied[option]; ok && now.Sub(last) < cooldown {
+		return fmt.Sprintf("%s is cooling down for %s", option.action, (cooldown - now.Sub(last)).Round(time.Second))
+	}
+	if !pet.IsAlive() {
+		return fmt.Sprintf("%s has passed away", pet.Name)
+	}
+	if _, err := h.Interact(option.petID, CrowdActions[option.action], CrowdIntensity); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// startRound starts a round if none is running (must be called with lock held)
+func (cc *CrowdControl) startRound(now time.Time) {
+	if cc.roundEnds.IsZero() {
+		cc.roundEnds = now.Add(cc.Round)
+	}
+}
+
+// standings implements Standings (must be called with lock held)
+func (cc *CrowdControl) standings() []CrowdStanding {
+	counts := make(map[crowdOption]int)
+	for _, option := range cc.votes {
+		counts[option]++
+	}
+	standings := make([]CrowdStanding, 0, len(counts))
+	for option, votes := range counts {
+		standings = append(standings, CrowdStanding{PetID: option.petID, Action: option.action, Votes: votes, first: cc.first[option]})
+	}
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Votes != standings[j].Votes {
+			return standings[i].Votes > standings[j].Votes
+		}
+		return standings[i].first < standings[j].first
+	})
+	return standings
+}
+
+// Find looks up a pet in the household by ID or case-insensitive name
+func (h *Household) Find(nameOrID string) (*core.DigitalPet, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if pet, ok := h.Pets[types.PetID(nameOrID)]; ok {
+		return pet, nil
+	}
+	for _, pet := range h.Pets {
+		if strings.EqualFold(pet.Name, nameOrID) {
+			return pet, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPetNotPresent, nameOrID)
+}
+
+// pet returns a pet in the household, or nil
+func (h *Household) pet(petID types.PetID) *core.DigitalPet {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.Pets[petID]
+}
@@ -0,0 +1,127 @@
+package interaction
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestCrowdVote(t *testing.T) {
+	cc := NewCrowdControl(time.Minute, time.Minute, time.Hour)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	if err := cc.Vote("", "pet-1", "feed", now); !errors.Is(err, ErrInvalidVoter) {
+		t.Errorf("Expected ErrInvalidVoter without a voter, got %v", err)
+	}
+	if err := cc.Vote("viewer", "pet-1", "juggle", now); !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("Expected ErrUnknownAction, got %v", err)
+	}
+
+	cc.Vote("ann", "pet-1", "play", now)
+	cc.Vote("bo", "pet-1", "Feed", now)
+	cc.Vote("cy", "pet-1", "feed", now)
+	cc.Vote("ann", "pet-1", "feed", now) // Changes ann's vote
+	standings := cc.Standings()
+	if len(standings) != 1 || standings[0].Action != "feed" || standings[0].Votes != 3 {
+		t.Errorf("Expected three votes to feed after ann changed their vote, got %+v", standings)
+	}
+	if !cc.RoundEnds().Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected the round to end a minute after the first vote, got %v", cc.RoundEnds())
+	}
+}
+
+func TestCrowdResolve(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	cc := NewCrowdControl(time.Minute, 5*time.Minute, time.Hour)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	cc.Vote("ann", pet.ID, "play", now)
+	cc.Vote("bo", pet.ID, "feed", now)
+	if _, ok := cc.Resolve(h, now.Add(30*time.Second)); ok {
+		t.Fatal("Nothing should be applied before the round ends")
+	}
+
+	before := pet.TotalInteractions
+	result, ok := cc.Resolve(h, now.Add(time.Minute))
+	if !ok || !result.Applied || result.Action != "play" || result.Name != "Rex" || result.Total != 2 {
+		t.Fatalf("Expected the earliest of tied actions to be applied, got %+v", result)
+	}
+	if pet.TotalInteractions != before+1 {
+		t.Errorf("Expected one interaction, got %d", pet.TotalInteractions-before)
+	}
+	if len(cc.Standings()) != 0 {
+		t.Error("Votes should be cleared for the next round")
+	}
+
+	// Play is cooling down, so the runner-up is applied instead
+	now = now.Add(2 * time.Minute)
+	cc.Vote("ann", pet.ID, "play", now)
+	cc.Vote("bo", pet.ID, "play", now)
+	cc.Vote("cy", pet.ID, "groom", now)
+	result, _ = cc.Resolve(h, now.Add(time.Minute))
+	if !result.Applied || result.Action != "groom" {
+		t.Errorf("Expected groom while play cools down, got %+v", result)
+	}
+
+	now = now.Add(2 * time.Minute)
+	cc.Vote("ann", pet.ID, "play", now)
+	result, _ = cc.Resolve(h, now.Add(time.Minute))
+	if result.Applied || !strings.Contains(result.Reason, "cooling down") {
+		t.Errorf("Expected play to be refused while cooling down, got %+v", result)
+	}
+	if last := cc.Last(); last == nil || *last != result {
+		t.Errorf("Expected the last result to be kept, got %+v", last)
+	}
+
+	if _, ok := cc.Resolve(h, now.Add(3*time.Minute)); ok {
+		t.Error("A round without votes should not report a result")
+	}
+}
+
+func TestCrowdDiscipline(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	cc := NewCrowdControl(time.Minute, time.Minute, time.Hour)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	cc.Vote("ann", pet.ID, ActionDiscipline, now)
+	cc.Vote("bo", pet.ID, ActionDiscipline, now)
+	cc.Vote("cy", pet.ID, "pet", now)
+	cc.Vote("di", pet.ID, "feed", now)
+	result, _ := cc.Resolve(h, now.Add(time.Minute))
+	if result.Action != "pet" || !result.Applied {
+		t.Errorf("Discipline without a majority should give way to the runner-up, got %+v", result)
+	}
+
+	now = now.Add(time.Minute)
+	cc.Vote("ann", pet.ID, ActionDiscipline, now)
+	cc.Vote("bo", pet.ID, ActionDiscipline, now)
+	result, _ = cc.Resolve(h, now.Add(time.Minute))
+	if result.Action != ActionDiscipline || !result.Applied {
+		t.Errorf("Expected discipline with a clear majority, got %+v", result)
+	}
+
+	now = now.Add(10 * time.Minute)
+	cc.Vote("ann", pet.ID, ActionDiscipline, now)
+	result, _ = cc.Resolve(h, now.Add(time.Minute))
+	if result.Applied {
+		t.Errorf("Discipline should have a longer cooldown, got %+v", result)
+	}
+}
+
+func TestHouseholdFind(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	for _, key := range []string{"rex", string(pet.ID)} {
+		if found, err := h.Find(key); err != nil || found != pet {
+			t.Errorf("Expected to find Rex by %q, got %v", key, err)
+		}
+	}
+	if _, err := h.Find("Fido"); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+}
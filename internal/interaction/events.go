@@ -0,0 +1,64 @@
+package interaction
+
+import (
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// Location event effect rates, per game day
+const (
+	ForageRate         = 0.05 // Nutrition gained per unit of extra food
+	CrowdHappinessRate = 0.05 // Happiness change per unit of extra social density
+	DroughtThirstRate  = 0.1  // Hydration lost per unit of missing water
+)
+
+// updateEnvironment advances location events, posts their announcements
+// to the inbox and applies local conditions to each pet (must be called
+// with lock held)
+func (h *Household) updateEnvironment(deltaTime float64) {
+	h.Environment.Update(deltaTime)
+	if h.Weather != nil {
+		h.Environment.UpdateWater(h.Weather, deltaTime)
+	}
+
+	for _, announcement := range h.Environment.DrainAnnouncements() {
+		category, text := MessageSystem, announcement.Text
+		if announcement.Event.Type == environment.EventFestival {
+			category = MessageFestival
+			if announcement.Started {
+				text += h.grantAccessory(environment.AccessoryPartyHat)
+			}
+		}
+		h.Inbox.Post(category, "", announcement.Event.Type.String(), text)
+	}
+
+	for _, pet := range h.Pets {
+		if !pet.IsAlive() {
+			continue
+		}
+
+		cond := h.Environment.Conditions(pet.Location)
+		vitals := pet.Biology.Vitals
+
+		// Plentiful forage tops pets up
+		if extra := cond.Food - 1.0; extra > 0 {
+			vitals.Nutrition += ForageRate * extra * deltaTime
+		}
+
+		// Drought leaves little to drink
+		if loc, exists := h.Environment.World.Location(pet.Location); exists && cond.Has(environment.EventDrought) {
+			if shortage := loc.Biome.Resources().Water - cond.Water; shortage > 0 {
+				vitals.Hydration -= DroughtThirstRate * shortage * deltaTime
+			}
+		}
+
+		// Outgoing pets enjoy a crowd; shy ones find it draining
+		if extra := cond.SocialDensity - 1.0; extra > 0 {
+			sociability := pet.Personality.Traits.Extraversion - 0.5
+			vitals.Happiness += CrowdHappinessRate * extra * sociability * deltaTime
+			if sociability < 0 {
+				vitals.Stress -= CrowdHappinessRate * extra * sociability * deltaTime
+			}
+		}
+		vitals.Clamp()
+	}
+}
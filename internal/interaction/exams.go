@@ -0,0 +1,130 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrExamScheduled is returned when scheduling an exam a pet is already booked for
+var ErrExamScheduled = errors.New("exam already scheduled")
+
+// ExamNoticeDays is how many game days after scheduling an exam is held
+const ExamNoticeDays = 1.0
+
+// Exam is a certification exam booked for a pet
+type Exam struct {
+	PetID types.PetID
+	Skill ai.SkillType
+	DueIn float64 // Game days until the exam
+}
+
+// LeaderboardEntry is one pet's standing on the household leaderboard
+type LeaderboardEntry struct {
+	PetID        types.PetID
+	Name         string
+	Credits      int
+	Certificates int
+}
+
+// ScheduleExam books a certification exam for a present pet, held after
+// ExamNoticeDays. The pet must be eligible now; it is checked again when
+// the exam is held.
+func (h *Household) ScheduleExam(petID types.PetID, skill ai.SkillType) (Exam, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, present := h.Pets[petID]
+	if !present {
+		return Exam{}, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	for _, exam := range h.exams {
+		if exam.PetID == petID && exam.Skill == skill {
+			return Exam{}, fmt.Errorf("%w: %s in %s", ErrExamScheduled, pet.Name, skill)
+		}
+	}
+	if err := pet.CanTakeExam(skill); err != nil {
+		return Exam{}, err
+	}
+	exam := &Exam{PetID: petID, Skill: skill, DueIn: ExamNoticeDays}
+	h.exams = append(h.exams, exam)
+	return *exam, nil
+}
+
+// Exams returns the exams booked but not yet held
+func (h *Household) Exams() []Exam {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	exams := make([]Exam, len(h.exams))
+	for i, exam := range h.exams {
+		exams[i] = *exam
+	}
+	return exams
+}
+
+// Leaderboard ranks present pets with certificates by credit, highest first
+func (h *Household) Leaderboard() []LeaderboardEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var entries []LeaderboardEntry
+	for id, pet := range h.Pets {
+		if pet.Certifications == nil || len(pet.Certifications.Certificates) == 0 {
+			continue
+		}
+		entries = append(entries, LeaderboardEntry{
+			PetID:        id,
+			Name:         pet.DisplayName(),
+			Credits:      pet.Credits(),
+			Certificates: len(pet.Certifications.Certificates),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Credits != entries[j].Credits {
+			return entries[i].Credits > entries[j].Credits
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// updateExams holds exams that are due and posts each result. Exams for
+// pets that left are dropped (must be called with lock held).
+func (h *Household) updateExams(deltaTime float64) {
+	remaining := h.exams[:0]
+	for _, exam := range h.exams {
+		pet, present := h.Pets[exam.PetID]
+		if !present {
+			continue
+		}
+		exam.DueIn -= deltaTime
+		if exam.DueIn > 0 {
+			remaining = append(remaining, exam)
+			continue
+		}
+		h.holdExam(pet, exam.Skill)
+	}
+	h.exams = remaining
+}
+
+// holdExam has a pet sit an exam and posts the result (must be called
+// with lock held)
+func (h *Household) holdExam(pet *core.DigitalPet, skill ai.SkillType) {
+	result, err := pet.TakeExam(skill, h.rng)
+	switch {
+	case err != nil:
+		h.Inbox.Post(MessageSystem, pet.ID, fmt.Sprintf("%s's %s exam was called off", pet.Name, skill),
+			fmt.Sprintf("The exam could not go ahead: %v.", err))
+	case result.Passed:
+		certificate := result.Certificate
+		h.Inbox.Post(MessageCelebration, pet.ID, fmt.Sprintf("%s is certified in %s", pet.Name, skill),
+			fmt.Sprintf("%s passed all %d parts and is now known as %s, earning %d leaderboard credits.",
+				pet.Name, len(result.Parts), certificate.Title(), certificate.Credit()))
+	default:
+		h.Inbox.Post(MessageSystem, pet.ID, fmt.Sprintf("%s did not pass the %s exam", pet.Name, skill),
+			fmt.Sprintf("%s stumbled on part %d of %d. The exam can be retaken in %.0f days.",
+				pet.Name, len(result.Parts), core.ExamParts, core.ExamCooldownDays))
+	}
+}
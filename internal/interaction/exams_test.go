@@ -0,0 +1,67 @@
+package interaction
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestScheduleAndHoldExam(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.rng = rand.New(rand.NewSource(1))
+	if _, err := h.ScheduleExam(pet.ID, ai.SkillAgility); !errors.Is(err, core.ErrExamNotEligible) {
+		t.Errorf("Expected ErrExamNotEligible for an untrained pet, got %v", err)
+	}
+
+	pet.Skills.Levels[ai.SkillAgility] = 0.8
+	if _, err := h.ScheduleExam(pet.ID, ai.SkillAgility); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.ScheduleExam(pet.ID, ai.SkillAgility); !errors.Is(err, ErrExamScheduled) {
+		t.Errorf("Expected ErrExamScheduled, got %v", err)
+	}
+	if exams := h.Exams(); len(exams) != 1 || exams[0].DueIn != ExamNoticeDays {
+		t.Fatalf("Expected one exam booked, got %+v", exams)
+	}
+
+	h.Update(ExamNoticeDays / 2)
+	if len(h.Exams()) != 1 {
+		t.Fatal("The exam should not be held before it is due")
+	}
+	h.Update(ExamNoticeDays)
+	if len(h.Exams()) != 0 {
+		t.Fatal("Expected the exam to be held once due")
+	}
+	msgs := h.Inbox.List()
+	if len(msgs) == 0 || msgs[0].PetID != pet.ID {
+		t.Fatalf("Expected the exam result in the inbox, got %+v", msgs)
+	}
+	_, certified := pet.Certificate(ai.SkillAgility)
+	if certified != (msgs[0].Category == MessageCelebration) {
+		t.Errorf("Expected a celebration only for a pass, got %v with certified %v", msgs[0].Category, certified)
+	}
+	if !certified {
+		if _, err := h.ScheduleExam(pet.ID, ai.SkillAgility); !errors.Is(err, core.ErrExamCooldown) {
+			t.Errorf("Expected no retry during the cooldown, got %v", err)
+		}
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "user123")
+	bella := core.NewDigitalPet("Bella", "user123")
+	h := NewHousehold(rex, bella, core.NewDigitalPet("Max", "user123"))
+	rex.Certifications = &core.Certifications{Certificates: []core.Certificate{{Skill: ai.SkillAgility, Score: 0.5}}}
+	bella.Certifications = &core.Certifications{Certificates: []core.Certificate{
+		{Skill: ai.SkillAgility, Score: 0.5}, {Skill: ai.SkillSocial, Score: 0.5},
+	}}
+
+	board := h.Leaderboard()
+	if len(board) != 2 || board[0].Name != "Bella" || board[0].Certificates != 2 || board[1].Name != "Rex" {
+		t.Errorf("Expected Bella ahead of Rex and uncertified pets left out, got %+v", board)
+	}
+}
@@ -0,0 +1,229 @@
+package interaction
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Feedback generation settings
+const (
+	FeedbackHistorySize  = 10 // Number of recent interactions remembered
+	FeedbackRepeatLimit  = 3  // Repeats in recent history before the pet gets bored
+	FeedbackIntenseLevel = 0.7
+)
+
+// FeedbackContext holds the pet state a feedback message is composed from
+type FeedbackContext struct {
+	Name        string
+	Interaction types.InteractionType
+	Emotion     string  // Dominant emotion
+	Intensity   float64 // Strength of the dominant emotion (0.0 to 1.0)
+	Bond        float64 // Attachment to the user (0.0 to 1.0)
+	Playful     bool
+	Shy         bool
+	Repeats     int // How often this interaction appears in recent history
+}
+
+// NewFeedbackContext builds a feedback context from a pet's current state
+func NewFeedbackContext(pet *core.DigitalPet, interactionType types.InteractionType) FeedbackContext {
+	traits := pet.Personality.Traits
+	return FeedbackContext{
+		Name:        pet.Name,
+		Interaction: interactionType,
+		Emotion:     pet.Emotions.DominantEmotion,
+		Intensity:   pet.Emotions.DominantIntensity(),
+		Bond:        (pet.Emotions.Affection + traits.Loyalty) / 2.0,
+		Playful:     traits.Playfulness > 0.7,
+		Shy:         traits.Extraversion < 0.3,
+	}
+}
+
+// reactionTemplates are the opening sentences for each interaction, split
+// into mild and intense reactions
+var reactionTemplates = map[types.InteractionType][2][]string{
+	types.InteractionFeeding: {
+		{"{name} eats the food.", "{name} nibbles at the meal.", "{name} finishes the bowl."},
+		{"{name} wolfs the food down!", "{name} devours every last crumb!", "{name} practically inhales the meal!"},
+	},
+	types.InteractionPetting: {
+		{"{name} leans into your hand.", "{name} lets you stroke its fur.", "{name} settles under your touch."},
+		{"{name} melts into your hand!", "{name} rolls over for more!", "{name} nuzzles you eagerly!"},
+	},
+	types.InteractionPlaying: {
+		{"{name} plays along.", "{name} chases the toy for a while.", "{name} bats at the toy."},
+		{"{name} bounds around wildly!", "{name} pounces on the toy with glee!", "{name} races in circles, delighted!"},
+	},
+	types.InteractionTraining: {
+		{"{name} practices the trick.", "{name} watches you closely.", "{name} tries the command."},
+		{"{name} nails the trick!", "{name} is eager to learn more!", "{name} gets it right on the first try!"},
+	},
+	types.InteractionGrooming: {
+		{"{name} sits still to be groomed.", "{name} tolerates the brush.", "{name} looks a little tidier."},
+		{"{name} gleams after grooming!", "{name} loves every stroke of the brush!", "{name} preens proudly!"},
+	},
+	types.InteractionMedicalCare: {
+		{"{name} puts up with the treatment.", "{name} takes the medicine.", "{name} is checked over."},
+		{"{name} squirms through the treatment!", "{name} is very relieved when it's over!", "{name} bravely endures the vet!"},
+	},
+	types.InteractionRewards: {
+		{"{name} accepts the treat.", "{name} wags at the reward.", "{name} takes the treat politely."},
+		{"{name} is thrilled with the reward!", "{name} does a happy dance!", "{name} snatches the treat with joy!"},
+	},
+	types.InteractionDiscipline: {
+		{"{name} lowers its head.", "{name} looks away.", "{name} goes quiet."},
+		{"{name} cowers!", "{name} slinks off to hide!", "{name} flattens its ears and whimpers!"},
+	},
+}
+
+// defaultReactions are used for interactions without specific templates
+var defaultReactions = [2][]string{
+	{"{name} notices.", "{name} responds calmly.", "{name} seems interested."},
+	{"{name} reacts with enthusiasm!", "{name} is very excited!", "{name} can hardly contain itself!"},
+}
+
+// emotionTemplates add colour based on the dominant emotion
+var emotionTemplates = map[string][]string{
+	"joyful":       {"{name} looks overjoyed.", "{name} is beaming."},
+	"sad":          {"Still, {name} seems sad.", "{name} looks a little down."},
+	"angry":        {"{name} is still grumpy.", "{name} huffs irritably."},
+	"fearful":      {"{name} keeps glancing around nervously.", "{name} seems on edge."},
+	"excited":      {"{name} is buzzing with energy.", "{name} can't sit still."},
+	"content":      {"{name} looks content.", "{name} sighs happily."},
+	"affectionate": {"{name} gazes at you fondly.", "{name} stays close to you."},
+	"lonely":       {"{name} seems glad for the company.", "{name} doesn't want you to leave."},
+	"jealous":      {"{name} eyes the other pets warily.", "{name} wants you all to itself."},
+}
+
+// personalityTemplates reflect standing personality traits
+var (
+	playfulTemplates = []string{"{name} wiggles playfully.", "{name} tries to turn it into a game."}
+	shyTemplates     = []string{"{name} hangs back a little.", "{name} peeks at you shyly."}
+)
+
+// bondTemplates close the message depending on attachment to the user
+var (
+	closeBondTemplates = []string{"{name} trusts you completely.", "{name} presses against you.", "You and {name} are inseparable."}
+	weakBondTemplates  = []string{"{name} keeps a wary distance.", "{name} isn't sure about you yet."}
+	boredTemplates     = []string{"{name} seems a bit bored of this.", "{name} yawns; maybe try something new?"}
+)
+
+// FeedbackGenerator composes varied feedback messages from templates.
+// Recently used messages are avoided so players see fresh text.
+type FeedbackGenerator struct {
+	rng     *rand.Rand
+	recent  []string
+	history []types.InteractionType
+}
+
+// NewFeedbackGenerator creates a generator seeded from the current time
+func NewFeedbackGenerator() *FeedbackGenerator {
+	return NewSeededFeedbackGenerator(time.Now().UnixNano())
+}
+
+// NewSeededFeedbackGenerator creates a deterministic generator for testing
+func NewSeededFeedbackGenerator(seed int64) *FeedbackGenerator {
+	return &FeedbackGenerator{
+		rng: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Generate returns a feedback message for an interaction with a pet
+func (fg *FeedbackGenerator) Generate(pet *core.DigitalPet, interactionType types.InteractionType) string {
+	ctx := NewFeedbackContext(pet, interactionType)
+	ctx.Repeats = fg.countRecent(interactionType)
+	fg.recordInteraction(interactionType)
+	return fg.Compose(ctx)
+}
+
+// Compose builds a message from a feedback context
+func (fg *FeedbackGenerator) Compose(ctx FeedbackContext) string {
+	var parts []string
+
+	tiers, ok := reactionTemplates[ctx.Interaction]
+	if !ok {
+		tiers = defaultReactions
+	}
+	tier := 0
+	if ctx.Intensity >= FeedbackIntenseLevel {
+		tier = 1
+	}
+	parts = append(parts, fg.pick(tiers[tier]))
+
+	if ctx.Intensity >= 0.5 {
+		if pool, ok := emotionTemplates[ctx.Emotion]; ok {
+			parts = append(parts, fg.pick(pool))
+		}
+	}
+
+	switch {
+	case ctx.Playful && ctx.Interaction == types.InteractionPlaying:
+		parts = append(parts, fg.pick(playfulTemplates))
+	case ctx.Shy && ctx.Bond < 0.5:
+		parts = append(parts, fg.pick(shyTemplates))
+	}
+
+	switch {
+	case ctx.Repeats >= FeedbackRepeatLimit:
+		parts = append(parts, fg.pick(boredTemplates))
+	case ctx.Bond >= 0.8:
+		parts = append(parts, fg.pick(closeBondTemplates))
+	case ctx.Bond < 0.3:
+		parts = append(parts, fg.pick(weakBondTemplates))
+	}
+
+	message := strings.Join(parts, " ")
+	return strings.ReplaceAll(message, "{name}", ctx.Name)
+}
+
+// pick chooses a template, avoiding recently used ones where possible
+func (fg *FeedbackGenerator) pick(pool []string) string {
+	fresh := make([]string, 0, len(pool))
+	for _, template := range pool {
+		if !fg.wasRecent(template) {
+			fresh = append(fresh, template)
+		}
+	}
+	if len(fresh) == 0 {
+		fresh = pool
+	}
+
+	choice := fresh[fg.rng.Intn(len(fresh))]
+	fg.recent = append(fg.recent, choice)
+	if len(fg.recent) > FeedbackHistorySize {
+		fg.recent = fg.recent[1:]
+	}
+	return choice
+}
+
+// wasRecent reports whether a template was used recently
+func (fg *FeedbackGenerator) wasRecent(template string) bool {
+	for _, used := range fg.recent {
+		if used == template {
+			return true
+		}
+	}
+	return false
+}
+
+// recordInteraction remembers an interaction for repetition detection
+func (fg *FeedbackGenerator) recordInteraction(interactionType types.InteractionType) {
+	fg.history = append(fg.history, interactionType)
+	if len(fg.history) > FeedbackHistorySize {
+		fg.history = fg.history[1:]
+	}
+}
+
+// countRecent counts an interaction in recent history
+func (fg *FeedbackGenerator) countRecent(interactionType types.InteractionType) int {
+	count := 0
+	for _, past := range fg.history {
+		if past == interactionType {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,74 @@
+package interaction
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestFeedbackDeterministicWithSeed(t *testing.T) {
+	pet := core.NewDigitalPet("Biscuit", "user123")
+
+	a := NewSeededFeedbackGenerator(42)
+	b := NewSeededFeedbackGenerator(42)
+
+	for i := 0; i < 5; i++ {
+		msgA := a.Generate(pet, types.InteractionPetting)
+		msgB := b.Generate(pet, types.InteractionPetting)
+		if msgA != msgB {
+			t.Fatalf("Same seed should produce same feedback: %q vs %q", msgA, msgB)
+		}
+	}
+}
+
+func TestFeedbackUsesPetName(t *testing.T) {
+	fg := NewSeededFeedbackGenerator(1)
+	pet := core.NewDigitalPet("Biscuit", "user123")
+
+	msg := fg.Generate(pet, types.InteractionFeeding)
+	if !strings.Contains(msg, "Biscuit") || strings.Contains(msg, "{name}") {
+		t.Errorf("Feedback should name the pet, got %q", msg)
+	}
+}
+
+func TestFeedbackVaries(t *testing.T) {
+	fg := NewSeededFeedbackGenerator(7)
+	ctx := FeedbackContext{Name: "Biscuit", Interaction: types.InteractionPlaying, Bond: 0.5}
+
+	first := fg.Compose(ctx)
+	second := fg.Compose(ctx)
+	if first == second {
+		t.Errorf("Consecutive feedback should vary, got %q twice", first)
+	}
+}
+
+func TestFeedbackReflectsState(t *testing.T) {
+	fg := NewSeededFeedbackGenerator(3)
+
+	intense := fg.Compose(FeedbackContext{Name: "B", Interaction: types.InteractionFeeding, Intensity: 0.9, Bond: 0.5})
+	if !containsAny(intense, reactionTemplates[types.InteractionFeeding][1], "B") {
+		t.Errorf("Intense emotion should use intense reactions, got %q", intense)
+	}
+
+	bored := fg.Compose(FeedbackContext{Name: "B", Interaction: types.InteractionFeeding, Bond: 0.5, Repeats: FeedbackRepeatLimit})
+	if !containsAny(bored, boredTemplates, "B") {
+		t.Errorf("Repeated interactions should bore the pet, got %q", bored)
+	}
+
+	wary := fg.Compose(FeedbackContext{Name: "B", Interaction: types.InteractionFeeding, Bond: 0.1})
+	if !containsAny(wary, weakBondTemplates, "B") {
+		t.Errorf("Weak bond should be reflected, got %q", wary)
+	}
+}
+
+// containsAny reports whether msg contains any of the templates filled with name
+func containsAny(msg string, templates []string, name string) bool {
+	for _, template := range templates {
+		if strings.Contains(msg, strings.ReplaceAll(template, "{name}", name)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package interaction
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// updateGarden grows the garden under the current weather and posts a
+// message when a crop ripens or wilts (must be called with lock held)
+func (h *Household) updateGarden(deltaTime float64) {
+	var effects environment.WeatherEffects
+	if h.Weather != nil {
+		effects = h.Weather.Effects()
+	}
+	h.Garden.Update(effects, deltaTime)
+
+	for _, notice := range h.Garden.DrainNotices() {
+		if notice.Ripe {
+			h.Inbox.Post(MessageSystem, "",
+				fmt.Sprintf("%s ready to harvest", notice.Crop),
+				fmt.Sprintf("The %s in plot %d are ripe.", notice.Crop, notice.Plot))
+		} else {
+			h.Inbox.Post(MessageSystem, "",
+				fmt.Sprintf("%s wilted", notice.Crop),
+				fmt.Sprintf("The %s in plot %d dried out and died. Remember to water the garden.", notice.Crop, notice.Plot))
+		}
+	}
+
+	for _, meal := range h.Inventory.AgeMeals(deltaTime) {
+		h.Inbox.Post(MessageSystem, "",
+			fmt.Sprintf("%s spoiled", meal.Recipe),
+			fmt.Sprintf("A %s went off before it was served and was thrown out.", strings.ToLower(meal.Recipe.String())))
+	}
+}
+
+// FeedProduce feeds a pet one item of harvested produce from the inventory
+func (h *Household) FeedProduce(petID types.PetID, crop environment.Crop) ([]JealousyReaction, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.Inventory.Produce[crop] < 1 {
+		return nil, fmt.Errorf("%w: %s", environment.ErrOutOfStock, crop)
+	}
+	reactions, err := h.eat(petID, crop.Nutrients())
+	if err != nil {
+		return nil, err
+	}
+	h.Pets[petID].RecordSpecialMeal()
+	return reactions, h.Inventory.TakeProduce(crop)
+}
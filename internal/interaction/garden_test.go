@@ -0,0 +1,45 @@
+package interaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestGardenRipensAndFeeds(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.Garden = environment.NewSeededGarden(1)
+	h.Inventory.Seeds[environment.CropBerries] = 1
+	if err := h.Garden.Plant(1, environment.CropBerries, h.Inventory); err != nil {
+		t.Fatalf("Plant failed: %v", err)
+	}
+
+	for day := 0; day < 6; day++ {
+		h.Garden.Water(1)
+		h.Update(1.0)
+	}
+	if msgs := h.Inbox.List(); len(msgs) == 0 || msgs[0].Subject != "Berries ready to harvest" {
+		t.Fatalf("Expected a harvest message, got %+v", msgs)
+	}
+
+	if _, err := h.FeedProduce(pet.ID, environment.CropBerries); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock before harvesting, got %v", err)
+	}
+	if _, _, err := h.Garden.Harvest(1, h.Inventory); err != nil {
+		t.Fatalf("Harvest failed: %v", err)
+	}
+
+	pet.Biology.Vitals.Nutrition = 0.2
+	if _, err := h.FeedProduce(pet.ID, environment.CropBerries); err != nil {
+		t.Fatalf("FeedProduce failed: %v", err)
+	}
+	if pet.Biology.Vitals.Nutrition <= 0.2 {
+		t.Error("Feeding produce should nourish the pet")
+	}
+	if pet.Renown == nil || pet.Renown.SpecialMeals != 1 {
+		t.Error("Produce should count as a special meal")
+	}
+}
@@ -0,0 +1,410 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/internal/social"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrPetNotPresent is returned when interacting with a pet that is not in the household
+	ErrPetNotPresent = errors.New("pet is not present in the household")
+	// ErrPetHibernating is returned for interactions a hibernating pet cannot take part in
+	ErrPetHibernating = errors.New("pet is hibernating")
+	// ErrInsufficientFunds is returned when the household cannot pay for an interaction
+	ErrInsufficientFunds = errors.New("not enough coins")
+)
+
+// AttentionDecayRate is how quickly remembered attention fades per game day
+const AttentionDecayRate = 0.5
+
+// AttentionTracker records how much recent attention each pet has received
+type AttentionTracker struct {
+	Attention map[types.PetID]float64
+}
+
+// NewAttentionTracker creates an empty attention tracker
+func NewAttentionTracker() *AttentionTracker {
+	return &AttentionTracker{
+		Attention: make(map[types.PetID]float64),
+	}
+}
+
+// Record adds attention for a pet
+func (at *AttentionTracker) Record(petID types.PetID, amount float64) {
+	at.Attention[petID] += amount
+}
+
+// Decay fades remembered attention over time
+func (at *AttentionTracker) Decay(deltaTime float64) {
+	factor := 1.0 - AttentionDecayRate*deltaTime
+	if factor < 0 {
+		factor = 0
+	}
+	for id := range at.Attention {
+		at.Attention[id] *= factor
+	}
+}
+
+// Share returns the fraction of recent attention received by a pet
+// among the given pets
+func (at *AttentionTracker) Share(petID types.PetID, among []types.PetID) float64 {
+	total := 0.0
+	for _, id := range among {
+		total += at.Attention[id]
+	}
+	if total == 0 {
+		return 1.0 / float64(max(len(among), 1))
+	}
+	return at.Attention[petID] / total
+}
+
+// Household is a group of pets present together with the user. Interacting
+// with one pet causes the others to react to the attention it receives.
+type Household struct {
+	mu sync.Mutex
+
+	Pets        map[types.PetID]*core.DigitalPet
+	Attention   *AttentionTracker
+	Hierarchy   *social.PackHierarchy
+	Territory   *social.TerritoryMap
+	Milestones  map[types.PetID]*MilestoneTracker
+	Inbox       *Inbox
+	Weather     *environment.WeatherSystem // Optional; nil disables weather
+	Environment *environment.Manager       // Optional; nil disables location events
+	Garden      *environment.Garden        // Optional; nil disables gardening
+	Surprises   *RandomEvents              // Optional; nil disables surprise events
+	Adoption    *AdoptionCenter            // Optional; nil closes the adoption center
+	World       *environment.WorldMap      // Optional; nil keeps neglected pets from running away
+	Inventory   *environment.Inventory
+	Habitat     *environment.Habitat // Comfort items at the home location
+	Sanctuary   *Sanctuary           // Donations and the community standing they earn
+	Unlocks     *Unlocks             // Optional; nil leaves every feature unlocked
+	Feedback    *FeedbackGenerator   // Describes how pets react to care
+
+	Relationships *social.RelationshipCoordinator // Keeps both sides of each relationship in step
+	PopulationCap int                             // Most pets kept at once; 0 for no cap
+	WorldSeed     int64                           // Seed the world was generated from, kept with the household
+
+	vetReminded map[types.PetID]bool
+	comforted   map[types.PetID]bool    // Young pets comforted during the current storm
+	neglect     map[types.PetID]float64 // Game days each pet has been neglected
+	rescues     []*Rescue
+	pregnancies []*Pregnancy
+	litters     []*Litter
+	exams       []*Exam
+	rng         *rand.Rand
+
+	policies map[types.PetID]*Policy // Insurance, kept for pets whose cover was cancelled
+	fund     int                     // Coins set aside for vet bills
+}
+
+// NewHousehold creates a household from the pets that are present
+func NewHousehold(pets ...*core.DigitalPet) *Household {
+	h := &Household{
+		Pets:       make(map[types.PetID]*core.DigitalPet),
+		Attention:  NewAttentionTracker(),
+		Hierarchy:  social.NewPackHierarchy(),
+		Territory:  social.NewTerritoryMap(),
+		Milestones: make(map[types.PetID]*MilestoneTracker),
+		Inbox:      NewInbox(),
+		Inventory:  environment.NewInventory(),
+		Habitat:    environment.NewHabitat(),
+		Sanctuary:  NewSanctuary(),
+		Feedback:   NewFeedbackGenerator(),
+
+		vetReminded: make(map[types.PetID]bool),
+		comforted:   make(map[types.PetID]bool),
+		neglect:     make(map[types.PetID]float64),
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		policies:    make(map[types.PetID]*Policy),
+
+		Relationships: social.NewRelationshipCoordinator(),
+	}
+	for _, pet := range pets {
+		h.join(pet)
+	}
+	return h
+}
+
+// AddPet makes a pet present in the household
+func (h *Household) AddPet(pet *core.DigitalPet) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.join(pet)
+}
+
+// RemovePet removes a pet from the household
+func (h *Household) RemovePet(petID types.PetID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.leave(petID)
+}
+
+// join makes a pet present, ranks it in the pack and coordinates its
+// relationships with those of the other pets (must be called with lock held)
+func (h *Household) join(pet *core.DigitalPet) {
+	h.Pets[pet.ID] = pet
+	h.Hierarchy.AddMember(pet.ID, InitialDominance(pet))
+	pet.CoordinateWith(h.Relationships)
+}
+
+// leave undoes join for a pet that leaves the household for good (must be
+// called with lock held)
+func (h *Household) leave(petID types.PetID) {
+	delete(h.Pets, petID)
+	h.Hierarchy.RemoveMember(petID)
+	h.Territory.RemovePet(petID)
+	h.Relationships.Unregister(petID)
+}
+
+// JealousyReaction describes how a bystander pet reacted to an interaction
+type JealousyReaction struct {
+	PetID    types.PetID
+	Favored  types.PetID
+	Jealousy float64 // Strength of the jealous reaction (0.0 to 1.0)
+}
+
+// Interact applies a user interaction to one pet and lets the other
+// present pets react to the attention it received
+func (h *Household) Interact(petID types.PetID, interactionType types.InteractionType, intensity float64) ([]JealousyReaction, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.interact(petID, interactionType, intensity)
+}
+
+// interact implements Interact (must be called with lock held). A genetic
+// screening is paid for from the household's coins.
+func (h *Household) interact(petID types.PetID, interactionType types.InteractionType, intensity float64) ([]JealousyReaction, error) {
+	paid := interactionType == types.InteractionGeneticScreening
+	if paid && h.Inventory.Coins < genetics.ScreeningCost {
+		return nil, fmt.Errorf("%w: a genetic screening costs %d coins, have %d", ErrInsufficientFunds, genetics.ScreeningCost, h.Inventory.Coins)
+	}
+	return h.attend(petID, interactionType, intensity, func(pet *core.DigitalPet) {
+		if paid && pet.IsAlive() {
+			h.Inventory.Spend(genetics.ScreeningCost)
+		}
+		pet.ProcessUserInteraction(interactionType, intensity)
+	})
+}
+
+// eat feeds a pet a particular food, which the other pets see as
+// attention like any feeding (must be called with lock held)
+func (h *Household) eat(petID types.PetID, food biology.Nutrients) ([]JealousyReaction, error) {
+	return h.attend(petID, types.InteractionFeeding, food.Filling(), func(pet *core.DigitalPet) {
+		pet.Eat(food)
+	})
+}
+
+// attend gives a pet an interaction through apply and lets the other
+// present pets react (must be called with lock held)
+func (h *Household) attend(petID types.PetID, interactionType types.InteractionType, intensity float64, apply func(*core.DigitalPet)) ([]JealousyReaction, error) {
+	favored, present := h.Pets[petID]
+	if !present {
+		return nil, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	if !favored.CanInteract(interactionType) {
+		return nil, fmt.Errorf("%w: %s", ErrPetHibernating, favored.Name)
+	}
+
+	apply(favored)
+	h.milestones(petID).RecordInteraction(interactionType)
+
+	if !IsAttention(interactionType) {
+		return nil, nil
+	}
+
+	h.Attention.Record(petID, intensity)
+
+	ids := make([]types.PetID, 0, len(h.Pets))
+	for id := range h.Pets {
+		ids = append(ids, id)
+	}
+
+	var reactions []JealousyReaction
+	favoredShare := h.Attention.Share(petID, ids)
+
+	for _, id := range ids {
+		if id == petID {
+			continue
+		}
+		bystander := h.Pets[id]
+		if !bystander.IsAlive() {
+			continue
+		}
+
+		// Attention that has been shared evenly causes little jealousy
+		imbalance := favoredShare - h.Attention.Share(id, ids)
+		if imbalance <= 0 {
+			continue
+		}
+
+		jealousy := JealousyIntensity(bystander, imbalance*intensity)
+		if jealousy <= 0.01 {
+			continue
+		}
+
+		ApplyJealousy(bystander, favored, jealousy)
+		h.Relationships.RecordFeelings(id, petID, -jealousy, -jealousy*0.5, bystander.GetAge())
+		reactions = append(reactions, JealousyReaction{
+			PetID:    id,
+			Favored:  petID,
+			Jealousy: jealousy,
+		})
+	}
+
+	return reactions, nil
+}
+
+// weatherAt returns the weather where a pet is: home's weather through
+// the habitat, or the weather of its climate zone when it is away (must
+// be called with lock held)
+func (h *Household) weatherAt(pet *core.DigitalPet, home environment.WeatherEffects) environment.WeatherEffects {
+	if pet.Location == environment.HomeLocation {
+		return h.Habitat.Apply(home)
+	}
+	if h.World != nil {
+		if loc, exists := h.World.Location(pet.Location); exists {
+			return h.Weather.EffectsAt(h.World, loc)
+		}
+	}
+	return home
+}
+
+// VetReminderThreshold is the health below which a vet reminder is sent
+const VetReminderThreshold = 0.5
+
+// Update fades remembered attention and the pets' relationships over
+// time, lets pets mark and react to
+// territory, runs location events, advances the weather and lets pets react
+// to it, lets parents care for their young, lets neglected pets run away
+// and turns up clues about missing ones, grows the garden, delivers litters
+// that are due, lets pets shake off accessories they dislike, holds
+// certification exams, collects insurance premiums, sends sanctuary pets
+// to patrons, awards epithets pets have earned, and sends vet reminders
+// for pets whose health has dropped
+func (h *Household) Update(deltaTime float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Attention.Decay(deltaTime)
+	h.Relationships.DecayAll(deltaTime)
+	h.updateTerritory(deltaTime)
+
+	if h.Environment != nil {
+		h.updateEnvironment(deltaTime)
+	}
+
+	if h.Weather != nil {
+		h.Weather.Update(deltaTime)
+		effects := h.Weather.Effects()
+		for _, pet := range h.Pets {
+			pet.ReactToWeather(h.weatherAt(pet, effects), deltaTime)
+		}
+	}
+
+	h.updateParenting(deltaTime)
+	h.updateRescues(deltaTime)
+
+	if h.Garden != nil {
+		h.updateGarden(deltaTime)
+	}
+
+	if h.Surprises != nil {
+		h.updateSurprises(deltaTime)
+	}
+
+	if h.Adoption != nil {
+		h.updateAdoption(deltaTime)
+	}
+
+	h.updatePregnancies(deltaTime)
+	h.updateAccessories(deltaTime)
+	h.updateExams(deltaTime)
+	h.updateInsurance(deltaTime)
+	h.updateSanctuary(deltaTime)
+	h.updateTitles()
+	h.updateUnlocks()
+
+	for id, pet := range h.Pets {
+		thermo := pet.Biology.Thermoregulation
+		unwell := pet.IsAlive() && (pet.Biology.Vitals.Health < VetReminderThreshold || thermo.HasCondition())
+		if unwell && !h.vetReminded[id] {
+			body := fmt.Sprintf("%s's health has dropped to %.0f%%. Consider medical care soon.",
+				pet.Name, pet.Biology.Vitals.Health*100)
+			if thermo.HasCondition() {
+				body = fmt.Sprintf("%s has %s (body temperature %.1f°C) and needs medical care.",
+					pet.Name, strings.ToLower(thermo.Condition.String()), thermo.BodyTemperature)
+			}
+			h.Inbox.Post(MessageVetReminder, id, fmt.Sprintf("%s should see the vet", pet.Name), body)
+		}
+		h.vetReminded[id] = unwell
+	}
+}
+
+// VetDue returns true while a pet has an unanswered vet reminder
+func (h *Household) VetDue(petID types.PetID) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.vetReminded[petID]
+}
+
+// IsAttention returns true for interactions that other pets would envy
+func IsAttention(interactionType types.InteractionType) bool {
+	switch interactionType {
+	case types.InteractionFeeding, types.InteractionPetting, types.InteractionPlaying,
+		types.InteractionGrooming, types.InteractionRewards, types.InteractionComfort:
+		return true
+	default:
+		return false
+	}
+}
+
+// JealousyIntensity scales a raw attention imbalance by how attached the
+// bystander is to the user and how prone its personality is to jealousy
+func JealousyIntensity(bystander *core.DigitalPet, imbalance float64) float64 {
+	traits := bystander.Personality.Traits
+
+	// Pets attached to the user mind more when attention goes elsewhere
+	userBond := (traits.Loyalty + traits.Affectionate + bystander.Emotions.Affection) / 3.0
+
+	// Anxious, territorial, dependent pets are more jealous
+	susceptibility := (traits.Neuroticism + traits.Territoriality + (1.0 - traits.Independence)) / 3.0
+
+	return clamp(imbalance*userBond*susceptibility*2.0, 0.0, 1.0)
+}
+
+// ApplyJealousy applies a jealous reaction to a bystander pet. The
+// household strains both sides of its relationship with the favored pet
+// through its coordinator.
+func ApplyJealousy(bystander, favored *core.DigitalPet, jealousy float64) {
+	bystander.Emotions.ApplyEmotionalStimulus(ai.EmotionalStimulus{
+		JealousyDelta: 0.3 * jealousy,
+		AngerDelta:    0.1 * jealousy,
+		SadnessDelta:  0.05 * jealousy,
+		Source:        "jealousy",
+	})
+	bystander.Biology.Vitals.Stress += 0.05 * jealousy
+	bystander.Biology.Vitals.Clamp()
+}
+
+// Helper function to clamp values
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
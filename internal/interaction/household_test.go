@@ -0,0 +1,232 @@
+package interaction
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// jealousPet creates a pet whose personality makes it prone to jealousy
+func jealousPet(name string) *core.DigitalPet {
+	pet := core.NewDigitalPet(name, "user123")
+	pet.Personality.Traits.Loyalty = 1.0
+	pet.Personality.Traits.Affectionate = 1.0
+	pet.Personality.Traits.Neuroticism = 1.0
+	pet.Personality.Traits.Territoriality = 1.0
+	pet.Personality.Traits.Independence = 0.0
+	return pet
+}
+
+func TestInteractCausesJealousy(t *testing.T) {
+	favored := core.NewDigitalPet("Favored", "user123")
+	ignored := jealousPet("Ignored")
+	h := NewHousehold(favored, ignored)
+	h.Relationships.Introduce(ignored.ID, favored.ID, types.RelationshipFriend)
+	initialBond, _ := ignored.Relationships.GetRelationship(favored.ID)
+	bondBefore := initialBond.BondStrength
+	initialBack, _ := favored.Relationships.GetRelationship(ignored.ID)
+	backBefore := initialBack.BondStrength
+
+	reactions, err := h.Interact(favored.ID, types.InteractionPetting, 1.0)
+	if err != nil {
+		t.Fatalf("Interact failed: %v", err)
+	}
+
+	if len(reactions) != 1 || reactions[0].PetID != ignored.ID {
+		t.Fatalf("Expected ignored pet to react, got %v", reactions)
+	}
+
+	if ignored.Emotions.Jealousy <= 0 {
+		t.Error("Ignored pet should become jealous")
+	}
+
+	rel, _ := ignored.Relationships.GetRelationship(favored.ID)
+	if rel.BondStrength >= bondBefore {
+		t.Error("Jealousy should strain the relationship with the favored pet")
+	}
+	back, _ := favored.Relationships.GetRelationship(ignored.ID)
+	if back.BondStrength >= backBefore {
+		t.Error("Jealousy should strain the favored pet's side of the relationship too")
+	}
+}
+
+func TestHouseholdDecaysRelationships(t *testing.T) {
+	a := core.NewDigitalPet("A", "user123")
+	b := core.NewDigitalPet("B", "user123")
+	h := NewHousehold(a, b)
+	h.Relationships.Introduce(a.ID, b.ID, types.RelationshipFriend)
+	h.Relationships.RecordInteraction(a.ID, b.ID, 1.0, 0)
+	rel, _ := a.Relationships.GetRelationship(b.ID)
+	rel.LastInteraction = time.Now().Add(-72 * time.Hour)
+	before := rel.BondStrength
+
+	a.Update(5.0)
+	if rel.BondStrength != before {
+		t.Errorf("Expected the pet's own update to leave relationships to the household, got %.3f -> %.3f", before, rel.BondStrength)
+	}
+	h.Update(5.0)
+	if rel.BondStrength >= before {
+		t.Errorf("Expected the household to decay relationships, got %.3f -> %.3f", before, rel.BondStrength)
+	}
+}
+
+func TestEvenAttentionMitigatesJealousy(t *testing.T) {
+	a := jealousPet("A")
+	b := jealousPet("B")
+	h := NewHousehold(a, b)
+
+	h.Interact(a.ID, types.InteractionPetting, 1.0)
+	reactions, _ := h.Interact(b.ID, types.InteractionPetting, 1.0)
+
+	if len(reactions) != 0 {
+		t.Errorf("Evenly shared attention should not cause jealousy, got %v", reactions)
+	}
+}
+
+func TestNonAttentionInteraction(t *testing.T) {
+	a := core.NewDigitalPet("A", "user123")
+	b := jealousPet("B")
+	h := NewHousehold(a, b)
+
+	reactions, _ := h.Interact(a.ID, types.InteractionDiscipline, 1.0)
+	if len(reactions) != 0 {
+		t.Error("Discipline should not make other pets jealous")
+	}
+}
+
+func TestInteractMissingPet(t *testing.T) {
+	h := NewHousehold()
+	if _, err := h.Interact("ghost", types.InteractionPetting, 1.0); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+}
+
+func TestGeneticScreeningIsPaidFor(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.Inventory.Coins = genetics.ScreeningCost + 10
+
+	if _, err := h.Interact(pet.ID, types.InteractionGeneticScreening, 1.0); err != nil {
+		t.Fatalf("Interact failed: %v", err)
+	}
+	if h.Inventory.Coins != 10 || pet.Screening == nil {
+		t.Errorf("Expected the screening done for %d coins, got %d coins left and screening %v", genetics.ScreeningCost, h.Inventory.Coins, pet.Screening)
+	}
+
+	pet.Screening = nil
+	if _, err := h.Interact(pet.ID, types.InteractionGeneticScreening, 1.0); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Expected ErrInsufficientFunds, got %v", err)
+	}
+	if h.Inventory.Coins != 10 || pet.Screening != nil {
+		t.Errorf("Expected nothing charged or screened, got %d coins and screening %v", h.Inventory.Coins, pet.Screening)
+	}
+}
+
+func TestAttentionDecay(t *testing.T) {
+	at := NewAttentionTracker()
+	at.Record("a", 1.0)
+	at.Decay(1.0)
+
+	if at.Attention["a"] != 0.5 {
+		t.Errorf("Attention should halve after one day, got %.2f", at.Attention["a"])
+	}
+
+	if share := at.Share("b", []types.PetID{"a", "b"}); share != 0.0 {
+		t.Errorf("Pet with no attention should have zero share, got %.2f", share)
+	}
+}
+
+func TestIndependentPetLessJealous(t *testing.T) {
+	clingy := jealousPet("Clingy")
+	aloof := core.NewDigitalPet("Aloof", "user123")
+	aloof.Personality.Traits.Independence = 1.0
+	aloof.Personality.Traits.Neuroticism = 0.0
+	aloof.Personality.Traits.Territoriality = 0.0
+
+	if JealousyIntensity(aloof, 1.0) >= JealousyIntensity(clingy, 1.0) {
+		t.Error("Independent, calm pets should be less jealous")
+	}
+}
+
+func TestInteractWithHibernatingPet(t *testing.T) {
+	pet := core.NewDigitalPet("Dozy", "user123")
+	pet.Biology.Hibernation.Enter()
+	h := NewHousehold(pet)
+
+	if _, err := h.Interact(pet.ID, types.InteractionPlaying, 1.0); !errors.Is(err, ErrPetHibernating) {
+		t.Errorf("Expected ErrPetHibernating, got %v", err)
+	}
+	if _, err := h.Interact(pet.ID, types.InteractionPetting, 1.0); err != nil {
+		t.Errorf("Gentle petting should be allowed, got %v", err)
+	}
+}
+
+func TestLocationEventsAnnouncedAndApplied(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Location = "town"
+	pet.Personality.Traits.Extraversion = 1.0
+	h := NewHousehold(pet)
+	h.Environment = environment.NewSeededManager(environment.DefaultWorldMap(), 1)
+	h.Environment.StartEvent(environment.EventFestival, "town")
+	happinessBefore := pet.Biology.Vitals.Happiness
+
+	h.Update(0.5)
+
+	messages := h.Inbox.List()
+	if len(messages) == 0 || messages[0].Category != MessageFestival {
+		t.Fatalf("Expected a festival announcement, got %+v", messages)
+	}
+	if pet.Biology.Vitals.Happiness <= happinessBefore {
+		t.Error("An outgoing pet should enjoy the festival crowd")
+	}
+}
+
+func TestThermalConditionSendsVetReminder(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	pet.Biology.Thermoregulation.Condition = biology.ThermalHeatstroke
+
+	h.Update(0.01)
+
+	messages := h.Inbox.List()
+	if len(messages) != 1 || messages[0].Category != MessageVetReminder || !strings.Contains(messages[0].Body, "heatstroke") {
+		t.Errorf("Expected a heatstroke vet reminder, got %+v", messages)
+	}
+	if !h.VetDue(pet.ID) {
+		t.Error("Expected a vet visit to be due")
+	}
+
+	pet.Biology.Thermoregulation.Condition = biology.ThermalNone
+	h.Update(0.01)
+	if h.VetDue(pet.ID) {
+		t.Error("Expected the vet visit to be cleared once the pet recovered")
+	}
+}
+
+func TestDroughtDehydratesPets(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Location = "forest"
+	other := core.NewDigitalPet("Bella", "user123")
+	other.Location = "meadow"
+	h := NewHousehold(pet, other)
+	h.Environment = environment.NewSeededManager(environment.DefaultWorldMap(), 1)
+	h.Environment.StartEvent(environment.EventDrought, "forest")
+
+	h.Update(0.5)
+
+	messages := h.Inbox.List()
+	if len(messages) == 0 || messages[0].Subject != environment.EventDrought.String() {
+		t.Fatalf("Expected a drought announcement, got %+v", messages)
+	}
+	if pet.Biology.Vitals.Hydration >= other.Biology.Vitals.Hydration {
+		t.Errorf("A pet in a drought should dehydrate faster, got %.3f vs %.3f",
+			pet.Biology.Vitals.Hydration, other.Biology.Vitals.Hydration)
+	}
+}
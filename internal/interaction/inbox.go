@@ -0,0 +1,203 @@
+package interaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrMessageNotFound is returned when a message ID does not exist
+var ErrMessageNotFound = errors.New("message not found")
+
+// DefaultInboxCapacity is the number of messages kept before the oldest
+// read messages are discarded
+const DefaultInboxCapacity = 200
+
+// MessageCategory classifies inbox messages
+type MessageCategory int
+
+const (
+	MessageSystem MessageCategory = iota
+	MessageVetReminder
+	MessageQuest
+	MessageFriendRequest
+	MessageTradeOffer
+	MessageFestival
+	MessageCelebration
+	MessageSurprise
+	MessageFarewell
+)
+
+// String returns the string representation of MessageCategory
+func (mc MessageCategory) String() string {
+	return [...]string{
+		"System", "Vet Reminder", "Quest", "Friend Request",
+		"Trade Offer", "Festival", "Celebration", "Surprise", "Farewell",
+	}[mc]
+}
+
+// Message is a single asynchronous message for the player
+type Message struct {
+	ID       int             `json:"id"`
+	Category MessageCategory `json:"category"`
+	Subject  string          `json:"subject"`
+	Body     string          `json:"body"`
+	PetID    types.PetID     `json:"pet_id,omitempty"` // Pet the message concerns, if any
+	Received time.Time       `json:"received"`
+	Read     bool            `json:"read"`
+}
+
+// Inbox collects messages for the player so subsystems don't need to
+// print directly to the console
+type Inbox struct {
+	mu sync.RWMutex
+
+	Messages []*Message `json:"messages"`
+	NextID   int        `json:"next_id"`
+	Capacity int        `json:"capacity"`
+}
+
+// NewInbox creates an empty inbox
+func NewInbox() *Inbox {
+	return &Inbox{
+		Messages: make([]*Message, 0),
+		NextID:   1,
+		Capacity: DefaultInboxCapacity,
+	}
+}
+
+// Post delivers a new message and returns it
+func (ib *Inbox) Post(category MessageCategory, petID types.PetID, subject, body string) *Message {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	msg := &Message{
+		ID:       ib.NextID,
+		Category: category,
+		Subject:  subject,
+		Body:     body,
+		PetID:    petID,
+		Received: time.Now(),
+	}
+	ib.NextID++
+	ib.Messages = append(ib.Messages, msg)
+	ib.trim()
+	return msg
+}
+
+// trim discards the oldest read messages over capacity (must be called with lock held)
+func (ib *Inbox) trim() {
+	for len(ib.Messages) > ib.Capacity {
+		removed := false
+		for i, msg := range ib.Messages {
+			if msg.Read {
+				ib.Messages = append(ib.Messages[:i], ib.Messages[i+1:]...)
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			// Everything is unread; drop the oldest
+			ib.Messages = ib.Messages[1:]
+		}
+	}
+}
+
+// Get returns a message by ID
+func (ib *Inbox) Get(id int) (*Message, error) {
+	ib.mu.RLock()
+	defer ib.mu.RUnlock()
+
+	for _, msg := range ib.Messages {
+		if msg.ID == id {
+			return msg, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d", ErrMessageNotFound, id)
+}
+
+// Open returns a message and marks it read
+func (ib *Inbox) Open(id int) (*Message, error) {
+	msg, err := ib.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ib.mu.Lock()
+	msg.Read = true
+	ib.mu.Unlock()
+	return msg, nil
+}
+
+// MarkAllRead marks every message read
+func (ib *Inbox) MarkAllRead() {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	for _, msg := range ib.Messages {
+		msg.Read = true
+	}
+}
+
+// Delete removes a message
+func (ib *Inbox) Delete(id int) error {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	for i, msg := range ib.Messages {
+		if msg.ID == id {
+			ib.Messages = append(ib.Messages[:i], ib.Messages[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %d", ErrMessageNotFound, id)
+}
+
+// List returns all messages, newest first
+func (ib *Inbox) List() []*Message {
+	ib.mu.RLock()
+	defer ib.mu.RUnlock()
+
+	list := make([]*Message, 0, len(ib.Messages))
+	for i := len(ib.Messages) - 1; i >= 0; i-- {
+		list = append(list, ib.Messages[i])
+	}
+	return list
+}
+
+// UnreadCount returns the number of unread messages
+func (ib *Inbox) UnreadCount() int {
+	ib.mu.RLock()
+	defer ib.mu.RUnlock()
+
+	count := 0
+	for _, msg := range ib.Messages {
+		if !msg.Read {
+			count++
+		}
+	}
+	return count
+}
+
+// Save serializes the inbox to JSON
+func (ib *Inbox) Save() ([]byte, error) {
+	ib.mu.RLock()
+	defer ib.mu.RUnlock()
+	return json.MarshalIndent(ib, "", "  ")
+}
+
+// LoadInbox deserializes an inbox from JSON
+func LoadInbox(data []byte) (*Inbox, error) {
+	ib := NewInbox()
+	if err := json.Unmarshal(data, ib); err != nil {
+		return nil, err
+	}
+	if ib.Capacity <= 0 {
+		ib.Capacity = DefaultInboxCapacity
+	}
+	return ib, nil
+}
@@ -0,0 +1,89 @@
+package interaction
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestInboxReadUnread(t *testing.T) {
+	ib := NewInbox()
+	first := ib.Post(MessageQuest, "", "Quest complete", "You found the lost ball.")
+	ib.Post(MessageFestival, "", "Spring festival", "The festival starts tomorrow.")
+
+	if ib.UnreadCount() != 2 {
+		t.Fatalf("Expected 2 unread, got %d", ib.UnreadCount())
+	}
+
+	if _, err := ib.Open(first.ID); err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	if ib.UnreadCount() != 1 {
+		t.Error("Opening a message should mark it read")
+	}
+
+	if list := ib.List(); list[0].Subject != "Spring festival" {
+		t.Error("List should return newest first")
+	}
+
+	if _, err := ib.Open(99); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("Expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestInboxCapacityDropsReadFirst(t *testing.T) {
+	ib := NewInbox()
+	ib.Capacity = 2
+	keep := ib.Post(MessageSystem, "", "unread", "")
+	read := ib.Post(MessageSystem, "", "read", "")
+	ib.Open(read.ID)
+	ib.Post(MessageSystem, "", "new", "")
+
+	if _, err := ib.Get(keep.ID); err != nil {
+		t.Error("Unread messages should be kept over read ones")
+	}
+	if _, err := ib.Get(read.ID); err == nil {
+		t.Error("Read message should be discarded first")
+	}
+}
+
+func TestInboxPersistence(t *testing.T) {
+	ib := NewInbox()
+	ib.Post(MessageTradeOffer, "pet_1", "Trade offer", "A neighbour offers a rare toy.")
+
+	data, err := ib.Save()
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadInbox(data)
+	if err != nil {
+		t.Fatalf("LoadInbox failed: %v", err)
+	}
+
+	if loaded.UnreadCount() != 1 || loaded.NextID != 2 {
+		t.Error("Loaded inbox should match the saved one")
+	}
+	if msg := loaded.Post(MessageSystem, "", "next", ""); msg.ID != 2 {
+		t.Errorf("IDs should continue after load, got %d", msg.ID)
+	}
+}
+
+func TestHouseholdPostsToInbox(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+
+	pet.Biology.Vitals.Health = 0.4
+	h.Update(0.0)
+	h.Update(0.0)
+	if h.Inbox.UnreadCount() != 1 {
+		t.Errorf("Expected a single vet reminder, got %d messages", h.Inbox.UnreadCount())
+	}
+
+	pet.Biology.Processes.Age = PetYearDays
+	h.Celebrate(time.Now())
+	if h.Inbox.UnreadCount() != 2 {
+		t.Error("Celebrations should be posted to the inbox")
+	}
+}
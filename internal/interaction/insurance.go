@@ -0,0 +1,357 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrUnknownPlan is returned when an insurance plan name is not recognised
+	ErrUnknownPlan = errors.New("unknown insurance plan")
+	// ErrNoTreatmentNeeded is returned when taking a pet to the vet that needs no major treatment
+	ErrNoTreatmentNeeded = errors.New("no major treatment needed")
+	// ErrInvalidAmount is returned when moving a zero or negative number of coins
+	ErrInvalidAmount = errors.New("amount must be a positive number of coins")
+)
+
+// Insurance settings
+const (
+	PremiumIntervalDays = 7.0  // Game days between premium payments
+	ClaimLoading        = 0.25 // Premium increase per claim
+	NoClaimsDiscount    = 0.1  // Loading removed for each claim-free premium period
+	SurgeryThreshold    = 0.25 // Health below which a pet needs surgery
+	SurgeryHealth       = 0.6  // Health a pet is brought back to by surgery
+)
+
+// Treatment is a major procedure at the vet, as opposed to everyday
+// medical care
+type Treatment int
+
+const (
+	TreatmentIllness Treatment = iota // Treating a serious illness such as heatstroke
+	TreatmentSurgery                  // Surgery for a pet in critical health
+)
+
+// String returns the string representation of Treatment
+func (t Treatment) String() string {
+	return [...]string{"Illness Treatment", "Surgery"}[t]
+}
+
+// Cost returns the vet's bill for the treatment in coins
+func (t Treatment) Cost() int {
+	return [...]int{90, 240}[t]
+}
+
+// InsurancePlan is a level of pet insurance cover
+type InsurancePlan int
+
+const (
+	PlanNone InsurancePlan = iota
+	PlanBasic
+	PlanStandard
+	PlanPremium
+)
+
+// String returns the string representation of InsurancePlan
+func (p InsurancePlan) String() string {
+	return [...]string{"None", "Basic", "Standard", "Premium"}[p]
+}
+
+// AllInsurancePlans returns the plans on offer, cheapest first
+func AllInsurancePlans() []InsurancePlan {
+	return []InsurancePlan{PlanBasic, PlanStandard, PlanPremium}
+}
+
+// ParseInsurancePlan looks up a plan by name, ignoring case; "none"
+// cancels cover
+func ParseInsurancePlan(name string) (InsurancePlan, error) {
+	for _, plan := range append([]InsurancePlan{PlanNone}, AllInsurancePlans()...) {
+		if strings.EqualFold(plan.String(), name) {
+			return plan, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownPlan, name)
+}
+
+// PlanTerms are what an insurance plan costs and covers
+type PlanTerms struct {
+	Premium  int     // Coins per PremiumIntervalDays before any claims loading
+	Coverage float64 // Share of a bill paid once the excess is taken off
+	Excess   int     // Coins the player pays towards every claim
+	Limit    int     // Most paid out on a single claim
+}
+
+// planTerms lists the terms of each plan
+var planTerms = map[InsurancePlan]PlanTerms{
+	PlanBasic:    {Premium: 8, Coverage: 0.5, Excess: 40, Limit: 100},
+	PlanStandard: {Premium: 15, Coverage: 0.7, Excess: 25, Limit: 200},
+	PlanPremium:  {Premium: 25, Coverage: 0.9, Excess: 10, Limit: 400},
+}
+
+// Terms returns the plan's terms; PlanNone covers nothing
+func (p InsurancePlan) Terms() PlanTerms {
+	return planTerms[p]
+}
+
+// Payout returns how much of a vet bill the plan pays
+func (t PlanTerms) Payout(cost int) int {
+	payout := int(math.Round(float64(cost-t.Excess) * t.Coverage))
+	if payout < 0 {
+		return 0
+	}
+	return min(payout, t.Limit)
+}
+
+// Policy is a pet's insurance cover. It is kept when cover is cancelled,
+// so a pet's claims still count against it if it is insured again.
+type Policy struct {
+	PetID   types.PetID   `json:"pet_id"`
+	Plan    InsurancePlan `json:"plan"`
+	Loading float64       `json:"loading"`  // Premium increase from recent claims
+	Claims  int           `json:"claims"`   // Claims made in total
+	Paid    int           `json:"paid"`     // Premiums paid in total
+	PaidOut int           `json:"paid_out"` // Coins paid out on claims in total
+	DueIn   float64       `json:"due_in"`   // Game days until the next premium
+
+	claimed bool // Whether a claim was made this premium period
+}
+
+// Premium returns what the policy costs per PremiumIntervalDays
+func (p Policy) Premium() int {
+	return p.premiumFor(p.Plan)
+}
+
+// premiumFor returns what a plan would cost the policy holder, claims
+// loading included
+func (p Policy) premiumFor(plan InsurancePlan) int {
+	return int(math.Round(float64(plan.Terms().Premium) * (1.0 + p.Loading)))
+}
+
+// PlanQuote compares one plan for a pet: what it costs and what the
+// player would still pay for each major treatment
+type PlanQuote struct {
+	Plan        InsurancePlan
+	Terms       PlanTerms
+	Premium     int               // Coins per PremiumIntervalDays with the pet's claims loading
+	OutOfPocket map[Treatment]int // Coins the player pays per treatment
+	Current     bool              // Whether the pet is on this plan
+}
+
+// VetBill is what a treatment cost and how it was paid for
+type VetBill struct {
+	Treatment Treatment
+	Cost      int
+	Covered   int // Paid by insurance
+	FromFund  int // Paid from the emergency fund
+	FromCoins int // Paid from the player's coins
+	Premium   int // Premium after the claim; 0 if the pet is uninsured
+}
+
+// Insure puts a present pet on an insurance plan. Taking out cover pays
+// the first premium at once; switching plans changes the next premium;
+// PlanNone cancels cover.
+func (h *Household) Insure(petID types.PetID, plan InsurancePlan) (Policy, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, present := h.Pets[petID]; !present {
+		return Policy{}, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+
+	policy := h.policies[petID]
+	if policy == nil {
+		policy = &Policy{PetID: petID}
+	}
+	if policy.Plan == PlanNone && plan != PlanNone {
+		premium := policy.premiumFor(plan)
+		if err := h.Inventory.Spend(premium); err != nil {
+			return Policy{}, err
+		}
+		policy.Paid += premium
+		policy.DueIn = PremiumIntervalDays
+	}
+	policy.Plan = plan
+	h.policies[petID] = policy
+	return *policy, nil
+}
+
+// Policies returns the insurance policies of present pets, including
+// cancelled ones, ordered by pet ID
+func (h *Household) Policies() []Policy {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var policies []Policy
+	for id, policy := range h.policies {
+		if _, present := h.Pets[id]; present {
+			policies = append(policies, *policy)
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].PetID < policies[j].PetID })
+	return policies
+}
+
+// ComparePlans quotes every plan for a present pet
+func (h *Household) ComparePlans(petID types.PetID) ([]PlanQuote, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, present := h.Pets[petID]; !present {
+		return nil, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+
+	policy := Policy{PetID: petID}
+	if existing := h.policies[petID]; existing != nil {
+		policy = *existing
+	}
+	quotes := make([]PlanQuote, 0, len(planTerms))
+	for _, plan := range AllInsurancePlans() {
+		terms := plan.Terms()
+		quote := PlanQuote{
+			Plan:        plan,
+			Terms:       terms,
+			Premium:     policy.premiumFor(plan),
+			OutOfPocket: make(map[Treatment]int),
+			Current:     policy.Plan == plan,
+		}
+		for _, treatment := range []Treatment{TreatmentIllness, TreatmentSurgery} {
+			quote.OutOfPocket[treatment] = treatment.Cost() - terms.Payout(treatment.Cost())
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes, nil
+}
+
+// EmergencyFund returns the coins set aside for vet bills
+func (h *Household) EmergencyFund() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.fund
+}
+
+// DepositFund moves coins from the inventory into the emergency fund
+func (h *Household) DepositFund(amount int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if amount <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+	if err := h.Inventory.Spend(amount); err != nil {
+		return err
+	}
+	h.fund += amount
+	return nil
+}
+
+// WithdrawFund moves coins from the emergency fund back to the inventory
+func (h *Household) WithdrawFund(amount int) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if amount <= 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidAmount, amount)
+	}
+	if amount > h.fund {
+		return fmt.Errorf("%w: %d coins, fund has %d", environment.ErrOutOfStock, amount, h.fund)
+	}
+	h.fund -= amount
+	h.Inventory.Coins += amount
+	return nil
+}
+
+// neededTreatment returns the major treatment a pet needs, if any
+func neededTreatment(pet *core.DigitalPet) (Treatment, bool) {
+	switch {
+	case pet.Biology.Thermoregulation.HasCondition():
+		return TreatmentIllness, true
+	case pet.Biology.Vitals.Health < SurgeryThreshold:
+		return TreatmentSurgery, true
+	}
+	return 0, false
+}
+
+// Treat takes a present pet to the vet for the major treatment it needs.
+// Insurance pays its share of the bill, the emergency fund pays what it
+// can of the rest and the player's coins cover the remainder; nothing is
+// paid or treated if that is not enough. A claim raises the premium.
+func (h *Household) Treat(petID types.PetID) (VetBill, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, present := h.Pets[petID]
+	if !present {
+		return VetBill{}, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	treatment, needed := neededTreatment(pet)
+	if !needed || !pet.IsAlive() {
+		return VetBill{}, fmt.Errorf("%w: %s", ErrNoTreatmentNeeded, pet.Name)
+	}
+
+	bill := VetBill{Treatment: treatment, Cost: treatment.Cost()}
+	policy := h.policies[petID]
+	if policy != nil {
+		bill.Covered = policy.Plan.Terms().Payout(bill.Cost)
+	}
+	owed := bill.Cost - bill.Covered
+	bill.FromFund = min(owed, h.fund)
+	bill.FromCoins = owed - bill.FromFund
+	if err := h.Inventory.Spend(bill.FromCoins); err != nil {
+		return VetBill{}, err
+	}
+	h.fund -= bill.FromFund
+
+	if _, err := h.interact(petID, types.InteractionMedicalCare, 1.0); err != nil {
+		h.fund += bill.FromFund
+		h.Inventory.Coins += bill.FromCoins
+		return VetBill{}, err
+	}
+	if treatment == TreatmentSurgery {
+		pet.Biology.Vitals.Health = math.Max(pet.Biology.Vitals.Health, SurgeryHealth)
+	}
+
+	if bill.Covered > 0 {
+		policy.Claims++
+		policy.Loading += ClaimLoading
+		policy.PaidOut += bill.Covered
+		policy.claimed = true
+		bill.Premium = policy.Premium()
+		h.Inbox.Post(MessageSystem, petID, fmt.Sprintf("Insurance claim for %s paid", pet.Name),
+			fmt.Sprintf("Your %s plan paid %d coins towards %s's %s. Your premium is now %d coins a week.",
+				policy.Plan, bill.Covered, pet.Name, strings.ToLower(treatment.String()), bill.Premium))
+	}
+	return bill, nil
+}
+
+// updateInsurance collects premiums that are due, easing the claims
+// loading after a claim-free period. Cover lapses when a premium cannot
+// be paid (must be called with lock held).
+func (h *Household) updateInsurance(deltaTime float64) {
+	for id, policy := range h.policies {
+		pet, present := h.Pets[id]
+		if !present || policy.Plan == PlanNone {
+			continue
+		}
+		policy.DueIn -= deltaTime
+		if policy.DueIn > 0 {
+			continue
+		}
+		policy.DueIn += PremiumIntervalDays
+		if !policy.claimed {
+			policy.Loading = math.Max(policy.Loading-NoClaimsDiscount, 0.0)
+		}
+		policy.claimed = false
+
+		premium := policy.Premium()
+		if err := h.Inventory.Spend(premium); err != nil {
+			plan := policy.Plan
+			policy.Plan = PlanNone
+			h.Inbox.Post(MessageSystem, id, fmt.Sprintf("%s's insurance has lapsed", pet.Name),
+				fmt.Sprintf("The %d coin premium for %s's %s plan could not be paid, so %s is no longer insured.",
+					premium, pet.Name, plan, pet.Name))
+			continue
+		}
+		policy.Paid += premium
+	}
+}
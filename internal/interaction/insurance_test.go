@@ -0,0 +1,130 @@
+package interaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestPlanPayout(t *testing.T) {
+	terms := PlanStandard.Terms()
+	if got := terms.Payout(TreatmentIllness.Cost()); got != 46 {
+		t.Errorf("Expected 70%% of 90-25 = 46 coins, got %d", got)
+	}
+	if got := terms.Payout(1000); got != terms.Limit {
+		t.Errorf("Payout should be capped at the limit, got %d", got)
+	}
+	if got := PlanBasic.Terms().Payout(20); got != 0 {
+		t.Errorf("A bill under the excess should pay nothing, got %d", got)
+	}
+	if got := PlanNone.Terms().Payout(240); got != 0 {
+		t.Errorf("No plan should pay nothing, got %d", got)
+	}
+	if plan, err := ParseInsurancePlan("premium"); err != nil || plan != PlanPremium {
+		t.Errorf("ParseInsurancePlan(premium) = %v, %v", plan, err)
+	}
+	if _, err := ParseInsurancePlan("gold"); !errors.Is(err, ErrUnknownPlan) {
+		t.Errorf("Expected ErrUnknownPlan, got %v", err)
+	}
+}
+
+func TestInsuranceClaimRaisesPremium(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	coins := h.Inventory.Coins
+
+	policy, err := h.Insure(pet.ID, PlanStandard)
+	if err != nil {
+		t.Fatalf("Insure failed: %v", err)
+	}
+	if h.Inventory.Coins != coins-policy.Premium() {
+		t.Errorf("The first premium should be paid at once, have %d coins", h.Inventory.Coins)
+	}
+	if _, err := h.Treat(pet.ID); !errors.Is(err, ErrNoTreatmentNeeded) {
+		t.Errorf("A healthy pet should need no treatment, got %v", err)
+	}
+
+	pet.Biology.Thermoregulation.Condition = biology.ThermalHeatstroke
+	coins = h.Inventory.Coins
+	bill, err := h.Treat(pet.ID)
+	if err != nil {
+		t.Fatalf("Treat failed: %v", err)
+	}
+	if bill.Treatment != TreatmentIllness || bill.Covered != PlanStandard.Terms().Payout(bill.Cost) {
+		t.Errorf("Expected the illness to be partly covered, got %+v", bill)
+	}
+	if h.Inventory.Coins != coins-(bill.Cost-bill.Covered) {
+		t.Errorf("Expected the player to pay the rest, have %d coins", h.Inventory.Coins)
+	}
+	if pet.Biology.Thermoregulation.HasCondition() {
+		t.Error("Treatment should cure the heatstroke")
+	}
+	if bill.Premium <= policy.Premium() {
+		t.Errorf("A claim should raise the premium from %d, got %d", policy.Premium(), bill.Premium)
+	}
+
+	// The loading eases off after claim-free periods
+	h.Inventory.Coins = 1000
+	h.Update(PremiumIntervalDays)
+	h.Update(PremiumIntervalDays)
+	if got := h.Policies()[0].Premium(); got >= bill.Premium {
+		t.Errorf("The premium should ease after a claim-free week, got %d", got)
+	}
+}
+
+func TestSurgeryDrawsOnEmergencyFund(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	if err := h.DepositFund(100); err != nil {
+		t.Fatalf("DepositFund failed: %v", err)
+	}
+	if err := h.DepositFund(0); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Expected ErrInvalidAmount, got %v", err)
+	}
+	coins := h.Inventory.Coins
+
+	pet.Biology.Vitals.Health = 0.1
+	bill, err := h.Treat(pet.ID)
+	if err != nil {
+		t.Fatalf("Treat failed: %v", err)
+	}
+	if bill.Treatment != TreatmentSurgery || bill.Covered != 0 || bill.FromFund != 100 || bill.FromCoins != bill.Cost-100 {
+		t.Errorf("Expected the fund to pay first and coins the rest, got %+v", bill)
+	}
+	if h.EmergencyFund() != 0 || h.Inventory.Coins != coins-bill.FromCoins {
+		t.Errorf("Expected an empty fund and %d coins, got %d and %d", coins-bill.FromCoins, h.EmergencyFund(), h.Inventory.Coins)
+	}
+	if pet.Biology.Vitals.Health < SurgeryHealth {
+		t.Errorf("Surgery should restore health, got %.2f", pet.Biology.Vitals.Health)
+	}
+
+	pet.Biology.Vitals.Health = 0.1
+	h.Inventory.Coins = 10
+	if _, err := h.Treat(pet.ID); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock without the coins, got %v", err)
+	}
+	if pet.Biology.Vitals.Health != 0.1 || h.Inventory.Coins != 10 {
+		t.Error("An unpaid treatment should change nothing")
+	}
+}
+
+func TestUnpaidPremiumLapsesCover(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	if _, err := h.Insure(pet.ID, PlanPremium); err != nil {
+		t.Fatalf("Insure failed: %v", err)
+	}
+	h.Inventory.Coins = 0
+
+	h.Update(PremiumIntervalDays)
+
+	if policies := h.Policies(); len(policies) != 1 || policies[0].Plan != PlanNone {
+		t.Errorf("Expected the cover to lapse, got %+v", policies)
+	}
+	if messages := h.Inbox.List(); len(messages) == 0 {
+		t.Error("Expected a lapse notice in the inbox")
+	}
+}
@@ -0,0 +1,232 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrAlreadyExpecting is returned when breeding a pet that is already expecting a litter
+	ErrAlreadyExpecting = errors.New("already expecting a litter")
+	// ErrNoSuchPup is returned when a name does not match any undecided pup
+	ErrNoSuchPup = errors.New("no such pup waiting for a decision")
+	// ErrNameTaken is returned when naming a pup after a pet that already has the name
+	ErrNameTaken = errors.New("name already taken")
+)
+
+// Litter settings
+const (
+	GestationDays = 3.0 // Game days from breeding to birth
+	TagLitter     = "litter"
+)
+
+// Pregnancy is a litter on its way. Its size is fixed at breeding from the
+// parents' genes and health.
+type Pregnancy struct {
+	Parents [2]*core.DigitalPet
+	Size    int     // Pups expected
+	DueIn   float64 // Game days until birth
+}
+
+// Litter is a group of newborns waiting for the player to name them and
+// decide who stays. Pups are not part of the household until kept.
+type Litter struct {
+	Parents [2]string          `json:"parents"` // Parents' names when the litter was born
+	Pups    []*core.DigitalPet `json:"pups"`
+}
+
+// Breed pairs two present pets. If they are compatible the litter is born
+// after GestationDays; the size is drawn from their genes and average
+// health.
+func (h *Household) Breed(parent1, parent2 *core.DigitalPet) (*Pregnancy, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, parent := range []*core.DigitalPet{parent1, parent2} {
+		if _, present := h.Pets[parent.ID]; !present {
+			return nil, fmt.Errorf("%w: %s", ErrPetNotPresent, parent.Name)
+		}
+		if h.expecting(parent.ID) {
+			return nil, fmt.Errorf("%w: %s", ErrAlreadyExpecting, parent.Name)
+		}
+	}
+	compat := core.CheckBreedingCompatibility(parent1, parent2)
+	if !compat.CanBreed {
+		return nil, fmt.Errorf("%w: %s", core.ErrIncompatibleParents, strings.Join(compat.Reasons, "; "))
+	}
+
+	health := (parent1.Biology.Vitals.Health + parent2.Biology.Vitals.Health) / 2.0
+	pregnancy := &Pregnancy{
+		Parents: [2]*core.DigitalPet{parent1, parent2},
+		Size:    genetics.LitterSize(parent1.Genome, parent2.Genome, health, h.rng),
+		DueIn:   GestationDays,
+	}
+	h.pregnancies = append(h.pregnancies, pregnancy)
+	return pregnancy, nil
+}
+
+// Pregnancies returns the litters on their way
+func (h *Household) Pregnancies() []Pregnancy {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pregnancies := make([]Pregnancy, len(h.pregnancies))
+	for i, pregnancy := range h.pregnancies {
+		pregnancies[i] = *pregnancy
+	}
+	return pregnancies
+}
+
+// Litters returns the newborns still waiting for a decision
+func (h *Household) Litters() []Litter {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	litters := make([]Litter, len(h.litters))
+	for i, litter := range h.litters {
+		litters[i] = Litter{Parents: litter.Parents, Pups: append([]*core.DigitalPet(nil), litter.Pups...)}
+	}
+	return litters
+}
+
+// NamePup renames an undecided pup. The name must not belong to a present
+// pet or another pup.
+func (h *Household) NamePup(nameOrID, name string) (*core.DigitalPet, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pup, _, err := h.findPup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	taken := func(pet *core.DigitalPet) bool { return pet != pup && strings.EqualFold(pet.Name, name) }
+	for _, pet := range h.Pets {
+		if taken(pet) {
+			return nil, fmt.Errorf("%w: %s", ErrNameTaken, name)
+		}
+	}
+	for _, litter := range h.litters {
+		for _, other := range litter.Pups {
+			if taken(other) {
+				return nil, fmt.Errorf("%w: %s", ErrNameTaken, name)
+			}
+		}
+	}
+	pup.Rename(name)
+	return pup, nil
+}
+
+// KeepPup brings an undecided pup into the household if it is below its
+// population cap
+func (h *Household) KeepPup(nameOrID string) (*core.DigitalPet, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pup, litter, err := h.findPup(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	// The pup is already counted while it waits, so only the pets present
+	// can leave it no room
+	if err := h.capped(len(h.Pets)); err != nil {
+		return nil, err
+	}
+	h.takePup(litter, pup)
+	pup.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: fmt.Sprintf("%s was born at home to %s and %s.", pup.Name, litter.Parents[0], litter.Parents[1]),
+		GameTime:    pup.GetAge(),
+		Strength:    0.8,
+		Valence:     0.8,
+		Emotion:     pup.Emotions.DominantEmotion,
+		Tags:        []string{TagLitter},
+	})
+	h.join(pup)
+	return pup, nil
+}
+
+// expecting returns true if a pet is a parent of a litter on its way (must
+// be called with lock held)
+func (h *Household) expecting(petID types.PetID) bool {
+	for _, pregnancy := range h.pregnancies {
+		if pregnancy.Parents[0].ID == petID || pregnancy.Parents[1].ID == petID {
+			return true
+		}
+	}
+	return false
+}
+
+// findPup looks up an undecided pup by ID or case-insensitive name (must
+// be called with lock held)
+func (h *Household) findPup(nameOrID string) (*core.DigitalPet, *Litter, error) {
+	for _, litter := range h.litters {
+		for _, pup := range litter.Pups {
+			if pup.ID == types.PetID(nameOrID) || strings.EqualFold(pup.Name, nameOrID) {
+				return pup, litter, nil
+			}
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrNoSuchPup, nameOrID)
+}
+
+// takePup removes a decided pup from its litter, dropping the litter once
+// every pup is decided (must be called with lock held)
+func (h *Household) takePup(litter *Litter, pup *core.DigitalPet) {
+	for i, waiting := range litter.Pups {
+		if waiting == pup {
+			litter.Pups = append(litter.Pups[:i], litter.Pups[i+1:]...)
+			break
+		}
+	}
+	if len(litter.Pups) > 0 {
+		return
+	}
+	for i, waiting := range h.litters {
+		if waiting == litter {
+			h.litters = append(h.litters[:i], h.litters[i+1:]...)
+			return
+		}
+	}
+}
+
+// updatePregnancies advances gestation and announces litters as they are
+// born (must be called with lock held)
+func (h *Household) updatePregnancies(deltaTime float64) {
+	remaining := h.pregnancies[:0]
+	for _, pregnancy := range h.pregnancies {
+		pregnancy.DueIn -= deltaTime
+		if pregnancy.DueIn > 0 {
+			remaining = append(remaining, pregnancy)
+			continue
+		}
+		h.birth(pregnancy)
+	}
+	h.pregnancies = remaining
+}
+
+// birth delivers a litter with placeholder names and asks the player to
+// decide about each pup (must be called with lock held)
+func (h *Household) birth(pregnancy *Pregnancy) {
+	parent1, parent2 := pregnancy.Parents[0], pregnancy.Parents[1]
+	names := make([]string, pregnancy.Size)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s-pup-%d", parent1.Name, i+1)
+	}
+	pups, err := core.BreedLitter(parent1, parent2, names, genetics.DefaultKinshipPolicy())
+	if err != nil {
+		h.Inbox.Post(MessageSystem, parent1.ID, "The litter did not arrive",
+			fmt.Sprintf("The litter %s and %s were expecting was lost: %v.", parent1.Name, parent2.Name, err))
+		return
+	}
+
+	h.litters = append(h.litters, &Litter{Parents: [2]string{parent1.Name, parent2.Name}, Pups: pups})
+	noun := "pup"
+	if len(pups) > 1 {
+		noun = "pups"
+	}
+	h.Inbox.Post(MessageCelebration, parent1.ID,
+		fmt.Sprintf("%s and %s had %d %s", parent1.Name, parent2.Name, len(pups), noun),
+		fmt.Sprintf("%s. Use `litter` to name them and decide who stays and who goes to the adoption center.",
+			strings.Join(names, ", ")))
+}
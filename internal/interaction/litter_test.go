@@ -0,0 +1,128 @@
+package interaction
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+)
+
+// expectingHousehold returns a household whose two pets have just been bred
+func expectingHousehold(t *testing.T) (*Household, *core.DigitalPet, *core.DigitalPet) {
+	t.Helper()
+	rex := core.NewDigitalPet("Rex", "sam")
+	bella := core.NewDigitalPet("Bella", "sam")
+	h := NewHousehold(rex, bella)
+	h.rng = rand.New(rand.NewSource(1))
+	if _, err := h.Breed(rex, bella); err != nil {
+		t.Fatal(err)
+	}
+	return h, rex, bella
+}
+
+func TestBreedStartsGestation(t *testing.T) {
+	h, rex, bella := expectingHousehold(t)
+	pregnancies := h.Pregnancies()
+	if len(pregnancies) != 1 || pregnancies[0].DueIn != GestationDays {
+		t.Fatalf("Expected one litter due in %.0f days, got %+v", GestationDays, pregnancies)
+	}
+	if size := pregnancies[0].Size; size < 1 || size > genetics.MaxLitterSize {
+		t.Errorf("Unexpected litter size %d", size)
+	}
+
+	if _, err := h.Breed(bella, rex); !errors.Is(err, ErrAlreadyExpecting) {
+		t.Errorf("Expected ErrAlreadyExpecting, got %v", err)
+	}
+	stranger := core.NewDigitalPet("Stray", "sam")
+	milo := core.NewDigitalPet("Milo", "sam")
+	h.AddPet(milo)
+	if _, err := h.Breed(milo, stranger); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+	if _, err := h.Breed(milo, milo); !errors.Is(err, core.ErrIncompatibleParents) {
+		t.Errorf("Expected ErrIncompatibleParents, got %v", err)
+	}
+}
+
+func TestLitterBirth(t *testing.T) {
+	h, _, _ := expectingHousehold(t)
+	size := h.Pregnancies()[0].Size
+
+	h.Update(GestationDays / 2)
+	if len(h.Litters()) != 0 {
+		t.Fatal("The litter should not be born halfway through gestation")
+	}
+	h.Update(GestationDays)
+	if len(h.Pregnancies()) != 0 {
+		t.Error("Expected the pregnancy to end at birth")
+	}
+	litters := h.Litters()
+	if len(litters) != 1 || len(litters[0].Pups) != size {
+		t.Fatalf("Expected a litter of %d, got %+v", size, litters)
+	}
+	if len(h.Pets) != 2 {
+		t.Errorf("Pups should wait for a decision before joining, got %d pets", len(h.Pets))
+	}
+	announced := false
+	for _, msg := range h.Inbox.List() {
+		announced = announced || (msg.Category == MessageCelebration && strings.Contains(msg.Body, "`litter`"))
+	}
+	if !announced {
+		t.Error("Expected the birth announced in the inbox")
+	}
+}
+
+func TestLitterDecisions(t *testing.T) {
+	h, rex, _ := expectingHousehold(t)
+	h.Adoption = NewSeededAdoptionCenter(1)
+	h.pregnancies[0].Size = 2
+	h.Update(GestationDays)
+	pups := h.Litters()[0].Pups
+
+	if _, err := h.NamePup(pups[0].Name, "rex"); !errors.Is(err, ErrNameTaken) {
+		t.Errorf("Expected ErrNameTaken for a household pet's name, got %v", err)
+	}
+	if _, err := h.NamePup(pups[0].Name, pups[1].Name); !errors.Is(err, ErrNameTaken) {
+		t.Errorf("Expected ErrNameTaken for a littermate's name, got %v", err)
+	}
+	if _, err := h.NamePup("Nobody", "Pip"); !errors.Is(err, ErrNoSuchPup) {
+		t.Errorf("Expected ErrNoSuchPup, got %v", err)
+	}
+	if _, err := h.NamePup(pups[0].Name, "Sprout"); err != nil {
+		t.Fatal(err)
+	}
+
+	kept, err := h.KeepPup("sprout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, present := h.Pets[kept.ID]; !present || len(kept.Memory.ByTag(TagLitter)) == 0 {
+		t.Error("A kept pup should join the household and remember being born there")
+	}
+
+	if _, err := h.AdoptOut(pups[1].Name, DestinationCenter); err != nil {
+		t.Fatal(err)
+	}
+	rehomed, err := h.Adoption.Find(pups[1].Name)
+	if err != nil {
+		t.Fatalf("The rehomed pup should wait at the adoption center: %v", err)
+	}
+	if rehomed.Pet.Owner != "" || !strings.Contains(rehomed.Backstory, rex.Name) || rehomed.Pet.Screening == nil {
+		t.Errorf("Expected an ownerless, screened arrival born to %s, got %+v", rex.Name, rehomed)
+	}
+	if len(h.Litters()) != 0 {
+		t.Error("The litter should be gone once every pup is decided")
+	}
+}
+
+func TestRehomePupWithoutCenter(t *testing.T) {
+	h, _, _ := expectingHousehold(t)
+	h.Update(GestationDays)
+	pup := h.Litters()[0].Pups[0]
+	if _, err := h.AdoptOut(pup.Name, DestinationCenter); !errors.Is(err, ErrNoAdoptionCenter) {
+		t.Errorf("Expected ErrNoAdoptionCenter, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Mentorship requirements
+const (
+	MentorSkillThreshold = 0.7 // Skill level a mentor needs before it can teach
+	MentorBondThreshold  = 0.5 // Bond strength the student needs with its mentor
+	MentorMinEnergy      = 0.2 // Energy both pets need to take part in a lesson
+)
+
+// Mentorship errors
+var (
+	ErrMentorUnqualified  = errors.New("mentor is not qualified to teach")
+	ErrStudentNotJuvenile = errors.New("only juveniles can be mentored")
+	ErrNotBonded          = errors.New("pets are not bonded closely enough")
+	ErrTooTired           = errors.New("pet is too tired")
+)
+
+// LessonResult describes the outcome of a mentoring session
+type LessonResult struct {
+	Skill       ai.SkillType
+	Gain        float64 // Increase in the student's skill level
+	MentorCost  float64 // Energy spent by the mentor
+	StudentCost float64 // Energy spent by the student
+}
+
+// Teach has an adult pet pass on a skill to a bonded juvenile. Learning
+// speed depends on both pets' intelligence and their relationship, and a
+// student can never surpass its mentor through teaching alone.
+func Teach(mentor, student *core.DigitalPet, skill ai.SkillType, duration float64) (LessonResult, error) {
+	result := LessonResult{Skill: skill}
+
+	if !mentor.IsAlive() || mentor.Biology.GetLifeStage() < types.LifeStageAdult {
+		return result, fmt.Errorf("%w: %s is not an adult", ErrMentorUnqualified, mentor.Name)
+	}
+	mentorLevel := mentor.Skills.Level(skill)
+	if mentorLevel < MentorSkillThreshold {
+		return result, fmt.Errorf("%w: %s skill is %.2f", ErrMentorUnqualified, skill, mentorLevel)
+	}
+	if !student.IsAlive() || student.Biology.GetLifeStage() != types.LifeStageJuvenile {
+		return result, fmt.Errorf("%w: %s", ErrStudentNotJuvenile, student.Name)
+	}
+
+	mentorRel, okM := mentor.Relationships.GetRelationship(student.ID)
+	studentRel, okS := student.Relationships.GetRelationship(mentor.ID)
+	if !okM || !okS || studentRel.BondStrength < MentorBondThreshold {
+		return result, fmt.Errorf("%w: %s and %s", ErrNotBonded, mentor.Name, student.Name)
+	}
+
+	for _, pet := range []*core.DigitalPet{mentor, student} {
+		if pet.Biology.Vitals.Energy < MentorMinEnergy {
+			return result, fmt.Errorf("%w: %s", ErrTooTired, pet.Name)
+		}
+	}
+
+	// Smart pets with a good relationship learn fastest
+	intelligence := (mentor.Personality.Traits.Intelligence + student.Personality.Traits.Intelligence) / 2.0
+	quality := (studentRel.BondStrength + studentRel.Trust) / 2.0
+	experience := 0.2 * duration * intelligence * quality
+
+	before := student.Skills.Level(skill)
+	gain := student.TrainSkill(skill, experience)
+	if student.Skills.Level(skill) > mentorLevel {
+		student.Skills.Levels[skill] = math.Max(before, mentorLevel)
+		gain = student.Skills.Levels[skill] - before
+	}
+	result.Gain = gain
+
+	// Teaching is tiring for both, but more so for the student
+	result.MentorCost = 0.1 * duration
+	result.StudentCost = 0.15 * duration
+	mentor.Biology.Vitals.Energy -= result.MentorCost
+	student.Biology.Vitals.Energy -= result.StudentCost
+	mentor.Biology.Vitals.Clamp()
+	student.Biology.Vitals.Clamp()
+
+	// Lessons are formative experiences for both pets
+	details := map[string]interface{}{
+		"skill":   skill.String(),
+		"gain":    gain,
+		"mentor":  string(mentor.ID),
+		"student": string(student.ID),
+	}
+	mentor.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryTraining,
+		Description: fmt.Sprintf("Taught %s %s", student.Name, skill),
+		GameTime:    mentor.GetAge(),
+		Strength:    0.8,
+		Valence:     0.7,
+		Emotion:     "proud",
+		Tags:        []string{ai.TagTraining, ai.SkillTag(skill), ai.PetTag(student.ID)},
+		Details:     details,
+	})
+	student.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryTraining,
+		Description: fmt.Sprintf("Learned %s from %s", skill, mentor.Name),
+		GameTime:    student.GetAge(),
+		Strength:    0.9,
+		Valence:     0.8,
+		Emotion:     "happy",
+		Tags:        []string{ai.TagTraining, ai.SkillTag(skill), ai.PetTag(mentor.ID)},
+		Details:     details,
+	})
+
+	description := fmt.Sprintf("%s taught %s %s", mentor.Name, student.Name, skill)
+	mentorRel.Update(0.5, mentor.GetAge())
+	studentRel.Update(0.5, student.GetAge())
+	mentorRel.AddSharedExperience(description, mentor.GetAge(), 0.5)
+	studentRel.AddSharedExperience(description, student.GetAge(), 0.5)
+
+	return result, nil
+}
+
+// Mentor runs a mentoring session between two present pets
+func (h *Household) Mentor(mentorID, studentID types.PetID, skill ai.SkillType, duration float64) (LessonResult, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	mentor, okM := h.Pets[mentorID]
+	if !okM {
+		return LessonResult{Skill: skill}, fmt.Errorf("%w: %s", ErrPetNotPresent, mentorID)
+	}
+	student, okS := h.Pets[studentID]
+	if !okS {
+		return LessonResult{Skill: skill}, fmt.Errorf("%w: %s", ErrPetNotPresent, studentID)
+	}
+
+	return Teach(mentor, student, skill, duration)
+}
@@ -0,0 +1,86 @@
+package interaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// mentorPair creates a skilled adult and a bonded juvenile
+func mentorPair() (*core.DigitalPet, *core.DigitalPet) {
+	mentor := core.NewDigitalPet("Mentor", "user123")
+	mentor.Biology.Processes.Age = 15.0
+	mentor.Skills.Levels[ai.SkillForaging] = 0.8
+
+	student := core.NewDigitalPet("Student", "user123")
+	student.Biology.Processes.Age = 5.0
+
+	mentorRel := mentor.Relationships.AddRelationship(student.ID, types.RelationshipParent)
+	studentRel := student.Relationships.AddRelationship(mentor.ID, types.RelationshipOffspring)
+	mentorRel.BondStrength = 0.8
+	studentRel.BondStrength = 0.8
+	return mentor, student
+}
+
+func TestTeachTransfersSkill(t *testing.T) {
+	mentor, student := mentorPair()
+	mentorEnergy := mentor.Biology.Vitals.Energy
+	studentEnergy := student.Biology.Vitals.Energy
+
+	result, err := Teach(mentor, student, ai.SkillForaging, 1.0)
+	if err != nil {
+		t.Fatalf("Teach failed: %v", err)
+	}
+
+	if result.Gain <= 0 || student.Skills.Level(ai.SkillForaging) <= 0 {
+		t.Error("Student should gain skill from the lesson")
+	}
+	if mentor.Biology.Vitals.Energy >= mentorEnergy || student.Biology.Vitals.Energy >= studentEnergy {
+		t.Error("Lessons should consume energy from both pets")
+	}
+	if !student.Memory.HasMemoryOf(ai.MemoryTraining) || !mentor.Memory.HasMemoryOf(ai.MemoryTraining) {
+		t.Error("Lessons should be remembered by both pets")
+	}
+}
+
+func TestTeachNeverSurpassesMentor(t *testing.T) {
+	mentor, student := mentorPair()
+	student.Skills.Levels[ai.SkillForaging] = 0.79
+
+	if _, err := Teach(mentor, student, ai.SkillForaging, 5.0); err != nil {
+		t.Fatalf("Teach failed: %v", err)
+	}
+
+	if student.Skills.Level(ai.SkillForaging) > mentor.Skills.Level(ai.SkillForaging) {
+		t.Error("Student should not surpass its mentor")
+	}
+}
+
+func TestTeachRequirements(t *testing.T) {
+	mentor, student := mentorPair()
+	if _, err := Teach(mentor, student, ai.SkillAgility, 1.0); !errors.Is(err, ErrMentorUnqualified) {
+		t.Errorf("Expected ErrMentorUnqualified for untrained skill, got %v", err)
+	}
+
+	mentor, student = mentorPair()
+	student.Biology.Processes.Age = 15.0
+	if _, err := Teach(mentor, student, ai.SkillForaging, 1.0); !errors.Is(err, ErrStudentNotJuvenile) {
+		t.Errorf("Expected ErrStudentNotJuvenile, got %v", err)
+	}
+
+	mentor, student = mentorPair()
+	rel, _ := student.Relationships.GetRelationship(mentor.ID)
+	rel.BondStrength = 0.1
+	if _, err := Teach(mentor, student, ai.SkillForaging, 1.0); !errors.Is(err, ErrNotBonded) {
+		t.Errorf("Expected ErrNotBonded, got %v", err)
+	}
+
+	mentor, student = mentorPair()
+	mentor.Biology.Vitals.Energy = 0.1
+	if _, err := Teach(mentor, student, ai.SkillForaging, 1.0); !errors.Is(err, ErrTooTired) {
+		t.Errorf("Expected ErrTooTired, got %v", err)
+	}
+}
@@ -0,0 +1,298 @@
+package interaction
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Milestone settings
+const (
+	PetYearDays       = 7.0  // Game days in a pet "year" for birthdays
+	CelebrationBonus  = 0.15 // Happiness gained from a celebration
+	TagMilestone      = "milestone"
+	AnniversaryDays   = 365
+	MaxAlbumSnapshots = 100
+)
+
+// CountThresholds are the interaction counts that are celebrated
+var CountThresholds = []int{10, 50, 100, 500, 1000}
+
+// countedInteractions are the interactions whose totals earn milestones
+var countedInteractions = map[types.InteractionType]string{
+	types.InteractionPlaying:  "play session",
+	types.InteractionFeeding:  "meal",
+	types.InteractionTraining: "training session",
+	types.InteractionGrooming: "grooming",
+}
+
+// MilestoneKind categorises milestones
+type MilestoneKind int
+
+const (
+	MilestoneAnniversary MilestoneKind = iota
+	MilestoneBirthday
+	MilestoneCount
+)
+
+// String returns the string representation of MilestoneKind
+func (mk MilestoneKind) String() string {
+	return [...]string{"Anniversary", "Birthday", "Count"}[mk]
+}
+
+// Milestone is a date or count worth celebrating
+type Milestone struct {
+	ID    string        `json:"id"` // Unique key, e.g. "birthday:1" or "Playing:100"
+	Kind  MilestoneKind `json:"kind"`
+	Title string        `json:"title"`
+}
+
+// Snapshot is an album entry capturing a pet at a special moment
+type Snapshot struct {
+	Title    string         `json:"title"`
+	Taken    time.Time      `json:"taken"`
+	GameTime float64        `json:"game_time"`
+	Status   core.PetStatus `json:"status"`
+	Caption  string         `json:"caption,omitempty"`
+	Picture  string         `json:"picture,omitempty"` // Composed scene for photos taken by the player
+}
+
+// Celebration is emitted when a pet reaches a milestone
+type Celebration struct {
+	Milestone Milestone
+	Message   string
+	Bonus     float64
+	Snapshot  Snapshot
+}
+
+// MilestoneTracker counts a pet's interactions and recognises milestones
+// from those counts, the pet's age, and its adoption date
+type MilestoneTracker struct {
+	PetID    types.PetID                   `json:"pet_id"`
+	Counts   map[types.InteractionType]int `json:"counts"`
+	Achieved map[string]time.Time          `json:"achieved"`
+	Album    []Snapshot                    `json:"album"`
+}
+
+// NewMilestoneTracker creates a tracker for a pet
+func NewMilestoneTracker(petID types.PetID) *MilestoneTracker {
+	return &MilestoneTracker{
+		PetID:    petID,
+		Counts:   make(map[types.InteractionType]int),
+		Achieved: make(map[string]time.Time),
+		Album:    make([]Snapshot, 0),
+	}
+}
+
+// RecordInteraction counts an interaction toward milestones
+func (mt *MilestoneTracker) RecordInteraction(interactionType types.InteractionType) {
+	mt.Counts[interactionType]++
+}
+
+// Pending returns milestones the pet has reached but not yet celebrated
+func (mt *MilestoneTracker) Pending(pet *core.DigitalPet, now time.Time) []Milestone {
+	var pending []Milestone
+	add := func(m Milestone) {
+		if _, done := mt.Achieved[m.ID]; !done {
+			pending = append(pending, m)
+		}
+	}
+
+	// Adoption anniversaries by the real-world clock
+	years := int(now.Sub(pet.CreatedAt).Hours() / 24 / AnniversaryDays)
+	for year := 1; year <= years; year++ {
+		add(Milestone{
+			ID:    fmt.Sprintf("anniversary:%d", year),
+			Kind:  MilestoneAnniversary,
+			Title: fmt.Sprintf("%s adoption anniversary", ordinal(year)),
+		})
+	}
+
+	// Birthdays by game age
+	birthdays := int(pet.GetAge() / PetYearDays)
+	for year := 1; year <= birthdays; year++ {
+		add(Milestone{
+			ID:    fmt.Sprintf("birthday:%d", year),
+			Kind:  MilestoneBirthday,
+			Title: fmt.Sprintf("%s birthday", ordinal(year)),
+		})
+	}
+
+	// Interaction counts
+	interactionTypes := make([]types.InteractionType, 0, len(countedInteractions))
+	for it := range countedInteractions {
+		interactionTypes = append(interactionTypes, it)
+	}
+	sort.Slice(interactionTypes, func(i, j int) bool { return interactionTypes[i] < interactionTypes[j] })
+
+	for _, it := range interactionTypes {
+		for _, threshold := range CountThresholds {
+			if mt.Counts[it] < threshold {
+				break
+			}
+			add(Milestone{
+				ID:    fmt.Sprintf("%s:%d", it, threshold),
+				Kind:  MilestoneCount,
+				Title: fmt.Sprintf("%s %s", ordinal(threshold), countedInteractions[it]),
+			})
+		}
+	}
+
+	return pending
+}
+
+// Check celebrates every newly reached milestone: the pet gets a happiness
+// bonus and a lasting memory, and a snapshot is added to the album
+func (mt *MilestoneTracker) Check(pet *core.DigitalPet, now time.Time) []Celebration {
+	if !pet.IsAlive() {
+		return nil
+	}
+
+	var celebrations []Celebration
+	for _, milestone := range mt.Pending(pet, now) {
+		mt.Achieved[milestone.ID] = now
+
+		pet.Biology.Vitals.Happiness += CelebrationBonus
+		pet.Biology.Vitals.Clamp()
+		pet.Emotions.ApplyEmotionalStimulus(ai.EmotionalStimulus{
+			JoyDelta:        0.2,
+			ExcitementDelta: 0.15,
+			Source:          "celebration",
+		})
+		pet.Memory.Remember(&ai.Memory{
+			Type:        ai.MemoryEvent,
+			Description: fmt.Sprintf("Celebrated %s", milestone.Title),
+			GameTime:    pet.GetAge(),
+			Strength:    0.9,
+			Valence:     0.9,
+			Emotion:     pet.Emotions.DominantEmotion,
+			Tags:        []string{TagMilestone, ai.TagUser},
+		})
+
+		snapshot := Snapshot{
+			Title:    fmt.Sprintf("%s's %s", pet.Name, milestone.Title),
+			Taken:    now,
+			GameTime: pet.GetAge(),
+			Status:   pet.GetCurrentStatus(),
+		}
+		mt.addSnapshot(snapshot)
+
+		celebrations = append(celebrations, Celebration{
+			Milestone: milestone,
+			Message:   celebrationMessage(pet.Name, milestone),
+			Bonus:     CelebrationBonus,
+			Snapshot:  snapshot,
+		})
+	}
+
+	return celebrations
+}
+
+// addSnapshot adds a snapshot to the album, dropping the oldest beyond
+// MaxAlbumSnapshots
+func (mt *MilestoneTracker) addSnapshot(snapshot Snapshot) {
+	mt.Album = append(mt.Album, snapshot)
+	if len(mt.Album) > MaxAlbumSnapshots {
+		mt.Album = mt.Album[1:]
+	}
+}
+
+// celebrationMessage returns the special feedback text for a milestone
+func celebrationMessage(name string, m Milestone) string {
+	switch m.Kind {
+	case MilestoneAnniversary:
+		return fmt.Sprintf("Happy %s, %s! Thank you for all the time together.", m.Title, name)
+	case MilestoneBirthday:
+		return fmt.Sprintf("Happy %s, %s! 🎂", m.Title, name)
+	default:
+		return fmt.Sprintf("That was %s's %s! What a milestone.", name, m.Title)
+	}
+}
+
+// ordinal formats a number as 1st, 2nd, 3rd, ...
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// Celebrate checks every present pet for newly reached milestones, posts
+// each celebration to the household inbox and awards a rosette for it
+func (h *Household) Celebrate(now time.Time) []Celebration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ids := make([]types.PetID, 0, len(h.Pets))
+	for id := range h.Pets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var celebrations []Celebration
+	for _, id := range ids {
+		for _, c := range h.milestones(id).Check(h.Pets[id], now) {
+			h.Inbox.Post(MessageCelebration, id, c.Snapshot.Title, c.Message+h.grantAccessory(environment.AccessoryRosette))
+			celebrations = append(celebrations, c)
+		}
+	}
+	return celebrations
+}
+
+// AddPhoto puts a photo of a pet in its album
+func (h *Household) AddPhoto(petID types.PetID, title, caption, picture string, now time.Time) (Snapshot, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	pet, exists := h.Pets[petID]
+	if !exists {
+		return Snapshot{}, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	snapshot := Snapshot{
+		Title:    title,
+		Taken:    now,
+		GameTime: pet.GetAge(),
+		Status:   pet.GetCurrentStatus(),
+		Caption:  caption,
+		Picture:  picture,
+	}
+	h.milestones(petID).addSnapshot(snapshot)
+	return snapshot, nil
+}
+
+// Album returns a copy of a pet's album, oldest first
+func (h *Household) Album(petID types.PetID) []Snapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tracker, exists := h.Milestones[petID]
+	if !exists {
+		return nil
+	}
+	return append([]Snapshot(nil), tracker.Album...)
+}
+
+// milestones returns a pet's milestone tracker, creating it if needed
+// (must be called with lock held)
+func (h *Household) milestones(petID types.PetID) *MilestoneTracker {
+	tracker, exists := h.Milestones[petID]
+	if !exists {
+		tracker = NewMilestoneTracker(petID)
+		h.Milestones[petID] = tracker
+	}
+	return tracker
+}
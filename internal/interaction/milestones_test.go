@@ -0,0 +1,103 @@
+package interaction
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestCountMilestone(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	tracker := NewMilestoneTracker(pet.ID)
+	for i := 0; i < 10; i++ {
+		tracker.RecordInteraction(types.InteractionPlaying)
+	}
+	pet.Biology.Vitals.Happiness = 0.5
+
+	celebrations := tracker.Check(pet, time.Now())
+	if len(celebrations) != 1 || celebrations[0].Milestone.Title != "10th play session" {
+		t.Fatalf("Expected 10th play session, got %v", celebrations)
+	}
+	if pet.Biology.Vitals.Happiness <= 0.5 {
+		t.Error("Celebrations should boost happiness")
+	}
+
+	if len(tracker.Album) != 1 {
+		t.Error("Celebrations should add an album snapshot")
+	}
+	if len(pet.Memory.ByTag(TagMilestone)) != 1 {
+		t.Error("Celebrations should be remembered")
+	}
+
+	if again := tracker.Check(pet, time.Now()); len(again) != 0 {
+		t.Error("A milestone should only be celebrated once")
+	}
+}
+
+func TestDateMilestones(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Biology.Processes.Age = PetYearDays + 0.5
+	tracker := NewMilestoneTracker(pet.ID)
+
+	oneYearOn := pet.CreatedAt.Add(366 * 24 * time.Hour)
+	celebrations := tracker.Check(pet, oneYearOn)
+
+	kinds := make(map[MilestoneKind]string)
+	for _, c := range celebrations {
+		kinds[c.Milestone.Kind] = c.Message
+	}
+	if !strings.Contains(kinds[MilestoneAnniversary], "1st adoption anniversary") {
+		t.Errorf("Expected first anniversary, got %v", kinds)
+	}
+	if !strings.Contains(kinds[MilestoneBirthday], "1st birthday") {
+		t.Errorf("Expected first birthday, got %v", kinds)
+	}
+}
+
+func TestHouseholdCountsMilestones(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	for i := 0; i < 10; i++ {
+		if _, err := h.Interact(pet.ID, types.InteractionFeeding, 0.1); err != nil {
+			t.Fatalf("Interact failed: %v", err)
+		}
+	}
+
+	if celebrations := h.Celebrate(time.Now()); len(celebrations) != 1 {
+		t.Errorf("Expected the 10th meal to be celebrated, got %v", celebrations)
+	}
+}
+
+func TestAddPhoto(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	now := time.Now()
+
+	if _, err := h.AddPhoto("missing", "Photo", "", "", now); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+	if _, err := h.AddPhoto(pet.ID, "Photo of Rex", "At the beach", "picture", now); err != nil {
+		t.Fatalf("AddPhoto failed: %v", err)
+	}
+	album := h.Album(pet.ID)
+	if len(album) != 1 || album[0].Caption != "At the beach" || album[0].Picture != "picture" {
+		t.Fatalf("Expected the photo in the album, got %+v", album)
+	}
+
+	album[0].Caption = "changed"
+	if h.Album(pet.ID)[0].Caption != "At the beach" {
+		t.Error("Album should return a copy")
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, expected := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 22: "22nd", 100: "100th"} {
+		if got := ordinal(n); got != expected {
+			t.Errorf("ordinal(%d) = %s, want %s", n, got, expected)
+		}
+	}
+}
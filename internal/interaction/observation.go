@@ -0,0 +1,187 @@
+package interaction
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Observation settings
+const (
+	MinutesPerDay          = 1440.0
+	ObservationInsightRate = 0.15 // Chance per behavior change of noticing something hidden
+	MaxObservationMinutes  = 24 * 60
+)
+
+// Observation is a single narrated moment during an observation session
+type Observation struct {
+	Minute    int
+	Behavior  types.BehaviorState
+	Narration string
+}
+
+// ObservationReport summarises an observation session
+type ObservationReport struct {
+	PetID        types.PetID
+	Name         string
+	Minutes      int
+	Observations []Observation
+	BehaviorTime map[types.BehaviorState]int // Minutes spent in each behavior
+	Insights     []string                    // Hidden preferences or symptoms noticed
+}
+
+// behaviorNarration describes what a pet is seen doing in each behavior
+var behaviorNarration = map[types.BehaviorState]string{
+	types.BehaviorIdle:              "lounges around",
+	types.BehaviorEating:            "heads to the food bowl",
+	types.BehaviorSleeping:          "curls up and dozes off",
+	types.BehaviorPlaying:           "starts batting a toy around",
+	types.BehaviorExploring:         "wanders off to investigate",
+	types.BehaviorSocialInteraction: "goes looking for company",
+	types.BehaviorGrooming:          "grooms itself carefully",
+	types.BehaviorExercising:        "runs laps around the room",
+	types.BehaviorSick:              "lies still, looking unwell",
+	types.BehaviorHappy:             "trots about happily",
+	types.BehaviorDistressed:        "paces anxiously",
+	types.BehaviorExcited:           "zooms around in excitement",
+	types.BehaviorSheltering:        "hunkers down somewhere sheltered",
+	types.BehaviorHibernating:       "stays curled up in a deep winter sleep",
+}
+
+// Observe watches a pet without interrupting it for the given number of game
+// minutes, letting its autonomous behavior play out. A nil rng uses a
+// time-seeded source.
+func Observe(pet *core.DigitalPet, minutes int, rng *rand.Rand) *ObservationReport {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if minutes > MaxObservationMinutes {
+		minutes = MaxObservationMinutes
+	}
+
+	report := &ObservationReport{
+		PetID:        pet.ID,
+		Name:         pet.Name,
+		Minutes:      minutes,
+		BehaviorTime: make(map[types.BehaviorState]int),
+	}
+
+	candidates := insightCandidates(pet)
+	noticed := make(map[string]bool)
+	last := types.BehaviorState(-1)
+
+	for minute := 0; minute < minutes && pet.IsAlive(); minute++ {
+		pet.Update(1.0 / MinutesPerDay)
+		behavior := pet.CurrentBehavior
+		report.BehaviorTime[behavior]++
+
+		if behavior == last {
+			continue
+		}
+		last = behavior
+
+		report.Observations = append(report.Observations, Observation{
+			Minute:    minute,
+			Behavior:  behavior,
+			Narration: fmt.Sprintf("%s %s.", pet.Name, behaviorNarration[behavior]),
+		})
+
+		// Watching patiently sometimes reveals something the status screen doesn't
+		if len(candidates) > 0 && rng.Float64() < ObservationInsightRate {
+			insight := candidates[rng.Intn(len(candidates))]
+			if !noticed[insight] {
+				noticed[insight] = true
+				report.Insights = append(report.Insights, insight)
+			}
+		}
+	}
+
+	if !pet.IsAlive() {
+		report.Observations = append(report.Observations, Observation{
+			Minute:    minutes,
+			Narration: fmt.Sprintf("%s has passed away.", pet.Name),
+		})
+	}
+
+	return report
+}
+
+// insightCandidates lists the hidden preferences and early symptoms an
+// observer could notice about a pet
+func insightCandidates(pet *core.DigitalPet) []string {
+	var insights []string
+	name := pet.Name
+	personality := pet.Personality
+
+	preferences := []struct {
+		influence string
+		insight   string
+	}{
+		{"play", "%s seems to prefer toys to company."},
+		{"explore", "%s is drawn to unfamiliar corners of the room."},
+		{"social", "%s perks up whenever it hears other pets."},
+		{"rest", "%s clearly enjoys a quiet nap in the sun."},
+		{"routine", "%s does things in the same order every time."},
+	}
+	for _, pref := range preferences {
+		if personality.GetTraitInfluence(pref.influence) > 0.65 {
+			insights = append(insights, fmt.Sprintf(pref.insight, name))
+		}
+	}
+
+	processes := pet.Biology.Processes
+	symptoms := []struct {
+		present bool
+		insight string
+	}{
+		{processes.CardiovascularHealth < 0.8, "%s pants heavily after short bursts of activity."},
+		{processes.RespiratoryHealth < 0.8, "%s wheezes quietly while resting."},
+		{processes.ImmuneStrength < 0.7, "%s sneezes every now and then."},
+		{processes.DigestiveEfficiency < 0.7, "%s leaves some of its food uneaten."},
+		{pet.Biology.Vitals.Health < 0.6, "%s seems listless and slow to respond."},
+	}
+	for _, symptom := range symptoms {
+		if symptom.present {
+			insights = append(insights, fmt.Sprintf(symptom.insight, name))
+		}
+	}
+
+	return insights
+}
+
+// String renders the observation report
+func (r *ObservationReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Observing %s for %d minutes ===\n", r.Name, r.Minutes)
+
+	for _, obs := range r.Observations {
+		fmt.Fprintf(&b, "[%02d:%02d] %s\n", obs.Minute/60, obs.Minute%60, obs.Narration)
+	}
+
+	behaviors := make([]types.BehaviorState, 0, len(r.BehaviorTime))
+	for behavior := range r.BehaviorTime {
+		behaviors = append(behaviors, behavior)
+	}
+	sort.Slice(behaviors, func(i, j int) bool {
+		return r.BehaviorTime[behaviors[i]] > r.BehaviorTime[behaviors[j]]
+	})
+
+	b.WriteString("Time spent:\n")
+	for _, behavior := range behaviors {
+		fmt.Fprintf(&b, "  %-20s %d min\n", behavior.String(), r.BehaviorTime[behavior])
+	}
+
+	if len(r.Insights) > 0 {
+		b.WriteString("You noticed:\n")
+		for _, insight := range r.Insights {
+			fmt.Fprintf(&b, "  - %s\n", insight)
+		}
+	}
+
+	return b.String()
+}
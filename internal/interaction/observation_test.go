@@ -0,0 +1,55 @@
+package interaction
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestObserveNarratesBehavior(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+
+	report := Observe(pet, 60, rand.New(rand.NewSource(1)))
+
+	if len(report.Observations) == 0 {
+		t.Fatal("Observation should narrate at least one behavior")
+	}
+
+	total := 0
+	for _, minutes := range report.BehaviorTime {
+		total += minutes
+	}
+	if total != 60 {
+		t.Errorf("Expected 60 minutes of behavior, got %d", total)
+	}
+
+	if !strings.Contains(report.String(), "Observing Rex for 60 minutes") {
+		t.Error("Report should have a header")
+	}
+}
+
+func TestInsightCandidatesRevealSymptoms(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Biology.Processes.RespiratoryHealth = 0.5
+
+	found := false
+	for _, insight := range insightCandidates(pet) {
+		if strings.Contains(insight, "wheezes") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Weak lungs should be noticeable during observation")
+	}
+}
+
+func TestInsightCandidatesHealthyPet(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	for _, insight := range insightCandidates(pet) {
+		if strings.Contains(insight, "wheezes") || strings.Contains(insight, "listless") {
+			t.Errorf("Healthy pet should show no symptoms, got %q", insight)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package interaction
+
+import (
+	"fmt"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/social"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// InitialDominance estimates a pet's starting dominance from its personality
+func InitialDominance(pet *core.DigitalPet) float64 {
+	traits := pet.Personality.Traits
+	return (traits.Territoriality + traits.Extraversion + (1.0 - traits.Agreeableness)) / 3.0
+}
+
+// ShareFood divides a scarce amount of food among the present pets in rank
+// order. Higher-ranked pets eat first; pets that go without suffer mild stress.
+// Returns the amount of food each pet received.
+func (h *Household) ShareFood(available float64) map[types.PetID]float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	demand := make(map[types.PetID]float64)
+	for id, pet := range h.Pets {
+		if pet.IsAlive() {
+			demand[id] = 1.0 - pet.Biology.Vitals.Nutrition
+		}
+	}
+
+	allocation := h.Hierarchy.AllocateResource(available, demand)
+	order := h.Hierarchy.Order()
+
+	for rank, id := range order {
+		pet, present := h.Pets[id]
+		if !present || !pet.IsAlive() {
+			continue
+		}
+
+		vitals := pet.Biology.Vitals
+		vitals.Nutrition += allocation[id]
+
+		shortfall := demand[id] - allocation[id]
+		if shortfall > 0.01 {
+			// Going without while higher-ranked pets eat is stressful
+			vitals.Stress += 0.1 * shortfall
+
+			for _, higherID := range order[:rank] {
+				if allocation[higherID] > 0 {
+					h.logExperience(id, higherID,
+						fmt.Sprintf("%s ate first while food was scarce", h.Pets[higherID].Name), -0.1*shortfall)
+				}
+			}
+		}
+
+		vitals.Clamp()
+	}
+
+	return allocation
+}
+
+// RecordContest records the outcome of a play fight or conflict between
+// two present pets, shifting their rank and logging the experience
+func (h *Household) RecordContest(winner, loser types.PetID, contest social.ContestType) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	winnerPet, okW := h.Pets[winner]
+	if !okW {
+		return fmt.Errorf("%w: %s", ErrPetNotPresent, winner)
+	}
+	if _, okL := h.Pets[loser]; !okL {
+		return fmt.Errorf("%w: %s", ErrPetNotPresent, loser)
+	}
+
+	h.Hierarchy.RecordContest(winner, loser, contest)
+
+	impact := 0.05
+	if contest == social.ContestConflict {
+		impact = -0.2
+	}
+	h.logExperience(winner, loser, fmt.Sprintf("%s won a %s contest", winnerPet.Name, contest), impact)
+	h.logExperience(loser, winner, fmt.Sprintf("%s won a %s contest", winnerPet.Name, contest), impact)
+
+	return nil
+}
+
+// logExperience records a shared experience on a pet's relationship with
+// another pet, if one exists (must be called with lock held)
+func (h *Household) logExperience(petID, otherID types.PetID, description string, impact float64) {
+	pet := h.Pets[petID]
+	if rel, exists := pet.Relationships.GetRelationship(otherID); exists {
+		rel.AddSharedExperience(description, pet.GetAge(), impact)
+	}
+}
@@ -0,0 +1,53 @@
+package interaction
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/social"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestShareFoodByRank(t *testing.T) {
+	alpha := core.NewDigitalPet("Alpha", "user123")
+	omega := core.NewDigitalPet("Omega", "user123")
+	alpha.Biology.Vitals.Nutrition = 0.5
+	omega.Biology.Vitals.Nutrition = 0.5
+	omega.Relationships.AddRelationship(alpha.ID, types.RelationshipFriend)
+
+	h := NewHousehold(alpha, omega)
+	h.Hierarchy.AddMember(alpha.ID, 0.9)
+	h.Hierarchy.AddMember(omega.ID, 0.1)
+	stressBefore := omega.Biology.Vitals.Stress
+
+	allocation := h.ShareFood(0.5)
+
+	if allocation[alpha.ID] != 0.5 || allocation[omega.ID] != 0.0 {
+		t.Errorf("Alpha should take all scarce food, got %v", allocation)
+	}
+
+	if omega.Biology.Vitals.Stress <= stressBefore {
+		t.Error("Low-ranked pet should be stressed by going without")
+	}
+
+	rel, _ := omega.Relationships.GetRelationship(alpha.ID)
+	if len(rel.History) == 0 {
+		t.Error("Food competition should be logged as a shared experience")
+	}
+}
+
+func TestRecordContestShiftsRank(t *testing.T) {
+	a := core.NewDigitalPet("A", "user123")
+	b := core.NewDigitalPet("B", "user123")
+	h := NewHousehold(a, b)
+	h.Hierarchy.AddMember(a.ID, 0.5)
+	h.Hierarchy.AddMember(b.ID, 0.5)
+
+	if err := h.RecordContest(b.ID, a.ID, social.ContestConflict); err != nil {
+		t.Fatalf("RecordContest failed: %v", err)
+	}
+
+	if rank, _ := h.Hierarchy.Rank(b.ID); rank != 0 {
+		t.Error("Winner should move to the top rank")
+	}
+}
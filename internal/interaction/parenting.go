@@ -0,0 +1,134 @@
+package interaction
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Parent care settings
+const (
+	ParentCareThreshold = 0.4  // Care drive a parent needs before it looks after its young
+	ParentShareHunger   = 0.3  // Nutrition below which a young pet is fed by a parent
+	ParentShareSurplus  = 0.6  // Nutrition a parent needs before it shares food
+	ParentShareAmount   = 0.2  // Nutrition passed from parent to young in one meal
+	ParentComfortAmount = 0.3  // Stress relieved by a parent during a storm, at full drive
+	ParentTeachingRate  = 0.05 // Skill experience per game day picked up from a parent, at full drive
+	TagParentCare       = "parent_care"
+)
+
+// CareDrive returns how strongly a parent looks after one of its offspring
+// (0.0 to 1.0): loyal, agreeable parents care most, more so for young they
+// are closely bonded with. It is 0 if the pet is not the parent's offspring.
+func CareDrive(parent, young *core.DigitalPet) float64 {
+	rel, exists := parent.Relationships.GetRelationship(young.ID)
+	if !exists || rel.Type != types.RelationshipOffspring {
+		return 0
+	}
+	traits := parent.Personality.Traits
+	return (traits.Loyalty + traits.Agreeableness) / 2.0 * (0.5 + 0.5*rel.BondStrength)
+}
+
+// updateParenting lets each young pet's most caring parent share food when
+// it is hungry, comfort it once per storm and pass on a little of the
+// parent's best skill. Parents only care for young in the same place
+// (must be called with lock held).
+func (h *Household) updateParenting(deltaTime float64) {
+	storm := h.Weather != nil && h.Weather.Current.Type == environment.WeatherStorm
+	if !storm {
+		h.comforted = make(map[types.PetID]bool)
+	}
+
+	ids := make([]types.PetID, 0, len(h.Pets))
+	for id := range h.Pets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		young := h.Pets[id]
+		if !young.IsAlive() || young.Biology.GetLifeStage() >= types.LifeStageAdult {
+			continue
+		}
+		parent, drive := h.carer(young, ids)
+		if parent == nil {
+			continue
+		}
+
+		if young.Biology.Vitals.Nutrition < ParentShareHunger && parent.Biology.Vitals.Nutrition >= ParentShareSurplus {
+			parent.Biology.Vitals.Nutrition -= ParentShareAmount
+			young.Biology.Vitals.Nutrition += ParentShareAmount
+			young.Biology.Vitals.Clamp()
+			h.recordCare(parent, young, fmt.Sprintf("%s shared food with %s", parent.Name, young.Name))
+			h.Inbox.Post(MessageSystem, young.ID, fmt.Sprintf("%s shared food with %s", parent.Name, young.Name),
+				fmt.Sprintf("%s was hungry, so %s gave up part of a meal.", young.Name, parent.Name))
+		}
+
+		if storm && !h.comforted[young.ID] {
+			h.comforted[young.ID] = true
+			young.Biology.Vitals.Stress -= ParentComfortAmount * drive
+			young.Biology.Vitals.Clamp()
+			h.recordCare(parent, young, fmt.Sprintf("%s sheltered %s from the storm", parent.Name, young.Name))
+			h.Inbox.Post(MessageSystem, young.ID, fmt.Sprintf("%s comforted %s during the storm", parent.Name, young.Name),
+				fmt.Sprintf("%s curled up next to %s until the thunder felt less frightening.", parent.Name, young.Name))
+		}
+
+		h.passOnSkill(parent, young, drive, deltaTime)
+	}
+}
+
+// carer returns the present parent that cares most for a young pet, if
+// any cares enough (must be called with lock held)
+func (h *Household) carer(young *core.DigitalPet, ids []types.PetID) (*core.DigitalPet, float64) {
+	var best *core.DigitalPet
+	bestDrive := 0.0
+	for _, id := range ids {
+		parent := h.Pets[id]
+		if parent == young || !parent.IsAlive() || parent.Biology.Hibernation.IsDormant() || parent.Location != young.Location {
+			continue
+		}
+		if drive := CareDrive(parent, young); drive >= ParentCareThreshold && drive > bestDrive {
+			best, bestDrive = parent, drive
+		}
+	}
+	return best, bestDrive
+}
+
+// passOnSkill lets a young pet pick up a parent's best skill by watching.
+// As with mentoring, it never surpasses the parent this way (must be
+// called with lock held).
+func (h *Household) passOnSkill(parent, young *core.DigitalPet, drive, deltaTime float64) {
+	skill, best := ai.AllSkills()[0], -1.0
+	for _, candidate := range ai.AllSkills() {
+		if level := parent.Skills.Level(candidate); level > best {
+			skill, best = candidate, level
+		}
+	}
+	if young.Skills.Level(skill) >= best {
+		return
+	}
+	young.TrainSkill(skill, ParentTeachingRate*drive*deltaTime)
+	if young.Skills.Level(skill) > best {
+		young.Skills.Levels[skill] = best
+	}
+}
+
+// recordCare remembers an act of care on both pets' relationship and in the
+// young pet's memory (must be called with lock held)
+func (h *Household) recordCare(parent, young *core.DigitalPet, description string) {
+	h.logExperience(parent.ID, young.ID, description, 0.1)
+	h.logExperience(young.ID, parent.ID, description, 0.2)
+	young.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: description,
+		GameTime:    young.GetAge(),
+		Strength:    0.5,
+		Valence:     0.6,
+		Emotion:     young.Emotions.DominantEmotion,
+		Tags:        []string{TagParentCare, string(parent.ID)},
+	})
+}
@@ -0,0 +1,109 @@
+package interaction
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// family creates an adult parent with a caring nature and a juvenile
+// offspring it is bonded with
+func family(caring float64) (*core.DigitalPet, *core.DigitalPet) {
+	parent := core.NewDigitalPet("Bella", "user123")
+	parent.Biology.Processes.Age = 20.0
+	parent.Personality.Traits.Loyalty = caring
+	parent.Personality.Traits.Agreeableness = caring
+	young := core.NewDigitalPet("Pip", "user123")
+	young.Biology.Processes.Age = 4.0
+
+	rel := parent.Relationships.AddRelationship(young.ID, types.RelationshipOffspring)
+	rel.BondStrength = 0.8
+	young.Relationships.AddRelationship(parent.ID, types.RelationshipParent)
+	return parent, young
+}
+
+func TestCareDrive(t *testing.T) {
+	parent, young := family(0.9)
+	if drive := CareDrive(parent, young); drive < ParentCareThreshold {
+		t.Errorf("A loyal, agreeable parent should care, got %.2f", drive)
+	}
+	if drive := CareDrive(young, parent); drive != 0 {
+		t.Errorf("Only parents care for their offspring, got %.2f", drive)
+	}
+	aloof, young := family(0.1)
+	if drive := CareDrive(aloof, young); drive >= ParentCareThreshold {
+		t.Errorf("An aloof parent should not care enough, got %.2f", drive)
+	}
+}
+
+func TestParentSharesFood(t *testing.T) {
+	parent, young := family(0.9)
+	h := NewHousehold(parent, young)
+	parent.Biology.Vitals.Nutrition = 0.9
+	young.Biology.Vitals.Nutrition = 0.1
+
+	h.updateParenting(0.01)
+	if young.Biology.Vitals.Nutrition <= 0.1 || parent.Biology.Vitals.Nutrition >= 0.9 {
+		t.Error("The parent should have given the hungry juvenile part of a meal")
+	}
+	if len(young.Memory.ByTag(TagParentCare)) != 1 {
+		t.Error("The juvenile should remember being fed")
+	}
+
+	aloof, other := family(0.1)
+	h = NewHousehold(aloof, other)
+	aloof.Biology.Vitals.Nutrition = 0.9
+	other.Biology.Vitals.Nutrition = 0.1
+	h.updateParenting(0.01)
+	if other.Biology.Vitals.Nutrition != 0.1 {
+		t.Error("An aloof parent should not share food")
+	}
+}
+
+func TestParentComfortsOncePerStorm(t *testing.T) {
+	parent, young := family(0.9)
+	h := NewHousehold(parent, young)
+	h.Weather = environment.NewSeededWeatherSystem(1)
+	h.Weather.Current.Type = environment.WeatherStorm
+	young.Biology.Vitals.Stress = 0.8
+
+	h.updateParenting(0.01)
+	if young.Biology.Vitals.Stress >= 0.8 {
+		t.Error("The parent should have comforted the juvenile during the storm")
+	}
+	messages := len(h.Inbox.List())
+	h.updateParenting(0.01)
+	if len(h.Inbox.List()) != messages {
+		t.Error("A juvenile should only be comforted once per storm")
+	}
+
+	h.Weather.Current.Type = environment.WeatherClear
+	h.updateParenting(0.01)
+	h.Weather.Current.Type = environment.WeatherStorm
+	h.updateParenting(0.01)
+	if len(h.Inbox.List()) != messages+1 {
+		t.Error("A new storm should bring more comfort")
+	}
+}
+
+func TestParentPassesOnSkill(t *testing.T) {
+	parent, young := family(0.9)
+	h := NewHousehold(parent, young)
+	parent.Skills.Levels[ai.SkillForaging] = 0.5
+
+	h.updateParenting(100.0)
+	if level := young.Skills.Level(ai.SkillForaging); level <= 0 || level > 0.5 {
+		t.Errorf("The juvenile should pick up foraging without passing the parent, got %.2f", level)
+	}
+
+	parent.Location = "beach"
+	before := young.Skills.Level(ai.SkillForaging)
+	young.Skills.Levels[ai.SkillForaging] = before - 0.1
+	h.updateParenting(1.0)
+	if young.Skills.Level(ai.SkillForaging) != before-0.1 {
+		t.Error("A parent elsewhere should not teach")
+	}
+}
@@ -0,0 +1,184 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// ErrHouseholdFull is returned when taking in a pet would pass the population cap
+var ErrHouseholdFull = errors.New("household is at its population cap")
+
+// TagFarewell tags the memories pets keep of a housemate leaving
+const TagFarewell = "farewell"
+
+// Destination is where a pet that is adopted out goes
+type Destination int
+
+const (
+	DestinationCenter      Destination = iota // Waits on the adoption center roster
+	DestinationSanctuary                      // Retires to the sanctuary run by the villagers
+	DestinationMarketplace                    // Offered to any player through the cloud
+	DestinationFriend                         // Reserved for a friend through the cloud
+)
+
+// String returns how a destination reads in messages
+func (d Destination) String() string {
+	return [...]string{"the adoption center", "the sanctuary", "the marketplace", "a friend"}[d]
+}
+
+// Population returns the pets in the household plus the newborns waiting
+// for a decision
+func (h *Household) Population() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.population()
+}
+
+// OverCap returns true if extra more pets would pass the population cap
+func (h *Household) OverCap(extra int) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.PopulationCap > 0 && h.population()+extra > h.PopulationCap
+}
+
+// CheckRoom returns ErrHouseholdFull if the household cannot take in
+// another pet
+func (h *Household) CheckRoom() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.full()
+}
+
+// Leaving looks up a pet that may be adopted out: an undecided pup or a
+// present pet that is not expecting a litter
+func (h *Household) Leaving(nameOrID string) (*core.DigitalPet, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, _, err := h.leaving(nameOrID)
+	return pet, err
+}
+
+// AdoptOut sends an undecided pup or a present pet to a destination.
+// Pets going to the adoption center join its roster; the cloud
+// destinations are up to the caller, which should offer the pet before
+// calling AdoptOut. The pets left behind say goodbye.
+func (h *Household) AdoptOut(nameOrID string, destination Destination) (*core.DigitalPet, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if destination == DestinationCenter && h.Adoption == nil {
+		return nil, ErrNoAdoptionCenter
+	}
+	pet, litter, err := h.leaving(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	if litter != nil {
+		h.takePup(litter, pet)
+	} else {
+		h.leave(pet.ID)
+	}
+	if destination == DestinationCenter {
+		pet.Owner = ""
+		h.Adoption.Roster = append(h.Adoption.Roster, h.rehomed(pet, litter))
+	}
+	h.farewell(pet, destination)
+	return pet, nil
+}
+
+// population returns the pets present and waiting (must be called with
+// lock held)
+func (h *Household) population() int {
+	count := len(h.Pets)
+	for _, litter := range h.litters {
+		count += len(litter.Pups)
+	}
+	return count
+}
+
+// full returns ErrHouseholdFull if one more pet would pass the cap,
+// counting the newborns waiting for a decision (must be called with lock
+// held)
+func (h *Household) full() error {
+	return h.capped(h.population())
+}
+
+// capped returns ErrHouseholdFull if count pets leave no room for another
+// under the cap
+func (h *Household) capped(count int) error {
+	if h.PopulationCap > 0 && count >= h.PopulationCap {
+		return fmt.Errorf("%w of %d", ErrHouseholdFull, h.PopulationCap)
+	}
+	return nil
+}
+
+// leaving finds a pet that may be adopted out and the litter it is waiting
+// in, if any (must be called with lock held)
+func (h *Household) leaving(nameOrID string) (*core.DigitalPet, *Litter, error) {
+	if pup, litter, err := h.findPup(nameOrID); err == nil {
+		return pup, litter, nil
+	}
+	for _, pet := range h.Pets {
+		if string(pet.ID) != nameOrID && !strings.EqualFold(pet.Name, nameOrID) {
+			continue
+		}
+		if h.expecting(pet.ID) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrAlreadyExpecting, pet.Name)
+		}
+		return pet, nil, nil
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrPetNotPresent, nameOrID)
+}
+
+// rehomed describes a pet placed at the adoption center by the household
+// (must be called with lock held)
+func (h *Household) rehomed(pet *core.DigitalPet, litter *Litter) *Adoptable {
+	backstory := fmt.Sprintf("%s's family could no longer keep everyone and hopes they find a home of their own.", pet.Name)
+	if litter != nil {
+		backstory = fmt.Sprintf("%s was born to %s and %s and is looking for a home of their own.",
+			pet.Name, litter.Parents[0], litter.Parents[1])
+	}
+	report := pet.ScreenGenetics()
+	return &Adoptable{
+		Pet:        pet,
+		Backstory:  backstory,
+		Quirk:      h.Adoption.quirk(pet.Personality.Traits),
+		Conditions: report.Affected,
+		Fee:        adoptionFee(pet.Biology.GetLifeStage(), len(report.Affected)),
+	}
+}
+
+// farewell lets the departing pet and the housemates who knew it remember
+// the goodbye, and posts a farewell letter (must be called with lock held)
+func (h *Household) farewell(pet *core.DigitalPet, destination Destination) {
+	pet.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: fmt.Sprintf("%s said goodbye to their family and left for %s.", pet.Name, destination),
+		GameTime:    pet.GetAge(),
+		Strength:    0.7,
+		Valence:     -0.1,
+		Emotion:     pet.Emotions.DominantEmotion,
+		Tags:        []string{TagFarewell},
+	})
+	for _, housemate := range h.Pets {
+		rel, known := housemate.Relationships.GetRelationship(pet.ID)
+		if !known {
+			continue
+		}
+		housemate.Memory.Remember(&ai.Memory{
+			Type:        ai.MemoryEvent,
+			Description: fmt.Sprintf("%s left for %s.", pet.Name, destination),
+			GameTime:    housemate.GetAge(),
+			Strength:    0.4 + 0.5*rel.BondStrength,
+			Valence:     -0.3 * rel.BondStrength,
+			Emotion:     housemate.Emotions.DominantEmotion,
+			Tags:        []string{TagFarewell},
+		})
+	}
+	h.Inbox.Post(MessageFarewell, pet.ID, fmt.Sprintf("Farewell, %s", pet.Name),
+		fmt.Sprintf("%s has left for %s. The pets who knew them will remember the goodbye.", pet.Name, destination))
+}
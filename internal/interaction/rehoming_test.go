@@ -0,0 +1,95 @@
+package interaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestPopulationCap(t *testing.T) {
+	h, _, _ := expectingHousehold(t)
+	h.Adoption = NewSeededAdoptionCenter(1)
+	h.Inventory.Coins = 1000
+	h.PopulationCap = 2
+
+	if !h.OverCap(1) || h.OverCap(0) {
+		t.Error("Expected a full household to be over its cap with one more pet only")
+	}
+	if _, err := h.Adopt(h.Adoption.Roster[0].Pet.Name, "sam"); !errors.Is(err, ErrHouseholdFull) {
+		t.Errorf("Expected ErrHouseholdFull when adopting, got %v", err)
+	}
+
+	h.pregnancies[0].Size = 1
+	h.Update(GestationDays)
+	if h.Population() != 3 {
+		t.Errorf("Expected the waiting pup counted, got %d", h.Population())
+	}
+	pup := h.Litters()[0].Pups[0]
+	if _, err := h.KeepPup(pup.Name); !errors.Is(err, ErrHouseholdFull) {
+		t.Errorf("Expected ErrHouseholdFull when keeping a pup, got %v", err)
+	}
+	h.PopulationCap = 0
+	if _, err := h.KeepPup(pup.Name); err != nil {
+		t.Errorf("A cap of 0 should not limit the household, got %v", err)
+	}
+}
+
+func TestAdoptCountsWaitingPups(t *testing.T) {
+	h, _, _ := expectingHousehold(t)
+	h.Adoption = NewSeededAdoptionCenter(1)
+	h.Inventory.Coins = 1000
+	h.PopulationCap = 3
+	h.pregnancies[0].Size = 1
+	h.Update(GestationDays)
+
+	if _, err := h.Adopt(h.Adoption.Roster[0].Pet.Name, "sam"); !errors.Is(err, ErrHouseholdFull) {
+		t.Errorf("Expected the undecided pup to fill the last place, got %v", err)
+	}
+	if len(h.Pets) != 2 {
+		t.Errorf("Expected no pet adopted, got %d pets", len(h.Pets))
+	}
+	if _, err := h.KeepPup(h.Litters()[0].Pups[0].Name); err != nil {
+		t.Errorf("Expected the pup to take its own place, got %v", err)
+	}
+}
+
+func TestAdoptOutSaysFarewell(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "sam")
+	bella := core.NewDigitalPet("Bella", "sam")
+	milo := core.NewDigitalPet("Milo", "sam")
+	rex.Relationships.AddRelationship(milo.ID, types.RelationshipFriend)
+	h := NewHousehold(rex, bella, milo)
+
+	left, err := h.AdoptOut("milo", DestinationSanctuary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, present := h.Pets[milo.ID]; present || left != milo {
+		t.Fatal("Expected Milo to leave the household")
+	}
+	if len(milo.Memory.ByTag(TagFarewell)) == 0 || len(rex.Memory.ByTag(TagFarewell)) == 0 {
+		t.Error("Expected Milo and their friend Rex to remember the goodbye")
+	}
+	if len(bella.Memory.ByTag(TagFarewell)) != 0 {
+		t.Error("Pets that never knew Milo should not remember the goodbye")
+	}
+	farewell := false
+	for _, msg := range h.Inbox.List() {
+		farewell = farewell || (msg.Category == MessageFarewell && msg.PetID == milo.ID)
+	}
+	if !farewell {
+		t.Error("Expected a farewell letter in the inbox")
+	}
+
+	if _, err := h.Breed(rex, bella); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.AdoptOut("rex", DestinationMarketplace); !errors.Is(err, ErrAlreadyExpecting) {
+		t.Errorf("An expecting pet should stay until the litter is born, got %v", err)
+	}
+	if _, err := h.Leaving("nobody"); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,310 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Runaway and rescue settings
+const (
+	NeglectHappiness   = 0.2 // Happiness below which a pet feels neglected
+	NeglectStress      = 0.6 // Stress above which a pet feels neglected
+	RunawayNeglectDays = 1.0 // Days of neglect before a pet of average independence runs away
+	ClueChancePerDay   = 0.8 // Chance per game day of a clue turning up
+	ClueAccuracy       = 0.7 // Chance a clue points to where the pet is hiding
+	SearchBaseChance   = 0.3 // Chance of finding a pet in the right place with no help
+	SearchEnergyCost   = 0.1 // Energy a searching pet spends
+	ReunionBond        = 0.2 // Bond strength a rescuing pet and the rescued pet gain
+	TagRescue          = "rescue"
+)
+
+// ErrNotMissing is returned when searching for a pet that has not run away
+var ErrNotMissing = errors.New("pet is not missing")
+
+// Rescue tracks a pet that ran away and the search for it
+type Rescue struct {
+	Pet      *core.DigitalPet
+	Hideout  string   // Location the pet is hiding at
+	Clues    []string // Locations clues have pointed to, oldest first; some are false leads
+	Searched []string // Locations searched without finding the pet
+	Days     float64  // Game days missing
+}
+
+// SearchResult describes one search for a missing pet
+type SearchResult struct {
+	Location string
+	Searcher string  // Name of the searching pet, or "" for the player
+	Chance   float64 // Chance the search had of finding the pet
+	Found    bool
+}
+
+// SearchChance returns the chance a searcher finds a missing pet when
+// searching where it hides. A pet searcher does better with foraging skill
+// to follow the trail and a close bond with the missing pet; the player
+// does better with a loyal pet that comes when called. A nil searcher is
+// the player.
+func SearchChance(missing, searcher *core.DigitalPet) float64 {
+	if searcher == nil {
+		return clamp(SearchBaseChance+0.4*missing.Personality.Traits.Loyalty, 0.0, 0.95)
+	}
+	bond := 0.0
+	if rel, exists := searcher.Relationships.GetRelationship(missing.ID); exists {
+		bond = rel.BondStrength
+	}
+	return clamp(SearchBaseChance+0.4*searcher.Skills.Level(ai.SkillForaging)+0.3*bond, 0.0, 0.95)
+}
+
+// Missing returns the pets that have run away, by name
+func (h *Household) Missing() []Rescue {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	rescues := make([]Rescue, len(h.rescues))
+	for i, rescue := range h.rescues {
+		rescues[i] = *rescue
+		rescues[i].Clues = append([]string(nil), rescue.Clues...)
+		rescues[i].Searched = append([]string(nil), rescue.Searched...)
+	}
+	sort.Slice(rescues, func(i, j int) bool { return rescues[i].Pet.Name < rescues[j].Pet.Name })
+	return rescues
+}
+
+// Search looks for a missing pet at a location. The searcher is a present
+// pet, which spends energy, or the player when searcherID is empty. A pet
+// that is found comes home for an emotional reunion.
+func (h *Household) Search(missingID types.PetID, location string, searcherID types.PetID) (SearchResult, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result := SearchResult{Location: location}
+
+	rescue := h.rescue(missingID)
+	if rescue == nil {
+		return result, fmt.Errorf("%w: %s", ErrNotMissing, missingID)
+	}
+	if _, exists := h.World.Location(location); !exists || location == environment.HomeLocation {
+		return result, fmt.Errorf("%w: %s", environment.ErrUnknownLocation, location)
+	}
+
+	var searcher *core.DigitalPet
+	if searcherID != "" {
+		pet, present := h.Pets[searcherID]
+		if !present {
+			return result, fmt.Errorf("%w: %s", ErrPetNotPresent, searcherID)
+		}
+		if !pet.IsAlive() || pet.Biology.Hibernation.IsDormant() || pet.Biology.Vitals.Energy < SearchEnergyCost {
+			return result, fmt.Errorf("%w: %s", ErrTooTired, pet.Name)
+		}
+		pet.Biology.Vitals.Energy -= SearchEnergyCost
+		pet.Biology.Vitals.Clamp()
+		searcher = pet
+		result.Searcher = pet.Name
+	}
+
+	if location == rescue.Hideout {
+		result.Chance = SearchChance(rescue.Pet, searcher)
+		result.Found = h.rng.Float64() < result.Chance
+	}
+	if !result.Found {
+		if !containsString(rescue.Searched, location) {
+			rescue.Searched = append(rescue.Searched, location)
+		}
+		return result, nil
+	}
+	h.reunite(rescue, searcher)
+	return result, nil
+}
+
+// SetWorld changes the world pets run away into. Missing pets hide again
+// somewhere in the new world, and their clues go cold.
+func (h *Household) SetWorld(world *environment.WorldMap) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.World = world
+	for _, rescue := range h.rescues {
+		if hideouts := h.hideouts(""); len(hideouts) > 0 {
+			rescue.Hideout = hideouts[h.rng.Intn(len(hideouts))]
+		}
+		rescue.Pet.Location = rescue.Hideout
+		rescue.Clues = nil
+		rescue.Searched = nil
+	}
+}
+
+// rescue returns the search for a missing pet, or nil (must be called
+// with lock held)
+func (h *Household) rescue(petID types.PetID) *Rescue {
+	for _, rescue := range h.rescues {
+		if rescue.Pet.ID == petID {
+			return rescue
+		}
+	}
+	return nil
+}
+
+// updateRescues lets neglected pets run away once they have been unhappy
+// for long enough, and turns up clues about pets already missing (must be
+// called with lock held). Without a world map pets never run away.
+func (h *Household) updateRescues(deltaTime float64) {
+	if h.World == nil {
+		return
+	}
+	for _, rescue := range h.rescues {
+		rescue.Days += deltaTime
+		if h.rng.Float64() < ClueChancePerDay*deltaTime {
+			h.clue(rescue)
+		}
+	}
+
+	ids := make([]types.PetID, 0, len(h.Pets))
+	for id := range h.Pets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		pet := h.Pets[id]
+		vitals := pet.Biology.Vitals
+		neglected := pet.IsAlive() && !pet.Biology.Hibernation.IsDormant() &&
+			vitals.Happiness < NeglectHappiness && vitals.Stress > NeglectStress
+		if !neglected {
+			delete(h.neglect, id)
+			continue
+		}
+		h.neglect[id] += deltaTime
+		if h.neglect[id] >= RunawayNeglectDays*(1.5-pet.Personality.Traits.Independence) {
+			h.runAway(pet)
+		}
+	}
+}
+
+// runAway has a neglected pet slip out to hide somewhere in the world
+// (must be called with lock held)
+func (h *Household) runAway(pet *core.DigitalPet) {
+	hideouts := h.hideouts(pet.Location)
+	if len(hideouts) == 0 {
+		return
+	}
+	rescue := &Rescue{Pet: pet, Hideout: hideouts[h.rng.Intn(len(hideouts))]}
+	h.rescues = append(h.rescues, rescue)
+	delete(h.Pets, pet.ID)
+	delete(h.neglect, pet.ID)
+	pet.Location = rescue.Hideout
+
+	pet.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: "Ran away from home after being neglected",
+		GameTime:    pet.GetAge(),
+		Strength:    0.8,
+		Valence:     -0.6,
+		Emotion:     pet.Emotions.DominantEmotion,
+		Tags:        []string{TagRescue, rescue.Hideout},
+	})
+	h.Inbox.Post(MessageQuest, pet.ID, fmt.Sprintf("%s has run away", pet.Name),
+		fmt.Sprintf("%s was unhappy for too long and slipped out. Watch for clues and use `rescue` to search for them.", pet.Name))
+}
+
+// hideouts returns the locations away from home a pet could hide at,
+// other than one to leave out (must be called with lock held)
+func (h *Household) hideouts(except string) []string {
+	var ids []string
+	for _, id := range h.World.LocationIDs() {
+		if id != environment.HomeLocation && id != except {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// clue posts a hint about where a missing pet is hiding. Some clues are
+// false leads (must be called with lock held).
+func (h *Household) clue(rescue *Rescue) {
+	location := rescue.Hideout
+	if h.rng.Float64() >= ClueAccuracy {
+		if leads := h.hideouts(rescue.Hideout); len(leads) > 0 {
+			location = leads[h.rng.Intn(len(leads))]
+		}
+	}
+	rescue.Clues = append(rescue.Clues, location)
+
+	h.Inbox.Post(MessageQuest, rescue.Pet.ID, fmt.Sprintf("A clue about %s", rescue.Pet.Name),
+		fmt.Sprintf("Someone spotted paw prints that might be %s's near %s. Search there with `rescue %s %s`.",
+			rescue.Pet.Name, h.locationName(location), rescue.Pet.Name, location))
+}
+
+// locationName returns a location's display name, or its ID if it is not
+// on the map (must be called with lock held)
+func (h *Household) locationName(id string) string {
+	if loc, exists := h.World.Location(id); exists {
+		return loc.Name
+	}
+	return id
+}
+
+// reunite brings a found pet home. Both it and its rescuer are overjoyed,
+// and every pet that knows it is glad to see it back (must be called with
+// lock held).
+func (h *Household) reunite(rescue *Rescue, rescuer *core.DigitalPet) {
+	pet := rescue.Pet
+	for i, missing := range h.rescues {
+		if missing == rescue {
+			h.rescues = append(h.rescues[:i], h.rescues[i+1:]...)
+			break
+		}
+	}
+	pet.Location = environment.HomeLocation
+	h.Pets[pet.ID] = pet
+
+	vitals := pet.Biology.Vitals
+	vitals.Happiness += 0.3
+	vitals.Stress -= 0.3
+	vitals.Clamp()
+	pet.Emotions.ApplyEmotionalStimulus(ai.EmotionalStimulus{
+		JoyDelta: 0.5, AffectionDelta: 0.4, FearDelta: -0.3, LonelinessDelta: -0.4,
+	})
+
+	rescuerName := "you"
+	if rescuer == nil {
+		pet.ProcessUserInteraction(types.InteractionComfort, 1.0)
+	} else {
+		rescuerName = rescuer.Name
+		for _, pair := range [][2]*core.DigitalPet{{pet, rescuer}, {rescuer, pet}} {
+			if rel, exists := pair[0].Relationships.GetRelationship(pair[1].ID); exists {
+				rel.BondStrength += ReunionBond
+				rel.Trust += ReunionBond
+				rel.Clamp()
+			}
+		}
+		rescuer.Memory.Remember(&ai.Memory{
+			Type:        ai.MemoryEvent,
+			Description: fmt.Sprintf("Found %s at %s and brought them home", pet.Name, h.locationName(rescue.Hideout)),
+			GameTime:    rescuer.GetAge(),
+			Strength:    0.8,
+			Valence:     0.8,
+			Emotion:     rescuer.Emotions.DominantEmotion,
+			Tags:        []string{TagRescue, string(pet.ID)},
+		})
+	}
+	for id := range h.Pets {
+		if id != pet.ID {
+			h.logExperience(id, pet.ID, fmt.Sprintf("%s came home after running away", pet.Name), 0.2)
+		}
+	}
+
+	pet.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: fmt.Sprintf("Was found at %s by %s and brought home", h.locationName(rescue.Hideout), rescuerName),
+		GameTime:    pet.GetAge(),
+		Strength:    0.9,
+		Valence:     0.9,
+		Emotion:     pet.Emotions.DominantEmotion,
+		Tags:        []string{TagRescue, rescue.Hideout},
+	})
+	h.Inbox.Post(MessageCelebration, pet.ID, fmt.Sprintf("%s is home!", pet.Name),
+		fmt.Sprintf("After %.1f days away, %s was found at %s by %s. There was a very happy reunion.",
+			rescue.Days, pet.Name, h.locationName(rescue.Hideout), rescuerName))
+}
@@ -0,0 +1,123 @@
+package interaction
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// neglect makes a pet unhappy and stressed enough to run away
+func neglect(pet *core.DigitalPet) {
+	pet.Biology.Vitals.Happiness = 0.05
+	pet.Biology.Vitals.Stress = 0.9
+}
+
+func TestNeglectedPetRunsAway(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.World = environment.DefaultWorldMap()
+	h.rng = rand.New(rand.NewSource(1))
+
+	neglect(pet)
+	h.updateRescues(0.5)
+	if len(h.Missing()) != 0 {
+		t.Fatal("A pet should put up with some neglect before running away")
+	}
+	h.updateRescues(1.5)
+	missing := h.Missing()
+	if len(missing) != 1 || missing[0].Pet != pet {
+		t.Fatalf("Expected Rex to run away, got %+v", missing)
+	}
+	if _, present := h.Pets[pet.ID]; present {
+		t.Error("A missing pet should not be present")
+	}
+	if pet.Location != missing[0].Hideout || pet.Location == environment.HomeLocation {
+		t.Errorf("Expected Rex hiding away from home, got %s", pet.Location)
+	}
+	if _, err := h.Interact(pet.ID, types.InteractionFeeding, 0.5); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+
+	h.updateRescues(10.0)
+	if len(h.Missing()[0].Clues) == 0 {
+		t.Error("Expected clues to turn up over time")
+	}
+}
+
+func TestSearchAndReunion(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "user123")
+	bella := core.NewDigitalPet("Bella", "user123")
+	bella.Relationships.AddRelationship(rex.ID, types.RelationshipFriend)
+	rex.Relationships.AddRelationship(bella.ID, types.RelationshipFriend)
+	h := NewHousehold(rex, bella)
+	h.World = environment.DefaultWorldMap()
+	h.rng = rand.New(rand.NewSource(1))
+
+	if _, err := h.Search(rex.ID, "forest", ""); !errors.Is(err, ErrNotMissing) {
+		t.Errorf("Expected ErrNotMissing, got %v", err)
+	}
+	neglect(rex)
+	h.updateRescues(2.0)
+	hideout := h.Missing()[0].Hideout
+	wrong := "beach"
+	if hideout == wrong {
+		wrong = "forest"
+	}
+
+	if _, err := h.Search(rex.ID, "atlantis", bella.ID); !errors.Is(err, environment.ErrUnknownLocation) {
+		t.Errorf("Expected ErrUnknownLocation, got %v", err)
+	}
+	result, err := h.Search(rex.ID, wrong, bella.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Found || len(h.Missing()[0].Searched) != 1 {
+		t.Errorf("Searching the wrong place should find nothing, got %+v", result)
+	}
+
+	bella.Skills.Levels[ai.SkillForaging] = 1.0
+	bondBefore := 0.0
+	if rel, ok := bella.Relationships.GetRelationship(rex.ID); ok {
+		bondBefore = rel.BondStrength
+	}
+	for i := 0; i < 20 && len(h.Missing()) > 0; i++ {
+		bella.Biology.Vitals.Energy = 1.0
+		if result, err = h.Search(rex.ID, hideout, bella.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !result.Found || result.Chance <= SearchBaseChance {
+		t.Fatalf("Expected a skilled, bonded searcher to find Rex, got %+v", result)
+	}
+	if _, present := h.Pets[rex.ID]; !present || rex.Location != environment.HomeLocation {
+		t.Error("Rex should be home again")
+	}
+	if rel, _ := bella.Relationships.GetRelationship(rex.ID); rel.BondStrength <= bondBefore {
+		t.Error("The rescue should bring Bella and Rex closer")
+	}
+	if len(rex.Memory.ByTag(TagRescue)) != 2 || len(bella.Memory.ByTag(TagRescue)) != 1 {
+		t.Error("Both pets should remember the rescue")
+	}
+}
+
+func TestSearchChance(t *testing.T) {
+	missing := core.NewDigitalPet("Rex", "user123")
+	novice := core.NewDigitalPet("Bella", "user123")
+	tracker := core.NewDigitalPet("Max", "user123")
+	tracker.Skills.Levels[ai.SkillForaging] = 0.9
+	if SearchChance(missing, tracker) <= SearchChance(missing, novice) {
+		t.Error("Foraging skill should help follow the trail")
+	}
+
+	missing.Personality.Traits.Loyalty = 0.1
+	aloof := SearchChance(missing, nil)
+	missing.Personality.Traits.Loyalty = 0.9
+	if SearchChance(missing, nil) <= aloof {
+		t.Error("A loyal pet should be easier for the player to find")
+	}
+}
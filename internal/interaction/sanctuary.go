@@ -0,0 +1,333 @@
+package interaction
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrStandingTooLow is returned when using a sanctuary reward the household has not unlocked
+	ErrStandingTooLow = errors.New("community standing too low")
+	// ErrVisitedRecently is returned when a pet visits the sanctuary again too soon
+	ErrVisitedRecently = errors.New("visited the sanctuary recently")
+)
+
+// Sanctuary settings
+const (
+	DonationHistoryLength = 20   // Donations kept in the history
+	ProduceDonationValue  = 4    // Standing per harvested item
+	FishDonationBase      = 5    // Standing per fish, before its difficulty
+	FishDonationScale     = 10   // Extra standing per unit of fish difficulty
+	EarnedAccessoryValue  = 10   // Standing for an accessory that is not sold
+	SanctuaryVisitDays    = 1.0  // Game days before a pet can visit again
+	SanctuaryVisitJoy     = 0.15 // Happiness from a visit
+	SanctuaryVisitCalm    = 0.1  // Stress relieved by a visit
+	SanctuaryArrivalDays  = 5.0  // Game days between sanctuary pets for patrons
+	TagSanctuary          = "sanctuary"
+)
+
+// Donation kinds
+const (
+	DonationCoins       = "coins"
+	DonationProduce     = "produce"
+	DonationFish        = "fish"
+	DonationAccessories = "accessories"
+)
+
+// StandingTier is how well the community knows the household for its
+// giving; each tier unlocks a reward
+type StandingTier int
+
+const (
+	StandingNewcomer   StandingTier = iota
+	StandingSupporter               // Pets may visit the sanctuary
+	StandingFriend                  // A laurel for the wardrobe
+	StandingPatron                  // Sanctuary pets come up for adoption
+	StandingBenefactor              // A medal for the wardrobe
+)
+
+// String returns the string representation of StandingTier
+func (st StandingTier) String() string {
+	return [...]string{"Newcomer", "Supporter", "Friend", "Patron", "Benefactor"}[st]
+}
+
+// Threshold returns the standing needed to reach the tier
+func (st StandingTier) Threshold() int {
+	return [...]int{0, 50, 150, 400, 1000}[st]
+}
+
+// Reward describes what reaching the tier unlocks
+func (st StandingTier) Reward() string {
+	return [...]string{
+		"",
+		"pets may visit the sanctuary",
+		"a laurel for the wardrobe",
+		"sanctuary pets come up for adoption",
+		"a medal for the wardrobe",
+	}[st]
+}
+
+// TierFor returns the tier a standing reaches
+func TierFor(standing int) StandingTier {
+	tier := StandingNewcomer
+	for st := StandingSupporter; st <= StandingBenefactor; st++ {
+		if standing >= st.Threshold() {
+			tier = st
+		}
+	}
+	return tier
+}
+
+// Donation is one gift to the sanctuary
+type Donation struct {
+	Kind  string    `json:"kind"`  // One of the donation kinds
+	Item  string    `json:"item"`  // What was given; "coins" for coins
+	Count int       `json:"count"` // Coins or items given
+	Value int       `json:"value"` // Standing earned
+	Time  time.Time `json:"time"`  // Wall-clock time of the gift
+}
+
+// Sanctuary tracks the household's giving to the villagers' sanctuary
+// and the community standing it has earned
+type Sanctuary struct {
+	Standing  int            `json:"standing"`
+	Donations []Donation     `json:"donations"` // Most recent last, up to DonationHistoryLength
+	Totals    map[string]int `json:"totals"`    // Standing earned by donation kind
+	Count     int            `json:"count"`     // Donations made in total
+	Visits    int            `json:"visits"`    // Pet visits in total
+
+	visited      map[types.PetID]float64 // Game days until each pet may visit again
+	untilArrival float64                 // Game days until the next sanctuary pet
+}
+
+// NewSanctuary creates a sanctuary the household has not given to yet
+func NewSanctuary() *Sanctuary {
+	return &Sanctuary{
+		Totals:       make(map[string]int),
+		visited:      make(map[types.PetID]float64),
+		untilArrival: SanctuaryArrivalDays,
+	}
+}
+
+// Tier returns the household's standing tier
+func (s *Sanctuary) Tier() StandingTier {
+	return TierFor(s.Standing)
+}
+
+// SanctuaryStats summarises the household's giving for the statistics
+type SanctuaryStats struct {
+	Standing  int            `json:"standing"`
+	Tier      string         `json:"tier"`
+	Donations int            `json:"donations"`
+	ByKind    map[string]int `json:"by_kind"` // Standing earned by donation kind
+	Visits    int            `json:"visits"`
+}
+
+// SanctuaryStats returns the household's standing and donation totals
+func (h *Household) SanctuaryStats() SanctuaryStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	byKind := make(map[string]int, len(h.Sanctuary.Totals))
+	for kind, value := range h.Sanctuary.Totals {
+		byKind[kind] = value
+	}
+	return SanctuaryStats{
+		Standing:  h.Sanctuary.Standing,
+		Tier:      h.Sanctuary.Tier().String(),
+		Donations: h.Sanctuary.Count,
+		ByKind:    byKind,
+		Visits:    h.Sanctuary.Visits,
+	}
+}
+
+// DonationHistory returns the most recent donations, oldest first
+func (h *Household) DonationHistory() []Donation {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]Donation(nil), h.Sanctuary.Donations...)
+}
+
+// Donate gives coins or surplus items from the inventory to the
+// sanctuary. item is "coins", a crop, a fish species or an accessory.
+// Reaching a new standing tier posts its reward to the inbox.
+func (h *Household) Donate(item string, count int) (Donation, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if count <= 0 {
+		return Donation{}, fmt.Errorf("%w: %d", ErrInvalidAmount, count)
+	}
+
+	donation, err := h.takeDonation(item, count)
+	if err != nil {
+		return Donation{}, err
+	}
+	donation.Count, donation.Time = count, time.Now()
+
+	s := h.Sanctuary
+	before := s.Tier()
+	s.Standing += donation.Value
+	s.Totals[donation.Kind] += donation.Value
+	s.Count++
+	s.Donations = append(s.Donations, donation)
+	if len(s.Donations) > DonationHistoryLength {
+		s.Donations = s.Donations[len(s.Donations)-DonationHistoryLength:]
+	}
+	for tier := before + 1; tier <= s.Tier(); tier++ {
+		h.unlockTier(tier)
+	}
+	return donation, nil
+}
+
+// takeDonation removes a gift from the inventory and values it (must be
+// called with lock held)
+func (h *Household) takeDonation(item string, count int) (Donation, error) {
+	if strings.EqualFold(item, DonationCoins) {
+		if err := h.Inventory.Spend(count); err != nil {
+			return Donation{}, err
+		}
+		return Donation{Kind: DonationCoins, Item: DonationCoins, Value: count}, nil
+	}
+
+	if crop, err := environment.ParseCrop(item); err == nil {
+		if h.Inventory.Produce[crop] < count {
+			return Donation{}, fmt.Errorf("%w: %s", environment.ErrOutOfStock, crop)
+		}
+		for i := 0; i < count; i++ {
+			h.Inventory.TakeProduce(crop)
+		}
+		return Donation{Kind: DonationProduce, Item: crop.String(), Value: ProduceDonationValue * count}, nil
+	}
+
+	if fish, err := environment.LookupFish(item); err == nil {
+		if h.Inventory.Fish[fish.Name] < count {
+			return Donation{}, fmt.Errorf("%w: %s", environment.ErrOutOfStock, fish.Name)
+		}
+		for i := 0; i < count; i++ {
+			h.Inventory.TakeFish(fish.Name)
+		}
+		value := FishDonationBase + int(FishDonationScale*fish.Difficulty)
+		return Donation{Kind: DonationFish, Item: fish.Name, Value: value * count}, nil
+	}
+
+	accessory, err := environment.ParseAccessory(item)
+	if err != nil {
+		return Donation{}, fmt.Errorf("%w: %s", environment.ErrOutOfStock, item)
+	}
+	if h.Inventory.Accessories[accessory] < count {
+		return Donation{}, fmt.Errorf("%w: %s", environment.ErrOutOfStock, accessory)
+	}
+	for i := 0; i < count; i++ {
+		h.Inventory.TakeAccessory(accessory)
+	}
+	value, err := accessory.Price()
+	if err != nil {
+		value = EarnedAccessoryValue
+	}
+	return Donation{Kind: DonationAccessories, Item: accessory.String(), Value: value * count}, nil
+}
+
+// unlockTier hands out a tier's reward and tells the player (must be
+// called with lock held)
+func (h *Household) unlockTier(tier StandingTier) {
+	body := fmt.Sprintf("The villagers now count you as a %s of the sanctuary: %s.", tier, tier.Reward())
+	switch tier {
+	case StandingFriend:
+		body += h.grantAccessory(environment.AccessoryLaurel)
+	case StandingPatron:
+		if h.Adoption != nil {
+			adoptable := h.Adoption.welcome()
+			body += fmt.Sprintf(" %s is waiting for you at the adoption center.", adoptable.Pet.Name)
+		}
+	case StandingBenefactor:
+		body += h.grantAccessory(environment.AccessoryMedal)
+	}
+	h.Inbox.Post(MessageCelebration, "", fmt.Sprintf("Sanctuary %s", tier), body)
+}
+
+// VisitSanctuary takes a present pet to meet the sanctuary's animals,
+// lifting its mood. Needs Supporter standing; each pet may visit once
+// every SanctuaryVisitDays.
+func (h *Household) VisitSanctuary(petID types.PetID) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, present := h.Pets[petID]
+	if !present || !pet.IsAlive() {
+		return fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	if tier := h.Sanctuary.Tier(); tier < StandingSupporter {
+		return fmt.Errorf("%w: visits need %s standing (%d), have %d",
+			ErrStandingTooLow, StandingSupporter, StandingSupporter.Threshold(), h.Sanctuary.Standing)
+	}
+	if wait := h.Sanctuary.visited[petID]; wait > 0 {
+		return fmt.Errorf("%w: %s can go again in %.1f days", ErrVisitedRecently, pet.Name, wait)
+	}
+
+	vitals := pet.Biology.Vitals
+	vitals.Happiness += SanctuaryVisitJoy
+	vitals.Stress -= SanctuaryVisitCalm
+	vitals.Clamp()
+	pet.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: fmt.Sprintf("%s visited the animals at the sanctuary.", pet.Name),
+		GameTime:    pet.GetAge(),
+		Strength:    0.5,
+		Valence:     0.6,
+		Emotion:     pet.Emotions.DominantEmotion,
+		Tags:        []string{TagSanctuary},
+	})
+	h.Sanctuary.visited[petID] = SanctuaryVisitDays
+	h.Sanctuary.Visits++
+	return nil
+}
+
+// updateSanctuary counts down visit waits and, for patrons, sends a
+// sanctuary pet to the adoption center every SanctuaryArrivalDays (must
+// be called with lock held)
+func (h *Household) updateSanctuary(deltaTime float64) {
+	for id, wait := range h.Sanctuary.visited {
+		if wait -= deltaTime; wait > 0 {
+			h.Sanctuary.visited[id] = wait
+		} else {
+			delete(h.Sanctuary.visited, id)
+		}
+	}
+
+	if h.Adoption == nil || h.Sanctuary.Tier() < StandingPatron {
+		return
+	}
+	h.Sanctuary.untilArrival -= deltaTime
+	if h.Sanctuary.untilArrival > 0 {
+		return
+	}
+	h.Sanctuary.untilArrival += SanctuaryArrivalDays
+	adoptable := h.Adoption.welcome()
+	h.Inbox.Post(MessageSystem, "", "A sanctuary pet needs a home",
+		fmt.Sprintf("%s has come from the sanctuary and may be adopted free of charge. Use `adopt` to meet them.", adoptable.Pet.Name))
+}
+
+// welcome brings a sanctuary pet to the adoption center: an affectionate
+// juvenile with no fee. It replaces the
+// longest-waiting pet if the roster is full.
+func (ac *AdoptionCenter) welcome() *Adoptable {
+	adoptable := ac.generate()
+	pet := adoptable.Pet
+	pet.Biology.Processes.Age = biology.JuvenileAge
+	pet.Personality.Traits.Affectionate = max(pet.Personality.Traits.Affectionate, 0.8)
+	adoptable.Backstory = fmt.Sprintf("%s was raised by the villagers at the sanctuary, who kept them for a family that gives back.", pet.Name)
+	adoptable.Quirk = ac.quirk(pet.Personality.Traits)
+	adoptable.Fee = 0
+	adoptable.Sanctuary = true
+
+	if len(ac.Roster) >= AdoptionRosterSize {
+		ac.Roster = ac.Roster[1:]
+	}
+	ac.Roster = append(ac.Roster, adoptable)
+	return adoptable
+}
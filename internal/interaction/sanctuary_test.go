@@ -0,0 +1,99 @@
+package interaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestTierFor(t *testing.T) {
+	for standing, want := range map[int]StandingTier{
+		0: StandingNewcomer, 49: StandingNewcomer, 50: StandingSupporter,
+		399: StandingFriend, 400: StandingPatron, 5000: StandingBenefactor,
+	} {
+		if got := TierFor(standing); got != want {
+			t.Errorf("TierFor(%d) = %s, want %s", standing, got, want)
+		}
+	}
+}
+
+func TestDonationsRaiseStanding(t *testing.T) {
+	h := NewHousehold()
+	h.Inventory.Produce[environment.CropCarrots] = 3
+	h.Inventory.AddAccessory(environment.AccessoryHat)
+
+	if _, err := h.Donate("coins", 40); err != nil {
+		t.Fatalf("Donate failed: %v", err)
+	}
+	if _, err := h.Donate("carrots", 3); err != nil {
+		t.Fatalf("Donate failed: %v", err)
+	}
+	if _, err := h.Donate("hat", 1); err != nil {
+		t.Fatalf("Donate failed: %v", err)
+	}
+	if _, err := h.Donate("carrots", 1); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock for produce already given, got %v", err)
+	}
+	if _, err := h.Donate("coins", 0); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("Expected ErrInvalidAmount, got %v", err)
+	}
+
+	stats := h.SanctuaryStats()
+	want := 40 + 3*ProduceDonationValue + 30
+	if stats.Standing != want || stats.Donations != 3 || stats.Tier != StandingSupporter.String() {
+		t.Errorf("Expected standing %d from 3 donations, got %+v", want, stats)
+	}
+	if stats.ByKind[DonationProduce] != 3*ProduceDonationValue {
+		t.Errorf("Expected produce tracked separately, got %v", stats.ByKind)
+	}
+	if history := h.DonationHistory(); len(history) != 3 || history[0].Item != "coins" {
+		t.Errorf("Expected the donations in order, got %+v", history)
+	}
+	if messages := h.Inbox.List(); len(messages) != 1 || messages[0].Category != MessageCelebration {
+		t.Errorf("Expected a Supporter notice, got %+v", messages)
+	}
+}
+
+func TestStandingUnlocksRewards(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.Adoption = NewSeededAdoptionCenter(1)
+
+	if err := h.VisitSanctuary(pet.ID); !errors.Is(err, ErrStandingTooLow) {
+		t.Errorf("Expected ErrStandingTooLow before donating, got %v", err)
+	}
+
+	h.Inventory.Coins = 1000
+	if _, err := h.Donate("coins", StandingPatron.Threshold()); err != nil {
+		t.Fatalf("Donate failed: %v", err)
+	}
+	if h.Inventory.Accessories[environment.AccessoryLaurel] != 1 {
+		t.Error("Friends of the sanctuary should get a laurel")
+	}
+	var special *Adoptable
+	for _, adoptable := range h.Adoption.Roster {
+		if adoptable.Sanctuary {
+			special = adoptable
+		}
+	}
+	if special == nil || special.Fee != 0 || len(h.Adoption.Roster) != AdoptionRosterSize {
+		t.Fatalf("Expected a free sanctuary pet on a full roster, got %+v", h.Adoption.Roster)
+	}
+
+	pet.Biology.Vitals.Happiness = 0.5
+	if err := h.VisitSanctuary(pet.ID); err != nil {
+		t.Fatalf("VisitSanctuary failed: %v", err)
+	}
+	if pet.Biology.Vitals.Happiness <= 0.5 {
+		t.Errorf("A visit should cheer the pet up, got %.2f", pet.Biology.Vitals.Happiness)
+	}
+	if err := h.VisitSanctuary(pet.ID); !errors.Is(err, ErrVisitedRecently) {
+		t.Errorf("Expected ErrVisitedRecently, got %v", err)
+	}
+	h.Update(SanctuaryVisitDays)
+	if err := h.VisitSanctuary(pet.ID); err != nil {
+		t.Errorf("The pet should be able to visit again, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package interaction
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// householdState is the household state kept between runs beside the pet
+// saves
+type householdState struct {
+	WorldSeed int64                  `json:"world_seed,omitempty"`
+	Inventory *environment.Inventory `json:"inventory"`
+	Garden    *environment.Garden    `json:"garden,omitempty"`
+	Habitat   *environment.Habitat   `json:"habitat"`
+
+	Policies  map[types.PetID]savedPolicy `json:"policies,omitempty"`
+	Fund      int                         `json:"fund,omitempty"`
+	Sanctuary savedSanctuary              `json:"sanctuary"`
+
+	Milestones map[types.PetID]*MilestoneTracker `json:"milestones,omitempty"` // Albums and milestones celebrated
+
+	Pregnancies []savedPregnancy `json:"pregnancies,omitempty"`
+	Litters     []*Litter        `json:"litters,omitempty"` // Pups are saved whole, as they have no saves of their own
+	Rescues     []savedRescue    `json:"rescues,omitempty"` // Missing pets keep their own saves
+}
+
+// savedPolicy is a policy as saved, with whether a claim was made in the
+// current premium period
+type savedPolicy struct {
+	Policy
+	Claimed bool `json:"claimed,omitempty"`
+}
+
+// savedSanctuary is the sanctuary as saved, with how long pets must wait
+// to visit again and until the next sanctuary pet arrives
+type savedSanctuary struct {
+	*Sanctuary
+	Visited      map[types.PetID]float64 `json:"visited,omitempty"`
+	UntilArrival float64                 `json:"until_arrival"`
+}
+
+// savedPregnancy is a pregnancy as saved, with its parents by ID
+type savedPregnancy struct {
+	Parents [2]types.PetID `json:"parents"`
+	Size    int            `json:"size"`
+	DueIn   float64        `json:"due_in"`
+}
+
+// savedRescue is the search for a missing pet as saved, with the pet by ID
+type savedRescue struct {
+	PetID    types.PetID `json:"pet_id"`
+	Hideout  string      `json:"hideout"`
+	Clues    []string    `json:"clues,omitempty"`
+	Searched []string    `json:"searched,omitempty"`
+	Days     float64     `json:"days"`
+}
+
+// SaveState serializes the household's state other than its pets, which
+// are saved on their own
+func (h *Household) SaveState() ([]byte, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	state := h.state()
+	state.Policies = make(map[types.PetID]savedPolicy, len(h.policies))
+	for id, policy := range h.policies {
+		state.Policies[id] = savedPolicy{Policy: *policy, Claimed: policy.claimed}
+	}
+	for _, pregnancy := range h.pregnancies {
+		state.Pregnancies = append(state.Pregnancies, savedPregnancy{
+			Parents: [2]types.PetID{pregnancy.Parents[0].ID, pregnancy.Parents[1].ID},
+			Size:    pregnancy.Size,
+			DueIn:   pregnancy.DueIn,
+		})
+	}
+	for _, rescue := range h.rescues {
+		state.Rescues = append(state.Rescues, savedRescue{
+			PetID:    rescue.Pet.ID,
+			Hideout:  rescue.Hideout,
+			Clues:    rescue.Clues,
+			Searched: rescue.Searched,
+			Days:     rescue.Days,
+		})
+	}
+	return json.Marshal(state)
+}
+
+// LoadState restores state written by SaveState. It is read into the
+// household's existing inventory, garden, habitat, sanctuary and
+// milestone trackers, so anything sharing them sees the saved state too.
+// Pets that were missing are taken out of the present pets to be searched
+// for again. Pregnancies and searches for pets no longer in the household
+// are dropped.
+func (h *Household) LoadState(payload []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	state := h.state()
+	if err := json.Unmarshal(payload, &state); err != nil {
+		return fmt.Errorf("reading household state: %w", err)
+	}
+	for id, saved := range state.Policies {
+		policy := saved.Policy
+		policy.claimed = saved.Claimed
+		h.policies[id] = &policy
+	}
+	h.fund = state.Fund
+	h.WorldSeed = state.WorldSeed
+	h.Sanctuary.visited = state.Sanctuary.Visited
+	h.Sanctuary.untilArrival = state.Sanctuary.UntilArrival
+	h.pregnancies = nil
+	for _, saved := range state.Pregnancies {
+		parent1, present1 := h.Pets[saved.Parents[0]]
+		parent2, present2 := h.Pets[saved.Parents[1]]
+		if !present1 || !present2 {
+			continue
+		}
+		h.pregnancies = append(h.pregnancies, &Pregnancy{
+			Parents: [2]*core.DigitalPet{parent1, parent2},
+			Size:    saved.Size,
+			DueIn:   saved.DueIn,
+		})
+	}
+	h.litters = state.Litters
+	h.rescues = nil
+	for _, saved := range state.Rescues {
+		pet, present := h.Pets[saved.PetID]
+		if !present {
+			continue
+		}
+		// Still ranked and befriended, as when it ran away
+		delete(h.Pets, pet.ID)
+		h.rescues = append(h.rescues, &Rescue{
+			Pet:      pet,
+			Hideout:  saved.Hideout,
+			Clues:    saved.Clues,
+			Searched: saved.Searched,
+			Days:     saved.Days,
+		})
+	}
+	return nil
+}
+
+// state returns the state to save, pointing into the household where it
+// can be read in place, so anything missing from a save keeps its current
+// value (must be called with lock held)
+func (h *Household) state() householdState {
+	return householdState{
+		WorldSeed: h.WorldSeed,
+		Inventory: h.Inventory,
+		Garden:    h.Garden,
+		Habitat:   h.Habitat,
+		Fund:      h.fund,
+		Sanctuary: savedSanctuary{
+			Sanctuary:    h.Sanctuary,
+			Visited:      h.Sanctuary.visited,
+			UntilArrival: h.Sanctuary.untilArrival,
+		},
+		Milestones: h.Milestones,
+		Litters:    h.litters,
+	}
+}
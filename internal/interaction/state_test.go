@@ -0,0 +1,198 @@
+package interaction
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// reloaded saves a household's state and loads it into a fresh one with
+// the same pets, missing ones included as they have saves too
+func reloaded(t *testing.T, h *Household) *Household {
+	t.Helper()
+	payload, err := h.SaveState()
+	if err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+	pets := make([]*core.DigitalPet, 0, len(h.Pets))
+	for _, pet := range h.Pets {
+		pets = append(pets, pet)
+	}
+	for _, rescue := range h.Missing() {
+		pets = append(pets, rescue.Pet)
+	}
+	fresh := NewHousehold(pets...)
+	fresh.Garden = environment.NewGarden()
+	if err := fresh.LoadState(payload); err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	return fresh
+}
+
+func TestStateKeepsStoresAndHome(t *testing.T) {
+	h := NewHousehold(core.NewDigitalPet("Rex", "user123"))
+	h.Garden = environment.NewGarden()
+	h.Inventory.Coins = 7
+	h.Inventory.Seeds[environment.CropCarrots] = 3
+	h.Habitat.Add(environment.HabitatBed)
+	h.Garden.Plots[0].Planted = true
+	h.Garden.Plots[0].Crop = environment.CropCarrots
+	h.Garden.Plots[0].Growth = 0.4
+
+	fresh := reloaded(t, h)
+	if fresh.Inventory.Coins != 7 || fresh.Inventory.Seeds[environment.CropCarrots] != 3 {
+		t.Errorf("Expected the inventory back, got %+v", fresh.Inventory)
+	}
+	if !fresh.Habitat.Items[environment.HabitatBed] {
+		t.Error("Expected the bed to stay in the habitat")
+	}
+	if plot := fresh.Garden.Plots[0]; !plot.Planted || plot.Crop != environment.CropCarrots || plot.Growth != 0.4 {
+		t.Errorf("Expected the growing carrots back, got %+v", plot)
+	}
+}
+
+func TestStateKeepsInsurance(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.Inventory.Coins = 1000
+	if _, err := h.Insure(pet.ID, PlanStandard); err != nil {
+		t.Fatalf("Insure failed: %v", err)
+	}
+	if err := h.DepositFund(500); err != nil {
+		t.Fatalf("DepositFund failed: %v", err)
+	}
+	pet.Biology.Vitals.Health = 0.1
+	if _, err := h.Treat(pet.ID); err != nil {
+		t.Fatalf("Treat failed: %v", err)
+	}
+	before := h.Policies()[0]
+
+	fresh := reloaded(t, h)
+	policies := fresh.Policies()
+	if len(policies) != 1 || policies[0] != before {
+		t.Fatalf("Expected %+v back, got %+v", before, policies)
+	}
+	if fresh.EmergencyFund() == 0 || fresh.EmergencyFund() != h.EmergencyFund() {
+		t.Errorf("Expected a fund of %d, got %d", h.EmergencyFund(), fresh.EmergencyFund())
+	}
+
+	// The claim still rules out a no-claims discount this period
+	fresh.updateInsurance(PremiumIntervalDays)
+	if after := fresh.Policies()[0]; after.Loading != before.Loading {
+		t.Errorf("Expected the loading to stay at %.2f after a period with a claim, got %.2f", before.Loading, after.Loading)
+	}
+}
+
+func TestStateKeepsSanctuary(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.Inventory.Coins = 100
+	if _, err := h.Donate(DonationCoins, 60); err != nil {
+		t.Fatalf("Donate failed: %v", err)
+	}
+	if err := h.VisitSanctuary(pet.ID); err != nil {
+		t.Fatalf("VisitSanctuary failed: %v", err)
+	}
+
+	fresh := reloaded(t, h)
+	stats := fresh.SanctuaryStats()
+	if stats.Standing != 60 || stats.Donations != 1 || stats.Visits != 1 || stats.ByKind[DonationCoins] != 60 {
+		t.Errorf("Expected the standing and totals back, got %+v", stats)
+	}
+	if len(fresh.Sanctuary.Donations) != 1 || fresh.Sanctuary.Donations[0].Count != 60 {
+		t.Errorf("Expected the donation history back, got %+v", fresh.Sanctuary.Donations)
+	}
+	if err := fresh.VisitSanctuary(pet.ID); !errors.Is(err, ErrVisitedRecently) {
+		t.Errorf("Expected the visit wait to be kept, got %v", err)
+	}
+}
+
+func TestStateKeepsMilestones(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	for i := 0; i < CountThresholds[0]; i++ {
+		h.Interact(pet.ID, types.InteractionPlaying, 0.5)
+	}
+	now := time.Now()
+	if celebrations := h.Celebrate(now); len(celebrations) != 1 {
+		t.Fatalf("Expected one celebration, got %v", celebrations)
+	}
+	if _, err := h.AddPhoto(pet.ID, "At the lake", "", "", now); err != nil {
+		t.Fatalf("AddPhoto failed: %v", err)
+	}
+
+	fresh := reloaded(t, h)
+	if album := fresh.Album(pet.ID); len(album) != 2 || album[1].Title != "At the lake" {
+		t.Errorf("Expected the album back, got %+v", album)
+	}
+	if celebrations := fresh.Celebrate(now); len(celebrations) != 0 {
+		t.Errorf("Expected milestones already celebrated to stay celebrated, got %v", celebrations)
+	}
+	if fresh.Milestones[pet.ID].Counts[types.InteractionPlaying] != CountThresholds[0] {
+		t.Errorf("Expected the play count back, got %v", fresh.Milestones[pet.ID].Counts)
+	}
+}
+
+func TestStateKeepsPregnanciesAndLitters(t *testing.T) {
+	h, rex, bella := expectingHousehold(t)
+	size := h.Pregnancies()[0].Size
+	h.Update(GestationDays)
+	if _, err := h.Breed(rex, bella); err != nil {
+		t.Fatalf("Breed failed: %v", err)
+	}
+	pup := h.Litters()[0].Pups[0]
+	if _, err := h.NamePup(pup.Name, "Scout"); err != nil {
+		t.Fatalf("NamePup failed: %v", err)
+	}
+
+	fresh := reloaded(t, h)
+	pregnancies := fresh.Pregnancies()
+	if len(pregnancies) != 1 || pregnancies[0].Parents[0] != rex || pregnancies[0].DueIn != GestationDays {
+		t.Errorf("Expected Rex and Bella's new pregnancy back, got %+v", pregnancies)
+	}
+	litters := fresh.Litters()
+	if len(litters) != 1 || len(litters[0].Pups) != size || litters[0].Parents != [2]string{"Rex", "Bella"} {
+		t.Fatalf("Expected the litter of %d back, got %+v", size, litters)
+	}
+	kept, err := fresh.KeepPup("Scout")
+	if err != nil {
+		t.Fatalf("KeepPup failed: %v", err)
+	}
+	if kept.ID != pup.ID || kept.Genome == nil {
+		t.Errorf("Expected the whole pup back, got %+v", kept)
+	}
+}
+
+func TestStateKeepsRescues(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "user123")
+	bella := core.NewDigitalPet("Bella", "user123")
+	h := NewHousehold(rex, bella)
+	h.World = environment.DefaultWorldMap()
+	h.rng = rand.New(rand.NewSource(1))
+	neglect(rex)
+	h.updateRescues(2.0)
+	h.updateRescues(10.0)
+	missing := h.Missing()
+	if len(missing) != 1 {
+		t.Fatalf("Expected Rex to run away, got %+v", missing)
+	}
+
+	fresh := reloaded(t, h)
+	fresh.World = h.World
+	again := fresh.Missing()
+	if len(again) != 1 || again[0].Pet != rex || again[0].Hideout != missing[0].Hideout ||
+		len(again[0].Clues) != len(missing[0].Clues) || again[0].Days != missing[0].Days {
+		t.Fatalf("Expected the search for Rex back, got %+v", again)
+	}
+	if _, present := fresh.Pets[rex.ID]; present {
+		t.Error("A pet that was missing should still be missing after loading")
+	}
+	if _, err := fresh.Search(rex.ID, missing[0].Hideout, ""); err != nil {
+		t.Errorf("Expected Rex to be searched for again, got %v", err)
+	}
+}
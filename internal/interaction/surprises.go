@@ -0,0 +1,335 @@
+package interaction
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Surprise settings
+const (
+	DefaultSurpriseRate = 1.5 // Surprises per pet per game day
+	TagSurprise         = "surprise"
+)
+
+// SurpriseKind identifies a kind of surprise happening
+type SurpriseKind int
+
+const (
+	SurpriseShinyPebble SurpriseKind = iota
+	SurpriseButterfly
+	SurpriseLoudNoise
+	SurprisePuddle
+	SurpriseThunderclap
+	SurpriseSnowflakes
+	SurpriseSunnySpot
+	SurpriseStrangeScent
+	SurpriseSeashell
+	SurpriseCarHorn
+	SurpriseStartledBird
+)
+
+// String returns the string representation of SurpriseKind
+func (sk SurpriseKind) String() string {
+	return [...]string{
+		"Shiny Pebble", "Butterfly", "Loud Noise", "Puddle", "Thunderclap",
+		"Snowflakes", "Sunny Spot", "Strange Scent", "Seashell", "Car Horn",
+		"Startled Bird",
+	}[sk]
+}
+
+// surpriseSpec describes where and when a surprise can happen, who it
+// tends to happen to, and what it does
+type surpriseSpec struct {
+	Weight  float64                     // Relative likelihood among eligible surprises
+	Biomes  []environment.Biome         // Only at locations in these biomes; empty for anywhere
+	Weather []environment.WeatherType   // Only in this weather; empty for any
+	Trait   func(t *ai.Traits) float64  // Personality trait that makes it likelier
+	Vitals  func(v *biology.VitalStats) // Small effect on vitals
+	Feeling ai.EmotionalStimulus        // Emotional reaction
+	Valence float64                     // How pleasant the memory is (-1.0 to 1.0)
+	Text    string                      // Format string taking the pet name
+}
+
+// surpriseSpecs lists every surprise
+var surpriseSpecs = map[SurpriseKind]surpriseSpec{
+	SurpriseShinyPebble: {
+		Weight:  1.0,
+		Trait:   func(t *ai.Traits) float64 { return t.Curiosity },
+		Vitals:  func(v *biology.VitalStats) { v.Happiness += 0.04 },
+		Feeling: ai.EmotionalStimulus{JoyDelta: 0.1, ExcitementDelta: 0.1},
+		Valence: 0.6,
+		Text:    "%s found a shiny pebble and proudly shows it off.",
+	},
+	SurpriseButterfly: {
+		Weight:  1.0,
+		Biomes:  []environment.Biome{environment.BiomeTemperate, environment.BiomeTropical, environment.BiomeSwamp},
+		Weather: []environment.WeatherType{environment.WeatherClear, environment.WeatherCloudy},
+		Trait:   func(t *ai.Traits) float64 { return t.Playfulness },
+		Vitals:  func(v *biology.VitalStats) { v.Happiness += 0.05; v.Energy -= 0.03 },
+		Feeling: ai.EmotionalStimulus{JoyDelta: 0.15, ExcitementDelta: 0.15},
+		Valence: 0.7,
+		Text:    "%s chased a butterfly around until it fluttered away.",
+	},
+	SurpriseLoudNoise: {
+		Weight:  0.8,
+		Trait:   func(t *ai.Traits) float64 { return t.Neuroticism },
+		Vitals:  func(v *biology.VitalStats) { v.Stress += 0.06 },
+		Feeling: ai.EmotionalStimulus{FearDelta: 0.2},
+		Valence: -0.4,
+		Text:    "A loud bang made %s jump. It's over now, but a little reassurance wouldn't hurt.",
+	},
+	SurprisePuddle: {
+		Weight:  1.2,
+		Weather: []environment.WeatherType{environment.WeatherRain, environment.WeatherHeavyRain},
+		Trait:   func(t *ai.Traits) float64 { return t.Playfulness },
+		Vitals:  func(v *biology.VitalStats) { v.Happiness += 0.04; v.Cleanliness -= 0.1 },
+		Feeling: ai.EmotionalStimulus{JoyDelta: 0.1, ExcitementDelta: 0.1},
+		Valence: 0.4,
+		Text:    "%s jumped into a puddle with a big splash. A bath might be in order.",
+	},
+	SurpriseThunderclap: {
+		Weight:  1.5,
+		Weather: []environment.WeatherType{environment.WeatherStorm},
+		Trait:   func(t *ai.Traits) float64 { return t.Neuroticism },
+		Vitals:  func(v *biology.VitalStats) { v.Stress += 0.1 },
+		Feeling: ai.EmotionalStimulus{FearDelta: 0.3},
+		Valence: -0.6,
+		Text:    "A crack of thunder sent %s diving for cover.",
+	},
+	SurpriseSnowflakes: {
+		Weight:  1.2,
+		Weather: []environment.WeatherType{environment.WeatherSnow},
+		Trait:   func(t *ai.Traits) float64 { return t.Curiosity },
+		Vitals:  func(v *biology.VitalStats) { v.Happiness += 0.05 },
+		Feeling: ai.EmotionalStimulus{JoyDelta: 0.15, ExcitementDelta: 0.1},
+		Valence: 0.6,
+		Text:    "%s tried to catch snowflakes on their tongue.",
+	},
+	SurpriseSunnySpot: {
+		Weight:  0.8,
+		Weather: []environment.WeatherType{environment.WeatherClear},
+		Trait:   func(t *ai.Traits) float64 { return 1 - t.EnergyLevel },
+		Vitals:  func(v *biology.VitalStats) { v.Stress -= 0.05; v.Fatigue -= 0.03 },
+		Feeling: ai.EmotionalStimulus{ContentmentDelta: 0.2},
+		Valence: 0.5,
+		Text:    "%s found a warm sunny spot and stretched out for a nap.",
+	},
+	SurpriseStrangeScent: {
+		Weight:  0.8,
+		Biomes:  []environment.Biome{environment.BiomeTemperate, environment.BiomeSwamp, environment.BiomeMountain, environment.BiomeArctic},
+		Trait:   func(t *ai.Traits) float64 { return t.Curiosity },
+		Vitals:  func(v *biology.VitalStats) { v.Happiness += 0.02; v.Energy -= 0.02 },
+		Feeling: ai.EmotionalStimulus{ExcitementDelta: 0.1},
+		Valence: 0.3,
+		Text:    "%s caught a strange scent and spent ages sniffing it out.",
+	},
+	SurpriseSeashell: {
+		Weight:  1.5,
+		Biomes:  []environment.Biome{environment.BiomeOcean, environment.BiomeTropical},
+		Trait:   func(t *ai.Traits) float64 { return t.Curiosity },
+		Vitals:  func(v *biology.VitalStats) { v.Happiness += 0.05 },
+		Feeling: ai.EmotionalStimulus{JoyDelta: 0.15},
+		Valence: 0.7,
+		Text:    "%s dug up a seashell and can hear the sea in it.",
+	},
+	SurpriseCarHorn: {
+		Weight:  1.5,
+		Biomes:  []environment.Biome{environment.BiomeUrban},
+		Trait:   func(t *ai.Traits) float64 { return t.Neuroticism },
+		Vitals:  func(v *biology.VitalStats) { v.Stress += 0.08 },
+		Feeling: ai.EmotionalStimulus{FearDelta: 0.2, AngerDelta: 0.05},
+		Valence: -0.4,
+		Text:    "A blaring car horn startled %s.",
+	},
+	SurpriseStartledBird: {
+		Weight:  0.6,
+		Biomes:  []environment.Biome{environment.BiomeTemperate, environment.BiomeMountain, environment.BiomeDesert},
+		Trait:   func(t *ai.Traits) float64 { return t.Territoriality },
+		Vitals:  func(v *biology.VitalStats) { v.Stress += 0.03; v.Happiness += 0.02 },
+		Feeling: ai.EmotionalStimulus{ExcitementDelta: 0.15, FearDelta: 0.05},
+		Valence: 0.1,
+		Text:    "A bird burst out of a bush right next to %s, and %[1]s gave chase.",
+	},
+}
+
+// Surprise is a surprise that happened to a pet
+type Surprise struct {
+	PetID types.PetID
+	Kind  SurpriseKind
+	Text  string // Player-facing description
+}
+
+// RandomEvents gives pets occasional small surprises so that day-to-day
+// play is less predictable. Which surprises are possible depends on the
+// biome a pet is in and the weather; a pet's personality makes some
+// likelier than others.
+type RandomEvents struct {
+	Rate float64 // Surprises per pet per game day
+
+	rng *rand.Rand
+}
+
+// NewRandomEvents creates random events seeded from the current time
+func NewRandomEvents() *RandomEvents {
+	return NewSeededRandomEvents(time.Now().UnixNano())
+}
+
+// NewSeededRandomEvents creates deterministic random events for testing
+func NewSeededRandomEvents(seed int64) *RandomEvents {
+	return &RandomEvents{
+		Rate: DefaultSurpriseRate,
+		rng:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+// EligibleSurprises returns the surprises possible in a biome and weather
+// with their relative weights for a pet. A nil biome or weather rules out
+// surprises limited to particular ones.
+func EligibleSurprises(pet *core.DigitalPet, biome *environment.Biome, weather *environment.WeatherType) map[SurpriseKind]float64 {
+	weights := make(map[SurpriseKind]float64)
+	for kind, spec := range surpriseSpecs {
+		if len(spec.Biomes) > 0 && (biome == nil || !containsBiome(spec.Biomes, *biome)) {
+			continue
+		}
+		if len(spec.Weather) > 0 && (weather == nil || !containsWeather(spec.Weather, *weather)) {
+			continue
+		}
+		weight := spec.Weight
+		if pet.Personality != nil && spec.Trait != nil {
+			// A trait of 0.5 leaves the weight as it is; 1.0 doubles it
+			weight *= 2 * spec.Trait(pet.Personality.Traits)
+		}
+		if weight > 0 {
+			weights[kind] = weight
+		}
+	}
+	return weights
+}
+
+// Roll gives a pet a chance of a surprise over deltaTime game days and
+// picks one from the eligible surprises. Returns false if nothing happened.
+func (re *RandomEvents) Roll(pet *core.DigitalPet, biome *environment.Biome, weather *environment.WeatherType, deltaTime float64) (SurpriseKind, bool) {
+	if re.rng.Float64() >= 1-math.Exp(-re.Rate*deltaTime) {
+		return 0, false
+	}
+	weights := EligibleSurprises(pet, biome, weather)
+	kinds := make([]SurpriseKind, 0, len(weights))
+	total := 0.0
+	for kind, weight := range weights {
+		kinds = append(kinds, kind)
+		total += weight
+	}
+	if total == 0 {
+		return 0, false
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+
+	pick := re.rng.Float64() * total
+	for _, kind := range kinds {
+		pick -= weights[kind]
+		if pick < 0 {
+			return kind, true
+		}
+	}
+	return kinds[len(kinds)-1], true
+}
+
+// ApplySurprise lets a surprise happen to a pet: its vitals and emotions
+// change a little and it remembers the moment
+func ApplySurprise(pet *core.DigitalPet, kind SurpriseKind) Surprise {
+	spec := surpriseSpecs[kind]
+	if spec.Vitals != nil {
+		spec.Vitals(pet.Biology.Vitals)
+		pet.Biology.Vitals.Clamp()
+	}
+	feeling := spec.Feeling
+	feeling.Source = TagSurprise
+	pet.Emotions.ApplyEmotionalStimulus(feeling)
+
+	text := fmt.Sprintf(spec.Text, pet.Name)
+	pet.Memory.Remember(&ai.Memory{
+		Type:        ai.MemoryEvent,
+		Description: text,
+		GameTime:    pet.GetAge(),
+		Strength:    0.3 + 0.3*math.Abs(spec.Valence),
+		Valence:     spec.Valence,
+		Emotion:     pet.Emotions.DominantEmotion,
+		Tags:        []string{TagSurprise},
+	})
+	return Surprise{PetID: pet.ID, Kind: kind, Text: text}
+}
+
+// updateSurprises rolls for a surprise for each awake pet and posts any
+// that happen to the inbox (must be called with lock held)
+func (h *Household) updateSurprises(deltaTime float64) {
+	var home environment.WeatherEffects
+	if h.Weather != nil {
+		home = h.Weather.Effects()
+	}
+
+	ids := make([]types.PetID, 0, len(h.Pets))
+	for id := range h.Pets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		pet := h.Pets[id]
+		if !pet.IsAlive() || pet.Biology.Hibernation.IsDormant() {
+			continue
+		}
+		var weather *environment.WeatherType
+		if h.Weather != nil {
+			local := h.weatherAt(pet, home).Weather
+			weather = &local
+		}
+		kind, ok := h.Surprises.Roll(pet, h.biomeOf(pet), weather, deltaTime)
+		if !ok {
+			continue
+		}
+		surprise := ApplySurprise(pet, kind)
+		h.Inbox.Post(MessageSurprise, id, fmt.Sprintf("%s: %s", pet.Name, kind), surprise.Text)
+	}
+}
+
+// biomeOf returns the biome of the location a pet is in, or nil if the
+// household has no world (must be called with lock held)
+func (h *Household) biomeOf(pet *core.DigitalPet) *environment.Biome {
+	if h.Environment == nil || h.Environment.World == nil {
+		return nil
+	}
+	location, exists := h.Environment.World.Location(pet.Location)
+	if !exists {
+		return nil
+	}
+	return &location.Biome
+}
+
+// containsBiome returns true if a biome is in a list
+func containsBiome(biomes []environment.Biome, biome environment.Biome) bool {
+	for _, b := range biomes {
+		if b == biome {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWeather returns true if a weather type is in a list
+func containsWeather(weather []environment.WeatherType, wt environment.WeatherType) bool {
+	for _, w := range weather {
+		if w == wt {
+			return true
+		}
+	}
+	return false
+}
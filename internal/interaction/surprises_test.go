@@ -0,0 +1,110 @@
+package interaction
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestEligibleSurprisesDependOnPlaceAndWeather(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+
+	anywhere := EligibleSurprises(pet, nil, nil)
+	if _, ok := anywhere[SurpriseShinyPebble]; !ok {
+		t.Error("A shiny pebble can turn up anywhere")
+	}
+	if _, ok := anywhere[SurpriseCarHorn]; ok {
+		t.Error("Car horns need a known urban location")
+	}
+
+	urban, storm := environment.BiomeUrban, environment.WeatherStorm
+	eligible := EligibleSurprises(pet, &urban, &storm)
+	for _, kind := range []SurpriseKind{SurpriseCarHorn, SurpriseThunderclap} {
+		if _, ok := eligible[kind]; !ok {
+			t.Errorf("Expected %s in a storm in town", kind)
+		}
+	}
+	for _, kind := range []SurpriseKind{SurpriseButterfly, SurpriseSeashell, SurpriseSnowflakes} {
+		if _, ok := eligible[kind]; ok {
+			t.Errorf("Did not expect %s in a storm in town", kind)
+		}
+	}
+}
+
+func TestPersonalityWeightsSurprises(t *testing.T) {
+	calm := core.NewDigitalPet("Calm", "user123")
+	calm.Personality.Traits.Neuroticism = 0.1
+	nervous := core.NewDigitalPet("Nervous", "user123")
+	nervous.Personality.Traits.Neuroticism = 0.9
+
+	calmWeight := EligibleSurprises(calm, nil, nil)[SurpriseLoudNoise]
+	nervousWeight := EligibleSurprises(nervous, nil, nil)[SurpriseLoudNoise]
+	if nervousWeight <= calmWeight {
+		t.Errorf("Loud noises should startle a nervous pet more often: %.2f vs %.2f", nervousWeight, calmWeight)
+	}
+}
+
+func TestApplySurprise(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Biology.Vitals.Happiness = 0.5
+	stressBefore := pet.Biology.Vitals.Stress
+
+	surprise := ApplySurprise(pet, SurpriseShinyPebble)
+	if surprise.Text != "Rex found a shiny pebble and proudly shows it off." {
+		t.Errorf("Unexpected text %q", surprise.Text)
+	}
+	if pet.Biology.Vitals.Happiness <= 0.5 {
+		t.Error("A shiny pebble should cheer the pet up")
+	}
+	if memories := pet.Memory.ByTag(TagSurprise); len(memories) != 1 || memories[0].Valence <= 0 {
+		t.Errorf("Expected one pleasant surprise memory, got %+v", memories)
+	}
+
+	ApplySurprise(pet, SurpriseLoudNoise)
+	if pet.Biology.Vitals.Stress <= stressBefore {
+		t.Error("A loud noise should stress the pet")
+	}
+}
+
+func TestSurprisesPostedToInbox(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	h.Environment = environment.NewSeededManager(environment.DefaultWorldMap(), 1)
+	h.Surprises = NewSeededRandomEvents(1)
+
+	for i := 0; i < 96; i++ {
+		h.Update(1.0 / 24)
+	}
+
+	surprises := 0
+	for _, msg := range h.Inbox.List() {
+		if msg.Category == MessageSurprise {
+			surprises++
+			if msg.PetID != pet.ID {
+				t.Errorf("Surprise for the wrong pet: %+v", msg)
+			}
+		}
+	}
+	if surprises == 0 {
+		t.Fatal("Expected surprises over four days")
+	}
+	if got := len(pet.Memory.ByTag(TagSurprise)); got != surprises {
+		t.Errorf("Expected %d surprise memories, got %d", surprises, got)
+	}
+}
+
+func TestNoSurprisesWhileHibernating(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Biology.Hibernation.Enter()
+	h := NewHousehold(pet)
+	h.Surprises = NewSeededRandomEvents(1)
+	h.Surprises.Rate = 100
+
+	h.Update(1.0)
+	for _, msg := range h.Inbox.List() {
+		if msg.Category == MessageSurprise {
+			t.Fatalf("A hibernating pet should not be surprised: %+v", msg)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package interaction
+
+import (
+	"fmt"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// TagPet adds tags to a present pet. Tags are checked before any is
+// added, so a bad tag leaves the pet as it was.
+func (h *Household) TagPet(petID types.PetID, tags ...string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, present := h.Pets[petID]
+	if !present {
+		return fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	for _, tag := range tags {
+		if _, err := core.NormalizeTag(tag); err != nil {
+			return err
+		}
+	}
+	for _, tag := range tags {
+		if err := pet.AddTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UntagPet takes tags off a present pet and returns those it carried
+func (h *Household) UntagPet(petID types.PetID, tags ...string) ([]string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, present := h.Pets[petID]
+	if !present {
+		return nil, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	var removed []string
+	for _, tag := range tags {
+		if pet.RemoveTag(tag) {
+			removed = append(removed, tag)
+		}
+	}
+	return removed, nil
+}
+
+// FindPets returns the present pets a search matches, sorted by name
+func (h *Household) FindPets(s core.Search) []*core.DigitalPet {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pets := make([]*core.DigitalPet, 0, len(h.Pets))
+	for _, pet := range h.Pets {
+		pets = append(pets, pet)
+	}
+	return core.SearchPets(pets, s)
+}
@@ -0,0 +1,41 @@
+package interaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestTagAndSearchHousehold(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "user123")
+	bo := core.NewDigitalPet("Bo", "user123")
+	h := NewHousehold(rex, bo)
+
+	if err := h.TagPet(rex.ID, "favorite", "bad tag"); !errors.Is(err, core.ErrInvalidTag) || len(rex.Tags) != 0 {
+		t.Errorf("Expected ErrInvalidTag with no tags added, got %v and %v", err, rex.Tags)
+	}
+	if err := h.TagPet(rex.ID, "favorite", "breeder"); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.TagPet(bo.ID, "favorite"); err != nil {
+		t.Fatal(err)
+	}
+
+	s, _ := core.ParseSearch("tag:breeder")
+	if found := h.FindPets(s); len(found) != 1 || found[0] != rex {
+		t.Errorf("Expected only Rex to be a breeder, got %v", found)
+	}
+	s, _ = core.ParseSearch("tag:favorite")
+	if found := h.FindPets(s); len(found) != 2 || found[0] != bo {
+		t.Errorf("Expected Bo and Rex as favorites, got %v", found)
+	}
+
+	removed, err := h.UntagPet(rex.ID, "breeder", "retired")
+	if err != nil || len(removed) != 1 || removed[0] != "breeder" {
+		t.Errorf("Expected only breeder removed, got %v (%v)", removed, err)
+	}
+	if err := h.TagPet("missing", "favorite"); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package interaction
+
+import (
+	"fmt"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/social"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Territory settings
+const (
+	TerritoryMarkRate = 0.2  // Claim gained per game day by a fully territorial pet
+	TerritoryComfort  = 0.05 // Stress relieved per game day in a pet's own territory
+	TrespassStress    = 0.03 // Stress gained per game day in another pet's territory
+	FamiliarBond      = 0.5  // Bond strength at which pets tolerate each other's territory
+)
+
+// TerritorialConflict describes a confrontation between a territory's
+// owner and a pet entering it
+type TerritorialConflict struct {
+	Owner     types.PetID
+	Intruder  types.PetID
+	Location  string
+	Intensity float64 // Strength of the confrontation (0.0 to 1.0)
+}
+
+// familiarity returns how well a pet knows another (0.0 strangers to 1.0)
+func familiarity(pet *core.DigitalPet, otherID types.PetID) float64 {
+	if rel, exists := pet.Relationships.GetRelationship(otherID); exists {
+		return clamp(rel.BondStrength/FamiliarBond, 0.0, 1.0)
+	}
+	return 0.0
+}
+
+// MovePet moves a present pet to another location. A territorial owner
+// already there confronts the newcomer unless they know each other well.
+func (h *Household) MovePet(petID types.PetID, location string) ([]TerritorialConflict, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	intruder, present := h.Pets[petID]
+	if !present {
+		return nil, fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	if intruder.Location == location {
+		return nil, nil
+	}
+	intruder.Location = location
+
+	ownerID, claim, claimed := h.Territory.Owner(location)
+	owner, ownerPresent := h.Pets[ownerID]
+	if !claimed || ownerID == petID || !ownerPresent || !owner.IsAlive() || owner.Location != location {
+		return nil, nil
+	}
+
+	intensity := owner.Personality.Traits.Territoriality * claim * (1.0 - familiarity(owner, petID))
+	if intensity <= 0.05 {
+		return nil, nil
+	}
+
+	h.applyTerritorialConflict(owner, intruder, location, intensity)
+	return []TerritorialConflict{{
+		Owner:     ownerID,
+		Intruder:  petID,
+		Location:  location,
+		Intensity: intensity,
+	}}, nil
+}
+
+// applyTerritorialConflict lets an owner drive off an intruder. Defending
+// home ground gives the owner the upper hand (must be called with lock held).
+func (h *Household) applyTerritorialConflict(owner, intruder *core.DigitalPet, location string, intensity float64) {
+	owner.Emotions.ApplyEmotionalStimulus(ai.EmotionalStimulus{
+		AngerDelta: 0.3 * intensity,
+		Source:     "territory",
+	})
+	intruder.Emotions.ApplyEmotionalStimulus(ai.EmotionalStimulus{
+		FearDelta: 0.3 * intensity,
+		Source:    "territory",
+	})
+	owner.Biology.Vitals.Stress += 0.05 * intensity
+	intruder.Biology.Vitals.Stress += 0.15 * intensity
+	owner.Biology.Vitals.Clamp()
+	intruder.Biology.Vitals.Clamp()
+
+	h.Hierarchy.RecordContest(owner.ID, intruder.ID, social.ContestConflict)
+
+	description := fmt.Sprintf("%s chased %s out of the %s", owner.Name, intruder.Name, location)
+	h.logExperience(owner.ID, intruder.ID, description, -intensity)
+	h.logExperience(intruder.ID, owner.ID, description, -intensity)
+}
+
+// updateTerritory lets each pet mark the location it is in and reacts to
+// whose territory it is (must be called with lock held)
+func (h *Household) updateTerritory(deltaTime float64) {
+	h.Territory.Decay(deltaTime)
+
+	for id, pet := range h.Pets {
+		if !pet.IsAlive() {
+			continue
+		}
+		h.Territory.Mark(id, pet.Location, TerritoryMarkRate*pet.Personality.Traits.Territoriality*deltaTime)
+	}
+
+	for id, pet := range h.Pets {
+		if !pet.IsAlive() {
+			continue
+		}
+
+		ownerID, claim, claimed := h.Territory.Owner(pet.Location)
+		if !claimed {
+			continue
+		}
+
+		vitals := pet.Biology.Vitals
+		if ownerID == id {
+			// Home ground is reassuring
+			vitals.Stress -= TerritoryComfort * claim * deltaTime
+		} else {
+			vitals.Stress += TrespassStress * claim * (1.0 - familiarity(pet, ownerID)) * deltaTime
+		}
+		vitals.Clamp()
+	}
+}
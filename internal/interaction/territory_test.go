@@ -0,0 +1,82 @@
+package interaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestPetsClaimHomeRange(t *testing.T) {
+	owner := core.NewDigitalPet("Owner", "user123")
+	owner.Personality.Traits.Territoriality = 1.0
+	h := NewHousehold(owner)
+
+	h.Update(2.0)
+
+	if got := h.Territory.HomeRange(owner.ID); len(got) != 1 || got[0] != owner.Location {
+		t.Errorf("Expected home range [%s], got %v", owner.Location, got)
+	}
+}
+
+func TestTerritorialConflict(t *testing.T) {
+	owner := core.NewDigitalPet("Owner", "user123")
+	owner.Personality.Traits.Territoriality = 1.0
+	intruder := core.NewDigitalPet("Stranger", "user123")
+	intruder.Location = "meadow"
+	h := NewHousehold(owner, intruder)
+	h.Territory.Mark(owner.ID, owner.Location, 1.0)
+	fearBefore := intruder.Emotions.Fear
+
+	conflicts, err := h.MovePet(intruder.ID, owner.Location)
+	if err != nil {
+		t.Fatalf("MovePet failed: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Owner != owner.ID {
+		t.Fatalf("Expected owner to confront the stranger, got %v", conflicts)
+	}
+	if intruder.Emotions.Fear <= fearBefore {
+		t.Error("Intruder should be frightened off")
+	}
+}
+
+func TestFamiliarPetsShareTerritory(t *testing.T) {
+	owner := core.NewDigitalPet("Owner", "user123")
+	owner.Personality.Traits.Territoriality = 1.0
+	friend := core.NewDigitalPet("Friend", "user123")
+	friend.Location = "meadow"
+	owner.Relationships.AddRelationship(friend.ID, types.RelationshipFriend)
+	rel, _ := owner.Relationships.GetRelationship(friend.ID)
+	rel.BondStrength = 1.0
+
+	h := NewHousehold(owner, friend)
+	h.Territory.Mark(owner.ID, owner.Location, 1.0)
+
+	conflicts, _ := h.MovePet(friend.ID, owner.Location)
+	if len(conflicts) != 0 {
+		t.Errorf("Close friends should not fight over territory, got %v", conflicts)
+	}
+
+	if _, err := h.MovePet("ghost", "meadow"); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+}
+
+func TestTrespassingIsStressful(t *testing.T) {
+	owner := core.NewDigitalPet("Owner", "user123")
+	visitor := core.NewDigitalPet("Visitor", "user123")
+	h := NewHousehold(owner, visitor)
+	h.Territory.Mark(owner.ID, owner.Location, 1.0)
+	owner.Biology.Vitals.Stress = 0.5
+	visitor.Biology.Vitals.Stress = 0.5
+
+	h.updateTerritory(1.0)
+
+	if owner.Biology.Vitals.Stress >= 0.5 {
+		t.Error("Owner should relax in its own territory")
+	}
+	if visitor.Biology.Vitals.Stress <= 0.5 {
+		t.Error("Visitor should be uneasy in another pet's territory")
+	}
+}
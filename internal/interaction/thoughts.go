@@ -0,0 +1,167 @@
+package interaction
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Thought settings
+const (
+	ThoughtTraitThreshold = 0.75 // How strong a trait must be to flavor a thought
+	ThoughtHistorySize    = 6    // Number of recent phrases avoided
+)
+
+// Thought is a short first-person thought a pet is having
+type Thought struct {
+	PetID  types.PetID
+	Source string // Need, emotion or "hibernating" behind the thought
+	Text   string
+}
+
+// needThoughts are thoughts about a pet's most urgent need, keyed by the
+// cue source the need is signalled with
+var needThoughts = map[string][]string{
+	"hunger":      {"I hope the big one brings the crunchy food again.", "Is it dinner time yet? It feels like dinner time.", "My bowl is looking very empty."},
+	"thirst":      {"A big bowl of cool water would be perfect right now.", "My mouth is all dry."},
+	"sleep":       {"My eyes keep closing by themselves.", "Just a little nap. A long little nap."},
+	"cleanliness": {"Something smells funny. Oh, it's me.", "My fur feels all sticky."},
+	"health":      {"I don't feel quite right today.", "Everything aches a bit."},
+	"loneliness":  {"Where did everyone go?", "It's too quiet without the big one around."},
+	"jealousy":    {"Why do the others get all the attention?", "I was here first, you know."},
+	"play":        {"Throw it! Throw it! Please throw it!", "I bet I could catch anything today."},
+	"fear":        {"What was that noise? I'd better stay hidden.", "I don't like this one bit."},
+}
+
+// emotionThoughts are thoughts about a pet's dominant emotion when no
+// need is pressing, keyed by emotion label
+var emotionThoughts = map[string][]string{
+	"joyful":       {"Today is the best day ever!", "Everything is wonderful."},
+	"sad":          {"I feel a bit blue today.", "Maybe a cuddle would help."},
+	"angry":        {"Hmph. I'm not in the mood.", "Nobody touch my things."},
+	"fearful":      {"I'll just keep an eye on things from here.", "Is it safe to come out yet?"},
+	"excited":      {"Something fun is going to happen, I can feel it!", "I can't sit still!"},
+	"content":      {"This is nice. Just this.", "Warm, fed and happy."},
+	"affectionate": {"The big one is my favourite.", "I could stay close like this all day."},
+	"lonely":       {"I wish someone would come and play.", "Is anyone there?"},
+	"jealous":      {"I saw that. I saw all of that.", "My turn next, surely."},
+}
+
+// traitThought flavors a thought with a strong personality trait
+type traitThought struct {
+	trait   func(t *ai.Traits) float64
+	phrases []string
+}
+
+// traitThoughts lists the traits that flavor thoughts; on a tie the
+// earlier trait wins
+var traitThoughts = []traitThought{
+	{func(t *ai.Traits) float64 { return t.Playfulness }, []string{"Then we can play chase!", "Maybe there's a ball somewhere."}},
+	{func(t *ai.Traits) float64 { return t.Curiosity }, []string{"I wonder what's behind the shed.", "There's still so much to sniff."}},
+	{func(t *ai.Traits) float64 { return t.Affectionate }, []string{"I'd like a scratch behind the ears too.", "A cuddle would make it even better."}},
+	{func(t *ai.Traits) float64 { return t.Loyalty }, []string{"As long as the big one is here, it's fine.", "I'll wait by the door."}},
+	{func(t *ai.Traits) float64 { return t.Independence }, []string{"Not that I need anyone, of course.", "I can manage on my own."}},
+	{func(t *ai.Traits) float64 { return t.Neuroticism }, []string{"I hope nothing goes wrong.", "What if the bowl never fills again?"}},
+	{func(t *ai.Traits) float64 { return t.Territoriality }, []string{"This spot is mine, by the way.", "Nobody else had better be in my bed."}},
+}
+
+// hibernationThoughts are the dreams of a hibernating pet
+var hibernationThoughts = []string{"Zzz... dreaming of spring...", "Zzz... warm sunny days..."}
+
+// ThoughtGenerator composes pet thoughts from phrase banks, avoiding
+// recently used phrases so the same thought does not repeat
+type ThoughtGenerator struct {
+	rng    *rand.Rand
+	recent []string
+}
+
+// NewThoughtGenerator creates a generator seeded from the current time
+func NewThoughtGenerator() *ThoughtGenerator {
+	return NewSeededThoughtGenerator(time.Now().UnixNano())
+}
+
+// NewSeededThoughtGenerator creates a deterministic generator for testing
+func NewSeededThoughtGenerator(seed int64) *ThoughtGenerator {
+	return &ThoughtGenerator{
+		rng: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Think returns what a pet is thinking about: its most urgent need, or
+// its dominant emotion if nothing is pressing, flavored by its strongest
+// personality trait. Returns false for a pet that has died.
+func (tg *ThoughtGenerator) Think(pet *core.DigitalPet) (Thought, bool) {
+	if !pet.IsAlive() {
+		return Thought{}, false
+	}
+	if pet.Biology.Hibernation.IsDormant() {
+		return Thought{PetID: pet.ID, Source: "hibernating", Text: tg.pick(hibernationThoughts)}, true
+	}
+
+	source := pet.Emotions.DominantEmotion
+	pool, ok := emotionThoughts[source]
+	if cues := Vocalize(pet); len(cues) > 0 {
+		if needs, exists := needThoughts[cues[0].Source]; exists {
+			source, pool, ok = cues[0].Source, needs, true
+		}
+	}
+	if !ok {
+		source, pool = "content", emotionThoughts["content"]
+	}
+
+	text := tg.pick(pool)
+	if flavor := strongestTrait(pet); flavor != nil {
+		text += " " + tg.pick(flavor.phrases)
+	}
+	return Thought{PetID: pet.ID, Source: source, Text: text}, true
+}
+
+// strongestTrait returns the pet's strongest flavoring trait above
+// ThoughtTraitThreshold, or nil
+func strongestTrait(pet *core.DigitalPet) *traitThought {
+	if pet.Personality == nil || pet.Personality.Traits == nil {
+		return nil
+	}
+	var strongest *traitThought
+	best := 0.0
+	for i := range traitThoughts {
+		value := traitThoughts[i].trait(pet.Personality.Traits)
+		if value >= ThoughtTraitThreshold && (strongest == nil || value > best) {
+			strongest, best = &traitThoughts[i], value
+		}
+	}
+	return strongest
+}
+
+// pick chooses a phrase, avoiding recently used ones where possible
+func (tg *ThoughtGenerator) pick(pool []string) string {
+	fresh := make([]string, 0, len(pool))
+	for _, phrase := range pool {
+		if !containsString(tg.recent, phrase) {
+			fresh = append(fresh, phrase)
+		}
+	}
+	if len(fresh) == 0 {
+		fresh = pool
+	}
+
+	choice := fresh[tg.rng.Intn(len(fresh))]
+	tg.recent = append(tg.recent, choice)
+	if len(tg.recent) > ThoughtHistorySize {
+		tg.recent = tg.recent[1:]
+	}
+	return choice
+}
+
+// containsString returns true if a string is in a list
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package interaction
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// plainPet returns a pet with a balanced personality, so thoughts are not
+// flavored
+func plainPet() *core.DigitalPet {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Personality.Traits = ai.NewBalancedTraits()
+	return pet
+}
+
+func TestThinkAboutUrgentNeed(t *testing.T) {
+	tg := NewSeededThoughtGenerator(1)
+	pet := plainPet()
+	pet.Biology.Vitals.Nutrition = 0.0
+
+	thought, ok := tg.Think(pet)
+	if !ok || thought.Source != "hunger" {
+		t.Fatalf("Expected a hungry thought, got %+v", thought)
+	}
+	if !containsString(needThoughts["hunger"], thought.Text) {
+		t.Errorf("Expected a phrase from the hunger bank, got %q", thought.Text)
+	}
+}
+
+func TestThinkAboutDominantEmotion(t *testing.T) {
+	tg := NewSeededThoughtGenerator(1)
+	pet := plainPet()
+	pet.Emotions.Excitement = 0.0
+	for _, cue := range Vocalize(pet) {
+		t.Fatalf("Expected a pet with no pressing needs, got cue %+v", cue)
+	}
+
+	thought, ok := tg.Think(pet)
+	if !ok || thought.Source != pet.Emotions.DominantEmotion {
+		t.Errorf("Expected a thought about feeling %s, got %+v", pet.Emotions.DominantEmotion, thought)
+	}
+}
+
+func TestThoughtFlavoredByPersonality(t *testing.T) {
+	tg := NewSeededThoughtGenerator(1)
+	pet := plainPet()
+	pet.Personality.Traits.Playfulness = 0.95
+
+	thought, _ := tg.Think(pet)
+	flavored := false
+	for _, phrase := range traitThoughts[0].phrases {
+		flavored = flavored || strings.HasSuffix(thought.Text, " "+phrase)
+	}
+	if !flavored {
+		t.Errorf("Expected a playful flavor, got %q", thought.Text)
+	}
+
+	pet.Personality.Traits.Playfulness = 0.5
+	if thought, _ := tg.Think(pet); strings.Contains(thought.Text, "play chase") || strings.Contains(thought.Text, "a ball") {
+		t.Errorf("A balanced pet should not get a playful flavor: %q", thought.Text)
+	}
+}
+
+func TestThoughtsAvoidRepeats(t *testing.T) {
+	tg := NewSeededThoughtGenerator(1)
+	pet := plainPet()
+	pet.Biology.Vitals.Nutrition = 0.0
+
+	first, _ := tg.Think(pet)
+	second, _ := tg.Think(pet)
+	if first.Text == second.Text {
+		t.Errorf("Expected a fresh thought, got %q twice", first.Text)
+	}
+}
+
+func TestHibernatingAndDeadPetsThoughts(t *testing.T) {
+	tg := NewSeededThoughtGenerator(1)
+	pet := plainPet()
+	pet.Biology.Hibernation.Enter()
+	if thought, ok := tg.Think(pet); !ok || thought.Source != "hibernating" {
+		t.Errorf("Expected a hibernating pet to dream, got %+v", thought)
+	}
+
+	pet.Biology.IsAlive = false
+	if _, ok := tg.Think(pet); ok {
+		t.Error("A pet that has died should not think")
+	}
+}
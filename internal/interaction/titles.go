@@ -0,0 +1,32 @@
+package interaction
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ChooseTitle sets which earned title a present pet goes by; an empty
+// title shows none
+func (h *Household) ChooseTitle(petID types.PetID, title string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	pet, present := h.Pets[petID]
+	if !present {
+		return fmt.Errorf("%w: %s", ErrPetNotPresent, petID)
+	}
+	return pet.SetTitle(title)
+}
+
+// updateTitles awards epithets pets have earned and posts a message for
+// each (must be called with lock held)
+func (h *Household) updateTitles() {
+	for _, pet := range h.Pets {
+		for _, epithet := range pet.AwardEpithets() {
+			h.Inbox.Post(MessageCelebration, pet.ID, fmt.Sprintf("%s is now known as %s %s", pet.Name, pet.Name, epithet),
+				fmt.Sprintf("%s earned the title %q for %s. Use `title %s %s` to show it.",
+					pet.Name, epithet, epithet.Reason(), strings.ToLower(pet.Name), epithet))
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package interaction
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestUpdateAwardsTitles(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	h := NewHousehold(pet)
+	pet.Renown = &core.Renown{SpecialMeals: core.GourmandSpecialMeals}
+
+	h.Update(0.01)
+	msgs := h.Inbox.List()
+	if len(msgs) == 0 || msgs[0].Category != MessageCelebration || !strings.Contains(msgs[0].Subject, "Rex the Gourmand") {
+		t.Fatalf("Expected a title celebration, got %+v", msgs)
+	}
+	h.Update(0.01)
+	if len(h.Inbox.List()) != len(msgs) {
+		t.Error("A title should only be announced once")
+	}
+
+	if err := h.ChooseTitle(pet.ID, "the gourmand"); err != nil {
+		t.Fatal(err)
+	}
+	if pet.DisplayName() != "Rex the Gourmand" {
+		t.Errorf("Expected the title shown, got %q", pet.DisplayName())
+	}
+	if err := h.ChooseTitle(pet.ID, "the Brave"); !errors.Is(err, core.ErrUnknownTitle) {
+		t.Errorf("Expected ErrUnknownTitle, got %v", err)
+	}
+	if err := h.ChooseTitle("nobody", ""); !errors.Is(err, ErrPetNotPresent) {
+		t.Errorf("Expected ErrPetNotPresent, got %v", err)
+	}
+}
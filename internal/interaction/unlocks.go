@@ -0,0 +1,281 @@
+package interaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Unlock state settings
+const (
+	UnlocksDirName  = "progress"     // Directory under a profile's save path holding its unlock state
+	UnlocksFileName = "unlocks.json" // The unlock state, inside UnlocksDirName
+	SkilledLevel    = 0.5            // Skill level that reaches GoalSkilled
+)
+
+// ErrFeatureLocked is returned when a feature has not been unlocked yet
+var ErrFeatureLocked = errors.New("not unlocked yet")
+
+// Feature is a group of advanced systems unlocked as the player progresses
+type Feature int
+
+const (
+	FeatureExploration Feature = iota // Travel, fishing, photos and rescues
+	FeatureEconomy                    // The garden, shops, marketplace, insurance and exams
+	FeatureBreeding                   // Breeding, litters and rehoming
+)
+
+// String returns the string representation of Feature
+func (f Feature) String() string {
+	return [...]string{"exploration", "economy", "breeding"}[f]
+}
+
+// AllFeatures returns every feature in the order they unlock
+func AllFeatures() []Feature {
+	return []Feature{FeatureExploration, FeatureEconomy, FeatureBreeding}
+}
+
+// Goal is a point in a pet's progress that unlocks a feature
+type Goal int
+
+const (
+	GoalJuvenile Goal = iota // A pet reaches the juvenile stage
+	GoalSkilled              // A pet's first skill reaches SkilledLevel
+	GoalAdult                // A pet reaches the adult stage
+)
+
+// String returns the string representation of Goal
+func (g Goal) String() string {
+	return [...]string{"juvenile", "skilled", "adult"}[g]
+}
+
+// reached returns true if a pet has reached the goal
+func (g Goal) reached(pet *core.DigitalPet) bool {
+	switch g {
+	case GoalJuvenile:
+		return pet.Biology.GetLifeStage() >= types.LifeStageJuvenile
+	case GoalSkilled:
+		for _, level := range pet.Skills.Levels {
+			if level >= SkilledLevel {
+				return true
+			}
+		}
+	case GoalAdult:
+		return pet.Biology.GetLifeStage() >= types.LifeStageAdult
+	}
+	return false
+}
+
+// TutorialStep is one step of the tutorial: a goal to reach and the
+// feature it unlocks, with what to tell the player on the way
+type TutorialStep struct {
+	Goal    Goal
+	Feature Feature
+	Hint    string // How to reach the goal
+	Title   string // Subject of the message sent when it is reached
+	Text    string // What the feature offers and how to start
+}
+
+// Tutorial is every step, in the order players usually reach them
+var Tutorial = []TutorialStep{
+	{
+		Goal:    GoalJuvenile,
+		Feature: FeatureExploration,
+		Hint:    "Raise a pet to the juvenile stage (3 days old).",
+		Title:   "Exploration unlocked",
+		Text: "Your pet is old enough to see the world. Use `map` to send it travelling, `activities` and `fish` " +
+			"to see what a place offers, `photo` to fill your album and `rescue` if a pet ever runs off.",
+	},
+	{
+		Goal:    GoalSkilled,
+		Feature: FeatureEconomy,
+		Hint:    "Train any skill to 0.5 (play, train and reward your pet).",
+		Title:   "Economy unlocked",
+		Text: "A skilled pet earns its keep. Grow food with `garden`, dress up with `wardrobe`, book `exam`s, " +
+			"trade on the `market`, `adopt` more pets and protect them with `insure` and `fund`.",
+	},
+	{
+		Goal:    GoalAdult,
+		Feature: FeatureBreeding,
+		Hint:    "Raise a pet to the adult stage (10 days old).",
+		Title:   "Breeding unlocked",
+		Text: "Your pet is grown. Pair two adults with `breed`, name the pups with `litter` and find homes for " +
+			"them with `rehome`. Check `genome` to see what they may inherit.",
+	},
+}
+
+// unlockState is the unlock state kept between runs
+type unlockState struct {
+	Reached map[string]time.Time `json:"reached"`           // Goal names and when they were reached
+	Skipped bool                 `json:"skipped,omitempty"` // The player unlocked everything at once
+}
+
+// Unlocks tracks the goals a profile's pets have reached and the features they unlock
+type Unlocks struct {
+	Path string // Where the state is kept; empty keeps it in memory only
+
+	mu    sync.Mutex
+	state unlockState
+}
+
+// NewUnlocks creates unlock tracking with nothing unlocked, kept at path
+func NewUnlocks(path string) *Unlocks {
+	return &Unlocks{Path: path, state: unlockState{Reached: make(map[string]time.Time)}}
+}
+
+// LoadUnlocks reads the unlock state at path, starting afresh if there
+// is none
+func LoadUnlocks(path string) (*Unlocks, error) {
+	u := NewUnlocks(path)
+	payload, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return u, nil
+	case err != nil:
+		return nil, err
+	}
+	if err := json.Unmarshal(payload, &u.state); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if u.state.Reached == nil {
+		u.state.Reached = make(map[string]time.Time)
+	}
+	return u, nil
+}
+
+// Unlocked returns true if a feature may be used
+func (u *Unlocks) Unlocked(f Feature) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.unlocked(f)
+}
+
+// unlocked implements Unlocked (must be called with lock held)
+func (u *Unlocks) unlocked(f Feature) bool {
+	if u.state.Skipped {
+		return true
+	}
+	for _, step := range Tutorial {
+		if step.Feature == f {
+			if _, ok := u.state.Reached[step.Goal.String()]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Require returns ErrFeatureLocked, saying how to unlock it, if a feature
+// may not be used yet
+func (u *Unlocks) Require(f Feature) error {
+	if u.Unlocked(f) {
+		return nil
+	}
+	for _, step := range Tutorial {
+		if step.Feature == f {
+			return fmt.Errorf("%w: %s unlocks as you play. %s", ErrFeatureLocked, f, step.Hint)
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrFeatureLocked, f)
+}
+
+// Next returns the first tutorial step whose feature is still locked
+func (u *Unlocks) Next() (TutorialStep, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for _, step := range Tutorial {
+		if !u.unlocked(step.Feature) {
+			return step, true
+		}
+	}
+	return TutorialStep{}, false
+}
+
+// Reached returns when a goal was reached, if it has been
+func (u *Unlocks) Reached(g Goal) (time.Time, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	at, ok := u.state.Reached[g.String()]
+	return at, ok
+}
+
+// Check records the goals the pets have reached, saving the state if
+// any are new, and returns the tutorial steps they complete
+func (u *Unlocks) Check(pets []*core.DigitalPet, now time.Time) ([]TutorialStep, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	var completed []TutorialStep
+	for _, step := range Tutorial {
+		if _, ok := u.state.Reached[step.Goal.String()]; ok {
+			continue
+		}
+		for _, pet := range pets {
+			if pet.IsAlive() && step.Goal.reached(pet) {
+				u.state.Reached[step.Goal.String()] = now
+				completed = append(completed, step)
+				break
+			}
+		}
+	}
+	if len(completed) == 0 {
+		return nil, nil
+	}
+	return completed, u.save()
+}
+
+// Skip unlocks every feature at once, for players who know the game
+func (u *Unlocks) Skip() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.state.Skipped = true
+	return u.save()
+}
+
+// save writes the state, replacing the old one only once the new one is
+// complete (must be called with lock held)
+func (u *Unlocks) save() error {
+	if u.Path == "" {
+		return nil
+	}
+	payload, err := json.MarshalIndent(u.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(u.Path), 0o755); err != nil {
+		return err
+	}
+	tmp := u.Path + ".tmp"
+	if err := os.WriteFile(tmp, payload, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, u.Path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// updateUnlocks records goals the pets have reached and sends the
+// tutorial for each feature they unlock (must be called with lock held)
+func (h *Household) updateUnlocks() {
+	if h.Unlocks == nil {
+		return
+	}
+	pets := make([]*core.DigitalPet, 0, len(h.Pets))
+	for _, pet := range h.Pets {
+		pets = append(pets, pet)
+	}
+	// A failed save is retried when the next goal is reached; the
+	// features stay unlocked for this run either way
+	completed, _ := h.Unlocks.Check(pets, time.Now())
+	for _, step := range completed {
+		h.Inbox.Post(MessageSystem, "", step.Title, step.Text)
+	}
+}
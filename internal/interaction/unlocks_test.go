@@ -0,0 +1,107 @@
+package interaction
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestUnlocksCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), UnlocksDirName, UnlocksFileName)
+	unlocks := NewUnlocks(path)
+	pet := core.NewDigitalPet("Mochi", "owner")
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	if completed, err := unlocks.Check([]*core.DigitalPet{pet}, now); err != nil || len(completed) != 0 {
+		t.Fatalf("Expected nothing unlocked by a baby, got %v, %v", completed, err)
+	}
+	for _, f := range AllFeatures() {
+		if unlocks.Unlocked(f) {
+			t.Errorf("Expected %s locked", f)
+		}
+	}
+	err := unlocks.Require(FeatureExploration)
+	if !errors.Is(err, ErrFeatureLocked) || !strings.Contains(err.Error(), "juvenile stage") {
+		t.Errorf("Expected a locked error saying how to unlock exploration, got %v", err)
+	}
+	if next, ok := unlocks.Next(); !ok || next.Feature != FeatureExploration {
+		t.Errorf("Expected exploration next, got %+v", next)
+	}
+
+	pet.Biology.Processes.Age = biology.AdultAge
+	pet.Skills.Levels[ai.SkillAgility] = SkilledLevel
+	completed, err := unlocks.Check([]*core.DigitalPet{pet}, now)
+	if err != nil || len(completed) != len(Tutorial) {
+		t.Fatalf("Expected every step completed by a skilled adult, got %v, %v", completed, err)
+	}
+	if at, ok := unlocks.Reached(GoalAdult); !ok || !at.Equal(now) {
+		t.Errorf("Expected the adult goal reached at %s, got %s", now, at)
+	}
+	if _, ok := unlocks.Next(); ok {
+		t.Error("Expected nothing left to unlock")
+	}
+	if completed, _ := unlocks.Check([]*core.DigitalPet{pet}, now.Add(time.Hour)); len(completed) != 0 {
+		t.Errorf("Expected each step completed once, got %v", completed)
+	}
+
+	loaded, err := LoadUnlocks(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if at, ok := loaded.Reached(GoalJuvenile); !ok || !at.Equal(now) || !loaded.Unlocked(FeatureBreeding) {
+		t.Errorf("Expected the goals kept between runs, got %v at %s", ok, at)
+	}
+}
+
+func TestUnlocksSkip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), UnlocksFileName)
+	fresh, err := LoadUnlocks(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.Unlocked(FeatureEconomy) {
+		t.Fatal("Expected a new profile to start locked")
+	}
+	if err := fresh.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadUnlocks(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range AllFeatures() {
+		if err := loaded.Require(f); err != nil {
+			t.Errorf("Expected %s unlocked after skipping, got %v", f, err)
+		}
+	}
+}
+
+func TestHouseholdUnlocksTutorial(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	h := NewHousehold(pet)
+	h.Unlocks = NewUnlocks("")
+
+	h.Update(0.01)
+	pet.Biology.Processes.Age = biology.JuvenileAge
+	h.Update(0.01)
+	h.Update(0.01)
+
+	var tutorials []string
+	for _, msg := range h.Inbox.List() {
+		if msg.Category == MessageSystem && strings.HasSuffix(msg.Subject, "unlocked") {
+			tutorials = append(tutorials, msg.Subject)
+		}
+	}
+	if len(tutorials) != 1 || tutorials[0] != "Exploration unlocked" {
+		t.Errorf("Expected one exploration tutorial, got %q", tutorials)
+	}
+	if !h.Unlocks.Unlocked(FeatureExploration) || h.Unlocks.Unlocked(FeatureBreeding) {
+		t.Error("Expected only exploration unlocked by a juvenile")
+	}
+}
@@ -0,0 +1,127 @@
+package interaction
+
+import (
+	"sort"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Vocalization settings
+const (
+	CueThreshold = 0.4 // Minimum urgency before a pet signals a need
+	MaxCues      = 3   // Maximum cues a pet gives at once
+)
+
+// Cue is a vocalization or body-language signal a pet gives the user
+type Cue struct {
+	PetID   types.PetID
+	Source  string  // Need or emotion behind the cue
+	Message string  // What the pet does, e.g. "paws at the empty bowl"
+	Urgency float64 // How pressing the underlying need is (0.0 to 1.0)
+}
+
+// cueSource maps an urgency reading from a pet's state to a signal
+type cueSource struct {
+	source  string
+	urgency func(pet *core.DigitalPet) float64
+	mild    string
+	urgent  string
+}
+
+// cueSources lists the needs and emotions a pet can communicate
+var cueSources = []cueSource{
+	{
+		source:  "hunger",
+		urgency: func(p *core.DigitalPet) float64 { return 1.0 - p.Biology.Vitals.Nutrition },
+		mild:    "sniffs around the food bowl",
+		urgent:  "paws at the empty bowl",
+	},
+	{
+		source:  "thirst",
+		urgency: func(p *core.DigitalPet) float64 { return 1.0 - p.Biology.Vitals.Hydration },
+		mild:    "licks its lips",
+		urgent:  "noses the dry water dish",
+	},
+	{
+		source: "sleep",
+		urgency: func(p *core.DigitalPet) float64 {
+			return (p.Biology.Vitals.Fatigue + 1.0 - p.Biology.Vitals.Energy) / 2.0
+		},
+		mild:   "yawns widely",
+		urgent: "circles its bed with drooping eyes",
+	},
+	{
+		source:  "cleanliness",
+		urgency: func(p *core.DigitalPet) float64 { return 1.0 - p.Biology.Vitals.Cleanliness },
+		mild:    "scratches at its fur",
+		urgent:  "tugs at its matted coat",
+	},
+	{
+		source:  "health",
+		urgency: func(p *core.DigitalPet) float64 { return 1.0 - p.Biology.Vitals.Health },
+		mild:    "moves a little stiffly",
+		urgent:  "limps and whimpers softly",
+	},
+	{
+		source:  "loneliness",
+		urgency: func(p *core.DigitalPet) float64 { return p.Emotions.Loneliness },
+		mild:    "follows you from room to room",
+		urgent:  "whines at the door",
+	},
+	{
+		source:  "jealousy",
+		urgency: func(p *core.DigitalPet) float64 { return p.Emotions.Jealousy },
+		mild:    "pushes its head under your hand",
+		urgent:  "squeezes between you and the other pets",
+	},
+	{
+		source: "play",
+		urgency: func(p *core.DigitalPet) float64 {
+			// Only a rested pet asks to play
+			return p.Emotions.Excitement * p.Biology.Vitals.Energy
+		},
+		mild:   "bounces on its paws",
+		urgent: "drops a toy at your feet",
+	},
+	{
+		source:  "fear",
+		urgency: func(p *core.DigitalPet) float64 { return p.Emotions.Fear },
+		mild:    "glances around nervously",
+		urgent:  "trembles and hides behind the couch",
+	},
+}
+
+// Vocalize returns the cues a pet is giving, most urgent first
+func Vocalize(pet *core.DigitalPet) []Cue {
+	if !pet.IsAlive() {
+		return nil
+	}
+
+	var cues []Cue
+	for _, source := range cueSources {
+		urgency := clamp(source.urgency(pet), 0.0, 1.0)
+		if urgency < CueThreshold {
+			continue
+		}
+
+		message := source.mild
+		if urgency >= 0.7 {
+			message = source.urgent
+		}
+		cues = append(cues, Cue{
+			PetID:   pet.ID,
+			Source:  source.source,
+			Message: message,
+			Urgency: urgency,
+		})
+	}
+
+	sort.SliceStable(cues, func(i, j int) bool {
+		return cues[i].Urgency > cues[j].Urgency
+	})
+	if len(cues) > MaxCues {
+		cues = cues[:MaxCues]
+	}
+	return cues
+}
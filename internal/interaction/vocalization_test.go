@@ -0,0 +1,48 @@
+package interaction
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestVocalizeRanksByUrgency(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Biology.Vitals.Nutrition = 0.0
+	pet.Emotions.Loneliness = 0.5
+
+	cues := Vocalize(pet)
+	if len(cues) < 2 {
+		t.Fatalf("Expected hunger and loneliness cues, got %v", cues)
+	}
+
+	if cues[0].Source != "hunger" || cues[0].Message != "paws at the empty bowl" {
+		t.Errorf("Most urgent cue should be hunger, got %+v", cues[0])
+	}
+
+	for i := 1; i < len(cues); i++ {
+		if cues[i].Urgency > cues[i-1].Urgency {
+			t.Error("Cues should be sorted by urgency")
+		}
+	}
+}
+
+func TestVocalizeContentPet(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Emotions.Excitement = 0.0
+
+	if cues := Vocalize(pet); len(cues) != 0 {
+		t.Errorf("A well cared for pet should be quiet, got %v", cues)
+	}
+}
+
+func TestVocalizeLimitsCues(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	vitals := pet.Biology.Vitals
+	vitals.Nutrition, vitals.Hydration, vitals.Cleanliness, vitals.Health = 0.1, 0.1, 0.1, 0.2
+	pet.Emotions.Loneliness = 0.9
+
+	if cues := Vocalize(pet); len(cues) != MaxCues {
+		t.Errorf("Expected %d cues, got %d", MaxCues, len(cues))
+	}
+}
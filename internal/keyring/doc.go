@@ -0,0 +1,14 @@
+// Package keyring keeps secrets such as the save encryption key and the
+// cloud token in the operating system's keychain rather than in plain
+// configuration files.
+//
+// This package provides:
+//   - The macOS Keychain, through the security tool
+//   - The Secret Service (GNOME Keyring, KWallet) on Linux and BSD, through secret-tool
+//   - The Windows Credential Manager
+//   - An in-memory keyring for tests and systems without a keychain
+//   - Lookups that fall back to asking the player, remembering the answer
+//
+// Secrets are stored per service, so separate installations or profiles
+// can keep separate keys.
+package keyring
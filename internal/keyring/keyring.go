@@ -0,0 +1,136 @@
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// DefaultService is the service secrets are stored under
+const DefaultService = "gochi"
+
+// Names of the secrets the game keeps
+const (
+	SaveKey    = "save-key"    // Passphrase that encrypts saves on disk
+	CloudToken = "cloud-token" // Bearer token for the cloud endpoint
+)
+
+var (
+	// ErrNotFound is returned when the keyring holds no secret by a name
+	ErrNotFound = errors.New("secret not found in keyring")
+	// ErrUnavailable is returned when the system has no usable keyring
+	ErrUnavailable = errors.New("no keyring available")
+)
+
+// Keyring stores named secrets for one service
+type Keyring interface {
+	Get(name string) (string, error)
+	Set(name, secret string) error
+	Delete(name string) error
+}
+
+// Memory is a keyring held in memory, used in tests and when the system
+// has no keyring
+type Memory struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+// NewMemory creates an empty in-memory keyring
+func NewMemory() *Memory {
+	return &Memory{secrets: make(map[string]string)}
+}
+
+// Get returns a secret, or ErrNotFound
+func (m *Memory) Get(name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, exists := m.secrets[name]
+	if !exists {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return secret, nil
+}
+
+// Set stores a secret, replacing any previous one
+func (m *Memory) Set(name, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secrets[name] = secret
+	return nil
+}
+
+// Delete removes a secret, or returns ErrNotFound
+func (m *Memory) Delete(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.secrets[name]; !exists {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	delete(m.secrets, name)
+	return nil
+}
+
+// Source is where a looked-up secret came from
+type Source int
+
+const (
+	SourceKeyring Source = iota
+	SourcePrompt
+)
+
+// String returns the string representation of Source
+func (s Source) String() string {
+	return [...]string{"keyring", "prompt"}[s]
+}
+
+// Prompt asks the player for a secret the keyring does not hold
+type Prompt func(name string) (string, error)
+
+// Lookup returns a secret from the keyring. When the keyring does not
+// hold it, or there is no keyring, prompt supplies it instead and it is
+// stored in the keyring for next time if possible. A nil ring is treated
+// as unavailable, and a nil prompt returns the keyring's error.
+func Lookup(ring Keyring, name string, prompt Prompt) (string, Source, error) {
+	err := fmt.Errorf("%w: %s", ErrUnavailable, name)
+	if ring != nil {
+		var secret string
+		if secret, err = ring.Get(name); err == nil {
+			return secret, SourceKeyring, nil
+		}
+		if !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrUnavailable) {
+			return "", SourceKeyring, err
+		}
+	}
+	if prompt == nil {
+		return "", SourcePrompt, err
+	}
+
+	secret, perr := prompt(name)
+	if perr != nil {
+		return "", SourcePrompt, perr
+	}
+	if ring != nil {
+		if err := ring.Set(name, secret); err != nil && !errors.Is(err, ErrUnavailable) {
+			return "", SourcePrompt, err
+		}
+	}
+	return secret, SourcePrompt, nil
+}
+
+// Missing returns the names, sorted, that the keyring holds no secret for
+func Missing(ring Keyring, names ...string) ([]string, error) {
+	var missing []string
+	for _, name := range names {
+		_, err := ring.Get(name)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			missing = append(missing, name)
+		case err != nil:
+			return nil, err
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
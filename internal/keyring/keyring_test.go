@@ -0,0 +1,62 @@
+package keyring
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	ring := NewMemory()
+	if _, err := ring.Get(SaveKey); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := ring.Set(SaveKey, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if secret, err := ring.Get(SaveKey); err != nil || secret != "secret" {
+		t.Errorf("Expected the secret back, got %q (%v)", secret, err)
+	}
+	if err := ring.Delete(SaveKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := ring.Delete(SaveKey); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestLookupFallsBackToPrompt(t *testing.T) {
+	ring := NewMemory()
+	prompts := 0
+	prompt := func(name string) (string, error) {
+		prompts++
+		return "typed-" + name, nil
+	}
+
+	secret, source, err := Lookup(ring, CloudToken, prompt)
+	if err != nil || secret != "typed-cloud-token" || source != SourcePrompt {
+		t.Fatalf("Expected the prompted secret, got %q from %s (%v)", secret, source, err)
+	}
+	secret, source, err = Lookup(ring, CloudToken, prompt)
+	if err != nil || secret != "typed-cloud-token" || source != SourceKeyring || prompts != 1 {
+		t.Errorf("Expected the secret remembered in the keyring, got %q from %s after %d prompts (%v)", secret, source, prompts, err)
+	}
+
+	if _, _, err := Lookup(nil, SaveKey, nil); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable without a keyring or prompt, got %v", err)
+	}
+	if secret, _, err := Lookup(nil, SaveKey, prompt); err != nil || secret != "typed-save-key" {
+		t.Errorf("Expected the prompt used without a keyring, got %q (%v)", secret, err)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	ring := NewMemory()
+	ring.Set(CloudToken, "token")
+	missing, err := Missing(ring, SaveKey, CloudToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 1 || missing[0] != SaveKey {
+		t.Errorf("Expected only the save key missing, got %v", missing)
+	}
+}
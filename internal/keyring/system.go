@@ -0,0 +1,126 @@
+//go:build !windows
+
+package keyring
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// securityNotFound is the exit status of the macOS security tool when an
+// item does not exist
+const securityNotFound = 44
+
+// runner runs a keychain tool with input on stdin and returns its output
+// and exit status
+type runner func(stdin string, args ...string) (string, int, error)
+
+// commandKeyring keeps secrets through a keychain command-line tool
+type commandKeyring struct {
+	service string
+	macOS   bool // Use the macOS security tool rather than secret-tool
+	run     runner
+}
+
+// System returns the operating system's keychain for a service: the macOS
+// Keychain, or the Secret Service on Linux and BSD. Returns ErrUnavailable
+// if the keychain tool is not installed.
+func System(service string) (Keyring, error) {
+	tool := "secret-tool"
+	if runtime.GOOS == "darwin" {
+		tool = "security"
+	}
+	path, err := exec.LookPath(tool)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s not found", ErrUnavailable, tool)
+	}
+	return &commandKeyring{service: service, macOS: tool == "security", run: execRunner(path)}, nil
+}
+
+// execRunner runs a tool as a child process
+func execRunner(path string) runner {
+	return func(stdin string, args ...string) (string, int, error) {
+		cmd := exec.Command(path, args...)
+		cmd.Stdin = strings.NewReader(stdin)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			return stdout.String(), exit.ExitCode(), fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		if err != nil {
+			return "", -1, fmt.Errorf("%w: %v", ErrUnavailable, err)
+		}
+		return stdout.String(), 0, nil
+	}
+}
+
+// Get returns a secret, or ErrNotFound
+func (k *commandKeyring) Get(name string) (string, error) {
+	var (
+		out    string
+		status int
+		err    error
+	)
+	if k.macOS {
+		out, status, err = k.run("", "find-generic-password", "-s", k.service, "-a", name, "-w")
+		if status == securityNotFound {
+			return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+	} else {
+		// secret-tool exits with status 1 and prints nothing for a missing secret
+		out, status, err = k.run("", "lookup", "service", k.service, "account", name)
+		if status == 1 && out == "" {
+			return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(out, "\n"), nil
+}
+
+// Set stores a secret, replacing any previous one. The secret is passed
+// on stdin so it never appears in the process list.
+func (k *commandKeyring) Set(name, secret string) error {
+	if strings.ContainsAny(secret, "\r\n") {
+		return fmt.Errorf("secret for %s must be a single line", name)
+	}
+	var err error
+	if k.macOS {
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", quote(k.service), quote(name), quote(secret))
+		_, _, err = k.run(command, "-i")
+	} else {
+		_, _, err = k.run(secret, "store", "--label", k.service+" "+name, "service", k.service, "account", name)
+	}
+	return err
+}
+
+// Delete removes a secret, or returns ErrNotFound
+func (k *commandKeyring) Delete(name string) error {
+	if k.macOS {
+		_, status, err := k.run("", "delete-generic-password", "-s", k.service, "-a", name)
+		if status == securityNotFound {
+			return fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return err
+	}
+	// secret-tool clear succeeds whether or not the secret existed
+	if _, err := k.Get(name); err != nil {
+		return err
+	}
+	_, _, err := k.run("", "clear", "service", k.service, "account", name)
+	return err
+}
+
+// quote wraps an argument in double quotes for the security tool's
+// interactive mode
+func quote(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
@@ -0,0 +1,86 @@
+//go:build !windows
+
+package keyring
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeTool records calls to a keychain tool and answers from a map
+type fakeTool struct {
+	secrets map[string]string
+	calls   []string
+	stdin   []string
+}
+
+// secretTool behaves like secret-tool for secrets stored by account
+func (f *fakeTool) secretTool(stdin string, args ...string) (string, int, error) {
+	f.calls = append(f.calls, strings.Join(args, " "))
+	f.stdin = append(f.stdin, stdin)
+	account := args[len(args)-1]
+	switch args[0] {
+	case "lookup":
+		secret, ok := f.secrets[account]
+		if !ok {
+			return "", 1, errors.New("exit status 1")
+		}
+		return secret, 0, nil
+	case "store":
+		f.secrets[account] = stdin
+	case "clear":
+		delete(f.secrets, account)
+	}
+	return "", 0, nil
+}
+
+func TestSecretServiceKeyring(t *testing.T) {
+	tool := &fakeTool{secrets: make(map[string]string)}
+	ring := &commandKeyring{service: "gochi", run: tool.secretTool}
+
+	if _, err := ring.Get(SaveKey); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := ring.Set(SaveKey, "s3cret"); err != nil {
+		t.Fatal(err)
+	}
+	for _, call := range tool.calls {
+		if strings.Contains(call, "s3cret") {
+			t.Errorf("The secret should be passed on stdin, not as an argument: %s", call)
+		}
+	}
+	if secret, err := ring.Get(SaveKey); err != nil || secret != "s3cret" {
+		t.Errorf("Expected the secret back, got %q (%v)", secret, err)
+	}
+	if err := ring.Delete(SaveKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := ring.Delete(SaveKey); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := ring.Set(SaveKey, "two\nlines"); err == nil {
+		t.Error("Expected a multi-line secret refused")
+	}
+}
+
+func TestMacOSKeyring(t *testing.T) {
+	var stdin string
+	ring := &commandKeyring{service: "gochi", macOS: true, run: func(in string, args ...string) (string, int, error) {
+		stdin = in
+		if args[0] == "find-generic-password" {
+			return "", securityNotFound, errors.New("exit status 44")
+		}
+		return "", 0, nil
+	}}
+
+	if _, err := ring.Get(SaveKey); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := ring.Set(SaveKey, `say "hi"`); err != nil {
+		t.Fatal(err)
+	}
+	if want := `add-generic-password -U -s "gochi" -a "save-key" -w "say \"hi\""`; !strings.HasPrefix(stdin, want) {
+		t.Errorf("Expected the secret quoted on stdin, got %q", stdin)
+	}
+}
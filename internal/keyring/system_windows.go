@@ -0,0 +1,118 @@
+//go:build windows
+
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Windows Credential Manager constants
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredRead   = advapi32.NewProc("CredReadW")
+	procCredWrite  = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// credential mirrors the Win32 CREDENTIALW structure
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// credentialKeyring keeps secrets in the Windows Credential Manager
+type credentialKeyring struct {
+	service string
+}
+
+// System returns the Windows Credential Manager for a service. Returns
+// ErrUnavailable if it cannot be loaded.
+func System(service string) (Keyring, error) {
+	if err := procCredRead.Find(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	return &credentialKeyring{service: service}, nil
+}
+
+// target returns the credential name a secret is stored under
+func (k *credentialKeyring) target(name string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(k.service + ":" + name)
+}
+
+// Get returns a secret, or ErrNotFound
+func (k *credentialKeyring) Get(name string) (string, error) {
+	target, err := k.target(name)
+	if err != nil {
+		return "", err
+	}
+	var cred *credential
+	r, _, callErr := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if r == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return "", fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return "", fmt.Errorf("reading %s: %w", name, callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+	if cred.CredentialBlobSize == 0 {
+		return "", nil
+	}
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+// Set stores a secret, replacing any previous one
+func (k *credentialKeyring) Set(name, secret string) error {
+	target, err := k.target(name)
+	if err != nil {
+		return err
+	}
+	blob := []byte(secret)
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+	if r, _, callErr := procCredWrite.Call(uintptr(unsafe.Pointer(&cred)), 0); r == 0 {
+		return fmt.Errorf("storing %s: %w", name, callErr)
+	}
+	return nil
+}
+
+// Delete removes a secret, or returns ErrNotFound
+func (k *credentialKeyring) Delete(name string) error {
+	target, err := k.target(name)
+	if err != nil {
+		return err
+	}
+	if r, _, callErr := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0); r == 0 {
+		if errors.Is(callErr, errorNotFound) {
+			return fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return fmt.Errorf("deleting %s: %w", name, callErr)
+	}
+	return nil
+}
@@ -0,0 +1,388 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Bridge settings
+const (
+	PetPlaceholder       = "{pet}"          // Replaced by a pet's topic name
+	EventPlaceholder     = "{event}"        // Replaced by an event name such as died
+	DefaultStateInterval = time.Minute      // Time between state messages
+	CareIntensity        = 0.5              // Strength of inbound feed and play commands
+	connectTimeout       = 30 * time.Second // Limit on connecting and subscribing
+	minReconnectDelay    = time.Second      // First wait after losing the broker
+	maxReconnectDelay    = time.Minute      // Longest wait between attempts
+	AuditActor           = "mqtt"           // Actor of commands in the audit log
+)
+
+// ErrUnknownCommand is returned for commands the bridge cannot perform
+var ErrUnknownCommand = errors.New("unknown mqtt command")
+
+// commands maps inbound command payloads to interactions
+var commands = map[string]types.InteractionType{
+	"feed": types.InteractionFeeding,
+	"play": types.InteractionPlaying,
+}
+
+// ParseAllowlist reads a comma-separated list of commands to accept from
+// the broker. An empty list accepts none.
+func ParseAllowlist(list string) (map[string]bool, error) {
+	allow := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := commands[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCommand, name)
+		}
+		allow[name] = true
+	}
+	return allow, nil
+}
+
+// Topics are where the bridge publishes and listens. Each contains
+// PetPlaceholder as a whole topic level.
+type Topics struct {
+	State   string // Retained pet state
+	Event   string // Pet events; may also contain EventPlaceholder
+	Command string // Inbound commands; empty disables them
+}
+
+// PetState is the JSON payload of a state message
+type PetState struct {
+	Name          string   `json:"name"`
+	Alive         bool     `json:"alive"`
+	Health        float64  `json:"health"`
+	Energy        float64  `json:"energy"`
+	Nutrition     float64  `json:"nutrition"`
+	Hydration     float64  `json:"hydration"`
+	Happiness     float64  `json:"happiness"`
+	Stress        float64  `json:"stress"`
+	Cleanliness   float64  `json:"cleanliness"`
+	Mood          string   `json:"mood"`
+	Behavior      string   `json:"behavior"`
+	Hungry        bool     `json:"hungry"` // Nutrition is critical
+	CriticalNeeds []string `json:"critical_needs"`
+}
+
+// NewPetState snapshots a pet for a state message
+func NewPetState(pet *core.DigitalPet) PetState {
+	vitals := pet.Biology.Vitals
+	state := PetState{
+		Name:          pet.Name,
+		Alive:         pet.IsAlive(),
+		Health:        vitals.Health,
+		Energy:        vitals.Energy,
+		Nutrition:     vitals.Nutrition,
+		Hydration:     vitals.Hydration,
+		Happiness:     vitals.Happiness,
+		Stress:        vitals.Stress,
+		Cleanliness:   vitals.Cleanliness,
+		Mood:          pet.Emotions.DominantEmotion,
+		Behavior:      pet.CurrentBehavior.String(),
+		CriticalNeeds: []string{},
+	}
+	if state.Alive {
+		state.CriticalNeeds = append(state.CriticalNeeds, pet.CriticalNeeds()...)
+	}
+	for _, need := range state.CriticalNeeds {
+		if need == "Nutrition" {
+			state.Hungry = true
+		}
+	}
+	return state
+}
+
+// TopicName turns a pet name into a topic level: lower case, with runs of
+// anything but letters and digits replaced by a dash
+func TopicName(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return "pet"
+	}
+	return b.String()
+}
+
+// Bridge publishes a game loop's pets to an MQTT broker and performs the
+// allowed commands it receives, reconnecting whenever the broker is lost
+type Bridge struct {
+	Loop     *game.GameLoop
+	Broker   string // host:port
+	Options  Options
+	Topics   Topics
+	Allow    map[string]bool // Commands accepted from the broker
+	Interval time.Duration   // Time between state messages
+	Retain   bool            // Whether the broker keeps the last state
+	Log      *log.Logger
+
+	mu       sync.Mutex
+	client   *Client
+	names    map[types.PetID]string // Topic names from the last state message
+	rejected int
+}
+
+// NewBridge creates a bridge that publishes state and events but accepts
+// no commands
+func NewBridge(loop *game.GameLoop, broker string, opts Options, topics Topics) *Bridge {
+	return &Bridge{
+		Loop:     loop,
+		Broker:   broker,
+		Options:  opts,
+		Topics:   topics,
+		Allow:    map[string]bool{},
+		Interval: DefaultStateInterval,
+		Retain:   true,
+		Log:      log.New(io.Discard, "", 0),
+		names:    make(map[types.PetID]string),
+	}
+}
+
+// Rejected returns how many inbound commands were ignored
+func (b *Bridge) Rejected() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rejected
+}
+
+// Run keeps the bridge connected until ctx is cancelled
+func (b *Bridge) Run(ctx context.Context) error {
+	sub := b.Loop.Events.Subscribe("pet.*", 0, b.publishEvent)
+	defer b.Loop.Events.Unsubscribe(sub)
+
+	delay := minReconnectDelay
+	for {
+		connected := time.Now()
+		err := b.session(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if time.Since(connected) > maxReconnectDelay {
+			delay = minReconnectDelay
+		}
+		b.Log.Printf("mqtt: %v; reconnecting in %s", err, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// session connects, subscribes and publishes state until the connection
+// or ctx ends
+func (b *Bridge) session(ctx context.Context) error {
+	connectCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+	client, err := Dial(connectCtx, b.Broker, b.Options)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if b.Topics.Command != "" && len(b.Allow) > 0 {
+		filter := strings.ReplaceAll(b.Topics.Command, PetPlaceholder, "+")
+		if err := client.Subscribe(connectCtx, filter, b.command); err != nil {
+			return err
+		}
+	}
+	b.mu.Lock()
+	b.client = client
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.client = nil
+		b.mu.Unlock()
+	}()
+	b.Log.Printf("mqtt: connected to %s", b.Broker)
+
+	ticker := time.NewTicker(b.Interval)
+	defer ticker.Stop()
+	for {
+		if err := b.PublishStates(); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-client.Done():
+			return client.Err()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// PublishStates sends the state of every pet. It does nothing while the
+// broker is not connected.
+func (b *Bridge) PublishStates() error {
+	var names map[types.PetID]string
+	var states map[types.PetID]PetState
+	b.Loop.Do(func() {
+		names = topicNames(b.Loop.Household.Pets)
+		states = make(map[types.PetID]PetState, len(names))
+		for id, pet := range b.Loop.Household.Pets {
+			states[id] = NewPetState(pet)
+		}
+	})
+
+	b.mu.Lock()
+	b.names = names
+	client := b.client
+	b.mu.Unlock()
+	if client == nil {
+		return nil
+	}
+	for id, state := range states {
+		if err := b.publish(client, b.Topics.State, names[id], "", state, b.Retain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishEvent sends a pet event as JSON with its data and type
+func (b *Bridge) publishEvent(e simulation.Event) {
+	b.mu.Lock()
+	client := b.client
+	name, ok := b.names[e.PetID]
+	b.mu.Unlock()
+	if client == nil || b.Topics.Event == "" {
+		return
+	}
+	if !ok {
+		petName, _ := e.Data["name"].(string)
+		name = TopicName(petName)
+	}
+
+	payload := make(map[string]interface{}, len(e.Data)+1)
+	for key, value := range e.Data {
+		payload[key] = value
+	}
+	payload["type"] = string(e.Type)
+	event := strings.TrimPrefix(string(e.Type), "pet.")
+	if err := b.publish(client, b.Topics.Event, name, event, payload, false); err != nil {
+		b.Log.Printf("mqtt: publishing %s: %v", e.Type, err)
+	}
+}
+
+// publish sends v as JSON to a topic template filled in for a pet and event
+func (b *Bridge) publish(client *Client, template, pet, event string, v interface{}, retain bool) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	topic := strings.ReplaceAll(template, PetPlaceholder, pet)
+	topic = strings.ReplaceAll(topic, EventPlaceholder, event)
+	return client.Publish(topic, payload, retain)
+}
+
+// command performs an inbound command if it is allowed. The payload is the
+// command name; the pet comes from the topic.
+func (b *Bridge) command(topic string, payload []byte) {
+	name := strings.ToLower(strings.TrimSpace(string(payload)))
+	pet, ok := petLevel(b.Topics.Command, topic)
+	if !ok || !b.Allow[name] {
+		b.mu.Lock()
+		b.rejected++
+		b.mu.Unlock()
+		b.Log.Printf("mqtt: ignored command %q on %s", name, topic)
+		return
+	}
+
+	var err error
+	var found types.PetID
+	b.Loop.Do(func() {
+		for id, topicName := range topicNames(b.Loop.Household.Pets) {
+			if topicName == pet {
+				found = id
+				_, err = b.Loop.Household.Interact(id, commands[name], CareIntensity)
+				break
+			}
+		}
+	})
+	b.Loop.Wake()
+	switch {
+	case found == "":
+		b.Log.Printf("mqtt: no pet called %s for %s", pet, name)
+	case err != nil:
+		b.Log.Printf("mqtt: %s %s: %v", name, pet, err)
+	default:
+		b.Loop.Audit(AuditActor, data.AuditInteract, found, fmt.Sprintf("%s for %s via %s", commands[name], pet, topic))
+		if err := b.PublishStates(); err != nil {
+			b.Log.Printf("mqtt: publishing state: %v", err)
+		}
+	}
+}
+
+// petLevel returns the level of topic that stands for PetPlaceholder in
+// template
+func petLevel(template, topic string) (string, bool) {
+	want := strings.Split(template, "/")
+	got := strings.Split(topic, "/")
+	if len(want) != len(got) {
+		return "", false
+	}
+	pet, found := "", false
+	for i, level := range want {
+		switch {
+		case level == PetPlaceholder:
+			pet, found = got[i], true
+		case level != got[i]:
+			return "", false
+		}
+	}
+	return pet, found
+}
+
+// topicNames gives every pet a distinct topic name. Pets sharing a name are
+// numbered in ID order.
+func topicNames(pets map[types.PetID]*core.DigitalPet) map[types.PetID]string {
+	ids := make([]types.PetID, 0, len(pets))
+	for id := range pets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	names := make(map[types.PetID]string, len(ids))
+	used := make(map[string]int)
+	for _, id := range ids {
+		name := TopicName(pets[id].Name)
+		if used[name]++; used[name] > 1 {
+			name += "-" + strconv.Itoa(used[name])
+		}
+		names[id] = name
+	}
+	return names
+}
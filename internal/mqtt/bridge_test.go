@@ -0,0 +1,165 @@
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var testTopics = Topics{
+	State:   "gochi/{pet}/state",
+	Event:   "gochi/{pet}/event/{event}",
+	Command: "gochi/{pet}/command",
+}
+
+func TestParseAllowlist(t *testing.T) {
+	allow, err := ParseAllowlist(" Feed, play ,")
+	if err != nil || len(allow) != 2 || !allow["feed"] || !allow["play"] {
+		t.Errorf("Expected feed and play, got %v (%v)", allow, err)
+	}
+	if allow, err := ParseAllowlist(""); err != nil || len(allow) != 0 {
+		t.Errorf("Expected nothing allowed, got %v (%v)", allow, err)
+	}
+	if _, err := ParseAllowlist("feed,discipline"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("Expected ErrUnknownCommand, got %v", err)
+	}
+}
+
+func TestTopicNames(t *testing.T) {
+	for name, want := range map[string]string{"Mochi": "mochi", "Sir Fluff #2": "sir-fluff-2", "  ": "pet", "Ñandú": "and"} {
+		if got := TopicName(name); got != want {
+			t.Errorf("TopicName(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	pets := map[types.PetID]*core.DigitalPet{}
+	for _, id := range []types.PetID{"b", "a", "c"} {
+		pet := core.NewDigitalPet("Mochi", "owner")
+		pet.ID = id
+		pets[id] = pet
+	}
+	pets["c"].Name = "Biscuit"
+	names := topicNames(pets)
+	if names["a"] != "mochi" || names["b"] != "mochi-2" || names["c"] != "biscuit" {
+		t.Errorf("Unexpected topic names %v", names)
+	}
+
+	if pet, ok := petLevel(testTopics.Command, "gochi/mochi/command"); !ok || pet != "mochi" {
+		t.Errorf("Expected mochi, got %q %v", pet, ok)
+	}
+	for _, topic := range []string{"gochi/mochi/state", "gochi/mochi/command/x", "other/mochi/command"} {
+		if _, ok := petLevel(testTopics.Command, topic); ok {
+			t.Errorf("Expected %s not to be a command topic", topic)
+		}
+	}
+}
+
+func TestNewPetState(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	pet.Biology.Vitals.Nutrition = 0.1
+	state := NewPetState(pet)
+	if !state.Alive || !state.Hungry || state.Name != "Mochi" || state.Nutrition != 0.1 {
+		t.Errorf("Unexpected state %+v", state)
+	}
+	pet.Biology.Vitals.Nutrition = 0.9
+	if state := NewPetState(pet); state.Hungry || state.CriticalNeeds == nil {
+		t.Errorf("Expected a fed pet with an empty needs list, got %+v", state)
+	}
+}
+
+// runBridge starts a bridge for a one-pet household against a test broker
+// and waits for its first state message
+func runBridge(t *testing.T, allow string) (*Bridge, *testBroker) {
+	t.Helper()
+	broker := newTestBroker(t)
+	household := interaction.NewHousehold(core.NewDigitalPet("Mochi", "owner"))
+	loop, err := game.NewGameLoop(config.Default().Simulation, household, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bridge := NewBridge(loop, broker.addr(), Options{ClientID: "gochi-test"}, testTopics)
+	if bridge.Allow, err = ParseAllowlist(allow); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- bridge.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Run: %v", err)
+		}
+		loop.Shutdown(context.Background())
+	})
+
+	msg := broker.next(t)
+	var state PetState
+	if msg.topic != "gochi/mochi/state" || !msg.retain || json.Unmarshal([]byte(msg.payload), &state) != nil || state.Name != "Mochi" {
+		t.Fatalf("Expected Mochi's retained state first, got %+v", msg)
+	}
+	return bridge, broker
+}
+
+func TestBridgePublishesEvents(t *testing.T) {
+	bridge, broker := runBridge(t, "")
+	var id types.PetID
+	bridge.Loop.Do(func() {
+		for id = range bridge.Loop.Household.Pets {
+		}
+	})
+	bridge.Loop.Events.Publish(simulation.Event{Type: simulation.EventPetCritical, PetID: id,
+		Data: map[string]interface{}{"name": "Mochi", "needs": []string{"Nutrition"}}})
+
+	msg := broker.next(t)
+	var event map[string]interface{}
+	if msg.topic != "gochi/mochi/event/critical" || msg.retain || json.Unmarshal([]byte(msg.payload), &event) != nil {
+		t.Fatalf("Unexpected event message %+v", msg)
+	}
+	if event["type"] != "pet.critical" || event["name"] != "Mochi" {
+		t.Errorf("Unexpected event payload %v", event)
+	}
+}
+
+func TestBridgeCommands(t *testing.T) {
+	bridge, broker := runBridge(t, "feed")
+	var before float64
+	bridge.Loop.Do(func() {
+		for _, pet := range bridge.Loop.Household.Pets {
+			pet.Biology.Vitals.Nutrition = 0.2
+			before = pet.Biology.Vitals.Nutrition
+		}
+	})
+
+	broker.send("gochi/mochi/command", "FEED")
+	msg := broker.next(t)
+	var state PetState
+	json.Unmarshal([]byte(msg.payload), &state)
+	if msg.topic != "gochi/mochi/state" || state.Nutrition <= before {
+		t.Errorf("Expected a fed state after the command, got %+v", msg)
+	}
+
+	broker.send("gochi/mochi/command", "play")
+	broker.send("gochi/mochi/command", "vet")
+	deadline := time.Now().Add(5 * time.Second)
+	for bridge.Rejected() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := bridge.Rejected(); got != 2 {
+		t.Errorf("Expected 2 commands outside the allowlist to be rejected, got %d", got)
+	}
+	select {
+	case msg := <-broker.published:
+		t.Errorf("Expected nothing published for rejected commands, got %+v", msg)
+	default:
+	}
+}
@@ -0,0 +1,430 @@
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client settings
+const (
+	DefaultKeepAlive = 60 * time.Second // Interval the broker expects to hear from the client
+	MaxPacketSize    = 256 << 10        // Largest packet accepted from the broker
+	maxRemaining     = 268435455        // Largest remaining length MQTT can encode
+)
+
+// MQTT 3.1.1 packet types
+const (
+	packetConnect    = 1
+	packetConnAck    = 2
+	packetPublish    = 3
+	packetPubAck     = 4
+	packetSubscribe  = 8
+	packetSubAck     = 9
+	packetPingReq    = 12
+	packetPingResp   = 13
+	packetDisconnect = 14
+)
+
+var (
+	// ErrConnectionRefused is returned when the broker refuses to connect
+	ErrConnectionRefused = errors.New("mqtt connection refused")
+	// ErrSubscribeRefused is returned when the broker refuses a subscription
+	ErrSubscribeRefused = errors.New("mqtt subscription refused")
+	// ErrMalformedPacket is returned when the broker sends something unreadable
+	ErrMalformedPacket = errors.New("malformed mqtt packet")
+	// ErrClientClosed is returned when using a closed client
+	ErrClientClosed = errors.New("mqtt client is closed")
+)
+
+// connectErrors describes the CONNACK return codes
+var connectErrors = [...]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// Options identify the client to the broker
+type Options struct {
+	ClientID  string
+	Username  string // Empty connects anonymously
+	Password  string
+	KeepAlive time.Duration // 0 uses DefaultKeepAlive
+}
+
+// Handler receives messages published to a subscribed topic. Handlers run
+// on the client's reader and must not subscribe.
+type Handler func(topic string, payload []byte)
+
+// subscription routes messages matching a topic filter to a handler
+type subscription struct {
+	filter  string
+	handler Handler
+}
+
+// Client is a minimal MQTT 3.1.1 client. Messages are sent and received at
+// QoS 0, which is what state updates and commands need.
+type Client struct {
+	conn      net.Conn
+	keepAlive time.Duration
+
+	r   *bufio.Reader
+	wmu sync.Mutex // Serializes packet writes
+	w   *bufio.Writer
+
+	mu      sync.Mutex
+	subs    []subscription
+	pending map[uint16]chan byte // SUBACK return codes by packet ID
+	nextID  uint16
+	closed  bool
+	err     error
+
+	done chan struct{}
+}
+
+// Dial connects to a broker at host:port
+func Dial(ctx context.Context, addr string, opts Options) (*Client, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	client, err := NewClient(conn, opts)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// NewClient connects over an established connection and starts reading
+func NewClient(conn net.Conn, opts Options) (*Client, error) {
+	keepAlive := opts.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = DefaultKeepAlive
+	}
+	c := &Client{
+		conn:      conn,
+		keepAlive: keepAlive,
+		r:         bufio.NewReader(conn),
+		w:         bufio.NewWriter(conn),
+		pending:   make(map[uint16]chan byte),
+		done:      make(chan struct{}),
+	}
+	if err := c.connect(opts); err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	go c.read()
+	go c.ping()
+	return c, nil
+}
+
+// connect sends CONNECT and waits for the broker's CONNACK
+func (c *Client) connect(opts Options) error {
+	flags := byte(0x02) // Clean session
+	payload := appendString(nil, opts.ClientID)
+	if opts.Username != "" {
+		flags |= 0x80
+		payload = appendString(payload, opts.Username)
+		if opts.Password != "" {
+			flags |= 0x40
+			payload = appendString(payload, opts.Password)
+		}
+	}
+	body := appendString(nil, "MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(c.keepAlive/time.Second))
+	if err := c.write(packetConnect<<4, append(body, payload...)); err != nil {
+		return err
+	}
+
+	header, ack, err := readPacket(c.r)
+	if err != nil {
+		return err
+	}
+	if header>>4 != packetConnAck || len(ack) != 2 {
+		return fmt.Errorf("%w: expected CONNACK", ErrMalformedPacket)
+	}
+	if code := ack[1]; code != 0 {
+		reason := "code " + fmt.Sprint(code)
+		if int(code) < len(connectErrors) {
+			reason = connectErrors[code]
+		}
+		return fmt.Errorf("%w: %s", ErrConnectionRefused, reason)
+	}
+	return nil
+}
+
+// Publish sends a message at QoS 0. Retained messages are kept by the
+// broker for clients that subscribe later.
+func (c *Client) Publish(topic string, payload []byte, retain bool) error {
+	header := byte(packetPublish << 4)
+	if retain {
+		header |= 0x01
+	}
+	return c.write(header, append(appendString(nil, topic), payload...))
+}
+
+// Subscribe asks the broker for messages matching filter, which may use
+// the + and # wildcards, and waits for it to agree
+func (c *Client) Subscribe(ctx context.Context, filter string, handler Handler) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return ErrClientClosed
+	}
+	c.nextID++
+	if c.nextID == 0 {
+		c.nextID = 1
+	}
+	id := c.nextID
+	ack := make(chan byte, 1)
+	c.pending[id] = ack
+	c.subs = append(c.subs, subscription{filter: filter, handler: handler})
+	c.mu.Unlock()
+
+	body := binary.BigEndian.AppendUint16(nil, id)
+	body = append(appendString(body, filter), 0)
+	if err := c.write(packetSubscribe<<4|0x02, body); err != nil {
+		return err
+	}
+	select {
+	case code := <-ack:
+		if code == 0x80 {
+			return fmt.Errorf("%w: %s", ErrSubscribeRefused, filter)
+		}
+		return nil
+	case <-c.done:
+		if err := c.Err(); err != nil {
+			return err
+		}
+		return ErrClientClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Done is closed when the connection ends
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the connection was lost; nil while connected and after
+// Close
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// Close disconnects from the broker
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	c.write(packetDisconnect<<4, nil)
+	err := c.conn.Close()
+	<-c.done
+	return err
+}
+
+// write sends one packet
+func (c *Client) write(header byte, body []byte) error {
+	if len(body) > maxRemaining {
+		return fmt.Errorf("mqtt packet of %d bytes is too large", len(body))
+	}
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(c.keepAlive))
+	c.w.WriteByte(header)
+	c.w.Write(appendLength(nil, len(body)))
+	c.w.Write(body)
+	return c.w.Flush()
+}
+
+// ping keeps the connection alive while nothing else is sent
+func (c *Client) ping() {
+	ticker := time.NewTicker(c.keepAlive / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.write(packetPingReq<<4, nil)
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// read handles packets from the broker until the connection ends
+func (c *Client) read() {
+	var err error
+	for err == nil {
+		c.conn.SetReadDeadline(time.Now().Add(c.keepAlive * 3 / 2))
+		var header byte
+		var body []byte
+		if header, body, err = readPacket(c.r); err == nil {
+			err = c.handle(header, body)
+		}
+	}
+
+	c.mu.Lock()
+	if !c.closed {
+		c.err = err
+		c.closed = true
+	}
+	c.mu.Unlock()
+	c.conn.Close()
+	close(c.done)
+}
+
+// handle acts on one packet from the broker
+func (c *Client) handle(header byte, body []byte) error {
+	switch header >> 4 {
+	case packetPublish:
+		topic, rest, err := readString(body)
+		if err != nil {
+			return err
+		}
+		if qos := header >> 1 & 0x03; qos > 0 {
+			// Only QoS 0 is subscribed to, but acknowledge anything else
+			if len(rest) < 2 {
+				return fmt.Errorf("%w: PUBLISH without a packet ID", ErrMalformedPacket)
+			}
+			if qos == 1 {
+				c.write(packetPubAck<<4, rest[:2])
+			}
+			rest = rest[2:]
+		}
+		c.dispatch(topic, rest)
+	case packetSubAck:
+		if len(body) < 3 {
+			return fmt.Errorf("%w: short SUBACK", ErrMalformedPacket)
+		}
+		id := binary.BigEndian.Uint16(body)
+		c.mu.Lock()
+		ack, ok := c.pending[id]
+		delete(c.pending, id)
+		c.mu.Unlock()
+		if ok {
+			ack <- body[2]
+		}
+	case packetPingResp, packetPubAck:
+	default:
+		return fmt.Errorf("%w: unexpected packet type %d", ErrMalformedPacket, header>>4)
+	}
+	return nil
+}
+
+// dispatch passes a message to every handler whose filter matches
+func (c *Client) dispatch(topic string, payload []byte) {
+	c.mu.Lock()
+	var handlers []Handler
+	for _, sub := range c.subs {
+		if Match(sub.filter, topic) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	c.mu.Unlock()
+	for _, handler := range handlers {
+		handler(topic, payload)
+	}
+}
+
+// Match reports whether a topic matches a filter with + (one level) and
+// # (all remaining levels) wildcards
+func Match(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) || (level != "+" && level != topicLevels[i]) {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
+
+// readPacket reads one packet's fixed header and body
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(b&0x7f) * multiplier
+		if b&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, nil, fmt.Errorf("%w: remaining length too long", ErrMalformedPacket)
+		}
+		multiplier *= 128
+	}
+	if length > MaxPacketSize {
+		return 0, nil, fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrMalformedPacket, length, MaxPacketSize)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header, body, nil
+}
+
+// appendLength appends an MQTT variable-length integer
+func appendLength(b []byte, n int) []byte {
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		b = append(b, digit)
+		if n == 0 {
+			return b
+		}
+	}
+}
+
+// appendString appends a length-prefixed UTF-8 string
+func appendString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// readString reads a length-prefixed string and returns what follows it
+func readString(b []byte) (string, []byte, error) {
+	if len(b) < 2 {
+		return "", nil, fmt.Errorf("%w: short string", ErrMalformedPacket)
+	}
+	n := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+n {
+		return "", nil, fmt.Errorf("%w: short string", ErrMalformedPacket)
+	}
+	return string(b[2 : 2+n]), b[2+n:], nil
+}
@@ -0,0 +1,263 @@
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// message is a publish seen by the test broker
+type message struct {
+	topic   string
+	payload string
+	retain  bool
+}
+
+// testBroker is a single-process broker that understands just enough MQTT
+// for the client: CONNECT, SUBSCRIBE, PUBLISH, PINGREQ and DISCONNECT
+type testBroker struct {
+	listener  net.Listener
+	refuse    byte // CONNACK return code
+	published chan message
+	connects  chan Options
+
+	mu   sync.Mutex
+	subs map[net.Conn][]string
+}
+
+// newTestBroker starts a broker on a loopback port
+func newTestBroker(t *testing.T) *testBroker {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	b := &testBroker{
+		listener:  listener,
+		published: make(chan message, 64),
+		connects:  make(chan Options, 8),
+		subs:      make(map[net.Conn][]string),
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+// addr returns the broker's host:port
+func (b *testBroker) addr() string {
+	return b.listener.Addr().String()
+}
+
+// serve speaks to one client
+func (b *testBroker) serve(conn net.Conn) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.subs, conn)
+		b.mu.Unlock()
+		conn.Close()
+	}()
+	r := bufio.NewReader(conn)
+	header, body, err := readPacket(r)
+	if err != nil || header>>4 != packetConnect {
+		return
+	}
+	b.connects <- parseConnect(body)
+	conn.Write([]byte{packetConnAck << 4, 2, 0, b.refuse})
+	if b.refuse != 0 {
+		return
+	}
+
+	for {
+		header, body, err := readPacket(r)
+		if err != nil {
+			return
+		}
+		switch header >> 4 {
+		case packetSubscribe:
+			filter, _, _ := readString(body[2:])
+			b.mu.Lock()
+			b.subs[conn] = append(b.subs[conn], filter)
+			b.mu.Unlock()
+			code := byte(0)
+			if filter == "forbidden" {
+				code = 0x80
+			}
+			conn.Write([]byte{packetSubAck << 4, 3, body[0], body[1], code})
+		case packetPublish:
+			topic, payload, _ := readString(body)
+			b.published <- message{topic: topic, payload: string(payload), retain: header&0x01 != 0}
+		case packetPingReq:
+			conn.Write([]byte{packetPingResp << 4, 0})
+		case packetDisconnect:
+			return
+		}
+	}
+}
+
+// parseConnect reads the client ID and user name from a CONNECT body
+func parseConnect(body []byte) Options {
+	var opts Options
+	_, rest, _ := readString(body)
+	flags := rest[1]
+	opts.KeepAlive = time.Duration(binary.BigEndian.Uint16(rest[2:])) * time.Second
+	opts.ClientID, rest, _ = readString(rest[4:])
+	if flags&0x80 != 0 {
+		opts.Username, rest, _ = readString(rest)
+	}
+	if flags&0x40 != 0 {
+		opts.Password, _, _ = readString(rest)
+	}
+	return opts
+}
+
+// send publishes a message to every client subscribed to a matching filter
+func (b *testBroker) send(topic, payload string) {
+	packet := appendString(nil, topic)
+	packet = append(packet, payload...)
+	packet = append(appendLength([]byte{packetPublish << 4}, len(packet)), packet...)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for conn, filters := range b.subs {
+		for _, filter := range filters {
+			if Match(filter, topic) {
+				conn.Write(packet)
+				break
+			}
+		}
+	}
+}
+
+// next returns the next message published to the broker
+func (b *testBroker) next(t *testing.T) message {
+	t.Helper()
+	select {
+	case msg := <-b.published:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a publish")
+		return message{}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		filter, topic string
+		want          bool
+	}{
+		{"gochi/mochi/state", "gochi/mochi/state", true},
+		{"gochi/+/command", "gochi/mochi/command", true},
+		{"gochi/+/command", "gochi/mochi/state", false},
+		{"gochi/+/command", "gochi/mochi/command/extra", false},
+		{"gochi/#", "gochi/mochi/event/died", true},
+		{"gochi/#", "gochi", true},
+		{"gochi/+", "gochi", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.filter, tt.topic); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.filter, tt.topic, got, tt.want)
+		}
+	}
+}
+
+func TestClientPublishAndSubscribe(t *testing.T) {
+	broker := newTestBroker(t)
+	client, err := Dial(context.Background(), broker.addr(), Options{ClientID: "gochi", Username: "user", Password: "pw", KeepAlive: 30 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if got := <-broker.connects; got.ClientID != "gochi" || got.Username != "user" || got.Password != "pw" || got.KeepAlive != 30*time.Second {
+		t.Errorf("Unexpected CONNECT %+v", got)
+	}
+
+	if err := client.Publish("gochi/mochi/state", []byte(`{"alive":true}`), true); err != nil {
+		t.Fatal(err)
+	}
+	if msg := broker.next(t); msg != (message{"gochi/mochi/state", `{"alive":true}`, true}) {
+		t.Errorf("Unexpected publish %+v", msg)
+	}
+
+	received := make(chan string, 1)
+	err = client.Subscribe(context.Background(), "gochi/+/command", func(topic string, payload []byte) {
+		received <- topic + " " + string(payload)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker.send("gochi/mochi/command", "feed")
+	select {
+	case got := <-received:
+		if got != "gochi/mochi/command feed" {
+			t.Errorf("Unexpected message %q", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the command")
+	}
+
+	if err := client.Subscribe(context.Background(), "forbidden", func(string, []byte) {}); !errors.Is(err, ErrSubscribeRefused) {
+		t.Errorf("Expected ErrSubscribeRefused, got %v", err)
+	}
+}
+
+func TestClientRefused(t *testing.T) {
+	broker := newTestBroker(t)
+	broker.refuse = 5
+	_, err := Dial(context.Background(), broker.addr(), Options{ClientID: "gochi"})
+	if !errors.Is(err, ErrConnectionRefused) {
+		t.Errorf("Expected ErrConnectionRefused, got %v", err)
+	}
+}
+
+func TestClientLostConnection(t *testing.T) {
+	server, conn := net.Pipe()
+	go func() {
+		r := bufio.NewReader(server)
+		readPacket(r)
+		server.Write([]byte{packetConnAck << 4, 2, 0, 0})
+		server.Write([]byte{0xF0, 0}) // Reserved packet type
+	}()
+	client, err := NewClient(conn, Options{ClientID: "gochi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-client.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the connection to end")
+	}
+	if !errors.Is(client.Err(), ErrMalformedPacket) {
+		t.Errorf("Expected ErrMalformedPacket, got %v", client.Err())
+	}
+	if err := client.Subscribe(context.Background(), "a", func(string, []byte) {}); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Expected ErrClientClosed, got %v", err)
+	}
+}
+
+func TestPacketLengths(t *testing.T) {
+	for _, n := range []int{0, 127, 128, 16383, 16384, MaxPacketSize} {
+		packet := appendLength([]byte{packetPingResp << 4}, n)
+		packet = append(packet, make([]byte, n)...)
+		_, body, err := readPacket(bufio.NewReader(bytes.NewReader(packet)))
+		if err != nil || len(body) != n {
+			t.Errorf("Length %d: got %d bytes, %v", n, len(body), err)
+		}
+	}
+	packet := appendLength([]byte{packetPublish << 4}, MaxPacketSize+1)
+	if _, _, err := readPacket(bufio.NewReader(bytes.NewReader(packet))); !errors.Is(err, ErrMalformedPacket) {
+		t.Errorf("Expected oversized packets to be refused, got %v", err)
+	}
+}
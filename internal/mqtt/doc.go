@@ -0,0 +1,12 @@
+// Package mqtt publishes a Gochi household to an MQTT broker for home
+// automation.
+//
+// This package provides:
+//   - A minimal MQTT 3.1.1 client speaking QoS 0 over TCP
+//   - Retained per-pet state messages in JSON, sent on an interval
+//   - Pet events such as deaths and critical needs as they happen
+//   - Inbound feed and play commands, accepted only when allowlisted
+//
+// Topics are configurable templates in which {pet} stands for the pet's
+// topic name and {event} for the event, e.g. gochi/{pet}/event/{event}.
+package mqtt
@@ -0,0 +1,12 @@
+// Package report sends the player a summary of each pet's week.
+//
+// This package provides:
+//   - A recorder that samples each pet's wellbeing on auto-save and keeps
+//     its milestones, incidents and the coins spent, across restarts
+//   - A weekly summary with each pet's wellbeing trend
+//   - Markdown and HTML rendering
+//   - Delivery to a file, a webhook or by email over SMTP
+//
+// The inbox and the household's coins are not saved between runs, so the
+// recorder keeps its own copy of what the report needs beside the saves.
+package report
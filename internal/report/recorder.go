@@ -0,0 +1,260 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Recording settings
+const (
+	DirName        = "reports"           // Directory under the save path that holds the record and file reports
+	StateFileName  = "state.json"        // The record, inside DirName
+	SampleInterval = time.Hour           // Least time between wellbeing samples of a pet
+	Retention      = 14 * 24 * time.Hour // Age after which samples and happenings are dropped
+)
+
+// Kind is what sort of happening a report lists
+type Kind int
+
+const (
+	KindMilestone Kind = iota
+	KindIncident
+)
+
+// String returns the string representation of Kind
+func (k Kind) String() string {
+	return [...]string{"Milestone", "Incident"}[k]
+}
+
+// Sample is a pet's wellbeing at one moment
+type Sample struct {
+	At        time.Time `json:"at"`
+	Wellbeing float64   `json:"wellbeing"`
+}
+
+// Happening is a milestone or incident in a pet's week
+type Happening struct {
+	At   time.Time `json:"at"`
+	Kind Kind      `json:"kind"`
+	Text string    `json:"text"`
+}
+
+// PetLog is what has been recorded of one pet
+type PetLog struct {
+	Name       string      `json:"name"`
+	Samples    []Sample    `json:"samples"`
+	Happenings []Happening `json:"happenings"`
+}
+
+// Spend is coins spent between two recordings
+type Spend struct {
+	At    time.Time `json:"at"`
+	Coins int       `json:"coins"`
+}
+
+// State is the record kept between runs
+type State struct {
+	Started  time.Time               `json:"started"`             // When recording began
+	LastSent time.Time               `json:"last_sent,omitempty"` // End of the last report sent
+	Pets     map[types.PetID]*PetLog `json:"pets"`
+	Spending []Spend                 `json:"spending"`
+}
+
+// milestoneMessages and incidentMessages are the inbox categories whose
+// messages about a pet become happenings
+var (
+	milestoneMessages = map[interaction.MessageCategory]bool{interaction.MessageCelebration: true}
+	incidentMessages  = map[interaction.MessageCategory]bool{
+		interaction.MessageVetReminder: true,
+		interaction.MessageQuest:       true,
+	}
+)
+
+// Recorder keeps what a game loop's weekly reports need in a file
+type Recorder struct {
+	Loop *game.GameLoop
+	Path string // The record; rewritten on every Record
+
+	mu        sync.Mutex
+	state     State
+	lastInbox int // Newest inbox message already recorded
+	lastSpent int // Inventory.Spent when last recorded
+	now       func() time.Time
+}
+
+// NewRecorder creates a recorder for a loop, picking up the record at
+// path if there is one
+func NewRecorder(loop *game.GameLoop, path string) (*Recorder, error) {
+	r := &Recorder{Loop: loop, Path: path, now: time.Now}
+	payload, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		r.state = State{Started: r.now()}
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(payload, &r.state); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+	}
+	if r.state.Pets == nil {
+		r.state.Pets = make(map[types.PetID]*PetLog)
+	}
+	return r, nil
+}
+
+// Attach records pet deaths and critical needs as incidents
+func (r *Recorder) Attach(events *simulation.EventSystem) simulation.SubscriptionID {
+	return events.Subscribe("pet.*", 0, func(e simulation.Event) {
+		if text, ok := incident(e); ok {
+			r.mu.Lock()
+			r.happen(e.PetID, Happening{At: r.now(), Kind: KindIncident, Text: text})
+			r.mu.Unlock()
+		}
+	})
+}
+
+// incident describes an event that is an incident in a pet's week
+func incident(e simulation.Event) (string, bool) {
+	name, _ := e.Data["name"].(string)
+	switch e.Type {
+	case simulation.EventPetDied:
+		if cause, _ := e.Data["cause"].(string); cause != "" {
+			return fmt.Sprintf("%s died (%s)", name, cause), true
+		}
+		return name + " died", true
+	case simulation.EventPetCritical:
+		needs, _ := e.Data["needs"].([]string)
+		return fmt.Sprintf("%s's %s became critical", name, strings.Join(needs, " and ")), true
+	}
+	return "", false
+}
+
+// Record samples every pet's wellbeing, picks up new inbox messages and
+// coins spent, and writes the record
+func (r *Recorder) Record() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.Loop.Do(func() {
+		household := r.Loop.Household
+		for id, pet := range household.Pets {
+			log := r.log(id, pet.Name)
+			if n := len(log.Samples); n > 0 && now.Sub(log.Samples[n-1].At) < SampleInterval {
+				continue
+			}
+			log.Samples = append(log.Samples, Sample{At: now, Wellbeing: pet.Biology.Vitals.GetOverallWellbeing()})
+		}
+
+		for _, msg := range household.Inbox.List() {
+			if msg.ID <= r.lastInbox || msg.PetID == "" {
+				continue
+			}
+			switch {
+			case milestoneMessages[msg.Category]:
+				r.happen(msg.PetID, Happening{At: msg.Received, Kind: KindMilestone, Text: msg.Subject})
+			case incidentMessages[msg.Category]:
+				r.happen(msg.PetID, Happening{At: msg.Received, Kind: KindIncident, Text: msg.Subject})
+			}
+		}
+		for _, msg := range household.Inbox.List() {
+			r.lastInbox = max(r.lastInbox, msg.ID)
+		}
+
+		// Spent starts again from zero each run
+		if spent := household.Inventory.Spent; spent > r.lastSpent {
+			r.state.Spending = append(r.state.Spending, Spend{At: now, Coins: spent - r.lastSpent})
+			r.lastSpent = spent
+		} else if spent < r.lastSpent {
+			r.lastSpent = spent
+		}
+	})
+	r.prune(now)
+	return r.save()
+}
+
+// log returns the record of a pet, starting one if needed (must be called
+// with lock held)
+func (r *Recorder) log(id types.PetID, name string) *PetLog {
+	log, exists := r.state.Pets[id]
+	if !exists {
+		log = &PetLog{}
+		r.state.Pets[id] = log
+	}
+	if name != "" {
+		log.Name = name
+	}
+	return log
+}
+
+// happen records a happening for a pet (must be called with lock held)
+func (r *Recorder) happen(id types.PetID, h Happening) {
+	if id == "" {
+		return
+	}
+	log := r.log(id, "")
+	log.Happenings = append(log.Happenings, h)
+}
+
+// prune drops everything older than Retention (must be called with lock
+// held)
+func (r *Recorder) prune(now time.Time) {
+	cutoff := now.Add(-Retention)
+	for id, log := range r.state.Pets {
+		samples := log.Samples[:0]
+		for _, s := range log.Samples {
+			if !s.At.Before(cutoff) {
+				samples = append(samples, s)
+			}
+		}
+		log.Samples = samples
+		happenings := log.Happenings[:0]
+		for _, h := range log.Happenings {
+			if !h.At.Before(cutoff) {
+				happenings = append(happenings, h)
+			}
+		}
+		log.Happenings = happenings
+		if len(log.Samples) == 0 && len(log.Happenings) == 0 {
+			delete(r.state.Pets, id)
+		}
+	}
+	spending := r.state.Spending[:0]
+	for _, s := range r.state.Spending {
+		if !s.At.Before(cutoff) {
+			spending = append(spending, s)
+		}
+	}
+	r.state.Spending = spending
+}
+
+// save writes the record, replacing the old one only once the new one is
+// complete (must be called with lock held)
+func (r *Recorder) save() error {
+	payload, err := json.MarshalIndent(r.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0o755); err != nil {
+		return err
+	}
+	tmp := r.Path + ".tmp"
+	if err := os.WriteFile(tmp, payload, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, r.Path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package report
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+var testStart = time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC)
+
+// newTestRecorder creates a recorder over a loop with one pet and a clock
+// the test moves
+func newTestRecorder(t *testing.T) (*Recorder, *core.DigitalPet, *time.Time) {
+	t.Helper()
+	pet := core.NewDigitalPet("Mochi", "owner")
+	loop, err := game.NewGameLoop(config.Default().Simulation, interaction.NewHousehold(pet), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { loop.Shutdown(context.Background()) })
+
+	clock := testStart
+	r, err := NewRecorder(loop, filepath.Join(t.TempDir(), DirName, StateFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.now = func() time.Time { return clock }
+	r.state.Started = clock
+	return r, pet, &clock
+}
+
+func TestRecorderKeepsTheWeek(t *testing.T) {
+	r, pet, clock := newTestRecorder(t)
+	household := r.Loop.Household
+
+	if err := r.Record(); err != nil {
+		t.Fatal(err)
+	}
+	*clock = clock.Add(10 * time.Minute)
+	msg := household.Inbox.Post(interaction.MessageCelebration, pet.ID, "Mochi is now known as Mochi the Brave", "")
+	msg.Received = *clock
+	household.Inbox.Post(interaction.MessageFestival, pet.ID, "A festival in town", "")
+	household.Inventory.Spend(30)
+	if err := r.Record(); err != nil {
+		t.Fatal(err)
+	}
+	r.Attach(r.Loop.Events)
+	r.Loop.Events.Publish(simulation.Event{Type: simulation.EventPetCritical, PetID: pet.ID,
+		Data: map[string]interface{}{"name": "Mochi", "needs": []string{"hunger"}}})
+
+	log := r.state.Pets[pet.ID]
+	if len(log.Samples) != 1 {
+		t.Errorf("Expected one sample within SampleInterval, got %d", len(log.Samples))
+	}
+	if len(log.Happenings) != 2 || log.Happenings[0].Kind != KindMilestone || log.Happenings[1].Text != "Mochi's hunger became critical" {
+		t.Errorf("Expected the title and the critical hunger, got %+v", log.Happenings)
+	}
+	if len(r.state.Spending) != 1 || r.state.Spending[0].Coins != 30 {
+		t.Errorf("Expected 30 coins spent, got %+v", r.state.Spending)
+	}
+
+	// A new run picks up the record but not the same messages twice
+	again, err := NewRecorder(r.Loop, r.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again.now = r.now
+	if len(again.state.Pets[pet.ID].Happenings) != 1 || again.state.Spending[0].Coins != 30 {
+		t.Errorf("Expected the saved record back, got %+v", again.state)
+	}
+
+	*clock = clock.Add(Retention + time.Hour)
+	if err := r.Record(); err != nil {
+		t.Fatal(err)
+	}
+	if log := r.state.Pets[pet.ID]; len(log.Samples) != 1 || len(log.Happenings) != 0 || len(r.state.Spending) != 0 {
+		t.Errorf("Expected only the new sample after Retention, got %+v and %+v", log, r.state.Spending)
+	}
+}
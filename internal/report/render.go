@@ -0,0 +1,122 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// ErrUnknownFormat is returned for a report format that does not exist
+var ErrUnknownFormat = errors.New("unknown report format")
+
+// Format is how a report is written
+type Format int
+
+const (
+	FormatMarkdown Format = iota
+	FormatHTML
+)
+
+// String returns the string representation of Format
+func (f Format) String() string {
+	return [...]string{"markdown", "html"}[f]
+}
+
+// ContentType returns the MIME type of a report in the format
+func (f Format) ContentType() string {
+	return [...]string{"text/markdown; charset=utf-8", "text/html; charset=utf-8"}[f]
+}
+
+// Ext returns the file extension of a report in the format
+func (f Format) Ext() string {
+	return [...]string{".md", ".html"}[f]
+}
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	for _, f := range []Format{FormatMarkdown, FormatHTML} {
+		if strings.EqualFold(name, f.String()) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+}
+
+// dateLayout is how days are written in reports
+const dateLayout = "Mon 2 Jan"
+
+// Subject returns the report's one-line title, e.g. for an email
+func (w *Weekly) Subject() string {
+	return fmt.Sprintf("%s: week of %s", w.Title, w.From.Format("2 Jan 2006"))
+}
+
+// Render writes the report in a format
+func (w *Weekly) Render(out io.Writer, f Format) error {
+	if f == FormatHTML {
+		return htmlReport.Execute(out, w)
+	}
+	return w.markdown(out)
+}
+
+// Wellbeing describes a pet's wellbeing over the week in one line
+func (p PetWeek) Wellbeing() string {
+	if p.Samples == 0 {
+		return "no wellbeing recorded"
+	}
+	return fmt.Sprintf("%.0f%% → %.0f%% (%s), average %.0f%%, low %.0f%%",
+		p.Start*100, p.End*100, p.Trend, p.Average*100, p.Low*100)
+}
+
+// markdown writes the report as Markdown
+func (w *Weekly) markdown(out io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", w.Subject())
+	fmt.Fprintf(&b, "%s to %s · %d coins spent\n", w.From.Format(dateLayout), w.To.Format(dateLayout), w.Spent)
+	if len(w.Pets) == 0 {
+		b.WriteString("\nNothing was recorded this week.\n")
+	}
+	for _, pet := range w.Pets {
+		fmt.Fprintf(&b, "\n## %s\n\n", pet.Name)
+		fmt.Fprintf(&b, "Wellbeing: %s\n", pet.Wellbeing())
+		for _, list := range []struct {
+			heading    string
+			happenings []Happening
+		}{{"Milestones", pet.Milestones}, {"Incidents", pet.Incidents}} {
+			fmt.Fprintf(&b, "\n### %s\n\n", list.heading)
+			if len(list.happenings) == 0 {
+				b.WriteString("- None\n")
+			}
+			for _, h := range list.happenings {
+				fmt.Fprintf(&b, "- %s: %s\n", h.At.Format(dateLayout), h.Text)
+			}
+		}
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// htmlReport writes the report as a standalone HTML page
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"day": func(t time.Time) string { return t.Format(dateLayout) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Subject}}</title></head>
+<body>
+<h1>{{.Subject}}</h1>
+<p>{{day .From}} to {{day .To}} · {{.Spent}} coins spent</p>
+{{- range .Pets}}
+<h2>{{.Name}}</h2>
+<p>Wellbeing: {{.Wellbeing}}</p>
+<h3>Milestones</h3>
+<ul>{{range .Milestones}}<li>{{day .At}}: {{.Text}}</li>{{else}}<li>None</li>{{end}}</ul>
+<h3>Incidents</h3>
+<ul>{{range .Incidents}}<li>{{day .At}}: {{.Text}}</li>{{else}}<li>None</li>{{end}}</ul>
+{{- else}}
+<p>Nothing was recorded this week.</p>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testWeekly is a report with one pet's eventful week
+func testWeekly() *Weekly {
+	return &Weekly{
+		Title: "Gochi weekly summary",
+		From:  testStart,
+		To:    testStart.Add(Week),
+		Spent: 120,
+		Pets: []PetWeek{{
+			ID: "p1", Name: "Mochi <3", Samples: 7, Start: 0.5, End: 0.8, Average: 0.65, Low: 0.4, Trend: TrendImproving,
+			Milestones: []Happening{{At: testStart.Add(24 * time.Hour), Kind: KindMilestone, Text: "Mochi turned 1"}},
+			Incidents:  []Happening{},
+		}},
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var b strings.Builder
+	if err := testWeekly().Render(&b, FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"# Gochi weekly summary: week of 5 Oct 2026",
+		"Mon 5 Oct to Mon 12 Oct · 120 coins spent",
+		"## Mochi <3",
+		"Wellbeing: 50% → 80% (improving), average 65%, low 40%",
+		"- Tue 6 Oct: Mochi turned 1",
+		"### Incidents\n\n- None",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	var b strings.Builder
+	if err := testWeekly().Render(&b, FormatHTML); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	if !strings.Contains(out, "<h2>Mochi &lt;3</h2>") || !strings.Contains(out, "<li>Tue 6 Oct: Mochi turned 1</li>") ||
+		!strings.Contains(out, "<li>None</li>") {
+		t.Errorf("Expected the escaped pet with its milestone, got:\n%s", out)
+	}
+
+	if f, err := ParseFormat("HTML"); err != nil || f != FormatHTML {
+		t.Errorf("Expected FormatHTML, got %v (%v)", f, err)
+	}
+	if _, err := ParseFormat("pdf"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Schedule sends a report once a week. Run it on every auto-save; it
+// records the household each time and only sends when a week has passed
+// since the last report, or since recording began.
+type Schedule struct {
+	Recorder *Recorder
+	Sender   Sender
+	Format   Format
+	Title    string
+	Timeout  time.Duration // Limit on delivering one report
+
+	running sync.Mutex
+}
+
+// NewSchedule creates a weekly schedule sending a recorder's reports
+func NewSchedule(recorder *Recorder, sender Sender, f Format, title string) *Schedule {
+	return &Schedule{Recorder: recorder, Sender: sender, Format: f, Title: title, Timeout: DefaultSendTimeout}
+}
+
+// Due returns true once a week has passed since the last report was sent
+func (r *Recorder) Due(now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	since := r.state.LastSent
+	if since.IsZero() {
+		since = r.state.Started
+	}
+	return now.Sub(since) >= Week
+}
+
+// markSent records that the week up to now was reported
+func (r *Recorder) markSent(now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state.LastSent = now
+	return r.save()
+}
+
+// Run records the household and sends the week's report if it is due,
+// returning it if it was sent. A run started while another is still
+// going returns at once. A report that fails to send is tried again on
+// the next run.
+func (s *Schedule) Run(ctx context.Context) (*Weekly, error) {
+	if !s.running.TryLock() {
+		return nil, nil
+	}
+	defer s.running.Unlock()
+
+	if err := s.Recorder.Record(); err != nil {
+		return nil, err
+	}
+	now := s.Recorder.now()
+	if !s.Recorder.Due(now) {
+		return nil, nil
+	}
+	weekly := s.Recorder.Summarize(s.Title, now)
+	sendCtx, cancel := context.WithTimeout(ctx, s.Timeout)
+	defer cancel()
+	if err := s.Sender.Send(sendCtx, weekly, s.Format); err != nil {
+		return nil, err
+	}
+	return weekly, s.Recorder.markSent(now)
+}
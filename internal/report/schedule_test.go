@@ -0,0 +1,49 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingSender records the reports it is given and can be made to fail
+type countingSender struct {
+	sent []*Weekly
+	err  error
+}
+
+func (s *countingSender) Send(ctx context.Context, report *Weekly, f Format) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, report)
+	return nil
+}
+
+func TestScheduleSendsWeekly(t *testing.T) {
+	r, _, clock := newTestRecorder(t)
+	sender := &countingSender{}
+	schedule := NewSchedule(r, sender, FormatMarkdown, "Weekly")
+
+	for day := 0; day < 6; day++ {
+		if weekly, err := schedule.Run(context.Background()); err != nil || weekly != nil {
+			t.Fatalf("Day %d: expected no report yet, got %v (%v)", day, weekly, err)
+		}
+		*clock = clock.Add(24 * time.Hour)
+	}
+	*clock = clock.Add(24 * time.Hour)
+
+	sender.err = errors.New("offline")
+	if _, err := schedule.Run(context.Background()); err == nil {
+		t.Error("Expected the failed delivery reported")
+	}
+	sender.err = nil
+	weekly, err := schedule.Run(context.Background())
+	if err != nil || weekly == nil || len(sender.sent) != 1 || len(weekly.Pets) != 1 || weekly.Pets[0].Samples != 6 {
+		t.Fatalf("Expected the week's report with the six days sampled after the first, got %+v (%v)", weekly, err)
+	}
+	if weekly, _ := schedule.Run(context.Background()); weekly != nil {
+		t.Error("Expected no second report in the same week")
+	}
+}
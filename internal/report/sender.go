@@ -0,0 +1,169 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Delivery settings
+const (
+	DefaultSendTimeout = 30 * time.Second // Limit on delivering one report
+	fileDateLayout     = "2006-01-02"     // Date in a report's file name
+)
+
+// ErrDeliveryFailed is returned when a webhook refuses a report
+var ErrDeliveryFailed = errors.New("report delivery failed")
+
+// Sender delivers a report
+type Sender interface {
+	Send(ctx context.Context, report *Weekly, f Format) error
+}
+
+// FileSender writes each report to a file named after the day it ends,
+// e.g. weekly-2026-10-16.md
+type FileSender struct {
+	Dir string
+}
+
+// Path returns the file a report is written to
+func (s FileSender) Path(report *Weekly, f Format) string {
+	return filepath.Join(s.Dir, "weekly-"+report.To.Format(fileDateLayout)+f.Ext())
+}
+
+// Send writes the report, replacing the file only once it is complete
+func (s FileSender) Send(ctx context.Context, report *Weekly, f Format) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := report.Render(&buf, f); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+	path := s.Path(report, f)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// webhookPayload is the body posted to a report webhook
+type webhookPayload struct {
+	Subject     string  `json:"subject"`
+	Format      string  `json:"format"`
+	ContentType string  `json:"content_type"`
+	Body        string  `json:"body"`   // The rendered report
+	Report      *Weekly `json:"report"` // The same report as data
+}
+
+// WebhookSender posts each report to a URL as JSON, rendered and as data
+type WebhookSender struct {
+	URL    string
+	Client *http.Client
+}
+
+// Send posts the report; any status but 2xx is ErrDeliveryFailed
+func (s WebhookSender) Send(ctx context.Context, report *Weekly, f Format) error {
+	var body strings.Builder
+	if err := report.Render(&body, f); err != nil {
+		return err
+	}
+	payload, err := json.Marshal(webhookPayload{
+		Subject:     report.Subject(),
+		Format:      f.String(),
+		ContentType: f.ContentType(),
+		Body:        body.String(),
+		Report:      report,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%w: %s returned %s", ErrDeliveryFailed, s.URL, resp.Status)
+	}
+	return nil
+}
+
+// SMTPSender emails each report
+type SMTPSender struct {
+	Server   string // host:port
+	Username string // Empty sends without authenticating
+	Password string
+	From     string
+	To       []string
+
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPSender creates a sender for a server and comma-separated
+// recipients
+func NewSMTPSender(server, username, password, from, to string) *SMTPSender {
+	var recipients []string
+	for _, addr := range strings.Split(to, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			recipients = append(recipients, addr)
+		}
+	}
+	return &SMTPSender{Server: server, Username: username, Password: password, From: from, To: recipients,
+		sendMail: smtp.SendMail}
+}
+
+// Send emails the report with the format's content type
+func (s *SMTPSender) Send(ctx context.Context, report *Weekly, f Format) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", report.Subject())
+	fmt.Fprintf(&msg, "Date: %s\r\n", report.To.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: %s\r\n\r\n", f.ContentType())
+	var body strings.Builder
+	if err := report.Render(&body, f); err != nil {
+		return err
+	}
+	msg.WriteString(strings.ReplaceAll(body.String(), "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Server)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+	return s.sendMail(s.Server, auth, s.From, s.To, msg.Bytes())
+}
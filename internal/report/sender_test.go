@@ -0,0 +1,74 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFileSender(t *testing.T) {
+	sender := FileSender{Dir: t.TempDir()}
+	weekly := testWeekly()
+	if err := sender.Send(context.Background(), weekly, FormatHTML); err != nil {
+		t.Fatal(err)
+	}
+	path := sender.Path(weekly, FormatHTML)
+	if !strings.HasSuffix(path, "weekly-2026-10-12.html") {
+		t.Errorf("Expected the file named after the last day, got %s", path)
+	}
+	if payload, err := os.ReadFile(path); err != nil || !strings.Contains(string(payload), "<!DOCTYPE html>") {
+		t.Errorf("Expected an HTML page, got %q (%v)", payload, err)
+	}
+}
+
+func TestWebhookSender(t *testing.T) {
+	var got webhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	if err := (WebhookSender{URL: server.URL}).Send(context.Background(), testWeekly(), FormatMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	if got.Format != "markdown" || !strings.HasPrefix(got.Body, "# Gochi weekly summary") || got.Report.Spent != 120 {
+		t.Errorf("Expected the rendered report and its data, got %+v", got)
+	}
+
+	refused := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer refused.Close()
+	if err := (WebhookSender{URL: refused.URL}).Send(context.Background(), testWeekly(), FormatMarkdown); !errors.Is(err, ErrDeliveryFailed) {
+		t.Errorf("Expected ErrDeliveryFailed, got %v", err)
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	sender := NewSMTPSender("mail.example.com:587", "me", "pw", "gochi@example.com", "ann@example.com, bo@example.com")
+	var to []string
+	var msg string
+	var auth smtp.Auth
+	sender.sendMail = func(addr string, a smtp.Auth, from string, recipients []string, body []byte) error {
+		auth, to, msg = a, recipients, string(body)
+		return nil
+	}
+	if err := sender.Send(context.Background(), testWeekly(), FormatHTML); err != nil {
+		t.Fatal(err)
+	}
+	if len(to) != 2 || auth == nil {
+		t.Errorf("Expected two recipients and authentication, got %v and %v", to, auth)
+	}
+	if !strings.Contains(msg, "Subject: Gochi weekly summary: week of 5 Oct 2026\r\n") ||
+		!strings.Contains(msg, "Content-Type: text/html; charset=utf-8\r\n\r\n<!DOCTYPE html>") {
+		t.Errorf("Expected an HTML email, got:\n%s", msg)
+	}
+}
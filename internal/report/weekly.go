@@ -0,0 +1,149 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Summary settings
+const (
+	Week           = 7 * 24 * time.Hour // Span of a report
+	TrendWindow    = 24 * time.Hour     // Start and end of the week compared for the trend
+	TrendThreshold = 0.05               // Change in wellbeing that counts as a trend
+)
+
+// Trend is which way a pet's wellbeing went over the week
+type Trend int
+
+const (
+	TrendUnknown Trend = iota
+	TrendSteady
+	TrendImproving
+	TrendDeclining
+)
+
+// String returns the string representation of Trend
+func (t Trend) String() string {
+	return [...]string{"not enough data", "steady", "improving", "declining"}[t]
+}
+
+// PetWeek is one pet's week
+type PetWeek struct {
+	ID         types.PetID `json:"id"`
+	Name       string      `json:"name"`
+	Samples    int         `json:"samples"` // Wellbeing samples taken during the week
+	Start      float64     `json:"start"`   // Mean wellbeing over the first TrendWindow
+	End        float64     `json:"end"`     // Mean wellbeing over the last TrendWindow
+	Average    float64     `json:"average"`
+	Low        float64     `json:"low"`
+	Trend      Trend       `json:"trend"`
+	Milestones []Happening `json:"milestones"`
+	Incidents  []Happening `json:"incidents"`
+}
+
+// Weekly is the summary of a household's week
+type Weekly struct {
+	Title string    `json:"title"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Spent int       `json:"spent"` // Coins spent by the household
+	Pets  []PetWeek `json:"pets"`
+}
+
+// Summarize builds the summary of the week up to now
+func (r *Recorder) Summarize(title string, now time.Time) *Weekly {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	from := now.Add(-Week)
+	weekly := &Weekly{Title: title, From: from, To: now}
+	for _, spend := range r.state.Spending {
+		if within(spend.At, from, now) {
+			weekly.Spent += spend.Coins
+		}
+	}
+	for id, log := range r.state.Pets {
+		if week, ok := summarizePet(id, log, from, now); ok {
+			weekly.Pets = append(weekly.Pets, week)
+		}
+	}
+	sort.Slice(weekly.Pets, func(i, j int) bool {
+		if weekly.Pets[i].Name != weekly.Pets[j].Name {
+			return weekly.Pets[i].Name < weekly.Pets[j].Name
+		}
+		return weekly.Pets[i].ID < weekly.Pets[j].ID
+	})
+	return weekly
+}
+
+// summarizePet returns a pet's week, or false if nothing was recorded of
+// it during the week
+func summarizePet(id types.PetID, log *PetLog, from, to time.Time) (PetWeek, bool) {
+	week := PetWeek{ID: id, Name: log.Name, Milestones: []Happening{}, Incidents: []Happening{}}
+	for _, h := range log.Happenings {
+		if !within(h.At, from, to) {
+			continue
+		}
+		if h.Kind == KindMilestone {
+			week.Milestones = append(week.Milestones, h)
+		} else {
+			week.Incidents = append(week.Incidents, h)
+		}
+	}
+
+	var samples []Sample
+	for _, s := range log.Samples {
+		if within(s.At, from, to) {
+			samples = append(samples, s)
+		}
+	}
+	if len(samples) == 0 {
+		return week, len(week.Milestones)+len(week.Incidents) > 0
+	}
+
+	week.Samples = len(samples)
+	week.Low = samples[0].Wellbeing
+	var total float64
+	for _, s := range samples {
+		total += s.Wellbeing
+		week.Low = min(week.Low, s.Wellbeing)
+	}
+	week.Average = total / float64(len(samples))
+	first, last := samples[0].At, samples[len(samples)-1].At
+	week.Start = mean(samples, func(s Sample) bool { return s.At.Before(first.Add(TrendWindow)) })
+	week.End = mean(samples, func(s Sample) bool { return s.At.After(last.Add(-TrendWindow)) })
+
+	switch {
+	case last.Sub(first) < TrendWindow:
+		week.Trend = TrendUnknown
+	case week.End-week.Start > TrendThreshold:
+		week.Trend = TrendImproving
+	case week.Start-week.End > TrendThreshold:
+		week.Trend = TrendDeclining
+	default:
+		week.Trend = TrendSteady
+	}
+	return week, true
+}
+
+// mean returns the mean wellbeing of the samples picked
+func mean(samples []Sample, pick func(Sample) bool) float64 {
+	var total float64
+	n := 0
+	for _, s := range samples {
+		if pick(s) {
+			total += s.Wellbeing
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return total / float64(n)
+}
+
+// within returns true if t lies in (from, to]
+func within(t, from, to time.Time) bool {
+	return t.After(from) && !t.After(to)
+}
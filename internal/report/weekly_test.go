@@ -0,0 +1,44 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeTrends(t *testing.T) {
+	r, pet, clock := newTestRecorder(t)
+	now := clock.Add(Week)
+	log := r.log(pet.ID, "Mochi")
+	for i := 0; i < 7; i++ {
+		log.Samples = append(log.Samples, Sample{At: clock.Add(time.Duration(i+1) * 24 * time.Hour), Wellbeing: 0.5 + float64(i)*0.05})
+	}
+	log.Happenings = []Happening{
+		{At: clock.Add(-time.Hour), Kind: KindIncident, Text: "last week"},
+		{At: clock.Add(time.Hour), Kind: KindIncident, Text: "ran away"},
+	}
+	r.log("gone", "Ghost").Samples = []Sample{{At: clock.Add(-time.Hour), Wellbeing: 0.1}}
+	r.state.Spending = []Spend{{At: clock.Add(-time.Hour), Coins: 99}, {At: now, Coins: 40}}
+
+	weekly := r.Summarize("Weekly", now)
+	if len(weekly.Pets) != 1 || weekly.Spent != 40 {
+		t.Fatalf("Expected only Mochi and this week's 40 coins, got %+v", weekly)
+	}
+	week := weekly.Pets[0]
+	if week.Trend != TrendImproving || week.Start != 0.5 || week.Low != 0.5 || week.Samples != 7 {
+		t.Errorf("Expected an improving week from 0.5, got %+v", week)
+	}
+	if len(week.Incidents) != 1 || week.Incidents[0].Text != "ran away" || len(week.Milestones) != 0 {
+		t.Errorf("Expected only this week's incident, got %+v", week.Incidents)
+	}
+
+	for i := range log.Samples {
+		log.Samples[i].Wellbeing = 0.8 - float64(i)*0.05
+	}
+	if week := r.Summarize("Weekly", now).Pets[0]; week.Trend != TrendDeclining {
+		t.Errorf("Expected a declining week, got %s", week.Trend)
+	}
+	log.Samples = log.Samples[:1]
+	if week := r.Summarize("Weekly", now).Pets[0]; week.Trend != TrendUnknown {
+		t.Errorf("One sample is not enough for a trend, got %s", week.Trend)
+	}
+}
@@ -0,0 +1,13 @@
+// Package script runs player-written reaction rules for Gochi pets.
+//
+// This package provides:
+//   - A small expression language over pet, emotion and weather state
+//   - Rules of the form "when <condition> then <action>, ..." that react
+//     when their condition becomes true
+//   - Actions that interact with the pet or post a message to the inbox
+//   - A sandbox: no loops, no side effects outside the actions, bounded
+//     rule size and nesting, and a step budget for every evaluation
+//
+// Scripting is disabled unless enabled in the configuration; the game loop
+// runs the loaded rules after every tick.
+package script
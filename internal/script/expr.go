@@ -0,0 +1,599 @@
+package script
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Script limits
+const (
+	DefaultMaxSteps    = 1000 // Evaluation steps allowed per rule and pet
+	MaxRules           = 256  // Rules accepted from one script
+	MaxRuleLength      = 1024 // Characters in one rule
+	MaxExpressionDepth = 32   // Nesting allowed in a condition
+)
+
+var (
+	// ErrInvalidScript is returned when a rule cannot be parsed or type checked
+	ErrInvalidScript = errors.New("invalid script")
+	// ErrStepLimit is returned when evaluating a rule takes more steps than allowed
+	ErrStepLimit = errors.New("script step limit exceeded")
+)
+
+// valueKind is the static type of an expression
+type valueKind int
+
+const (
+	kindNumber valueKind = iota
+	kindString
+	kindBool
+)
+
+// String returns the string representation of valueKind
+func (k valueKind) String() string {
+	return [...]string{"number", "string", "boolean"}[k]
+}
+
+// tokenKind classifies a lexical token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+// token is one lexical element of a rule
+type token struct {
+	kind tokenKind
+	text string  // Identifier, operator or decoded string
+	num  float64 // Value of a number token
+	pos  int     // Byte offset in the rule
+}
+
+// operators lists the operator tokens, longest first
+var operators = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"<", ">", "!", "+", "-", "*", "/", "(", ")", ",",
+}
+
+// tokenize splits a rule into tokens
+func tokenize(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			num, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("bad number %q at column %d", src[start:i], start+1)
+			}
+			toks = append(toks, token{kind: tokNumber, num: num, text: src[start:i], pos: start})
+		case c == '"':
+			start := i
+			for i++; i < len(src) && src[i] != '"'; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(src) {
+				return nil, fmt.Errorf("unterminated string at column %d", start+1)
+			}
+			i++
+			text, err := strconv.Unquote(src[start:i])
+			if err != nil {
+				return nil, fmt.Errorf("bad string at column %d", start+1)
+			}
+			toks = append(toks, token{kind: tokString, text: text, pos: start})
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			start := i
+			for i < len(src) && (src[i] == '_' || src[i] >= 'a' && src[i] <= 'z' || src[i] >= 'A' && src[i] <= 'Z' || src[i] >= '0' && src[i] <= '9') {
+				i++
+			}
+			toks = append(toks, token{kind: tokIdent, text: strings.ToLower(src[start:i]), pos: start})
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at column %d", c, i+1)
+			}
+			toks = append(toks, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// evaluator holds the state of one evaluation
+type evaluator struct {
+	lookup   func(name string) interface{}
+	steps    int
+	maxSteps int
+}
+
+// step charges one step against the budget
+func (ev *evaluator) step() error {
+	ev.steps++
+	if ev.steps > ev.maxSteps {
+		return fmt.Errorf("%w: %d", ErrStepLimit, ev.maxSteps)
+	}
+	return nil
+}
+
+// node is a type-checked expression
+type node interface {
+	kind() valueKind
+	eval(ev *evaluator) (interface{}, error)
+}
+
+// literal is a constant value
+type literal struct {
+	k     valueKind
+	value interface{}
+}
+
+func (n *literal) kind() valueKind { return n.k }
+
+func (n *literal) eval(ev *evaluator) (interface{}, error) {
+	return n.value, ev.step()
+}
+
+// variable reads a value from the scope
+type variable struct {
+	name string
+	k    valueKind
+}
+
+func (n *variable) kind() valueKind { return n.k }
+
+func (n *variable) eval(ev *evaluator) (interface{}, error) {
+	if err := ev.step(); err != nil {
+		return nil, err
+	}
+	return ev.lookup(n.name), nil
+}
+
+// unary is negation or logical not
+type unary struct {
+	op      string
+	operand node
+}
+
+func (n *unary) kind() valueKind { return n.operand.kind() }
+
+func (n *unary) eval(ev *evaluator) (interface{}, error) {
+	if err := ev.step(); err != nil {
+		return nil, err
+	}
+	v, err := n.operand.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+	if n.op == "-" {
+		return -v.(float64), nil
+	}
+	return !v.(bool), nil
+}
+
+// binary is an arithmetic, comparison or logical operation
+type binary struct {
+	op          string
+	left, right node
+}
+
+func (n *binary) kind() valueKind {
+	switch n.op {
+	case "+", "-", "*", "/":
+		return kindNumber
+	default:
+		return kindBool
+	}
+}
+
+func (n *binary) eval(ev *evaluator) (interface{}, error) {
+	if err := ev.step(); err != nil {
+		return nil, err
+	}
+	l, err := n.left.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	// Logical operators short-circuit
+	switch n.op {
+	case "&&":
+		if !l.(bool) {
+			return false, nil
+		}
+		return n.right.eval(ev)
+	case "||":
+		if l.(bool) {
+			return true, nil
+		}
+		return n.right.eval(ev)
+	}
+
+	r, err := n.right.eval(ev)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "==":
+		return equal(l, r), nil
+	case "!=":
+		return !equal(l, r), nil
+	}
+
+	a, b := l.(float64), r.(float64)
+	switch n.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		return a / b, nil
+	case "<":
+		return a < b, nil
+	case "<=":
+		return a <= b, nil
+	case ">":
+		return a > b, nil
+	default:
+		return a >= b, nil
+	}
+}
+
+// equal compares two values of the same kind; strings ignore case
+func equal(a, b interface{}) bool {
+	if s, ok := a.(string); ok {
+		return strings.EqualFold(s, b.(string))
+	}
+	return a == b
+}
+
+// builtin is a function callable from conditions
+type builtin struct {
+	args int
+	fn   func(args []float64) float64
+}
+
+// builtins lists the functions available to conditions; all take and
+// return numbers
+var builtins = map[string]builtin{
+	"abs": {1, func(a []float64) float64 { return math.Abs(a[0]) }},
+	"min": {2, func(a []float64) float64 { return math.Min(a[0], a[1]) }},
+	"max": {2, func(a []float64) float64 { return math.Max(a[0], a[1]) }},
+}
+
+// call invokes a builtin function
+type call struct {
+	fn   builtin
+	args []node
+}
+
+func (n *call) kind() valueKind { return kindNumber }
+
+func (n *call) eval(ev *evaluator) (interface{}, error) {
+	if err := ev.step(); err != nil {
+		return nil, err
+	}
+	args := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(ev)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v.(float64)
+	}
+	return n.fn.fn(args), nil
+}
+
+// parser builds a type-checked expression from tokens
+type parser struct {
+	toks  []token
+	pos   int
+	depth int
+}
+
+// peek returns the next token without consuming it
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+// next consumes the next token
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the given operators or keywords
+func (p *parser) accept(texts ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOp && t.kind != tokIdent {
+		return "", false
+	}
+	for _, text := range texts {
+		if t.text == text {
+			p.pos++
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// errorf reports a problem at a token
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return fmt.Errorf("column %d: %s", t.pos+1, fmt.Sprintf(format, args...))
+}
+
+// expect checks that an expression has the wanted kind
+func (p *parser) expect(t token, n node, want valueKind) error {
+	if n.kind() != want {
+		return p.errorf(t, "expected a %s, got a %s", want, n.kind())
+	}
+	return nil
+}
+
+// condition parses a boolean expression
+func (p *parser) condition() (node, error) {
+	t := p.peek()
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(t, n, kindBool); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// logical parses a chain of one logical operator
+func (p *parser) logical(op string, spellings []string, operand func() (node, error)) (node, error) {
+	t := p.peek()
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept(spellings...); !ok {
+			return left, nil
+		}
+		rt := p.peek()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(t, left, kindBool); err != nil {
+			return nil, err
+		}
+		if err := p.expect(rt, right, kindBool); err != nil {
+			return nil, err
+		}
+		left = &binary{op: op, left: left, right: right}
+	}
+}
+
+// or parses "a or b"
+func (p *parser) or() (node, error) {
+	return p.logical("||", []string{"or", "||"}, p.and)
+}
+
+// and parses "a and b"
+func (p *parser) and() (node, error) {
+	return p.logical("&&", []string{"and", "&&"}, p.not)
+}
+
+// not parses "not a"
+func (p *parser) not() (node, error) {
+	t := p.peek()
+	if _, ok := p.accept("not", "!"); ok {
+		if err := p.enter(t); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		operand, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(t, operand, kindBool); err != nil {
+			return nil, err
+		}
+		return &unary{op: "!", operand: operand}, nil
+	}
+	return p.comparison()
+}
+
+// comparison parses "a < b" and friends; comparisons do not chain
+func (p *parser) comparison() (node, error) {
+	t := p.peek()
+	left, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<=", ">=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	if op == "==" || op == "!=" {
+		if left.kind() != right.kind() {
+			return nil, p.errorf(t, "cannot compare a %s with a %s", left.kind(), right.kind())
+		}
+	} else if left.kind() != kindNumber || right.kind() != kindNumber {
+		return nil, p.errorf(t, "%s needs numbers", op)
+	}
+	return &binary{op: op, left: left, right: right}, nil
+}
+
+// arithmetic parses a chain of arithmetic operators at one precedence
+func (p *parser) arithmetic(ops []string, operand func() (node, error)) (node, error) {
+	t := p.peek()
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		rt := p.peek()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(t, left, kindNumber); err != nil {
+			return nil, err
+		}
+		if err := p.expect(rt, right, kindNumber); err != nil {
+			return nil, err
+		}
+		left = &binary{op: op, left: left, right: right}
+	}
+}
+
+// sum parses "a + b" and "a - b"
+func (p *parser) sum() (node, error) {
+	return p.arithmetic([]string{"+", "-"}, p.product)
+}
+
+// product parses "a * b" and "a / b"
+func (p *parser) product() (node, error) {
+	return p.arithmetic([]string{"*", "/"}, p.negation)
+}
+
+// negation parses "-a"
+func (p *parser) negation() (node, error) {
+	t := p.peek()
+	if _, ok := p.accept("-"); ok {
+		if err := p.enter(t); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+		operand, err := p.negation()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(t, operand, kindNumber); err != nil {
+			return nil, err
+		}
+		return &unary{op: "-", operand: operand}, nil
+	}
+	return p.primary()
+}
+
+// enter tracks nesting so deeply nested rules are rejected
+func (p *parser) enter(t token) error {
+	p.depth++
+	if p.depth > MaxExpressionDepth {
+		return p.errorf(t, "nested more than %d levels", MaxExpressionDepth)
+	}
+	return nil
+}
+
+// leave ends a level of nesting
+func (p *parser) leave() {
+	p.depth--
+}
+
+// primary parses literals, variables, calls and parenthesized expressions
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		return &literal{k: kindNumber, value: t.num}, nil
+	case tokString:
+		return &literal{k: kindString, value: t.text}, nil
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			return &literal{k: kindBool, value: t.text == "true"}, nil
+		case "when", "then", "and", "or", "not":
+			return nil, p.errorf(t, "unexpected %q", t.text)
+		}
+		if fn, ok := builtins[t.text]; ok {
+			return p.call(t, fn)
+		}
+		v, ok := variables[t.text]
+		if !ok {
+			return nil, p.errorf(t, "unknown name %q", t.text)
+		}
+		return &variable{name: t.text, k: v.kind}, nil
+	case tokOp:
+		if t.text == "(" {
+			if err := p.enter(t); err != nil {
+				return nil, err
+			}
+			defer p.leave()
+			n, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, p.errorf(p.peek(), "missing )")
+			}
+			return n, nil
+		}
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	default:
+		return nil, p.errorf(t, "unexpected end of rule")
+	}
+}
+
+// call parses the arguments of a builtin function
+func (p *parser) call(name token, fn builtin) (node, error) {
+	if _, ok := p.accept("("); !ok {
+		return nil, p.errorf(p.peek(), "%s needs arguments in parentheses", name.text)
+	}
+	if err := p.enter(name); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	c := &call{fn: fn}
+	for {
+		t := p.peek()
+		arg, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(t, arg, kindNumber); err != nil {
+			return nil, err
+		}
+		c.args = append(c.args, arg)
+		if _, ok := p.accept(","); !ok {
+			break
+		}
+	}
+	if _, ok := p.accept(")"); !ok {
+		return nil, p.errorf(p.peek(), "missing )")
+	}
+	if len(c.args) != fn.args {
+		return nil, p.errorf(name, "%s takes %d arguments, got %d", name.text, fn.args, len(c.args))
+	}
+	return c, nil
+}
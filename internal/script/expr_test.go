@@ -0,0 +1,99 @@
+package script
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// evalString compiles and evaluates a condition against fixed values
+func evalString(t *testing.T, src string, maxSteps int) (bool, error) {
+	t.Helper()
+	toks, err := tokenize(src)
+	if err != nil {
+		return false, err
+	}
+	p := &parser{toks: toks}
+	n, err := p.condition()
+	if err != nil {
+		return false, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		t.Fatalf("Unparsed input at column %d in %q", tok.pos+1, src)
+	}
+	values := map[string]interface{}{"fear": 0.6, "joy": 0.2, "weather": "Storm", "name": "Mochi"}
+	ev := &evaluator{lookup: func(name string) interface{} { return values[name] }, maxSteps: maxSteps}
+	v, err := n.eval(ev)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{`fear > 0.5`, true},
+		{`weather == "storm" and fear > 0.5`, true},
+		{`Weather == "Clear" or joy >= 0.2`, true},
+		{`not (fear > 0.5)`, false},
+		{`!(joy < 0.1) && name != "Rex"`, true},
+		{`fear - joy * 2 > 0.1`, true},
+		{`(fear - joy) * 2 > 0.9`, false},
+		{`-fear < 0`, true},
+		{`max(fear, joy) == 0.6 and abs(joy - fear) > 0.3 and min(1, 2) == 1`, true},
+		{`true`, true},
+		{`false or false`, false},
+	}
+	for _, tt := range tests {
+		got, err := evalString(t, tt.src, DefaultMaxSteps)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.src, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.src, tt.want, got)
+		}
+	}
+}
+
+func TestTypeErrors(t *testing.T) {
+	for _, src := range []string{
+		`fear`,
+		`fear > "high"`,
+		`weather < 3`,
+		`fear and joy`,
+		`not fear`,
+		`-weather == 1`,
+		`abs(weather) > 0`,
+		`max(fear) > 0`,
+		`hunger > 0.5`,
+		`(fear > 0.5`,
+		`fear > `,
+		`fear $ 1`,
+		`weather == "Storm`,
+	} {
+		if _, err := evalString(t, src, DefaultMaxSteps); err == nil {
+			t.Errorf("%s: expected an error", src)
+		}
+	}
+}
+
+func TestNestingLimit(t *testing.T) {
+	src := strings.Repeat("(", MaxExpressionDepth+1) + "true" + strings.Repeat(")", MaxExpressionDepth+1)
+	if _, err := evalString(t, src, DefaultMaxSteps); err == nil || !strings.Contains(err.Error(), "nested") {
+		t.Errorf("Expected a nesting error, got %v", err)
+	}
+}
+
+func TestStepLimit(t *testing.T) {
+	src := "fear > 0" + strings.Repeat(" and fear > 0", 20)
+	if _, err := evalString(t, src, 10); !errors.Is(err, ErrStepLimit) {
+		t.Errorf("Expected ErrStepLimit, got %v", err)
+	}
+	if ok, err := evalString(t, src, DefaultMaxSteps); err != nil || !ok {
+		t.Errorf("Expected the condition to hold within the default budget, got %v (%v)", ok, err)
+	}
+}
@@ -0,0 +1,381 @@
+package script
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// DefaultIntensity is the strength of an interaction action that does not give one
+const DefaultIntensity = 0.5
+
+// varDef describes a name conditions can read
+type varDef struct {
+	kind valueKind
+	get  func(pet *core.DigitalPet, h *interaction.Household) interface{}
+}
+
+// number describes a numeric name read from the pet
+func number(field func(p *core.DigitalPet) float64) varDef {
+	return varDef{kindNumber, func(pet *core.DigitalPet, _ *interaction.Household) interface{} { return field(pet) }}
+}
+
+// variables lists the names conditions can read
+var variables = map[string]varDef{
+	"age": number(func(p *core.DigitalPet) float64 { return p.GetAge() }),
+
+	"name": {kindString, func(p *core.DigitalPet, _ *interaction.Household) interface{} { return p.Name }},
+	"mood": {kindString, func(p *core.DigitalPet, _ *interaction.Household) interface{} { return p.Emotions.DominantEmotion }},
+	"behavior": {kindString, func(p *core.DigitalPet, _ *interaction.Household) interface{} {
+		return p.CurrentBehavior.String()
+	}},
+	"stage": {kindString, func(p *core.DigitalPet, _ *interaction.Household) interface{} {
+		return p.Biology.GetLifeStage().String()
+	}},
+	"location": {kindString, func(p *core.DigitalPet, _ *interaction.Household) interface{} { return p.Location }},
+
+	// Weather reads as empty and 0 when the household has none
+	"weather": {kindString, func(_ *core.DigitalPet, h *interaction.Household) interface{} {
+		if h.Weather == nil {
+			return ""
+		}
+		return h.Weather.Current.Type.String()
+	}},
+	"temperature": {kindNumber, func(_ *core.DigitalPet, h *interaction.Household) interface{} {
+		if h.Weather == nil {
+			return 0.0
+		}
+		return h.Weather.Current.Temperature
+	}},
+	"season": {kindString, func(_ *core.DigitalPet, h *interaction.Household) interface{} {
+		if h.Weather == nil {
+			return ""
+		}
+		return h.Weather.Season.String()
+	}},
+}
+
+// Every vital and built-in emotion is a variable under its own name
+func init() {
+	for _, vital := range biology.AllVitals() {
+		vital := vital
+		variables[vital.Key()] = number(func(p *core.DigitalPet) float64 { return p.Biology.Vitals.Get(vital) })
+	}
+	for _, emotion := range ai.BuiltinEmotions() {
+		emotion := emotion
+		variables[emotion.String()] = number(func(p *core.DigitalPet) float64 { return p.Emotions.Get(emotion) })
+	}
+}
+
+// Variables returns the names conditions can read, sorted
+func Variables() []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// interactionActions maps action names to the interaction they perform
+var interactionActions = map[string]types.InteractionType{
+	"feed":       types.InteractionFeeding,
+	"pet":        types.InteractionPetting,
+	"play":       types.InteractionPlaying,
+	"train":      types.InteractionTraining,
+	"groom":      types.InteractionGrooming,
+	"vet":        types.InteractionMedicalCare,
+	"enrich":     types.InteractionEnvironmentalEnrichment,
+	"discipline": types.InteractionDiscipline,
+	"reward":     types.InteractionRewards,
+	"comfort":    types.InteractionComfort,
+}
+
+// InteractionAction returns the interaction an action name performs
+func InteractionAction(name string) (types.InteractionType, bool) {
+	it, ok := interactionActions[name]
+	return it, ok
+}
+
+// notifyAction is the action that posts an inbox message
+const notifyAction = "notify"
+
+// Actions returns the action names rules can use, sorted
+func Actions() []string {
+	names := []string{notifyAction}
+	for name := range interactionActions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Action is one thing a rule does when it triggers
+type Action struct {
+	Name      string
+	Intensity float64 // For interactions
+	Message   string  // For notify; "{name}" is replaced by the pet's name
+}
+
+// Rule reacts when its condition becomes true for a pet
+type Rule struct {
+	Line      int    // Line in the script, counting from 1
+	Source    string // The rule as written
+	Actions   []Action
+	condition node
+}
+
+// Condition is a condition compiled on its own, without "when" or any
+// actions, for testing pets against
+type Condition struct {
+	Source string
+	node   node
+}
+
+// CompileCondition parses a condition such as "energy < 0.3 and mood == \"Joy\""
+func CompileCondition(src string) (*Condition, error) {
+	if len(src) > MaxRuleLength {
+		return nil, fmt.Errorf("%w: condition is longer than %d characters", ErrInvalidScript, MaxRuleLength)
+	}
+	toks, err := tokenize(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScript, err)
+	}
+	p := &parser{toks: toks}
+	cond, err := p.condition()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScript, err)
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScript, p.errorf(t, "unexpected %q", t.text))
+	}
+	return &Condition{Source: strings.TrimSpace(src), node: cond}, nil
+}
+
+// Eval evaluates the condition for a pet within a step budget
+func (c *Condition) Eval(pet *core.DigitalPet, h *interaction.Household, maxSteps int) (bool, error) {
+	return evalCondition(c.node, pet, h, maxSteps)
+}
+
+// Compile parses a single rule
+func Compile(src string) (*Rule, error) {
+	if len(src) > MaxRuleLength {
+		return nil, fmt.Errorf("%w: rule is longer than %d characters", ErrInvalidScript, MaxRuleLength)
+	}
+	toks, err := tokenize(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScript, err)
+	}
+
+	p := &parser{toks: toks}
+	if _, ok := p.accept("when"); !ok {
+		return nil, fmt.Errorf("%w: rule must start with \"when\"", ErrInvalidScript)
+	}
+	cond, err := p.condition()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScript, err)
+	}
+	if _, ok := p.accept("then"); !ok {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScript, p.errorf(p.peek(), "expected \"then\""))
+	}
+
+	rule := &Rule{Source: strings.TrimSpace(src), condition: cond}
+	for {
+		action, err := p.action()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidScript, err)
+		}
+		rule.Actions = append(rule.Actions, action)
+		if _, ok := p.accept(","); !ok {
+			break
+		}
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScript, p.errorf(t, "unexpected %q", t.text))
+	}
+	return rule, nil
+}
+
+// action parses one action: an interaction with an optional intensity, or
+// notify with a message
+func (p *parser) action() (Action, error) {
+	t := p.next()
+	if t.kind != tokIdent {
+		return Action{}, p.errorf(t, "expected an action")
+	}
+
+	if t.text == notifyAction {
+		msg := p.next()
+		if msg.kind != tokString {
+			return Action{}, p.errorf(msg, "notify needs a message in quotes")
+		}
+		return Action{Name: t.text, Message: msg.text}, nil
+	}
+
+	if _, ok := interactionActions[t.text]; !ok {
+		return Action{}, p.errorf(t, "unknown action %q", t.text)
+	}
+	action := Action{Name: t.text, Intensity: DefaultIntensity}
+	if n := p.peek(); n.kind == tokNumber {
+		p.next()
+		if n.num <= 0 || n.num > 1 {
+			return Action{}, p.errorf(n, "intensity %g must be above 0 and at most 1", n.num)
+		}
+		action.Intensity = n.num
+	}
+	return action, nil
+}
+
+// Parse compiles a script of one rule per line. Blank lines and lines
+// starting with # are ignored. Every bad rule is reported.
+func Parse(src string) ([]*Rule, error) {
+	var rules []*Rule
+	var problems []error
+	for i, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, err := Compile(line)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("line %d: %w", i+1, err))
+			continue
+		}
+		rule.Line = i + 1
+		rules = append(rules, rule)
+	}
+	if len(rules) > MaxRules {
+		problems = append(problems, fmt.Errorf("%w: %d rules, at most %d allowed", ErrInvalidScript, len(rules), MaxRules))
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return rules, nil
+}
+
+// LoadFile compiles the script in a file
+func LoadFile(path string) ([]*Rule, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(src))
+}
+
+// Eval evaluates the rule's condition for a pet within a step budget
+func (r *Rule) Eval(pet *core.DigitalPet, h *interaction.Household, maxSteps int) (bool, error) {
+	return evalCondition(r.condition, pet, h, maxSteps)
+}
+
+// evalCondition evaluates a condition for a pet within a step budget
+func evalCondition(cond node, pet *core.DigitalPet, h *interaction.Household, maxSteps int) (bool, error) {
+	ev := &evaluator{
+		lookup:   func(name string) interface{} { return variables[name].get(pet, h) },
+		maxSteps: maxSteps,
+	}
+	v, err := cond.eval(ev)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// perform carries out the rule's actions for a pet
+func (r *Rule) perform(pet *core.DigitalPet, h *interaction.Household) error {
+	var problems []error
+	for _, action := range r.Actions {
+		if action.Name == notifyAction {
+			msg := strings.ReplaceAll(action.Message, "{name}", pet.Name)
+			h.Inbox.Post(interaction.MessageSystem, pet.ID, msg, "Triggered by: "+r.Source)
+			continue
+		}
+		if _, err := h.Interact(pet.ID, interactionActions[action.Name], action.Intensity); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// Reaction records a rule that triggered, or failed, for a pet
+type Reaction struct {
+	Rule  *Rule
+	PetID types.PetID
+	Err   error // Why the condition or an action failed
+}
+
+// Engine runs rules against a household. A rule reacts once when its
+// condition becomes true for a pet and again only after it has been false.
+type Engine struct {
+	Rules    []*Rule
+	MaxSteps int
+
+	states map[types.PetID][]ruleState
+}
+
+// ruleState is what the engine remembers about one rule for one pet
+type ruleState struct {
+	met    bool // Condition held at the last run
+	failed bool // Evaluation failed at the last run
+}
+
+// NewEngine creates an engine; maxSteps below 1 uses DefaultMaxSteps
+func NewEngine(rules []*Rule, maxSteps int) *Engine {
+	if maxSteps < 1 {
+		maxSteps = DefaultMaxSteps
+	}
+	return &Engine{
+		Rules:    rules,
+		MaxSteps: maxSteps,
+		states:   make(map[types.PetID][]ruleState),
+	}
+}
+
+// Run evaluates every rule for every living pet and performs the actions
+// of rules whose condition has just become true. A rule that runs out of
+// steps counts as false and is reported when it starts failing.
+func (e *Engine) Run(h *interaction.Household) []Reaction {
+	ids := make([]string, 0, len(h.Pets))
+	for id := range h.Pets {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+
+	var reactions []Reaction
+	for id := range e.states {
+		if _, present := h.Pets[id]; !present {
+			delete(e.states, id)
+		}
+	}
+	for _, id := range ids {
+		petID := types.PetID(id)
+		pet := h.Pets[petID]
+		if !pet.IsAlive() {
+			continue
+		}
+		states := e.states[petID]
+		if len(states) != len(e.Rules) {
+			states = make([]ruleState, len(e.Rules))
+			e.states[petID] = states
+		}
+
+		for i, rule := range e.Rules {
+			met, err := rule.Eval(pet, h, e.MaxSteps)
+			if err != nil && !states[i].failed {
+				reactions = append(reactions, Reaction{Rule: rule, PetID: petID, Err: err})
+			}
+			if met && !states[i].met {
+				reactions = append(reactions, Reaction{Rule: rule, PetID: petID, Err: rule.perform(pet, h)})
+			}
+			states[i] = ruleState{met: met, failed: err != nil}
+		}
+	}
+	return reactions
+}
@@ -0,0 +1,190 @@
+package script
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestCompile(t *testing.T) {
+	rule, err := Compile(`when weather == "Storm" and fear > 0.5 then comfort 0.8, notify "{name} is scared"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rule.Actions) != 2 {
+		t.Fatalf("Expected 2 actions, got %+v", rule.Actions)
+	}
+	if a := rule.Actions[0]; a.Name != "comfort" || a.Intensity != 0.8 {
+		t.Errorf("Unexpected first action %+v", a)
+	}
+	if a := rule.Actions[1]; a.Name != "notify" || a.Message != "{name} is scared" {
+		t.Errorf("Unexpected second action %+v", a)
+	}
+
+	rule, err = Compile(`when nutrition < 0.2 then feed`)
+	if err != nil || rule.Actions[0].Intensity != DefaultIntensity {
+		t.Errorf("Expected the default intensity, got %+v (%v)", rule, err)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, src := range []string{
+		`fear > 0.5 then comfort`,
+		`when fear > 0.5`,
+		`when fear > 0.5 then`,
+		`when fear > 0.5 then dance`,
+		`when fear > 0.5 then comfort 2`,
+		`when fear > 0.5 then notify`,
+		`when fear > 0.5 then comfort extra`,
+		`when fear then comfort`,
+		`when ` + strings.Repeat("fear > 0 and ", MaxRuleLength/10) + `true then comfort`,
+	} {
+		if _, err := Compile(src); !errors.Is(err, ErrInvalidScript) {
+			t.Errorf("%s: expected ErrInvalidScript, got %v", src, err)
+		}
+	}
+}
+
+func TestCompileCondition(t *testing.T) {
+	cond, err := CompileCondition(`energy < 0.5 and name == "Mochi"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet := core.NewDigitalPet("Mochi", "owner")
+	h := interaction.NewHousehold(pet)
+	if met, err := cond.Eval(pet, h, DefaultMaxSteps); err != nil || met {
+		t.Errorf("Expected a rested pet to fail the condition, got %v (%v)", met, err)
+	}
+	pet.Biology.Vitals.Energy = 0.2
+	if met, _ := cond.Eval(pet, h, DefaultMaxSteps); !met {
+		t.Error("Expected a tired pet to meet the condition")
+	}
+
+	for _, src := range []string{`energy`, `energy < 0.5 then feed`, `when energy < 0.5`} {
+		if _, err := CompileCondition(src); !errors.Is(err, ErrInvalidScript) {
+			t.Errorf("%s: expected ErrInvalidScript, got %v", src, err)
+		}
+	}
+}
+
+func TestParseReportsEveryBadLine(t *testing.T) {
+	src := "# comment\n\nwhen fear > 0.5 then comfort\nwhen bogus then pet\nwhen joy > 0.5 then dance\n"
+	_, err := Parse(src)
+	if !errors.Is(err, ErrInvalidScript) {
+		t.Fatalf("Expected ErrInvalidScript, got %v", err)
+	}
+	for _, line := range []string{"line 4", "line 5"} {
+		if !strings.Contains(err.Error(), line) {
+			t.Errorf("Expected %s to be reported in:\n%v", line, err)
+		}
+	}
+
+	rules, err := Parse("when fear > 0.5 then comfort\n# done\n")
+	if err != nil || len(rules) != 1 || rules[0].Line != 1 {
+		t.Errorf("Expected one rule on line 1, got %+v (%v)", rules, err)
+	}
+}
+
+func TestShippedReactionsCompile(t *testing.T) {
+	rules, err := LoadFile(filepath.Join("..", "..", "configs", "reactions.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) == 0 {
+		t.Error("Expected the shipped script to contain rules")
+	}
+}
+
+func TestVariablesAndActionsAreListed(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("..", "..", "configs", "reactions.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range append(Variables(), Actions()...) {
+		if !strings.Contains(string(src), name) {
+			t.Errorf("%s is not documented in the shipped script", name)
+		}
+	}
+}
+
+func TestEngineReactsWhenConditionBecomesTrue(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	h := interaction.NewHousehold(pet)
+	h.Weather = environment.NewWeatherSystem()
+	h.Weather.Current.Type = environment.WeatherStorm
+
+	rules, err := Parse(`when weather == "Storm" and fear > 0.5 then comfort, notify "{name} is scared"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := NewEngine(rules, 0)
+
+	pet.Emotions.Fear = 0.2
+	if reactions := engine.Run(h); len(reactions) != 0 {
+		t.Fatalf("Expected no reaction while calm, got %+v", reactions)
+	}
+
+	pet.Emotions.Fear = 0.9
+	reactions := engine.Run(h)
+	if len(reactions) != 1 || reactions[0].PetID != pet.ID || reactions[0].Err != nil {
+		t.Fatalf("Expected one successful reaction, got %+v", reactions)
+	}
+	if pet.TotalInteractions != 1 {
+		t.Errorf("Expected the pet to be comforted, got %d interactions", pet.TotalInteractions)
+	}
+	if msgs := h.Inbox.List(); len(msgs) != 1 || msgs[0].Subject != "Mochi is scared" {
+		t.Errorf("Expected a notification about Mochi, got %+v", msgs)
+	}
+
+	// Still true: no repeat until the condition has been false
+	pet.Emotions.Fear = 0.9
+	if reactions := engine.Run(h); len(reactions) != 0 {
+		t.Errorf("Expected no repeat while the condition holds, got %+v", reactions)
+	}
+	h.Weather.Current.Type = environment.WeatherClear
+	engine.Run(h)
+	h.Weather.Current.Type = environment.WeatherStorm
+	pet.Emotions.Fear = 0.9
+	if reactions := engine.Run(h); len(reactions) != 1 {
+		t.Errorf("Expected a new reaction after the storm returned, got %+v", reactions)
+	}
+}
+
+func TestEngineReportsStepLimitOnce(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	h := interaction.NewHousehold(pet)
+	rules, err := Parse("when fear >= 0" + strings.Repeat(" and fear >= 0", 10) + " then comfort")
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := NewEngine(rules, 5)
+
+	reactions := engine.Run(h)
+	if len(reactions) != 1 || !errors.Is(reactions[0].Err, ErrStepLimit) {
+		t.Fatalf("Expected a step limit failure, got %+v", reactions)
+	}
+	if pet.TotalInteractions != 0 {
+		t.Error("A rule out of steps should not act")
+	}
+	if reactions := engine.Run(h); len(reactions) != 0 {
+		t.Errorf("Expected the failure to be reported once, got %+v", reactions)
+	}
+}
+
+func TestEngineWithoutWeather(t *testing.T) {
+	pet := core.NewDigitalPet("Mochi", "owner")
+	h := interaction.NewHousehold(pet)
+	rules, err := Parse(`when weather == "" and temperature == 0 and season == "" then pet`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reactions := NewEngine(rules, 0).Run(h); len(reactions) != 1 {
+		t.Errorf("Expected weather to read as empty, got %+v", reactions)
+	}
+}
@@ -0,0 +1,577 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Admin API settings
+const (
+	AdminPrefix   = "/admin/" // Path every admin endpoint lives under
+	MaxAdminBody  = 4 << 10   // Bytes accepted in a request body
+	CareIntensity = 0.5       // Strength of care given through the API
+	AuditActor    = "api"     // Actor in the audit log, followed by ":" and the key name
+)
+
+var (
+	// ErrNoAdminToken is returned when serving without an admin token
+	ErrNoAdminToken = errors.New("admin token is not set")
+	// ErrUnauthorized is returned for requests without the admin token
+	ErrUnauthorized = errors.New("admin token required")
+	// ErrDebugOnly is returned for endpoints that need debug mode
+	ErrDebugOnly = errors.New("only available in debug mode")
+	// ErrNoWeather is returned when weather is forced but not simulated
+	ErrNoWeather = errors.New("weather is not simulated")
+	// ErrBadRequest is returned when a request body cannot be understood
+	ErrBadRequest = errors.New("bad request")
+	// ErrNoCrowd is returned for votes when crowd control is not enabled
+	ErrNoCrowd = errors.New("crowd control is not enabled")
+	// ErrUnknownCare is returned for care the API does not offer
+	ErrUnknownCare = errors.New("unknown care action")
+)
+
+// route is one admin endpoint
+type route struct {
+	method  string
+	role    Role // Least role allowed to call it
+	debug   bool // Only served in debug mode
+	handler func(a *Admin, r *http.Request) (interface{}, error)
+}
+
+// routes maps endpoint names, relative to AdminPrefix, to their handlers
+var routes = map[string]route{
+	"stats":     {http.MethodGet, RoleViewer, false, (*Admin).stats},
+	"history":   {http.MethodGet, RoleViewer, false, (*Admin).history},
+	"crowd":     {http.MethodGet, RoleViewer, false, (*Admin).crowd},
+	"care":      {http.MethodPost, RoleCaretaker, false, (*Admin).care},
+	"vote":      {http.MethodPost, RoleCaretaker, false, (*Admin).vote},
+	"pause":     {http.MethodPost, RoleAdmin, false, (*Admin).pause},
+	"resume":    {http.MethodPost, RoleAdmin, false, (*Admin).resume},
+	"timescale": {http.MethodPost, RoleAdmin, false, (*Admin).timeScale},
+	"backup":    {http.MethodPost, RoleAdmin, false, (*Admin).backup},
+	"audit":     {http.MethodGet, RoleAdmin, false, (*Admin).audit},
+	"pets":      {http.MethodGet, RoleViewer, false, (*Admin).pets},
+	"weather":   {http.MethodPost, RoleAdmin, true, (*Admin).weather},
+	"season":    {http.MethodPost, RoleAdmin, true, (*Admin).season},
+
+	SessionEndpoint: {http.MethodGet, RoleViewer, false, (*Admin).sessionState},
+	ActEndpoint:     {http.MethodPost, RoleCaretaker, false, (*Admin).act},
+}
+
+// careActions maps the care the API offers to interactions
+var careActions = map[string]types.InteractionType{
+	"feed":    types.InteractionFeeding,
+	"pet":     types.InteractionPetting,
+	"play":    types.InteractionPlaying,
+	"groom":   types.InteractionGrooming,
+	"train":   types.InteractionTraining,
+	"comfort": types.InteractionComfort,
+	"reward":  types.InteractionRewards,
+}
+
+// Admin serves the admin API of a running game loop
+type Admin struct {
+	Loop      *game.GameLoop
+	Token     string   // Bearer token of the admin role; required to serve
+	Keys      []APIKey // Further tokens, each limited to its role
+	BackupDir string   // Where backups are written
+	Debug     bool     // Allows forcing weather and seasons
+
+	session session // Shared by every caller
+}
+
+// NewAdmin creates the admin API for a loop
+func NewAdmin(loop *game.GameLoop, token, backupDir string) *Admin {
+	return &Admin{Loop: loop, Token: token, BackupDir: backupDir}
+}
+
+// Serve answers admin requests on listener until ctx is cancelled
+func (a *Admin) Serve(ctx context.Context, listener net.Listener) error {
+	if a.Token == "" {
+		return ErrNoAdminToken
+	}
+	return Serve(ctx, listener, a)
+}
+
+// Serve answers requests on listener with handler until ctx is cancelled.
+// Server mode uses it to serve the admin API beside other endpoints.
+func Serve(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.Close()
+		case <-stopped:
+		}
+	}()
+	err := server.Serve(listener)
+	close(stopped)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// ServeHTTP answers a single admin request with JSON
+func (a *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := a.authenticate(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="gochi"`)
+		writeError(w, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+	a.session.seen(key)
+	if r.URL.Path == AdminPrefix+EventsEndpoint {
+		a.streamEvents(w, r)
+		return
+	}
+	rt, ok := routes[strings.TrimPrefix(r.URL.Path, AdminPrefix)]
+	if !ok || !strings.HasPrefix(r.URL.Path, AdminPrefix) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no admin endpoint %s", r.URL.Path))
+		return
+	}
+	if r.Method != rt.method {
+		w.Header().Set("Allow", rt.method)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s needs %s", r.URL.Path, rt.method))
+		return
+	}
+	if key.Role < rt.role {
+		writeError(w, http.StatusForbidden, fmt.Errorf("%w: %s needs the %s role", ErrForbidden, r.URL.Path, rt.role))
+		return
+	}
+	if rt.debug && !a.Debug {
+		writeError(w, http.StatusForbidden, ErrDebugOnly)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxAdminBody)
+	result, err := rt.handler(a, r.WithContext(withCaller(r.Context(), key)))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	if rt.method == http.MethodPost {
+		a.Loop.Wake() // Changes take effect promptly even when idle
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// authenticate finds the key of a request's bearer token. Without an
+// admin token set nothing is authorized.
+func (a *Admin) authenticate(r *http.Request) (APIKey, bool) {
+	given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || a.Token == "" {
+		return APIKey{}, false
+	}
+	// Every token is compared so the time taken does not tell which matched
+	var found APIKey
+	matched := false
+	for _, key := range append([]APIKey{{Name: AdminKeyName, Role: RoleAdmin, Token: a.Token}}, a.Keys...) {
+		if key.Token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(key.Token)) == 1 && !matched {
+			found, matched = key, true
+		}
+	}
+	return found, matched
+}
+
+// statusFor returns the HTTP status for a handler error
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrBadRequest), errors.Is(err, config.ErrUnknownTimeScale),
+		errors.Is(err, environment.ErrUnknownWeather), errors.Is(err, environment.ErrUnknownSeason),
+		errors.Is(err, interaction.ErrUnknownAction), errors.Is(err, interaction.ErrInvalidVoter),
+		errors.Is(err, ErrUnknownCare), errors.Is(err, core.ErrBadSearch):
+		return http.StatusBadRequest
+	case errors.Is(err, interaction.ErrPetNotPresent):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNoWeather), errors.Is(err, game.ErrNoStorage), errors.Is(err, game.ErrClockAligned),
+		errors.Is(err, ErrNoCrowd), errors.Is(err, interaction.ErrPetHibernating), errors.Is(err, ErrStaleAction):
+		return http.StatusConflict
+	case errors.Is(err, interaction.ErrRoundFull):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error as {"error": "..."}
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// decode reads a JSON request body into v
+func decode(r *http.Request, v interface{}) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	return nil
+}
+
+// Stats is the response of the stats endpoint
+type Stats struct {
+	game.LoopStats
+	Weather string `json:"weather,omitempty"` // Empty when weather is not simulated
+	Season  string `json:"season,omitempty"`
+}
+
+// stats reports the loop, household and weather
+func (a *Admin) stats(*http.Request) (interface{}, error) {
+	stats := Stats{LoopStats: a.Loop.Stats()}
+	a.Loop.Do(func() {
+		if ws := a.Loop.Household.Weather; ws != nil {
+			stats.Weather = ws.Current.Type.String()
+			stats.Season = ws.Season.String()
+		}
+	})
+	return stats, nil
+}
+
+// history lists the loop's per-minute statistics, oldest first. Query
+// parameter minutes keeps only the most recent ones.
+func (a *Admin) history(r *http.Request) (interface{}, error) {
+	history := a.Loop.History()
+	if minutes := r.URL.Query().Get("minutes"); minutes != "" {
+		n, err := strconv.Atoi(minutes)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%w: minutes must be a positive number", ErrBadRequest)
+		}
+		if n < len(history) {
+			history = history[len(history)-n:]
+		}
+	}
+	if history == nil {
+		history = []game.MinuteStats{}
+	}
+	return history, nil
+}
+
+// pauseState is the response of the pause and resume endpoints
+type pauseState struct {
+	Paused bool `json:"paused"`
+}
+
+// pause stops game time; the loop keeps running and saving
+func (a *Admin) pause(*http.Request) (interface{}, error) {
+	a.Loop.Time.Pause()
+	return pauseState{Paused: true}, nil
+}
+
+// resume restarts game time without a jump for the time spent paused
+func (a *Admin) resume(*http.Request) (interface{}, error) {
+	a.Loop.Time.Resume()
+	return pauseState{Paused: false}, nil
+}
+
+// timeScaleRequest is the body of the timescale endpoint
+type timeScaleRequest struct {
+	TimeScale string `json:"time_scale"` // Configuration spelling, e.g. ACCELERATED_24X
+}
+
+// timeScale changes how fast game time runs
+func (a *Admin) timeScale(r *http.Request) (interface{}, error) {
+	var req timeScaleRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	scale, err := config.ParseTimeScale(req.TimeScale)
+	if err != nil {
+		return nil, err
+	}
+	if a.Loop.Time.IsAligned() {
+		return nil, game.ErrClockAligned
+	}
+	a.Loop.Time.SetTimeScale(scale)
+	return timeScaleRequest{TimeScale: config.TimeScaleName(scale)}, nil
+}
+
+// backupResult is the response of the backup endpoint
+type backupResult struct {
+	Path string `json:"path"`
+}
+
+// backup saves the household and copies the saves into a new backup
+func (a *Admin) backup(r *http.Request) (interface{}, error) {
+	path, err := a.Loop.Backup(r.Context(), a.BackupDir)
+	if err != nil {
+		return nil, err
+	}
+	return backupResult{Path: path}, nil
+}
+
+// weatherRequest is the body of the weather endpoint
+type weatherRequest struct {
+	Weather string `json:"weather"`
+}
+
+// weather forces the current weather
+func (a *Admin) weather(r *http.Request) (interface{}, error) {
+	var req weatherRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	wt, err := environment.ParseWeatherType(req.Weather)
+	if err != nil {
+		return nil, err
+	}
+	err = ErrNoWeather
+	a.Loop.Do(func() {
+		if ws := a.Loop.Household.Weather; ws != nil {
+			ws.SetWeather(wt)
+			err = nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return weatherRequest{Weather: wt.String()}, nil
+}
+
+// seasonRequest is the body of the season endpoint
+type seasonRequest struct {
+	Season string `json:"season"`
+}
+
+// season jumps forward to a season
+func (a *Admin) season(r *http.Request) (interface{}, error) {
+	var req seasonRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	season, err := environment.ParseSeason(req.Season)
+	if err != nil {
+		return nil, err
+	}
+	err = ErrNoWeather
+	a.Loop.Do(func() {
+		switch ws := a.Loop.Household.Weather; {
+		case ws == nil:
+		case ws.IsAligned():
+			err = game.ErrClockAligned
+		default:
+			ws.SetSeason(season)
+			err = nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seasonRequest{Season: season.String()}, nil
+}
+
+// careRequest is the body of the care endpoint
+type careRequest struct {
+	Pet    string `json:"pet"`    // Pet name or ID
+	Action string `json:"action"` // One of feed, pet, play, groom, train, comfort or reward
+}
+
+// careResult is the response of the care endpoint
+type careResult struct {
+	Pet      string   `json:"pet"`
+	Action   string   `json:"action"`
+	Reaction string   `json:"reaction"`          // How the pet took it, in words
+	Jealous  []string `json:"jealous,omitempty"` // Pets that saw it and felt left out
+}
+
+// care gives a pet some care and records who gave it
+func (a *Admin) care(r *http.Request) (interface{}, error) {
+	var req careRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	action := strings.ToLower(strings.TrimSpace(req.Action))
+	kind, ok := careActions[action]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCare, req.Action)
+	}
+
+	result := careResult{Action: action}
+	var petID types.PetID
+	var err error
+	a.Loop.Do(func() {
+		var pet *core.DigitalPet
+		if pet, err = a.Loop.Household.Find(req.Pet); err != nil {
+			return
+		}
+		var reactions []interaction.JealousyReaction
+		if reactions, err = a.Loop.Household.Interact(pet.ID, kind, CareIntensity); err != nil {
+			return
+		}
+		petID, result.Pet = pet.ID, pet.Name
+		result.Reaction = a.Loop.Household.Feedback.Generate(pet, kind)
+		for _, reaction := range reactions {
+			if other, ok := a.Loop.Household.Pets[reaction.PetID]; ok {
+				result.Jealous = append(result.Jealous, other.Name)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	actor := AuditActor
+	if key, ok := Caller(r.Context()); ok {
+		actor += ":" + key.Name
+	}
+	a.Loop.Audit(actor, data.AuditInteract, petID, fmt.Sprintf("%s for %s", kind, result.Pet))
+	return result, nil
+}
+
+// voteRequest is the body of the vote endpoint
+type voteRequest struct {
+	Voter  string `json:"voter"`  // Viewer casting the vote, e.g. a chat user name
+	Pet    string `json:"pet"`    // Pet name or ID
+	Action string `json:"action"` // One of interaction.CrowdActions
+}
+
+// crowdState is the response of the vote and crowd endpoints
+type crowdState struct {
+	RoundEnds time.Time                   `json:"round_ends"`
+	Standings []interaction.CrowdStanding `json:"standings"`
+	Last      *interaction.CrowdResult    `json:"last,omitempty"` // Outcome of the last round with votes
+}
+
+// vote casts a viewer's vote in the current crowd control round
+func (a *Admin) vote(r *http.Request) (interface{}, error) {
+	if a.Loop.Crowd == nil {
+		return nil, ErrNoCrowd
+	}
+	var req voteRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	var petID types.PetID
+	var err error
+	a.Loop.Do(func() {
+		var pet *core.DigitalPet
+		if pet, err = a.Loop.Household.Find(req.Pet); err == nil {
+			petID = pet.ID
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := a.Loop.Crowd.Vote(req.Voter, petID, req.Action, time.Now()); err != nil {
+		return nil, err
+	}
+	return a.crowdState(), nil
+}
+
+// crowd reports the votes in the current round and the last outcome
+func (a *Admin) crowd(*http.Request) (interface{}, error) {
+	if a.Loop.Crowd == nil {
+		return nil, ErrNoCrowd
+	}
+	return a.crowdState(), nil
+}
+
+// crowdState describes crowd control for the vote and crowd endpoints
+func (a *Admin) crowdState() crowdState {
+	return crowdState{
+		RoundEnds: a.Loop.Crowd.RoundEnds(),
+		Standings: a.Loop.Crowd.Standings(),
+		Last:      a.Loop.Crowd.Last(),
+	}
+}
+
+// petResult is one pet found by the pets endpoint
+type petResult struct {
+	ID     types.PetID        `json:"id"`
+	Name   string             `json:"name"`
+	Tags   []string           `json:"tags"`
+	Traits map[string]float64 `json:"traits,omitempty"` // Levels of the traits searched on
+}
+
+// pets searches the household's pets, sorted by name. Query parameter q
+// takes a search as typed at the prompt, e.g. "playful fur>0.7
+// tag:favorite"; name, tag and trait (e.g. trait=fur>0.7) add terms and
+// may be repeated.
+func (a *Admin) pets(r *http.Request) (interface{}, error) {
+	params := r.URL.Query()
+	terms := strings.Fields(params.Get("q"))
+	for _, name := range params["name"] {
+		terms = append(terms, "name:"+name)
+	}
+	for _, tag := range params["tag"] {
+		terms = append(terms, "tag:"+tag)
+	}
+	terms = append(terms, params["trait"]...)
+	search, err := core.ParseSearch(strings.Join(terms, " "))
+	if err != nil {
+		return nil, err
+	}
+
+	results := []petResult{}
+	a.Loop.Do(func() {
+		for _, pet := range a.Loop.Household.FindPets(search) {
+			result := petResult{ID: pet.ID, Name: pet.Name, Tags: append([]string{}, pet.Tags...)}
+			for _, condition := range search.Traits {
+				if result.Traits == nil {
+					result.Traits = make(map[string]float64)
+				}
+				result.Traits[condition.Trait], _ = pet.TraitLevel(condition.Trait)
+			}
+			results = append(results, result)
+		}
+	})
+	return results, nil
+}
+
+// audit lists audit log entries, newest first. Query parameters pet,
+// actor, action, since (RFC 3339) and limit narrow the list.
+func (a *Admin) audit(r *http.Request) (interface{}, error) {
+	if a.Loop.Data == nil {
+		return nil, game.ErrNoStorage
+	}
+	params := r.URL.Query()
+	q := data.AuditQuery{Actor: params.Get("actor"), Action: params.Get("action"), PetID: types.PetID(params.Get("pet"))}
+	if since := params.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, fmt.Errorf("%w: since: %v", ErrBadRequest, err)
+		}
+		q.Since = t
+	}
+	if limit := params.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%w: limit must be a positive number", ErrBadRequest)
+		}
+		q.Limit = n
+	}
+	entries, err := a.Loop.Data.Audit.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []data.AuditEntry{}
+	}
+	return entries, nil
+}
@@ -0,0 +1,366 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+const testToken = "s3cret"
+
+// newTestAdmin creates an admin API over a loop with one pet and weather
+func newTestAdmin(t *testing.T) *Admin {
+	t.Helper()
+	dm, err := data.NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	household := interaction.NewHousehold(core.NewDigitalPet("Mochi", "owner"))
+	household.Weather = environment.NewSeededWeatherSystem(1)
+	loop, err := game.NewGameLoop(config.Default().Simulation, household, dm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { loop.Shutdown(context.Background()) })
+	return NewAdmin(loop, testToken, t.TempDir())
+}
+
+// call sends an authenticated request and decodes the JSON response
+func call(t *testing.T, a *Admin, method, path, body string, out interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: bad JSON %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestAdminRequiresToken(t *testing.T) {
+	a := newTestAdmin(t)
+	for _, header := range []string{"", "Bearer wrong", testToken} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		a.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("Authorization %q: expected 401 with a challenge, got %d", header, rec.Code)
+		}
+	}
+
+	// An empty token must never match an empty header
+	a.Token = ""
+	req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a configured token, got %d", rec.Code)
+	}
+	if err := a.Serve(context.Background(), nil); err != ErrNoAdminToken {
+		t.Errorf("Expected ErrNoAdminToken, got %v", err)
+	}
+}
+
+func TestAdminRoles(t *testing.T) {
+	a := newTestAdmin(t)
+	a.Keys = []APIKey{{Name: "kids", Role: RoleCaretaker, Token: "feed-me"}, {Name: "tv", Role: RoleViewer, Token: "watch"}}
+	request := func(token, method, path, body string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		a.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	tests := []struct {
+		token, method, path, body string
+		want                      int
+	}{
+		{"watch", http.MethodGet, "/admin/stats", "", http.StatusOK},
+		{"watch", http.MethodPost, "/admin/care", `{"pet":"mochi","action":"feed"}`, http.StatusForbidden},
+		{"feed-me", http.MethodPost, "/admin/care", `{"pet":"mochi","action":"feed"}`, http.StatusOK},
+		{"feed-me", http.MethodPost, "/admin/pause", "", http.StatusForbidden},
+		{"feed-me", http.MethodPost, "/admin/backup", "", http.StatusForbidden},
+		{"feed-me", http.MethodGet, "/admin/audit", "", http.StatusForbidden},
+		{"nobody", http.MethodGet, "/admin/stats", "", http.StatusUnauthorized},
+		{testToken, http.MethodGet, "/admin/audit", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		if code := request(tt.token, tt.method, tt.path, tt.body); code != tt.want {
+			t.Errorf("%s %s with %q: expected %d, got %d", tt.method, tt.path, tt.token, tt.want, code)
+		}
+	}
+	if a.Loop.Time.IsPausedState() {
+		t.Error("A caretaker must not be able to pause the game")
+	}
+
+	var entries []data.AuditEntry
+	call(t, a, http.MethodGet, "/admin/audit?actor=api:kids", "", &entries)
+	if len(entries) != 1 || entries[0].Summary != "Feeding for Mochi" {
+		t.Errorf("Expected the caretaker's feeding in the audit log, got %+v", entries)
+	}
+}
+
+func TestAdminCare(t *testing.T) {
+	a := newTestAdmin(t)
+	var result careResult
+	if code := call(t, a, http.MethodPost, "/admin/care", `{"pet":"Mochi","action":"Play"}`, &result); code != http.StatusOK || result.Pet != "Mochi" || result.Action != "play" || !strings.HasPrefix(result.Reaction, "Mochi ") {
+		t.Errorf("Expected Mochi played with, got %d %+v", code, result)
+	}
+	for body, want := range map[string]int{
+		`{"pet":"mochi","action":"discipline"}`: http.StatusBadRequest,
+		`{"pet":"fido","action":"feed"}`:        http.StatusNotFound,
+	} {
+		if code := call(t, a, http.MethodPost, "/admin/care", body, nil); code != want {
+			t.Errorf("%s: expected %d, got %d", body, want, code)
+		}
+	}
+}
+
+func TestAdminHistory(t *testing.T) {
+	a := newTestAdmin(t)
+	var history []game.MinuteStats
+	if code := call(t, a, http.MethodGet, "/admin/history", "", &history); code != http.StatusOK || history == nil {
+		t.Errorf("Expected an empty history, got %d %v", code, history)
+	}
+	if code := call(t, a, http.MethodGet, "/admin/history?minutes=0", "", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for minutes=0, got %d", code)
+	}
+}
+
+func TestAdminRouting(t *testing.T) {
+	a := newTestAdmin(t)
+	if code := call(t, a, http.MethodGet, "/admin/nothing", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected 404, got %d", code)
+	}
+	if code := call(t, a, http.MethodGet, "/stats", "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected 404 outside the admin prefix, got %d", code)
+	}
+	if code := call(t, a, http.MethodGet, "/admin/pause", "", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", code)
+	}
+}
+
+func TestAdminPauseAndTimeScale(t *testing.T) {
+	a := newTestAdmin(t)
+
+	var state pauseState
+	if code := call(t, a, http.MethodPost, "/admin/pause", "", &state); code != http.StatusOK || !state.Paused {
+		t.Fatalf("Expected paused, got %d %+v", code, state)
+	}
+	var stats Stats
+	call(t, a, http.MethodGet, "/admin/stats", "", &stats)
+	if !stats.Paused || stats.Pets != 1 || stats.Alive != 1 || stats.Weather != "Clear" || stats.Season != "Spring" {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+	if call(t, a, http.MethodPost, "/admin/resume", "", &state); state.Paused || a.Loop.Time.IsPausedState() {
+		t.Error("Expected resumed")
+	}
+
+	var scale timeScaleRequest
+	code := call(t, a, http.MethodPost, "/admin/timescale", `{"time_scale":"accelerated_24x"}`, &scale)
+	if code != http.StatusOK || scale.TimeScale != "ACCELERATED_24X" || a.Loop.Time.GetTimeScale() != types.TimeScaleAccelerated24X {
+		t.Errorf("Expected 24x, got %d %+v", code, scale)
+	}
+	if code := call(t, a, http.MethodPost, "/admin/timescale", `{"time_scale":"WARP"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown scale, got %d", code)
+	}
+	if code := call(t, a, http.MethodPost, "/admin/timescale", `not json`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a bad body, got %d", code)
+	}
+}
+
+func TestAdminAlignedClock(t *testing.T) {
+	a := newTestAdmin(t)
+	a.Debug = true
+	a.Loop.AlignToClock(time.UTC, environment.HemisphereNorth, 0)
+
+	if code := call(t, a, http.MethodPost, "/admin/timescale", `{"time_scale":"accelerated_4x"}`, nil); code != http.StatusConflict {
+		t.Errorf("Expected 409 changing aligned time, got %d", code)
+	}
+	if code := call(t, a, http.MethodPost, "/admin/season", `{"season":"winter"}`, nil); code != http.StatusConflict {
+		t.Errorf("Expected 409 changing an aligned season, got %d", code)
+	}
+	if a.Loop.Time.GetTimeScale() != types.TimeScaleRealTime {
+		t.Errorf("Expected real time, got %v", a.Loop.Time.GetTimeScale())
+	}
+}
+
+func TestAdminBackup(t *testing.T) {
+	a := newTestAdmin(t)
+	var result backupResult
+	if code := call(t, a, http.MethodPost, "/admin/backup", "", &result); code != http.StatusOK {
+		t.Fatalf("Backup failed with %d", code)
+	}
+	backup, err := data.NewDataManager(result.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pets, err := backup.LoadAll(context.Background()); err != nil || len(pets) != 1 || pets[0].Name != "Mochi" {
+		t.Errorf("Expected Mochi in the backup, got %v (%v)", pets, err)
+	}
+
+	a.Loop.Data = nil
+	if code := call(t, a, http.MethodPost, "/admin/backup", "", nil); code != http.StatusConflict {
+		t.Errorf("Expected 409 without storage, got %d", code)
+	}
+}
+
+func TestAdminForcingNeedsDebug(t *testing.T) {
+	a := newTestAdmin(t)
+	if code := call(t, a, http.MethodPost, "/admin/weather", `{"weather":"storm"}`, nil); code != http.StatusForbidden {
+		t.Errorf("Expected 403 outside debug mode, got %d", code)
+	}
+
+	a.Debug = true
+	var weather weatherRequest
+	if code := call(t, a, http.MethodPost, "/admin/weather", `{"weather":"storm"}`, &weather); code != http.StatusOK || weather.Weather != "Storm" {
+		t.Errorf("Expected Storm, got %d %+v", code, weather)
+	}
+	var season seasonRequest
+	if code := call(t, a, http.MethodPost, "/admin/season", `{"season":"winter"}`, &season); code != http.StatusOK || season.Season != "Winter" {
+		t.Errorf("Expected Winter, got %d %+v", code, season)
+	}
+	var stats Stats
+	call(t, a, http.MethodGet, "/admin/stats", "", &stats)
+	if stats.Weather != "Storm" || stats.Season != "Winter" {
+		t.Errorf("Expected the forced weather in stats, got %+v", stats)
+	}
+
+	if code := call(t, a, http.MethodPost, "/admin/weather", `{"weather":"meteors"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for unknown weather, got %d", code)
+	}
+	a.Loop.Household.Weather = nil
+	if code := call(t, a, http.MethodPost, "/admin/season", `{"season":"summer"}`, nil); code != http.StatusConflict {
+		t.Errorf("Expected 409 without weather, got %d", code)
+	}
+}
+
+func TestAdminVote(t *testing.T) {
+	a := newTestAdmin(t)
+	vote := `{"voter":"ann","pet":"mochi","action":"feed"}`
+	if code := call(t, a, http.MethodPost, "/admin/vote", vote, nil); code != http.StatusConflict {
+		t.Errorf("Expected 409 without crowd control, got %d", code)
+	}
+
+	a.Loop.Crowd = interaction.NewCrowdControl(time.Minute, time.Minute, time.Hour)
+	var state crowdState
+	if code := call(t, a, http.MethodPost, "/admin/vote", vote, &state); code != http.StatusOK {
+		t.Fatalf("Expected the vote to count, got %d", code)
+	}
+	if len(state.Standings) != 1 || state.Standings[0].Action != "feed" || state.Standings[0].Votes != 1 || state.RoundEnds.IsZero() {
+		t.Errorf("Unexpected crowd state %+v", state)
+	}
+
+	for body, want := range map[string]int{
+		`{"voter":"bo","pet":"mochi","action":"juggle"}`: http.StatusBadRequest,
+		`{"voter":"","pet":"mochi","action":"feed"}`:     http.StatusBadRequest,
+		`{"voter":"bo","pet":"fido","action":"feed"}`:    http.StatusNotFound,
+	} {
+		if code := call(t, a, http.MethodPost, "/admin/vote", body, nil); code != want {
+			t.Errorf("%s: expected %d, got %d", body, want, code)
+		}
+	}
+
+	state = crowdState{}
+	if code := call(t, a, http.MethodGet, "/admin/crowd", "", &state); code != http.StatusOK || len(state.Standings) != 1 || state.Last != nil {
+		t.Errorf("Expected the standings so far, got %d %+v", code, state)
+	}
+}
+
+func TestAdminAudit(t *testing.T) {
+	a := newTestAdmin(t)
+	if code := call(t, a, http.MethodPost, "/admin/backup", "", nil); code != http.StatusOK {
+		t.Fatalf("backup failed with %d", code)
+	}
+	a.Loop.Audit("mqtt", data.AuditInteract, "pet-1", "Feeding for Mochi")
+
+	var entries []data.AuditEntry
+	if code := call(t, a, http.MethodGet, "/admin/audit", "", &entries); code != http.StatusOK || len(entries) != 2 {
+		t.Fatalf("Expected the backup's save and the feeding, got %d %+v", code, entries)
+	}
+	if entries[0].Actor != "mqtt" || entries[1].Action != data.AuditSave {
+		t.Errorf("Expected newest first, got %+v", entries)
+	}
+	entries = nil
+	call(t, a, http.MethodGet, "/admin/audit?actor=mqtt&pet=pet-1&limit=5", "", &entries)
+	if len(entries) != 1 {
+		t.Errorf("Expected the filters applied, got %+v", entries)
+	}
+	if code := call(t, a, http.MethodGet, "/admin/audit?since=yesterday", "", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a bad since, got %d", code)
+	}
+}
+
+func TestAdminServe(t *testing.T) {
+	a := newTestAdmin(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.Serve(ctx, listener) }()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://"+listener.Addr().String()+"/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200, got %d", resp.StatusCode)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected a clean stop, got %v", err)
+	}
+}
+
+func TestAdminPetSearch(t *testing.T) {
+	a := newTestAdmin(t)
+	var mochi *core.DigitalPet
+	a.Loop.Do(func() {
+		for _, pet := range a.Loop.Household.Pets {
+			mochi = pet
+		}
+		mochi.Personality.Traits.Playfulness = 0.9
+	})
+	if err := a.Loop.Household.TagPet(mochi.ID, "favorite"); err != nil {
+		t.Fatal(err)
+	}
+
+	var found []petResult
+	if code := call(t, a, http.MethodGet, "/admin/pets?q=playful&tag=favorite&trait=fur%3E%3D0", "", &found); code != http.StatusOK ||
+		len(found) != 1 || found[0].Name != "Mochi" || found[0].Traits["playfulness"] != 0.9 {
+		t.Errorf("Expected Mochi found with its playfulness, got %d %+v", code, found)
+	}
+	if code := call(t, a, http.MethodGet, "/admin/pets?tag=retired", "", &found); code != http.StatusOK || len(found) != 0 {
+		t.Errorf("Expected no retired pets, got %d %+v", code, found)
+	}
+	if code := call(t, a, http.MethodGet, "/admin/pets?trait=wings%3E0.5", "", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown trait, got %d", code)
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrRemote is returned when the hosting instance refuses a request
+var ErrRemote = errors.New("host refused request")
+
+// Client joins the shared session of an instance serving the admin API.
+// It is a thin client: the host runs the game loop and the client only
+// reads its state and asks it to give care.
+type Client struct {
+	BaseURL string // e.g. http://192.168.1.20:8080
+	Token   string // The member's API key
+	HTTP    *http.Client
+}
+
+// NewClient creates a client for a host given as host:port or a URL
+func NewClient(host, token string) *Client {
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	return &Client{BaseURL: strings.TrimSuffix(host, "/"), Token: token, HTTP: http.DefaultClient}
+}
+
+// Session fetches the live state of the shared session
+func (c *Client) Session(ctx context.Context) (*SessionState, error) {
+	var state SessionState
+	if err := c.do(ctx, http.MethodGet, SessionEndpoint, nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Act gives a pet care. Seen is the newest Seq the member has seen; care
+// over newer care by someone else may be refused.
+func (c *Client) Act(ctx context.Context, pet, action string, seen int64) (*Action, error) {
+	var given Action
+	if err := c.do(ctx, http.MethodPost, ActEndpoint, actRequest{Pet: pet, Action: action, Seen: seen}, &given); err != nil {
+		return nil, err
+	}
+	return &given, nil
+}
+
+// Events calls handle with each event the host streams, as chosen by
+// patterns (DefaultStreamPatterns if none), until ctx is cancelled or the
+// host goes away
+func (c *Client) Events(ctx context.Context, handle func(StreamedEvent), patterns ...string) error {
+	query := url.Values{"pattern": patterns}
+	resp, err := c.send(ctx, http.MethodGet, EventsEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	lines := bufio.NewScanner(resp.Body)
+	for lines.Scan() {
+		payload, ok := strings.CutPrefix(lines.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var e StreamedEvent
+		if err := json.Unmarshal([]byte(payload), &e); err == nil {
+			handle(e)
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return lines.Err()
+}
+
+// do sends a request with an optional JSON body and decodes the response
+func (c *Client) do(ctx context.Context, method, endpoint string, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	resp, err := c.send(ctx, method, endpoint, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// send sends an authenticated request, turning error responses into
+// ErrRemote with the host's message
+func (c *Client) send(ctx context.Context, method, endpoint string, payload []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+AdminPrefix+endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		var failure struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&failure) != nil || failure.Error == "" {
+			failure.Error = resp.Status
+		}
+		return nil, fmt.Errorf("%w: %s", ErrRemote, failure.Error)
+	}
+	return resp, nil
+}
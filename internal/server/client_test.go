@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient(t *testing.T) {
+	a, _ := newTestSession(t)
+	srv := httptest.NewServer(a)
+	defer srv.Close()
+	mum := NewClient(strings.TrimPrefix(srv.URL, "http://"), "mum-key")
+	kids := NewClient(srv.URL, "kids-key")
+	ctx := context.Background()
+
+	state, err := kids.Session(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Pets) != 1 || state.Pets[0].Name != "Mochi" || state.Seq != 0 {
+		t.Fatalf("Expected Mochi and no care yet, got %+v", state)
+	}
+
+	events := make(chan StreamedEvent, 4)
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go kids.Events(streamCtx, func(e StreamedEvent) { events <- e })
+	// Give the stream a moment to subscribe before caring
+	time.Sleep(50 * time.Millisecond)
+
+	given, err := mum.Act(ctx, "mochi", "feed", state.Seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if given.Seq != 1 || given.Member != "mum" {
+		t.Errorf("Expected mum's care numbered 1, got %+v", given)
+	}
+	select {
+	case e := <-events:
+		if e.Data["member"] != "mum" || e.Data["action"] != "feed" {
+			t.Errorf("Expected mum's care streamed, got %+v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected mum's care on the event stream")
+	}
+
+	if _, err := kids.Act(ctx, "Mochi", "feed", state.Seq); !errors.Is(err, ErrRemote) || !strings.Contains(err.Error(), "mum chose feed") {
+		t.Errorf("Expected the host to refuse care over unseen care, got %v", err)
+	}
+	if _, err := NewClient(srv.URL, "wrong").Session(ctx); !errors.Is(err, ErrRemote) {
+		t.Errorf("Expected ErrRemote for a bad key, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+// Package server runs Gochi as a long-lived, headless pet server.
+//
+// This package provides:
+//   - An authenticated admin API for operators of a running server
+//   - Pausing and resuming the game loop and changing its time scale
+//   - On-demand backups of the saves
+//   - Statistics about the loop and its household
+//   - Forcing weather and seasons, in debug mode only
+//   - Caring for pets and voting on crowd-controlled care
+//   - API keys limited to the viewer, caretaker or admin role
+//   - A server-sent event stream of pet and ambience events
+//
+// The admin API is served alongside the game loop by "gochi serve"; every
+// request must carry the admin token or an API key whose role allows the
+// endpoint.
+package server
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Event stream settings
+const (
+	EventsEndpoint = "events" // Server-sent event stream, relative to AdminPrefix
+	StreamBuffer   = 64       // Events held for a slow client; newer ones are dropped once full
+)
+
+// ErrNoStreaming is returned when the connection cannot stream events
+var ErrNoStreaming = errors.New("streaming is not supported")
+
+// DefaultStreamPatterns are the events streamed when a client names none
+var DefaultStreamPatterns = []string{"pet.*", "ambience.*"}
+
+// StreamedEvent is one event as sent on the event stream
+type StreamedEvent struct {
+	Type  simulation.EventType   `json:"type"`
+	PetID types.PetID            `json:"pet,omitempty"`
+	Data  map[string]interface{} `json:"data,omitempty"`
+}
+
+// newStreamedEvent copies an event for the stream. Errors in its data are
+// sent as their messages.
+func newStreamedEvent(e simulation.Event) StreamedEvent {
+	streamed := StreamedEvent{Type: e.Type, PetID: e.PetID, Data: make(map[string]interface{}, len(e.Data))}
+	for key, value := range e.Data {
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		streamed.Data[key] = value
+	}
+	return streamed
+}
+
+// streamEvents sends game loop events as server-sent events until the
+// client goes away. Repeated pattern query parameters choose the events,
+// e.g. ?pattern=ambience.*; without any, DefaultStreamPatterns are sent.
+// Every role may listen.
+func (a *Admin) streamEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s needs %s", r.URL.Path, http.MethodGet))
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, ErrNoStreaming)
+		return
+	}
+	patterns := r.URL.Query()["pattern"]
+	if len(patterns) == 0 {
+		patterns = DefaultStreamPatterns
+	}
+
+	events := make(chan simulation.Event, StreamBuffer)
+	for _, pattern := range patterns {
+		id := a.Loop.Events.Subscribe(pattern, 0, func(e simulation.Event) {
+			select {
+			case events <- e:
+			default: // The client is not keeping up
+			}
+		})
+		defer a.Loop.Events.Unsubscribe(id)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-events:
+			payload, err := json.Marshal(newStreamedEvent(e))
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, payload); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+func TestEventStream(t *testing.T) {
+	a := newTestAdmin(t)
+	a.Keys = []APIKey{{Name: "tv", Role: RoleViewer, Token: "t0ken"}}
+	srv := httptest.NewServer(a)
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+AdminPrefix+EventsEndpoint+"?pattern=ambience.*", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer t0ken")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	// Events outside the pattern are not streamed
+	a.Loop.Events.Publish(simulation.Event{Type: simulation.EventTick})
+	a.Loop.Events.Publish(simulation.Event{Type: simulation.EventAmbience,
+		Data: map[string]interface{}{"cue": string(simulation.CueRainStart)}})
+
+	lines := bufio.NewScanner(resp.Body)
+	var event, payload string
+	for lines.Scan() && (event == "" || payload == "") {
+		line := lines.Text()
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			event = name
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			payload = data
+		}
+	}
+	if event != string(simulation.EventAmbience) {
+		t.Fatalf("Expected an ambience event first, got %q", event)
+	}
+	var streamed StreamedEvent
+	if err := json.Unmarshal([]byte(payload), &streamed); err != nil {
+		t.Fatal(err)
+	}
+	if streamed.Data["cue"] != string(simulation.CueRainStart) {
+		t.Errorf("Expected the rain_start cue, got %+v", streamed)
+	}
+}
+
+func TestEventStreamNeedsGet(t *testing.T) {
+	a := newTestAdmin(t)
+	if code := call(t, a, http.MethodPost, AdminPrefix+EventsEndpoint, "", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", code)
+	}
+}
+
+func TestStreamedEventSendsErrorMessages(t *testing.T) {
+	streamed := newStreamedEvent(simulation.Event{Type: simulation.EventScript,
+		Data: map[string]interface{}{"error": ErrBadRequest}})
+	if streamed.Data["error"] != ErrBadRequest.Error() {
+		t.Errorf("Expected the error message, got %+v", streamed.Data)
+	}
+}
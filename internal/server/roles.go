@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidAPIKey is returned when an API key list cannot be understood
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	// ErrForbidden is returned when a key's role does not allow an endpoint
+	ErrForbidden = errors.New("not allowed for this api key")
+)
+
+// Role is what an API key may do. Each role may do everything the roles
+// before it may.
+type Role int
+
+const (
+	RoleViewer    Role = iota // Read statistics, crowd standings and the event stream
+	RoleCaretaker             // Also care for pets and vote
+	RoleAdmin                 // Also pause, change time, back up and read the audit log
+)
+
+// String returns the configuration spelling of a role
+func (r Role) String() string {
+	return [...]string{"viewer", "caretaker", "admin"}[r]
+}
+
+// ParseRole looks up a role by name, ignoring case
+func ParseRole(name string) (Role, error) {
+	for r := RoleViewer; r <= RoleAdmin; r++ {
+		if strings.EqualFold(name, r.String()) {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown role %q", ErrInvalidAPIKey, name)
+}
+
+// AdminKeyName names the configured admin token in the audit log
+const AdminKeyName = "admin"
+
+// APIKey is a bearer token with a role. Its name identifies the caller in
+// the audit log.
+type APIKey struct {
+	Name  string
+	Role  Role
+	Token string
+}
+
+// ParseAPIKeys reads a comma-separated list of name:role:token entries,
+// e.g. "kids:caretaker:s3cret,tv:viewer:t0ken". Names and tokens must be
+// unique, and no key may reuse adminToken, which would make a request with
+// it ambiguous.
+func ParseAPIKeys(list, adminToken string) ([]APIKey, error) {
+	var keys []APIKey
+	names := make(map[string]bool)
+	tokens := make(map[string]bool)
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("%w: %q is not name:role:token", ErrInvalidAPIKey, strings.SplitN(entry, ":", 2)[0])
+		}
+		role, err := ParseRole(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		name := strings.ToLower(parts[0])
+		if names[name] || name == AdminKeyName {
+			return nil, fmt.Errorf("%w: name %q is used twice", ErrInvalidAPIKey, parts[0])
+		}
+		if adminToken != "" && parts[2] == adminToken {
+			return nil, fmt.Errorf("%w: %s shares its token with the admin token", ErrInvalidAPIKey, parts[0])
+		}
+		if tokens[parts[2]] {
+			return nil, fmt.Errorf("%w: %s shares its token with another key", ErrInvalidAPIKey, parts[0])
+		}
+		names[name] = true
+		tokens[parts[2]] = true
+		keys = append(keys, APIKey{Name: parts[0], Role: role, Token: parts[2]})
+	}
+	return keys, nil
+}
+
+// callerKey is the context key of the API key making a request
+type callerKey struct{}
+
+// withCaller attaches the API key making a request to its context
+func withCaller(ctx context.Context, key APIKey) context.Context {
+	return context.WithValue(ctx, callerKey{}, key)
+}
+
+// Caller returns the API key that made a request
+func Caller(ctx context.Context) (APIKey, bool) {
+	key, ok := ctx.Value(callerKey{}).(APIKey)
+	return key, ok
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys(" kids:Caretaker:s3cret , tv:viewer:t0k:en,", "adm1n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys[0] != (APIKey{Name: "kids", Role: RoleCaretaker, Token: "s3cret"}) || keys[1].Token != "t0k:en" {
+		t.Errorf("Unexpected keys %+v", keys)
+	}
+	if keys, err := ParseAPIKeys("", "adm1n"); err != nil || len(keys) != 0 {
+		t.Errorf("Expected no keys from an empty list, got %+v (%v)", keys, err)
+	}
+
+	for _, list := range []string{
+		"kids:caretaker",
+		"kids:owner:s3cret",
+		":viewer:s3cret",
+		"kids:viewer:a,Kids:viewer:b",
+		"kids:viewer:a,tv:viewer:a",
+		"admin:viewer:a",
+		"kids:viewer:adm1n",
+	} {
+		if _, err := ParseAPIKeys(list, "adm1n"); !errors.Is(err, ErrInvalidAPIKey) {
+			t.Errorf("%q: expected ErrInvalidAPIKey, got %v", list, err)
+		}
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	for r := RoleViewer; r <= RoleAdmin; r++ {
+		if parsed, err := ParseRole(r.String()); err != nil || parsed != r {
+			t.Errorf("Expected %s to round-trip, got %v (%v)", r, parsed, err)
+		}
+	}
+	if !(RoleViewer < RoleCaretaker && RoleCaretaker < RoleAdmin) {
+		t.Error("Roles should be ordered from least to most allowed")
+	}
+}
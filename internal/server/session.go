@@ -0,0 +1,239 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Shared session settings
+const (
+	SessionEndpoint = "session"        // Live state of the shared session, relative to AdminPrefix
+	ActEndpoint     = "act"            // Care given by a session member, relative to AdminPrefix
+	PresenceTimeout = 2 * time.Minute  // Members not heard from for this long are no longer listed
+	ConflictWindow  = 10 * time.Second // How long care is refused from members who have not seen the care before it
+	RecentActions   = 20               // Care kept for the session state, newest last
+	SessionActor    = "coop"           // Actor in the audit log, followed by ":" and the member name
+)
+
+// ErrStaleAction is returned when a member cares for a pet another member
+// has just cared for without having seen it
+var ErrStaleAction = errors.New("someone else just cared for this pet")
+
+// Action is care given in the shared session. Seq numbers care in the
+// order it was given, across all pets.
+type Action struct {
+	Seq      int64       `json:"seq"`
+	Member   string      `json:"member"`
+	PetID    types.PetID `json:"pet_id"`
+	Pet      string      `json:"pet"`
+	Action   string      `json:"action"`
+	At       time.Time   `json:"at"`
+	Reaction string      `json:"reaction"`          // How the pet took it, in words
+	Jealous  []string    `json:"jealous,omitempty"` // Pets that saw it and felt left out
+}
+
+// Member is someone connected to the shared session. Members are named
+// after their API key.
+type Member struct {
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// SessionPet is one pet as every member sees it
+type SessionPet struct {
+	ID         types.PetID `json:"id"`
+	Name       string      `json:"name"`
+	Alive      bool        `json:"alive"`
+	Location   string      `json:"location"`
+	Mood       string      `json:"mood"`
+	Wellbeing  float64     `json:"wellbeing"`
+	Nutrition  float64     `json:"nutrition"`
+	Happiness  float64     `json:"happiness"`
+	Energy     float64     `json:"energy"`
+	LastAction int64       `json:"last_action,omitempty"` // Seq of the last care given to the pet
+}
+
+// SessionState is the response of the session endpoint
+type SessionState struct {
+	Seq     int64        `json:"seq"` // Newest care given; send it back as seen when acting
+	Members []Member     `json:"members"`
+	Pets    []SessionPet `json:"pets"`
+	Recent  []Action     `json:"recent"`
+}
+
+// session is the shared session of everyone using the admin API. Care is
+// given one action at a time, in the order it arrives.
+type session struct {
+	mu      sync.Mutex
+	seq     int64
+	recent  []Action
+	last    map[types.PetID]Action // Newest care given to each pet
+	members map[string]Member
+	now     func() time.Time
+}
+
+// clock returns the session's time (must be called with lock held)
+func (s *session) clock() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
+// seen marks a member as present
+func (s *session) seen(key APIKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.members == nil {
+		s.members = make(map[string]Member)
+	}
+	s.members[key.Name] = Member{Name: key.Name, Role: key.Role.String(), LastSeen: s.clock()}
+}
+
+// conflict returns the care a member would be acting over: care given to
+// the pet by someone else, newer than the member has seen and within
+// ConflictWindow (must be called with lock held)
+func (s *session) conflict(member string, petID types.PetID, seen int64) (Action, bool) {
+	last, ok := s.last[petID]
+	if !ok || last.Seq <= seen || last.Member == member {
+		return Action{}, false
+	}
+	return last, s.clock().Sub(last.At) < ConflictWindow
+}
+
+// record numbers care and keeps it (must be called with lock held)
+func (s *session) record(action Action) Action {
+	s.seq++
+	action.Seq, action.At = s.seq, s.clock()
+	if s.last == nil {
+		s.last = make(map[types.PetID]Action)
+	}
+	s.last[action.PetID] = action
+	s.recent = append(s.recent, action)
+	if len(s.recent) > RecentActions {
+		s.recent = s.recent[len(s.recent)-RecentActions:]
+	}
+	return action
+}
+
+// present returns the members heard from within PresenceTimeout, sorted by
+// name (must be called with lock held)
+func (s *session) present() []Member {
+	members := []Member{}
+	now := s.clock()
+	for name, member := range s.members {
+		if now.Sub(member.LastSeen) > PresenceTimeout {
+			delete(s.members, name)
+			continue
+		}
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+	return members
+}
+
+// sessionState reports the pets, the members present and the latest care,
+// all as of the same moment
+func (a *Admin) sessionState(*http.Request) (interface{}, error) {
+	s := &a.session
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := SessionState{Seq: s.seq, Members: s.present(), Pets: []SessionPet{}, Recent: append([]Action{}, s.recent...)}
+	a.Loop.Do(func() {
+		for _, pet := range a.Loop.Household.Pets {
+			vitals := pet.Biology.Vitals
+			state.Pets = append(state.Pets, SessionPet{
+				ID:         pet.ID,
+				Name:       pet.Name,
+				Alive:      pet.IsAlive(),
+				Location:   pet.Location,
+				Mood:       pet.Emotions.GetMoodDescription(),
+				Wellbeing:  vitals.GetOverallWellbeing(),
+				Nutrition:  vitals.Nutrition,
+				Happiness:  vitals.Happiness,
+				Energy:     vitals.Energy,
+				LastAction: s.last[pet.ID].Seq,
+			})
+		}
+	})
+	sort.Slice(state.Pets, func(i, j int) bool {
+		if state.Pets[i].Name != state.Pets[j].Name {
+			return state.Pets[i].Name < state.Pets[j].Name
+		}
+		return state.Pets[i].ID < state.Pets[j].ID
+	})
+	return state, nil
+}
+
+// actRequest is the body of the act endpoint
+type actRequest struct {
+	Pet    string `json:"pet"`    // Pet name or ID
+	Action string `json:"action"` // One of feed, pet, play, groom, train, comfort or reward
+	Seen   int64  `json:"seen"`   // Newest Seq the member has seen
+}
+
+// act gives a pet care on behalf of a session member. Care is applied in
+// the order it arrives; care on a pet someone else cared for within
+// ConflictWindow, after the Seq the member last saw, is refused with
+// ErrStaleAction so two members do not, say, both feed the same pet.
+func (a *Admin) act(r *http.Request) (interface{}, error) {
+	var req actRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	action := strings.ToLower(strings.TrimSpace(req.Action))
+	kind, ok := careActions[action]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCare, req.Action)
+	}
+	key, _ := Caller(r.Context())
+
+	s := &a.session
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	given := Action{Member: key.Name, Action: action}
+	var err error
+	a.Loop.Do(func() {
+		var pet *core.DigitalPet
+		if pet, err = a.Loop.Household.Find(req.Pet); err != nil {
+			return
+		}
+		if last, conflicts := s.conflict(key.Name, pet.ID, req.Seen); conflicts {
+			err = fmt.Errorf("%w: %s chose %s for %s %s ago", ErrStaleAction, last.Member, last.Action,
+				pet.Name, s.clock().Sub(last.At).Round(time.Second))
+			return
+		}
+		var reactions []interaction.JealousyReaction
+		if reactions, err = a.Loop.Household.Interact(pet.ID, kind, CareIntensity); err != nil {
+			return
+		}
+		given.PetID, given.Pet = pet.ID, pet.Name
+		given.Reaction = a.Loop.Household.Feedback.Generate(pet, kind)
+		for _, reaction := range reactions {
+			if other, ok := a.Loop.Household.Pets[reaction.PetID]; ok {
+				given.Jealous = append(given.Jealous, other.Name)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	given = s.record(given)
+	a.Loop.Audit(SessionActor+":"+key.Name, data.AuditInteract, given.PetID, fmt.Sprintf("%s for %s", kind, given.Pet))
+	a.Loop.Events.PublishAsync(simulation.Event{Type: simulation.EventPetCare, PetID: given.PetID, Data: map[string]interface{}{
+		"name": given.Pet, "action": given.Action, "member": given.Member, "seq": given.Seq,
+	}})
+	return given, nil
+}
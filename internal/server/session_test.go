@@ -0,0 +1,148 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+// callAs sends a request with a member's token and decodes the response
+func callAs(t *testing.T, a *Admin, token, method, path, body string, out interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: bad JSON %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+// newTestSession creates an admin API with two caretakers and a fake clock
+func newTestSession(t *testing.T) (*Admin, *time.Time) {
+	t.Helper()
+	a := newTestAdmin(t)
+	a.Keys = []APIKey{{Name: "mum", Role: RoleCaretaker, Token: "mum-key"}, {Name: "kids", Role: RoleCaretaker, Token: "kids-key"}}
+	clock := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	a.session.now = func() time.Time { return clock }
+	return a, &clock
+}
+
+func TestSessionState(t *testing.T) {
+	a, clock := newTestSession(t)
+	a.Loop.Do(func() { a.Loop.Household.AddPet(core.NewDigitalPet("Biscuit", "owner")) })
+
+	var state SessionState
+	if code := callAs(t, a, "kids-key", http.MethodGet, AdminPrefix+SessionEndpoint, "", &state); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if len(state.Pets) != 2 || state.Pets[0].Name != "Biscuit" || state.Pets[1].Name != "Mochi" {
+		t.Fatalf("Expected both pets sorted by name, got %+v", state.Pets)
+	}
+	if !state.Pets[0].Alive || state.Pets[0].Wellbeing <= 0 || state.Pets[0].Mood == "" {
+		t.Errorf("Expected the pet's live condition, got %+v", state.Pets[0])
+	}
+	if len(state.Members) != 1 || state.Members[0].Name != "kids" || state.Members[0].Role != "caretaker" {
+		t.Errorf("Expected the caller listed as connected, got %+v", state.Members)
+	}
+
+	// Members drop out once they have not been heard from for a while
+	callAs(t, a, "mum-key", http.MethodGet, AdminPrefix+"stats", "", nil)
+	*clock = clock.Add(PresenceTimeout + time.Second)
+	callAs(t, a, "mum-key", http.MethodGet, AdminPrefix+SessionEndpoint, "", &state)
+	if len(state.Members) != 1 || state.Members[0].Name != "mum" {
+		t.Errorf("Expected only mum still connected, got %+v", state.Members)
+	}
+}
+
+func TestSessionActOrdersAndAttributesCare(t *testing.T) {
+	a, _ := newTestSession(t)
+	var events []simulation.Event
+	a.Loop.Events.Subscribe(string(simulation.EventPetCare), 0, func(e simulation.Event) { events = append(events, e) })
+
+	var first, second Action
+	if code := callAs(t, a, "mum-key", http.MethodPost, AdminPrefix+ActEndpoint, `{"pet":"Mochi","action":"feed"}`, &first); code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", code)
+	}
+	if first.Seq != 1 || first.Member != "mum" || first.Pet != "Mochi" || first.Action != "feed" || !strings.HasPrefix(first.Reaction, "Mochi ") {
+		t.Errorf("Expected mum's feeding numbered 1, got %+v", first)
+	}
+	callAs(t, a, "kids-key", http.MethodPost, AdminPrefix+ActEndpoint, `{"pet":"Mochi","action":"play","seen":1}`, &second)
+	if second.Seq != 2 || second.Member != "kids" {
+		t.Errorf("Expected the kids' play numbered 2, got %+v", second)
+	}
+
+	var state SessionState
+	callAs(t, a, "kids-key", http.MethodGet, AdminPrefix+SessionEndpoint, "", &state)
+	if state.Seq != 2 || len(state.Recent) != 2 || state.Recent[1].Seq != 2 || state.Pets[0].LastAction != 2 {
+		t.Errorf("Expected both actions in order, got %+v", state)
+	}
+
+	a.Loop.Events.Flush()
+	if len(events) != 2 || events[0].Data["member"] != "mum" || events[1].Data["seq"] != int64(2) {
+		t.Errorf("Expected a pet.care event for each action, got %+v", events)
+	}
+	var entries []data.AuditEntry
+	call(t, a, http.MethodGet, AdminPrefix+"audit?actor="+SessionActor+":kids", "", &entries)
+	if len(entries) != 1 || entries[0].Summary != "Playing for Mochi" {
+		t.Errorf("Expected the kids' care in the audit log, got %+v", entries)
+	}
+}
+
+func TestSessionActRefusesUnseenCare(t *testing.T) {
+	a, clock := newTestSession(t)
+	callAs(t, a, "mum-key", http.MethodPost, AdminPrefix+ActEndpoint, `{"pet":"Mochi","action":"feed"}`, nil)
+
+	// The kids had not seen mum's feeding, so theirs is refused
+	var refused map[string]string
+	if code := callAs(t, a, "kids-key", http.MethodPost, AdminPrefix+ActEndpoint, `{"pet":"Mochi","action":"feed","seen":0}`, &refused); code != http.StatusConflict {
+		t.Fatalf("Expected 409, got %d", code)
+	}
+	if !strings.Contains(refused["error"], "mum chose feed for Mochi") {
+		t.Errorf("Expected the refusal to say who cared, got %q", refused["error"])
+	}
+
+	// Members are never refused over their own care
+	if code := callAs(t, a, "mum-key", http.MethodPost, AdminPrefix+ActEndpoint, `{"pet":"Mochi","action":"pet"}`, nil); code != http.StatusOK {
+		t.Errorf("Expected mum's own follow-up to be accepted, got %d", code)
+	}
+	// Nor once they have seen it, nor once the window has passed
+	if code := callAs(t, a, "kids-key", http.MethodPost, AdminPrefix+ActEndpoint, `{"pet":"Mochi","action":"play","seen":2}`, nil); code != http.StatusOK {
+		t.Errorf("Expected care after seeing mum's to be accepted, got %d", code)
+	}
+	*clock = clock.Add(ConflictWindow)
+	if code := callAs(t, a, "mum-key", http.MethodPost, AdminPrefix+ActEndpoint, `{"pet":"Mochi","action":"feed","seen":0}`, nil); code != http.StatusOK {
+		t.Errorf("Expected care after the conflict window to be accepted, got %d", code)
+	}
+}
+
+func TestSessionActErrors(t *testing.T) {
+	a, _ := newTestSession(t)
+	a.Keys = append(a.Keys, APIKey{Name: "tv", Role: RoleViewer, Token: "watch"})
+	for _, tt := range []struct {
+		token, body string
+		status      int
+	}{
+		{"kids-key", `{"pet":"Mochi","action":"tickle"}`, http.StatusBadRequest},
+		{"kids-key", `{"pet":"Nobody","action":"feed"}`, http.StatusNotFound},
+		{"kids-key", `not json`, http.StatusBadRequest},
+		{"watch", `{"pet":"Mochi","action":"feed"}`, http.StatusForbidden},
+	} {
+		if code := callAs(t, a, tt.token, http.MethodPost, AdminPrefix+ActEndpoint, tt.body, nil); code != tt.status {
+			t.Errorf("%s %s: expected %d, got %d", tt.token, tt.body, tt.status, code)
+		}
+	}
+	if statusFor(ErrStaleAction) != http.StatusConflict {
+		t.Error("ErrStaleAction must be reported as a conflict")
+	}
+}
@@ -0,0 +1,29 @@
+package simulation
+
+// EventAmbience is published when the sounds of the scene change, so
+// frontends can play them. Data: "cue" and, for cues about one pet, "name".
+const EventAmbience EventType = "ambience.cue"
+
+// Cue names an ambience cue. Frontends map cues to sounds; the terminal
+// shows them as short lines of text.
+type Cue string
+
+const (
+	CueRainStart     Cue = "rain_start"     // Rain begins
+	CueRainStop      Cue = "rain_stop"      // Rain or a storm clears
+	CueThunder       Cue = "thunder"        // A storm rolls in
+	CueSnowfall      Cue = "snowfall"       // Snow begins
+	CueWind          Cue = "wind"           // Fog or cold wind settles in
+	CueDawnBirds     Cue = "dawn_birds"     // Night turns to day
+	CueNightCrickets Cue = "night_crickets" // Day turns to night
+	CuePurr          Cue = "purr"           // A pet becomes deeply content
+	CueSnore         Cue = "snore"          // A pet falls asleep
+)
+
+// AllCues returns every ambience cue
+func AllCues() []Cue {
+	return []Cue{
+		CueRainStart, CueRainStop, CueThunder, CueSnowfall, CueWind,
+		CueDawnBirds, CueNightCrickets, CuePurr, CueSnore,
+	}
+}
@@ -6,7 +6,7 @@
 //   - Needs management system for tracking pet requirements
 //   - Need decay and satisfaction mechanics
 //   - Overall wellbeing calculation
-//   - Event scheduling and management
+//   - An event system with prioritized, wildcard and asynchronous handlers
 //
 // The simulation systems control the pace of the game and ensure that
 // pet needs evolve realistically over time.
@@ -0,0 +1,302 @@
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Event dispatch settings
+const (
+	DefaultEventQueueSize = 256 // Async events that can wait for dispatch
+	MaxRecordedPanics     = 32  // Async handler panics kept until the next flush
+	WildcardEvent         = "*" // Subscribes to every event type
+)
+
+var (
+	// ErrEventQueueFull is returned when an async event is dropped because
+	// the queue is full
+	ErrEventQueueFull = errors.New("event queue is full")
+	// ErrEventSystemClosed is returned when an event is published after Close
+	ErrEventSystemClosed = errors.New("event system is closed")
+	// ErrHandlerPanic wraps a panic recovered from an event handler
+	ErrHandlerPanic = errors.New("event handler panicked")
+)
+
+// EventType names an event. Types are dot-separated, most general part
+// first, so "pet.*" subscribes to every pet event.
+type EventType string
+
+// Game loop events
+const (
+	EventTick     EventType = "game.tick"     // Data: "days" advanced
+	EventAutoSave EventType = "game.autosave" // Data: "pets" queued for saving
+	EventShutdown EventType = "game.shutdown" // Published once before the final save
+	EventScript   EventType = "game.script"   // Data: "rule" that reacted and "error", if it failed
+	EventPanic    EventType = "game.panic"    // Data: "subsystem", "panic" and "count" in a row; the subsystem is degraded
+	EventStall    EventType = "game.stall"    // Data: "overdue" seconds, whether the pets were "saved" and whether the loop "restarted"
+)
+
+// Pet events, published when a pet's condition changes
+const (
+	EventPetDied     EventType = "pet.died"     // Data: "name" and "cause"
+	EventPetCritical EventType = "pet.critical" // Data: "name" and "needs" ([]string) that became critical
+	EventPetCrowd    EventType = "pet.crowd"    // Data: "name", "action", "votes", "total", "applied" and "reason", if refused
+	EventPetCare     EventType = "pet.care"     // Data: "name", "action", "member" who gave it and "seq" in the shared session
+)
+
+// Data events, published when the data layer finishes an operation,
+// whether it succeeded or not. Data: "pets", "summary" and "error", if it
+// failed; backups add "path" and "size" in bytes, syncs add "conflicts".
+const (
+	EventDataSave      EventType = "data.save"      // Queued saves written
+	EventDataBackup    EventType = "data.backup"    // A backup taken
+	EventDataSync      EventType = "data.sync"      // A sync of every pet
+	EventDataMigration EventType = "data.migration" // Saves moved into the current layout
+)
+
+// Event is something that happened in the simulation
+type Event struct {
+	Type  EventType
+	PetID types.PetID // Empty for events not about one pet
+	Data  map[string]interface{}
+}
+
+// EventHandler reacts to an event
+type EventHandler func(Event)
+
+// SubscriptionID identifies a subscription so it can be removed
+type SubscriptionID uint64
+
+// subscription is one handler registered for a pattern
+type subscription struct {
+	id       SubscriptionID
+	pattern  string
+	priority int
+	handler  EventHandler
+}
+
+// matches returns true if the subscription's pattern covers an event type.
+// A pattern is "*", an exact type, or a prefix ending in ".*".
+func (s *subscription) matches(eventType EventType) bool {
+	if s.pattern == WildcardEvent || s.pattern == string(eventType) {
+		return true
+	}
+	if prefix := strings.TrimSuffix(s.pattern, WildcardEvent); prefix != s.pattern && strings.HasSuffix(prefix, ".") {
+		return strings.HasPrefix(string(eventType), prefix)
+	}
+	return false
+}
+
+// EventSystem delivers events to any number of handlers per type. Handlers
+// run in priority order, highest first, and in subscription order within a
+// priority. A panicking handler is recovered and reported without stopping
+// the remaining handlers or the publisher.
+type EventSystem struct {
+	mu            sync.RWMutex
+	subscriptions []*subscription
+	nextID        SubscriptionID
+
+	queue     chan Event
+	pending   sync.WaitGroup
+	done      chan struct{}
+	closed    bool
+	published int
+	dropped   int
+	panics    int
+	errs      []error
+}
+
+// NewEventSystem creates an event system whose async queue holds up to
+// queueSize events. With a size below 1 async events are dispatched
+// immediately on the publisher's goroutine.
+func NewEventSystem(queueSize int) *EventSystem {
+	es := &EventSystem{}
+	if queueSize > 0 {
+		es.queue = make(chan Event, queueSize)
+		es.done = make(chan struct{})
+		go es.run()
+	}
+	return es
+}
+
+// Subscribe registers a handler for a pattern: an event type, a prefix
+// such as "pet.*", or "*" for everything. Higher priorities run first.
+func (es *EventSystem) Subscribe(pattern string, priority int, handler EventHandler) SubscriptionID {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	es.nextID++
+	es.subscriptions = append(es.subscriptions, &subscription{
+		id:       es.nextID,
+		pattern:  pattern,
+		priority: priority,
+		handler:  handler,
+	})
+	sort.SliceStable(es.subscriptions, func(i, j int) bool {
+		return es.subscriptions[i].priority > es.subscriptions[j].priority
+	})
+	return es.nextID
+}
+
+// Unsubscribe removes a subscription. Returns false if it was not found.
+func (es *EventSystem) Unsubscribe(id SubscriptionID) bool {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	for i, sub := range es.subscriptions {
+		if sub.id == id {
+			es.subscriptions = append(es.subscriptions[:i], es.subscriptions[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Publish delivers an event to every matching handler before returning.
+// Returns the panics recovered from handlers, if any.
+func (es *EventSystem) Publish(event Event) error {
+	es.mu.RLock()
+	closed := es.closed
+	es.mu.RUnlock()
+	if closed {
+		return ErrEventSystemClosed
+	}
+	es.mu.Lock()
+	es.published++
+	es.mu.Unlock()
+	return es.dispatch(event)
+}
+
+// PublishAsync queues an event for delivery on the dispatch goroutine and
+// returns immediately. If the queue is full the event is dropped and
+// ErrEventQueueFull is returned, so the publisher never blocks.
+func (es *EventSystem) PublishAsync(event Event) error {
+	if es.queue == nil {
+		return es.Publish(event)
+	}
+
+	es.mu.Lock()
+	if es.closed {
+		es.mu.Unlock()
+		return ErrEventSystemClosed
+	}
+	es.pending.Add(1)
+	select {
+	case es.queue <- event:
+		es.published++
+		es.mu.Unlock()
+		return nil
+	default:
+		es.pending.Done()
+		es.dropped++
+		es.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrEventQueueFull, event.Type)
+	}
+}
+
+// Flush waits until every queued event has been delivered and returns the
+// handler panics recorded since the last flush
+func (es *EventSystem) Flush() error {
+	es.pending.Wait()
+
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	err := errors.Join(es.errs...)
+	es.errs = nil
+	return err
+}
+
+// Close stops accepting events, delivers everything already queued and
+// stops the dispatch goroutine
+func (es *EventSystem) Close() error {
+	es.mu.Lock()
+	if es.closed {
+		es.mu.Unlock()
+		return nil
+	}
+	es.closed = true
+	es.mu.Unlock()
+
+	err := es.Flush()
+	if es.queue != nil {
+		close(es.queue)
+		<-es.done
+	}
+	return err
+}
+
+// Published returns how many events have been accepted for delivery
+func (es *EventSystem) Published() int {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return es.published
+}
+
+// Dropped returns how many async events were dropped because the queue was full
+func (es *EventSystem) Dropped() int {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return es.dropped
+}
+
+// Panics returns how many handler panics have been recovered
+func (es *EventSystem) Panics() int {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+	return es.panics
+}
+
+// run delivers queued events until the queue is closed
+func (es *EventSystem) run() {
+	defer close(es.done)
+	for event := range es.queue {
+		if err := es.dispatch(event); err != nil {
+			es.mu.Lock()
+			if len(es.errs) < MaxRecordedPanics {
+				es.errs = append(es.errs, err)
+			}
+			es.mu.Unlock()
+		}
+		es.pending.Done()
+	}
+}
+
+// dispatch calls the matching handlers in order. The handler list is
+// copied first so handlers may subscribe or unsubscribe while running.
+func (es *EventSystem) dispatch(event Event) error {
+	es.mu.RLock()
+	var handlers []EventHandler
+	for _, sub := range es.subscriptions {
+		if sub.matches(event.Type) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	es.mu.RUnlock()
+
+	var problems []error
+	for _, handler := range handlers {
+		if err := es.call(handler, event); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// call runs one handler, turning a panic into an error
+func (es *EventSystem) call(handler EventHandler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			es.mu.Lock()
+			es.panics++
+			es.mu.Unlock()
+			err = fmt.Errorf("%w: %s: %v", ErrHandlerPanic, event.Type, r)
+		}
+	}()
+	handler(event)
+	return nil
+}
@@ -0,0 +1,113 @@
+package simulation
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestEventHandlersRunInPriorityOrder(t *testing.T) {
+	es := NewEventSystem(0)
+	var order []string
+	es.Subscribe("pet.fed", 0, func(Event) { order = append(order, "exact") })
+	es.Subscribe("pet.*", 10, func(Event) { order = append(order, "prefix") })
+	es.Subscribe(WildcardEvent, -5, func(Event) { order = append(order, "wildcard") })
+	es.Subscribe("pet.fed", 0, func(Event) { order = append(order, "exact2") })
+	es.Subscribe("game.*", 20, func(Event) { order = append(order, "other") })
+
+	if err := es.Publish(Event{Type: "pet.fed"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"prefix", "exact", "exact2", "wildcard"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("Expected %v, got %v", want, order)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	es := NewEventSystem(0)
+	calls := 0
+	id := es.Subscribe("pet.fed", 0, func(Event) { calls++ })
+	if !es.Unsubscribe(id) || es.Unsubscribe(id) {
+		t.Fatal("Expected the first unsubscribe to succeed and the second to fail")
+	}
+	es.Publish(Event{Type: "pet.fed"})
+	if calls != 0 {
+		t.Error("Unsubscribed handler should not run")
+	}
+}
+
+func TestHandlerPanicIsIsolated(t *testing.T) {
+	es := NewEventSystem(0)
+	ran := false
+	es.Subscribe("pet.fed", 1, func(Event) { panic("boom") })
+	es.Subscribe("pet.fed", 0, func(Event) { ran = true })
+
+	err := es.Publish(Event{Type: "pet.fed"})
+	if !errors.Is(err, ErrHandlerPanic) {
+		t.Errorf("Expected ErrHandlerPanic, got %v", err)
+	}
+	if !ran || es.Panics() != 1 {
+		t.Errorf("Expected the next handler to run and one panic counted, got %v and %d", ran, es.Panics())
+	}
+}
+
+func TestPublishAsync(t *testing.T) {
+	es := NewEventSystem(8)
+	var mu sync.Mutex
+	var seen []EventType
+	es.Subscribe(WildcardEvent, 0, func(e Event) {
+		mu.Lock()
+		seen = append(seen, e.Type)
+		mu.Unlock()
+	})
+	es.Subscribe("game.tick", 0, func(Event) { panic("boom") })
+
+	for i := 0; i < 3; i++ {
+		if err := es.PublishAsync(Event{Type: EventTick}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := es.Flush(); !errors.Is(err, ErrHandlerPanic) {
+		t.Errorf("Expected the async panics from Flush, got %v", err)
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 events delivered, got %d", len(seen))
+	}
+
+	if err := es.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if err := es.PublishAsync(Event{Type: EventTick}); !errors.Is(err, ErrEventSystemClosed) {
+		t.Errorf("Expected ErrEventSystemClosed, got %v", err)
+	}
+}
+
+func TestPublishAsyncDropsWhenFull(t *testing.T) {
+	es := NewEventSystem(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	es.Subscribe(WildcardEvent, 0, func(Event) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+	})
+
+	es.PublishAsync(Event{Type: EventTick})
+	<-started // The worker is now busy with the first event
+	es.PublishAsync(Event{Type: EventTick})
+	if err := es.PublishAsync(Event{Type: EventTick}); !errors.Is(err, ErrEventQueueFull) {
+		t.Errorf("Expected ErrEventQueueFull, got %v", err)
+	}
+	if es.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", es.Dropped())
+	}
+	if es.Published() != 2 {
+		t.Errorf("Expected 2 published events, got %d", es.Published())
+	}
+	close(release)
+	es.Close()
+}
@@ -165,21 +165,7 @@ func NewNeedsManager() *NeedsManager {
 		Needs: make(map[types.NeedType]*Need),
 	}
 
-	// Initialize all 10 need types
-	needTypes := []types.NeedType{
-		types.NeedHunger,
-		types.NeedThirst,
-		types.NeedSleep,
-		types.NeedExercise,
-		types.NeedSocial,
-		types.NeedMentalStimulation,
-		types.NeedAffection,
-		types.NeedCleanliness,
-		types.NeedMedicalCare,
-		types.NeedExploration,
-	}
-
-	for _, needType := range needTypes {
+	for _, needType := range types.AllNeeds() {
 		nm.Needs[needType] = NewNeed(needType)
 	}
 
@@ -22,6 +22,8 @@ type TimeManager struct {
 	CurrentTimeScale    types.TimeScale // Current time scaling mode
 	TimeScaleMultiplier float64         // Current multiplier (derived from TimeScale)
 	IsPaused            bool            // Whether time is paused
+	Aligned             bool            // Whether time follows the wall clock
+	Location            *time.Location  // Time zone of aligned time
 
 	// Delta time tracking
 	LastDeltaTime       float64 // Last frame's delta time in game seconds
@@ -77,7 +79,11 @@ func (tm *TimeManager) Update() float64 {
 	tm.LastDeltaTime = simDelta
 
 	// Update simulation time
-	tm.CurrentSimTime = tm.CurrentSimTime.Add(time.Duration(simDelta * float64(time.Second)))
+	if tm.Aligned {
+		tm.CurrentSimTime = now.In(tm.Location)
+	} else {
+		tm.CurrentSimTime = tm.CurrentSimTime.Add(time.Duration(simDelta * float64(time.Second)))
+	}
 
 	// Track total simulated days
 	tm.TotalSimulatedDays = tm.CurrentSimTime.Sub(tm.SimulationStartTime).Hours() / 24.0
@@ -116,11 +122,15 @@ func (tm *TimeManager) GetAgeInHours() float64 {
 	return tm.TotalSimulatedDays * 24.0
 }
 
-// SetTimeScale changes the current time scale
+// SetTimeScale changes the current time scale. Aligned time always runs
+// at real time, so the scale is left alone.
 func (tm *TimeManager) SetTimeScale(scale types.TimeScale) {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
+	if tm.Aligned {
+		return
+	}
 	tm.CurrentTimeScale = scale
 	tm.TimeScaleMultiplier = tm.getMultiplierForScale(scale)
 }
@@ -166,6 +176,30 @@ func (tm *TimeManager) TogglePause() bool {
 	return tm.IsPaused
 }
 
+// AlignToClock locks simulation time to the wall clock in a time zone, so
+// game day and night follow the player's own. Time runs at real time from
+// now on; after a pause it jumps back to the clock.
+func (tm *TimeManager) AlignToClock(loc *time.Location) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	now := time.Now()
+	tm.Aligned = true
+	tm.Location = loc
+	tm.CurrentTimeScale = types.TimeScaleRealTime
+	tm.TimeScaleMultiplier = 1.0
+	tm.CurrentSimTime = now.In(loc)
+	tm.LastUpdateRealTime = now
+	tm.TotalSimulatedDays = tm.CurrentSimTime.Sub(tm.SimulationStartTime).Hours() / 24.0
+}
+
+// IsAligned returns whether time follows the wall clock
+func (tm *TimeManager) IsAligned() bool {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.Aligned
+}
+
 // IsPausedState returns whether time is currently paused
 func (tm *TimeManager) IsPausedState() bool {
 	tm.mu.RLock()
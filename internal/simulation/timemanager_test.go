@@ -82,6 +82,31 @@ func TestSetTimeScale(t *testing.T) {
 	}
 }
 
+func TestAlignToClock(t *testing.T) {
+	tm := NewTimeManager(types.TimeScaleAccelerated24X)
+	tm.AdvanceTime(5 * time.Hour)
+	loc := time.FixedZone("test", -7*3600)
+
+	tm.AlignToClock(loc)
+	if !tm.IsAligned() || tm.GetTimeScale() != types.TimeScaleRealTime {
+		t.Fatalf("Expected aligned real time, got %v", tm.GetTimeScale())
+	}
+	tm.SetTimeScale(types.TimeScaleAccelerated4X)
+	if tm.GetTimeScaleMultiplier() != 1.0 {
+		t.Errorf("Expected the time scale to stay at real time, got %.1f", tm.GetTimeScaleMultiplier())
+	}
+
+	tm.Update()
+	now := time.Now().In(loc)
+	if got := tm.GetSimulationTime(); got.Location() != loc || now.Sub(got) > time.Second || got.Sub(now) > time.Second {
+		t.Errorf("Expected the wall clock in %v, got %v", loc, got)
+	}
+	want := float64(now.Hour()) + float64(now.Minute())/60.0
+	if hour := tm.GetTimeOfDay(); hour < want-0.1 || hour > want+0.1 {
+		t.Errorf("Expected time of day near %.2f, got %.2f", want, hour)
+	}
+}
+
 func TestPauseAndResume(t *testing.T) {
 	tm := NewTimeManager(types.TimeScaleRealTime)
 
@@ -0,0 +1,248 @@
+package social
+
+import (
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// DefaultMaxAsymmetry is the asymmetry bound used when none is specified
+const DefaultMaxAsymmetry = 0.2
+
+// ReciprocalType returns the relationship type seen from the other pet's side
+func ReciprocalType(relType types.RelationshipType) types.RelationshipType {
+	switch relType {
+	case types.RelationshipParent:
+		return types.RelationshipOffspring
+	case types.RelationshipOffspring:
+		return types.RelationshipParent
+	default:
+		return relType
+	}
+}
+
+// coordinatedPet holds a registered pet's relationships and asymmetry bound
+type coordinatedPet struct {
+	relationships *SocialRelationships
+	maxAsymmetry  float64 // How far this pet's feelings may differ from the other side
+}
+
+// RelationshipCoordinator keeps reciprocal relationship entries consistent
+// across a group of pets. Both sides of a relationship are created, updated,
+// and decayed together, and the difference between them is bounded by the
+// personalities of the pets involved.
+type RelationshipCoordinator struct {
+	mu sync.Mutex
+
+	pets map[types.PetID]*coordinatedPet
+}
+
+// NewRelationshipCoordinator creates an empty coordinator
+func NewRelationshipCoordinator() *RelationshipCoordinator {
+	return &RelationshipCoordinator{
+		pets: make(map[types.PetID]*coordinatedPet),
+	}
+}
+
+// Register adds a pet's relationships to the coordinator.
+// maxAsymmetry bounds how much this pet's bond may differ from the other side.
+func (rc *RelationshipCoordinator) Register(id types.PetID, relationships *SocialRelationships, maxAsymmetry float64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.pets[id] = &coordinatedPet{
+		relationships: relationships,
+		maxAsymmetry:  clamp(maxAsymmetry, 0.0, 1.0),
+	}
+}
+
+// Unregister removes a pet from the coordinator
+func (rc *RelationshipCoordinator) Unregister(id types.PetID) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	delete(rc.pets, id)
+}
+
+// Introduce creates a relationship on both sides. relType is the type
+// as seen from pet a; pet b receives the reciprocal type.
+func (rc *RelationshipCoordinator) Introduce(a, b types.PetID, relType types.RelationshipType) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	petA, okA := rc.pets[a]
+	petB, okB := rc.pets[b]
+	if !okA || !okB || a == b {
+		return false
+	}
+
+	if !petA.relationships.HasRelationshipWith(b) {
+		petA.relationships.AddRelationship(b, relType)
+	}
+	if !petB.relationships.HasRelationshipWith(a) {
+		petB.relationships.AddRelationship(a, ReciprocalType(relType))
+	}
+
+	rc.enforceBounds(a, b)
+	return true
+}
+
+// RecordInteraction updates both sides of a relationship after the pets interact
+func (rc *RelationshipCoordinator) RecordInteraction(a, b types.PetID, quality float64, gameTime float64) bool {
+	return rc.RecordFeelings(a, b, quality, quality, gameTime)
+}
+
+// RecordFeelings updates each side of a relationship by its own quality,
+// e.g. when one pet minds more than the other, then re-applies the bound
+// on how far they may differ
+func (rc *RelationshipCoordinator) RecordFeelings(a, b types.PetID, qualityA, qualityB float64, gameTime float64) bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	petA, okA := rc.pets[a]
+	petB, okB := rc.pets[b]
+	if !okA || !okB {
+		return false
+	}
+
+	relA, hasA := petA.relationships.GetRelationship(b)
+	relB, hasB := petB.relationships.GetRelationship(a)
+	if !hasA || !hasB {
+		return false
+	}
+
+	relA.Update(qualityA, gameTime)
+	relB.Update(qualityB, gameTime)
+	rc.enforceBounds(a, b)
+	return true
+}
+
+// DecayAll decays every registered pet's relationships and re-applies
+// the asymmetry bounds
+func (rc *RelationshipCoordinator) DecayAll(deltaTime float64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, pet := range rc.pets {
+		pet.relationships.UpdateAll(deltaTime)
+	}
+
+	for id, pet := range rc.pets {
+		for otherID := range pet.relationships.Relationships {
+			if id < otherID {
+				rc.enforceBounds(id, otherID)
+			}
+		}
+	}
+}
+
+// Reconcile repairs one-sided relationships between registered pets by
+// creating the missing reciprocal entry (mirroring the existing side) and
+// clamping any asymmetry beyond the allowed bound. It returns the number
+// of relationships repaired and is intended for migrating existing saves.
+func (rc *RelationshipCoordinator) Reconcile() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	repaired := 0
+
+	for id, pet := range rc.pets {
+		for otherID, rel := range pet.relationships.Relationships {
+			other, registered := rc.pets[otherID]
+			if !registered {
+				continue
+			}
+
+			if !other.relationships.HasRelationshipWith(id) {
+				mirror := other.relationships.AddRelationship(id, ReciprocalType(rel.Type))
+				mirror.BondStrength = rel.BondStrength
+				mirror.Trust = rel.Trust
+				mirror.Affection = rel.Affection
+				mirror.Rivalry = rel.Rivalry
+				mirror.FirstMet = rel.FirstMet
+				mirror.LastInteraction = rel.LastInteraction
+				mirror.TotalInteractions = rel.TotalInteractions
+				repaired++
+			}
+
+			if rc.enforceBounds(id, otherID) {
+				repaired++
+			}
+		}
+	}
+
+	return repaired
+}
+
+// Asymmetry returns the bond strength difference between two pets' views
+// of their relationship
+func (rc *RelationshipCoordinator) Asymmetry(a, b types.PetID) float64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	relA, relB, ok := rc.pair(a, b)
+	if !ok {
+		return 0.0
+	}
+	diff := relA.BondStrength - relB.BondStrength
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff
+}
+
+// pair returns both sides of a relationship (must be called with lock held)
+func (rc *RelationshipCoordinator) pair(a, b types.PetID) (*Relationship, *Relationship, bool) {
+	petA, okA := rc.pets[a]
+	petB, okB := rc.pets[b]
+	if !okA || !okB {
+		return nil, nil, false
+	}
+
+	relA, hasA := petA.relationships.GetRelationship(b)
+	relB, hasB := petB.relationships.GetRelationship(a)
+	if !hasA || !hasB {
+		return nil, nil, false
+	}
+	return relA, relB, true
+}
+
+// enforceBounds pulls both sides of a relationship toward each other so
+// their difference stays within the pair's allowed asymmetry. Returns true
+// if any value was adjusted (must be called with lock held).
+func (rc *RelationshipCoordinator) enforceBounds(a, b types.PetID) bool {
+	relA, relB, ok := rc.pair(a, b)
+	if !ok {
+		return false
+	}
+
+	bound := (rc.pets[a].maxAsymmetry + rc.pets[b].maxAsymmetry) / 2.0
+
+	adjusted := false
+	adjusted = boundPair(&relA.BondStrength, &relB.BondStrength, bound) || adjusted
+	adjusted = boundPair(&relA.Trust, &relB.Trust, bound) || adjusted
+	adjusted = boundPair(&relA.Affection, &relB.Affection, bound) || adjusted
+	adjusted = boundPair(&relA.Rivalry, &relB.Rivalry, bound) || adjusted
+
+	relA.Clamp()
+	relB.Clamp()
+	return adjusted
+}
+
+// boundPair moves two values symmetrically toward their midpoint until
+// they differ by at most bound
+func boundPair(x, y *float64, bound float64) bool {
+	diff := *x - *y
+	if diff <= bound && diff >= -bound {
+		return false
+	}
+
+	mid := (*x + *y) / 2.0
+	if diff > 0 {
+		*x = mid + bound/2.0
+		*y = mid - bound/2.0
+	} else {
+		*x = mid - bound/2.0
+		*y = mid + bound/2.0
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package social
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestReciprocalType(t *testing.T) {
+	if ReciprocalType(types.RelationshipParent) != types.RelationshipOffspring {
+		t.Error("Parent should reciprocate as offspring")
+	}
+	if ReciprocalType(types.RelationshipFriend) != types.RelationshipFriend {
+		t.Error("Friendship should be symmetric")
+	}
+}
+
+func TestCoordinatorIntroduce(t *testing.T) {
+	a := NewSocialRelationships(10)
+	b := NewSocialRelationships(10)
+
+	rc := NewRelationshipCoordinator()
+	rc.Register("a", a, 0.1)
+	rc.Register("b", b, 0.1)
+
+	if !rc.Introduce("a", "b", types.RelationshipParent) {
+		t.Fatal("Introduce should succeed for registered pets")
+	}
+
+	relB, ok := b.GetRelationship("a")
+	if !ok || relB.Type != types.RelationshipOffspring {
+		t.Error("Introduce should create reciprocal entry with reciprocal type")
+	}
+
+	if rc.Introduce("a", "ghost", types.RelationshipFriend) {
+		t.Error("Introduce should fail for unregistered pets")
+	}
+}
+
+func TestCoordinatorRecordInteraction(t *testing.T) {
+	a := NewSocialRelationships(10)
+	b := NewSocialRelationships(10)
+
+	rc := NewRelationshipCoordinator()
+	rc.Register("a", a, 0.1)
+	rc.Register("b", b, 0.1)
+	rc.Introduce("a", "b", types.RelationshipFriend)
+
+	rc.RecordInteraction("a", "b", 1.0, 0.0)
+
+	relA, _ := a.GetRelationship("b")
+	relB, _ := b.GetRelationship("a")
+	if relA.TotalInteractions != 1 || relB.TotalInteractions != 1 {
+		t.Error("Both sides should record the interaction")
+	}
+}
+
+func TestCoordinatorReconcile(t *testing.T) {
+	a := NewSocialRelationships(10)
+	b := NewSocialRelationships(10)
+
+	// One-sided data from before coordination existed
+	rel := a.AddRelationship("b", types.RelationshipFriend)
+	rel.BondStrength = 0.9
+
+	rc := NewRelationshipCoordinator()
+	rc.Register("a", a, 0.1)
+	rc.Register("b", b, 0.1)
+
+	if repaired := rc.Reconcile(); repaired == 0 {
+		t.Error("Reconcile should repair the one-sided relationship")
+	}
+
+	if !b.HasRelationshipWith("a") {
+		t.Fatal("Reconcile should create the missing reciprocal entry")
+	}
+
+	if rc.Asymmetry("a", "b") > 0.1+1e-9 {
+		t.Errorf("Asymmetry should be within bound, got %.3f", rc.Asymmetry("a", "b"))
+	}
+
+	if rc.Reconcile() != 0 {
+		t.Error("Reconcile should be idempotent")
+	}
+}
+
+func TestCoordinatorBoundsAsymmetry(t *testing.T) {
+	a := NewSocialRelationships(10)
+	b := NewSocialRelationships(10)
+
+	rc := NewRelationshipCoordinator()
+	rc.Register("a", a, 0.0)
+	rc.Register("b", b, 0.2)
+	rc.Introduce("a", "b", types.RelationshipFriend)
+
+	relA, _ := a.GetRelationship("b")
+	relA.BondStrength = 1.0
+	rc.DecayAll(0.0)
+
+	if got := rc.Asymmetry("a", "b"); math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("Asymmetry should be bounded by average personality bound 0.1, got %.3f", got)
+	}
+}
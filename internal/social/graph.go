@@ -0,0 +1,255 @@
+package social
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// CliqueBondThreshold is the bond strength needed for two pets to count
+// as connected when searching for cliques
+const CliqueBondThreshold = 0.5
+
+// GraphNode represents a pet in the social graph
+type GraphNode struct {
+	ID   types.PetID `json:"id"`
+	Name string      `json:"name"`
+}
+
+// GraphEdge represents one pet's relationship toward another
+type GraphEdge struct {
+	From         types.PetID            `json:"from"`
+	To           types.PetID            `json:"to"`
+	Type         types.RelationshipType `json:"type"`
+	BondStrength float64                `json:"bond_strength"`
+	Rivalry      float64                `json:"rivalry"`
+}
+
+// SocialGraph aggregates the relationships of many pets into a single
+// directed graph for network-wide analysis
+type SocialGraph struct {
+	Nodes map[types.PetID]GraphNode
+	Edges []GraphEdge
+}
+
+// NewSocialGraph creates an empty social graph
+func NewSocialGraph() *SocialGraph {
+	return &SocialGraph{
+		Nodes: make(map[types.PetID]GraphNode),
+		Edges: make([]GraphEdge, 0),
+	}
+}
+
+// AddPet adds a pet and all of its outgoing relationships to the graph
+func (g *SocialGraph) AddPet(id types.PetID, name string, relationships *SocialRelationships) {
+	g.Nodes[id] = GraphNode{ID: id, Name: name}
+
+	if relationships == nil {
+		return
+	}
+
+	otherIDs := make([]types.PetID, 0, len(relationships.Relationships))
+	for otherID := range relationships.Relationships {
+		otherIDs = append(otherIDs, otherID)
+	}
+	sort.Slice(otherIDs, func(i, j int) bool { return otherIDs[i] < otherIDs[j] })
+
+	for _, otherID := range otherIDs {
+		rel := relationships.Relationships[otherID]
+		g.Edges = append(g.Edges, GraphEdge{
+			From:         id,
+			To:           otherID,
+			Type:         rel.Type,
+			BondStrength: rel.BondStrength,
+			Rivalry:      rel.Rivalry,
+		})
+	}
+}
+
+// Popularity returns the total incoming bond strength for each known pet
+func (g *SocialGraph) Popularity() map[types.PetID]float64 {
+	popularity := make(map[types.PetID]float64)
+	for id := range g.Nodes {
+		popularity[id] = 0.0
+	}
+
+	for _, edge := range g.Edges {
+		if _, known := g.Nodes[edge.To]; known {
+			popularity[edge.To] += edge.BondStrength
+		}
+	}
+
+	return popularity
+}
+
+// MostPopular returns the pet with the highest incoming bond strength
+func (g *SocialGraph) MostPopular() (types.PetID, bool) {
+	var best types.PetID
+	bestScore := -1.0
+	popularity := g.Popularity()
+
+	for _, id := range g.sortedIDs() {
+		if score := popularity[id]; score > bestScore {
+			best = id
+			bestScore = score
+		}
+	}
+
+	return best, bestScore > 0
+}
+
+// IsolatedPets returns pets with no relationships to other pets in the graph
+func (g *SocialGraph) IsolatedPets() []types.PetID {
+	connected := make(map[types.PetID]bool)
+	for _, edge := range g.Edges {
+		if _, known := g.Nodes[edge.To]; known {
+			connected[edge.From] = true
+			connected[edge.To] = true
+		}
+	}
+
+	var isolated []types.PetID
+	for _, id := range g.sortedIDs() {
+		if !connected[id] {
+			isolated = append(isolated, id)
+		}
+	}
+	return isolated
+}
+
+// Cliques returns maximal groups of three or more pets that are all
+// connected to each other with at least CliqueBondThreshold bond strength
+func (g *SocialGraph) Cliques() [][]types.PetID {
+	adjacency := g.undirectedAdjacency(CliqueBondThreshold)
+
+	var cliques [][]types.PetID
+	var expand func(r, p, x []types.PetID)
+	expand = func(r, p, x []types.PetID) {
+		if len(p) == 0 && len(x) == 0 {
+			if len(r) >= 3 {
+				clique := append([]types.PetID(nil), r...)
+				sort.Slice(clique, func(i, j int) bool { return clique[i] < clique[j] })
+				cliques = append(cliques, clique)
+			}
+			return
+		}
+
+		for len(p) > 0 {
+			v := p[0]
+			expand(append(r, v), intersect(p, adjacency[v]), intersect(x, adjacency[v]))
+			p = p[1:]
+			x = append(x, v)
+		}
+	}
+
+	expand(nil, g.sortedIDs(), nil)
+	return cliques
+}
+
+// undirectedAdjacency builds neighbour sets where either direction meets the threshold
+func (g *SocialGraph) undirectedAdjacency(threshold float64) map[types.PetID]map[types.PetID]bool {
+	adjacency := make(map[types.PetID]map[types.PetID]bool)
+	for id := range g.Nodes {
+		adjacency[id] = make(map[types.PetID]bool)
+	}
+
+	for _, edge := range g.Edges {
+		if edge.From == edge.To || edge.BondStrength < threshold {
+			continue
+		}
+		if _, known := g.Nodes[edge.To]; !known {
+			continue
+		}
+		adjacency[edge.From][edge.To] = true
+		adjacency[edge.To][edge.From] = true
+	}
+
+	return adjacency
+}
+
+// sortedIDs returns node IDs in a stable order
+func (g *SocialGraph) sortedIDs() []types.PetID {
+	ids := make([]types.PetID, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// intersect returns the members of ids that are in set
+func intersect(ids []types.PetID, set map[types.PetID]bool) []types.PetID {
+	var result []types.PetID
+	for _, id := range ids {
+		if set[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// WriteDOT exports the graph in Graphviz DOT format
+func (g *SocialGraph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph social {"); err != nil {
+		return err
+	}
+
+	for _, id := range g.sortedIDs() {
+		if _, err := fmt.Fprintf(w, "  %q [label=%q];\n", id, g.Nodes[id].Name); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range g.Edges {
+		if _, err := fmt.Fprintf(w, "  %q -> %q [label=%q, penwidth=%.1f];\n",
+			edge.From, edge.To, edge.Type.String(), 1.0+edge.BondStrength*3.0); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// ExportJSON exports the graph nodes and edges as JSON
+func (g *SocialGraph) ExportJSON() ([]byte, error) {
+	nodes := make([]GraphNode, 0, len(g.Nodes))
+	for _, id := range g.sortedIDs() {
+		nodes = append(nodes, g.Nodes[id])
+	}
+
+	return json.MarshalIndent(struct {
+		Nodes []GraphNode `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+	}{nodes, g.Edges}, "", "  ")
+}
+
+// Summary returns a human-readable summary of the network
+func (g *SocialGraph) Summary() string {
+	summary := fmt.Sprintf("=== Social Network (%d pets, %d relationships) ===\n", len(g.Nodes), len(g.Edges))
+
+	if id, ok := g.MostPopular(); ok {
+		summary += fmt.Sprintf("Most popular: %s\n", g.Nodes[id].Name)
+	}
+
+	for _, clique := range g.Cliques() {
+		names := make([]string, 0, len(clique))
+		for _, id := range clique {
+			names = append(names, g.Nodes[id].Name)
+		}
+		summary += fmt.Sprintf("Clique: %v\n", names)
+	}
+
+	if isolated := g.IsolatedPets(); len(isolated) > 0 {
+		names := make([]string, 0, len(isolated))
+		for _, id := range isolated {
+			names = append(names, g.Nodes[id].Name)
+		}
+		summary += fmt.Sprintf("Isolated: %v\n", names)
+	}
+
+	return summary
+}
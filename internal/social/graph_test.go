@@ -0,0 +1,106 @@
+package social
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// befriend creates a strong friendship from one pet toward another
+func befriend(rels *SocialRelationships, id types.PetID) {
+	rel := rels.AddRelationship(id, types.RelationshipFriend)
+	rel.BondStrength = 0.8
+}
+
+func buildTestGraph() *SocialGraph {
+	a := NewSocialRelationships(10)
+	b := NewSocialRelationships(10)
+	c := NewSocialRelationships(10)
+	d := NewSocialRelationships(10)
+
+	befriend(a, "b")
+	befriend(a, "c")
+	befriend(b, "c")
+	befriend(d, "c")
+
+	g := NewSocialGraph()
+	g.AddPet("a", "Alpha", a)
+	g.AddPet("b", "Beta", b)
+	g.AddPet("c", "Gamma", c)
+	g.AddPet("d", "Delta", d)
+	g.AddPet("e", "Echo", NewSocialRelationships(10))
+	return g
+}
+
+func TestMostPopular(t *testing.T) {
+	g := buildTestGraph()
+
+	id, ok := g.MostPopular()
+	if !ok || id != "c" {
+		t.Errorf("Expected Gamma to be most popular, got %s", id)
+	}
+}
+
+func TestIsolatedPets(t *testing.T) {
+	g := buildTestGraph()
+
+	isolated := g.IsolatedPets()
+	if len(isolated) != 1 || isolated[0] != "e" {
+		t.Errorf("Expected only Echo to be isolated, got %v", isolated)
+	}
+}
+
+func TestCliques(t *testing.T) {
+	g := buildTestGraph()
+
+	cliques := g.Cliques()
+	if len(cliques) != 1 {
+		t.Fatalf("Expected one clique, got %d: %v", len(cliques), cliques)
+	}
+
+	if len(cliques[0]) != 3 || cliques[0][0] != "a" || cliques[0][2] != "c" {
+		t.Errorf("Expected clique [a b c], got %v", cliques[0])
+	}
+}
+
+func TestGraphExports(t *testing.T) {
+	g := buildTestGraph()
+
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT failed: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "digraph social {") {
+		t.Error("DOT output should start with digraph header")
+	}
+
+	data, err := g.ExportJSON()
+	if err != nil {
+		t.Fatalf("ExportJSON failed: %v", err)
+	}
+
+	var decoded struct {
+		Nodes []GraphNode `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("JSON export should be valid: %v", err)
+	}
+	if len(decoded.Nodes) != 5 || len(decoded.Edges) != 4 {
+		t.Errorf("Expected 5 nodes and 4 edges, got %d and %d", len(decoded.Nodes), len(decoded.Edges))
+	}
+}
+
+func TestGraphSummary(t *testing.T) {
+	summary := buildTestGraph().Summary()
+
+	if !strings.Contains(summary, "Most popular: Gamma") {
+		t.Errorf("Summary should name the most popular pet:\n%s", summary)
+	}
+	if !strings.Contains(summary, "Isolated: [Echo]") {
+		t.Errorf("Summary should list isolated pets:\n%s", summary)
+	}
+}
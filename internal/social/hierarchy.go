@@ -0,0 +1,136 @@
+package social
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ContestType identifies how a dominance contest was decided
+type ContestType int
+
+const (
+	ContestPlay ContestType = iota
+	ContestConflict
+)
+
+// String returns the string representation of ContestType
+func (ct ContestType) String() string {
+	return [...]string{"Play", "Conflict"}[ct]
+}
+
+// PackHierarchy tracks dominance within a group of pets
+type PackHierarchy struct {
+	mu sync.RWMutex
+
+	Dominance map[types.PetID]float64 // Dominance score (0.0 to 1.0)
+}
+
+// NewPackHierarchy creates an empty pack hierarchy
+func NewPackHierarchy() *PackHierarchy {
+	return &PackHierarchy{
+		Dominance: make(map[types.PetID]float64),
+	}
+}
+
+// AddMember adds a pet with an initial dominance score
+func (ph *PackHierarchy) AddMember(petID types.PetID, dominance float64) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+	ph.Dominance[petID] = clamp(dominance, 0.0, 1.0)
+}
+
+// RemoveMember removes a pet from the hierarchy
+func (ph *PackHierarchy) RemoveMember(petID types.PetID) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+	delete(ph.Dominance, petID)
+}
+
+// Order returns pet IDs from highest to lowest rank
+func (ph *PackHierarchy) Order() []types.PetID {
+	ph.mu.RLock()
+	defer ph.mu.RUnlock()
+	return ph.order()
+}
+
+// order returns the rank order (must be called with lock held)
+func (ph *PackHierarchy) order() []types.PetID {
+	ids := make([]types.PetID, 0, len(ph.Dominance))
+	for id := range ph.Dominance {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ph.Dominance[ids[i]] != ph.Dominance[ids[j]] {
+			return ph.Dominance[ids[i]] > ph.Dominance[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// Rank returns a pet's rank (0 = top) and whether it is a member
+func (ph *PackHierarchy) Rank(petID types.PetID) (int, bool) {
+	ph.mu.RLock()
+	defer ph.mu.RUnlock()
+
+	for i, id := range ph.order() {
+		if id == petID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// RecordContest shifts dominance after one pet beats another. Upsets
+// against higher-ranked pets move scores more than expected wins.
+// Conflicts move rank more than play.
+func (ph *PackHierarchy) RecordContest(winner, loser types.PetID, contest ContestType) {
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	w, okW := ph.Dominance[winner]
+	l, okL := ph.Dominance[loser]
+	if !okW || !okL || winner == loser {
+		return
+	}
+
+	k := 0.05
+	if contest == ContestConflict {
+		k = 0.1
+	}
+
+	// Elo-style expected outcome
+	expected := 1.0 / (1.0 + math.Pow(10, (l-w)/0.4))
+	shift := k * (1.0 - expected)
+
+	ph.Dominance[winner] = clamp(w+shift, 0.0, 1.0)
+	ph.Dominance[loser] = clamp(l-shift, 0.0, 1.0)
+}
+
+// AllocateResource divides a scarce resource in rank order. Each pet takes
+// what it wants before the next pet gets access; low-ranked pets may get
+// nothing.
+func (ph *PackHierarchy) AllocateResource(available float64, demand map[types.PetID]float64) map[types.PetID]float64 {
+	ph.mu.RLock()
+	defer ph.mu.RUnlock()
+
+	allocation := make(map[types.PetID]float64)
+	for _, id := range ph.order() {
+		want, wants := demand[id]
+		if !wants {
+			continue
+		}
+
+		take := math.Min(want, available)
+		if take < 0 {
+			take = 0
+		}
+		allocation[id] = take
+		available -= take
+	}
+
+	return allocation
+}
@@ -0,0 +1,75 @@
+package social
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestHierarchyOrder(t *testing.T) {
+	ph := NewPackHierarchy()
+	ph.AddMember("low", 0.2)
+	ph.AddMember("high", 0.9)
+	ph.AddMember("mid", 0.5)
+
+	order := ph.Order()
+	if order[0] != "high" || order[2] != "low" {
+		t.Errorf("Expected high, mid, low order; got %v", order)
+	}
+
+	if rank, ok := ph.Rank("mid"); !ok || rank != 1 {
+		t.Errorf("Expected mid to rank 1, got %d", rank)
+	}
+}
+
+func TestRecordContestUpset(t *testing.T) {
+	ph := NewPackHierarchy()
+	ph.AddMember("top", 0.8)
+	ph.AddMember("under", 0.2)
+
+	ph.RecordContest("top", "under", ContestPlay)
+	expectedShift := ph.Dominance["top"] - 0.8
+
+	ph.AddMember("top", 0.8)
+	ph.AddMember("under", 0.2)
+	ph.RecordContest("under", "top", ContestPlay)
+	upsetShift := ph.Dominance["under"] - 0.2
+
+	if upsetShift <= expectedShift {
+		t.Error("An upset should shift dominance more than an expected win")
+	}
+}
+
+func TestConflictShiftsMoreThanPlay(t *testing.T) {
+	play := NewPackHierarchy()
+	play.AddMember("a", 0.5)
+	play.AddMember("b", 0.5)
+	play.RecordContest("a", "b", ContestPlay)
+
+	conflict := NewPackHierarchy()
+	conflict.AddMember("a", 0.5)
+	conflict.AddMember("b", 0.5)
+	conflict.RecordContest("a", "b", ContestConflict)
+
+	if conflict.Dominance["a"] <= play.Dominance["a"] {
+		t.Error("Conflicts should shift rank more than play")
+	}
+}
+
+func TestAllocateResource(t *testing.T) {
+	ph := NewPackHierarchy()
+	ph.AddMember("alpha", 0.9)
+	ph.AddMember("omega", 0.1)
+
+	allocation := ph.AllocateResource(0.5, map[types.PetID]float64{
+		"alpha": 0.4,
+		"omega": 0.4,
+	})
+
+	if allocation["alpha"] != 0.4 {
+		t.Errorf("Alpha should eat its fill first, got %.2f", allocation["alpha"])
+	}
+	if diff := allocation["omega"] - 0.1; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Omega should get the remainder, got %.2f", allocation["omega"])
+	}
+}
@@ -23,6 +23,8 @@ type Relationship struct {
 	Affection        float64 // 0.0 to 1.0
 	Rivalry          float64 // 0.0 to 1.0 (conflict level)
 	History          []SharedExperience
+	SummarizedExperiences int     // Older experiences kept only as a count
+	SummarizedImpact      float64 // Total impact of the summarized experiences
 	LastInteraction  time.Time
 	TotalInteractions int
 	FirstMet         time.Time
@@ -77,10 +79,8 @@ func (r *Relationship) AddSharedExperience(description string, gameTime float64,
 
 	r.History = append(r.History, exp)
 
-	// Keep only last 50 experiences
-	if len(r.History) > 50 {
-		r.History = r.History[1:]
-	}
+	// Keep only last 50 experiences in detail
+	r.summarize(50)
 }
 
 // Decay weakens relationships over time without interaction
@@ -253,6 +253,13 @@ func (s *SocialRelationships) HasRelationshipWith(petID types.PetID) bool {
 	return exists
 }
 
+// RemoveRelationship forgets a relationship and returns it, if it existed
+func (s *SocialRelationships) RemoveRelationship(petID types.PetID) (*Relationship, bool) {
+	rel, exists := s.Relationships[petID]
+	delete(s.Relationships, petID)
+	return rel, exists
+}
+
 // removeWeakestRelationship removes the relationship with lowest bond strength
 func (s *SocialRelationships) removeWeakestRelationship() {
 	var weakestID types.PetID
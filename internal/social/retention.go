@@ -0,0 +1,54 @@
+package social
+
+// DefaultMaxSharedExperiences is how many shared experiences a relationship
+// keeps in detail once compacted
+const DefaultMaxSharedExperiences = 20
+
+// summarize folds the oldest shared experiences into aggregate counts
+// until at most max remain (must be given a non-negative max)
+func (r *Relationship) summarize(max int) int {
+	excess := len(r.History) - max
+	if excess <= 0 {
+		return 0
+	}
+	for _, exp := range r.History[:excess] {
+		r.SummarizedExperiences++
+		r.SummarizedImpact += exp.Impact
+	}
+	// Copy so the dropped experiences can be garbage collected
+	r.History = append(make([]SharedExperience, 0, max), r.History[excess:]...)
+	return excess
+}
+
+// Compact keeps only the most recent max shared experiences in detail.
+// Returns how many experiences were summarized.
+func (r *Relationship) Compact(max int) int {
+	if max < 0 {
+		max = 0
+	}
+	return r.summarize(max)
+}
+
+// ExperienceCount returns every shared experience, detailed or summarized
+func (r *Relationship) ExperienceCount() int {
+	return len(r.History) + r.SummarizedExperiences
+}
+
+// Compact compacts every relationship's shared history. Returns how many
+// experiences were summarized.
+func (s *SocialRelationships) Compact(maxPerRelationship int) int {
+	total := 0
+	for _, rel := range s.Relationships {
+		total += rel.Compact(maxPerRelationship)
+	}
+	return total
+}
+
+// ExperienceCount returns how many shared experiences are kept in detail
+func (s *SocialRelationships) ExperienceCount() int {
+	total := 0
+	for _, rel := range s.Relationships {
+		total += len(rel.History)
+	}
+	return total
+}
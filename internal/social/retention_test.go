@@ -0,0 +1,31 @@
+package social
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestRelationshipCompact(t *testing.T) {
+	s := NewSocialRelationships(5)
+	rel := s.AddRelationship("friend", types.RelationshipFriend)
+	for i := 0; i < 60; i++ {
+		rel.AddSharedExperience("played", float64(i), 0.1)
+	}
+	if len(rel.History) != 50 || rel.SummarizedExperiences != 10 {
+		t.Fatalf("Expected 50 detailed and 10 summarized, got %d and %d", len(rel.History), rel.SummarizedExperiences)
+	}
+
+	if summarized := s.Compact(20); summarized != 30 {
+		t.Errorf("Expected 30 more summarized, got %d", summarized)
+	}
+	if rel.ExperienceCount() != 60 || s.ExperienceCount() != 20 {
+		t.Errorf("Expected 60 total and 20 detailed, got %d and %d", rel.ExperienceCount(), s.ExperienceCount())
+	}
+	if rel.History[0].GameTime != 40 {
+		t.Errorf("Expected the most recent experiences kept, oldest is %.0f", rel.History[0].GameTime)
+	}
+	if rel.SummarizedImpact < 3.99 || rel.SummarizedImpact > 4.01 {
+		t.Errorf("Expected summarized impact 4.0, got %.2f", rel.SummarizedImpact)
+	}
+}
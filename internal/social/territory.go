@@ -0,0 +1,115 @@
+package social
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Territory settings
+const (
+	ClaimThreshold     = 0.3  // Claim strength at which a location becomes home range
+	TerritoryDecayRate = 0.05 // Claim strength lost per game day
+)
+
+// TerritoryMap tracks how strongly each pet claims each location
+type TerritoryMap struct {
+	mu sync.RWMutex
+
+	Claims map[string]map[types.PetID]float64 // Location ID -> pet -> claim (0.0 to 1.0)
+}
+
+// NewTerritoryMap creates an empty territory map
+func NewTerritoryMap() *TerritoryMap {
+	return &TerritoryMap{
+		Claims: make(map[string]map[types.PetID]float64),
+	}
+}
+
+// Mark strengthens a pet's claim on a location
+func (tm *TerritoryMap) Mark(petID types.PetID, location string, amount float64) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	claims, exists := tm.Claims[location]
+	if !exists {
+		claims = make(map[types.PetID]float64)
+		tm.Claims[location] = claims
+	}
+	claims[petID] = clamp(claims[petID]+amount, 0.0, 1.0)
+}
+
+// Decay fades all claims over time, dropping those that disappear
+func (tm *TerritoryMap) Decay(deltaTime float64) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	for location, claims := range tm.Claims {
+		for id, strength := range claims {
+			strength -= TerritoryDecayRate * deltaTime
+			if strength <= 0 {
+				delete(claims, id)
+			} else {
+				claims[id] = strength
+			}
+		}
+		if len(claims) == 0 {
+			delete(tm.Claims, location)
+		}
+	}
+}
+
+// RemovePet drops all of a pet's claims
+func (tm *TerritoryMap) RemovePet(petID types.PetID) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	for _, claims := range tm.Claims {
+		delete(claims, petID)
+	}
+}
+
+// Strength returns a pet's claim on a location
+func (tm *TerritoryMap) Strength(petID types.PetID, location string) float64 {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return tm.Claims[location][petID]
+}
+
+// Owner returns the pet with the strongest claim on a location, if any
+// claim reaches ClaimThreshold
+func (tm *TerritoryMap) Owner(location string) (types.PetID, float64, bool) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	var owner types.PetID
+	best := 0.0
+	for id, strength := range tm.Claims[location] {
+		if strength > best || (strength == best && id < owner) {
+			owner, best = id, strength
+		}
+	}
+	return owner, best, best >= ClaimThreshold
+}
+
+// HomeRange returns the locations a pet claims, strongest first
+func (tm *TerritoryMap) HomeRange(petID types.PetID) []string {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	var locations []string
+	for location, claims := range tm.Claims {
+		if claims[petID] >= ClaimThreshold {
+			locations = append(locations, location)
+		}
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		si, sj := tm.Claims[locations[i]][petID], tm.Claims[locations[j]][petID]
+		if si != sj {
+			return si > sj
+		}
+		return locations[i] < locations[j]
+	})
+	return locations
+}
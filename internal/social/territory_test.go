@@ -0,0 +1,42 @@
+package social
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+func TestTerritoryOwnership(t *testing.T) {
+	tm := NewTerritoryMap()
+	a, b := types.PetID("a"), types.PetID("b")
+
+	tm.Mark(a, "forest", 0.2)
+	if _, _, claimed := tm.Owner("forest"); claimed {
+		t.Error("Weak claims should not establish territory")
+	}
+
+	tm.Mark(a, "forest", 0.4)
+	tm.Mark(b, "forest", 0.3)
+	owner, strength, claimed := tm.Owner("forest")
+	if !claimed || owner != a || strength < 0.59 {
+		t.Errorf("Expected a to own forest, got %s (%.2f, %v)", owner, strength, claimed)
+	}
+
+	if got := tm.HomeRange(b); len(got) != 1 || got[0] != "forest" {
+		t.Errorf("Expected b's home range to be [forest], got %v", got)
+	}
+}
+
+func TestTerritoryDecay(t *testing.T) {
+	tm := NewTerritoryMap()
+	tm.Mark("a", "meadow", 0.1)
+
+	tm.Decay(10.0)
+
+	if tm.Strength("a", "meadow") != 0 {
+		t.Error("Unmarked territory should fade away")
+	}
+	if len(tm.Claims) != 0 {
+		t.Error("Empty locations should be dropped")
+	}
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// conditionsAt returns the weather effects at a pet's location. Without a
+// weather system, a mild clear midday is assumed.
+func (s *Shell) conditionsAt(pet *core.DigitalPet) (environment.WeatherEffects, *environment.Location, error) {
+	loc, exists := s.World.Location(pet.Location)
+	if !exists {
+		return environment.WeatherEffects{}, nil, fmt.Errorf("%w: %s", environment.ErrUnknownLocation, pet.Location)
+	}
+	effects := s.outdoors(loc)
+	if loc.ID == environment.HomeLocation {
+		effects = s.Habitat.Apply(effects)
+	}
+	return effects, loc, nil
+}
+
+// outdoors returns the weather out in the open at a location, without the
+// shelter of the habitat
+func (s *Shell) outdoors(loc *environment.Location) environment.WeatherEffects {
+	if s.Weather != nil {
+		return s.Weather.EffectsAt(s.World, loc)
+	}
+	return environment.WeatherEffects{
+		Biome:       loc.Biome,
+		Temperature: 18.0 + loc.Biome.Climate().TemperatureShift,
+		Hour:        12.0,
+	}
+}
+
+// RenderActivities lists each activity's availability
+func RenderActivities(statuses []environment.ActivityStatus) string {
+	var b strings.Builder
+	for _, status := range statuses {
+		switch {
+		case !status.Available:
+			fmt.Fprintf(&b, "  x %-12s unavailable: %s\n", status.Activity, status.Reason)
+		case status.Penalty > 0:
+			fmt.Fprintf(&b, "  ~ %-12s -%.0f%% (%s)\n", status.Activity, status.Penalty*100, status.Reason)
+		default:
+			fmt.Fprintf(&b, "  + %s\n", status.Activity)
+		}
+	}
+	return b.String()
+}
+
+// activitiesCommand handles `activities <pet>`
+func (s *Shell) activitiesCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", usageError("activities <pet>")
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	effects, loc, err := s.conditionsAt(pet)
+	if err != nil {
+		return "", err
+	}
+
+	header := fmt.Sprintf("=== Activities for %s at %s (%s, %s, %.0f°C, %02d:00) ===\n",
+		pet.Name, loc.Name, effects.Season, effects.Weather, effects.Temperature, int(effects.Hour))
+	return header + RenderActivities(core.Activities.Statuses(effects)), nil
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestActivitiesCommand(t *testing.T) {
+	shell := NewShell()
+	rex := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(rex)
+
+	out, err := shell.Execute("activities Rex")
+	if err != nil {
+		t.Fatalf("activities failed: %v", err)
+	}
+	if !strings.Contains(out, "x Stargazing   unavailable: daytime") {
+		t.Errorf("Stargazing should be unavailable at midday:\n%s", out)
+	}
+	if !strings.Contains(out, "+ Outdoor Play") {
+		t.Errorf("Outdoor play should be available:\n%s", out)
+	}
+
+	shell.Weather = environment.NewSeededWeatherSystem(1)
+	rex.Location = "beach"
+	beach, _ := shell.World.Location("beach")
+	shell.Weather.SetWeatherAt(shell.World, beach, environment.WeatherStorm)
+	if _, err := shell.Execute("fish Rex"); !errors.Is(err, environment.ErrActivityUnavailable) {
+		t.Errorf("Expected no fishing in a storm, got %v", err)
+	}
+}
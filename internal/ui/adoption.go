@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// RenderAdoptionCenter lists the pets waiting for a home and the player's coins
+func RenderAdoptionCenter(center *interaction.AdoptionCenter, coins int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Adoption Center (you have %d coins) ===\n", coins)
+	for _, adoptable := range center.Roster {
+		pet := adoptable.Pet
+		fmt.Fprintf(&b, "  %-10s %-8s %4.1f days  %3d coins\n",
+			pet.Name, pet.Biology.GetLifeStage(), pet.GetAge(), adoptable.Fee)
+		fmt.Fprintf(&b, "    %s\n", adoptable.Backstory)
+		if adoptable.Sanctuary {
+			b.WriteString("    From the sanctuary, free to a patron.\n")
+		}
+		fmt.Fprintf(&b, "    Quirk: %s %s.\n", pet.Name, adoptable.Quirk)
+		for _, condition := range adoptable.Conditions {
+			fmt.Fprintf(&b, "    Condition: %s (found at screening)\n", condition)
+		}
+	}
+	b.WriteString("Use `adopt <name>` to bring one home.\n")
+	return b.String()
+}
+
+// adoptCommand handles `adopt [<name>]`
+func (s *Shell) adoptCommand(args []string) (string, error) {
+	if s.Household == nil || s.Household.Adoption == nil {
+		return "", interaction.ErrNoAdoptionCenter
+	}
+
+	switch len(args) {
+	case 0:
+		return RenderAdoptionCenter(s.Household.Adoption, s.Household.Inventory.Coins), nil
+	case 1:
+	default:
+		return "", usageError("adopt [<name>]")
+	}
+
+	adopted, err := s.Household.Adopt(args[0], s.Owner)
+	if err != nil {
+		return "", err
+	}
+	s.AddPet(adopted.Pet)
+	s.audit(data.AuditAdopt, adopted.Pet.ID, "adopted %s for %d coins", adopted.Pet.Name, adopted.Fee)
+	return fmt.Sprintf("You paid %d coins and %s is coming home with you. Welcome, %s!\n",
+		adopted.Fee, adopted.Pet.Name, adopted.Pet.Name), nil
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestAdoptCommand(t *testing.T) {
+	shell := NewShell()
+	shell.Owner = "user123"
+	pet := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(pet)
+	if _, err := shell.Execute("adopt"); !errors.Is(err, interaction.ErrNoAdoptionCenter) {
+		t.Errorf("Expected ErrNoAdoptionCenter, got %v", err)
+	}
+
+	shell.Household = interaction.NewHousehold(pet)
+	shell.Household.Adoption = interaction.NewSeededAdoptionCenter(1)
+	waiting := shell.Household.Adoption.Roster[0]
+
+	listing, err := shell.Execute("adopt")
+	if err != nil {
+		t.Fatalf("adopt failed: %v", err)
+	}
+	for _, want := range []string{"Adoption Center", waiting.Pet.Name, waiting.Backstory, waiting.Quirk} {
+		if !strings.Contains(listing, want) {
+			t.Errorf("Expected %q in the listing, got:\n%s", want, listing)
+		}
+	}
+
+	if _, err := shell.Execute("adopt " + waiting.Pet.Name); err != nil {
+		t.Fatalf("adopt failed: %v", err)
+	}
+	adopted, err := shell.FindPet(waiting.Pet.Name)
+	if err != nil || adopted.Owner != "user123" {
+		t.Errorf("Expected the adopted pet in the shell with its new owner, got %v (%v)", adopted, err)
+	}
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+// cueLines describes each ambience cue as a line of text. Cues about a pet
+// take its name.
+var cueLines = map[simulation.Cue]string{
+	simulation.CueRainStart:     "rain begins to patter on the roof",
+	simulation.CueRainStop:      "the rain eases off and drips from the eaves",
+	simulation.CueThunder:       "thunder rumbles in the distance",
+	simulation.CueSnowfall:      "snow falls softly outside",
+	simulation.CueWind:          "a cold wind sighs past the window",
+	simulation.CueDawnBirds:     "birds start singing at first light",
+	simulation.CueNightCrickets: "crickets chirp in the dark",
+	simulation.CuePurr:          "%s purrs contentedly",
+	simulation.CueSnore:         "%s snores softly",
+}
+
+// RenderCue returns a subtle line of text for an ambience cue, or "" for a
+// cue the terminal does not know
+func RenderCue(cue simulation.Cue, name string) string {
+	line, ok := cueLines[cue]
+	if !ok {
+		return ""
+	}
+	if strings.Contains(line, "%s") {
+		line = fmt.Sprintf(line, name)
+	}
+	return fmt.Sprintf("  ~ %s ~\n", line)
+}
@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/simulation"
+)
+
+func TestRenderCue(t *testing.T) {
+	for _, cue := range simulation.AllCues() {
+		if line := RenderCue(cue, "Mochi"); line == "" || strings.Contains(line, "%!") {
+			t.Errorf("Expected a line for %s, got %q", cue, line)
+		}
+	}
+	if line := RenderCue(simulation.CuePurr, "Mochi"); !strings.Contains(line, "Mochi purrs") {
+		t.Errorf("Expected the purring pet named, got %q", line)
+	}
+	if line := RenderCue("kazoo", ""); line != "" {
+		t.Errorf("Expected unknown cues to stay quiet, got %q", line)
+	}
+}
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI image settings
+const (
+	PNGScale    = 2 // Pixels per font pixel in exported images
+	cellWidth   = glyphWidth + 1
+	cellHeight  = glyphHeight + 2
+	ansiTabStop = 8
+)
+
+// ansiPalette holds the 16 standard terminal colors: the eight normal
+// colors followed by their bright versions
+var ansiPalette = [16]color.RGBA{
+	{0x00, 0x00, 0x00, 0xff}, {0xaa, 0x00, 0x00, 0xff}, {0x00, 0xaa, 0x00, 0xff}, {0xaa, 0x55, 0x00, 0xff},
+	{0x00, 0x00, 0xaa, 0xff}, {0xaa, 0x00, 0xaa, 0xff}, {0x00, 0xaa, 0xaa, 0xff}, {0xaa, 0xaa, 0xaa, 0xff},
+	{0x55, 0x55, 0x55, 0xff}, {0xff, 0x55, 0x55, 0xff}, {0x55, 0xff, 0x55, 0xff}, {0xff, 0xff, 0x55, 0xff},
+	{0x55, 0x55, 0xff, 0xff}, {0xff, 0x55, 0xff, 0xff}, {0x55, 0xff, 0xff, 0xff}, {0xff, 0xff, 0xff, 0xff},
+}
+
+// Default colors of text without color codes
+const (
+	defaultForeground = 7
+	defaultBackground = 0
+)
+
+// ansiCell is one character on the terminal grid with its colors
+type ansiCell struct {
+	r      rune
+	fg, bg int
+}
+
+// ansiState is the current color state while reading ANSI text
+type ansiState struct {
+	fg, bg int
+	bold   bool
+}
+
+// apply updates the state from the parameters of an SGR sequence
+func (st *ansiState) apply(params string) {
+	if params == "" {
+		params = "0"
+	}
+	for _, param := range strings.Split(params, ";") {
+		code, err := strconv.Atoi(param)
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*st = ansiState{fg: defaultForeground, bg: defaultBackground}
+		case code == 1:
+			st.bold = true
+		case code == 22:
+			st.bold = false
+		case code >= 30 && code <= 37:
+			st.fg = code - 30
+		case code == 39:
+			st.fg = defaultForeground
+		case code >= 40 && code <= 47:
+			st.bg = code - 40
+		case code == 49:
+			st.bg = defaultBackground
+		case code >= 90 && code <= 97:
+			st.fg = code - 90 + 8
+		case code >= 100 && code <= 107:
+			st.bg = code - 100 + 8
+		}
+	}
+}
+
+// foreground returns the palette index text is drawn in; bold brightens
+// the normal colors as terminals do
+func (st *ansiState) foreground() int {
+	if st.bold && st.fg < 8 {
+		return st.fg + 8
+	}
+	return st.fg
+}
+
+// parseANSI lays text with SGR color codes out on a grid of cells. Other
+// escape sequences are skipped.
+func parseANSI(text string) [][]ansiCell {
+	st := ansiState{fg: defaultForeground, bg: defaultBackground}
+	rows := [][]ansiCell{nil}
+	for i := 0; i < len(text); {
+		if text[i] == '\x1b' && i+1 < len(text) && text[i+1] == '[' {
+			end := i + 2
+			for end < len(text) && (text[end] < 0x40 || text[end] > 0x7e) {
+				end++
+			}
+			if end < len(text) && text[end] == 'm' {
+				st.apply(text[i+2 : end])
+			}
+			i = end + 1
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		row := &rows[len(rows)-1]
+		switch r {
+		case '\n':
+			rows = append(rows, nil)
+		case '\r':
+		case '\t':
+			*row = append(*row, ansiCell{r: ' ', fg: st.foreground(), bg: st.bg})
+			for len(*row)%ansiTabStop != 0 {
+				*row = append(*row, ansiCell{r: ' ', fg: st.foreground(), bg: st.bg})
+			}
+		default:
+			*row = append(*row, ansiCell{r: r, fg: st.foreground(), bg: st.bg})
+		}
+	}
+	if len(rows) > 1 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+// StripANSI removes color codes, leaving the plain text
+func StripANSI(text string) string {
+	var b strings.Builder
+	for i, row := range parseANSI(text) {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		for _, cell := range row {
+			b.WriteRune(cell.r)
+		}
+	}
+	if strings.HasSuffix(text, "\n") {
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// RenderANSI draws text with ANSI color codes as an image, the way a
+// terminal would show it, scaling each font pixel to scale pixels.
+// Characters outside printable ASCII are drawn as '?'.
+func RenderANSI(text string, scale int) *image.RGBA {
+	if scale < 1 {
+		scale = 1
+	}
+	rows := parseANSI(text)
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, max(cols, 1)*cellWidth*scale, len(rows)*cellHeight*scale))
+	fill(img, img.Bounds(), ansiPalette[defaultBackground])
+	for y, row := range rows {
+		for x, cell := range row {
+			origin := image.Pt(x*cellWidth*scale, y*cellHeight*scale)
+			if cell.bg != defaultBackground {
+				fill(img, image.Rectangle{Min: origin, Max: origin.Add(image.Pt(cellWidth*scale, cellHeight*scale))}, ansiPalette[cell.bg])
+			}
+			drawGlyph(img, origin.Add(image.Pt(0, scale)), cell.r, ansiPalette[cell.fg], scale)
+		}
+	}
+	return img
+}
+
+// WriteANSIPNG writes text with ANSI color codes as a PNG image
+func WriteANSIPNG(w io.Writer, text string) error {
+	return png.Encode(w, RenderANSI(text, PNGScale))
+}
+
+// drawGlyph draws one character of the built-in font with its top left
+// corner at origin
+func drawGlyph(img *image.RGBA, origin image.Point, r rune, c color.RGBA, scale int) {
+	if r < ' ' || r > '~' {
+		r = '?'
+	}
+	glyph := font5x7[r-' ']
+	for col, bits := range glyph {
+		for row := 0; row < glyphHeight; row++ {
+			if bits&(1<<row) == 0 {
+				continue
+			}
+			corner := origin.Add(image.Pt(col*scale, row*scale))
+			fill(img, image.Rectangle{Min: corner, Max: corner.Add(image.Pt(scale, scale))}, c)
+		}
+	}
+}
+
+// fill paints a rectangle in one color
+func fill(img *image.RGBA, rect image.Rectangle, c color.RGBA) {
+	rect = rect.Intersect(img.Bounds())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	got := StripANSI("\x1b[32mgrass\x1b[0m and \x1b[1;97msnow\x1b[0m\n")
+	if got != "grass and snow\n" {
+		t.Errorf("Expected color codes removed, got %q", got)
+	}
+	if got := StripANSI("a\tb"); got != "a       b" {
+		t.Errorf("Expected tabs expanded to the next stop, got %q", got)
+	}
+}
+
+func TestRenderANSI(t *testing.T) {
+	img := RenderANSI("\x1b[31m!\x1b[0m\n\x1b[44m \x1b[0m", 1)
+	if w, h := img.Bounds().Dx(), img.Bounds().Dy(); w != cellWidth || h != 2*cellHeight {
+		t.Fatalf("Expected one column and two rows of cells, got %dx%d", w, h)
+	}
+
+	// The exclamation mark's stem is the middle column of its glyph
+	if got := img.RGBAAt(2, 1); got != ansiPalette[1] {
+		t.Errorf("Expected a red glyph pixel, got %v", got)
+	}
+	if got := img.RGBAAt(0, 1); got != ansiPalette[defaultBackground] {
+		t.Errorf("Expected background beside the glyph, got %v", got)
+	}
+	if got := img.RGBAAt(0, cellHeight+1); got != ansiPalette[4] {
+		t.Errorf("Expected a blue background cell, got %v", got)
+	}
+}
+
+func TestWriteANSIPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteANSIPNG(&buf, "hello\nworld"); err != nil {
+		t.Fatalf("WriteANSIPNG failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("Expected a valid PNG: %v", err)
+	}
+	if w := img.Bounds().Dx(); w != 5*cellWidth*PNGScale {
+		t.Errorf("Expected width of five cells, got %d", w)
+	}
+}
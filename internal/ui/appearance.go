@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// furFor returns the characters drawn along the pet's back and flanks
+func furFor(coat *biology.Coat) (back, side string) {
+	switch {
+	case coat.Stage() == biology.CoatShedding:
+		return "~'~'~", "'"
+	case coat.Stage() == biology.CoatWinter:
+		return "@@@@@", "@"
+	case coat.IsLong():
+		return "~~~~~", "~"
+	default:
+		return "_____", " "
+	}
+}
+
+// RenderPet draws a small ASCII portrait of the pet's current appearance,
+// including any accessories it wears
+func RenderPet(pet *core.DigitalPet) string {
+	coat := pet.Biology.Coat
+	back, side := furFor(coat)
+	chin := "> ^ <"
+	var worn []string
+
+	var b strings.Builder
+	if hat, ok := pet.Wearing(environment.SlotHead); ok {
+		fmt.Fprintf(&b, "    %s\n", hat.Art())
+		worn = append(worn, hat.String())
+	}
+	if collar, ok := pet.Wearing(environment.SlotNeck); ok {
+		chin = collar.Art()
+		worn = append(worn, collar.String())
+	}
+	fmt.Fprintf(&b, "    /\\_/\\  %s\n", back)
+	fmt.Fprintf(&b, "   ( o.o )%s     %s\n", side, side)
+	fmt.Fprintf(&b, "    %s %s_____%s\n", chin, side, side)
+	fmt.Fprintf(&b, "     || ||    || ||\n")
+
+	fmt.Fprintf(&b, "%s - %s", pet.DisplayName(), coat.Stage())
+	if len(worn) > 0 {
+		fmt.Fprintf(&b, ", wearing %s", strings.Join(worn, " and "))
+	}
+	if coat.IsShedding() {
+		b.WriteString(" (brushing season!)")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// lookCommand handles `look <pet>`
+func (s *Shell) lookCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", usageError("look <pet>")
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	return RenderPet(pet), nil
+}
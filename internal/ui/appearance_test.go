@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestLookShowsCoat(t *testing.T) {
+	shell := NewShell()
+	pet := core.NewDigitalPet("Fluff", "user123")
+	pet.Biology.Coat = biology.NewCoat(1.0)
+	pet.Biology.Coat.LooseFur = 0.5
+	shell.AddPet(pet)
+
+	out, err := shell.Execute("look fluff")
+	if err != nil {
+		t.Fatalf("look command failed: %v", err)
+	}
+	if !strings.Contains(out, "brushing season") {
+		t.Error("Shedding pets should be drawn in brushing season")
+	}
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ErrNoStorage is returned by commands that need saves when the shell has none
+var ErrNoStorage = errors.New("no save storage available")
+
+// RenderArchive renders the list of archived pets
+func RenderArchive(pets []*core.DigitalPet) string {
+	var b strings.Builder
+	b.WriteString("=== Archived Pets ===\n")
+	if len(pets) == 0 {
+		b.WriteString("No archived pets.\n")
+		return b.String()
+	}
+	for _, pet := range pets {
+		fmt.Fprintf(&b, "  %-16s age %.1f days (%s)\n", pet.Name, pet.GetAge(), pet.ID)
+	}
+	return b.String()
+}
+
+// archiveCommand handles `archive [<pet>]`
+func (s *Shell) archiveCommand(args []string) (string, error) {
+	if s.Data == nil {
+		return "", ErrNoStorage
+	}
+	ctx := context.Background()
+
+	switch len(args) {
+	case 0:
+		pets, err := s.Data.LoadArchived(ctx)
+		if err != nil && len(pets) == 0 {
+			return "", err
+		}
+		return RenderArchive(pets), nil
+	case 1:
+	default:
+		return "", usageError("archive [<pet>]")
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	if err := s.Data.ArchivePet(ctx, pet); err != nil {
+		return "", err
+	}
+	s.RemovePet(pet.ID)
+	if s.Household != nil {
+		s.Household.RemovePet(pet.ID)
+	}
+	return fmt.Sprintf("%s has been archived. Use `unarchive %s` to bring them back.", pet.Name, pet.Name), nil
+}
+
+// unarchiveCommand handles `unarchive <pet>`
+func (s *Shell) unarchiveCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", usageError("unarchive <pet>")
+	}
+	if s.Data == nil {
+		return "", ErrNoStorage
+	}
+	ctx := context.Background()
+
+	// Archived pets are not in the shell, so look the name up in the archive
+	archived, _ := s.Data.LoadArchived(ctx)
+	id := types.PetID(args[0])
+	for _, pet := range archived {
+		if strings.EqualFold(pet.Name, args[0]) {
+			id = pet.ID
+			break
+		}
+	}
+
+	pet, err := s.Data.UnarchivePet(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	s.AddPet(pet)
+	if s.Household != nil {
+		s.Household.AddPet(pet)
+	}
+	return fmt.Sprintf("%s is back in the household.", pet.Name), nil
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestArchiveCommands(t *testing.T) {
+	dm, err := data.NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pet := core.NewDigitalPet("Rex", "user123")
+	shell := NewShell()
+	shell.Data = dm
+	shell.Household = interaction.NewHousehold(pet)
+	shell.AddPet(pet)
+
+	if _, err := shell.Execute("archive rex"); err != nil {
+		t.Fatalf("archive failed: %v", err)
+	}
+	if _, err := shell.FindPet("rex"); !errors.Is(err, ErrPetNotFound) {
+		t.Error("Expected the archived pet to leave the shell")
+	}
+	if _, ok := shell.Household.Pets[pet.ID]; ok {
+		t.Error("Expected the archived pet to leave the household")
+	}
+
+	out, err := shell.Execute("archive")
+	if err != nil || !strings.Contains(out, "Rex") {
+		t.Errorf("Expected Rex in the archive listing, got %q (%v)", out, err)
+	}
+
+	if _, err := shell.Execute("unarchive rex"); err != nil {
+		t.Fatalf("unarchive failed: %v", err)
+	}
+	if _, err := shell.FindPet("rex"); err != nil {
+		t.Errorf("Expected Rex back in the shell: %v", err)
+	}
+	if _, ok := shell.Household.Pets[pet.ID]; !ok {
+		t.Error("Expected Rex back in the household")
+	}
+}
+
+func TestArchiveWithoutStorage(t *testing.T) {
+	shell := NewShell()
+	shell.AddPet(core.NewDigitalPet("Rex", "user123"))
+	if _, err := shell.Execute("archive rex"); !errors.Is(err, ErrNoStorage) {
+		t.Errorf("Expected ErrNoStorage, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// ActorPlayer is the audit log actor of commands typed at the prompt,
+// followed by ":" and the owner when there is one
+const ActorPlayer = "player"
+
+// auditUsage lists the audit filters
+const auditUsage = "audit [pet=<name>] [actor=<who>] [action=<kind>] [since=<duration>] [limit=<n>]"
+
+// RenderAudit lists audit entries, newest first, followed by how many
+// changes each actor made
+func RenderAudit(entries []data.AuditEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Audit log (%d entries) ===\n", len(entries))
+	if len(entries) == 0 {
+		b.WriteString("  (nothing recorded)\n")
+		return b.String()
+	}
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		counts[entry.Actor]++
+		fmt.Fprintf(&b, "  %s  %-16s %-8s %s\n", entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Actor, entry.Action, entry.Summary)
+	}
+
+	actors := make([]string, 0, len(counts))
+	for actor := range counts {
+		actors = append(actors, actor)
+	}
+	sort.Slice(actors, func(i, j int) bool {
+		if counts[actors[i]] != counts[actors[j]] {
+			return counts[actors[i]] > counts[actors[j]]
+		}
+		return actors[i] < actors[j]
+	})
+	b.WriteString("By actor:")
+	for _, actor := range actors {
+		fmt.Fprintf(&b, " %s %d", actor, counts[actor])
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// auditCommand handles `audit` with optional key=value filters
+func (s *Shell) auditCommand(args []string) (string, error) {
+	if s.Data == nil {
+		return "", ErrNoStorage
+	}
+	var q data.AuditQuery
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || value == "" {
+			return "", usageError(auditUsage)
+		}
+		switch strings.ToLower(key) {
+		case "pet":
+			q.PetID = types.PetID(value)
+			if pet, err := s.FindPet(value); err == nil {
+				q.PetID = pet.ID
+			}
+		case "actor":
+			q.Actor = value
+		case "action":
+			q.Action = value
+		case "since":
+			ago, err := time.ParseDuration(value)
+			if err != nil || ago <= 0 {
+				return "", usageError(auditUsage)
+			}
+			q.Since = time.Now().Add(-ago)
+		case "limit":
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit < 1 {
+				return "", usageError(auditUsage)
+			}
+			q.Limit = limit
+		default:
+			return "", usageError(auditUsage)
+		}
+	}
+	entries, err := s.Data.Audit.Query(q)
+	if err != nil {
+		return "", err
+	}
+	return RenderAudit(entries), nil
+}
+
+// audit records a change made at the prompt when the shell has storage
+func (s *Shell) audit(action string, petID types.PetID, format string, args ...interface{}) {
+	if s.Data == nil {
+		return
+	}
+	actor := ActorPlayer
+	if s.Owner != "" {
+		actor += ":" + string(s.Owner)
+	}
+	s.Data.Audit.Record(data.AuditEntry{Actor: actor, Action: action, PetID: petID, Summary: fmt.Sprintf(format, args...)})
+}
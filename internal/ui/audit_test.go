@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+)
+
+func TestAuditCommand(t *testing.T) {
+	shell := NewShell()
+	if _, err := shell.Execute("audit"); !errors.Is(err, ErrNoStorage) {
+		t.Errorf("Expected ErrNoStorage without saves, got %v", err)
+	}
+
+	dm, err := data.NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	shell.Data = dm
+	shell.Owner = "sam"
+	rex := core.NewDigitalPet("Rex", "sam")
+	shell.AddPet(rex)
+
+	for i := 0; i < 3; i++ {
+		dm.Audit.Record(data.AuditEntry{Actor: "mqtt", Action: data.AuditInteract, PetID: rex.ID, Summary: "Feeding for rex"})
+	}
+	shell.audit(data.AuditInteract, rex.ID, "%s for %s", "Playing", rex.Name)
+	dm.Audit.Record(data.AuditEntry{Actor: "autosave", Action: data.AuditSave, Summary: "queued 1 pets"})
+
+	out, err := shell.Execute("audit pet=rex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "4 entries") || !strings.Contains(out, "By actor: mqtt 3 player:sam 1") {
+		t.Errorf("Expected Rex's four interactions tallied by actor, got:\n%s", out)
+	}
+	if out, _ := shell.Execute("audit actor=player: limit=5"); !strings.Contains(out, "Playing for Rex") || strings.Contains(out, "mqtt") {
+		t.Errorf("Expected only the player's entries, got:\n%s", out)
+	}
+	for _, bad := range []string{"audit rex", "audit limit=0", "audit since=yesterday", "audit colour=red"} {
+		if _, err := shell.Execute(bad); !errors.Is(err, ErrUsage) {
+			t.Errorf("%s: expected ErrUsage, got %v", bad, err)
+		}
+	}
+}
+
+func TestRenderAuditEmpty(t *testing.T) {
+	if out := RenderAudit(nil); !strings.Contains(out, "nothing recorded") {
+		t.Errorf("Expected an empty log noted, got %q", out)
+	}
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+)
+
+// ErrNothingToConfirm is returned by `checkpoint confirm` without a restore waiting
+var ErrNothingToConfirm = errors.New("no checkpoint restore waiting for confirmation")
+
+// checkpointUsage lists the checkpoint subcommands
+const checkpointUsage = "checkpoint [list] | checkpoint save <pet|all> <name> | checkpoint restore <name> | checkpoint confirm | checkpoint delete <name>"
+
+// RenderCheckpoints lists checkpoints, oldest first
+func RenderCheckpoints(checkpoints []data.Checkpoint) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Checkpoints (%d of %d) ===\n", len(checkpoints), data.MaxCheckpoints)
+	if len(checkpoints) == 0 {
+		b.WriteString("  (none yet; try \"checkpoint save all <name>\")\n")
+	}
+	for _, cp := range checkpoints {
+		scope := fmt.Sprintf("%d pet", len(cp.Pets))
+		if len(cp.Pets) != 1 {
+			scope += "s"
+		}
+		if cp.Household {
+			scope = "household, " + scope
+		}
+		fmt.Fprintf(&b, "  %s  %-32s (%s)\n", cp.Created.Local().Format("2006-01-02 15:04"), cp.Name, scope)
+	}
+	return b.String()
+}
+
+// checkpointCommand handles the `checkpoint` command and its subcommands
+func (s *Shell) checkpointCommand(args []string) (string, error) {
+	if s.Data == nil {
+		return "", ErrNoStorage
+	}
+	ctx := context.Background()
+	if len(args) == 0 || (args[0] == "list" && len(args) == 1) {
+		checkpoints, err := s.Data.ListCheckpoints(ctx)
+		if err != nil {
+			return "", err
+		}
+		return RenderCheckpoints(checkpoints), nil
+	}
+
+	switch {
+	case args[0] == "save" && len(args) >= 3:
+		return s.saveCheckpoint(ctx, args[1], strings.Join(args[2:], " "))
+	case args[0] == "restore" && len(args) >= 2:
+		return s.warnRestore(ctx, strings.Join(args[1:], " "))
+	case args[0] == "confirm" && len(args) == 1:
+		return s.restoreCheckpoint(ctx)
+	case args[0] == "delete" && len(args) >= 2:
+		name := strings.Join(args[1:], " ")
+		if err := s.Data.DeleteCheckpoint(ctx, name); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Deleted checkpoint %q.\n", name), nil
+	}
+	return "", usageError(checkpointUsage)
+}
+
+// saveCheckpoint snapshots one pet, or every pet for "all"
+func (s *Shell) saveCheckpoint(ctx context.Context, target, name string) (string, error) {
+	household := strings.EqualFold(target, "all")
+	var pets []*core.DigitalPet
+	if household {
+		s.mu.RLock()
+		pets = append(pets, s.Pets...)
+		s.mu.RUnlock()
+	} else {
+		pet, err := s.FindPet(target)
+		if err != nil {
+			return "", err
+		}
+		pets = append(pets, pet)
+	}
+
+	cp, err := s.Data.SaveCheckpoint(ctx, name, household, pets)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Saved checkpoint %q of %s. Restore it with `checkpoint restore %s`.\n",
+		cp.Name, petNames(pets), cp.Name), nil
+}
+
+// warnRestore explains what restoring a checkpoint would overwrite and
+// waits for `checkpoint confirm`
+func (s *Shell) warnRestore(ctx context.Context, name string) (string, error) {
+	cp, pets, err := s.Data.LoadCheckpoint(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	s.pendingRestore = cp.Name
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "WARNING: restoring %q, taken %s ago, replaces the current state of:\n",
+		cp.Name, time.Since(cp.Created).Round(time.Minute))
+	for _, pet := range pets {
+		if current, err := s.FindPet(string(pet.ID)); err == nil {
+			fmt.Fprintf(&b, "  %-16s age %.1f days now, %.1f days in the checkpoint\n", current.Name, current.GetAge(), pet.GetAge())
+		} else {
+			fmt.Fprintf(&b, "  %-16s not in the household; it will be skipped\n", pet.Name)
+		}
+	}
+	b.WriteString("Everything since then is lost for these pets; other pets are left as they are.\n")
+	b.WriteString("Type `checkpoint confirm` to restore.\n")
+	return b.String(), nil
+}
+
+// restoreCheckpoint replaces the household's pets with their state in the
+// checkpoint waiting for confirmation. The next save writes them.
+func (s *Shell) restoreCheckpoint(ctx context.Context) (string, error) {
+	name := s.pendingRestore
+	if name == "" {
+		return "", ErrNothingToConfirm
+	}
+	s.pendingRestore = ""
+
+	_, pets, err := s.Data.LoadCheckpoint(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	var restored []*core.DigitalPet
+	for _, pet := range pets {
+		if _, err := s.FindPet(string(pet.ID)); err != nil {
+			continue
+		}
+		s.RemovePet(pet.ID)
+		s.AddPet(pet)
+		if s.Household != nil {
+			s.Household.RemovePet(pet.ID)
+			s.Household.AddPet(pet)
+		}
+		restored = append(restored, pet)
+		s.audit(data.AuditRestore, pet.ID, "restored %s from checkpoint %q", pet.Name, name)
+	}
+	if len(restored) == 0 {
+		return fmt.Sprintf("None of the pets in %q are in the household; nothing was restored.\n", name), nil
+	}
+	return fmt.Sprintf("Restored %s from %q.\n", petNames(restored), name), nil
+}
+
+// petNames joins the names of pets for messages
+func petNames(pets []*core.DigitalPet) string {
+	names := make([]string, len(pets))
+	for i, pet := range pets {
+		names[i] = pet.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestCheckpointCommand(t *testing.T) {
+	dm, err := data.NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	shell := NewShell()
+	shell.Data = dm
+	rex := core.NewDigitalPet("Rex", "user123")
+	bella := core.NewDigitalPet("Bella", "user123")
+	shell.AddPet(rex)
+	shell.AddPet(bella)
+	shell.Household = interaction.NewHousehold(rex, bella)
+
+	rex.Biology.Vitals.Happiness = 0.9
+	if _, err := shell.Execute("checkpoint save rex before breeding experiment"); err != nil {
+		t.Fatalf("checkpoint save failed: %v", err)
+	}
+	if _, err := shell.Execute("checkpoint save all whole house"); err != nil {
+		t.Fatalf("checkpoint save all failed: %v", err)
+	}
+	listing, err := shell.Execute("checkpoint")
+	if err != nil || !strings.Contains(listing, "before breeding experiment") || !strings.Contains(listing, "household, 2 pets") {
+		t.Errorf("Expected both checkpoints listed, got %q (%v)", listing, err)
+	}
+
+	rex.Biology.Vitals.Happiness = 0.2
+	if _, err := shell.Execute("checkpoint confirm"); !errors.Is(err, ErrNothingToConfirm) {
+		t.Errorf("Expected ErrNothingToConfirm before a restore, got %v", err)
+	}
+	warning, err := shell.Execute("checkpoint restore before breeding experiment")
+	if err != nil || !strings.Contains(warning, "WARNING") || !strings.Contains(warning, "Rex") {
+		t.Fatalf("Expected a warning naming Rex, got %q (%v)", warning, err)
+	}
+	if current, _ := shell.FindPet("Rex"); current.Biology.Vitals.Happiness != 0.2 {
+		t.Error("Nothing should be restored before confirming")
+	}
+
+	if _, err := shell.Execute("checkpoint confirm"); err != nil {
+		t.Fatalf("checkpoint confirm failed: %v", err)
+	}
+	restored, _ := shell.FindPet("Rex")
+	if restored == rex || restored.Biology.Vitals.Happiness != 0.9 {
+		t.Errorf("Expected Rex as saved in the checkpoint, got happiness %.1f", restored.Biology.Vitals.Happiness)
+	}
+	if shell.Household.Pets[rex.ID] != restored {
+		t.Error("The household should have the restored Rex")
+	}
+	if current, _ := shell.FindPet("Bella"); current != bella {
+		t.Error("Pets not in the checkpoint should be left alone")
+	}
+
+	if _, err := shell.Execute("checkpoint delete whole house"); err != nil {
+		t.Fatalf("checkpoint delete failed: %v", err)
+	}
+	if _, err := shell.Execute("checkpoint restore whole house"); !errors.Is(err, data.ErrCheckpointNotFound) {
+		t.Errorf("Expected ErrCheckpointNotFound after deleting, got %v", err)
+	}
+}
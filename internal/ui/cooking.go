@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// cookUsage lists the cooking subcommands
+const cookUsage = "cook [<ingredient> + <ingredient> [+ <ingredient>] | buy <dairy> | serve <pet> <recipe>]"
+
+// RenderKitchen lists the recipes, the meals waiting to be served and
+// the dairy in stock
+func RenderKitchen(inv *environment.Inventory) string {
+	var b strings.Builder
+	b.WriteString("=== Kitchen ===\n")
+	for _, recipe := range environment.AllRecipes() {
+		var kinds []string
+		for _, kind := range recipe.Ingredients() {
+			kinds = append(kinds, strings.ToLower(kind.String()))
+		}
+		fmt.Fprintf(&b, "  %-15s %-6s %s\n", recipe, recipe.Taste(), strings.Join(kinds, " + "))
+	}
+
+	b.WriteString("Meals:\n")
+	if len(inv.Meals) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, meal := range inv.Meals {
+		fmt.Fprintf(&b, "  %-15s %s %3.0f%% fresh  (%s)\n", meal.Recipe, RenderBar(meal.Freshness(), 10),
+			meal.Freshness()*100, strings.Join(meal.Ingredients, ", "))
+	}
+
+	var dairy []string
+	for _, item := range environment.AllDairy() {
+		if n := inv.Dairy[item]; n > 0 {
+			dairy = append(dairy, fmt.Sprintf("%s x%d", item, n))
+		}
+	}
+	if len(dairy) == 0 {
+		dairy = append(dairy, "(none)")
+	}
+	fmt.Fprintf(&b, "Dairy: %s  (%d coins each)\n", strings.Join(dairy, ", "), environment.DairyPrice)
+	return b.String()
+}
+
+// cookCommand handles the `cook` command and its subcommands
+func (s *Shell) cookCommand(args []string) (string, error) {
+	switch {
+	case len(args) == 0:
+		return RenderKitchen(s.Inventory), nil
+
+	case args[0] == "buy" && len(args) == 2:
+		item, err := environment.ParseDairy(args[1])
+		if err != nil {
+			return "", err
+		}
+		if err := s.Inventory.BuyDairy(item); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Bought %s for %d coins.\n", strings.ToLower(item.String()), environment.DairyPrice), nil
+
+	case args[0] == "serve" && len(args) >= 3:
+		pet, err := s.FindPet(args[1])
+		if err != nil {
+			return "", err
+		}
+		recipe, err := environment.ParseRecipe(strings.Join(args[2:], " "))
+		if err != nil {
+			return "", err
+		}
+		if !pet.CanInteract(types.InteractionFeeding) {
+			return "", fmt.Errorf("%w: %s", interaction.ErrPetHibernating, pet.Name)
+		}
+		meal, err := s.Inventory.TakeMeal(recipe)
+		if err != nil {
+			return "", err
+		}
+		pet.EatMeal(meal)
+		s.audit(data.AuditInteract, pet.ID, "%s for %s: %s", types.InteractionFeeding, pet.Name, strings.ToLower(recipe.String()))
+		reaction := "eats"
+		if pet.TastePreference(recipe.Taste()) >= 1.0 {
+			reaction = "wolfs down"
+		}
+		return fmt.Sprintf("%s %s the %s.\n", pet.Name, reaction, strings.ToLower(recipe.String())), nil
+	}
+
+	var ingredients []environment.Ingredient
+	for _, name := range strings.Split(strings.Join(args, " "), "+") {
+		ingredient, err := environment.ParseIngredient(name)
+		if err != nil {
+			return "", err
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+	if len(ingredients) < 2 {
+		return "", usageError(cookUsage)
+	}
+	meal, err := s.Inventory.Cook(ingredients)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Cooked a %s. Serve it within %.0f days.\n", strings.ToLower(meal.Recipe.String()), environment.MealShelfDays), nil
+}
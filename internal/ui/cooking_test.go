@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestCookCommand(t *testing.T) {
+	shell := NewShell()
+	rex := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(rex)
+	shell.Inventory.Fish["Cod"] = 1
+	shell.Inventory.Produce[environment.CropPumpkins] = 1
+
+	if _, err := shell.Execute("cook cod + pumpkins + milk"); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock without milk, got %v", err)
+	}
+	if _, err := shell.Execute("cook buy milk"); err != nil {
+		t.Fatalf("cook buy failed: %v", err)
+	}
+	if out, err := shell.Execute("cook cod + pumpkins + milk"); err != nil || !strings.Contains(out, "fish pie") {
+		t.Fatalf("Expected a fish pie, got %q (%v)", out, err)
+	}
+	if out, _ := shell.Execute("cook"); !strings.Contains(out, "Fish Pie") || !strings.Contains(out, "100% fresh") {
+		t.Errorf("Kitchen should list the fresh pie:\n%s", out)
+	}
+
+	rex.Biology.Vitals.Nutrition = 0.2
+	if _, err := shell.Execute("cook serve Rex fish pie"); err != nil {
+		t.Fatalf("cook serve failed: %v", err)
+	}
+	if rex.Biology.Vitals.Nutrition <= 0.2 {
+		t.Error("Serving the pie should nourish the pet")
+	}
+	if _, err := shell.Execute("cook serve Rex fish pie"); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected the pie eaten, got %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+// Package ui provides the text-based presentation layer for Gochi.
+//
+// This package provides:
+//   - Console shell with named commands
+//   - Pet lookup by name or ID
+//   - Text views for pet state and genetics
+//   - Side-by-side comparison views
+//
+// The UI systems render game state for the player and translate typed
+// commands into calls on the underlying game systems.
+package ui
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// ErrorMessage explains an error to the player, with advice for the
+// errors they can do something about
+func ErrorMessage(err error) string {
+	advice := ""
+	switch {
+	case data.IsTemporary(err):
+		advice = "your pets are safe locally; try again in a little while"
+	case errors.Is(err, data.ErrConflict):
+		advice = "the pet was changed on another device too; choose which copy to keep"
+	case errors.Is(err, data.ErrCorruptSave):
+		advice = "the save file is damaged and was left untouched; restore it from a backup"
+	case errors.Is(err, data.ErrEncryptedSave):
+		advice = "turn on data.encryption_enabled to read encrypted saves"
+	case errors.Is(err, data.ErrWrongKey):
+		advice = "check the passphrase; \"gochi keys set save\" replaces the one in the keychain"
+	case errors.Is(err, data.ErrPetNotFound), errors.Is(err, ErrPetNotFound):
+		advice = "check the pet's name, or look at the list of pets"
+	case errors.Is(err, data.ErrPetArchived):
+		advice = "use \"unarchive\" to bring the pet back first"
+	case errors.Is(err, game.ErrSubsystemPanic):
+		advice = "this is a bug; the game is still running and your pets were saved"
+	case errors.Is(err, core.ErrBadSearch):
+		advice = "search with words like playful, traits like fur>0.7, tag:<tag> or name:<text>"
+	case errors.Is(err, interaction.ErrFeatureLocked):
+		advice = "type \"progress\" to see what unlocks next"
+	case errors.Is(err, ErrUnknownCommand):
+		advice = "type \"help\" for commands"
+	}
+
+	if advice == "" {
+		return "error: " + err.Error()
+	}
+	return "error: " + err.Error() + " (" + advice + ")"
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+)
+
+func TestErrorMessage(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("mochi: %w", data.ErrCloudUnavailable), "try again"},
+		{fmt.Errorf("%w: mochi", data.ErrConflict), "which copy to keep"},
+		{fmt.Errorf("mochi: %w", data.ErrCorruptSave), "backup"},
+		{data.ErrWrongKey, "gochi keys set save"},
+		{fmt.Errorf("%w: commands: boom", game.ErrSubsystemPanic), "still running"},
+		{fmt.Errorf("%w: rex", ErrPetNotFound), "check the pet's name"},
+		{fmt.Errorf("%w: dance", ErrUnknownCommand), "help"},
+	}
+	for _, c := range cases {
+		if msg := ErrorMessage(c.err); !strings.Contains(msg, c.want) || !strings.Contains(msg, c.err.Error()) {
+			t.Errorf("Expected %q in the message for %v, got %q", c.want, c.err, msg)
+		}
+	}
+	if msg := ErrorMessage(fmt.Errorf("odd")); msg != "error: odd" {
+		t.Errorf("Expected a plain message, got %q", msg)
+	}
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// examUsage describes the exam command
+const examUsage = "exam [<pet> [<skill>]]"
+
+// RenderExams lists the exams booked but not yet held
+func RenderExams(exams []interaction.Exam, pets []*core.DigitalPet) string {
+	names := make(map[string]string, len(pets))
+	for _, pet := range pets {
+		names[string(pet.ID)] = pet.Name
+	}
+	var b strings.Builder
+	b.WriteString("=== Exams ===\n")
+	if len(exams) == 0 {
+		b.WriteString("  (none booked)\n")
+	}
+	for _, exam := range exams {
+		fmt.Fprintf(&b, "  %-12s %-16s in %.1f days\n", names[string(exam.PetID)], exam.Skill, exam.DueIn)
+	}
+	b.WriteString("Use `exam <pet>` to see which skills a pet can be certified in.\n")
+	return b.String()
+}
+
+// RenderCertification shows a pet's certificates and how ready it is for
+// each exam
+func RenderCertification(pet *core.DigitalPet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s's certification ===\n", pet.Name)
+	for _, skill := range ai.AllSkills() {
+		level := pet.Skills.Level(skill)
+		if certificate, ok := pet.Certificate(skill); ok {
+			fmt.Fprintf(&b, "  %-16s %s  certified: %s (%d credits)\n", skill, RenderBar(level, 10), certificate.Title(), certificate.Credit())
+			continue
+		}
+		readiness := fmt.Sprintf("ready, %.0f%% per part over %d parts", pet.ExamPartChance(skill)*100, core.ExamParts)
+		if err := pet.CanTakeExam(skill); errors.Is(err, core.ErrExamCooldown) {
+			readiness = "cooling down after a failed exam"
+		} else if err != nil {
+			readiness = fmt.Sprintf("not yet (needs %.0f%%)", core.ExamSkillThreshold*100)
+		}
+		fmt.Fprintf(&b, "  %-16s %s  %s\n", skill, RenderBar(level, 10), readiness)
+	}
+	return b.String()
+}
+
+// RenderLeaderboard ranks certified pets by their credits
+func RenderLeaderboard(entries []interaction.LeaderboardEntry) string {
+	var b strings.Builder
+	b.WriteString("=== Leaderboard ===\n")
+	if len(entries) == 0 {
+		b.WriteString("  (no certified pets yet; see `exam`)\n")
+	}
+	for i, entry := range entries {
+		fmt.Fprintf(&b, "  %d. %-12s %4d credits  %d certificates\n", i+1, entry.Name, entry.Credits, entry.Certificates)
+	}
+	return b.String()
+}
+
+// examCommand handles `exam [<pet> [<skill>]]`
+func (s *Shell) examCommand(args []string) (string, error) {
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	if len(args) == 0 {
+		return RenderExams(s.Household.Exams(), s.Pets), nil
+	}
+	if len(args) > 2 {
+		return "", usageError(examUsage)
+	}
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 1 {
+		return RenderCertification(pet), nil
+	}
+
+	skill, err := ai.ParseSkill(args[1])
+	if err != nil {
+		return "", err
+	}
+	exam, err := s.Household.ScheduleExam(pet.ID, skill)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s's %s exam is booked for %.0f day from now. All %d parts must be passed, and a failed exam cannot be retaken for %.0f days.\n",
+		pet.Name, exam.Skill, exam.DueIn, core.ExamParts, core.ExamCooldownDays), nil
+}
+
+// leaderboardCommand handles `leaderboard`
+func (s *Shell) leaderboardCommand(args []string) (string, error) {
+	if len(args) != 0 {
+		return "", usageError("leaderboard")
+	}
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	return RenderLeaderboard(s.Household.Leaderboard()), nil
+}
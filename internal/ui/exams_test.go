@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestExamCommand(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "owner-ann")
+	rex.Skills.Levels[ai.SkillAgility] = 0.8
+	shell := marketShell(nil, "ann", rex)
+
+	if out, _ := shell.Execute("exam"); !strings.Contains(out, "(none booked)") {
+		t.Errorf("Expected no exams booked, got:\n%s", out)
+	}
+	out, err := shell.Execute("exam rex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "ready, ") || !strings.Contains(out, "not yet") {
+		t.Errorf("Expected agility ready and other skills not yet, got:\n%s", out)
+	}
+
+	if _, err := shell.Execute("exam rex juggling"); !errors.Is(err, ai.ErrUnknownSkill) {
+		t.Errorf("Expected ErrUnknownSkill, got %v", err)
+	}
+	if _, err := shell.Execute("exam rex obedience"); !errors.Is(err, core.ErrExamNotEligible) {
+		t.Errorf("Expected ErrExamNotEligible, got %v", err)
+	}
+	if _, err := shell.Execute("exam rex agility"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := shell.Execute("exam rex agility"); !errors.Is(err, interaction.ErrExamScheduled) {
+		t.Errorf("Expected ErrExamScheduled, got %v", err)
+	}
+	if out, _ := shell.Execute("exam"); !strings.Contains(out, "Rex") {
+		t.Errorf("Expected Rex's exam listed, got:\n%s", out)
+	}
+	if _, err := shell.Execute("exam rex agility now"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
+
+func TestLeaderboardCommand(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "owner-ann")
+	shell := marketShell(nil, "ann", rex)
+
+	if out, _ := shell.Execute("leaderboard"); !strings.Contains(out, "no certified pets") {
+		t.Errorf("Expected an empty leaderboard, got:\n%s", out)
+	}
+	rex.Certifications = &core.Certifications{
+		Certificates: []core.Certificate{{Skill: ai.SkillAgility, Score: 0.8}},
+	}
+	out, err := shell.Execute("leaderboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "1. Rex") || !strings.Contains(out, "90 credits") {
+		t.Errorf("Expected Rex ranked with 90 credits, got:\n%s", out)
+	}
+	if _, err := shell.Execute("leaderboard now"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+
+	shell.Household = nil
+	if _, err := shell.Execute("leaderboard"); !errors.Is(err, ErrNoHousehold) {
+		t.Errorf("Expected ErrNoHousehold, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// fishUsage lists the fishing subcommands
+const fishUsage = "fish <pet> | fish album | fish feed <pet> <fish>"
+
+// RenderFishAlbum lists every species caught so far and the fish in stock
+func RenderFishAlbum(inv *environment.Inventory) string {
+	names := make([]string, 0, len(inv.FishAlbum))
+	for name := range inv.FishAlbum {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Fish Album (%d species) ===\n", len(names))
+	if len(names) == 0 {
+		b.WriteString("  (nothing caught yet)\n")
+	}
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-12s caught %3d  in stock %3d\n", name, inv.FishAlbum[name], inv.Fish[name])
+	}
+	return b.String()
+}
+
+// fishCommand handles the `fish` command and its subcommands
+func (s *Shell) fishCommand(args []string) (string, error) {
+	switch {
+	case len(args) == 1 && args[0] == "album":
+		return RenderFishAlbum(s.Inventory), nil
+
+	case len(args) >= 3 && args[0] == "feed":
+		pet, err := s.FindPet(args[1])
+		if err != nil {
+			return "", err
+		}
+		fish, err := environment.LookupFish(strings.Join(args[2:], " "))
+		if err != nil {
+			return "", err
+		}
+		if !pet.CanInteract(types.InteractionFeeding) {
+			return "", fmt.Errorf("%w: %s", interaction.ErrPetHibernating, pet.Name)
+		}
+		if err := s.Inventory.TakeFish(fish.Name); err != nil {
+			return "", err
+		}
+		pet.Eat(fish.Nutrients)
+		pet.RecordSpecialMeal()
+		s.audit(data.AuditInteract, pet.ID, "%s for %s: %s", types.InteractionFeeding, pet.Name, strings.ToLower(fish.Name))
+		return fmt.Sprintf("%s gobbles up the %s.\n", pet.Name, strings.ToLower(fish.Name)), nil
+
+	case len(args) == 1:
+		pet, err := s.FindPet(args[0])
+		if err != nil {
+			return "", err
+		}
+		effects, loc, err := s.conditionsAt(pet)
+		if err != nil {
+			return "", err
+		}
+		if loc.Biome.HasFishing() {
+			if err := core.Activities.Require(environment.ActivityFishing, effects); err != nil {
+				return "", err
+			}
+		}
+
+		result, err := pet.GoFishing(loc.Biome, effects.Season, s.rng)
+		if err != nil {
+			return "", err
+		}
+		if !result.Caught {
+			return fmt.Sprintf("%s hooked a %s at %s, but it got away.\n", pet.Name, result.Fish.Name, loc.Name), nil
+		}
+
+		out := fmt.Sprintf("%s caught a %s at %s!\n", pet.Name, result.Fish.Name, loc.Name)
+		if s.Inventory.AddFish(result.Fish) {
+			out += fmt.Sprintf("New species added to the fish album: %s.\n", result.Fish.Name)
+		}
+		return out, nil
+
+	default:
+		return "", usageError(fishUsage)
+	}
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestFishCommand(t *testing.T) {
+	shell := NewShell()
+	shell.rng = rand.New(rand.NewSource(3))
+	rex := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(rex)
+
+	if _, err := shell.Execute("fish Rex"); !errors.Is(err, environment.ErrNoFishing) {
+		t.Errorf("Expected ErrNoFishing at home, got %v", err)
+	}
+
+	rex.Location = "beach"
+	caught := false
+	for i := 0; i < 20 && !caught; i++ {
+		out, err := shell.Execute("fish Rex")
+		if err != nil {
+			t.Fatalf("fish failed: %v", err)
+		}
+		caught = strings.Contains(out, "caught")
+	}
+	if !caught {
+		t.Fatal("Expected a catch within 20 tries")
+	}
+
+	out, _ := shell.Execute("fish album")
+	if strings.Contains(out, "(nothing caught yet)") {
+		t.Errorf("Album should list the catch:\n%s", out)
+	}
+
+	var species string
+	for name := range shell.Inventory.Fish {
+		species = name
+	}
+	if _, err := shell.Execute("fish feed Rex " + species); err != nil {
+		t.Errorf("Feeding a caught fish failed: %v", err)
+	}
+	if _, err := shell.Execute("fish"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
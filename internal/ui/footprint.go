@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// RenderFootprint renders how much history a pet holds and its saved size
+func RenderFootprint(pet *core.DigitalPet) string {
+	return fmt.Sprintf("=== %s's Footprint ===\n%s\n", pet.Name, pet.MemoryUsage())
+}
+
+// footprintCommand handles `footprint <pet>`
+func (s *Shell) footprintCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", usageError("footprint <pet>")
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	return RenderFootprint(pet), nil
+}
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestFootprintCommand(t *testing.T) {
+	shell := NewShell()
+	shell.AddPet(core.NewDigitalPet("Rex", "user123"))
+
+	out, err := shell.Execute("footprint rex")
+	if err != nil {
+		t.Fatalf("footprint failed: %v", err)
+	}
+	if !strings.Contains(out, "Rex's Footprint") || !strings.Contains(out, "total") {
+		t.Errorf("Expected a footprint report, got:\n%s", out)
+	}
+	if _, err := shell.Execute("footprint"); err == nil {
+		t.Error("Expected a usage error without a pet")
+	}
+}
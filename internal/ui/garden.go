@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// gardenUsage lists the garden subcommands
+const gardenUsage = "garden [gather <pet> | plant <plot> <crop> | water <plot> | tend <plot> | harvest <plot> | feed <pet> <crop>]"
+
+// RenderGarden draws each plot's progress followed by the inventory
+func RenderGarden(garden *environment.Garden, inv *environment.Inventory) string {
+	var b strings.Builder
+	b.WriteString("=== Garden ===\n")
+	for i, p := range garden.Plots {
+		if !p.Planted {
+			fmt.Fprintf(&b, "%d. (empty)\n", i+1)
+			continue
+		}
+
+		filled := int(p.Growth * 10)
+		fmt.Fprintf(&b, "%d. %-9s [%s%s] %3.0f%%  water %3.0f%%  weeds %3.0f%%",
+			i+1, p.Crop, strings.Repeat("#", filled), strings.Repeat(".", 10-filled),
+			p.Growth*100, p.Moisture*100, p.Weeds*100)
+		switch {
+		case p.IsRipe():
+			b.WriteString("  ripe!")
+		case p.Moisture < environment.DroughtThreshold:
+			b.WriteString("  wilting!")
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "Seeds:   %s\n", formatStock(inv.Seeds))
+	fmt.Fprintf(&b, "Produce: %s\n", formatStock(inv.Produce))
+	return b.String()
+}
+
+// formatStock lists inventory counts in crop order
+func formatStock(stock map[environment.Crop]int) string {
+	var items []string
+	for _, crop := range environment.AllCrops() {
+		if n := stock[crop]; n > 0 {
+			items = append(items, fmt.Sprintf("%s x%d", crop, n))
+		}
+	}
+	if len(items) == 0 {
+		return "(none)"
+	}
+	return strings.Join(items, ", ")
+}
+
+// gardeningWeather returns an error if the weather at home rules out
+// working the garden, e.g. because the ground is frozen. The garden is
+// outdoors, so the habitat does not shelter it.
+func (s *Shell) gardeningWeather() error {
+	home, exists := s.World.Location(environment.HomeLocation)
+	if !exists {
+		return fmt.Errorf("%w: %s", environment.ErrUnknownLocation, environment.HomeLocation)
+	}
+	return core.Activities.Require(environment.ActivityGardening, s.outdoors(home))
+}
+
+// gardenCommand handles the `garden` command and its subcommands
+func (s *Shell) gardenCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		return RenderGarden(s.Garden, s.Inventory), nil
+	}
+
+	action, args := args[0], args[1:]
+	switch {
+	case action == "gather" && len(args) == 1:
+		pet, err := s.FindPet(args[0])
+		if err != nil {
+			return "", err
+		}
+		loc, exists := s.World.Location(pet.Location)
+		if !exists {
+			return "", fmt.Errorf("%w: %s", environment.ErrUnknownLocation, pet.Location)
+		}
+		crop, err := s.Garden.Gather(loc.Biome, s.Inventory)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s found %s seeds in %s.\n", pet.Name, crop, loc.Name), nil
+
+	case action == "plant" && len(args) == 2:
+		plot, err := strconv.Atoi(args[0])
+		if err != nil {
+			return "", usageError(gardenUsage)
+		}
+		crop, err := environment.ParseCrop(args[1])
+		if err != nil {
+			return "", err
+		}
+		if err := s.gardeningWeather(); err != nil {
+			return "", err
+		}
+		if err := s.Garden.Plant(plot, crop, s.Inventory); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Planted %s in plot %d.\n", crop, plot), nil
+
+	case (action == "water" || action == "tend" || action == "harvest") && len(args) == 1:
+		plot, err := strconv.Atoi(args[0])
+		if err != nil {
+			return "", usageError(gardenUsage)
+		}
+		switch action {
+		case "water":
+			err = s.Garden.Water(plot)
+		case "tend":
+			if err = s.gardeningWeather(); err == nil {
+				err = s.Garden.Tend(plot)
+			}
+		default:
+			crop, yield, err := s.Garden.Harvest(plot, s.Inventory)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Harvested %d %s from plot %d.\n", yield, crop, plot), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return RenderGarden(s.Garden, s.Inventory), nil
+
+	case action == "feed" && len(args) == 2:
+		pet, err := s.FindPet(args[0])
+		if err != nil {
+			return "", err
+		}
+		crop, err := environment.ParseCrop(args[1])
+		if err != nil {
+			return "", err
+		}
+		if !pet.CanInteract(types.InteractionFeeding) {
+			return "", fmt.Errorf("%w: %s", interaction.ErrPetHibernating, pet.Name)
+		}
+		if err := s.Inventory.TakeProduce(crop); err != nil {
+			return "", err
+		}
+		pet.Eat(crop.Nutrients())
+		s.audit(data.AuditInteract, pet.ID, "%s for %s: %s from the garden", types.InteractionFeeding, pet.Name, strings.ToLower(crop.String()))
+		return fmt.Sprintf("%s eats the %s.\n", pet.Name, strings.ToLower(crop.String())), nil
+
+	default:
+		return "", usageError(gardenUsage)
+	}
+}
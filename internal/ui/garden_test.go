@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestGardenCommand(t *testing.T) {
+	shell := NewShell()
+	shell.AddPet(core.NewDigitalPet("Rex", "user123"))
+
+	if _, err := shell.Execute("garden gather Rex"); err != nil {
+		t.Fatalf("gather failed: %v", err)
+	}
+	var seed environment.Crop
+	for crop := range shell.Inventory.Seeds {
+		seed = crop
+	}
+
+	if _, err := shell.Execute("garden plant 2 " + seed.String()); err != nil {
+		t.Fatalf("plant failed: %v", err)
+	}
+	out, err := shell.Execute("garden")
+	if err != nil {
+		t.Fatalf("garden failed: %v", err)
+	}
+	if !strings.Contains(out, "2. "+seed.String()) || !strings.Contains(out, "1. (empty)") {
+		t.Errorf("Garden should show the planted plot:\n%s", out)
+	}
+
+	if _, err := shell.Execute("garden harvest 2"); !errors.Is(err, environment.ErrNotRipe) {
+		t.Errorf("Expected ErrNotRipe, got %v", err)
+	}
+	if _, err := shell.Execute("garden feed Rex " + seed.String()); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock, got %v", err)
+	}
+	if _, err := shell.Execute("garden dig"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
+
+func TestGardeningNeedsThawedGround(t *testing.T) {
+	shell := NewShell()
+	shell.AddPet(core.NewDigitalPet("Rex", "user123"))
+	shell.Inventory.Seeds[environment.CropCarrots] = 1
+	shell.Weather = environment.NewSeededWeatherSystem(1)
+	shell.Weather.Current.Temperature = -5.0
+
+	_, err := shell.Execute("garden plant 1 carrots")
+	if !errors.Is(err, environment.ErrActivityUnavailable) || !strings.Contains(err.Error(), "ground frozen") {
+		t.Fatalf("Expected planting refused on frozen ground, got %v", err)
+	}
+	if _, err := shell.Execute("garden tend 1"); !errors.Is(err, environment.ErrActivityUnavailable) {
+		t.Errorf("Expected tending refused on frozen ground, got %v", err)
+	}
+
+	shell.Weather.Current.Temperature = 12.0
+	if _, err := shell.Execute("garden plant 1 carrots"); err != nil {
+		t.Errorf("Expected planting once the ground thaws, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+)
+
+// BarWidth is the number of characters used for trait bars
+const BarWidth = 20
+
+// RenderBar draws a horizontal bar for a value in [0.0, 1.0]
+func RenderBar(value float64, width int) string {
+	filled := int(value*float64(width) + 0.5)
+	if filled < 0 {
+		filled = 0
+	}
+	if filled > width {
+		filled = width
+	}
+	return "[" + strings.Repeat("#", filled) + strings.Repeat("-", width-filled) + "]"
+}
+
+// formatAllele renders an allele as D/r plus value, marking mutations with '*'
+func formatAllele(a genetics.Allele) string {
+	prefix := "r"
+	if a.Dominant {
+		prefix = "D"
+	}
+	marker := " "
+	if a.Mutated {
+		marker = "*"
+	}
+	return fmt.Sprintf("%s%.2f%s", prefix, a.Value, marker)
+}
+
+// RenderGenome renders a pet's expressed traits, allele pairs and biome affinities
+func RenderGenome(pet *core.DigitalPet) string {
+	var b strings.Builder
+	g := pet.Genome
+
+	fmt.Fprintf(&b, "=== Genome: %s (Generation %d) ===\n", pet.Name, g.Generation)
+	fmt.Fprintf(&b, "%-18s %-22s %-6s %s\n", "Trait", "Expressed", "Value", "Alleles (D=dominant, r=recessive)")
+
+	mutated := 0
+	for _, name := range genetics.TraitNames {
+		pair := g.Traits[name]
+		value := pair.Expressed()
+		fmt.Fprintf(&b, "%-18s %s %.2f   %s / %s\n", name, RenderBar(value, BarWidth), value,
+			formatAllele(pair.Maternal), formatAllele(pair.Paternal))
+
+		if pair.Maternal.Mutated {
+			mutated++
+		}
+		if pair.Paternal.Mutated {
+			mutated++
+		}
+	}
+
+	if mutated > 0 {
+		fmt.Fprintf(&b, "* %d rare mutated allele(s)\n", mutated)
+	}
+
+	tolerance := g.ClimateTolerance()
+	fmt.Fprintf(&b, "\nClimate tolerance: cold %.0f%% | heat %.0f%%\n", tolerance.Cold*100, tolerance.Heat*100)
+	fmt.Fprintf(&b, "%-18s %-22s %-6s %s\n", "Biome", "Affinity", "Value", "Acclimatized")
+	for _, biome := range environment.AllBiomes() {
+		affinity := pet.BiomeAffinity(biome)
+		fmt.Fprintf(&b, "%-18s %s %.2f   %.0f%%\n", biome, RenderBar(affinity, BarWidth), affinity,
+			pet.Acclimatization.Level(biome)*100)
+	}
+
+	if pet.Screening != nil {
+		fmt.Fprintf(&b, "Screening: %s\n", pet.Screening.String())
+	}
+
+	return b.String()
+}
+
+// RenderGenomeComparison renders two pets side by side with a breeding preview
+func RenderGenomeComparison(a, b *core.DigitalPet) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "=== Genome Comparison: %s vs %s ===\n", a.Name, b.Name)
+	fmt.Fprintf(&sb, "%-18s %-28s %-28s\n", "Trait", a.Name, b.Name)
+
+	for _, name := range genetics.TraitNames {
+		va := a.Genome.GetTraitValue(name)
+		vb := b.Genome.GetTraitValue(name)
+		fmt.Fprintf(&sb, "%-18s %s %.2f   %s %.2f\n", name,
+			RenderBar(va, BarWidth), va, RenderBar(vb, BarWidth), vb)
+	}
+
+	compat := core.CheckBreedingCompatibility(a, b)
+	fmt.Fprintf(&sb, "\nBreeding preview: compatibility %.0f%%", compat.Score*100)
+	if compat.CanBreed {
+		health := (a.Biology.Vitals.Health + b.Biology.Vitals.Health) / 2.0
+		fmt.Fprintf(&sb, " (allowed, about %.1f pups per litter)\n", genetics.ExpectedLitterSize(a.Genome, b.Genome, health))
+	} else {
+		fmt.Fprintf(&sb, " (blocked: %s)\n", strings.Join(compat.Reasons, "; "))
+	}
+	for _, warning := range compat.Warnings {
+		fmt.Fprintf(&sb, "  ! %s\n", warning)
+	}
+
+	return sb.String()
+}
+
+// genomeCommand handles `genome <pet> [other-pet]`
+func (s *Shell) genomeCommand(args []string) (string, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", usageError("genome <pet> [other-pet]")
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	if len(args) == 1 {
+		return RenderGenome(pet), nil
+	}
+
+	other, err := s.FindPet(args[1])
+	if err != nil {
+		return "", err
+	}
+	return RenderGenomeComparison(pet, other), nil
+}
+
+// populationCommand handles `population [csv <file>]`
+func (s *Shell) populationCommand(args []string) (string, error) {
+	if len(args) != 0 && (len(args) != 2 || args[0] != "csv") {
+		return "", usageError("population [csv <file>]")
+	}
+	s.mu.RLock()
+	pets := append([]*core.DigitalPet(nil), s.Pets...)
+	s.mu.RUnlock()
+
+	analyzer := core.AnalyzePopulation(pets)
+	if len(args) == 0 {
+		return analyzer.Report(), nil
+	}
+
+	file, err := os.Create(args[1])
+	if err != nil {
+		return "", err
+	}
+	if err := analyzer.WriteCSV(file); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Exported population genetics of %d pets to %s.\n", len(pets), args[1]), nil
+}
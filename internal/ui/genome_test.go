@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestRenderBar(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{0.0, "[----]"},
+		{0.5, "[##--]"},
+		{1.0, "[####]"},
+		{1.5, "[####]"},
+	}
+
+	for _, tt := range tests {
+		if got := RenderBar(tt.value, 4); got != tt.expected {
+			t.Errorf("RenderBar(%.1f) = %s, want %s", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestGenomeCommand(t *testing.T) {
+	shell := NewShell()
+	mom := core.NewDigitalPet("Mom", "user123")
+	dad := core.NewDigitalPet("Dad", "user123")
+	shell.AddPet(mom)
+	shell.AddPet(dad)
+
+	out, err := shell.Execute("genome mom")
+	if err != nil {
+		t.Fatalf("genome command failed: %v", err)
+	}
+	if !strings.Contains(out, "openness") {
+		t.Error("Genome view should list traits")
+	}
+	if !strings.Contains(out, "Arctic") {
+		t.Error("Genome view should list biome affinities")
+	}
+
+	out, err = shell.Execute("genome Mom Dad")
+	if err != nil {
+		t.Fatalf("genome comparison failed: %v", err)
+	}
+	if !strings.Contains(out, "Breeding preview") {
+		t.Error("Comparison should include breeding preview")
+	}
+}
+
+func TestPopulationCSV(t *testing.T) {
+	shell := NewShell()
+	shell.AddPet(core.NewDigitalPet("Mom", "user123"))
+	shell.AddPet(core.NewDigitalPet("Dad", "user123"))
+	file := filepath.Join(t.TempDir(), "population.csv")
+
+	out, err := shell.Execute("population csv " + file)
+	if err != nil {
+		t.Fatalf("population csv failed: %v", err)
+	}
+	if !strings.Contains(out, "2 pets") {
+		t.Errorf("Unexpected output %q", out)
+	}
+	raw, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer raw.Close()
+	rows, err := csv.NewReader(raw).ReadAll()
+	if err != nil || len(rows) < 2 || strings.Join(rows[0], ",") != "section,key,trait,value" {
+		t.Errorf("Expected CSV rows under a header, got %v (%v)", rows, err)
+	}
+
+	if _, err := shell.Execute("population json " + file); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
+
+func TestRenderGenomeFlagsMutations(t *testing.T) {
+	pet := core.NewDigitalPet("Mutant", "user123")
+	pet.Genome.Mutate(1.0)
+
+	if !strings.Contains(RenderGenome(pet), "rare mutated") {
+		t.Error("Genome view should flag mutated alleles")
+	}
+}
+
+func TestShellErrors(t *testing.T) {
+	shell := NewShell()
+
+	if _, err := shell.Execute("nonsense"); !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("Expected ErrUnknownCommand, got %v", err)
+	}
+
+	if _, err := shell.Execute("genome ghost"); !errors.Is(err, ErrPetNotFound) {
+		t.Errorf("Expected ErrPetNotFound, got %v", err)
+	}
+
+	if _, err := shell.Execute("genome"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
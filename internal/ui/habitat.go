@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// RenderHabitat lists the comfort items in the home and the shelter they give
+func RenderHabitat(habitat *environment.Habitat) string {
+	var items []string
+	for _, item := range environment.AllHabitatItems() {
+		if habitat.Items[item] {
+			items = append(items, item.String())
+		}
+	}
+	if len(items) == 0 {
+		items = append(items, "(none)")
+	}
+	return fmt.Sprintf("=== Habitat ===\nItems: %s\nShelter: %.0f%%\n", strings.Join(items, ", "), habitat.Shelter()*100)
+}
+
+// habitatCommand handles `habitat [add|remove <item>]`
+func (s *Shell) habitatCommand(args []string) (string, error) {
+	const usage = "habitat [add|remove <item>]"
+
+	switch {
+	case len(args) == 0:
+		return RenderHabitat(s.Habitat), nil
+
+	case len(args) == 2 && (args[0] == "add" || args[0] == "remove"):
+		item, err := environment.ParseHabitatItem(args[1])
+		if err != nil {
+			return "", err
+		}
+		if args[0] == "add" {
+			s.Habitat.Add(item)
+		} else {
+			s.Habitat.Remove(item)
+		}
+		return RenderHabitat(s.Habitat), nil
+
+	default:
+		return "", usageError(usage)
+	}
+}
@@ -0,0 +1,26 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHabitatCommand(t *testing.T) {
+	shell := NewShell()
+
+	out, err := shell.Execute("habitat add heater")
+	if err != nil {
+		t.Fatalf("habitat add failed: %v", err)
+	}
+	if !strings.Contains(out, "Items: Heater") {
+		t.Errorf("Habitat should list the heater:\n%s", out)
+	}
+
+	out, _ = shell.Execute("habitat remove heater")
+	if !strings.Contains(out, "Items: (none)") {
+		t.Errorf("Heater should be removed:\n%s", out)
+	}
+	if _, err := shell.Execute("habitat add jacuzzi"); err == nil {
+		t.Error("Expected an error for an unknown item")
+	}
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// Insurance command usages
+const (
+	insureUsage = "insure [<pet> [<plan>|none]]"
+	fundUsage   = "fund [deposit|withdraw <coins>]"
+)
+
+// RenderPolicies lists the insured pets and the emergency fund
+func RenderPolicies(policies []interaction.Policy, pets []*core.DigitalPet, fund, coins int) string {
+	names := make(map[string]string, len(pets))
+	for _, pet := range pets {
+		names[string(pet.ID)] = pet.Name
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Insurance (fund %d coins, you have %d coins) ===\n", fund, coins)
+	insured := 0
+	for _, policy := range policies {
+		if policy.Plan == interaction.PlanNone {
+			continue
+		}
+		insured++
+		fmt.Fprintf(&b, "  %-12s %-9s %3d coins due in %.1f days  %d claim(s), %d coins paid out\n",
+			names[string(policy.PetID)], policy.Plan, policy.Premium(), policy.DueIn, policy.Claims, policy.PaidOut)
+	}
+	if insured == 0 {
+		b.WriteString("  (no pets insured)\n")
+	}
+	b.WriteString("Use `insure <pet>` to compare plans.\n")
+	return b.String()
+}
+
+// RenderPlanComparison compares the insurance plans for a pet with what
+// each major treatment would cost the player
+func RenderPlanComparison(name string, quotes []interaction.PlanQuote) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Insurance plans for %s ===\n", name)
+	fmt.Fprintf(&b, "  %-10s %8s %6s %7s %6s   you pay: %s %d / %s %d\n", "Plan", "Premium", "Cover", "Excess", "Limit",
+		strings.ToLower(interaction.TreatmentIllness.String()), interaction.TreatmentIllness.Cost(),
+		strings.ToLower(interaction.TreatmentSurgery.String()), interaction.TreatmentSurgery.Cost())
+	for _, quote := range quotes {
+		current := ""
+		if quote.Current {
+			current = " (current)"
+		}
+		fmt.Fprintf(&b, "  %-10s %8d %5.0f%% %7d %6d   %d / %d%s\n", quote.Plan, quote.Premium, quote.Terms.Coverage*100,
+			quote.Terms.Excess, quote.Terms.Limit, quote.OutOfPocket[interaction.TreatmentIllness],
+			quote.OutOfPocket[interaction.TreatmentSurgery], current)
+	}
+	fmt.Fprintf(&b, "Premiums are due every %.0f days and rise %.0f%% with each claim.\n",
+		interaction.PremiumIntervalDays, interaction.ClaimLoading*100)
+	return b.String()
+}
+
+// insureCommand handles `insure [<pet> [<plan>|none]]`
+func (s *Shell) insureCommand(args []string) (string, error) {
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	if len(args) == 0 {
+		return RenderPolicies(s.Household.Policies(), s.Pets, s.Household.EmergencyFund(), s.Household.Inventory.Coins), nil
+	}
+	if len(args) > 2 {
+		return "", usageError(insureUsage)
+	}
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 1 {
+		quotes, err := s.Household.ComparePlans(pet.ID)
+		if err != nil {
+			return "", err
+		}
+		return RenderPlanComparison(pet.Name, quotes), nil
+	}
+
+	plan, err := interaction.ParseInsurancePlan(args[1])
+	if err != nil {
+		return "", err
+	}
+	policy, err := s.Household.Insure(pet.ID, plan)
+	if err != nil {
+		return "", err
+	}
+	if plan == interaction.PlanNone {
+		s.audit(data.AuditInsure, pet.ID, "cancelled %s's insurance", pet.Name)
+		return fmt.Sprintf("%s is no longer insured.\n", pet.Name), nil
+	}
+	s.audit(data.AuditInsure, pet.ID, "put %s on the %s plan", pet.Name, plan)
+	return fmt.Sprintf("%s is on the %s plan. The next %d coin premium is due in %.1f days.\n",
+		pet.Name, plan, policy.Premium(), policy.DueIn), nil
+}
+
+// vetCommand handles `vet <pet>`
+func (s *Shell) vetCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", usageError("vet <pet>")
+	}
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	bill, err := s.Household.Treat(pet.ID)
+	if err != nil {
+		return "", err
+	}
+	s.audit(data.AuditInsure, pet.ID, "%s for %s: %d coins, %d covered", strings.ToLower(bill.Treatment.String()),
+		pet.Name, bill.Cost, bill.Covered)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s had %s. The bill came to %d coins:\n", pet.Name, strings.ToLower(bill.Treatment.String()), bill.Cost)
+	if bill.Covered > 0 {
+		fmt.Fprintf(&b, "  %d paid by insurance (premium now %d coins)\n", bill.Covered, bill.Premium)
+	}
+	if bill.FromFund > 0 {
+		fmt.Fprintf(&b, "  %d from the emergency fund\n", bill.FromFund)
+	}
+	fmt.Fprintf(&b, "  %d from your coins\n", bill.FromCoins)
+	return b.String(), nil
+}
+
+// fundCommand handles `fund [deposit|withdraw <coins>]`
+func (s *Shell) fundCommand(args []string) (string, error) {
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	if len(args) == 0 {
+		return fmt.Sprintf("The emergency fund holds %d coins for vet bills; you have %d coins to spend.\n",
+			s.Household.EmergencyFund(), s.Household.Inventory.Coins), nil
+	}
+	if len(args) != 2 {
+		return "", usageError(fundUsage)
+	}
+	amount, err := strconv.Atoi(args[1])
+	if err != nil {
+		return "", usageError(fundUsage)
+	}
+
+	switch args[0] {
+	case "deposit":
+		err = s.Household.DepositFund(amount)
+	case "withdraw":
+		err = s.Household.WithdrawFund(amount)
+	default:
+		return "", usageError(fundUsage)
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("The emergency fund now holds %d coins.\n", s.Household.EmergencyFund()), nil
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestInsuranceCommands(t *testing.T) {
+	shell := NewShell()
+	pet := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(pet)
+	if _, err := shell.Execute("insure"); !errors.Is(err, ErrNoHousehold) {
+		t.Errorf("Expected ErrNoHousehold, got %v", err)
+	}
+	shell.Household = interaction.NewHousehold(pet)
+
+	comparison, err := shell.Execute("insure Rex")
+	if err != nil {
+		t.Fatalf("insure failed: %v", err)
+	}
+	for _, want := range []string{"Basic", "Standard", "Premium", "surgery 240"} {
+		if !strings.Contains(comparison, want) {
+			t.Errorf("Expected %q in the comparison, got:\n%s", want, comparison)
+		}
+	}
+
+	if _, err := shell.Execute("insure Rex premium"); err != nil {
+		t.Fatalf("insure failed: %v", err)
+	}
+	if listing, _ := shell.Execute("insure"); !strings.Contains(listing, "Rex") || !strings.Contains(listing, "Premium") {
+		t.Errorf("Expected Rex's policy in the listing, got:\n%s", listing)
+	}
+	if _, err := shell.Execute("fund deposit 50"); err != nil {
+		t.Fatalf("fund failed: %v", err)
+	}
+
+	pet.Biology.Vitals.Health = 0.1
+	out, err := shell.Execute("vet Rex")
+	if err != nil {
+		t.Fatalf("vet failed: %v", err)
+	}
+	for _, want := range []string{"surgery", "paid by insurance", "33 from the emergency fund"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in the bill, got:\n%s", want, out)
+		}
+	}
+	if _, err := shell.Execute("fund withdraw lots"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
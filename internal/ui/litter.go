@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// ErrNoHousehold is returned by commands that need a household when the shell has none
+var ErrNoHousehold = errors.New("no household available")
+
+// litterUsage lists the litter subcommands
+const litterUsage = "litter [list] | litter name <pup> <name> | litter keep <pup|all> | litter rehome <pup|all> [center | sanctuary | market | friend <account>]"
+
+// PupTraitCount is how many of a pup's strongest traits are shown
+const PupTraitCount = 3
+
+// RenderLitters lists the litters on their way and the newborns waiting
+// for a decision, with the traits that set each pup apart
+func RenderLitters(pregnancies []interaction.Pregnancy, litters []interaction.Litter) string {
+	var b strings.Builder
+	b.WriteString("=== Litters ===\n")
+	if len(pregnancies) == 0 && len(litters) == 0 {
+		b.WriteString("  (none; try \"breed <pet> <pet>\")\n")
+		return b.String()
+	}
+	for _, pregnancy := range pregnancies {
+		fmt.Fprintf(&b, "  %s and %s are expecting %d in %.1f days\n",
+			pregnancy.Parents[0].Name, pregnancy.Parents[1].Name, pregnancy.Size, pregnancy.DueIn)
+	}
+	for _, litter := range litters {
+		fmt.Fprintf(&b, "  Born to %s and %s:\n", litter.Parents[0], litter.Parents[1])
+		for _, pup := range litter.Pups {
+			fmt.Fprintf(&b, "    %-16s %s\n", pup.Name, strongestTraits(pup))
+		}
+	}
+	if len(litters) > 0 {
+		b.WriteString("Name each pup with `litter name <pup> <name>`, then `litter keep <pup>` to raise them\n")
+		b.WriteString("or `litter rehome <pup> [center | sanctuary | market | friend <account>]` to find them a home.\n")
+	}
+	return b.String()
+}
+
+// strongestTraits describes the traits a pup expresses most strongly
+func strongestTraits(pup *core.DigitalPet) string {
+	names := append([]string(nil), genetics.TraitNames...)
+	sort.SliceStable(names, func(i, j int) bool {
+		return pup.Genome.GetTraitValue(names[i]) > pup.Genome.GetTraitValue(names[j])
+	})
+	parts := make([]string, PupTraitCount)
+	for i, name := range names[:PupTraitCount] {
+		parts[i] = fmt.Sprintf("%s %.2f", name, pup.Genome.GetTraitValue(name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// breedCommand handles `breed <pet> <pet>`
+func (s *Shell) breedCommand(args []string) (string, error) {
+	if len(args) != 2 {
+		return "", usageError("breed <pet> <pet>")
+	}
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	parent1, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	parent2, err := s.FindPet(args[1])
+	if err != nil {
+		return "", err
+	}
+
+	pregnancy, err := s.Household.Breed(parent1, parent2)
+	if err != nil {
+		return "", err
+	}
+	s.audit(data.AuditBreed, parent1.ID, "bred %s with %s", parent1.Name, parent2.Name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s and %s are expecting! The litter is due in %.0f days.\n",
+		parent1.Name, parent2.Name, pregnancy.DueIn)
+	for _, warning := range core.CheckBreedingCompatibility(parent1, parent2).Warnings {
+		fmt.Fprintf(&b, "  ! %s\n", warning)
+	}
+	if s.Household.OverCap(pregnancy.Size) {
+		fmt.Fprintf(&b, "  ! %d more pets would take the household past its cap of %d; plan to rehome some of the litter\n",
+			pregnancy.Size, s.Household.PopulationCap)
+	}
+	return b.String(), nil
+}
+
+// litterCommand handles the `litter` command and its subcommands
+func (s *Shell) litterCommand(args []string) (string, error) {
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	if len(args) == 0 || (args[0] == "list" && len(args) == 1) {
+		out := RenderLitters(s.Household.Pregnancies(), s.Household.Litters())
+		if limit := s.Household.PopulationCap; limit > 0 {
+			out += fmt.Sprintf("Household: %d of %d pets, counting pups.\n", s.Household.Population(), limit)
+		}
+		return out, nil
+	}
+
+	switch {
+	case args[0] == "name" && len(args) == 3:
+		old := args[1]
+		pup, err := s.Household.NamePup(old, args[2])
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s is now called %s.\n", old, pup.Name), nil
+	case args[0] == "keep" && len(args) == 2:
+		return s.decidePups(args[1], s.keepPup)
+	case args[0] == "rehome" && len(args) >= 2:
+		destination, friend, err := parseDestination(args[2:])
+		if err != nil {
+			return "", usageError(litterUsage)
+		}
+		return s.decidePups(args[1], func(name string) (string, error) {
+			return s.adoptOut(name, destination, friend)
+		})
+	}
+	return "", usageError(litterUsage)
+}
+
+// decidePups applies a decision to one pup, or to every waiting pup for "all"
+func (s *Shell) decidePups(target string, decide func(name string) (string, error)) (string, error) {
+	if !strings.EqualFold(target, "all") {
+		return decide(target)
+	}
+	var b strings.Builder
+	for _, litter := range s.Household.Litters() {
+		for _, pup := range litter.Pups {
+			out, err := decide(string(pup.ID))
+			if err != nil {
+				return b.String(), err
+			}
+			b.WriteString(out)
+		}
+	}
+	if b.Len() == 0 {
+		return "", fmt.Errorf("%w: %s", interaction.ErrNoSuchPup, target)
+	}
+	return b.String(), nil
+}
+
+// keepPup brings a pup into the household
+func (s *Shell) keepPup(name string) (string, error) {
+	pup, err := s.Household.KeepPup(name)
+	if err != nil {
+		return "", err
+	}
+	s.AddPet(pup)
+	s.audit(data.AuditBreed, pup.ID, "kept %s from the litter", pup.Name)
+	return fmt.Sprintf("%s is staying with you. Welcome, %s!\n", pup.Name, pup.Name), nil
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestBreedAndLitterCommands(t *testing.T) {
+	shell := NewShell()
+	rex := core.NewDigitalPet("Rex", "sam")
+	bella := core.NewDigitalPet("Bella", "sam")
+	shell.AddPet(rex)
+	shell.AddPet(bella)
+	if _, err := shell.Execute("breed rex bella"); !errors.Is(err, ErrNoHousehold) {
+		t.Errorf("Expected ErrNoHousehold without a household, got %v", err)
+	}
+
+	shell.Household = interaction.NewHousehold(rex, bella)
+	shell.Household.Adoption = interaction.NewSeededAdoptionCenter(1)
+	if out, _ := shell.Execute("litter"); !strings.Contains(out, "none") {
+		t.Errorf("Expected no litters yet, got:\n%s", out)
+	}
+	if out, err := shell.Execute("breed rex bella"); err != nil || !strings.Contains(out, "expecting") {
+		t.Fatalf("Expected Rex and Bella to be expecting, got %q (%v)", out, err)
+	}
+	if out, _ := shell.Execute("litter"); !strings.Contains(out, "Rex and Bella are expecting") {
+		t.Errorf("Expected the pregnancy listed, got:\n%s", out)
+	}
+
+	shell.Household.Update(interaction.GestationDays)
+	pups := shell.Household.Litters()[0].Pups
+	out, err := shell.Execute("litter")
+	if err != nil || !strings.Contains(out, pups[0].Name) || !strings.Contains(out, "litter keep") {
+		t.Fatalf("Expected the pups listed with guidance, got %q (%v)", out, err)
+	}
+
+	if _, err := shell.Execute("litter name " + pups[0].Name + " Sprout"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := shell.Execute("litter keep sprout"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := shell.FindPet("Sprout"); err != nil {
+		t.Errorf("Expected the kept pup available to commands: %v", err)
+	}
+	if len(pups) > 1 {
+		if _, err := shell.Execute("litter rehome all"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := shell.Household.Adoption.Find(pups[1].Name); err != nil {
+			t.Errorf("Expected the rest of the litter at the adoption center: %v", err)
+		}
+	}
+	if _, err := shell.Execute("litter keep all"); !errors.Is(err, interaction.ErrNoSuchPup) {
+		t.Errorf("Expected ErrNoSuchPup once every pup is decided, got %v", err)
+	}
+	if _, err := shell.Execute("litter adopt sprout"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
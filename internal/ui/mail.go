@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// RenderInbox renders a one-line summary of each message
+func RenderInbox(inbox *interaction.Inbox) string {
+	messages := inbox.List()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Inbox (%d unread) ===\n", inbox.UnreadCount())
+	if len(messages) == 0 {
+		b.WriteString("  (no messages)\n")
+		return b.String()
+	}
+
+	for _, msg := range messages {
+		marker := " "
+		if !msg.Read {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, "%s %3d  %-14s %s\n", marker, msg.ID, msg.Category, msg.Subject)
+	}
+	return b.String()
+}
+
+// RenderMessage renders a full message
+func RenderMessage(msg *interaction.Message) string {
+	return fmt.Sprintf("[%s] %s\n%s\n\n%s\n",
+		msg.Category, msg.Subject, msg.Received.Format("2006-01-02 15:04"), msg.Body)
+}
+
+// mailCommand handles `mail [<id> | read-all | delete <id>]`
+func (s *Shell) mailCommand(args []string) (string, error) {
+	const usage = "mail [<id> | read-all | delete <id>]"
+
+	switch {
+	case len(args) == 0:
+		return RenderInbox(s.Inbox), nil
+
+	case len(args) == 1 && args[0] == "read-all":
+		s.Inbox.MarkAllRead()
+		return "All messages marked read.\n", nil
+
+	case len(args) == 2 && args[0] == "delete":
+		id, err := strconv.Atoi(args[1])
+		if err != nil {
+			return "", usageError(usage)
+		}
+		if err := s.Inbox.Delete(id); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Message %d deleted.\n", id), nil
+
+	case len(args) == 1:
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return "", usageError(usage)
+		}
+		msg, err := s.Inbox.Open(id)
+		if err != nil {
+			return "", err
+		}
+		return RenderMessage(msg), nil
+
+	default:
+		return "", usageError(usage)
+	}
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestMailCommand(t *testing.T) {
+	shell := NewShell()
+	msg := shell.Inbox.Post(interaction.MessageFriendRequest, "", "New friend", "Biscuit wants to be friends.")
+
+	out, _ := shell.Execute("mail")
+	if !strings.Contains(out, "1 unread") || !strings.Contains(out, "* ") {
+		t.Errorf("Expected unread message in listing, got:\n%s", out)
+	}
+
+	out, err := shell.Execute("mail 1")
+	if err != nil || !strings.Contains(out, "Biscuit wants to be friends.") {
+		t.Errorf("Expected message body, got %q (%v)", out, err)
+	}
+	if !msg.Read {
+		t.Error("Reading a message should mark it read")
+	}
+
+	if _, err := shell.Execute("mail delete 7"); !errors.Is(err, interaction.ErrMessageNotFound) {
+		t.Errorf("Expected ErrMessageNotFound, got %v", err)
+	}
+	if _, err := shell.Execute("mail nope"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// MemoriesShown is the number of memories listed by `memories`
+const MemoriesShown = 10
+
+// RenderMemories renders a list of memories with their valence and tags
+func RenderMemories(pet *core.DigitalPet, memories []*ai.Memory) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s's Memories ===\n", pet.Name)
+
+	if len(memories) == 0 {
+		b.WriteString("  (nothing comes to mind)\n")
+		return b.String()
+	}
+
+	for _, memory := range memories {
+		mood := "~"
+		if memory.Valence > 0.2 {
+			mood = "+"
+		} else if memory.Valence < -0.2 {
+			mood = "-"
+		}
+		fmt.Fprintf(&b, "%s %s %s", mood, RenderBar(memory.Strength, 10), memory.Description)
+		if len(memory.Tags) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(memory.Tags, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// memoriesCommand handles `memories <pet> [tag]`
+func (s *Shell) memoriesCommand(args []string) (string, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", usageError("memories <pet> [tag]")
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	var memories []*ai.Memory
+	if len(args) == 2 {
+		memories = pet.Memory.ByTag(args[1])
+		if len(memories) > MemoriesShown {
+			memories = memories[:MemoriesShown]
+		}
+	} else {
+		memories = pet.Memory.Strongest(MemoriesShown)
+	}
+
+	return RenderMemories(pet, memories), nil
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestMemoriesCommand(t *testing.T) {
+	shell := NewShell()
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Memory.Remember(&ai.Memory{Description: "Thunderstorm", Strength: 0.9, Valence: -0.9, Tags: []string{"storm"}})
+	pet.Memory.Remember(&ai.Memory{Description: "Walk in the park", Strength: 0.6, Valence: 0.8})
+	shell.AddPet(pet)
+
+	out, err := shell.Execute("memories rex storm")
+	if err != nil {
+		t.Fatalf("memories failed: %v", err)
+	}
+	if !strings.Contains(out, "- ") || !strings.Contains(out, "Thunderstorm [storm]") {
+		t.Errorf("Expected tagged unpleasant memory, got:\n%s", out)
+	}
+	if strings.Contains(out, "park") {
+		t.Error("Tag filter should exclude untagged memories")
+	}
+}
@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"strconv"
+
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// DefaultObserveMinutes is how long `observe` watches when no duration is given
+const DefaultObserveMinutes = 30
+
+// observeCommand handles `observe <pet> [minutes]`
+func (s *Shell) observeCommand(args []string) (string, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", usageError("observe <pet> [minutes]")
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	minutes := DefaultObserveMinutes
+	if len(args) == 2 {
+		minutes, err = strconv.Atoi(args[1])
+		if err != nil || minutes <= 0 {
+			return "", usageError("observe <pet> [minutes]")
+		}
+	}
+
+	return interaction.Observe(pet, minutes, nil).String(), nil
+}
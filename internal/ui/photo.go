@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+var (
+	// ErrNotOwned is returned when posing a pet with an item the player does not have
+	ErrNotOwned = errors.New("item not owned")
+	// ErrNoPicture is returned when exporting an album entry that is not a photo
+	ErrNoPicture = errors.New("album entry has no picture")
+	// ErrNoAlbum is returned when there is no household to keep an album in
+	ErrNoAlbum = errors.New("no album available")
+)
+
+// Photo settings
+const (
+	SceneWidth    = 36 // Columns inside a photo's frame
+	MaxSceneProps = 4  // Items a pet can be posed with
+	propColumn    = 22 // Column props are placed at, right of the pet
+)
+
+// photoUsage lists the photo subcommands
+const photoUsage = "photo <pet> [+item ...] [caption] | photo album <pet> | photo export <pet> <number> <file.txt|file.png>"
+
+// ANSI codes used to color scenes
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiFormat = "\x1b[%dm"
+)
+
+// backdrop is the sky and ground drawn behind a pet in a biome
+type backdrop struct {
+	Sky         string // Pattern repeated across the sky
+	Ground      string // Pattern repeated across the ground
+	SkyColor    int    // ANSI foreground color code
+	GroundColor int
+}
+
+// backdrops lists the backdrop of every biome
+var backdrops = map[environment.Biome]backdrop{
+	environment.BiomeTemperate: {Sky: "   ~~   (  )     ", Ground: `,.;'"`, SkyColor: 37, GroundColor: 32},
+	environment.BiomeArctic:    {Sky: " *   .  *  ", Ground: "=-", SkyColor: 97, GroundColor: 97},
+	environment.BiomeDesert:    {Sky: "   \\|/          ", Ground: ".:", SkyColor: 93, GroundColor: 33},
+	environment.BiomeTropical:  {Sky: " ) ~ (    ", Ground: "~^~", SkyColor: 96, GroundColor: 92},
+	environment.BiomeOcean:     {Sky: "  v     ", Ground: "~^~~", SkyColor: 37, GroundColor: 34},
+	environment.BiomeSwamp:     {Sky: " . '  ", Ground: "_,~", SkyColor: 90, GroundColor: 36},
+	environment.BiomeMountain:  {Sky: "  /\\    /\\/\\ ", Ground: "^_", SkyColor: 37, GroundColor: 90},
+	environment.BiomeUrban:     {Sky: " |#|_|##| ", Ground: "=_", SkyColor: 90, GroundColor: 90},
+}
+
+// Scene is a pet posed for a photo
+type Scene struct {
+	Pet     *core.DigitalPet
+	Biome   environment.Biome // Backdrop drawn behind the pet
+	Props   []string          // Items placed beside the pet
+	Caption string
+}
+
+// ComposeScene draws a scene as a framed picture with ANSI colors; use
+// StripANSI for plain text
+func ComposeScene(scene Scene) string {
+	bd, ok := backdrops[scene.Biome]
+	if !ok {
+		bd = backdrops[environment.BiomeTemperate]
+	}
+	border := strings.Repeat("-", SceneWidth)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, ".%s.\n", border)
+	fmt.Fprintf(&b, "|%s|\n", colored(repeatTo(bd.Sky, SceneWidth), bd.SkyColor))
+
+	portrait := strings.Split(strings.TrimSuffix(RenderPet(scene.Pet), "\n"), "\n")
+	portrait = portrait[:len(portrait)-1] // The name goes in the caption
+	for i, line := range portrait {
+		prop := ""
+		if i < len(scene.Props) {
+			prop = "[" + scene.Props[i] + "]"
+		}
+		fmt.Fprintf(&b, "|%s|\n", fit(fit(line, propColumn)+prop, SceneWidth))
+	}
+
+	fmt.Fprintf(&b, "|%s|\n", colored(repeatTo(bd.Ground, SceneWidth), bd.GroundColor))
+	fmt.Fprintf(&b, "'%s'\n", border)
+	if scene.Caption != "" {
+		fmt.Fprintf(&b, " %s\"%s\"%s\n", ansiBold, scene.Caption, ansiReset)
+	}
+	fmt.Fprintf(&b, " %s, %s\n", scene.Pet.Name, scene.Biome)
+	return b.String()
+}
+
+// repeatTo repeats a pattern to exactly width characters
+func repeatTo(pattern string, width int) string {
+	return strings.Repeat(pattern, width/len(pattern)+1)[:width]
+}
+
+// fit pads or truncates a line to exactly width characters
+func fit(line string, width int) string {
+	if len(line) > width {
+		return line[:width]
+	}
+	return line + strings.Repeat(" ", width-len(line))
+}
+
+// colored wraps text in an ANSI foreground color
+func colored(text string, code int) string {
+	return fmt.Sprintf(ansiFormat, code) + text + ansiReset
+}
+
+// prop checks the player owns an item, from the habitat, harvested
+// produce or caught fish, and returns its display name. Underscores stand
+// for spaces in multi-word names.
+func (s *Shell) prop(name string) (string, error) {
+	name = strings.ReplaceAll(name, "_", " ")
+	if item, err := environment.ParseHabitatItem(name); err == nil && s.Habitat.Items[item] {
+		return item.String(), nil
+	}
+	if crop, err := environment.ParseCrop(name); err == nil && s.Inventory.Produce[crop] > 0 {
+		return crop.String(), nil
+	}
+	if fish, err := environment.LookupFish(name); err == nil && s.Inventory.Fish[fish.Name] > 0 {
+		return fish.Name, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrNotOwned, name)
+}
+
+// petBiome returns the biome of the location a pet is in
+func (s *Shell) petBiome(pet *core.DigitalPet) environment.Biome {
+	if loc, exists := s.World.Location(pet.Location); exists {
+		return loc.Biome
+	}
+	return environment.BiomeTemperate
+}
+
+// photoCommand handles the `photo` command and its subcommands
+func (s *Shell) photoCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", usageError(photoUsage)
+	}
+	if s.Household == nil {
+		return "", ErrNoAlbum
+	}
+
+	switch {
+	case args[0] == "album" && len(args) == 2:
+		pet, err := s.FindPet(args[1])
+		if err != nil {
+			return "", err
+		}
+		return s.renderAlbum(pet), nil
+
+	case args[0] == "export" && len(args) == 4:
+		pet, err := s.FindPet(args[1])
+		if err != nil {
+			return "", err
+		}
+		return s.exportPhoto(pet, args[2], args[3])
+
+	case args[0] == "album" || args[0] == "export":
+		return "", usageError(photoUsage)
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	scene := Scene{Pet: pet, Biome: s.petBiome(pet)}
+	rest := args[1:]
+	for len(rest) > 0 && strings.HasPrefix(rest[0], "+") {
+		if len(scene.Props) == MaxSceneProps {
+			return "", fmt.Errorf("%w: at most %d items fit in a photo", ErrUsage, MaxSceneProps)
+		}
+		name, err := s.prop(strings.TrimPrefix(rest[0], "+"))
+		if err != nil {
+			return "", err
+		}
+		scene.Props = append(scene.Props, name)
+		rest = rest[1:]
+	}
+	scene.Caption = strings.Join(rest, " ")
+
+	picture := ComposeScene(scene)
+	if _, err := s.Household.AddPhoto(pet.ID, fmt.Sprintf("Photo of %s", pet.Name), scene.Caption, picture, time.Now()); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%sSaved to %s's album as #%d.\n", picture, pet.Name, len(s.Household.Album(pet.ID))), nil
+}
+
+// renderAlbum lists a pet's album, numbered oldest first
+func (s *Shell) renderAlbum(pet *core.DigitalPet) string {
+	album := s.Household.Album(pet.ID)
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s's Album (%d) ===\n", pet.Name, len(album))
+	if len(album) == 0 {
+		b.WriteString("  (no pictures yet; try \"photo\")\n")
+	}
+	for i, snapshot := range album {
+		fmt.Fprintf(&b, "  %2d. %s  %s", i+1, snapshot.Taken.Format("2006-01-02"), snapshot.Title)
+		if snapshot.Caption != "" {
+			fmt.Fprintf(&b, " - %q", snapshot.Caption)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// exportPhoto writes an album photo to a file, as a PNG image if the file
+// name ends in .png and as plain text otherwise
+func (s *Shell) exportPhoto(pet *core.DigitalPet, number, file string) (string, error) {
+	album := s.Household.Album(pet.ID)
+	n, err := strconv.Atoi(number)
+	if err != nil || n < 1 || n > len(album) {
+		return "", fmt.Errorf("%w: %s has %d album entries", ErrUsage, pet.Name, len(album))
+	}
+	snapshot := album[n-1]
+	if snapshot.Picture == "" {
+		return "", fmt.Errorf("%w: #%d %s", ErrNoPicture, n, snapshot.Title)
+	}
+
+	var out []byte
+	if strings.EqualFold(filepath.Ext(file), ".png") {
+		var buf bytes.Buffer
+		if err := WriteANSIPNG(&buf, snapshot.Picture); err != nil {
+			return "", err
+		}
+		out = buf.Bytes()
+	} else {
+		out = []byte(StripANSI(snapshot.Picture))
+	}
+	if err := os.WriteFile(file, out, 0o644); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Exported #%d to %s.\n", n, file), nil
+}
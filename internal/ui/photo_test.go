@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func photoShell(t *testing.T) (*Shell, *core.DigitalPet) {
+	t.Helper()
+	shell := NewShell()
+	pet := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(pet)
+	shell.Household = interaction.NewHousehold(pet)
+	return shell, pet
+}
+
+func TestComposeScene(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	picture := ComposeScene(Scene{Pet: pet, Biome: environment.BiomeOcean, Props: []string{"Bed"}, Caption: "Beach day"})
+
+	lines := strings.Split(strings.TrimSuffix(StripANSI(picture), "\n"), "\n")
+	for _, line := range lines[:7] {
+		if len(line) != SceneWidth+2 {
+			t.Errorf("Expected framed lines %d wide, got %q", SceneWidth+2, line)
+		}
+	}
+	if !strings.Contains(lines[1], "~^~~") && !strings.Contains(lines[6], "~^~~") {
+		t.Errorf("Expected an ocean backdrop, got\n%s", StripANSI(picture))
+	}
+	if !strings.Contains(lines[2], "[Bed]") {
+		t.Errorf("Expected the bed beside the pet, got %q", lines[2])
+	}
+	if lines[8] != ` "Beach day"` || lines[9] != " Rex, Ocean" {
+		t.Errorf("Expected the caption and name below, got %q", lines[8:])
+	}
+	if !strings.Contains(picture, "\x1b[34m") {
+		t.Error("Expected the sea drawn in blue")
+	}
+}
+
+func TestPhotoCommand(t *testing.T) {
+	shell, pet := photoShell(t)
+	shell.Habitat.Add(environment.HabitatBlanket)
+
+	if _, err := shell.Execute("photo Rex +heater"); !errors.Is(err, ErrNotOwned) {
+		t.Errorf("Expected ErrNotOwned for a heater not in the home, got %v", err)
+	}
+	out, err := shell.Execute("photo Rex +blanket Snug as a bug")
+	if err != nil {
+		t.Fatalf("photo failed: %v", err)
+	}
+	if !strings.Contains(out, "[Blanket]") || !strings.Contains(out, "Saved to Rex's album as #1") {
+		t.Errorf("Unexpected output:\n%s", out)
+	}
+
+	album := shell.Household.Album(pet.ID)
+	if len(album) != 1 || album[0].Caption != "Snug as a bug" || album[0].Picture == "" {
+		t.Fatalf("Expected the photo in the album, got %+v", album)
+	}
+	if out, _ := shell.Execute("photo album Rex"); !strings.Contains(out, `1. `) || !strings.Contains(out, `"Snug as a bug"`) {
+		t.Errorf("Expected the album listing, got %q", out)
+	}
+}
+
+func TestPhotoExport(t *testing.T) {
+	shell, pet := photoShell(t)
+	if _, err := shell.Execute("photo Rex Hello"); err != nil {
+		t.Fatalf("photo failed: %v", err)
+	}
+	dir := t.TempDir()
+
+	text := filepath.Join(dir, "rex.txt")
+	if _, err := shell.Execute("photo export Rex 1 " + text); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	raw, err := os.ReadFile(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "\x1b") || !strings.Contains(string(raw), `"Hello"`) {
+		t.Errorf("Expected plain text with the caption, got %q", raw)
+	}
+
+	image := filepath.Join(dir, "rex.png")
+	if _, err := shell.Execute("photo export Rex 1 " + image); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if raw, _ := os.ReadFile(image); !strings.HasPrefix(string(raw), "\x89PNG") {
+		t.Error("Expected a PNG file")
+	}
+
+	if _, err := shell.Execute("photo export Rex 2 " + text); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage for a missing entry, got %v", err)
+	}
+	shell.Household.AddPhoto(pet.ID, "Birthday", "", "", pet.CreatedAt)
+	if _, err := shell.Execute("photo export Rex 2 " + text); !errors.Is(err, ErrNoPicture) {
+		t.Errorf("Expected ErrNoPicture, got %v", err)
+	}
+}
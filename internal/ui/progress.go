@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// progressUsage is the usage of the progress command
+const progressUsage = "progress [skip]"
+
+// commandFeatures maps the commands of advanced systems to the feature
+// that unlocks them
+var commandFeatures = map[string]interaction.Feature{
+	"map":        interaction.FeatureExploration,
+	"newworld":   interaction.FeatureExploration,
+	"activities": interaction.FeatureExploration,
+	"fish":       interaction.FeatureExploration,
+	"photo":      interaction.FeatureExploration,
+	"rescue":     interaction.FeatureExploration,
+
+	"garden":      interaction.FeatureEconomy,
+	"cook":        interaction.FeatureEconomy,
+	"adopt":       interaction.FeatureEconomy,
+	"sanctuary":   interaction.FeatureEconomy,
+	"insure":      interaction.FeatureEconomy,
+	"fund":        interaction.FeatureEconomy,
+	"market":      interaction.FeatureEconomy,
+	"wardrobe":    interaction.FeatureEconomy,
+	"exam":        interaction.FeatureEconomy,
+	"leaderboard": interaction.FeatureEconomy,
+
+	"breed":  interaction.FeatureBreeding,
+	"litter": interaction.FeatureBreeding,
+	"rehome": interaction.FeatureBreeding,
+}
+
+// commandLocked returns ErrFeatureLocked if a command belongs to a
+// feature that is not unlocked yet
+func (s *Shell) commandLocked(name string) error {
+	feature, gated := commandFeatures[name]
+	if !gated || s.Unlocks == nil {
+		return nil
+	}
+	return s.Unlocks.Require(feature)
+}
+
+// RenderProgress shows which features are unlocked and how to unlock the
+// next one
+func RenderProgress(unlocks *interaction.Unlocks) string {
+	var b strings.Builder
+	b.WriteString("=== Progress ===\n")
+	for _, step := range interaction.Tutorial {
+		if unlocks.Unlocked(step.Feature) {
+			fmt.Fprintf(&b, "  [x] %-12s %s\n", step.Feature, commandList(step.Feature))
+		} else {
+			fmt.Fprintf(&b, "  [ ] %-12s %s\n", step.Feature, step.Hint)
+		}
+	}
+	if next, ok := unlocks.Next(); ok {
+		fmt.Fprintf(&b, "Next: %s\n", next.Hint)
+		b.WriteString("Know the game already? `progress skip` unlocks everything.\n")
+	} else {
+		b.WriteString("Everything is unlocked.\n")
+	}
+	return b.String()
+}
+
+// commandList names the commands a feature unlocks, alphabetically
+func commandList(f interaction.Feature) string {
+	var names []string
+	for name, feature := range commandFeatures {
+		if feature == f {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// progressCommand handles `progress [skip]`
+func (s *Shell) progressCommand(args []string) (string, error) {
+	if len(args) > 1 || (len(args) == 1 && args[0] != "skip") {
+		return "", usageError(progressUsage)
+	}
+	if s.Unlocks == nil {
+		return "Every feature is available.\n", nil
+	}
+	if len(args) == 1 {
+		if err := s.Unlocks.Skip(); err != nil {
+			return "", err
+		}
+		return "Everything is unlocked. Type \"help\" to see every command.\n", nil
+	}
+	return RenderProgress(s.Unlocks), nil
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestProgressiveUnlocks(t *testing.T) {
+	shell := NewShell()
+	shell.AddPet(core.NewDigitalPet("Mochi", "owner"))
+	if out, err := shell.Execute("progress"); err != nil || !strings.Contains(out, "Every feature") {
+		t.Fatalf("Expected every feature available without unlocks, got %q, %v", out, err)
+	}
+	shell.Unlocks = interaction.NewUnlocks("")
+
+	_, err := shell.Execute("breed mochi mochi")
+	if !errors.Is(err, interaction.ErrFeatureLocked) || !strings.Contains(err.Error(), "adult stage") {
+		t.Errorf("Expected breeding locked until adulthood, got %v", err)
+	}
+	if msg := ErrorMessage(err); !strings.Contains(msg, "progress") {
+		t.Errorf("Expected advice pointing at progress, got %q", msg)
+	}
+	if _, err := shell.Execute("look mochi"); err != nil {
+		t.Errorf("Expected basic care always available, got %v", err)
+	}
+
+	help, _ := shell.Execute("help")
+	if strings.Contains(help, "breed ") || strings.Contains(help, "garden") || !strings.Contains(help, "unlock as your pets grow") {
+		t.Errorf("Expected locked commands hidden from help, got:\n%s", help)
+	}
+	out, _ := shell.Execute("progress")
+	if !strings.Contains(out, "[ ] exploration") || !strings.Contains(out, "Next: Raise a pet to the juvenile stage") {
+		t.Errorf("Expected exploration next, got:\n%s", out)
+	}
+
+	if _, err := shell.Execute("progress everything"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+	if _, err := shell.Execute("progress skip"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := shell.Execute("breed mochi mochi"); errors.Is(err, interaction.ErrFeatureLocked) {
+		t.Errorf("Expected breeding unlocked after skipping, got %v", err)
+	}
+	if out, _ := shell.Execute("progress"); !strings.Contains(out, "[x] breeding") || !strings.Contains(out, "Everything is unlocked") {
+		t.Errorf("Expected everything unlocked, got:\n%s", out)
+	}
+}
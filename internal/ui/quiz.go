@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+)
+
+// QuizSkip is typed to skip the rest of the creation quiz
+const QuizSkip = "skip"
+
+// AskQuiz asks the player each question of a quiz and returns the index
+// of each answer, or -1 for questions left unanswered. An empty line
+// skips a question; QuizSkip or the end of input skips the rest.
+func AskQuiz(in *bufio.Scanner, out io.Writer, quiz []genetics.QuizQuestion) []int {
+	answers := make([]int, len(quiz))
+	for i := range answers {
+		answers[i] = -1
+	}
+
+	fmt.Fprintf(out, "Tell us about the companion you'd like. Press Enter to skip a question, or type %q to skip the quiz.\n", QuizSkip)
+	for i, question := range quiz {
+		fmt.Fprintf(out, "\n%d. %s\n", i+1, question.Prompt)
+		for j, answer := range question.Answers {
+			fmt.Fprintf(out, "   %d) %s\n", j+1, answer.Text)
+		}
+		for {
+			fmt.Fprint(out, "? ")
+			if !in.Scan() {
+				fmt.Fprintln(out)
+				return answers
+			}
+			line := strings.TrimSpace(in.Text())
+			if strings.EqualFold(line, QuizSkip) {
+				return answers
+			}
+			if line == "" {
+				break
+			}
+			if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(question.Answers) {
+				answers[i] = n - 1
+				break
+			}
+			fmt.Fprintf(out, "Please answer 1 to %d.\n", len(question.Answers))
+		}
+	}
+	return answers
+}
+
+// Answered returns true if any quiz question was answered
+func Answered(answers []int) bool {
+	for _, answer := range answers {
+		if answer != -1 {
+			return true
+		}
+	}
+	return false
+}
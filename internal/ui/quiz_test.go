@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+)
+
+func TestAskQuiz(t *testing.T) {
+	quiz := genetics.CreationQuiz[:4]
+	var out bytes.Buffer
+
+	in := bufio.NewScanner(strings.NewReader("2\n\n9\n1\nskip\n"))
+	answers := AskQuiz(in, &out, quiz)
+	if want := []int{1, -1, 0, -1}; !reflect.DeepEqual(answers, want) {
+		t.Errorf("Expected answers %v, got %v", want, answers)
+	}
+	if !strings.Contains(out.String(), "Please answer 1 to 3.") {
+		t.Errorf("Expected an invalid answer to be asked again, got:\n%s", out.String())
+	}
+	if !Answered(answers) {
+		t.Error("Expected the quiz to count as answered")
+	}
+
+	in = bufio.NewScanner(strings.NewReader(""))
+	if answers := AskQuiz(in, &out, quiz); Answered(answers) {
+		t.Errorf("Expected no answers at the end of input, got %v", answers)
+	}
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// ErrNoMarketplace is returned when offering or claiming pets without cloud storage
+var ErrNoMarketplace = errors.New("no cloud marketplace available")
+
+// rehomeUsage lists where pets can be adopted out to
+const rehomeUsage = "rehome <pet|pup> [center | sanctuary | market | friend <account>]"
+
+// parseDestination reads where a pet is going, and for a friend, their
+// account. No arguments means the adoption center.
+func parseDestination(args []string) (interaction.Destination, string, error) {
+	switch {
+	case len(args) == 0, len(args) == 1 && args[0] == "center":
+		return interaction.DestinationCenter, "", nil
+	case len(args) == 1 && args[0] == "sanctuary":
+		return interaction.DestinationSanctuary, "", nil
+	case len(args) == 1 && args[0] == "market":
+		return interaction.DestinationMarketplace, "", nil
+	case len(args) == 2 && args[0] == "friend":
+		return interaction.DestinationFriend, args[1], nil
+	}
+	return 0, "", usageError(rehomeUsage)
+}
+
+// RenderMarket lists the pets and items this player may claim from the
+// marketplace
+func RenderMarket(listings []data.MarketListing) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Marketplace (%d offers) ===\n", len(listings))
+	if len(listings) == 0 {
+		b.WriteString("  (nobody is offering anything right now)\n")
+		return b.String()
+	}
+	for _, listing := range listings {
+		note := ""
+		if listing.Item != "" {
+			note = " (accessory)"
+		}
+		if listing.For != "" {
+			note += " (reserved for you)"
+		}
+		fmt.Fprintf(&b, "  %-16s from %-12s %s%s\n", listing.Name, listing.From, listing.Listed.Local().Format("2006-01-02"), note)
+	}
+	b.WriteString("Use `market claim <name>` to bring one home.\n")
+	return b.String()
+}
+
+// rehomeCommand handles `rehome <pet|pup> [destination]`
+func (s *Shell) rehomeCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", usageError(rehomeUsage)
+	}
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	destination, friend, err := parseDestination(args[1:])
+	if err != nil {
+		return "", err
+	}
+	return s.adoptOut(args[0], destination, friend)
+}
+
+// adoptOut sends a pet or pup away. Pets bound for the cloud are offered
+// before they leave, so a failed upload keeps them at home. A pet retired
+// to the sanctuary is archived; other saves are removed.
+func (s *Shell) adoptOut(name string, destination interaction.Destination, friend string) (string, error) {
+	pet, err := s.Household.Leaving(name)
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	if destination == interaction.DestinationMarketplace || destination == interaction.DestinationFriend {
+		if s.Market == nil {
+			return "", ErrNoMarketplace
+		}
+		if err := s.Market.Offer(ctx, pet, friend); err != nil {
+			return "", err
+		}
+	}
+	if _, err := s.Household.AdoptOut(string(pet.ID), destination); err != nil {
+		return "", err
+	}
+	s.RemovePet(pet.ID)
+
+	to := destination.String()
+	if destination == interaction.DestinationFriend {
+		to = friend
+	}
+	s.audit(data.AuditRehome, pet.ID, "sent %s to %s", pet.Name, to)
+	if s.Data != nil {
+		if destination == interaction.DestinationSanctuary {
+			err = s.Data.ArchivePet(ctx, pet)
+		} else if err = s.Data.DeletePet(ctx, pet.ID); errors.Is(err, data.ErrPetNotFound) {
+			err = nil // Pups are not saved until kept
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s left, but their save could not be updated: %w", pet.Name, err)
+		}
+	}
+
+	switch destination {
+	case interaction.DestinationSanctuary:
+		return fmt.Sprintf("%s is off to the sanctuary, where the villagers will look after them. Use `unarchive %s` to bring them back.\n",
+			pet.Name, pet.Name), nil
+	case interaction.DestinationMarketplace:
+		return fmt.Sprintf("%s is on the marketplace for another player to adopt.\n", pet.Name), nil
+	case interaction.DestinationFriend:
+		return fmt.Sprintf("%s is waiting on the marketplace for %s to claim them.\n", pet.Name, friend), nil
+	}
+	return fmt.Sprintf("%s will wait at the adoption center for a new family.\n", pet.Name), nil
+}
+
+// marketCommand handles `market [claim <pet|item>]`
+func (s *Shell) marketCommand(args []string) (string, error) {
+	if s.Market == nil {
+		return "", ErrNoMarketplace
+	}
+	ctx := context.Background()
+	switch {
+	case len(args) == 0:
+		listings, err := s.Market.Listings(ctx)
+		if err != nil {
+			return "", err
+		}
+		return RenderMarket(listings), nil
+	case len(args) == 2 && args[0] == "claim":
+	default:
+		return "", usageError("market [claim <pet|item>]")
+	}
+
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	if accessory, err := environment.ParseAccessory(args[1]); err == nil {
+		return s.claimAccessory(ctx, accessory)
+	}
+	if err := s.Household.CheckRoom(); err != nil {
+		return "", err
+	}
+	pet, err := s.Market.Claim(ctx, args[1], s.Owner)
+	if err != nil {
+		return "", err
+	}
+	s.Household.AddPet(pet)
+	s.AddPet(pet)
+	s.audit(data.AuditAdopt, pet.ID, "claimed %s from the marketplace", pet.Name)
+	return fmt.Sprintf("%s is coming home with you. Welcome, %s!\n", pet.Name, pet.Name), nil
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// marketShell returns a shell for an account, using the marketplace
+// provider if there is one
+func marketShell(provider data.CloudProvider, account string, pets ...*core.DigitalPet) *Shell {
+	shell := NewShell()
+	shell.Owner = types.UserID("owner-" + account)
+	for _, pet := range pets {
+		shell.AddPet(pet)
+	}
+	shell.Household = interaction.NewHousehold(pets...)
+	if provider != nil {
+		shell.Market = data.NewMarketplace(provider, account)
+	}
+	return shell
+}
+
+func TestRehomeThroughMarketplace(t *testing.T) {
+	provider := data.NewMemoryProvider()
+	sprout := core.NewDigitalPet("Sprout", "owner-ann")
+	ann := marketShell(provider, "ann", core.NewDigitalPet("Rex", "owner-ann"), sprout)
+	ben := marketShell(provider, "ben")
+	cal := marketShell(provider, "cal")
+
+	if _, err := ann.Execute("rehome sprout friend ben"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ann.FindPet("Sprout"); err == nil {
+		t.Error("Sprout should have left Ann's shell")
+	}
+	if out, _ := cal.Execute("market"); strings.Contains(out, "Sprout") {
+		t.Errorf("A pet reserved for Ben should be hidden from Cal, got:\n%s", out)
+	}
+	if out, _ := ben.Execute("market"); !strings.Contains(out, "Sprout") || !strings.Contains(out, "reserved for you") {
+		t.Errorf("Expected Sprout reserved for Ben, got:\n%s", out)
+	}
+
+	ben.Household.PopulationCap = 1
+	ben.AddPet(core.NewDigitalPet("Milo", "owner-ben"))
+	ben.Household.AddPet(ben.Pets[0])
+	if _, err := ben.Execute("market claim sprout"); !errors.Is(err, interaction.ErrHouseholdFull) {
+		t.Errorf("Expected ErrHouseholdFull at the cap, got %v", err)
+	}
+	ben.Household.PopulationCap = 0
+	if _, err := ben.Execute("market claim sprout"); err != nil {
+		t.Fatal(err)
+	}
+	if pet, err := ben.FindPet("Sprout"); err != nil || pet.Owner != "owner-ben" {
+		t.Errorf("Expected Sprout to belong to Ben now, got %v (%v)", pet, err)
+	}
+}
+
+func TestRehomeToSanctuary(t *testing.T) {
+	dm, err := data.NewDataManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	milo := core.NewDigitalPet("Milo", "sam")
+	shell := marketShell(nil, "sam", core.NewDigitalPet("Rex", "sam"), milo)
+	shell.Data = dm
+
+	if _, err := shell.Execute("rehome milo market"); !errors.Is(err, ErrNoMarketplace) {
+		t.Errorf("Expected ErrNoMarketplace without cloud storage, got %v", err)
+	}
+	out, err := shell.Execute("rehome milo sanctuary")
+	if err != nil || !strings.Contains(out, "sanctuary") {
+		t.Fatalf("Expected Milo sent to the sanctuary, got %q (%v)", out, err)
+	}
+	if !dm.IsArchived(milo.ID) {
+		t.Error("A pet retired to the sanctuary should be archived")
+	}
+	if _, err := shell.Execute("rehome rex zoo"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage for an unknown destination, got %v", err)
+	}
+}
+
+func TestBreedWarnsAboutCap(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "sam")
+	bella := core.NewDigitalPet("Bella", "sam")
+	shell := marketShell(nil, "sam", rex, bella)
+	shell.Household.PopulationCap = 2
+	out, err := shell.Execute("breed rex bella")
+	if err != nil || !strings.Contains(out, "past its cap of 2") {
+		t.Errorf("Expected a warning about the cap, got %q (%v)", out, err)
+	}
+}
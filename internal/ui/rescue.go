@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// rescueUsage describes the rescue command
+const rescueUsage = "rescue [<missing pet> <location> [<searching pet>]]"
+
+// RenderRescues lists missing pets with the clues found so far and the
+// places already searched
+func RenderRescues(rescues []interaction.Rescue, world *environment.WorldMap) string {
+	name := func(id string) string {
+		if loc, exists := world.Location(id); exists {
+			return fmt.Sprintf("%s (%s)", loc.Name, id)
+		}
+		return id
+	}
+
+	var b strings.Builder
+	b.WriteString("=== Missing pets ===\n")
+	if len(rescues) == 0 {
+		b.WriteString("  (everyone is home)\n")
+	}
+	for _, rescue := range rescues {
+		fmt.Fprintf(&b, "%s, missing for %.1f days\n", rescue.Pet.Name, rescue.Days)
+		if len(rescue.Clues) == 0 {
+			b.WriteString("  Clues: none yet\n")
+		}
+		counts := make(map[string]int)
+		var order []string
+		for _, clue := range rescue.Clues {
+			if counts[clue] == 0 {
+				order = append(order, clue)
+			}
+			counts[clue]++
+		}
+		for _, clue := range order {
+			fmt.Fprintf(&b, "  Clue: seen near %s x%d\n", name(clue), counts[clue])
+		}
+		if len(rescue.Searched) > 0 {
+			searched := make([]string, len(rescue.Searched))
+			for i, id := range rescue.Searched {
+				searched[i] = name(id)
+			}
+			fmt.Fprintf(&b, "  Searched: %s\n", strings.Join(searched, ", "))
+		}
+	}
+	b.WriteString("Some clues are false leads, and a search can miss a pet that is there.\n")
+	return b.String()
+}
+
+// rescueCommand handles `rescue [<missing pet> <location> [<searching pet>]]`
+func (s *Shell) rescueCommand(args []string) (string, error) {
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	if len(args) == 0 {
+		return RenderRescues(s.Household.Missing(), s.World), nil
+	}
+	if len(args) != 2 && len(args) != 3 {
+		return "", usageError(rescueUsage)
+	}
+	missing, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	var searcherID types.PetID
+	if len(args) == 3 {
+		searcher, err := s.FindPet(args[2])
+		if err != nil {
+			return "", err
+		}
+		searcherID = searcher.ID
+	}
+
+	location := s.resolveDestination(args[1])
+	result, err := s.Household.Search(missing.ID, location, searcherID)
+	if err != nil {
+		return "", err
+	}
+	who := "You search"
+	if result.Searcher != "" {
+		who = result.Searcher + " sniffs around"
+	}
+	place := location
+	if loc, exists := s.World.Location(location); exists {
+		place = loc.Name
+	}
+	if !result.Found {
+		return fmt.Sprintf("%s %s, but there is no sign of %s.\n", who, place, missing.Name), nil
+	}
+	return fmt.Sprintf("%s %s and finds %s! They are home again.\n", who, place, missing.Name), nil
+}
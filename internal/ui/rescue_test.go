@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestRescueCommand(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "owner-ann")
+	bella := core.NewDigitalPet("Bella", "owner-ann")
+	shell := marketShell(nil, "ann", rex, bella)
+	shell.Household.World = shell.World
+
+	if out, _ := shell.Execute("rescue"); !strings.Contains(out, "everyone is home") {
+		t.Errorf("Expected nobody missing, got:\n%s", out)
+	}
+	if _, err := shell.Execute("rescue rex forest"); !errors.Is(err, interaction.ErrNotMissing) {
+		t.Errorf("Expected ErrNotMissing, got %v", err)
+	}
+
+	rex.Biology.Vitals.Happiness = 0.05
+	rex.Biology.Vitals.Stress = 0.9
+	shell.Household.Update(2.0)
+	missing := shell.Household.Missing()
+	if len(missing) != 1 {
+		t.Fatalf("Expected Rex to run away, got %+v", missing)
+	}
+	if out, _ := shell.Execute("rescue"); !strings.Contains(out, "Rex, missing for") {
+		t.Errorf("Expected Rex listed as missing, got:\n%s", out)
+	}
+
+	wrong := "beach"
+	if missing[0].Hideout == wrong {
+		wrong = "forest"
+	}
+	out, err := shell.Execute("rescue rex " + wrong + " bella")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Bella sniffs around") || !strings.Contains(out, "no sign of Rex") {
+		t.Errorf("Expected a failed search by Bella, got:\n%s", out)
+	}
+	if out, _ := shell.Execute("rescue"); !strings.Contains(out, "Searched: ") {
+		t.Errorf("Expected the search recorded, got:\n%s", out)
+	}
+
+	for i := 0; i < 30 && len(shell.Household.Missing()) > 0; i++ {
+		if out, err = shell.Execute("rescue rex " + missing[0].Hideout); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !strings.Contains(out, "finds Rex") || rex.Location != environment.HomeLocation {
+		t.Errorf("Expected Rex found and home, got:\n%s", out)
+	}
+	if _, err := shell.Execute("rescue rex"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
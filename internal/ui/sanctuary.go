@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// sanctuaryUsage lists the sanctuary subcommands
+const sanctuaryUsage = "sanctuary [donate <coins|item> [count] | visit <pet>]"
+
+// RenderSanctuary shows the household's community standing, what the next
+// tier unlocks and the most recent donations
+func RenderSanctuary(stats interaction.SanctuaryStats, history []interaction.Donation) string {
+	var b strings.Builder
+	tier := interaction.TierFor(stats.Standing)
+	fmt.Fprintf(&b, "=== Sanctuary (standing %d, %s) ===\n", stats.Standing, tier)
+	if next := tier + 1; next <= interaction.StandingBenefactor {
+		fmt.Fprintf(&b, "  %d more for %s: %s\n", next.Threshold()-stats.Standing, next, next.Reward())
+	}
+	fmt.Fprintf(&b, "  %d donation(s), %d visit(s)", stats.Donations, stats.Visits)
+	for _, kind := range []string{interaction.DonationCoins, interaction.DonationProduce, interaction.DonationFish, interaction.DonationAccessories} {
+		if value := stats.ByKind[kind]; value > 0 {
+			fmt.Fprintf(&b, ", %s %d", kind, value)
+		}
+	}
+	b.WriteString("\n")
+	for i := len(history) - 1; i >= 0 && i >= len(history)-5; i-- {
+		donation := history[i]
+		fmt.Fprintf(&b, "  %s  %d %s (+%d)\n", donation.Time.Local().Format("2006-01-02 15:04"), donation.Count, donation.Item, donation.Value)
+	}
+	b.WriteString("Use `sanctuary donate coins <amount>` or `sanctuary donate <item> [count]` to give.\n")
+	return b.String()
+}
+
+// sanctuaryCommand handles the `sanctuary` command and its subcommands
+func (s *Shell) sanctuaryCommand(args []string) (string, error) {
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+
+	switch {
+	case len(args) == 0:
+		return RenderSanctuary(s.Household.SanctuaryStats(), s.Household.DonationHistory()), nil
+
+	case len(args) == 2 && args[0] == "visit":
+		pet, err := s.FindPet(args[1])
+		if err != nil {
+			return "", err
+		}
+		if err := s.Household.VisitSanctuary(pet.ID); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s spent the afternoon with the animals at the sanctuary and came home beaming.\n", pet.Name), nil
+
+	case len(args) >= 2 && args[0] == "donate":
+		item, count := args[1:], 1
+		if n, err := strconv.Atoi(item[len(item)-1]); err == nil && len(item) > 1 {
+			item, count = item[:len(item)-1], n
+		}
+		donation, err := s.Household.Donate(strings.Join(item, " "), count)
+		if err != nil {
+			return "", err
+		}
+		s.audit(data.AuditDonate, "", "donated %d %s to the sanctuary", donation.Count, donation.Item)
+		stats := s.Household.SanctuaryStats()
+		return fmt.Sprintf("Thank you! You gave %d %s to the sanctuary (+%d standing, now %d, %s).\n",
+			donation.Count, donation.Item, donation.Value, stats.Standing, stats.Tier), nil
+	}
+	return "", usageError(sanctuaryUsage)
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestSanctuaryCommand(t *testing.T) {
+	shell := NewShell()
+	pet := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(pet)
+	shell.Household = interaction.NewHousehold(pet)
+
+	if _, err := shell.Execute("sanctuary visit Rex"); !errors.Is(err, interaction.ErrStandingTooLow) {
+		t.Errorf("Expected ErrStandingTooLow, got %v", err)
+	}
+	out, err := shell.Execute("sanctuary donate coins 60")
+	if err != nil {
+		t.Fatalf("sanctuary donate failed: %v", err)
+	}
+	if !strings.Contains(out, "+60 standing") || !strings.Contains(out, "Supporter") {
+		t.Errorf("Expected the new standing, got %q", out)
+	}
+	if _, err := shell.Execute("sanctuary visit Rex"); err != nil {
+		t.Errorf("sanctuary visit failed: %v", err)
+	}
+
+	listing, err := shell.Execute("sanctuary")
+	if err != nil {
+		t.Fatalf("sanctuary failed: %v", err)
+	}
+	for _, want := range []string{"standing 60", "90 more for Friend", "60 coins (+60)", "1 visit(s)"} {
+		if !strings.Contains(listing, want) {
+			t.Errorf("Expected %q in the listing, got:\n%s", want, listing)
+		}
+	}
+	if _, err := shell.Execute("sanctuary donate"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
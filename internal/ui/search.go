@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// Search command usages
+const (
+	findUsage = "find <terms>..."
+	tagUsage  = "tag <pet> [add|remove <tag>...]"
+)
+
+// RenderSearchResults lists the pets a search found with their tags and
+// the levels of the traits it asked about
+func RenderSearchResults(pets []*core.DigitalPet, s core.Search) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Found %d pet(s) ===\n", len(pets))
+	if len(pets) == 0 {
+		b.WriteString("  (no pets match)\n")
+	}
+	for _, pet := range pets {
+		fmt.Fprintf(&b, "  %-12s", pet.Name)
+		for _, condition := range s.Traits {
+			level, _ := pet.TraitLevel(condition.Trait)
+			fmt.Fprintf(&b, " %s %.2f", condition.Trait, level)
+		}
+		if len(pet.Tags) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(pet.Tags, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// findCommand handles `find <terms>...`, e.g. `find playful fur>0.7 tag:favorite`
+func (s *Shell) findCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", usageError(findUsage)
+	}
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	search, err := core.ParseSearch(strings.Join(args, " "))
+	if err != nil {
+		return "", err
+	}
+	return RenderSearchResults(s.Household.FindPets(search), search), nil
+}
+
+// tagCommand handles `tag <pet> [add|remove <tag>...]`
+func (s *Shell) tagCommand(args []string) (string, error) {
+	if len(args) == 0 || len(args) == 2 {
+		return "", usageError(tagUsage)
+	}
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 1 {
+		if len(pet.Tags) == 0 {
+			return fmt.Sprintf("%s has no tags.\n", pet.Name), nil
+		}
+		return fmt.Sprintf("%s is tagged %s.\n", pet.Name, strings.Join(pet.Tags, ", ")), nil
+	}
+
+	switch args[1] {
+	case "add":
+		if err := s.Household.TagPet(pet.ID, args[2:]...); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s is tagged %s.\n", pet.Name, strings.Join(pet.Tags, ", ")), nil
+	case "remove":
+		removed, err := s.Household.UntagPet(pet.ID, args[2:]...)
+		if err != nil {
+			return "", err
+		}
+		if len(removed) == 0 {
+			return fmt.Sprintf("%s had none of those tags.\n", pet.Name), nil
+		}
+		return fmt.Sprintf("Removed %s from %s.\n", strings.Join(removed, ", "), pet.Name), nil
+	}
+	return "", usageError(tagUsage)
+}
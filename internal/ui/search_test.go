@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestTagAndFindCommands(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "owner-ann")
+	rex.Personality.Traits.Playfulness = 0.9
+	bo := core.NewDigitalPet("Bo", "owner-ann")
+	bo.Personality.Traits.Playfulness = 0.2
+	shell := marketShell(nil, "ann", rex, bo)
+
+	out, err := shell.Execute("tag rex add Favorite breeder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "breeder, favorite") {
+		t.Errorf("Expected both tags listed, got:\n%s", out)
+	}
+	if _, err := shell.Execute("tag bo add favorite"); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err = shell.Execute("find playful tag:favorite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Found 1 pet") || !strings.Contains(out, "Rex") || strings.Contains(out, "Bo ") ||
+		!strings.Contains(out, "playfulness 0.90") {
+		t.Errorf("Expected only Rex with his playfulness, got:\n%s", out)
+	}
+	if out, _ := shell.Execute("find tag:retired"); !strings.Contains(out, "(no pets match)") {
+		t.Errorf("Expected no retired pets, got:\n%s", out)
+	}
+	if _, err := shell.Execute("find wings>0.5"); !errors.Is(err, core.ErrBadSearch) {
+		t.Errorf("Expected ErrBadSearch, got %v", err)
+	}
+
+	if out, _ := shell.Execute("tag rex remove breeder"); !strings.Contains(out, "Removed breeder from Rex") {
+		t.Errorf("Expected breeder removed, got:\n%s", out)
+	}
+	if out, _ := shell.Execute("tag rex"); out != "Rex is tagged favorite.\n" {
+		t.Errorf("Expected only favorite left, got %q", out)
+	}
+}
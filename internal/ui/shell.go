@@ -0,0 +1,419 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/social"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+var (
+	// ErrUnknownCommand is returned when a command name is not registered
+	ErrUnknownCommand = errors.New("unknown command")
+	// ErrPetNotFound is returned when a pet name or ID does not match any pet
+	ErrPetNotFound = errors.New("pet not found")
+	// ErrUsage is returned when a command is called with the wrong arguments
+	ErrUsage = errors.New("invalid usage")
+)
+
+// CommandHandler executes a command with its arguments and returns the output
+type CommandHandler func(args []string) (string, error)
+
+// Command describes a registered shell command
+type Command struct {
+	Name        string
+	Usage       string
+	Description string
+	Handler     CommandHandler
+}
+
+// Shell dispatches typed commands to registered handlers
+type Shell struct {
+	mu sync.RWMutex
+
+	Pets      []*core.DigitalPet
+	Inbox     *interaction.Inbox
+	World     *environment.WorldMap
+	Territory *social.TerritoryMap
+	Weather   *environment.WeatherSystem // Optional; nil hides forecasts
+	Events    *environment.Manager       // Optional; nil hides location events
+	Garden    *environment.Garden
+	Inventory *environment.Inventory
+	Habitat   *environment.Habitat
+	Data      *data.DataManager      // Optional; nil disables archiving
+	Household *interaction.Household // Optional; kept in step when pets are archived
+	Market    *data.Marketplace      // Optional; nil keeps pets off the cloud marketplace
+	Owner     types.UserID           // Owner of pets the player adopts
+	Unlocks   *interaction.Unlocks   // Optional; nil leaves every command available
+	// Qualitative describes pets' vitals in words instead of showing
+	// numbers and bars
+	Qualitative bool
+	commands    map[string]Command
+	thoughts    *interaction.ThoughtGenerator
+	impressions biology.Impressions
+	rng         *rand.Rand
+
+	pendingRestore string // Checkpoint waiting for `checkpoint confirm`
+}
+
+// NewShell creates a shell with the built-in commands registered
+func NewShell() *Shell {
+	s := &Shell{
+		Pets:      make([]*core.DigitalPet, 0),
+		Inbox:     interaction.NewInbox(),
+		World:     environment.DefaultWorldMap(),
+		Territory: social.NewTerritoryMap(),
+		Garden:    environment.NewGarden(),
+		Inventory: environment.NewInventory(),
+		Habitat:   environment.NewHabitat(),
+		commands:  make(map[string]Command),
+		thoughts:  interaction.NewThoughtGenerator(),
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	s.Register(Command{
+		Name:        "help",
+		Usage:       "help",
+		Description: "list available commands",
+		Handler:     s.helpCommand,
+	})
+	s.Register(Command{
+		Name:        "look",
+		Usage:       "look <pet>",
+		Description: "draw a pet's current appearance",
+		Handler:     s.lookCommand,
+	})
+	s.Register(Command{
+		Name:        "genome",
+		Usage:       "genome <pet> [other-pet]",
+		Description: "inspect a pet's genome or compare two pets",
+		Handler:     s.genomeCommand,
+	})
+	s.Register(Command{
+		Name:        "population",
+		Usage:       "population [csv <file>]",
+		Description: "show population genetics for all pets, or export them as CSV",
+		Handler:     s.populationCommand,
+	})
+	s.Register(Command{
+		Name:        "social",
+		Usage:       "social",
+		Description: "summarise the social network between pets",
+		Handler:     s.socialCommand,
+	})
+	s.Register(Command{
+		Name:        "map",
+		Usage:       "map [pet] [destination]",
+		Description: "show the world map or send a pet travelling",
+		Handler:     s.mapCommand,
+	})
+	s.Register(Command{
+		Name:        "newworld",
+		Usage:       "newworld [seed] [locations]",
+		Description: "generate a fresh world and send every pet home",
+		Handler:     s.newWorldCommand,
+	})
+	s.Register(Command{
+		Name:        "observe",
+		Usage:       "observe <pet> [minutes]",
+		Description: "quietly watch a pet and write up what it does",
+		Handler:     s.observeCommand,
+	})
+	s.Register(Command{
+		Name:        "memories",
+		Usage:       "memories <pet> [tag]",
+		Description: "list a pet's strongest memories, optionally by tag",
+		Handler:     s.memoriesCommand,
+	})
+	s.Register(Command{
+		Name:        "footprint",
+		Usage:       "footprint <pet>",
+		Description: "show how much history a pet keeps and its saved size",
+		Handler:     s.footprintCommand,
+	})
+	s.Register(Command{
+		Name:        "mail",
+		Usage:       "mail [<id> | read-all | delete <id>]",
+		Description: "browse messages in your inbox",
+		Handler:     s.mailCommand,
+	})
+	s.Register(Command{
+		Name:        "garden",
+		Usage:       "garden [action] [args]",
+		Description: "gather seeds, plant, water, tend, harvest and feed produce",
+		Handler:     s.gardenCommand,
+	})
+	s.Register(Command{
+		Name:        "cook",
+		Usage:       cookUsage,
+		Description: "cook fish, produce and dairy into meals, buy dairy or serve a meal",
+		Handler:     s.cookCommand,
+	})
+	s.Register(Command{
+		Name:        "habitat",
+		Usage:       "habitat [add|remove <item>]",
+		Description: "furnish the home to keep pets warm or cool",
+		Handler:     s.habitatCommand,
+	})
+	s.Register(Command{
+		Name:        "activities",
+		Usage:       "activities <pet>",
+		Description: "list what the weather and time allow at a pet's location",
+		Handler:     s.activitiesCommand,
+	})
+	s.Register(Command{
+		Name:        "fish",
+		Usage:       "fish <pet> | album | feed",
+		Description: "fish at a pet's location, browse the album or feed a catch",
+		Handler:     s.fishCommand,
+	})
+	s.Register(Command{
+		Name:        "photo",
+		Usage:       "photo <pet> [+item ...] [caption] | album | export",
+		Description: "pose a pet with your items for the album, or export a photo as text or PNG",
+		Handler:     s.photoCommand,
+	})
+	s.Register(Command{
+		Name:        "adopt",
+		Usage:       "adopt [<name>]",
+		Description: "meet the pets at the adoption center or adopt one for a fee",
+		Handler:     s.adoptCommand,
+	})
+	s.Register(Command{
+		Name:        "sanctuary",
+		Usage:       sanctuaryUsage,
+		Description: "donate coins or surplus items to the sanctuary, or take a pet to visit",
+		Handler:     s.sanctuaryCommand,
+	})
+	s.Register(Command{
+		Name:        "insure",
+		Usage:       insureUsage,
+		Description: "compare insurance plans for a pet, take one out or cancel it",
+		Handler:     s.insureCommand,
+	})
+	s.Register(Command{
+		Name:        "vet",
+		Usage:       "vet <pet>",
+		Description: "pay for surgery or illness treatment, with insurance and the emergency fund",
+		Handler:     s.vetCommand,
+	})
+	s.Register(Command{
+		Name:        "fund",
+		Usage:       fundUsage,
+		Description: "set coins aside for vet bills, or take them back",
+		Handler:     s.fundCommand,
+	})
+	s.Register(Command{
+		Name:        "breed",
+		Usage:       "breed <pet> <pet>",
+		Description: "pair two pets; their litter is born a few days later",
+		Handler:     s.breedCommand,
+	})
+	s.Register(Command{
+		Name:        "litter",
+		Usage:       litterUsage,
+		Description: "name newborn pups and decide who stays and who is rehomed",
+		Handler:     s.litterCommand,
+	})
+	s.Register(Command{
+		Name:        "rehome",
+		Usage:       rehomeUsage,
+		Description: "adopt a pet or pup out to the adoption center, the sanctuary, the marketplace or a friend",
+		Handler:     s.rehomeCommand,
+	})
+	s.Register(Command{
+		Name:        "market",
+		Usage:       "market [claim <pet|item>]",
+		Description: "browse pets and items offered through the cloud, or claim one",
+		Handler:     s.marketCommand,
+	})
+	s.Register(Command{
+		Name:        "wardrobe",
+		Usage:       wardrobeUsage,
+		Description: "buy accessories, dress pets up, or trade accessories on the marketplace",
+		Handler:     s.wardrobeCommand,
+	})
+	s.Register(Command{
+		Name:        "stats",
+		Usage:       "stats <pet>",
+		Description: "see a pet's vital stats and how well fed it is in each nutrient",
+		Handler:     s.statsCommand,
+	})
+	s.Register(Command{
+		Name:        "skills",
+		Usage:       "skills <pet>",
+		Description: "see a pet's skills and the synergies that training them together earns",
+		Handler:     s.skillsCommand,
+	})
+	s.Register(Command{
+		Name:        "exam",
+		Usage:       examUsage,
+		Description: "book a skill certification exam, or see exams and certificates",
+		Handler:     s.examCommand,
+	})
+	s.Register(Command{
+		Name:        "leaderboard",
+		Usage:       "leaderboard",
+		Description: "rank certified pets by their leaderboard credits",
+		Handler:     s.leaderboardCommand,
+	})
+	s.Register(Command{
+		Name:        "title",
+		Usage:       titleUsage,
+		Description: "list the titles a pet has earned, or choose the one shown with its name",
+		Handler:     s.titleCommand,
+	})
+	s.Register(Command{
+		Name:        "progress",
+		Usage:       progressUsage,
+		Description: "see which features are unlocked and how to unlock the next",
+		Handler:     s.progressCommand,
+	})
+	s.Register(Command{
+		Name:        "find",
+		Usage:       findUsage,
+		Description: "search pets by name, tag:<tag>, traits like fur>0.7 or words like playful",
+		Handler:     s.findCommand,
+	})
+	s.Register(Command{
+		Name:        "tag",
+		Usage:       tagUsage,
+		Description: "list a pet's tags, or add and remove labels such as favorite or breeder",
+		Handler:     s.tagCommand,
+	})
+	s.Register(Command{
+		Name:        "rescue",
+		Usage:       rescueUsage,
+		Description: "list pets that ran away and the clues to where they are, or search a location for one",
+		Handler:     s.rescueCommand,
+	})
+	s.Register(Command{
+		Name:        "archive",
+		Usage:       "archive [<pet>]",
+		Description: "retire a pet from the game, or list retired pets",
+		Handler:     s.archiveCommand,
+	})
+	s.Register(Command{
+		Name:        "checkpoint",
+		Usage:       "checkpoint [save|restore|confirm|delete]",
+		Description: "keep named snapshots of a pet or the household and restore them",
+		Handler:     s.checkpointCommand,
+	})
+	s.Register(Command{
+		Name:        "audit",
+		Usage:       auditUsage,
+		Description: "list who changed what, e.g. audit pet=Rex action=interact",
+		Handler:     s.auditCommand,
+	})
+	s.Register(Command{
+		Name:        "unarchive",
+		Usage:       "unarchive <pet>",
+		Description: "bring an archived pet back into the household",
+		Handler:     s.unarchiveCommand,
+	})
+
+	return s
+}
+
+// Register adds or replaces a command
+func (s *Shell) Register(cmd Command) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.commands[cmd.Name] = cmd
+}
+
+// AddPet makes a pet available to shell commands
+func (s *Shell) AddPet(pet *core.DigitalPet) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Pets = append(s.Pets, pet)
+}
+
+// RemovePet takes a pet out of shell commands
+func (s *Shell) RemovePet(id types.PetID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, pet := range s.Pets {
+		if pet.ID == id {
+			s.Pets = append(s.Pets[:i], s.Pets[i+1:]...)
+			return
+		}
+	}
+}
+
+// FindPet looks up a pet by ID or case-insensitive name
+func (s *Shell) FindPet(nameOrID string) (*core.DigitalPet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, pet := range s.Pets {
+		if pet.ID == types.PetID(nameOrID) || strings.EqualFold(pet.Name, nameOrID) {
+			return pet, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPetNotFound, nameOrID)
+}
+
+// Execute parses and runs a single command line
+func (s *Shell) Execute(line string) (string, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	s.mu.RLock()
+	cmd, exists := s.commands[fields[0]]
+	s.mu.RUnlock()
+
+	if !exists {
+		return "", fmt.Errorf("%w: %s", ErrUnknownCommand, fields[0])
+	}
+	if err := s.commandLocked(fields[0]); err != nil {
+		return "", err
+	}
+
+	return cmd.Handler(fields[1:])
+}
+
+// helpCommand lists registered commands
+func (s *Shell) helpCommand(args []string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	names := make([]string, 0, len(s.commands))
+	for name := range s.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	locked := 0
+	for _, name := range names {
+		if s.commandLocked(name) != nil {
+			locked++
+			continue
+		}
+		cmd := s.commands[name]
+		fmt.Fprintf(&b, "  %-28s %s\n", cmd.Usage, cmd.Description)
+	}
+	if locked > 0 {
+		fmt.Fprintf(&b, "%d more commands unlock as your pets grow; type \"progress\" to see how.\n", locked)
+	}
+	return b.String(), nil
+}
+
+// usageError builds an ErrUsage error for a command
+func usageError(usage string) error {
+	return fmt.Errorf("%w: %s", ErrUsage, usage)
+}
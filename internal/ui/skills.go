@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// RenderSkills shows a pet's skills and how close it is to each synergy,
+// so players can plan which skills to train together
+func RenderSkills(pet *core.DigitalPet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s's skills ===\n", pet.Name)
+	for _, skill := range ai.AllSkills() {
+		level := pet.Skills.Level(skill)
+		fmt.Fprintf(&b, "  %-16s %s %3.0f%%\n", skill, RenderBar(level, 10), level*100)
+	}
+	fmt.Fprintf(&b, "Synergies (both skills at %.0f%%):\n", ai.SynergyLevel*100)
+	for _, rule := range ai.SynergyTable {
+		mark := "[ ]"
+		if pet.Skills.Has(rule.Synergy) {
+			mark = "[x]"
+		}
+		fmt.Fprintf(&b, "  %s %-22s %s + %s: %s\n", mark, rule.Synergy, rule.Skills[0], rule.Skills[1], rule.Effect)
+	}
+	return b.String()
+}
+
+// skillsCommand handles `skills <pet>`
+func (s *Shell) skillsCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", usageError("skills <pet>")
+	}
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	return RenderSkills(pet), nil
+}
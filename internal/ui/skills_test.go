@@ -0,0 +1,32 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestSkillsCommand(t *testing.T) {
+	shell := NewShell()
+	rex := core.NewDigitalPet("Rex", "owner")
+	rex.Skills.Levels[ai.SkillObedience] = 0.9
+	rex.Skills.Levels[ai.SkillForaging] = 0.6
+	shell.AddPet(rex)
+
+	out, err := shell.Execute("skills rex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Obedience") || !strings.Contains(out, " 90%") {
+		t.Errorf("Expected Rex's obedience shown, got:\n%s", out)
+	}
+	if !strings.Contains(out, "[x] off-leash exploration") || !strings.Contains(out, "[ ] advanced agility") {
+		t.Errorf("Expected only off-leash exploration earned, got:\n%s", out)
+	}
+	if _, err := shell.Execute("skills"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package ui
+
+import "github.com/Michael-W-Ellison/gochi/internal/core"
+
+// socialCommand handles `social`
+func (s *Shell) socialCommand(args []string) (string, error) {
+	s.mu.RLock()
+	pets := append([]*core.DigitalPet(nil), s.Pets...)
+	s.mu.RUnlock()
+
+	return core.BuildSocialGraph(pets).Summary(), nil
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Spectator settings
+const (
+	SpectatorFeedSize = 5 // Recent inbox messages shown in the event feed
+	maskedIDVisible   = 4 // Leading runes of a pet ID left visible
+)
+
+// MaskID hides all but the start of a pet ID, so viewers of a stream
+// cannot use it to address the save
+func MaskID(id types.PetID) string {
+	visible := []rune(string(id))
+	if len(visible) > maskedIDVisible {
+		visible = visible[:maskedIDVisible]
+	}
+	return string(visible) + "****"
+}
+
+// SpectatorView is everything shown to spectators
+type SpectatorView struct {
+	Pets    []*core.DigitalPet
+	Weather *environment.WeatherSystem // Optional; nil hides the weather
+	Inbox   *interaction.Inbox
+	// Impressions describes the vitals in words instead of bars when set
+	Impressions *biology.Impressions
+}
+
+// RenderSpectator draws the read-only dashboard shown in spectator mode:
+// each pet's portrait and status, the weather and the latest events. Pet
+// IDs are masked wherever they appear.
+func RenderSpectator(v SpectatorView) string {
+	pets := append([]*core.DigitalPet(nil), v.Pets...)
+	sort.Slice(pets, func(i, j int) bool { return pets[i].Name < pets[j].Name })
+
+	var b strings.Builder
+	b.WriteString("=== Spectating ===\n")
+	if v.Weather != nil {
+		effects := v.Weather.Effects()
+		fmt.Fprintf(&b, "%s, %s, %.0f°C, %02d:00\n", effects.Season, effects.Weather, effects.Temperature, int(effects.Hour))
+	}
+	for _, pet := range pets {
+		status := pet.GetCurrentStatus()
+		b.WriteString("\n")
+		b.WriteString(RenderPet(pet))
+		fmt.Fprintf(&b, "ID %s, %.1f days old\n", MaskID(pet.ID), status.Age)
+		if !status.IsAlive {
+			fmt.Fprintf(&b, "%s has passed away.\n", pet.Name)
+			continue
+		}
+		fmt.Fprintf(&b, "%s, %s (%s)\n", status.StatusDescription, status.MoodDescription, status.CurrentBehavior)
+		if v.Impressions != nil {
+			fmt.Fprintf(&b, "%s, %s and %s\n", VitalImpression(v.Impressions, pet, biology.VitalHealth),
+				VitalImpression(v.Impressions, pet, biology.VitalEnergy), VitalImpression(v.Impressions, pet, biology.VitalHappiness))
+			continue
+		}
+		fmt.Fprintf(&b, "Health %s Energy %s Happiness %s\n",
+			RenderBar(status.Health, 10), RenderBar(status.Energy, 10), RenderBar(status.Happiness, 10))
+	}
+
+	if v.Inbox != nil {
+		b.WriteString("\nRecent events:\n")
+		messages := v.Inbox.List()
+		if len(messages) > SpectatorFeedSize {
+			messages = messages[:SpectatorFeedSize]
+		}
+		if len(messages) == 0 {
+			b.WriteString("  (all quiet)\n")
+		}
+		for _, msg := range messages {
+			fmt.Fprintf(&b, "  %s  %s\n", msg.Received.Local().Format("15:04"), maskIDs(msg.Subject, pets))
+		}
+	}
+	return b.String()
+}
+
+// maskIDs masks any pet IDs mentioned in text
+func maskIDs(text string, pets []*core.DigitalPet) string {
+	for _, pet := range pets {
+		text = strings.ReplaceAll(text, string(pet.ID), MaskID(pet.ID))
+	}
+	return text
+}
+
+// Spectate renders the spectator dashboard from the shell's state
+func (s *Shell) Spectate() string {
+	s.mu.RLock()
+	pets := append([]*core.DigitalPet(nil), s.Pets...)
+	s.mu.RUnlock()
+	view := SpectatorView{Pets: pets, Weather: s.Weather, Inbox: s.Inbox}
+	if s.Qualitative {
+		view.Impressions = &s.impressions
+	}
+	return RenderSpectator(view)
+}
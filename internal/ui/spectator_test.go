@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestMaskID(t *testing.T) {
+	if got := MaskID("pet_1700000000_Rex"); got != "pet_****" {
+		t.Errorf("Expected pet_****, got %q", got)
+	}
+	if got := MaskID("ab"); got != "ab****" {
+		t.Errorf("Expected short IDs to be padded, got %q", got)
+	}
+	if got := MaskID("Renée_1700000000"); got != "René****" {
+		t.Errorf("Expected whole characters of a non-ASCII ID kept, got %q", got)
+	}
+}
+
+func TestRenderSpectator(t *testing.T) {
+	pet := core.NewDigitalPet("Rex", "user123")
+	inbox := interaction.NewInbox()
+	inbox.Post(interaction.MessageSystem, pet.ID, "Saved "+string(pet.ID), "")
+
+	dashboard := RenderSpectator(SpectatorView{
+		Pets:    []*core.DigitalPet{pet},
+		Weather: environment.NewWeatherSystem(),
+		Inbox:   inbox,
+	})
+	for _, want := range []string{"Spectating", "Rex", "Health [", "Recent events:", MaskID(pet.ID)} {
+		if !strings.Contains(dashboard, want) {
+			t.Errorf("Expected %q on the dashboard, got:\n%s", want, dashboard)
+		}
+	}
+	if strings.Contains(dashboard, string(pet.ID)) {
+		t.Errorf("The pet ID should be masked everywhere, got:\n%s", dashboard)
+	}
+
+	words := RenderSpectator(SpectatorView{Pets: []*core.DigitalPet{pet}, Impressions: &biology.Impressions{}})
+	if strings.Contains(words, "Health [") || !strings.Contains(words, biology.VitalScales[biology.VitalHealth].Describe(pet.Biology.Vitals.Health)) {
+		t.Errorf("Expected the vitals described in words, got:\n%s", words)
+	}
+}
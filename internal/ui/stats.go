@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// deficiencyEffects describes what being short of a nutrient does
+var deficiencyEffects = map[biology.Nutrient]string{
+	biology.NutrientProtein:  "agility training builds fitness more slowly",
+	biology.NutrientVitamins: "immune system weakening",
+}
+
+// RenderStats shows a pet's vital stats and a nutrition panel with how
+// well stocked it is with each nutrient against what it needs
+func RenderStats(pet *core.DigitalPet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s's stats ===\n", pet.Name)
+	vitals := pet.Biology.Vitals
+	for _, vital := range biology.AllVitals() {
+		value := vitals.Get(vital)
+		fmt.Fprintf(&b, "  %-12s %s %3.0f%%\n", vital, RenderBar(value, 10), value*100)
+	}
+	fmt.Fprintf(&b, "  %-12s %s %3.0f%%\n", "Immunity", RenderBar(pet.Biology.Processes.ImmuneStrength, 10), pet.Biology.Processes.ImmuneStrength*100)
+
+	diet := pet.Biology.Diet
+	needs := pet.NutrientNeeds()
+	fmt.Fprintln(&b, "Nutrition (need relative to an average adult):")
+	for _, nutrient := range biology.AllNutrients() {
+		level := diet.Level(nutrient)
+		line := fmt.Sprintf("  %-12s %s %3.0f%%  need x%.1f", nutrient, RenderBar(level, 10), level*100, needs.Get(nutrient))
+		if diet.Deficient(nutrient) {
+			line += "  deficient"
+			if effect, ok := deficiencyEffects[nutrient]; ok {
+				line += ": " + effect
+			}
+		}
+		fmt.Fprintln(&b, line)
+	}
+	return b.String()
+}
+
+// extraNeed is the need, relative to an average adult, above which the
+// qualitative display says a pet needs more of a nutrient than most
+const extraNeed = 1.2
+
+// RenderImpressions is RenderStats for the qualitative display: every
+// stat is described in words and no numbers are shown. Descriptions settle
+// through im, so they do not flicker as the values drift.
+func RenderImpressions(pet *core.DigitalPet, im *biology.Impressions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== How %s is doing ===\n", pet.Name)
+	for _, vital := range biology.AllVitals() {
+		fmt.Fprintf(&b, "  %-12s %s\n", vital, VitalImpression(im, pet, vital))
+	}
+	fmt.Fprintf(&b, "  %-12s %s\n", "Immunity", Impression(im, pet, "immunity", biology.ImmunityScale, pet.Biology.Processes.ImmuneStrength))
+
+	diet := pet.Biology.Diet
+	needs := pet.NutrientNeeds()
+	fmt.Fprintln(&b, "Nutrition:")
+	for _, nutrient := range biology.AllNutrients() {
+		line := fmt.Sprintf("  %-12s %s", nutrient, Impression(im, pet, strings.ToLower(nutrient.String()), biology.NutrientScale, diet.Level(nutrient)))
+		if effect, ok := deficiencyEffects[nutrient]; ok && diet.Deficient(nutrient) {
+			line += ": " + effect
+		}
+		if needs.Get(nutrient) >= extraNeed {
+			line += " (needs more than most)"
+		}
+		fmt.Fprintln(&b, line)
+	}
+	return b.String()
+}
+
+// Impression describes one of a pet's stats in words, settling on a
+// description through im
+func Impression(im *biology.Impressions, pet *core.DigitalPet, stat string, scale biology.Scale, value float64) string {
+	return im.Describe(string(pet.ID)+"/"+stat, scale, value)
+}
+
+// VitalImpression describes one of a pet's vitals in words
+func VitalImpression(im *biology.Impressions, pet *core.DigitalPet, vital biology.Vital) string {
+	return Impression(im, pet, vital.Key(), biology.VitalScales[vital], pet.Biology.Vitals.Get(vital))
+}
+
+// statsCommand handles `stats <pet>`
+func (s *Shell) statsCommand(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", usageError("stats <pet>")
+	}
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	if s.Qualitative {
+		return RenderImpressions(pet, &s.impressions), nil
+	}
+	return RenderStats(pet), nil
+}
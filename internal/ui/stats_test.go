@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestStatsCommand(t *testing.T) {
+	shell := NewShell()
+	rex := core.NewDigitalPet("Rex", "owner")
+	rex.Biology.Diet.Levels.Vitamins = 0.1
+	shell.AddPet(rex)
+
+	out, err := shell.Execute("stats rex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Health") || !strings.Contains(out, "Protein") {
+		t.Errorf("Expected vitals and nutrition shown, got:\n%s", out)
+	}
+	if !strings.Contains(out, "deficient: immune system weakening") || strings.Count(out, "deficient") != 1 {
+		t.Errorf("Expected only the vitamin deficiency shown, got:\n%s", out)
+	}
+	if _, err := shell.Execute("stats"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
+
+func TestStatsInWords(t *testing.T) {
+	shell := NewShell()
+	shell.Qualitative = true
+	rex := core.NewDigitalPet("Rex", "owner")
+	rex.Biology.Vitals.Nutrition = 0.45
+	rex.Biology.Diet.Levels.Vitamins = 0.1
+	shell.AddPet(rex)
+
+	out, err := shell.Execute("stats rex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.ContainsAny(out, "0123456789%[") {
+		t.Errorf("Expected no numbers or bars, got:\n%s", out)
+	}
+	if !strings.Contains(out, "looks a bit thin") || !strings.Contains(out, "sorely lacking: immune system weakening") {
+		t.Errorf("Expected the vitals and nutrition described, got:\n%s", out)
+	}
+
+	// A small recovery does not change the description straight away
+	rex.Biology.Vitals.Nutrition = 0.61
+	if out, _ := shell.Execute("stats rex"); !strings.Contains(out, "looks a bit thin") {
+		t.Errorf("Expected the description to hold near the edge, got:\n%s", out)
+	}
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// RenderThought renders a pet's thought as a thought bubble
+func RenderThought(pet *core.DigitalPet, thought interaction.Thought) string {
+	return fmt.Sprintf("  o O ( %s: %s )\n", pet.Name, thought.Text)
+}
+
+// Thoughts returns a thought from every living pet, for display every so
+// often between commands
+func (s *Shell) Thoughts() string {
+	s.mu.RLock()
+	pets := append([]*core.DigitalPet(nil), s.Pets...)
+	s.mu.RUnlock()
+
+	var b strings.Builder
+	for _, pet := range pets {
+		if thought, ok := s.thoughts.Think(pet); ok {
+			b.WriteString(RenderThought(pet, thought))
+		}
+	}
+	return b.String()
+}
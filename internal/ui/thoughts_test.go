@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestShellThoughts(t *testing.T) {
+	shell := NewShell()
+	hungry := core.NewDigitalPet("Rex", "user123")
+	hungry.Biology.Vitals.Nutrition = 0.0
+	gone := core.NewDigitalPet("Ghost", "user123")
+	gone.Biology.IsAlive = false
+	shell.AddPet(hungry)
+	shell.AddPet(gone)
+
+	out := shell.Thoughts()
+	if !strings.HasPrefix(out, "  o O ( Rex: ") || strings.Count(out, "\n") != 1 {
+		t.Errorf("Expected one thought bubble from Rex, got %q", out)
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+// titleUsage describes the title command
+const titleUsage = "title <pet> [<title> | none]"
+
+// RenderTitles lists the titles a pet has earned, marking the one it goes
+// by, and the epithets still to earn
+func RenderTitles(pet *core.DigitalPet) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s's titles ===\n", pet.Name)
+	titles := pet.Titles()
+	if len(titles) == 0 {
+		b.WriteString("  (none yet)\n")
+	}
+	for _, title := range titles {
+		marker := " "
+		if title == pet.ActiveTitle() {
+			marker = "*"
+		}
+		fmt.Fprintf(&b, "%s %s\n", marker, title)
+	}
+
+	var unearned []string
+	for _, epithet := range core.AllEpithets() {
+		if !pet.HasEpithet(epithet) {
+			unearned = append(unearned, fmt.Sprintf("  %-14s for %s\n", epithet, epithet.Reason()))
+		}
+	}
+	if len(unearned) > 0 {
+		b.WriteString("Still to earn:\n")
+		b.WriteString(strings.Join(unearned, ""))
+	}
+	return b.String()
+}
+
+// titleCommand handles `title <pet> [<title> | none]`
+func (s *Shell) titleCommand(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", usageError(titleUsage)
+	}
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 1 {
+		return RenderTitles(pet), nil
+	}
+
+	title := strings.Join(args[1:], " ")
+	if strings.EqualFold(title, "none") {
+		title = ""
+	}
+	if err := s.Household.ChooseTitle(pet.ID, title); err != nil {
+		return "", err
+	}
+	if title == "" {
+		return fmt.Sprintf("%s goes by just %s again.\n", pet.Name, pet.Name), nil
+	}
+	return fmt.Sprintf("%s will now be known as %s.\n", pet.Name, pet.DisplayName()), nil
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestTitleCommand(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "owner-ann")
+	shell := marketShell(nil, "ann", rex)
+
+	out, err := shell.Execute("title rex")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "(none yet)") || !strings.Contains(out, "the Brave") {
+		t.Errorf("Expected no titles and the epithets still to earn, got:\n%s", out)
+	}
+	if _, err := shell.Execute("title rex the brave"); !errors.Is(err, core.ErrUnknownTitle) {
+		t.Errorf("Expected ErrUnknownTitle, got %v", err)
+	}
+
+	rex.Renown = &core.Renown{Epithets: []core.Epithet{core.EpithetBrave}}
+	if _, err := shell.Execute("title rex the brave"); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := shell.Execute("title rex"); !strings.Contains(out, "* the Brave") {
+		t.Errorf("Expected the active title marked, got:\n%s", out)
+	}
+	if !strings.Contains(RenderPet(rex), "Rex the Brave - ") {
+		t.Errorf("Expected the title in the portrait, got:\n%s", RenderPet(rex))
+	}
+
+	if _, err := shell.Execute("title rex none"); err != nil {
+		t.Fatal(err)
+	}
+	if rex.DisplayName() != "Rex" {
+		t.Errorf("Expected the title cleared, got %q", rex.DisplayName())
+	}
+	if _, err := shell.Execute("title"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+}
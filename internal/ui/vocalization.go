@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+// RenderCues renders a pet's vocalizations as narration lines
+func RenderCues(pet *core.DigitalPet, cues []interaction.Cue) string {
+	var b strings.Builder
+	for _, cue := range cues {
+		marker := " "
+		if cue.Urgency >= 0.7 {
+			marker = "!"
+		}
+		fmt.Fprintf(&b, "%s %s %s.\n", marker, pet.Name, cue.Message)
+	}
+	return b.String()
+}
+
+// Cues returns the current vocalizations of every pet, for display between
+// commands. Only each pet's most urgent cues are shown.
+func (s *Shell) Cues() string {
+	s.mu.RLock()
+	pets := append([]*core.DigitalPet(nil), s.Pets...)
+	s.mu.RUnlock()
+
+	var b strings.Builder
+	for _, pet := range pets {
+		b.WriteString(RenderCues(pet, interaction.Vocalize(pet)))
+	}
+	return b.String()
+}
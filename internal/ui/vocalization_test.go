@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+)
+
+func TestShellCues(t *testing.T) {
+	shell := NewShell()
+	pet := core.NewDigitalPet("Rex", "user123")
+	pet.Biology.Vitals.Nutrition = 0.0
+	shell.AddPet(pet)
+
+	out := shell.Cues()
+	if !strings.Contains(out, "! Rex paws at the empty bowl.") {
+		t.Errorf("Expected urgent hunger cue, got %q", out)
+	}
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+// wardrobeUsage lists the wardrobe subcommands
+const wardrobeUsage = "wardrobe [buy <item> | wear <pet> <item> | remove <pet> <head|neck> | offer <item> [<account>]]"
+
+// RenderWardrobe lists spare accessories, what each pet wears and what
+// the shop sells
+func RenderWardrobe(inv *environment.Inventory, pets []*core.DigitalPet) string {
+	var b strings.Builder
+	b.WriteString("=== Wardrobe ===\n")
+	var spare []string
+	for _, accessory := range environment.AllAccessories() {
+		if count := inv.Accessories[accessory]; count > 0 {
+			spare = append(spare, fmt.Sprintf("%s x%d", accessory, count))
+		}
+	}
+	if len(spare) == 0 {
+		spare = append(spare, "(none)")
+	}
+	fmt.Fprintf(&b, "Spare: %s\n", strings.Join(spare, ", "))
+
+	for _, pet := range pets {
+		var worn []string
+		for _, slot := range []environment.AccessorySlot{environment.SlotHead, environment.SlotNeck} {
+			if accessory, ok := pet.Wearing(slot); ok {
+				worn = append(worn, fmt.Sprintf("%s (%s)", accessory, strings.ToLower(slot.String())))
+			}
+		}
+		if len(worn) > 0 {
+			fmt.Fprintf(&b, "  %-12s wears %s\n", pet.Name, strings.Join(worn, ", "))
+		}
+	}
+
+	var shop []string
+	for _, accessory := range environment.AllAccessories() {
+		if price, err := accessory.Price(); err == nil {
+			shop = append(shop, fmt.Sprintf("%s %d", accessory, price))
+		}
+	}
+	fmt.Fprintf(&b, "Shop (coins): %s\n", strings.Join(shop, ", "))
+	b.WriteString("Party hats come from festivals and rosettes from milestones.\n")
+	return b.String()
+}
+
+// parseSlot reads an accessory slot by name
+func parseSlot(name string) (environment.AccessorySlot, error) {
+	for _, slot := range []environment.AccessorySlot{environment.SlotHead, environment.SlotNeck} {
+		if strings.EqualFold(slot.String(), name) {
+			return slot, nil
+		}
+	}
+	return 0, usageError(wardrobeUsage)
+}
+
+// wardrobeCommand handles `wardrobe [buy|wear|remove|offer ...]`
+func (s *Shell) wardrobeCommand(args []string) (string, error) {
+	if s.Household == nil {
+		return "", ErrNoHousehold
+	}
+	switch {
+	case len(args) == 0:
+		return RenderWardrobe(s.Household.Inventory, s.Pets), nil
+
+	case len(args) == 2 && args[0] == "buy":
+		accessory, err := environment.ParseAccessory(args[1])
+		if err != nil {
+			return "", err
+		}
+		if err := s.Household.BuyAccessory(accessory); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Bought a %s. Use `wardrobe wear <pet> %s` to try it on.\n", accessory, strings.ToLower(accessory.String())), nil
+
+	case len(args) == 3 && args[0] == "wear":
+		pet, err := s.FindPet(args[1])
+		if err != nil {
+			return "", err
+		}
+		accessory, err := environment.ParseAccessory(args[2])
+		if err != nil {
+			return "", err
+		}
+		reaction, err := s.Household.DressUp(pet.ID, accessory)
+		if err != nil {
+			return "", err
+		}
+		return describeReaction(pet, accessory, reaction) + RenderPet(pet), nil
+
+	case len(args) == 3 && args[0] == "remove":
+		pet, err := s.FindPet(args[1])
+		if err != nil {
+			return "", err
+		}
+		slot, err := parseSlot(args[2])
+		if err != nil {
+			return "", err
+		}
+		accessory, err := s.Household.Undress(pet.ID, slot)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Took the %s off %s and put it back in the wardrobe.\n", accessory, pet.Name), nil
+
+	case (len(args) == 2 || len(args) == 3) && args[0] == "offer":
+		accessory, err := environment.ParseAccessory(args[1])
+		if err != nil {
+			return "", err
+		}
+		friend := ""
+		if len(args) == 3 {
+			friend = args[2]
+		}
+		return s.offerAccessory(accessory, friend)
+	}
+	return "", usageError(wardrobeUsage)
+}
+
+// describeReaction tells the player how a pet took to an accessory
+func describeReaction(pet *core.DigitalPet, accessory environment.Accessory, reaction core.AccessoryReaction) string {
+	switch reaction {
+	case core.ReactionProud:
+		return fmt.Sprintf("%s struts around showing off the %s.\n", pet.Name, accessory)
+	case core.ReactionFussy:
+		return fmt.Sprintf("%s paws at the %s and may not keep it on for long.\n", pet.Name, accessory)
+	}
+	return fmt.Sprintf("%s doesn't seem to mind the %s.\n", pet.Name, accessory)
+}
+
+// offerAccessory lists a spare accessory on the marketplace, putting it
+// back in the wardrobe if the upload fails
+func (s *Shell) offerAccessory(accessory environment.Accessory, friend string) (string, error) {
+	if s.Market == nil {
+		return "", ErrNoMarketplace
+	}
+	if err := s.Household.TakeAccessory(accessory); err != nil {
+		return "", err
+	}
+	if err := s.Market.OfferItem(context.Background(), accessory.String(), friend); err != nil {
+		s.Household.StoreAccessory(accessory)
+		return "", err
+	}
+	s.audit(data.AuditTrade, "", "offered a %s on the marketplace", accessory)
+	if friend != "" {
+		return fmt.Sprintf("The %s is waiting on the marketplace for %s to claim it.\n", accessory, friend), nil
+	}
+	return fmt.Sprintf("The %s is on the marketplace for another player to claim.\n", accessory), nil
+}
+
+// claimAccessory takes an accessory off the marketplace into the wardrobe
+func (s *Shell) claimAccessory(ctx context.Context, accessory environment.Accessory) (string, error) {
+	if err := s.Market.ClaimItem(ctx, accessory.String()); err != nil {
+		return "", err
+	}
+	s.Household.StoreAccessory(accessory)
+	s.audit(data.AuditTrade, "", "claimed a %s from the marketplace", accessory)
+	return fmt.Sprintf("The %s is in your wardrobe.\n", accessory), nil
+}
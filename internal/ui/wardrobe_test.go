@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/data"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+)
+
+func TestWardrobeCommand(t *testing.T) {
+	rex := core.NewDigitalPet("Rex", "owner-ann")
+	rex.Personality.Traits.Independence = 0.2
+	shell := marketShell(nil, "ann", rex)
+
+	if _, err := shell.Execute("wardrobe wear rex hat"); !errors.Is(err, environment.ErrOutOfStock) {
+		t.Errorf("Expected ErrOutOfStock before buying, got %v", err)
+	}
+	if _, err := shell.Execute("wardrobe buy rosette"); !errors.Is(err, environment.ErrNotForSale) {
+		t.Errorf("Expected ErrNotForSale, got %v", err)
+	}
+	if _, err := shell.Execute("wardrobe buy hat"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := shell.Execute("wardrobe buy scarf"); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := shell.Execute("wardrobe"); !strings.Contains(out, "Hat x1") || !strings.Contains(out, "Scarf x1") {
+		t.Errorf("Expected the purchases listed, got:\n%s", out)
+	}
+
+	if _, err := shell.Execute("wardrobe wear rex hat"); err != nil {
+		t.Fatal(err)
+	}
+	out, err := shell.Execute("wardrobe wear rex scarf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, environment.AccessoryHat.Art()) || !strings.Contains(out, environment.AccessoryScarf.Art()) ||
+		!strings.Contains(out, "wearing Hat and Scarf") {
+		t.Errorf("Expected Rex drawn in the hat and scarf, got:\n%s", out)
+	}
+	if out, _ := shell.Execute("wardrobe"); !strings.Contains(out, "Rex") {
+		t.Errorf("Expected Rex's outfit listed, got:\n%s", out)
+	}
+
+	if _, err := shell.Execute("wardrobe remove rex head"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := shell.Execute("wardrobe remove rex head"); !errors.Is(err, interaction.ErrNothingWorn) {
+		t.Errorf("Expected ErrNothingWorn, got %v", err)
+	}
+	if _, err := shell.Execute("wardrobe remove rex tail"); !errors.Is(err, ErrUsage) {
+		t.Errorf("Expected ErrUsage, got %v", err)
+	}
+	if _, err := shell.Execute("wardrobe offer hat"); !errors.Is(err, ErrNoMarketplace) {
+		t.Errorf("Expected ErrNoMarketplace, got %v", err)
+	}
+}
+
+func TestTradeAccessoriesOnMarketplace(t *testing.T) {
+	provider := data.NewMemoryProvider()
+	ann := marketShell(provider, "ann")
+	ben := marketShell(provider, "ben")
+	ann.Household.Inventory.AddAccessory(environment.AccessoryPartyHat)
+
+	if _, err := ann.Execute("wardrobe offer partyhat ben"); err != nil {
+		t.Fatal(err)
+	}
+	if ann.Household.Inventory.Accessories[environment.AccessoryPartyHat] != 0 {
+		t.Error("Expected the offered party hat to leave Ann's wardrobe")
+	}
+	if out, _ := ben.Execute("market"); !strings.Contains(out, "PartyHat") || !strings.Contains(out, "(accessory)") {
+		t.Errorf("Expected the party hat listed for Ben, got:\n%s", out)
+	}
+	if _, err := ben.Execute("market claim partyhat"); err != nil {
+		t.Fatal(err)
+	}
+	if ben.Household.Inventory.Accessories[environment.AccessoryPartyHat] != 1 {
+		t.Error("Expected the party hat in Ben's wardrobe")
+	}
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+	"github.com/Michael-W-Ellison/gochi/internal/social"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// MapView holds everything drawn on the world map
+type MapView struct {
+	World     *environment.WorldMap
+	Territory *social.TerritoryMap
+	Weather   *environment.WeatherSystem // Optional; nil hides the forecast
+	Events    *environment.Manager       // Optional; nil hides location events
+	Pets      []*core.DigitalPet
+	Focus     *core.DigitalPet // Optional; pet whose position and distances are shown
+}
+
+// forecastAt returns the forecast weather at a location, if it is worth
+// showing
+func (v MapView) forecastAt(loc *environment.Location) (environment.WeatherType, bool) {
+	if v.Weather == nil {
+		return environment.WeatherClear, false
+	}
+	forecast := v.Weather.ForecastAt(v.World, loc)
+	return forecast, forecast != environment.WeatherClear && forecast != environment.WeatherCloudy
+}
+
+// planTrip plans a trip the way travel would, honoring location events
+func (v MapView) planTrip(from, to string) (environment.Trip, error) {
+	if v.Events != nil {
+		return v.Events.PlanTrip(from, to)
+	}
+	return v.World.PlanTrip(from, to)
+}
+
+// setCell writes a character onto the grid if it is in bounds
+func setCell(grid [][]byte, x, y int, c byte) {
+	if y >= 0 && y < len(grid) && x >= 0 && x < len(grid[y]) {
+		grid[y][x] = c
+	}
+}
+
+// RenderWorldMap draws the discovered world as an ASCII grid followed by a
+// numbered legend. Each location shows its biome icon with the forecast to
+// its left and a '!' to its right if it is dangerous; '@' marks the focused
+// pet.
+func RenderWorldMap(v MapView) string {
+	byID := make(map[types.PetID]*core.DigitalPet, len(v.Pets))
+	for _, pet := range v.Pets {
+		byID[pet.ID] = pet
+	}
+
+	discovered := v.World.DiscoveredIDs()
+
+	grid := make([][]byte, v.World.Height)
+	for y := range grid {
+		grid[y] = []byte(strings.Repeat(".", v.World.Width))
+	}
+	for _, id := range discovered {
+		loc := v.World.Locations[id]
+		icon := loc.Biome.Icon()
+		if v.Focus != nil && v.Focus.Location == id {
+			icon = '@'
+		}
+		setCell(grid, loc.X, loc.Y, icon)
+		if forecast, show := v.forecastAt(loc); show {
+			setCell(grid, loc.X-1, loc.Y, forecast.Icon())
+		}
+		if loc.IsDangerous() {
+			setCell(grid, loc.X+1, loc.Y, '!')
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("=== World Map ===\n")
+	border := "+" + strings.Repeat("-", v.World.Width) + "+\n"
+	b.WriteString(border)
+	for _, row := range grid {
+		fmt.Fprintf(&b, "|%s|\n", row)
+	}
+	b.WriteString(border)
+
+	for i, id := range discovered {
+		loc := v.World.Locations[id]
+		danger := ' '
+		if loc.IsDangerous() {
+			danger = '!'
+		}
+		fmt.Fprintf(&b, "%2d. [%c%c] %-16s %-10s", i+1, loc.Biome.Icon(), danger, loc.Name, loc.Biome)
+
+		if v.Focus != nil {
+			if trip, err := v.planTrip(v.Focus.Location, id); err == nil {
+				fmt.Fprintf(&b, " %5.1f (%.1f days)", trip.Distance, trip.Days)
+			}
+		}
+		if forecast, show := v.forecastAt(loc); show {
+			fmt.Fprintf(&b, " forecast: %s", forecast)
+		}
+		if v.Events != nil {
+			cond := v.Events.Conditions(id)
+			for _, event := range cond.Events {
+				fmt.Fprintf(&b, " [%s]", event)
+			}
+			if cond.Closed {
+				b.WriteString(" CLOSED")
+			}
+		}
+		if ownerID, claim, claimed := v.Territory.Owner(id); claimed {
+			if owner := byID[ownerID]; owner != nil {
+				fmt.Fprintf(&b, " %s's territory (%.0f%%)", owner.Name, claim*100)
+			}
+		}
+
+		var here []string
+		for _, pet := range v.Pets {
+			if pet.Location == id {
+				here = append(here, pet.Name)
+			}
+		}
+		if len(here) > 0 {
+			fmt.Fprintf(&b, " - here: %s", strings.Join(here, ", "))
+		}
+		b.WriteString("\n")
+	}
+
+	if hidden := len(v.World.Locations) - len(discovered); hidden > 0 {
+		fmt.Fprintf(&b, "(%d undiscovered location(s))\n", hidden)
+	}
+	return b.String()
+}
+
+// resolveDestination turns a legend number or location ID into a location ID
+func (s *Shell) resolveDestination(selection string) string {
+	if n, err := strconv.Atoi(selection); err == nil {
+		discovered := s.World.DiscoveredIDs()
+		if n >= 1 && n <= len(discovered) {
+			return discovered[n-1]
+		}
+	}
+	return selection
+}
+
+// mapCommand handles `map [pet] [destination]`. With a destination (legend
+// number or location ID) the pet travels there and nearby locations are
+// discovered.
+func (s *Shell) mapCommand(args []string) (string, error) {
+	if len(args) > 2 {
+		return "", usageError("map [pet] [destination]")
+	}
+
+	s.mu.RLock()
+	view := MapView{
+		World:     s.World,
+		Territory: s.Territory,
+		Weather:   s.Weather,
+		Events:    s.Events,
+		Pets:      append([]*core.DigitalPet(nil), s.Pets...),
+	}
+	s.mu.RUnlock()
+
+	if len(args) == 0 {
+		return RenderWorldMap(view), nil
+	}
+
+	pet, err := s.FindPet(args[0])
+	if err != nil {
+		return "", err
+	}
+	view.Focus = pet
+
+	if len(args) == 1 {
+		return RenderWorldMap(view), nil
+	}
+
+	trip, err := view.planTrip(pet.Location, s.resolveDestination(args[1]))
+	if err != nil {
+		return "", err
+	}
+	if err := pet.Travel(trip); err != nil {
+		return "", err
+	}
+	s.World.Discover(trip.To)
+
+	dest, _ := s.World.Location(trip.To)
+	summary := fmt.Sprintf("%s travels to %s (%.1f away, %.1f days).\n", pet.Name, dest.Name, trip.Distance, trip.Days)
+	return summary + RenderWorldMap(view), nil
+}
+
+// newWorldCommand handles `newworld [seed] [locations]`. All pets are sent
+// home, since their old locations no longer exist, except missing pets,
+// which hide somewhere in the new world.
+func (s *Shell) newWorldCommand(args []string) (string, error) {
+	const usage = "newworld [seed] [locations]"
+	if len(args) > 2 {
+		return "", usageError(usage)
+	}
+
+	cfg := environment.DefaultWorldGenConfig(time.Now().UnixNano())
+	if len(args) >= 1 {
+		seed, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return "", usageError(usage)
+		}
+		cfg.Seed = seed
+	}
+	if len(args) == 2 {
+		count, err := strconv.Atoi(args[1])
+		if err != nil || count < 1 {
+			return "", usageError(usage)
+		}
+		cfg.Locations = count
+	}
+
+	world, err := environment.GenerateWorld(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	s.World = world
+	if s.Events != nil {
+		s.Events = environment.NewManager(world)
+	}
+	for _, pet := range s.Pets {
+		pet.Location = environment.HomeLocation
+	}
+	s.mu.Unlock()
+	if s.Household != nil {
+		s.Household.SetWorld(world)
+	}
+
+	return s.mapCommand(nil)
+}
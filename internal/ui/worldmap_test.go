@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/environment"
+)
+
+func TestMapShowsTerritory(t *testing.T) {
+	shell := NewShell()
+	rex := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(rex)
+	shell.Territory.Mark(rex.ID, "forest", 0.8)
+
+	out, err := shell.Execute("map")
+	if err != nil {
+		t.Fatalf("map command failed: %v", err)
+	}
+	if !strings.Contains(out, "Rex's territory (80%)") {
+		t.Error("Legend should show who owns a location")
+	}
+	if !strings.Contains(out, "here: Rex") {
+		t.Error("Legend should show where pets are")
+	}
+	if strings.Contains(out, "Frozen Tundra") {
+		t.Error("Undiscovered locations should be hidden")
+	}
+	if !strings.Contains(out, "[M!] Windy Peak") {
+		t.Error("Legend should show biome icons and danger markers")
+	}
+}
+
+func TestMapFocusShowsPositionAndForecast(t *testing.T) {
+	shell := NewShell()
+	shell.Weather = environment.NewSeededWeatherSystem(1)
+	shell.Weather.Forecast = environment.WeatherRain
+	shell.AddPet(core.NewDigitalPet("Rex", "user123"))
+
+	out, err := shell.Execute("map rex")
+	if err != nil {
+		t.Fatalf("map command failed: %v", err)
+	}
+	if !strings.Contains(out, ",@") {
+		t.Error("Map should mark the pet's location under the rain overlay")
+	}
+	if !strings.Contains(out, "days)") {
+		t.Error("Legend should show travel distances")
+	}
+	if !strings.Contains(out, "forecast: Rain") {
+		t.Error("Legend should show the forecast")
+	}
+}
+
+func TestMapTravel(t *testing.T) {
+	shell := NewShell()
+	rex := core.NewDigitalPet("Rex", "user123")
+	shell.AddPet(rex)
+
+	if _, err := shell.Execute("map rex tundra"); !errors.Is(err, environment.ErrUndiscovered) {
+		t.Errorf("Expected ErrUndiscovered, got %v", err)
+	}
+
+	out, err := shell.Execute("map rex forest")
+	if err != nil {
+		t.Fatalf("travel failed: %v", err)
+	}
+	if rex.Location != "forest" {
+		t.Errorf("Expected Rex in the forest, got %s", rex.Location)
+	}
+	if !strings.Contains(out, "Misty Marsh") {
+		t.Error("Arriving in the forest should discover the marsh")
+	}
+
+	// Legend entries can be selected by number; home is entry 2 of the
+	// alphabetical discovered list (forest, home, ...)
+	if _, err := shell.Execute("map rex 2"); err != nil {
+		t.Fatalf("travel by number failed: %v", err)
+	}
+	if rex.Location != environment.HomeLocation {
+		t.Errorf("Expected Rex back home, got %s", rex.Location)
+	}
+}
+
+func TestNewWorldSendsPetsHome(t *testing.T) {
+	shell := NewShell()
+	rex := core.NewDigitalPet("Rex", "user123")
+	rex.Location = "forest"
+	shell.AddPet(rex)
+
+	if _, err := shell.Execute("newworld 42 8"); err != nil {
+		t.Fatalf("newworld failed: %v", err)
+	}
+	if len(shell.World.Locations) != 8 {
+		t.Errorf("Expected 8 locations, got %d", len(shell.World.Locations))
+	}
+	if rex.Location != environment.HomeLocation {
+		t.Errorf("Expected Rex to be sent home, got %s", rex.Location)
+	}
+}
+
+func TestMapShowsLocationEvents(t *testing.T) {
+	shell := NewShell()
+	shell.Events = environment.NewSeededManager(shell.World, 1)
+	shell.Events.StartEvent(environment.EventRockslide, "peak")
+	shell.AddPet(core.NewDigitalPet("Rex", "user123"))
+
+	out, err := shell.Execute("map")
+	if err != nil {
+		t.Fatalf("map command failed: %v", err)
+	}
+	if !strings.Contains(out, "[Rockslide] CLOSED") {
+		t.Error("Legend should show active events and closures")
+	}
+	if _, err := shell.Execute("map Rex peak"); !errors.Is(err, environment.ErrLocationClosed) {
+		t.Errorf("Expected ErrLocationClosed, got %v", err)
+	}
+}
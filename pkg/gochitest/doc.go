@@ -0,0 +1,20 @@
+// Package gochitest provides a harness for testing content and balance
+// changes against the Gochi simulation with ordinary Go tests.
+//
+// This package provides:
+//   - Pets built with chosen genes, vitals, skills and age
+//   - A harness that advances simulated time in small, fixed steps
+//   - Scheduled interactions, named as in reaction scripts
+//   - Assertions written in the reaction script condition language
+//
+// Pets start from a neutral genome with every trait at 0.5 and no
+// disorders, and the harness has no weather, so a test gives the same
+// result on every run. A typical table-driven test:
+//
+//	for _, tc := range cases {
+//		pet := gochitest.NewPet(t, "Mochi", gochitest.Vital(biology.VitalNutrition, tc.nutrition))
+//		h := gochitest.New(t, pet)
+//		h.Run(gochitest.Schedule{{Day: 0.5, Action: "feed"}}, 1)
+//		h.Expect(pet, tc.condition)
+//	}
+package gochitest
@@ -0,0 +1,175 @@
+package gochitest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/config"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/game"
+	"github.com/Michael-W-Ellison/gochi/internal/interaction"
+	"github.com/Michael-W-Ellison/gochi/internal/script"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// DefaultStep is the game time advanced per loop step, five minutes
+const DefaultStep = 1.0 / 288
+
+// Harness runs a household through simulated time as fast as the CPU
+// allows. It steps the same game loop the game uses, without saving.
+type Harness struct {
+	T         testing.TB
+	Loop      *game.GameLoop
+	Household *interaction.Household
+	Step      float64 // Days advanced per loop step
+
+	day float64
+}
+
+// Interaction is one scheduled interaction. Pet may be empty to interact
+// with every pet in the household.
+type Interaction struct {
+	Day       float64     // Game day the interaction happens, counted from the start of the run
+	Pet       types.PetID // Pet to interact with; empty for every pet
+	Action    string      // Action as in reaction scripts, such as "feed" or "play"
+	Intensity float64     // 0 uses script.DefaultIntensity
+}
+
+// Schedule is a list of interactions to perform as time advances
+type Schedule []Interaction
+
+// New creates a harness over a household of pets. The loop is shut down
+// when the test ends.
+func New(t testing.TB, pets ...*core.DigitalPet) *Harness {
+	t.Helper()
+	household := interaction.NewHousehold(pets...)
+	loop, err := game.NewGameLoop(config.Default().Simulation, household, nil)
+	if err != nil {
+		t.Fatalf("gochitest.New: %v", err)
+	}
+	t.Cleanup(func() { loop.Shutdown(context.Background()) })
+	return &Harness{T: t, Loop: loop, Household: household, Step: DefaultStep}
+}
+
+// Day returns how many game days the harness has advanced
+func (h *Harness) Day() float64 {
+	return h.day
+}
+
+// Advance steps the loop forward by days, in steps of at most Step
+func (h *Harness) Advance(days float64) {
+	step := h.Step
+	if step <= 0 {
+		step = DefaultStep
+	}
+	for days > 0 {
+		dt := min(step, days)
+		h.Loop.Step(dt)
+		h.day += dt
+		days -= dt
+	}
+}
+
+// AdvanceTo steps the loop forward until Day returns day
+func (h *Harness) AdvanceTo(day float64) {
+	h.Advance(day - h.day)
+}
+
+// Interact performs an interaction named as in reaction scripts. A
+// failed interaction fails the test.
+func (h *Harness) Interact(pet types.PetID, action string, intensity float64) {
+	h.T.Helper()
+	it, ok := script.InteractionAction(action)
+	if !ok {
+		h.T.Fatalf("unknown action %q; expected one of %v", action, script.Actions())
+	}
+	if intensity == 0 {
+		intensity = script.DefaultIntensity
+	}
+	var err error
+	h.Loop.Do(func() { _, err = h.Household.Interact(pet, it, intensity) })
+	if err != nil {
+		h.T.Errorf("day %.3f: %s %s: %v", h.day, action, pet, err)
+	}
+}
+
+// Run performs a schedule, advancing to each interaction in day order,
+// then advances to until. Days are counted from the current day.
+func (h *Harness) Run(schedule Schedule, until float64) {
+	h.T.Helper()
+	ordered := append(Schedule(nil), schedule...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Day < ordered[j].Day })
+
+	start := h.day
+	for _, step := range ordered {
+		h.AdvanceTo(start + step.Day)
+		for _, id := range h.targets(step.Pet) {
+			h.Interact(id, step.Action, step.Intensity)
+		}
+	}
+	h.AdvanceTo(start + until)
+}
+
+// targets returns the pet an interaction is for, or every pet in ID
+// order if it names none
+func (h *Harness) targets(pet types.PetID) []types.PetID {
+	if pet != "" {
+		return []types.PetID{pet}
+	}
+	ids := make([]types.PetID, 0, len(h.Household.Pets))
+	for id := range h.Household.Pets {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Check evaluates a reaction script condition, such as
+// `energy < 0.3 and behavior == "Sleeping"`, for a pet. A condition that
+// does not compile fails the test.
+func (h *Harness) Check(pet *core.DigitalPet, condition string) bool {
+	h.T.Helper()
+	cond, err := script.CompileCondition(condition)
+	if err != nil {
+		h.T.Fatalf("%s: %v", condition, err)
+	}
+	met, err := cond.Eval(pet, h.Household, script.DefaultMaxSteps)
+	if err != nil {
+		h.T.Fatalf("%s: %v", condition, err)
+	}
+	return met
+}
+
+// Expect reports an error if a condition is false for a pet, along with
+// the pet's vitals, and returns whether it held
+func (h *Harness) Expect(pet *core.DigitalPet, condition string) bool {
+	h.T.Helper()
+	if h.Check(pet, condition) {
+		return true
+	}
+	h.T.Errorf("day %.3f: expected %s for %s\n%s", h.day, condition, pet.Name, Describe(pet))
+	return false
+}
+
+// Require is Expect that stops the test
+func (h *Harness) Require(pet *core.DigitalPet, condition string) {
+	h.T.Helper()
+	if !h.Expect(pet, condition) {
+		h.T.FailNow()
+	}
+}
+
+// Describe summarizes a pet's state for failure messages
+func Describe(pet *core.DigitalPet) string {
+	vitals := biology.AllVitals()
+	sort.Slice(vitals, func(i, j int) bool { return vitals[i].Key() < vitals[j].Key() })
+
+	s := fmt.Sprintf("  behavior %s, age %.2f days, alive %t\n ", pet.CurrentBehavior, pet.GetAge(), pet.IsAlive())
+	for _, vital := range vitals {
+		s += fmt.Sprintf(" %s %.3f", vital.Key(), pet.Biology.Vitals.Get(vital))
+	}
+	return s
+}
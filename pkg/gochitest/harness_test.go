@@ -0,0 +1,64 @@
+package gochitest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+)
+
+// recorder is a testing.TB that records errors instead of failing
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Helper() {}
+
+func TestAdvance(t *testing.T) {
+	pet := NewPet(t, "Mochi")
+	h := New(t, pet)
+	h.Advance(1.5)
+	if day := h.Day(); day < 1.4999 || day > 1.5001 {
+		t.Errorf("Expected day 1.5, got %v", day)
+	}
+	h.Expect(pet, "age > 1.49 and age < 1.51")
+}
+
+func TestScheduledFeedingKeepsPetFed(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		schedule Schedule
+		expect   string
+	}{
+		{"no meals", nil, "nutrition < 0.5"},
+		{"two meals", Schedule{{Day: 0.3, Action: "feed"}, {Day: 0.8, Action: "feed"}}, "nutrition > 0.6"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pet := NewPet(t, "Mochi", Vital(biology.VitalNutrition, 0.5))
+			h := New(t, pet)
+			h.Run(tc.schedule, 1)
+			h.Expect(pet, tc.expect)
+		})
+	}
+}
+
+func TestExpectReportsState(t *testing.T) {
+	rec := &recorder{TB: t}
+	pet := NewPet(t, "Mochi", Vital(biology.VitalEnergy, 0.2))
+	h := New(rec, pet)
+	if !h.Expect(pet, "energy < 0.3") {
+		t.Error("Expected a met condition to pass")
+	}
+	if h.Expect(pet, `behavior == "Sleeping"`) || len(rec.errors) != 1 {
+		t.Fatalf("Expected one failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "energy 0.200") {
+		t.Errorf("Expected the failure to describe the pet, got %s", rec.errors[0])
+	}
+}
@@ -0,0 +1,101 @@
+package gochitest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+	"github.com/Michael-W-Ellison/gochi/internal/core"
+	"github.com/Michael-W-Ellison/gochi/internal/genetics"
+	"github.com/Michael-W-Ellison/gochi/pkg/types"
+)
+
+// Owner owns every pet built by NewPet
+const Owner types.UserID = "gochitest"
+
+// ErrBadOption is returned by an option that names something unknown or
+// sets a value out of range
+var ErrBadOption = errors.New("bad pet option")
+
+// Option adjusts a pet built by NewPet
+type Option func(pet *core.DigitalPet) error
+
+// NewPet builds a pet from a neutral genome and applies the options in
+// order. Pets in one test need different names, since the name is part
+// of the ID. A bad option fails the test.
+func NewPet(t testing.TB, name string, opts ...Option) *core.DigitalPet {
+	t.Helper()
+	pet := core.NewDigitalPetFromGenome(name, Owner, genetics.NewGenome())
+	for _, opt := range opts {
+		if err := opt(pet); err != nil {
+			t.Fatalf("NewPet(%q): %v", name, err)
+		}
+	}
+	return pet
+}
+
+// Trait sets a heritable trait, such as "playfulness" or "coat_length",
+// to value on both alleles and re-expresses the pet's personality and
+// coat from its genome
+func Trait(name string, value float64) Option {
+	return func(pet *core.DigitalPet) error {
+		if err := checkUnit(name, value); err != nil {
+			return err
+		}
+		known := false
+		for _, trait := range genetics.TraitNames {
+			known = known || trait == name
+		}
+		if !known {
+			return fmt.Errorf("%w: unknown trait %q", ErrBadOption, name)
+		}
+		allele := genetics.Allele{Value: value, Dominant: true}
+		pet.Genome.Traits[name] = genetics.GenePair{Maternal: allele, Paternal: allele}
+		pet.Personality.Traits = pet.Genome.ExpressTraits()
+		pet.Genome.ApplyCoat(pet.Biology)
+		return nil
+	}
+}
+
+// Vital sets a vital sign, such as biology.VitalEnergy
+func Vital(vital biology.Vital, value float64) Option {
+	return func(pet *core.DigitalPet) error {
+		if err := checkUnit(vital.Key(), value); err != nil {
+			return err
+		}
+		pet.Biology.Vitals.Set(vital, value)
+		return nil
+	}
+}
+
+// Skill sets a skill's proficiency
+func Skill(skill ai.SkillType, level float64) Option {
+	return func(pet *core.DigitalPet) error {
+		if err := checkUnit(skill.String(), level); err != nil {
+			return err
+		}
+		pet.Skills.Levels[skill] = level
+		return nil
+	}
+}
+
+// Age makes the pet days old, which also sets its life stage
+func Age(days float64) Option {
+	return func(pet *core.DigitalPet) error {
+		if days < 0 {
+			return fmt.Errorf("%w: age %g is negative", ErrBadOption, days)
+		}
+		pet.Biology.Processes.Age = days
+		return nil
+	}
+}
+
+// checkUnit returns ErrBadOption if value is outside 0.0 to 1.0
+func checkUnit(name string, value float64) error {
+	if value < 0 || value > 1 {
+		return fmt.Errorf("%w: %s %g is outside 0 to 1", ErrBadOption, name, value)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package gochitest
+
+import (
+	"testing"
+
+	"github.com/Michael-W-Ellison/gochi/internal/ai"
+	"github.com/Michael-W-Ellison/gochi/internal/biology"
+)
+
+func TestNewPetOptions(t *testing.T) {
+	pet := NewPet(t, "Mochi",
+		Trait("playfulness", 0.9),
+		Vital(biology.VitalEnergy, 0.25),
+		Skill(ai.SkillAgility, 0.6),
+		Age(12),
+	)
+	if pet.Personality.Traits.Playfulness != 0.9 || pet.Personality.Traits.Curiosity != 0.5 {
+		t.Errorf("Expected playfulness 0.9 and other traits neutral, got %+v", pet.Personality.Traits)
+	}
+	if pet.Biology.Vitals.Energy != 0.25 || pet.Skills.Level(ai.SkillAgility) != 0.6 || pet.GetAge() != 12 {
+		t.Errorf("Expected the vitals, skill and age set, got:\n%s", Describe(pet))
+	}
+	if len(pet.Genome.Disorders) != 0 {
+		t.Errorf("Expected no disorders, got %v", pet.Genome.Disorders)
+	}
+}
+
+func TestOptionsRejectBadValues(t *testing.T) {
+	pet := NewPet(t, "Mochi")
+	for name, opt := range map[string]Option{
+		"unknown trait": Trait("wingspan", 0.5),
+		"trait range":   Trait("loyalty", 1.5),
+		"vital range":   Vital(biology.VitalEnergy, -0.1),
+		"skill range":   Skill(ai.SkillSocial, 2),
+		"negative age":  Age(-1),
+	} {
+		if err := opt(pet); err == nil {
+			t.Errorf("%s: expected ErrBadOption", name)
+		}
+	}
+}
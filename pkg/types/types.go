@@ -1,6 +1,11 @@
 package types
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // PetID is a unique identifier for a digital pet
 type PetID string
@@ -41,6 +46,8 @@ const (
 	BehaviorDistressed
 	BehaviorHappy
 	BehaviorExcited
+	BehaviorSheltering
+	BehaviorHibernating
 )
 
 // String returns the string representation of BehaviorState
@@ -48,7 +55,8 @@ func (bs BehaviorState) String() string {
 	return [...]string{
 		"Idle", "Sleeping", "Eating", "Playing", "Exploring",
 		"Social Interaction", "Grooming", "Exercising",
-		"Sick", "Distressed", "Happy", "Excited",
+		"Sick", "Distressed", "Happy", "Excited", "Sheltering",
+		"Hibernating",
 	}[bs]
 }
 
@@ -66,6 +74,8 @@ const (
 	InteractionSocialIntroduction
 	InteractionDiscipline
 	InteractionRewards
+	InteractionGeneticScreening
+	InteractionComfort
 )
 
 // String returns the string representation of InteractionType
@@ -73,7 +83,7 @@ func (it InteractionType) String() string {
 	return [...]string{
 		"Feeding", "Petting", "Playing", "Training", "Grooming",
 		"Medical Care", "Environmental Enrichment", "Social Introduction",
-		"Discipline", "Rewards",
+		"Discipline", "Rewards", "Genetic Screening", "Comfort",
 	}[it]
 }
 
@@ -102,6 +112,35 @@ func (nt NeedType) String() string {
 	}[nt]
 }
 
+// ErrUnknownNeed is returned when a need name is not recognised
+var ErrUnknownNeed = errors.New("unknown need")
+
+// AllNeeds returns every need type
+func AllNeeds() []NeedType {
+	return []NeedType{
+		NeedHunger, NeedThirst, NeedSleep, NeedExercise, NeedSocial,
+		NeedMentalStimulation, NeedAffection, NeedCleanliness,
+		NeedMedicalCare, NeedExploration,
+	}
+}
+
+// ParseNeed looks up a need by name, ignoring case, spaces, dashes and
+// underscores, so "Mental Stimulation", "MentalStimulation" and
+// "mental_stimulation" all match
+func ParseNeed(name string) (NeedType, error) {
+	for _, need := range AllNeeds() {
+		if needKey(need.String()) == needKey(name) {
+			return need, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownNeed, name)
+}
+
+// needKey folds a need name for comparison
+func needKey(name string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "", "_", "").Replace(name))
+}
+
 // RelationshipType represents the type of relationship between pets
 type RelationshipType int
 
@@ -120,6 +159,40 @@ func (rt RelationshipType) String() string {
 	}[rt]
 }
 
+// LifeStage represents a pet's stage of development
+type LifeStage int
+
+const (
+	LifeStageBaby LifeStage = iota
+	LifeStageJuvenile
+	LifeStageAdult
+	LifeStageElder
+)
+
+// String returns the string representation of LifeStage
+func (ls LifeStage) String() string {
+	return [...]string{
+		"Baby", "Juvenile", "Adult", "Elder",
+	}[ls]
+}
+
+// Season represents a season of the year
+type Season int
+
+const (
+	SeasonSpring Season = iota
+	SeasonSummer
+	SeasonAutumn
+	SeasonWinter
+)
+
+// String returns the string representation of Season
+func (s Season) String() string {
+	return [...]string{
+		"Spring", "Summer", "Autumn", "Winter",
+	}[s]
+}
+
 // Priority represents the importance level of needs or actions
 type Priority int
 
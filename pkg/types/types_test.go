@@ -1,6 +1,9 @@
 package types
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestBehaviorStateString(t *testing.T) {
 	tests := []struct {
@@ -65,3 +68,19 @@ func TestNeedTypeString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNeed(t *testing.T) {
+	for _, name := range []string{"Mental Stimulation", "MentalStimulation", "mental_stimulation", "mental-stimulation"} {
+		if need, err := ParseNeed(name); err != nil || need != NeedMentalStimulation {
+			t.Errorf("ParseNeed(%q) = %v, %v; want Mental Stimulation", name, need, err)
+		}
+	}
+	for _, need := range AllNeeds() {
+		if got, err := ParseNeed(need.String()); err != nil || got != need {
+			t.Errorf("ParseNeed(%q) = %v, %v", need.String(), got, err)
+		}
+	}
+	if _, err := ParseNeed("MentalStimulaton"); !errors.Is(err, ErrUnknownNeed) {
+		t.Errorf("Expected ErrUnknownNeed for a typo, got %v", err)
+	}
+}